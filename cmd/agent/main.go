@@ -18,6 +18,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
@@ -25,6 +26,7 @@ import (
 	"net/url"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/kelseyhightower/envconfig"
@@ -32,10 +34,20 @@ import (
 	"github.com/kserve/kserve/pkg/agent/storage"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/batcher"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/cors"
 	kfslogger "github.com/kserve/kserve/pkg/logger"
+	"github.com/kserve/kserve/pkg/middleware"
+	"github.com/kserve/kserve/pkg/quota"
+	"github.com/kserve/kserve/pkg/replay"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	flag "github.com/spf13/pflag"
 	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 
 	"knative.dev/networking/pkg/http/header"
 	proxy "knative.dev/networking/pkg/http/proxy"
@@ -51,6 +63,7 @@ import (
 var (
 	port          = flag.String("port", "9081", "Agent port")
 	componentPort = flag.Int("component-port", 8080, "Component port")
+	metricsPort   = flag.Int("metrics-port", 0, "Port of the prometheus metrics endpoint, metrics are disabled when not set")
 	// model puller flags
 	enablePuller = flag.Bool("enable-puller", false, "Enable model puller")
 	configDir    = flag.String("config-dir", "/mnt/configs", "directory for model config files")
@@ -58,8 +71,12 @@ var (
 	// logger flags
 	logUrl           = flag.String("log-url", "", "The URL to send request/response logs to")
 	workers          = flag.Int("workers", 5, "Number of workers")
+	logRetries       = flag.Int("log-retries", 0, "Number of times the delivery of a log event is retried before it is dropped")
 	sourceUri        = flag.String("source-uri", "", "The source URI to use when publishing cloudevents")
 	logMode          = flag.String("log-mode", string(v1beta1.LogAll), "Whether to log 'request', 'response' or 'all'")
+	logDeliveryMode  = flag.String("log-delivery-mode", string(v1beta1.LogDeliveryBestEffort), "Whether log events which do not fit in the queue are dropped ('bestEffort'), reject requests ('backPressure') or are written to disk ('spill')")
+	logSpillDir      = flag.String("log-spill-dir", constants.LoggerSpillDir, "Directory the log events are written to in the 'spill' delivery mode")
+	logSpillMaxBytes = flag.Int64("log-spill-max-bytes", 100*1024*1024, "Maximum size of the log events written to disk in the 'spill' delivery mode")
 	inferenceService = flag.String("inference-service", "", "The InferenceService name to add as header to log events")
 	namespace        = flag.String("namespace", "", "The namespace to add as header to log events")
 	endpoint         = flag.String("endpoint", "", "The endpoint name to add as header to log events")
@@ -68,6 +85,17 @@ var (
 	enableBatcher = flag.Bool("enable-batcher", false, "Enable request batcher")
 	maxBatchSize  = flag.String("max-batchsize", "32", "Max Batch Size")
 	maxLatency    = flag.String("max-latency", "5000", "Max Latency in milliseconds")
+	// request replay flags
+	enableReplay     = flag.Bool("enable-replay", false, "Enable capture and replay of failing requests for debugging")
+	replayBufferSize = flag.Int("replay-buffer-size", 10, "Number of failing requests kept for replay")
+	replayTokenFile  = flag.String("replay-token-file", "", "File holding the bearer token required by the replay endpoints")
+	// middleware flags
+	middlewareSpec = flag.String("middleware", "", "JSON of the middleware applied to the proxied requests, e.g. header transforms and token exchange")
+	// cors flags
+	corsPolicy = flag.String("cors", "", "JSON of the CORS policy of the cross-origin requests sent by the browsers")
+	// logging flags
+	logLevel  = flag.String("log-level", "", "Level of the agent logs (debug, info, warn, error), overrides the serving logging level")
+	logFormat = flag.String("log-format", "", "Format of the agent logs (json, text), overrides the encoding of the serving logging config")
 	// probing flags
 	readinessProbeTimeout = flag.Duration("probe-period", -1, "run readiness probe with given timeout") //nolint: unused
 	// This creates an abstract socket instead of an actual file.
@@ -84,6 +112,26 @@ const (
 	drainSleepDuration = 30 * time.Second
 )
 
+var (
+	requestCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kserve_agent_requests_total",
+		Help: "Number of requests proxied by the agent to the model server",
+	}, []string{"code"})
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kserve_agent_request_duration_seconds",
+		Help:    "Latency of the requests proxied by the agent to the model server",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"code"})
+	upstreamHealthy = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "kserve_agent_upstream_healthy",
+		Help: "Whether the last readiness probe of the model server succeeded",
+	})
+	upstreamErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "kserve_agent_upstream_errors_total",
+		Help: "Number of requests which could not be proxied to the model server",
+	})
+)
+
 type config struct {
 	// Making the below fields optional since raw deployment wont have them
 	ContainerConcurrency   int    `split_words:"true"`
@@ -97,10 +145,14 @@ type config struct {
 	ServingRequestLogTemplate    string `split_words:"true"` // optional
 	ServingEnableRequestLog      bool   `split_words:"true"` // optional
 	ServingEnableProbeRequestLog bool   `split_words:"true"` // optional
+	// Client credentials of the token exchange of the middleware
+	TokenExchangeClientId     string `split_words:"true"` // optional
+	TokenExchangeClientSecret string `split_words:"true"` // optional
 }
 
 type loggerArgs struct {
 	loggerType       v1beta1.LoggerType
+	deliveryMode     v1beta1.LoggerDeliveryMode
 	logUrl           *url.URL
 	sourceUrl        *url.URL
 	inferenceService string
@@ -114,6 +166,19 @@ type batcherArgs struct {
 	maxLatency   int
 }
 
+type middlewareArgs struct {
+	spec         *v1beta1.AgentMiddleware
+	clientID     string
+	clientSecret string
+	reviewer     *middleware.TokenReviewer
+}
+
+type replayArgs struct {
+	bufferSize int
+	token      string
+	namespace  string
+}
+
 func main() {
 	flag.Parse()
 	// Parse the environment.
@@ -123,12 +188,22 @@ func main() {
 		os.Exit(1)
 	}
 
-	logger, _ := pkglogging.NewLogger(env.ServingLoggingConfig, env.ServingLoggingLevel)
+	loggingLevel := env.ServingLoggingLevel
+	if *logLevel != "" {
+		loggingLevel = *logLevel
+	}
+	loggingConfig, err := withLogFormat(env.ServingLoggingConfig, *logFormat)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	logger, _ := pkglogging.NewLogger(loggingConfig, loggingLevel)
 	// Setup probe to run for checking user container healthiness.
 	probe := func() bool { return true }
 	if env.ServingReadinessProbe != "" {
 		probe = buildProbe(logger, env.ServingReadinessProbe).ProbeContainer
 	}
+	probe = instrumentProbe(probe)
 
 	if *enablePuller {
 		logger.Infof("Initializing model agent with config-dir %s, model-dir %s", *configDir, *modelDir)
@@ -146,12 +221,34 @@ func main() {
 		logger.Info("Starting batcher")
 		batcherArgs = startBatcher(logger)
 	}
+
+	var middlewareArgs *middlewareArgs
+	if *middlewareSpec != "" {
+		logger.Info("Starting middleware")
+		middlewareArgs = startMiddleware(env, logger)
+	}
+
+	var replayArgs *replayArgs
+	if *enableReplay {
+		logger.Info("Starting request replay")
+		replayArgs = startReplay(logger)
+	}
+
+	var corsConfig *cors.Config
+	if *corsPolicy != "" {
+		logger.Info("Starting CORS handler")
+		corsConfig = startCORS(logger)
+	}
 	logger.Info("Starting agent http server...")
 	ctx := signals.NewContext()
-	mainServer, drain := buildServer(ctx, *port, *componentPort, loggerArgs, batcherArgs, probe, logger)
+	mainServer, drain := buildServer(ctx, *port, *componentPort, loggerArgs, batcherArgs, middlewareArgs, replayArgs, corsConfig,
+		probe, logger)
 	servers := map[string]*http.Server{
 		"main": mainServer,
 	}
+	if *metricsPort != 0 {
+		servers["metrics"] = buildMetricsServer(*metricsPort)
+	}
 	errCh := make(chan error)
 	listenCh := make(chan struct{})
 	for name, server := range servers {
@@ -249,6 +346,73 @@ func startBatcher(logger *zap.SugaredLogger) *batcherArgs {
 	}
 }
 
+func startMiddleware(env config, logger *zap.SugaredLogger) *middlewareArgs {
+	spec := &v1beta1.AgentMiddleware{}
+	if err := json.Unmarshal([]byte(*middlewareSpec), spec); err != nil {
+		logger.Errorw("Failed to parse the middleware", zap.Error(err))
+		os.Exit(1)
+	}
+	args := &middlewareArgs{
+		spec:         spec,
+		clientID:     env.TokenExchangeClientId,
+		clientSecret: env.TokenExchangeClientSecret,
+	}
+	if spec.TokenReview != nil {
+		// The tokens are reviewed with the credentials of the service account of the pod
+		restConfig, err := rest.InClusterConfig()
+		if err != nil {
+			logger.Errorw("Failed to get the in-cluster config of the token review", zap.Error(err))
+			os.Exit(1)
+		}
+		client, err := kubernetes.NewForConfig(restConfig)
+		if err != nil {
+			logger.Errorw("Failed to create the client of the token review", zap.Error(err))
+			os.Exit(1)
+		}
+		args.reviewer = middleware.NewTokenReviewer(spec.TokenReview, client, *namespace, *inferenceService)
+	}
+	return args
+}
+
+func startCORS(logger *zap.SugaredLogger) *cors.Config {
+	policy := &v1beta1.CORSPolicy{}
+	if err := json.Unmarshal([]byte(*corsPolicy), policy); err != nil {
+		logger.Errorw("Failed to parse the cors policy", zap.Error(err))
+		os.Exit(1)
+	}
+	config := policy.Config()
+	if err := config.Validate(); err != nil {
+		logger.Errorw("Invalid cors policy", zap.Error(err))
+		os.Exit(1)
+	}
+	return &config
+}
+
+func startReplay(logger *zap.SugaredLogger) *replayArgs {
+	if *replayBufferSize <= 0 {
+		logger.Errorf("Invalid replay buffer size %d", *replayBufferSize)
+		os.Exit(1)
+	}
+	if *replayTokenFile == "" {
+		logger.Error("The replay token file is required when request replay is enabled")
+		os.Exit(1)
+	}
+	token, err := os.ReadFile(*replayTokenFile)
+	if err != nil {
+		logger.Errorw("Failed to read the replay token file", zap.Error(err))
+		os.Exit(1)
+	}
+	if strings.TrimSpace(string(token)) == "" {
+		logger.Errorf("The replay token file %s is empty", *replayTokenFile)
+		os.Exit(1)
+	}
+	return &replayArgs{
+		bufferSize: *replayBufferSize,
+		token:      strings.TrimSpace(string(token)),
+		namespace:  *namespace,
+	}
+}
+
 func startLogger(workers int, logger *zap.SugaredLogger) *loggerArgs {
 	loggingMode := v1beta1.LoggerType(*logMode)
 	switch loggingMode {
@@ -258,6 +422,19 @@ func startLogger(workers int, logger *zap.SugaredLogger) *loggerArgs {
 		os.Exit(-1)
 	}
 
+	deliveryMode := v1beta1.LoggerDeliveryMode(*logDeliveryMode)
+	switch deliveryMode {
+	case v1beta1.LogDeliveryBestEffort, v1beta1.LogDeliveryBackPressure:
+	case v1beta1.LogDeliverySpill:
+		if err := kfslogger.StartSpiller(*logSpillDir, *logSpillMaxBytes, logger); err != nil {
+			logger.Errorf("Failed to start the log spill in %s: %v", *logSpillDir, err)
+			os.Exit(-1)
+		}
+	default:
+		logger.Errorf("Malformed log-delivery-mode %s", *logDeliveryMode)
+		os.Exit(-1)
+	}
+
 	logUrlParsed, err := url.Parse(*logUrl)
 	if err != nil {
 		logger.Errorf("Malformed log-url %s", *logUrl)
@@ -274,9 +451,10 @@ func startLogger(workers int, logger *zap.SugaredLogger) *loggerArgs {
 		os.Exit(-1)
 	}
 	logger.Info("Starting the log dispatcher")
-	kfslogger.StartDispatcher(workers, logger)
+	kfslogger.StartDispatcher(workers, *logRetries, logger)
 	return &loggerArgs{
 		loggerType:       loggingMode,
+		deliveryMode:     deliveryMode,
 		logUrl:           logUrlParsed,
 		sourceUrl:        sourceUriParsed,
 		inferenceService: *inferenceService,
@@ -311,8 +489,36 @@ func buildProbe(logger *zap.SugaredLogger, probeJSON string) *readiness.Probe {
 	return newProbe
 }
 
+// instrumentProbe records the result of the readiness probe of the model server
+func instrumentProbe(probe func() bool) func() bool {
+	return func() bool {
+		healthy := probe()
+		if healthy {
+			upstreamHealthy.Set(1)
+		} else {
+			upstreamHealthy.Set(0)
+		}
+		return healthy
+	}
+}
+
+// buildMetricsServer serves the metrics of the agent, its logger and batcher on a dedicated port
+func buildMetricsServer(port int) *http.Server {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		requestCount, requestDuration, upstreamHealthy, upstreamErrors)
+	kfslogger.RegisterMetrics(registry)
+	batcher.RegisterMetrics(registry)
+	quota.RegisterMetrics(registry)
+	middleware.RegisterMetrics(registry)
+	mux := http.NewServeMux()
+	mux.Handle(constants.DefaultPrometheusPath, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	return pkgnet.NewServer(":"+strconv.Itoa(port), mux)
+}
+
 func buildServer(ctx context.Context, port string, userPort int, loggerArgs *loggerArgs, batcherArgs *batcherArgs, // nolint unparam
-	probeContainer func() bool, logging *zap.SugaredLogger) (server *http.Server, drain func()) {
+	middlewareArgs *middlewareArgs, replayArgs *replayArgs, corsConfig *cors.Config, probeContainer func() bool,
+	logging *zap.SugaredLogger) (server *http.Server, drain func()) {
 	logging.Infof("Building server user port %s port %s", userPort, port)
 	target := &url.URL{
 		Scheme: "http",
@@ -323,7 +529,11 @@ func buildServer(ctx context.Context, port string, userPort int, loggerArgs *log
 
 	httpProxy := httputil.NewSingleHostReverseProxy(target)
 	httpProxy.Transport = pkgnet.NewAutoTransport(maxIdleConns /* max-idle */, maxIdleConns /* max-idle-per-host */)
-	httpProxy.ErrorHandler = pkghandler.Error(logging)
+	errorHandler := pkghandler.Error(logging)
+	httpProxy.ErrorHandler = func(w http.ResponseWriter, req *http.Request, err error) {
+		upstreamErrors.Inc()
+		errorHandler(w, req, err)
+	}
 	httpProxy.BufferPool = proxy.NewBufferPool()
 	httpProxy.FlushInterval = proxy.FlushInterval
 
@@ -335,10 +545,30 @@ func buildServer(ctx context.Context, port string, userPort int, loggerArgs *log
 		composedHandler = batcher.New(batcherArgs.maxBatchSize, batcherArgs.maxLatency, composedHandler, logging)
 	}
 	if loggerArgs != nil {
-		composedHandler = kfslogger.New(loggerArgs.logUrl, loggerArgs.sourceUrl, loggerArgs.loggerType,
+		composedHandler = kfslogger.New(loggerArgs.logUrl, loggerArgs.sourceUrl, loggerArgs.loggerType, loggerArgs.deliveryMode,
 			loggerArgs.inferenceService, loggerArgs.namespace, loggerArgs.endpoint, loggerArgs.component, composedHandler)
 	}
+	if middlewareArgs != nil {
+		// the quotas are counted inside the middleware, once the token review authenticated the tenant of the request
+		if spec := middlewareArgs.spec.Quota; spec != nil {
+			composedHandler = quota.NewHandler(quota.Config{
+				Period:       spec.Period.Duration,
+				DefaultLimit: spec.DefaultLimit,
+				Tenants:      spec.Tenants,
+			}, composedHandler)
+		}
+		composedHandler = middleware.New(middlewareArgs.spec, middlewareArgs.clientID, middlewareArgs.clientSecret,
+			middlewareArgs.reviewer, composedHandler, logging)
+	}
+	if replayArgs != nil {
+		composedHandler = replay.New(replayArgs.bufferSize, replayArgs.token, replayArgs.namespace, composedHandler, logging)
+	}
+	if corsConfig != nil {
+		composedHandler = cors.NewHandler(*corsConfig, composedHandler)
+	}
 
+	composedHandler = promhttp.InstrumentHandlerDuration(requestDuration,
+		promhttp.InstrumentHandlerCounter(requestCount, composedHandler))
 	composedHandler = queue.ForwardedShimHandler(composedHandler)
 
 	drainer := &pkghandler.Drainer{
@@ -351,3 +581,31 @@ func buildServer(ctx context.Context, port string, userPort int, loggerArgs *log
 	composedHandler = drainer
 	return pkgnet.NewServer(":"+port, composedHandler), drainer.Drain
 }
+
+// withLogFormat overrides the encoding of the zap logging config with the given format.
+func withLogFormat(loggingConfig string, format string) (string, error) {
+	if format == "" {
+		return loggingConfig, nil
+	}
+	var encoding string
+	switch format {
+	case string(v1beta1.SidecarLogFormatJSON):
+		encoding = "json"
+	case string(v1beta1.SidecarLogFormatText):
+		encoding = "console"
+	default:
+		return "", fmt.Errorf("unsupported log format %q", format)
+	}
+	config := map[string]interface{}{}
+	if loggingConfig != "" {
+		if err := json.Unmarshal([]byte(loggingConfig), &config); err != nil {
+			return "", fmt.Errorf("failed to parse the logging config: %w", err)
+		}
+	}
+	config["encoding"] = encoding
+	b, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,34 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+
+	"k8s.io/klog"
+
+	"github.com/kserve/kserve/pkg/router/api"
+)
+
+// Generate the JSON schema of the graph document passed to the InferenceGraph router
+func main() {
+	schema, err := api.Schema()
+	if err != nil {
+		klog.Fatal(err.Error())
+	}
+	fmt.Println(string(schema))
+}
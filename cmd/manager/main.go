@@ -17,16 +17,30 @@ limitations under the License.
 package main
 
 import (
+	"context"
+	"crypto/tls"
 	"flag"
+	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
+	"time"
+	// the time zones of the active hours are resolved without the zoneinfo of the image
+	_ "time/tzdata"
 
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"github.com/kserve/kserve/pkg/capabilities"
+	"github.com/kserve/kserve/pkg/configvalidator"
+	"github.com/kserve/kserve/pkg/idlestop"
+	"github.com/kserve/kserve/pkg/preemption"
+	"github.com/kserve/kserve/pkg/telemetry"
 	"github.com/kserve/kserve/pkg/utils"
 	istio_networking "istio.io/api/networking/v1beta1"
 	istioclientv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	istioclientsecurityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
 	v1 "k8s.io/api/core/v1"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -34,6 +48,8 @@ import (
 	"k8s.io/client-go/tools/record"
 	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -41,6 +57,7 @@ import (
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	servingv1 "github.com/kserve/kserve/pkg/apis/serving/v1"
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
@@ -48,8 +65,14 @@ import (
 	trainedmodelcontroller "github.com/kserve/kserve/pkg/controller/v1alpha1/trainedmodel"
 	"github.com/kserve/kserve/pkg/controller/v1alpha1/trainedmodel/reconcilers/modelconfig"
 	v1beta1controller "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice"
+	isvcutils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
+	"github.com/kserve/kserve/pkg/podconfig"
+	"github.com/kserve/kserve/pkg/webhook/admission/capacity"
 	"github.com/kserve/kserve/pkg/webhook/admission/pod"
+	"github.com/kserve/kserve/pkg/webhook/admission/scope"
 	"github.com/kserve/kserve/pkg/webhook/admission/servingruntime"
+	"github.com/kserve/kserve/pkg/webhook/certs"
+	"github.com/kserve/kserve/pkg/webhook/settings"
 )
 
 var (
@@ -59,6 +82,11 @@ var (
 
 const (
 	LeaderLockName = "kserve-controller-manager-leader-lock"
+	// WatchNamespacesEnvVar is the default of the --watch-namespaces flag
+	WatchNamespacesEnvVar = "WATCH_NAMESPACES"
+	// WebhookServiceName is the service of the webhook server, the CA bundles of the webhooks pointing to it are
+	// patched when the serving certificate is rotated
+	WebhookServiceName = "kserve-webhook-server-service"
 )
 
 // Options defines the program configurable options that may be passed on the command line.
@@ -66,8 +94,27 @@ type Options struct {
 	metricsAddr          string
 	webhookPort          int
 	enableLeaderElection bool
+	leaderElection       leaderElectionOptions
 	probeAddr            string
-	zapOpts              zap.Options
+	watchNamespaces      string
+	webhookCertDir       string
+	webhookCABundleCM    string
+	// capabilitiesRefresh is the interval the optional APIs, e.g. Knative Serving, are discovered again
+	capabilitiesRefresh time.Duration
+	zapOpts             zap.Options
+}
+
+// leaderElectionOptions tunes how fast a standby replica of the manager takes over the leadership, so that the
+// rollouts are not paused for long when the leader restarts.
+type leaderElectionOptions struct {
+	// leaseDuration is the duration the standby replicas wait before acquiring a lease which is not renewed
+	leaseDuration time.Duration
+	// renewDeadline is the duration the leader retries renewing the lease before giving up the leadership
+	renewDeadline time.Duration
+	// retryPeriod is the duration the replicas wait between the attempts to acquire or renew the lease
+	retryPeriod time.Duration
+	// releaseOnCancel releases the lease when the leader stops, so that a standby replica takes over right away
+	releaseOnCancel bool
 }
 
 // DefaultOptions returns the default values for the program options.
@@ -76,8 +123,16 @@ func DefaultOptions() Options {
 		metricsAddr:          ":8080",
 		webhookPort:          9443,
 		enableLeaderElection: false,
-		probeAddr:            ":8081",
-		zapOpts:              zap.Options{},
+		leaderElection: leaderElectionOptions{
+			leaseDuration: 15 * time.Second,
+			renewDeadline: 10 * time.Second,
+			retryPeriod:   2 * time.Second,
+		},
+		probeAddr:           ":8081",
+		watchNamespaces:     os.Getenv(WatchNamespacesEnvVar),
+		webhookCertDir:      filepath.Join(os.TempDir(), "k8s-webhook-server", "serving-certs"),
+		capabilitiesRefresh: capabilities.DefaultRefreshInterval,
+		zapOpts:             zap.Options{},
 	}
 }
 
@@ -89,12 +144,63 @@ func GetOptions() Options {
 	flag.BoolVar(&opts.enableLeaderElection, "leader-elect", opts.enableLeaderElection,
 		"Enable leader election for kserve controller manager. "+
 			"Enabling this will ensure there is only one active kserve controller manager.")
+	flag.DurationVar(&opts.leaderElection.leaseDuration, "leader-election-lease-duration", opts.leaderElection.leaseDuration,
+		"The duration the standby kserve controller managers wait before acquiring a leadership which is not renewed.")
+	flag.DurationVar(&opts.leaderElection.renewDeadline, "leader-election-renew-deadline", opts.leaderElection.renewDeadline,
+		"The duration the leader retries renewing the leadership before giving it up.")
+	flag.DurationVar(&opts.leaderElection.retryPeriod, "leader-election-retry-period", opts.leaderElection.retryPeriod,
+		"The duration the kserve controller managers wait between the attempts to acquire or renew the leadership.")
+	flag.BoolVar(&opts.leaderElection.releaseOnCancel, "leader-election-release-on-cancel", opts.leaderElection.releaseOnCancel,
+		"Release the leadership when the leader stops so that a standby kserve controller manager takes over right away.")
 	flag.StringVar(&opts.probeAddr, "health-probe-addr", opts.probeAddr, "The address the probe endpoint binds to.")
+	flag.StringVar(&opts.watchNamespaces, "watch-namespaces", opts.watchNamespaces,
+		"The comma separated allow-list of namespaces the kserve controller manager watches and admits, "+
+			"it overrides the watchNamespaces of the controller config. All the namespaces are watched when empty.")
+	flag.StringVar(&opts.webhookCertDir, "webhook-cert-dir", opts.webhookCertDir,
+		"The directory of the webhook serving certificate, it is reloaded when rotated.")
+	flag.StringVar(&opts.webhookCABundleCM, "webhook-ca-bundle-configmap", opts.webhookCABundleCM,
+		"The ConfigMap of the service-ca.crt CA bundle patched on the webhook configurations when the webhook "+
+			"certificate directory has no ca.crt, e.g. openshift-service-ca.crt with the OpenShift service-ca operator.")
+	flag.DurationVar(&opts.capabilitiesRefresh, "capabilities-refresh-interval", opts.capabilitiesRefresh,
+		"The interval the optional APIs, e.g. Knative Serving, are discovered again so that the APIs installed later "+
+			"are picked up without restarting the kserve controller manager.")
 	opts.zapOpts.BindFlags(flag.CommandLine)
 	flag.Parse()
 	return opts
 }
 
+// validate checks the leader election durations are consistent, client-go only reports them once the manager starts.
+func (o leaderElectionOptions) validate() error {
+	if o.retryPeriod <= 0 {
+		return fmt.Errorf("leader election retry period must be positive, got %v", o.retryPeriod)
+	}
+	if o.renewDeadline <= o.retryPeriod {
+		return fmt.Errorf("leader election renew deadline %v must be greater than the retry period %v",
+			o.renewDeadline, o.retryPeriod)
+	}
+	if o.leaseDuration <= o.renewDeadline {
+		return fmt.Errorf("leader election lease duration %v must be greater than the renew deadline %v",
+			o.leaseDuration, o.renewDeadline)
+	}
+	return nil
+}
+
+// newCacheOptions restricts the cache of the manager to the watched namespaces so that the controllers only need the
+// permissions of these namespaces. The cluster-scoped storage containers are read live instead of being watched, the
+// storage initializer falls back to its default container when they cannot be read.
+func newCacheOptions(watchNamespaces []string) (cache.Options, client.Options) {
+	if len(watchNamespaces) == 0 {
+		return cache.Options{}, client.Options{}
+	}
+	namespaces := make(map[string]cache.Config, len(watchNamespaces))
+	for _, namespace := range watchNamespaces {
+		namespaces[namespace] = cache.Config{}
+	}
+	return cache.Options{DefaultNamespaces: namespaces}, client.Options{
+		Cache: &client.CacheOptions{DisableFor: []client.Object{&v1alpha1.ClusterStorageContainer{}}},
+	}
+}
+
 func init() {
 	// Allow unknown fields in Istio API client for backwards compatibility if cluster has existing vs with deprecated fields.
 	istio_networking.VirtualServiceUnmarshaler.AllowUnknownFields = true
@@ -104,6 +210,10 @@ func init() {
 func main() {
 	options := GetOptions()
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&options.zapOpts)))
+	if err := options.leaderElection.validate(); err != nil {
+		setupLog.Error(err, "invalid leader election options")
+		os.Exit(1)
+	}
 
 	// Get a config to talk to the apiserver
 	setupLog.Info("Setting up client for manager")
@@ -120,16 +230,78 @@ func main() {
 		os.Exit(1)
 	}
 
+	securityConfig, err := v1beta1.NewSecurityConfig(clientSet)
+	if err != nil {
+		setupLog.Error(err, "unable to get security config.")
+		os.Exit(1)
+	}
+	// The webhook TLS configuration is only set at startup
+	var webhookTLSOpts []func(*tls.Config)
+	if securityConfig.FIPSMode {
+		setupLog.Info("FIPS mode enabled, restricting webhook server to FIPS approved ciphers")
+		webhookTLSOpts = append(webhookTLSOpts, utils.ApplyFIPSTLSConfig)
+	}
+
+	// The settings the webhooks validate against are loaded before they are served and reloaded on every change
+	settingsReloader := &settings.Reloader{Clientset: clientSet, Log: ctrl.Log.WithName("webhooksettings")}
+	if err := settingsReloader.Reload(context.Background()); err != nil {
+		setupLog.Error(err, "unable to get webhook settings.")
+		os.Exit(1)
+	}
+
+	watchNamespaces := scope.ParseNamespaces(options.watchNamespaces)
+	if len(watchNamespaces) == 0 {
+		controllerConfig, err := v1beta1.NewControllerConfig(clientSet)
+		if err != nil {
+			setupLog.Error(err, "unable to get controller config.")
+			os.Exit(1)
+		}
+		watchNamespaces = controllerConfig.WatchNamespaces
+	}
+	cacheOptions, clientOptions := newCacheOptions(watchNamespaces)
+
+	// The webhook serving certificate is reloaded and its CA bundle patched when cert-manager or service-ca rotate it
+	apiExtensionsClientSet, err := apiextensionsclientset.NewForConfig(cfg)
+	if err != nil {
+		setupLog.Error(err, "unable to create apiextensions clientSet")
+		os.Exit(1)
+	}
+	certRotator := &certs.Rotator{
+		CertDir:                options.webhookCertDir,
+		ServiceName:            WebhookServiceName,
+		ServiceNamespace:       constants.KServeNamespace,
+		CRDs:                   []string{constants.InferenceServiceAPIName + "." + constants.KServeAPIGroupName},
+		CABundleConfigMap:      options.webhookCABundleCM,
+		Clientset:              clientSet,
+		APIExtensionsClientset: apiExtensionsClientSet,
+		Log:                    ctrl.Log.WithName("webhookCertRotator"),
+	}
+
 	// Create a new Cmd to provide shared dependencies and start components
-	setupLog.Info("Setting up manager")
+	setupLog.Info("Setting up manager", "watchNamespaces", watchNamespaces)
 	mgr, err := manager.New(cfg, manager.Options{
 		Metrics: metricsserver.Options{
-			BindAddress: options.metricsAddr},
+			BindAddress: options.metricsAddr,
+			// The child resource tree is served next to the metrics, the handler authenticates and authorizes the
+			// requests itself since the metrics port may be reachable without the auth proxy
+			ExtraHandlers: map[string]http.Handler{
+				isvcutils.ChildResourcesDebugPath: isvcutils.AuthorizeDebugRequests(clientSet,
+					isvcutils.ChildResourcesDebugPath, isvcutils.ChildResources),
+			},
+		},
 		WebhookServer: webhook.NewServer(webhook.Options{
-			Port: options.webhookPort}),
-		LeaderElection:         options.enableLeaderElection,
-		LeaderElectionID:       LeaderLockName,
-		HealthProbeBindAddress: options.probeAddr,
+			Port:    options.webhookPort,
+			CertDir: options.webhookCertDir,
+			TLSOpts: append(webhookTLSOpts, certRotator.ConfigureTLS)}),
+		LeaderElection:                options.enableLeaderElection,
+		LeaderElectionID:              LeaderLockName,
+		LeaseDuration:                 &options.leaderElection.leaseDuration,
+		RenewDeadline:                 &options.leaderElection.renewDeadline,
+		RetryPeriod:                   &options.leaderElection.retryPeriod,
+		LeaderElectionReleaseOnCancel: options.leaderElection.releaseOnCancel,
+		HealthProbeBindAddress:        options.probeAddr,
+		Cache:                         cacheOptions,
+		Client:                        clientOptions,
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to set up overall controller manager")
@@ -137,6 +309,10 @@ func main() {
 	}
 
 	setupLog.Info("Registering Components.")
+	if err := mgr.Add(certRotator); err != nil {
+		setupLog.Error(err, "unable to set up webhook certificate rotator")
+		os.Exit(1)
+	}
 
 	setupLog.Info("Setting up KServe v1alpha1 scheme")
 	if err := v1alpha1.AddToScheme(mgr.GetScheme()); err != nil {
@@ -150,6 +326,13 @@ func main() {
 		os.Exit(1)
 	}
 
+	// The v1 InferenceService is converted from and to v1beta1 by the conversion webhook
+	setupLog.Info("Setting up KServe v1 scheme")
+	if err := servingv1.AddToScheme(mgr.GetScheme()); err != nil {
+		setupLog.Error(err, "unable to add KServe v1 to scheme")
+		os.Exit(1)
+	}
+
 	deployConfig, err := v1beta1.NewDeployConfig(clientSet)
 	if err != nil {
 		setupLog.Error(err, "unable to get deploy config.")
@@ -161,17 +344,35 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Resolve the system images to digests so that they are pinned on the generated pods when enabled, the leader
+	// resolves them in the background and every replica loads the recorded digests
+	if err := mgr.Add(&podconfig.ImageDigestResolver{
+		Clientset:  clientSet,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+		Log:        ctrl.Log.WithName("imagedigests"),
+	}); err != nil {
+		setupLog.Error(err, "unable to set up image digest resolution")
+		os.Exit(1)
+	}
+	if err := mgr.Add(&podconfig.ResolvedImageDigestLoader{
+		Clientset: clientSet,
+		Log:       ctrl.Log.WithName("imagedigests"),
+	}); err != nil {
+		setupLog.Error(err, "unable to set up image digest loading")
+		os.Exit(1)
+	}
+
 	ksvcFound, ksvcCheckErr := utils.IsCrdAvailable(cfg, knservingv1.SchemeGroupVersion.String(), constants.KnativeServiceKind)
 	if ksvcCheckErr != nil {
 		setupLog.Error(ksvcCheckErr, "error when checking if Knative Service kind is available")
 		os.Exit(1)
 	}
-	if ksvcFound {
-		setupLog.Info("Setting up Knative scheme")
-		if err := knservingv1.AddToScheme(mgr.GetScheme()); err != nil {
-			setupLog.Error(err, "unable to add Knative APIs to scheme")
-			os.Exit(1)
-		}
+	// The Knative scheme is added even when Knative Serving is not installed yet, so that its Services are watched
+	// once the capability detector finds it
+	setupLog.Info("Setting up Knative scheme")
+	if err := knservingv1.AddToScheme(mgr.GetScheme()); err != nil {
+		setupLog.Error(err, "unable to add Knative APIs to scheme")
+		os.Exit(1)
 	}
 	if !ingressConfig.DisableIstioVirtualHost {
 		vsFound, vsCheckErr := utils.IsCrdAvailable(cfg, istioclientv1beta1.SchemeGroupVersion.String(), constants.IstioVirtualServiceKind)
@@ -187,6 +388,28 @@ func main() {
 			}
 		}
 	}
+	meshConfig, err := v1beta1.NewMeshConfig(clientSet)
+	if err != nil {
+		setupLog.Error(err, "unable to get mesh config.")
+		os.Exit(1)
+	}
+	if meshConfig.PeerAuthenticationMode != "" {
+		paFound, paCheckErr := utils.IsCrdAvailable(cfg, istioclientsecurityv1beta1.SchemeGroupVersion.String(), constants.IstioPeerAuthenticationKind)
+		if paCheckErr != nil {
+			setupLog.Error(paCheckErr, "error when checking if Istio PeerAuthentications are available")
+			os.Exit(1)
+		}
+		// The PeerAuthentications are skipped by the raw reconcilers until Istio is installed, so that the workloads
+		// are still served
+		if !paFound {
+			setupLog.Info("Istio PeerAuthentications are not available, they are not created until Istio is installed")
+		}
+		setupLog.Info("Setting up Istio security scheme")
+		if err := istioclientsecurityv1beta1.AddToScheme(mgr.GetScheme()); err != nil {
+			setupLog.Error(err, "unable to add Istio security v1beta1 APIs to scheme")
+			os.Exit(1)
+		}
+	}
 
 	setupLog.Info("Setting up core scheme")
 	if err := v1.AddToScheme(mgr.GetScheme()); err != nil {
@@ -198,14 +421,15 @@ func main() {
 	setupLog.Info("Setting up v1beta1 controller")
 	eventBroadcaster := record.NewBroadcaster()
 	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: clientSet.CoreV1().Events("")})
-	if err = (&v1beta1controller.InferenceServiceReconciler{
+	isvcReconciler := &v1beta1controller.InferenceServiceReconciler{
 		Client:    mgr.GetClient(),
 		Clientset: clientSet,
 		Log:       ctrl.Log.WithName("v1beta1Controllers").WithName("InferenceService"),
 		Scheme:    mgr.GetScheme(),
 		Recorder: eventBroadcaster.NewRecorder(
 			mgr.GetScheme(), v1.EventSource{Component: "v1beta1Controllers"}),
-	}).SetupWithManager(mgr, deployConfig, ingressConfig); err != nil {
+	}
+	if err = isvcReconciler.SetupWithManager(mgr, deployConfig, ingressConfig); err != nil {
 		setupLog.Error(err, "unable to create controller", "v1beta1Controller", "InferenceService")
 		os.Exit(1)
 	}
@@ -229,23 +453,124 @@ func main() {
 	inferenceGraphEventBroadcaster := record.NewBroadcaster()
 	setupLog.Info("Setting up InferenceGraph controller")
 	inferenceGraphEventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: clientSet.CoreV1().Events("")})
-	if err = (&graphcontroller.InferenceGraphReconciler{
+	graphReconciler := &graphcontroller.InferenceGraphReconciler{
 		Client:    mgr.GetClient(),
 		Clientset: clientSet,
 		Log:       ctrl.Log.WithName("v1alpha1Controllers").WithName("InferenceGraph"),
 		Scheme:    mgr.GetScheme(),
 		Recorder:  eventBroadcaster.NewRecorder(mgr.GetScheme(), v1.EventSource{Component: "InferenceGraphController"}),
-	}).SetupWithManager(mgr, deployConfig); err != nil {
+	}
+	if err = graphReconciler.SetupWithManager(mgr, deployConfig); err != nil {
 		setupLog.Error(err, "unable to create controller", "v1alpha1Controllers", "InferenceGraph")
 		os.Exit(1)
 	}
 
+	// The optional APIs are discovered periodically and reported in the capabilities configmap, the Knative Services
+	// are watched once Knative Serving is installed when it was not at startup
+	utils.SetAvailableResourcesTTL(options.capabilitiesRefresh)
+	capabilityDetector := &capabilities.Detector{
+		Config:    cfg,
+		Clientset: clientSet,
+		Log:       ctrl.Log.WithName("capabilities"),
+		Interval:  options.capabilitiesRefresh,
+	}
+	if !ksvcFound {
+		capabilityDetector.OnAvailable(capabilities.KnativeServing, func() error {
+			return isvcReconciler.WatchKnativeServices(mgr)
+		})
+		capabilityDetector.OnAvailable(capabilities.KnativeServing, func() error {
+			return graphReconciler.WatchKnativeServices(mgr)
+		})
+	}
+	if err := mgr.Add(capabilityDetector); err != nil {
+		setupLog.Error(err, "unable to set up capability detection")
+		os.Exit(1)
+	}
+
+	// The anonymous usage telemetry is strictly opt-in
+	telemetryConfig, err := v1beta1.NewTelemetryConfig(clientSet)
+	if err != nil {
+		setupLog.Error(err, "unable to get telemetry config.")
+		os.Exit(1)
+	}
+	if telemetryConfig.Enabled {
+		setupLog.Info("Setting up usage telemetry", "endpoint", telemetryConfig.Endpoint)
+		if err := mgr.Add(&telemetry.Reporter{
+			Client:     mgr.GetClient(),
+			Clientset:  clientSet,
+			Config:     telemetryConfig,
+			HTTPClient: &http.Client{Timeout: 30 * time.Second},
+			Log:        ctrl.Log.WithName("telemetry"),
+		}); err != nil {
+			setupLog.Error(err, "unable to set up usage telemetry")
+			os.Exit(1)
+		}
+	}
+
+	// The idle InferenceServices are only stopped when the idle stop is enabled
+	idleStopConfig, err := v1beta1.NewIdleStopConfig(clientSet)
+	if err != nil {
+		setupLog.Error(err, "unable to get idle stop config.")
+		os.Exit(1)
+	}
+	if idleStopConfig.Enabled {
+		setupLog.Info("Setting up idle stop", "prometheusUrl", idleStopConfig.PrometheusURL,
+			"idleSeconds", idleStopConfig.IdleSeconds)
+		if err := mgr.Add(&idlestop.Reaper{
+			Client:     mgr.GetClient(),
+			Config:     idleStopConfig,
+			HTTPClient: &http.Client{Timeout: 30 * time.Second},
+			Recorder:   eventBroadcaster.NewRecorder(mgr.GetScheme(), v1.EventSource{Component: "IdleStop"}),
+			Log:        ctrl.Log.WithName("idlestop"),
+		}); err != nil {
+			setupLog.Error(err, "unable to set up idle stop")
+			os.Exit(1)
+		}
+	}
+
+	gpuPreemptionConfig, err := v1beta1.NewGPUPreemptionConfig(clientSet)
+	if err != nil {
+		setupLog.Error(err, "unable to get gpu preemption config.")
+		os.Exit(1)
+	}
+	if gpuPreemptionConfig.Enabled {
+		setupLog.Info("Setting up gpu preemption", "resourceName", gpuPreemptionConfig.ResourceName,
+			"pendingSeconds", gpuPreemptionConfig.PendingSeconds)
+		if err := mgr.Add(&preemption.Preemptor{
+			Client:    mgr.GetClient(),
+			Clientset: clientSet,
+			Config:    gpuPreemptionConfig,
+			Recorder:  eventBroadcaster.NewRecorder(mgr.GetScheme(), v1.EventSource{Component: "GPUPreemption"}),
+			Log:       ctrl.Log.WithName("preemption"),
+		}); err != nil {
+			setupLog.Error(err, "unable to set up gpu preemption")
+			os.Exit(1)
+		}
+	}
+
+	if err := mgr.Add(settingsReloader); err != nil {
+		setupLog.Error(err, "unable to set up webhook settings reloading")
+		os.Exit(1)
+	}
+
+	// The inferenceservice configmap is validated at startup and on every change, the field errors are reported as
+	// events on the configmap
+	if err := mgr.Add(&configvalidator.Validator{
+		Clientset: clientSet,
+		Recorder:  eventBroadcaster.NewRecorder(mgr.GetScheme(), v1.EventSource{Component: "ConfigValidator"}),
+		Log:       ctrl.Log.WithName("configvalidator"),
+	}); err != nil {
+		setupLog.Error(err, "unable to set up config validation")
+		os.Exit(1)
+	}
+
 	setupLog.Info("setting up webhook server")
 	hookServer := mgr.GetWebhookServer()
 
 	setupLog.Info("registering webhooks to the webhook server")
 	hookServer.Register("/mutate-pods", &webhook.Admission{
-		Handler: &pod.Mutator{Client: mgr.GetClient(), Clientset: clientSet, Decoder: admission.NewDecoder(mgr.GetScheme())},
+		Handler: scope.NewNamespaceScopedHandler(watchNamespaces,
+			&pod.Mutator{Client: mgr.GetClient(), Clientset: clientSet, Decoder: admission.NewDecoder(mgr.GetScheme())}),
 	})
 
 	// log.Info("registering cluster serving runtime validator webhook to the webhook server")
@@ -255,7 +580,14 @@ func main() {
 
 	setupLog.Info("registering serving runtime validator webhook to the webhook server")
 	hookServer.Register("/validate-serving-kserve-io-v1alpha1-servingruntime", &webhook.Admission{
-		Handler: &servingruntime.ServingRuntimeValidator{Client: mgr.GetClient(), Decoder: admission.NewDecoder(mgr.GetScheme())},
+		Handler: scope.NewNamespaceScopedHandler(watchNamespaces,
+			&servingruntime.ServingRuntimeValidator{Client: mgr.GetClient(), Decoder: admission.NewDecoder(mgr.GetScheme())}),
+	})
+
+	setupLog.Info("registering inference service capacity validator webhook to the webhook server")
+	hookServer.Register("/validate-serving-kserve-io-v1beta1-inferenceservice-capacity", &webhook.Admission{
+		Handler: scope.NewNamespaceScopedHandler(watchNamespaces,
+			&capacity.CapacityValidator{Clientset: clientSet, Decoder: admission.NewDecoder(mgr.GetScheme())}),
 	})
 
 	if err = ctrl.NewWebhookManagedBy(mgr).
@@ -267,6 +599,7 @@ func main() {
 
 	if err = ctrl.NewWebhookManagedBy(mgr).
 		For(&v1alpha1.InferenceGraph{}).
+		WithValidator(&v1alpha1.InferenceGraphValidator{Clientset: clientSet}).
 		Complete(); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "v1alpha1")
 		os.Exit(1)
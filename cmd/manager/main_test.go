@@ -20,9 +20,14 @@ import (
 	"flag"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 )
 
 func TestGetOptions(t *testing.T) {
@@ -41,7 +46,12 @@ func TestGetOptions(t *testing.T) {
 				metricsAddr:          defaults.metricsAddr,
 				webhookPort:          8000,
 				enableLeaderElection: defaults.enableLeaderElection,
+				leaderElection:       defaults.leaderElection,
 				probeAddr:            defaults.probeAddr,
+				watchNamespaces:      defaults.watchNamespaces,
+				webhookCertDir:       defaults.webhookCertDir,
+				webhookCABundleCM:    defaults.webhookCABundleCM,
+				capabilitiesRefresh:  defaults.capabilitiesRefresh,
 				zapOpts:              defaults.zapOpts,
 			}},
 		{"withMetricsAddr", []string{"-metrics-addr=:9090"},
@@ -49,7 +59,12 @@ func TestGetOptions(t *testing.T) {
 				metricsAddr:          ":9090",
 				webhookPort:          defaults.webhookPort,
 				enableLeaderElection: defaults.enableLeaderElection,
+				leaderElection:       defaults.leaderElection,
 				probeAddr:            defaults.probeAddr,
+				watchNamespaces:      defaults.watchNamespaces,
+				webhookCertDir:       defaults.webhookCertDir,
+				webhookCABundleCM:    defaults.webhookCABundleCM,
+				capabilitiesRefresh:  defaults.capabilitiesRefresh,
 				zapOpts:              defaults.zapOpts,
 			}},
 		{"withEnableLeaderElection", []string{"-leader-elect=true"},
@@ -57,7 +72,12 @@ func TestGetOptions(t *testing.T) {
 				metricsAddr:          defaults.metricsAddr,
 				webhookPort:          defaults.webhookPort,
 				enableLeaderElection: true,
+				leaderElection:       defaults.leaderElection,
 				probeAddr:            defaults.probeAddr,
+				watchNamespaces:      defaults.watchNamespaces,
+				webhookCertDir:       defaults.webhookCertDir,
+				webhookCABundleCM:    defaults.webhookCABundleCM,
+				capabilitiesRefresh:  defaults.capabilitiesRefresh,
 				zapOpts:              defaults.zapOpts,
 			}},
 		{"withHealthProbeAddr", []string{"-health-probe-addr=:8090"},
@@ -65,7 +85,12 @@ func TestGetOptions(t *testing.T) {
 				metricsAddr:          defaults.metricsAddr,
 				webhookPort:          defaults.webhookPort,
 				enableLeaderElection: defaults.enableLeaderElection,
+				leaderElection:       defaults.leaderElection,
 				probeAddr:            ":8090",
+				watchNamespaces:      defaults.watchNamespaces,
+				webhookCertDir:       defaults.webhookCertDir,
+				webhookCABundleCM:    defaults.webhookCABundleCM,
+				capabilitiesRefresh:  defaults.capabilitiesRefresh,
 				zapOpts:              defaults.zapOpts,
 			}},
 		{"withZapFlags", []string{"-zap-devel"},
@@ -73,7 +98,12 @@ func TestGetOptions(t *testing.T) {
 				metricsAddr:          defaults.metricsAddr,
 				webhookPort:          defaults.webhookPort,
 				enableLeaderElection: defaults.enableLeaderElection,
+				leaderElection:       defaults.leaderElection,
 				probeAddr:            defaults.probeAddr,
+				watchNamespaces:      defaults.watchNamespaces,
+				webhookCertDir:       defaults.webhookCertDir,
+				webhookCABundleCM:    defaults.webhookCABundleCM,
+				capabilitiesRefresh:  defaults.capabilitiesRefresh,
 				zapOpts: zap.Options{
 					Development: true,
 				},
@@ -83,7 +113,70 @@ func TestGetOptions(t *testing.T) {
 				metricsAddr:          defaults.metricsAddr,
 				webhookPort:          8000,
 				enableLeaderElection: true,
+				leaderElection:       defaults.leaderElection,
+				probeAddr:            defaults.probeAddr,
+				watchNamespaces:      defaults.watchNamespaces,
+				webhookCertDir:       defaults.webhookCertDir,
+				webhookCABundleCM:    defaults.webhookCABundleCM,
+				capabilitiesRefresh:  defaults.capabilitiesRefresh,
+				zapOpts:              defaults.zapOpts,
+			}},
+		{"withLeaderElectionTuning", []string{"-leader-elect=true", "-leader-election-lease-duration=30s",
+			"-leader-election-renew-deadline=20s", "-leader-election-retry-period=5s", "-leader-election-release-on-cancel"},
+			Options{
+				metricsAddr:          defaults.metricsAddr,
+				webhookPort:          defaults.webhookPort,
+				enableLeaderElection: true,
+				leaderElection: leaderElectionOptions{
+					leaseDuration:   30 * time.Second,
+					renewDeadline:   20 * time.Second,
+					retryPeriod:     5 * time.Second,
+					releaseOnCancel: true,
+				},
+				probeAddr:           defaults.probeAddr,
+				watchNamespaces:     defaults.watchNamespaces,
+				webhookCertDir:      defaults.webhookCertDir,
+				webhookCABundleCM:   defaults.webhookCABundleCM,
+				capabilitiesRefresh: defaults.capabilitiesRefresh,
+				zapOpts:             defaults.zapOpts,
+			}},
+		{"withWatchNamespaces", []string{"-watch-namespaces=team-a,team-b"},
+			Options{
+				metricsAddr:          defaults.metricsAddr,
+				webhookPort:          defaults.webhookPort,
+				enableLeaderElection: defaults.enableLeaderElection,
+				leaderElection:       defaults.leaderElection,
 				probeAddr:            defaults.probeAddr,
+				watchNamespaces:      "team-a,team-b",
+				webhookCertDir:       defaults.webhookCertDir,
+				webhookCABundleCM:    defaults.webhookCABundleCM,
+				capabilitiesRefresh:  defaults.capabilitiesRefresh,
+				zapOpts:              defaults.zapOpts,
+			}},
+		{"withWebhookCerts", []string{"-webhook-cert-dir=/certs", "-webhook-ca-bundle-configmap=openshift-service-ca.crt"},
+			Options{
+				metricsAddr:          defaults.metricsAddr,
+				webhookPort:          defaults.webhookPort,
+				enableLeaderElection: defaults.enableLeaderElection,
+				leaderElection:       defaults.leaderElection,
+				probeAddr:            defaults.probeAddr,
+				watchNamespaces:      defaults.watchNamespaces,
+				webhookCertDir:       "/certs",
+				webhookCABundleCM:    "openshift-service-ca.crt",
+				capabilitiesRefresh:  defaults.capabilitiesRefresh,
+				zapOpts:              defaults.zapOpts,
+			}},
+		{"withCapabilitiesRefreshInterval", []string{"-capabilities-refresh-interval=1m"},
+			Options{
+				metricsAddr:          defaults.metricsAddr,
+				webhookPort:          defaults.webhookPort,
+				enableLeaderElection: defaults.enableLeaderElection,
+				leaderElection:       defaults.leaderElection,
+				probeAddr:            defaults.probeAddr,
+				watchNamespaces:      defaults.watchNamespaces,
+				webhookCertDir:       defaults.webhookCertDir,
+				webhookCABundleCM:    defaults.webhookCABundleCM,
+				capabilitiesRefresh:  time.Minute,
 				zapOpts:              defaults.zapOpts,
 			}},
 		{"withAll", []string{"-metrics-addr=:9090", "-webhook-port=8000", "-leader-elect=true", "-health-probe-addr=:8080", "-zap-devel"},
@@ -91,7 +184,12 @@ func TestGetOptions(t *testing.T) {
 				metricsAddr:          ":9090",
 				webhookPort:          8000,
 				enableLeaderElection: true,
+				leaderElection:       defaults.leaderElection,
 				probeAddr:            ":8080",
+				watchNamespaces:      defaults.watchNamespaces,
+				webhookCertDir:       defaults.webhookCertDir,
+				webhookCABundleCM:    defaults.webhookCABundleCM,
+				capabilitiesRefresh:  defaults.capabilitiesRefresh,
 				zapOpts: zap.Options{
 					Development: true,
 				},
@@ -104,3 +202,42 @@ func TestGetOptions(t *testing.T) {
 		assert.Equal(t, tc.ExpectedOptions, GetOptions())
 	}
 }
+
+func TestLeaderElectionOptionsValidate(t *testing.T) {
+	cases := []struct {
+		Name        string
+		Options     leaderElectionOptions
+		ExpectedErr string
+	}{
+		{"defaults", DefaultOptions().leaderElection, ""},
+		{"renewDeadlineNotBelowLeaseDuration",
+			leaderElectionOptions{leaseDuration: 10 * time.Second, renewDeadline: 10 * time.Second, retryPeriod: 2 * time.Second},
+			"lease duration 10s must be greater than the renew deadline 10s"},
+		{"retryPeriodNotBelowRenewDeadline",
+			leaderElectionOptions{leaseDuration: 15 * time.Second, renewDeadline: 2 * time.Second, retryPeriod: 3 * time.Second},
+			"renew deadline 2s must be greater than the retry period 3s"},
+		{"zeroRetryPeriod",
+			leaderElectionOptions{leaseDuration: 15 * time.Second, renewDeadline: 10 * time.Second},
+			"retry period must be positive"},
+	}
+	for _, tc := range cases {
+		err := tc.Options.validate()
+		if tc.ExpectedErr == "" {
+			assert.NoError(t, err, tc.Name)
+		} else {
+			assert.ErrorContains(t, err, tc.ExpectedErr, tc.Name)
+		}
+	}
+}
+
+func TestNewCacheOptions(t *testing.T) {
+	cacheOptions, clientOptions := newCacheOptions(nil)
+	assert.Empty(t, cacheOptions.DefaultNamespaces)
+	assert.Nil(t, clientOptions.Cache)
+
+	cacheOptions, clientOptions = newCacheOptions([]string{"team-a", "team-b"})
+	assert.Equal(t, map[string]cache.Config{"team-a": {}, "team-b": {}}, cacheOptions.DefaultNamespaces)
+	if assert.NotNil(t, clientOptions.Cache) {
+		assert.Equal(t, []client.Object{&v1alpha1.ClusterStorageContainer{}}, clientOptions.Cache.DisableFor)
+	}
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+// isPredictionAggregation returns whether the response aggregation combines the predictions of the steps of an
+// Ensemble node into a single response
+func isPredictionAggregation(aggregation v1alpha1.ResponseAggregationType) bool {
+	switch aggregation {
+	case v1alpha1.MajorityVote, v1alpha1.Average, v1alpha1.Concat, v1alpha1.ResponseTemplate:
+		return true
+	}
+	return false
+}
+
+// aggregatePredictions combines the responses of the successful steps of an Ensemble node according to its response
+// aggregation. The responses of the soft dependencies which did not succeed are left out.
+func aggregatePredictions(nodeName string, node v1alpha1.InferenceRouter, outputs []EnsembleStepOutput) ([]byte, error) {
+	responses := map[string]interface{}{}
+	var bodies [][]byte
+	for i, output := range outputs {
+		if !isSuccessFul(output.StepStatusCode) {
+			log.Info("Leaving the unsuccessful step out of the aggregation", "node", nodeName, "stepName", node.Steps[i].StepName, "statusCode", output.StepStatusCode)
+			continue
+		}
+		body, err := json.Marshal(output.StepResponse)
+		if err != nil {
+			return nil, err
+		}
+		responses[stepKey(node.Steps[i], i)] = output.StepResponse
+		bodies = append(bodies, body)
+	}
+	if len(bodies) == 0 {
+		return nil, fmt.Errorf("none of the steps of node %q succeeded", nodeName)
+	}
+	if node.ResponseAggregation == v1alpha1.ResponseTemplate {
+		return renderAggregationTemplate(node.Aggregation, responses)
+	}
+
+	format := node.Aggregation.GetFormat()
+	stepPredictions := make([][]interface{}, len(bodies))
+	for i, body := range bodies {
+		predictions, err := decodeResponse(format, body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the predictions of a step of node %q: %w", nodeName, err)
+		}
+		stepPredictions[i] = predictions
+	}
+	var predictions []interface{}
+	var err error
+	switch node.ResponseAggregation {
+	case v1alpha1.MajorityVote:
+		predictions, err = majorityVote(stepPredictions)
+	case v1alpha1.Average:
+		predictions, err = averagePredictions(stepPredictions)
+	case v1alpha1.Concat:
+		for _, p := range stepPredictions {
+			predictions = append(predictions, p...)
+		}
+	default:
+		err = fmt.Errorf("unknown response aggregation %q", node.ResponseAggregation)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate the predictions of node %q: %w", nodeName, err)
+	}
+	return encodeResponse(&v1alpha1.ProtocolTranslation{From: format}, predictions)
+}
+
+// majorityVote returns the prediction predicted by most steps for each instance, ties go to the prediction of the
+// earliest step
+func majorityVote(stepPredictions [][]interface{}) ([]interface{}, error) {
+	if err := checkPredictionCounts(stepPredictions); err != nil {
+		return nil, err
+	}
+	votes := make([]interface{}, len(stepPredictions[0]))
+	for i := range votes {
+		counts := map[string]int{}
+		best := 0
+		for _, predictions := range stepPredictions {
+			key, err := json.Marshal(predictions[i])
+			if err != nil {
+				return nil, err
+			}
+			counts[string(key)]++
+			if count := counts[string(key)]; count > best {
+				best = count
+				votes[i] = predictions[i]
+			}
+		}
+	}
+	return votes, nil
+}
+
+// averagePredictions returns the element-wise average of the numeric predictions of the steps for each instance
+func averagePredictions(stepPredictions [][]interface{}) ([]interface{}, error) {
+	if err := checkPredictionCounts(stepPredictions); err != nil {
+		return nil, err
+	}
+	averages := make([]interface{}, len(stepPredictions[0]))
+	for i := range averages {
+		values := make([]interface{}, len(stepPredictions))
+		for j, predictions := range stepPredictions {
+			values[j] = predictions[i]
+		}
+		average, err := averageValues(values)
+		if err != nil {
+			return nil, fmt.Errorf("prediction %d: %w", i, err)
+		}
+		averages[i] = average
+	}
+	return averages, nil
+}
+
+// averageValues returns the average of numbers, or the element-wise average of arrays of the same length
+func averageValues(values []interface{}) (interface{}, error) {
+	switch first := values[0].(type) {
+	case float64:
+		sum := 0.0
+		for _, value := range values {
+			number, ok := value.(float64)
+			if !ok {
+				return nil, fmt.Errorf("cannot average %v with %v", first, value)
+			}
+			sum += number
+		}
+		return sum / float64(len(values)), nil
+	case []interface{}:
+		arrays := make([][]interface{}, len(values))
+		for i, value := range values {
+			array, ok := value.([]interface{})
+			if !ok || len(array) != len(first) {
+				return nil, fmt.Errorf("cannot average %v with %v", first, value)
+			}
+			arrays[i] = array
+		}
+		return averagePredictions(arrays)
+	}
+	return nil, fmt.Errorf("cannot average the non numeric prediction %v", values[0])
+}
+
+// checkPredictionCounts checks that every step returned the same number of predictions
+func checkPredictionCounts(stepPredictions [][]interface{}) error {
+	for _, predictions := range stepPredictions[1:] {
+		if len(predictions) != len(stepPredictions[0]) {
+			return fmt.Errorf("the steps returned %d and %d predictions", len(stepPredictions[0]), len(predictions))
+		}
+	}
+	return nil
+}
+
+// renderAggregationTemplate renders the response of a node with the Template aggregation from the responses of its
+// steps keyed by step name
+func renderAggregationTemplate(spec *v1alpha1.AggregationSpec, responses map[string]interface{}) ([]byte, error) {
+	if spec == nil || spec.Template == "" {
+		return nil, fmt.Errorf("the Template response aggregation requires a template")
+	}
+	tmpl, err := spec.ParseTemplate()
+	if err != nil {
+		return nil, err
+	}
+	var response bytes.Buffer
+	if err := tmpl.Execute(&response, map[string]interface{}{"steps": responses}); err != nil {
+		return nil, err
+	}
+	if !json.Valid(response.Bytes()) {
+		return nil, fmt.Errorf("the aggregation template rendered an invalid JSON response")
+	}
+	return response.Bytes(), nil
+}
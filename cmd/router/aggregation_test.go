@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregatePredictions(t *testing.T) {
+	steps := []v1alpha1.InferenceStep{{StepName: "a"}, {StepName: "b"}, {StepName: "c"}}
+	scenarios := map[string]struct {
+		aggregation v1alpha1.ResponseAggregationType
+		spec        *v1alpha1.AggregationSpec
+		responses   []string
+		statusCodes []int
+		expected    string
+		expectErr   bool
+	}{
+		"majority vote": {
+			aggregation: v1alpha1.MajorityVote,
+			responses:   []string{`{"predictions":[1,"cat"]}`, `{"predictions":[2,"dog"]}`, `{"predictions":[2,"cat"]}`},
+			expected:    `{"predictions":[2,"cat"]}`,
+		},
+		"majority vote tie goes to the earliest step": {
+			aggregation: v1alpha1.MajorityVote,
+			responses:   []string{`{"predictions":[1]}`, `{"predictions":[2]}`, `{"predictions":[3]}`},
+			expected:    `{"predictions":[1]}`,
+		},
+		"majority vote leaves out the unsuccessful steps": {
+			aggregation: v1alpha1.MajorityVote,
+			responses:   []string{`{"predictions":[1]}`, `{"error":"unavailable"}`, `{"predictions":[2]}`},
+			statusCodes: []int{200, 503, 200},
+			expected:    `{"predictions":[1]}`,
+		},
+		"average": {
+			aggregation: v1alpha1.Average,
+			responses:   []string{`{"predictions":[[0.25,0.75],1]}`, `{"predictions":[[0.75,0.25],2]}`, `{"predictions":[[0.5,0.5],6]}`},
+			expected:    `{"predictions":[[0.5,0.5],3]}`,
+		},
+		"average of v2 responses": {
+			aggregation: v1alpha1.Average,
+			spec:        &v1alpha1.AggregationSpec{Format: v1alpha1.ProtocolFormatV2},
+			responses: []string{
+				`{"outputs":[{"name":"output-0","shape":[2],"datatype":"FP32","data":[1,2]}]}`,
+				`{"outputs":[{"name":"output-0","shape":[2],"datatype":"FP32","data":[3,4]}]}`,
+				`{"outputs":[{"name":"output-0","shape":[2],"datatype":"FP32","data":[5,6]}]}`,
+			},
+			expected: `{"outputs":[{"name":"output-0","shape":[2],"datatype":"FP32","data":[3,4]}]}`,
+		},
+		"average of non numeric predictions": {
+			aggregation: v1alpha1.Average,
+			responses:   []string{`{"predictions":["cat"]}`, `{"predictions":["dog"]}`, `{"predictions":["cat"]}`},
+			expectErr:   true,
+		},
+		"average of predictions of different lengths": {
+			aggregation: v1alpha1.Average,
+			responses:   []string{`{"predictions":[1,2]}`, `{"predictions":[1]}`, `{"predictions":[1,2]}`},
+			expectErr:   true,
+		},
+		"concat": {
+			aggregation: v1alpha1.Concat,
+			responses:   []string{`{"predictions":[1]}`, `{"predictions":[2,3]}`, `{"predictions":[]}`},
+			expected:    `{"predictions":[1,2,3]}`,
+		},
+		"template": {
+			aggregation: v1alpha1.ResponseTemplate,
+			spec: &v1alpha1.AggregationSpec{
+				Template: `{"label": {{ json .steps.a.label }}, "score": {{ .steps.c.score }}}`,
+			},
+			responses: []string{`{"label":"cat"}`, `{"label":"dog"}`, `{"score":0.9}`},
+			expected:  `{"label":"cat","score":0.9}`,
+		},
+		"template rendering invalid json": {
+			aggregation: v1alpha1.ResponseTemplate,
+			spec:        &v1alpha1.AggregationSpec{Template: `{{ .steps.a.label }}`},
+			responses:   []string{`{"label":"cat"}`, `{"label":"dog"}`, `{"label":"cat"}`},
+			expectErr:   true,
+		},
+		"template referencing an unsuccessful step": {
+			aggregation: v1alpha1.ResponseTemplate,
+			spec:        &v1alpha1.AggregationSpec{Template: `{{ json .steps.b }}`},
+			responses:   []string{`{"label":"cat"}`, `{"error":"unavailable"}`, `{"label":"cat"}`},
+			statusCodes: []int{200, 503, 200},
+			expectErr:   true,
+		},
+		"no successful step": {
+			aggregation: v1alpha1.Concat,
+			responses:   []string{`{"error":"a"}`, `{"error":"b"}`, `{"error":"c"}`},
+			statusCodes: []int{500, 500, 500},
+			expectErr:   true,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			node := v1alpha1.InferenceRouter{
+				RouterType:          v1alpha1.Ensemble,
+				Steps:               steps,
+				ResponseAggregation: scenario.aggregation,
+				Aggregation:         scenario.spec,
+			}
+			outputs := make([]EnsembleStepOutput, len(scenario.responses))
+			for i, response := range scenario.responses {
+				outputs[i].StepStatusCode = 200
+				if scenario.statusCodes != nil {
+					outputs[i].StepStatusCode = scenario.statusCodes[i]
+				}
+				assert.NoError(t, json.Unmarshal([]byte(response), &outputs[i].StepResponse))
+			}
+			response, err := aggregatePredictions("root", node, outputs)
+			if scenario.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.JSONEq(t, scenario.expected, string(response))
+		})
+	}
+}
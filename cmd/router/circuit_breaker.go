@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+// The reasons the router rejects the requests and the calls to the target services
+const (
+	shedReasonInFlight       = "in_flight"
+	shedReasonTargetInFlight = "target_in_flight"
+	shedReasonTargetEjected  = "target_ejected"
+)
+
+var (
+	shedRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kserve_router_shed_requests_total",
+		Help: "The number of requests and calls to the target services rejected by the load shedding and the circuit breakers of the router, by reason",
+	}, []string{"reason"})
+	targetEjections = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "kserve_router_target_ejections_total",
+		Help: "The number of times a target service was ejected by its circuit breaker",
+	})
+)
+
+// targetState is the state of the circuit breaker of a target service and its number of calls in flight
+type targetState struct {
+	mu                sync.Mutex
+	inFlight          int32
+	consecutiveErrors int32
+	// ejectedUntil is when the ejection of the target ends, zero while the target is not ejected
+	ejectedUntil time.Time
+	// probing is set while the single call let through once the ejection time elapsed is in flight
+	probing bool
+}
+
+// targetStates are the states of the target services, by URL
+var targetStates sync.Map
+
+func getTargetState(serviceURL string) *targetState {
+	state, _ := targetStates.LoadOrStore(serviceURL, &targetState{})
+	return state.(*targetState)
+}
+
+// acquire reserves a call to the target, the call is rejected with a reason when the target is ejected by the circuit
+// breaker or has the maximum number of calls in flight. probe is set for the call let through once the ejection time
+// elapsed.
+func (s *targetState) acquire(breaker *v1alpha1.CircuitBreakerPolicy, maxInFlight int32, now time.Time) (probe bool, reason string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if breaker != nil && !s.ejectedUntil.IsZero() {
+		if now.Before(s.ejectedUntil) || s.probing {
+			return false, shedReasonTargetEjected
+		}
+		probe = true
+	}
+	if maxInFlight > 0 && s.inFlight >= maxInFlight {
+		return false, shedReasonTargetInFlight
+	}
+	s.probing = s.probing || probe
+	s.inFlight++
+	return probe, ""
+}
+
+// release records the outcome of a call reserved by acquire and returns whether the failed call ejected the target,
+// the target is ejected once the consecutive failed calls reach the threshold of the circuit breaker or when the probe
+// call fails
+func (s *targetState) release(breaker *v1alpha1.CircuitBreakerPolicy, probe bool, failed bool, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inFlight--
+	if probe {
+		s.probing = false
+	}
+	if breaker == nil {
+		return false
+	}
+	if !failed {
+		s.consecutiveErrors = 0
+		s.ejectedUntil = time.Time{}
+		return false
+	}
+	s.consecutiveErrors++
+	if !probe && s.consecutiveErrors < breaker.ConsecutiveErrors {
+		return false
+	}
+	ejectionTime := v1alpha1.DefaultCircuitBreakerEjectionTime
+	if breaker.EjectionTime != nil {
+		ejectionTime = breaker.EjectionTime.Duration
+	}
+	s.ejectedUntil = now.Add(ejectionTime)
+	return true
+}
+
+/*
+Calls the target service of the step through its circuit breaker, the circuit breaker of the step or else of the graph,
+and within the maximum number of calls in flight to a single target of the graph. The rejected calls fail right away
+with 503 Service Unavailable, so that a failing or slow target does not hold the requests of the router.
+*/
+func callTarget(step *v1alpha1.InferenceStep, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header) ([]byte, int, error) {
+	breaker := step.CircuitBreaker
+	if breaker == nil {
+		breaker = graph.CircuitBreaker
+	}
+	var maxInFlight int32
+	if graph.LoadShedding != nil && graph.LoadShedding.MaxInFlightCallsPerTarget != nil {
+		maxInFlight = *graph.LoadShedding.MaxInFlightCallsPerTarget
+	}
+	if breaker == nil && maxInFlight == 0 {
+		return callServiceWithRetries(step, input, headers)
+	}
+
+	state := getTargetState(step.ServiceURL)
+	probe, reason := state.acquire(breaker, maxInFlight, time.Now())
+	switch reason {
+	case shedReasonTargetEjected:
+		shedRequests.WithLabelValues(reason).Inc()
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("the service %s of step %q is ejected by its circuit breaker",
+			step.ServiceURL, step.StepName)
+	case shedReasonTargetInFlight:
+		shedRequests.WithLabelValues(reason).Inc()
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("the service %s of step %q has %d calls in flight",
+			step.ServiceURL, step.StepName, maxInFlight)
+	}
+	response, statusCode, err := callServiceWithRetries(step, input, headers)
+	if state.release(breaker, probe, err != nil || statusCode >= http.StatusInternalServerError, time.Now()) {
+		targetEjections.Inc()
+		log.Info("Ejecting the service of the step after consecutive failed calls", "stepName", step.StepName,
+			"serviceUrl", step.ServiceURL, "statusCode", statusCode)
+	}
+	return response, statusCode, err
+}
+
+// newLoadSheddingHandler rejects the requests over the maximum number of requests in flight with 503 Service
+// Unavailable, the clients are asked to retry after a second
+func newLoadSheddingHandler(maxInFlight int32, next http.Handler) http.Handler {
+	var inFlight atomic.Int32
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		defer inFlight.Add(-1)
+		if inFlight.Add(1) > maxInFlight {
+			shedRequests.WithLabelValues(shedReasonInFlight).Inc()
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, "the router has too many requests in flight", http.StatusServiceUnavailable)
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
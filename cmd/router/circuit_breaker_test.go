@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+func TestCallTargetCircuitBreaker(t *testing.T) {
+	// the model fails until it is healthy
+	healthy := &atomic.Bool{}
+	calls := &atomic.Int32{}
+	model := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		calls.Add(1)
+		if !healthy.Load() {
+			rw.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = rw.Write([]byte(`{"predictions": [1]}`))
+	}))
+	defer model.Close()
+	graph := v1alpha1.InferenceGraphSpec{
+		CircuitBreaker: &v1alpha1.CircuitBreakerPolicy{ConsecutiveErrors: 2,
+			EjectionTime: &metav1.Duration{Duration: 50 * time.Millisecond}},
+	}
+	step := &v1alpha1.InferenceStep{StepName: "model", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL}}
+	call := func() int {
+		_, statusCode, _ := callTarget(step, graph, []byte(`{"instances": [1]}`), http.Header{})
+		return statusCode
+	}
+
+	assert.Equal(t, http.StatusInternalServerError, call())
+	assert.Equal(t, http.StatusInternalServerError, call())
+	// the target is ejected after the consecutive failed calls, the calls fail without reaching it
+	_, statusCode, err := callTarget(step, graph, []byte(`{"instances": [1]}`), http.Header{})
+	assert.Equal(t, http.StatusServiceUnavailable, statusCode)
+	assert.ErrorContains(t, err, "ejected by its circuit breaker")
+	assert.Equal(t, int32(2), calls.Load())
+
+	// the failed probe call ejects the target again
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(t, http.StatusInternalServerError, call())
+	assert.Equal(t, http.StatusServiceUnavailable, call())
+	assert.Equal(t, int32(3), calls.Load())
+
+	// the successful probe call closes the circuit breaker
+	healthy.Store(true)
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(t, http.StatusOK, call())
+	assert.Equal(t, http.StatusOK, call())
+	assert.Equal(t, int32(5), calls.Load())
+
+	// the circuit breaker of the step overrides the one of the graph
+	healthy.Store(false)
+	step.CircuitBreaker = &v1alpha1.CircuitBreakerPolicy{ConsecutiveErrors: 3}
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusInternalServerError, call())
+	}
+	assert.Equal(t, http.StatusServiceUnavailable, call())
+}
+
+func TestTargetStateMaxInFlight(t *testing.T) {
+	state := &targetState{}
+	now := time.Now()
+	_, reason := state.acquire(nil, 1, now)
+	assert.Empty(t, reason)
+	_, reason = state.acquire(nil, 1, now)
+	assert.Equal(t, shedReasonTargetInFlight, reason)
+	assert.False(t, state.release(nil, false, true, now))
+	_, reason = state.acquire(nil, 1, now)
+	assert.Empty(t, reason)
+}
+
+func TestLoadSheddingHandler(t *testing.T) {
+	entered := make(chan struct{}, 1)
+	done := make(chan struct{})
+	handler := newLoadSheddingHandler(1, http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		select {
+		case entered <- struct{}{}:
+		default:
+		}
+		<-done
+	}))
+
+	go handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", nil))
+	<-entered
+	// the requests over the maximum number of requests in flight are rejected
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+	close(done)
+
+	// the requests are served again once the requests in flight completed
+	assert.Eventually(t, func() bool {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", nil))
+		return w.Code == http.StatusOK
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/kserve/kserve/pkg/expression"
+)
+
+// expressionPrograms caches the compiled expressions of the Switch steps by source
+var expressionPrograms sync.Map
+
+// expressionVars returns the variables of the Switch step expressions, the JSON body of the request as `body` and
+// the headers with lower case names as `headers`
+func expressionVars(input []byte, headers http.Header) map[string]interface{} {
+	var body interface{}
+	if err := json.Unmarshal(input, &body); err != nil {
+		body = nil
+	}
+	return expression.Vars(body, headers)
+}
+
+// matchExpression evaluates the expression of a Switch step
+func matchExpression(source string, vars map[string]interface{}) (bool, error) {
+	program, ok := expressionPrograms.Load(source)
+	if !ok {
+		compiled, err := expression.Compile(source)
+		if err != nil {
+			return false, err
+		}
+		program, _ = expressionPrograms.LoadOrStore(source, compiled)
+	}
+	return program.(*expression.Program).Matches(vars)
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+func TestPickupRouteByExpression(t *testing.T) {
+	steps := []v1alpha1.InferenceStep{
+		{StepName: "gold", Expression: `headers["x-tenant"] == "gold"`},
+		{StepName: "adult", Expression: `body.instances[0].age >= 18`},
+		{StepName: "condition", Condition: "instances.0.minor"},
+	}
+	headers := func(tenant string) http.Header {
+		h := http.Header{}
+		if tenant != "" {
+			h.Set("X-Tenant", tenant)
+		}
+		return h
+	}
+
+	scenarios := map[string]struct {
+		input   string
+		headers http.Header
+		step    string
+	}{
+		"header":                     {input: `{"instances": [{"age": 10}]}`, headers: headers("gold"), step: "gold"},
+		"body":                       {input: `{"instances": [{"age": 42}]}`, headers: headers("silver"), step: "adult"},
+		"fallback to the condition":  {input: `{"instances": [{"minor": true}]}`, headers: headers(""), step: "condition"},
+		"expression on invalid body": {input: `not json`, headers: headers("gold"), step: "gold"},
+		"no match":                   {input: `{"instances": [{"age": 10}]}`, headers: headers(""), step: ""},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			route := pickupRouteByCondition([]byte(scenario.input), scenario.headers, steps)
+			if scenario.step == "" {
+				assert.Nil(t, route)
+				return
+			}
+			if assert.NotNil(t, route) {
+				assert.Equal(t, scenario.step, route.StepName)
+			}
+		})
+	}
+}
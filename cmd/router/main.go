@@ -18,6 +18,8 @@ package main
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/json"
 	goerrors "errors"
 	"fmt"
@@ -27,12 +29,14 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/pkg/errors"
 
 	"github.com/tidwall/gjson"
+	"go.uber.org/zap/zapcore"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
@@ -40,15 +44,55 @@ import (
 	"math/big"
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/cors"
+	"github.com/kserve/kserve/pkg/quota"
+	routerapi "github.com/kserve/kserve/pkg/router/api"
+	"github.com/kserve/kserve/pkg/routerplugin"
+	"github.com/kserve/kserve/pkg/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	flag "github.com/spf13/pflag"
 )
 
 var log = logf.Log.WithName("InferenceGraphRouter")
 
-func callService(serviceUrl string, input []byte, headers http.Header) ([]byte, int, error) {
+// envReferencePattern matches the `$(VAR_NAME)` references to environment variables in the step header values
+var envReferencePattern = regexp.MustCompile(`\$\(([A-Za-z_][A-Za-z0-9_]*)\)`)
+
+// expandEnv expands the references to the environment variables of the router, unresolved references are kept as is
+func expandEnv(value string) string {
+	return envReferencePattern.ReplaceAllStringFunc(value, func(reference string) string {
+		if envValue, ok := os.LookupEnv(envReferencePattern.FindStringSubmatch(reference)[1]); ok {
+			return envValue
+		}
+		return reference
+	})
+}
+
+// setStepHeaders removes and then sets the headers configured on the step on the request to its target
+func setStepHeaders(req *http.Request, step *v1alpha1.InferenceStep) {
+	if step == nil {
+		return
+	}
+	for _, name := range step.RemoveHeaders {
+		req.Header.Del(name)
+	}
+	for _, header := range step.Headers {
+		req.Header.Set(header.Name, expandEnv(header.Value))
+	}
+}
+
+func callService(serviceUrl string, input []byte, headers http.Header, step *v1alpha1.InferenceStep) ([]byte, int, error) {
 	defer timeTrack(time.Now(), "step", serviceUrl)
 	log.Info("Entering callService", "url", serviceUrl)
-	req, err := http.NewRequest("POST", serviceUrl, bytes.NewBuffer(input))
+	ctx := context.Background()
+	if step != nil && step.Timeout != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, step.Timeout.Duration)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", serviceUrl, bytes.NewBuffer(input))
 	if err != nil {
 		log.Error(err, "An error occurred while preparing request object with serviceUrl.", "serviceUrl", serviceUrl)
 		return nil, 500, err
@@ -69,6 +113,7 @@ func callService(serviceUrl string, input []byte, headers http.Header) ([]byte,
 		}
 	}
 	log.Info("These headers will be propagated by the router to all the steps", "headers", headersToPropagate)
+	setStepHeaders(req, step)
 	if val := req.Header.Get("Content-Type"); val == "" {
 		req.Header.Add("Content-Type", "application/json")
 	}
@@ -76,6 +121,9 @@ func callService(serviceUrl string, input []byte, headers http.Header) ([]byte,
 
 	if err != nil {
 		log.Error(err, "An error has occurred while calling service", "service", serviceUrl)
+		if goerrors.Is(err, context.DeadlineExceeded) {
+			return nil, http.StatusGatewayTimeout, err
+		}
 		return nil, 500, err
 	}
 
@@ -95,30 +143,45 @@ func callService(serviceUrl string, input []byte, headers http.Header) ([]byte,
 	return body, resp.StatusCode, err
 }
 
-func pickupRoute(routes []v1alpha1.InferenceStep) *v1alpha1.InferenceStep {
-	randomNumber, err := rand.Int(rand.Reader, big.NewInt(101))
+// pickupRoute returns the index of the route picked according to the weights, or -1 if none was picked
+func pickupRoute(routes []v1alpha1.InferenceStep) int {
+	randomNumber, err := rand.Int(rand.Reader, big.NewInt(100))
 	if err != nil {
 		panic(err)
 	}
 	// generate num [0,100)
 	point := int(randomNumber.Int64())
 	end := 0
-	for _, route := range routes {
+	for i, route := range routes {
 		end += int(*route.Weight)
 		if point < end {
-			return &route
+			return i
 		}
 	}
-	return nil
+	return -1
 }
 
-func pickupRouteByCondition(input []byte, routes []v1alpha1.InferenceStep) *v1alpha1.InferenceStep {
-	if !gjson.ValidBytes(input) {
-		return nil
-	}
-	for _, route := range routes {
-		if gjson.GetBytes(input, route.Condition).Exists() {
-			return &route
+// pickupRouteByCondition returns the first step of a Switch node whose expression or condition matches the request,
+// the steps whose expression cannot be evaluated do not match
+func pickupRouteByCondition(input []byte, headers http.Header, routes []v1alpha1.InferenceStep) *v1alpha1.InferenceStep {
+	validJSON := gjson.ValidBytes(input)
+	var vars map[string]interface{}
+	for i, route := range routes {
+		if route.Expression != "" {
+			if vars == nil {
+				vars = expressionVars(input, headers)
+			}
+			matched, err := matchExpression(route.Expression, vars)
+			if err != nil {
+				log.Info("Failed to evaluate the expression of the step", "stepName", route.StepName, "error", err.Error())
+			}
+			if matched {
+				return &routes[i]
+			}
+			continue
+		}
+		if validJSON && gjson.GetBytes(input, route.Condition).Exists() {
+			return &routes[i]
 		}
 	}
 	return nil
@@ -129,13 +192,123 @@ func timeTrack(start time.Time, nodeOrStep string, name string) {
 	log.Info("elapsed time", nodeOrStep, name, "time", elapsed)
 }
 
+// graphLimiter enforces the graph limits for a single request, it is shared by the parallel steps of the request
+type graphLimiter struct {
+	maxNodesVisited int32
+	maxFanOut       int32
+	nodesVisited    atomic.Int32
+	parallelSteps   atomic.Int32
+	// trace records the steps executed for the request when the traces of the failed requests are persisted
+	trace *executionTrace
+}
+
+func newGraphLimiter(maxNodesVisited int, maxFanOut int) *graphLimiter {
+	limiter := &graphLimiter{maxNodesVisited: int32(maxNodesVisited), maxFanOut: int32(maxFanOut)} // #nosec G115
+	// the request itself is the first step in flight
+	limiter.parallelSteps.Store(1)
+	return limiter
+}
+
+// visitNode counts a node visited by the request and fails once the limit is exceeded
+func (l *graphLimiter) visitNode(nodeName string) error {
+	if visited := l.nodesVisited.Add(1); l.maxNodesVisited != 0 && visited > l.maxNodesVisited {
+		return fmt.Errorf("visiting node %q exceeds the limit of %d nodes visited per request", nodeName, l.maxNodesVisited)
+	}
+	return nil
+}
+
+// fanOut counts the additional steps in flight when a node executes its steps in parallel and fails once the limit
+// is exceeded. The returned function releases the steps.
+func (l *graphLimiter) fanOut(nodeName string, steps int) (func(), error) {
+	additional := int32(steps - 1) // #nosec G115
+	if additional <= 0 {
+		return func() {}, nil
+	}
+	release := func() { l.parallelSteps.Add(-additional) }
+	if inFlight := l.parallelSteps.Add(additional); l.maxFanOut != 0 && inFlight > l.maxFanOut {
+		release()
+		return nil, fmt.Errorf("executing the %d steps of node %q in parallel exceeds the limit of %d parallel steps per request",
+			steps, nodeName, l.maxFanOut)
+	}
+	return release, nil
+}
+
 type EnsembleStepOutput struct {
 	StepResponse   map[string]interface{}
 	StepStatusCode int
 }
 
+// ensembleStepResult is the output of the step of an Ensemble node at index, or the error executing it
+type ensembleStepResult struct {
+	index  int
+	output EnsembleStepOutput
+	err    error
+}
+
+// stepKey returns the key of the response of a step, the step name or its index when the step has no name
+func stepKey(step v1alpha1.InferenceStep, index int) string {
+	if step.StepName != "" {
+		return step.StepName
+	}
+	return strconv.Itoa(index) // Use index if no step name
+}
+
+// firstSuccessfulStep returns the first successful response of the steps of an Ensemble node. The response of the
+// last step is returned when none of the steps succeeded.
+func firstSuccessfulStep(nodeName string, steps []v1alpha1.InferenceStep, results <-chan ensembleStepResult) ([]byte, int, error) {
+	var lastErr error
+	var lastOutput *EnsembleStepOutput
+	for range steps {
+		result := <-results
+		if result.err != nil {
+			lastErr = result.err
+			continue
+		}
+		if isSuccessFul(result.output.StepStatusCode) {
+			response, err := json.Marshal(result.output.StepResponse)
+			if err != nil {
+				return nil, 500, err
+			}
+			return response, result.output.StepStatusCode, nil
+		}
+		lastOutput = &result.output
+	}
+	log.Info("None of the steps of the node succeeded", "node", nodeName)
+	if lastOutput != nil {
+		response, err := json.Marshal(lastOutput.StepResponse)
+		if err != nil {
+			return nil, 500, err
+		}
+		return response, lastOutput.StepStatusCode, nil
+	}
+	return nil, 500, lastErr
+}
+
+// aggregateSelectedStep wraps the response of the step selected by a Splitter node according to its response
+// aggregation, the response is returned as is by default
+func aggregateSelectedStep(node v1alpha1.InferenceRouter, index int, response []byte, statusCode int) ([]byte, int, error) {
+	if node.ResponseAggregation != v1alpha1.Keyed && node.ResponseAggregation != v1alpha1.Array {
+		return response, statusCode, nil
+	}
+	var res map[string]interface{}
+	if err := json.Unmarshal(response, &res); err != nil {
+		return nil, 500, err
+	}
+	var aggregated []byte
+	var err error
+	if node.ResponseAggregation == v1alpha1.Array {
+		aggregated, err = json.Marshal([]interface{}{res})
+	} else {
+		aggregated, err = json.Marshal(map[string]interface{}{stepKey(node.Steps[index], index): res})
+	}
+	if err != nil {
+		return nil, 500, err
+	}
+	return aggregated, statusCode, nil
+}
+
 // See if reviewer suggests a better name for this function
-func handleSplitterORSwitchNode(route *v1alpha1.InferenceStep, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header) ([]byte, int, error) {
+func handleSplitterORSwitchNode(route *v1alpha1.InferenceStep, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header, limiter *graphLimiter) ([]byte, int, error) {
 	var statusCode int
 	var responseBytes []byte
 	var err error
@@ -144,7 +317,7 @@ func handleSplitterORSwitchNode(route *v1alpha1.InferenceStep, graph v1alpha1.In
 		stepType = "node"
 	}
 	log.Info("Starting execution of step", "type", stepType, "stepName", route.StepName)
-	if responseBytes, statusCode, err = executeStep(route, graph, input, headers); err != nil {
+	if responseBytes, statusCode, err = executeStep(route, graph, input, headers, limiter); err != nil {
 		return nil, 500, err
 	}
 
@@ -154,28 +327,110 @@ func handleSplitterORSwitchNode(route *v1alpha1.InferenceStep, graph v1alpha1.In
 	return responseBytes, statusCode, nil
 }
 
-func routeStep(nodeName string, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header) ([]byte, int, error) {
+func routeStep(nodeName string, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header, limiter *graphLimiter) ([]byte, int, error) {
 	defer timeTrack(time.Now(), "node", nodeName)
+	if err := limiter.visitNode(nodeName); err != nil {
+		log.Error(err, "graph limit exceeded")
+		return nil, 500, err
+	}
+	plugins := pluginsOf(nodeName)
+	if len(plugins) == 0 {
+		return routeNode(nodeName, graph, input, headers, limiter)
+	}
+
+	// the headers are copied so that the changes of the plugins only apply to this node and the nodes it routes to
+	ctx := context.Background()
+	request := &routerplugin.Request{Node: nodeName, Headers: headers.Clone(), Body: input}
+	for _, plugin := range plugins {
+		if err := plugin.ProcessRequest(ctx, request); err != nil {
+			log.Error(err, "plugin failed to process the request", "node", nodeName)
+			return nil, pluginErrorStatusCode(err), err
+		}
+	}
+	responseBytes, statusCode, err := routeNode(nodeName, graph, request.Body, request.Headers, limiter)
+	if err != nil {
+		return responseBytes, statusCode, err
+	}
+	response := &routerplugin.Response{Node: nodeName, StatusCode: statusCode, Body: responseBytes}
+	for _, plugin := range plugins {
+		if err := plugin.ProcessResponse(ctx, response); err != nil {
+			log.Error(err, "plugin failed to process the response", "node", nodeName)
+			return nil, pluginErrorStatusCode(err), err
+		}
+	}
+	return response.Body, response.StatusCode, nil
+}
+
+// pluginErrorStatusCode returns the status code a plugin answered the request with, 500 for unexpected errors
+func pluginErrorStatusCode(err error) int {
+	var pluginErr *routerplugin.Error
+	if goerrors.As(err, &pluginErr) {
+		return pluginErr.StatusCode
+	}
+	return 500
+}
+
+// loadPlugins creates the plugins configured on the nodes of the graph, the Go plugins are loaded from the directory
+func loadPlugins(graph *v1alpha1.InferenceGraphSpec, dir string) (map[string][]routerplugin.Plugin, error) {
+	factories := map[string]routerplugin.Factory{}
+	plugins := map[string][]routerplugin.Plugin{}
+	for nodeName, node := range graph.Nodes {
+		for _, nodePlugin := range node.Plugins {
+			factory, ok := factories[nodePlugin.Name]
+			if !ok {
+				var err error
+				if factory, err = routerplugin.Load(dir, nodePlugin.Name); err != nil {
+					return nil, err
+				}
+				factories[nodePlugin.Name] = factory
+			}
+			plugin, err := factory(nodePlugin.Config)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create plugin %q of node %q: %w", nodePlugin.Name, nodeName, err)
+			}
+			plugins[nodeName] = append(plugins[nodeName], plugin)
+		}
+	}
+	return plugins, nil
+}
+
+func routeNode(nodeName string, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header, limiter *graphLimiter) ([]byte, int, error) {
 	currentNode := graph.Nodes[nodeName]
 
 	if currentNode.RouterType == v1alpha1.Splitter {
-		route := pickupRoute(currentNode.Steps)
-		return handleSplitterORSwitchNode(route, graph, input, headers)
+		index := pickupRoute(currentNode.Steps)
+		if index < 0 {
+			return nil, 500, fmt.Errorf("none of the routes of node %q was picked, the weights should sum to 100", nodeName)
+		}
+		response, statusCode, err := handleSplitterORSwitchNode(&currentNode.Steps[index], graph, input, headers, limiter)
+		if err != nil {
+			return response, statusCode, err
+		}
+		return aggregateSelectedStep(currentNode, index, response, statusCode)
 	}
 	if currentNode.RouterType == v1alpha1.Switch {
 		var err error
-		route := pickupRouteByCondition(input, currentNode.Steps)
+		route := pickupRouteByCondition(input, headers, currentNode.Steps)
 		if route == nil {
 			errorMessage := "None of the routes matched with the switch condition"
 			err = errors.New(errorMessage)
 			log.Error(err, errorMessage)
 			return nil, 404, err
 		}
-		return handleSplitterORSwitchNode(route, graph, input, headers)
+		return handleSplitterORSwitchNode(route, graph, input, headers, limiter)
+	}
+	if currentNode.RouterType == v1alpha1.Map {
+		return handleMapNode(nodeName, currentNode, graph, input, headers, limiter)
 	}
 	if currentNode.RouterType == v1alpha1.Ensemble {
-		ensembleRes := make([]chan EnsembleStepOutput, len(currentNode.Steps))
-		errChan := make(chan error)
+		release, err := limiter.fanOut(nodeName, len(currentNode.Steps))
+		if err != nil {
+			log.Error(err, "graph limit exceeded")
+			return nil, 500, err
+		}
+		defer release()
+		// the results are buffered so that the steps still running do not block once the node returned
+		results := make(chan ensembleStepResult, len(currentNode.Steps))
 		for i := range currentNode.Steps {
 			step := &currentNode.Steps[i]
 			stepType := "serviceUrl"
@@ -183,76 +438,103 @@ func routeStep(nodeName string, graph v1alpha1.InferenceGraphSpec, input []byte,
 				stepType = "node"
 			}
 			log.Info("Starting execution of step", "type", stepType, "stepName", step.StepName)
-			resultChan := make(chan EnsembleStepOutput)
-			ensembleRes[i] = resultChan
-			go func() {
-				output, statusCode, err := executeStep(step, graph, input, headers)
+			go func(index int) {
+				output, statusCode, err := executeStep(step, graph, input, headers, limiter)
 				if err == nil {
 					var res map[string]interface{}
 					if err = json.Unmarshal(output, &res); err == nil {
-						resultChan <- EnsembleStepOutput{
+						results <- ensembleStepResult{index: index, output: EnsembleStepOutput{
 							StepResponse:   res,
 							StepStatusCode: statusCode,
-						}
+						}}
 						return
 					}
 				}
-				errChan <- err
-			}()
+				results <- ensembleStepResult{index: index, err: err}
+			}(i)
+		}
+		if currentNode.ResponseAggregation == v1alpha1.FirstSuccess {
+			return firstSuccessfulStep(nodeName, currentNode.Steps, results)
+		}
+		outputs := make([]EnsembleStepOutput, len(currentNode.Steps))
+		for range currentNode.Steps {
+			result := <-results
+			if result.err != nil {
+				return nil, 500, result.err
+			}
+			outputs[result.index] = result.output
 		}
 		// merge responses from parallel steps
 		response := map[string]interface{}{}
-		ensembleStepOutput := EnsembleStepOutput{}
-		for i, resultChan := range ensembleRes {
-			key := currentNode.Steps[i].StepName
-			if key == "" {
-				key = strconv.Itoa(i) // Use index if no step name
-			}
-			select {
-			case ensembleStepOutput = <-resultChan:
-				if !isSuccessFul(ensembleStepOutput.StepStatusCode) && currentNode.Steps[i].Dependency == v1alpha1.Hard {
-					log.Info("This step is a hard dependency and it is unsuccessful", "stepName", currentNode.Steps[i].StepName, "statusCode", ensembleStepOutput.StepStatusCode)
-					stepResponse, _ := json.Marshal(ensembleStepOutput.StepResponse) // TODO check if you need err handling for Marshalling
-					return stepResponse, ensembleStepOutput.StepStatusCode, nil      // First failed hard dependency will decide the response and response code for ensemble node
-				} else {
-					response[key] = ensembleStepOutput.StepResponse
+		responses := make([]interface{}, 0, len(outputs))
+		for i, ensembleStepOutput := range outputs {
+			if !isSuccessFul(ensembleStepOutput.StepStatusCode) && currentNode.Steps[i].Dependency == v1alpha1.Hard {
+				log.Info("This step is a hard dependency and it is unsuccessful", "stepName", currentNode.Steps[i].StepName, "statusCode", ensembleStepOutput.StepStatusCode)
+				stepResponse, err := json.Marshal(ensembleStepOutput.StepResponse)
+				if err != nil {
+					return nil, 500, err
 				}
-			case err := <-errChan:
+				return stepResponse, ensembleStepOutput.StepStatusCode, nil // First failed hard dependency will decide the response and response code for ensemble node
+			}
+			response[stepKey(currentNode.Steps[i], i)] = ensembleStepOutput.StepResponse
+			responses = append(responses, ensembleStepOutput.StepResponse)
+		}
+		if isPredictionAggregation(currentNode.ResponseAggregation) {
+			combinedResponse, err := aggregatePredictions(nodeName, currentNode, outputs)
+			if err != nil {
 				return nil, 500, err
 			}
+			return combinedResponse, 200, nil
+		}
+		var combinedResponse []byte
+		if currentNode.ResponseAggregation == v1alpha1.Array {
+			combinedResponse, err = json.Marshal(responses)
+		} else {
+			combinedResponse, err = json.Marshal(response)
+		}
+		if err != nil {
+			return nil, 500, err
 		}
-		// return json.Marshal(response)
-		combinedResponse, _ := json.Marshal(response) // TODO check if you need err handling for Marshalling
 		return combinedResponse, 200, nil
 	}
 	if currentNode.RouterType == v1alpha1.Sequence {
 		var statusCode int
 		var responseBytes []byte
 		var err error
+		// responses of the executed steps by step name, the conditions of the later steps can match them
+		stepResponses := map[string][]byte{}
 		for i := range currentNode.Steps {
 			step := &currentNode.Steps[i]
 			stepType := "serviceUrl"
 			if step.NodeName != "" {
 				stepType = "node"
 			}
-			log.Info("Starting execution of step", "type", stepType, "stepName", step.StepName)
 
 			request := input
-			if step.Data == "$response" && i > 0 {
+			if step.Data == "$response" && responseBytes != nil {
 				request = responseBytes
 			}
 
 			if step.Condition != "" {
-				if !gjson.ValidBytes(responseBytes) {
-					return nil, 500, fmt.Errorf("invalid response")
+				matched, err := matchCondition(step.Condition, input, responseBytes, stepResponses)
+				if err != nil {
+					return nil, 500, err
 				}
-				// if the condition does not match for the step in the sequence we stop and return the response
-				if !gjson.GetBytes(responseBytes, step.Condition).Exists() {
+				if !matched {
+					if step.OnConditionNotMet == v1alpha1.Skip {
+						log.Info("Skipping step since its condition does not match", "stepName", step.StepName)
+						continue
+					}
+					// if the condition does not match for the step in the sequence we stop and return the response
 					return responseBytes, 500, nil
 				}
 			}
-			if responseBytes, statusCode, err = executeStep(step, graph, request, headers); err != nil {
-				return nil, 500, err
+			log.Info("Starting execution of step", "type", stepType, "stepName", step.StepName)
+			if responseBytes, statusCode, err = executeStep(step, graph, request, headers, limiter); err != nil {
+				return nil, statusCode, err
+			}
+			if step.StepName != "" {
+				stepResponses[step.StepName] = responseBytes
 			}
 			/*
 			   Only if a step is a hard dependency, we will check for its success.
@@ -266,12 +548,43 @@ func routeStep(nodeName string, graph v1alpha1.InferenceGraphSpec, input []byte,
 			}
 		}
 
+		if responseBytes == nil {
+			// every step was skipped, the request is passed through
+			return input, 200, nil
+		}
 		return responseBytes, statusCode, nil
 	}
 	log.Error(nil, "invalid route type", "type", currentNode.RouterType)
 	return nil, 500, fmt.Errorf("invalid route type: %v", currentNode.RouterType)
 }
 
+// matchCondition matches the condition of a Sequence step against the response of the previous step, the request
+// of the node with the $request. prefix or the response of a previous step with the $steps.<step name>. prefix.
+// The condition of a step referencing a skipped step does not match.
+func matchCondition(condition string, request []byte, previousResponse []byte, stepResponses map[string][]byte) (bool, error) {
+	document, path := previousResponse, condition
+	switch {
+	case strings.HasPrefix(condition, v1alpha1.ConditionRequestPrefix):
+		document, path = request, strings.TrimPrefix(condition, v1alpha1.ConditionRequestPrefix)
+	case strings.HasPrefix(condition, v1alpha1.ConditionResponsePrefix):
+		path = strings.TrimPrefix(condition, v1alpha1.ConditionResponsePrefix)
+	case strings.HasPrefix(condition, v1alpha1.ConditionStepsPrefix):
+		stepName, stepPath, found := strings.Cut(strings.TrimPrefix(condition, v1alpha1.ConditionStepsPrefix), ".")
+		if !found {
+			return false, fmt.Errorf("invalid condition %q, expected %s<step name>.<path>", condition, v1alpha1.ConditionStepsPrefix)
+		}
+		response, executed := stepResponses[stepName]
+		if !executed {
+			return false, nil
+		}
+		document, path = response, stepPath
+	}
+	if !gjson.ValidBytes(document) {
+		return false, fmt.Errorf("invalid response")
+	}
+	return gjson.GetBytes(document, path).Exists(), nil
+}
+
 func isSuccessFul(statusCode int) bool {
 	if statusCode >= 200 && statusCode <= 299 {
 		return true
@@ -279,12 +592,45 @@ func isSuccessFul(statusCode int) bool {
 	return false
 }
 
-func executeStep(step *v1alpha1.InferenceStep, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header) ([]byte, int, error) {
+func executeStep(step *v1alpha1.InferenceStep, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header, limiter *graphLimiter) ([]byte, int, error) {
+	start := time.Now()
+	var response []byte
+	var statusCode int
+	var err error
+	if step.Translation != nil {
+		response, statusCode, err = executeTranslatedStep(step, graph, input, headers, limiter)
+	} else {
+		response, statusCode, err = executeStepTarget(step, graph, input, headers, limiter)
+	}
+	limiter.trace.recordStep(step, start, input, response, statusCode, err)
+	return response, statusCode, err
+}
+
+// executeTranslatedStep translates the input to the protocol of the target of the step and its successful response
+// back to the protocol of the graph
+func executeTranslatedStep(step *v1alpha1.InferenceStep, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header, limiter *graphLimiter) ([]byte, int, error) {
+	translated, err := translateRequest(step.Translation, input)
+	if err != nil {
+		log.Error(err, "failed to translate the request of the step", "stepName", step.StepName)
+		return nil, http.StatusBadRequest, err
+	}
+	response, statusCode, err := executeStepTarget(step, graph, translated, headers, limiter)
+	if err != nil || !isSuccessFul(statusCode) {
+		return response, statusCode, err
+	}
+	if response, err = translateResponse(step.Translation, response); err != nil {
+		log.Error(err, "failed to translate the response of the step", "stepName", step.StepName)
+		return nil, http.StatusBadGateway, err
+	}
+	return response, statusCode, nil
+}
+
+func executeStepTarget(step *v1alpha1.InferenceStep, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header, limiter *graphLimiter) ([]byte, int, error) {
 	if step.NodeName != "" {
 		// when nodeName is specified make a recursive call for routing to next step
-		return routeStep(step.NodeName, graph, input, headers)
+		return routeStep(step.NodeName, graph, input, headers, limiter)
 	}
-	return callService(step.ServiceURL, input, headers)
+	return callTarget(step, graph, input, headers)
 }
 
 func prepareErrorResponse(err error, errorMessage string) []byte {
@@ -301,9 +647,23 @@ func prepareErrorResponse(err error, errorMessage string) []byte {
 
 var inferenceGraph *v1alpha1.InferenceGraphSpec
 
+// nodePlugins are the plugins processing the requests and the responses of the nodes, by node name
+var nodePlugins map[string][]routerplugin.Plugin
+
 func graphHandler(w http.ResponseWriter, req *http.Request) {
 	inputBytes, _ := io.ReadAll(req.Body)
-	if response, statusCode, err := routeStep(v1alpha1.GraphRootNodeName, *inferenceGraph, inputBytes, req.Header); err != nil {
+	limiter := newGraphLimiter(*maxNodesVisited, *maxFanOut)
+	if graphTraceStore != nil {
+		requestID := getOrCreateRequestID(req)
+		w.Header().Set(requestIDHeader, requestID)
+		limiter.trace = newExecutionTrace(requestID, graphTraceConfig, inputBytes)
+	}
+	response, statusCode, err := routeStep(v1alpha1.GraphRootNodeName, routedGraph(), inputBytes, req.Header, limiter)
+	if limiter.trace != nil && (err != nil || !isSuccessFul(statusCode)) {
+		limiter.trace.finish(response, statusCode, err)
+		persistTrace(graphTraceStore, limiter.trace)
+	}
+	if err != nil {
 		log.Error(err, "failed to process request")
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(statusCode)
@@ -337,12 +697,94 @@ func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
 
 var (
 	jsonGraph              = flag.String("graph-json", "", "serialized json graph def")
+	graphFile              = flag.String("graph-file", "", "file of the json graph def, it takes precedence over --graph-json and is reloaded when it changes")
+	port                   = flag.Int("port", constants.RouterDefaultPort, "port on which the graph is served")
+	healthPort             = flag.Int("health-port", 0, "port of the health endpoint, it is served on the graph port when not set")
+	metricsPort            = flag.Int("metrics-port", 0, "port of the prometheus metrics endpoint, metrics are disabled when not set")
+	maxNodesVisited        = flag.Int("max-nodes-visited", 0, "maximum number of nodes visited for a single request, unlimited when not set")
+	maxFanOut              = flag.Int("max-fan-out", 0, "maximum number of steps executed in parallel for a single request, unlimited when not set")
+	pluginDir              = flag.String("plugin-dir", constants.RouterPluginDir, "directory the Go plugins of the nodes are loaded from")
+	traceConfig            = flag.String("trace-config", "", "serialized json config of the store the traces of the failed requests are persisted to, they are not persisted when not set")
+	logLevel               = flag.String("log-level", "", "minimum level of the logs of the router, one of debug, info, warn or error")
+	logFormat              = flag.String("log-format", "", "format of the logs of the router, one of json or text")
+	enableTLS              = flag.Bool("enable-tls", false, "serve the graph over HTTPS, the health and metrics ports keep serving HTTP")
+	fipsMode               = flag.Bool("fips-mode", false, "restrict the HTTPS listener to the FIPS approved TLS settings")
+	tlsCertFile            = flag.String("tls-cert-file", constants.RouterTLSDir+"/tls.crt", "certificate file of the HTTPS listener")
+	tlsKeyFile             = flag.String("tls-key-file", constants.RouterTLSDir+"/tls.key", "private key file of the HTTPS listener")
 	compiledHeaderPatterns []*regexp.Regexp
 )
 
+var (
+	requestCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kserve_router_requests_total",
+		Help: "The number of requests processed by the inference graph router",
+	}, []string{"code"})
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kserve_router_request_duration_seconds",
+		Help:    "The latency of the requests processed by the inference graph router",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"code"})
+)
+
+func healthHandler(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte("ok")); err != nil {
+		log.Error(err, "failed to write healthHandler response")
+	}
+}
+
+// newServer returns a server with the timeouts of the router
+func newServer(port int, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:         fmt.Sprintf(":%d", port), // specify the address and port
+		Handler:      handler,                  // specify your HTTP handler
+		ReadTimeout:  time.Minute,              // set the maximum duration for reading the entire request, including the body
+		WriteTimeout: time.Minute,              // set the maximum duration before timing out writes of the response
+		IdleTimeout:  3 * time.Minute,          // set the maximum amount of time to wait for the next request when keep-alives are enabled
+	}
+}
+
+// listenAndServe serves the handler on a dedicated port, so that the auth and TLS of the data path do not apply
+// to the kubelet probes and the prometheus scraping.
+func listenAndServe(name string, port int, handler http.Handler) {
+	go func() {
+		if err := newServer(port, handler).ListenAndServe(); err != nil {
+			log.Error(err, "failed to listen", "listener", name, "port", port)
+			os.Exit(1)
+		}
+	}()
+}
+
+// loggerOptions returns the options of the logger of the router for the log level and format flags
+func loggerOptions(level string, format string) ([]zap.Opts, error) {
+	var opts []zap.Opts
+	if level != "" {
+		zapLevel, err := zapcore.ParseLevel(level)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, zap.Level(zapLevel))
+	}
+	switch v1alpha1.SidecarLogFormat(format) {
+	case "":
+	case v1alpha1.SidecarLogFormatJSON:
+		opts = append(opts, zap.JSONEncoder())
+	case v1alpha1.SidecarLogFormatText:
+		opts = append(opts, zap.ConsoleEncoder())
+	default:
+		return nil, fmt.Errorf("unsupported log format %q", format)
+	}
+	return opts, nil
+}
+
 func main() {
 	flag.Parse()
-	logf.SetLogger(zap.New())
+	loggerOpts, err := loggerOptions(*logLevel, *logFormat)
+	logf.SetLogger(zap.New(loggerOpts...))
+	if err != nil {
+		log.Error(err, "invalid logging flags")
+		os.Exit(1)
+	}
 	if headersToPropagateEnvVar, ok := os.LookupEnv(constants.RouterHeadersPropagateEnvVar); ok {
 		var err error
 		log.Info("The headers that will match these patterns will be propagated by the router to all the steps",
@@ -352,26 +794,87 @@ func main() {
 			log.Error(err, "Failed to compile some header patterns")
 		}
 	}
-	inferenceGraph = &v1alpha1.InferenceGraphSpec{}
-	err := json.Unmarshal([]byte(*jsonGraph), inferenceGraph)
-	if err != nil {
-		log.Error(err, "failed to unmarshall inference graph json")
-		os.Exit(1)
+	if *graphFile != "" {
+		reloader := &graphReloader{file: *graphFile, pluginDir: *pluginDir}
+		if err = reloader.reload(); err != nil {
+			log.Error(err, "failed to load the graph document", "file", *graphFile)
+			os.Exit(1)
+		}
+		if err = reloader.watch(); err != nil {
+			log.Error(err, "failed to watch the graph document", "file", *graphFile)
+			os.Exit(1)
+		}
+	} else {
+		graph, err := routerapi.Decode([]byte(*jsonGraph))
+		if err != nil {
+			log.Error(err, "failed to decode the graph document")
+			os.Exit(1)
+		}
+		inferenceGraph = &graph.InferenceGraphSpec
+		if nodePlugins, err = loadPlugins(inferenceGraph, *pluginDir); err != nil {
+			log.Error(err, "failed to load the plugins of the graph")
+			os.Exit(1)
+		}
+	}
+	if *traceConfig != "" {
+		graphTraceConfig = &v1alpha1.GraphTraceConfig{}
+		if err = json.Unmarshal([]byte(*traceConfig), graphTraceConfig); err != nil {
+			log.Error(err, "failed to unmarshall the trace config")
+			os.Exit(1)
+		}
+		if graphTraceStore, err = newTraceStore(graphTraceConfig); err != nil {
+			log.Error(err, "failed to create the trace store", "store", graphTraceConfig.Store)
+			os.Exit(1)
+		}
 	}
 
-	http.HandleFunc("/", graphHandler)
+	var handler http.Handler = http.HandlerFunc(graphHandler)
+	if spec := inferenceGraph.Quota; spec != nil {
+		if handler, err = newQuotaHandler(spec, handler); err != nil {
+			log.Error(err, "failed to create the quota handler")
+			os.Exit(1)
+		}
+	}
+	if spec := inferenceGraph.LoadShedding; spec != nil && spec.MaxInFlightRequests != nil {
+		handler = newLoadSheddingHandler(*spec.MaxInFlightRequests, handler)
+	}
+	if inferenceGraph.CORS != nil {
+		handler = cors.NewHandler(inferenceGraph.CORS.Config(), handler)
+	}
+	if *metricsPort != 0 {
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+			requestCount, requestDuration, shedRequests, targetEjections)
+		quota.RegisterMetrics(registry)
+		handler = promhttp.InstrumentHandlerDuration(requestDuration, promhttp.InstrumentHandlerCounter(requestCount, handler))
+		metricsMux := http.NewServeMux()
+		metricsMux.Handle(constants.DefaultPrometheusPath, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+		listenAndServe("metrics", *metricsPort, metricsMux)
+	}
 
-	server := &http.Server{
-		Addr:         ":8080",                        // specify the address and port
-		Handler:      http.HandlerFunc(graphHandler), // specify your HTTP handler
-		ReadTimeout:  time.Minute,                    // set the maximum duration for reading the entire request, including the body
-		WriteTimeout: time.Minute,                    // set the maximum duration before timing out writes of the response
-		IdleTimeout:  3 * time.Minute,                // set the maximum amount of time to wait for the next request when keep-alives are enabled
+	mux := http.NewServeMux()
+	if *healthPort != 0 {
+		healthMux := http.NewServeMux()
+		healthMux.HandleFunc(constants.RouterHealthPath, healthHandler)
+		listenAndServe("health", *healthPort, healthMux)
+	} else {
+		mux.HandleFunc(constants.RouterHealthPath, healthHandler)
+	}
+	mux.Handle("/", handler)
+
+	server := newServer(*port, mux)
+	if *enableTLS {
+		server.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		if *fipsMode {
+			utils.ApplyFIPSTLSConfig(server.TLSConfig)
+		}
+		err = server.ListenAndServeTLS(*tlsCertFile, *tlsKeyFile)
+	} else {
+		err = server.ListenAndServe()
 	}
-	err = server.ListenAndServe()
 
 	if err != nil {
-		log.Error(err, "failed to listen on 8080")
+		log.Error(err, "failed to listen", "port", *port)
 		os.Exit(1)
 	}
 }
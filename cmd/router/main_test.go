@@ -17,9 +17,12 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/routerplugin"
 	"github.com/stretchr/testify/assert"
 	"io"
 	"knative.dev/pkg/apis"
@@ -99,7 +102,7 @@ func TestSimpleModelChainer(t *testing.T) {
 		"Authorization": {"Bearer Token"},
 	}
 
-	res, _, err := routeStep("root", graphSpec, jsonBytes, headers)
+	res, _, err := routeStep("root", graphSpec, jsonBytes, headers, newGraphLimiter(0, 0))
 	var response map[string]interface{}
 	err = json.Unmarshal(res, &response)
 	expectedResponse := map[string]interface{}{
@@ -171,7 +174,7 @@ func TestSimpleModelEnsemble(t *testing.T) {
 	headers := http.Header{
 		"Authorization": {"Bearer Token"},
 	}
-	res, _, err := routeStep("root", graphSpec, jsonBytes, headers)
+	res, _, err := routeStep("root", graphSpec, jsonBytes, headers, newGraphLimiter(0, 0))
 	var response map[string]interface{}
 	err = json.Unmarshal(res, &response)
 	expectedResponse := map[string]interface{}{
@@ -350,7 +353,7 @@ func TestInferenceGraphWithCondition(t *testing.T) {
 	headers := http.Header{
 		"Authorization": {"Bearer Token"},
 	}
-	res, _, err := routeStep("root", graphSpec, jsonBytes, headers)
+	res, _, err := routeStep("root", graphSpec, jsonBytes, headers, newGraphLimiter(0, 0))
 	var response map[string]interface{}
 	err = json.Unmarshal(res, &response)
 	expectedModel3Response := map[string]interface{}{
@@ -420,7 +423,7 @@ func TestCallServiceWhenNoneHeadersToPropagateIsEmpty(t *testing.T) {
 	}
 	// Propagating no header
 	compiledHeaderPatterns = []*regexp.Regexp{}
-	res, _, err := callService(model1Url.String(), jsonBytes, headers)
+	res, _, err := callService(model1Url.String(), jsonBytes, headers, nil)
 	var response map[string]interface{}
 	err = json.Unmarshal(res, &response)
 	expectedResponse := map[string]interface{}{
@@ -474,7 +477,7 @@ func TestCallServiceWhen1HeaderToPropagate(t *testing.T) {
 	compiledHeaderPatterns, err = compilePatterns(headersToPropagate)
 	assert.Nil(t, err)
 
-	res, _, err := callService(model1Url.String(), jsonBytes, headers)
+	res, _, err := callService(model1Url.String(), jsonBytes, headers, nil)
 	var response map[string]interface{}
 	err = json.Unmarshal(res, &response)
 	expectedResponse := map[string]interface{}{
@@ -529,7 +532,7 @@ func TestCallServiceWhenMultipleHeadersToPropagate(t *testing.T) {
 	compiledHeaderPatterns, err = compilePatterns(headersToPropagate)
 	assert.Nil(t, err)
 
-	res, _, err := callService(model1Url.String(), jsonBytes, headers)
+	res, _, err := callService(model1Url.String(), jsonBytes, headers, nil)
 	var response map[string]interface{}
 	err = json.Unmarshal(res, &response)
 	expectedResponse := map[string]interface{}{
@@ -543,7 +546,7 @@ func TestCallServiceWhenMultipleHeadersToPropagate(t *testing.T) {
 
 func TestMalformedURL(t *testing.T) {
 	malformedURL := "http://single-1.default.{$your-domain}/switch"
-	_, response, err := callService(malformedURL, []byte{}, http.Header{})
+	_, response, err := callService(malformedURL, []byte{}, http.Header{}, nil)
 	if err != nil {
 		assert.Equal(t, 500, response)
 	}
@@ -595,7 +598,7 @@ func TestCallServiceWhenMultipleHeadersToPropagateUsingPatterns(t *testing.T) {
 	compiledHeaderPatterns, err = compilePatterns(headersToPropagate)
 	assert.Nil(t, err)
 
-	res, _, err := callService(model1Url.String(), jsonBytes, headers)
+	res, _, err := callService(model1Url.String(), jsonBytes, headers, nil)
 	var response map[string]interface{}
 	err = json.Unmarshal(res, &response)
 	expectedResponse := map[string]interface{}{
@@ -655,7 +658,7 @@ func TestCallServiceWhenMultipleHeadersToPropagateUsingInvalidPattern(t *testing
 	compiledHeaderPatterns, err = compilePatterns(headersToPropagate)
 	assert.NotNil(t, err)
 
-	res, _, err := callService(model1Url.String(), jsonBytes, headers)
+	res, _, err := callService(model1Url.String(), jsonBytes, headers, nil)
 	var response map[string]interface{}
 	err = json.Unmarshal(res, &response)
 	// Invalid pattern should be ignored.
@@ -666,3 +669,351 @@ func TestCallServiceWhenMultipleHeadersToPropagateUsingInvalidPattern(t *testing
 	fmt.Printf("final response:%v\n", response)
 	assert.Equal(t, expectedResponse, response)
 }
+
+func TestHealthHandler(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	healthHandler(recorder, httptest.NewRequest(http.MethodGet, constants.RouterHealthPath, nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "ok", recorder.Body.String())
+}
+
+func TestGraphLimits(t *testing.T) {
+	model := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"predictions": "1"}`))
+	}))
+	defer model.Close()
+	modelStep := v1alpha1.InferenceStep{InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL}}
+
+	graphSpec := v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"root": {
+				RouterType: v1alpha1.Sequence,
+				Steps: []v1alpha1.InferenceStep{
+					{InferenceTarget: v1alpha1.InferenceTarget{NodeName: "ensemble"}},
+				},
+			},
+			"ensemble": {
+				RouterType: v1alpha1.Ensemble,
+				Steps:      []v1alpha1.InferenceStep{modelStep, modelStep, modelStep},
+			},
+		},
+	}
+	headers := http.Header{}
+
+	_, statusCode, err := routeStep("root", graphSpec, []byte("{}"), headers, newGraphLimiter(2, 3))
+	assert.Nil(t, err)
+	assert.Equal(t, 200, statusCode)
+
+	_, statusCode, err = routeStep("root", graphSpec, []byte("{}"), headers, newGraphLimiter(1, 0))
+	assert.EqualError(t, err, `visiting node "ensemble" exceeds the limit of 1 nodes visited per request`)
+	assert.Equal(t, 500, statusCode)
+
+	_, statusCode, err = routeStep("root", graphSpec, []byte("{}"), headers, newGraphLimiter(0, 2))
+	assert.EqualError(t, err, `executing the 3 steps of node "ensemble" in parallel exceeds the limit of 2 parallel steps per request`)
+	assert.Equal(t, 500, statusCode)
+}
+
+func TestSequenceWithSkippedSteps(t *testing.T) {
+	newModel := func(label string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			_, _ = rw.Write([]byte(fmt.Sprintf(`{"predictions": [{"label": "%s"}]}`, label)))
+		}))
+	}
+	classifier := newModel("dog")
+	defer classifier.Close()
+	catModel := newModel("persian")
+	defer catModel.Close()
+	dogModel := newModel("beagle")
+	defer dogModel.Close()
+	auditModel := newModel("audited")
+	defer auditModel.Close()
+
+	graphSpec := v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"root": {
+				RouterType: v1alpha1.Sequence,
+				Steps: []v1alpha1.InferenceStep{
+					{
+						StepName:          "audit",
+						InferenceTarget:   v1alpha1.InferenceTarget{ServiceURL: auditModel.URL},
+						Condition:         "$request.audit",
+						OnConditionNotMet: v1alpha1.Skip,
+					},
+					{
+						StepName:        "classifier",
+						InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: classifier.URL},
+					},
+					{
+						StepName:          "cat",
+						InferenceTarget:   v1alpha1.InferenceTarget{ServiceURL: catModel.URL},
+						Condition:         "predictions.#(label==\"cat\")",
+						OnConditionNotMet: v1alpha1.Skip,
+					},
+					{
+						StepName:          "dog",
+						InferenceTarget:   v1alpha1.InferenceTarget{ServiceURL: dogModel.URL},
+						Data:              "$response",
+						Condition:         "$steps.classifier.predictions.#(label==\"dog\")",
+						OnConditionNotMet: v1alpha1.Skip,
+					},
+				},
+			},
+		},
+	}
+	res, statusCode, err := routeStep("root", graphSpec, []byte(`{"instances": []}`), http.Header{}, newGraphLimiter(0, 0))
+	assert.Nil(t, err)
+	assert.Equal(t, 200, statusCode)
+	assert.JSONEq(t, `{"predictions": [{"label": "beagle"}]}`, string(res))
+
+	// the condition of a step referencing a skipped step does not match
+	matched, err := matchCondition("$steps.audit.predictions", nil, nil, map[string][]byte{})
+	assert.Nil(t, err)
+	assert.False(t, matched)
+	_, err = matchCondition("$steps.audit", nil, nil, map[string][]byte{})
+	assert.NotNil(t, err)
+}
+
+func TestResponseAggregation(t *testing.T) {
+	newModel := func(statusCode int, prediction string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			rw.WriteHeader(statusCode)
+			_, _ = rw.Write([]byte(fmt.Sprintf(`{"predictions": "%s"}`, prediction)))
+		}))
+	}
+	model1 := newModel(200, "1")
+	defer model1.Close()
+	model2 := newModel(200, "2")
+	defer model2.Close()
+	failingModel := newModel(503, "unavailable")
+	defer failingModel.Close()
+	fullWeight := int64(100)
+
+	scenarios := map[string]struct {
+		node             v1alpha1.InferenceRouter
+		expectedCode     int
+		expectedResponse string
+	}{
+		"ensemble keyed by default": {
+			node: v1alpha1.InferenceRouter{
+				RouterType: v1alpha1.Ensemble,
+				Steps: []v1alpha1.InferenceStep{
+					{StepName: "model1", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model1.URL}},
+					{InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model2.URL}},
+				},
+			},
+			expectedCode:     200,
+			expectedResponse: `{"model1": {"predictions": "1"}, "1": {"predictions": "2"}}`,
+		},
+		"ensemble array": {
+			node: v1alpha1.InferenceRouter{
+				RouterType:          v1alpha1.Ensemble,
+				ResponseAggregation: v1alpha1.Array,
+				Steps: []v1alpha1.InferenceStep{
+					{StepName: "model2", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model2.URL}},
+					{StepName: "model1", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model1.URL}},
+				},
+			},
+			expectedCode:     200,
+			expectedResponse: `[{"predictions": "2"}, {"predictions": "1"}]`,
+		},
+		"ensemble first success": {
+			node: v1alpha1.InferenceRouter{
+				RouterType:          v1alpha1.Ensemble,
+				ResponseAggregation: v1alpha1.FirstSuccess,
+				Steps: []v1alpha1.InferenceStep{
+					{StepName: "failing", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: failingModel.URL}},
+					{StepName: "model1", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model1.URL}},
+				},
+			},
+			expectedCode:     200,
+			expectedResponse: `{"predictions": "1"}`,
+		},
+		"ensemble first success without successful step": {
+			node: v1alpha1.InferenceRouter{
+				RouterType:          v1alpha1.Ensemble,
+				ResponseAggregation: v1alpha1.FirstSuccess,
+				Steps: []v1alpha1.InferenceStep{
+					{StepName: "failing", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: failingModel.URL}},
+				},
+			},
+			expectedCode:     503,
+			expectedResponse: `{"predictions": "unavailable"}`,
+		},
+		"splitter returns the response as is by default": {
+			node: v1alpha1.InferenceRouter{
+				RouterType: v1alpha1.Splitter,
+				Steps: []v1alpha1.InferenceStep{
+					{StepName: "model1", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model1.URL}, Weight: &fullWeight},
+				},
+			},
+			expectedCode:     200,
+			expectedResponse: `{"predictions": "1"}`,
+		},
+		"splitter keyed": {
+			node: v1alpha1.InferenceRouter{
+				RouterType:          v1alpha1.Splitter,
+				ResponseAggregation: v1alpha1.Keyed,
+				Steps: []v1alpha1.InferenceStep{
+					{StepName: "model1", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model1.URL}, Weight: &fullWeight},
+				},
+			},
+			expectedCode:     200,
+			expectedResponse: `{"model1": {"predictions": "1"}}`,
+		},
+		"splitter array": {
+			node: v1alpha1.InferenceRouter{
+				RouterType:          v1alpha1.Splitter,
+				ResponseAggregation: v1alpha1.Array,
+				Steps: []v1alpha1.InferenceStep{
+					{StepName: "model1", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model1.URL}, Weight: &fullWeight},
+				},
+			},
+			expectedCode:     200,
+			expectedResponse: `[{"predictions": "1"}]`,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			graphSpec := v1alpha1.InferenceGraphSpec{
+				Nodes: map[string]v1alpha1.InferenceRouter{"root": scenario.node},
+			}
+			res, statusCode, err := routeStep("root", graphSpec, []byte(`{"instances": []}`), http.Header{}, newGraphLimiter(0, 0))
+			assert.Nil(t, err)
+			assert.Equal(t, scenario.expectedCode, statusCode)
+			assert.JSONEq(t, scenario.expectedResponse, string(res))
+		})
+	}
+}
+
+func TestCallServiceWithStepHeaders(t *testing.T) {
+	model1 := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		// Putting headers as part of response so that we can assert the headers' presence later
+		response := map[string]interface{}{}
+		for h, values := range req.Header {
+			if h != "Content-Type" && h != "Content-Length" && h != "User-Agent" && h != "Accept-Encoding" {
+				response[h] = values[0]
+			}
+		}
+		responseBytes, _ := json.Marshal(response)
+		_, _ = rw.Write(responseBytes)
+	}))
+	defer model1.Close()
+
+	t.Setenv("MODEL_API_KEY", "secret-key")
+	var err error
+	compiledHeaderPatterns, err = compilePatterns([]string{"Test-Header-Key", "Model-Version", "Authorization"})
+	assert.Nil(t, err)
+	headers := http.Header{
+		"Authorization":   {"Bearer Token"},
+		"Test-Header-Key": {"Test-Header-Value"},
+		"Model-Version":   {"1"},
+	}
+	step := &v1alpha1.InferenceStep{
+		Headers: []v1alpha1.StepHeader{
+			{Name: "Model-Version", Value: "2"},
+			{Name: "X-Api-Key", Value: "$(MODEL_API_KEY)"},
+			{Name: "X-Unresolved", Value: "$(UNDEFINED_ENV_VAR)"},
+		},
+		RemoveHeaders: []string{"Authorization"},
+	}
+	res, _, err := callService(model1.URL, []byte(`{"instances": []}`), headers, step)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"Test-Header-Key": "Test-Header-Value",
+		"Model-Version": "2",
+		"X-Api-Key": "secret-key",
+		"X-Unresolved": "$(UNDEFINED_ENV_VAR)"
+	}`, string(res))
+}
+
+// tenantPlugin rejects the requests without tenant header and wraps the response of the node
+type tenantPlugin struct {
+	field string
+}
+
+func (p *tenantPlugin) ProcessRequest(_ context.Context, req *routerplugin.Request) error {
+	tenant := req.Headers.Get("X-Tenant")
+	if tenant == "" {
+		return routerplugin.NewError(http.StatusUnauthorized, "missing tenant of node %s", req.Node)
+	}
+	req.Headers.Set("X-Tenant-Id", "tenant-"+tenant)
+	return nil
+}
+
+func (p *tenantPlugin) ProcessResponse(_ context.Context, resp *routerplugin.Response) error {
+	body, err := json.Marshal(map[string]json.RawMessage{p.field: resp.Body})
+	if err != nil {
+		return err
+	}
+	resp.Body = body
+	return nil
+}
+
+func TestNodePlugins(t *testing.T) {
+	model := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		response := map[string]interface{}{"predictions": "1", "tenant": req.Header.Get("X-Tenant-Id")}
+		responseBytes, _ := json.Marshal(response)
+		_, _ = rw.Write(responseBytes)
+	}))
+	defer model.Close()
+	routerplugin.Register("tenant", func(config map[string]string) (routerplugin.Plugin, error) {
+		if config["field"] == "" {
+			return nil, fmt.Errorf("field is not configured")
+		}
+		return &tenantPlugin{field: config["field"]}, nil
+	})
+	graphSpec := v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"root": {
+				RouterType: v1alpha1.Sequence,
+				Steps: []v1alpha1.InferenceStep{
+					{InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL}},
+				},
+				Plugins: []v1alpha1.NodePlugin{{Name: "tenant", Config: map[string]string{"field": "result"}}},
+			},
+		},
+	}
+	var err error
+	nodePlugins, err = loadPlugins(&graphSpec, t.TempDir())
+	assert.NoError(t, err)
+	defer func() { nodePlugins = nil }()
+	compiledHeaderPatterns, err = compilePatterns([]string{"X-Tenant-Id"})
+	assert.NoError(t, err)
+	defer func() { compiledHeaderPatterns = nil }()
+
+	headers := http.Header{"X-Tenant": {"a"}}
+	res, statusCode, err := routeStep("root", graphSpec, []byte(`{"instances":[1]}`), headers, newGraphLimiter(0, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, statusCode)
+	assert.JSONEq(t, `{"result":{"predictions":"1","tenant":"tenant-a"}}`, string(res))
+	// the changes of the plugins to the headers are not seen by the caller
+	assert.Equal(t, "", headers.Get("X-Tenant-Id"))
+
+	_, statusCode, err = routeStep("root", graphSpec, []byte(`{"instances":[1]}`), http.Header{}, newGraphLimiter(0, 0))
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, statusCode)
+
+	// the errors of the plugin factory and of the Go plugins which are not found are reported at startup
+	graphSpec.Nodes["root"].Plugins[0].Config = nil
+	_, err = loadPlugins(&graphSpec, t.TempDir())
+	assert.ErrorContains(t, err, "field is not configured")
+	graphSpec.Nodes["root"].Plugins[0].Name = "missing"
+	_, err = loadPlugins(&graphSpec, t.TempDir())
+	assert.ErrorContains(t, err, "missing.so")
+}
+
+func TestLoggerOptions(t *testing.T) {
+	opts, err := loggerOptions("", "")
+	assert.Nil(t, err)
+	assert.Empty(t, opts)
+
+	opts, err = loggerOptions("warn", "text")
+	assert.Nil(t, err)
+	assert.Len(t, opts, 2)
+
+	_, err = loggerOptions("verbose", "")
+	assert.NotNil(t, err)
+
+	_, err = loggerOptions("", "xml")
+	assert.NotNil(t, err)
+}
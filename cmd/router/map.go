@@ -0,0 +1,234 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+const (
+	// defaultMapItemsField is the field of the V1 requests holding the items split by a Map node
+	defaultMapItemsField = "instances"
+	// defaultMapResultsField is the field of the V1 responses holding the result of an item
+	defaultMapResultsField = "predictions"
+)
+
+// mapItemResult is the response of the step of a Map node to the request of an item
+type mapItemResult struct {
+	response   []byte
+	statusCode int
+	err        error
+}
+
+// handleMapNode splits the input into per-item requests to the step of the node, up to parallelism items are sent
+// in parallel. The results are reassembled in the order of the items, the first failing item fails the node.
+func handleMapNode(nodeName string, node v1alpha1.InferenceRouter, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header, limiter *graphLimiter) ([]byte, int, error) {
+	if len(node.Steps) != 1 {
+		return nil, 500, fmt.Errorf("map node %q must have exactly one step", nodeName)
+	}
+	requests, err := splitMapRequest(node.Map, input)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("failed to split the request of map node %q: %w", nodeName, err)
+	}
+	if maxItems := node.Map.GetMaxItems(); len(requests) > maxItems {
+		return nil, http.StatusBadRequest, fmt.Errorf("the request of map node %q has %d items which exceeds the limit of %d",
+			nodeName, len(requests), maxItems)
+	}
+
+	parallelism := min(node.Map.GetParallelism(), len(requests))
+	release, err := limiter.fanOut(nodeName, parallelism)
+	if err != nil {
+		log.Error(err, "graph limit exceeded")
+		return nil, 500, err
+	}
+	defer release()
+
+	results := make([]mapItemResult, len(requests))
+	slots := make(chan struct{}, max(parallelism, 1))
+	var wg sync.WaitGroup
+	for i := range requests {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(index int) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			result := &results[index]
+			result.response, result.statusCode, result.err = executeStep(&node.Steps[0], graph, requests[index], headers, limiter)
+		}(i)
+	}
+	wg.Wait()
+
+	responses := make([][]byte, len(results))
+	for i, result := range results {
+		if result.err != nil {
+			return nil, result.statusCode, fmt.Errorf("item %d of map node %q failed: %w", i, nodeName, result.err)
+		}
+		if !isSuccessFul(result.statusCode) {
+			log.Info("map item failed", "nodeName", nodeName, "item", i, "statusCode", result.statusCode)
+			return result.response, result.statusCode, nil
+		}
+		responses[i] = result.response
+	}
+	response, err := mergeMapResponses(node.Map, responses)
+	if err != nil {
+		return nil, http.StatusBadGateway, fmt.Errorf("failed to merge the responses of map node %q: %w", nodeName, err)
+	}
+	return response, 200, nil
+}
+
+// splitMapRequest returns the request of each item of the input
+func splitMapRequest(spec *v1alpha1.MapRouterSpec, input []byte) ([][]byte, error) {
+	var request map[string]interface{}
+	if err := json.Unmarshal(input, &request); err != nil {
+		return nil, err
+	}
+	if spec != nil && spec.Format == v1alpha1.ProtocolFormatV2 {
+		return splitV2Request(request)
+	}
+
+	field := defaultMapItemsField
+	if spec != nil && spec.ItemsField != "" {
+		field = spec.ItemsField
+	}
+	items, ok := request[field].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("the request has no %q array", field)
+	}
+	requests := make([][]byte, len(items))
+	for i, item := range items {
+		request[field] = []interface{}{item}
+		itemRequest, err := json.Marshal(request)
+		if err != nil {
+			return nil, err
+		}
+		requests[i] = itemRequest
+	}
+	return requests, nil
+}
+
+// splitV2Request splits the input tensors of the request along their first dimension, which must be the same for
+// all the tensors
+func splitV2Request(request map[string]interface{}) ([][]byte, error) {
+	inputsJSON, err := json.Marshal(request["inputs"])
+	if err != nil {
+		return nil, err
+	}
+	var inputs []v2Tensor
+	if err := json.Unmarshal(inputsJSON, &inputs); err != nil || len(inputs) == 0 {
+		return nil, fmt.Errorf("the request has no input tensors")
+	}
+	items := make([][]interface{}, len(inputs))
+	for i, input := range inputs {
+		if items[i], err = tensorToInstances(input); err != nil {
+			return nil, err
+		}
+		if len(items[i]) != len(items[0]) {
+			return nil, fmt.Errorf("the first dimension of the input tensors %q and %q differ", inputs[0].Name, input.Name)
+		}
+	}
+	requests := make([][]byte, len(items[0]))
+	for item := range requests {
+		itemInputs := make([]v2Tensor, len(inputs))
+		for i, input := range inputs {
+			if itemInputs[i], err = instancesToTensor(input.Name, input.Datatype, items[i][item:item+1]); err != nil {
+				return nil, err
+			}
+		}
+		request["inputs"] = itemInputs
+		if requests[item], err = json.Marshal(request); err != nil {
+			return nil, err
+		}
+	}
+	return requests, nil
+}
+
+// mergeMapResponses reassembles the responses of the items in their order
+func mergeMapResponses(spec *v1alpha1.MapRouterSpec, responses [][]byte) ([]byte, error) {
+	if spec != nil && spec.Format == v1alpha1.ProtocolFormatV2 {
+		return mergeV2Responses(responses)
+	}
+
+	field := defaultMapResultsField
+	if spec != nil && spec.ResultsField != "" {
+		field = spec.ResultsField
+	}
+	// the other fields of the response are taken from the response of the first item
+	merged := map[string]interface{}{}
+	results := make([]interface{}, len(responses))
+	for i, response := range responses {
+		var itemResponse map[string]interface{}
+		if err := json.Unmarshal(response, &itemResponse); err != nil {
+			return nil, err
+		}
+		result, ok := itemResponse[field]
+		if !ok {
+			return nil, fmt.Errorf("the response of item %d has no %q field", i, field)
+		}
+		if values, ok := result.([]interface{}); ok && len(values) == 1 {
+			result = values[0]
+		}
+		results[i] = result
+		if i == 0 {
+			merged = itemResponse
+		}
+	}
+	merged[field] = results
+	return json.Marshal(merged)
+}
+
+// mergeV2Responses concatenates the output tensors of the responses along their first dimension
+func mergeV2Responses(responses [][]byte) ([]byte, error) {
+	merged := &v2Response{Outputs: []v2Tensor{}}
+	var outputs [][]interface{}
+	for i, response := range responses {
+		itemResponse := &v2Response{}
+		if err := json.Unmarshal(response, itemResponse); err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			merged.ModelName = itemResponse.ModelName
+			merged.Outputs = itemResponse.Outputs
+			outputs = make([][]interface{}, len(itemResponse.Outputs))
+		}
+		if len(itemResponse.Outputs) != len(merged.Outputs) {
+			return nil, fmt.Errorf("the response of item %d has %d output tensors instead of %d", i,
+				len(itemResponse.Outputs), len(merged.Outputs))
+		}
+		for j, output := range itemResponse.Outputs {
+			instances, err := tensorToInstances(output)
+			if err != nil {
+				return nil, err
+			}
+			outputs[j] = append(outputs[j], instances...)
+		}
+	}
+	for j, output := range merged.Outputs {
+		tensor, err := instancesToTensor(output.Name, output.Datatype, outputs[j])
+		if err != nil {
+			return nil, err
+		}
+		merged.Outputs[j] = tensor
+	}
+	return json.Marshal(merged)
+}
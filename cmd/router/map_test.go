@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapNode(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	// the model only accepts a single instance and doubles it
+	model := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			observed := maxInFlight.Load()
+			if current <= observed || maxInFlight.CompareAndSwap(observed, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		var request struct {
+			Instances  []float64 `json:"instances"`
+			Parameters string    `json:"parameters"`
+		}
+		body, _ := io.ReadAll(req.Body)
+		if err := json.Unmarshal(body, &request); err != nil || len(request.Instances) != 1 {
+			http.Error(rw, `{"error":"a single instance is accepted"}`, http.StatusBadRequest)
+			return
+		}
+		if request.Instances[0] < 0 {
+			http.Error(rw, `{"error":"negative instance"}`, http.StatusUnprocessableEntity)
+			return
+		}
+		response, _ := json.Marshal(map[string]interface{}{
+			"predictions": []float64{request.Instances[0] * 2},
+			"parameters":  request.Parameters,
+		})
+		_, _ = rw.Write(response)
+	}))
+	defer model.Close()
+
+	parallelism, maxItems := 2, 5
+	graphSpec := v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"root": {
+				RouterType: v1alpha1.Map,
+				Steps: []v1alpha1.InferenceStep{
+					{InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL}},
+				},
+				Map: &v1alpha1.MapRouterSpec{Parallelism: &parallelism, MaxItems: &maxItems},
+			},
+		},
+	}
+
+	res, statusCode, err := routeStep("root", graphSpec, []byte(`{"instances":[1,2,3,4,5],"parameters":"p"}`),
+		http.Header{}, newGraphLimiter(0, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.JSONEq(t, `{"predictions":[2,4,6,8,10],"parameters":"p"}`, string(res))
+	assert.Equal(t, int32(2), maxInFlight.Load())
+
+	// the first failing item fails the node
+	res, statusCode, err = routeStep("root", graphSpec, []byte(`{"instances":[1,-2,3]}`), http.Header{},
+		newGraphLimiter(0, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, statusCode)
+	assert.Contains(t, string(res), "negative instance")
+
+	_, statusCode, err = routeStep("root", graphSpec, []byte(`{"instances":[1,2,3,4,5,6]}`), http.Header{},
+		newGraphLimiter(0, 0))
+	assert.ErrorContains(t, err, "exceeds the limit of 5")
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+
+	_, statusCode, err = routeStep("root", graphSpec, []byte(`{"inputs":[1]}`), http.Header{}, newGraphLimiter(0, 0))
+	assert.ErrorContains(t, err, `no "instances" array`)
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+
+	// the items in flight count towards the fan out limit of the request
+	_, _, err = routeStep("root", graphSpec, []byte(`{"instances":[1,2]}`), http.Header{}, newGraphLimiter(0, 1))
+	assert.ErrorContains(t, err, "exceeds the limit of 1 parallel steps")
+}
+
+func TestMapNodeV2(t *testing.T) {
+	model := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		request := &v2Request{}
+		body, _ := io.ReadAll(req.Body)
+		if err := json.Unmarshal(body, request); err != nil || request.Inputs[0].Shape[0] != 1 {
+			http.Error(rw, `{"error":"a single item is accepted"}`, http.StatusBadRequest)
+			return
+		}
+		features := request.Inputs[0].Data
+		response, _ := json.Marshal(&v2Response{ModelName: "sklearn", Outputs: []v2Tensor{
+			{Name: "predict", Shape: []int{1}, Datatype: "FP32", Data: []interface{}{features[0].(float64) + features[1].(float64)}},
+		}})
+		_, _ = rw.Write(response)
+	}))
+	defer model.Close()
+
+	graphSpec := v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"root": {
+				RouterType: v1alpha1.Map,
+				Steps: []v1alpha1.InferenceStep{
+					{InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL}},
+				},
+				Map: &v1alpha1.MapRouterSpec{Format: v1alpha1.ProtocolFormatV2},
+			},
+		},
+	}
+	res, statusCode, err := routeStep("root", graphSpec,
+		[]byte(`{"inputs":[{"name":"input-0","shape":[3,2],"datatype":"FP32","data":[1,2,3,4,5,6]}]}`),
+		http.Header{}, newGraphLimiter(0, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.JSONEq(t, `{"model_name":"sklearn","outputs":[{"name":"predict","shape":[3],"datatype":"FP32","data":[3,7,11]}]}`,
+		string(res))
+
+	_, statusCode, err = routeStep("root", graphSpec,
+		[]byte(`{"inputs":[{"name":"a","shape":[2],"datatype":"FP32","data":[1,2]},`+
+			`{"name":"b","shape":[3],"datatype":"FP32","data":[1,2,3]}]}`),
+		http.Header{}, newGraphLimiter(0, 0))
+	assert.ErrorContains(t, err, "first dimension")
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+}
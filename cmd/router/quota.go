@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/middleware"
+	"github.com/kserve/kserve/pkg/quota"
+)
+
+// newQuotaHandler enforces the quotas of the graph, the tenant of a request is the user its bearer token is
+// authenticated as with a TokenReview
+func newQuotaHandler(spec *v1alpha1.QuotaSpec, next http.Handler) (http.Handler, error) {
+	restConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, err
+	}
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	handler := quota.NewHandler(quota.Config{
+		Period:       spec.Period.Duration,
+		DefaultLimit: spec.DefaultLimit,
+		Tenants:      spec.Tenants,
+	}, next)
+	return newAuthenticationHandler(middleware.NewTokenAuthenticator(clientset, spec.Audiences), handler), nil
+}
+
+// newAuthenticationHandler authenticates the bearer tokens of the requests and passes the user of the token to the
+// next handler. The requests without a token are passed unauthenticated, the requests with a token which is not
+// authenticated are rejected with 401 Unauthorized.
+func newAuthenticationHandler(reviewer *middleware.TokenReviewer, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			next.ServeHTTP(w, req)
+			return
+		}
+		user, err := reviewer.Review(req.Context(), token)
+		if errors.Is(err, middleware.ErrTokenInvalid) {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if err != nil {
+			log.Error(err, "Failed to review the token of the request")
+			http.Error(w, "failed to review the token of the request", http.StatusBadGateway)
+			return
+		}
+		next.ServeHTTP(w, req.WithContext(middleware.ContextWithUser(req.Context(), user)))
+	})
+}
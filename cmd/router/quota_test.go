@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kserve/kserve/pkg/middleware"
+	"github.com/kserve/kserve/pkg/quota"
+)
+
+func TestAuthenticatedQuotas(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		assert.Equal(t, []string{"llm-graph"}, review.Spec.Audiences)
+		if review.Spec.Token == "team-a-token" {
+			review.Status = authenticationv1.TokenReviewStatus{Authenticated: true,
+				User: authenticationv1.UserInfo{Username: "system:serviceaccount:team-a:client"}}
+		}
+		return true, review, nil
+	})
+	graph := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {})
+	limit := int64(1)
+	handler := newAuthenticationHandler(middleware.NewTokenAuthenticator(client, []string{"llm-graph"}),
+		quota.NewHandler(quota.Config{
+			Period:       time.Hour,
+			DefaultLimit: &limit,
+			Tenants:      map[string]int64{"system:serviceaccount:team-a:client": 2},
+		}, graph))
+	send := func(token string) int {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, req)
+		return rw.Code
+	}
+
+	assert.Equal(t, http.StatusOK, send("team-a-token"))
+	assert.Equal(t, http.StatusOK, send("team-a-token"))
+	assert.Equal(t, http.StatusTooManyRequests, send("team-a-token"))
+	assert.Equal(t, http.StatusUnauthorized, send("forged-token"))
+	// the requests without a token share the default quota
+	assert.Equal(t, http.StatusOK, send(""))
+	assert.Equal(t, http.StatusTooManyRequests, send(""))
+}
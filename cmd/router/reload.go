@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	routerapi "github.com/kserve/kserve/pkg/router/api"
+	"github.com/kserve/kserve/pkg/routerplugin"
+)
+
+// graphLock guards the graph and the plugins of its nodes, they are swapped when the graph file is reloaded
+var graphLock sync.RWMutex
+
+// routedGraph returns the graph the requests are routed through
+func routedGraph() v1alpha1.InferenceGraphSpec {
+	graphLock.RLock()
+	defer graphLock.RUnlock()
+	return *inferenceGraph
+}
+
+// pluginsOf returns the plugins of the node
+func pluginsOf(nodeName string) []routerplugin.Plugin {
+	graphLock.RLock()
+	defer graphLock.RUnlock()
+	return nodePlugins[nodeName]
+}
+
+// graphReloader reloads the graph document of a file, the file is usually mounted from a ConfigMap which the
+// controller updates when the graph is edited
+type graphReloader struct {
+	file      string
+	pluginDir string
+	// document is the graph document loaded last, the graph is not reloaded when it does not change
+	document []byte
+}
+
+/*
+Reads the graph document of the file and routes the requests through it, together with the plugins of its nodes. The
+current graph is kept when the document is invalid or its plugins fail to load. The quota, the CORS policy and the
+maximum number of requests in flight of the graph are applied when the router starts only, the controller rolls the
+router pods out when they change.
+*/
+func (r *graphReloader) reload() error {
+	document, err := os.ReadFile(r.file)
+	if err != nil {
+		return err
+	}
+	if r.document != nil && bytes.Equal(document, r.document) {
+		return nil
+	}
+	graph, err := routerapi.Decode(document)
+	if err != nil {
+		return err
+	}
+	plugins, err := loadPlugins(&graph.InferenceGraphSpec, r.pluginDir)
+	if err != nil {
+		return err
+	}
+	graphLock.Lock()
+	inferenceGraph = &graph.InferenceGraphSpec
+	nodePlugins = plugins
+	graphLock.Unlock()
+	if r.document != nil {
+		log.Info("Reloaded the graph document", "file", r.file)
+	}
+	r.document = document
+	return nil
+}
+
+/*
+Watches the directory of the graph file and reloads the graph when the file changes. The kubelet updates the files of
+a ConfigMap volume by swapping the ..data symlink of the volume directory, the files written in place are reloaded
+as well.
+*/
+func (r *graphReloader) watch() error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err := watcher.Add(filepath.Dir(r.file)); err != nil {
+		watcher.Close()
+		return err
+	}
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if !r.isUpdate(event) {
+					continue
+				}
+				if err := r.reload(); err != nil {
+					log.Error(err, "failed to reload the graph document, the current graph is kept", "file", r.file)
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Error(err, "failed to watch the graph document", "file", r.file)
+			}
+		}
+	}()
+	return nil
+}
+
+// isUpdate returns whether the event updates the graph file
+func (r *graphReloader) isUpdate(event fsnotify.Event) bool {
+	if filepath.Base(event.Name) == "..data" {
+		return event.Op&fsnotify.Create != 0
+	}
+	return filepath.Clean(event.Name) == filepath.Clean(r.file) && event.Op&(fsnotify.Create|fsnotify.Write) != 0
+}
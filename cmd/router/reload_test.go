@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+const (
+	sequenceGraphDocument = `{"version":"v1","nodes":{"root":{"routerType":"Sequence","steps":[{"serviceUrl":"http://model-a"}]}}}`
+	splitterGraphDocument = `{"version":"v1","nodes":{"root":{"routerType":"Splitter","steps":[{"serviceUrl":"http://model-a","weight":90},{"serviceUrl":"http://model-b","weight":10}]}}}`
+)
+
+func TestGraphReload(t *testing.T) {
+	defer func() { inferenceGraph, nodePlugins = nil, nil }()
+	file := filepath.Join(t.TempDir(), "graph.json")
+	assert.NoError(t, os.WriteFile(file, []byte(sequenceGraphDocument), 0o600))
+	reloader := &graphReloader{file: file, pluginDir: t.TempDir()}
+
+	assert.NoError(t, reloader.reload())
+	assert.Equal(t, v1alpha1.Sequence, routedGraph().Nodes[v1alpha1.GraphRootNodeName].RouterType)
+
+	// the graph is swapped when the document changes
+	assert.NoError(t, os.WriteFile(file, []byte(splitterGraphDocument), 0o600))
+	assert.NoError(t, reloader.reload())
+	assert.Equal(t, v1alpha1.Splitter, routedGraph().Nodes[v1alpha1.GraphRootNodeName].RouterType)
+	assert.Len(t, routedGraph().Nodes[v1alpha1.GraphRootNodeName].Steps, 2)
+
+	// the current graph is kept when the document is invalid
+	assert.NoError(t, os.WriteFile(file, []byte(`{"version":"v2"}`), 0o600))
+	assert.Error(t, reloader.reload())
+	assert.Equal(t, v1alpha1.Splitter, routedGraph().Nodes[v1alpha1.GraphRootNodeName].RouterType)
+
+	// the current graph is kept when the plugins of the nodes fail to load
+	assert.NoError(t, os.WriteFile(file, []byte(`{"nodes":{"root":{"routerType":"Sequence","plugins":[{"name":"missing"}]}}}`), 0o600))
+	assert.Error(t, reloader.reload())
+	assert.Equal(t, v1alpha1.Splitter, routedGraph().Nodes[v1alpha1.GraphRootNodeName].RouterType)
+}
+
+func TestGraphWatch(t *testing.T) {
+	defer func() { inferenceGraph, nodePlugins = nil, nil }()
+	// the layout of a ConfigMap volume, the files are symlinks to the ..data symlink of a timestamped directory
+	dir := t.TempDir()
+	writeConfigMapVolume := func(version string, document string) {
+		versionDir := filepath.Join(dir, version)
+		assert.NoError(t, os.Mkdir(versionDir, 0o700))
+		assert.NoError(t, os.WriteFile(filepath.Join(versionDir, "graph.json"), []byte(document), 0o600))
+		assert.NoError(t, os.Symlink(version, filepath.Join(dir, "..data_tmp")))
+		assert.NoError(t, os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")))
+	}
+	writeConfigMapVolume("..v1", sequenceGraphDocument)
+	file := filepath.Join(dir, "graph.json")
+	assert.NoError(t, os.Symlink(filepath.Join("..data", "graph.json"), file))
+
+	reloader := &graphReloader{file: file, pluginDir: t.TempDir()}
+	assert.NoError(t, reloader.reload())
+	assert.NoError(t, reloader.watch())
+	assert.Equal(t, v1alpha1.Sequence, routedGraph().Nodes[v1alpha1.GraphRootNodeName].RouterType)
+
+	writeConfigMapVolume("..v2", splitterGraphDocument)
+	assert.Eventually(t, func() bool {
+		return routedGraph().Nodes[v1alpha1.GraphRootNodeName].RouterType == v1alpha1.Splitter
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+// callServiceWithRetries calls the target service of the step and retries the failed calls up to the retries of the
+// step, the delay between the retries starts at the retry backoff of the step and doubles on each retry
+func callServiceWithRetries(step *v1alpha1.InferenceStep, input []byte, headers http.Header) ([]byte, int, error) {
+	retries := 0
+	if step.Retries != nil {
+		retries = int(*step.Retries)
+	}
+	backoff := v1alpha1.DefaultStepRetryBackoff
+	if step.RetryBackoff != nil {
+		backoff = step.RetryBackoff.Duration
+	}
+	for attempt := 1; ; attempt++ {
+		response, statusCode, err := callService(step.ServiceURL, input, headers, step)
+		if attempt > retries || !isRetryable(statusCode, err) {
+			return response, statusCode, err
+		}
+		log.Info("Retrying the call to the service of the step", "stepName", step.StepName, "attempt", attempt,
+			"statusCode", statusCode, "backoff", backoff)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// isRetryable returns whether a call failed with an error, e.g. a connection error or a timeout, or with the status of
+// an unavailable service
+func isRetryable(statusCode int, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch statusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
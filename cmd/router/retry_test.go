@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+func TestCallServiceWithRetries(t *testing.T) {
+	// newModel returns a model failing the first calls with the status, and the number of calls it received
+	newModel := func(failures int32, status int) (*httptest.Server, *atomic.Int32) {
+		calls := &atomic.Int32{}
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			if calls.Add(1) <= failures {
+				rw.WriteHeader(status)
+				return
+			}
+			_, _ = rw.Write([]byte(`{"predictions": [1]}`))
+		}))
+		return server, calls
+	}
+	retries := func(n int32) *int32 { return &n }
+	backoff := &metav1.Duration{Duration: time.Millisecond}
+
+	scenarios := map[string]struct {
+		failures      int32
+		status        int
+		retries       *int32
+		expectedCalls int32
+		expectedCode  int
+	}{
+		"no retries": {
+			failures: 1, status: http.StatusServiceUnavailable, expectedCalls: 1, expectedCode: http.StatusServiceUnavailable,
+		},
+		"succeeds after retries": {
+			failures: 2, status: http.StatusServiceUnavailable, retries: retries(3), expectedCalls: 3, expectedCode: http.StatusOK,
+		},
+		"retries exhausted": {
+			failures: 5, status: http.StatusBadGateway, retries: retries(2), expectedCalls: 3, expectedCode: http.StatusBadGateway,
+		},
+		"client errors are not retried": {
+			failures: 1, status: http.StatusBadRequest, retries: retries(2), expectedCalls: 1, expectedCode: http.StatusBadRequest,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			model, calls := newModel(scenario.failures, scenario.status)
+			defer model.Close()
+			step := &v1alpha1.InferenceStep{
+				InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL},
+				Retries:         scenario.retries,
+				RetryBackoff:    backoff,
+			}
+			_, statusCode, err := callServiceWithRetries(step, []byte(`{"instances": [1]}`), http.Header{})
+			assert.Nil(t, err)
+			assert.Equal(t, scenario.expectedCode, statusCode)
+			assert.Equal(t, scenario.expectedCalls, calls.Load())
+		})
+	}
+}
+
+func TestCallServiceTimeout(t *testing.T) {
+	calls := &atomic.Int32{}
+	model := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if calls.Add(1) == 1 {
+			time.Sleep(200 * time.Millisecond)
+		}
+		_, _ = rw.Write([]byte(`{"predictions": [1]}`))
+	}))
+	defer model.Close()
+	retries := int32(1)
+	step := &v1alpha1.InferenceStep{
+		InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL},
+		Timeout:         &metav1.Duration{Duration: 50 * time.Millisecond},
+	}
+
+	_, statusCode, err := callServiceWithRetries(step, []byte(`{"instances": [1]}`), http.Header{})
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusGatewayTimeout, statusCode)
+
+	calls.Store(0)
+	step.Retries = &retries
+	step.RetryBackoff = &metav1.Duration{Duration: time.Millisecond}
+	response, statusCode, err := callServiceWithRetries(step, []byte(`{"instances": [1]}`), http.Header{})
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, `{"predictions": [1]}`, string(response))
+	assert.Equal(t, int32(2), calls.Load())
+}
@@ -0,0 +1,393 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	guuid "github.com/google/uuid"
+	v1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+const (
+	// requestIDHeader is the header identifying the request in the traces, it is generated when not set
+	requestIDHeader = "X-Request-Id"
+	// traceStoreTimeout bounds the time persisting a trace takes
+	traceStoreTimeout = 10 * time.Second
+	// traceConfigMapMaxBytes keeps the traces ConfigMap below the 1MiB size limit of the Kubernetes objects
+	traceConfigMapMaxBytes = 900 * 1024
+)
+
+// traceKeyPattern matches the characters of the request IDs which are not allowed in the ConfigMap keys
+var traceKeyPattern = regexp.MustCompile(`[^-._a-zA-Z0-9]`)
+
+// stepTrace is the execution of a step for a traced request
+type stepTrace struct {
+	StepName   string    `json:"stepName,omitempty"`
+	NodeName   string    `json:"nodeName,omitempty"`
+	ServiceURL string    `json:"serviceUrl,omitempty"`
+	StartTime  time.Time `json:"startTime"`
+	Duration   string    `json:"duration"`
+	StatusCode int       `json:"statusCode"`
+	Error      string    `json:"error,omitempty"`
+	Request    string    `json:"request,omitempty"`
+	Response   string    `json:"response,omitempty"`
+}
+
+// executionTrace records the steps executed for a request, it is persisted when the request fails. The steps of
+// the parallel nodes are recorded concurrently.
+type executionTrace struct {
+	RequestID  string      `json:"requestId"`
+	Graph      string      `json:"graph,omitempty"`
+	Namespace  string      `json:"namespace,omitempty"`
+	StartTime  time.Time   `json:"startTime"`
+	Duration   string      `json:"duration"`
+	StatusCode int         `json:"statusCode"`
+	Error      string      `json:"error,omitempty"`
+	Request    string      `json:"request,omitempty"`
+	Response   string      `json:"response,omitempty"`
+	Steps      []stepTrace `json:"steps"`
+
+	maxPayloadBytes int
+	mu              sync.Mutex
+}
+
+func newExecutionTrace(requestID string, config *v1alpha1.GraphTraceConfig, request []byte) *executionTrace {
+	trace := &executionTrace{
+		RequestID:       requestID,
+		Graph:           config.Graph,
+		Namespace:       config.Namespace,
+		StartTime:       time.Now(),
+		Steps:           []stepTrace{},
+		maxPayloadBytes: config.GetMaxPayloadBytes(),
+	}
+	trace.Request = trace.truncate(request)
+	return trace
+}
+
+// recordStep records the execution of a step, it does nothing when the request is not traced
+func (t *executionTrace) recordStep(step *v1alpha1.InferenceStep, start time.Time, request []byte, response []byte, statusCode int, err error) {
+	if t == nil {
+		return
+	}
+	record := stepTrace{
+		StepName:   step.StepName,
+		NodeName:   step.NodeName,
+		ServiceURL: step.ServiceURL,
+		StartTime:  start,
+		Duration:   time.Since(start).String(),
+		StatusCode: statusCode,
+		Request:    t.truncate(request),
+		Response:   t.truncate(response),
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.Steps = append(t.Steps, record)
+}
+
+// finish records the outcome of the request and orders the steps by start time
+func (t *executionTrace) finish(response []byte, statusCode int, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.Duration = time.Since(t.StartTime).String()
+	t.StatusCode = statusCode
+	t.Response = t.truncate(response)
+	if err != nil {
+		t.Error = err.Error()
+	}
+	sort.SliceStable(t.Steps, func(i, j int) bool { return t.Steps[i].StartTime.Before(t.Steps[j].StartTime) })
+}
+
+// marshal encodes the trace, the steps still running after their node returned keep recording concurrently
+func (t *executionTrace) marshal() ([]byte, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return json.Marshal(t)
+}
+
+// truncate returns the payload truncated to the configured size
+func (t *executionTrace) truncate(payload []byte) string {
+	if len(payload) <= t.maxPayloadBytes {
+		return strings.ToValidUTF8(string(payload), "")
+	}
+	return strings.ToValidUTF8(string(payload[:t.maxPayloadBytes]), "") +
+		fmt.Sprintf("...(%d bytes truncated)", len(payload)-t.maxPayloadBytes)
+}
+
+// getOrCreateRequestID returns the ID of the request, a generated ID is set on the request when it has none so that
+// it is propagated to the steps like the IDs of the clients
+func getOrCreateRequestID(req *http.Request) string {
+	id := req.Header.Get(requestIDHeader)
+	if id == "" {
+		id = guuid.New().String()
+		req.Header.Set(requestIDHeader, id)
+	}
+	return id
+}
+
+// traceStore persists the execution traces of the failed requests
+type traceStore interface {
+	Save(ctx context.Context, trace *executionTrace) error
+}
+
+// graphTraceConfig and graphTraceStore are set when the traces of the failed requests are persisted
+var (
+	graphTraceConfig *v1alpha1.GraphTraceConfig
+	graphTraceStore  traceStore
+)
+
+// persistTrace saves the trace in the background so that the response of the request is not delayed
+func persistTrace(store traceStore, trace *executionTrace) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), traceStoreTimeout)
+		defer cancel()
+		if err := store.Save(ctx, trace); err != nil {
+			log.Error(err, "failed to persist the execution trace", "requestId", trace.RequestID)
+			return
+		}
+		log.Info("Persisted the execution trace of the failed request", "requestId", trace.RequestID)
+	}()
+}
+
+func newTraceStore(config *v1alpha1.GraphTraceConfig) (traceStore, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	switch config.Store {
+	case v1alpha1.ConfigMapTraceStore:
+		restConfig, err := rest.InClusterConfig()
+		if err != nil {
+			return nil, err
+		}
+		clientset, err := kubernetes.NewForConfig(restConfig)
+		if err != nil {
+			return nil, err
+		}
+		return newConfigMapTraceStore(clientset, config), nil
+	case v1alpha1.S3TraceStore:
+		awsConfig := aws.Config{Region: aws.String(config.S3.Region)}
+		if config.S3.Endpoint != "" {
+			awsConfig.Endpoint = aws.String(config.S3.Endpoint)
+			awsConfig.S3ForcePathStyle = aws.Bool(true)
+		}
+		sess, err := session.NewSession(&awsConfig)
+		if err != nil {
+			return nil, err
+		}
+		return &s3TraceStore{client: s3.New(sess), config: config}, nil
+	default:
+		return &lokiTraceStore{client: http.DefaultClient, config: config}, nil
+	}
+}
+
+// configMapTraceStore keeps the latest traces in the <graph name>-traces ConfigMap keyed by request ID, the ConfigMap
+// is owned by the graph
+type configMapTraceStore struct {
+	client     kubernetes.Interface
+	config     *v1alpha1.GraphTraceConfig
+	name       string
+	maxEntries int
+}
+
+func newConfigMapTraceStore(client kubernetes.Interface, config *v1alpha1.GraphTraceConfig) *configMapTraceStore {
+	return &configMapTraceStore{
+		client:     client,
+		config:     config,
+		name:       config.Graph + "-traces",
+		maxEntries: config.ConfigMap.GetMaxEntries(),
+	}
+}
+
+func (s *configMapTraceStore) Save(ctx context.Context, trace *executionTrace) error {
+	value, err := trace.marshal()
+	if err != nil {
+		return err
+	}
+	key := traceKeyPattern.ReplaceAllString(trace.RequestID, "_")
+	// the router replicas update the ConfigMap concurrently
+	return retry.OnError(retry.DefaultRetry, func(err error) bool {
+		return apierr.IsConflict(err) || apierr.IsAlreadyExists(err)
+	}, func() error {
+		configMaps := s.client.CoreV1().ConfigMaps(s.config.Namespace)
+		configMap, err := configMaps.Get(ctx, s.name, metav1.GetOptions{})
+		if apierr.IsNotFound(err) {
+			_, err = configMaps.Create(ctx, s.newConfigMap(key, string(value)), metav1.CreateOptions{})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		configMap.Data[key] = string(value)
+		evictOldestTraces(configMap.Data, s.maxEntries)
+		_, err = configMaps.Update(ctx, configMap, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+func (s *configMapTraceStore) newConfigMap(key string, value string) *v1.ConfigMap {
+	configMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.name,
+			Namespace: s.config.Namespace,
+			Labels:    map[string]string{constants.InferenceGraphLabel: s.config.Graph},
+		},
+		Data: map[string]string{key: value},
+	}
+	if s.config.UID != "" {
+		configMap.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       "InferenceGraph",
+			Name:       s.config.Graph,
+			UID:        types.UID(s.config.UID),
+		}}
+	}
+	return configMap
+}
+
+// evictOldestTraces removes the oldest traces until at most maxEntries traces are kept and they fit in a ConfigMap
+func evictOldestTraces(data map[string]string, maxEntries int) {
+	type entry struct {
+		key       string
+		startTime time.Time
+	}
+	entries := make([]entry, 0, len(data))
+	size := 0
+	for key, value := range data {
+		var trace struct {
+			StartTime time.Time `json:"startTime"`
+		}
+		// the entries which are not traces are evicted first
+		_ = json.Unmarshal([]byte(value), &trace)
+		entries = append(entries, entry{key: key, startTime: trace.StartTime})
+		size += len(key) + len(value)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].startTime.Before(entries[j].startTime) })
+	for i := 0; len(data) > 1 && (len(data) > maxEntries || size > traceConfigMapMaxBytes); i++ {
+		size -= len(entries[i].key) + len(data[entries[i].key])
+		delete(data, entries[i].key)
+	}
+}
+
+// s3TraceStore uploads each trace to <prefix>/<namespace>/<graph name>/<request ID>.json
+type s3TraceStore struct {
+	client s3iface.S3API
+	config *v1alpha1.GraphTraceConfig
+}
+
+func (s *s3TraceStore) Save(ctx context.Context, trace *executionTrace) error {
+	value, err := trace.marshal()
+	if err != nil {
+		return err
+	}
+	key := path.Join(s.config.S3.Prefix, s.config.Namespace, s.config.Graph,
+		traceKeyPattern.ReplaceAllString(trace.RequestID, "_")+".json")
+	_, err = s.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.config.S3.Bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(value),
+		ContentType: aws.String("application/json"),
+	})
+	return err
+}
+
+// lokiTraceStore pushes each trace as a log line of the stream of the graph, the traces are retrieved by filtering
+// the stream on the request ID
+type lokiTraceStore struct {
+	client *http.Client
+	config *v1alpha1.GraphTraceConfig
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+type lokiPushRequest struct {
+	Streams []lokiStream `json:"streams"`
+}
+
+func (s *lokiTraceStore) Save(ctx context.Context, trace *executionTrace) error {
+	line, err := trace.marshal()
+	if err != nil {
+		return err
+	}
+	labels := map[string]string{
+		"app":            "kserve-router",
+		"inferencegraph": s.config.Graph,
+		"namespace":      s.config.Namespace,
+	}
+	for name, value := range s.config.Loki.Labels {
+		labels[name] = value
+	}
+	body, err := json.Marshal(&lokiPushRequest{Streams: []lokiStream{{
+		Stream: labels,
+		Values: [][2]string{{strconv.FormatInt(trace.StartTime.UnixNano(), 10), string(line)}},
+	}}})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		strings.TrimSuffix(s.config.Loki.URL, "/")+"/loki/api/v1/push", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.config.Loki.TenantID != "" {
+		req.Header.Set("X-Scope-OrgID", s.config.Loki.TenantID)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if !isSuccessFul(resp.StatusCode) {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("loki rejected the trace with status %d: %s", resp.StatusCode, message)
+	}
+	return nil
+}
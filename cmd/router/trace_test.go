@@ -0,0 +1,238 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+// channelTraceStore sends the persisted traces to a channel
+type channelTraceStore chan *executionTrace
+
+func (s channelTraceStore) Save(_ context.Context, trace *executionTrace) error {
+	s <- trace
+	return nil
+}
+
+func TestTraceFailedRequest(t *testing.T) {
+	preprocess := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"instances":[[1,2,3,4,5,6,7,8]]}`))
+	}))
+	defer preprocess.Close()
+	model := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		if strings.Contains(string(body), "fail") {
+			http.Error(rw, `{"error":"model failed"}`, http.StatusInternalServerError)
+			return
+		}
+		_, _ = rw.Write([]byte(`{"predictions":[1]}`))
+	}))
+	defer model.Close()
+
+	store := make(channelTraceStore, 1)
+	inferenceGraph = &v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			v1alpha1.GraphRootNodeName: {
+				RouterType: v1alpha1.Sequence,
+				Steps: []v1alpha1.InferenceStep{
+					{StepName: "preprocess", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: preprocess.URL}},
+					{StepName: "model", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL}},
+				},
+			},
+		},
+	}
+	graphTraceConfig = &v1alpha1.GraphTraceConfig{Store: v1alpha1.ConfigMapTraceStore, MaxPayloadBytes: 16,
+		Graph: "graph", Namespace: "default"}
+	graphTraceStore = store
+	defer func() {
+		inferenceGraph, graphTraceConfig, graphTraceStore = nil, nil, nil
+	}()
+
+	// the successful requests are not persisted
+	w := httptest.NewRecorder()
+	graphHandler(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"instances":[1]}`)))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEmpty(t, w.Header().Get(requestIDHeader))
+
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"instances":["fail"]}`))
+	req.Header.Set(requestIDHeader, "request-1")
+	graphHandler(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "request-1", w.Header().Get(requestIDHeader))
+
+	var trace *executionTrace
+	select {
+	case trace = <-store:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the trace of the failed request was not persisted")
+	}
+	assert.Equal(t, "request-1", trace.RequestID)
+	assert.Equal(t, "graph", trace.Graph)
+	assert.Equal(t, http.StatusInternalServerError, trace.StatusCode)
+	assert.Equal(t, `{"instances":["f...(6 bytes truncated)`, trace.Request)
+	if assert.Len(t, trace.Steps, 2) {
+		assert.Equal(t, "preprocess", trace.Steps[0].StepName)
+		assert.Equal(t, http.StatusOK, trace.Steps[0].StatusCode)
+		assert.Equal(t, `{"instances":[[1...(17 bytes truncated)`, trace.Steps[0].Response)
+		assert.Equal(t, "model", trace.Steps[1].StepName)
+		assert.Equal(t, model.URL, trace.Steps[1].ServiceURL)
+		assert.Equal(t, http.StatusInternalServerError, trace.Steps[1].StatusCode)
+		assert.Equal(t, `{"error":"model ...(9 bytes truncated)`, trace.Steps[1].Response)
+	}
+}
+
+func TestConfigMapTraceStore(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	store := newConfigMapTraceStore(clientset, &v1alpha1.GraphTraceConfig{
+		Store:     v1alpha1.ConfigMapTraceStore,
+		ConfigMap: &v1alpha1.ConfigMapTraceStoreConfig{MaxEntries: 2},
+		Graph:     "graph",
+		Namespace: "default",
+		UID:       "uid",
+	})
+	start := time.Now()
+	for i, requestID := range []string{"request/1", "request-2", "request-3"} {
+		trace := &executionTrace{RequestID: requestID, StartTime: start.Add(time.Duration(i) * time.Second)}
+		assert.NoError(t, store.Save(context.Background(), trace))
+	}
+
+	configMap, err := clientset.CoreV1().ConfigMaps("default").Get(context.Background(), "graph-traces", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "graph", configMap.Labels["serving.kserve.io/inferencegraph"])
+	if assert.Len(t, configMap.OwnerReferences, 1) {
+		assert.Equal(t, "InferenceGraph", configMap.OwnerReferences[0].Kind)
+		assert.Equal(t, "uid", string(configMap.OwnerReferences[0].UID))
+	}
+	// the oldest trace is evicted
+	assert.Len(t, configMap.Data, 2)
+	assert.NotContains(t, configMap.Data, "request_1")
+	var trace executionTrace
+	assert.NoError(t, json.Unmarshal([]byte(configMap.Data["request-3"]), &trace))
+	assert.Equal(t, "request-3", trace.RequestID)
+}
+
+func TestEvictOldestTraces(t *testing.T) {
+	large := func(startTime string) string {
+		return `{"startTime":"` + startTime + `","request":"` + strings.Repeat("x", traceConfigMapMaxBytes/2) + `"}`
+	}
+	data := map[string]string{
+		"a": `{"startTime":"2024-01-01T00:00:00Z"}`,
+		"b": large("2024-01-02T00:00:00Z"),
+		"c": `{"startTime":"2024-01-03T00:00:00Z"}`,
+		"d": large("2024-01-04T00:00:00Z"),
+	}
+	evictOldestTraces(data, 10)
+	// the oldest traces are evicted until the ConfigMap fits the size limit
+	assert.Equal(t, []string{"c", "d"}, sortedKeys(data))
+
+	evictOldestTraces(data, 1)
+	assert.Equal(t, []string{"d"}, sortedKeys(data))
+}
+
+func sortedKeys(data map[string]string) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// mockS3Client records the objects put to the bucket
+type mockS3Client struct {
+	s3iface.S3API
+	inputs []*s3.PutObjectInput
+}
+
+func (m *mockS3Client) PutObjectWithContext(_ aws.Context, input *s3.PutObjectInput, _ ...request.Option) (*s3.PutObjectOutput, error) {
+	m.inputs = append(m.inputs, input)
+	return &s3.PutObjectOutput{}, nil
+}
+
+func TestS3TraceStore(t *testing.T) {
+	client := &mockS3Client{}
+	store := &s3TraceStore{client: client, config: &v1alpha1.GraphTraceConfig{
+		Store:     v1alpha1.S3TraceStore,
+		S3:        &v1alpha1.S3TraceStoreConfig{Bucket: "traces", Prefix: "router"},
+		Graph:     "graph",
+		Namespace: "default",
+	}}
+	assert.NoError(t, store.Save(context.Background(), &executionTrace{RequestID: "request-1"}))
+	if assert.Len(t, client.inputs, 1) {
+		assert.Equal(t, "traces", aws.StringValue(client.inputs[0].Bucket))
+		assert.Equal(t, "router/default/graph/request-1.json", aws.StringValue(client.inputs[0].Key))
+		body, _ := io.ReadAll(client.inputs[0].Body)
+		assert.Contains(t, string(body), `"requestId":"request-1"`)
+	}
+}
+
+func TestLokiTraceStore(t *testing.T) {
+	var pushed lokiPushRequest
+	var tenant string
+	loki := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/loki/api/v1/push" {
+			http.NotFound(rw, req)
+			return
+		}
+		tenant = req.Header.Get("X-Scope-OrgID")
+		body, _ := io.ReadAll(req.Body)
+		_ = json.Unmarshal(body, &pushed)
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	defer loki.Close()
+
+	config := &v1alpha1.GraphTraceConfig{
+		Store:     v1alpha1.LokiTraceStore,
+		Loki:      &v1alpha1.LokiTraceStoreConfig{URL: loki.URL + "/", TenantID: "team-a", Labels: map[string]string{"cluster": "prod"}},
+		Graph:     "graph",
+		Namespace: "default",
+	}
+	store := &lokiTraceStore{client: http.DefaultClient, config: config}
+	startTime := time.Unix(1700000000, 0)
+	assert.NoError(t, store.Save(context.Background(), &executionTrace{RequestID: "request-1", StartTime: startTime}))
+	assert.Equal(t, "team-a", tenant)
+	if assert.Len(t, pushed.Streams, 1) {
+		assert.Equal(t, map[string]string{"app": "kserve-router", "inferencegraph": "graph", "namespace": "default",
+			"cluster": "prod"}, pushed.Streams[0].Stream)
+		if assert.Len(t, pushed.Streams[0].Values, 1) {
+			assert.Equal(t, "1700000000000000000", pushed.Streams[0].Values[0][0])
+			assert.Contains(t, pushed.Streams[0].Values[0][1], `"requestId":"request-1"`)
+		}
+	}
+
+	config.Loki.URL = loki.URL + "/missing"
+	assert.ErrorContains(t, store.Save(context.Background(), &executionTrace{RequestID: "request-2"}), "status 404")
+}
@@ -0,0 +1,336 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+const (
+	// defaultInputName is the name of the V2 input tensor of the translated requests
+	defaultInputName = "input-0"
+	// defaultOutputName is the name of the V2 output tensor of the translated responses
+	defaultOutputName = "output-0"
+)
+
+// v2Tensor is an input or output tensor of the V2 protocol
+type v2Tensor struct {
+	Name     string        `json:"name"`
+	Shape    []int         `json:"shape"`
+	Datatype string        `json:"datatype"`
+	Data     []interface{} `json:"data"`
+}
+
+type v2Request struct {
+	Inputs []v2Tensor `json:"inputs"`
+}
+
+type v2Response struct {
+	ModelName string     `json:"model_name,omitempty"`
+	Outputs   []v2Tensor `json:"outputs"`
+}
+
+type openAICompletionRequest struct {
+	Model  string      `json:"model,omitempty"`
+	Prompt interface{} `json:"prompt"`
+}
+
+type openAIChoice struct {
+	Index        int    `json:"index"`
+	Text         string `json:"text"`
+	FinishReason string `json:"finish_reason,omitempty"`
+}
+
+type openAICompletionResponse struct {
+	Object  string         `json:"object,omitempty"`
+	Model   string         `json:"model,omitempty"`
+	Choices []openAIChoice `json:"choices"`
+}
+
+// translateRequest translates the request of a step from the protocol of the graph to the protocol of its target
+func translateRequest(translation *v1alpha1.ProtocolTranslation, body []byte) ([]byte, error) {
+	instances, err := decodeRequest(translation.From, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to translate the %s request: %w", translation.From, err)
+	}
+	translated, err := encodeRequest(translation, instances)
+	if err != nil {
+		return nil, fmt.Errorf("failed to translate the request to %s: %w", translation.To, err)
+	}
+	return translated, nil
+}
+
+// translateResponse translates the response of the target of a step back to the protocol of the graph
+func translateResponse(translation *v1alpha1.ProtocolTranslation, body []byte) ([]byte, error) {
+	predictions, err := decodeResponse(translation.To, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to translate the %s response: %w", translation.To, err)
+	}
+	translated, err := encodeResponse(translation, predictions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to translate the response to %s: %w", translation.From, err)
+	}
+	return translated, nil
+}
+
+// decodeRequest returns the instances of a request
+func decodeRequest(format v1alpha1.InferenceProtocolFormat, body []byte) ([]interface{}, error) {
+	switch format {
+	case v1alpha1.ProtocolFormatV1:
+		var request map[string]interface{}
+		if err := json.Unmarshal(body, &request); err != nil {
+			return nil, err
+		}
+		for _, key := range []string{"instances", "inputs"} {
+			if instances, ok := request[key].([]interface{}); ok {
+				return instances, nil
+			}
+		}
+		return nil, fmt.Errorf("the request has no instances")
+	case v1alpha1.ProtocolFormatV2:
+		request := &v2Request{}
+		if err := json.Unmarshal(body, request); err != nil {
+			return nil, err
+		}
+		if len(request.Inputs) != 1 {
+			return nil, fmt.Errorf("expected exactly one input tensor, got %d", len(request.Inputs))
+		}
+		return tensorToInstances(request.Inputs[0])
+	case v1alpha1.ProtocolFormatOpenAI:
+		request := &openAICompletionRequest{}
+		if err := json.Unmarshal(body, request); err != nil {
+			return nil, err
+		}
+		switch prompt := request.Prompt.(type) {
+		case string:
+			return []interface{}{prompt}, nil
+		case []interface{}:
+			return prompt, nil
+		}
+		return nil, fmt.Errorf("the request has no prompt")
+	}
+	return nil, fmt.Errorf("unknown protocol %q", format)
+}
+
+// encodeRequest returns the request of the instances
+func encodeRequest(translation *v1alpha1.ProtocolTranslation, instances []interface{}) ([]byte, error) {
+	switch translation.To {
+	case v1alpha1.ProtocolFormatV1:
+		return json.Marshal(map[string]interface{}{"instances": instances})
+	case v1alpha1.ProtocolFormatV2:
+		name := translation.InputName
+		if name == "" {
+			name = defaultInputName
+		}
+		tensor, err := instancesToTensor(name, translation.Datatype, instances)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(&v2Request{Inputs: []v2Tensor{tensor}})
+	case v1alpha1.ProtocolFormatOpenAI:
+		prompts := make([]string, len(instances))
+		for i, instance := range instances {
+			prompt, err := toText(instance)
+			if err != nil {
+				return nil, err
+			}
+			prompts[i] = prompt
+		}
+		return json.Marshal(&openAICompletionRequest{Model: translation.Model, Prompt: prompts})
+	}
+	return nil, fmt.Errorf("unknown protocol %q", translation.To)
+}
+
+// decodeResponse returns the predictions of a response
+func decodeResponse(format v1alpha1.InferenceProtocolFormat, body []byte) ([]interface{}, error) {
+	switch format {
+	case v1alpha1.ProtocolFormatV1:
+		var response map[string]interface{}
+		if err := json.Unmarshal(body, &response); err != nil {
+			return nil, err
+		}
+		if predictions, ok := response["predictions"].([]interface{}); ok {
+			return predictions, nil
+		}
+		return nil, fmt.Errorf("the response has no predictions")
+	case v1alpha1.ProtocolFormatV2:
+		response := &v2Response{}
+		if err := json.Unmarshal(body, response); err != nil {
+			return nil, err
+		}
+		if len(response.Outputs) != 1 {
+			return nil, fmt.Errorf("expected exactly one output tensor, got %d", len(response.Outputs))
+		}
+		return tensorToInstances(response.Outputs[0])
+	case v1alpha1.ProtocolFormatOpenAI:
+		response := &openAICompletionResponse{}
+		if err := json.Unmarshal(body, response); err != nil {
+			return nil, err
+		}
+		sort.SliceStable(response.Choices, func(i, j int) bool {
+			return response.Choices[i].Index < response.Choices[j].Index
+		})
+		predictions := make([]interface{}, len(response.Choices))
+		for i, choice := range response.Choices {
+			predictions[i] = choice.Text
+		}
+		return predictions, nil
+	}
+	return nil, fmt.Errorf("unknown protocol %q", format)
+}
+
+// encodeResponse returns the response of the predictions
+func encodeResponse(translation *v1alpha1.ProtocolTranslation, predictions []interface{}) ([]byte, error) {
+	switch translation.From {
+	case v1alpha1.ProtocolFormatV1:
+		return json.Marshal(map[string]interface{}{"predictions": predictions})
+	case v1alpha1.ProtocolFormatV2:
+		tensor, err := instancesToTensor(defaultOutputName, "", predictions)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(&v2Response{ModelName: translation.Model, Outputs: []v2Tensor{tensor}})
+	case v1alpha1.ProtocolFormatOpenAI:
+		choices := make([]openAIChoice, len(predictions))
+		for i, prediction := range predictions {
+			text, err := toText(prediction)
+			if err != nil {
+				return nil, err
+			}
+			choices[i] = openAIChoice{Index: i, Text: text, FinishReason: "stop"}
+		}
+		return json.Marshal(&openAICompletionResponse{Object: "text_completion", Model: translation.Model, Choices: choices})
+	}
+	return nil, fmt.Errorf("unknown protocol %q", translation.From)
+}
+
+// toText returns the text of an instance or a prediction, other values than strings are encoded as JSON
+func toText(value interface{}) (string, error) {
+	if text, ok := value.(string); ok {
+		return text, nil
+	}
+	text, err := json.Marshal(value)
+	return string(text), err
+}
+
+// instancesToTensor returns the tensor whose first dimension are the instances, all the instances must have the
+// same shape
+func instancesToTensor(name string, datatype string, instances []interface{}) (v2Tensor, error) {
+	shape := []int{len(instances)}
+	if len(instances) > 0 {
+		for value := instances[0]; ; {
+			values, ok := value.([]interface{})
+			if !ok {
+				break
+			}
+			shape = append(shape, len(values))
+			if len(values) == 0 {
+				break
+			}
+			value = values[0]
+		}
+	}
+	data := make([]interface{}, 0)
+	if err := flattenTensor(instances, shape, &data); err != nil {
+		return v2Tensor{}, err
+	}
+	if datatype == "" {
+		datatype = "FP32"
+		if len(data) > 0 {
+			switch data[0].(type) {
+			case string:
+				datatype = "BYTES"
+			case bool:
+				datatype = "BOOL"
+			case float64:
+				datatype = "FP32"
+			default:
+				return v2Tensor{}, fmt.Errorf("unsupported tensor element %v", data[0])
+			}
+		}
+	}
+	return v2Tensor{Name: name, Shape: shape, Datatype: datatype, Data: data}, nil
+}
+
+// flattenTensor appends the elements of the value to data in row-major order, checking it has the given shape
+func flattenTensor(value interface{}, shape []int, data *[]interface{}) error {
+	if len(shape) == 0 {
+		if _, ok := value.([]interface{}); ok {
+			return fmt.Errorf("the instances do not have the same shape")
+		}
+		*data = append(*data, value)
+		return nil
+	}
+	values, ok := value.([]interface{})
+	if !ok || len(values) != shape[0] {
+		return fmt.Errorf("the instances do not have the same shape")
+	}
+	for _, v := range values {
+		if err := flattenTensor(v, shape[1:], data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tensorToInstances returns the instances of the first dimension of the tensor, its data may be flat or nested
+func tensorToInstances(tensor v2Tensor) ([]interface{}, error) {
+	if len(tensor.Shape) == 0 {
+		return nil, fmt.Errorf("tensor %q has no shape", tensor.Name)
+	}
+	size := 1
+	for _, dim := range tensor.Shape {
+		if dim < 0 {
+			return nil, fmt.Errorf("tensor %q has the invalid shape %v", tensor.Name, tensor.Shape)
+		}
+		size *= dim
+	}
+	var data []interface{}
+	var flatten func(value interface{})
+	flatten = func(value interface{}) {
+		if values, ok := value.([]interface{}); ok {
+			for _, v := range values {
+				flatten(v)
+			}
+			return
+		}
+		data = append(data, value)
+	}
+	flatten(tensor.Data)
+	if len(data) != size {
+		return nil, fmt.Errorf("tensor %q has %d elements which does not match its shape %v", tensor.Name, len(data),
+			tensor.Shape)
+	}
+	return reshape(data, tensor.Shape), nil
+}
+
+// reshape returns the nested arrays of the given shape holding the data in row-major order
+func reshape(data []interface{}, shape []int) []interface{} {
+	if len(shape) == 1 {
+		return data
+	}
+	size := len(data) / max(shape[0], 1)
+	nested := make([]interface{}, shape[0])
+	for i := range nested {
+		nested[i] = reshape(data[i*size:(i+1)*size], shape[1:])
+	}
+	return nested
+}
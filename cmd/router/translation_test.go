@@ -0,0 +1,197 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslateRequest(t *testing.T) {
+	scenarios := map[string]struct {
+		translation v1alpha1.ProtocolTranslation
+		request     string
+		expected    string
+		expectErr   bool
+	}{
+		"v1 to v2": {
+			translation: v1alpha1.ProtocolTranslation{From: v1alpha1.ProtocolFormatV1, To: v1alpha1.ProtocolFormatV2},
+			request:     `{"instances":[[6.8,2.8,4.8,1.4],[6.0,3.4,4.5,1.6]]}`,
+			expected:    `{"inputs":[{"name":"input-0","shape":[2,4],"datatype":"FP32","data":[6.8,2.8,4.8,1.4,6.0,3.4,4.5,1.6]}]}`,
+		},
+		"v1 to v2 with input name and datatype": {
+			translation: v1alpha1.ProtocolTranslation{From: v1alpha1.ProtocolFormatV1, To: v1alpha1.ProtocolFormatV2,
+				InputName: "features", Datatype: "INT64"},
+			request:  `{"instances":[1,2,3]}`,
+			expected: `{"inputs":[{"name":"features","shape":[3],"datatype":"INT64","data":[1,2,3]}]}`,
+		},
+		"v1 to v2 with ragged instances": {
+			translation: v1alpha1.ProtocolTranslation{From: v1alpha1.ProtocolFormatV1, To: v1alpha1.ProtocolFormatV2},
+			request:     `{"instances":[[1,2],[3]]}`,
+			expectErr:   true,
+		},
+		"v2 to v1": {
+			translation: v1alpha1.ProtocolTranslation{From: v1alpha1.ProtocolFormatV2, To: v1alpha1.ProtocolFormatV1},
+			request:     `{"inputs":[{"name":"input-0","shape":[2,2],"datatype":"FP32","data":[1,2,3,4]}]}`,
+			expected:    `{"instances":[[1,2],[3,4]]}`,
+		},
+		"v2 to v1 with nested data": {
+			translation: v1alpha1.ProtocolTranslation{From: v1alpha1.ProtocolFormatV2, To: v1alpha1.ProtocolFormatV1},
+			request:     `{"inputs":[{"name":"input-0","shape":[2,2],"datatype":"FP32","data":[[1,2],[3,4]]}]}`,
+			expected:    `{"instances":[[1,2],[3,4]]}`,
+		},
+		"v2 with a shape mismatch": {
+			translation: v1alpha1.ProtocolTranslation{From: v1alpha1.ProtocolFormatV2, To: v1alpha1.ProtocolFormatV1},
+			request:     `{"inputs":[{"name":"input-0","shape":[2,2],"datatype":"FP32","data":[1,2,3]}]}`,
+			expectErr:   true,
+		},
+		"v1 to openai": {
+			translation: v1alpha1.ProtocolTranslation{From: v1alpha1.ProtocolFormatV1, To: v1alpha1.ProtocolFormatOpenAI,
+				Model: "gpt2"},
+			request:  `{"instances":["Hello","Bonjour"]}`,
+			expected: `{"model":"gpt2","prompt":["Hello","Bonjour"]}`,
+		},
+		"openai to v2": {
+			translation: v1alpha1.ProtocolTranslation{From: v1alpha1.ProtocolFormatOpenAI, To: v1alpha1.ProtocolFormatV2},
+			request:     `{"model":"gpt2","prompt":"Hello"}`,
+			expected:    `{"inputs":[{"name":"input-0","shape":[1],"datatype":"BYTES","data":["Hello"]}]}`,
+		},
+		"v1 without instances": {
+			translation: v1alpha1.ProtocolTranslation{From: v1alpha1.ProtocolFormatV1, To: v1alpha1.ProtocolFormatV2},
+			request:     `{"data":[1]}`,
+			expectErr:   true,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			translated, err := translateRequest(&scenario.translation, []byte(scenario.request))
+			if scenario.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.JSONEq(t, scenario.expected, string(translated))
+		})
+	}
+}
+
+func TestTranslateResponse(t *testing.T) {
+	scenarios := map[string]struct {
+		translation v1alpha1.ProtocolTranslation
+		response    string
+		expected    string
+		expectErr   bool
+	}{
+		"v2 to v1": {
+			translation: v1alpha1.ProtocolTranslation{From: v1alpha1.ProtocolFormatV1, To: v1alpha1.ProtocolFormatV2},
+			response:    `{"model_name":"sklearn","outputs":[{"name":"predict","shape":[2],"datatype":"INT64","data":[1,1]}]}`,
+			expected:    `{"predictions":[1,1]}`,
+		},
+		"v2 with several outputs": {
+			translation: v1alpha1.ProtocolTranslation{From: v1alpha1.ProtocolFormatV1, To: v1alpha1.ProtocolFormatV2},
+			response: `{"outputs":[{"name":"a","shape":[1],"datatype":"INT64","data":[1]},` +
+				`{"name":"b","shape":[1],"datatype":"INT64","data":[1]}]}`,
+			expectErr: true,
+		},
+		"v1 to v2": {
+			translation: v1alpha1.ProtocolTranslation{From: v1alpha1.ProtocolFormatV2, To: v1alpha1.ProtocolFormatV1,
+				Model: "sklearn"},
+			response: `{"predictions":[[0.1,0.9],[0.8,0.2]]}`,
+			expected: `{"model_name":"sklearn","outputs":[{"name":"output-0","shape":[2,2],"datatype":"FP32","data":[0.1,0.9,0.8,0.2]}]}`,
+		},
+		"openai to v1": {
+			translation: v1alpha1.ProtocolTranslation{From: v1alpha1.ProtocolFormatV1, To: v1alpha1.ProtocolFormatOpenAI},
+			response:    `{"object":"text_completion","choices":[{"index":1,"text":" world"},{"index":0,"text":" there"}]}`,
+			expected:    `{"predictions":[" there"," world"]}`,
+		},
+		"v1 to openai": {
+			translation: v1alpha1.ProtocolTranslation{From: v1alpha1.ProtocolFormatOpenAI, To: v1alpha1.ProtocolFormatV1,
+				Model: "classifier"},
+			response: `{"predictions":["positive",{"label":"negative"}]}`,
+			expected: `{"object":"text_completion","model":"classifier","choices":[` +
+				`{"index":0,"text":"positive","finish_reason":"stop"},` +
+				`{"index":1,"text":"{\"label\":\"negative\"}","finish_reason":"stop"}]}`,
+		},
+		"v1 without predictions": {
+			translation: v1alpha1.ProtocolTranslation{From: v1alpha1.ProtocolFormatV2, To: v1alpha1.ProtocolFormatV1},
+			response:    `{"error":"failed"}`,
+			expectErr:   true,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			translated, err := translateResponse(&scenario.translation, []byte(scenario.response))
+			if scenario.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.JSONEq(t, scenario.expected, string(translated))
+		})
+	}
+}
+
+func TestTranslatedStep(t *testing.T) {
+	v2Model := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		request := &v2Request{}
+		body, _ := io.ReadAll(req.Body)
+		if err := json.Unmarshal(body, request); err != nil || len(request.Inputs) != 1 {
+			http.Error(rw, `{"error":"invalid request"}`, http.StatusBadRequest)
+			return
+		}
+		response := &v2Response{ModelName: "sklearn", Outputs: []v2Tensor{
+			{Name: "predict", Shape: []int{request.Inputs[0].Shape[0]}, Datatype: "INT64", Data: []interface{}{1, 0}},
+		}}
+		responseBytes, _ := json.Marshal(response)
+		_, _ = rw.Write(responseBytes)
+	}))
+	defer v2Model.Close()
+
+	graphSpec := v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"root": {
+				RouterType: v1alpha1.Sequence,
+				Steps: []v1alpha1.InferenceStep{
+					{
+						InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: v2Model.URL},
+						Translation: &v1alpha1.ProtocolTranslation{
+							From: v1alpha1.ProtocolFormatV1,
+							To:   v1alpha1.ProtocolFormatV2,
+						},
+					},
+				},
+			},
+		},
+	}
+	res, statusCode, err := routeStep("root", graphSpec, []byte(`{"instances":[[6.8,2.8],[6.0,3.4]]}`), http.Header{},
+		newGraphLimiter(0, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.JSONEq(t, `{"predictions":[1,0]}`, string(res))
+
+	// the requests which can not be translated are rejected before the target is called
+	_, statusCode, err = routeStep("root", graphSpec, []byte(`{"instances":[[1,2],[3]]}`), http.Header{},
+		newGraphLimiter(0, 0))
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+}
@@ -84,3 +84,4 @@ cp config/crd/full/serving.kserve.io_trainedmodels.yaml charts/kserve-crd/templa
 cp config/crd/full/serving.kserve.io_inferencegraphs.yaml charts/kserve-crd/templates/serving.kserve.io_inferencegraphs.yaml
 cp config/crd/full/serving.kserve.io_servingruntimes.yaml charts/kserve-crd/templates/serving.kserve.io_servingruntimes.yaml
 cp config/crd/full/serving.kserve.io_clusterstoragecontainers.yaml charts/kserve-crd/templates/serving.kserve.io_clusterstoragecontainers.yaml
+cp config/crd/full/serving.kserve.io_servingprofiles.yaml charts/kserve-crd/templates/serving.kserve.io_servingprofiles.yaml
@@ -31,20 +31,11 @@ CODEGEN_PKG="$GOPATH/pkg/mod/k8s.io/code-generator@${CODEGEN_VERSION}"
 chmod +x "${CODEGEN_PKG}/generate-groups.sh"
 chmod +x "${CODEGEN_PKG}/generate-internal-groups.sh"
 
-# We can not generate client-go for v1alpha1 and v1beta1 and add them to the same directory.
-# So, we add each to a separate directory.
-# Generating files for v1alpha1
-"${CODEGEN_PKG}/generate-groups.sh" \
-    "deepcopy,client,informer,lister" \
-    "github.com/kserve/kserve/pkg/clientv1alpha1" \
-    "github.com/kserve/kserve/pkg/apis" \
-    "serving:v1alpha1" \
-    --go-header-file "${KUBE_ROOT}/hack/boilerplate.go.txt"
-
-# Generating files for v1beta1
+# Generate the clientset, informers and listers of both API versions into pkg/client so that
+# external controllers can consume the KServe APIs with client-go.
 "${CODEGEN_PKG}/generate-groups.sh" \
     "deepcopy,client,informer,lister" \
     "github.com/kserve/kserve/pkg/client" \
     "github.com/kserve/kserve/pkg/apis" \
-    "serving:v1beta1" \
+    "serving:v1alpha1,v1beta1" \
     --go-header-file "${KUBE_ROOT}/hack/boilerplate.go.txt"
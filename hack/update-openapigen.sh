@@ -42,3 +42,6 @@ diff $CURRENT_VIOLATION_EXCEPTIONS $KNOWN_VIOLATION_EXCEPTIONS || \
 
 # Generating swagger file
 go run cmd/spec-gen/main.go 0.1 > pkg/apis/serving/v1beta1/swagger.json
+
+# Generating the JSON schema of the router graph document
+go run cmd/graph-schema-gen/main.go > pkg/router/api/graph.v1.schema.json
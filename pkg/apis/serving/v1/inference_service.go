@@ -0,0 +1,126 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// InferenceServiceSpec is the top level type for this resource
+type InferenceServiceSpec struct {
+	// DeploymentMode is the deployment mode of the InferenceService, it replaces the
+	// serving.kserve.io/deploymentMode annotation of v1beta1.
+	// +kubebuilder:validation:Enum=Serverless;RawDeployment;ModelMesh
+	// +optional
+	DeploymentMode constants.DeploymentModeType `json:"deploymentMode,omitempty"`
+	// AutoscalerClass is the autoscaler used in raw deployment mode, it replaces the
+	// serving.kserve.io/autoscalerClass annotation of v1beta1.
+	// +kubebuilder:validation:Enum=hpa;external
+	// +optional
+	AutoscalerClass constants.AutoscalerClassType `json:"autoscalerClass,omitempty"`
+	// Predictor defines the model serving spec
+	// +required
+	Predictor PredictorSpec `json:"predictor"`
+	// Explainer defines the model explanation service spec,
+	// explainer service calls to predictor or transformer if it is specified.
+	// +optional
+	Explainer *v1beta1.ExplainerSpec `json:"explainer,omitempty"`
+	// Transformer defines the pre/post processing before and after the predictor call,
+	// transformer service calls to predictor service.
+	// +optional
+	Transformer *v1beta1.TransformerSpec `json:"transformer,omitempty"`
+	// Validation defines a Job run against every new revision of the predictor,
+	// the InferenceService is not marked ready until the Job succeeds.
+	// +optional
+	Validation *v1beta1.ValidationSpec `json:"validation,omitempty"`
+	// Benchmark defines a load test run against every new revision of the predictor once it is ready,
+	// its results are reported in the status.
+	// +optional
+	Benchmark *v1beta1.BenchmarkSpec `json:"benchmark,omitempty"`
+	// ServingPriority ranks the InferenceService when the GPUs are scarce, an InferenceService whose GPU pods cannot
+	// be scheduled stops the InferenceServices of lower priority of its namespace when the GPU preemption is enabled.
+	// It defaults to the value of the PriorityClass of the predictor, or else to 0. It cannot exceed the
+	// maxServingPriority of the GPU preemption config.
+	// +optional
+	ServingPriority *int32 `json:"servingPriority,omitempty"`
+	// ActiveHours restricts the workloads to cron-based windows, e.g. business hours, they are removed outside the
+	// windows and created again when the next window opens.
+	// +optional
+	ActiveHours *v1beta1.ActiveHours `json:"activeHours,omitempty"`
+	// CORS allows the browsers to call the endpoints from other origins, the policy is enforced by the agent of the
+	// components and mirrored into the Istio VirtualService.
+	// +optional
+	CORS *v1beta1.CORSPolicy `json:"cors,omitempty"`
+}
+
+// PredictorSpec defines the configuration for a predictor. Unlike v1beta1, the model server is
+// always selected through the model format, the framework specific fields are not supported.
+type PredictorSpec struct {
+	// Model spec for any arbitrary framework.
+	// +optional
+	Model *v1beta1.ModelSpec `json:"model,omitempty"`
+
+	// ModelConversion declares a step converting the model after it is downloaded by the storage initializer,
+	// e.g. to safetensors or to a quantized checkpoint, so the converted artifacts do not have to be generated offline.
+	// +optional
+	ModelConversion *v1beta1.ModelConversionSpec `json:"modelConversion,omitempty"`
+
+	// This spec is dual purpose. <br />
+	// 1) Provide a full PodSpec for custom predictor.
+	// The field PodSpec.Containers is mutually exclusive with the model. <br />
+	// 2) Provide a model and specify PodSpec
+	// overrides, you must not provide PodSpec.Containers in this case. <br />
+	v1beta1.PodSpec `json:",inline"`
+	// Component extension defines the deployment configurations for a predictor
+	v1beta1.ComponentExtensionSpec `json:",inline"`
+}
+
+// InferenceService is the Schema for the InferenceServices API
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="URL",type="string",JSONPath=".status.url"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="Mode",type="string",JSONPath=".spec.deploymentMode"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:path=inferenceservices,shortName=isvc
+type InferenceService struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec InferenceServiceSpec `json:"spec,omitempty"`
+
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Status v1beta1.InferenceServiceStatus `json:"status,omitempty"`
+}
+
+// InferenceServiceList contains a list of Service
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+type InferenceServiceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	// +listType=set
+	Items []InferenceService `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&InferenceService{}, &InferenceServiceList{})
+}
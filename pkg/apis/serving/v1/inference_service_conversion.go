@@ -0,0 +1,232 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"encoding/json"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// LegacyPredictorAnnotationKey keeps the v1beta1 predictor framework specs, which have no v1 field, on the v1
+// InferenceServices so that they are restored when the InferenceService is converted back to v1beta1
+var LegacyPredictorAnnotationKey = constants.KServeAPIGroupName + "/v1beta1-legacy-predictor"
+
+var _ conversion.Convertible = &InferenceService{}
+
+// ConvertTo converts the v1 InferenceService to the v1beta1 storage version,
+// the spec fields which replace annotations are converted back to annotations and the predictor framework specs
+// of the legacy predictor annotation are restored.
+func (src *InferenceService) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.InferenceService)
+	spec := src.Spec.DeepCopy()
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	for key, value := range map[string]string{
+		constants.DeploymentMode:  string(spec.DeploymentMode),
+		constants.AutoscalerClass: string(spec.AutoscalerClass),
+	} {
+		if value == "" {
+			continue
+		}
+		if dst.Annotations == nil {
+			dst.Annotations = map[string]string{}
+		}
+		dst.Annotations[key] = value
+	}
+	dst.Spec = v1beta1.InferenceServiceSpec{
+		Predictor: v1beta1.PredictorSpec{
+			Model:                  spec.Predictor.Model,
+			ModelConversion:        spec.Predictor.ModelConversion,
+			PodSpec:                spec.Predictor.PodSpec,
+			ComponentExtensionSpec: spec.Predictor.ComponentExtensionSpec,
+		},
+		Explainer:       spec.Explainer,
+		Transformer:     spec.Transformer,
+		Validation:      spec.Validation,
+		Benchmark:       spec.Benchmark,
+		ServingPriority: spec.ServingPriority,
+		ActiveHours:     spec.ActiveHours,
+		CORS:            spec.CORS,
+	}
+	restoreLegacyPredictor(&dst.ObjectMeta.Annotations, &dst.Spec.Predictor)
+	dst.Status = *src.Status.DeepCopy()
+	return nil
+}
+
+// ConvertFrom converts the v1beta1 storage version to the v1 InferenceService, the deprecated predictor
+// framework specs are converted to model specs and the mode annotations are converted to spec fields. The framework
+// specs are kept in the legacy predictor annotation so that the conversion back to v1beta1 is lossless.
+func (dst *InferenceService) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.InferenceService).DeepCopy()
+	legacy, err := getLegacyPredictor(&src.Spec.Predictor)
+	if err != nil {
+		return err
+	}
+	src.ConvertLegacyPredictor()
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = InferenceServiceSpec{
+		DeploymentMode:  constants.DeploymentModeType(popAnnotation(&dst.ObjectMeta.Annotations, constants.DeploymentMode)),
+		AutoscalerClass: constants.AutoscalerClassType(popAnnotation(&dst.ObjectMeta.Annotations, constants.AutoscalerClass)),
+		Predictor: PredictorSpec{
+			Model:                  src.Spec.Predictor.Model,
+			ModelConversion:        src.Spec.Predictor.ModelConversion,
+			PodSpec:                src.Spec.Predictor.PodSpec,
+			ComponentExtensionSpec: src.Spec.Predictor.ComponentExtensionSpec,
+		},
+		Explainer:       src.Spec.Explainer,
+		Transformer:     src.Spec.Transformer,
+		Validation:      src.Spec.Validation,
+		Benchmark:       src.Spec.Benchmark,
+		ServingPriority: src.Spec.ServingPriority,
+		ActiveHours:     src.Spec.ActiveHours,
+		CORS:            src.Spec.CORS,
+	}
+	if legacy != "" {
+		if dst.Annotations == nil {
+			dst.Annotations = map[string]string{}
+		}
+		dst.Annotations[LegacyPredictorAnnotationKey] = legacy
+	}
+	dst.Status = src.Status
+	return nil
+}
+
+// popAnnotation removes an annotation and returns its value, the annotations are set to nil when none is left. The
+// annotations with an empty value are kept since the spec fields can not tell them from unset annotations.
+func popAnnotation(annotations *map[string]string, key string) string {
+	value := (*annotations)[key]
+	if value == "" {
+		return ""
+	}
+	delete(*annotations, key)
+	if len(*annotations) == 0 {
+		*annotations = nil
+	}
+	return value
+}
+
+// legacyPredictor holds the v1beta1 predictor fields the conversion of the framework specs rewrites
+type legacyPredictor struct {
+	SKLearn     *v1beta1.SKLearnSpec            `json:"sklearn,omitempty"`
+	XGBoost     *v1beta1.XGBoostSpec            `json:"xgboost,omitempty"`
+	Tensorflow  *v1beta1.TFServingSpec          `json:"tensorflow,omitempty"`
+	PyTorch     *v1beta1.TorchServeSpec         `json:"pytorch,omitempty"`
+	Triton      *v1beta1.TritonSpec             `json:"triton,omitempty"`
+	ONNX        *v1beta1.ONNXRuntimeSpec        `json:"onnx,omitempty"`
+	HuggingFace *v1beta1.HuggingFaceRuntimeSpec `json:"huggingface,omitempty"`
+	PMML        *v1beta1.PMMLSpec               `json:"pmml,omitempty"`
+	LightGBM    *v1beta1.LightGBMSpec           `json:"lightgbm,omitempty"`
+	Paddle      *v1beta1.PaddleServerSpec       `json:"paddle,omitempty"`
+	Model       *v1beta1.ModelSpec              `json:"model,omitempty"`
+}
+
+// getLegacyPredictor returns the framework specs of the predictor and its model spec encoded for the legacy predictor
+// annotation, or an empty string when the predictor has no framework spec
+func getLegacyPredictor(predictor *v1beta1.PredictorSpec) (string, error) {
+	legacy := legacyPredictor{
+		SKLearn:     predictor.SKLearn,
+		XGBoost:     predictor.XGBoost,
+		Tensorflow:  predictor.Tensorflow,
+		PyTorch:     predictor.PyTorch,
+		Triton:      predictor.Triton,
+		ONNX:        predictor.ONNX,
+		HuggingFace: predictor.HuggingFace,
+		PMML:        predictor.PMML,
+		LightGBM:    predictor.LightGBM,
+		Paddle:      predictor.Paddle,
+	}
+	if equality.Semantic.DeepEqual(legacy, legacyPredictor{}) {
+		return "", nil
+	}
+	legacy.Model = predictor.Model
+	data, err := json.Marshal(legacy)
+	return string(data), err
+}
+
+/*
+Restores the framework specs of the legacy predictor annotation and removes the annotation. The specs are only
+restored when the model of the predictor is still the one they are converted to, the model edited in v1 wins
+otherwise.
+*/
+func restoreLegacyPredictor(annotations *map[string]string, predictor *v1beta1.PredictorSpec) {
+	data, ok := (*annotations)[LegacyPredictorAnnotationKey]
+	if !ok {
+		return
+	}
+	delete(*annotations, LegacyPredictorAnnotationKey)
+	if len(*annotations) == 0 {
+		*annotations = nil
+	}
+	legacy := legacyPredictor{}
+	if err := json.Unmarshal([]byte(data), &legacy); err != nil {
+		return
+	}
+	restored := predictor.DeepCopy()
+	restored.SKLearn = legacy.SKLearn
+	restored.XGBoost = legacy.XGBoost
+	restored.Tensorflow = legacy.Tensorflow
+	restored.PyTorch = legacy.PyTorch
+	restored.Triton = legacy.Triton
+	restored.ONNX = legacy.ONNX
+	restored.HuggingFace = legacy.HuggingFace
+	restored.PMML = legacy.PMML
+	restored.LightGBM = legacy.LightGBM
+	restored.Paddle = legacy.Paddle
+	restored.Model = legacy.Model
+	converted := &v1beta1.InferenceService{Spec: v1beta1.InferenceServiceSpec{Predictor: *restored.DeepCopy()}}
+	converted.ConvertLegacyPredictor()
+	if equality.Semantic.DeepEqual(converted.Spec.Predictor.Model, predictor.Model) {
+		*predictor = *restored
+	}
+}
+
+// GetDeprecatedFields returns the v1beta1 fields used by an InferenceService which are not part of the v1 API
+// and are rewritten by the conversion, it helps to find the manifests to migrate before moving to v1.
+func GetDeprecatedFields(isvc *v1beta1.InferenceService) []string {
+	var fields []string
+	predictor := isvc.Spec.Predictor
+	for field, spec := range map[string]bool{
+		"spec.predictor.sklearn":     predictor.SKLearn != nil,
+		"spec.predictor.xgboost":     predictor.XGBoost != nil,
+		"spec.predictor.tensorflow":  predictor.Tensorflow != nil,
+		"spec.predictor.pytorch":     predictor.PyTorch != nil,
+		"spec.predictor.triton":      predictor.Triton != nil,
+		"spec.predictor.onnx":        predictor.ONNX != nil,
+		"spec.predictor.huggingface": predictor.HuggingFace != nil,
+		"spec.predictor.pmml":        predictor.PMML != nil,
+		"spec.predictor.lightgbm":    predictor.LightGBM != nil,
+		"spec.predictor.paddle":      predictor.Paddle != nil,
+	} {
+		if spec {
+			fields = append(fields, field)
+		}
+	}
+	for _, key := range []string{constants.DeploymentMode, constants.AutoscalerClass} {
+		if _, ok := isvc.Annotations[key]; ok {
+			fields = append(fields, "metadata.annotations["+key+"]")
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+	"github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"knative.dev/pkg/apis"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestConvertFrom(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	src := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sklearn",
+			Namespace: "default",
+			Annotations: map[string]string{
+				constants.DeploymentMode:  string(constants.RawDeployment),
+				constants.AutoscalerClass: string(constants.AutoscalerClassHPA),
+			},
+		},
+		Spec: v1beta1.InferenceServiceSpec{
+			Predictor: v1beta1.PredictorSpec{
+				SKLearn: &v1beta1.SKLearnSpec{
+					PredictorExtensionSpec: v1beta1.PredictorExtensionSpec{
+						StorageURI: proto.String("gs://kfserving-examples/models/sklearn/1.0/model"),
+					},
+				},
+				ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{
+					MinReplicas: v1beta1.GetIntReference(1),
+				},
+			},
+		},
+	}
+
+	dst := &InferenceService{}
+	g.Expect(dst.ConvertFrom(src)).Should(gomega.Succeed())
+	g.Expect(dst.Annotations).Should(gomega.HaveLen(1))
+	g.Expect(dst.Annotations).Should(gomega.HaveKey(LegacyPredictorAnnotationKey))
+	g.Expect(dst.Spec.DeploymentMode).Should(gomega.Equal(constants.RawDeployment))
+	g.Expect(dst.Spec.AutoscalerClass).Should(gomega.Equal(constants.AutoscalerClassHPA))
+	g.Expect(dst.Spec.Predictor.Model.ModelFormat.Name).Should(gomega.Equal(constants.SupportedModelSKLearn))
+	g.Expect(dst.Spec.Predictor.Model.StorageURI).Should(gomega.Equal(src.Spec.Predictor.SKLearn.StorageURI))
+	g.Expect(dst.Spec.Predictor.MinReplicas).Should(gomega.Equal(v1beta1.GetIntReference(1)))
+
+	// the source object is not modified
+	g.Expect(src.Spec.Predictor.SKLearn).ShouldNot(gomega.BeNil())
+	g.Expect(src.Annotations).Should(gomega.HaveLen(2))
+
+	g.Expect(GetDeprecatedFields(src)).Should(gomega.Equal([]string{
+		"metadata.annotations[" + constants.AutoscalerClass + "]",
+		"metadata.annotations[" + constants.DeploymentMode + "]",
+		"spec.predictor.sklearn",
+	}))
+}
+
+func TestConvertLegacyPredictorBack(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	src := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default"},
+		Spec: v1beta1.InferenceServiceSpec{
+			Predictor: v1beta1.PredictorSpec{
+				SKLearn: &v1beta1.SKLearnSpec{
+					PredictorExtensionSpec: v1beta1.PredictorExtensionSpec{
+						StorageURI: proto.String("gs://kfserving-examples/models/sklearn/1.0/model"),
+					},
+				},
+			},
+		},
+	}
+	isvc := &InferenceService{}
+	g.Expect(isvc.ConvertFrom(src)).Should(gomega.Succeed())
+
+	// the framework spec is restored when the model is unchanged
+	hub := &v1beta1.InferenceService{}
+	g.Expect(isvc.ConvertTo(hub)).Should(gomega.Succeed())
+	g.Expect(hub).Should(gomega.Equal(src))
+
+	// the model edited in v1 replaces the framework spec
+	isvc.Spec.Predictor.Model.StorageURI = proto.String("gs://kfserving-examples/models/sklearn/2.0/model")
+	hub = &v1beta1.InferenceService{}
+	g.Expect(isvc.ConvertTo(hub)).Should(gomega.Succeed())
+	g.Expect(hub.Annotations).Should(gomega.BeNil())
+	g.Expect(hub.Spec.Predictor.SKLearn).Should(gomega.BeNil())
+	g.Expect(hub.Spec.Predictor.Model.StorageURI).Should(gomega.Equal(isvc.Spec.Predictor.Model.StorageURI))
+}
+
+func TestConvertRoundTrip(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	src := &InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "custom",
+			Namespace:   "default",
+			Annotations: map[string]string{"foo": "bar"},
+		},
+		Spec: InferenceServiceSpec{
+			DeploymentMode: constants.Serverless,
+			Predictor: PredictorSpec{
+				PodSpec: v1beta1.PodSpec{
+					Containers: []v1.Container{{Name: constants.InferenceServiceContainerName, Image: "custom/image:latest"}},
+				},
+			},
+			Transformer: &v1beta1.TransformerSpec{
+				PodSpec: v1beta1.PodSpec{
+					Containers: []v1.Container{{Name: constants.InferenceServiceContainerName, Image: "transformer:latest"}},
+				},
+			},
+		},
+	}
+
+	hub := &v1beta1.InferenceService{}
+	g.Expect(src.ConvertTo(hub)).Should(gomega.Succeed())
+	g.Expect(hub.Annotations).Should(gomega.Equal(map[string]string{
+		"foo":                    "bar",
+		constants.DeploymentMode: string(constants.Serverless),
+	}))
+	g.Expect(hub.Spec.Predictor.Containers).Should(gomega.Equal(src.Spec.Predictor.Containers))
+
+	dst := &InferenceService{}
+	g.Expect(dst.ConvertFrom(hub)).Should(gomega.Succeed())
+	g.Expect(dst).Should(gomega.Equal(src))
+}
+
+func TestConvertFuzzRoundTrip(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	// the values with a json encoding are kept valid since the InferenceServices are decoded from json
+	fuzzer := fuzz.NewWithSeed(1).NilChance(0.3).NumElements(0, 2).Funcs(
+		func(fields *metav1.FieldsV1, c fuzz.Continue) {
+			fields.Raw = []byte("{}")
+		},
+		func(url *apis.URL, c fuzz.Continue) {
+			*url = *apis.HTTP(fmt.Sprintf("model-%d.example.com", c.Uint32()))
+		},
+		func(quantity *resource.Quantity, c fuzz.Continue) {
+			*quantity = *resource.NewQuantity(c.Int63n(1000), resource.DecimalSI)
+		},
+		func(value *intstr.IntOrString, c fuzz.Continue) {
+			if c.RandBool() {
+				*value = intstr.FromInt32(c.Int31())
+			} else {
+				*value = intstr.FromString(c.RandString())
+			}
+		},
+	)
+	for i := 0; i < 500; i++ {
+		src := &v1beta1.InferenceService{}
+		fuzzer.Fuzz(src)
+		// the type meta is set by the conversion webhook
+		src.TypeMeta = metav1.TypeMeta{}
+		// the InferenceServices are decoded from json, e.g. there are no pointers to nil maps
+		data, err := json.Marshal(src)
+		g.Expect(err).ShouldNot(gomega.HaveOccurred())
+		src = &v1beta1.InferenceService{}
+		g.Expect(json.Unmarshal(data, src)).Should(gomega.Succeed())
+		isvc := &InferenceService{}
+		g.Expect(isvc.ConvertFrom(src)).Should(gomega.Succeed())
+		hub := &v1beta1.InferenceService{}
+		g.Expect(isvc.ConvertTo(hub)).Should(gomega.Succeed())
+		g.Expect(equality.Semantic.DeepEqual(hub, src)).Should(gomega.BeTrue(), "round trip %d", i)
+	}
+}
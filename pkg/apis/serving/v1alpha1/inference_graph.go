@@ -17,10 +17,18 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"encoding/json"
+	"text/template"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"github.com/kserve/kserve/pkg/cors"
+	"github.com/kserve/kserve/pkg/utils"
 )
 
 // InferenceGraph is the Schema for the InferenceGraph API for multiple models
@@ -31,7 +39,10 @@ import (
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="URL",type="string",JSONPath=".status.url"
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="Prev",type="integer",JSONPath=".status.revisions.traffic[?(@.tag=='prev')].percent"
+// +kubebuilder:printcolumn:name="Latest",type="integer",JSONPath=".status.revisions.traffic[?(@.latestRevision==true)].percent"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Cost/h",type="string",JSONPath=".metadata.annotations.serving\\.kserve\\.io/estimated-hourly-cost",priority=1
 // +kubebuilder:resource:path=inferencegraphs,shortName=ig,singular=inferencegraph
 type InferenceGraph struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -50,6 +61,47 @@ type InferenceGraphSpec struct {
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 	// +optional
 	Affinity *corev1.Affinity `json:"affinity,omitempty" protobuf:"bytes,18,opt,name=affinity"`
+	// If specified, the router pod's tolerations.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// NodeSelector is a selector which must be true for the router pod to fit on a node.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// If specified, indicates the priority of the router pod.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// TopologySpreadConstraints describes how the router pods ought to spread across topology domains.
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// ServiceAccountName is the name of the ServiceAccount the router pod runs with, e.g. to pull the router image from a
+	// private registry or to use a workload identity. Defaults to the default ServiceAccount of the namespace.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// ImagePullSecrets are the secrets used to pull the router image, they are merged with the default image pull secrets
+	// of the inferenceservice-config ConfigMap.
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// Volumes added to the router pod, e.g. a custom CA bundle or the files read by the condition templates. The names
+	// of the volumes created by the router, router-tls, router-ca-bundle and router-plugins, are reserved.
+	// +optional
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+	// VolumeMounts of the volumes in the router container, they must mount the volumes of the graph outside of the
+	// directories of the router.
+	// +optional
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+	// RouterImage overrides the image of the router ConfigMap entry for this graph, e.g. to canary a router build. It takes
+	// precedence over a router image rollout.
+	// +optional
+	RouterImage string `json:"routerImage,omitempty"`
+	// HeadersToPropagate lists the headers, or regular expressions matching them, the router forwards from the request
+	// of the graph to its steps, e.g. graph specific authorization or tracing headers. They are added to the headers of
+	// the router ConfigMap entry, or replace them when the headersPropagationPolicy is Replace.
+	// +optional
+	HeadersToPropagate []string `json:"headersToPropagate,omitempty"`
+	// HeadersPropagationPolicy defines how the headersToPropagate are combined with the headers of the router ConfigMap
+	// entry, defaults to Merge.
+	// +optional
+	HeadersPropagationPolicy HeadersPropagationPolicy `json:"headersPropagationPolicy,omitempty"`
 	// TimeoutSeconds specifies the number of seconds to wait before timing out a request to the component.
 	// +optional
 	TimeoutSeconds *int64 `json:"timeout,omitempty"`
@@ -69,15 +121,314 @@ type InferenceGraphSpec struct {
 	// Knative Pod Autoscaler(https://knative.dev/docs/serving/autoscaling/autoscaling-metrics).
 	// +optional
 	ScaleMetric *ScaleMetric `json:"scaleMetric,omitempty"`
+	// The deployment strategy to use to replace existing router pods with new ones. Only applicable for raw deployment mode.
+	// +optional
+	DeploymentStrategy *appsv1.DeploymentStrategy `json:"deploymentStrategy,omitempty"`
+	// Minimum number of seconds a new router pod should be ready without any of its containers crashing before it is
+	// considered available and the rollout proceeds. Only applicable for raw deployment mode.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinReadySeconds *int32 `json:"minReadySeconds,omitempty"`
+	// CanaryTrafficPercent defines the traffic split percentage between the candidate revision and the last rolled out
+	// revision of the router. In Serverless mode the last rolled out Knative revision is pinned. In raw deployment mode
+	// the candidate revision runs in a second Deployment behind the Service of the graph, the traffic is then split by
+	// the number of replicas of the two Deployments, the last rolled out revision being scaled up within MaxReplicas to
+	// deliver the percentage. The candidate revision is rolled out when it is not set or 100.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	CanaryTrafficPercent *int64 `json:"canaryTrafficPercent,omitempty"`
+	// SmokeTest is a golden request sent to the InferenceGraph after each rollout, the InferenceGraph is not
+	// marked ready until the response matches the expected status code and assertions.
+	// +optional
+	SmokeTest *SmokeTestSpec `json:"smokeTest,omitempty"`
+	// Plugins are the router plugins the nodes of the graph can use to process their requests and responses
+	// +optional
+	Plugins []RouterPluginSource `json:"plugins,omitempty"`
+	// ActiveHours restricts the router to cron-based windows, e.g. business hours, it is removed outside the windows
+	// and created again when the next window opens.
+	// +optional
+	ActiveHours *ActiveHours `json:"activeHours,omitempty"`
+	// Quota specifies per-tenant request quotas enforced by the router, e.g. for a graph shared by several teams
+	// +optional
+	Quota *QuotaSpec `json:"quota,omitempty"`
+	// Log level and format of the router
+	// +optional
+	SidecarLogging *SidecarLoggingSpec `json:"sidecarLogging,omitempty"`
+	// Overrides of the security context of the router, e.g. to run it with the supplemental groups required by a
+	// storage driver. The overrides must be within the bounds set by the administrator in the security config.
+	// +optional
+	RouterSecurityContext *RouterSecurityContext `json:"routerSecurityContext,omitempty"`
+	// CORS allows the browsers to call the graph from other origins, the policy is enforced by the router
+	// +optional
+	CORS *CORSPolicy `json:"cors,omitempty"`
+	// CircuitBreaker ejects the target services of the steps which keep failing, so that the requests to a failing
+	// target fail right away instead of queuing up in the router. It applies to the steps without a circuit breaker of
+	// their own.
+	// +optional
+	CircuitBreaker *CircuitBreakerPolicy `json:"circuitBreaker,omitempty"`
+	// LoadShedding rejects the requests the router cannot serve with 503 Service Unavailable instead of queuing them
+	// +optional
+	LoadShedding *LoadSheddingPolicy `json:"loadShedding,omitempty"`
+}
+
+// RouterPluginSource defines where the Go plugin of a router plugin is loaded from, exactly one of configMap and
+// image must be specified. The plugin has to be built with the Go version and the kserve module of the router
+// release.
+// +k8s:openapi-gen=true
+type RouterPluginSource struct {
+	// Name of the plugin referenced by the nodes
+	Name string `json:"name"`
+	// ConfigMap key holding the plugin shared object in its binaryData
+	// +optional
+	ConfigMap *corev1.ConfigMapKeySelector `json:"configMap,omitempty"`
+	// Image holding the plugin shared object, it is copied by an init container which runs cp in the image
+	// +optional
+	Image string `json:"image,omitempty"`
+	// Path of the plugin shared object in the image, defaults to /plugin.so
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
+// NodePlugin references a router plugin processing the requests and the responses of a node
+// +k8s:openapi-gen=true
+type NodePlugin struct {
+	// Name of the plugin in the plugins of the graph
+	Name string `json:"name"`
+	// Config passed to the plugin
+	// +optional
+	Config map[string]string `json:"config,omitempty"`
+}
+
+// QuotaSpec specifies per-tenant request quotas, the requests over the quota of their tenant are rejected with
+// 429 Too Many Requests. The requests are counted over fixed windows of the period by each replica independently.
+// The tenant of a request is the user its bearer token is authenticated as with a TokenReview, e.g.
+// system:serviceaccount:team-a:client, so the service account of the router must be allowed to create tokenreviews.
+// The requests with a token which is not authenticated are rejected with 401 Unauthorized.
+// +k8s:openapi-gen=true
+type QuotaSpec struct {
+	// Audiences the bearer tokens are reviewed for, the audiences of the API server when not set
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+	// Period the requests are counted over, e.g. 1m or 24h
+	Period metav1.Duration `json:"period"`
+	// Number of requests per period shared by the tenants without a quota of their own and by the requests without
+	// a bearer token. They are unlimited when not set.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	DefaultLimit *int64 `json:"defaultLimit,omitempty"`
+	// Number of requests per period, by tenant
+	// +optional
+	Tenants map[string]int64 `json:"tenants,omitempty"`
+}
+
+// SidecarLogLevel enum
+// +kubebuilder:validation:Enum=debug;info;warn;error
+type SidecarLogLevel string
+
+const (
+	SidecarLogLevelDebug SidecarLogLevel = "debug"
+	SidecarLogLevelInfo  SidecarLogLevel = "info"
+	SidecarLogLevelWarn  SidecarLogLevel = "warn"
+	SidecarLogLevelError SidecarLogLevel = "error"
+)
+
+// SidecarLogFormat enum
+// +kubebuilder:validation:Enum=json;text
+type SidecarLogFormat string
+
+const (
+	SidecarLogFormatJSON SidecarLogFormat = "json"
+	SidecarLogFormatText SidecarLogFormat = "text"
+)
+
+// SidecarLoggingSpec sets the log level and format of the router, the defaults of the router are kept for the
+// fields which are not set.
+// +k8s:openapi-gen=true
+type SidecarLoggingSpec struct {
+	// Minimum level of the logs written by the router
+	// +optional
+	Level SidecarLogLevel `json:"level,omitempty"`
+	// Format of the logs written by the router, one json object per line or human readable text
+	// +optional
+	Format SidecarLogFormat `json:"format,omitempty"`
+}
+
+// RouterSecurityContext overrides fields of the security context of the router, the defaults of the router are kept
+// for the fields which are not set.
+// +k8s:openapi-gen=true
+type RouterSecurityContext struct {
+	// The UID to run the router container as
+	// +optional
+	RunAsUser *int64 `json:"runAsUser,omitempty"`
+	// The GID to run the router container as
+	// +optional
+	RunAsGroup *int64 `json:"runAsGroup,omitempty"`
+	// The supplemental groups of the router pod
+	// +optional
+	SupplementalGroups []int64 `json:"supplementalGroups,omitempty"`
+	// The SELinux context of the router container, only the type and the level can be set
+	// +optional
+	SELinuxOptions *corev1.SELinuxOptions `json:"seLinuxOptions,omitempty"`
+	// The capabilities added to the router container
+	// +optional
+	AddCapabilities []corev1.Capability `json:"addCapabilities,omitempty"`
+}
+
+// ToSecurityContext returns the overridden fields of the router container as a container security context
+func (s *RouterSecurityContext) ToSecurityContext() *corev1.SecurityContext {
+	if s == nil {
+		return nil
+	}
+	securityContext := &corev1.SecurityContext{
+		RunAsUser:      s.RunAsUser,
+		RunAsGroup:     s.RunAsGroup,
+		SELinuxOptions: s.SELinuxOptions,
+	}
+	if len(s.AddCapabilities) > 0 {
+		securityContext.Capabilities = &corev1.Capabilities{Add: s.AddCapabilities}
+	}
+	return securityContext
+}
+
+// CORSPolicy specifies the cross-origin requests the browsers are allowed to send
+// +k8s:openapi-gen=true
+type CORSPolicy struct {
+	// Origins allowed to send requests, e.g. https://app.example.com, "*" allows any origin
+	// +kubebuilder:validation:MinItems=1
+	AllowOrigins []string `json:"allowOrigins"`
+	// Methods allowed, they default to GET, HEAD and POST
+	// +optional
+	AllowMethods []string `json:"allowMethods,omitempty"`
+	// Request headers allowed besides the CORS-safelisted ones, e.g. Authorization and Content-Type, "*" allows any
+	// header
+	// +optional
+	AllowHeaders []string `json:"allowHeaders,omitempty"`
+	// How long in seconds the browsers cache the response to a preflight request
+	// +optional
+	MaxAge *int32 `json:"maxAge,omitempty"`
+}
+
+// Config returns the config of the CORS handler of the router
+func (c *CORSPolicy) Config() cors.Config {
+	return cors.Config{
+		AllowOrigins: c.AllowOrigins,
+		AllowMethods: c.AllowMethods,
+		AllowHeaders: c.AllowHeaders,
+		MaxAge:       c.MaxAge,
+	}
+}
+
+// CircuitBreakerPolicy ejects a target service after consecutive failed calls, the calls to the target fail right
+// away with 503 Service Unavailable while it is ejected. Once the ejection time elapses a single call is let through,
+// the target is ejected again when it fails. The calls are counted by each replica of the router independently.
+// +k8s:openapi-gen=true
+type CircuitBreakerPolicy struct {
+	// Number of consecutive calls, after their retries, failing with an error, a timeout or a 5xx status which eject
+	// the target
+	// +kubebuilder:validation:Minimum=1
+	ConsecutiveErrors int32 `json:"consecutiveErrors"`
+	// How long the target is ejected, e.g. 30s. Defaults to 30s.
+	// +optional
+	EjectionTime *metav1.Duration `json:"ejectionTime,omitempty"`
+}
+
+// DefaultCircuitBreakerEjectionTime is how long a target service is ejected by a circuit breaker without ejection time
+const DefaultCircuitBreakerEjectionTime = 30 * time.Second
+
+// LoadSheddingPolicy specifies the thresholds over which the router rejects the requests with 503 Service Unavailable
+// and a Retry-After header. The thresholds apply to each replica of the router independently.
+// +k8s:openapi-gen=true
+type LoadSheddingPolicy struct {
+	// Maximum number of requests processed concurrently by the router, the requests are unlimited when not set
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxInFlightRequests *int32 `json:"maxInFlightRequests,omitempty"`
+	// Maximum number of concurrent calls to a single target service, so that a slow target cannot hold all the
+	// requests of the router. The calls over it fail right away. The calls are unlimited when not set.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxInFlightCallsPerTarget *int32 `json:"maxInFlightCallsPerTarget,omitempty"`
+}
+
+// ActiveHours specifies the windows during which the router runs
+// +k8s:openapi-gen=true
+type ActiveHours struct {
+	// Windows during which the router runs, at least one of them must be open
+	// +kubebuilder:validation:MinItems=1
+	Windows []ActiveWindow `json:"windows"`
+	// IANA time zone the schedules are evaluated in, e.g. Europe/Paris, defaults to UTC
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// ActiveWindow is a window opened at the times of a cron schedule for a duration
+// +k8s:openapi-gen=true
+type ActiveWindow struct {
+	// Cron expression of five fields, minute hour day-of-month month day-of-week, the window opens at, e.g.
+	// "0 8 * * 1-5" for 8am on weekdays
+	Schedule string `json:"schedule"`
+	// How long the window stays open, e.g. 10h
+	Duration metav1.Duration `json:"duration"`
+}
+
+// IsActive returns whether one of the windows is open at now and the time of the next opening or closing of a window
+func (a *ActiveHours) IsActive(now time.Time) (bool, time.Time, error) {
+	windows := make([]utils.ActiveWindow, 0, len(a.Windows))
+	for _, window := range a.Windows {
+		windows = append(windows, utils.ActiveWindow{Schedule: window.Schedule, Duration: window.Duration.Duration})
+	}
+	return utils.GetActiveWindows(windows, a.TimeZone, now)
+}
+
+// SmokeTestSpec defines a golden request sent to the InferenceGraph and the expectations on its response
+// +k8s:openapi-gen=true
+type SmokeTestSpec struct {
+	// Path of the request relative to the URL of the InferenceGraph, defaults to /
+	// +optional
+	Path string `json:"path,omitempty"`
+	// JSON body of the request, it is sent with the POST method
+	Request string `json:"request"`
+	// Expected HTTP status code of the response, defaults to 200
+	// +optional
+	ExpectedStatusCode *int32 `json:"expectedStatusCode,omitempty"`
+	// Assertions on the JSON body of the response
+	// +optional
+	Assertions []SmokeTestAssertion `json:"assertions,omitempty"`
+}
+
+// SmokeTestAssertion checks the result of a JSONPath expression on the response, e.g. {.predictions[0]}
+// +k8s:openapi-gen=true
+type SmokeTestAssertion struct {
+	// JSONPath expression evaluated on the response
+	JSONPath string `json:"jsonPath"`
+	// Expected result of the expression, when not set the expression only needs to find a value
+	// +optional
+	Value *string `json:"value,omitempty"`
 }
 
 // ScaleMetric enum
 // +kubebuilder:validation:Enum=cpu;memory;concurrency;rps
 type ScaleMetric string
 
+// HeadersPropagationPolicy defines how the headers propagated by the router of a graph are combined with the headers of
+// the router ConfigMap entry
+// +k8s:openapi-gen=true
+// +kubebuilder:validation:Enum=Merge;Replace
+type HeadersPropagationPolicy string
+
+// HeadersPropagationPolicy Enum
+const (
+	// MergeHeaders propagates the headers of the graph in addition to the headers of the router ConfigMap entry
+	MergeHeaders HeadersPropagationPolicy = "Merge"
+
+	// ReplaceHeaders propagates the headers of the graph only
+	ReplaceHeaders HeadersPropagationPolicy = "Replace"
+)
+
 // InferenceRouterType constant for inference routing types
 // +k8s:openapi-gen=true
-// +kubebuilder:validation:Enum=Sequence;Splitter;Ensemble;Switch
+// +kubebuilder:validation:Enum=Sequence;Splitter;Ensemble;Switch;Map
 type InferenceRouterType string
 
 // InferenceRouterType Enum
@@ -93,6 +444,46 @@ const (
 
 	// Switch routes the request to the model based on certain condition
 	Switch InferenceRouterType = "Switch"
+
+	// Map splits the request into per-item requests to its step and reassembles the results in order
+	Map InferenceRouterType = "Map"
+)
+
+// Defaults of the Map nodes
+const (
+	// DefaultMapParallelism is the default maximum number of items sent to the step of a Map node in parallel
+	DefaultMapParallelism = 8
+	// DefaultMapMaxItems is the default maximum number of items of a request to a Map node
+	DefaultMapMaxItems = 256
+)
+
+// ResponseAggregationType defines how the responses of the steps of a Splitter or Ensemble node are merged
+// +k8s:openapi-gen=true
+// +kubebuilder:validation:Enum=Keyed;Array;FirstSuccess;MajorityVote;Average;Concat;Template
+type ResponseAggregationType string
+
+// ResponseAggregationType Enum
+const (
+	// Keyed merges the responses into an object keyed by step name, or by step index for unnamed steps
+	Keyed ResponseAggregationType = "Keyed"
+
+	// Array merges the responses into an array in the order of the steps
+	Array ResponseAggregationType = "Array"
+
+	// FirstSuccess returns the first successful response as is
+	FirstSuccess ResponseAggregationType = "FirstSuccess"
+
+	// MajorityVote returns for each instance the prediction returned by most steps
+	MajorityVote ResponseAggregationType = "MajorityVote"
+
+	// Average returns for each instance the element-wise average of the numeric predictions of the steps
+	Average ResponseAggregationType = "Average"
+
+	// Concat returns the predictions of the steps one after the other, in the order of the steps
+	Concat ResponseAggregationType = "Concat"
+
+	// ResponseTemplate renders the responses of the steps with the template of the aggregation
+	ResponseTemplate ResponseAggregationType = "Template"
 )
 
 const (
@@ -231,11 +622,134 @@ type InferenceRouter struct {
 	//
 	// - `Switch:` routes the request to one of the steps based on condition
 	//
+	// - `Map:` splits the request into per-item requests to its single step and reassembles the results in order
+	//
 	RouterType InferenceRouterType `json:"routerType"`
 
 	// Steps defines destinations for the current router node
 	// +optional
 	Steps []InferenceStep `json:"steps,omitempty"`
+
+	// ResponseAggregation defines how the responses of the steps are merged into the response of the node,
+	// only applies to Splitter and Ensemble nodes
+	//
+	// - `Keyed:` an object keyed by step name, or by step index for unnamed steps. Default for Ensemble nodes
+	//
+	// - `Array:` an array of the responses in the order of the steps
+	//
+	// - `FirstSuccess:` the first successful response as is. Default for Splitter nodes
+	//
+	// - `MajorityVote:` for each instance, the prediction returned by most steps. Only for Ensemble nodes
+	//
+	// - `Average:` for each instance, the element-wise average of the numeric predictions. Only for Ensemble nodes
+	//
+	// - `Concat:` the predictions of the steps one after the other. Only for Ensemble nodes
+	//
+	// - `Template:` the responses of the steps rendered with the template of the aggregation. Only for Ensemble nodes
+	//
+	// +optional
+	ResponseAggregation ResponseAggregationType `json:"responseAggregation,omitempty"`
+
+	// Aggregation configures the MajorityVote, Average, Concat and Template response aggregations of an Ensemble node
+	// +optional
+	Aggregation *AggregationSpec `json:"aggregation,omitempty"`
+
+	// Plugins process the request of the node before it is routed to the steps and the response of the node, in
+	// order
+	// +optional
+	Plugins []NodePlugin `json:"plugins,omitempty"`
+
+	// Map defines how a Map node splits its request and reassembles the results, only applies to Map nodes
+	// +optional
+	Map *MapRouterSpec `json:"map,omitempty"`
+
+	// Resources of the node when it is deployed separately from the other nodes of the graph. Every node is served by
+	// the router of the graph today, whose resources are set with spec.resources, so the resources of a node are
+	// rejected.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// AggregationSpec configures how an Ensemble node combines the predictions of its steps. The unsuccessful responses of
+// the soft dependencies are left out of the aggregation.
+type AggregationSpec struct {
+	// Protocol of the responses of the steps and of the node, defaults to `v1`. The predictions are read from the
+	// predictions field of V1 responses, and from the first dimension of the single output tensor of V2 responses.
+	// +kubebuilder:validation:Enum=v1;v2
+	// +optional
+	Format InferenceProtocolFormat `json:"format,omitempty"`
+
+	// Go template rendering the response of the node, required by the Template aggregation. The responses of the steps
+	// are available as .steps.<step name>, or by step index for unnamed steps, and the json function encodes a value as
+	// JSON, e.g. `{"predictions": {{ json .steps.classifier.predictions }}}`. The rendered response must be JSON.
+	// +optional
+	Template string `json:"template,omitempty"`
+}
+
+// GetFormat returns the protocol of the responses aggregated by the node
+func (a *AggregationSpec) GetFormat() InferenceProtocolFormat {
+	if a == nil || a.Format == "" {
+		return ProtocolFormatV1
+	}
+	return a.Format
+}
+
+// ParseTemplate parses the template of the Template aggregation
+func (a *AggregationSpec) ParseTemplate() (*template.Template, error) {
+	return template.New("aggregation").Funcs(template.FuncMap{
+		"json": func(value interface{}) (string, error) {
+			data, err := json.Marshal(value)
+			return string(data), err
+		},
+	}).Option("missingkey=error").Parse(a.Template)
+}
+
+// +k8s:openapi-gen=true
+// MapRouterSpec defines how a Map node splits its request into per-item requests to its step and reassembles the
+// results of the items in order
+type MapRouterSpec struct {
+	// Protocol of the request, defaults to `v1`. V1 requests are split along the array of the items field, V2
+	// requests along the first dimension of their input tensors.
+	// +kubebuilder:validation:Enum=v1;v2
+	// +optional
+	Format InferenceProtocolFormat `json:"format,omitempty"`
+
+	// Field of the V1 request holding the array of items, defaults to `instances`. Each item is sent in a copy of
+	// the request whose field holds an array of the single item.
+	// +optional
+	ItemsField string `json:"itemsField,omitempty"`
+
+	// Field of the V1 responses holding the result of an item, defaults to `predictions`. A result holding an array
+	// of a single element is unwrapped, the results are returned in the same field in the order of the items.
+	// +optional
+	ResultsField string `json:"resultsField,omitempty"`
+
+	// Maximum number of items sent to the step in parallel, defaults to 8
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Parallelism *int `json:"parallelism,omitempty"`
+
+	// Maximum number of items of a request, requests with more items are rejected. Defaults to 256
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxItems *int `json:"maxItems,omitempty"`
+}
+
+// GetParallelism returns the maximum number of items sent to the step of the Map node in parallel
+func (m *MapRouterSpec) GetParallelism() int {
+	if m == nil || m.Parallelism == nil {
+		return DefaultMapParallelism
+	}
+	return *m.Parallelism
+}
+
+// GetMaxItems returns the maximum number of items of a request to the Map node
+func (m *MapRouterSpec) GetMaxItems() int {
+	if m == nil || m.MaxItems == nil {
+		return DefaultMapMaxItems
+	}
+	return *m.MaxItems
 }
 
 // +k8s:openapi-gen=true
@@ -248,6 +762,12 @@ type InferenceTarget struct {
 	// named reference for InferenceService
 	ServiceName string `json:"serviceName,omitempty"`
 
+	// Namespace of the InferenceService named by serviceName, defaults to the namespace of the InferenceGraph. The
+	// InferenceServices of other namespaces can only be targeted when the router config enables cross namespace
+	// targets.
+	// +optional
+	ServiceNamespace string `json:"serviceNamespace,omitempty"`
+
 	// InferenceService URL, mutually exclusive with ServiceName
 	// +optional
 	ServiceURL string `json:"serviceUrl,omitempty"`
@@ -267,6 +787,27 @@ const (
 	Hard InferenceStepDependencyType = "Hard"
 )
 
+// ConditionNotMetAction defines the action of a Sequence node when the condition of a step does not match
+// +k8s:openapi-gen=true
+// +kubebuilder:validation:Enum=Stop;Skip
+type ConditionNotMetAction string
+
+// ConditionNotMetAction Enum
+const (
+	// Stop returns the response of the previous step
+	Stop ConditionNotMetAction = "Stop"
+
+	// Skip skips the step and continues with the next step
+	Skip ConditionNotMetAction = "Skip"
+)
+
+// Prefixes of the Sequence step conditions selecting the document the condition is matched against
+const (
+	ConditionRequestPrefix  = "$request."
+	ConditionResponsePrefix = "$response."
+	ConditionStepsPrefix    = "$steps."
+)
+
 // InferenceStep defines the inference target of the current step with condition, weights and data.
 // +k8s:openapi-gen=true
 type InferenceStep struct {
@@ -289,12 +830,129 @@ type InferenceStep struct {
 	Weight *int64 `json:"weight,omitempty"`
 
 	// routing based on the condition
+	//
+	// In a Sequence node the step is executed when the condition matches the response of the previous step. The
+	// condition can instead match the request of the node with the `$request.` prefix or the response of a previous
+	// step of the node with the `$steps.<step name>.` prefix, e.g. `$steps.classifier.predictions.#(label=="dog")`.
 	// +optional
 	Condition string `json:"condition,omitempty"`
 
+	// CEL expression routing the requests of a Switch node, the request is forwarded to the first step whose
+	// expression or condition matches. The expression can use the JSON body of the request as `body` and its headers,
+	// with lower case names, as `headers`, e.g. `body.instances[0].age >= 18 && headers["x-tenant"] == "gold"`.
+	// Only supported on the steps of a Switch node and cannot be combined with condition.
+	// +optional
+	Expression string `json:"expression,omitempty"`
+
+	// action of a Sequence node when the condition of the step does not match, `Stop` returns the response of the
+	// previous step and `Skip` continues with the next step. Defaults to `Stop`.
+	// +optional
+	OnConditionNotMet ConditionNotMetAction `json:"onConditionNotMet,omitempty"`
+
 	// to decide whether a step is a hard or a soft dependency in the Inference Graph
 	// +optional
 	Dependency InferenceStepDependencyType `json:"dependency,omitempty"`
+
+	// headers set on the requests to the target service of the step, they override the headers propagated by the
+	// router. Only supported on steps with a serviceName or serviceUrl target.
+	// +optional
+	Headers []StepHeader `json:"headers,omitempty"`
+
+	// names of the headers removed from the requests to the target service of the step, applied before the headers
+	// are set. Only supported on steps with a serviceName or serviceUrl target.
+	// +optional
+	RemoveHeaders []string `json:"removeHeaders,omitempty"`
+
+	// translation of the request of the step to the protocol of its target and of the response back, e.g. to let
+	// V1 clients call a V2 or OpenAI target without a dedicated transformer
+	// +optional
+	Translation *ProtocolTranslation `json:"translation,omitempty"`
+
+	// timeout of each call to the target service of the step, e.g. `30s` or `5m`, the call fails with a 504 status
+	// once it expires. The calls are only bounded by the timeouts of the router when not set. Only supported on steps
+	// with a serviceName or serviceUrl target.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// number of times the call to the target service of the step is retried when it fails with an error, a timeout or
+	// a 502, 503 or 504 status. The calls are not retried when not set. Only supported on steps with a serviceName or
+	// serviceUrl target.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	Retries *int32 `json:"retries,omitempty"`
+
+	// delay before the first retry of the call to the target service of the step, doubled on each following retry.
+	// Defaults to 100ms.
+	// +optional
+	RetryBackoff *metav1.Duration `json:"retryBackoff,omitempty"`
+
+	// circuit breaker of the target service of the step, it overrides the circuit breaker of the graph. Only supported
+	// on steps with a serviceName or serviceUrl target.
+	// +optional
+	CircuitBreaker *CircuitBreakerPolicy `json:"circuitBreaker,omitempty"`
+}
+
+// DefaultStepRetryBackoff is the delay before the first retry of the call to the target service of a step
+const DefaultStepRetryBackoff = 100 * time.Millisecond
+
+// MaxStepRetries is the maximum number of retries of the call to the target service of a step
+const MaxStepRetries = 10
+
+// InferenceProtocolFormat is the format of the requests and the responses of an inference protocol
+// +k8s:openapi-gen=true
+// +kubebuilder:validation:Enum=v1;v2;openai
+type InferenceProtocolFormat string
+
+// InferenceProtocolFormat Enum
+const (
+	// ProtocolFormatV1 is the V1 protocol, `{"instances": [...]}` requests and `{"predictions": [...]}` responses
+	ProtocolFormatV1 InferenceProtocolFormat = "v1"
+	// ProtocolFormatV2 is the V2 (Open Inference) protocol, the instances are the first dimension of the tensor
+	ProtocolFormatV2 InferenceProtocolFormat = "v2"
+	// ProtocolFormatOpenAI is the OpenAI completions API, the instances are the prompts
+	ProtocolFormatOpenAI InferenceProtocolFormat = "openai"
+)
+
+// +k8s:openapi-gen=true
+// ProtocolTranslation defines the translation of the request of a step from the protocol of the graph to the
+// protocol of the target of the step, the successful responses are translated back
+type ProtocolTranslation struct {
+	// Protocol of the request of the step
+	From InferenceProtocolFormat `json:"from"`
+
+	// Protocol of the target of the step
+	To InferenceProtocolFormat `json:"to"`
+
+	// Name of the V2 input tensor, defaults to `input-0`
+	// +optional
+	InputName string `json:"inputName,omitempty"`
+
+	// Datatype of the V2 input tensor, inferred from the instances when not set: `BYTES` for strings, `BOOL` for
+	// booleans and `FP32` for numbers
+	// +optional
+	Datatype string `json:"datatype,omitempty"`
+
+	// Model of the OpenAI requests and of the translated OpenAI and V2 responses
+	// +optional
+	Model string `json:"model,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// StepHeader defines a header set by the router on the requests to the target of a step, exactly one of value and
+// secretKeyRef must be specified
+type StepHeader struct {
+	// Name of the header
+	Name string `json:"name"`
+
+	// Value of the header, `$(VAR_NAME)` references are expanded with the environment variables of the router
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// Selects a key of a Secret in the namespace of the InferenceGraph as the value of the header, e.g. to pass an
+	// API key of the target service
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
 }
 
 // InferenceGraphStatus defines the InferenceGraph conditions and status
@@ -305,6 +963,109 @@ type InferenceGraphStatus struct {
 	// Url for the InferenceGraph
 	// +optional
 	URL *apis.URL `json:"url,omitempty"`
+	// Endpoints lists all the addresses the InferenceGraph is reachable at, per protocol and visibility
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Endpoints []Endpoint `json:"endpoints,omitempty"`
+	// Global configuration the InferenceGraph was built with at its last reconciliation
+	// +optional
+	EffectiveConfig *EffectiveConfig `json:"effectiveConfig,omitempty"`
+	// Result of the smoke test of the latest rollout
+	// +optional
+	SmokeTest *SmokeTestStatus `json:"smokeTest,omitempty"`
+	// Revisions of the router and the traffic split between them
+	// +optional
+	Revisions *RouterRevisionStatus `json:"revisions,omitempty"`
+}
+
+// RouterRevisionStatus describes the revisions of the router of the InferenceGraph. The revisions are the Knative
+// revisions in Serverless mode and the revisions of the router pod spec in raw deployment mode.
+// +k8s:openapi-gen=true
+type RouterRevisionStatus struct {
+	// Latest revision name that is in ready state
+	// +optional
+	LatestReadyRevision string `json:"latestReadyRevision,omitempty"`
+	// Latest revision name that is created
+	// +optional
+	LatestCreatedRevision string `json:"latestCreatedRevision,omitempty"`
+	// Previous revision name that is rolled out with 100 percent traffic
+	// +optional
+	PreviousRolledoutRevision string `json:"previousRolledoutRevision,omitempty"`
+	// Latest revision name that is rolled out with 100 percent traffic
+	// +optional
+	LatestRolledoutRevision string `json:"latestRolledoutRevision,omitempty"`
+	// Traffic holds the traffic distribution between the latest revision and the previous rolled out revision
+	// +optional
+	Traffic []RouterTrafficTarget `json:"traffic,omitempty"`
+}
+
+// RouterTrafficTarget is the percentage of the traffic of the InferenceGraph served by a revision of its router
+// +k8s:openapi-gen=true
+type RouterTrafficTarget struct {
+	// Name of the revision
+	RevisionName string `json:"revisionName"`
+	// Whether the revision is the latest revision of the router
+	// +optional
+	LatestRevision bool `json:"latestRevision,omitempty"`
+	// Percentage of the traffic served by the revision
+	Percent int64 `json:"percent"`
+	// Tag of the revision, prev for the previous rolled out revision
+	// +optional
+	Tag string `json:"tag,omitempty"`
+}
+
+// SmokeTestSucceeded is set when the smoke test of the latest generation of the InferenceGraph got the expected response
+const SmokeTestSucceeded apis.ConditionType = "SmokeTestSucceeded"
+
+// TargetsReady is set on the InferenceGraphs with steps targeting an InferenceService by name, it is false while one of
+// the InferenceServices is missing or not ready
+const TargetsReady apis.ConditionType = "TargetsReady"
+
+// SmokeTestStatus is the result of the smoke test of a generation of the InferenceGraph
+// +k8s:openapi-gen=true
+type SmokeTestStatus struct {
+	// Generation of the InferenceGraph the smoke test was run against
+	ObservedGeneration int64 `json:"observedGeneration"`
+	// Whether the response matched the expected status code and assertions
+	Succeeded bool `json:"succeeded"`
+	// Reason of the failure of the smoke test
+	// +optional
+	Message string `json:"message,omitempty"`
+	// Time of the last run of the smoke test
+	// +optional
+	LastRunTime *metav1.Time `json:"lastRunTime,omitempty"`
+}
+
+// Endpoint is an address the InferenceGraph is reachable at
+// +k8s:openapi-gen=true
+type Endpoint struct {
+	// Name of the endpoint, external or cluster-local
+	Name string `json:"name"`
+	// Visibility of the endpoint, External or ClusterLocal
+	Visibility string `json:"visibility"`
+	// Protocol served at the endpoint, http or https
+	Protocol string `json:"protocol"`
+	// URL of the endpoint
+	URL *apis.URL `json:"url"`
+}
+
+// EffectiveConfig is the resolved global configuration an InferenceGraph was built with, so that it can be
+// told apart from the current content of the inferenceservice-config ConfigMap
+// +k8s:openapi-gen=true
+type EffectiveConfig struct {
+	// Resource version of the inferenceservice-config ConfigMap
+	// +optional
+	ConfigMapResourceVersion string `json:"configMapResourceVersion,omitempty"`
+	// Resolved deployment mode
+	// +optional
+	DeploymentMode string `json:"deploymentMode,omitempty"`
+	// Image of the router
+	// +optional
+	RouterImage string `json:"routerImage,omitempty"`
+	// Resolved autoscaler class
+	// +optional
+	AutoscalerClass string `json:"autoscalerClass,omitempty"`
 }
 
 // InferenceGraphList contains a list of InferenceGraph
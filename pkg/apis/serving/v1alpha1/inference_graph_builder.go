@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// InferenceGraphBuilder builds an InferenceGraph with a fluent API, e.g.
+//
+//	NewInferenceGraphBuilder("graph", "default").
+//		Node(GraphRootNodeName, Sequence,
+//			NewStepBuilder("preprocess").Service("transformer").Build(),
+//			NewStepBuilder("predict").Service("model").Hard().Build()).
+//		Build()
+//
+// +kubebuilder:object:generate=false
+type InferenceGraphBuilder struct {
+	graph InferenceGraph
+}
+
+// NewInferenceGraphBuilder returns a builder of an InferenceGraph without nodes
+func NewInferenceGraphBuilder(name, namespace string) *InferenceGraphBuilder {
+	return &InferenceGraphBuilder{graph: InferenceGraph{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       InferenceGraphSpec{Nodes: map[string]InferenceRouter{}},
+	}}
+}
+
+// Annotation sets an annotation of the InferenceGraph
+func (b *InferenceGraphBuilder) Annotation(key, value string) *InferenceGraphBuilder {
+	if b.graph.Annotations == nil {
+		b.graph.Annotations = map[string]string{}
+	}
+	b.graph.Annotations[key] = value
+	return b
+}
+
+// Label sets a label of the InferenceGraph
+func (b *InferenceGraphBuilder) Label(key, value string) *InferenceGraphBuilder {
+	if b.graph.Labels == nil {
+		b.graph.Labels = map[string]string{}
+	}
+	b.graph.Labels[key] = value
+	return b
+}
+
+// Node adds a node routing to the steps, the entrypoint of the graph is the GraphRootNodeName node
+func (b *InferenceGraphBuilder) Node(name string, routerType InferenceRouterType, steps ...InferenceStep) *InferenceGraphBuilder {
+	return b.Router(name, InferenceRouter{RouterType: routerType, Steps: steps})
+}
+
+// Router adds a node with the full router spec, e.g. for the plugins or the map settings of the node
+func (b *InferenceGraphBuilder) Router(name string, router InferenceRouter) *InferenceGraphBuilder {
+	b.graph.Spec.Nodes[name] = router
+	return b
+}
+
+// Resources sets the resources of the router container
+func (b *InferenceGraphBuilder) Resources(resources corev1.ResourceRequirements) *InferenceGraphBuilder {
+	b.graph.Spec.Resources = resources
+	return b
+}
+
+// Replicas sets the min and max replicas of the router
+func (b *InferenceGraphBuilder) Replicas(minReplicas, maxReplicas int) *InferenceGraphBuilder {
+	b.graph.Spec.MinReplicas = &minReplicas
+	b.graph.Spec.MaxReplicas = maxReplicas
+	return b
+}
+
+// TimeoutSeconds sets the timeout of the graph requests
+func (b *InferenceGraphBuilder) TimeoutSeconds(timeout int64) *InferenceGraphBuilder {
+	b.graph.Spec.TimeoutSeconds = &timeout
+	return b
+}
+
+// Build returns a copy of the InferenceGraph, the builder can be reused to build variants
+func (b *InferenceGraphBuilder) Build() *InferenceGraph {
+	return b.graph.DeepCopy()
+}
+
+// StepBuilder builds an InferenceStep with a fluent API, exactly one of Service, URL and Node must be called
+// +kubebuilder:object:generate=false
+type StepBuilder struct {
+	step InferenceStep
+}
+
+// NewStepBuilder returns a builder of the named step
+func NewStepBuilder(name string) *StepBuilder {
+	return &StepBuilder{step: InferenceStep{StepName: name}}
+}
+
+// Service targets the InferenceService of the namespace of the graph
+func (b *StepBuilder) Service(serviceName string) *StepBuilder {
+	b.step.ServiceName = serviceName
+	return b
+}
+
+// URL targets the service URL
+func (b *StepBuilder) URL(serviceURL string) *StepBuilder {
+	b.step.ServiceURL = serviceURL
+	return b
+}
+
+// Node targets another node of the graph
+func (b *StepBuilder) Node(nodeName string) *StepBuilder {
+	b.step.NodeName = nodeName
+	return b
+}
+
+// Data sets the data sent to the target, $request for the request of the node or $response for the response of
+// the previous step
+func (b *StepBuilder) Data(data string) *StepBuilder {
+	b.step.Data = data
+	return b
+}
+
+// Weight sets the share of the traffic of the step of a Splitter node
+func (b *StepBuilder) Weight(weight int64) *StepBuilder {
+	b.step.Weight = &weight
+	return b
+}
+
+// Condition sets the GJSON condition the step runs on
+func (b *StepBuilder) Condition(condition string) *StepBuilder {
+	b.step.Condition = condition
+	return b
+}
+
+// Hard fails the graph request when the step fails
+func (b *StepBuilder) Hard() *StepBuilder {
+	b.step.Dependency = Hard
+	return b
+}
+
+// Header sets a header on the requests of the step
+func (b *StepBuilder) Header(name, value string) *StepBuilder {
+	b.step.Headers = append(b.step.Headers, StepHeader{Name: name, Value: value})
+	return b
+}
+
+// Build returns a copy of the step, the builder can be reused to build variants
+func (b *StepBuilder) Build() InferenceStep {
+	return *b.step.DeepCopy()
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestInferenceGraphBuilder(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	builder := NewInferenceGraphBuilder("graph", "default").
+		Annotation("serving.kserve.io/deploymentMode", "RawDeployment").
+		Node(GraphRootNodeName, Sequence,
+			NewStepBuilder("preprocess").Service("transformer").Build(),
+			NewStepBuilder("split").Node("splitter").Data("$response").Hard().Build()).
+		Node("splitter", Splitter,
+			NewStepBuilder("a").Service("model-a").Weight(80).Build(),
+			NewStepBuilder("b").URL("http://model-b.default.svc.cluster.local").Weight(20).
+				Header("X-Model", "b").Build()).
+		Replicas(1, 2).
+		TimeoutSeconds(30)
+	graph := builder.Build()
+
+	g.Expect(graph.Spec.Nodes).Should(gomega.HaveLen(2))
+	root := graph.Spec.Nodes[GraphRootNodeName]
+	g.Expect(root.RouterType).Should(gomega.Equal(Sequence))
+	g.Expect(root.Steps[1].NodeName).Should(gomega.Equal("splitter"))
+	g.Expect(root.Steps[1].Dependency).Should(gomega.Equal(Hard))
+	splitter := graph.Spec.Nodes["splitter"]
+	g.Expect(*splitter.Steps[0].Weight).Should(gomega.Equal(int64(80)))
+	g.Expect(splitter.Steps[1].Headers).Should(gomega.Equal([]StepHeader{{Name: "X-Model", Value: "b"}}))
+	g.Expect(*graph.Spec.TimeoutSeconds).Should(gomega.Equal(int64(30)))
+	_, err := graph.ValidateCreate()
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+
+	// the builder returns copies so that it can be reused to build variants
+	variant := builder.Node("extra", Ensemble, NewStepBuilder("c").Service("model-c").Build()).Build()
+	g.Expect(variant.Spec.Nodes).Should(gomega.HaveLen(3))
+	g.Expect(graph.Spec.Nodes).Should(gomega.HaveLen(2))
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// RouterConfigKeyName is the key of the router configuration in the inferenceservice-config ConfigMap
+const RouterConfigKeyName = "router"
+
+// GraphLimits guards the router against the combinatorial explosion of complex graphs, zero means unlimited
+// +kubebuilder:object:generate=false
+type GraphLimits struct {
+	// MaxNodesVisited is the maximum number of nodes the router visits for a single request
+	MaxNodesVisited int `json:"maxNodesVisited,omitempty"`
+	// MaxFanOut is the maximum number of steps the router executes in parallel for a single request
+	MaxFanOut int `json:"maxFanOut,omitempty"`
+}
+
+var graphLimits atomic.Pointer[GraphLimits]
+
+// SetGraphLimits sets the limits the InferenceGraphs are validated against
+func SetGraphLimits(limits *GraphLimits) {
+	graphLimits.Store(limits)
+}
+
+func getGraphLimits() *GraphLimits {
+	if limits := graphLimits.Load(); limits != nil {
+		return limits
+	}
+	return &GraphLimits{}
+}
+
+func NewGraphLimits(clientset kubernetes.Interface) (*GraphLimits, error) {
+	configMap, err := clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Get(context.TODO(), constants.InferenceServiceConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return GetGraphLimits(configMap)
+}
+
+func GetGraphLimits(configMap *v1.ConfigMap) (*GraphLimits, error) {
+	limits := &GraphLimits{}
+	if router, ok := configMap.Data[RouterConfigKeyName]; ok {
+		if err := json.Unmarshal([]byte(router), limits); err != nil {
+			return nil, fmt.Errorf("unable to parse router config json: %w", err)
+		}
+	}
+	if limits.MaxNodesVisited < 0 || limits.MaxFanOut < 0 {
+		return nil, fmt.Errorf("invalid router config - maxNodesVisited and maxFanOut must not be negative")
+	}
+	return limits, nil
+}
+
+// graphComplexity returns the worst case number of nodes visited and of steps executed in parallel for a single
+// request, starting at the given node. An error is returned when the graph contains a cycle since neither is bounded.
+func graphComplexity(spec *InferenceGraphSpec, nodeName string, visiting map[string]bool) (int, int, error) {
+	node, ok := spec.Nodes[nodeName]
+	if !ok {
+		return 1, 1, nil
+	}
+	if visiting[nodeName] {
+		return 0, 0, fmt.Errorf(GraphCycleError, nodeName)
+	}
+	visiting[nodeName] = true
+	defer delete(visiting, nodeName)
+
+	nodesVisited, fanOut := 0, 0
+	for _, step := range node.Steps {
+		stepNodes, stepFanOut := 0, 1
+		if step.NodeName != "" {
+			var err error
+			if stepNodes, stepFanOut, err = graphComplexity(spec, step.NodeName, visiting); err != nil {
+				return 0, 0, err
+			}
+		}
+		switch node.RouterType {
+		case Ensemble:
+			// the steps of an ensemble are executed in parallel
+			nodesVisited += stepNodes
+			fanOut += stepFanOut
+		case Map:
+			// the step of a map is executed for each item, up to parallelism items in parallel
+			nodesVisited += node.Map.GetMaxItems() * stepNodes
+			fanOut = node.Map.GetParallelism() * stepFanOut
+		case Splitter, Switch:
+			// a single step of a splitter or a switch is executed
+			nodesVisited = max(nodesVisited, stepNodes)
+			fanOut = max(fanOut, stepFanOut)
+		default:
+			nodesVisited += stepNodes
+			fanOut = max(fanOut, stepFanOut)
+		}
+	}
+	return nodesVisited + 1, max(fanOut, 1), nil
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestGetGraphLimits(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	limits, err := GetGraphLimits(&v1.ConfigMap{})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(limits).Should(gomega.Equal(&GraphLimits{}))
+
+	limits, err = GetGraphLimits(&v1.ConfigMap{Data: map[string]string{
+		RouterConfigKeyName: `{"image": "kserve/router:latest", "maxNodesVisited": 10, "maxFanOut": 4}`,
+	}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(limits).Should(gomega.Equal(&GraphLimits{MaxNodesVisited: 10, MaxFanOut: 4}))
+
+	_, err = GetGraphLimits(&v1.ConfigMap{Data: map[string]string{RouterConfigKeyName: `{"maxFanOut": -1}`}})
+	g.Expect(err).Should(gomega.HaveOccurred())
+}
+
+func TestValidateInferenceGraphLimits(t *testing.T) {
+	serviceStep := InferenceStep{InferenceTarget: InferenceTarget{ServiceName: "model"}}
+	nodeStep := func(name string) InferenceStep {
+		return InferenceStep{InferenceTarget: InferenceTarget{NodeName: name}}
+	}
+	two, three := 2, 3
+	scenarios := map[string]struct {
+		nodes       map[string]InferenceRouter
+		limits      GraphLimits
+		expectedErr string
+	}{
+		"Unlimited": {
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {RouterType: Sequence, Steps: []InferenceStep{nodeStep(GraphRootNodeName)}},
+			},
+		},
+		"WithinLimits": {
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {RouterType: Sequence, Steps: []InferenceStep{nodeStep("ensemble"), serviceStep}},
+				"ensemble":        {RouterType: Ensemble, Steps: []InferenceStep{serviceStep, serviceStep, serviceStep}},
+			},
+			limits: GraphLimits{MaxNodesVisited: 2, MaxFanOut: 3},
+		},
+		"TooManyNodes": {
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {RouterType: Ensemble, Steps: []InferenceStep{nodeStep("a"), nodeStep("b")}},
+				"a":               {RouterType: Sequence, Steps: []InferenceStep{serviceStep}},
+				"b":               {RouterType: Sequence, Steps: []InferenceStep{nodeStep("a")}},
+			},
+			limits:      GraphLimits{MaxNodesVisited: 3},
+			expectedErr: `InferenceGraph "foo-bar" can visit up to 4 nodes for a single request which exceeds the limit of 3`,
+		},
+		"SplitterVisitsASingleStep": {
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {RouterType: Splitter, Steps: []InferenceStep{nodeStep("a"), nodeStep("b")}},
+				"a":               {RouterType: Sequence, Steps: []InferenceStep{serviceStep}},
+				"b":               {RouterType: Sequence, Steps: []InferenceStep{nodeStep("a")}},
+			},
+			limits: GraphLimits{MaxNodesVisited: 3},
+		},
+		"TooManyParallelSteps": {
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {RouterType: Ensemble, Steps: []InferenceStep{nodeStep("ensemble"), serviceStep}},
+				"ensemble":        {RouterType: Ensemble, Steps: []InferenceStep{serviceStep, serviceStep, serviceStep}},
+			},
+			limits:      GraphLimits{MaxFanOut: 3},
+			expectedErr: `InferenceGraph "foo-bar" can execute up to 4 steps in parallel for a single request which exceeds the limit of 3`,
+		},
+		"MapWithinLimits": {
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {RouterType: Map, Steps: []InferenceStep{serviceStep}},
+			},
+			limits: GraphLimits{MaxNodesVisited: 1, MaxFanOut: DefaultMapParallelism},
+		},
+		"MapTooManyParallelSteps": {
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {RouterType: Map, Steps: []InferenceStep{nodeStep("ensemble")},
+					Map: &MapRouterSpec{Parallelism: &two, MaxItems: &three}},
+				"ensemble": {RouterType: Ensemble, Steps: []InferenceStep{serviceStep, serviceStep, serviceStep}},
+			},
+			limits:      GraphLimits{MaxNodesVisited: 4, MaxFanOut: 4},
+			expectedErr: `InferenceGraph "foo-bar" can execute up to 6 steps in parallel for a single request which exceeds the limit of 4`,
+		},
+		"MapTooManyNodes": {
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {RouterType: Map, Steps: []InferenceStep{nodeStep("a")},
+					Map: &MapRouterSpec{MaxItems: &three}},
+				"a": {RouterType: Sequence, Steps: []InferenceStep{serviceStep}},
+			},
+			limits:      GraphLimits{MaxNodesVisited: 3},
+			expectedErr: `InferenceGraph "foo-bar" can visit up to 4 nodes for a single request which exceeds the limit of 3`,
+		},
+		"Cycle": {
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {RouterType: Sequence, Steps: []InferenceStep{nodeStep("a")}},
+				"a":               {RouterType: Switch, Steps: []InferenceStep{nodeStep(GraphRootNodeName), serviceStep}},
+			},
+			limits:      GraphLimits{MaxNodesVisited: 10},
+			expectedErr: `InferenceGraph "foo-bar" is invalid: the graph contains a cycle through node "root", the number of nodes visited by a request is unbounded`,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			ig := makeTestInferenceGraph()
+			ig.Spec.Nodes = scenario.nodes
+			err := validateInferenceGraphLimits(&ig, &scenario.limits)
+			if scenario.expectedErr == "" {
+				g.Expect(err).ShouldNot(gomega.HaveOccurred())
+			} else {
+				g.Expect(err).Should(gomega.MatchError(scenario.expectedErr))
+			}
+		})
+	}
+}
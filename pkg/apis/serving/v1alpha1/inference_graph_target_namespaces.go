@@ -0,0 +1,181 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// TargetNamespaces controls the namespaces of the InferenceServices the steps of the graphs can target with
+// serviceNamespace, the graphs can only target the InferenceServices of their own namespace by default
+// +kubebuilder:object:generate=false
+type TargetNamespaces struct {
+	// EnableCrossNamespaceTargets allows the steps to target the InferenceServices of other namespaces
+	EnableCrossNamespaceTargets bool `json:"enableCrossNamespaceTargets,omitempty"`
+	// AllowedTargetNamespaces restricts the namespaces of the InferenceServices targeted across namespaces, any
+	// namespace can be targeted when empty
+	AllowedTargetNamespaces []string `json:"allowedTargetNamespaces,omitempty"`
+}
+
+// Allows returns whether a graph of the namespace can target the InferenceServices of the target namespace
+func (t *TargetNamespaces) Allows(graphNamespace string, targetNamespace string) bool {
+	if targetNamespace == "" || targetNamespace == graphNamespace {
+		return true
+	}
+	if !t.EnableCrossNamespaceTargets {
+		return false
+	}
+	return len(t.AllowedTargetNamespaces) == 0 || slices.Contains(t.AllowedTargetNamespaces, targetNamespace)
+}
+
+var targetNamespaces atomic.Pointer[TargetNamespaces]
+
+// SetTargetNamespaces sets the namespaces the steps of the InferenceGraphs are validated against
+func SetTargetNamespaces(namespaces *TargetNamespaces) {
+	targetNamespaces.Store(namespaces)
+}
+
+func getTargetNamespaces() *TargetNamespaces {
+	if namespaces := targetNamespaces.Load(); namespaces != nil {
+		return namespaces
+	}
+	return &TargetNamespaces{}
+}
+
+func NewTargetNamespaces(clientset kubernetes.Interface) (*TargetNamespaces, error) {
+	configMap, err := clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Get(context.TODO(), constants.InferenceServiceConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return GetTargetNamespaces(configMap)
+}
+
+func GetTargetNamespaces(configMap *v1.ConfigMap) (*TargetNamespaces, error) {
+	namespaces := &TargetNamespaces{}
+	if router, ok := configMap.Data[RouterConfigKeyName]; ok {
+		if err := json.Unmarshal([]byte(router), namespaces); err != nil {
+			return nil, fmt.Errorf("unable to parse router config json: %w", err)
+		}
+	}
+	return namespaces, nil
+}
+
+// Validation of the namespaces of the InferenceServices targeted by the steps of the graph
+func validateInferenceGraphTargetNamespaces(ig *InferenceGraph, namespaces *TargetNamespaces) error {
+	for nodeName, node := range ig.Spec.Nodes {
+		for i, step := range node.Steps {
+			if step.ServiceNamespace == "" {
+				continue
+			}
+			if step.ServiceName == "" {
+				return fmt.Errorf(InvalidServiceNamespaceTargetError, i, step.StepName, nodeName, ig.Name)
+			}
+			if errs := validation.IsDNS1123Label(step.ServiceNamespace); len(errs) > 0 {
+				return fmt.Errorf(InvalidServiceNamespaceError, i, step.StepName, nodeName, ig.Name,
+					step.ServiceNamespace, strings.Join(errs, "; "))
+			}
+			if !namespaces.Allows(ig.Namespace, step.ServiceNamespace) {
+				return fmt.Errorf(ServiceNamespaceNotAllowedError, i, step.StepName, nodeName, ig.Name,
+					step.ServiceNamespace)
+			}
+		}
+	}
+	return nil
+}
+
+// targetedServices returns the InferenceServices of other namespaces targeted by the steps of the graph as
+// namespace/name
+func targetedServices(ig *InferenceGraph) sets.String {
+	services := sets.NewString()
+	for _, node := range ig.Spec.Nodes {
+		for _, step := range node.Steps {
+			if step.ServiceNamespace != "" && step.ServiceNamespace != ig.Namespace {
+				services.Insert(step.ServiceNamespace + "/" + step.ServiceName)
+			}
+		}
+	}
+	return services
+}
+
+// authorizeTargetNamespaces reviews with a SubjectAccessReview that the user of the admission request can get the
+// InferenceServices of other namespaces targeted by the steps of the graph, so that the router of the graph does not
+// give access to InferenceServices the user cannot access. The targets already set on the old graph are not reviewed.
+func (v *InferenceGraphValidator) authorizeTargetNamespaces(ctx context.Context, ig *InferenceGraph, old *InferenceGraph) error {
+	reviewed := sets.NewString()
+	if old != nil {
+		reviewed = targetedServices(old)
+	}
+	if targetedServices(ig).Difference(reviewed).Len() == 0 {
+		return nil
+	}
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	user := req.UserInfo
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	for nodeName, node := range ig.Spec.Nodes {
+		for i, step := range node.Steps {
+			key := step.ServiceNamespace + "/" + step.ServiceName
+			if step.ServiceNamespace == "" || step.ServiceNamespace == ig.Namespace || reviewed.Has(key) {
+				continue
+			}
+			reviewed.Insert(key)
+			accessReview, err := v.Clientset.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+				Spec: authorizationv1.SubjectAccessReviewSpec{
+					ResourceAttributes: &authorizationv1.ResourceAttributes{
+						Namespace: step.ServiceNamespace,
+						Verb:      "get",
+						Group:     constants.KServeAPIGroupName,
+						Resource:  constants.InferenceServiceAPIName,
+						Name:      step.ServiceName,
+					},
+					User:   user.Username,
+					Groups: user.Groups,
+					Extra:  extra,
+					UID:    user.UID,
+				},
+			}, metav1.CreateOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to review the access of the user %q: %w", user.Username, err)
+			}
+			if !accessReview.Status.Allowed {
+				return fmt.Errorf(ServiceNamespaceForbiddenError, i, step.StepName, nodeName, ig.Name, step.ServiceName,
+					step.ServiceNamespace, user.Username)
+			}
+		}
+	}
+	return nil
+}
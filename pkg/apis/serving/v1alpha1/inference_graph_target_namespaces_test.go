@@ -0,0 +1,183 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestGetTargetNamespaces(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	namespaces, err := GetTargetNamespaces(&v1.ConfigMap{})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(namespaces).Should(gomega.Equal(&TargetNamespaces{}))
+
+	namespaces, err = GetTargetNamespaces(&v1.ConfigMap{Data: map[string]string{
+		RouterConfigKeyName: `{"image": "kserve/router:latest", "enableCrossNamespaceTargets": true, "allowedTargetNamespaces": ["models"]}`,
+	}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(namespaces).Should(gomega.Equal(&TargetNamespaces{
+		EnableCrossNamespaceTargets: true,
+		AllowedTargetNamespaces:     []string{"models"},
+	}))
+}
+
+func TestValidateInferenceGraphTargetNamespaces(t *testing.T) {
+	step := func(target InferenceTarget) map[string]InferenceRouter {
+		return map[string]InferenceRouter{
+			GraphRootNodeName: {RouterType: Sequence, Steps: []InferenceStep{{StepName: "step1", InferenceTarget: target}}},
+		}
+	}
+	scenarios := map[string]struct {
+		nodes       map[string]InferenceRouter
+		namespaces  TargetNamespaces
+		expectedErr string
+	}{
+		"same namespace": {
+			nodes: step(InferenceTarget{ServiceName: "model", ServiceNamespace: "default"}),
+		},
+		"cross namespace disabled": {
+			nodes:       step(InferenceTarget{ServiceName: "model", ServiceNamespace: "models"}),
+			expectedErr: `Step 0 ("step1") in node "root" of InferenceGraph "foo-bar" targets namespace "models" which is not allowed, the router config must enable cross namespace targets and allow the namespace`,
+		},
+		"any namespace": {
+			nodes:      step(InferenceTarget{ServiceName: "model", ServiceNamespace: "models"}),
+			namespaces: TargetNamespaces{EnableCrossNamespaceTargets: true},
+		},
+		"allowed namespace": {
+			nodes:      step(InferenceTarget{ServiceName: "model", ServiceNamespace: "models"}),
+			namespaces: TargetNamespaces{EnableCrossNamespaceTargets: true, AllowedTargetNamespaces: []string{"models"}},
+		},
+		"namespace not in the allowed namespaces": {
+			nodes:       step(InferenceTarget{ServiceName: "model", ServiceNamespace: "team-b"}),
+			namespaces:  TargetNamespaces{EnableCrossNamespaceTargets: true, AllowedTargetNamespaces: []string{"models"}},
+			expectedErr: `Step 0 ("step1") in node "root" of InferenceGraph "foo-bar" targets namespace "team-b" which is not allowed, the router config must enable cross namespace targets and allow the namespace`,
+		},
+		"namespace without service name": {
+			nodes:       step(InferenceTarget{ServiceURL: "http://model.models", ServiceNamespace: "models"}),
+			namespaces:  TargetNamespaces{EnableCrossNamespaceTargets: true},
+			expectedErr: `Step 0 ("step1") in node "root" of InferenceGraph "foo-bar" sets serviceNamespace which is only supported with serviceName`,
+		},
+		"invalid namespace": {
+			nodes:       step(InferenceTarget{ServiceName: "model", ServiceNamespace: "Models"}),
+			namespaces:  TargetNamespaces{EnableCrossNamespaceTargets: true},
+			expectedErr: `Step 0 ("step1") in node "root" of InferenceGraph "foo-bar" has an invalid serviceNamespace "Models": a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			ig := makeTestInferenceGraph()
+			ig.Namespace = "default"
+			ig.Spec.Nodes = scenario.nodes
+			err := validateInferenceGraphTargetNamespaces(&ig, &scenario.namespaces)
+			if scenario.expectedErr == "" {
+				g.Expect(err).ShouldNot(gomega.HaveOccurred())
+			} else {
+				g.Expect(err).Should(gomega.MatchError(scenario.expectedErr))
+			}
+		})
+	}
+}
+
+func TestInferenceGraphValidatorTargetNamespaces(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	defer SetTargetNamespaces(nil)
+	SetTargetNamespaces(&TargetNamespaces{EnableCrossNamespaceTargets: true})
+
+	// alice can get the InferenceServices of the models namespace only
+	clientset := fake.NewSimpleClientset()
+	var reviews []authorizationv1.SubjectAccessReviewSpec
+	clientset.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		reviews = append(reviews, review.Spec)
+		review.Status.Allowed = review.Spec.User == "alice" && review.Spec.ResourceAttributes.Namespace == "models"
+		return true, review, nil
+	})
+	validator := &InferenceGraphValidator{Clientset: clientset}
+	newGraph := func(serviceNamespace string) *InferenceGraph {
+		ig := makeTestInferenceGraph()
+		ig.Namespace = "default"
+		ig.Spec.Nodes = map[string]InferenceRouter{
+			GraphRootNodeName: {RouterType: Sequence, Steps: []InferenceStep{{StepName: "step1",
+				InferenceTarget: InferenceTarget{ServiceName: "model", ServiceNamespace: serviceNamespace}}}},
+		}
+		return &ig
+	}
+	userContext := func(username string) context.Context {
+		return admission.NewContextWithRequest(context.TODO(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			UserInfo: authenticationv1.UserInfo{Username: username, Groups: []string{"team-a"}},
+		}})
+	}
+
+	t.Run("Allowed", func(t *testing.T) {
+		reviews = nil
+		_, err := validator.ValidateCreate(userContext("alice"), newGraph("models"))
+		g.Expect(err).ShouldNot(gomega.HaveOccurred())
+		g.Expect(reviews).Should(gomega.Equal([]authorizationv1.SubjectAccessReviewSpec{{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: "models",
+				Verb:      "get",
+				Group:     "serving.kserve.io",
+				Resource:  "inferenceservices",
+				Name:      "model",
+			},
+			User:   "alice",
+			Groups: []string{"team-a"},
+			Extra:  map[string]authorizationv1.ExtraValue{},
+		}}))
+	})
+
+	t.Run("Denied", func(t *testing.T) {
+		_, err := validator.ValidateCreate(userContext("bob"), newGraph("models"))
+		g.Expect(err).Should(gomega.MatchError(`Step 0 ("step1") in node "root" of InferenceGraph "foo-bar" targets InferenceService "model" of namespace "models" which user "bob" is not allowed to get`))
+		_, err = validator.ValidateCreate(userContext("alice"), newGraph("team-b"))
+		g.Expect(err).Should(gomega.MatchError(gomega.ContainSubstring(`which user "alice" is not allowed to get`)))
+	})
+
+	t.Run("SameNamespace", func(t *testing.T) {
+		reviews = nil
+		_, err := validator.ValidateCreate(userContext("bob"), newGraph("default"))
+		g.Expect(err).ShouldNot(gomega.HaveOccurred())
+		g.Expect(reviews).Should(gomega.BeEmpty())
+	})
+
+	t.Run("UpdateKeepsTargets", func(t *testing.T) {
+		// the targets of the old graph were reviewed when they were set
+		reviews = nil
+		updated := newGraph("models")
+		updated.Annotations = map[string]string{"team": "a"}
+		_, err := validator.ValidateUpdate(userContext("bob"), newGraph("models"), updated)
+		g.Expect(err).ShouldNot(gomega.HaveOccurred())
+		g.Expect(reviews).Should(gomega.BeEmpty())
+
+		_, err = validator.ValidateUpdate(userContext("bob"), newGraph("default"), updated)
+		g.Expect(err).Should(gomega.MatchError(gomega.ContainSubstring(`which user "bob" is not allowed to get`)))
+	})
+}
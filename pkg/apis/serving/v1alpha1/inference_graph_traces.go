@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// GraphTraceStoreType is where the router persists the execution traces of the failed requests
+type GraphTraceStoreType string
+
+const (
+	// ConfigMapTraceStore keeps the latest traces in a ConfigMap of the namespace of the graph
+	ConfigMapTraceStore GraphTraceStoreType = "configmap"
+	// S3TraceStore uploads each trace to an S3 bucket
+	S3TraceStore GraphTraceStoreType = "s3"
+	// LokiTraceStore pushes each trace to Loki
+	LokiTraceStore GraphTraceStoreType = "loki"
+)
+
+const (
+	// DefaultTraceMaxPayloadBytes is the size the payloads recorded in the traces are truncated to by default
+	DefaultTraceMaxPayloadBytes = 1024
+	// DefaultTraceMaxEntries is the number of traces kept in the ConfigMap by default
+	DefaultTraceMaxEntries = 20
+)
+
+// GraphTraceConfig configures the persistence of the execution traces of the failed graph requests by the router.
+// A trace holds the status, the duration and the truncated payloads of each step executed for the request and is
+// keyed by the request ID.
+// +kubebuilder:object:generate=false
+type GraphTraceConfig struct {
+	// Store is where the traces are persisted, configmap, s3 or loki
+	Store GraphTraceStoreType `json:"store"`
+	// MaxPayloadBytes is the size the requests and the responses recorded in the traces are truncated to
+	MaxPayloadBytes int `json:"maxPayloadBytes,omitempty"`
+	// ConfigMap configures the configmap store
+	ConfigMap *ConfigMapTraceStoreConfig `json:"configMap,omitempty"`
+	// S3 configures the s3 store
+	S3 *S3TraceStoreConfig `json:"s3,omitempty"`
+	// Loki configures the loki store
+	Loki *LokiTraceStoreConfig `json:"loki,omitempty"`
+
+	// Graph, Namespace and UID identify the InferenceGraph of the router, they are set by the controller
+	Graph     string `json:"graph,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	UID       string `json:"uid,omitempty"`
+}
+
+// ConfigMapTraceStoreConfig configures the ring of the latest traces kept in the <graph name>-traces ConfigMap,
+// the service account of the router must be allowed to get, create and update ConfigMaps.
+// +kubebuilder:object:generate=false
+type ConfigMapTraceStoreConfig struct {
+	// MaxEntries is the number of traces kept, the oldest trace is evicted first
+	MaxEntries int `json:"maxEntries,omitempty"`
+}
+
+// S3TraceStoreConfig configures the bucket the traces are uploaded to as
+// <prefix>/<namespace>/<graph name>/<request ID>.json
+// +kubebuilder:object:generate=false
+type S3TraceStoreConfig struct {
+	Bucket   string `json:"bucket"`
+	Prefix   string `json:"prefix,omitempty"`
+	Endpoint string `json:"endpoint,omitempty"`
+	Region   string `json:"region,omitempty"`
+	// SecretName is the Secret of the namespace of the graph holding the awsAccessKeyID and awsSecretAccessKey of
+	// the bucket, the default credentials of the router pods are used when not set
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// LokiTraceStoreConfig configures the Loki instance the traces are pushed to
+// +kubebuilder:object:generate=false
+type LokiTraceStoreConfig struct {
+	// URL is the base URL of Loki, the traces are pushed to its /loki/api/v1/push endpoint
+	URL string `json:"url"`
+	// TenantID is sent as the X-Scope-OrgID header when set
+	TenantID string `json:"tenantId,omitempty"`
+	// Labels are added to the labels of the stream of the graph
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// GetMaxPayloadBytes returns the size the payloads are truncated to
+func (c *GraphTraceConfig) GetMaxPayloadBytes() int {
+	if c.MaxPayloadBytes == 0 {
+		return DefaultTraceMaxPayloadBytes
+	}
+	return c.MaxPayloadBytes
+}
+
+// GetMaxEntries returns the number of traces kept in the ConfigMap
+func (c *ConfigMapTraceStoreConfig) GetMaxEntries() int {
+	if c == nil || c.MaxEntries == 0 {
+		return DefaultTraceMaxEntries
+	}
+	return c.MaxEntries
+}
+
+// Validate checks the store is known and configured
+func (c *GraphTraceConfig) Validate() error {
+	if c.MaxPayloadBytes < 0 {
+		return fmt.Errorf("invalid trace config - maxPayloadBytes must not be negative")
+	}
+	switch c.Store {
+	case ConfigMapTraceStore:
+		if c.ConfigMap.GetMaxEntries() < 0 {
+			return fmt.Errorf("invalid trace config - configMap.maxEntries must not be negative")
+		}
+	case S3TraceStore:
+		if c.S3 == nil || c.S3.Bucket == "" {
+			return fmt.Errorf("invalid trace config - s3.bucket is required by the s3 store")
+		}
+	case LokiTraceStore:
+		if c.Loki == nil || c.Loki.URL == "" {
+			return fmt.Errorf("invalid trace config - loki.url is required by the loki store")
+		}
+		if _, err := url.ParseRequestURI(c.Loki.URL); err != nil {
+			return fmt.Errorf("invalid trace config - loki.url: %w", err)
+		}
+	default:
+		return fmt.Errorf("invalid trace config - unknown store %q, expected configmap, s3 or loki", c.Store)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestGraphTraceConfigValidate(t *testing.T) {
+	scenarios := map[string]struct {
+		config      GraphTraceConfig
+		expectedErr string
+	}{
+		"ConfigMap": {
+			config: GraphTraceConfig{Store: ConfigMapTraceStore},
+		},
+		"ConfigMapNegativeEntries": {
+			config:      GraphTraceConfig{Store: ConfigMapTraceStore, ConfigMap: &ConfigMapTraceStoreConfig{MaxEntries: -1}},
+			expectedErr: "configMap.maxEntries must not be negative",
+		},
+		"S3": {
+			config: GraphTraceConfig{Store: S3TraceStore, S3: &S3TraceStoreConfig{Bucket: "traces"}},
+		},
+		"S3WithoutBucket": {
+			config:      GraphTraceConfig{Store: S3TraceStore},
+			expectedErr: "s3.bucket is required",
+		},
+		"Loki": {
+			config: GraphTraceConfig{Store: LokiTraceStore, Loki: &LokiTraceStoreConfig{URL: "http://loki.monitoring:3100"}},
+		},
+		"LokiInvalidURL": {
+			config:      GraphTraceConfig{Store: LokiTraceStore, Loki: &LokiTraceStoreConfig{URL: "loki"}},
+			expectedErr: "loki.url",
+		},
+		"UnknownStore": {
+			config:      GraphTraceConfig{Store: "elasticsearch"},
+			expectedErr: `unknown store "elasticsearch"`,
+		},
+		"NegativePayloadSize": {
+			config:      GraphTraceConfig{Store: ConfigMapTraceStore, MaxPayloadBytes: -1},
+			expectedErr: "maxPayloadBytes must not be negative",
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			err := scenario.config.Validate()
+			if scenario.expectedErr == "" {
+				g.Expect(err).ShouldNot(gomega.HaveOccurred())
+			} else {
+				g.Expect(err).Should(gomega.MatchError(gomega.ContainSubstring(scenario.expectedErr)))
+			}
+		})
+	}
+}
+
+func TestGraphTraceConfigDefaults(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	config := &GraphTraceConfig{Store: ConfigMapTraceStore}
+	g.Expect(config.GetMaxPayloadBytes()).Should(gomega.Equal(DefaultTraceMaxPayloadBytes))
+	g.Expect(config.ConfigMap.GetMaxEntries()).Should(gomega.Equal(DefaultTraceMaxEntries))
+
+	config = &GraphTraceConfig{Store: ConfigMapTraceStore, MaxPayloadBytes: 64,
+		ConfigMap: &ConfigMapTraceStoreConfig{MaxEntries: 5}}
+	g.Expect(config.GetMaxPayloadBytes()).Should(gomega.Equal(64))
+	g.Expect(config.ConfigMap.GetMaxEntries()).Should(gomega.Equal(5))
+}
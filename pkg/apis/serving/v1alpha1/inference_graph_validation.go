@@ -17,14 +17,26 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"regexp"
 
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/expression"
+	"github.com/kserve/kserve/pkg/utils"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/jsonpath"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
@@ -44,6 +56,101 @@ const (
 	TargetNotProvidedError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" does not specify an inference target"
 	// InvalidTargetError defines the error message for inference graph target specifies more than one of nodeName, serviceName, serviceUrl
 	InvalidTargetError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" specifies more than one of nodeName, serviceName, serviceUrl"
+	// InvalidConditionNotMetActionError defines the error message for onConditionNotMet set on a step which is not a conditional step of a Sequence node
+	InvalidConditionNotMetActionError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" sets onConditionNotMet which is only supported on the steps of a Sequence node with a condition"
+	// UnknownConditionStepError defines the error message for a condition referencing a step which is not executed before the step
+	UnknownConditionStepError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" has a condition referencing step \"%s\" which is not a previous step of the node"
+	// InvalidStepExpressionTargetError defines the error message for an expression set on a step which is not a step of a Switch node
+	InvalidStepExpressionTargetError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" sets expression which is only supported on the steps of a Switch node without a condition"
+	// InvalidStepExpressionError defines the error message for a step expression which does not compile
+	InvalidStepExpressionError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" has an %v"
+	// InvalidRouterCaBundleError defines the error message for an invalid ConfigMap or key of the CA bundle trusted by the router
+	InvalidRouterCaBundleError = "invalid router CA bundle \"%s\": %s"
+	// InvalidRouterTLSError defines the error message for a router TLS annotation which is not a boolean
+	InvalidRouterTLSError = "invalid value \"%s\" of annotation %s, it must be true or false"
+	// InvalidRouteTLSTerminationError defines the error message for a route TLS termination annotation which is not supported
+	InvalidRouteTLSTerminationError = "[%s] is not a supported route TLS termination, must be one of %s or %s"
+	// InvalidStepRetryPolicyTargetError defines the error message for a timeout, retries or a circuit breaker set on a step which does not call a service
+	InvalidStepRetryPolicyTargetError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" sets a timeout, retries or a circuit breaker which are only supported on steps with a serviceName or serviceUrl target"
+	// InvalidStepRetryPolicyError defines the error message for an invalid timeout, retries, retry backoff or circuit breaker of a step
+	InvalidStepRetryPolicyError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" has an invalid %s: %s"
+	// InvalidResponseAggregationError defines the error message for responseAggregation set on a node which does not merge the responses of its steps
+	InvalidResponseAggregationError = "Node \"%s\" of InferenceGraph \"%s\" sets responseAggregation which is only supported on Splitter and Ensemble nodes"
+	// EnsembleResponseAggregationError defines the error message for a response aggregation combining the predictions of the steps set on another node than an Ensemble node
+	EnsembleResponseAggregationError = "Node \"%s\" of InferenceGraph \"%s\" sets responseAggregation %s which is only supported on Ensemble nodes"
+	// InvalidAggregationSpecError defines the error message for aggregation set on a node whose response aggregation does not combine the predictions of the steps
+	InvalidAggregationSpecError = "Node \"%s\" of InferenceGraph \"%s\" sets aggregation which is only supported with the MajorityVote, Average, Concat and Template response aggregations"
+	// InvalidAggregationFormatError defines the error message for an aggregation of the responses of an unsupported protocol
+	InvalidAggregationFormatError = "Node \"%s\" of InferenceGraph \"%s\" aggregates \"%s\" responses, only v1 and v2 responses can be aggregated"
+	// InvalidAggregationTemplateError defines the error message for a missing or invalid template of the Template response aggregation
+	InvalidAggregationTemplateError = "Node \"%s\" of InferenceGraph \"%s\" has an invalid aggregation template: %s"
+	// InvalidServiceNamespaceTargetError defines the error message for serviceNamespace set on a step which does not target an InferenceService by name
+	InvalidServiceNamespaceTargetError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" sets serviceNamespace which is only supported with serviceName"
+	// InvalidServiceNamespaceError defines the error message for an invalid namespace of the InferenceService targeted by a step
+	InvalidServiceNamespaceError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" has an invalid serviceNamespace \"%s\": %s"
+	// ServiceNamespaceForbiddenError defines the error message for a step targeting an InferenceService of another namespace which the user creating or updating the graph cannot get
+	ServiceNamespaceForbiddenError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" targets InferenceService \"%s\" of namespace \"%s\" which user \"%s\" is not allowed to get"
+	// ServiceNamespaceNotAllowedError defines the error message for a step targeting an InferenceService of a namespace which is not allowed by the router config
+	ServiceNamespaceNotAllowedError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" targets namespace \"%s\" which is not allowed, the router config must enable cross namespace targets and allow the namespace"
+	// NodeResourcesNotSupportedError defines the error message for resources set on a node, the nodes share the router of the graph
+	NodeResourcesNotSupportedError = "Node \"%s\" of InferenceGraph \"%s\" sets resources which are only supported when the nodes are deployed separately, the nodes are served by the router of the graph whose resources are set with spec.resources"
+	// InvalidMapSpecError defines the error message for a map spec set on another node than a Map node
+	InvalidMapSpecError = "Node \"%s\" of InferenceGraph \"%s\" sets map which is only supported on Map nodes"
+	// MapNodeStepsError defines the error message for a Map node without exactly one step
+	MapNodeStepsError = "Map node \"%s\" of InferenceGraph \"%s\" must have exactly one step"
+	// InvalidMapFormatError defines the error message for a Map node splitting requests of an unsupported protocol
+	InvalidMapFormatError = "Map node \"%s\" of InferenceGraph \"%s\" splits \"%s\" requests, only v1 and v2 requests can be split"
+	// InvalidMapLimitError defines the error message for a Map node with a parallelism or a maximum number of items below 1
+	InvalidMapLimitError = "Map node \"%s\" of InferenceGraph \"%s\" sets %s to %d which must be at least 1"
+	// InvalidStepHeadersTargetError defines the error message for headers set on a step which does not call a service
+	InvalidStepHeadersTargetError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" sets headers which are only supported on steps with a serviceName or serviceUrl target"
+	// InvalidStepHeaderNameError defines the error message for a step header with an invalid name
+	InvalidStepHeaderNameError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" has an invalid header name \"%s\": %s"
+	// InvalidStepHeaderValueError defines the error message for a step header which does not specify exactly one of value and secretKeyRef
+	InvalidStepHeaderValueError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" header \"%s\" must specify exactly one of value and secretKeyRef"
+	// InvalidStepTranslationError defines the error message for a step translation between unknown or identical protocols
+	InvalidStepTranslationError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" translates from \"%s\" to \"%s\", the protocols must be distinct ones of v1, v2 and openai"
+	// DeploymentStrategyUnsupportedError defines the error message for a deployment strategy set on a serverless InferenceGraph
+	DeploymentStrategyUnsupportedError = "InferenceGraph \"%s\" customizes deploymentStrategy which is only supported for raw deployment mode"
+	// MinReadySecondsUnsupportedError defines the error message for minReadySeconds set on a serverless InferenceGraph
+	MinReadySecondsUnsupportedError = "InferenceGraph \"%s\" customizes minReadySeconds which is only supported for raw deployment mode"
+	// InvalidSmokeTestRequestError defines the error message for a smoke test request which is not a JSON document
+	InvalidSmokeTestRequestError = "the smoke test request of InferenceGraph \"%s\" is not a valid JSON document"
+	// InvalidSmokeTestPathError defines the error message for a smoke test path which is not absolute
+	InvalidSmokeTestPathError = "the smoke test path \"%s\" of InferenceGraph \"%s\" must start with /"
+	// InvalidSmokeTestStatusCodeError defines the error message for a smoke test expecting an invalid HTTP status code
+	InvalidSmokeTestStatusCodeError = "the smoke test of InferenceGraph \"%s\" expects the invalid HTTP status code %d"
+	// InvalidSmokeTestAssertionError defines the error message for a smoke test assertion with an invalid JSONPath expression
+	InvalidSmokeTestAssertionError = "the smoke test assertion %d of InferenceGraph \"%s\" has an invalid JSONPath expression \"%s\": %s"
+	// InvalidPluginNameError defines the error message for a router plugin with an invalid name
+	InvalidPluginNameError = "the plugin name \"%s\" of InferenceGraph \"%s\" is invalid: %s"
+	// DuplicatePluginError defines the error message for more than one router plugin with the same name
+	DuplicatePluginError = "InferenceGraph \"%s\" contains more than one plugin with name \"%s\""
+	// InvalidPluginSourceError defines the error message for a router plugin which does not specify exactly one of configMap and image
+	InvalidPluginSourceError = "the plugin \"%s\" of InferenceGraph \"%s\" must specify exactly one of configMap and image"
+	// InvalidPluginPathError defines the error message for a router plugin path which is not an absolute path in the image
+	InvalidPluginPathError = "the plugin \"%s\" of InferenceGraph \"%s\" sets path \"%s\" which must be an absolute path and requires image"
+	// InvalidVolumeError defines the error message for an invalid or reserved volume of the router pod
+	InvalidVolumeError = "the volume \"%s\" of InferenceGraph \"%s\" is invalid: %s"
+	// InvalidVolumeMountError defines the error message for a volume mount of the router container which does not mount a
+	// volume of the graph or mounts it in a directory of the router
+	InvalidVolumeMountError = "the volume mount \"%s\" of InferenceGraph \"%s\" is invalid: %s"
+	// GraphCycleError defines the error message for a graph which can visit a node again while a limit is configured
+	GraphCycleError = "the graph contains a cycle through node \"%s\", the number of nodes visited by a request is unbounded"
+	// MaxNodesVisitedExceededError defines the error message for a graph visiting more nodes than the configured limit
+	MaxNodesVisitedExceededError = "InferenceGraph \"%s\" can visit up to %d nodes for a single request which exceeds the limit of %d"
+	// MaxFanOutExceededError defines the error message for a graph executing more parallel steps than the configured limit
+	MaxFanOutExceededError = "InferenceGraph \"%s\" can execute up to %d steps in parallel for a single request which exceeds the limit of %d"
+	// InvalidActiveHoursError defines the error message for active hours without windows or with an invalid schedule, duration or time zone
+	InvalidActiveHoursError = "the activeHours of InferenceGraph \"%s\" are invalid: %s"
+	// InvalidQuotaError defines the error message for a quota without a single tenant source or with a non positive period or a negative limit
+	InvalidQuotaError = "the quota of InferenceGraph \"%s\" is invalid: %s"
+	// InvalidCORSError defines the error message for a CORS policy with an invalid origin, method, header or max age
+	InvalidCORSError = "the cors policy of InferenceGraph \"%s\" is invalid: %s"
+	// InvalidCircuitBreakerError defines the error message for a circuit breaker without consecutive errors or with a non positive ejection time
+	InvalidCircuitBreakerError = "the circuit breaker of InferenceGraph \"%s\" is invalid: %s"
+	// InvalidLoadSheddingError defines the error message for a load shedding threshold which is not positive
+	InvalidLoadSheddingError = "the load shedding of InferenceGraph \"%s\" is invalid: %s"
 )
 
 const (
@@ -65,7 +172,12 @@ var _ webhook.Validator = &InferenceGraph{}
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (ig *InferenceGraph) ValidateCreate() (admission.Warnings, error) {
 	validatorLogger.Info("validate create", "name", ig.Name)
+	return validateInferenceGraph(ig, ig.Annotations)
+}
 
+// validateInferenceGraph validates the InferenceGraph, routeAnnotations are the annotations checked against the
+// router annotation allow list, on update they are limited to the ones added or changed by the update.
+func validateInferenceGraph(ig *InferenceGraph, routeAnnotations map[string]string) (admission.Warnings, error) {
 	if err := validateInferenceGraphName(ig); err != nil {
 		return nil, err
 	}
@@ -85,14 +197,121 @@ func (ig *InferenceGraph) ValidateCreate() (admission.Warnings, error) {
 	if err := validateInferenceGraphSplitterWeight(ig); err != nil {
 		return nil, err
 	}
+
+	if err := validateInferenceGraphStepConditions(ig); err != nil {
+		return nil, err
+	}
+
+	if err := validateInferenceGraphResponseAggregation(ig); err != nil {
+		return nil, err
+	}
+
+	if err := validateInferenceGraphMapNodes(ig); err != nil {
+		return nil, err
+	}
+
+	if err := validateInferenceGraphNodeResources(ig); err != nil {
+		return nil, err
+	}
+
+	if err := validateInferenceGraphStepHeaders(ig); err != nil {
+		return nil, err
+	}
+
+	if err := validateInferenceGraphStepTranslations(ig); err != nil {
+		return nil, err
+	}
+
+	if err := validateInferenceGraphStepRetryPolicies(ig); err != nil {
+		return nil, err
+	}
+
+	if err := validateInferenceGraphDeploymentStrategy(ig); err != nil {
+		return nil, err
+	}
+
+	if err := validateInferenceGraphSmokeTest(ig); err != nil {
+		return nil, err
+	}
+
+	if err := validateInferenceGraphPlugins(ig); err != nil {
+		return nil, err
+	}
+
+	if err := validateInferenceGraphVolumes(ig); err != nil {
+		return nil, err
+	}
+
+	if err := validateInferenceGraphActiveHours(ig); err != nil {
+		return nil, err
+	}
+
+	if err := validateInferenceGraphQuota(ig); err != nil {
+		return nil, err
+	}
+
+	if err := validateInferenceGraphCORS(ig); err != nil {
+		return nil, err
+	}
+
+	if err := validateInferenceGraphLoadShedding(ig); err != nil {
+		return nil, err
+	}
+
+	if err := validateInferenceGraphLimits(ig, getGraphLimits()); err != nil {
+		return nil, err
+	}
+
+	if err := validateInferenceGraphTargetNamespaces(ig, getTargetNamespaces()); err != nil {
+		return nil, err
+	}
+
+	if err := utils.ValidateRouteAnnotations(routeAnnotations); err != nil {
+		return nil, err
+	}
+
+	if err := utils.ValidateDeploymentAnnotations(ig.Annotations); err != nil {
+		return nil, err
+	}
+
+	if err := utils.ValidateServiceAnnotations(ig.Annotations); err != nil {
+		return nil, err
+	}
+
+	if err := utils.ValidateFIPSCompatibility(ig.Annotations); err != nil {
+		return nil, err
+	}
+
+	if err := validateRouterSecurityContext(ig.Spec.RouterSecurityContext); err != nil {
+		return nil, err
+	}
+
+	if err := validateInferenceGraphRouterCaBundle(ig); err != nil {
+		return nil, err
+	}
+
+	if err := validateInferenceGraphRouterTLS(ig); err != nil {
+		return nil, err
+	}
+
+	if err := validateInferenceGraphRouteTLSTermination(ig); err != nil {
+		return nil, err
+	}
 	return nil, nil
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (ig *InferenceGraph) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	validatorLogger.Info("validate update", "name", ig.Name)
-
-	return ig.ValidateCreate()
+	// the finalizer is removed by an update of an object being deleted, which must not be rejected
+	if ig.DeletionTimestamp != nil {
+		return nil, nil
+	}
+	routeAnnotations := ig.Annotations
+	if oldIg, ok := old.(*InferenceGraph); ok {
+		routeAnnotations = utils.ChangedAnnotations(oldIg.Annotations, ig.Annotations)
+	}
+	return validateInferenceGraph(ig, routeAnnotations)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -101,6 +320,51 @@ func (ig *InferenceGraph) ValidateDelete() (admission.Warnings, error) {
 	return nil, nil
 }
 
+// InferenceGraphValidator is the validating webhook of the InferenceGraphs. On top of the validation of the graph, the
+// user creating or updating the graph must be allowed to get the InferenceServices its steps target in other namespaces.
+// +kubebuilder:object:generate=false
+type InferenceGraphValidator struct {
+	Clientset kubernetes.Interface
+}
+
+var _ admission.CustomValidator = &InferenceGraphValidator{}
+
+// ValidateCreate implements admission.CustomValidator so a webhook will be registered for the type
+func (v *InferenceGraphValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	ig, ok := obj.(*InferenceGraph)
+	if !ok {
+		return nil, fmt.Errorf("expected an InferenceGraph but got a %T", obj)
+	}
+	warnings, err := ig.ValidateCreate()
+	if err != nil {
+		return warnings, err
+	}
+	return warnings, v.authorizeTargetNamespaces(ctx, ig, nil)
+}
+
+// ValidateUpdate implements admission.CustomValidator so a webhook will be registered for the type
+func (v *InferenceGraphValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	ig, ok := newObj.(*InferenceGraph)
+	if !ok {
+		return nil, fmt.Errorf("expected an InferenceGraph but got a %T", newObj)
+	}
+	warnings, err := ig.ValidateUpdate(oldObj)
+	if err != nil || ig.DeletionTimestamp != nil {
+		return warnings, err
+	}
+	oldIg, _ := oldObj.(*InferenceGraph)
+	return warnings, v.authorizeTargetNamespaces(ctx, ig, oldIg)
+}
+
+// ValidateDelete implements admission.CustomValidator so a webhook will be registered for the type
+func (v *InferenceGraphValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	ig, ok := obj.(*InferenceGraph)
+	if !ok {
+		return nil, fmt.Errorf("expected an InferenceGraph but got a %T", obj)
+	}
+	return ig.ValidateDelete()
+}
+
 // Validation of unique step names
 func validateInferenceGraphStepNameUniqueness(ig *InferenceGraph) error {
 	nodes := ig.Spec.Nodes
@@ -184,3 +448,445 @@ func validateInferenceGraphSplitterWeight(ig *InferenceGraph) error {
 	}
 	return nil
 }
+
+// Validation of the conditions of the Sequence steps, a condition can only reference the response of a previous step,
+// and of the expressions of the Switch steps
+func validateInferenceGraphStepConditions(ig *InferenceGraph) error {
+	for nodeName, node := range ig.Spec.Nodes {
+		previousSteps := sets.NewString()
+		for i, step := range node.Steps {
+			if step.OnConditionNotMet != "" && (node.RouterType != Sequence || step.Condition == "") {
+				return fmt.Errorf(InvalidConditionNotMetActionError, i, step.StepName, nodeName, ig.Name)
+			}
+			if node.RouterType == Sequence && strings.HasPrefix(step.Condition, ConditionStepsPrefix) {
+				stepName, _, _ := strings.Cut(strings.TrimPrefix(step.Condition, ConditionStepsPrefix), ".")
+				if !previousSteps.Has(stepName) {
+					return fmt.Errorf(UnknownConditionStepError, i, step.StepName, nodeName, ig.Name, stepName)
+				}
+			}
+			if step.Expression != "" {
+				if node.RouterType != Switch || step.Condition != "" {
+					return fmt.Errorf(InvalidStepExpressionTargetError, i, step.StepName, nodeName, ig.Name)
+				}
+				if _, err := expression.Compile(step.Expression); err != nil {
+					return fmt.Errorf(InvalidStepExpressionError, i, step.StepName, nodeName, ig.Name, err)
+				}
+			}
+			if step.StepName != "" {
+				previousSteps.Insert(step.StepName)
+			}
+		}
+	}
+	return nil
+}
+
+// Validation of the response aggregation, only the Splitter and Ensemble nodes merge the responses of their steps and
+// only the Ensemble nodes combine their predictions
+func validateInferenceGraphResponseAggregation(ig *InferenceGraph) error {
+	for nodeName, node := range ig.Spec.Nodes {
+		if node.ResponseAggregation != "" && node.RouterType != Splitter && node.RouterType != Ensemble {
+			return fmt.Errorf(InvalidResponseAggregationError, nodeName, ig.Name)
+		}
+		combined := node.ResponseAggregation == MajorityVote || node.ResponseAggregation == Average ||
+			node.ResponseAggregation == Concat || node.ResponseAggregation == ResponseTemplate
+		if combined && node.RouterType != Ensemble {
+			return fmt.Errorf(EnsembleResponseAggregationError, nodeName, ig.Name, node.ResponseAggregation)
+		}
+		if node.Aggregation == nil {
+			if node.ResponseAggregation == ResponseTemplate {
+				return fmt.Errorf(InvalidAggregationTemplateError, nodeName, ig.Name, "the Template response aggregation requires a template")
+			}
+			continue
+		}
+		if !combined {
+			return fmt.Errorf(InvalidAggregationSpecError, nodeName, ig.Name)
+		}
+		if format := node.Aggregation.Format; format != "" && format != ProtocolFormatV1 && format != ProtocolFormatV2 {
+			return fmt.Errorf(InvalidAggregationFormatError, nodeName, ig.Name, format)
+		}
+		if node.ResponseAggregation != ResponseTemplate {
+			if node.Aggregation.Template != "" {
+				return fmt.Errorf(InvalidAggregationTemplateError, nodeName, ig.Name, "the template is only used by the Template response aggregation")
+			}
+			continue
+		}
+		if node.Aggregation.Template == "" {
+			return fmt.Errorf(InvalidAggregationTemplateError, nodeName, ig.Name, "the Template response aggregation requires a template")
+		}
+		if _, err := node.Aggregation.ParseTemplate(); err != nil {
+			return fmt.Errorf(InvalidAggregationTemplateError, nodeName, ig.Name, err.Error())
+		}
+	}
+	return nil
+}
+
+// Validation of the resources of the nodes, which are rejected until the nodes can be deployed separately from the
+// router of the graph
+func validateInferenceGraphNodeResources(ig *InferenceGraph) error {
+	for nodeName, node := range ig.Spec.Nodes {
+		if node.Resources != nil {
+			return fmt.Errorf(NodeResourcesNotSupportedError, nodeName, ig.Name)
+		}
+	}
+	return nil
+}
+
+// Validation of the Map nodes, they split the request of the node into per-item requests to their single step
+func validateInferenceGraphMapNodes(ig *InferenceGraph) error {
+	for nodeName, node := range ig.Spec.Nodes {
+		if node.RouterType != Map {
+			if node.Map != nil {
+				return fmt.Errorf(InvalidMapSpecError, nodeName, ig.Name)
+			}
+			continue
+		}
+		if len(node.Steps) != 1 {
+			return fmt.Errorf(MapNodeStepsError, nodeName, ig.Name)
+		}
+		if node.Map == nil {
+			continue
+		}
+		if format := node.Map.Format; format != "" && format != ProtocolFormatV1 && format != ProtocolFormatV2 {
+			return fmt.Errorf(InvalidMapFormatError, nodeName, ig.Name, format)
+		}
+		if parallelism := node.Map.GetParallelism(); parallelism < 1 {
+			return fmt.Errorf(InvalidMapLimitError, nodeName, ig.Name, "parallelism", parallelism)
+		}
+		if maxItems := node.Map.GetMaxItems(); maxItems < 1 {
+			return fmt.Errorf(InvalidMapLimitError, nodeName, ig.Name, "maxItems", maxItems)
+		}
+	}
+	return nil
+}
+
+// Validation of the headers set and removed by the router on the requests to the target service of the steps
+func validateInferenceGraphStepHeaders(ig *InferenceGraph) error {
+	for nodeName, node := range ig.Spec.Nodes {
+		for i, step := range node.Steps {
+			if len(step.Headers) == 0 && len(step.RemoveHeaders) == 0 {
+				continue
+			}
+			if step.NodeName != "" {
+				return fmt.Errorf(InvalidStepHeadersTargetError, i, step.StepName, nodeName, ig.Name)
+			}
+			for _, header := range step.Headers {
+				if errs := validation.IsHTTPHeaderName(header.Name); len(errs) > 0 {
+					return fmt.Errorf(InvalidStepHeaderNameError, i, step.StepName, nodeName, ig.Name, header.Name, strings.Join(errs, "; "))
+				}
+				if (header.Value == "") == (header.SecretKeyRef == nil) {
+					return fmt.Errorf(InvalidStepHeaderValueError, i, step.StepName, nodeName, ig.Name, header.Name)
+				}
+			}
+			for _, name := range step.RemoveHeaders {
+				if errs := validation.IsHTTPHeaderName(name); len(errs) > 0 {
+					return fmt.Errorf(InvalidStepHeaderNameError, i, step.StepName, nodeName, ig.Name, name, strings.Join(errs, "; "))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Validation of the timeouts and the retries of the calls to the target services of the steps
+func validateInferenceGraphStepRetryPolicies(ig *InferenceGraph) error {
+	for nodeName, node := range ig.Spec.Nodes {
+		for i, step := range node.Steps {
+			if step.Timeout == nil && step.Retries == nil && step.RetryBackoff == nil && step.CircuitBreaker == nil {
+				continue
+			}
+			if step.NodeName != "" {
+				return fmt.Errorf(InvalidStepRetryPolicyTargetError, i, step.StepName, nodeName, ig.Name)
+			}
+			if step.Timeout != nil && step.Timeout.Duration <= 0 {
+				return fmt.Errorf(InvalidStepRetryPolicyError, i, step.StepName, nodeName, ig.Name, "timeout", "must be positive")
+			}
+			if step.Retries != nil && (*step.Retries < 0 || *step.Retries > MaxStepRetries) {
+				return fmt.Errorf(InvalidStepRetryPolicyError, i, step.StepName, nodeName, ig.Name, "retries",
+					fmt.Sprintf("must be between 0 and %d", MaxStepRetries))
+			}
+			if step.RetryBackoff != nil && step.RetryBackoff.Duration <= 0 {
+				return fmt.Errorf(InvalidStepRetryPolicyError, i, step.StepName, nodeName, ig.Name, "retryBackoff", "must be positive")
+			}
+			if err := validateCircuitBreaker(step.CircuitBreaker); err != nil {
+				return fmt.Errorf(InvalidStepRetryPolicyError, i, step.StepName, nodeName, ig.Name, "circuitBreaker", err)
+			}
+		}
+	}
+	return nil
+}
+
+// Validation of the protocols of the step translations
+func validateInferenceGraphStepTranslations(ig *InferenceGraph) error {
+	isFormat := func(format InferenceProtocolFormat) bool {
+		return format == ProtocolFormatV1 || format == ProtocolFormatV2 || format == ProtocolFormatOpenAI
+	}
+	for nodeName, node := range ig.Spec.Nodes {
+		for i, step := range node.Steps {
+			translation := step.Translation
+			if translation == nil {
+				continue
+			}
+			if !isFormat(translation.From) || !isFormat(translation.To) || translation.From == translation.To {
+				return fmt.Errorf(InvalidStepTranslationError, i, step.StepName, nodeName, ig.Name, translation.From, translation.To)
+			}
+		}
+	}
+	return nil
+}
+
+// Validation of the deployment strategy and min ready seconds, the serverless router rollouts are managed by Knative
+func validateInferenceGraphDeploymentStrategy(ig *InferenceGraph) error {
+	if ig.Annotations[constants.DeploymentMode] != string(constants.Serverless) {
+		return nil
+	}
+	if ig.Spec.DeploymentStrategy != nil {
+		return fmt.Errorf(DeploymentStrategyUnsupportedError, ig.Name)
+	}
+	if ig.Spec.MinReadySeconds != nil {
+		return fmt.Errorf(MinReadySecondsUnsupportedError, ig.Name)
+	}
+	return nil
+}
+
+// Validation of the golden request sent to the graph after each rollout and of the assertions on its response
+func validateInferenceGraphSmokeTest(ig *InferenceGraph) error {
+	smokeTest := ig.Spec.SmokeTest
+	if smokeTest == nil {
+		return nil
+	}
+	if !json.Valid([]byte(smokeTest.Request)) {
+		return fmt.Errorf(InvalidSmokeTestRequestError, ig.Name)
+	}
+	if smokeTest.Path != "" && !strings.HasPrefix(smokeTest.Path, "/") {
+		return fmt.Errorf(InvalidSmokeTestPathError, smokeTest.Path, ig.Name)
+	}
+	if code := smokeTest.ExpectedStatusCode; code != nil && (*code < 100 || *code > 599) {
+		return fmt.Errorf(InvalidSmokeTestStatusCodeError, ig.Name, *code)
+	}
+	for i, assertion := range smokeTest.Assertions {
+		if err := jsonpath.New("assertion").Parse(assertion.JSONPath); err != nil {
+			return fmt.Errorf(InvalidSmokeTestAssertionError, i, ig.Name, assertion.JSONPath, err)
+		}
+	}
+	return nil
+}
+
+// Validation of the cron schedules, the durations and the time zone of the active hours
+func validateInferenceGraphActiveHours(ig *InferenceGraph) error {
+	activeHours := ig.Spec.ActiveHours
+	if activeHours == nil {
+		return nil
+	}
+	if len(activeHours.Windows) == 0 {
+		return fmt.Errorf(InvalidActiveHoursError, ig.Name, "at least one window is required")
+	}
+	if _, _, err := activeHours.IsActive(time.Now()); err != nil {
+		return fmt.Errorf(InvalidActiveHoursError, ig.Name, err)
+	}
+	return nil
+}
+
+// Validation of the origins, the methods and the headers of the CORS policy
+func validateInferenceGraphCORS(ig *InferenceGraph) error {
+	if ig.Spec.CORS == nil {
+		return nil
+	}
+	if err := ig.Spec.CORS.Config().Validate(); err != nil {
+		return fmt.Errorf(InvalidCORSError, ig.Name, err)
+	}
+	return nil
+}
+
+// Validation of the circuit breaker of the graph and of the load shedding thresholds
+func validateInferenceGraphLoadShedding(ig *InferenceGraph) error {
+	if err := validateCircuitBreaker(ig.Spec.CircuitBreaker); err != nil {
+		return fmt.Errorf(InvalidCircuitBreakerError, ig.Name, err)
+	}
+	shedding := ig.Spec.LoadShedding
+	if shedding == nil {
+		return nil
+	}
+	if shedding.MaxInFlightRequests != nil && *shedding.MaxInFlightRequests < 1 {
+		return fmt.Errorf(InvalidLoadSheddingError, ig.Name, "maxInFlightRequests must be positive")
+	}
+	if shedding.MaxInFlightCallsPerTarget != nil && *shedding.MaxInFlightCallsPerTarget < 1 {
+		return fmt.Errorf(InvalidLoadSheddingError, ig.Name, "maxInFlightCallsPerTarget must be positive")
+	}
+	return nil
+}
+
+func validateCircuitBreaker(breaker *CircuitBreakerPolicy) error {
+	if breaker == nil {
+		return nil
+	}
+	if breaker.ConsecutiveErrors < 1 {
+		return fmt.Errorf("consecutiveErrors must be positive")
+	}
+	if breaker.EjectionTime != nil && breaker.EjectionTime.Duration <= 0 {
+		return fmt.Errorf("ejectionTime must be positive")
+	}
+	return nil
+}
+
+func validateInferenceGraphQuota(ig *InferenceGraph) error {
+	quota := ig.Spec.Quota
+	if quota == nil {
+		return nil
+	}
+	if quota.Period.Duration <= 0 {
+		return fmt.Errorf(InvalidQuotaError, ig.Name, "the period must be positive")
+	}
+	if quota.DefaultLimit != nil && *quota.DefaultLimit < 0 {
+		return fmt.Errorf(InvalidQuotaError, ig.Name, "the default limit must not be negative")
+	}
+	for tenant, limit := range quota.Tenants {
+		if limit < 0 {
+			return fmt.Errorf(InvalidQuotaError, ig.Name, fmt.Sprintf("the limit of tenant %q must not be negative", tenant))
+		}
+	}
+	return nil
+}
+
+// Validation of the router plugin sources and of the plugins referenced by the nodes, a node can reference a plugin
+// without source which is compiled into the router
+func validateInferenceGraphPlugins(ig *InferenceGraph) error {
+	names := sets.NewString()
+	for _, plugin := range ig.Spec.Plugins {
+		if errs := validation.IsDNS1123Label(plugin.Name); len(errs) > 0 {
+			return fmt.Errorf(InvalidPluginNameError, plugin.Name, ig.Name, strings.Join(errs, "; "))
+		}
+		if names.Has(plugin.Name) {
+			return fmt.Errorf(DuplicatePluginError, ig.Name, plugin.Name)
+		}
+		names.Insert(plugin.Name)
+		if (plugin.ConfigMap == nil) == (plugin.Image == "") {
+			return fmt.Errorf(InvalidPluginSourceError, plugin.Name, ig.Name)
+		}
+		if plugin.Path != "" && (plugin.Image == "" || !strings.HasPrefix(plugin.Path, "/")) {
+			return fmt.Errorf(InvalidPluginPathError, plugin.Name, ig.Name, plugin.Path)
+		}
+	}
+	for _, node := range ig.Spec.Nodes {
+		for _, plugin := range node.Plugins {
+			if errs := validation.IsDNS1123Label(plugin.Name); len(errs) > 0 {
+				return fmt.Errorf(InvalidPluginNameError, plugin.Name, ig.Name, strings.Join(errs, "; "))
+			}
+		}
+	}
+	return nil
+}
+
+// Validation of the volumes of the router pod, they must not collide with the volumes and directories of the router
+func validateInferenceGraphVolumes(ig *InferenceGraph) error {
+	names := sets.NewString()
+	for _, volume := range ig.Spec.Volumes {
+		if errs := validation.IsDNS1123Label(volume.Name); len(errs) > 0 {
+			return fmt.Errorf(InvalidVolumeError, volume.Name, ig.Name, strings.Join(errs, "; "))
+		}
+		if volume.Name == constants.RouterTLSVolumeName || volume.Name == constants.RouterCaBundleVolumeName ||
+			volume.Name == constants.RouterPluginVolumeName || strings.HasPrefix(volume.Name, constants.RouterPluginVolumeName+"-") {
+			return fmt.Errorf(InvalidVolumeError, volume.Name, ig.Name, "the name is reserved by the router")
+		}
+		if names.Has(volume.Name) {
+			return fmt.Errorf(InvalidVolumeError, volume.Name, ig.Name, "the name is used by more than one volume")
+		}
+		names.Insert(volume.Name)
+	}
+	for _, mount := range ig.Spec.VolumeMounts {
+		if !names.Has(mount.Name) {
+			return fmt.Errorf(InvalidVolumeMountError, mount.Name, ig.Name, "the graph has no volume of this name")
+		}
+		if !strings.HasPrefix(mount.MountPath, "/") {
+			return fmt.Errorf(InvalidVolumeMountError, mount.Name, ig.Name, "the mount path must be an absolute path")
+		}
+		mountPath := path.Clean(mount.MountPath)
+		for _, dir := range []string{constants.RouterTLSDir, constants.RouterCaBundleDir, constants.RouterPluginDir} {
+			if mountPath == dir || strings.HasPrefix(mountPath, dir+"/") {
+				return fmt.Errorf(InvalidVolumeMountError, mount.Name, ig.Name,
+					fmt.Sprintf("the mount path is in the directory %s of the router", dir))
+			}
+		}
+	}
+	return nil
+}
+
+// Validation of the worst case traversal of the graph against the limits enforced by the router
+func validateInferenceGraphLimits(ig *InferenceGraph, limits *GraphLimits) error {
+	if limits.MaxNodesVisited == 0 && limits.MaxFanOut == 0 {
+		return nil
+	}
+	nodesVisited, fanOut, err := graphComplexity(&ig.Spec, GraphRootNodeName, map[string]bool{})
+	if err != nil {
+		return fmt.Errorf("InferenceGraph \"%s\" is invalid: %w", ig.Name, err)
+	}
+	if limits.MaxNodesVisited != 0 && nodesVisited > limits.MaxNodesVisited {
+		return fmt.Errorf(MaxNodesVisitedExceededError, ig.Name, nodesVisited, limits.MaxNodesVisited)
+	}
+	if limits.MaxFanOut != 0 && fanOut > limits.MaxFanOut {
+		return fmt.Errorf(MaxFanOutExceededError, ig.Name, fanOut, limits.MaxFanOut)
+	}
+	return nil
+}
+
+// Validation of the router security context overrides against the bounds set by the administrator
+func validateRouterSecurityContext(securityContext *RouterSecurityContext) error {
+	if securityContext == nil {
+		return nil
+	}
+	if err := utils.ValidateSecurityContextOverride(securityContext.ToSecurityContext(),
+		securityContext.SupplementalGroups); err != nil {
+		return fmt.Errorf("invalid routerSecurityContext: %w", err)
+	}
+	return nil
+}
+
+// Validation of the CA bundle trusted by the router of the graph, set with the router CA bundle annotation
+func validateInferenceGraphRouterCaBundle(ig *InferenceGraph) error {
+	value, ok := ig.Annotations[constants.RouterCaBundleAnnotationKey]
+	if !ok || value == constants.RouterCaBundleDisabled {
+		return nil
+	}
+	name, key, _ := strings.Cut(value, "/")
+	return ValidateRouterCaBundle(name, key)
+}
+
+// Validation of the router TLS annotation, which enables or disables the HTTPS listener of the router of the graph
+func validateInferenceGraphRouterTLS(ig *InferenceGraph) error {
+	value, ok := ig.Annotations[constants.RouterTLSAnnotationKey]
+	if !ok {
+		return nil
+	}
+	if _, err := strconv.ParseBool(value); err != nil {
+		return fmt.Errorf(InvalidRouterTLSError, value, constants.RouterTLSAnnotationKey)
+	}
+	return nil
+}
+
+// Validation of the route TLS termination annotation of the route which exposes the router of the graph
+func validateInferenceGraphRouteTLSTermination(ig *InferenceGraph) error {
+	value, ok := ig.Annotations[constants.RouteTLSTerminationAnnotationKey]
+	if !ok {
+		return nil
+	}
+	if termination := constants.RouteTLSTerminationType(value); termination != constants.RouteTLSTerminationEdge &&
+		termination != constants.RouteTLSTerminationReencrypt {
+		return fmt.Errorf(InvalidRouteTLSTerminationError, value, constants.RouteTLSTerminationEdge,
+			constants.RouteTLSTerminationReencrypt)
+	}
+	return nil
+}
+
+// ValidateRouterCaBundle validates the name of the ConfigMap and the key of the CA bundle trusted by the router, the
+// key is optional
+func ValidateRouterCaBundle(name string, key string) error {
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return fmt.Errorf(InvalidRouterCaBundleError, name, strings.Join(errs, "; "))
+	}
+	if key == "" {
+		return nil
+	}
+	if errs := validation.IsConfigMapKey(key); len(errs) > 0 {
+		return fmt.Errorf(InvalidRouterCaBundleError, name+"/"+key, strings.Join(errs, "; "))
+	}
+	return nil
+}
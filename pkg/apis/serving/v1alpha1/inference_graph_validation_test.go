@@ -18,11 +18,19 @@ package v1alpha1
 
 import (
 	"fmt"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/expression"
 	"github.com/onsi/gomega"
 	"github.com/onsi/gomega/types"
 	"google.golang.org/protobuf/proto"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"strings"
 	"testing"
+	"time"
 )
 
 func makeTestInferenceGraph() InferenceGraph {
@@ -41,6 +49,10 @@ func makeTestInferenceGraph() InferenceGraph {
 
 func TestInferenceGraph_ValidateCreate(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
+	compileError := func(source string) error {
+		_, err := expression.Compile(source)
+		return err
+	}
 	scenarios := map[string]struct {
 		ig              InferenceGraph
 		update          map[string]string
@@ -258,6 +270,398 @@ func TestInferenceGraph_ValidateCreate(t *testing.T) {
 			errMatcher:      gomega.MatchError(fmt.Errorf(DuplicateStepNameError, GraphRootNodeName, "foo-bar", "step1")),
 			warningsMatcher: gomega.BeEmpty(),
 		},
+		"sequence step skipped on the response of a previous step": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName:        "step1",
+							InferenceTarget: InferenceTarget{ServiceName: "service1"},
+						},
+						{
+							StepName:          "step2",
+							InferenceTarget:   InferenceTarget{ServiceName: "service2"},
+							Condition:         "$steps.step1.predictions.#(label==\"dog\")",
+							OnConditionNotMet: Skip,
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"sequence step condition references a later step": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName:        "step1",
+							InferenceTarget: InferenceTarget{ServiceName: "service1"},
+							Condition:       "$steps.step2.predictions",
+						},
+						{
+							StepName:        "step2",
+							InferenceTarget: InferenceTarget{ServiceName: "service2"},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(UnknownConditionStepError, 0, "step1", GraphRootNodeName, "foo-bar", "step2")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"onConditionNotMet without condition": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName:          "step1",
+							InferenceTarget:   InferenceTarget{ServiceName: "service1"},
+							OnConditionNotMet: Skip,
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidConditionNotMetActionError, 0, "step1", GraphRootNodeName, "foo-bar")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"switch with expressions": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Switch,
+					Steps: []InferenceStep{
+						{
+							StepName:        "step1",
+							InferenceTarget: InferenceTarget{ServiceName: "service1"},
+							Expression:      `headers["x-tenant"] == "gold"`,
+						},
+						{
+							StepName:        "step2",
+							InferenceTarget: InferenceTarget{ServiceName: "service2"},
+							Condition:       "instances",
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"expression on a sequence step": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName:        "step1",
+							InferenceTarget: InferenceTarget{ServiceName: "service1"},
+							Expression:      "body.instances[0] > 1",
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidStepExpressionTargetError, 0, "step1", GraphRootNodeName, "foo-bar")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"invalid expression": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Switch,
+					Steps: []InferenceStep{
+						{
+							StepName:        "step1",
+							InferenceTarget: InferenceTarget{ServiceName: "service1"},
+							Expression:      "body.instances[0] >",
+						},
+					},
+				},
+			},
+			errMatcher: gomega.MatchError(fmt.Errorf(InvalidStepExpressionError, 0, "step1", GraphRootNodeName, "foo-bar",
+				compileError("body.instances[0] >"))),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"mistyped expression": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Switch,
+					Steps: []InferenceStep{
+						{
+							StepName:        "step1",
+							InferenceTarget: InferenceTarget{ServiceName: "service1"},
+							Expression:      `headers["x-tenant"] == 1`,
+						},
+					},
+				},
+			},
+			errMatcher: gomega.MatchError(fmt.Errorf(InvalidStepExpressionError, 0, "step1", GraphRootNodeName, "foo-bar",
+				compileError(`headers["x-tenant"] == 1`))),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"ensemble with array response aggregation": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType:          Ensemble,
+					ResponseAggregation: Array,
+					Steps: []InferenceStep{
+						{
+							InferenceTarget: InferenceTarget{ServiceName: "service1"},
+						},
+						{
+							InferenceTarget: InferenceTarget{ServiceName: "service2"},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"sequence with response aggregation": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType:          Sequence,
+					ResponseAggregation: Keyed,
+					Steps: []InferenceStep{
+						{
+							InferenceTarget: InferenceTarget{ServiceName: "service1"},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidResponseAggregationError, GraphRootNodeName, "foo-bar")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"ensemble with majority vote aggregation": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType:          Ensemble,
+					ResponseAggregation: MajorityVote,
+					Steps: []InferenceStep{
+						{
+							InferenceTarget: InferenceTarget{ServiceName: "service1"},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"ensemble with template aggregation": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType:          Ensemble,
+					ResponseAggregation: ResponseTemplate,
+					Aggregation: &AggregationSpec{
+						Format:   ProtocolFormatV1,
+						Template: `{"predictions": {{ json .steps.service1.predictions }}}`,
+					},
+					Steps: []InferenceStep{
+						{
+							InferenceTarget: InferenceTarget{ServiceName: "service1"},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"splitter with average aggregation": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType:          Splitter,
+					ResponseAggregation: Average,
+					Steps: []InferenceStep{
+						{
+							InferenceTarget: InferenceTarget{ServiceName: "service1"},
+							Weight:          proto.Int64(100),
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(EnsembleResponseAggregationError, GraphRootNodeName, "foo-bar", Average)),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"ensemble with aggregation spec and keyed aggregation": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType:          Ensemble,
+					ResponseAggregation: Keyed,
+					Aggregation:         &AggregationSpec{Format: ProtocolFormatV2},
+					Steps: []InferenceStep{
+						{
+							InferenceTarget: InferenceTarget{ServiceName: "service1"},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidAggregationSpecError, GraphRootNodeName, "foo-bar")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"ensemble with aggregation of an unsupported format": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType:          Ensemble,
+					ResponseAggregation: Concat,
+					Aggregation:         &AggregationSpec{Format: "v3"},
+					Steps: []InferenceStep{
+						{
+							InferenceTarget: InferenceTarget{ServiceName: "service1"},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidAggregationFormatError, GraphRootNodeName, "foo-bar", "v3")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"ensemble with template aggregation without template": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType:          Ensemble,
+					ResponseAggregation: ResponseTemplate,
+					Steps: []InferenceStep{
+						{
+							InferenceTarget: InferenceTarget{ServiceName: "service1"},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidAggregationTemplateError, GraphRootNodeName, "foo-bar", "the Template response aggregation requires a template")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"ensemble with unparsable aggregation template": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType:          Ensemble,
+					ResponseAggregation: ResponseTemplate,
+					Aggregation:         &AggregationSpec{Template: "{{ .steps"},
+					Steps: []InferenceStep{
+						{
+							InferenceTarget: InferenceTarget{ServiceName: "service1"},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidAggregationTemplateError, GraphRootNodeName, "foo-bar", "template: aggregation:1: unclosed action")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"node with resources": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Ensemble,
+					Steps: []InferenceStep{
+						{
+							InferenceTarget: InferenceTarget{ServiceName: "service1"},
+						},
+					},
+					Resources: &v1.ResourceRequirements{
+						Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("2")},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(NodeResourcesNotSupportedError, GraphRootNodeName, "foo-bar")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"step with headers": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName:        "step1",
+							InferenceTarget: InferenceTarget{ServiceName: "service1"},
+							Headers: []StepHeader{
+								{Name: "Model-Version", Value: "2"},
+								{Name: "X-Api-Key", SecretKeyRef: &v1.SecretKeySelector{
+									LocalObjectReference: v1.LocalObjectReference{Name: "service1-credentials"},
+									Key:                  "api-key",
+								}},
+							},
+							RemoveHeaders: []string{"Authorization"},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"node step with headers": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName:        "step1",
+							InferenceTarget: InferenceTarget{NodeName: "node1"},
+							RemoveHeaders:   []string{"Authorization"},
+						},
+					},
+				},
+				"node1": {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							InferenceTarget: InferenceTarget{ServiceName: "service1"},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidStepHeadersTargetError, 0, "step1", GraphRootNodeName, "foo-bar")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"step header with invalid name": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName:        "step1",
+							InferenceTarget: InferenceTarget{ServiceName: "service1"},
+							Headers:         []StepHeader{{Name: "Model Version", Value: "2"}},
+						},
+					},
+				},
+			},
+			errMatcher: gomega.MatchError(fmt.Errorf(InvalidStepHeaderNameError, 0, "step1", GraphRootNodeName, "foo-bar",
+				"Model Version", strings.Join(validation.IsHTTPHeaderName("Model Version"), "; "))),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"step header without value": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName:        "step1",
+							InferenceTarget: InferenceTarget{ServiceName: "service1"},
+							Headers:         []StepHeader{{Name: "Model-Version"}},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidStepHeaderValueError, 0, "step1", GraphRootNodeName, "foo-bar", "Model-Version")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
 	}
 
 	for testName, scenario := range scenarios {
@@ -279,9 +683,675 @@ func TestInferenceGraph_ValidateCreate(t *testing.T) {
 	}
 }
 
-func TestInferenceGraph_ValidateUpdate(t *testing.T) {
+func TestInferenceGraph_ValidateDeploymentStrategy(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
-	temptIg := makeTestTrainModel()
+	minReadySeconds := int32(30)
+	scenarios := map[string]struct {
+		deploymentMode     string
+		deploymentStrategy *appsv1.DeploymentStrategy
+		minReadySeconds    *int32
+		errMatcher         types.GomegaMatcher
+	}{
+		"raw deployment": {
+			deploymentMode:     string(constants.RawDeployment),
+			deploymentStrategy: &appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+			minReadySeconds:    &minReadySeconds,
+			errMatcher:         gomega.MatchError(nil),
+		},
+		"default deployment mode": {
+			deploymentStrategy: &appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+			minReadySeconds:    &minReadySeconds,
+			errMatcher:         gomega.MatchError(nil),
+		},
+		"serverless with deployment strategy": {
+			deploymentMode:     string(constants.Serverless),
+			deploymentStrategy: &appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+			errMatcher:         gomega.MatchError(fmt.Errorf(DeploymentStrategyUnsupportedError, "foo-bar")),
+		},
+		"serverless with min ready seconds": {
+			deploymentMode:  string(constants.Serverless),
+			minReadySeconds: &minReadySeconds,
+			errMatcher:      gomega.MatchError(fmt.Errorf(MinReadySecondsUnsupportedError, "foo-bar")),
+		},
+	}
+
+	for testName, scenario := range scenarios {
+		t.Run(testName, func(t *testing.T) {
+			ig := makeTestInferenceGraph()
+			if scenario.deploymentMode != "" {
+				ig.Annotations = map[string]string{constants.DeploymentMode: scenario.deploymentMode}
+			}
+			ig.Spec.Nodes = map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							InferenceTarget: InferenceTarget{ServiceName: "service1"},
+						},
+					},
+				},
+			}
+			ig.Spec.DeploymentStrategy = scenario.deploymentStrategy
+			ig.Spec.MinReadySeconds = scenario.minReadySeconds
+			_, err := ig.ValidateCreate()
+			g.Expect(gomega.MatchError(err)).To(gomega.Equal(scenario.errMatcher))
+		})
+	}
+}
+
+func TestInferenceGraph_ValidateSmokeTest(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	expected := "0"
+	notFound := int32(404)
+	invalidCode := int32(42)
+	scenarios := map[string]struct {
+		smokeTest *SmokeTestSpec
+		expectErr bool
+	}{
+		"valid smoke test": {
+			smokeTest: &SmokeTestSpec{
+				Path:               "/v1/models/model:predict",
+				Request:            `{"instances": [[1, 2, 3, 4]]}`,
+				ExpectedStatusCode: &notFound,
+				Assertions: []SmokeTestAssertion{
+					{JSONPath: "{.predictions[0]}", Value: &expected},
+					{JSONPath: "{.model_name}"},
+				},
+			},
+		},
+		"request is not json": {
+			smokeTest: &SmokeTestSpec{Request: "instances"},
+			expectErr: true,
+		},
+		"relative path": {
+			smokeTest: &SmokeTestSpec{Path: "v1/models", Request: "{}"},
+			expectErr: true,
+		},
+		"invalid status code": {
+			smokeTest: &SmokeTestSpec{Request: "{}", ExpectedStatusCode: &invalidCode},
+			expectErr: true,
+		},
+		"invalid jsonpath": {
+			smokeTest: &SmokeTestSpec{Request: "{}", Assertions: []SmokeTestAssertion{{JSONPath: "{.predictions["}}},
+			expectErr: true,
+		},
+	}
+
+	for testName, scenario := range scenarios {
+		t.Run(testName, func(t *testing.T) {
+			ig := makeTestInferenceGraph()
+			ig.Spec.Nodes = map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							InferenceTarget: InferenceTarget{ServiceName: "service1"},
+						},
+					},
+				},
+			}
+			ig.Spec.SmokeTest = scenario.smokeTest
+			_, err := ig.ValidateCreate()
+			if scenario.expectErr {
+				g.Expect(err).To(gomega.HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestInferenceGraph_ValidatePlugins(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	configMap := &v1.ConfigMapKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "plugins"}, Key: "auth.so"}
+	scenarios := map[string]struct {
+		plugins     []RouterPluginSource
+		nodePlugins []NodePlugin
+		expectErr   bool
+	}{
+		"valid plugins": {
+			plugins: []RouterPluginSource{
+				{Name: "auth", ConfigMap: configMap},
+				{Name: "translate", Image: "registry.example.com/translate:v1", Path: "/plugins/translate.so"},
+			},
+			nodePlugins: []NodePlugin{{Name: "auth", Config: map[string]string{"scheme": "hmac"}}, {Name: "translate"}},
+		},
+		"compiled in plugin": {
+			nodePlugins: []NodePlugin{{Name: "builtin"}},
+		},
+		"invalid name": {
+			plugins:   []RouterPluginSource{{Name: "Auth", ConfigMap: configMap}},
+			expectErr: true,
+		},
+		"duplicate name": {
+			plugins:   []RouterPluginSource{{Name: "auth", ConfigMap: configMap}, {Name: "auth", Image: "auth:v1"}},
+			expectErr: true,
+		},
+		"no source": {
+			plugins:   []RouterPluginSource{{Name: "auth"}},
+			expectErr: true,
+		},
+		"both sources": {
+			plugins:   []RouterPluginSource{{Name: "auth", ConfigMap: configMap, Image: "auth:v1"}},
+			expectErr: true,
+		},
+		"relative path": {
+			plugins:   []RouterPluginSource{{Name: "auth", Image: "auth:v1", Path: "auth.so"}},
+			expectErr: true,
+		},
+		"path without image": {
+			plugins:   []RouterPluginSource{{Name: "auth", ConfigMap: configMap, Path: "/auth.so"}},
+			expectErr: true,
+		},
+		"invalid node plugin name": {
+			nodePlugins: []NodePlugin{{Name: "../auth"}},
+			expectErr:   true,
+		},
+	}
+
+	for testName, scenario := range scenarios {
+		t.Run(testName, func(t *testing.T) {
+			ig := makeTestInferenceGraph()
+			ig.Spec.Nodes = map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							InferenceTarget: InferenceTarget{ServiceName: "service1"},
+						},
+					},
+					Plugins: scenario.nodePlugins,
+				},
+			}
+			ig.Spec.Plugins = scenario.plugins
+			_, err := ig.ValidateCreate()
+			if scenario.expectErr {
+				g.Expect(err).To(gomega.HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestInferenceGraph_ValidateVolumes(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	emptyDir := func(name string) v1.Volume {
+		return v1.Volume{Name: name, VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}}
+	}
+	scenarios := map[string]struct {
+		volumes      []v1.Volume
+		volumeMounts []v1.VolumeMount
+		expectErr    bool
+	}{
+		"valid volumes": {
+			volumes:      []v1.Volume{emptyDir("templates"), emptyDir("ca-bundle")},
+			volumeMounts: []v1.VolumeMount{{Name: "templates", MountPath: "/etc/templates"}, {Name: "ca-bundle", MountPath: "/etc/ssl/custom"}},
+		},
+		"invalid name": {
+			volumes:   []v1.Volume{emptyDir("Templates")},
+			expectErr: true,
+		},
+		"duplicate name": {
+			volumes:   []v1.Volume{emptyDir("templates"), emptyDir("templates")},
+			expectErr: true,
+		},
+		"reserved name": {
+			volumes:   []v1.Volume{emptyDir(constants.RouterCaBundleVolumeName)},
+			expectErr: true,
+		},
+		"reserved plugin name": {
+			volumes:   []v1.Volume{emptyDir(constants.RouterPluginVolumeName + "-0")},
+			expectErr: true,
+		},
+		"mount of an unknown volume": {
+			volumeMounts: []v1.VolumeMount{{Name: "templates", MountPath: "/etc/templates"}},
+			expectErr:    true,
+		},
+		"relative mount path": {
+			volumes:      []v1.Volume{emptyDir("templates")},
+			volumeMounts: []v1.VolumeMount{{Name: "templates", MountPath: "templates"}},
+			expectErr:    true,
+		},
+		"mount in a router directory": {
+			volumes:      []v1.Volume{emptyDir("tls")},
+			volumeMounts: []v1.VolumeMount{{Name: "tls", MountPath: constants.RouterTLSDir + "/extra"}},
+			expectErr:    true,
+		},
+	}
+
+	for testName, scenario := range scenarios {
+		t.Run(testName, func(t *testing.T) {
+			ig := makeTestInferenceGraph()
+			ig.Spec.Nodes = map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							InferenceTarget: InferenceTarget{ServiceName: "service1"},
+						},
+					},
+				},
+			}
+			ig.Spec.Volumes = scenario.volumes
+			ig.Spec.VolumeMounts = scenario.volumeMounts
+			_, err := ig.ValidateCreate()
+			if scenario.expectErr {
+				g.Expect(err).To(gomega.HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestInferenceGraph_ValidateStepTranslations(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		translation *ProtocolTranslation
+		expectErr   bool
+	}{
+		"no translation": {},
+		"v1 to v2": {
+			translation: &ProtocolTranslation{From: ProtocolFormatV1, To: ProtocolFormatV2, InputName: "input-0"},
+		},
+		"v2 to openai": {
+			translation: &ProtocolTranslation{From: ProtocolFormatV2, To: ProtocolFormatOpenAI, Model: "gpt2"},
+		},
+		"identical protocols": {
+			translation: &ProtocolTranslation{From: ProtocolFormatV1, To: ProtocolFormatV1},
+			expectErr:   true,
+		},
+		"unknown protocol": {
+			translation: &ProtocolTranslation{From: ProtocolFormatV1, To: "grpc"},
+			expectErr:   true,
+		},
+		"missing protocol": {
+			translation: &ProtocolTranslation{To: ProtocolFormatV2},
+			expectErr:   true,
+		},
+	}
+
+	for testName, scenario := range scenarios {
+		t.Run(testName, func(t *testing.T) {
+			ig := makeTestInferenceGraph()
+			ig.Spec.Nodes = map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							InferenceTarget: InferenceTarget{ServiceName: "service1"},
+							Translation:     scenario.translation,
+						},
+					},
+				},
+			}
+			_, err := ig.ValidateCreate()
+			if scenario.expectErr {
+				g.Expect(err).To(gomega.HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestInferenceGraph_ValidateStepRetryPolicies(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	retries := func(n int32) *int32 { return &n }
+	duration := func(d time.Duration) *metav1.Duration { return &metav1.Duration{Duration: d} }
+	scenarios := map[string]struct {
+		step      InferenceStep
+		expectErr bool
+	}{
+		"timeout and retries": {
+			step: InferenceStep{InferenceTarget: InferenceTarget{ServiceName: "service1"},
+				Timeout: duration(time.Minute), Retries: retries(3), RetryBackoff: duration(time.Second)},
+		},
+		"node target": {
+			step:      InferenceStep{InferenceTarget: InferenceTarget{NodeName: "node1"}, Timeout: duration(time.Minute)},
+			expectErr: true,
+		},
+		"negative timeout": {
+			step:      InferenceStep{InferenceTarget: InferenceTarget{ServiceName: "service1"}, Timeout: duration(-time.Second)},
+			expectErr: true,
+		},
+		"too many retries": {
+			step:      InferenceStep{InferenceTarget: InferenceTarget{ServiceName: "service1"}, Retries: retries(11)},
+			expectErr: true,
+		},
+		"zero retry backoff": {
+			step:      InferenceStep{InferenceTarget: InferenceTarget{ServiceName: "service1"}, RetryBackoff: duration(0)},
+			expectErr: true,
+		},
+		"circuit breaker": {
+			step: InferenceStep{InferenceTarget: InferenceTarget{ServiceName: "service1"},
+				CircuitBreaker: &CircuitBreakerPolicy{ConsecutiveErrors: 5, EjectionTime: duration(time.Minute)}},
+		},
+		"circuit breaker on node target": {
+			step: InferenceStep{InferenceTarget: InferenceTarget{NodeName: "node1"},
+				CircuitBreaker: &CircuitBreakerPolicy{ConsecutiveErrors: 5}},
+			expectErr: true,
+		},
+		"circuit breaker without consecutive errors": {
+			step: InferenceStep{InferenceTarget: InferenceTarget{ServiceName: "service1"},
+				CircuitBreaker: &CircuitBreakerPolicy{}},
+			expectErr: true,
+		},
+	}
+
+	for testName, scenario := range scenarios {
+		t.Run(testName, func(t *testing.T) {
+			ig := makeTestInferenceGraph()
+			ig.Spec.Nodes = map[string]InferenceRouter{
+				GraphRootNodeName: {RouterType: Sequence, Steps: []InferenceStep{scenario.step}},
+				"node1": {RouterType: Sequence,
+					Steps: []InferenceStep{{InferenceTarget: InferenceTarget{ServiceName: "service2"}}}},
+			}
+			_, err := ig.ValidateCreate()
+			if scenario.expectErr {
+				g.Expect(err).To(gomega.HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestInferenceGraph_ValidateMapNodes(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	step := InferenceStep{InferenceTarget: InferenceTarget{ServiceName: "service1"}}
+	zero := 0
+	scenarios := map[string]struct {
+		node      InferenceRouter
+		expectErr bool
+	}{
+		"map node with defaults": {
+			node: InferenceRouter{RouterType: Map, Steps: []InferenceStep{step}},
+		},
+		"map node splitting v2 requests": {
+			node: InferenceRouter{RouterType: Map, Steps: []InferenceStep{step},
+				Map: &MapRouterSpec{Format: ProtocolFormatV2}},
+		},
+		"map node without step": {
+			node:      InferenceRouter{RouterType: Map},
+			expectErr: true,
+		},
+		"map node with several steps": {
+			node:      InferenceRouter{RouterType: Map, Steps: []InferenceStep{step, step}},
+			expectErr: true,
+		},
+		"map node splitting openai requests": {
+			node: InferenceRouter{RouterType: Map, Steps: []InferenceStep{step},
+				Map: &MapRouterSpec{Format: ProtocolFormatOpenAI}},
+			expectErr: true,
+		},
+		"map node without parallelism": {
+			node: InferenceRouter{RouterType: Map, Steps: []InferenceStep{step},
+				Map: &MapRouterSpec{Parallelism: &zero}},
+			expectErr: true,
+		},
+		"map spec on a sequence node": {
+			node:      InferenceRouter{RouterType: Sequence, Steps: []InferenceStep{step}, Map: &MapRouterSpec{}},
+			expectErr: true,
+		},
+	}
+
+	for testName, scenario := range scenarios {
+		t.Run(testName, func(t *testing.T) {
+			ig := makeTestInferenceGraph()
+			ig.Spec.Nodes = map[string]InferenceRouter{GraphRootNodeName: scenario.node}
+			_, err := ig.ValidateCreate()
+			if scenario.expectErr {
+				g.Expect(err).To(gomega.HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestInferenceGraph_ValidateActiveHours(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	businessHours := ActiveWindow{Schedule: "0 8 * * 1-5", Duration: metav1.Duration{Duration: 10 * time.Hour}}
+	scenarios := map[string]struct {
+		activeHours *ActiveHours
+		expectErr   bool
+	}{
+		"business hours": {
+			activeHours: &ActiveHours{Windows: []ActiveWindow{businessHours}, TimeZone: "America/New_York"},
+		},
+		"without window": {
+			activeHours: &ActiveHours{},
+			expectErr:   true,
+		},
+		"invalid schedule": {
+			activeHours: &ActiveHours{Windows: []ActiveWindow{{Schedule: "0 25 * * *",
+				Duration: metav1.Duration{Duration: time.Hour}}}},
+			expectErr: true,
+		},
+		"invalid time zone": {
+			activeHours: &ActiveHours{Windows: []ActiveWindow{businessHours}, TimeZone: "Nowhere"},
+			expectErr:   true,
+		},
+	}
+
+	for testName, scenario := range scenarios {
+		t.Run(testName, func(t *testing.T) {
+			ig := makeTestInferenceGraph()
+			ig.Spec.Nodes = map[string]InferenceRouter{GraphRootNodeName: {RouterType: Sequence,
+				Steps: []InferenceStep{{InferenceTarget: InferenceTarget{ServiceName: "service1"}}}}}
+			ig.Spec.ActiveHours = scenario.activeHours
+			_, err := ig.ValidateCreate()
+			if scenario.expectErr {
+				g.Expect(err).To(gomega.HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestInferenceGraph_ValidateCORS(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		cors      *CORSPolicy
+		expectErr bool
+	}{
+		"allowed origin": {
+			cors: &CORSPolicy{AllowOrigins: []string{"https://app.example.com"},
+				AllowMethods: []string{"POST", "OPTIONS"}, AllowHeaders: []string{"Content-Type"}},
+		},
+		"origin with path": {
+			cors:      &CORSPolicy{AllowOrigins: []string{"https://app.example.com/ui"}},
+			expectErr: true,
+		},
+		"invalid header": {
+			cors:      &CORSPolicy{AllowOrigins: []string{"*"}, AllowHeaders: []string{"Content Type"}},
+			expectErr: true,
+		},
+	}
+
+	for testName, scenario := range scenarios {
+		t.Run(testName, func(t *testing.T) {
+			ig := makeTestInferenceGraph()
+			ig.Spec.Nodes = map[string]InferenceRouter{GraphRootNodeName: {RouterType: Sequence,
+				Steps: []InferenceStep{{InferenceTarget: InferenceTarget{ServiceName: "service1"}}}}}
+			ig.Spec.CORS = scenario.cors
+			_, err := ig.ValidateCreate()
+			if scenario.expectErr {
+				g.Expect(err).To(gomega.HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestInferenceGraph_ValidateLoadShedding(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		breaker   *CircuitBreakerPolicy
+		shedding  *LoadSheddingPolicy
+		expectErr bool
+	}{
+		"thresholds": {
+			breaker:  &CircuitBreakerPolicy{ConsecutiveErrors: 3},
+			shedding: &LoadSheddingPolicy{MaxInFlightRequests: proto.Int32(100), MaxInFlightCallsPerTarget: proto.Int32(20)},
+		},
+		"zero ejection time": {
+			breaker:   &CircuitBreakerPolicy{ConsecutiveErrors: 3, EjectionTime: &metav1.Duration{}},
+			expectErr: true,
+		},
+		"zero max in flight requests": {
+			shedding:  &LoadSheddingPolicy{MaxInFlightRequests: proto.Int32(0)},
+			expectErr: true,
+		},
+		"negative max in flight calls per target": {
+			shedding:  &LoadSheddingPolicy{MaxInFlightCallsPerTarget: proto.Int32(-1)},
+			expectErr: true,
+		},
+	}
+
+	for testName, scenario := range scenarios {
+		t.Run(testName, func(t *testing.T) {
+			ig := makeTestInferenceGraph()
+			ig.Spec.Nodes = map[string]InferenceRouter{GraphRootNodeName: {RouterType: Sequence,
+				Steps: []InferenceStep{{InferenceTarget: InferenceTarget{ServiceName: "service1"}}}}}
+			ig.Spec.CircuitBreaker = scenario.breaker
+			ig.Spec.LoadShedding = scenario.shedding
+			_, err := ig.ValidateCreate()
+			if scenario.expectErr {
+				g.Expect(err).To(gomega.HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestInferenceGraph_ValidateRouterCaBundle(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		annotation string
+		expectErr  bool
+	}{
+		"disabled":                    {annotation: "none"},
+		"ConfigMap":                   {annotation: "custom-ca"},
+		"ConfigMap and key":           {annotation: "custom-ca/ca.pem"},
+		"invalid ConfigMap name":      {annotation: "Custom_CA", expectErr: true},
+		"invalid key":                 {annotation: "custom-ca/ca:pem", expectErr: true},
+		"empty ConfigMap name":        {annotation: "/ca.pem", expectErr: true},
+		"key with a nested directory": {annotation: "custom-ca/certs/ca.pem", expectErr: true},
+	}
+
+	for testName, scenario := range scenarios {
+		t.Run(testName, func(t *testing.T) {
+			ig := makeTestInferenceGraph()
+			ig.Spec.Nodes = map[string]InferenceRouter{GraphRootNodeName: {RouterType: Sequence,
+				Steps: []InferenceStep{{InferenceTarget: InferenceTarget{ServiceName: "service1"}}}}}
+			ig.Annotations = map[string]string{constants.RouterCaBundleAnnotationKey: scenario.annotation}
+			_, err := ig.ValidateCreate()
+			if scenario.expectErr {
+				g.Expect(err).To(gomega.HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestInferenceGraph_ValidateRouterTLS(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		annotation string
+		expected   gomega.OmegaMatcher
+	}{
+		"enabled":  {annotation: "true", expected: gomega.BeNil()},
+		"disabled": {annotation: "false", expected: gomega.BeNil()},
+		"invalid": {annotation: "https", expected: gomega.MatchError(fmt.Errorf(InvalidRouterTLSError, "https",
+			constants.RouterTLSAnnotationKey))},
+	}
+
+	for testName, scenario := range scenarios {
+		t.Run(testName, func(t *testing.T) {
+			ig := makeTestInferenceGraph()
+			ig.Spec.Nodes = map[string]InferenceRouter{GraphRootNodeName: {RouterType: Sequence,
+				Steps: []InferenceStep{{InferenceTarget: InferenceTarget{ServiceName: "service1"}}}}}
+			ig.Annotations = map[string]string{constants.RouterTLSAnnotationKey: scenario.annotation}
+			_, err := ig.ValidateCreate()
+			g.Expect(err).Should(scenario.expected)
+		})
+	}
+}
+
+func TestInferenceGraph_ValidateRouteTLSTermination(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		annotation string
+		expected   gomega.OmegaMatcher
+	}{
+		"edge":      {annotation: "edge", expected: gomega.BeNil()},
+		"reencrypt": {annotation: "reencrypt", expected: gomega.BeNil()},
+		"passthrough": {annotation: "passthrough", expected: gomega.MatchError(fmt.Errorf(InvalidRouteTLSTerminationError,
+			"passthrough", constants.RouteTLSTerminationEdge, constants.RouteTLSTerminationReencrypt))},
+	}
+
+	for testName, scenario := range scenarios {
+		t.Run(testName, func(t *testing.T) {
+			ig := makeTestInferenceGraph()
+			ig.Spec.Nodes = map[string]InferenceRouter{GraphRootNodeName: {RouterType: Sequence,
+				Steps: []InferenceStep{{InferenceTarget: InferenceTarget{ServiceName: "service1"}}}}}
+			ig.Annotations = map[string]string{constants.RouteTLSTerminationAnnotationKey: scenario.annotation}
+			_, err := ig.ValidateCreate()
+			g.Expect(err).Should(scenario.expected)
+		})
+	}
+}
+
+func TestInferenceGraph_ValidateQuota(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		quota     *QuotaSpec
+		expectErr bool
+	}{
+		"default limit": {
+			quota: &QuotaSpec{Period: metav1.Duration{Duration: time.Minute}, DefaultLimit: proto.Int64(10),
+				Tenants: map[string]int64{"system:serviceaccount:team-a:client": 100}},
+		},
+		"audiences": {
+			quota: &QuotaSpec{Audiences: []string{"llm-graph"}, Period: metav1.Duration{Duration: 24 * time.Hour}},
+		},
+		"without period": {
+			quota:     &QuotaSpec{},
+			expectErr: true,
+		},
+		"negative limit": {
+			quota: &QuotaSpec{Period: metav1.Duration{Duration: time.Minute},
+				Tenants: map[string]int64{"team-a": -1}},
+			expectErr: true,
+		},
+	}
+
+	for testName, scenario := range scenarios {
+		t.Run(testName, func(t *testing.T) {
+			ig := makeTestInferenceGraph()
+			ig.Spec.Nodes = map[string]InferenceRouter{GraphRootNodeName: {RouterType: Sequence,
+				Steps: []InferenceStep{{InferenceTarget: InferenceTarget{ServiceName: "service1"}}}}}
+			ig.Spec.Quota = scenario.quota
+			_, err := ig.ValidateCreate()
+			if scenario.expectErr {
+				g.Expect(err).To(gomega.HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestInferenceGraph_ValidateUpdate(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	temptIg := makeTestTrainModel()
 	old := temptIg.DeepCopyObject()
 	scenarios := map[string]struct {
 		ig              InferenceGraph
@@ -318,6 +1388,50 @@ func TestInferenceGraph_ValidateUpdate(t *testing.T) {
 	}
 }
 
+func TestInferenceGraph_ValidateUpdateRouteAnnotations(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	unsupported := constants.OpenshiftRouterAnnotationPrefix + "hsts_header"
+	scenarios := map[string]struct {
+		old        map[string]string
+		updated    map[string]string
+		deleting   bool
+		errMatcher types.GomegaMatcher
+	}{
+		"unchanged unsupported annotation": {
+			old:        map[string]string{unsupported: "max-age=31536000"},
+			updated:    map[string]string{unsupported: "max-age=31536000", "foo": "bar"},
+			errMatcher: gomega.Succeed(),
+		},
+		"added unsupported annotation": {
+			old:        map[string]string{},
+			updated:    map[string]string{unsupported: "max-age=31536000"},
+			errMatcher: gomega.HaveOccurred(),
+		},
+		"being deleted": {
+			old:        map[string]string{},
+			updated:    map[string]string{unsupported: "max-age=31536000"},
+			deleting:   true,
+			errMatcher: gomega.Succeed(),
+		},
+	}
+
+	for testName, scenario := range scenarios {
+		t.Run(testName, func(t *testing.T) {
+			old := makeTestInferenceGraph()
+			old.Annotations = scenario.old
+			ig := makeTestInferenceGraph()
+			ig.Spec.Nodes = map[string]InferenceRouter{GraphRootNodeName: {RouterType: Sequence,
+				Steps: []InferenceStep{{InferenceTarget: InferenceTarget{ServiceName: "service1"}}}}}
+			ig.Annotations = scenario.updated
+			if scenario.deleting {
+				ig.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			}
+			_, err := ig.ValidateUpdate(&old)
+			g.Expect(err).To(scenario.errMatcher)
+		})
+	}
+}
+
 func TestInferenceGraph_ValidateDelete(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	scenarios := map[string]struct {
@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ServingProfileSpec defines the named sizes of a ServingProfile, e.g. small, medium and large.
+// +k8s:openapi-gen=true
+type ServingProfileSpec struct {
+	// Sizes offered by the profile, an InferenceService selects one with the serving.kserve.io/size-class annotation
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	Sizes []ServingProfileSize `json:"sizes"`
+	// DefaultSize is the size applied when the InferenceService does not select one
+	// +optional
+	DefaultSize string `json:"defaultSize,omitempty"`
+}
+
+// ServingProfileSize defines the defaults a size applies to the predictor of an InferenceService.
+// Fields set on the predictor are left untouched.
+// +k8s:openapi-gen=true
+type ServingProfileSize struct {
+	// Name of the size
+	Name string `json:"name"`
+	// Resources of the predictor container
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// NodeSelector of the predictor pods
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations of the predictor pods, e.g. for the taints of the GPU nodes
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// Minimum number of replicas of the predictor
+	// +optional
+	MinReplicas *int `json:"minReplicas,omitempty"`
+	// Maximum number of replicas of the predictor
+	// +optional
+	MaxReplicas int `json:"maxReplicas,omitempty"`
+	// ScaleTarget of the autoscaler of the predictor
+	// +optional
+	ScaleTarget *int `json:"scaleTarget,omitempty"`
+	// ReadinessProbe of the predictor container
+	// +optional
+	ReadinessProbe *corev1.Probe `json:"readinessProbe,omitempty"`
+	// LivenessProbe of the predictor container
+	// +optional
+	LivenessProbe *corev1.Probe `json:"livenessProbe,omitempty"`
+}
+
+// ServingProfile maps named sizes to the resources, scheduling, autoscaling bounds and probes of a predictor, so
+// that an InferenceService only references the profile and a size.
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="DefaultSize",type="string",JSONPath=".spec.defaultSize"
+// +kubebuilder:printcolumn:name="Sizes",type="string",JSONPath=".spec.sizes[*].name"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:path=servingprofiles,singular=servingprofile
+type ServingProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ServingProfileSpec `json:"spec,omitempty"`
+}
+
+// ServingProfileList contains a list of ServingProfile
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ServingProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServingProfile `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ServingProfile{}, &ServingProfileList{})
+}
+
+// GetSize returns the size of the profile with the name, or the default size when the name is empty.
+// It returns nil when the profile has no such size.
+func (spec *ServingProfileSpec) GetSize(name string) *ServingProfileSize {
+	if name == "" {
+		name = spec.DefaultSize
+	}
+	for i := range spec.Sizes {
+		if spec.Sizes[i].Name == name {
+			return &spec.Sizes[i]
+		}
+	}
+	return nil
+}
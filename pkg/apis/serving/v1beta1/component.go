@@ -18,6 +18,9 @@ package v1beta1
 
 import (
 	"fmt"
+	"net"
+	"net/url"
+	"path"
 	"reflect"
 	"strings"
 
@@ -26,6 +29,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 // Known error messages
@@ -39,6 +43,15 @@ const (
 	InvalidLoggerType                   = "Invalid logger type"
 	InvalidISVCNameFormatError          = "The InferenceService \"%s\" is invalid: a InferenceService name must consist of lower case alphanumeric characters or '-', and must start with alphabetical character. (e.g. \"my-name\" or \"abc-123\", regex used for validation is '%s')"
 	InvalidProtocol                     = "Invalid protocol %s. Must be one of [%s]"
+	InvalidMiddlewareHeaderError        = "Invalid middleware header name %q: %s"
+	InvalidTokenExchangeURLError        = "Invalid token exchange url %q: must be an absolute http or https url"
+	InvalidUnauthenticatedPathError     = "Invalid unauthenticated path %q: must be a clean absolute path other than /"
+	InvalidTokenReviewVerbError         = "Invalid token review verb %q: %s"
+	InvalidUnauthenticatedSourceError   = "Invalid source CIDR %q of unauthenticated path %q: %s"
+	InvalidQuotaTenantError             = "Invalid quota: the tenants of the requests are identified by the tokenReview which must be specified"
+	InvalidQuotaPeriodError             = "Invalid quota period %s: must be positive"
+	InvalidQuotaLimitError              = "Invalid quota limit %d of tenant %q: must not be negative"
+	InvalidGuardrailHookURLError        = "Invalid guardrail hook url %q: must be an absolute http or https url"
 )
 
 // Constants
@@ -99,6 +112,17 @@ type ComponentExtensionSpec struct {
 	// Activate request batching and batching configurations
 	// +optional
 	Batcher *Batcher `json:"batcher,omitempty"`
+	// Middleware the agent applies to the requests proxied to the component
+	// +optional
+	Middleware *AgentMiddleware `json:"middleware,omitempty"`
+	// Log level and format of the containers injected by KServe in the component pods, the agent and the storage
+	// initializer.
+	// +optional
+	SidecarLogging *SidecarLoggingSpec `json:"sidecarLogging,omitempty"`
+	// Overrides of the security context of the agent container, e.g. to run it with the SELinux type required by a
+	// storage driver. The overrides must be within the bounds set by the administrator in the security config.
+	// +optional
+	AgentSecurityContext *SidecarSecurityContext `json:"agentSecurityContext,omitempty"`
 	// Labels that will be add to the component pod.
 	// More info: http://kubernetes.io/docs/user-guide/labels
 	// +optional
@@ -111,6 +135,11 @@ type ComponentExtensionSpec struct {
 	// The deployment strategy to use to replace existing pods with new ones. Only applicable for raw deployment mode.
 	// +optional
 	DeploymentStrategy *appsv1.DeploymentStrategy `json:"deploymentStrategy,omitempty"`
+	// Minimum number of seconds a new pod should be ready without any of its containers crashing before it is
+	// considered available and the rollout proceeds. Only applicable for raw deployment mode.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinReadySeconds *int32 `json:"minReadySeconds,omitempty"`
 }
 
 // ScaleMetric enum
@@ -124,6 +153,70 @@ const (
 	MetricRPS         ScaleMetric = "rps"
 )
 
+// SidecarLogLevel enum
+// +kubebuilder:validation:Enum=debug;info;warn;error
+type SidecarLogLevel string
+
+const (
+	SidecarLogLevelDebug SidecarLogLevel = "debug"
+	SidecarLogLevelInfo  SidecarLogLevel = "info"
+	SidecarLogLevelWarn  SidecarLogLevel = "warn"
+	SidecarLogLevelError SidecarLogLevel = "error"
+)
+
+// SidecarLogFormat enum
+// +kubebuilder:validation:Enum=json;text
+type SidecarLogFormat string
+
+const (
+	SidecarLogFormatJSON SidecarLogFormat = "json"
+	SidecarLogFormatText SidecarLogFormat = "text"
+)
+
+// SidecarLoggingSpec sets the log level and format of the containers injected by KServe, the defaults of each
+// container are kept for the fields which are not set.
+type SidecarLoggingSpec struct {
+	// Minimum level of the logs written by the containers
+	// +optional
+	Level SidecarLogLevel `json:"level,omitempty"`
+	// Format of the logs written by the containers, one json object per line or human readable text
+	// +optional
+	Format SidecarLogFormat `json:"format,omitempty"`
+}
+
+// SidecarSecurityContext overrides fields of the security context of a container injected by KServe, the security
+// context of the container is kept for the fields which are not set.
+type SidecarSecurityContext struct {
+	// The UID to run the container as
+	// +optional
+	RunAsUser *int64 `json:"runAsUser,omitempty"`
+	// The GID to run the container as
+	// +optional
+	RunAsGroup *int64 `json:"runAsGroup,omitempty"`
+	// The SELinux context of the container, only the type and the level can be set
+	// +optional
+	SELinuxOptions *v1.SELinuxOptions `json:"seLinuxOptions,omitempty"`
+	// The capabilities added to the container
+	// +optional
+	AddCapabilities []v1.Capability `json:"addCapabilities,omitempty"`
+}
+
+// ToSecurityContext returns the overridden fields as a container security context
+func (s *SidecarSecurityContext) ToSecurityContext() *v1.SecurityContext {
+	if s == nil {
+		return nil
+	}
+	securityContext := &v1.SecurityContext{
+		RunAsUser:      s.RunAsUser,
+		RunAsGroup:     s.RunAsGroup,
+		SELinuxOptions: s.SELinuxOptions,
+	}
+	if len(s.AddCapabilities) > 0 {
+		securityContext.Capabilities = &v1.Capabilities{Add: s.AddCapabilities}
+	}
+	return securityContext
+}
+
 // Default the ComponentExtensionSpec
 func (s *ComponentExtensionSpec) Default(config *InferenceServicesConfig) {}
 
@@ -133,6 +226,8 @@ func (s *ComponentExtensionSpec) Validate() error {
 		validateContainerConcurrency(s.ContainerConcurrency),
 		validateReplicas(s.MinReplicas, s.MaxReplicas),
 		validateLogger(s.Logger),
+		validateMiddleware(s.Middleware),
+		validateAgentSecurityContext(s.AgentSecurityContext),
 	})
 }
 
@@ -196,6 +291,104 @@ func validateLogger(logger *LoggerSpec) error {
 	return nil
 }
 
+func validateAgentSecurityContext(securityContext *SidecarSecurityContext) error {
+	if securityContext == nil {
+		return nil
+	}
+	if err := utils.ValidateSecurityContextOverride(securityContext.ToSecurityContext(), nil); err != nil {
+		return fmt.Errorf("invalid agentSecurityContext: %w", err)
+	}
+	return nil
+}
+
+func validateMiddleware(middleware *AgentMiddleware) error {
+	if middleware == nil {
+		return nil
+	}
+	for _, transform := range []*HeaderTransform{middleware.RequestHeaders, middleware.ResponseHeaders} {
+		if transform == nil {
+			continue
+		}
+		names := append([]string{}, transform.Remove...)
+		for name := range transform.Set {
+			names = append(names, name)
+		}
+		for name := range transform.Add {
+			names = append(names, name)
+		}
+		for _, name := range names {
+			if errs := validation.IsHTTPHeaderName(name); len(errs) > 0 {
+				return fmt.Errorf(InvalidMiddlewareHeaderError, name, strings.Join(errs, ", "))
+			}
+		}
+	}
+	if middleware.TokenExchange != nil {
+		tokenURL, err := url.Parse(middleware.TokenExchange.TokenURL)
+		if err != nil || (tokenURL.Scheme != "http" && tokenURL.Scheme != "https") || tokenURL.Host == "" {
+			return fmt.Errorf(InvalidTokenExchangeURLError, middleware.TokenExchange.TokenURL)
+		}
+		if err := validateUnauthenticatedPaths(middleware.TokenExchange.UnauthenticatedPaths); err != nil {
+			return err
+		}
+	}
+	if middleware.TokenReview != nil {
+		if errs := validation.IsDNS1123Label(middleware.TokenReview.GetVerb()); len(errs) > 0 {
+			return fmt.Errorf(InvalidTokenReviewVerbError, middleware.TokenReview.Verb, strings.Join(errs, ", "))
+		}
+		if err := validateUnauthenticatedPaths(middleware.TokenReview.UnauthenticatedPaths); err != nil {
+			return err
+		}
+	}
+	if middleware.Guardrail != nil {
+		for _, hook := range []*GuardrailHook{middleware.Guardrail.Request, middleware.Guardrail.Response} {
+			if hook == nil {
+				continue
+			}
+			hookURL, err := url.Parse(hook.URL)
+			if err != nil || (hookURL.Scheme != "http" && hookURL.Scheme != "https") || hookURL.Host == "" {
+				return fmt.Errorf(InvalidGuardrailHookURLError, hook.URL)
+			}
+		}
+	}
+	if middleware.Quota != nil && middleware.TokenReview == nil {
+		return fmt.Errorf(InvalidQuotaTenantError)
+	}
+	return validateQuota(middleware.Quota)
+}
+
+func validateUnauthenticatedPaths(paths []UnauthenticatedPath) error {
+	for _, unauthenticated := range paths {
+		prefix := unauthenticated.Prefix
+		if !strings.HasPrefix(prefix, "/") || prefix == "/" || path.Clean(prefix) != prefix {
+			return fmt.Errorf(InvalidUnauthenticatedPathError, prefix)
+		}
+		for _, cidr := range unauthenticated.SourceCIDRs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return fmt.Errorf(InvalidUnauthenticatedSourceError, cidr, prefix, err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateQuota(quota *QuotaSpec) error {
+	if quota == nil {
+		return nil
+	}
+	if quota.Period.Duration <= 0 {
+		return fmt.Errorf(InvalidQuotaPeriodError, quota.Period.Duration)
+	}
+	if quota.DefaultLimit != nil && *quota.DefaultLimit < 0 {
+		return fmt.Errorf(InvalidQuotaLimitError, *quota.DefaultLimit, "")
+	}
+	for tenant, limit := range quota.Tenants {
+		if limit < 0 {
+			return fmt.Errorf(InvalidQuotaLimitError, limit, tenant)
+		}
+	}
+	return nil
+}
+
 func validateExactlyOneImplementation(component Component) error {
 	if len(component.GetImplementations()) != 1 {
 		return ExactlyOneErrorFor(component)
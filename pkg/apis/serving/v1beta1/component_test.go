@@ -20,10 +20,12 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/onsi/gomega"
 	"github.com/onsi/gomega/types"
 	"google.golang.org/protobuf/proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestComponentExtensionSpec_Validate(t *testing.T) {
@@ -156,6 +158,186 @@ func TestComponentExtensionSpec_validateLogger(t *testing.T) {
 	}
 }
 
+func TestComponentExtensionSpec_validateMiddleware(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		middleware *AgentMiddleware
+		matcher    types.GomegaMatcher
+	}{
+		"MiddlewareIsNil": {
+			middleware: nil,
+			matcher:    gomega.BeNil(),
+		},
+		"ValidMiddleware": {
+			middleware: &AgentMiddleware{
+				RequestHeaders: &HeaderTransform{
+					Set:    map[string]string{"X-Tenant": "team-a"},
+					Remove: []string{"Cookie"},
+				},
+				ResponseHeaders: &HeaderTransform{
+					Add: map[string]string{"Cache-Control": "no-store"},
+				},
+				TokenExchange: &TokenExchange{
+					TokenURL: "https://sts.example.com/token",
+					Audience: "model-server",
+				},
+			},
+			matcher: gomega.BeNil(),
+		},
+		"InvalidRequestHeaderName": {
+			middleware: &AgentMiddleware{
+				RequestHeaders: &HeaderTransform{
+					Set: map[string]string{"X Tenant": "team-a"},
+				},
+			},
+			matcher: gomega.MatchError(gomega.ContainSubstring("Invalid middleware header name \"X Tenant\"")),
+		},
+		"InvalidResponseHeaderName": {
+			middleware: &AgentMiddleware{
+				ResponseHeaders: &HeaderTransform{
+					Remove: []string{"Server:"},
+				},
+			},
+			matcher: gomega.MatchError(gomega.ContainSubstring("Invalid middleware header name \"Server:\"")),
+		},
+		"RelativeTokenURL": {
+			middleware: &AgentMiddleware{
+				TokenExchange: &TokenExchange{
+					TokenURL: "/token",
+				},
+			},
+			matcher: gomega.MatchError(fmt.Errorf(InvalidTokenExchangeURLError, "/token")),
+		},
+		"UnsupportedTokenURLScheme": {
+			middleware: &AgentMiddleware{
+				TokenExchange: &TokenExchange{
+					TokenURL: "ftp://sts.example.com/token",
+				},
+			},
+			matcher: gomega.MatchError(fmt.Errorf(InvalidTokenExchangeURLError, "ftp://sts.example.com/token")),
+		},
+		"ValidTokenReview": {
+			middleware: &AgentMiddleware{
+				TokenReview: &TokenReview{
+					Audiences:            []string{"https://kubernetes.default.svc"},
+					Verb:                 "create",
+					UnauthenticatedPaths: []UnauthenticatedPath{{Prefix: "/v2/health"}},
+				},
+			},
+			matcher: gomega.BeNil(),
+		},
+		"InvalidTokenReviewVerb": {
+			middleware: &AgentMiddleware{
+				TokenReview: &TokenReview{Verb: "Get"},
+			},
+			matcher: gomega.MatchError(gomega.ContainSubstring("Invalid token review verb \"Get\"")),
+		},
+		"UnauthenticatedRootOfTokenReview": {
+			middleware: &AgentMiddleware{
+				TokenReview: &TokenReview{UnauthenticatedPaths: []UnauthenticatedPath{{Prefix: "/"}}},
+			},
+			matcher: gomega.MatchError(fmt.Errorf(InvalidUnauthenticatedPathError, "/")),
+		},
+		"ValidUnauthenticatedPaths": {
+			middleware: &AgentMiddleware{
+				TokenExchange: &TokenExchange{
+					TokenURL: "https://sts.example.com/token",
+					UnauthenticatedPaths: []UnauthenticatedPath{
+						{Prefix: "/v2/health"},
+						{Prefix: "/metrics", SourceCIDRs: []string{"10.128.0.0/14", "fd00::/8"}},
+					},
+				},
+			},
+			matcher: gomega.BeNil(),
+		},
+		"UnauthenticatedRoot": {
+			middleware: &AgentMiddleware{
+				TokenExchange: &TokenExchange{
+					TokenURL:             "https://sts.example.com/token",
+					UnauthenticatedPaths: []UnauthenticatedPath{{Prefix: "/"}},
+				},
+			},
+			matcher: gomega.MatchError(fmt.Errorf(InvalidUnauthenticatedPathError, "/")),
+		},
+		"UncleanUnauthenticatedPath": {
+			middleware: &AgentMiddleware{
+				TokenExchange: &TokenExchange{
+					TokenURL:             "https://sts.example.com/token",
+					UnauthenticatedPaths: []UnauthenticatedPath{{Prefix: "/v2/health/"}},
+				},
+			},
+			matcher: gomega.MatchError(fmt.Errorf(InvalidUnauthenticatedPathError, "/v2/health/")),
+		},
+		"InvalidUnauthenticatedSource": {
+			middleware: &AgentMiddleware{
+				TokenExchange: &TokenExchange{
+					TokenURL:             "https://sts.example.com/token",
+					UnauthenticatedPaths: []UnauthenticatedPath{{Prefix: "/metrics", SourceCIDRs: []string{"10.0.0.1"}}},
+				},
+			},
+			matcher: gomega.MatchError(gomega.ContainSubstring("Invalid source CIDR \"10.0.0.1\"")),
+		},
+		"ValidGuardrail": {
+			middleware: &AgentMiddleware{
+				Guardrail: &GuardrailSpec{
+					Request:  &GuardrailHook{URL: "http://moderation.guardrails.svc/check"},
+					Response: &GuardrailHook{URL: "https://moderation.example.com/check", FailurePolicy: GuardrailFailurePolicyIgnore},
+				},
+			},
+			matcher: gomega.BeNil(),
+		},
+		"RelativeGuardrailHookURL": {
+			middleware: &AgentMiddleware{
+				Guardrail: &GuardrailSpec{
+					Response: &GuardrailHook{URL: "/check"},
+				},
+			},
+			matcher: gomega.MatchError(fmt.Errorf(InvalidGuardrailHookURLError, "/check")),
+		},
+		"ValidQuota": {
+			middleware: &AgentMiddleware{
+				TokenReview: &TokenReview{},
+				Quota: &QuotaSpec{
+					Period:       metav1.Duration{Duration: time.Minute},
+					DefaultLimit: proto.Int64(10),
+					Tenants:      map[string]int64{"system:serviceaccount:team-a:client": 100},
+				},
+			},
+			matcher: gomega.BeNil(),
+		},
+		"QuotaWithoutTokenReview": {
+			middleware: &AgentMiddleware{
+				Quota: &QuotaSpec{
+					Period: metav1.Duration{Duration: time.Minute},
+				},
+			},
+			matcher: gomega.MatchError(fmt.Errorf(InvalidQuotaTenantError)),
+		},
+		"QuotaWithoutPeriod": {
+			middleware: &AgentMiddleware{
+				TokenReview: &TokenReview{},
+				Quota:       &QuotaSpec{},
+			},
+			matcher: gomega.MatchError(fmt.Errorf(InvalidQuotaPeriodError, time.Duration(0))),
+		},
+		"NegativeTenantQuota": {
+			middleware: &AgentMiddleware{
+				TokenReview: &TokenReview{},
+				Quota: &QuotaSpec{
+					Period:  metav1.Duration{Duration: time.Hour},
+					Tenants: map[string]int64{"team-a": -1},
+				},
+			},
+			matcher: gomega.MatchError(fmt.Errorf(InvalidQuotaLimitError, -1, "team-a")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g.Expect(validateMiddleware(scenario.middleware)).To(scenario.matcher)
+		})
+	}
+}
+
 func TestFirstNonNilComponent(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	spec := PredictorSpec{
@@ -20,28 +20,65 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"strings"
+	"sync/atomic"
 	"text/template"
 
+	securityv1beta1 "istio.io/api/security/v1beta1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/network"
 
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
 )
 
 // ConfigMap Keys
 const (
-	ExplainerConfigKeyName = "explainers"
+	ExplainerConfigKeyName        = "explainers"
+	ResourceProfilesConfigKeyName = "resourceProfiles"
 )
 
 const (
-	IngressConfigKeyName = "ingress"
-	DeployConfigName     = "deploy"
+	IngressConfigKeyName     = "ingress"
+	DeployConfigName         = "deploy"
+	SecurityConfigName       = "security"
+	DriftPolicyConfigName    = "driftPolicy"
+	MeshConfigName           = "mesh"
+	LifecycleEventsName      = "lifecycleEvents"
+	DashboardConfigName      = "dashboard"
+	ControllerConfigName     = "controller"
+	TelemetryConfigName      = "telemetry"
+	BenchmarkConfigName      = "benchmark"
+	CostEstimationConfigName = "costEstimation"
+	IdleStopConfigName       = "idleStop"
+	GPUPreemptionConfigName  = "gpuPreemption"
 
 	DefaultDomainTemplate = "{{ .Name }}-{{ .Namespace }}.{{ .IngressDomain }}"
 	DefaultIngressDomain  = "example.com"
 
 	DefaultUrlScheme = "http"
+
+	DefaultCostCurrency = "USD"
+
+	// DefaultIdleSeconds stops the InferenceServices without requests for a week by default
+	DefaultIdleSeconds = 7 * 24 * 60 * 60
+	// MinIdleSeconds leaves time to the InferenceServices to receive their first requests
+	MinIdleSeconds = 60 * 60
+	// DefaultIdleCheckIntervalSeconds checks the traffic every hour by default
+	DefaultIdleCheckIntervalSeconds = 60 * 60
+
+	// DefaultPreemptionPendingSeconds leaves two minutes to the cluster autoscaler to add GPU nodes by default
+	DefaultPreemptionPendingSeconds       = 2 * 60
+	DefaultPreemptionCheckIntervalSeconds = 60
+
+	// DefaultTelemetryIntervalSeconds reports the usage once a day by default
+	DefaultTelemetryIntervalSeconds = 24 * 60 * 60
+	// MinTelemetryIntervalSeconds keeps the endpoint from being flooded by a misconfiguration
+	MinTelemetryIntervalSeconds = 60 * 60
 )
 
 // +kubebuilder:object:generate=false
@@ -61,6 +98,22 @@ type ExplainersConfig struct {
 type InferenceServicesConfig struct {
 	// Explainer configurations
 	Explainers ExplainersConfig `json:"explainers"`
+	// Default resources of the model predictors which do not specify resources
+	ResourceProfiles []ResourceProfile `json:"resourceProfiles,omitempty"`
+}
+
+// ResourceProfile defines the default resources of the model predictors matching a runtime, a model format
+// and a size class. An empty field matches any value.
+// +kubebuilder:object:generate=false
+type ResourceProfile struct {
+	// Runtime is the name of the serving runtime set on the predictor
+	Runtime string `json:"runtime,omitempty"`
+	// ModelFormat is the name of the model format of the predictor
+	ModelFormat string `json:"modelFormat,omitempty"`
+	// SizeClass is matched against the serving.kserve.io/size-class annotation of the InferenceService
+	SizeClass string `json:"sizeClass,omitempty"`
+	// Resources are the default CPU, memory and accelerator requests and limits
+	Resources v1.ResourceRequirements `json:"resources"`
 }
 
 // +kubebuilder:object:generate=false
@@ -77,11 +130,236 @@ type IngressConfig struct {
 	DisableIstioVirtualHost  bool      `json:"disableIstioVirtualHost,omitempty"`
 	PathTemplate             string    `json:"pathTemplate,omitempty"`
 	DisableIngressCreation   bool      `json:"disableIngressCreation,omitempty"`
+	RouteTLSTermination      string    `json:"routeTLSTermination,omitempty"`
+	// ClusterDomain is the DNS domain of the cluster used for the cluster local addresses of the raw deployments,
+	// it defaults to the domain detected from the resolv.conf of the controller.
+	ClusterDomain string `json:"clusterDomain,omitempty"`
+	// InternalUrlScheme is the scheme of the cluster local addresses, it defaults to the urlScheme.
+	InternalUrlScheme string `json:"internalUrlScheme,omitempty"`
+	// InternalPort is added to the cluster local addresses when set, the default port of the scheme is used otherwise.
+	InternalPort int32 `json:"internalPort,omitempty"`
+	// IngressDomains publish the InferenceServices and InferenceGraphs matching a rule on the domain of the rule in
+	// place of the ingressDomain, the first matching rule applies.
+	IngressDomains []IngressDomainRule `json:"ingressDomains,omitempty"`
+}
+
+// IngressDomainRule selects the resources published on a domain by their labels and annotations
+// +kubebuilder:object:generate=false
+type IngressDomainRule struct {
+	// Domain of the resources matching the rule
+	Domain string `json:"domain"`
+	// Labels the resources must have, with the same values
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations the resources must have, with the same values
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Matches returns whether the resource has all the labels and annotations of the rule
+func (r *IngressDomainRule) Matches(obj metav1.ObjectMeta) bool {
+	for key, value := range r.Labels {
+		if actual, ok := obj.Labels[key]; !ok || actual != value {
+			return false
+		}
+	}
+	for key, value := range r.Annotations {
+		if actual, ok := obj.Annotations[key]; !ok || actual != value {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate checks that the rule selects the resources of a valid domain
+func (r *IngressDomainRule) Validate() error {
+	if errs := validation.IsDNS1123Subdomain(r.Domain); len(errs) > 0 {
+		return fmt.Errorf("invalid domain %q: %s", r.Domain, strings.Join(errs, ", "))
+	}
+	if len(r.Labels) == 0 && len(r.Annotations) == 0 {
+		return fmt.Errorf("the rule of domain %q selects no labels or annotations", r.Domain)
+	}
+	return nil
+}
+
+// ForObject returns the ingress config of a resource, the ingressDomain is replaced with the domain of the first
+// ingressDomains rule matching the resource
+func (ic *IngressConfig) ForObject(obj metav1.ObjectMeta) *IngressConfig {
+	for i := range ic.IngressDomains {
+		if ic.IngressDomains[i].Matches(obj) {
+			config := *ic
+			config.IngressDomain = ic.IngressDomains[i].Domain
+			return &config
+		}
+	}
+	return ic
 }
 
 // +kubebuilder:object:generate=false
 type DeployConfig struct {
 	DefaultDeploymentMode string `json:"defaultDeploymentMode,omitempty"`
+	// RevisionHistoryLimit is the number of old ReplicaSets retained by the raw deployments, it defaults to 10 and
+	// can be overridden per resource with the serving.kserve.io/revisionHistoryLimit annotation.
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+	// ProgressDeadlineSeconds is the time the raw deployments have to make progress before they are reported as
+	// failed, it defaults to 600 and can be overridden per resource with the
+	// serving.kserve.io/progressDeadlineSeconds annotation.
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+}
+
+// +kubebuilder:object:generate=false
+type SecurityConfig struct {
+	// FIPSMode restricts the controller to FIPS compliant crypto: FIPS validated sidecar images are selected,
+	// only FIPS approved ciphers are offered by the webhook server and options incompatible with FIPS are rejected.
+	FIPSMode bool `json:"fipsMode,omitempty"`
+	// FIPSImages maps the sidecar images to their FIPS validated variants, it is only used in FIPS mode.
+	FIPSImages map[string]string `json:"fipsImages,omitempty"`
+	// SidecarSecurityContextBounds bounds the security context overrides of the router and agent containers, the
+	// overrides are rejected when it is not set.
+	SidecarSecurityContextBounds *utils.SecurityContextBounds `json:"sidecarSecurityContextBounds,omitempty"`
+}
+
+// +kubebuilder:object:generate=false
+type MeshConfig struct {
+	// Enabled requests the Istio sidecar injection into the raw deployments and excludes their health and metrics
+	// ports from the sidecar interception, so that they can be probed and scraped from outside the mesh.
+	Enabled bool `json:"enabled,omitempty"`
+	// ExcludeInboundPorts are additional container ports which bypass the sidecar of the raw deployments.
+	ExcludeInboundPorts []int32 `json:"excludeInboundPorts,omitempty"`
+	// PeerAuthenticationMode is the mTLS mode of the PeerAuthentication created for each raw deployment, one of
+	// STRICT, PERMISSIVE or DISABLE. No PeerAuthentication is created when it is empty.
+	PeerAuthenticationMode string `json:"peerAuthenticationMode,omitempty"`
+	// RequireNamespaceMembership rejects the Serverless InferenceServices and InferenceGraphs whose namespace is not
+	// enrolled into the service mesh, instead of letting their networking fail.
+	RequireNamespaceMembership bool `json:"requireNamespaceMembership,omitempty"`
+}
+
+// LifecycleEventsConfig selects the sink the lifecycle CloudEvents of the InferenceServices and InferenceGraphs of
+// each namespace are sent to
+// +kubebuilder:object:generate=false
+type LifecycleEventsConfig struct {
+	// DefaultSink is the URL the events of the namespaces without their own sink are sent to, no events are sent for
+	// them when it is empty.
+	DefaultSink string `json:"defaultSink,omitempty"`
+	// NamespaceSinks maps a namespace to the URL its events are sent to, an empty URL disables the events of the
+	// namespace.
+	NamespaceSinks map[string]string `json:"namespaceSinks,omitempty"`
+}
+
+// DashboardConfig controls the Grafana dashboard ConfigMap generated in each namespace with InferenceServices or
+// InferenceGraphs
+// +kubebuilder:object:generate=false
+type DashboardConfig struct {
+	// Enabled generates the dashboard ConfigMaps.
+	Enabled bool `json:"enabled,omitempty"`
+	// Label is the label the Grafana sidecar discovers the dashboard ConfigMaps with, it defaults to grafana_dashboard.
+	Label string `json:"label,omitempty"`
+	// LabelValue is the value of the label, it defaults to 1.
+	LabelValue string `json:"labelValue,omitempty"`
+	// Folder is set as the grafana_folder annotation of the dashboard ConfigMaps when it is not empty.
+	Folder string `json:"folder,omitempty"`
+}
+
+// ControllerConfig scopes the controller manager of this KServe instance
+// +kubebuilder:object:generate=false
+type ControllerConfig struct {
+	// WatchNamespaces is the allow-list of namespaces the controllers and the webhooks manage, all the namespaces are
+	// managed when it is empty. The --watch-namespaces flag and the WATCH_NAMESPACES env var take precedence.
+	WatchNamespaces []string `json:"watchNamespaces,omitempty"`
+}
+
+// TelemetryConfig opts in to the anonymous usage reporting. The aggregate counts of the InferenceServices per
+// deployment mode and runtime and of the InferenceGraph nodes per router type are posted to the endpoint, no names,
+// namespaces or specs are sent. It is disabled by default.
+// +kubebuilder:object:generate=false
+type TelemetryConfig struct {
+	// Enabled reports the usage to the endpoint.
+	Enabled bool `json:"enabled,omitempty"`
+	// Endpoint is the URL the usage reports are posted to as JSON, it is required when enabled.
+	Endpoint string `json:"endpoint,omitempty"`
+	// IntervalSeconds is the time between two reports, it defaults to a day and must be at least an hour.
+	IntervalSeconds int64 `json:"intervalSeconds,omitempty"`
+}
+
+// BenchmarkConfig holds the images of the load generators run by the benchmark Jobs of the InferenceServices, an
+// InferenceService can only use a tool whose image is configured.
+// +kubebuilder:object:generate=false
+type BenchmarkConfig struct {
+	// VegetaImage is an image with a shell and the vegeta binary on the PATH.
+	VegetaImage string `json:"vegetaImage,omitempty"`
+	// GhzImage is an image whose entrypoint is the ghz binary.
+	GhzImage string `json:"ghzImage,omitempty"`
+}
+
+// CostEstimationConfig holds the pricing table the hourly cost of the InferenceServices and InferenceGraphs is
+// estimated with. The estimate is the price of the resources requested by the containers of each component,
+// multiplied by its minimum number of replicas. It is disabled by default.
+// +kubebuilder:object:generate=false
+type CostEstimationConfig struct {
+	// Enabled annotates the InferenceServices and InferenceGraphs with their estimated hourly cost.
+	Enabled bool `json:"enabled,omitempty"`
+	// Currency is appended to the estimated cost, it defaults to USD.
+	Currency string `json:"currency,omitempty"`
+	// CPUCoreHour is the price of a CPU core per hour.
+	CPUCoreHour float64 `json:"cpuCoreHour,omitempty"`
+	// MemoryGiBHour is the price of a GiB of memory per hour.
+	MemoryGiBHour float64 `json:"memoryGiBHour,omitempty"`
+	// Accelerators maps the name of an extended resource, such as nvidia.com/gpu, to the price of a unit per hour.
+	Accelerators map[string]float64 `json:"accelerators,omitempty"`
+}
+
+// IdleStopConfig enables the stop of the InferenceServices that received no requests for a period, as reported by
+// the request metrics of the model servers in Prometheus. An InferenceService is opted out with the
+// serving.kserve.io/idle-stop-exempt label. It is disabled by default.
+// +kubebuilder:object:generate=false
+type IdleStopConfig struct {
+	// Enabled stops the idle InferenceServices with the serving.kserve.io/stop annotation.
+	Enabled bool `json:"enabled,omitempty"`
+	// PrometheusURL is the URL of the Prometheus API the request metrics are queried from, it is required when enabled.
+	PrometheusURL string `json:"prometheusUrl,omitempty"`
+	// IdleSeconds is the period without requests after which an InferenceService is stopped, it defaults to a week
+	// and must be at least an hour.
+	IdleSeconds int64 `json:"idleSeconds,omitempty"`
+	// CheckIntervalSeconds is the time between two checks of the traffic, it defaults to an hour.
+	CheckIntervalSeconds int64 `json:"checkIntervalSeconds,omitempty"`
+}
+
+// GPUPreemptionConfig enables the preemption of the InferenceServices of lower priority of the same namespace when the
+// GPU pods of an InferenceService cannot be scheduled. The priority of an InferenceService is its servingPriority, or
+// else the value of the PriorityClass of its predictor. It is disabled by default.
+// +kubebuilder:object:generate=false
+type GPUPreemptionConfig struct {
+	// Enabled stops the InferenceServices of lower priority with the serving.kserve.io/stop annotation to free their
+	// GPUs, they are started again once the InferenceService that preempted them is deleted.
+	Enabled bool `json:"enabled,omitempty"`
+	// ResourceName is the extended resource of the GPUs, it defaults to nvidia.com/gpu.
+	ResourceName string `json:"resourceName,omitempty"`
+	// PendingSeconds is how long a GPU pod is unschedulable before the InferenceServices of lower priority are
+	// preempted, it defaults to two minutes.
+	PendingSeconds int64 `json:"pendingSeconds,omitempty"`
+	// CheckIntervalSeconds is the time between two checks of the pending pods, it defaults to a minute.
+	CheckIntervalSeconds int64 `json:"checkIntervalSeconds,omitempty"`
+	// MaxServingPriority is the highest servingPriority the InferenceServices can set, the webhook rejects the higher
+	// ones and the preemption caps the value of the PriorityClass of the predictor to it. It defaults to 0 so that the
+	// servingPriority can only lower the priority of an InferenceService.
+	MaxServingPriority int32 `json:"maxServingPriority,omitempty"`
+}
+
+// DriftPolicy defines how the out-of-band edits of a child resource owned by an InferenceService are handled
+type DriftPolicy string
+
+const (
+	// DriftPolicyRevert reverts the edited fields to the desired state and records an event
+	DriftPolicyRevert DriftPolicy = "Revert"
+	// DriftPolicyWarn keeps the edited fields and records a warning event, the child resource is updated again
+	// only when the InferenceService changes
+	DriftPolicyWarn DriftPolicy = "Warn"
+)
+
+// DriftPolicyConfig selects the drift policy of each kind of child resource, the edits are reverted by default
+// +kubebuilder:object:generate=false
+type DriftPolicyConfig struct {
+	Deployment DriftPolicy `json:"deployment,omitempty"`
+	Service    DriftPolicy `json:"service,omitempty"`
+	Ingress    DriftPolicy `json:"ingress,omitempty"`
 }
 
 func NewInferenceServicesConfig(clientset kubernetes.Interface) (*InferenceServicesConfig, error) {
@@ -89,9 +367,15 @@ func NewInferenceServicesConfig(clientset kubernetes.Interface) (*InferenceServi
 	if err != nil {
 		return nil, err
 	}
+	return GetInferenceServicesConfig(configMap)
+}
+
+// GetInferenceServicesConfig parses the inference services config from the inferenceservice configmap
+func GetInferenceServicesConfig(configMap *v1.ConfigMap) (*InferenceServicesConfig, error) {
 	icfg := &InferenceServicesConfig{}
 	for _, err := range []error{
 		getComponentConfig(ExplainerConfigKeyName, configMap, &icfg.Explainers),
+		getComponentConfig(ResourceProfilesConfigKeyName, configMap, &icfg.ResourceProfiles),
 	} {
 		if err != nil {
 			return nil, err
@@ -100,6 +384,35 @@ func NewInferenceServicesConfig(clientset kubernetes.Interface) (*InferenceServi
 	return icfg, nil
 }
 
+// GetResourceProfile returns the most specific resource profile matching the runtime, the model format and
+// the size class, the first profile wins between equally specific profiles.
+func (c *InferenceServicesConfig) GetResourceProfile(runtime string, modelFormat string, sizeClass string) *ResourceProfile {
+	var match *ResourceProfile
+	bestScore := -1
+	for i := range c.ResourceProfiles {
+		profile := &c.ResourceProfiles[i]
+		score, matches := 0, true
+		for _, field := range [][2]string{
+			{profile.Runtime, runtime},
+			{profile.ModelFormat, modelFormat},
+			{profile.SizeClass, sizeClass},
+		} {
+			if field[0] == "" {
+				continue
+			}
+			if field[0] != field[1] {
+				matches = false
+				break
+			}
+			score++
+		}
+		if matches && score > bestScore {
+			match, bestScore = profile, score
+		}
+	}
+	return match
+}
+
 func NewIngressConfig(clientset kubernetes.Interface) (*IngressConfig, error) {
 	configMap, err := clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Get(context.TODO(), constants.InferenceServiceConfigMapName, metav1.GetOptions{})
 	if err != nil {
@@ -128,6 +441,24 @@ func NewIngressConfig(clientset kubernetes.Interface) (*IngressConfig, error) {
 				return nil, fmt.Errorf("invalid ingress config - ingressDomain is required if pathTemplate is given")
 			}
 		}
+		if ingressConfig.RouteTLSTermination != "" &&
+			ingressConfig.RouteTLSTermination != string(constants.RouteTLSTerminationEdge) &&
+			ingressConfig.RouteTLSTermination != string(constants.RouteTLSTerminationReencrypt) {
+			return nil, fmt.Errorf("invalid ingress config - routeTLSTermination must be one of %s or %s",
+				constants.RouteTLSTerminationEdge, constants.RouteTLSTerminationReencrypt)
+		}
+		if ingressConfig.InternalUrlScheme != "" && ingressConfig.InternalUrlScheme != "http" &&
+			ingressConfig.InternalUrlScheme != "https" {
+			return nil, fmt.Errorf("invalid ingress config - internalUrlScheme must be one of http or https")
+		}
+		if ingressConfig.InternalPort < 0 || ingressConfig.InternalPort > 65535 {
+			return nil, fmt.Errorf("invalid ingress config - internalPort must be between 1 and 65535")
+		}
+		for i := range ingressConfig.IngressDomains {
+			if err := ingressConfig.IngressDomains[i].Validate(); err != nil {
+				return nil, fmt.Errorf("invalid ingress config - ingressDomains: %w", err)
+			}
+		}
 	}
 
 	if ingressConfig.DomainTemplate == "" {
@@ -142,6 +473,14 @@ func NewIngressConfig(clientset kubernetes.Interface) (*IngressConfig, error) {
 		ingressConfig.UrlScheme = DefaultUrlScheme
 	}
 
+	if ingressConfig.InternalUrlScheme == "" {
+		ingressConfig.InternalUrlScheme = ingressConfig.UrlScheme
+	}
+
+	if ingressConfig.ClusterDomain == "" {
+		ingressConfig.ClusterDomain = network.GetClusterDomainName()
+	}
+
 	return ingressConfig, nil
 }
 
@@ -177,6 +516,346 @@ func NewDeployConfig(clientset kubernetes.Interface) (*DeployConfig, error) {
 			return nil, fmt.Errorf("invalid deployment mode. Supported modes are Serverless," +
 				" RawDeployment and ModelMesh")
 		}
+
+		if deployConfig.RevisionHistoryLimit != nil && *deployConfig.RevisionHistoryLimit < 0 {
+			return nil, fmt.Errorf("invalid deploy config, revisionHistoryLimit must not be negative")
+		}
+
+		if deployConfig.ProgressDeadlineSeconds != nil && *deployConfig.ProgressDeadlineSeconds <= 0 {
+			return nil, fmt.Errorf("invalid deploy config, progressDeadlineSeconds must be positive")
+		}
 	}
 	return deployConfig, nil
 }
+
+func NewSecurityConfig(clientset kubernetes.Interface) (*SecurityConfig, error) {
+	configMap, err := clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Get(context.TODO(), constants.InferenceServiceConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return GetSecurityConfig(configMap)
+}
+
+// GetSecurityConfig parses the security config from the inferenceservice configmap
+func GetSecurityConfig(configMap *v1.ConfigMap) (*SecurityConfig, error) {
+	securityConfig := &SecurityConfig{}
+	if err := getComponentConfig(SecurityConfigName, configMap, securityConfig); err != nil {
+		return nil, err
+	}
+	for image, fipsImage := range securityConfig.FIPSImages {
+		if image == "" || fipsImage == "" {
+			return nil, fmt.Errorf("invalid security config - fipsImages entries must not be empty")
+		}
+	}
+	if securityConfig.SidecarSecurityContextBounds != nil {
+		if err := securityConfig.SidecarSecurityContextBounds.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return securityConfig, nil
+}
+
+// GetImage returns the FIPS validated variant of a sidecar image in FIPS mode, otherwise the image is returned unchanged.
+func (c *SecurityConfig) GetImage(image string) string {
+	if c.FIPSMode {
+		if fipsImage, ok := c.FIPSImages[image]; ok {
+			return fipsImage
+		}
+	}
+	return image
+}
+
+func NewDriftPolicyConfig(clientset kubernetes.Interface) (*DriftPolicyConfig, error) {
+	configMap, err := clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Get(context.TODO(), constants.InferenceServiceConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return GetDriftPolicyConfig(configMap)
+}
+
+// GetDriftPolicyConfig parses the drift policy config from the inferenceservice configmap
+func GetDriftPolicyConfig(configMap *v1.ConfigMap) (*DriftPolicyConfig, error) {
+	driftPolicyConfig := &DriftPolicyConfig{}
+	if err := getComponentConfig(DriftPolicyConfigName, configMap, driftPolicyConfig); err != nil {
+		return nil, err
+	}
+	for _, policy := range []*DriftPolicy{&driftPolicyConfig.Deployment, &driftPolicyConfig.Service, &driftPolicyConfig.Ingress} {
+		switch *policy {
+		case "":
+			*policy = DriftPolicyRevert
+		case DriftPolicyRevert, DriftPolicyWarn:
+		default:
+			return nil, fmt.Errorf("invalid drift policy %s, must be one of %s or %s", *policy, DriftPolicyRevert, DriftPolicyWarn)
+		}
+	}
+	return driftPolicyConfig, nil
+}
+
+func NewMeshConfig(clientset kubernetes.Interface) (*MeshConfig, error) {
+	configMap, err := clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Get(context.TODO(), constants.InferenceServiceConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return GetMeshConfig(configMap)
+}
+
+// GetMeshConfig parses the service mesh config from the inferenceservice configmap
+func GetMeshConfig(configMap *v1.ConfigMap) (*MeshConfig, error) {
+	meshConfig := &MeshConfig{}
+	if err := getComponentConfig(MeshConfigName, configMap, meshConfig); err != nil {
+		return nil, err
+	}
+	for _, port := range meshConfig.ExcludeInboundPorts {
+		if port < 1 || port > 65535 {
+			return nil, fmt.Errorf("invalid mesh config - excludeInboundPorts must be between 1 and 65535")
+		}
+	}
+	if meshConfig.PeerAuthenticationMode != "" {
+		if !meshConfig.Enabled {
+			return nil, fmt.Errorf("invalid mesh config - peerAuthenticationMode requires the mesh to be enabled")
+		}
+		if _, ok := securityv1beta1.PeerAuthentication_MutualTLS_Mode_value[meshConfig.PeerAuthenticationMode]; !ok ||
+			meshConfig.PeerAuthenticationMode == securityv1beta1.PeerAuthentication_MutualTLS_UNSET.String() {
+			return nil, fmt.Errorf("invalid mesh config - peerAuthenticationMode must be one of STRICT, PERMISSIVE or DISABLE")
+		}
+	}
+	return meshConfig, nil
+}
+
+func NewLifecycleEventsConfig(clientset kubernetes.Interface) (*LifecycleEventsConfig, error) {
+	configMap, err := clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Get(context.TODO(), constants.InferenceServiceConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return GetLifecycleEventsConfig(configMap)
+}
+
+// GetLifecycleEventsConfig parses the lifecycle events config from the inferenceservice configmap
+func GetLifecycleEventsConfig(configMap *v1.ConfigMap) (*LifecycleEventsConfig, error) {
+	lifecycleEventsConfig := &LifecycleEventsConfig{}
+	if err := getComponentConfig(LifecycleEventsName, configMap, lifecycleEventsConfig); err != nil {
+		return nil, err
+	}
+	sinks := []string{lifecycleEventsConfig.DefaultSink}
+	for _, sink := range lifecycleEventsConfig.NamespaceSinks {
+		sinks = append(sinks, sink)
+	}
+	for _, sink := range sinks {
+		if sink == "" {
+			continue
+		}
+		if sinkURL, err := url.Parse(sink); err != nil || (sinkURL.Scheme != "http" && sinkURL.Scheme != "https") || sinkURL.Host == "" {
+			return nil, fmt.Errorf("invalid lifecycle events config - sink %s must be an absolute http or https URL", sink)
+		}
+	}
+	return lifecycleEventsConfig, nil
+}
+
+// GetSink returns the sink the events of the namespace are sent to, it is empty when no events are sent
+func (c *LifecycleEventsConfig) GetSink(namespace string) string {
+	if sink, ok := c.NamespaceSinks[namespace]; ok {
+		return sink
+	}
+	return c.DefaultSink
+}
+
+func NewDashboardConfig(clientset kubernetes.Interface) (*DashboardConfig, error) {
+	configMap, err := clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Get(context.TODO(), constants.InferenceServiceConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return GetDashboardConfig(configMap)
+}
+
+// GetDashboardConfig parses the dashboard config from the inferenceservice configmap
+func GetDashboardConfig(configMap *v1.ConfigMap) (*DashboardConfig, error) {
+	dashboardConfig := &DashboardConfig{}
+	if err := getComponentConfig(DashboardConfigName, configMap, dashboardConfig); err != nil {
+		return nil, err
+	}
+	if dashboardConfig.Label == "" {
+		dashboardConfig.Label = constants.DefaultDashboardLabel
+		if dashboardConfig.LabelValue == "" {
+			dashboardConfig.LabelValue = constants.DefaultDashboardLabelValue
+		}
+	}
+	if errs := validation.IsQualifiedName(dashboardConfig.Label); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid dashboard config - label %s: %s", dashboardConfig.Label, strings.Join(errs, ", "))
+	}
+	if errs := validation.IsValidLabelValue(dashboardConfig.LabelValue); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid dashboard config - labelValue %s: %s", dashboardConfig.LabelValue, strings.Join(errs, ", "))
+	}
+	return dashboardConfig, nil
+}
+
+func NewControllerConfig(clientset kubernetes.Interface) (*ControllerConfig, error) {
+	configMap, err := clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Get(context.TODO(), constants.InferenceServiceConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return GetControllerConfig(configMap)
+}
+
+// GetControllerConfig parses the controller config from the inferenceservice configmap
+func GetControllerConfig(configMap *v1.ConfigMap) (*ControllerConfig, error) {
+	controllerConfig := &ControllerConfig{}
+	if err := getComponentConfig(ControllerConfigName, configMap, controllerConfig); err != nil {
+		return nil, err
+	}
+	for _, namespace := range controllerConfig.WatchNamespaces {
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid controller config - watchNamespaces %s: %s", namespace, strings.Join(errs, ", "))
+		}
+	}
+	return controllerConfig, nil
+}
+
+func NewTelemetryConfig(clientset kubernetes.Interface) (*TelemetryConfig, error) {
+	configMap, err := clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Get(context.TODO(), constants.InferenceServiceConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return GetTelemetryConfig(configMap)
+}
+
+// GetTelemetryConfig parses the telemetry config from the inferenceservice configmap
+func GetTelemetryConfig(configMap *v1.ConfigMap) (*TelemetryConfig, error) {
+	telemetryConfig := &TelemetryConfig{}
+	if err := getComponentConfig(TelemetryConfigName, configMap, telemetryConfig); err != nil {
+		return nil, err
+	}
+	if telemetryConfig.IntervalSeconds == 0 {
+		telemetryConfig.IntervalSeconds = DefaultTelemetryIntervalSeconds
+	}
+	if !telemetryConfig.Enabled {
+		return telemetryConfig, nil
+	}
+	if telemetryConfig.Endpoint == "" {
+		return nil, fmt.Errorf("invalid telemetry config - endpoint is required when enabled")
+	}
+	if endpoint, err := url.ParseRequestURI(telemetryConfig.Endpoint); err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid telemetry config - endpoint %s is not an absolute URL", telemetryConfig.Endpoint)
+	}
+	if telemetryConfig.IntervalSeconds < MinTelemetryIntervalSeconds {
+		return nil, fmt.Errorf("invalid telemetry config - intervalSeconds must be at least %d", MinTelemetryIntervalSeconds)
+	}
+	return telemetryConfig, nil
+}
+
+func NewBenchmarkConfig(clientset kubernetes.Interface) (*BenchmarkConfig, error) {
+	configMap, err := clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Get(context.TODO(), constants.InferenceServiceConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return GetBenchmarkConfig(configMap)
+}
+
+// GetBenchmarkConfig parses the benchmark config from the inferenceservice configmap
+func GetBenchmarkConfig(configMap *v1.ConfigMap) (*BenchmarkConfig, error) {
+	benchmarkConfig := &BenchmarkConfig{}
+	if err := getComponentConfig(BenchmarkConfigName, configMap, benchmarkConfig); err != nil {
+		return nil, err
+	}
+	return benchmarkConfig, nil
+}
+
+func NewCostEstimationConfig(clientset kubernetes.Interface) (*CostEstimationConfig, error) {
+	configMap, err := clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Get(context.TODO(), constants.InferenceServiceConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return GetCostEstimationConfig(configMap)
+}
+
+// GetCostEstimationConfig parses the cost estimation config from the inferenceservice configmap
+func GetCostEstimationConfig(configMap *v1.ConfigMap) (*CostEstimationConfig, error) {
+	costEstimationConfig := &CostEstimationConfig{}
+	if err := getComponentConfig(CostEstimationConfigName, configMap, costEstimationConfig); err != nil {
+		return nil, err
+	}
+	if costEstimationConfig.Currency == "" {
+		costEstimationConfig.Currency = DefaultCostCurrency
+	}
+	if costEstimationConfig.CPUCoreHour < 0 || costEstimationConfig.MemoryGiBHour < 0 {
+		return nil, fmt.Errorf("invalid cost estimation config - prices must not be negative")
+	}
+	for name, price := range costEstimationConfig.Accelerators {
+		if price < 0 {
+			return nil, fmt.Errorf("invalid cost estimation config - the price of %s must not be negative", name)
+		}
+	}
+	return costEstimationConfig, nil
+}
+
+func NewIdleStopConfig(clientset kubernetes.Interface) (*IdleStopConfig, error) {
+	configMap, err := clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Get(context.TODO(), constants.InferenceServiceConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return GetIdleStopConfig(configMap)
+}
+
+// GetIdleStopConfig parses the idle stop config from the inferenceservice configmap
+func GetIdleStopConfig(configMap *v1.ConfigMap) (*IdleStopConfig, error) {
+	idleStopConfig := &IdleStopConfig{}
+	if err := getComponentConfig(IdleStopConfigName, configMap, idleStopConfig); err != nil {
+		return nil, err
+	}
+	if idleStopConfig.IdleSeconds == 0 {
+		idleStopConfig.IdleSeconds = DefaultIdleSeconds
+	}
+	if idleStopConfig.CheckIntervalSeconds == 0 {
+		idleStopConfig.CheckIntervalSeconds = DefaultIdleCheckIntervalSeconds
+	}
+	if !idleStopConfig.Enabled {
+		return idleStopConfig, nil
+	}
+	if prometheusURL, err := url.ParseRequestURI(idleStopConfig.PrometheusURL); err != nil || prometheusURL.Host == "" {
+		return nil, fmt.Errorf("invalid idle stop config - prometheusUrl %q is not an absolute URL", idleStopConfig.PrometheusURL)
+	}
+	if idleStopConfig.IdleSeconds < MinIdleSeconds {
+		return nil, fmt.Errorf("invalid idle stop config - idleSeconds must be at least %d", MinIdleSeconds)
+	}
+	if idleStopConfig.CheckIntervalSeconds < 0 {
+		return nil, fmt.Errorf("invalid idle stop config - checkIntervalSeconds must be positive")
+	}
+	return idleStopConfig, nil
+}
+
+func NewGPUPreemptionConfig(clientset kubernetes.Interface) (*GPUPreemptionConfig, error) {
+	configMap, err := clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Get(context.TODO(), constants.InferenceServiceConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return GetGPUPreemptionConfig(configMap)
+}
+
+// GetGPUPreemptionConfig parses the GPU preemption config from the inferenceservice configmap
+func GetGPUPreemptionConfig(configMap *v1.ConfigMap) (*GPUPreemptionConfig, error) {
+	gpuPreemptionConfig := &GPUPreemptionConfig{}
+	if err := getComponentConfig(GPUPreemptionConfigName, configMap, gpuPreemptionConfig); err != nil {
+		return nil, err
+	}
+	if gpuPreemptionConfig.ResourceName == "" {
+		gpuPreemptionConfig.ResourceName = constants.NvidiaGPUResourceType
+	}
+	if gpuPreemptionConfig.PendingSeconds == 0 {
+		gpuPreemptionConfig.PendingSeconds = DefaultPreemptionPendingSeconds
+	}
+	if gpuPreemptionConfig.CheckIntervalSeconds == 0 {
+		gpuPreemptionConfig.CheckIntervalSeconds = DefaultPreemptionCheckIntervalSeconds
+	}
+	if gpuPreemptionConfig.PendingSeconds < 0 || gpuPreemptionConfig.CheckIntervalSeconds < 0 {
+		return nil, fmt.Errorf("invalid gpu preemption config - pendingSeconds and checkIntervalSeconds must be positive")
+	}
+	return gpuPreemptionConfig, nil
+}
+
+// maxServingPriority is set by the controller manager from the GPU preemption config every time it changes, the
+// webhooks do not have access to the configmap.
+var maxServingPriority atomic.Int32
+
+// SetMaxServingPriority sets the highest servingPriority the InferenceServices are validated against
+func SetMaxServingPriority(priority int32) {
+	maxServingPriority.Store(priority)
+}
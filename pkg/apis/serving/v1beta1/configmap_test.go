@@ -25,6 +25,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"knative.dev/pkg/network"
 )
 
 var (
@@ -77,6 +78,69 @@ func TestNewIngressConfig(t *testing.T) {
 	g.Expect(ingressCfg.UrlScheme).To(gomega.Equal(UrlScheme))
 	g.Expect(ingressCfg.IngressDomain).To(gomega.Equal(IngressDomain))
 	g.Expect(*ingressCfg.AdditionalIngressDomains).To(gomega.Equal([]string{AdditionalDomain, AdditionalDomainExtra}))
+	g.Expect(ingressCfg.InternalUrlScheme).To(gomega.Equal(UrlScheme))
+	g.Expect(ingressCfg.ClusterDomain).To(gomega.Equal(network.GetClusterDomainName()))
+	g.Expect(ingressCfg.InternalPort).To(gomega.BeZero())
+
+	clientset = fakeclientset.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+		Data: map[string]string{
+			IngressConfigKeyName: `{"ingressGateway": "knative-serving/knative-ingress-gateway", "ingressService": "test-destination",
+				"urlScheme": "https", "clusterDomain": "corp.internal", "internalUrlScheme": "http", "internalPort": 8080}`,
+		},
+	})
+	ingressCfg, err = NewIngressConfig(clientset)
+	g.Expect(err).Should(gomega.BeNil())
+	g.Expect(ingressCfg.UrlScheme).To(gomega.Equal("https"))
+	g.Expect(ingressCfg.InternalUrlScheme).To(gomega.Equal("http"))
+	g.Expect(ingressCfg.ClusterDomain).To(gomega.Equal("corp.internal"))
+	g.Expect(ingressCfg.InternalPort).To(gomega.Equal(int32(8080)))
+
+	clientset = fakeclientset.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+		Data: map[string]string{
+			IngressConfigKeyName: `{"ingressGateway": "knative-serving/knative-ingress-gateway", "ingressService": "test-destination",
+				"internalUrlScheme": "grpc"}`,
+		},
+	})
+	_, err = NewIngressConfig(clientset)
+	g.Expect(err).ShouldNot(gomega.BeNil())
+
+	clientset = fakeclientset.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+		Data: map[string]string{
+			IngressConfigKeyName: `{"ingressGateway": "knative-serving/knative-ingress-gateway", "ingressService": "test-destination",
+				"ingressDomains": [{"domain": "internal.example.com"}]}`,
+		},
+	})
+	_, err = NewIngressConfig(clientset)
+	g.Expect(err).ShouldNot(gomega.BeNil())
+}
+
+func TestIngressConfigForObject(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ingressCfg := &IngressConfig{
+		IngressDomain: "example.com",
+		IngressDomains: []IngressDomainRule{
+			{Domain: "internal.example.com", Labels: map[string]string{"exposure": "internal"}},
+			{Domain: "partner.example.com", Annotations: map[string]string{"example.com/partner": "true"}},
+			{Domain: "other.example.com", Labels: map[string]string{"exposure": "internal"}},
+		},
+	}
+
+	g.Expect(ingressCfg.ForObject(metav1.ObjectMeta{}).IngressDomain).To(gomega.Equal("example.com"))
+	g.Expect(ingressCfg.ForObject(metav1.ObjectMeta{
+		Labels: map[string]string{"exposure": "public"},
+	}).IngressDomain).To(gomega.Equal("example.com"))
+	g.Expect(ingressCfg.ForObject(metav1.ObjectMeta{
+		Labels:      map[string]string{"exposure": "internal"},
+		Annotations: map[string]string{"example.com/partner": "true"},
+	}).IngressDomain).To(gomega.Equal("internal.example.com"))
+	g.Expect(ingressCfg.ForObject(metav1.ObjectMeta{
+		Annotations: map[string]string{"example.com/partner": "true"},
+	}).IngressDomain).To(gomega.Equal("partner.example.com"))
+	// the config shared by the resources is left unchanged
+	g.Expect(ingressCfg.IngressDomain).To(gomega.Equal("example.com"))
 }
 
 func TestNewDeployConfig(t *testing.T) {
@@ -87,4 +151,311 @@ func TestNewDeployConfig(t *testing.T) {
 	deployConfig, err := NewDeployConfig(clientset)
 	g.Expect(err).Should(gomega.BeNil())
 	g.Expect(deployConfig).ShouldNot(gomega.BeNil())
+
+	clientset = fakeclientset.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+		Data: map[string]string{
+			DeployConfigName: `{"defaultDeploymentMode": "RawDeployment", "revisionHistoryLimit": 3, "progressDeadlineSeconds": 3600}`,
+		},
+	})
+	deployConfig, err = NewDeployConfig(clientset)
+	g.Expect(err).Should(gomega.BeNil())
+	g.Expect(*deployConfig.RevisionHistoryLimit).Should(gomega.Equal(int32(3)))
+	g.Expect(*deployConfig.ProgressDeadlineSeconds).Should(gomega.Equal(int32(3600)))
+
+	clientset = fakeclientset.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+		Data: map[string]string{
+			DeployConfigName: `{"defaultDeploymentMode": "RawDeployment", "progressDeadlineSeconds": 0}`,
+		},
+	})
+	_, err = NewDeployConfig(clientset)
+	g.Expect(err).ShouldNot(gomega.BeNil())
+}
+
+func TestNewSecurityConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	clientset := fakeclientset.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+		Data: map[string]string{
+			SecurityConfigName: `{"fipsMode": true, "fipsImages": {"kserve/agent:latest": "kserve/agent-fips:latest"}}`,
+		},
+	})
+	securityConfig, err := NewSecurityConfig(clientset)
+	g.Expect(err).Should(gomega.BeNil())
+	g.Expect(securityConfig.GetImage("kserve/agent:latest")).Should(gomega.Equal("kserve/agent-fips:latest"))
+	g.Expect(securityConfig.GetImage("kserve/router:latest")).Should(gomega.Equal("kserve/router:latest"))
+
+	securityConfig.FIPSMode = false
+	g.Expect(securityConfig.GetImage("kserve/agent:latest")).Should(gomega.Equal("kserve/agent:latest"))
+
+	_, err = GetSecurityConfig(&v1.ConfigMap{
+		Data: map[string]string{
+			SecurityConfigName: `{"fipsMode": true, "fipsImages": {"kserve/agent:latest": ""}}`,
+		},
+	})
+	g.Expect(err).ShouldNot(gomega.BeNil())
+}
+
+func TestNewDriftPolicyConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	clientset := fakeclientset.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+		Data: map[string]string{
+			DriftPolicyConfigName: `{"deployment": "Warn"}`,
+		},
+	})
+	driftPolicyConfig, err := NewDriftPolicyConfig(clientset)
+	g.Expect(err).Should(gomega.BeNil())
+	g.Expect(driftPolicyConfig).Should(gomega.Equal(&DriftPolicyConfig{
+		Deployment: DriftPolicyWarn,
+		Service:    DriftPolicyRevert,
+		Ingress:    DriftPolicyRevert,
+	}))
+
+	_, err = GetDriftPolicyConfig(&v1.ConfigMap{
+		Data: map[string]string{
+			DriftPolicyConfigName: `{"service": "Ignore"}`,
+		},
+	})
+	g.Expect(err).ShouldNot(gomega.BeNil())
+}
+
+func TestNewMeshConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	clientset := fakeclientset.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+		Data: map[string]string{
+			MeshConfigName: `{"enabled": true, "excludeInboundPorts": [15020], "peerAuthenticationMode": "STRICT"}`,
+		},
+	})
+	meshConfig, err := NewMeshConfig(clientset)
+	g.Expect(err).Should(gomega.BeNil())
+	g.Expect(meshConfig).Should(gomega.Equal(&MeshConfig{
+		Enabled:                true,
+		ExcludeInboundPorts:    []int32{15020},
+		PeerAuthenticationMode: "STRICT",
+	}))
+
+	for _, data := range []string{
+		`{"enabled": true, "excludeInboundPorts": [0]}`,
+		`{"enabled": true, "peerAuthenticationMode": "UNSET"}`,
+		`{"enabled": true, "peerAuthenticationMode": "strict"}`,
+		`{"peerAuthenticationMode": "STRICT"}`,
+	} {
+		_, err = GetMeshConfig(&v1.ConfigMap{
+			Data: map[string]string{
+				MeshConfigName: data,
+			},
+		})
+		g.Expect(err).ShouldNot(gomega.BeNil(), data)
+	}
+}
+
+func TestNewLifecycleEventsConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	clientset := fakeclientset.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+		Data: map[string]string{
+			LifecycleEventsName: `{"defaultSink": "http://broker.platform", "namespaceSinks": {"team-a": "https://events.team-a", "quiet": ""}}`,
+		},
+	})
+	lifecycleEventsConfig, err := NewLifecycleEventsConfig(clientset)
+	g.Expect(err).Should(gomega.BeNil())
+	g.Expect(lifecycleEventsConfig.GetSink("team-a")).Should(gomega.Equal("https://events.team-a"))
+	g.Expect(lifecycleEventsConfig.GetSink("quiet")).Should(gomega.BeEmpty())
+	g.Expect(lifecycleEventsConfig.GetSink("default")).Should(gomega.Equal("http://broker.platform"))
+
+	for _, data := range []string{
+		`{"defaultSink": "broker.platform"}`,
+		`{"namespaceSinks": {"team-a": "ftp://events.team-a"}}`,
+		`{"namespaceSinks": {"team-a": "/events"}}`,
+	} {
+		_, err = GetLifecycleEventsConfig(&v1.ConfigMap{
+			Data: map[string]string{
+				LifecycleEventsName: data,
+			},
+		})
+		g.Expect(err).ShouldNot(gomega.BeNil(), data)
+	}
+}
+
+func TestNewDashboardConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	clientset := fakeclientset.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+		Data: map[string]string{
+			DashboardConfigName: `{"enabled": true}`,
+		},
+	})
+	dashboardConfig, err := NewDashboardConfig(clientset)
+	g.Expect(err).Should(gomega.BeNil())
+	g.Expect(dashboardConfig).Should(gomega.Equal(&DashboardConfig{
+		Enabled:    true,
+		Label:      constants.DefaultDashboardLabel,
+		LabelValue: constants.DefaultDashboardLabelValue,
+	}))
+
+	for _, data := range []string{
+		`{"enabled": true, "label": "grafana dashboard"}`,
+		`{"enabled": true, "label": "grafana_dashboard", "labelValue": "not a value"}`,
+	} {
+		_, err = GetDashboardConfig(&v1.ConfigMap{
+			Data: map[string]string{
+				DashboardConfigName: data,
+			},
+		})
+		g.Expect(err).ShouldNot(gomega.BeNil(), data)
+	}
+}
+
+func TestNewControllerConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	clientset := fakeclientset.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+		Data: map[string]string{
+			ControllerConfigName: `{"watchNamespaces": ["team-a", "team-b"]}`,
+		},
+	})
+	controllerConfig, err := NewControllerConfig(clientset)
+	g.Expect(err).Should(gomega.BeNil())
+	g.Expect(controllerConfig.WatchNamespaces).Should(gomega.Equal([]string{"team-a", "team-b"}))
+
+	controllerConfig, err = GetControllerConfig(&v1.ConfigMap{})
+	g.Expect(err).Should(gomega.BeNil())
+	g.Expect(controllerConfig.WatchNamespaces).Should(gomega.BeEmpty())
+
+	_, err = GetControllerConfig(&v1.ConfigMap{
+		Data: map[string]string{
+			ControllerConfigName: `{"watchNamespaces": ["Team_A"]}`,
+		},
+	})
+	g.Expect(err).ShouldNot(gomega.BeNil())
+}
+
+func TestNewTelemetryConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	clientset := fakeclientset.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+		Data: map[string]string{
+			TelemetryConfigName: `{"enabled": true, "endpoint": "https://telemetry.example.com/v1/report"}`,
+		},
+	})
+	telemetryConfig, err := NewTelemetryConfig(clientset)
+	g.Expect(err).Should(gomega.BeNil())
+	g.Expect(telemetryConfig).Should(gomega.Equal(&TelemetryConfig{
+		Enabled:         true,
+		Endpoint:        "https://telemetry.example.com/v1/report",
+		IntervalSeconds: DefaultTelemetryIntervalSeconds,
+	}))
+
+	// the telemetry is disabled by default
+	telemetryConfig, err = GetTelemetryConfig(&v1.ConfigMap{})
+	g.Expect(err).Should(gomega.BeNil())
+	g.Expect(telemetryConfig.Enabled).Should(gomega.BeFalse())
+
+	for _, data := range []string{
+		`{"enabled": true}`,
+		`{"enabled": true, "endpoint": "telemetry.example.com"}`,
+		`{"enabled": true, "endpoint": "https://telemetry.example.com", "intervalSeconds": 60}`,
+	} {
+		_, err = GetTelemetryConfig(&v1.ConfigMap{
+			Data: map[string]string{
+				TelemetryConfigName: data,
+			},
+		})
+		g.Expect(err).ShouldNot(gomega.BeNil(), data)
+	}
+}
+
+func TestNewBenchmarkConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	clientset := fakeclientset.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+		Data: map[string]string{
+			BenchmarkConfigName: `{"vegetaImage": "peterevans/vegeta:6.9.1"}`,
+		},
+	})
+	benchmarkConfig, err := NewBenchmarkConfig(clientset)
+	g.Expect(err).Should(gomega.BeNil())
+	g.Expect(benchmarkConfig).Should(gomega.Equal(&BenchmarkConfig{VegetaImage: "peterevans/vegeta:6.9.1"}))
+
+	_, err = GetBenchmarkConfig(&v1.ConfigMap{
+		Data: map[string]string{
+			BenchmarkConfigName: `{"vegetaImage": 1}`,
+		},
+	})
+	g.Expect(err).ShouldNot(gomega.BeNil())
+}
+
+func TestGetCostEstimationConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	costEstimationConfig, err := GetCostEstimationConfig(&v1.ConfigMap{
+		Data: map[string]string{
+			CostEstimationConfigName: `{"enabled": true, "cpuCoreHour": 0.03, "memoryGiBHour": 0.004, "accelerators": {"nvidia.com/gpu": 2.5}}`,
+		},
+	})
+	g.Expect(err).Should(gomega.BeNil())
+	g.Expect(costEstimationConfig).Should(gomega.Equal(&CostEstimationConfig{
+		Enabled:       true,
+		Currency:      DefaultCostCurrency,
+		CPUCoreHour:   0.03,
+		MemoryGiBHour: 0.004,
+		Accelerators:  map[string]float64{"nvidia.com/gpu": 2.5},
+	}))
+
+	_, err = GetCostEstimationConfig(&v1.ConfigMap{
+		Data: map[string]string{
+			CostEstimationConfigName: `{"enabled": true, "accelerators": {"nvidia.com/gpu": -1}}`,
+		},
+	})
+	g.Expect(err).ShouldNot(gomega.BeNil())
+}
+
+func TestGetIdleStopConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	idleStopConfig, err := GetIdleStopConfig(&v1.ConfigMap{
+		Data: map[string]string{
+			IdleStopConfigName: `{"enabled": true, "prometheusUrl": "http://prometheus.monitoring:9090"}`,
+		},
+	})
+	g.Expect(err).Should(gomega.BeNil())
+	g.Expect(idleStopConfig).Should(gomega.Equal(&IdleStopConfig{
+		Enabled:              true,
+		PrometheusURL:        "http://prometheus.monitoring:9090",
+		IdleSeconds:          DefaultIdleSeconds,
+		CheckIntervalSeconds: DefaultIdleCheckIntervalSeconds,
+	}))
+
+	for _, config := range []string{
+		`{"enabled": true}`,
+		`{"enabled": true, "prometheusUrl": "http://prometheus.monitoring:9090", "idleSeconds": 60}`,
+	} {
+		_, err = GetIdleStopConfig(&v1.ConfigMap{Data: map[string]string{IdleStopConfigName: config}})
+		g.Expect(err).ShouldNot(gomega.BeNil())
+	}
+}
+
+func TestGetGPUPreemptionConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	gpuPreemptionConfig, err := GetGPUPreemptionConfig(&v1.ConfigMap{
+		Data: map[string]string{
+			GPUPreemptionConfigName: `{"enabled": true, "pendingSeconds": 300, "maxServingPriority": 100}`,
+		},
+	})
+	g.Expect(err).Should(gomega.BeNil())
+	g.Expect(gpuPreemptionConfig).Should(gomega.Equal(&GPUPreemptionConfig{
+		Enabled:              true,
+		ResourceName:         constants.NvidiaGPUResourceType,
+		PendingSeconds:       300,
+		CheckIntervalSeconds: DefaultPreemptionCheckIntervalSeconds,
+		MaxServingPriority:   100,
+	}))
+
+	_, err = GetGPUPreemptionConfig(&v1.ConfigMap{
+		Data: map[string]string{
+			GPUPreemptionConfigName: `{"enabled": true, "pendingSeconds": -1}`,
+		},
+	})
+	g.Expect(err).ShouldNot(gomega.BeNil())
 }
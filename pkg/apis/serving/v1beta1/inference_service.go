@@ -17,7 +17,12 @@ limitations under the License.
 package v1beta1
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kserve/kserve/pkg/cors"
+	"github.com/kserve/kserve/pkg/utils"
 )
 
 // InferenceServiceSpec is the top level type for this resource
@@ -33,6 +38,28 @@ type InferenceServiceSpec struct {
 	// transformer service calls to predictor service.
 	// +optional
 	Transformer *TransformerSpec `json:"transformer,omitempty"`
+	// Validation defines a Job run against every new revision of the predictor,
+	// the InferenceService is not marked ready until the Job succeeds.
+	// +optional
+	Validation *ValidationSpec `json:"validation,omitempty"`
+	// Benchmark defines a load test run against every new revision of the predictor once it is ready,
+	// its results are reported in the status.
+	// +optional
+	Benchmark *BenchmarkSpec `json:"benchmark,omitempty"`
+	// ServingPriority ranks the InferenceService when the GPUs are scarce, an InferenceService whose GPU pods cannot
+	// be scheduled stops the InferenceServices of lower priority of its namespace when the GPU preemption is enabled.
+	// It defaults to the value of the PriorityClass of the predictor, or else to 0. It cannot exceed the
+	// maxServingPriority of the GPU preemption config.
+	// +optional
+	ServingPriority *int32 `json:"servingPriority,omitempty"`
+	// ActiveHours restricts the workloads to cron-based windows, e.g. business hours, they are removed outside the
+	// windows and created again when the next window opens.
+	// +optional
+	ActiveHours *ActiveHours `json:"activeHours,omitempty"`
+	// CORS allows the browsers to call the endpoints from other origins, the policy is enforced by the agent of the
+	// components and mirrored into the Istio VirtualService.
+	// +optional
+	CORS *CORSPolicy `json:"cors,omitempty"`
 }
 
 // LoggerType controls the scope of log publishing
@@ -49,6 +76,20 @@ const (
 	LogResponse LoggerType = "response"
 )
 
+// LoggerDeliveryMode controls what happens to the log events when the logger queue is full
+// +kubebuilder:validation:Enum=bestEffort;backPressure;spill
+type LoggerDeliveryMode string
+
+// LoggerDeliveryMode Enum
+const (
+	// Drop the log events which do not fit in the queue
+	LogDeliveryBestEffort LoggerDeliveryMode = "bestEffort"
+	// Reject the inference requests with 503 while the queue is full
+	LogDeliveryBackPressure LoggerDeliveryMode = "backPressure"
+	// Write the log events which do not fit in the queue to disk and send them later
+	LogDeliverySpill LoggerDeliveryMode = "spill"
+)
+
 // LoggerSpec specifies optional payload logging available for all components
 type LoggerSpec struct {
 	// URL to send logging events
@@ -61,6 +102,13 @@ type LoggerSpec struct {
 	// - "response": log only response <br />
 	// +optional
 	Mode LoggerType `json:"mode,omitempty"`
+	// Specifies what happens to the log events when the sink can not keep up and the logger queue is full. <br />
+	// Valid values are: <br />
+	// - "bestEffort" (default): drop the log events, the inference requests are not affected; <br />
+	// - "backPressure": reject the inference requests with 503 until the queue has room; <br />
+	// - "spill": write the log events to a local volume and send them once the queue has room <br />
+	// +optional
+	DeliveryMode LoggerDeliveryMode `json:"deliveryMode,omitempty"`
 }
 
 // Batcher specifies optional payload batching available for all components
@@ -76,6 +124,266 @@ type Batcher struct {
 	Timeout *int `json:"timeout,omitempty"`
 }
 
+// AgentMiddleware specifies the middleware the agent applies to the requests proxied to the component, it allows
+// common interoperability patterns without a custom transformer
+type AgentMiddleware struct {
+	// Transformation of the headers of the requests before they are sent to the component
+	// +optional
+	RequestHeaders *HeaderTransform `json:"requestHeaders,omitempty"`
+	// Transformation of the headers of the responses of the component
+	// +optional
+	ResponseHeaders *HeaderTransform `json:"responseHeaders,omitempty"`
+	// Exchange of the bearer token of the requests for a token accepted by the component
+	// +optional
+	TokenExchange *TokenExchange `json:"tokenExchange,omitempty"`
+	// Validation of the bearer token of the requests by the Kubernetes API server, it is done before the token is
+	// exchanged
+	// +optional
+	TokenReview *TokenReview `json:"tokenReview,omitempty"`
+	// Per-tenant quotas of the requests, they are enforced before the bearer token of the requests is exchanged
+	// +optional
+	Quota *QuotaSpec `json:"quota,omitempty"`
+	// HTTP callouts moderating the requests and the responses of the component, e.g. a content moderation service
+	// in front of an LLM
+	// +optional
+	Guardrail *GuardrailSpec `json:"guardrail,omitempty"`
+	// Accounting of the tokens reported in the usage of the OpenAI protocol responses of the component
+	// +optional
+	TokenUsage *TokenUsageSpec `json:"tokenUsage,omitempty"`
+}
+
+// HeaderTransform specifies the headers set, added and removed, the headers are removed first
+type HeaderTransform struct {
+	// Headers replacing the values of the existing headers of the same name
+	// +optional
+	Set map[string]string `json:"set,omitempty"`
+	// Headers appended to the values of the existing headers of the same name
+	// +optional
+	Add map[string]string `json:"add,omitempty"`
+	// Names of the headers removed
+	// +optional
+	Remove []string `json:"remove,omitempty"`
+}
+
+// TokenExchange specifies the OAuth 2.0 token exchange (RFC 8693) of the bearer token of the requests, the
+// exchanged token replaces the Authorization header of the requests sent to the component
+type TokenExchange struct {
+	// URL of the token endpoint of the authorization server
+	TokenURL string `json:"tokenUrl"`
+	// Audience the exchanged token is requested for
+	// +optional
+	Audience string `json:"audience,omitempty"`
+	// Scopes the exchanged token is requested for
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+	// Name of the secret holding the client_id and client_secret the agent authenticates at the token endpoint
+	// with, the agent does not authenticate when not set
+	// +optional
+	ClientSecretName string `json:"clientSecretName,omitempty"`
+	// Paths the GET and HEAD requests are proxied for without a bearer token, e.g. the health endpoints probed and
+	// the metrics scraped from inside the cluster. Every other request requires a token.
+	// +optional
+	UnauthenticatedPaths []UnauthenticatedPath `json:"unauthenticatedPaths,omitempty"`
+}
+
+// TokenReview specifies the validation of the bearer token of the requests with a Kubernetes TokenReview and the
+// authorization of the user of the token with a SubjectAccessReview on the InferenceService, without an
+// authenticating proxy in front of the component. The service account of the component must be allowed to create
+// TokenReviews and SubjectAccessReviews, e.g. with the system:auth-delegator ClusterRole.
+type TokenReview struct {
+	// Audiences the token must be issued for, the token must be valid for the audiences of the API server when not set
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+	// Verb the user of the token must be allowed on the InferenceService, defaults to get
+	// +optional
+	Verb string `json:"verb,omitempty"`
+	// Paths the GET and HEAD requests are proxied for without a bearer token, e.g. the health endpoints probed and
+	// the metrics scraped from inside the cluster. Every other request requires a token.
+	// +optional
+	UnauthenticatedPaths []UnauthenticatedPath `json:"unauthenticatedPaths,omitempty"`
+}
+
+// GetVerb returns the verb the user of the token must be allowed on the InferenceService
+func (r *TokenReview) GetVerb() string {
+	if r.Verb == "" {
+		return "get"
+	}
+	return r.Verb
+}
+
+// UnauthenticatedPath specifies the path prefix the requests of the allowed sources are proxied for without a bearer
+// token
+type UnauthenticatedPath struct {
+	// Prefix of the paths, e.g. /v2/health or /metrics, it matches whole path segments
+	Prefix string `json:"prefix"`
+	// CIDRs of the sources allowed to send the requests without a token, they default to the loopback and the private
+	// networks, e.g. 10.0.0.0/8, the pods and the nodes of the clusters are usually assigned addresses from
+	// +optional
+	SourceCIDRs []string `json:"sourceCIDRs,omitempty"`
+}
+
+// QuotaSpec specifies per-tenant request quotas, the requests over the quota of their tenant are rejected with
+// 429 Too Many Requests. The requests are counted over fixed windows of the period by each replica independently.
+// The tenant of a request is the user its bearer token is authenticated as by the token review of the middleware,
+// e.g. system:serviceaccount:team-a:client, so the quotas require a token review.
+type QuotaSpec struct {
+	// Period the requests are counted over, e.g. 1m or 24h
+	Period metav1.Duration `json:"period"`
+	// Number of requests per period shared by the tenants without a quota of their own and by the requests of the
+	// unauthenticated paths. They are unlimited when not set.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	DefaultLimit *int64 `json:"defaultLimit,omitempty"`
+	// Number of requests per period, by tenant
+	// +optional
+	Tenants map[string]int64 `json:"tenants,omitempty"`
+}
+
+// GuardrailSpec specifies the hooks moderating the requests before they are sent to the component and the responses
+// before they are returned to the client
+type GuardrailSpec struct {
+	// Hook moderating the requests
+	// +optional
+	Request *GuardrailHook `json:"request,omitempty"`
+	// Hook moderating the successful responses, the responses are buffered until the hook allows them so streamed
+	// responses are returned at once
+	// +optional
+	Response *GuardrailHook `json:"response,omitempty"`
+}
+
+// GuardrailFailurePolicy controls what happens to the requests when the guardrail hook can not be called or fails
+// +kubebuilder:validation:Enum=Fail;Ignore
+type GuardrailFailurePolicy string
+
+// GuardrailFailurePolicy Enum
+const (
+	// Reject the requests with 503 when the hook fails, the moderation is fail-closed
+	GuardrailFailurePolicyFail GuardrailFailurePolicy = "Fail"
+	// Proceed with the requests when the hook fails, the moderation is fail-open
+	GuardrailFailurePolicyIgnore GuardrailFailurePolicy = "Ignore"
+)
+
+// GuardrailHook specifies an HTTP callout the body of the requests or of the responses is posted to. A 2xx status of
+// the hook allows the request, a 4xx status rejects it and the status and body of the hook are returned to the
+// client, any other outcome is handled according to the failure policy.
+type GuardrailHook struct {
+	// URL of the hook
+	URL string `json:"url"`
+	// Timeout of the callout in seconds, defaults to 5
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int64 `json:"timeoutSeconds,omitempty"`
+	// What happens when the hook can not be called, times out or fails, Fail (default) or Ignore
+	// +optional
+	FailurePolicy GuardrailFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// TokenUsageSpec specifies the accounting of the prompt and completion tokens of the OpenAI protocol responses. The
+// tokens are exported as the kserve_agent_tokens_total metric on the metrics port of the agent, the usage of streamed
+// responses is only reported when the requests set stream_options.include_usage.
+type TokenUsageSpec struct {
+	// Whether the usage is returned in the X-Usage-Prompt-Tokens, X-Usage-Completion-Tokens and X-Usage-Total-Tokens
+	// headers, they are sent as trailers of the streamed responses and the other responses are buffered to set them
+	// +optional
+	Headers bool `json:"headers,omitempty"`
+}
+
+// ValidationSpec specifies the Job validating a new revision of the predictor, e.g. by sending golden requests
+type ValidationSpec struct {
+	// Name of the ConfigMap, in the namespace of the InferenceService, holding the manifest of the Job under the
+	// "job" key. The URL of the InferenceService and the revision of the predictor are passed to the containers
+	// of the Job in the INFERENCE_SERVICE_URL and PREDICTOR_REVISION environment variables.
+	JobTemplateConfigMap string `json:"jobTemplateConfigMap"`
+	// Maximum duration of the validation Job in seconds, defaults to 600
+	// +optional
+	TimeoutSeconds *int64 `json:"timeoutSeconds,omitempty"`
+}
+
+// BenchmarkTool is the load generator of a benchmark
+// +kubebuilder:validation:Enum=vegeta;ghz
+type BenchmarkTool string
+
+const (
+	// BenchmarkToolVegeta sends HTTP requests to the predict endpoint of the protocol of the predictor
+	BenchmarkToolVegeta BenchmarkTool = "vegeta"
+	// BenchmarkToolGhz sends gRPC requests, the predictor must serve the gRPC reflection service
+	BenchmarkToolGhz BenchmarkTool = "ghz"
+)
+
+// BenchmarkSpec specifies the load test run against a new revision of the predictor
+type BenchmarkSpec struct {
+	// Load generator, vegeta for HTTP or ghz for gRPC, defaults to vegeta
+	// +optional
+	Tool BenchmarkTool `json:"tool,omitempty"`
+	// Rate of the requests per second
+	// +kubebuilder:validation:Minimum=1
+	RPS int32 `json:"rps"`
+	// Duration of the load test in seconds
+	// +kubebuilder:validation:Minimum=1
+	DurationSeconds int32 `json:"durationSeconds"`
+	// Name of the ConfigMap, in the namespace of the InferenceService, holding the body of the requests under the
+	// "payload" key, a JSON message of the gRPC method for ghz
+	PayloadConfigMap string `json:"payloadConfigMap"`
+	// Fully qualified gRPC method called by ghz, defaults to inference.GRPCInferenceService/ModelInfer
+	// +optional
+	Call string `json:"call,omitempty"`
+}
+
+// CORSPolicy specifies the cross-origin requests the browsers are allowed to send
+type CORSPolicy struct {
+	// Origins allowed to send requests, e.g. https://app.example.com, "*" allows any origin
+	// +kubebuilder:validation:MinItems=1
+	AllowOrigins []string `json:"allowOrigins"`
+	// Methods allowed, they default to GET, HEAD and POST
+	// +optional
+	AllowMethods []string `json:"allowMethods,omitempty"`
+	// Request headers allowed besides the CORS-safelisted ones, e.g. Authorization and Content-Type, "*" allows any
+	// header
+	// +optional
+	AllowHeaders []string `json:"allowHeaders,omitempty"`
+	// How long in seconds the browsers cache the response to a preflight request
+	// +optional
+	MaxAge *int32 `json:"maxAge,omitempty"`
+}
+
+// Config returns the config of the CORS handler of the agent
+func (c *CORSPolicy) Config() cors.Config {
+	return cors.Config{
+		AllowOrigins: c.AllowOrigins,
+		AllowMethods: c.AllowMethods,
+		AllowHeaders: c.AllowHeaders,
+		MaxAge:       c.MaxAge,
+	}
+}
+
+// ActiveHours specifies the windows during which the workloads run
+type ActiveHours struct {
+	// Windows during which the workloads run, at least one of them must be open
+	// +kubebuilder:validation:MinItems=1
+	Windows []ActiveWindow `json:"windows"`
+	// IANA time zone the schedules are evaluated in, e.g. Europe/Paris, defaults to UTC
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// ActiveWindow is a window opened at the times of a cron schedule for a duration
+type ActiveWindow struct {
+	// Cron expression of five fields, minute hour day-of-month month day-of-week, the window opens at, e.g.
+	// "0 8 * * 1-5" for 8am on weekdays
+	Schedule string `json:"schedule"`
+	// How long the window stays open, e.g. 10h
+	Duration metav1.Duration `json:"duration"`
+}
+
+// IsActive returns whether one of the windows is open at now and the time of the next opening or closing of a window
+func (a *ActiveHours) IsActive(now time.Time) (bool, time.Time, error) {
+	windows := make([]utils.ActiveWindow, 0, len(a.Windows))
+	for _, window := range a.Windows {
+		windows = append(windows, utils.ActiveWindow{Schedule: window.Schedule, Duration: window.Duration.Duration})
+	}
+	return utils.GetActiveWindows(windows, a.TimeZone, now)
+}
+
 // InferenceService is the Schema for the InferenceServices API
 // +k8s:openapi-gen=true
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -89,6 +397,7 @@ type Batcher struct {
 // +kubebuilder:printcolumn:name="PrevRolledoutRevision",type="string",JSONPath=".status.components.predictor.traffic[?(@.tag=='prev')].revisionName"
 // +kubebuilder:printcolumn:name="LatestReadyRevision",type="string",JSONPath=".status.components.predictor.traffic[?(@.latestRevision==true)].revisionName"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Cost/h",type="string",JSONPath=".metadata.annotations.serving\\.kserve\\.io/estimated-hourly-cost",priority=1
 // +kubebuilder:resource:path=inferenceservices,shortName=isvc
 // +kubebuilder:storageversion
 type InferenceService struct {
@@ -0,0 +1,209 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// InferenceServiceBuilder builds an InferenceService with a fluent API, e.g.
+//
+//	NewInferenceServiceBuilder("sklearn-iris", "default").
+//		Predictor(NewPredictorBuilder().SKLearn("gs://kfserving-examples/models/sklearn/1.0/model").
+//			Resources(resources).Build()).
+//		Build()
+//
+// +kubebuilder:object:generate=false
+type InferenceServiceBuilder struct {
+	isvc InferenceService
+}
+
+// NewInferenceServiceBuilder returns a builder of an InferenceService without components
+func NewInferenceServiceBuilder(name, namespace string) *InferenceServiceBuilder {
+	return &InferenceServiceBuilder{isvc: InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+	}}
+}
+
+// Annotation sets an annotation of the InferenceService
+func (b *InferenceServiceBuilder) Annotation(key, value string) *InferenceServiceBuilder {
+	if b.isvc.Annotations == nil {
+		b.isvc.Annotations = map[string]string{}
+	}
+	b.isvc.Annotations[key] = value
+	return b
+}
+
+// Label sets a label of the InferenceService
+func (b *InferenceServiceBuilder) Label(key, value string) *InferenceServiceBuilder {
+	if b.isvc.Labels == nil {
+		b.isvc.Labels = map[string]string{}
+	}
+	b.isvc.Labels[key] = value
+	return b
+}
+
+// DeploymentMode sets the deployment mode annotation of the InferenceService
+func (b *InferenceServiceBuilder) DeploymentMode(mode constants.DeploymentModeType) *InferenceServiceBuilder {
+	return b.Annotation(constants.DeploymentMode, string(mode))
+}
+
+// Predictor sets the predictor of the InferenceService
+func (b *InferenceServiceBuilder) Predictor(predictor PredictorSpec) *InferenceServiceBuilder {
+	b.isvc.Spec.Predictor = predictor
+	return b
+}
+
+// Transformer sets a custom transformer running the containers
+func (b *InferenceServiceBuilder) Transformer(containers ...v1.Container) *InferenceServiceBuilder {
+	b.isvc.Spec.Transformer = &TransformerSpec{PodSpec: PodSpec{Containers: containers}}
+	return b
+}
+
+// Explainer sets a custom explainer running the containers
+func (b *InferenceServiceBuilder) Explainer(containers ...v1.Container) *InferenceServiceBuilder {
+	b.isvc.Spec.Explainer = &ExplainerSpec{PodSpec: PodSpec{Containers: containers}}
+	return b
+}
+
+// Build returns a copy of the InferenceService, the builder can be reused to build variants
+func (b *InferenceServiceBuilder) Build() *InferenceService {
+	return b.isvc.DeepCopy()
+}
+
+// PredictorBuilder builds a PredictorSpec with a fluent API. The framework methods set the model spec with the
+// model format of the framework, the serving runtime is then selected automatically unless Runtime is called.
+// +kubebuilder:object:generate=false
+type PredictorBuilder struct {
+	predictor PredictorSpec
+}
+
+// NewPredictorBuilder returns a builder of an empty predictor
+func NewPredictorBuilder() *PredictorBuilder {
+	return &PredictorBuilder{}
+}
+
+// Model serves the model of the format from the storage URI
+func (b *PredictorBuilder) Model(modelFormat, storageURI string) *PredictorBuilder {
+	model := b.model()
+	model.ModelFormat.Name = modelFormat
+	model.StorageURI = &storageURI
+	return b
+}
+
+// SKLearn serves the scikit-learn model from the storage URI
+func (b *PredictorBuilder) SKLearn(storageURI string) *PredictorBuilder {
+	return b.Model(constants.SupportedModelSKLearn, storageURI)
+}
+
+// XGBoost serves the XGBoost model from the storage URI
+func (b *PredictorBuilder) XGBoost(storageURI string) *PredictorBuilder {
+	return b.Model(constants.SupportedModelXGBoost, storageURI)
+}
+
+// Tensorflow serves the TensorFlow model from the storage URI
+func (b *PredictorBuilder) Tensorflow(storageURI string) *PredictorBuilder {
+	return b.Model(constants.SupportedModelTensorflow, storageURI)
+}
+
+// PyTorch serves the PyTorch model from the storage URI
+func (b *PredictorBuilder) PyTorch(storageURI string) *PredictorBuilder {
+	return b.Model(constants.SupportedModelPyTorch, storageURI)
+}
+
+// ONNX serves the ONNX model from the storage URI
+func (b *PredictorBuilder) ONNX(storageURI string) *PredictorBuilder {
+	return b.Model(constants.SupportedModelONNX, storageURI)
+}
+
+// HuggingFace serves the Hugging Face model from the storage URI
+func (b *PredictorBuilder) HuggingFace(storageURI string) *PredictorBuilder {
+	return b.Model(constants.SupportedModelHuggingFace, storageURI)
+}
+
+// ModelFormatVersion sets the version of the model format
+func (b *PredictorBuilder) ModelFormatVersion(version string) *PredictorBuilder {
+	b.model().ModelFormat.Version = &version
+	return b
+}
+
+// Runtime selects the serving runtime of the model
+func (b *PredictorBuilder) Runtime(runtime string) *PredictorBuilder {
+	b.model().Runtime = &runtime
+	return b
+}
+
+// ProtocolVersion sets the inference protocol of the model server
+func (b *PredictorBuilder) ProtocolVersion(protocol constants.InferenceServiceProtocol) *PredictorBuilder {
+	b.model().ProtocolVersion = &protocol
+	return b
+}
+
+// Resources sets the resources of the model container
+func (b *PredictorBuilder) Resources(resources v1.ResourceRequirements) *PredictorBuilder {
+	b.model().Resources = resources
+	return b
+}
+
+// Env adds environment variables to the model container
+func (b *PredictorBuilder) Env(env ...v1.EnvVar) *PredictorBuilder {
+	model := b.model()
+	model.Env = append(model.Env, env...)
+	return b
+}
+
+// Args adds arguments to the model container
+func (b *PredictorBuilder) Args(args ...string) *PredictorBuilder {
+	model := b.model()
+	model.Args = append(model.Args, args...)
+	return b
+}
+
+// Containers makes the predictor a custom predictor running the containers instead of a model server
+func (b *PredictorBuilder) Containers(containers ...v1.Container) *PredictorBuilder {
+	b.predictor.Containers = containers
+	return b
+}
+
+// Replicas sets the min and max replicas of the predictor
+func (b *PredictorBuilder) Replicas(minReplicas, maxReplicas int) *PredictorBuilder {
+	b.predictor.MinReplicas = &minReplicas
+	b.predictor.MaxReplicas = maxReplicas
+	return b
+}
+
+// ServiceAccountName sets the service account of the predictor pods
+func (b *PredictorBuilder) ServiceAccountName(serviceAccountName string) *PredictorBuilder {
+	b.predictor.ServiceAccountName = serviceAccountName
+	return b
+}
+
+// Build returns a copy of the predictor, the builder can be reused to build variants
+func (b *PredictorBuilder) Build() PredictorSpec {
+	return *b.predictor.DeepCopy()
+}
+
+// model returns the model spec of the predictor, it is created on the first call
+func (b *PredictorBuilder) model() *ModelSpec {
+	if b.predictor.Model == nil {
+		b.predictor.Model = &ModelSpec{}
+	}
+	return b.predictor.Model
+}
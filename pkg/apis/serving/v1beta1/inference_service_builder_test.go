@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestInferenceServiceBuilder(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	resources := v1.ResourceRequirements{
+		Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("1Gi")},
+	}
+	predictor := NewPredictorBuilder().
+		SKLearn("gs://kfserving-examples/models/sklearn/1.0/model").
+		Runtime("kserve-sklearnserver").
+		ProtocolVersion(constants.ProtocolV2).
+		Resources(resources).
+		Env(v1.EnvVar{Name: "A", Value: "1"}).
+		Replicas(1, 3)
+	builder := NewInferenceServiceBuilder("sklearn-iris", "default").
+		DeploymentMode(constants.RawDeployment).
+		Label("team", "a").
+		Predictor(predictor.Build())
+	isvc := builder.Build()
+
+	g.Expect(isvc.Name).Should(gomega.Equal("sklearn-iris"))
+	g.Expect(isvc.Annotations).Should(gomega.HaveKeyWithValue(constants.DeploymentMode, "RawDeployment"))
+	g.Expect(isvc.Labels).Should(gomega.HaveKeyWithValue("team", "a"))
+	model := isvc.Spec.Predictor.Model
+	g.Expect(model.ModelFormat.Name).Should(gomega.Equal("sklearn"))
+	g.Expect(*model.StorageURI).Should(gomega.Equal("gs://kfserving-examples/models/sklearn/1.0/model"))
+	g.Expect(*model.Runtime).Should(gomega.Equal("kserve-sklearnserver"))
+	g.Expect(*model.ProtocolVersion).Should(gomega.Equal(constants.ProtocolV2))
+	g.Expect(model.Resources).Should(gomega.Equal(resources))
+	g.Expect(model.Env).Should(gomega.Equal([]v1.EnvVar{{Name: "A", Value: "1"}}))
+	g.Expect(*isvc.Spec.Predictor.MinReplicas).Should(gomega.Equal(1))
+	g.Expect(isvc.Spec.Predictor.MaxReplicas).Should(gomega.Equal(3))
+	_, err := isvc.ValidateCreate()
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+
+	// the builders return copies so that they can be reused to build variants
+	variant := builder.Transformer(v1.Container{Name: "transformer", Image: "transformer"}).Build()
+	g.Expect(variant.Spec.Transformer).ShouldNot(gomega.BeNil())
+	g.Expect(isvc.Spec.Transformer).Should(gomega.BeNil())
+	g.Expect(predictor.Env(v1.EnvVar{Name: "B"}).Build().Model.Env).Should(gomega.HaveLen(2))
+	g.Expect(model.Env).Should(gomega.HaveLen(1))
+}
+
+func TestCustomPredictorBuilder(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	predictor := NewPredictorBuilder().
+		Containers(v1.Container{Name: constants.InferenceServiceContainerName, Image: "custom-model:latest"}).
+		ServiceAccountName("model").
+		Build()
+	g.Expect(predictor.Model).Should(gomega.BeNil())
+	g.Expect(predictor.Containers).Should(gomega.HaveLen(1))
+	g.Expect(predictor.ServiceAccountName).Should(gomega.Equal("model"))
+}
@@ -17,3 +17,8 @@ limitations under the License.
 package v1beta1
 
 func (*InferenceService) Hub() {}
+
+// ConvertLegacyPredictor moves a deprecated predictor framework spec, e.g. sklearn, to the equivalent model spec.
+func (isvc *InferenceService) ConvertLegacyPredictor() {
+	isvc.setPredictorModelDefaults()
+}
@@ -23,11 +23,15 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/kserve/kserve/pkg/utils"
 )
@@ -84,9 +88,33 @@ func (isvc *InferenceService) Default() {
 	if err != nil {
 		panic(err)
 	}
+	if _, ok := isvc.Annotations[constants.ServingProfileAnnotationKey]; ok {
+		isvc.defaultServingProfile(cfg)
+	}
 	isvc.DefaultInferenceService(configMap, deployConfig)
 }
 
+// defaultServingProfile expands the ServingProfile the InferenceService references, the validating webhook rejects
+// the InferenceService when it could not be expanded.
+func (isvc *InferenceService) defaultServingProfile(cfg *rest.Config) {
+	scheme := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		mutatorLogger.Error(err, "unable to add the v1alpha1 API to the scheme")
+		return
+	}
+	cl, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		mutatorLogger.Error(err, "unable to create client")
+		return
+	}
+	size, err := GetServingProfileSize(cl, isvc)
+	if err != nil {
+		mutatorLogger.Error(err, "unable to get the serving profile", "namespace", isvc.Namespace, "isvc", isvc.Name)
+		return
+	}
+	isvc.ApplyServingProfileSize(size)
+}
+
 func (isvc *InferenceService) DefaultInferenceService(config *InferenceServicesConfig, deployConfig *DeployConfig) {
 	deploymentMode, ok := isvc.ObjectMeta.Annotations[constants.DeploymentMode]
 
@@ -103,6 +131,7 @@ func (isvc *InferenceService) DefaultInferenceService(config *InferenceServicesC
 	if !ok || deploymentMode != string(constants.ModelMeshDeployment) {
 		// Only attempt to assign runtimes and apply defaulting logic for non-modelmesh predictors
 		isvc.setPredictorModelDefaults()
+		isvc.setPredictorResourceDefaults(config)
 		components = append(components, &isvc.Spec.Predictor)
 	} else {
 		// If this is a modelmesh predictor, we still want to do "Exactly One" validation.
@@ -165,6 +194,23 @@ func (isvc *InferenceService) setPredictorModelDefaults() {
 	}
 }
 
+// setPredictorResourceDefaults applies the resources of the best matching resource profile to a model
+// predictor which does not specify any resources.
+func (isvc *InferenceService) setPredictorResourceDefaults(config *InferenceServicesConfig) {
+	model := isvc.Spec.Predictor.Model
+	if config == nil || model == nil || len(model.Resources.Requests) != 0 || len(model.Resources.Limits) != 0 {
+		return
+	}
+	runtime := ""
+	if model.Runtime != nil {
+		runtime = *model.Runtime
+	}
+	profile := config.GetResourceProfile(runtime, model.ModelFormat.Name, isvc.Annotations[constants.ResourceSizeClassAnnotationKey])
+	if profile != nil {
+		model.Resources = *profile.Resources.DeepCopy()
+	}
+}
+
 func (isvc *InferenceService) assignSKLearnRuntime() {
 	isvc.Spec.Predictor.Model = &ModelSpec{
 		ModelFormat:            ModelFormat{Name: constants.SupportedModelSKLearn},
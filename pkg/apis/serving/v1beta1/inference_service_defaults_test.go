@@ -26,6 +26,7 @@ import (
 	"github.com/onsi/gomega"
 	"github.com/onsi/gomega/types"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -749,3 +750,70 @@ func TestMlServerDefaults(t *testing.T) {
 		g.Expect(scenario.isvc.ObjectMeta.Labels).To(scenario.matcher["labels"])
 	}
 }
+
+func TestResourceProfileDefaults(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	resources := func(cpu, memory, gpu string) v1.ResourceRequirements {
+		list := v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse(cpu),
+			v1.ResourceMemory: resource.MustParse(memory),
+		}
+		if gpu != "" {
+			list[constants.NvidiaGPUResourceType] = resource.MustParse(gpu)
+		}
+		return v1.ResourceRequirements{Requests: list, Limits: list}
+	}
+	config := &InferenceServicesConfig{
+		ResourceProfiles: []ResourceProfile{
+			{Resources: resources("1", "2Gi", "")},
+			{ModelFormat: "huggingface", Resources: resources("4", "16Gi", "1")},
+			{ModelFormat: "huggingface", SizeClass: "large", Resources: resources("8", "64Gi", "2")},
+			{Runtime: "kserve-vllm", ModelFormat: "huggingface", SizeClass: "large", Resources: resources("8", "96Gi", "4")},
+		},
+	}
+	scenarios := map[string]struct {
+		annotations map[string]string
+		model       *ModelSpec
+		expected    v1.ResourceRequirements
+	}{
+		"DefaultProfile": {
+			model:    &ModelSpec{ModelFormat: ModelFormat{Name: "sklearn"}},
+			expected: resources("1", "2Gi", ""),
+		},
+		"ModelFormatProfile": {
+			model:    &ModelSpec{ModelFormat: ModelFormat{Name: "huggingface"}},
+			expected: resources("4", "16Gi", "1"),
+		},
+		"SizeClassProfile": {
+			annotations: map[string]string{constants.ResourceSizeClassAnnotationKey: "large"},
+			model:       &ModelSpec{ModelFormat: ModelFormat{Name: "huggingface"}},
+			expected:    resources("8", "64Gi", "2"),
+		},
+		"RuntimeProfile": {
+			annotations: map[string]string{constants.ResourceSizeClassAnnotationKey: "large"},
+			model:       &ModelSpec{ModelFormat: ModelFormat{Name: "huggingface"}, Runtime: proto.String("kserve-vllm")},
+			expected:    resources("8", "96Gi", "4"),
+		},
+		"UserResourcesAreKept": {
+			model: &ModelSpec{
+				ModelFormat: ModelFormat{Name: "huggingface"},
+				PredictorExtensionSpec: PredictorExtensionSpec{
+					Container: v1.Container{Resources: resources("2", "4Gi", "")},
+				},
+			},
+			expected: resources("2", "4Gi", ""),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			isvc := InferenceService{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default", Annotations: scenario.annotations},
+				Spec: InferenceServiceSpec{
+					Predictor: PredictorSpec{Model: scenario.model},
+				},
+			}
+			isvc.DefaultInferenceService(config, nil)
+			g.Expect(isvc.Spec.Predictor.Model.Resources).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
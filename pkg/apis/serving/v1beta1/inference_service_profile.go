@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// GetServingProfileSize returns the size of the ServingProfile referenced by the serving.kserve.io/serving-profile
+// annotation, selected by the serving.kserve.io/size-class annotation or else the default size of the profile.
+// It returns nil when the InferenceService does not reference a profile.
+func GetServingProfileSize(cl client.Client, isvc *InferenceService) (*v1alpha1.ServingProfileSize, error) {
+	name, ok := isvc.Annotations[constants.ServingProfileAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	profile := &v1alpha1.ServingProfile{}
+	if err := cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: isvc.Namespace}, profile); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("serving profile %s does not exist in namespace %s", name, isvc.Namespace)
+		}
+		return nil, err
+	}
+	sizeClass := isvc.Annotations[constants.ResourceSizeClassAnnotationKey]
+	size := profile.Spec.GetSize(sizeClass)
+	if size == nil {
+		if sizeClass == "" {
+			return nil, fmt.Errorf("serving profile %s has no default size, select one with the %s annotation",
+				name, constants.ResourceSizeClassAnnotationKey)
+		}
+		return nil, fmt.Errorf("serving profile %s has no size %s", name, sizeClass)
+	}
+	return size, nil
+}
+
+// ApplyServingProfileSize expands the size of the ServingProfile into the predictor and records it in the
+// serving.kserve.io/applied-serving-profile annotation. The fields set on the predictor are kept, unless the
+// InferenceService switched to another profile or size since the last expansion, the fields the new size defines
+// then replace the ones of the previous size.
+func (isvc *InferenceService) ApplyServingProfileSize(size *v1alpha1.ServingProfileSize) {
+	applied := isvc.Annotations[constants.ServingProfileAnnotationKey] + "/" + size.Name
+	override := isvc.Annotations[constants.AppliedServingProfileAnnotationKey] != "" &&
+		isvc.Annotations[constants.AppliedServingProfileAnnotationKey] != applied
+
+	predictor := &isvc.Spec.Predictor
+	if container := predictor.getPredictorContainer(); container != nil {
+		if (len(size.Resources.Requests) != 0 || len(size.Resources.Limits) != 0) &&
+			(override || (len(container.Resources.Requests) == 0 && len(container.Resources.Limits) == 0)) {
+			container.Resources = *size.Resources.DeepCopy()
+		}
+		if size.ReadinessProbe != nil && (override || container.ReadinessProbe == nil) {
+			container.ReadinessProbe = size.ReadinessProbe.DeepCopy()
+		}
+		if size.LivenessProbe != nil && (override || container.LivenessProbe == nil) {
+			container.LivenessProbe = size.LivenessProbe.DeepCopy()
+		}
+	}
+	if len(size.NodeSelector) != 0 && (override || len(predictor.NodeSelector) == 0) {
+		predictor.NodeSelector = make(map[string]string, len(size.NodeSelector))
+		for key, value := range size.NodeSelector {
+			predictor.NodeSelector[key] = value
+		}
+	}
+	if len(size.Tolerations) != 0 && (override || len(predictor.Tolerations) == 0) {
+		predictor.Tolerations = append([]v1.Toleration{}, size.Tolerations...)
+	}
+	if size.MinReplicas != nil && (override || predictor.MinReplicas == nil) {
+		predictor.MinReplicas = GetIntReference(*size.MinReplicas)
+	}
+	if size.MaxReplicas != 0 && (override || predictor.MaxReplicas == 0) {
+		predictor.MaxReplicas = size.MaxReplicas
+	}
+	if size.ScaleTarget != nil && (override || predictor.ScaleTarget == nil) {
+		predictor.ScaleTarget = GetIntReference(*size.ScaleTarget)
+	}
+	isvc.Annotations[constants.AppliedServingProfileAnnotationKey] = applied
+}
+
+// validateServingProfile checks that the mutating webhook expanded the ServingProfile and the size the
+// InferenceService references, which it fails to do when the profile or the size does not exist.
+func validateServingProfile(isvc *InferenceService) error {
+	profile, ok := isvc.Annotations[constants.ServingProfileAnnotationKey]
+	if !ok {
+		return nil
+	}
+	applied := strings.SplitN(isvc.Annotations[constants.AppliedServingProfileAnnotationKey], "/", 2)
+	sizeClass := isvc.Annotations[constants.ResourceSizeClassAnnotationKey]
+	if len(applied) != 2 || applied[0] != profile || (sizeClass != "" && applied[1] != sizeClass) {
+		return fmt.Errorf("the serving profile %s could not be applied, check that the ServingProfile exists in "+
+			"namespace %s and offers the size selected by the %s annotation",
+			profile, isvc.Namespace, constants.ResourceSizeClassAnnotationKey)
+	}
+	return nil
+}
+
+// getPredictorContainer returns the container the predictor serves the model with, it is nil when the predictor
+// has no implementation.
+func (s *PredictorSpec) getPredictorContainer() *v1.Container {
+	if len(s.PodSpec.Containers) != 0 {
+		return &s.PodSpec.Containers[0]
+	}
+	return s.GetModelServerContainer()
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func newProfileTestService(annotations map[string]string) *InferenceService {
+	return &InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "foo",
+			Namespace:   "default",
+			Annotations: annotations,
+		},
+		Spec: InferenceServiceSpec{
+			Predictor: PredictorSpec{
+				SKLearn: &SKLearnSpec{
+					PredictorExtensionSpec: PredictorExtensionSpec{
+						StorageURI: proto.String("gs://testbucket/testmodel"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGetServingProfileSize(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	profile := &v1alpha1.ServingProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "gpu", Namespace: "default"},
+		Spec: v1alpha1.ServingProfileSpec{
+			DefaultSize: "small",
+			Sizes:       []v1alpha1.ServingProfileSize{{Name: "small"}, {Name: "large"}},
+		},
+	}
+	noDefault := &v1alpha1.ServingProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "nodefault", Namespace: "default"},
+		Spec: v1alpha1.ServingProfileSpec{
+			Sizes: []v1alpha1.ServingProfileSize{{Name: "small"}},
+		},
+	}
+	s := runtime.NewScheme()
+	g.Expect(v1alpha1.AddToScheme(s)).To(gomega.Succeed())
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(profile, noDefault).Build()
+
+	scenarios := map[string]struct {
+		annotations map[string]string
+		expected    string
+		errMatcher  gomega.OmegaMatcher
+	}{
+		"NoProfile": {
+			annotations: nil,
+			expected:    "",
+			errMatcher:  gomega.BeNil(),
+		},
+		"DefaultSize": {
+			annotations: map[string]string{constants.ServingProfileAnnotationKey: "gpu"},
+			expected:    "small",
+			errMatcher:  gomega.BeNil(),
+		},
+		"SelectedSize": {
+			annotations: map[string]string{
+				constants.ServingProfileAnnotationKey:    "gpu",
+				constants.ResourceSizeClassAnnotationKey: "large",
+			},
+			expected:   "large",
+			errMatcher: gomega.BeNil(),
+		},
+		"UnknownSize": {
+			annotations: map[string]string{
+				constants.ServingProfileAnnotationKey:    "gpu",
+				constants.ResourceSizeClassAnnotationKey: "huge",
+			},
+			errMatcher: gomega.MatchError("serving profile gpu has no size huge"),
+		},
+		"NoDefaultSize": {
+			annotations: map[string]string{constants.ServingProfileAnnotationKey: "nodefault"},
+			errMatcher:  gomega.HaveOccurred(),
+		},
+		"UnknownProfile": {
+			annotations: map[string]string{constants.ServingProfileAnnotationKey: "cpu"},
+			errMatcher:  gomega.MatchError("serving profile cpu does not exist in namespace default"),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			size, err := GetServingProfileSize(cl, newProfileTestService(scenario.annotations))
+			g.Expect(err).To(scenario.errMatcher)
+			if scenario.expected == "" {
+				g.Expect(size).To(gomega.BeNil())
+			} else {
+				g.Expect(size.Name).To(gomega.Equal(scenario.expected))
+			}
+		})
+	}
+}
+
+func TestApplyServingProfileSize(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	large := &v1alpha1.ServingProfileSize{
+		Name: "large",
+		Resources: v1.ResourceRequirements{
+			Limits: v1.ResourceList{constants.NvidiaGPUResourceType: resource.MustParse("1")},
+		},
+		NodeSelector:   map[string]string{"accelerator": "a100"},
+		Tolerations:    []v1.Toleration{{Key: "nvidia.com/gpu", Operator: v1.TolerationOpExists}},
+		MinReplicas:    GetIntReference(1),
+		MaxReplicas:    4,
+		ScaleTarget:    GetIntReference(10),
+		ReadinessProbe: &v1.Probe{InitialDelaySeconds: 60},
+	}
+	ownResources := v1.ResourceRequirements{
+		Limits: v1.ResourceList{v1.ResourceCPU: resource.MustParse("2")},
+	}
+
+	t.Run("FillsUnsetFields", func(t *testing.T) {
+		isvc := newProfileTestService(map[string]string{constants.ServingProfileAnnotationKey: "gpu"})
+		isvc.Spec.Predictor.SKLearn.Resources = ownResources
+		isvc.Spec.Predictor.MaxReplicas = 2
+		isvc.ApplyServingProfileSize(large)
+
+		g.Expect(isvc.Spec.Predictor.SKLearn.Resources).To(gomega.Equal(ownResources))
+		g.Expect(isvc.Spec.Predictor.MaxReplicas).To(gomega.Equal(2))
+		g.Expect(isvc.Spec.Predictor.SKLearn.ReadinessProbe).To(gomega.Equal(large.ReadinessProbe))
+		g.Expect(isvc.Spec.Predictor.NodeSelector).To(gomega.Equal(large.NodeSelector))
+		g.Expect(isvc.Spec.Predictor.Tolerations).To(gomega.Equal(large.Tolerations))
+		g.Expect(isvc.Spec.Predictor.MinReplicas).To(gomega.Equal(GetIntReference(1)))
+		g.Expect(isvc.Spec.Predictor.ScaleTarget).To(gomega.Equal(GetIntReference(10)))
+		g.Expect(isvc.Annotations[constants.AppliedServingProfileAnnotationKey]).To(gomega.Equal("gpu/large"))
+	})
+
+	t.Run("OverridesOnSizeChange", func(t *testing.T) {
+		isvc := newProfileTestService(map[string]string{
+			constants.ServingProfileAnnotationKey:        "gpu",
+			constants.AppliedServingProfileAnnotationKey: "gpu/small",
+		})
+		isvc.Spec.Predictor.SKLearn.Resources = ownResources
+		isvc.Spec.Predictor.MaxReplicas = 2
+		isvc.ApplyServingProfileSize(large)
+
+		g.Expect(isvc.Spec.Predictor.SKLearn.Resources).To(gomega.Equal(large.Resources))
+		g.Expect(isvc.Spec.Predictor.MaxReplicas).To(gomega.Equal(4))
+		g.Expect(isvc.Annotations[constants.AppliedServingProfileAnnotationKey]).To(gomega.Equal("gpu/large"))
+	})
+
+	t.Run("CustomPredictor", func(t *testing.T) {
+		isvc := newProfileTestService(map[string]string{constants.ServingProfileAnnotationKey: "gpu"})
+		isvc.Spec.Predictor.SKLearn = nil
+		isvc.Spec.Predictor.Containers = []v1.Container{{Name: constants.InferenceServiceContainerName, Image: "model:latest"}}
+		isvc.ApplyServingProfileSize(large)
+
+		g.Expect(isvc.Spec.Predictor.Containers[0].Resources).To(gomega.Equal(large.Resources))
+	})
+}
+
+func TestValidateServingProfile(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		annotations map[string]string
+		errMatcher  gomega.OmegaMatcher
+	}{
+		"NoProfile": {
+			annotations: nil,
+			errMatcher:  gomega.BeNil(),
+		},
+		"Applied": {
+			annotations: map[string]string{
+				constants.ServingProfileAnnotationKey:        "gpu",
+				constants.AppliedServingProfileAnnotationKey: "gpu/small",
+			},
+			errMatcher: gomega.BeNil(),
+		},
+		"NotApplied": {
+			annotations: map[string]string{constants.ServingProfileAnnotationKey: "gpu"},
+			errMatcher:  gomega.HaveOccurred(),
+		},
+		"StaleSize": {
+			annotations: map[string]string{
+				constants.ServingProfileAnnotationKey:        "gpu",
+				constants.ResourceSizeClassAnnotationKey:     "huge",
+				constants.AppliedServingProfileAnnotationKey: "gpu/small",
+			},
+			errMatcher: gomega.HaveOccurred(),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g.Expect(validateServingProfile(newProfileTestService(scenario.annotations))).To(scenario.errMatcher)
+		})
+	}
+}
@@ -17,7 +17,10 @@ limitations under the License.
 package v1beta1
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
+	"time"
 
 	"github.com/kserve/kserve/pkg/constants"
 	appsv1 "k8s.io/api/apps/v1"
@@ -45,10 +48,114 @@ type InferenceServiceStatus struct {
 	// It generally has the form http[s]://{route-name}.{route-namespace}.{cluster-level-suffix}
 	// +optional
 	URL *apis.URL `json:"url,omitempty"`
+	// Endpoints lists all the addresses the InferenceService is reachable at, per protocol and visibility
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Endpoints []Endpoint `json:"endpoints,omitempty"`
 	// Statuses for the components of the InferenceService
 	Components map[ComponentType]ComponentStatusSpec `json:"components,omitempty"`
 	// Model related statuses
 	ModelStatus ModelStatus `json:"modelStatus,omitempty"`
+	// Global configuration the InferenceService was built with at its last reconciliation
+	// +optional
+	EffectiveConfig *EffectiveConfig `json:"effectiveConfig,omitempty"`
+	// Results of the benchmark of the latest predictor revision
+	// +optional
+	Benchmark *BenchmarkStatus `json:"benchmark,omitempty"`
+}
+
+// BenchmarkState is the state of the benchmark Job of a predictor revision
+type BenchmarkState string
+
+const (
+	BenchmarkRunning   BenchmarkState = "Running"
+	BenchmarkSucceeded BenchmarkState = "Succeeded"
+	BenchmarkFailed    BenchmarkState = "Failed"
+)
+
+// BenchmarkStatus summarizes the benchmark of a predictor revision
+type BenchmarkStatus struct {
+	// Predictor revision the benchmark ran against
+	// +optional
+	Revision string `json:"revision,omitempty"`
+	// Name of the benchmark Job
+	// +optional
+	JobName string `json:"jobName,omitempty"`
+	// State of the benchmark
+	// +optional
+	State BenchmarkState `json:"state,omitempty"`
+	// Median latency of the requests, e.g. 12.5ms
+	// +optional
+	P50Latency string `json:"p50Latency,omitempty"`
+	// 95th percentile latency of the requests
+	// +optional
+	P95Latency string `json:"p95Latency,omitempty"`
+	// Successful requests per second
+	// +optional
+	Throughput string `json:"throughput,omitempty"`
+	// Ratio of the successful requests, between 0 and 1
+	// +optional
+	SuccessRatio string `json:"successRatio,omitempty"`
+	// Reason the results are missing when the benchmark failed
+	// +optional
+	Message string `json:"message,omitempty"`
+	// Time the benchmark Job completed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// EffectiveConfig is the resolved global configuration an InferenceService was built with, so that it can be
+// told apart from the current content of the inferenceservice-config ConfigMap
+type EffectiveConfig struct {
+	// Resource version of the inferenceservice-config ConfigMap
+	// +optional
+	ConfigMapResourceVersion string `json:"configMapResourceVersion,omitempty"`
+	// Resolved deployment mode
+	// +optional
+	DeploymentMode string `json:"deploymentMode,omitempty"`
+	// Ingress class of the generated ingress, only set in raw deployment mode
+	// +optional
+	IngressClassName string `json:"ingressClassName,omitempty"`
+	// Resolved autoscaler class
+	// +optional
+	AutoscalerClass string `json:"autoscalerClass,omitempty"`
+}
+
+// EndpointVisibility tells whether an endpoint is reachable from outside of the cluster
+type EndpointVisibility string
+
+const (
+	// ExternalEndpoint is reachable from outside of the cluster
+	ExternalEndpoint EndpointVisibility = "External"
+	// ClusterLocalEndpoint is only reachable from inside of the cluster
+	ClusterLocalEndpoint EndpointVisibility = "ClusterLocal"
+)
+
+// Endpoint names
+const (
+	ExternalEndpointName           = "external"
+	ClusterLocalEndpointName       = "cluster-local"
+	GrpcEndpointName               = "grpc"
+	GrpcClusterLocalEndpointName   = "grpc-cluster-local"
+	OpenAIEndpointName             = "openai"
+	OpenAIClusterLocalEndpointName = "openai-cluster-local"
+)
+
+// Endpoint is an address the InferenceService is reachable at
+type Endpoint struct {
+	// Name of the endpoint, one of external, cluster-local, grpc, grpc-cluster-local, openai or openai-cluster-local
+	Name string `json:"name"`
+	// Visibility of the endpoint, External or ClusterLocal
+	Visibility EndpointVisibility `json:"visibility"`
+	// Protocol served at the endpoint, one of http, https or grpc
+	Protocol string `json:"protocol"`
+	// URL of the endpoint including the base path of the API served at it, it is set for the http and https endpoints
+	// +optional
+	URL *apis.URL `json:"url,omitempty"`
+	// Target is the host:port to dial, it is set for the grpc endpoints
+	// +optional
+	Target string `json:"target,omitempty"`
 }
 
 // ComponentStatusSpec describes the state of the component
@@ -82,6 +189,43 @@ type ComponentStatusSpec struct {
 	// Addressable endpoint for the InferenceService
 	// +optional
 	Address *duckv1.Addressable `json:"address,omitempty"`
+	// External IP addresses or hostnames of the load balancer, when the Service of a raw deployment
+	// is exposed with the LoadBalancer type.
+	// +optional
+	ExternalAddresses []string `json:"externalAddresses,omitempty"`
+	// Images run by the containers of the latest pod of the component, including the containers injected by KServe,
+	// with the digests they were resolved to.
+	// +optional
+	// +listType=map
+	// +listMapKey=container
+	Images []ImageStatus `json:"images,omitempty"`
+}
+
+// ImageVerification is the result of the verification of the sigstore attestation of an image
+type ImageVerification string
+
+const (
+	ImageVerified           ImageVerification = "Verified"
+	ImageVerificationFailed ImageVerification = "Failed"
+)
+
+// ImageStatus records the image run by a container and the digest it was resolved to
+type ImageStatus struct {
+	// Name of the container
+	Container string `json:"container"`
+	// Image of the container as set in the pod
+	// +optional
+	Image string `json:"image,omitempty"`
+	// Digest of the image pulled by the container runtime, e.g. sha256:4d2f...
+	// +optional
+	Digest string `json:"digest,omitempty"`
+	// Result of the verification of the sigstore attestation of the image, it is empty when no attestation
+	// public key is configured in the image policy.
+	// +optional
+	Verification ImageVerification `json:"verification,omitempty"`
+	// Reason the verification failed
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // ComponentType contains the different types of components of the service
@@ -120,6 +264,18 @@ const (
 	RoutesReady apis.ConditionType = "RoutesReady"
 	// LatestDeploymentReady is set when underlying configurations for all components have reported readiness.
 	LatestDeploymentReady apis.ConditionType = "LatestDeploymentReady"
+	// Paused is set when the reconciliation of the child resources is paused by the serving.kserve.io/paused annotation.
+	Paused apis.ConditionType = "Paused"
+	// Stopped is set when the workloads are removed by the serving.kserve.io/stop annotation.
+	Stopped apis.ConditionType = "Stopped"
+	// Scheduled is set on the InferenceServices with active hours, it is false while the workloads are removed outside
+	// the windows.
+	Scheduled apis.ConditionType = "Scheduled"
+	// ServiceMeshMember is set to false when the namespace of a Serverless InferenceService is required to be
+	// enrolled into the service mesh but it is not.
+	ServiceMeshMember apis.ConditionType = "ServiceMeshMember"
+	// ValidationSucceeded is set when the validation Job of the latest predictor revision has succeeded.
+	ValidationSucceeded apis.ConditionType = "ValidationSucceeded"
 )
 
 type ModelStatus struct {
@@ -318,6 +474,26 @@ func (ss *InferenceServiceStatus) PropagateRawStatus(
 	ss.ObservedGeneration = deployment.Status.ObservedGeneration
 }
 
+// PropagateRawServiceStatus propagates the load balancer ingress of the Service of a raw deployment component.
+func (ss *InferenceServiceStatus) PropagateRawServiceStatus(component ComponentType, service *v1.Service) {
+	statusSpec, ok := ss.Components[component]
+	if !ok || service == nil {
+		return
+	}
+	var externalAddresses []string
+	if service.Spec.Type == v1.ServiceTypeLoadBalancer {
+		for _, ingress := range service.Status.LoadBalancer.Ingress {
+			if ingress.IP != "" {
+				externalAddresses = append(externalAddresses, ingress.IP)
+			} else if ingress.Hostname != "" {
+				externalAddresses = append(externalAddresses, ingress.Hostname)
+			}
+		}
+	}
+	statusSpec.ExternalAddresses = externalAddresses
+	ss.Components[component] = statusSpec
+}
+
 func getDeploymentCondition(deployment *appsv1.Deployment, conditionType appsv1.DeploymentConditionType) *apis.Condition {
 	condition := apis.Condition{}
 	for _, con := range deployment.Status.Conditions {
@@ -449,6 +625,109 @@ func (ss *InferenceServiceStatus) ClearCondition(conditionType apis.ConditionTyp
 	}
 }
 
+// SetPaused sets the Paused condition when the reconciliation of the child resources is paused and clears it otherwise,
+// the Paused condition does not affect the readiness.
+func (ss *InferenceServiceStatus) SetPaused(paused bool) {
+	if !paused {
+		ss.ClearCondition(Paused)
+		return
+	}
+	conditionSet.Manage(ss).SetCondition(apis.Condition{
+		Type:     Paused,
+		Status:   v1.ConditionTrue,
+		Severity: apis.ConditionSeverityInfo,
+		Reason:   "PausedByAnnotation",
+		Message:  "The child resources are not reconciled while the serving.kserve.io/paused annotation is true",
+	})
+}
+
+// SetStopped sets the Stopped condition and marks the predictor not ready while the workloads are removed by the stop
+// annotation, the Stopped condition is cleared otherwise.
+func (ss *InferenceServiceStatus) SetStopped(stopped bool) {
+	if !stopped {
+		ss.ClearCondition(Stopped)
+		return
+	}
+	manager := conditionSet.Manage(ss)
+	manager.SetCondition(apis.Condition{
+		Type:     Stopped,
+		Status:   v1.ConditionTrue,
+		Severity: apis.ConditionSeverityInfo,
+		Reason:   "StoppedByAnnotation",
+		Message:  "The workloads are removed while the serving.kserve.io/stop annotation is true",
+	})
+	manager.MarkFalse(PredictorReady, "Stopped", "The InferenceService is stopped")
+}
+
+// SetScheduled sets the Scheduled condition to true inside the active hours and to false outside them, when the
+// predictor is marked not ready as the workloads are removed. The next time is the next opening or closing of a
+// window, it is zero when no window opens or closes anymore.
+func (ss *InferenceServiceStatus) SetScheduled(active bool, next time.Time) {
+	manager := conditionSet.Manage(ss)
+	if active {
+		message := "The workloads run inside the active hours"
+		if !next.IsZero() {
+			message = fmt.Sprintf("The workloads run until %s", next.UTC().Format(time.RFC3339))
+		}
+		manager.SetCondition(apis.Condition{
+			Type:     Scheduled,
+			Status:   v1.ConditionTrue,
+			Severity: apis.ConditionSeverityInfo,
+			Reason:   "InsideActiveHours",
+			Message:  message,
+		})
+		return
+	}
+	message := "The workloads are removed as no window of the active hours opens anymore"
+	if !next.IsZero() {
+		message = fmt.Sprintf("The workloads are removed until %s", next.UTC().Format(time.RFC3339))
+	}
+	manager.SetCondition(apis.Condition{
+		Type:     Scheduled,
+		Status:   v1.ConditionFalse,
+		Severity: apis.ConditionSeverityInfo,
+		Reason:   "OutsideActiveHours",
+		Message:  message,
+	})
+	manager.MarkFalse(PredictorReady, "OutsideActiveHours", "The InferenceService is outside its active hours")
+}
+
+// SetServiceMeshMember clears the ServiceMeshMember condition when the namespace is enrolled into the service mesh and
+// sets it to false otherwise.
+func (ss *InferenceServiceStatus) SetServiceMeshMember(member bool, message string) {
+	if member {
+		ss.ClearCondition(ServiceMeshMember)
+		return
+	}
+	conditionSet.Manage(ss).SetCondition(apis.Condition{
+		Type:     ServiceMeshMember,
+		Status:   v1.ConditionFalse,
+		Severity: apis.ConditionSeverityError,
+		Reason:   "NamespaceNotEnrolled",
+		Message:  message,
+	})
+}
+
+// SetValidationCondition sets the ValidationSucceeded condition. The Ready condition is held with the same status,
+// reason and message until the validation succeeds.
+func (ss *InferenceServiceStatus) SetValidationCondition(status v1.ConditionStatus, reason, message string) {
+	conditionSet.Manage(ss).SetCondition(apis.Condition{
+		Type:     ValidationSucceeded,
+		Status:   status,
+		Severity: apis.ConditionSeverityError,
+		Reason:   reason,
+		Message:  message,
+	})
+	if status != v1.ConditionTrue && ss.IsConditionReady(apis.ConditionReady) {
+		conditionSet.Manage(ss).SetCondition(apis.Condition{
+			Type:    apis.ConditionReady,
+			Status:  status,
+			Reason:  reason,
+			Message: message,
+		})
+	}
+}
+
 func (ss *InferenceServiceStatus) UpdateModelRevisionStates(modelState ModelState, totalCopies int, info *FailureInfo) {
 	if ss.ModelStatus.ModelRevisionStates == nil {
 		ss.ModelStatus.ModelRevisionStates = &ModelRevisionStates{TargetModelState: modelState}
@@ -494,6 +773,45 @@ func (ss *InferenceServiceStatus) SetModelFailureInfo(info *FailureInfo) bool {
 	return true
 }
 
+// PropagateImageStatus records the images of the containers of the newest pod of the component, the pods are
+// sorted by creation time, newest first. The verification of the images resolved to the same digest is kept.
+func (ss *InferenceServiceStatus) PropagateImageStatus(component ComponentType, podList *v1.PodList) {
+	if len(podList.Items) == 0 {
+		return
+	}
+	if ss.Components == nil {
+		ss.Components = make(map[ComponentType]ComponentStatusSpec)
+	}
+	statusSpec := ss.Components[component]
+	previous := map[string]ImageStatus{}
+	for _, image := range statusSpec.Images {
+		previous[image.Container] = image
+	}
+	pod := podList.Items[0]
+	images := []ImageStatus{}
+	for _, containerStatuses := range [][]v1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, cs := range containerStatuses {
+			image := ImageStatus{Container: cs.Name, Image: cs.Image, Digest: imageDigest(cs.ImageID)}
+			if old, ok := previous[cs.Name]; ok && old.Digest == image.Digest {
+				image.Verification = old.Verification
+				image.Message = old.Message
+			}
+			images = append(images, image)
+		}
+	}
+	statusSpec.Images = images
+	ss.Components[component] = statusSpec
+}
+
+// imageDigest returns the digest of the image id reported by the container runtime,
+// e.g. docker-pullable://kserve/agent@sha256:4d2f..., it is empty when the image id has no digest.
+func imageDigest(imageID string) string {
+	if idx := strings.LastIndex(imageID, "@"); idx >= 0 {
+		return imageID[idx+1:]
+	}
+	return ""
+}
+
 func (ss *InferenceServiceStatus) PropagateModelStatus(statusSpec ComponentStatusSpec, podList *v1.PodList, rawDeployment bool) {
 	// Check at least one pod is running for the latest revision of inferenceservice
 	totalCopies := len(podList.Items)
@@ -1226,3 +1226,160 @@ func TestInferenceServiceStatus_UpdateModelRevisionStates(t *testing.T) {
 		})
 	}
 }
+
+func TestInferenceServiceStatus_SetPaused(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	status := &InferenceServiceStatus{}
+	status.InitializeConditions()
+	status.SetCondition(PredictorReady, &apis.Condition{Status: v1.ConditionTrue})
+	status.SetCondition(IngressReady, &apis.Condition{Status: v1.ConditionTrue})
+
+	status.SetPaused(true)
+	g.Expect(status.IsConditionReady(Paused)).Should(gomega.BeTrue())
+	g.Expect(status.GetCondition(Paused).Reason).Should(gomega.Equal("PausedByAnnotation"))
+	g.Expect(status.IsReady()).Should(gomega.BeTrue())
+
+	status.SetPaused(false)
+	g.Expect(status.GetCondition(Paused)).Should(gomega.BeNil())
+	g.Expect(status.IsReady()).Should(gomega.BeTrue())
+}
+
+func TestInferenceServiceStatus_SetStopped(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	status := &InferenceServiceStatus{}
+	status.InitializeConditions()
+	status.SetCondition(PredictorReady, &apis.Condition{Status: v1.ConditionTrue})
+	status.SetCondition(IngressReady, &apis.Condition{Status: v1.ConditionTrue})
+
+	status.SetStopped(true)
+	g.Expect(status.IsConditionReady(Stopped)).Should(gomega.BeTrue())
+	g.Expect(status.GetCondition(PredictorReady).Reason).Should(gomega.Equal("Stopped"))
+	g.Expect(status.IsReady()).Should(gomega.BeFalse())
+
+	status.SetStopped(false)
+	g.Expect(status.GetCondition(Stopped)).Should(gomega.BeNil())
+}
+
+func TestInferenceServiceStatus_SetScheduled(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	status := &InferenceServiceStatus{}
+	status.InitializeConditions()
+	status.SetCondition(PredictorReady, &apis.Condition{Status: v1.ConditionTrue})
+	status.SetCondition(IngressReady, &apis.Condition{Status: v1.ConditionTrue})
+	closing := time.Date(2024, 3, 1, 18, 0, 0, 0, time.UTC)
+
+	status.SetScheduled(true, closing)
+	g.Expect(status.IsConditionReady(Scheduled)).Should(gomega.BeTrue())
+	g.Expect(status.GetCondition(Scheduled).Message).Should(gomega.Equal("The workloads run until 2024-03-01T18:00:00Z"))
+	g.Expect(status.IsReady()).Should(gomega.BeTrue())
+
+	status.SetScheduled(false, closing.AddDate(0, 0, 3).Add(-10*time.Hour))
+	g.Expect(status.IsConditionFalse(Scheduled)).Should(gomega.BeTrue())
+	g.Expect(status.GetCondition(Scheduled).Message).Should(gomega.Equal("The workloads are removed until 2024-03-04T08:00:00Z"))
+	g.Expect(status.GetCondition(PredictorReady).Reason).Should(gomega.Equal("OutsideActiveHours"))
+	g.Expect(status.IsReady()).Should(gomega.BeFalse())
+}
+
+func TestInferenceServiceStatus_SetServiceMeshMember(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	status := &InferenceServiceStatus{}
+	status.InitializeConditions()
+
+	status.SetServiceMeshMember(false, "The namespace default is not enrolled into the service mesh")
+	g.Expect(status.IsConditionFalse(ServiceMeshMember)).Should(gomega.BeTrue())
+	g.Expect(status.GetCondition(ServiceMeshMember).Reason).Should(gomega.Equal("NamespaceNotEnrolled"))
+
+	status.SetServiceMeshMember(true, "")
+	g.Expect(status.GetCondition(ServiceMeshMember)).Should(gomega.BeNil())
+}
+
+func TestInferenceServiceStatus_PropagateRawServiceStatus(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	status := &InferenceServiceStatus{
+		Components: map[ComponentType]ComponentStatusSpec{
+			PredictorComponent: {},
+		},
+	}
+	service := &v1.Service{
+		Spec: v1.ServiceSpec{
+			Type: v1.ServiceTypeLoadBalancer,
+		},
+		Status: v1.ServiceStatus{
+			LoadBalancer: v1.LoadBalancerStatus{
+				Ingress: []v1.LoadBalancerIngress{
+					{IP: "203.0.113.10"},
+					{Hostname: "lb.example.com"},
+				},
+			},
+		},
+	}
+	status.PropagateRawServiceStatus(PredictorComponent, service)
+	g.Expect(status.Components[PredictorComponent].ExternalAddresses).Should(gomega.Equal([]string{"203.0.113.10", "lb.example.com"}))
+
+	// the addresses are cleared when the Service is no longer a LoadBalancer
+	service.Spec.Type = v1.ServiceTypeClusterIP
+	status.PropagateRawServiceStatus(PredictorComponent, service)
+	g.Expect(status.Components[PredictorComponent].ExternalAddresses).Should(gomega.BeNil())
+
+	// components without status are left untouched
+	status.PropagateRawServiceStatus(TransformerComponent, service)
+	g.Expect(status.Components).ShouldNot(gomega.HaveKey(TransformerComponent))
+}
+
+func TestInferenceServiceStatus_PropagateImageStatus(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	status := &InferenceServiceStatus{
+		Components: map[ComponentType]ComponentStatusSpec{
+			PredictorComponent: {
+				Images: []ImageStatus{
+					{Container: "kserve-container", Image: "kserve/sklearnserver:latest", Digest: "sha256:aaa", Verification: ImageVerified},
+					{Container: "agent", Image: "kserve/agent:latest", Digest: "sha256:bbb", Verification: ImageVerified},
+				},
+			},
+		},
+	}
+	pods := &v1.PodList{Items: []v1.Pod{{
+		Status: v1.PodStatus{
+			InitContainerStatuses: []v1.ContainerStatus{
+				{Name: "storage-initializer", Image: "kserve/storage-initializer:latest", ImageID: "docker.io/kserve/storage-initializer@sha256:ccc"},
+			},
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: "kserve-container", Image: "kserve/sklearnserver:latest", ImageID: "docker-pullable://kserve/sklearnserver@sha256:aaa"},
+				{Name: "agent", Image: "kserve/agent:latest", ImageID: "docker.io/kserve/agent@sha256:ddd"},
+				{Name: "queue-proxy", Image: "queue:latest"},
+			},
+		},
+	}}}
+	status.PropagateImageStatus(PredictorComponent, pods)
+	g.Expect(status.Components[PredictorComponent].Images).Should(gomega.Equal([]ImageStatus{
+		{Container: "storage-initializer", Image: "kserve/storage-initializer:latest", Digest: "sha256:ccc"},
+		// the verification is kept for the image resolved to the same digest only
+		{Container: "kserve-container", Image: "kserve/sklearnserver:latest", Digest: "sha256:aaa", Verification: ImageVerified},
+		{Container: "agent", Image: "kserve/agent:latest", Digest: "sha256:ddd"},
+		{Container: "queue-proxy", Image: "queue:latest"},
+	}))
+
+	// the images are kept while there is no pod
+	status.PropagateImageStatus(PredictorComponent, &v1.PodList{})
+	g.Expect(status.Components[PredictorComponent].Images).Should(gomega.HaveLen(4))
+}
+
+func TestInferenceServiceStatus_SetValidationCondition(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	status := &InferenceServiceStatus{}
+	status.InitializeConditions()
+	status.SetCondition(PredictorReady, &apis.Condition{Status: v1.ConditionTrue})
+	status.SetCondition(IngressReady, &apis.Condition{Status: v1.ConditionTrue})
+	g.Expect(status.IsReady()).Should(gomega.BeTrue())
+
+	status.SetValidationCondition(v1.ConditionUnknown, "JobRunning", "Validation job foo-validation-1 is running")
+	g.Expect(status.IsConditionUnknown(ValidationSucceeded)).Should(gomega.BeTrue())
+	g.Expect(status.IsReady()).Should(gomega.BeFalse())
+	g.Expect(status.GetCondition(apis.ConditionReady).Reason).Should(gomega.Equal("JobRunning"))
+
+	// the Ready condition is computed again from the components once the validation succeeded
+	status.SetValidationCondition(v1.ConditionTrue, "JobSucceeded", "Validation job foo-validation-1 succeeded")
+	status.SetCondition(PredictorReady, &apis.Condition{Status: v1.ConditionTrue})
+	g.Expect(status.IsConditionReady(ValidationSucceeded)).Should(gomega.BeTrue())
+	g.Expect(status.IsReady()).Should(gomega.BeTrue())
+}
@@ -20,6 +20,8 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
+	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -28,6 +30,8 @@ import (
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/kserve/kserve/pkg/utils"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/serving/pkg/apis/autoscaling"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -39,6 +43,14 @@ const (
 	StorageUriPresentInTransformerError string = "storage uri should not be specified in transformer container"
 )
 
+// Known warning messages
+const (
+	DeprecatedPredictorWarning      = "spec.predictor.%s is deprecated, use spec.predictor.model with modelFormat.name %s instead"
+	DeprecatedServiceAccountWarning = "spec.%s.serviceAccount is deprecated, use spec.%s.serviceAccountName instead"
+	ZeroMinReplicasRawWarning       = "spec.%s.minReplicas 0 is raised to %d in %s mode since the HPA can not scale to zero, " +
+		"set the %s annotation to %s and use an external autoscaler such as KEDA to scale to zero"
+)
+
 var (
 	// logger for the validation webhook.
 	validatorLogger = logf.Log.WithName("inferenceservice-v1beta1-validation-webhook")
@@ -52,6 +64,15 @@ var _ webhook.Validator = &InferenceService{}
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (isvc *InferenceService) ValidateCreate() (admission.Warnings, error) {
 	validatorLogger.Info("validate create", "name", isvc.Name)
+	if err := validateServingPriority(isvc.Spec.ServingPriority); err != nil {
+		return nil, err
+	}
+	return validateInferenceService(isvc, isvc.Annotations)
+}
+
+// validateInferenceService validates the InferenceService, routeAnnotations are the annotations checked against
+// the router annotation allow list, on update they are limited to the ones added or changed by the update.
+func validateInferenceService(isvc *InferenceService, routeAnnotations map[string]string) (admission.Warnings, error) {
 	var allWarnings admission.Warnings
 	annotations := isvc.Annotations
 
@@ -71,6 +92,58 @@ func (isvc *InferenceService) ValidateCreate() (admission.Warnings, error) {
 		return allWarnings, err
 	}
 
+	if err := utils.ValidateRouteAnnotations(routeAnnotations); err != nil {
+		return allWarnings, err
+	}
+
+	if err := utils.ValidateDeploymentAnnotations(annotations); err != nil {
+		return allWarnings, err
+	}
+
+	if err := utils.ValidateServiceAnnotations(annotations); err != nil {
+		return allWarnings, err
+	}
+
+	if err := validateRouteTLSTermination(isvc); err != nil {
+		return allWarnings, err
+	}
+
+	if err := validateInitContainersAfterStorageInitializer(isvc); err != nil {
+		return allWarnings, err
+	}
+
+	if err := validateRequestReplay(isvc); err != nil {
+		return allWarnings, err
+	}
+
+	if err := utils.ValidateFIPSCompatibility(annotations); err != nil {
+		return allWarnings, err
+	}
+
+	if err := validateValidationSpec(isvc.Spec.Validation); err != nil {
+		return allWarnings, err
+	}
+
+	if err := validateBenchmarkSpec(isvc.Spec.Benchmark); err != nil {
+		return allWarnings, err
+	}
+
+	if err := validateActiveHours(isvc.Spec.ActiveHours); err != nil {
+		return allWarnings, err
+	}
+
+	if err := validateCORS(isvc.Spec.CORS); err != nil {
+		return allWarnings, err
+	}
+
+	if err := validateServingProfile(isvc); err != nil {
+		return allWarnings, err
+	}
+
+	if err := validateModelConversion(isvc.Spec.Predictor.ModelConversion); err != nil {
+		return allWarnings, err
+	}
+
 	for _, component := range []Component{
 		&isvc.Spec.Predictor,
 		isvc.Spec.Transformer,
@@ -89,7 +162,58 @@ func (isvc *InferenceService) ValidateCreate() (admission.Warnings, error) {
 			}
 		}
 	}
-	return allWarnings, nil
+	return getInferenceServiceWarnings(isvc), nil
+}
+
+// getInferenceServiceWarnings returns the warnings for the deprecated fields and the configurations which are
+// accepted but unlikely to do what the user expects, they are shown by kubectl without rejecting the request.
+func getInferenceServiceWarnings(isvc *InferenceService) admission.Warnings {
+	var warnings admission.Warnings
+	predictor := isvc.Spec.Predictor
+	for _, deprecated := range []struct {
+		framework string
+		set       bool
+	}{
+		{"sklearn", predictor.SKLearn != nil},
+		{"xgboost", predictor.XGBoost != nil},
+		{"tensorflow", predictor.Tensorflow != nil},
+		{"pytorch", predictor.PyTorch != nil},
+		{"triton", predictor.Triton != nil},
+		{"onnx", predictor.ONNX != nil},
+		{"huggingface", predictor.HuggingFace != nil},
+		{"pmml", predictor.PMML != nil},
+		{"lightgbm", predictor.LightGBM != nil},
+		{"paddle", predictor.Paddle != nil},
+	} {
+		if deprecated.set {
+			warnings = append(warnings, fmt.Sprintf(DeprecatedPredictorWarning, deprecated.framework, deprecated.framework))
+		}
+	}
+
+	type component struct {
+		name       ComponentType
+		podSpec    *PodSpec
+		extensions *ComponentExtensionSpec
+	}
+	components := []component{{PredictorComponent, &predictor.PodSpec, &predictor.ComponentExtensionSpec}}
+	if isvc.Spec.Transformer != nil {
+		components = append(components, component{TransformerComponent, &isvc.Spec.Transformer.PodSpec, &isvc.Spec.Transformer.ComponentExtensionSpec})
+	}
+	if isvc.Spec.Explainer != nil {
+		components = append(components, component{ExplainerComponent, &isvc.Spec.Explainer.PodSpec, &isvc.Spec.Explainer.ComponentExtensionSpec})
+	}
+	rawDeployment := isvc.Annotations[constants.DeploymentMode] == string(constants.RawDeployment)
+	externalAutoscaler := isvc.Annotations[constants.AutoscalerClass] == string(constants.AutoscalerClassExternal)
+	for _, c := range components {
+		if c.podSpec.DeprecatedServiceAccount != "" {
+			warnings = append(warnings, fmt.Sprintf(DeprecatedServiceAccountWarning, c.name, c.name))
+		}
+		if rawDeployment && !externalAutoscaler && c.extensions.MinReplicas != nil && *c.extensions.MinReplicas == 0 {
+			warnings = append(warnings, fmt.Sprintf(ZeroMinReplicasRawWarning, c.name, constants.DefaultMinReplicas,
+				constants.RawDeployment, constants.AutoscalerClass, constants.AutoscalerClassExternal))
+		}
+	}
+	return warnings
 }
 
 // Validate scaling options component extensions
@@ -106,8 +230,23 @@ func validateAutoScalingCompExtension(annotations map[string]string, compExtSpec
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (isvc *InferenceService) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	validatorLogger.Info("validate update", "name", isvc.Name)
-
-	return isvc.ValidateCreate()
+	// the finalizer is removed by an update of an object being deleted, which must not be rejected
+	if isvc.DeletionTimestamp != nil {
+		return nil, nil
+	}
+	routeAnnotations := isvc.Annotations
+	servingPriority := isvc.Spec.ServingPriority
+	if oldIsvc, ok := old.(*InferenceService); ok {
+		routeAnnotations = utils.ChangedAnnotations(oldIsvc.Annotations, isvc.Annotations)
+		// the servingPriority set before the max was lowered is kept, the preemptor caps it to the max
+		if reflect.DeepEqual(oldIsvc.Spec.ServingPriority, servingPriority) {
+			servingPriority = nil
+		}
+	}
+	if err := validateServingPriority(servingPriority); err != nil {
+		return nil, err
+	}
+	return validateInferenceService(isvc, routeAnnotations)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -130,6 +269,85 @@ func validateInferenceServiceName(isvc *InferenceService) error {
 	return nil
 }
 
+// Validation of the route TLS termination annotation
+func validateRouteTLSTermination(isvc *InferenceService) error {
+	if value, ok := isvc.ObjectMeta.Annotations[constants.RouteTLSTerminationAnnotationKey]; ok {
+		termination := constants.RouteTLSTerminationType(value)
+		if termination != constants.RouteTLSTerminationEdge && termination != constants.RouteTLSTerminationReencrypt {
+			return fmt.Errorf("[%s] is not a supported route TLS termination, must be one of %s or %s", value,
+				constants.RouteTLSTerminationEdge, constants.RouteTLSTerminationReencrypt)
+		}
+	}
+	return nil
+}
+
+// Validation of the init containers ordered after the storage initializer, each of them must be an init container of
+// one of the components
+func validateInitContainersAfterStorageInitializer(isvc *InferenceService) error {
+	value, ok := isvc.ObjectMeta.Annotations[constants.InitContainersAfterStorageInitializerAnnotationKey]
+	if !ok {
+		return nil
+	}
+	initContainers := sets.NewString()
+	for _, podSpec := range []*PodSpec{
+		&isvc.Spec.Predictor.PodSpec,
+		getTransformerPodSpec(isvc.Spec.Transformer),
+		getExplainerPodSpec(isvc.Spec.Explainer),
+	} {
+		if podSpec == nil {
+			continue
+		}
+		for _, container := range podSpec.InitContainers {
+			initContainers.Insert(container.Name)
+		}
+	}
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); !initContainers.Has(name) {
+			return fmt.Errorf("the annotation %s references %q which is not an init container of the InferenceService",
+				constants.InitContainersAfterStorageInitializerAnnotationKey, name)
+		}
+	}
+	return nil
+}
+
+// Validation of the request replay annotations, the token Secret must be named and the buffer size must be positive
+func validateRequestReplay(isvc *InferenceService) error {
+	secretName, enabled := isvc.ObjectMeta.Annotations[constants.RequestReplaySecretAnnotationKey]
+	bufferSize, bufferSizeSet := isvc.ObjectMeta.Annotations[constants.RequestReplayBufferSizeAnnotationKey]
+	if !enabled {
+		if bufferSizeSet {
+			return fmt.Errorf("the annotation %s requires the annotation %s",
+				constants.RequestReplayBufferSizeAnnotationKey, constants.RequestReplaySecretAnnotationKey)
+		}
+		return nil
+	}
+	if errs := validation.IsDNS1123Subdomain(secretName); len(errs) > 0 {
+		return fmt.Errorf("the annotation %s must name a Secret: %s", constants.RequestReplaySecretAnnotationKey,
+			strings.Join(errs, ", "))
+	}
+	if bufferSizeSet {
+		if size, err := strconv.Atoi(bufferSize); err != nil || size <= 0 {
+			return fmt.Errorf("the annotation %s must be a positive integer, got %q",
+				constants.RequestReplayBufferSizeAnnotationKey, bufferSize)
+		}
+	}
+	return nil
+}
+
+func getTransformerPodSpec(transformer *TransformerSpec) *PodSpec {
+	if transformer == nil {
+		return nil
+	}
+	return &transformer.PodSpec
+}
+
+func getExplainerPodSpec(explainer *ExplainerSpec) *PodSpec {
+	if explainer == nil {
+		return nil
+	}
+	return &explainer.PodSpec
+}
+
 // Validation of isvc autoscaler class
 func validateInferenceServiceAutoscaler(isvc *InferenceService) error {
 	annotations := isvc.ObjectMeta.Annotations
@@ -222,6 +440,9 @@ func validateScalingKPACompExtension(compExtSpec *ComponentExtensionSpec) error
 	if compExtSpec.DeploymentStrategy != nil {
 		return fmt.Errorf("customizing deploymentStrategy is only supported for raw deployment mode")
 	}
+	if compExtSpec.MinReadySeconds != nil {
+		return fmt.Errorf("customizing minReadySeconds is only supported for raw deployment mode")
+	}
 	metric := MetricConcurrency
 	if compExtSpec.ScaleMetric != nil {
 		metric = *compExtSpec.ScaleMetric
@@ -244,6 +465,97 @@ func validateScalingKPACompExtension(compExtSpec *ComponentExtensionSpec) error
 	return nil
 }
 
+// validates the reference to the template of the validation job and its timeout
+func validateValidationSpec(spec *ValidationSpec) error {
+	if spec == nil {
+		return nil
+	}
+	if errs := validation.IsDNS1123Subdomain(spec.JobTemplateConfigMap); len(errs) > 0 {
+		return fmt.Errorf("the validation jobTemplateConfigMap %q is not a valid ConfigMap name: %s",
+			spec.JobTemplateConfigMap, strings.Join(errs, ", "))
+	}
+	if spec.TimeoutSeconds != nil && *spec.TimeoutSeconds <= 0 {
+		return fmt.Errorf("the validation timeoutSeconds must be positive, got %d", *spec.TimeoutSeconds)
+	}
+	return nil
+}
+
+// validates the reference to the payload of the benchmark and its load
+func validateBenchmarkSpec(spec *BenchmarkSpec) error {
+	if spec == nil {
+		return nil
+	}
+	if errs := validation.IsDNS1123Subdomain(spec.PayloadConfigMap); len(errs) > 0 {
+		return fmt.Errorf("the benchmark payloadConfigMap %q is not a valid ConfigMap name: %s",
+			spec.PayloadConfigMap, strings.Join(errs, ", "))
+	}
+	if spec.RPS <= 0 {
+		return fmt.Errorf("the benchmark rps must be positive, got %d", spec.RPS)
+	}
+	if spec.DurationSeconds <= 0 {
+		return fmt.Errorf("the benchmark durationSeconds must be positive, got %d", spec.DurationSeconds)
+	}
+	if spec.Call != "" && spec.Tool != BenchmarkToolGhz {
+		return fmt.Errorf("the benchmark call is only supported by the %s tool", BenchmarkToolGhz)
+	}
+	return nil
+}
+
+// validates the image of the model conversion and the reference to its cache
+func validateModelConversion(spec *ModelConversionSpec) error {
+	if spec == nil {
+		return nil
+	}
+	if strings.TrimSpace(spec.Image) == "" {
+		return fmt.Errorf("the modelConversion image must be specified")
+	}
+	if spec.CachePVCName != "" {
+		if errs := validation.IsDNS1123Subdomain(spec.CachePVCName); len(errs) > 0 {
+			return fmt.Errorf("the modelConversion cachePvcName %q is not a valid PersistentVolumeClaim name: %s",
+				spec.CachePVCName, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+// validates the cron schedules, the durations and the time zone of the active hours
+func validateActiveHours(activeHours *ActiveHours) error {
+	if activeHours == nil {
+		return nil
+	}
+	if len(activeHours.Windows) == 0 {
+		return fmt.Errorf("the activeHours must have at least one window")
+	}
+	if _, _, err := activeHours.IsActive(time.Now()); err != nil {
+		return fmt.Errorf("the activeHours are invalid: %w", err)
+	}
+	return nil
+}
+
+// validates the servingPriority against the maxServingPriority of the GPU preemption config, so that the tenants
+// cannot raise the priority of their InferenceServices above the ones granted by the PriorityClasses
+func validateServingPriority(priority *int32) error {
+	if priority == nil {
+		return nil
+	}
+	if limit := maxServingPriority.Load(); *priority > limit {
+		return fmt.Errorf("the servingPriority %d exceeds the maxServingPriority %d of the gpu preemption config, "+
+			"use a PriorityClass for the higher priorities", *priority, limit)
+	}
+	return nil
+}
+
+// validates the origins, the methods and the headers of the CORS policy
+func validateCORS(policy *CORSPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	if err := policy.Config().Validate(); err != nil {
+		return fmt.Errorf("the cors policy is invalid: %w", err)
+	}
+	return nil
+}
+
 // validates if transformer container has storage uri or not in collocation of predictor and transformer scenario
 func validateCollocationStorageURI(predictorSpec PredictorSpec) error {
 	for _, container := range predictorSpec.Containers {
@@ -17,8 +17,10 @@ limitations under the License.
 package v1beta1
 
 import (
+	"fmt"
 	"github.com/kserve/kserve/pkg/constants"
 	"testing"
+	"time"
 
 	"google.golang.org/protobuf/proto"
 
@@ -42,7 +44,8 @@ func makeTestRawInferenceService() InferenceService {
 		},
 		Spec: InferenceServiceSpec{
 			Predictor: PredictorSpec{
-				Tensorflow: &TFServingSpec{
+				Model: &ModelSpec{
+					ModelFormat: ModelFormat{Name: "tensorflow"},
 					PredictorExtensionSpec: PredictorExtensionSpec{
 						StorageURI:     proto.String("gs://testbucket/testmodel"),
 						RuntimeVersion: proto.String("0.14.0"),
@@ -62,7 +65,8 @@ func makeTestInferenceService() InferenceService {
 		},
 		Spec: InferenceServiceSpec{
 			Predictor: PredictorSpec{
-				Tensorflow: &TFServingSpec{
+				Model: &ModelSpec{
+					ModelFormat: ModelFormat{Name: "tensorflow"},
 					PredictorExtensionSpec: PredictorExtensionSpec{
 						StorageURI:     proto.String("gs://testbucket/testmodel"),
 						RuntimeVersion: proto.String("0.14.0"),
@@ -154,6 +158,56 @@ func TestCustomizeDeploymentStrategyUnsupportedForServerless(t *testing.T) {
 	g.Expect(warnings).Should(gomega.BeEmpty())
 }
 
+func TestCustomizeMinReadySecondsUnsupportedForServerless(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestInferenceService()
+	isvc.Spec.Predictor.PodSpec = PodSpec{ServiceAccountName: "test"}
+	minReadySeconds := int32(30)
+	isvc.Spec.Predictor.MinReadySeconds = &minReadySeconds
+	warnings, err := isvc.ValidateCreate()
+	g.Expect(err).Should(gomega.MatchError("customizing minReadySeconds is only supported for raw deployment mode"))
+	g.Expect(warnings).Should(gomega.BeEmpty())
+}
+
+func TestInitContainersAfterStorageInitializer(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestInferenceService()
+	isvc.Spec.Predictor.InitContainers = []v1.Container{{Name: "tokenizer", Image: "tokenizer:latest"}}
+	isvc.Annotations = map[string]string{constants.InitContainersAfterStorageInitializerAnnotationKey: "tokenizer"}
+	_, err := isvc.ValidateCreate()
+	g.Expect(err).Should(gomega.Succeed())
+
+	isvc.Annotations[constants.InitContainersAfterStorageInitializerAnnotationKey] = "tokenizer,warmer"
+	_, err = isvc.ValidateCreate()
+	g.Expect(err).Should(gomega.MatchError(fmt.Sprintf("the annotation %s references %q which is not an init container of the InferenceService",
+		constants.InitContainersAfterStorageInitializerAnnotationKey, "warmer")))
+}
+
+func TestRequestReplayAnnotations(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestInferenceService()
+	isvc.Annotations = map[string]string{
+		constants.RequestReplaySecretAnnotationKey:     "replay-token",
+		constants.RequestReplayBufferSizeAnnotationKey: "20",
+	}
+	_, err := isvc.ValidateCreate()
+	g.Expect(err).Should(gomega.Succeed())
+
+	isvc.Annotations[constants.RequestReplayBufferSizeAnnotationKey] = "0"
+	_, err = isvc.ValidateCreate()
+	g.Expect(err).Should(gomega.MatchError(fmt.Sprintf("the annotation %s must be a positive integer, got %q",
+		constants.RequestReplayBufferSizeAnnotationKey, "0")))
+
+	isvc.Annotations[constants.RequestReplaySecretAnnotationKey] = "Replay_Token"
+	_, err = isvc.ValidateCreate()
+	g.Expect(err).Should(gomega.MatchError(gomega.ContainSubstring("must name a Secret")))
+
+	delete(isvc.Annotations, constants.RequestReplaySecretAnnotationKey)
+	_, err = isvc.ValidateCreate()
+	g.Expect(err).Should(gomega.MatchError(fmt.Sprintf("the annotation %s requires the annotation %s",
+		constants.RequestReplayBufferSizeAnnotationKey, constants.RequestReplaySecretAnnotationKey)))
+}
+
 func TestModelSpecAndCustomOverridesIsValid(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	isvc := makeTestInferenceService()
@@ -166,7 +220,7 @@ func TestModelSpecAndCustomOverridesIsValid(t *testing.T) {
 func TestRejectModelSpecMissing(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	isvc := makeTestInferenceService()
-	isvc.Spec.Predictor.Tensorflow = nil
+	isvc.Spec.Predictor.Model = nil
 	warnings, err := isvc.ValidateCreate()
 	g.Expect(err).Should(gomega.MatchError(ExactlyOneErrorFor(&isvc.Spec.Predictor)))
 	g.Expect(warnings).Should(gomega.BeEmpty())
@@ -261,7 +315,7 @@ func TestBadReplicaValues(t *testing.T) {
 func TestCustomOK(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	isvc := makeTestInferenceService()
-	isvc.Spec.Predictor.Tensorflow = nil
+	isvc.Spec.Predictor.Model = nil
 	isvc.Spec.Predictor.PodSpec = PodSpec{
 		Containers: []v1.Container{
 			{
@@ -334,6 +388,171 @@ func TestRejectBadNameIncludeDot(t *testing.T) {
 	g.Expect(warnings).Should(gomega.BeEmpty())
 }
 
+func TestValidateValidationSpec(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestInferenceService()
+	isvc.Spec.Validation = &ValidationSpec{JobTemplateConfigMap: "golden-requests", TimeoutSeconds: proto.Int64(300)}
+	_, err := isvc.ValidateCreate()
+	g.Expect(err).Should(gomega.Succeed())
+
+	isvc.Spec.Validation.TimeoutSeconds = proto.Int64(0)
+	_, err = isvc.ValidateCreate()
+	g.Expect(err).ShouldNot(gomega.Succeed())
+
+	isvc.Spec.Validation = &ValidationSpec{JobTemplateConfigMap: "Golden_Requests"}
+	_, err = isvc.ValidateCreate()
+	g.Expect(err).ShouldNot(gomega.Succeed())
+}
+
+func TestValidateBenchmarkSpec(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		benchmark  *BenchmarkSpec
+		errMatcher gomega.OmegaMatcher
+	}{
+		"Valid": {
+			benchmark:  &BenchmarkSpec{RPS: 10, DurationSeconds: 60, PayloadConfigMap: "payload"},
+			errMatcher: gomega.Succeed(),
+		},
+		"ValidGhzCall": {
+			benchmark: &BenchmarkSpec{Tool: BenchmarkToolGhz, RPS: 10, DurationSeconds: 60, PayloadConfigMap: "payload",
+				Call: "inference.GRPCInferenceService/ModelInfer"},
+			errMatcher: gomega.Succeed(),
+		},
+		"InvalidPayloadConfigMap": {
+			benchmark:  &BenchmarkSpec{RPS: 10, DurationSeconds: 60, PayloadConfigMap: "Payload_1"},
+			errMatcher: gomega.HaveOccurred(),
+		},
+		"ZeroRPS": {
+			benchmark:  &BenchmarkSpec{DurationSeconds: 60, PayloadConfigMap: "payload"},
+			errMatcher: gomega.HaveOccurred(),
+		},
+		"ZeroDuration": {
+			benchmark:  &BenchmarkSpec{RPS: 10, PayloadConfigMap: "payload"},
+			errMatcher: gomega.HaveOccurred(),
+		},
+		"CallWithVegeta": {
+			benchmark: &BenchmarkSpec{RPS: 10, DurationSeconds: 60, PayloadConfigMap: "payload",
+				Call: "inference.GRPCInferenceService/ModelInfer"},
+			errMatcher: gomega.HaveOccurred(),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			isvc := makeTestInferenceService()
+			isvc.Spec.Benchmark = scenario.benchmark
+			_, err := isvc.ValidateCreate()
+			g.Expect(err).Should(scenario.errMatcher)
+		})
+	}
+}
+
+func TestValidateModelConversion(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		conversion *ModelConversionSpec
+		errMatcher gomega.OmegaMatcher
+	}{
+		"Valid": {
+			conversion: &ModelConversionSpec{Image: "converter:latest", Args: []string{"--to", "safetensors"}},
+			errMatcher: gomega.Succeed(),
+		},
+		"ValidCache": {
+			conversion: &ModelConversionSpec{Image: "converter:latest", CachePVCName: "conversion-cache"},
+			errMatcher: gomega.Succeed(),
+		},
+		"MissingImage": {
+			conversion: &ModelConversionSpec{Args: []string{"--to", "safetensors"}},
+			errMatcher: gomega.HaveOccurred(),
+		},
+		"InvalidCachePVCName": {
+			conversion: &ModelConversionSpec{Image: "converter:latest", CachePVCName: "Conversion_Cache"},
+			errMatcher: gomega.HaveOccurred(),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			isvc := makeTestInferenceService()
+			isvc.Spec.Predictor.ModelConversion = scenario.conversion
+			_, err := isvc.ValidateCreate()
+			g.Expect(err).Should(scenario.errMatcher)
+		})
+	}
+}
+
+func TestValidateActiveHours(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	businessHours := ActiveWindow{Schedule: "0 8 * * 1-5", Duration: metav1.Duration{Duration: 10 * time.Hour}}
+	scenarios := map[string]struct {
+		activeHours *ActiveHours
+		errMatcher  gomega.OmegaMatcher
+	}{
+		"Valid": {
+			activeHours: &ActiveHours{Windows: []ActiveWindow{businessHours}, TimeZone: "Europe/Paris"},
+			errMatcher:  gomega.Succeed(),
+		},
+		"NoWindow": {
+			activeHours: &ActiveHours{},
+			errMatcher:  gomega.HaveOccurred(),
+		},
+		"InvalidSchedule": {
+			activeHours: &ActiveHours{Windows: []ActiveWindow{{Schedule: "0 8 * *",
+				Duration: metav1.Duration{Duration: time.Hour}}}},
+			errMatcher: gomega.HaveOccurred(),
+		},
+		"ZeroDuration": {
+			activeHours: &ActiveHours{Windows: []ActiveWindow{{Schedule: "0 8 * * 1-5"}}},
+			errMatcher:  gomega.HaveOccurred(),
+		},
+		"InvalidTimeZone": {
+			activeHours: &ActiveHours{Windows: []ActiveWindow{businessHours}, TimeZone: "Europe/Atlantis"},
+			errMatcher:  gomega.HaveOccurred(),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			isvc := makeTestInferenceService()
+			isvc.Spec.ActiveHours = scenario.activeHours
+			_, err := isvc.ValidateCreate()
+			g.Expect(err).Should(scenario.errMatcher)
+		})
+	}
+}
+
+func TestValidateCORS(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		cors       *CORSPolicy
+		errMatcher gomega.OmegaMatcher
+	}{
+		"Valid": {
+			cors: &CORSPolicy{AllowOrigins: []string{"https://app.example.com"},
+				AllowHeaders: []string{"Content-Type"}, MaxAge: proto.Int32(600)},
+			errMatcher: gomega.Succeed(),
+		},
+		"AnyOrigin": {
+			cors:       &CORSPolicy{AllowOrigins: []string{"*"}},
+			errMatcher: gomega.Succeed(),
+		},
+		"InvalidOrigin": {
+			cors:       &CORSPolicy{AllowOrigins: []string{"app.example.com"}},
+			errMatcher: gomega.HaveOccurred(),
+		},
+		"UnsupportedMethod": {
+			cors:       &CORSPolicy{AllowOrigins: []string{"*"}, AllowMethods: []string{"CONNECT"}},
+			errMatcher: gomega.HaveOccurred(),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			isvc := makeTestInferenceService()
+			isvc.Spec.CORS = scenario.cors
+			_, err := isvc.ValidateCreate()
+			g.Expect(err).Should(scenario.errMatcher)
+		})
+	}
+}
+
 func TestValidateCollocationStorageURI(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	scenarios := map[string]struct {
@@ -489,3 +708,134 @@ func TestValidateCollocationStorageURI(t *testing.T) {
 	}
 
 }
+
+func TestInferenceServiceWarnings(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		update   func(isvc *InferenceService)
+		expected []string
+	}{
+		"DeprecatedPredictor": {
+			update: func(isvc *InferenceService) {
+				isvc.Spec.Predictor.Model = nil
+				isvc.Spec.Predictor.SKLearn = &SKLearnSpec{
+					PredictorExtensionSpec: PredictorExtensionSpec{StorageURI: proto.String("gs://testbucket/testmodel")},
+				}
+			},
+			expected: []string{"spec.predictor.sklearn is deprecated, use spec.predictor.model with modelFormat.name sklearn instead"},
+		},
+		"DeprecatedServiceAccount": {
+			update: func(isvc *InferenceService) {
+				isvc.Spec.Predictor.DeprecatedServiceAccount = "sa"
+			},
+			expected: []string{"spec.predictor.serviceAccount is deprecated, use spec.predictor.serviceAccountName instead"},
+		},
+		"RawDeploymentZeroMinReplicas": {
+			update: func(isvc *InferenceService) {
+				isvc.Annotations = map[string]string{constants.DeploymentMode: string(constants.RawDeployment)}
+				isvc.Spec.Predictor.MinReplicas = GetIntReference(0)
+			},
+			expected: []string{"spec.predictor.minReplicas 0 is raised to 1 in RawDeployment mode since the HPA can not " +
+				"scale to zero, set the serving.kserve.io/autoscalerClass annotation to external and use an external " +
+				"autoscaler such as KEDA to scale to zero"},
+		},
+		"RawDeploymentZeroMinReplicasWithExternalAutoscaler": {
+			update: func(isvc *InferenceService) {
+				isvc.Annotations = map[string]string{
+					constants.DeploymentMode:  string(constants.RawDeployment),
+					constants.AutoscalerClass: string(constants.AutoscalerClassExternal),
+				}
+				isvc.Spec.Predictor.MinReplicas = GetIntReference(0)
+			},
+		},
+		"ServerlessZeroMinReplicas": {
+			update: func(isvc *InferenceService) {
+				isvc.Spec.Predictor.MinReplicas = GetIntReference(0)
+			},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			isvc := makeTestInferenceService()
+			scenario.update(&isvc)
+			warnings, err := isvc.ValidateCreate()
+			g.Expect(err).Should(gomega.Succeed())
+			if scenario.expected == nil {
+				g.Expect(warnings).Should(gomega.BeEmpty())
+			} else {
+				g.Expect([]string(warnings)).Should(gomega.Equal(scenario.expected))
+			}
+		})
+	}
+}
+
+func TestValidateUpdateRouteAnnotations(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	unsupported := constants.OpenshiftRouterAnnotationPrefix + "hsts_header"
+	scenarios := map[string]struct {
+		old        map[string]string
+		updated    map[string]string
+		deleting   bool
+		errMatcher gomega.OmegaMatcher
+	}{
+		"UnchangedUnsupportedAnnotation": {
+			old:        map[string]string{unsupported: "max-age=31536000"},
+			updated:    map[string]string{unsupported: "max-age=31536000", "foo": "bar"},
+			errMatcher: gomega.Succeed(),
+		},
+		"AddedUnsupportedAnnotation": {
+			old:        map[string]string{},
+			updated:    map[string]string{unsupported: "max-age=31536000"},
+			errMatcher: gomega.HaveOccurred(),
+		},
+		"ChangedInvalidAnnotation": {
+			old:        map[string]string{constants.RouteTimeoutAnnotationKey: "5m"},
+			updated:    map[string]string{constants.RouteTimeoutAnnotationKey: "5 minutes"},
+			errMatcher: gomega.HaveOccurred(),
+		},
+		"BeingDeleted": {
+			old:        map[string]string{},
+			updated:    map[string]string{unsupported: "max-age=31536000"},
+			deleting:   true,
+			errMatcher: gomega.Succeed(),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			old := makeTestInferenceService()
+			old.Annotations = scenario.old
+			isvc := makeTestInferenceService()
+			isvc.Annotations = scenario.updated
+			if scenario.deleting {
+				isvc.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			}
+			_, err := isvc.ValidateUpdate(&old)
+			g.Expect(err).Should(scenario.errMatcher)
+		})
+	}
+}
+
+func TestValidateServingPriority(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	SetMaxServingPriority(100)
+	defer SetMaxServingPriority(0)
+
+	isvc := makeTestInferenceService()
+	isvc.Spec.ServingPriority = proto.Int32(100)
+	_, err := isvc.ValidateCreate()
+	g.Expect(err).Should(gomega.Succeed())
+	isvc.Spec.ServingPriority = proto.Int32(101)
+	_, err = isvc.ValidateCreate()
+	g.Expect(err).Should(gomega.HaveOccurred())
+
+	// the servingPriority set before the max was lowered is only rejected when it is changed
+	old := makeTestInferenceService()
+	old.Spec.ServingPriority = proto.Int32(500)
+	updated := old.DeepCopy()
+	updated.Labels = map[string]string{"team": "a"}
+	_, err = updated.ValidateUpdate(&old)
+	g.Expect(err).Should(gomega.Succeed())
+	updated.Spec.ServingPriority = proto.Int32(400)
+	_, err = updated.ValidateUpdate(&old)
+	g.Expect(err).Should(gomega.HaveOccurred())
+}
@@ -30,11 +30,18 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ActiveHours":                 schema_pkg_apis_serving_v1alpha1_ActiveHours(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ActiveWindow":                schema_pkg_apis_serving_v1alpha1_ActiveWindow(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.AggregationSpec":             schema_pkg_apis_serving_v1alpha1_AggregationSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.BuiltInAdapter":              schema_pkg_apis_serving_v1alpha1_BuiltInAdapter(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.CORSPolicy":                  schema_pkg_apis_serving_v1alpha1_CORSPolicy(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.CircuitBreakerPolicy":        schema_pkg_apis_serving_v1alpha1_CircuitBreakerPolicy(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ClusterServingRuntime":       schema_pkg_apis_serving_v1alpha1_ClusterServingRuntime(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ClusterServingRuntimeList":   schema_pkg_apis_serving_v1alpha1_ClusterServingRuntimeList(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ClusterStorageContainer":     schema_pkg_apis_serving_v1alpha1_ClusterStorageContainer(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ClusterStorageContainerList": schema_pkg_apis_serving_v1alpha1_ClusterStorageContainerList(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.EffectiveConfig":             schema_pkg_apis_serving_v1alpha1_EffectiveConfig(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.Endpoint":                    schema_pkg_apis_serving_v1alpha1_Endpoint(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceGraph":              schema_pkg_apis_serving_v1alpha1_InferenceGraph(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceGraphList":          schema_pkg_apis_serving_v1alpha1_InferenceGraphList(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceGraphSpec":          schema_pkg_apis_serving_v1alpha1_InferenceGraphSpec(ref),
@@ -42,12 +49,30 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceRouter":             schema_pkg_apis_serving_v1alpha1_InferenceRouter(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceStep":               schema_pkg_apis_serving_v1alpha1_InferenceStep(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceTarget":             schema_pkg_apis_serving_v1alpha1_InferenceTarget(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.LoadSheddingPolicy":          schema_pkg_apis_serving_v1alpha1_LoadSheddingPolicy(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.MapRouterSpec":               schema_pkg_apis_serving_v1alpha1_MapRouterSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ModelSpec":                   schema_pkg_apis_serving_v1alpha1_ModelSpec(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.NodePlugin":                  schema_pkg_apis_serving_v1alpha1_NodePlugin(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ProtocolTranslation":         schema_pkg_apis_serving_v1alpha1_ProtocolTranslation(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.QuotaSpec":                   schema_pkg_apis_serving_v1alpha1_QuotaSpec(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.RouterPluginSource":          schema_pkg_apis_serving_v1alpha1_RouterPluginSource(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.RouterRevisionStatus":        schema_pkg_apis_serving_v1alpha1_RouterRevisionStatus(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.RouterSecurityContext":       schema_pkg_apis_serving_v1alpha1_RouterSecurityContext(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.RouterTrafficTarget":         schema_pkg_apis_serving_v1alpha1_RouterTrafficTarget(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ServingProfile":              schema_pkg_apis_serving_v1alpha1_ServingProfile(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ServingProfileList":          schema_pkg_apis_serving_v1alpha1_ServingProfileList(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ServingProfileSize":          schema_pkg_apis_serving_v1alpha1_ServingProfileSize(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ServingProfileSpec":          schema_pkg_apis_serving_v1alpha1_ServingProfileSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ServingRuntime":              schema_pkg_apis_serving_v1alpha1_ServingRuntime(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ServingRuntimeList":          schema_pkg_apis_serving_v1alpha1_ServingRuntimeList(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ServingRuntimePodSpec":       schema_pkg_apis_serving_v1alpha1_ServingRuntimePodSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ServingRuntimeSpec":          schema_pkg_apis_serving_v1alpha1_ServingRuntimeSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ServingRuntimeStatus":        schema_pkg_apis_serving_v1alpha1_ServingRuntimeStatus(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.SidecarLoggingSpec":          schema_pkg_apis_serving_v1alpha1_SidecarLoggingSpec(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.SmokeTestAssertion":          schema_pkg_apis_serving_v1alpha1_SmokeTestAssertion(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.SmokeTestSpec":               schema_pkg_apis_serving_v1alpha1_SmokeTestSpec(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.SmokeTestStatus":             schema_pkg_apis_serving_v1alpha1_SmokeTestStatus(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.StepHeader":                  schema_pkg_apis_serving_v1alpha1_StepHeader(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.StorageContainerSpec":        schema_pkg_apis_serving_v1alpha1_StorageContainerSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.StorageHelper":               schema_pkg_apis_serving_v1alpha1_StorageHelper(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.SupportedModelFormat":        schema_pkg_apis_serving_v1alpha1_SupportedModelFormat(ref),
@@ -56,19 +81,31 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.TrainedModelList":            schema_pkg_apis_serving_v1alpha1_TrainedModelList(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.TrainedModelSpec":            schema_pkg_apis_serving_v1alpha1_TrainedModelSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ARTExplainerSpec":             schema_pkg_apis_serving_v1beta1_ARTExplainerSpec(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ActiveHours":                  schema_pkg_apis_serving_v1beta1_ActiveHours(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ActiveWindow":                 schema_pkg_apis_serving_v1beta1_ActiveWindow(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.AgentMiddleware":              schema_pkg_apis_serving_v1beta1_AgentMiddleware(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.Batcher":                      schema_pkg_apis_serving_v1beta1_Batcher(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.BenchmarkSpec":                schema_pkg_apis_serving_v1beta1_BenchmarkSpec(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.BenchmarkStatus":              schema_pkg_apis_serving_v1beta1_BenchmarkStatus(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.CORSPolicy":                   schema_pkg_apis_serving_v1beta1_CORSPolicy(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ComponentExtensionSpec":       schema_pkg_apis_serving_v1beta1_ComponentExtensionSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ComponentStatusSpec":          schema_pkg_apis_serving_v1beta1_ComponentStatusSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.CustomExplainer":              schema_pkg_apis_serving_v1beta1_CustomExplainer(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.CustomPredictor":              schema_pkg_apis_serving_v1beta1_CustomPredictor(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.CustomTransformer":            schema_pkg_apis_serving_v1beta1_CustomTransformer(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.DeployConfig":                 schema_pkg_apis_serving_v1beta1_DeployConfig(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.EffectiveConfig":              schema_pkg_apis_serving_v1beta1_EffectiveConfig(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.Endpoint":                     schema_pkg_apis_serving_v1beta1_Endpoint(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ExplainerConfig":              schema_pkg_apis_serving_v1beta1_ExplainerConfig(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ExplainerExtensionSpec":       schema_pkg_apis_serving_v1beta1_ExplainerExtensionSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ExplainerSpec":                schema_pkg_apis_serving_v1beta1_ExplainerSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ExplainersConfig":             schema_pkg_apis_serving_v1beta1_ExplainersConfig(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.FailureInfo":                  schema_pkg_apis_serving_v1beta1_FailureInfo(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.GuardrailHook":                schema_pkg_apis_serving_v1beta1_GuardrailHook(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.GuardrailSpec":                schema_pkg_apis_serving_v1beta1_GuardrailSpec(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.HeaderTransform":              schema_pkg_apis_serving_v1beta1_HeaderTransform(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.HuggingFaceRuntimeSpec":       schema_pkg_apis_serving_v1beta1_HuggingFaceRuntimeSpec(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ImageStatus":                  schema_pkg_apis_serving_v1beta1_ImageStatus(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.InferenceService":             schema_pkg_apis_serving_v1beta1_InferenceService(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.InferenceServiceList":         schema_pkg_apis_serving_v1beta1_InferenceServiceList(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.InferenceServiceSpec":         schema_pkg_apis_serving_v1beta1_InferenceServiceSpec(ref),
@@ -77,6 +114,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.IngressConfig":                schema_pkg_apis_serving_v1beta1_IngressConfig(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.LightGBMSpec":                 schema_pkg_apis_serving_v1beta1_LightGBMSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.LoggerSpec":                   schema_pkg_apis_serving_v1beta1_LoggerSpec(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelConversionSpec":          schema_pkg_apis_serving_v1beta1_ModelConversionSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelCopies":                  schema_pkg_apis_serving_v1beta1_ModelCopies(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelFormat":                  schema_pkg_apis_serving_v1beta1_ModelFormat(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelRevisionStates":          schema_pkg_apis_serving_v1beta1_ModelRevisionStates(ref),
@@ -88,16 +126,118 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.PodSpec":                      schema_pkg_apis_serving_v1beta1_PodSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.PredictorExtensionSpec":       schema_pkg_apis_serving_v1beta1_PredictorExtensionSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.PredictorSpec":                schema_pkg_apis_serving_v1beta1_PredictorSpec(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.QuotaSpec":                    schema_pkg_apis_serving_v1beta1_QuotaSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.SKLearnSpec":                  schema_pkg_apis_serving_v1beta1_SKLearnSpec(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.SidecarLoggingSpec":           schema_pkg_apis_serving_v1beta1_SidecarLoggingSpec(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.SidecarSecurityContext":       schema_pkg_apis_serving_v1beta1_SidecarSecurityContext(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.StorageSpec":                  schema_pkg_apis_serving_v1beta1_StorageSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.TFServingSpec":                schema_pkg_apis_serving_v1beta1_TFServingSpec(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.TokenExchange":                schema_pkg_apis_serving_v1beta1_TokenExchange(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.TokenReview":                  schema_pkg_apis_serving_v1beta1_TokenReview(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.TokenUsageSpec":               schema_pkg_apis_serving_v1beta1_TokenUsageSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.TorchServeSpec":               schema_pkg_apis_serving_v1beta1_TorchServeSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.TransformerSpec":              schema_pkg_apis_serving_v1beta1_TransformerSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.TritonSpec":                   schema_pkg_apis_serving_v1beta1_TritonSpec(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.UnauthenticatedPath":          schema_pkg_apis_serving_v1beta1_UnauthenticatedPath(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ValidationSpec":               schema_pkg_apis_serving_v1beta1_ValidationSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.XGBoostSpec":                  schema_pkg_apis_serving_v1beta1_XGBoostSpec(ref),
 	}
 }
 
+func schema_pkg_apis_serving_v1alpha1_ActiveHours(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ActiveHours specifies the windows during which the router runs",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"windows": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Windows during which the router runs, at least one of them must be open",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ActiveWindow"),
+									},
+								},
+							},
+						},
+					},
+					"timeZone": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IANA time zone the schedules are evaluated in, e.g. Europe/Paris, defaults to UTC",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"windows"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ActiveWindow"},
+	}
+}
+
+func schema_pkg_apis_serving_v1alpha1_ActiveWindow(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ActiveWindow is a window opened at the times of a cron schedule for a duration",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"schedule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cron expression of five fields, minute hour day-of-month month day-of-week, the window opens at, e.g. \"0 8 * * 1-5\" for 8am on weekdays",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"duration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "How long the window stays open, e.g. 10h",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+				Required: []string{"schedule", "duration"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_pkg_apis_serving_v1alpha1_AggregationSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AggregationSpec configures how an Ensemble node combines the predictions of its steps. The unsuccessful responses of the soft dependencies are left out of the aggregation.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"format": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Protocol of the responses of the steps and of the node, defaults to `v1`. The predictions are read from the predictions field of V1 responses, and from the first dimension of the single output tensor of V2 responses.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"template": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Go template rendering the response of the node, required by the Template aggregation. The responses of the steps are available as .steps.<step name>, or by step index for unnamed steps, and the json function encodes a value as JSON, e.g. `{\"predictions\": {{ json .steps.classifier.predictions }}}`. The rendered response must be JSON.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_serving_v1alpha1_BuiltInAdapter(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -154,6 +294,102 @@ func schema_pkg_apis_serving_v1alpha1_BuiltInAdapter(ref common.ReferenceCallbac
 	}
 }
 
+func schema_pkg_apis_serving_v1alpha1_CORSPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CORSPolicy specifies the cross-origin requests the browsers are allowed to send",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"allowOrigins": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Origins allowed to send requests, e.g. https://app.example.com, \"*\" allows any origin",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"allowMethods": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Methods allowed, they default to GET, HEAD and POST",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"allowHeaders": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Request headers allowed besides the CORS-safelisted ones, e.g. Authorization and Content-Type, \"*\" allows any header",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"maxAge": {
+						SchemaProps: spec.SchemaProps{
+							Description: "How long in seconds the browsers cache the response to a preflight request",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"allowOrigins"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_serving_v1alpha1_CircuitBreakerPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CircuitBreakerPolicy ejects a target service after consecutive failed calls, the calls to the target fail right away with 503 Service Unavailable while it is ejected. Once the ejection time elapses a single call is let through, the target is ejected again when it fails. The calls are counted by each replica of the router independently.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"consecutiveErrors": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of consecutive calls, after their retries, failing with an error, a timeout or a 5xx status which eject the target",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"ejectionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "How long the target is ejected, e.g. 30s. Defaults to 30s.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+				Required: []string{"consecutiveErrors"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
 func schema_pkg_apis_serving_v1alpha1_ClusterServingRuntime(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -344,6 +580,93 @@ func schema_pkg_apis_serving_v1alpha1_ClusterStorageContainerList(ref common.Ref
 	}
 }
 
+func schema_pkg_apis_serving_v1alpha1_EffectiveConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "EffectiveConfig is the resolved global configuration an InferenceGraph was built with, so that it can be told apart from the current content of the inferenceservice-config ConfigMap",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"configMapResourceVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resource version of the inferenceservice-config ConfigMap",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"deploymentMode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resolved deployment mode",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"routerImage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Image of the router",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"autoscalerClass": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resolved autoscaler class",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_serving_v1alpha1_Endpoint(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Endpoint is an address the InferenceGraph is reachable at",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the endpoint, external or cluster-local",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"visibility": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Visibility of the endpoint, External or ClusterLocal",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"protocol": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Protocol served at the endpoint, http or https",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"url": {
+						SchemaProps: spec.SchemaProps{
+							Description: "URL of the endpoint",
+							Ref:         ref("knative.dev/pkg/apis.URL"),
+						},
+					},
+				},
+				Required: []string{"name", "visibility", "protocol", "url"},
+			},
+		},
+		Dependencies: []string{
+			"knative.dev/pkg/apis.URL"},
+	}
+}
+
 func schema_pkg_apis_serving_v1alpha1_InferenceGraph(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -478,33 +801,162 @@ func schema_pkg_apis_serving_v1alpha1_InferenceGraphSpec(ref common.ReferenceCal
 							Ref: ref("k8s.io/api/core/v1.Affinity"),
 						},
 					},
-					"timeout": {
+					"tolerations": {
 						SchemaProps: spec.SchemaProps{
-							Description: "TimeoutSeconds specifies the number of seconds to wait before timing out a request to the component.",
-							Type:        []string{"integer"},
-							Format:      "int64",
+							Description: "If specified, the router pod's tolerations.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Toleration"),
+									},
+								},
+							},
 						},
 					},
-					"minReplicas": {
+					"nodeSelector": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Minimum number of replicas, defaults to 1 but can be set to 0 to enable scale-to-zero.",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "NodeSelector is a selector which must be true for the router pod to fit on a node.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"maxReplicas": {
+					"priorityClassName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Maximum number of replicas for autoscaling.",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "If specified, indicates the priority of the router pod.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"scaleTarget": {
+					"topologySpreadConstraints": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ScaleTarget specifies the integer target value of the metric type the Autoscaler watches for. concurrency and rps targets are supported by Knative Pod Autoscaler (https://knative.dev/docs/serving/autoscaling/autoscaling-targets/).",
-							Type:        []string{"integer"},
-							Format:      "int32",
-						},
+							Description: "TopologySpreadConstraints describes how the router pods ought to spread across topology domains.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.TopologySpreadConstraint"),
+									},
+								},
+							},
+						},
+					},
+					"serviceAccountName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServiceAccountName is the name of the ServiceAccount the router pod runs with, e.g. to pull the router image from a private registry or to use a workload identity. Defaults to the default ServiceAccount of the namespace.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"imagePullSecrets": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ImagePullSecrets are the secrets used to pull the router image, they are merged with the default image pull secrets of the inferenceservice-config ConfigMap.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.LocalObjectReference"),
+									},
+								},
+							},
+						},
+					},
+					"volumes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Volumes added to the router pod, e.g. a custom CA bundle or the files read by the condition templates. The names of the volumes created by the router, router-tls, router-ca-bundle and router-plugins, are reserved.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Volume"),
+									},
+								},
+							},
+						},
+					},
+					"volumeMounts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VolumeMounts of the volumes in the router container, they must mount the volumes of the graph outside of the directories of the router.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.VolumeMount"),
+									},
+								},
+							},
+						},
+					},
+					"routerImage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RouterImage overrides the image of the router ConfigMap entry for this graph, e.g. to canary a router build. It takes precedence over a router image rollout.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"headersToPropagate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HeadersToPropagate lists the headers, or regular expressions matching them, the router forwards from the request of the graph to its steps, e.g. graph specific authorization or tracing headers. They are added to the headers of the router ConfigMap entry, or replace them when the headersPropagationPolicy is Replace.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"headersPropagationPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HeadersPropagationPolicy defines how the headersToPropagate are combined with the headers of the router ConfigMap entry, defaults to Merge.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"timeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeoutSeconds specifies the number of seconds to wait before timing out a request to the component.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"minReplicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Minimum number of replicas, defaults to 1 but can be set to 0 to enable scale-to-zero.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"maxReplicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Maximum number of replicas for autoscaling.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"scaleTarget": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ScaleTarget specifies the integer target value of the metric type the Autoscaler watches for. concurrency and rps targets are supported by Knative Pod Autoscaler (https://knative.dev/docs/serving/autoscaling/autoscaling-targets/).",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
 					},
 					"scaleMetric": {
 						SchemaProps: spec.SchemaProps{
@@ -513,12 +965,94 @@ func schema_pkg_apis_serving_v1alpha1_InferenceGraphSpec(ref common.ReferenceCal
 							Format:      "",
 						},
 					},
+					"deploymentStrategy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The deployment strategy to use to replace existing router pods with new ones. Only applicable for raw deployment mode.",
+							Ref:         ref("k8s.io/api/apps/v1.DeploymentStrategy"),
+						},
+					},
+					"minReadySeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Minimum number of seconds a new router pod should be ready without any of its containers crashing before it is considered available and the rollout proceeds. Only applicable for raw deployment mode.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"canaryTrafficPercent": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CanaryTrafficPercent defines the traffic split percentage between the candidate revision and the last rolled out revision of the router. In Serverless mode the last rolled out Knative revision is pinned. In raw deployment mode the candidate revision runs in a second Deployment behind the Service of the graph, the traffic is then split by the number of replicas of the two Deployments, the last rolled out revision being scaled up within MaxReplicas to deliver the percentage. The candidate revision is rolled out when it is not set or 100.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"smokeTest": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SmokeTest is a golden request sent to the InferenceGraph after each rollout, the InferenceGraph is not marked ready until the response matches the expected status code and assertions.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.SmokeTestSpec"),
+						},
+					},
+					"plugins": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Plugins are the router plugins the nodes of the graph can use to process their requests and responses",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.RouterPluginSource"),
+									},
+								},
+							},
+						},
+					},
+					"activeHours": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ActiveHours restricts the router to cron-based windows, e.g. business hours, it is removed outside the windows and created again when the next window opens.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ActiveHours"),
+						},
+					},
+					"quota": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Quota specifies per-tenant request quotas enforced by the router, e.g. for a graph shared by several teams",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.QuotaSpec"),
+						},
+					},
+					"sidecarLogging": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Log level and format of the router",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.SidecarLoggingSpec"),
+						},
+					},
+					"routerSecurityContext": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Overrides of the security context of the router, e.g. to run it with the supplemental groups required by a storage driver. The overrides must be within the bounds set by the administrator in the security config.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.RouterSecurityContext"),
+						},
+					},
+					"cors": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CORS allows the browsers to call the graph from other origins, the policy is enforced by the router",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.CORSPolicy"),
+						},
+					},
+					"circuitBreaker": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CircuitBreaker ejects the target services of the steps which keep failing, so that the requests to a failing target fail right away instead of queuing up in the router. It applies to the steps without a circuit breaker of their own.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.CircuitBreakerPolicy"),
+						},
+					},
+					"loadShedding": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LoadShedding rejects the requests the router cannot serve with 503 Service Unavailable instead of queuing them",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.LoadSheddingPolicy"),
+						},
+					},
 				},
 				Required: []string{"nodes"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceRouter", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.ResourceRequirements"},
+			"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ActiveHours", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.CORSPolicy", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.CircuitBreakerPolicy", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceRouter", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.LoadSheddingPolicy", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.QuotaSpec", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.RouterPluginSource", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.RouterSecurityContext", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.SidecarLoggingSpec", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.SmokeTestSpec", "k8s.io/api/apps/v1.DeploymentStrategy", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.TopologySpreadConstraint", "k8s.io/api/core/v1.Volume", "k8s.io/api/core/v1.VolumeMount"},
 	}
 }
 
@@ -578,11 +1112,51 @@ func schema_pkg_apis_serving_v1alpha1_InferenceGraphStatus(ref common.ReferenceC
 							Ref:         ref("knative.dev/pkg/apis.URL"),
 						},
 					},
+					"endpoints": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Endpoints lists all the addresses the InferenceGraph is reachable at, per protocol and visibility",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.Endpoint"),
+									},
+								},
+							},
+						},
+					},
+					"effectiveConfig": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Global configuration the InferenceGraph was built with at its last reconciliation",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.EffectiveConfig"),
+						},
+					},
+					"smokeTest": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Result of the smoke test of the latest rollout",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.SmokeTestStatus"),
+						},
+					},
+					"revisions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Revisions of the router and the traffic split between them",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.RouterRevisionStatus"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"knative.dev/pkg/apis.Condition", "knative.dev/pkg/apis.URL"},
+			"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.EffectiveConfig", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.Endpoint", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.RouterRevisionStatus", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.SmokeTestStatus", "knative.dev/pkg/apis.Condition", "knative.dev/pkg/apis.URL"},
 	}
 }
 
@@ -595,7 +1169,7 @@ func schema_pkg_apis_serving_v1alpha1_InferenceRouter(ref common.ReferenceCallba
 				Properties: map[string]spec.Schema{
 					"routerType": {
 						SchemaProps: spec.SchemaProps{
-							Description: "RouterType\n\n- `Sequence:` chain multiple inference steps with input/output from previous step\n\n- `Splitter:` randomly routes to the target service according to the weight\n\n- `Ensemble:` routes the request to multiple models and then merge the responses\n\n- `Switch:` routes the request to one of the steps based on condition",
+							Description: "RouterType\n\n- `Sequence:` chain multiple inference steps with input/output from previous step\n\n- `Splitter:` randomly routes to the target service according to the weight\n\n- `Ensemble:` routes the request to multiple models and then merge the responses\n\n- `Switch:` routes the request to one of the steps based on condition\n\n- `Map:` splits the request into per-item requests to its single step and reassembles the results in order",
 							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
@@ -615,12 +1189,51 @@ func schema_pkg_apis_serving_v1alpha1_InferenceRouter(ref common.ReferenceCallba
 							},
 						},
 					},
+					"responseAggregation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ResponseAggregation defines how the responses of the steps are merged into the response of the node, only applies to Splitter and Ensemble nodes\n\n- `Keyed:` an object keyed by step name, or by step index for unnamed steps. Default for Ensemble nodes\n\n- `Array:` an array of the responses in the order of the steps\n\n- `FirstSuccess:` the first successful response as is. Default for Splitter nodes\n\n- `MajorityVote:` for each instance, the prediction returned by most steps. Only for Ensemble nodes\n\n- `Average:` for each instance, the element-wise average of the numeric predictions. Only for Ensemble nodes\n\n- `Concat:` the predictions of the steps one after the other. Only for Ensemble nodes\n\n- `Template:` the responses of the steps rendered with the template of the aggregation. Only for Ensemble nodes",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"aggregation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Aggregation configures the MajorityVote, Average, Concat and Template response aggregations of an Ensemble node",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.AggregationSpec"),
+						},
+					},
+					"plugins": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Plugins process the request of the node before it is routed to the steps and the response of the node, in order",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.NodePlugin"),
+									},
+								},
+							},
+						},
+					},
+					"map": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Map defines how a Map node splits its request and reassembles the results, only applies to Map nodes",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.MapRouterSpec"),
+						},
+					},
+					"resources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resources of the node when it is deployed separately from the other nodes of the graph. Every node is served by the router of the graph today, whose resources are set with spec.resources, so the resources of a node are rejected.",
+							Ref:         ref("k8s.io/api/core/v1.ResourceRequirements"),
+						},
+					},
 				},
 				Required: []string{"routerType"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceStep"},
+			"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.AggregationSpec", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceStep", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.MapRouterSpec", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.NodePlugin", "k8s.io/api/core/v1.ResourceRequirements"},
 	}
 }
 
@@ -640,128 +1253,881 @@ func schema_pkg_apis_serving_v1alpha1_InferenceStep(ref common.ReferenceCallback
 					},
 					"nodeName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The node name for routing as next step",
+							Description: "The node name for routing as next step",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"serviceName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "named reference for InferenceService",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"serviceNamespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespace of the InferenceService named by serviceName, defaults to the namespace of the InferenceGraph. The InferenceServices of other namespaces can only be targeted when the router config enables cross namespace targets.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"serviceUrl": {
+						SchemaProps: spec.SchemaProps{
+							Description: "InferenceService URL, mutually exclusive with ServiceName",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"data": {
+						SchemaProps: spec.SchemaProps{
+							Description: "request data sent to the next route with input/output from the previous step $request $response.predictions",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"weight": {
+						SchemaProps: spec.SchemaProps{
+							Description: "the weight for split of the traffic, only used for Split Router when weight is specified all the routing targets should be sum to 100",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"condition": {
+						SchemaProps: spec.SchemaProps{
+							Description: "routing based on the condition\n\nIn a Sequence node the step is executed when the condition matches the response of the previous step. The condition can instead match the request of the node with the `$request.` prefix or the response of a previous step of the node with the `$steps.<step name>.` prefix, e.g. `$steps.classifier.predictions.#(label==\"dog\")`.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"expression": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CEL expression routing the requests of a Switch node, the request is forwarded to the first step whose expression or condition matches. The expression can use the JSON body of the request as `body` and its headers, with lower case names, as `headers`, e.g. `body.instances[0].age >= 18 && headers[\"x-tenant\"] == \"gold\"`. Only supported on the steps of a Switch node and cannot be combined with condition.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"onConditionNotMet": {
+						SchemaProps: spec.SchemaProps{
+							Description: "action of a Sequence node when the condition of the step does not match, `Stop` returns the response of the previous step and `Skip` continues with the next step. Defaults to `Stop`.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"dependency": {
+						SchemaProps: spec.SchemaProps{
+							Description: "to decide whether a step is a hard or a soft dependency in the Inference Graph",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"headers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "headers set on the requests to the target service of the step, they override the headers propagated by the router. Only supported on steps with a serviceName or serviceUrl target.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.StepHeader"),
+									},
+								},
+							},
+						},
+					},
+					"removeHeaders": {
+						SchemaProps: spec.SchemaProps{
+							Description: "names of the headers removed from the requests to the target service of the step, applied before the headers are set. Only supported on steps with a serviceName or serviceUrl target.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"translation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "translation of the request of the step to the protocol of its target and of the response back, e.g. to let V1 clients call a V2 or OpenAI target without a dedicated transformer",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ProtocolTranslation"),
+						},
+					},
+					"timeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "timeout of each call to the target service of the step, e.g. `30s` or `5m`, the call fails with a 504 status once it expires. The calls are only bounded by the timeouts of the router when not set. Only supported on steps with a serviceName or serviceUrl target.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"retries": {
+						SchemaProps: spec.SchemaProps{
+							Description: "number of times the call to the target service of the step is retried when it fails with an error, a timeout or a 502, 503 or 504 status. The calls are not retried when not set. Only supported on steps with a serviceName or serviceUrl target.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"retryBackoff": {
+						SchemaProps: spec.SchemaProps{
+							Description: "delay before the first retry of the call to the target service of the step, doubled on each following retry. Defaults to 100ms.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"circuitBreaker": {
+						SchemaProps: spec.SchemaProps{
+							Description: "circuit breaker of the target service of the step, it overrides the circuit breaker of the graph. Only supported on steps with a serviceName or serviceUrl target.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.CircuitBreakerPolicy"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.CircuitBreakerPolicy", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ProtocolTranslation", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.StepHeader", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_pkg_apis_serving_v1alpha1_InferenceTarget(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Exactly one InferenceTarget field must be specified",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"nodeName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The node name for routing as next step",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"serviceName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "named reference for InferenceService",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"serviceNamespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespace of the InferenceService named by serviceName, defaults to the namespace of the InferenceGraph. The InferenceServices of other namespaces can only be targeted when the router config enables cross namespace targets.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"serviceUrl": {
+						SchemaProps: spec.SchemaProps{
+							Description: "InferenceService URL, mutually exclusive with ServiceName",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_serving_v1alpha1_LoadSheddingPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LoadSheddingPolicy specifies the thresholds over which the router rejects the requests with 503 Service Unavailable and a Retry-After header. The thresholds apply to each replica of the router independently.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"maxInFlightRequests": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Maximum number of requests processed concurrently by the router, the requests are unlimited when not set",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"maxInFlightCallsPerTarget": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Maximum number of concurrent calls to a single target service, so that a slow target cannot hold all the requests of the router. The calls over it fail right away. The calls are unlimited when not set.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_serving_v1alpha1_MapRouterSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MapRouterSpec defines how a Map node splits its request into per-item requests to its step and reassembles the results of the items in order",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"format": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Protocol of the request, defaults to `v1`. V1 requests are split along the array of the items field, V2 requests along the first dimension of their input tensors.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"itemsField": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Field of the V1 request holding the array of items, defaults to `instances`. Each item is sent in a copy of the request whose field holds an array of the single item.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resultsField": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Field of the V1 responses holding the result of an item, defaults to `predictions`. A result holding an array of a single element is unwrapped, the results are returned in the same field in the order of the items.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"parallelism": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Maximum number of items sent to the step in parallel, defaults to 8",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"maxItems": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Maximum number of items of a request, requests with more items are rejected. Defaults to 256",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_serving_v1alpha1_ModelSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ModelSpec describes a TrainedModel",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"storageUri": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Storage URI for the model repository",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"framework": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Machine Learning <framework name> The values could be: \"tensorflow\",\"pytorch\",\"sklearn\",\"onnx\",\"xgboost\", \"myawesomeinternalframework\" etc.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"memory": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Maximum memory this model will consume, this field is used to decide if a model server has enough memory to load this model.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+				},
+				Required: []string{"storageUri", "framework", "memory"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
+func schema_pkg_apis_serving_v1alpha1_NodePlugin(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NodePlugin references a router plugin processing the requests and the responses of a node",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the plugin in the plugins of the graph",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"config": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Config passed to the plugin",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_serving_v1alpha1_ProtocolTranslation(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ProtocolTranslation defines the translation of the request of a step from the protocol of the graph to the protocol of the target of the step, the successful responses are translated back",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"from": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Protocol of the request of the step",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"to": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Protocol of the target of the step",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"inputName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the V2 input tensor, defaults to `input-0`",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"datatype": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Datatype of the V2 input tensor, inferred from the instances when not set: `BYTES` for strings, `BOOL` for booleans and `FP32` for numbers",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"model": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Model of the OpenAI requests and of the translated OpenAI and V2 responses",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"from", "to"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_serving_v1alpha1_QuotaSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "QuotaSpec specifies per-tenant request quotas, the requests over the quota of their tenant are rejected with 429 Too Many Requests. The requests are counted over fixed windows of the period by each replica independently. The tenant of a request is the user its bearer token is authenticated as with a TokenReview, e.g. system:serviceaccount:team-a:client, so the service account of the router must be allowed to create tokenreviews. The requests with a token which is not authenticated are rejected with 401 Unauthorized.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"audiences": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Audiences the bearer tokens are reviewed for, the audiences of the API server when not set",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"period": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Period the requests are counted over, e.g. 1m or 24h",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"defaultLimit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of requests per period shared by the tenants without a quota of their own and by the requests without a bearer token. They are unlimited when not set.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"tenants": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of requests per period, by tenant",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: 0,
+										Type:    []string{"integer"},
+										Format:  "int64",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"period"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_pkg_apis_serving_v1alpha1_RouterPluginSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RouterPluginSource defines where the Go plugin of a router plugin is loaded from, exactly one of configMap and image must be specified. The plugin has to be built with the Go version and the kserve module of the router release.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the plugin referenced by the nodes",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"configMap": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConfigMap key holding the plugin shared object in its binaryData",
+							Ref:         ref("k8s.io/api/core/v1.ConfigMapKeySelector"),
+						},
+					},
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Image holding the plugin shared object, it is copied by an init container which runs cp in the image",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path of the plugin shared object in the image, defaults to /plugin.so",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.ConfigMapKeySelector"},
+	}
+}
+
+func schema_pkg_apis_serving_v1alpha1_RouterRevisionStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RouterRevisionStatus describes the revisions of the router of the InferenceGraph. The revisions are the Knative revisions in Serverless mode and the revisions of the router pod spec in raw deployment mode.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"latestReadyRevision": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Latest revision name that is in ready state",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"latestCreatedRevision": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Latest revision name that is created",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"previousRolledoutRevision": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Previous revision name that is rolled out with 100 percent traffic",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"latestRolledoutRevision": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Latest revision name that is rolled out with 100 percent traffic",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"traffic": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Traffic holds the traffic distribution between the latest revision and the previous rolled out revision",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.RouterTrafficTarget"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.RouterTrafficTarget"},
+	}
+}
+
+func schema_pkg_apis_serving_v1alpha1_RouterSecurityContext(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RouterSecurityContext overrides fields of the security context of the router, the defaults of the router are kept for the fields which are not set.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"runAsUser": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The UID to run the router container as",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"runAsGroup": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The GID to run the router container as",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"supplementalGroups": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The supplemental groups of the router pod",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: 0,
+										Type:    []string{"integer"},
+										Format:  "int64",
+									},
+								},
+							},
+						},
+					},
+					"seLinuxOptions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The SELinux context of the router container, only the type and the level can be set",
+							Ref:         ref("k8s.io/api/core/v1.SELinuxOptions"),
+						},
+					},
+					"addCapabilities": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The capabilities added to the router container",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.SELinuxOptions"},
+	}
+}
+
+func schema_pkg_apis_serving_v1alpha1_RouterTrafficTarget(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RouterTrafficTarget is the percentage of the traffic of the InferenceGraph served by a revision of its router",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"revisionName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the revision",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"latestRevision": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Whether the revision is the latest revision of the router",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"percent": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Percentage of the traffic served by the revision",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"tag": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Tag of the revision, prev for the previous rolled out revision",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"revisionName", "percent"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_serving_v1alpha1_ServingProfile(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ServingProfile maps named sizes to the resources, scheduling, autoscaling bounds and probes of a predictor, so that an InferenceService only references the profile and a size.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ServingProfileSpec"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ServingProfileSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_serving_v1alpha1_ServingProfileList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ServingProfileList contains a list of ServingProfile",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ServingProfile"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ServingProfile", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_pkg_apis_serving_v1alpha1_ServingProfileSize(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ServingProfileSize defines the defaults a size applies to the predictor of an InferenceService. Fields set on the predictor are left untouched.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the size",
+							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"serviceName": {
+					"resources": {
 						SchemaProps: spec.SchemaProps{
-							Description: "named reference for InferenceService",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Resources of the predictor container",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/api/core/v1.ResourceRequirements"),
 						},
 					},
-					"serviceUrl": {
+					"nodeSelector": {
 						SchemaProps: spec.SchemaProps{
-							Description: "InferenceService URL, mutually exclusive with ServiceName",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "NodeSelector of the predictor pods",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"data": {
+					"tolerations": {
 						SchemaProps: spec.SchemaProps{
-							Description: "request data sent to the next route with input/output from the previous step $request $response.predictions",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Tolerations of the predictor pods, e.g. for the taints of the GPU nodes",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Toleration"),
+									},
+								},
+							},
 						},
 					},
-					"weight": {
+					"minReplicas": {
 						SchemaProps: spec.SchemaProps{
-							Description: "the weight for split of the traffic, only used for Split Router when weight is specified all the routing targets should be sum to 100",
+							Description: "Minimum number of replicas of the predictor",
 							Type:        []string{"integer"},
-							Format:      "int64",
-						},
-					},
-					"condition": {
-						SchemaProps: spec.SchemaProps{
-							Description: "routing based on the condition",
-							Type:        []string{"string"},
-							Format:      "",
+							Format:      "int32",
 						},
 					},
-					"dependency": {
+					"maxReplicas": {
 						SchemaProps: spec.SchemaProps{
-							Description: "to decide whether a step is a hard or a soft dependency in the Inference Graph",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Maximum number of replicas of the predictor",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-				},
-			},
-		},
-	}
-}
-
-func schema_pkg_apis_serving_v1alpha1_InferenceTarget(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "Exactly one InferenceTarget field must be specified",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"nodeName": {
+					"scaleTarget": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The node name for routing as next step",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "ScaleTarget of the autoscaler of the predictor",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"serviceName": {
+					"readinessProbe": {
 						SchemaProps: spec.SchemaProps{
-							Description: "named reference for InferenceService",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "ReadinessProbe of the predictor container",
+							Ref:         ref("k8s.io/api/core/v1.Probe"),
 						},
 					},
-					"serviceUrl": {
+					"livenessProbe": {
 						SchemaProps: spec.SchemaProps{
-							Description: "InferenceService URL, mutually exclusive with ServiceName",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "LivenessProbe of the predictor container",
+							Ref:         ref("k8s.io/api/core/v1.Probe"),
 						},
 					},
 				},
+				Required: []string{"name"},
 			},
 		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.Probe", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.Toleration"},
 	}
 }
 
-func schema_pkg_apis_serving_v1alpha1_ModelSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_serving_v1alpha1_ServingProfileSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "ModelSpec describes a TrainedModel",
+				Description: "ServingProfileSpec defines the named sizes of a ServingProfile, e.g. small, medium and large.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"storageUri": {
+					"sizes": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
 						SchemaProps: spec.SchemaProps{
-							Description: "Storage URI for the model repository",
-							Default:     "",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Sizes offered by the profile, an InferenceService selects one with the serving.kserve.io/size-class annotation",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ServingProfileSize"),
+									},
+								},
+							},
 						},
 					},
-					"framework": {
+					"defaultSize": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Machine Learning <framework name> The values could be: \"tensorflow\",\"pytorch\",\"sklearn\",\"onnx\",\"xgboost\", \"myawesomeinternalframework\" etc.",
-							Default:     "",
+							Description: "DefaultSize is the size applied when the InferenceService does not select one",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"memory": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Maximum memory this model will consume, this field is used to decide if a model server has enough memory to load this model.",
-							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
-						},
-					},
 				},
-				Required: []string{"storageUri", "framework", "memory"},
+				Required: []string{"sizes"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+			"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ServingProfileSize"},
 	}
 }
 
@@ -1203,42 +2569,232 @@ func schema_pkg_apis_serving_v1alpha1_ServingRuntimeSpec(ref common.ReferenceCal
 							Format:      "",
 						},
 					},
-					"replicas": {
+					"replicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Configure the number of replicas in the Deployment generated by this ServingRuntime If specified, this overrides the podsPerRuntime configuration value",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"storageHelper": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Configuration for this runtime's use of the storage helper (model puller) It is enabled unless explicitly disabled",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.StorageHelper"),
+						},
+					},
+					"builtInAdapter": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Provide the details about built-in runtime adapter",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.BuiltInAdapter"),
+						},
+					},
+				},
+				Required: []string{"containers"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.BuiltInAdapter", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.StorageHelper", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.SupportedModelFormat", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume"},
+	}
+}
+
+func schema_pkg_apis_serving_v1alpha1_ServingRuntimeStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ServingRuntimeStatus defines the observed state of ServingRuntime",
+				Type:        []string{"object"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_serving_v1alpha1_SidecarLoggingSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SidecarLoggingSpec sets the log level and format of the router, the defaults of the router are kept for the fields which are not set.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"level": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Minimum level of the logs written by the router",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"format": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Format of the logs written by the router, one json object per line or human readable text",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_serving_v1alpha1_SmokeTestAssertion(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SmokeTestAssertion checks the result of a JSONPath expression on the response, e.g. {.predictions[0]}",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"jsonPath": {
+						SchemaProps: spec.SchemaProps{
+							Description: "JSONPath expression evaluated on the response",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"value": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Expected result of the expression, when not set the expression only needs to find a value",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"jsonPath"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_serving_v1alpha1_SmokeTestSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SmokeTestSpec defines a golden request sent to the InferenceGraph and the expectations on its response",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path of the request relative to the URL of the InferenceGraph, defaults to /",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"request": {
+						SchemaProps: spec.SchemaProps{
+							Description: "JSON body of the request, it is sent with the POST method",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"expectedStatusCode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Expected HTTP status code of the response, defaults to 200",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"assertions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Assertions on the JSON body of the response",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.SmokeTestAssertion"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"request"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.SmokeTestAssertion"},
+	}
+}
+
+func schema_pkg_apis_serving_v1alpha1_SmokeTestStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SmokeTestStatus is the result of the smoke test of a generation of the InferenceGraph",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Generation of the InferenceGraph the smoke test was run against",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"succeeded": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Configure the number of replicas in the Deployment generated by this ServingRuntime If specified, this overrides the podsPerRuntime configuration value",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "Whether the response matched the expected status code and assertions",
+							Default:     false,
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"storageHelper": {
+					"message": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Configuration for this runtime's use of the storage helper (model puller) It is enabled unless explicitly disabled",
-							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.StorageHelper"),
+							Description: "Reason of the failure of the smoke test",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"builtInAdapter": {
+					"lastRunTime": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Provide the details about built-in runtime adapter",
-							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.BuiltInAdapter"),
+							Description: "Time of the last run of the smoke test",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
 				},
-				Required: []string{"containers"},
+				Required: []string{"observedGeneration", "succeeded"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.BuiltInAdapter", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.StorageHelper", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.SupportedModelFormat", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
-func schema_pkg_apis_serving_v1alpha1_ServingRuntimeStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_serving_v1alpha1_StepHeader(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "ServingRuntimeStatus defines the observed state of ServingRuntime",
+				Description: "StepHeader defines a header set by the router on the requests to the target of a step, exactly one of value and secretKeyRef must be specified",
 				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the header",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"value": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Value of the header, `$(VAR_NAME)` references are expanded with the environment variables of the router",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"secretKeyRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Selects a key of a Secret in the namespace of the InferenceGraph as the value of the header, e.g. to pass an API key of the target service",
+							Ref:         ref("k8s.io/api/core/v1.SecretKeySelector"),
+						},
+					},
+				},
+				Required: []string{"name"},
 			},
 		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.SecretKeySelector"},
 	}
 }
 
@@ -1797,50 +3353,369 @@ func schema_pkg_apis_serving_v1beta1_ARTExplainerSpec(ref common.ReferenceCallba
 							Format:      "",
 						},
 					},
-					"storage": {
+					"storage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Storage Spec for model location",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.StorageSpec"),
+						},
+					},
+				},
+				Required: []string{"type", "name"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.StorageSpec", "k8s.io/api/core/v1.ContainerPort", "k8s.io/api/core/v1.ContainerResizePolicy", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.Lifecycle", "k8s.io/api/core/v1.Probe", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.SecurityContext", "k8s.io/api/core/v1.VolumeDevice", "k8s.io/api/core/v1.VolumeMount"},
+	}
+}
+
+func schema_pkg_apis_serving_v1beta1_ActiveHours(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ActiveHours specifies the windows during which the workloads run",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"windows": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Windows during which the workloads run, at least one of them must be open",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.ActiveWindow"),
+									},
+								},
+							},
+						},
+					},
+					"timeZone": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IANA time zone the schedules are evaluated in, e.g. Europe/Paris, defaults to UTC",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"windows"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ActiveWindow"},
+	}
+}
+
+func schema_pkg_apis_serving_v1beta1_ActiveWindow(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ActiveWindow is a window opened at the times of a cron schedule for a duration",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"schedule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cron expression of five fields, minute hour day-of-month month day-of-week, the window opens at, e.g. \"0 8 * * 1-5\" for 8am on weekdays",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"duration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "How long the window stays open, e.g. 10h",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+				Required: []string{"schedule", "duration"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_pkg_apis_serving_v1beta1_AgentMiddleware(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AgentMiddleware specifies the middleware the agent applies to the requests proxied to the component, it allows common interoperability patterns without a custom transformer",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"requestHeaders": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Transformation of the headers of the requests before they are sent to the component",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.HeaderTransform"),
+						},
+					},
+					"responseHeaders": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Transformation of the headers of the responses of the component",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.HeaderTransform"),
+						},
+					},
+					"tokenExchange": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Exchange of the bearer token of the requests for a token accepted by the component",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.TokenExchange"),
+						},
+					},
+					"tokenReview": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Validation of the bearer token of the requests by the Kubernetes API server, it is done before the token is exchanged",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.TokenReview"),
+						},
+					},
+					"quota": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Per-tenant quotas of the requests, they are enforced before the bearer token of the requests is exchanged",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.QuotaSpec"),
+						},
+					},
+					"guardrail": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HTTP callouts moderating the requests and the responses of the component, e.g. a content moderation service in front of an LLM",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.GuardrailSpec"),
+						},
+					},
+					"tokenUsage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Accounting of the tokens reported in the usage of the OpenAI protocol responses of the component",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.TokenUsageSpec"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.GuardrailSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.HeaderTransform", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.QuotaSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.TokenExchange", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.TokenReview", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.TokenUsageSpec"},
+	}
+}
+
+func schema_pkg_apis_serving_v1beta1_Batcher(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Batcher specifies optional payload batching available for all components",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"maxBatchSize": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Specifies the max number of requests to trigger a batch",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"maxLatency": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Specifies the max latency to trigger a batch",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"timeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Specifies the timeout of a batch",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_serving_v1beta1_BenchmarkSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BenchmarkSpec specifies the load test run against a new revision of the predictor",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"tool": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Load generator, vegeta for HTTP or ghz for gRPC, defaults to vegeta",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"rps": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Rate of the requests per second",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"durationSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Duration of the load test in seconds",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"payloadConfigMap": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the ConfigMap, in the namespace of the InferenceService, holding the body of the requests under the \"payload\" key, a JSON message of the gRPC method for ghz",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"call": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Fully qualified gRPC method called by ghz, defaults to inference.GRPCInferenceService/ModelInfer",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"rps", "durationSeconds", "payloadConfigMap"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_serving_v1beta1_BenchmarkStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BenchmarkStatus summarizes the benchmark of a predictor revision",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"revision": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Predictor revision the benchmark ran against",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"jobName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the benchmark Job",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"state": {
+						SchemaProps: spec.SchemaProps{
+							Description: "State of the benchmark",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"p50Latency": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Median latency of the requests, e.g. 12.5ms",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"p95Latency": {
+						SchemaProps: spec.SchemaProps{
+							Description: "95th percentile latency of the requests",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"throughput": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Successful requests per second",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"successRatio": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Ratio of the successful requests, between 0 and 1",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reason the results are missing when the benchmark failed",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"completionTime": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Storage Spec for model location",
-							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.StorageSpec"),
+							Description: "Time the benchmark Job completed",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
 				},
-				Required: []string{"type", "name"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.StorageSpec", "k8s.io/api/core/v1.ContainerPort", "k8s.io/api/core/v1.ContainerResizePolicy", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.Lifecycle", "k8s.io/api/core/v1.Probe", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.SecurityContext", "k8s.io/api/core/v1.VolumeDevice", "k8s.io/api/core/v1.VolumeMount"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
-func schema_pkg_apis_serving_v1beta1_Batcher(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_serving_v1beta1_CORSPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "Batcher specifies optional payload batching available for all components",
+				Description: "CORSPolicy specifies the cross-origin requests the browsers are allowed to send",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"maxBatchSize": {
+					"allowOrigins": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Specifies the max number of requests to trigger a batch",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "Origins allowed to send requests, e.g. https://app.example.com, \"*\" allows any origin",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"maxLatency": {
+					"allowMethods": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Specifies the max latency to trigger a batch",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "Methods allowed, they default to GET, HEAD and POST",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"timeout": {
+					"allowHeaders": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Specifies the timeout of a batch",
+							Description: "Request headers allowed besides the CORS-safelisted ones, e.g. Authorization and Content-Type, \"*\" allows any header",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"maxAge": {
+						SchemaProps: spec.SchemaProps{
+							Description: "How long in seconds the browsers cache the response to a preflight request",
 							Type:        []string{"integer"},
 							Format:      "int32",
 						},
 					},
 				},
+				Required: []string{"allowOrigins"},
 			},
 		},
 	}
@@ -1914,6 +3789,24 @@ func schema_pkg_apis_serving_v1beta1_ComponentExtensionSpec(ref common.Reference
 							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.Batcher"),
 						},
 					},
+					"middleware": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Middleware the agent applies to the requests proxied to the component",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.AgentMiddleware"),
+						},
+					},
+					"sidecarLogging": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Log level and format of the containers injected by KServe in the component pods, the agent and the storage initializer.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.SidecarLoggingSpec"),
+						},
+					},
+					"agentSecurityContext": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Overrides of the security context of the agent container, e.g. to run it with the SELinux type required by a storage driver. The overrides must be within the bounds set by the administrator in the security config.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.SidecarSecurityContext"),
+						},
+					},
 					"labels": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Labels that will be add to the component pod. More info: http://kubernetes.io/docs/user-guide/labels",
@@ -1952,11 +3845,18 @@ func schema_pkg_apis_serving_v1beta1_ComponentExtensionSpec(ref common.Reference
 							Ref:         ref("k8s.io/api/apps/v1.DeploymentStrategy"),
 						},
 					},
+					"minReadySeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Minimum number of seconds a new pod should be ready without any of its containers crashing before it is considered available and the rollout proceeds. Only applicable for raw deployment mode.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.Batcher", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.LoggerSpec", "k8s.io/api/apps/v1.DeploymentStrategy"},
+			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.AgentMiddleware", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.Batcher", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.LoggerSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.SidecarLoggingSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.SidecarSecurityContext", "k8s.io/api/apps/v1.DeploymentStrategy"},
 	}
 }
 
@@ -2033,11 +3933,48 @@ func schema_pkg_apis_serving_v1beta1_ComponentStatusSpec(ref common.ReferenceCal
 							Ref:         ref("knative.dev/pkg/apis/duck/v1.Addressable"),
 						},
 					},
+					"externalAddresses": {
+						SchemaProps: spec.SchemaProps{
+							Description: "External IP addresses or hostnames of the load balancer, when the Service of a raw deployment is exposed with the LoadBalancer type.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"images": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"container",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Images run by the containers of the latest pod of the component, including the containers injected by KServe, with the digests they were resolved to.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.ImageStatus"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"knative.dev/pkg/apis.URL", "knative.dev/pkg/apis/duck/v1.Addressable", "knative.dev/serving/pkg/apis/serving/v1.TrafficTarget"},
+			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ImageStatus", "knative.dev/pkg/apis.URL", "knative.dev/pkg/apis/duck/v1.Addressable", "knative.dev/serving/pkg/apis/serving/v1.TrafficTarget"},
 	}
 }
 
@@ -3409,6 +5346,100 @@ func schema_pkg_apis_serving_v1beta1_DeployConfig(ref common.ReferenceCallback)
 	}
 }
 
+func schema_pkg_apis_serving_v1beta1_EffectiveConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "EffectiveConfig is the resolved global configuration an InferenceService was built with, so that it can be told apart from the current content of the inferenceservice-config ConfigMap",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"configMapResourceVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resource version of the inferenceservice-config ConfigMap",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"deploymentMode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resolved deployment mode",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"ingressClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Ingress class of the generated ingress, only set in raw deployment mode",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"autoscalerClass": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resolved autoscaler class",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_serving_v1beta1_Endpoint(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Endpoint is an address the InferenceService is reachable at",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the endpoint, one of external, cluster-local, grpc, grpc-cluster-local, openai or openai-cluster-local",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"visibility": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Visibility of the endpoint, External or ClusterLocal",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"protocol": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Protocol served at the endpoint, one of http, https or grpc",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"url": {
+						SchemaProps: spec.SchemaProps{
+							Description: "URL of the endpoint including the base path of the API served at it, it is set for the http and https endpoints",
+							Ref:         ref("knative.dev/pkg/apis.URL"),
+						},
+					},
+					"target": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Target is the host:port to dial, it is set for the grpc endpoints",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name", "visibility", "protocol"},
+			},
+		},
+		Dependencies: []string{
+			"knative.dev/pkg/apis.URL"},
+	}
+}
+
 func schema_pkg_apis_serving_v1beta1_ExplainerConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -4254,6 +6285,24 @@ func schema_pkg_apis_serving_v1beta1_ExplainerSpec(ref common.ReferenceCallback)
 							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.Batcher"),
 						},
 					},
+					"middleware": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Middleware the agent applies to the requests proxied to the component",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.AgentMiddleware"),
+						},
+					},
+					"sidecarLogging": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Log level and format of the containers injected by KServe in the component pods, the agent and the storage initializer.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.SidecarLoggingSpec"),
+						},
+					},
+					"agentSecurityContext": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Overrides of the security context of the agent container, e.g. to run it with the SELinux type required by a storage driver. The overrides must be within the bounds set by the administrator in the security config.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.SidecarSecurityContext"),
+						},
+					},
 					"labels": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Labels that will be add to the component pod. More info: http://kubernetes.io/docs/user-guide/labels",
@@ -4292,11 +6341,18 @@ func schema_pkg_apis_serving_v1beta1_ExplainerSpec(ref common.ReferenceCallback)
 							Ref:         ref("k8s.io/api/apps/v1.DeploymentStrategy"),
 						},
 					},
+					"minReadySeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Minimum number of seconds a new pod should be ready without any of its containers crashing before it is considered available and the rollout proceeds. Only applicable for raw deployment mode.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ARTExplainerSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.Batcher", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.LoggerSpec", "k8s.io/api/apps/v1.DeploymentStrategy", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EphemeralContainer", "k8s.io/api/core/v1.HostAlias", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodOS", "k8s.io/api/core/v1.PodReadinessGate", "k8s.io/api/core/v1.PodResourceClaim", "k8s.io/api/core/v1.PodSchedulingGate", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.TopologySpreadConstraint", "k8s.io/api/core/v1.Volume", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
+			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ARTExplainerSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.AgentMiddleware", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.Batcher", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.LoggerSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.SidecarLoggingSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.SidecarSecurityContext", "k8s.io/api/apps/v1.DeploymentStrategy", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EphemeralContainer", "k8s.io/api/core/v1.HostAlias", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodOS", "k8s.io/api/core/v1.PodReadinessGate", "k8s.io/api/core/v1.PodResourceClaim", "k8s.io/api/core/v1.PodSchedulingGate", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.TopologySpreadConstraint", "k8s.io/api/core/v1.Volume", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
 	}
 }
 
@@ -4349,29 +6405,152 @@ func schema_pkg_apis_serving_v1beta1_FailureInfo(ref common.ReferenceCallback) c
 					},
 					"modelRevisionName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Internal Revision/ID of model, tied to specific Spec contents",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Internal Revision/ID of model, tied to specific Spec contents",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"time": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Time failure occurred or was discovered",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"exitCode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Exit status from the last termination of the container",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_serving_v1beta1_GuardrailHook(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "GuardrailHook specifies an HTTP callout the body of the requests or of the responses is posted to. A 2xx status of the hook allows the request, a 4xx status rejects it and the status and body of the hook are returned to the client, any other outcome is handled according to the failure policy.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"url": {
+						SchemaProps: spec.SchemaProps{
+							Description: "URL of the hook",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"timeoutSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Timeout of the callout in seconds, defaults to 5",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"failurePolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "What happens when the hook can not be called, times out or fails, Fail (default) or Ignore",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"url"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_serving_v1beta1_GuardrailSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "GuardrailSpec specifies the hooks moderating the requests before they are sent to the component and the responses before they are returned to the client",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"request": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Hook moderating the requests",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.GuardrailHook"),
+						},
+					},
+					"response": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Hook moderating the successful responses, the responses are buffered until the hook allows them so streamed responses are returned at once",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.GuardrailHook"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.GuardrailHook"},
+	}
+}
+
+func schema_pkg_apis_serving_v1beta1_HeaderTransform(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "HeaderTransform specifies the headers set, added and removed, the headers are removed first",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"set": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Headers replacing the values of the existing headers of the same name",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"time": {
+					"add": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Time failure occurred or was discovered",
-							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+							Description: "Headers appended to the values of the existing headers of the same name",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"exitCode": {
+					"remove": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Exit status from the last termination of the container",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "Names of the headers removed",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
 				},
 			},
 		},
-		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -4674,6 +6853,56 @@ func schema_pkg_apis_serving_v1beta1_HuggingFaceRuntimeSpec(ref common.Reference
 	}
 }
 
+func schema_pkg_apis_serving_v1beta1_ImageStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ImageStatus records the image run by a container and the digest it was resolved to",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"container": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the container",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Image of the container as set in the pod",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"digest": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Digest of the image pulled by the container runtime, e.g. sha256:4d2f...",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"verification": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Result of the verification of the sigstore attestation of the image, it is empty when no attestation public key is configured in the image policy.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reason the verification failed",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"container"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_serving_v1beta1_InferenceService(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -4801,12 +7030,43 @@ func schema_pkg_apis_serving_v1beta1_InferenceServiceSpec(ref common.ReferenceCa
 							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.TransformerSpec"),
 						},
 					},
+					"validation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Validation defines a Job run against every new revision of the predictor, the InferenceService is not marked ready until the Job succeeds.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.ValidationSpec"),
+						},
+					},
+					"benchmark": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Benchmark defines a load test run against every new revision of the predictor once it is ready, its results are reported in the status.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.BenchmarkSpec"),
+						},
+					},
+					"servingPriority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServingPriority ranks the InferenceService when the GPUs are scarce, an InferenceService whose GPU pods cannot be scheduled stops the InferenceServices of lower priority of its namespace when the GPU preemption is enabled. It defaults to the value of the PriorityClass of the predictor, or else to 0. It cannot exceed the maxServingPriority of the GPU preemption config.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"activeHours": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ActiveHours restricts the workloads to cron-based windows, e.g. business hours, they are removed outside the windows and created again when the next window opens.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.ActiveHours"),
+						},
+					},
+					"cors": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CORS allows the browsers to call the endpoints from other origins, the policy is enforced by the agent of the components and mirrored into the Istio VirtualService.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.CORSPolicy"),
+						},
+					},
 				},
 				Required: []string{"predictor"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ExplainerSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.PredictorSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.TransformerSpec"},
+			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ActiveHours", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.BenchmarkSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.CORSPolicy", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.ExplainerSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.PredictorSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.TransformerSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.ValidationSpec"},
 	}
 }
 
@@ -4872,6 +7132,28 @@ func schema_pkg_apis_serving_v1beta1_InferenceServiceStatus(ref common.Reference
 							Ref:         ref("knative.dev/pkg/apis.URL"),
 						},
 					},
+					"endpoints": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Endpoints lists all the addresses the InferenceService is reachable at, per protocol and visibility",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.Endpoint"),
+									},
+								},
+							},
+						},
+					},
 					"components": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Statuses for the components of the InferenceService",
@@ -4894,11 +7176,23 @@ func schema_pkg_apis_serving_v1beta1_InferenceServiceStatus(ref common.Reference
 							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelStatus"),
 						},
 					},
+					"effectiveConfig": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Global configuration the InferenceService was built with at its last reconciliation",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.EffectiveConfig"),
+						},
+					},
+					"benchmark": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Results of the benchmark of the latest predictor revision",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.BenchmarkStatus"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ComponentStatusSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelStatus", "knative.dev/pkg/apis.Condition", "knative.dev/pkg/apis.URL", "knative.dev/pkg/apis/duck/v1.Addressable"},
+			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.BenchmarkStatus", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.ComponentStatusSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.EffectiveConfig", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.Endpoint", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelStatus", "knative.dev/pkg/apis.Condition", "knative.dev/pkg/apis.URL", "knative.dev/pkg/apis/duck/v1.Addressable"},
 	}
 }
 
@@ -5336,9 +7630,98 @@ func schema_pkg_apis_serving_v1beta1_LoggerSpec(ref common.ReferenceCallback) co
 							Format:      "",
 						},
 					},
+					"deliveryMode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Specifies what happens to the log events when the sink can not keep up and the logger queue is full. <br /> Valid values are: <br /> - \"bestEffort\" (default): drop the log events, the inference requests are not affected; <br /> - \"backPressure\": reject the inference requests with 503 until the queue has room; <br /> - \"spill\": write the log events to a local volume and send them once the queue has room <br />",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_serving_v1beta1_ModelConversionSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ModelConversionSpec defines the init container converting the model before the model server starts. The container runs after the storage initializer and converts the model in place in the directory set in the MODEL_DIR environment variable, which is the directory the model server loads the model from.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Image of the container running the conversion.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"command": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Entrypoint of the conversion container, the entrypoint of the image is used when it is not set.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"args": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Arguments of the conversion container.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"env": {
+						SchemaProps: spec.SchemaProps{
+							Description: "List of environment variables to set in the conversion container.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvVar"),
+									},
+								},
+							},
+						},
+					},
+					"resources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Compute resources of the conversion container, e.g. a GPU for the quantization.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/api/core/v1.ResourceRequirements"),
+						},
+					},
+					"cachePvcName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of a PersistentVolumeClaim caching the converted artifacts across the pods. When it is set, the CONVERSION_CACHE_DIR environment variable points to a directory of the claim which is unique for the storage uri and the conversion command, the container should reuse the artifacts found there instead of converting the model again.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
+				Required: []string{"image"},
 			},
 		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.ResourceRequirements"},
 	}
 }
 
@@ -7495,6 +9878,12 @@ func schema_pkg_apis_serving_v1beta1_PredictorSpec(ref common.ReferenceCallback)
 							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelSpec"),
 						},
 					},
+					"modelConversion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ModelConversion declares a step converting the model after it is downloaded by the storage initializer, e.g. to safetensors or to a quantized checkpoint, so the converted artifacts do not have to be generated offline.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelConversionSpec"),
+						},
+					},
 					"volumes": {
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
@@ -7990,6 +10379,24 @@ func schema_pkg_apis_serving_v1beta1_PredictorSpec(ref common.ReferenceCallback)
 							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.Batcher"),
 						},
 					},
+					"middleware": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Middleware the agent applies to the requests proxied to the component",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.AgentMiddleware"),
+						},
+					},
+					"sidecarLogging": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Log level and format of the containers injected by KServe in the component pods, the agent and the storage initializer.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.SidecarLoggingSpec"),
+						},
+					},
+					"agentSecurityContext": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Overrides of the security context of the agent container, e.g. to run it with the SELinux type required by a storage driver. The overrides must be within the bounds set by the administrator in the security config.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.SidecarSecurityContext"),
+						},
+					},
 					"labels": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Labels that will be add to the component pod. More info: http://kubernetes.io/docs/user-guide/labels",
@@ -8006,33 +10413,85 @@ func schema_pkg_apis_serving_v1beta1_PredictorSpec(ref common.ReferenceCallback)
 							},
 						},
 					},
-					"annotations": {
+					"annotations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Annotations that will be add to the component pod. More info: http://kubernetes.io/docs/user-guide/annotations",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"deploymentStrategy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The deployment strategy to use to replace existing pods with new ones. Only applicable for raw deployment mode.",
+							Ref:         ref("k8s.io/api/apps/v1.DeploymentStrategy"),
+						},
+					},
+					"minReadySeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Minimum number of seconds a new pod should be ready without any of its containers crashing before it is considered available and the rollout proceeds. Only applicable for raw deployment mode.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.AgentMiddleware", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.Batcher", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.HuggingFaceRuntimeSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.LightGBMSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.LoggerSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelConversionSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.ONNXRuntimeSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.PMMLSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.PaddleServerSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.SKLearnSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.SidecarLoggingSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.SidecarSecurityContext", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.TFServingSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.TorchServeSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.TritonSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.XGBoostSpec", "k8s.io/api/apps/v1.DeploymentStrategy", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EphemeralContainer", "k8s.io/api/core/v1.HostAlias", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodOS", "k8s.io/api/core/v1.PodReadinessGate", "k8s.io/api/core/v1.PodResourceClaim", "k8s.io/api/core/v1.PodSchedulingGate", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.TopologySpreadConstraint", "k8s.io/api/core/v1.Volume", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
+func schema_pkg_apis_serving_v1beta1_QuotaSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "QuotaSpec specifies per-tenant request quotas, the requests over the quota of their tenant are rejected with 429 Too Many Requests. The requests are counted over fixed windows of the period by each replica independently. The tenant of a request is the user its bearer token is authenticated as by the token review of the middleware, e.g. system:serviceaccount:team-a:client, so the quotas require a token review.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"period": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Period the requests are counted over, e.g. 1m or 24h",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"defaultLimit": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Annotations that will be add to the component pod. More info: http://kubernetes.io/docs/user-guide/annotations",
+							Description: "Number of requests per period shared by the tenants without a quota of their own and by the requests of the unauthenticated paths. They are unlimited when not set.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"tenants": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of requests per period, by tenant",
 							Type:        []string{"object"},
 							AdditionalProperties: &spec.SchemaOrBool{
 								Allows: true,
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
+										Default: 0,
+										Type:    []string{"integer"},
+										Format:  "int64",
 									},
 								},
 							},
 						},
 					},
-					"deploymentStrategy": {
-						SchemaProps: spec.SchemaProps{
-							Description: "The deployment strategy to use to replace existing pods with new ones. Only applicable for raw deployment mode.",
-							Ref:         ref("k8s.io/api/apps/v1.DeploymentStrategy"),
-						},
-					},
 				},
+				Required: []string{"period"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.Batcher", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.HuggingFaceRuntimeSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.LightGBMSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.LoggerSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.ONNXRuntimeSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.PMMLSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.PaddleServerSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.SKLearnSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.TFServingSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.TorchServeSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.TritonSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.XGBoostSpec", "k8s.io/api/apps/v1.DeploymentStrategy", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EphemeralContainer", "k8s.io/api/core/v1.HostAlias", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodOS", "k8s.io/api/core/v1.PodReadinessGate", "k8s.io/api/core/v1.PodResourceClaim", "k8s.io/api/core/v1.PodSchedulingGate", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.TopologySpreadConstraint", "k8s.io/api/core/v1.Volume", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
@@ -8335,6 +10794,83 @@ func schema_pkg_apis_serving_v1beta1_SKLearnSpec(ref common.ReferenceCallback) c
 	}
 }
 
+func schema_pkg_apis_serving_v1beta1_SidecarLoggingSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SidecarLoggingSpec sets the log level and format of the containers injected by KServe, the defaults of each container are kept for the fields which are not set.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"level": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Minimum level of the logs written by the containers",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"format": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Format of the logs written by the containers, one json object per line or human readable text",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_serving_v1beta1_SidecarSecurityContext(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SidecarSecurityContext overrides fields of the security context of a container injected by KServe, the security context of the container is kept for the fields which are not set.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"runAsUser": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The UID to run the container as",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"runAsGroup": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The GID to run the container as",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"seLinuxOptions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The SELinux context of the container, only the type and the level can be set",
+							Ref:         ref("k8s.io/api/core/v1.SELinuxOptions"),
+						},
+					},
+					"addCapabilities": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The capabilities added to the container",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.SELinuxOptions"},
+	}
+}
+
 func schema_pkg_apis_serving_v1beta1_StorageSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -8683,6 +11219,144 @@ func schema_pkg_apis_serving_v1beta1_TFServingSpec(ref common.ReferenceCallback)
 	}
 }
 
+func schema_pkg_apis_serving_v1beta1_TokenExchange(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TokenExchange specifies the OAuth 2.0 token exchange (RFC 8693) of the bearer token of the requests, the exchanged token replaces the Authorization header of the requests sent to the component",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"tokenUrl": {
+						SchemaProps: spec.SchemaProps{
+							Description: "URL of the token endpoint of the authorization server",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"audience": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Audience the exchanged token is requested for",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"scopes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Scopes the exchanged token is requested for",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"clientSecretName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the secret holding the client_id and client_secret the agent authenticates at the token endpoint with, the agent does not authenticate when not set",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"unauthenticatedPaths": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Paths the GET and HEAD requests are proxied for without a bearer token, e.g. the health endpoints probed and the metrics scraped from inside the cluster. Every other request requires a token.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.UnauthenticatedPath"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"tokenUrl"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.UnauthenticatedPath"},
+	}
+}
+
+func schema_pkg_apis_serving_v1beta1_TokenReview(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TokenReview specifies the validation of the bearer token of the requests with a Kubernetes TokenReview and the authorization of the user of the token with a SubjectAccessReview on the InferenceService, without an authenticating proxy in front of the component. The service account of the component must be allowed to create TokenReviews and SubjectAccessReviews, e.g. with the system:auth-delegator ClusterRole.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"audiences": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Audiences the token must be issued for, the token must be valid for the audiences of the API server when not set",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"verb": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Verb the user of the token must be allowed on the InferenceService, defaults to get",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"unauthenticatedPaths": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Paths the GET and HEAD requests are proxied for without a bearer token, e.g. the health endpoints probed and the metrics scraped from inside the cluster. Every other request requires a token.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.UnauthenticatedPath"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.UnauthenticatedPath"},
+	}
+}
+
+func schema_pkg_apis_serving_v1beta1_TokenUsageSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TokenUsageSpec specifies the accounting of the prompt and completion tokens of the OpenAI protocol responses. The tokens are exported as the kserve_agent_tokens_total metric on the metrics port of the agent, the usage of streamed responses is only reported when the requests set stream_options.include_usage.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"headers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Whether the usage is returned in the X-Usage-Prompt-Tokens, X-Usage-Completion-Tokens and X-Usage-Total-Tokens headers, they are sent as trailers of the streamed responses and the other responses are buffered to set them",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_serving_v1beta1_TorchServeSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -9484,6 +12158,24 @@ func schema_pkg_apis_serving_v1beta1_TransformerSpec(ref common.ReferenceCallbac
 							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.Batcher"),
 						},
 					},
+					"middleware": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Middleware the agent applies to the requests proxied to the component",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.AgentMiddleware"),
+						},
+					},
+					"sidecarLogging": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Log level and format of the containers injected by KServe in the component pods, the agent and the storage initializer.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.SidecarLoggingSpec"),
+						},
+					},
+					"agentSecurityContext": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Overrides of the security context of the agent container, e.g. to run it with the SELinux type required by a storage driver. The overrides must be within the bounds set by the administrator in the security config.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.SidecarSecurityContext"),
+						},
+					},
 					"labels": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Labels that will be add to the component pod. More info: http://kubernetes.io/docs/user-guide/labels",
@@ -9522,11 +12214,18 @@ func schema_pkg_apis_serving_v1beta1_TransformerSpec(ref common.ReferenceCallbac
 							Ref:         ref("k8s.io/api/apps/v1.DeploymentStrategy"),
 						},
 					},
+					"minReadySeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Minimum number of seconds a new pod should be ready without any of its containers crashing before it is considered available and the rollout proceeds. Only applicable for raw deployment mode.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.Batcher", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.LoggerSpec", "k8s.io/api/apps/v1.DeploymentStrategy", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EphemeralContainer", "k8s.io/api/core/v1.HostAlias", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodOS", "k8s.io/api/core/v1.PodReadinessGate", "k8s.io/api/core/v1.PodResourceClaim", "k8s.io/api/core/v1.PodSchedulingGate", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.TopologySpreadConstraint", "k8s.io/api/core/v1.Volume", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
+			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.AgentMiddleware", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.Batcher", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.LoggerSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.SidecarLoggingSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.SidecarSecurityContext", "k8s.io/api/apps/v1.DeploymentStrategy", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EphemeralContainer", "k8s.io/api/core/v1.HostAlias", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodOS", "k8s.io/api/core/v1.PodReadinessGate", "k8s.io/api/core/v1.PodResourceClaim", "k8s.io/api/core/v1.PodSchedulingGate", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.TopologySpreadConstraint", "k8s.io/api/core/v1.Volume", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
 	}
 }
 
@@ -9829,6 +12528,72 @@ func schema_pkg_apis_serving_v1beta1_TritonSpec(ref common.ReferenceCallback) co
 	}
 }
 
+func schema_pkg_apis_serving_v1beta1_UnauthenticatedPath(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "UnauthenticatedPath specifies the path prefix the requests of the allowed sources are proxied for without a bearer token",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"prefix": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Prefix of the paths, e.g. /v2/health or /metrics, it matches whole path segments",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"sourceCIDRs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CIDRs of the sources allowed to send the requests without a token, they default to the loopback and the private networks, e.g. 10.0.0.0/8, the pods and the nodes of the clusters are usually assigned addresses from",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"prefix"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_serving_v1beta1_ValidationSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ValidationSpec specifies the Job validating a new revision of the predictor, e.g. by sending golden requests",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"jobTemplateConfigMap": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the ConfigMap, in the namespace of the InferenceService, holding the manifest of the Job under the \"job\" key. The URL of the InferenceService and the revision of the predictor are passed to the containers of the Job in the INFERENCE_SERVICE_URL and PREDICTOR_REVISION environment variables.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"timeoutSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Maximum duration of the validation Job in seconds, defaults to 600",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"jobTemplateConfigMap"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_serving_v1beta1_XGBoostSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -56,6 +56,11 @@ type PredictorSpec struct {
 	// Model spec for any arbitrary framework.
 	Model *ModelSpec `json:"model,omitempty"`
 
+	// ModelConversion declares a step converting the model after it is downloaded by the storage initializer,
+	// e.g. to safetensors or to a quantized checkpoint, so the converted artifacts do not have to be generated offline.
+	// +optional
+	ModelConversion *ModelConversionSpec `json:"modelConversion,omitempty"`
+
 	// This spec is dual purpose. <br />
 	// 1) Provide a full PodSpec for custom predictor.
 	// The field PodSpec.Containers is mutually exclusive with other predictors (i.e. TFServing). <br />
@@ -104,6 +109,32 @@ type StorageSpec struct {
 	StorageKey *string `json:"key,omitempty"`
 }
 
+// ModelConversionSpec defines the init container converting the model before the model server starts. The
+// container runs after the storage initializer and converts the model in place in the directory set in the
+// MODEL_DIR environment variable, which is the directory the model server loads the model from.
+type ModelConversionSpec struct {
+	// Image of the container running the conversion.
+	Image string `json:"image"`
+	// Entrypoint of the conversion container, the entrypoint of the image is used when it is not set.
+	// +optional
+	Command []string `json:"command,omitempty"`
+	// Arguments of the conversion container.
+	// +optional
+	Args []string `json:"args,omitempty"`
+	// List of environment variables to set in the conversion container.
+	// +optional
+	Env []v1.EnvVar `json:"env,omitempty"`
+	// Compute resources of the conversion container, e.g. a GPU for the quantization.
+	// +optional
+	Resources v1.ResourceRequirements `json:"resources,omitempty"`
+	// Name of a PersistentVolumeClaim caching the converted artifacts across the pods. When it is set, the
+	// CONVERSION_CACHE_DIR environment variable points to a directory of the claim which is unique for the
+	// storage uri and the conversion command, the container should reuse the artifacts found there instead
+	// of converting the model again.
+	// +optional
+	CachePVCName string `json:"cachePvcName,omitempty"`
+}
+
 // GetImplementations returns the implementations for the component
 func (s *PredictorSpec) GetImplementations() []ComponentImplementation {
 	implementations := NonNilComponents([]ComponentImplementation{
@@ -137,6 +168,36 @@ func (s *PredictorSpec) GetExtensions() *ComponentExtensionSpec {
 	return &s.ComponentExtensionSpec
 }
 
+// GetModelServerContainer returns the container of the model server selected by the predictor, it is nil for a
+// custom predictor whose containers are all in the pod spec.
+func (s *PredictorSpec) GetModelServerContainer() *v1.Container {
+	switch {
+	case s.SKLearn != nil:
+		return &s.SKLearn.Container
+	case s.XGBoost != nil:
+		return &s.XGBoost.Container
+	case s.Tensorflow != nil:
+		return &s.Tensorflow.Container
+	case s.PyTorch != nil:
+		return &s.PyTorch.Container
+	case s.Triton != nil:
+		return &s.Triton.Container
+	case s.ONNX != nil:
+		return &s.ONNX.Container
+	case s.HuggingFace != nil:
+		return &s.HuggingFace.Container
+	case s.PMML != nil:
+		return &s.PMML.Container
+	case s.LightGBM != nil:
+		return &s.LightGBM.Container
+	case s.Paddle != nil:
+		return &s.Paddle.Container
+	case s.Model != nil:
+		return &s.Model.Container
+	}
+	return nil
+}
+
 // Validate returns an error if invalid
 func (p *PredictorExtensionSpec) Validate() error {
 	return utils.FirstNonNilError([]error{
@@ -103,9 +103,14 @@ func (handler *BatchHandler) batchPredict() {
 	reader := bytes.NewReader(jsonStr)
 	r := httptest.NewRequest("POST", handler.batcherInfo.Path, reader)
 	rr := httptest.NewRecorder()
+	start := GetNowTime()
+	batchSize.Observe(float64(len(handler.batcherInfo.Instances)))
+	batchWait.Observe(start.Sub(handler.batcherInfo.Start).Seconds())
 	handler.next.ServeHTTP(rr, r)
+	batchLatency.Observe(GetNowTime().Sub(start).Seconds())
 	responseBody := rr.Body.Bytes()
 	if rr.Code != http.StatusOK {
+		batchErrors.Inc()
 		handler.log.Errorf("error response with code %v", rr)
 		for _, v := range handler.batcherInfo.ContextMap {
 			res := Response{
@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batcher
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	batchSize = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "kserve_agent_batcher_batch_size",
+		Help:    "Number of instances of the batches sent to the model server",
+		Buckets: prometheus.ExponentialBuckets(1, 2, 10),
+	})
+	batchWait = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "kserve_agent_batcher_batch_wait_seconds",
+		Help:    "Time from the first instance of a batch being queued to the batch being sent",
+		Buckets: prometheus.DefBuckets,
+	})
+	batchLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "kserve_agent_batcher_batch_latency_seconds",
+		Help:    "Latency of the batch predictions of the model server",
+		Buckets: prometheus.DefBuckets,
+	})
+	batchErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "kserve_agent_batcher_batch_errors_total",
+		Help: "Number of batch predictions which failed",
+	})
+)
+
+// RegisterMetrics registers the metrics of the batches
+func RegisterMetrics(registerer prometheus.Registerer) {
+	registerer.MustRegister(batchSize, batchWait, batchLatency, batchErrors)
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batcher
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+func TestBatchMetrics(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	registry := prometheus.NewRegistry()
+	RegisterMetrics(registry)
+	histogram := func(name string) (uint64, float64) {
+		families, err := registry.Gather()
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		for _, family := range families {
+			if family.GetName() == name {
+				h := family.GetMetric()[0].GetHistogram()
+				return h.GetSampleCount(), h.GetSampleSum()
+			}
+		}
+		return 0, 0
+	}
+	count, sum := histogram("kserve_agent_batcher_batch_size")
+	errors := testutil.ToFloat64(batchErrors)
+
+	handler := &BatchHandler{
+		next: http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			http.Error(rw, "model is not loaded", http.StatusServiceUnavailable)
+		}),
+		log: logger,
+	}
+	handler.batcherInfo.InitializeInfo()
+	ctx := context.Background()
+	out := make(chan Response, 1)
+	handler.batcherInfo.Path = "/v1/models/test:predict"
+	handler.batcherInfo.Instances = []interface{}{1, 2, 3}
+	handler.batcherInfo.ContextMap[&ctx] = InputInfo{ChannelOut: &out, Index: []int{0, 1, 2}}
+	handler.batchPredict()
+	<-out
+
+	newCount, newSum := histogram("kserve_agent_batcher_batch_size")
+	g.Expect(newCount).To(gomega.Equal(count + 1))
+	g.Expect(newSum).To(gomega.Equal(sum + 3))
+	latencyCount, _ := histogram("kserve_agent_batcher_batch_latency_seconds")
+	g.Expect(latencyCount).To(gomega.BeNumerically(">=", 1))
+	g.Expect(testutil.ToFloat64(batchErrors)).To(gomega.Equal(errors + 1))
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capabilities
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
+
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+const (
+	// ConditionsKey is the key of the capabilities configmap holding the conditions of the optional APIs
+	ConditionsKey = "conditions"
+	// APIDiscoveredReason is the reason of the condition of an optional API served by the cluster
+	APIDiscoveredReason = "APIDiscovered"
+	// APINotFoundReason is the reason of the condition of an optional API not served by the cluster
+	APINotFoundReason = "APINotFound"
+	// DiscoveryFailedReason is the reason of the condition of an optional API that could not be discovered
+	DiscoveryFailedReason = "DiscoveryFailed"
+	// DefaultRefreshInterval is the interval the optional APIs are discovered again
+	DefaultRefreshInterval = utils.DefaultAvailableResourcesTTL
+)
+
+// Capability is an optional API the controller integrates with when the cluster serves it
+type Capability struct {
+	// Name of the capability, it is reported by a condition of type <Name>Available
+	Name         string
+	GroupVersion string
+	Kind         string
+}
+
+// ConditionType returns the type of the condition reporting whether the capability is available
+func (c Capability) ConditionType() string {
+	return c.Name + "Available"
+}
+
+var (
+	KnativeServing = Capability{Name: "KnativeServing", GroupVersion: knservingv1.SchemeGroupVersion.String(),
+		Kind: constants.KnativeServiceKind}
+	OpenShiftRoute = Capability{Name: "OpenShiftRoute", GroupVersion: "route.openshift.io/v1", Kind: "Route"}
+	KEDA           = Capability{Name: "KEDA", GroupVersion: "keda.sh/v1alpha1", Kind: "ScaledObject"}
+	GatewayAPI     = Capability{Name: "GatewayAPI", GroupVersion: "gateway.networking.k8s.io/v1", Kind: "HTTPRoute"}
+)
+
+// OptionalAPIs are the optional APIs detected by default
+var OptionalAPIs = []Capability{KnativeServing, OpenShiftRoute, KEDA, GatewayAPI}
+
+// Detector discovers the optional APIs served by the cluster when the controller starts and then periodically, so that
+// an API installed later, e.g. Knative Serving, is picked up without restarting the controller. The outcome is reported
+// as one condition per API in the kserve-controller-capabilities configmap, and the handlers registered for an API are
+// run once it becomes available.
+type Detector struct {
+	Config    *rest.Config
+	Clientset kubernetes.Interface
+	Log       logr.Logger
+	// Interval is the interval the APIs are discovered again, it defaults to DefaultRefreshInterval
+	Interval time.Duration
+	// Capabilities are the APIs detected, they default to OptionalAPIs
+	Capabilities []Capability
+
+	mu         sync.Mutex
+	conditions []metav1.Condition
+	handlers   map[string][]func() error
+}
+
+// OnAvailable registers a handler run once the capability is available, the handler is retried on the next refresh
+// when it fails
+func (d *Detector) OnAvailable(capability Capability, handler func() error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.handlers == nil {
+		d.handlers = make(map[string][]func() error)
+	}
+	d.handlers[capability.Name] = append(d.handlers[capability.Name], handler)
+}
+
+// Conditions returns the conditions of the detected APIs
+func (d *Detector) Conditions() []metav1.Condition {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	conditions := make([]metav1.Condition, len(d.conditions))
+	copy(conditions, d.conditions)
+	return conditions
+}
+
+// Start discovers the APIs every interval until the context is done
+func (d *Detector) Start(ctx context.Context) error {
+	interval := d.Interval
+	if interval == 0 {
+		interval = DefaultRefreshInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := d.Refresh(ctx); err != nil {
+			d.Log.Error(err, "unable to detect the optional APIs")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh discovers the APIs, runs the handlers of the available ones and reports the conditions in the capabilities
+// configmap
+func (d *Detector) Refresh(ctx context.Context) error {
+	capabilities := d.Capabilities
+	if capabilities == nil {
+		capabilities = OptionalAPIs
+	}
+
+	d.mu.Lock()
+	for _, capability := range capabilities {
+		condition := metav1.Condition{
+			Type:    capability.ConditionType(),
+			Status:  metav1.ConditionFalse,
+			Reason:  APINotFoundReason,
+			Message: fmt.Sprintf("%s %s is not served by the cluster", capability.GroupVersion, capability.Kind),
+		}
+		available, err := d.isAvailable(capability)
+		if err != nil {
+			d.Log.Error(err, "unable to discover the API", "capability", capability.Name)
+			condition.Status = metav1.ConditionUnknown
+			condition.Reason = DiscoveryFailedReason
+			condition.Message = err.Error()
+		} else if available {
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = APIDiscoveredReason
+			condition.Message = fmt.Sprintf("%s %s is served by the cluster", capability.GroupVersion, capability.Kind)
+			d.runHandlers(capability)
+		}
+		previous := apimeta.FindStatusCondition(d.conditions, condition.Type)
+		if previous == nil || previous.Status != condition.Status {
+			d.Log.Info("optional API detected", "capability", capability.Name, "available", condition.Status)
+		}
+		apimeta.SetStatusCondition(&d.conditions, condition)
+	}
+	data, err := json.Marshal(d.conditions)
+	d.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return d.report(ctx, string(data))
+}
+
+func (d *Detector) isAvailable(capability Capability) (bool, error) {
+	resources, err := utils.RefreshAvailableResourcesForApi(d.Config, capability.GroupVersion)
+	if err != nil || resources == nil {
+		return false, err
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Kind == capability.Kind {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// runHandlers runs the handlers registered for the capability, the successful ones are not run again
+func (d *Detector) runHandlers(capability Capability) {
+	if len(d.handlers[capability.Name]) == 0 {
+		return
+	}
+	var pending []func() error
+	for _, handler := range d.handlers[capability.Name] {
+		if err := handler(); err != nil {
+			d.Log.Error(err, "unable to handle the available API", "capability", capability.Name)
+			pending = append(pending, handler)
+		}
+	}
+	d.handlers[capability.Name] = pending
+}
+
+// report writes the conditions to the capabilities configmap when they changed
+func (d *Detector) report(ctx context.Context, conditions string) error {
+	configMaps := d.Clientset.CoreV1().ConfigMaps(constants.KServeNamespace)
+	configMap, err := configMaps.Get(ctx, constants.CapabilitiesConfigMapName, metav1.GetOptions{})
+	if apierr.IsNotFound(err) {
+		_, err = configMaps.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      constants.CapabilitiesConfigMapName,
+				Namespace: constants.KServeNamespace,
+			},
+			Data: map[string]string{ConditionsKey: conditions},
+		}, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if configMap.Data[ConditionsKey] == conditions {
+		return nil
+	}
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	configMap.Data[ConditionsKey] = conditions
+	_, err = configMaps.Update(ctx, configMap, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capabilities
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/onsi/gomega"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// newDiscoveryServer serves the Knative Service kind once installed, the other APIs are not found
+func newDiscoveryServer(installed *atomic.Bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		groupVersion := strings.TrimPrefix(r.URL.Path, "/apis/")
+		if groupVersion != KnativeServing.GroupVersion || !installed.Load() {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(&metav1.APIResourceList{
+			GroupVersion: groupVersion,
+			APIResources: []metav1.APIResource{{Name: "services", Kind: KnativeServing.Kind, Namespaced: true}},
+		})
+	}))
+}
+
+func readConditions(g *gomega.WithT, clientset *fake.Clientset) []metav1.Condition {
+	configMap, err := clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Get(context.TODO(),
+		constants.CapabilitiesConfigMapName, metav1.GetOptions{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	var conditions []metav1.Condition
+	g.Expect(json.Unmarshal([]byte(configMap.Data[ConditionsKey]), &conditions)).To(gomega.Succeed())
+	return conditions
+}
+
+func TestRefresh(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	var installed atomic.Bool
+	server := newDiscoveryServer(&installed)
+	defer server.Close()
+	clientset := fake.NewSimpleClientset()
+	detector := &Detector{
+		Config:    &rest.Config{Host: server.URL},
+		Clientset: clientset,
+		Log:       logr.Discard(),
+	}
+	var calls, failures int
+	detector.OnAvailable(KnativeServing, func() error {
+		calls++
+		if calls == 1 {
+			failures++
+			return errors.New("watch failed")
+		}
+		return nil
+	})
+
+	g.Expect(detector.Refresh(context.TODO())).To(gomega.Succeed())
+	conditions := readConditions(g, clientset)
+	g.Expect(conditions).To(gomega.HaveLen(len(OptionalAPIs)))
+	for _, capability := range OptionalAPIs {
+		g.Expect(apimeta.IsStatusConditionFalse(conditions, capability.ConditionType())).To(gomega.BeTrue())
+	}
+	g.Expect(calls).To(gomega.Equal(0))
+
+	// Knative Serving installed after the controller started is picked up on the next refresh
+	installed.Store(true)
+	g.Expect(detector.Refresh(context.TODO())).To(gomega.Succeed())
+	conditions = readConditions(g, clientset)
+	g.Expect(apimeta.IsStatusConditionTrue(conditions, KnativeServing.ConditionType())).To(gomega.BeTrue())
+	g.Expect(apimeta.IsStatusConditionFalse(conditions, KEDA.ConditionType())).To(gomega.BeTrue())
+	g.Expect(apimeta.IsStatusConditionTrue(detector.Conditions(), KnativeServing.ConditionType())).To(gomega.BeTrue())
+	g.Expect(calls).To(gomega.Equal(1))
+
+	// the failed handler is retried, the successful one is not run again
+	g.Expect(detector.Refresh(context.TODO())).To(gomega.Succeed())
+	g.Expect(detector.Refresh(context.TODO())).To(gomega.Succeed())
+	g.Expect(calls).To(gomega.Equal(2))
+	g.Expect(failures).To(gomega.Equal(1))
+}
+
+func TestRefreshUnchangedConditions(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	var installed atomic.Bool
+	installed.Store(true)
+	server := newDiscoveryServer(&installed)
+	defer server.Close()
+	clientset := fake.NewSimpleClientset()
+	detector := &Detector{
+		Config:       &rest.Config{Host: server.URL},
+		Clientset:    clientset,
+		Log:          logr.Discard(),
+		Capabilities: []Capability{KnativeServing},
+	}
+
+	g.Expect(detector.Refresh(context.TODO())).To(gomega.Succeed())
+	g.Expect(detector.Refresh(context.TODO())).To(gomega.Succeed())
+	updates := 0
+	for _, action := range clientset.Actions() {
+		if action.GetVerb() == "update" {
+			updates++
+		}
+	}
+	g.Expect(updates).To(gomega.Equal(0))
+	g.Expect(readConditions(g, clientset)).To(gomega.HaveLen(1))
+}
@@ -21,6 +21,7 @@ package externalversions
 import (
 	"fmt"
 
+	v1alpha1 "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	v1beta1 "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	cache "k8s.io/client-go/tools/cache"
@@ -52,7 +53,21 @@ func (f *genericInformer) Lister() cache.GenericLister {
 // TODO extend this to unknown resources with a client pool
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
-	// Group=serving.kserve.io, Version=v1beta1
+	// Group=serving.kserve.io, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("clusterservingruntimes"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Serving().V1alpha1().ClusterServingRuntimes().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("clusterstoragecontainers"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Serving().V1alpha1().ClusterStorageContainers().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("inferencegraphs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Serving().V1alpha1().InferenceGraphs().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("servingprofiles"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Serving().V1alpha1().ServingProfiles().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("servingruntimes"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Serving().V1alpha1().ServingRuntimes().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("trainedmodels"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Serving().V1alpha1().TrainedModels().Informer()}, nil
+
+		// Group=serving.kserve.io, Version=v1beta1
 	case v1beta1.SchemeGroupVersion.WithResource("inferenceservices"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Serving().V1beta1().InferenceServices().Informer()}, nil
 
@@ -0,0 +1,288 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configvalidator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1alpha1/inferencegraph"
+	"github.com/kserve/kserve/pkg/webhook/admission/pod"
+)
+
+const (
+	// ConfigValidReason is the reason of the event recorded on the configmap when its sections are valid
+	ConfigValidReason = "ConfigValid"
+	// ConfigInvalidReason is the reason of the event recorded on the configmap when a section is invalid
+	ConfigInvalidReason = "ConfigInvalid"
+	// DefaultCheckInterval is the interval the configmap is checked for changes
+	DefaultCheckInterval = 30 * time.Second
+)
+
+// Validator validates the router, ingress and storageInitializer sections of the inferenceservice configmap when the
+// controller starts and every time the configmap changes. The field errors are logged and recorded as a ConfigInvalid
+// event on the configmap, so that a broken configuration is reported before a reconcile fails on it.
+type Validator struct {
+	Clientset kubernetes.Interface
+	Recorder  record.EventRecorder
+	Log       logr.Logger
+	// Interval is the interval the configmap is checked for changes, it defaults to DefaultCheckInterval
+	Interval time.Duration
+
+	// resourceVersion is the version of the configmap last validated
+	resourceVersion string
+}
+
+// Start validates the configmap every time its resource version changes until the context is done
+func (v *Validator) Start(ctx context.Context) error {
+	interval := v.Interval
+	if interval == 0 {
+		interval = DefaultCheckInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := v.Check(ctx); err != nil {
+			v.Log.Error(err, "unable to validate the inferenceservice configmap")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check validates the configmap when it changed since the last check and records the outcome as an event
+func (v *Validator) Check(ctx context.Context) error {
+	configMap, err := v.Clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Get(ctx,
+		constants.InferenceServiceConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if configMap.ResourceVersion == v.resourceVersion {
+		return nil
+	}
+	v.resourceVersion = configMap.ResourceVersion
+
+	errs := Validate(configMap)
+	if len(errs) == 0 {
+		v.Log.Info("inferenceservice configmap is valid", "resourceVersion", configMap.ResourceVersion)
+		v.Recorder.Event(configMap, corev1.EventTypeNormal, ConfigValidReason,
+			"The router, ingress and storageInitializer configs are valid")
+		return nil
+	}
+	for _, fieldErr := range errs {
+		v.Log.Info("invalid inferenceservice configmap", "field", fieldErr.Field, "error", fieldErr.ErrorBody())
+	}
+	v.Recorder.Event(configMap, corev1.EventTypeWarning, ConfigInvalidReason, errs.ToAggregate().Error())
+	return nil
+}
+
+// Validate returns the field errors of the router, ingress and storageInitializer sections of the configmap, the
+// fields are reported with the key of their section as root, e.g. router.cpuLimit
+func Validate(configMap *corev1.ConfigMap) field.ErrorList {
+	var errs field.ErrorList
+	if data, ok := configMap.Data[v1alpha1.RouterConfigKeyName]; ok {
+		errs = append(errs, validateRouterConfig(data, field.NewPath(v1alpha1.RouterConfigKeyName))...)
+	}
+	if data, ok := configMap.Data[v1beta1.IngressConfigKeyName]; ok {
+		errs = append(errs, validateIngressConfig(data, field.NewPath(v1beta1.IngressConfigKeyName))...)
+	}
+	if data, ok := configMap.Data[pod.StorageInitializerConfigMapKeyName]; ok {
+		errs = append(errs, validateStorageInitializerConfig(data,
+			field.NewPath(pod.StorageInitializerConfigMapKeyName))...)
+	}
+	return errs
+}
+
+func validateRouterConfig(data string, path *field.Path) field.ErrorList {
+	config := &inferencegraph.RouterConfig{}
+	if err := decodeStrict(data, config); err != nil {
+		return field.ErrorList{field.Invalid(path, data, err.Error())}
+	}
+	var errs field.ErrorList
+	if config.Image == "" {
+		errs = append(errs, field.Required(path.Child("image"), ""))
+	}
+	errs = append(errs, validateResources(path, config.CpuRequest, config.CpuLimit, config.MemoryRequest,
+		config.MemoryLimit)...)
+	errs = append(errs, validatePort(path.Child("healthPort"), config.HealthPort)...)
+	errs = append(errs, validatePort(path.Child("metricsPort"), config.MetricsPort)...)
+	if config.MaxNodesVisited < 0 {
+		errs = append(errs, field.Invalid(path.Child("maxNodesVisited"), config.MaxNodesVisited, "must not be negative"))
+	}
+	if config.MaxFanOut < 0 {
+		errs = append(errs, field.Invalid(path.Child("maxFanOut"), config.MaxFanOut, "must not be negative"))
+	}
+	if config.Traces != nil {
+		if err := config.Traces.Validate(); err != nil {
+			errs = append(errs, field.Invalid(path.Child("traces"), config.Traces.Store,
+				strings.TrimPrefix(err.Error(), "invalid trace config - ")))
+		}
+	}
+	return errs
+}
+
+func validateIngressConfig(data string, path *field.Path) field.ErrorList {
+	config := &v1beta1.IngressConfig{}
+	if err := decodeStrict(data, config); err != nil {
+		return field.ErrorList{field.Invalid(path, data, err.Error())}
+	}
+	var errs field.ErrorList
+	if config.IngressGateway == "" {
+		errs = append(errs, field.Required(path.Child("ingressGateway"), ""))
+	}
+	if config.IngressServiceName == "" {
+		errs = append(errs, field.Required(path.Child("ingressService"), ""))
+	}
+	if config.DomainTemplate != "" {
+		if _, err := template.New("domain-template").Parse(config.DomainTemplate); err != nil {
+			errs = append(errs, field.Invalid(path.Child("domainTemplate"), config.DomainTemplate, err.Error()))
+		}
+	}
+	if config.PathTemplate != "" {
+		if _, err := template.New("path-template").Parse(config.PathTemplate); err != nil {
+			errs = append(errs, field.Invalid(path.Child("pathTemplate"), config.PathTemplate, err.Error()))
+		}
+		if config.IngressDomain == "" {
+			errs = append(errs, field.Required(path.Child("ingressDomain"), "ingressDomain is required with pathTemplate"))
+		}
+	}
+	schemes := []string{"http", "https"}
+	if config.UrlScheme != "" && config.UrlScheme != "http" && config.UrlScheme != "https" {
+		errs = append(errs, field.NotSupported(path.Child("urlScheme"), config.UrlScheme, schemes))
+	}
+	if config.InternalUrlScheme != "" && config.InternalUrlScheme != "http" && config.InternalUrlScheme != "https" {
+		errs = append(errs, field.NotSupported(path.Child("internalUrlScheme"), config.InternalUrlScheme, schemes))
+	}
+	if config.RouteTLSTermination != "" &&
+		config.RouteTLSTermination != string(constants.RouteTLSTerminationEdge) &&
+		config.RouteTLSTermination != string(constants.RouteTLSTerminationReencrypt) {
+		errs = append(errs, field.NotSupported(path.Child("routeTLSTermination"), config.RouteTLSTermination,
+			[]string{string(constants.RouteTLSTerminationEdge), string(constants.RouteTLSTerminationReencrypt)}))
+	}
+	errs = append(errs, validatePort(path.Child("internalPort"), config.InternalPort)...)
+	for i := range config.IngressDomains {
+		if err := config.IngressDomains[i].Validate(); err != nil {
+			errs = append(errs, field.Invalid(path.Child("ingressDomains").Index(i), config.IngressDomains[i].Domain, err.Error()))
+		}
+	}
+	return errs
+}
+
+func validateStorageInitializerConfig(data string, path *field.Path) field.ErrorList {
+	config := &pod.StorageInitializerConfig{}
+	if err := decodeStrict(data, config); err != nil {
+		return field.ErrorList{field.Invalid(path, data, err.Error())}
+	}
+	var errs field.ErrorList
+	if config.Image == "" {
+		errs = append(errs, field.Required(path.Child("image"), ""))
+	}
+	errs = append(errs, validateResources(path, config.CpuRequest, config.CpuLimit, config.MemoryRequest,
+		config.MemoryLimit)...)
+	if config.CpuModelcar != "" {
+		errs = append(errs, validateQuantity(path.Child("cpuModelcar"), config.CpuModelcar)...)
+	}
+	if config.MemoryModelcar != "" {
+		errs = append(errs, validateQuantity(path.Child("memoryModelcar"), config.MemoryModelcar)...)
+	}
+	if config.UidModelcar != nil && *config.UidModelcar < 0 {
+		errs = append(errs, field.Invalid(path.Child("uidModelcar"), *config.UidModelcar, "must not be negative"))
+	}
+	if config.CaBundleVolumeMountPath != "" && !filepath.IsAbs(config.CaBundleVolumeMountPath) {
+		errs = append(errs, field.Invalid(path.Child("caBundleVolumeMountPath"), config.CaBundleVolumeMountPath,
+			"must be an absolute path"))
+	}
+	if config.MaxConcurrentDownloadsPerNode < 0 {
+		errs = append(errs, field.Invalid(path.Child("maxConcurrentDownloadsPerNode"),
+			config.MaxConcurrentDownloadsPerNode, "must not be negative"))
+	}
+	if config.DownloadSlotsPort < 0 || config.DownloadSlotsPort > 65535 {
+		errs = append(errs, field.Invalid(path.Child("downloadSlotsPort"), config.DownloadSlotsPort,
+			"must be a port number"))
+	}
+	return errs
+}
+
+// validateResources checks that the requests and limits are valid quantities and that the requests do not exceed
+// the limits
+func validateResources(path *field.Path, cpuRequest, cpuLimit, memoryRequest, memoryLimit string) field.ErrorList {
+	var errs field.ErrorList
+	for _, pair := range []struct {
+		requestName, limitName string
+		request, limit         string
+	}{
+		{"cpuRequest", "cpuLimit", cpuRequest, cpuLimit},
+		{"memoryRequest", "memoryLimit", memoryRequest, memoryLimit},
+	} {
+		requestErrs := validateQuantity(path.Child(pair.requestName), pair.request)
+		limitErrs := validateQuantity(path.Child(pair.limitName), pair.limit)
+		errs = append(errs, requestErrs...)
+		errs = append(errs, limitErrs...)
+		if len(requestErrs) != 0 || len(limitErrs) != 0 {
+			continue
+		}
+		if request, limit := resource.MustParse(pair.request), resource.MustParse(pair.limit); request.Cmp(limit) > 0 {
+			errs = append(errs, field.Invalid(path.Child(pair.requestName), pair.request,
+				fmt.Sprintf("must be less than or equal to %s %s", pair.limitName, pair.limit)))
+		}
+	}
+	return errs
+}
+
+func validateQuantity(path *field.Path, value string) field.ErrorList {
+	if value == "" {
+		return field.ErrorList{field.Required(path, "")}
+	}
+	if _, err := resource.ParseQuantity(value); err != nil {
+		return field.ErrorList{field.Invalid(path, value, err.Error())}
+	}
+	return nil
+}
+
+func validatePort(path *field.Path, port int32) field.ErrorList {
+	if port < 0 || port > 65535 {
+		return field.ErrorList{field.Invalid(path, port, "must be between 1 and 65535")}
+	}
+	return nil
+}
+
+// decodeStrict decodes the json of a section, the unknown fields are rejected as they usually are misspelled keys
+func decodeStrict(data string, config interface{}) error {
+	decoder := json.NewDecoder(bytes.NewBufferString(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(config)
+}
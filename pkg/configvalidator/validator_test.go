@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configvalidator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+const (
+	validRouter = `{"image": "kserve/router:latest", "cpuRequest": "100m", "cpuLimit": "1",
+		"memoryRequest": "100Mi", "memoryLimit": "1Gi"}`
+	validIngress = `{"ingressGateway": "knative-serving/knative-ingress-gateway",
+		"ingressService": "istio-ingressgateway.istio-system.svc.cluster.local"}`
+	validStorageInitializer = `{"image": "kserve/storage-initializer:latest", "cpuRequest": "100m",
+		"cpuLimit": "1", "memoryRequest": "100Mi", "memoryLimit": "1Gi"}`
+)
+
+func TestValidate(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		data     map[string]string
+		expected []string
+	}{
+		"valid": {
+			data: map[string]string{
+				"router":             validRouter,
+				"ingress":            validIngress,
+				"storageInitializer": validStorageInitializer,
+			},
+		},
+		"missing sections": {
+			data: map[string]string{},
+		},
+		"invalid json": {
+			data:     map[string]string{"router": `{"image": `},
+			expected: []string{"router"},
+		},
+		"unknown field": {
+			data:     map[string]string{"ingress": `{"ingressGateway": "gw", "ingressService": "svc", "urlSchema": "https"}`},
+			expected: []string{"ingress"},
+		},
+		"invalid router fields": {
+			data: map[string]string{"router": `{"cpuRequest": "2", "cpuLimit": "1", "memoryRequest": "1Gi",
+				"memoryLimit": "lots", "healthPort": 70000, "traces": {"store": "disk"}}`},
+			expected: []string{"router.image", "router.cpuRequest", "router.memoryLimit", "router.healthPort",
+				"router.traces"},
+		},
+		"invalid ingress fields": {
+			data: map[string]string{"ingress": `{"pathTemplate": "/{{ .Name", "urlScheme": "ftp",
+				"routeTLSTermination": "passthrough", "internalPort": -1,
+				"ingressDomains": [{"domain": "internal.example.com"}, {"domain": "Bad_Domain", "labels": {"a": "b"}}]}`},
+			expected: []string{"ingress.ingressGateway", "ingress.ingressService", "ingress.pathTemplate",
+				"ingress.ingressDomain", "ingress.urlScheme", "ingress.routeTLSTermination", "ingress.internalPort",
+				"ingress.ingressDomains[0]", "ingress.ingressDomains[1]"},
+		},
+		"invalid storage initializer fields": {
+			data: map[string]string{"storageInitializer": `{"image": "kserve/storage-initializer:latest",
+				"cpuRequest": "100m", "cpuLimit": "1", "memoryRequest": "100Mi", "memoryLimit": "1Gi",
+				"memoryModelcar": "1x", "maxConcurrentDownloadsPerNode": -1, "downloadSlotsPort": 70000}`},
+			expected: []string{"storageInitializer.memoryModelcar", "storageInitializer.maxConcurrentDownloadsPerNode",
+				"storageInitializer.downloadSlotsPort"},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			errs := Validate(&v1.ConfigMap{Data: scenario.data})
+			fields := []string{}
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			if scenario.expected == nil {
+				g.Expect(errs).To(gomega.BeEmpty())
+			} else {
+				g.Expect(fields).To(gomega.Equal(scenario.expected))
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	configMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            constants.InferenceServiceConfigMapName,
+			Namespace:       constants.KServeNamespace,
+			ResourceVersion: "1",
+		},
+		Data: map[string]string{"router": validRouter, "ingress": validIngress},
+	}
+	clientset := fake.NewSimpleClientset(configMap)
+	recorder := record.NewFakeRecorder(10)
+	validator := &Validator{Clientset: clientset, Recorder: recorder, Log: logr.Discard()}
+	ctx := context.Background()
+
+	g.Expect(validator.Check(ctx)).To(gomega.Succeed())
+	g.Expect(recorder.Events).To(gomega.Receive(gomega.HavePrefix("Normal ConfigValid")))
+
+	// the configmap is not validated again until it changes
+	g.Expect(validator.Check(ctx)).To(gomega.Succeed())
+	g.Expect(recorder.Events).NotTo(gomega.Receive())
+
+	configMap.ResourceVersion = "2"
+	configMap.Data["router"] = `{"image": "kserve/router:latest", "cpuRequest": "1 cpu"}`
+	_, err := clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Update(ctx, configMap, metav1.UpdateOptions{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(validator.Check(ctx)).To(gomega.Succeed())
+	var event string
+	g.Expect(recorder.Events).To(gomega.Receive(&event))
+	g.Expect(event).To(gomega.HavePrefix("Warning ConfigInvalid"))
+	g.Expect(event).To(gomega.ContainSubstring("router.cpuRequest"))
+	g.Expect(event).To(gomega.ContainSubstring("router.memoryLimit: Required value"))
+}
@@ -18,6 +18,7 @@ package constants
 
 import (
 	"fmt"
+	"hash/fnv"
 	"os"
 	"regexp"
 	"strings"
@@ -48,11 +49,56 @@ var (
 	InferenceServiceConfigMapName = "inferenceservice-config"
 )
 
+// CapabilitiesConfigMapName is the ConfigMap reporting the optional APIs detected by the controller
+const CapabilitiesConfigMapName = "kserve-controller-capabilities"
+
+// IdleStopExemptLabelKey opts an InferenceService out of the idle stop when it is set to true
+var IdleStopExemptLabelKey = KServeAPIGroupName + "/idle-stop-exempt"
+
+// ModelVersionHeader pins a request to a tagged revision of a serverless InferenceService, e.g. "prev" during a
+// canary rollout. The value is the traffic tag or the name of the revision.
+const ModelVersionHeader = "kserve-model-version"
+
 // InferenceGraph Constants
 const (
 	RouterHeadersPropagateEnvVar = "PROPAGATE_HEADERS"
+	RouterStepHeaderEnvVarPrefix = "KSERVE_STEP_HEADER_"
 	InferenceGraphLabel          = "serving.kserve.io/inferencegraph"
-)
+	RouterDefaultPort            = 8080
+	RouterHealthPath             = "/healthz"
+	RouterPortName               = "http"
+	RouterMetricsPortName        = "metrics"
+	RouterPluginDir              = "/mnt/router-plugins"
+	RouterPluginVolumeName       = "router-plugins"
+	RouterPluginDefaultImagePath = "/plugin.so"
+	RouterCaBundleDir            = "/etc/ssl/router-ca-bundle"
+	RouterCaBundleVolumeName     = "router-ca-bundle"
+	RouterCaBundleDisabled       = "none"
+	RouterTLSDir                 = "/etc/tls/router"
+	RouterTLSVolumeName          = "router-tls"
+	RouterTLSSecretSuffix        = "-router-tls"
+	// RouterCanaryDeploymentSuffix is the suffix of the Deployment running the canary revision of a raw router
+	RouterCanaryDeploymentSuffix = "-canary"
+	// SSLCertFileEnvVar is the file of the CA certificates trusted by Go programs instead of the system CAs
+	SSLCertFileEnvVar = "SSL_CERT_FILE"
+	// The keys of the topology ConfigMap of an InferenceGraph
+	InferenceGraphTopologyJSONKey = "topology.json"
+	InferenceGraphTopologyDOTKey  = "topology.dot"
+	// The graph ConfigMap of an InferenceGraph holds the graph document the raw router reloads when it changes
+	RouterGraphDir        = "/etc/router/graph"
+	RouterGraphVolumeName = "router-graph"
+	RouterGraphKey        = "graph.json"
+)
+
+// InferenceGraphTopologyConfigMapName is the ConfigMap holding the topology of the graph rendered for the UIs
+func InferenceGraphTopologyConfigMapName(graphName string) string {
+	return graphName + "-topology"
+}
+
+// InferenceGraphRouterGraphConfigMapName is the ConfigMap holding the graph document the router reloads
+func InferenceGraphRouterGraphConfigMapName(graphName string) string {
+	return graphName + "-router-graph"
+}
 
 // TrainedModel Constants
 var (
@@ -79,21 +125,60 @@ var (
 	DeploymentMode                              = KServeAPIGroupName + "/deploymentMode"
 	EnableRoutingTagAnnotationKey               = KServeAPIGroupName + "/enable-tag-routing"
 	AutoscalerClass                             = KServeAPIGroupName + "/autoscalerClass"
+	ResourceSizeClassAnnotationKey              = KServeAPIGroupName + "/size-class"
+	ServingProfileAnnotationKey                 = KServeAPIGroupName + "/serving-profile"
+	AppliedServingProfileAnnotationKey          = KServeAPIGroupName + "/applied-serving-profile"
+	PausedAnnotationKey                         = KServeAPIGroupName + "/paused"
+	EstimatedHourlyCostAnnotationKey            = KServeAPIGroupName + "/estimated-hourly-cost"
+	StopAnnotationKey                           = KServeAPIGroupName + "/stop"
+	PreemptedByAnnotationKey                    = KServeAPIGroupName + "/preempted-by"
 	AutoscalerMetrics                           = KServeAPIGroupName + "/metrics"
 	TargetUtilizationPercentage                 = KServeAPIGroupName + "/targetUtilizationPercentage"
-	MinScaleAnnotationKey                       = KnativeAutoscalingAPIGroupName + "/min-scale"
-	MaxScaleAnnotationKey                       = KnativeAutoscalingAPIGroupName + "/max-scale"
-	RollOutDurationAnnotationKey                = KnativeServingAPIGroupName + "/rollout-duration"
-	KnativeOpenshiftEnablePassthroughKey        = "serving.knative.openshift.io/enablePassthrough"
-	EnableMetricAggregation                     = KServeAPIGroupName + "/enable-metric-aggregation"
-	SetPrometheusAnnotation                     = KServeAPIGroupName + "/enable-prometheus-scraping"
-	KserveContainerPrometheusPortKey            = "prometheus.kserve.io/port"
-	KServeContainerPrometheusPathKey            = "prometheus.kserve.io/path"
-	PrometheusPortAnnotationKey                 = "prometheus.io/port"
-	PrometheusPathAnnotationKey                 = "prometheus.io/path"
-	DefaultPrometheusPath                       = "/metrics"
-	QueueProxyAggregatePrometheusMetricsPort    = 9088
-	DefaultPodPrometheusPort                    = "9091"
+	RevisionHistoryLimitAnnotationKey           = KServeAPIGroupName + "/revisionHistoryLimit"
+	ProgressDeadlineSecondsAnnotationKey        = KServeAPIGroupName + "/progressDeadlineSeconds"
+	// ServiceTypeAnnotationKey sets the type of the Service created for a raw deployment, one of ClusterIP, NodePort
+	// or LoadBalancer. The LoadBalancer class and node port allocation can be set with the two following annotations.
+	ServiceTypeAnnotationKey                   = KServeAPIGroupName + "/serviceType"
+	LoadBalancerClassAnnotationKey             = KServeAPIGroupName + "/loadBalancerClass"
+	AllocateLoadBalancerNodePortsAnnotationKey = KServeAPIGroupName + "/allocateLoadBalancerNodePorts"
+	// InternalTrafficPolicyAnnotationKey and TopologyAwareRoutingAnnotationKey keep the traffic of the Service of a
+	// raw deployment on the same node or zone. They give the Service a cluster IP, as kube-proxy skips headless Services.
+	InternalTrafficPolicyAnnotationKey = KServeAPIGroupName + "/internalTrafficPolicy"
+	TopologyAwareRoutingAnnotationKey  = KServeAPIGroupName + "/topologyAwareRouting"
+	// ServiceAliasAnnotationKey creates a Service of the given name in the namespace of a raw InferenceService which
+	// points to its top level Service, e.g. a stable production name moved between InferenceServices for a blue/green
+	// cutover. ServiceAliasTypeAnnotationKey sets the type of the alias, ExternalName by default or ClusterIP.
+	ServiceAliasAnnotationKey     = KServeAPIGroupName + "/serviceAlias"
+	ServiceAliasTypeAnnotationKey = KServeAPIGroupName + "/serviceAliasType"
+	// ServiceAliasLabelKey marks the alias Services, its value is the name of the InferenceService the alias points to
+	ServiceAliasLabelKey = KServeAPIGroupName + "/service-alias-of"
+	// RouterCaBundleAnnotationKey overrides the CA bundle trusted by the router of an InferenceGraph, its value is the
+	// name of a ConfigMap of the namespace, optionally followed by /<key>, or none to trust the system CAs only
+	RouterCaBundleAnnotationKey = KServeAPIGroupName + "/router-ca-bundle"
+	// RouterTLSAnnotationKey enables or disables the HTTPS listener of the router of an InferenceGraph, overriding
+	// the router config
+	RouterTLSAnnotationKey = KServeAPIGroupName + "/router-tls"
+	// RequestReplaySecretAnnotationKey enables the capture and replay of the failing requests of an InferenceService by
+	// the agent, its value is the name of a Secret of the namespace whose token key holds the bearer token required by
+	// the replay endpoints. RequestReplayBufferSizeAnnotationKey sets the number of failing requests kept, 10 by default.
+	RequestReplaySecretAnnotationKey     = KServeAPIGroupName + "/request-replay-secret"
+	RequestReplayBufferSizeAnnotationKey = KServeAPIGroupName + "/request-replay-buffer-size"
+	// InitContainersAfterStorageInitializerAnnotationKey lists the user init containers, separated by commas, which
+	// run after the storage initializer and can read the downloaded model
+	InitContainersAfterStorageInitializerAnnotationKey = KServeAPIGroupName + "/init-containers-after-storage-initializer"
+	MinScaleAnnotationKey                              = KnativeAutoscalingAPIGroupName + "/min-scale"
+	MaxScaleAnnotationKey                              = KnativeAutoscalingAPIGroupName + "/max-scale"
+	RollOutDurationAnnotationKey                       = KnativeServingAPIGroupName + "/rollout-duration"
+	KnativeOpenshiftEnablePassthroughKey               = "serving.knative.openshift.io/enablePassthrough"
+	EnableMetricAggregation                            = KServeAPIGroupName + "/enable-metric-aggregation"
+	SetPrometheusAnnotation                            = KServeAPIGroupName + "/enable-prometheus-scraping"
+	KserveContainerPrometheusPortKey                   = "prometheus.kserve.io/port"
+	KServeContainerPrometheusPathKey                   = "prometheus.kserve.io/path"
+	PrometheusPortAnnotationKey                        = "prometheus.io/port"
+	PrometheusPathAnnotationKey                        = "prometheus.io/path"
+	DefaultPrometheusPath                              = "/metrics"
+	QueueProxyAggregatePrometheusMetricsPort           = 9088
+	DefaultPodPrometheusPort                           = "9091"
 )
 
 // InferenceService Internal Annotations
@@ -106,15 +191,24 @@ var (
 	LoggerInternalAnnotationKey                      = InferenceServiceInternalAnnotationsPrefix + "/logger"
 	LoggerSinkUrlInternalAnnotationKey               = InferenceServiceInternalAnnotationsPrefix + "/logger-sink-url"
 	LoggerModeInternalAnnotationKey                  = InferenceServiceInternalAnnotationsPrefix + "/logger-mode"
+	LoggerDeliveryModeInternalAnnotationKey          = InferenceServiceInternalAnnotationsPrefix + "/logger-delivery-mode"
 	BatcherInternalAnnotationKey                     = InferenceServiceInternalAnnotationsPrefix + "/batcher"
 	BatcherMaxBatchSizeInternalAnnotationKey         = InferenceServiceInternalAnnotationsPrefix + "/batcher-max-batchsize"
 	BatcherMaxLatencyInternalAnnotationKey           = InferenceServiceInternalAnnotationsPrefix + "/batcher-max-latency"
+	AgentMiddlewareInternalAnnotationKey             = InferenceServiceInternalAnnotationsPrefix + "/agent-middleware"
+	ModelConversionInternalAnnotationKey             = InferenceServiceInternalAnnotationsPrefix + "/model-conversion"
+	SidecarLoggingInternalAnnotationKey              = InferenceServiceInternalAnnotationsPrefix + "/sidecar-logging"
+	AgentSecurityContextInternalAnnotationKey        = InferenceServiceInternalAnnotationsPrefix + "/agent-security-context"
+	CORSInternalAnnotationKey                        = InferenceServiceInternalAnnotationsPrefix + "/cors"
 	AgentShouldInjectAnnotationKey                   = InferenceServiceInternalAnnotationsPrefix + "/agent"
 	AgentModelConfigVolumeNameAnnotationKey          = InferenceServiceInternalAnnotationsPrefix + "/configVolumeName"
 	AgentModelConfigMountPathAnnotationKey           = InferenceServiceInternalAnnotationsPrefix + "/configMountPath"
 	AgentModelDirAnnotationKey                       = InferenceServiceInternalAnnotationsPrefix + "/modelDir"
 	PredictorHostAnnotationKey                       = InferenceServiceInternalAnnotationsPrefix + "/predictor-host"
 	PredictorProtocolAnnotationKey                   = InferenceServiceInternalAnnotationsPrefix + "/predictor-protocol"
+	DesiredSpecHashAnnotationKey                     = InferenceServiceInternalAnnotationsPrefix + "/desired-spec-hash"
+	RouterConfigHashAnnotationKey                    = InferenceServiceInternalAnnotationsPrefix + "/router-config-hash"
+	RouterRevisionAnnotationKey                      = InferenceServiceInternalAnnotationsPrefix + "/router-revision"
 )
 
 // kserve networking constants
@@ -124,6 +218,67 @@ const (
 	ClusterLocalDomain     = "svc.cluster.local"
 )
 
+// OpenShift router annotations which can be passed through from an InferenceService or InferenceGraph
+// onto the generated routes
+const (
+	OpenshiftRouterAnnotationPrefix            = "haproxy.router.openshift.io/"
+	RouteTimeoutAnnotationKey                  = OpenshiftRouterAnnotationPrefix + "timeout"
+	RouteTimeoutTunnelAnnotationKey            = OpenshiftRouterAnnotationPrefix + "timeout-tunnel"
+	RouteBalanceAnnotationKey                  = OpenshiftRouterAnnotationPrefix + "balance"
+	RouteIPWhitelistAnnotationKey              = OpenshiftRouterAnnotationPrefix + "ip_whitelist"
+	RouteDisableCookiesAnnotationKey           = OpenshiftRouterAnnotationPrefix + "disable_cookies"
+	RouteRateLimitConnectionsAnnotationKey     = OpenshiftRouterAnnotationPrefix + "rate-limit-connections"
+	RouteRateLimitConcurrentTCPAnnotationKey   = RouteRateLimitConnectionsAnnotationKey + ".concurrent-tcp"
+	RouteRateLimitHTTPRequestRateAnnotationKey = RouteRateLimitConnectionsAnnotationKey + ".rate-http"
+	RouteRateLimitTCPRequestRateAnnotationKey  = RouteRateLimitConnectionsAnnotationKey + ".rate-tcp"
+)
+
+var (
+	// RouteAnnotationAllowList is the list of router annotations which are copied onto generated routes.
+	// Any other annotation with the OpenshiftRouterAnnotationPrefix is rejected by the validation webhooks.
+	RouteAnnotationAllowList = []string{
+		RouteTimeoutAnnotationKey,
+		RouteTimeoutTunnelAnnotationKey,
+		RouteBalanceAnnotationKey,
+		RouteIPWhitelistAnnotationKey,
+		RouteDisableCookiesAnnotationKey,
+		RouteRateLimitConnectionsAnnotationKey,
+		RouteRateLimitConcurrentTCPAnnotationKey,
+		RouteRateLimitHTTPRequestRateAnnotationKey,
+		RouteRateLimitTCPRequestRateAnnotationKey,
+	}
+
+	// RouteBalanceAlgorithms is the list of load balancing algorithms supported by the OpenShift router
+	RouteBalanceAlgorithms = []string{"roundrobin", "leastconn", "source", "random"}
+)
+
+// OpenShift route TLS termination constants
+const (
+	RouteTLSTerminationAnnotationKey               = "serving.kserve.io/route-tls-termination"
+	OpenshiftRouteTerminationAnnotationKey         = "route.openshift.io/termination"
+	OpenshiftRouteDestinationCASecretAnnotationKey = "route.openshift.io/destination-ca-certificate-secret"
+	OpenshiftServiceCAConfigMapName                = "openshift-service-ca.crt"
+	OpenshiftServiceCAFileName                     = "service-ca.crt"
+	RouteDestinationCASecretSuffix                 = "-route-destination-ca"
+	// OpenshiftServingCertSecretAnnotationKey requests a serving certificate of the Service from the OpenShift
+	// service-ca operator, it is written to the named kubernetes.io/tls Secret
+	OpenshiftServingCertSecretAnnotationKey = "service.beta.openshift.io/serving-cert-secret-name"
+)
+
+// RouteTLSTerminationType is the TLS termination used by the OpenShift routes created for an InferenceService or an
+// InferenceGraph
+type RouteTLSTerminationType string
+
+const (
+	// RouteTLSTerminationEdge terminates TLS at the router and forwards plain HTTP to the backend
+	RouteTLSTerminationEdge RouteTLSTerminationType = "edge"
+	// RouteTLSTerminationReencrypt terminates TLS at the router and opens a new TLS connection to the backend,
+	// which is verified against the service-ca bundle
+	RouteTLSTerminationReencrypt RouteTLSTerminationType = "reencrypt"
+	// RouteTLSTerminationPassthrough forwards the encrypted traffic to the backend without terminating TLS at the router
+	RouteTLSTerminationPassthrough RouteTLSTerminationType = "passthrough"
+)
+
 // StorageSpec Constants
 var (
 	DefaultStorageSpecSecret     = "storage-config"
@@ -142,6 +297,59 @@ var (
 	IstioSidecarStatusAnnotation    = "sidecar.istio.io/status"
 )
 
+// Istio sidecar injection of the raw deployments
+var (
+	IstioSidecarInjectKey                 = "sidecar.istio.io/inject"
+	IstioExcludeInboundPortsAnnotationKey = "traffic.sidecar.istio.io/excludeInboundPorts"
+)
+
+// Namespace labels enrolling the namespace into the service mesh
+var (
+	IstioInjectionLabel      = "istio-injection"
+	IstioRevisionLabel       = "istio.io/rev"
+	ServiceMeshMemberOfLabel = "maistra.io/member-of"
+)
+
+// Fields of the raw Deployments mutated by the cluster admission which are preserved when checking for drift
+var (
+	// AdmissionInjectedContainerNames are the sidecar and init containers injected into the pod templates
+	AdmissionInjectedContainerNames = []string{
+		IstioInitContainerName,
+		"istio-proxy",
+		"istio-validation",
+		"linkerd-init",
+		"linkerd-proxy",
+	}
+	// AdmissionInjectedPodAnnotations are the pod template annotations set by the cluster or by kubectl
+	AdmissionInjectedPodAnnotations = []string{
+		IstioSidecarStatusAnnotation,
+		"kubectl.kubernetes.io/restartedAt",
+		"openshift.io/scc",
+	}
+)
+
+// Validation Jobs run against the new predictor revisions
+var (
+	ValidationJobTemplateKey               = "job"
+	ValidationJobLabel                     = KServeAPIGroupName + "/validation-job"
+	ValidationJobRevisionAnnotationKey     = KServeAPIGroupName + "/validated-revision"
+	DefaultValidationJobTimeoutSeconds     = int64(600)
+	ValidationInferenceServiceURLEnvVarKey = "INFERENCE_SERVICE_URL"
+	ValidationPredictorRevisionEnvVarKey   = "PREDICTOR_REVISION"
+)
+
+// Benchmark Jobs run against the new predictor revisions
+var (
+	BenchmarkPayloadKey               = "payload"
+	BenchmarkJobLabel                 = KServeAPIGroupName + "/benchmark-job"
+	BenchmarkJobRevisionAnnotationKey = KServeAPIGroupName + "/benchmarked-revision"
+	BenchmarkPayloadMountPath         = "/mnt/benchmark"
+	DefaultBenchmarkGRPCCall          = "inference.GRPCInferenceService/ModelInfer"
+	BenchmarkJobDeadlineMarginSeconds = int64(300)
+	BenchmarkContainerName            = "benchmark"
+	BenchmarkPayloadVolumeName        = "benchmark-payload"
+)
+
 type AutoscalerClassType string
 type AutoscalerMetricsType string
 type AutoScalerKPAMetricsType string
@@ -199,6 +407,7 @@ var (
 var (
 	PodMutatorWebhookName              = KServeName + "-pod-mutator-webhook"
 	ServingRuntimeValidatorWebhookName = KServeName + "-servingRuntime-validator-webhook"
+	CapacityValidatorWebhookName       = KServeName + "-capacity-validator-webhook"
 )
 
 // GPU Constants
@@ -256,13 +465,23 @@ const (
 	ProtocolVersionENV                          = "PROTOCOL_VERSION"
 )
 
+// OpenAIBasePath is the base path of the OpenAI compatible API served by the huggingface runtime
+const OpenAIBasePath = "/openai/v1"
+
 // InferenceService Endpoint Ports
 const (
 	InferenceServiceDefaultHttpPort     = "8080"
 	InferenceServiceDefaultAgentPortStr = "9081"
 	InferenceServiceDefaultAgentPort    = 9081
 	CommonDefaultHttpPort               = 80
+	CommonDefaultHttpsPort              = 443
+	HttpsPortName                       = "https"
 	AggregateMetricsPortName            = "aggr-metric"
+
+	// InferenceServiceAgentMetricsPort is the port of the metrics endpoint of the agent, it is enabled whenever the
+	// agent is injected
+	InferenceServiceAgentMetricsPort = 9089
+	AgentMetricsPortName             = "agent-metrics"
 )
 
 // Labels to put on kservice
@@ -272,6 +491,10 @@ const (
 	KServiceEndpointLabel  = "endpoint"
 )
 
+// PartOfLabel is put on every resource generated for an InferenceService or an InferenceGraph together with the
+// owner label, its value is the name of the owner so that a whole model stack can be selected, e.g. for backup and restore
+const PartOfLabel = "kserve.io/part-of"
+
 // Labels for TrainedModel
 const (
 	ParentInferenceServiceLabel = "inferenceservice"
@@ -315,6 +538,14 @@ const DefaultCaBundleFileName = "cabundle.crt"
 // Default CA bundle configmap name that will be created in the user namespace.
 const DefaultGlobalCaBundleConfigMapName = "global-ca-bundle"
 
+// Grafana dashboard configmap created in the user namespaces, the sidecar of Grafana writes each data key to a file
+const (
+	DashboardConfigMapName     = "kserve-dashboard"
+	DashboardFileName          = "kserve-dashboard.json"
+	DefaultDashboardLabel      = "grafana_dashboard"
+	DefaultDashboardLabelValue = "1"
+)
+
 // Custom CA bundle configmap Environment Variables
 const (
 	CaBundleConfigMapNameEnvVarKey   = "CA_BUNDLE_CONFIGMAP_NAME"
@@ -329,11 +560,28 @@ const (
 	ModelDir              = DefaultModelLocalMountPath
 )
 
+// Logger spill volume of the agent
+const (
+	LoggerSpillVolumeName = "logger-spill"
+	LoggerSpillDir        = "/mnt/logger-spill"
+)
+
+// Request replay token volume of the agent
+const (
+	ReplayTokenVolumeName = "replay-token"
+	ReplayTokenDir        = "/var/run/secrets/kserve/replay"
+	ReplayTokenSecretKey  = "token"
+)
+
 var (
 	ServiceAnnotationDisallowedList = []string{
 		autoscaling.MinScaleAnnotationKey,
 		autoscaling.MaxScaleAnnotationKey,
 		StorageInitializerSourceUriInternalAnnotationKey,
+		PausedAnnotationKey,
+		EstimatedHourlyCostAnnotationKey,
+		StopAnnotationKey,
+		PreemptedByAnnotationKey,
 		"kubectl.kubernetes.io/last-applied-configuration",
 	}
 
@@ -440,8 +688,9 @@ const (
 
 // CRD Kinds
 const (
-	IstioVirtualServiceKind = "VirtualService"
-	KnativeServiceKind      = "Service"
+	IstioVirtualServiceKind     = "VirtualService"
+	IstioPeerAuthenticationKind = "PeerAuthentication"
+	KnativeServiceKind          = "Service"
 )
 
 // GetRawServiceLabel generate native service label
@@ -449,6 +698,39 @@ func GetRawServiceLabel(service string) string {
 	return "isvc." + service
 }
 
+// InferenceServiceOwnerLabels returns the labels identifying the resources generated for an InferenceService
+func InferenceServiceOwnerLabels(name string) map[string]string {
+	return map[string]string{
+		InferenceServicePodLabelKey: name,
+		PartOfLabel:                 name,
+	}
+}
+
+// InferenceGraphOwnerLabels returns the labels identifying the resources generated for an InferenceGraph
+func InferenceGraphOwnerLabels(name string) map[string]string {
+	return map[string]string{
+		InferenceGraphLabel: name,
+		PartOfLabel:         name,
+	}
+}
+
+func (c CheckResultType) String() string {
+	switch c {
+	case CheckResultCreate:
+		return "Create"
+	case CheckResultUpdate:
+		return "Update"
+	case CheckResultExisted:
+		return "Existed"
+	case CheckResultDelete:
+		return "Delete"
+	case CheckResultSkipped:
+		return "Skipped"
+	default:
+		return "Unknown"
+	}
+}
+
 func (e InferenceServiceComponent) String() string {
 	return string(e)
 }
@@ -521,10 +803,28 @@ func CanaryServiceName(name string, component InferenceServiceComponent) string
 	return name + "-" + component.String() + "-" + InferenceServiceCanary
 }
 
+func RouteDestinationCASecretName(name string) string {
+	return name + RouteDestinationCASecretSuffix
+}
+
 func ModelConfigName(inferenceserviceName string, shardId int) string {
 	return fmt.Sprintf("modelconfig-%s-%d", inferenceserviceName, shardId)
 }
 
+// ValidationJobName is the name of the Job validating a revision of the predictor
+func ValidationJobName(isvcName string, revision string) string {
+	hash := fnv.New32a()
+	hash.Write([]byte(revision))
+	return fmt.Sprintf("%s-validation-%08x", isvcName, hash.Sum32())
+}
+
+// BenchmarkJobName is the name of the Job benchmarking a revision of the predictor
+func BenchmarkJobName(isvcName string, revision string) string {
+	hash := fnv.New32a()
+	hash.Write([]byte(revision))
+	return fmt.Sprintf("%s-benchmark-%08x", isvcName, hash.Sum32())
+}
+
 func InferenceServicePrefix(name string) string {
 	return fmt.Sprintf("/v1/models/%s", name)
 }
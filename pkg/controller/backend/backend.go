@@ -0,0 +1,134 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package backend implements the deployment modes of the InferenceServices and the InferenceGraphs. The controllers
+// resolve the DeploymentBackend registered for the deployment mode of a resource and delegate the mode specific steps
+// to it, so that a new deployment mode is added by registering its backend.
+package backend
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// DeploymentBackend implements the steps of a deployment mode which differ between the modes
+type DeploymentBackend interface {
+	// Mode returns the deployment mode implemented by the backend
+	Mode() constants.DeploymentModeType
+	// Admit returns a *RejectedError when the resources of the namespace cannot be deployed with the backend, e.g. the
+	// API the workloads are created with is not installed
+	Admit(ctx context.Context, namespace string) error
+	// Workloads returns the empty lists of the workloads deleted while a resource is stopped or outside its active
+	// hours, the services are kept so that the addresses do not change
+	Workloads() []client.ObjectList
+	// ReconcilesPredictor returns whether the predictor of the InferenceService is reconciled by KServe
+	ReconcilesPredictor() bool
+	// ReconcileIngress reconciles the ingress of the InferenceService
+	ReconcileIngress(isvc *v1beta1.InferenceService, ingressConfig *v1beta1.IngressConfig) error
+	// PropagateStatus propagates the conditions of the workloads which are specific to the backend to the status of
+	// the InferenceService once its components are reconciled
+	PropagateStatus(isvc *v1beta1.InferenceService)
+	// StatusEqual returns whether the statuses of the InferenceService are equal in the fields managed by KServe
+	StatusEqual(s1, s2 v1beta1.InferenceServiceStatus) bool
+}
+
+// Options are the clients the backends are created with
+type Options struct {
+	Client       client.Client
+	Clientset    kubernetes.Interface
+	ClientConfig *rest.Config
+	Scheme       *runtime.Scheme
+	Recorder     record.EventRecorder
+}
+
+// Factory creates the backend of a deployment mode
+type Factory func(opts Options) DeploymentBackend
+
+// RejectedError reports why the resources of a namespace cannot be deployed with a backend
+type RejectedError struct {
+	// Reason is the reason of the warning event recorded on the rejected resource
+	Reason string
+	// Message is the message of the warning event recorded on the rejected resource
+	Message string
+	// Cause completes the error returned by the controller, e.g. "Knative Serving is not available"
+	Cause string
+	// Condition is the type of the condition set to false with the message on the rejected resource, if any
+	Condition apis.ConditionType
+}
+
+func (e *RejectedError) Error() string {
+	return e.Message
+}
+
+var (
+	factoriesLock sync.RWMutex
+	factories     = map[constants.DeploymentModeType]Factory{}
+)
+
+// Register makes the backend of a deployment mode available to the controllers, it panics when a backend is already
+// registered for the mode
+func Register(mode constants.DeploymentModeType, factory Factory) {
+	factoriesLock.Lock()
+	defer factoriesLock.Unlock()
+	if factory == nil {
+		panic("backend: Register factory is nil for " + string(mode))
+	}
+	if _, dup := factories[mode]; dup {
+		panic("backend: Register called twice for " + string(mode))
+	}
+	factories[mode] = factory
+}
+
+// New creates the backend registered for the deployment mode
+func New(mode constants.DeploymentModeType, opts Options) (DeploymentBackend, error) {
+	factoriesLock.RLock()
+	factory, ok := factories[mode]
+	factoriesLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no backend is registered for the deployment mode %q", mode)
+	}
+	return factory(opts), nil
+}
+
+// Modes returns the sorted deployment modes with a registered backend
+func Modes() []constants.DeploymentModeType {
+	factoriesLock.RLock()
+	defer factoriesLock.RUnlock()
+	modes := make([]constants.DeploymentModeType, 0, len(factories))
+	for mode := range factories {
+		modes = append(modes, mode)
+	}
+	sort.Slice(modes, func(i, j int) bool { return modes[i] < modes[j] })
+	return modes
+}
+
+func init() {
+	Register(constants.Serverless, newServerlessBackend)
+	Register(constants.RawDeployment, newRawBackend)
+	Register(constants.ModelMeshDeployment, newModelMeshBackend)
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backend
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"knative.dev/pkg/apis"
+	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+func TestNew(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	g.Expect(Modes()).To(gomega.Equal([]constants.DeploymentModeType{
+		constants.ModelMeshDeployment, constants.RawDeployment, constants.Serverless}))
+
+	for _, mode := range Modes() {
+		deploymentBackend, err := New(mode, Options{})
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(deploymentBackend.Mode()).To(gomega.Equal(mode))
+	}
+
+	_, err := New("LeaderWorkerSet", Options{})
+	g.Expect(err).To(gomega.MatchError(`no backend is registered for the deployment mode "LeaderWorkerSet"`))
+	g.Expect(func() { Register(constants.Serverless, newServerlessBackend) }).To(gomega.Panic())
+}
+
+func TestWorkloads(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[constants.DeploymentModeType]int{
+		constants.Serverless:          1,
+		constants.RawDeployment:       2,
+		constants.ModelMeshDeployment: 0,
+	}
+	for mode, expected := range scenarios {
+		deploymentBackend, err := New(mode, Options{})
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(deploymentBackend.Workloads()).To(gomega.HaveLen(expected), string(mode))
+		g.Expect(deploymentBackend.ReconcilesPredictor()).To(gomega.Equal(mode != constants.ModelMeshDeployment))
+	}
+}
+
+func TestServerlessAdmit(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	newClientset := func(meshConfig string) *fakeclientset.Clientset {
+		return fakeclientset.NewSimpleClientset([]runtime.Object{
+			&v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+				Data:       map[string]string{v1beta1.MeshConfigName: meshConfig},
+			},
+			&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "plain"}},
+		}...)
+	}
+	servingResources := &metav1.APIResourceList{
+		GroupVersion: knservingv1.SchemeGroupVersion.String(),
+		APIResources: []metav1.APIResource{{Name: "services", Kind: constants.KnativeServiceKind}},
+	}
+	scenarios := map[string]struct {
+		resources  *metav1.APIResourceList
+		meshConfig string
+		cause      string
+		condition  apis.ConditionType
+	}{
+		"admitted": {
+			resources:  servingResources,
+			meshConfig: `{}`,
+		},
+		"knative not installed": {
+			meshConfig: `{}`,
+			cause:      "Knative Serving is not available",
+		},
+		"namespace not enrolled": {
+			resources:  servingResources,
+			meshConfig: `{"requireNamespaceMembership": true}`,
+			cause:      "its namespace is not enrolled into the service mesh",
+			condition:  v1beta1.ServiceMeshMember,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			utils.SetAvailableResourcesForApi(knservingv1.SchemeGroupVersion.String(), scenario.resources)
+			deploymentBackend, err := New(constants.Serverless, Options{Clientset: newClientset(scenario.meshConfig)})
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+
+			err = deploymentBackend.Admit(context.TODO(), "plain")
+			if scenario.cause == "" {
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+				return
+			}
+			var rejected *RejectedError
+			g.Expect(errors.As(err, &rejected)).To(gomega.BeTrue())
+			g.Expect(rejected.Reason).To(gomega.Equal(ServerlessModeRejectedReason))
+			g.Expect(rejected.Cause).To(gomega.Equal(scenario.cause))
+			g.Expect(rejected.Condition).To(gomega.Equal(scenario.condition))
+		})
+	}
+}
+
+func TestModelMeshStatusEqual(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	deploymentBackend, err := New(constants.ModelMeshDeployment, Options{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	s1 := v1beta1.InferenceServiceStatus{}
+	s2 := v1beta1.InferenceServiceStatus{
+		Components: map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+			v1beta1.PredictorComponent: {LatestCreatedRevision: "predictor-00001"},
+		},
+	}
+	// the predictor is managed by the ModelMesh controllers
+	g.Expect(deploymentBackend.StatusEqual(s1, s2)).To(gomega.BeTrue())
+	s2.Components[v1beta1.TransformerComponent] = v1beta1.ComponentStatusSpec{LatestCreatedRevision: "transformer-00001"}
+	g.Expect(deploymentBackend.StatusEqual(s1, s2)).To(gomega.BeFalse())
+
+	rawBackend, err := New(constants.RawDeployment, Options{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(rawBackend.StatusEqual(s1, v1beta1.InferenceServiceStatus{
+		Components: map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+			v1beta1.PredictorComponent: {LatestCreatedRevision: "predictor-00001"},
+		},
+	})).To(gomega.BeFalse())
+}
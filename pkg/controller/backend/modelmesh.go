@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backend
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/ingress"
+)
+
+// modelMeshBackend leaves the predictor to the ModelMesh controllers, only the transformer and the explainer are
+// reconciled by KServe
+type modelMeshBackend struct {
+	opts Options
+}
+
+func newModelMeshBackend(opts Options) DeploymentBackend {
+	return &modelMeshBackend{opts: opts}
+}
+
+func (b *modelMeshBackend) Mode() constants.DeploymentModeType {
+	return constants.ModelMeshDeployment
+}
+
+func (b *modelMeshBackend) Admit(ctx context.Context, namespace string) error {
+	return nil
+}
+
+// Workloads returns no workloads, the ModelMesh deployments are shared by the InferenceServices
+func (b *modelMeshBackend) Workloads() []client.ObjectList {
+	return nil
+}
+
+func (b *modelMeshBackend) ReconcilesPredictor() bool {
+	return false
+}
+
+func (b *modelMeshBackend) ReconcileIngress(isvc *v1beta1.InferenceService, ingressConfig *v1beta1.IngressConfig) error {
+	return ingress.NewIngressReconciler(b.opts.Client, b.opts.Clientset, b.opts.Scheme, ingressConfig).Reconcile(isvc)
+}
+
+func (b *modelMeshBackend) PropagateStatus(isvc *v1beta1.InferenceService) {}
+
+// StatusEqual reduces the status scope to compare, the predictor and the model status are managed by the ModelMesh
+// controllers
+func (b *modelMeshBackend) StatusEqual(s1, s2 v1beta1.InferenceServiceStatus) bool {
+	return equality.Semantic.DeepEqual(s1.Address, s2.Address) &&
+		equality.Semantic.DeepEqual(s1.URL, s2.URL) &&
+		equality.Semantic.DeepEqual(s1.Status, s2.Status) &&
+		equality.Semantic.DeepEqual(s1.Components[v1beta1.TransformerComponent], s2.Components[v1beta1.TransformerComponent]) &&
+		equality.Semantic.DeepEqual(s1.Components[v1beta1.ExplainerComponent], s2.Components[v1beta1.ExplainerComponent])
+}
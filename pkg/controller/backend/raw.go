@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backend
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/ingress"
+)
+
+// rawBackend deploys the workloads as Deployments scaled by HorizontalPodAutoscalers behind Kubernetes Ingresses
+type rawBackend struct {
+	opts Options
+}
+
+func newRawBackend(opts Options) DeploymentBackend {
+	return &rawBackend{opts: opts}
+}
+
+func (b *rawBackend) Mode() constants.DeploymentModeType {
+	return constants.RawDeployment
+}
+
+func (b *rawBackend) Admit(ctx context.Context, namespace string) error {
+	return nil
+}
+
+func (b *rawBackend) Workloads() []client.ObjectList {
+	return []client.ObjectList{&appsv1.DeploymentList{}, &autoscalingv2.HorizontalPodAutoscalerList{}}
+}
+
+func (b *rawBackend) ReconcilesPredictor() bool {
+	return true
+}
+
+func (b *rawBackend) ReconcileIngress(isvc *v1beta1.InferenceService, ingressConfig *v1beta1.IngressConfig) error {
+	reconciler, err := ingress.NewRawIngressReconciler(b.opts.Client, b.opts.Clientset, b.opts.ClientConfig, b.opts.Scheme, b.opts.Recorder,
+		ingressConfig)
+	if err != nil {
+		return err
+	}
+	return reconciler.Reconcile(isvc)
+}
+
+func (b *rawBackend) PropagateStatus(isvc *v1beta1.InferenceService) {}
+
+func (b *rawBackend) StatusEqual(s1, s2 v1beta1.InferenceServiceStatus) bool {
+	return equality.Semantic.DeepEqual(s1, s2)
+}
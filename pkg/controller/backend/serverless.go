@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backend
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/ingress"
+	isvcutils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+// ServerlessModeRejectedReason is the reason of the event recorded when the Serverless mode cannot be used
+const ServerlessModeRejectedReason = "ServerlessModeRejected"
+
+// serverlessBackend deploys the workloads as Knative Services behind the Istio ingress gateway
+type serverlessBackend struct {
+	opts Options
+}
+
+func newServerlessBackend(opts Options) DeploymentBackend {
+	return &serverlessBackend{opts: opts}
+}
+
+func (b *serverlessBackend) Mode() constants.DeploymentModeType {
+	return constants.Serverless
+}
+
+// Admit rejects the namespaces when Knative Serving is not installed or when they are not enrolled into the service
+// mesh while the mesh config requires it
+func (b *serverlessBackend) Admit(ctx context.Context, namespace string) error {
+	ksvcAvailable, err := utils.IsCrdAvailable(b.opts.ClientConfig, knservingv1.SchemeGroupVersion.String(),
+		constants.KnativeServiceKind)
+	if err != nil {
+		return err
+	}
+	if !ksvcAvailable {
+		return &RejectedError{
+			Reason:  ServerlessModeRejectedReason,
+			Message: "It is not possible to use Serverless deployment mode when Knative Services are not available",
+			Cause:   "Knative Serving is not available",
+		}
+	}
+
+	member, err := isvcutils.CheckServiceMeshMembership(b.opts.Clientset, namespace)
+	if err != nil {
+		return err
+	}
+	if !member {
+		return &RejectedError{
+			Reason: ServerlessModeRejectedReason,
+			Message: fmt.Sprintf("The namespace %s is not enrolled into the service mesh, it must be added to the "+
+				"ServiceMeshMemberRoll or labeled with %s=enabled", namespace, constants.IstioInjectionLabel),
+			Cause:     "its namespace is not enrolled into the service mesh",
+			Condition: v1beta1.ServiceMeshMember,
+		}
+	}
+	return nil
+}
+
+func (b *serverlessBackend) Workloads() []client.ObjectList {
+	return []client.ObjectList{&knservingv1.ServiceList{}}
+}
+
+func (b *serverlessBackend) ReconcilesPredictor() bool {
+	return true
+}
+
+func (b *serverlessBackend) ReconcileIngress(isvc *v1beta1.InferenceService, ingressConfig *v1beta1.IngressConfig) error {
+	return ingress.NewIngressReconciler(b.opts.Client, b.opts.Clientset, b.opts.Scheme, ingressConfig).Reconcile(isvc)
+}
+
+// PropagateStatus aggregates the RoutesReady and LatestDeploymentReady conditions of the Knative Services of the
+// components
+func (b *serverlessBackend) PropagateStatus(isvc *v1beta1.InferenceService) {
+	componentList := []v1beta1.ComponentType{v1beta1.PredictorComponent}
+	if isvc.Spec.Transformer != nil {
+		componentList = append(componentList, v1beta1.TransformerComponent)
+	}
+	if isvc.Spec.Explainer != nil {
+		componentList = append(componentList, v1beta1.ExplainerComponent)
+	}
+	isvc.Status.PropagateCrossComponentStatus(componentList, v1beta1.RoutesReady)
+	isvc.Status.PropagateCrossComponentStatus(componentList, v1beta1.LatestDeploymentReady)
+}
+
+func (b *serverlessBackend) StatusEqual(s1, s2 v1beta1.InferenceServiceStatus) bool {
+	return equality.Semantic.DeepEqual(s1, s2)
+}
@@ -0,0 +1,354 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/deployment"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+// prevTrafficTag is the tag of the traffic target of the previous rolled out revision during a canary rollout
+const prevTrafficTag = "prev"
+
+// knativeRouterTraffic returns the traffic split between the latest revision of the router and the last rolled out
+// revision when the graph sets a canary traffic percent. The latest revision serves all the traffic otherwise.
+func knativeRouterTraffic(graph *v1alpha1api.InferenceGraph) []knservingv1.TrafficTarget {
+	percent := graph.Spec.CanaryTrafficPercent
+	if percent == nil || graph.Status.Revisions == nil || graph.Status.Revisions.LatestRolledoutRevision == "" {
+		return nil
+	}
+	traffic := []knservingv1.TrafficTarget{
+		{
+			LatestRevision: proto.Bool(true),
+			Percent:        proto.Int64(*percent),
+		},
+	}
+	if *percent < 100 {
+		traffic = append(traffic, knservingv1.TrafficTarget{
+			RevisionName:   graph.Status.Revisions.LatestRolledoutRevision,
+			LatestRevision: proto.Bool(false),
+			Percent:        proto.Int64(100 - *percent),
+			Tag:            prevTrafficTag,
+		})
+	}
+	return traffic
+}
+
+// propagateKnativeRevisions records the revisions of the Knative Service of the router. The latest ready revision is
+// rolled out once it serves all the traffic, and the rolled out revision is rolled back when the traffic is split
+// again while the latest revision did not change.
+func propagateKnativeRevisions(status *v1alpha1api.InferenceGraphStatus, serviceStatus *knservingv1.ServiceStatus) {
+	revisions := status.Revisions
+	if revisions == nil {
+		revisions = &v1alpha1api.RouterRevisionStatus{}
+	}
+	revisions.LatestCreatedRevision = serviceStatus.LatestCreatedRevisionName
+	revisionTraffic := map[string]int64{}
+	for _, traffic := range serviceStatus.Traffic {
+		if traffic.Percent != nil {
+			revisionTraffic[traffic.RevisionName] += *traffic.Percent
+		}
+	}
+	revisions.Traffic = nil
+	for _, traffic := range serviceStatus.Traffic {
+		target := v1alpha1api.RouterTrafficTarget{
+			RevisionName:   traffic.RevisionName,
+			LatestRevision: traffic.LatestRevision != nil && *traffic.LatestRevision,
+			Tag:            traffic.Tag,
+		}
+		if traffic.Percent != nil {
+			target.Percent = *traffic.Percent
+		}
+		revisions.Traffic = append(revisions.Traffic, target)
+		if traffic.RevisionName != serviceStatus.LatestReadyRevisionName || !target.LatestRevision {
+			continue
+		}
+		if revisions.LatestRolledoutRevision != serviceStatus.LatestReadyRevisionName {
+			if target.Percent == 100 {
+				revisions.PreviousRolledoutRevision = revisions.LatestRolledoutRevision
+				revisions.LatestRolledoutRevision = serviceStatus.LatestReadyRevisionName
+			}
+		} else if serviceStatus.LatestReadyRevisionName == serviceStatus.LatestCreatedRevisionName &&
+			target.Percent < 100 && revisionTraffic[traffic.RevisionName] == 100 &&
+			revisions.PreviousRolledoutRevision != "" {
+			// the rolled out revision is split with itself, the canary traffic percent was set again after it was
+			// rolled out
+			revisions.LatestRolledoutRevision = revisions.PreviousRolledoutRevision
+		}
+	}
+	revisions.LatestReadyRevision = serviceStatus.LatestReadyRevisionName
+	status.Revisions = revisions
+}
+
+// routerRevision is a revision of the router pod spec in raw deployment mode
+type routerRevision struct {
+	// Name of the revision, the name of the graph suffixed with the hash of the pod spec and of the injected config
+	Name string
+	// ConfigHash is the hash of the configuration injected into the router pods
+	ConfigHash string
+	// PodSpec is the pod spec of the router
+	PodSpec *v1.PodSpec
+}
+
+// newRouterRevision returns the revision of the router pod spec, the revision changes whenever the pod spec or the
+// configuration injected into the router pods changes
+func newRouterRevision(clientset kubernetes.Interface, graph *v1alpha1api.InferenceGraph, config *RouterConfig,
+	podSpec *v1.PodSpec) (*routerRevision, error) {
+	configHash, err := routerConfigHash(clientset, graph, config, &podSpec.Containers[0])
+	if err != nil {
+		return nil, errors.Wrapf(err, "fails to hash the router config of inference graph")
+	}
+	data, err := json.Marshal(podSpec)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(append(data, configHash...))
+	return &routerRevision{
+		Name:       fmt.Sprintf("%s-%x", graph.Name, hash[:5]),
+		ConfigHash: configHash,
+		PodSpec:    podSpec,
+	}, nil
+}
+
+// deployedRouterRevision returns the revision the Deployment of the router runs, it returns nil when the Deployment was
+// created before the revisions were recorded
+func deployedRouterRevision(deployment *appsv1.Deployment) *routerRevision {
+	name := deployment.Annotations[constants.RouterRevisionAnnotationKey]
+	if name == "" {
+		return nil
+	}
+	return &routerRevision{
+		Name:       name,
+		ConfigHash: deployment.Annotations[constants.RouterConfigHashAnnotationKey],
+		PodSpec:    deployment.Spec.Template.Spec.DeepCopy(),
+	}
+}
+
+// annotations returns the annotations recording the revision on the resources of the router
+func (r *routerRevision) annotations() map[string]string {
+	return map[string]string{
+		constants.RouterConfigHashAnnotationKey: r.ConfigHash,
+		constants.RouterRevisionAnnotationKey:   r.Name,
+	}
+}
+
+/*
+Returns the revision of the stable Deployment of a raw router and the revision of its canary Deployment. While the graph
+sets a canary traffic percent below 100, the stable Deployment keeps the revision it runs and the latest revision is
+deployed by the canary Deployment, unless the percent is 0. The latest revision is deployed by the stable Deployment
+otherwise.
+*/
+func (r *InferenceGraphReconciler) planRawRollout(ctx context.Context, graph *v1alpha1api.InferenceGraph,
+	latest *routerRevision) (stable *routerRevision, canary *routerRevision, err error) {
+	percent := graph.Spec.CanaryTrafficPercent
+	if percent == nil || *percent >= 100 {
+		return latest, nil, nil
+	}
+	existing := &appsv1.Deployment{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: graph.Namespace, Name: graph.Name}, existing); err != nil {
+		if apierr.IsNotFound(err) {
+			return latest, nil, nil
+		}
+		return nil, nil, err
+	}
+	stable = deployedRouterRevision(existing)
+	if stable == nil || stable.Name == latest.Name {
+		return latest, nil, nil
+	}
+	if *percent == 0 {
+		return stable, nil, nil
+	}
+	return stable, latest, nil
+}
+
+/*
+Returns the replicas of the stable and canary Deployments of a raw router delivering the canary traffic percent of the
+requests, which the Service of the graph balances over the pods of both Deployments. The stable Deployment is scaled up
+to the smallest number of replicas the percent can be split from, within the maximum replicas of the graph. The canary
+Deployment runs the number of replicas closest to the percent, at least one, so the split is only approximated when the
+maximum replicas are too low.
+*/
+func rawCanarySplit(stableReplicas int32, maxReplicas int32, percent int64) (stable int32, canary int32) {
+	stable = max(stableReplicas, 1)
+	// the stable replicas of the smallest exact split, 9 stable replicas for 1 canary replica at 10%
+	divisor := int64(100)
+	for percent%divisor != 0 || 100%divisor != 0 {
+		divisor--
+	}
+	if unit := int32((100 - percent) / divisor); stable < unit {
+		stable = min(unit, max(maxReplicas, stable))
+	}
+	canary = int32((int64(stable)*percent*2 + 100 - percent) / ((100 - percent) * 2))
+	return stable, max(canary, 1)
+}
+
+// rawStableMinReplicas returns the minimum replicas of the stable Deployment of a raw router during a canary rollout
+func rawStableMinReplicas(graph *v1alpha1api.InferenceGraph) int32 {
+	minReplicas := int32(constants.DefaultMinReplicas)
+	if graph.Spec.MinReplicas != nil && int32(*graph.Spec.MinReplicas) > minReplicas {
+		minReplicas = int32(*graph.Spec.MinReplicas)
+	}
+	stable, _ := rawCanarySplit(minReplicas, int32(graph.Spec.MaxReplicas), *graph.Spec.CanaryTrafficPercent)
+	return stable
+}
+
+// rawCanaryTrafficPercent returns the percent of the requests the ready pods of the canary Deployment serve along with
+// the ready pods of the stable Deployment
+func rawCanaryTrafficPercent(stable *appsv1.Deployment, canary *appsv1.Deployment) int64 {
+	if canary == nil || canary.Status.ReadyReplicas == 0 {
+		return 0
+	}
+	return canaryPercent(stable.Status.ReadyReplicas, canary.Status.ReadyReplicas)
+}
+
+// canaryPercent returns the rounded percent of the canary replicas among the stable and canary replicas
+func canaryPercent(stableReplicas int32, canaryReplicas int32) int64 {
+	total := int64(stableReplicas + canaryReplicas)
+	return (int64(canaryReplicas)*200 + total) / (total * 2)
+}
+
+/*
+Reconciles the canary Deployment of a raw router, it is deleted when no canary rollout is in progress. The canary
+Deployment runs the canary revision with the metadata of the stable Deployment, its pods are selected by the Service of
+the graph along with the stable pods. It is not autoscaled, its replicas follow the replicas of the stable Deployment so
+that the canary pods serve the canary traffic percent of the requests.
+*/
+func (r *InferenceGraphReconciler) reconcileRawCanary(ctx context.Context, graph *v1alpha1api.InferenceGraph,
+	canary *routerRevision, stable *appsv1.Deployment, config *RouterConfig) (*appsv1.Deployment, error) {
+	name := graph.Name + constants.RouterCanaryDeploymentSuffix
+	if canary == nil {
+		existing := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: graph.Namespace}}
+		return nil, client.IgnoreNotFound(r.Delete(ctx, existing, client.PropagationPolicy(metav1.DeletePropagationBackground)))
+	}
+
+	objectMeta, componentExtSpec := constructForRawDeployment(graph)
+	objectMeta.Name = name
+	// the labels are shared with the graph and the stable Deployment
+	objectMeta.Labels = maps.Clone(objectMeta.Labels)
+	objectMeta.Annotations = utils.Union(routerPrometheusAnnotations(config), objectMeta.Annotations, canary.annotations())
+	driftPolicyConfig, err := v1beta1.NewDriftPolicyConfig(r.Clientset)
+	if err != nil {
+		return nil, err
+	}
+	deployConfig, err := v1beta1.NewDeployConfig(r.Clientset)
+	if err != nil {
+		return nil, err
+	}
+	meshConfig, err := v1beta1.NewMeshConfig(r.Clientset)
+	if err != nil {
+		return nil, err
+	}
+	reconciler := deployment.NewDeploymentReconciler(r.Client, r.Scheme, r.Recorder, driftPolicyConfig.Deployment,
+		deployConfig, meshConfig, objectMeta, &componentExtSpec, canary.PodSpec.DeepCopy())
+	stableReplicas := int32(1)
+	if stable.Spec.Replicas != nil {
+		stableReplicas = *stable.Spec.Replicas
+	}
+	percent := *graph.Spec.CanaryTrafficPercent
+	stableReplicas, replicas := rawCanarySplit(stableReplicas, int32(graph.Spec.MaxReplicas), percent)
+	if split := canaryPercent(stableReplicas, replicas); split != percent {
+		r.Recorder.Eventf(graph, v1.EventTypeWarning, "CanaryTrafficApproximated",
+			"%d%% of the requests are served by the canary revision instead of %d%%, raise the maximum replicas to "+
+				"split the traffic by the canary traffic percent", split, percent)
+	}
+	reconciler.Deployment.Spec.Replicas = &replicas
+	if err := controllerutil.SetControllerReference(graph, reconciler.Deployment, r.Scheme); err != nil {
+		return nil, errors.Wrapf(err, "fails to set canary deployment owner reference for inference graph")
+	}
+	canaryDeployment, err := reconciler.Reconcile()
+	if err != nil {
+		return nil, errors.Wrapf(err, "fails to reconcile inference graph canary deployment")
+	}
+	// the replicas are not compared by the deployment reconciler, as they are usually managed by an autoscaler
+	if canaryDeployment.Spec.Replicas == nil || *canaryDeployment.Spec.Replicas != replicas {
+		canaryDeployment.Spec.Replicas = &replicas
+		if err := r.Update(ctx, canaryDeployment); err != nil {
+			return nil, errors.Wrapf(err, "fails to scale inference graph canary deployment")
+		}
+	}
+	return canaryDeployment, nil
+}
+
+/*
+Records the revisions of a raw router once its stable Deployment is available. The latest revision is rolled out once
+the stable Deployment runs it, it is ready once the stable or the canary Deployment running it is available. The
+traffic reports the percent of the requests the ready canary pods serve.
+*/
+func propagateRawRevisions(status *v1alpha1api.InferenceGraphStatus, latest *routerRevision, stable *routerRevision,
+	canaryAvailable bool, percent int64) {
+	revisions := status.Revisions
+	if revisions == nil {
+		revisions = &v1alpha1api.RouterRevisionStatus{}
+	}
+	revisions.LatestCreatedRevision = latest.Name
+	if stable.Name == latest.Name {
+		if revisions.LatestRolledoutRevision != latest.Name {
+			revisions.PreviousRolledoutRevision = revisions.LatestRolledoutRevision
+			revisions.LatestRolledoutRevision = latest.Name
+		}
+		revisions.LatestReadyRevision = latest.Name
+		revisions.Traffic = []v1alpha1api.RouterTrafficTarget{
+			{RevisionName: latest.Name, LatestRevision: true, Percent: 100},
+		}
+		status.Revisions = revisions
+		return
+	}
+	revisions.LatestRolledoutRevision = stable.Name
+	revisions.LatestReadyRevision = stable.Name
+	if canaryAvailable {
+		revisions.LatestReadyRevision = latest.Name
+	}
+	revisions.Traffic = []v1alpha1api.RouterTrafficTarget{
+		{RevisionName: latest.Name, LatestRevision: true, Percent: percent},
+		{RevisionName: stable.Name, Percent: 100 - percent, Tag: prevTrafficTag},
+	}
+	status.Revisions = revisions
+}
+
+// deploymentAvailable returns true when the Deployment has the Available condition
+func deploymentAvailable(deployment *appsv1.Deployment) bool {
+	if deployment == nil {
+		return false
+	}
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentAvailable {
+			return true
+		}
+	}
+	return false
+}
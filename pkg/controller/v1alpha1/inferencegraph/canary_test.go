@@ -0,0 +1,275 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestKnativeRouterTraffic(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	graph := &v1alpha1api.InferenceGraph{ObjectMeta: metav1.ObjectMeta{Name: "graph", Namespace: "default"}}
+	g.Expect(knativeRouterTraffic(graph)).To(gomega.BeNil())
+
+	// the latest revision serves all the traffic until a revision is rolled out
+	graph.Spec.CanaryTrafficPercent = proto.Int64(10)
+	g.Expect(knativeRouterTraffic(graph)).To(gomega.BeNil())
+
+	graph.Status.Revisions = &v1alpha1api.RouterRevisionStatus{LatestRolledoutRevision: "graph-00001"}
+	g.Expect(knativeRouterTraffic(graph)).To(gomega.Equal([]knservingv1.TrafficTarget{
+		{LatestRevision: proto.Bool(true), Percent: proto.Int64(10)},
+		{RevisionName: "graph-00001", LatestRevision: proto.Bool(false), Percent: proto.Int64(90), Tag: "prev"},
+	}))
+
+	graph.Spec.CanaryTrafficPercent = proto.Int64(100)
+	g.Expect(knativeRouterTraffic(graph)).To(gomega.Equal([]knservingv1.TrafficTarget{
+		{LatestRevision: proto.Bool(true), Percent: proto.Int64(100)},
+	}))
+}
+
+func TestPropagateKnativeRevisions(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	serviceStatus := func(created string, ready string, traffic ...knservingv1.TrafficTarget) *knservingv1.ServiceStatus {
+		return &knservingv1.ServiceStatus{
+			ConfigurationStatusFields: knservingv1.ConfigurationStatusFields{
+				LatestCreatedRevisionName: created,
+				LatestReadyRevisionName:   ready,
+			},
+			RouteStatusFields: knservingv1.RouteStatusFields{Traffic: traffic},
+		}
+	}
+	status := &v1alpha1api.InferenceGraphStatus{}
+
+	// the first revision is rolled out
+	propagateKnativeRevisions(status, serviceStatus("graph-00001", "graph-00001",
+		knservingv1.TrafficTarget{RevisionName: "graph-00001", LatestRevision: proto.Bool(true), Percent: proto.Int64(100)}))
+	g.Expect(status.Revisions).To(gomega.Equal(&v1alpha1api.RouterRevisionStatus{
+		LatestReadyRevision:     "graph-00001",
+		LatestCreatedRevision:   "graph-00001",
+		LatestRolledoutRevision: "graph-00001",
+		Traffic: []v1alpha1api.RouterTrafficTarget{
+			{RevisionName: "graph-00001", LatestRevision: true, Percent: 100},
+		},
+	}))
+
+	// the canary revision serves a part of the traffic
+	propagateKnativeRevisions(status, serviceStatus("graph-00002", "graph-00002",
+		knservingv1.TrafficTarget{RevisionName: "graph-00002", LatestRevision: proto.Bool(true), Percent: proto.Int64(10)},
+		knservingv1.TrafficTarget{RevisionName: "graph-00001", LatestRevision: proto.Bool(false), Percent: proto.Int64(90), Tag: "prev"}))
+	g.Expect(status.Revisions).To(gomega.Equal(&v1alpha1api.RouterRevisionStatus{
+		LatestReadyRevision:     "graph-00002",
+		LatestCreatedRevision:   "graph-00002",
+		LatestRolledoutRevision: "graph-00001",
+		Traffic: []v1alpha1api.RouterTrafficTarget{
+			{RevisionName: "graph-00002", LatestRevision: true, Percent: 10},
+			{RevisionName: "graph-00001", Percent: 90, Tag: "prev"},
+		},
+	}))
+
+	// the canary revision is promoted
+	propagateKnativeRevisions(status, serviceStatus("graph-00002", "graph-00002",
+		knservingv1.TrafficTarget{RevisionName: "graph-00002", LatestRevision: proto.Bool(true), Percent: proto.Int64(100)}))
+	g.Expect(status.Revisions.LatestRolledoutRevision).To(gomega.Equal("graph-00002"))
+	g.Expect(status.Revisions.PreviousRolledoutRevision).To(gomega.Equal("graph-00001"))
+
+	// the canary traffic percent is set again after the promotion, the previous revision is rolled back
+	propagateKnativeRevisions(status, serviceStatus("graph-00002", "graph-00002",
+		knservingv1.TrafficTarget{RevisionName: "graph-00002", LatestRevision: proto.Bool(true), Percent: proto.Int64(10)},
+		knservingv1.TrafficTarget{RevisionName: "graph-00002", LatestRevision: proto.Bool(false), Percent: proto.Int64(90), Tag: "prev"}))
+	g.Expect(status.Revisions.LatestRolledoutRevision).To(gomega.Equal("graph-00001"))
+}
+
+func TestRawCanarySplit(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	split := func(stableReplicas int32, maxReplicas int32, percent int64) []int32 {
+		stable, canary := rawCanarySplit(stableReplicas, maxReplicas, percent)
+		return []int32{stable, canary}
+	}
+	// the stable Deployment is scaled up within the maximum replicas
+	g.Expect(split(1, 10, 10)).To(gomega.Equal([]int32{9, 1}))
+	g.Expect(split(1, 10, 30)).To(gomega.Equal([]int32{7, 3}))
+	g.Expect(split(1, 5, 10)).To(gomega.Equal([]int32{5, 1}))
+	g.Expect(split(1, 0, 10)).To(gomega.Equal([]int32{1, 1}))
+	g.Expect(split(0, 0, 20)).To(gomega.Equal([]int32{1, 1}))
+	// the canary replicas are the closest to the percent
+	g.Expect(split(10, 10, 10)).To(gomega.Equal([]int32{10, 1}))
+	g.Expect(split(14, 0, 10)).To(gomega.Equal([]int32{14, 2}))
+	g.Expect(split(4, 0, 50)).To(gomega.Equal([]int32{4, 4}))
+	g.Expect(split(1, 0, 99)).To(gomega.Equal([]int32{1, 99}))
+
+	g.Expect(canaryPercent(9, 1)).To(gomega.Equal(int64(10)))
+	g.Expect(canaryPercent(1, 1)).To(gomega.Equal(int64(50)))
+	g.Expect(canaryPercent(5, 1)).To(gomega.Equal(int64(17)))
+
+	graph := &v1alpha1api.InferenceGraph{Spec: v1alpha1api.InferenceGraphSpec{
+		CanaryTrafficPercent: proto.Int64(10), MaxReplicas: 20,
+	}}
+	g.Expect(rawStableMinReplicas(graph)).To(gomega.Equal(int32(9)))
+	minReplicas := 12
+	graph.Spec.MinReplicas = &minReplicas
+	g.Expect(rawStableMinReplicas(graph)).To(gomega.Equal(int32(12)))
+
+	deployment := func(readyReplicas int32) *appsv1.Deployment {
+		return &appsv1.Deployment{Status: appsv1.DeploymentStatus{ReadyReplicas: readyReplicas}}
+	}
+	g.Expect(rawCanaryTrafficPercent(deployment(9), deployment(1))).To(gomega.Equal(int64(10)))
+	g.Expect(rawCanaryTrafficPercent(deployment(1), deployment(1))).To(gomega.Equal(int64(50)))
+	g.Expect(rawCanaryTrafficPercent(deployment(9), deployment(0))).To(gomega.Equal(int64(0)))
+	g.Expect(rawCanaryTrafficPercent(deployment(9), nil)).To(gomega.Equal(int64(0)))
+}
+
+func TestRawCanaryRollout(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(v1alpha1api.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(appsv1.AddToScheme(scheme)).To(gomega.Succeed())
+	clientset := fakeclientset.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+		Data:       map[string]string{"deploy": `{"defaultDeploymentMode": "RawDeployment"}`},
+	})
+	config := &RouterConfig{Image: "kserve/router:v0.10.0", CpuRequest: "100m", CpuLimit: "100m", MemoryRequest: "100Mi",
+		MemoryLimit: "100Mi"}
+	graph := &v1alpha1api.InferenceGraph{
+		ObjectMeta: metav1.ObjectMeta{Name: "graph", Namespace: "default", UID: "uid"},
+		Spec: v1alpha1api.InferenceGraphSpec{
+			Nodes: map[string]v1alpha1api.InferenceRouter{
+				v1alpha1api.GraphRootNodeName: {
+					RouterType: v1alpha1api.Sequence,
+					Steps:      []v1alpha1api.InferenceStep{{InferenceTarget: v1alpha1api.InferenceTarget{ServiceURL: "http://model"}}},
+				},
+			},
+		},
+	}
+	stableRevision, err := newRouterRevision(clientset, graph, config, createInferenceGraphPodSpec(graph, config))
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	again, _ := newRouterRevision(clientset, graph, config, createInferenceGraphPodSpec(graph, config))
+	g.Expect(again.Name).To(gomega.Equal(stableRevision.Name))
+
+	stable := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "graph", Namespace: "default", Annotations: stableRevision.annotations()},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: proto.Int32(4),
+			Template: v1.PodTemplateSpec{Spec: *stableRevision.PodSpec},
+		},
+	}
+	r := &InferenceGraphReconciler{
+		Client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(stable).Build(),
+		Clientset: clientset,
+		Log:       logf.Log.WithName("test"),
+		Scheme:    scheme,
+		Recorder:  record.NewFakeRecorder(10),
+	}
+
+	// the graph changes, the latest revision is deployed by the stable Deployment without a canary traffic percent
+	graph.Spec.Nodes[v1alpha1api.GraphRootNodeName].Steps[0].ServiceURL = "http://model-v2"
+	latest, err := newRouterRevision(clientset, graph, config, createInferenceGraphPodSpec(graph, config))
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(latest.Name).NotTo(gomega.Equal(stableRevision.Name))
+	planned, canary, err := r.planRawRollout(context.TODO(), graph, latest)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(planned).To(gomega.Equal(latest))
+	g.Expect(canary).To(gomega.BeNil())
+
+	// the stable Deployment keeps its revision while the latest revision is deployed as a canary
+	graph.Spec.CanaryTrafficPercent = proto.Int64(20)
+	planned, canary, err = r.planRawRollout(context.TODO(), graph, latest)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(planned.Name).To(gomega.Equal(stableRevision.Name))
+	g.Expect(planned.PodSpec).To(gomega.Equal(stableRevision.PodSpec))
+	g.Expect(canary).To(gomega.Equal(latest))
+
+	canaryDeployment, err := r.reconcileRawCanary(context.TODO(), graph, canary, stable, config)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	created := &appsv1.Deployment{}
+	g.Expect(r.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "graph-canary"}, created)).To(gomega.Succeed())
+	g.Expect(created.Spec.Replicas).To(gomega.Equal(proto.Int32(1)))
+	g.Expect(created.Annotations[constants.RouterRevisionAnnotationKey]).To(gomega.Equal(latest.Name))
+	g.Expect(created.Spec.Template.Labels[constants.InferenceGraphLabel]).To(gomega.Equal("graph"))
+	g.Expect(created.Spec.Template.Spec.Containers[0].Args).To(gomega.Equal(latest.PodSpec.Containers[0].Args))
+	g.Expect(metav1.IsControlledBy(created, graph)).To(gomega.BeTrue())
+
+	g.Expect(r.Recorder.(*record.FakeRecorder).Events).To(gomega.BeEmpty())
+
+	// the traffic reports the split of the ready pods
+	stable.Status.ReadyReplicas = 4
+	canaryDeployment.Status.ReadyReplicas = 1
+	status := &v1alpha1api.InferenceGraphStatus{
+		Revisions: &v1alpha1api.RouterRevisionStatus{LatestRolledoutRevision: stableRevision.Name},
+	}
+	propagateRawRevisions(status, latest, planned, deploymentAvailable(canaryDeployment),
+		rawCanaryTrafficPercent(stable, canaryDeployment))
+	g.Expect(status.Revisions).To(gomega.Equal(&v1alpha1api.RouterRevisionStatus{
+		LatestReadyRevision:     stableRevision.Name,
+		LatestCreatedRevision:   latest.Name,
+		LatestRolledoutRevision: stableRevision.Name,
+		Traffic: []v1alpha1api.RouterTrafficTarget{
+			{RevisionName: latest.Name, LatestRevision: true, Percent: 20},
+			{RevisionName: stableRevision.Name, Percent: 80, Tag: "prev"},
+		},
+	}))
+
+	// the canary traffic percent cannot be split from the stable replicas within the maximum replicas
+	graph.Spec.CanaryTrafficPercent = proto.Int64(10)
+	_, err = r.reconcileRawCanary(context.TODO(), graph, canary, stable, config)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(<-r.Recorder.(*record.FakeRecorder).Events).To(gomega.ContainSubstring(
+		"CanaryTrafficApproximated 20% of the requests are served by the canary revision instead of 10%"))
+
+	// no canary is deployed while the canary traffic percent is 0
+	graph.Spec.CanaryTrafficPercent = proto.Int64(0)
+	planned, canary, err = r.planRawRollout(context.TODO(), graph, latest)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(planned.Name).To(gomega.Equal(stableRevision.Name))
+	g.Expect(canary).To(gomega.BeNil())
+
+	// the latest revision is promoted, the canary Deployment is deleted
+	graph.Spec.CanaryTrafficPercent = proto.Int64(100)
+	planned, canary, err = r.planRawRollout(context.TODO(), graph, latest)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(planned).To(gomega.Equal(latest))
+	_, err = r.reconcileRawCanary(context.TODO(), graph, canary, stable, config)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(r.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "graph-canary"}, created)).NotTo(gomega.Succeed())
+
+	propagateRawRevisions(status, latest, planned, false, 0)
+	g.Expect(status.Revisions).To(gomega.Equal(&v1alpha1api.RouterRevisionStatus{
+		LatestReadyRevision:       latest.Name,
+		LatestCreatedRevision:     latest.Name,
+		PreviousRolledoutRevision: stableRevision.Name,
+		LatestRolledoutRevision:   latest.Name,
+		Traffic: []v1alpha1api.RouterTrafficTarget{
+			{RevisionName: latest.Name, LatestRevision: true, Percent: 100},
+		},
+	}))
+}
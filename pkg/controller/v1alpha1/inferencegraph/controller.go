@@ -19,12 +19,16 @@ limitations under the License.
 // +kubebuilder:rbac:groups=serving.knative.dev,resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=serving.knative.dev,resources=services/finalizers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=serving.knative.dev,resources=services/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;create;update;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;create;update
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;create;update
 package inferencegraph
 
 import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/kserve/kserve/pkg/utils"
@@ -33,6 +37,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -41,17 +46,24 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
 	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	v1beta1api "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/backend"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/cost"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/dashboard"
 	isvcutils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
+	"github.com/kserve/kserve/pkg/podconfig"
 )
 
 // InferenceGraphReconciler reconciles a InferenceGraph object
@@ -62,6 +74,9 @@ type InferenceGraphReconciler struct {
 	Log          logr.Logger
 	Scheme       *runtime.Scheme
 	Recorder     record.EventRecorder
+
+	// controller is kept to watch the Knative Services when Knative Serving is installed after the controller started
+	controller controller.Controller
 }
 
 // InferenceGraphState describes the Readiness of the InferenceGraph
@@ -91,6 +106,38 @@ type RouterConfig struct {
 		want to transform headers keys or values before passing down to nodes.
 	*/
 	Headers map[string][]string `json:"headers"`
+	// HealthPort is the port of the health endpoint of the router, it is served on the graph port when not set.
+	HealthPort int32 `json:"healthPort,omitempty"`
+	// MetricsPort is the port of the prometheus metrics endpoint of the router, metrics are disabled when not set.
+	MetricsPort int32 `json:"metricsPort,omitempty"`
+	// GraphLimits are the maximum number of nodes visited and of parallel steps enforced by the router
+	v1alpha1api.GraphLimits `json:",inline"`
+	// Traces configures the persistence of the execution traces of the failed requests, they are not persisted
+	// when not set.
+	Traces *v1alpha1api.GraphTraceConfig `json:"traces,omitempty"`
+	// ImageRollout upgrades the graphs to a new router image in stages, every graph is upgraded at once when not set.
+	ImageRollout *RouterImageRollout `json:"imageRollout,omitempty"`
+	// CaBundleConfigMapName is the ConfigMap of the namespace of the graphs holding the CA bundle trusted by the
+	// router instead of the system CAs, the graphs can override it with the router CA bundle annotation.
+	CaBundleConfigMapName string `json:"caBundleConfigMapName,omitempty"`
+	// CaBundleKey is the key of the CA bundle in the ConfigMap, defaults to cabundle.crt
+	CaBundleKey string `json:"caBundleKey,omitempty"`
+	// TLS serves the graphs over HTTPS in raw deployment mode, the graphs can override it with the router TLS
+	// annotation
+	TLS *RouterTLSConfig `json:"tls,omitempty"`
+	// GraphHotReload mounts the graph document from a ConfigMap the router watches in raw deployment mode, so that
+	// the graph edits are reloaded by the router pods instead of rolling them out. Editing the quota, the CORS
+	// policy or the load shedding of a graph still rolls its router pods out.
+	GraphHotReload bool `json:"graphHotReload,omitempty"`
+}
+
+// RouterTLSConfig configures the HTTPS listener of the routers, the certificate of the router of a graph is read from
+// the kubernetes.io/tls Secret <graph name>-router-tls
+type RouterTLSConfig struct {
+	// Enabled serves every graph over HTTPS unless the graph disables it
+	Enabled bool `json:"enabled"`
+	// OpenshiftServingCert requests the certificate of the routers from the OpenShift service-ca operator
+	OpenshiftServingCert bool `json:"openshiftServingCert,omitempty"`
 }
 
 func getRouterConfigs(configMap *v1.ConfigMap) (*RouterConfig, error) {
@@ -98,7 +145,7 @@ func getRouterConfigs(configMap *v1.ConfigMap) (*RouterConfig, error) {
 	if agentConfigValue, ok := configMap.Data["router"]; ok {
 		err := json.Unmarshal([]byte(agentConfigValue), &routerConfig)
 		if err != nil {
-			panic(fmt.Errorf("Unable to unmarshall agent json string due to %w ", err))
+			return routerConfig, fmt.Errorf("Unable to unmarshall router json string due to %w ", err)
 		}
 	}
 
@@ -114,11 +161,26 @@ func getRouterConfigs(configMap *v1.ConfigMap) (*RouterConfig, error) {
 				err.Error())
 		}
 	}
+	if routerConfig.Traces != nil {
+		if err := routerConfig.Traces.Validate(); err != nil {
+			return routerConfig, err
+		}
+	}
+	if routerConfig.ImageRollout != nil {
+		if err := routerConfig.ImageRollout.Validate(); err != nil {
+			return routerConfig, err
+		}
+	}
+	if routerConfig.CaBundleConfigMapName != "" {
+		if err := v1alpha1api.ValidateRouterCaBundle(routerConfig.CaBundleConfigMapName, routerConfig.CaBundleKey); err != nil {
+			return routerConfig, err
+		}
+	}
 
 	return routerConfig, nil
 }
 
-func (r *InferenceGraphReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *InferenceGraphReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, reconcileErr error) {
 	_ = context.Background()
 
 	// Fetch the InferenceService instance
@@ -127,44 +189,30 @@ func (r *InferenceGraphReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		if apierr.IsNotFound(err) {
 			// Object not found, return.  Created objects are automatically garbage collected.
 			// For additional cleanup logic use finalizers.
+			isvcutils.ChildResources.Forget(isvcutils.InferenceGraphKind, req.Namespace, req.Name)
+			isvcutils.LifecycleEvents.Forget(isvcutils.InferenceGraphKind, req.Namespace, req.Name)
+			if err := dashboard.NewDashboardReconciler(r.Client, r.Clientset).Reconcile(req.Namespace); err != nil {
+				return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile dashboard")
+			}
 			return reconcile.Result{}, nil
 		}
 		return reconcile.Result{}, err
 	}
-
-	r.Log.Info("Reconciling inference graph", "apiVersion", graph.APIVersion, "graph", graph.Name)
-	configMap, err := r.Clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Get(context.TODO(), constants.InferenceServiceConfigMapName, metav1.GetOptions{})
-	if err != nil {
-		r.Log.Error(err, "Failed to find config map", "name", constants.InferenceServiceConfigMapName)
-		return reconcile.Result{}, err
-	}
-	routerConfig, err := getRouterConfigs(configMap)
-	if err != nil {
-		return reconcile.Result{}, err
-	}
-	// resolve service urls
-	for node, router := range graph.Spec.Nodes {
-		for i, route := range router.Steps {
-			isvc := v1beta1.InferenceService{}
-			if route.ServiceName != "" {
-				err := r.Client.Get(ctx, types.NamespacedName{Namespace: graph.Namespace, Name: route.ServiceName}, &isvc)
-				if err == nil {
-					if graph.Spec.Nodes[node].Steps[i].ServiceURL == "" {
-						serviceUrl, err := isvcutils.GetPredictorEndpoint(&isvc)
-						if err == nil {
-							graph.Spec.Nodes[node].Steps[i].ServiceURL = serviceUrl
-						} else {
-							r.Log.Info("inference service is not ready", "name", route.ServiceName)
-							return reconcile.Result{Requeue: true}, errors.Wrapf(err, "service %s is not ready", route.ServiceName)
-						}
-					}
-				} else {
-					r.Log.Info("inference service is not found", "name", route.ServiceName)
-					return reconcile.Result{Requeue: true}, errors.Wrapf(err, "Failed to find graph service %s", route.ServiceName)
-				}
-			}
+	defer func() {
+		isvcutils.ChildResources.RecordReconcile(isvcutils.InferenceGraphKind, req.Namespace, req.Name, reconcileErr)
+	}()
+
+	// Freeze the child resources while the InferenceGraph is paused, nothing is created, updated or scaled down
+	setPausedCondition(&graph.Status, utils.IsPaused(graph.Annotations))
+	if utils.IsPaused(graph.Annotations) {
+		r.Log.Info("Skipping reconciliation of paused inference graph", "graph", graph.Name)
+		if err := r.updateStatus(graph); err != nil {
+			return reconcile.Result{}, err
 		}
+		return ctrl.Result{}, nil
 	}
+
+	r.Log.Info("Reconciling inference graph", "apiVersion", graph.APIVersion, "graph", graph.Name)
 	deployConfig, err := v1beta1api.NewDeployConfig(r.Clientset)
 	if err != nil {
 		return reconcile.Result{}, errors.Wrapf(err, "fails to create DeployConfig")
@@ -172,75 +220,224 @@ func (r *InferenceGraphReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 
 	deploymentMode := isvcutils.GetDeploymentMode(graph.ObjectMeta.Annotations, deployConfig)
 	r.Log.Info("Inference graph deployment ", "deployment mode ", deploymentMode)
-	if deploymentMode == constants.RawDeployment {
-		// Create inference graph resources such as deployment, service, hpa in raw deployment mode
-		deployment, url, err := handleInferenceGraphRawDeployment(r.Client, r.Clientset, r.Scheme, graph, routerConfig)
+	// The graphs are deployed in Serverless mode when no router is registered for their deployment mode, e.g. ModelMesh
+	routerMode := deploymentMode
+	reconcileRouter, ok := getRouterReconciler(routerMode)
+	if !ok {
+		routerMode = constants.Serverless
+		reconcileRouter, _ = getRouterReconciler(routerMode)
+	}
+	deploymentBackend, err := backend.New(routerMode, backend.Options{
+		Client:       r.Client,
+		Clientset:    r.Clientset,
+		ClientConfig: r.ClientConfig,
+		Scheme:       r.Scheme,
+		Recorder:     r.Recorder,
+	})
+	if err != nil {
+		return reconcile.Result{}, reconcile.TerminalError(err)
+	}
 
-		if err != nil {
-			return ctrl.Result{}, errors.Wrapf(err, "fails to reconcile inference graph raw deployment")
+	// Remove the router outside the active hours, it is created again when the next window opens
+	active, requeueAfter := true, time.Duration(0)
+	var next time.Time
+	if graph.Spec.ActiveHours != nil {
+		if active, next, err = graph.Spec.ActiveHours.IsActive(time.Now()); err != nil {
+			return reconcile.Result{}, reconcile.TerminalError(errors.Wrapf(err, "fails to evaluate the active hours"))
 		}
-
-		r.Log.Info("Inference graph raw", "deployment conditions", deployment.Status.Conditions)
-		igAvailable := false
-		for _, con := range deployment.Status.Conditions {
-			if con.Type == appsv1.DeploymentAvailable {
-				igAvailable = true
-				break
+		if !next.IsZero() {
+			requeueAfter = time.Until(next)
+		}
+		if previous := graph.Status.GetCondition(v1beta1api.Scheduled); previous != nil && previous.IsTrue() != active {
+			if active {
+				r.Recorder.Event(graph, v1.EventTypeNormal, "ActiveHoursStarted", "Creating the router as an active window opened")
+			} else {
+				r.Recorder.Event(graph, v1.EventTypeNormal, "ActiveHoursEnded", "Removing the router as the active windows closed")
 			}
 		}
-		if !igAvailable {
-			// If Deployment resource not yet available, IG is not available as well. Reconcile again.
-			return reconcile.Result{Requeue: true}, errors.Wrapf(err,
-				"Failed to find inference graph deployment  %s", graph.Name)
+	}
+	if !active {
+		r.Log.Info("Removing the router of inference graph outside its active hours", "graph", graph.Name,
+			"requeueAfter", requeueAfter)
+		if err := r.deleteWorkloads(graph, deploymentBackend); err != nil {
+			return reconcile.Result{}, errors.Wrapf(err, "fails to remove the router outside the active hours")
 		}
-		logger.Info("Inference graph raw before propagate status")
-		PropagateRawStatus(&graph.Status, deployment, url)
-	} else {
-		// Abort if Knative Services are not available
-		ksvcAvailable, checkKsvcErr := utils.IsCrdAvailable(r.ClientConfig, knservingv1.SchemeGroupVersion.String(), constants.KnativeServiceKind)
-		if err != nil {
-			return reconcile.Result{}, checkKsvcErr
+		setScheduledCondition(&graph.Status, graph.Spec.ActiveHours != nil, active, next)
+		if err := r.updateStatus(graph); err != nil {
+			return reconcile.Result{}, err
 		}
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
 
-		if !ksvcAvailable {
-			r.Recorder.Event(graph, v1.EventTypeWarning, "ServerlessModeRejected",
-				"It is not possible to use Serverless deployment mode when Knative Services are not available")
-			return reconcile.Result{Requeue: false}, reconcile.TerminalError(fmt.Errorf("the resolved deployment mode of InferenceGraph '%s' is Serverless, but Knative Serving is not available", graph.Name))
-		}
+	configMap, err := r.Clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Get(context.TODO(), constants.InferenceServiceConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		r.Log.Error(err, "Failed to find config map", "name", constants.InferenceServiceConfigMapName)
+		return reconcile.Result{}, err
+	}
+	routerConfig, err := getRouterConfigs(configMap)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	targetNamespaces, err := v1alpha1api.GetTargetNamespaces(configMap)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
 
-		// @TODO check raw deployment mode
-		desired := createKnativeService(graph.ObjectMeta, graph, routerConfig)
-		err = controllerutil.SetControllerReference(graph, desired, r.Scheme)
-		if err != nil {
+	// Reconcile the Grafana dashboard of the namespace
+	if err := dashboard.NewDashboardReconciler(r.Client, r.Clientset).Reconcile(graph.Namespace); err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile dashboard")
+	}
+
+	// Annotate the estimated hourly cost when the cost estimation is enabled
+	if err := cost.NewCostReconciler(r.Client, r.Clientset).Reconcile(graph); err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile estimated cost")
+	}
+
+	// Export the topology of the graph before its services are resolved, so that the services which are not ready yet
+	// are shown
+	if err := r.reconcileTopology(ctx, graph, targetNamespaces); err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile topology")
+	}
+	// Check the InferenceServices targeted by name and resolve the URLs of their steps, the graph is reconciled again
+	// when they are created or their status changes
+	targets, err := r.resolveTargets(ctx, graph, targetNamespaces)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to check the targets")
+	}
+	if previous := graph.Status.GetCondition(v1alpha1api.TargetsReady); !targets.ready() && (previous == nil || previous.IsTrue()) {
+		r.Recorder.Event(graph, v1.EventTypeWarning, "TargetsNotReady", targets.message())
+	}
+	if targets.unresolved {
+		r.Log.Info("Waiting for the targets of inference graph", "graph", graph.Name, "reason", targets.message())
+		setTargetsCondition(&graph.Status, targets)
+		setScheduledCondition(&graph.Status, graph.Spec.ActiveHours != nil, active, next)
+		if err := r.updateStatus(graph); err != nil {
 			return reconcile.Result{}, err
 		}
-		knativeReconciler := NewGraphKnativeServiceReconciler(r.Client, r.Scheme, desired)
-		ksvcStatus, err := knativeReconciler.Reconcile()
-		if err != nil {
-			r.Log.Error(err, "failed to reconcile inference graph ksvc", "name", graph.GetName())
-			return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile inference graph ksvc")
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+	// Abort if the resolved deployment mode cannot be used, e.g. Serverless while Knative Services are not available
+	if err := deploymentBackend.Admit(ctx, graph.Namespace); err != nil {
+		var rejected *backend.RejectedError
+		if !errors.As(err, &rejected) {
+			return reconcile.Result{}, err
 		}
-
-		r.Log.Info("updating inference graph status", "status", ksvcStatus)
-		graph.Status.Conditions = ksvcStatus.Status.Conditions
-		// @TODO Need to check the status of all the graph components, find the inference services from all the nodes and collect the status
-		for _, con := range ksvcStatus.Status.Conditions {
-			if con.Type == apis.ConditionReady {
-				if con.Status == "True" {
-					graph.Status.URL = ksvcStatus.URL
-				} else {
-					graph.Status.URL = nil
-				}
+		r.Recorder.Event(graph, v1.EventTypeWarning, rejected.Reason, rejected.Message)
+		if rejected.Condition == v1beta1api.ServiceMeshMember {
+			setServiceMeshMemberCondition(&graph.Status, rejected.Message)
+			if err := r.updateStatus(graph); err != nil {
+				return reconcile.Result{}, err
 			}
 		}
+		return reconcile.Result{Requeue: false}, reconcile.TerminalError(fmt.Errorf("the resolved deployment mode of InferenceGraph '%s' is %s, but %s", graph.Name, routerMode, rejected.Cause))
+	}
+
+	// Keep the current router image until the canaries of a router image rollout are upgraded, unless the graph overrides
+	// the router image
+	image, waitForCanaries, err := r.rolloutRouterImage(ctx, graph, routerConfig, configMap)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to roll out the router image")
+	}
+	routerConfig.Image = image
+
+	router, err := reconcileRouter(r, graph, routerConfig, configMap)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if router.Requeue {
+		return reconcile.Result{Requeue: true}, nil
+	}
+	routerImage, clusterLocalURL := router.Image, router.ClusterLocalURL
+
+	graph.Status.Endpoints = getInferenceGraphEndpoints(graph.Status.URL, clusterLocalURL)
+	setTargetsCondition(&graph.Status, targets)
+
+	// Record the global configuration the InferenceGraph was built with
+	graph.Status.EffectiveConfig = &v1alpha1api.EffectiveConfig{
+		ConfigMapResourceVersion: configMap.ResourceVersion,
+		DeploymentMode:           string(deploymentMode),
+		RouterImage:              routerImage,
+		AutoscalerClass:          isvcutils.GetAutoscalerClass(graph.Annotations, deploymentMode),
+	}
+
+	// Send the golden request to the graph, it is reached through its cluster local address when there is one
+	smokeTestURL := clusterLocalURL
+	if smokeTestURL == nil {
+		smokeTestURL = graph.Status.URL
 	}
+	retryAfter := r.reconcileSmokeTest(ctx, graph, smokeTestURL)
+	setScheduledCondition(&graph.Status, graph.Spec.ActiveHours != nil, active, next)
 
 	if err := r.updateStatus(graph); err != nil {
 		r.Recorder.Eventf(graph, v1.EventTypeWarning, "InternalError", err.Error())
 		return reconcile.Result{}, err
 	}
 
-	return ctrl.Result{}, nil
+	if retryAfter == 0 || (requeueAfter > 0 && requeueAfter < retryAfter) {
+		retryAfter = requeueAfter
+	}
+	if waitForCanaries && (retryAfter == 0 || routerImageRolloutPeriod < retryAfter) {
+		retryAfter = routerImageRolloutPeriod
+	}
+	return ctrl.Result{RequeueAfter: retryAfter}, nil
+}
+
+// deleteWorkloads deletes the Knative Service or the Deployment and the HorizontalPodAutoscaler of the router, the
+// Service of a raw deployment is kept so that the router address does not change
+func (r *InferenceGraphReconciler) deleteWorkloads(graph *v1alpha1api.InferenceGraph, deploymentBackend backend.DeploymentBackend) error {
+	lists := deploymentBackend.Workloads()
+	for _, list := range lists {
+		if err := r.List(context.TODO(), list, client.InNamespace(graph.Namespace),
+			client.MatchingLabels{constants.InferenceGraphLabel: graph.Name}); err != nil {
+			return err
+		}
+		if err := meta.EachListItem(list, func(obj runtime.Object) error {
+			workload := obj.(client.Object)
+			if !metav1.IsControlledBy(workload, graph) {
+				return nil
+			}
+			return client.IgnoreNotFound(r.Delete(context.TODO(), workload, client.PropagationPolicy(metav1.DeletePropagationBackground)))
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setPodDefaults applies the namespace default pull secrets, the FIPS images and TLS settings, the image policy and the
+// egress proxy to the router pod
+func (r *InferenceGraphReconciler) setPodDefaults(podSpec *v1.PodSpec, graph *v1alpha1api.InferenceGraph, configMap *v1.ConfigMap) error {
+	imagePullSecretsConfig, err := podconfig.GetImagePullSecretsConfig(configMap)
+	if err != nil {
+		return err
+	}
+	if len(imagePullSecretsConfig.SecretNames) != 0 {
+		imagePullSecrets, err := podconfig.GetDefaultImagePullSecrets(r.Clientset, imagePullSecretsConfig, graph.Namespace, "")
+		if err != nil {
+			return err
+		}
+		podSpec.ImagePullSecrets = podconfig.MergeImagePullSecrets(podSpec.ImagePullSecrets, imagePullSecrets...)
+	}
+
+	securityConfig, err := v1beta1api.GetSecurityConfig(configMap)
+	if err != nil {
+		return err
+	}
+	imagePolicyConfig, err := podconfig.GetImagePolicyConfig(configMap)
+	if err != nil {
+		return err
+	}
+	egressProxyConfig, err := podconfig.GetEgressProxyConfig(configMap)
+	if err != nil {
+		return err
+	}
+	for i := range podSpec.Containers {
+		podSpec.Containers[i].Image = securityConfig.GetImage(podSpec.Containers[i].Image)
+		imagePolicyConfig.ApplyToContainer(&podSpec.Containers[i])
+	}
+	setRouterFIPSMode(&podSpec.Containers[0], securityConfig)
+	egressProxyConfig.ApplyToPodSpec(podSpec)
+	return nil
 }
 
 func (r *InferenceGraphReconciler) updateStatus(desiredGraph *v1alpha1api.InferenceGraph) error {
@@ -273,9 +470,172 @@ func (r *InferenceGraphReconciler) updateStatus(desiredGraph *v1alpha1api.Infere
 				fmt.Sprintf("InferenceGraph [%v] is Ready", desiredGraph.GetName()))
 		}
 	}
+	r.publishLifecycleEvents(graph, desiredGraph)
 	return nil
 }
 
+// publishLifecycleEvents sends the lifecycle transitions of the InferenceGraph to the event sink of its namespace
+func (r *InferenceGraphReconciler) publishLifecycleEvents(graph *v1alpha1api.InferenceGraph,
+	desiredGraph *v1alpha1api.InferenceGraph) {
+	lifecycleEventsConfig, err := v1beta1api.NewLifecycleEventsConfig(r.Clientset)
+	if err != nil {
+		r.Log.Error(err, "Failed to read the lifecycle events config", "InferenceGraph", desiredGraph.Name)
+		return
+	}
+	sink := lifecycleEventsConfig.GetSink(desiredGraph.Namespace)
+	if sink == "" {
+		return
+	}
+	data := isvcutils.LifecycleEventData{
+		Kind:       isvcutils.InferenceGraphKind,
+		Namespace:  desiredGraph.Namespace,
+		Name:       desiredGraph.Name,
+		Generation: desiredGraph.Generation,
+	}
+	if desiredGraph.Status.URL != nil {
+		data.URL = desiredGraph.Status.URL.String()
+	}
+	readyReplicas := isvcutils.ReadyReplicas(r.Client, desiredGraph.Namespace,
+		map[string]string{constants.InferenceGraphLabel: desiredGraph.Name})
+	isvcutils.LifecycleEvents.Publish(sink, data, graph.Status.GetCondition(apis.ConditionReady),
+		desiredGraph.Status.GetCondition(apis.ConditionReady), readyReplicas)
+}
+
+// setPausedCondition adds the Paused condition when the reconciliation of the child resources is paused and
+// removes it otherwise
+func setPausedCondition(status *v1alpha1api.InferenceGraphStatus, paused bool) {
+	conditions := duckv1.Conditions{}
+	for _, condition := range status.Conditions {
+		if condition.Type != v1beta1api.Paused {
+			conditions = append(conditions, condition)
+		}
+	}
+	if paused {
+		if existing := status.GetCondition(v1beta1api.Paused); existing != nil {
+			conditions = append(conditions, *existing)
+		} else {
+			conditions = append(conditions, apis.Condition{
+				Type:               v1beta1api.Paused,
+				Status:             v1.ConditionTrue,
+				Severity:           apis.ConditionSeverityInfo,
+				LastTransitionTime: apis.VolatileTime{Inner: metav1.Now()},
+				Reason:             "PausedByAnnotation",
+				Message:            "The child resources are not reconciled while the serving.kserve.io/paused annotation is true",
+			})
+		}
+	}
+	if len(conditions) == 0 {
+		conditions = nil
+	}
+	status.Conditions = conditions
+}
+
+// setScheduledCondition sets the Scheduled condition of an InferenceGraph with active hours to whether a window is
+// open, the Ready condition is set to false outside the windows as the router is removed. The condition is removed
+// when the InferenceGraph has no active hours.
+func setScheduledCondition(status *v1alpha1api.InferenceGraphStatus, activeHours bool, active bool, next time.Time) {
+	var scheduled, ready *apis.Condition
+	if activeHours && active {
+		scheduled = &apis.Condition{
+			Type:     v1beta1api.Scheduled,
+			Status:   v1.ConditionTrue,
+			Severity: apis.ConditionSeverityInfo,
+			Reason:   "InsideActiveHours",
+			Message:  "The router runs inside the active hours",
+		}
+		if !next.IsZero() {
+			scheduled.Message = fmt.Sprintf("The router runs until %s", next.UTC().Format(time.RFC3339))
+		}
+	} else if activeHours {
+		scheduled = &apis.Condition{
+			Type:     v1beta1api.Scheduled,
+			Status:   v1.ConditionFalse,
+			Severity: apis.ConditionSeverityInfo,
+			Reason:   "OutsideActiveHours",
+			Message:  "The router is removed as no window of the active hours opens anymore",
+		}
+		if !next.IsZero() {
+			scheduled.Message = fmt.Sprintf("The router is removed until %s", next.UTC().Format(time.RFC3339))
+		}
+		ready = &apis.Condition{
+			Type:    apis.ConditionReady,
+			Status:  v1.ConditionFalse,
+			Reason:  "OutsideActiveHours",
+			Message: "The InferenceGraph is outside its active hours",
+		}
+	}
+	conditions := duckv1.Conditions{}
+	for _, condition := range status.Conditions {
+		if condition.Type != v1beta1api.Scheduled && (ready == nil || condition.Type != apis.ConditionReady) {
+			conditions = append(conditions, condition)
+		}
+	}
+	for _, condition := range []*apis.Condition{scheduled, ready} {
+		if condition == nil {
+			continue
+		}
+		// the transition time is kept while the status does not change
+		if existing := status.GetCondition(condition.Type); existing != nil && existing.Status == condition.Status {
+			condition.LastTransitionTime = existing.LastTransitionTime
+		} else {
+			condition.LastTransitionTime = apis.VolatileTime{Inner: metav1.Now()}
+		}
+		conditions = append(conditions, *condition)
+	}
+	if len(conditions) == 0 {
+		conditions = nil
+	}
+	status.Conditions = conditions
+}
+
+// setServiceMeshMemberCondition sets the ServiceMeshMember condition to false, it is replaced by the conditions of the
+// Knative Service once the namespace is enrolled into the service mesh
+func setServiceMeshMemberCondition(status *v1alpha1api.InferenceGraphStatus, message string) {
+	if existing := status.GetCondition(v1beta1api.ServiceMeshMember); existing != nil && existing.Message == message {
+		return
+	}
+	conditions := duckv1.Conditions{}
+	for _, condition := range status.Conditions {
+		if condition.Type != v1beta1api.ServiceMeshMember {
+			conditions = append(conditions, condition)
+		}
+	}
+	status.Conditions = append(conditions, apis.Condition{
+		Type:               v1beta1api.ServiceMeshMember,
+		Status:             v1.ConditionFalse,
+		Severity:           apis.ConditionSeverityError,
+		LastTransitionTime: apis.VolatileTime{Inner: metav1.Now()},
+		Reason:             "NamespaceNotEnrolled",
+		Message:            message,
+	})
+}
+
+// getInferenceGraphEndpoints lists the external and cluster local addresses of a ready InferenceGraph, the URL of a
+// cluster local InferenceGraph is its cluster local address
+func getInferenceGraphEndpoints(url *apis.URL, clusterLocalURL *apis.URL) []v1alpha1api.Endpoint {
+	if url == nil {
+		return nil
+	}
+	var endpoints []v1alpha1api.Endpoint
+	if clusterLocalURL == nil || url.Host != clusterLocalURL.Host {
+		endpoints = append(endpoints, v1alpha1api.Endpoint{
+			Name:       v1beta1api.ExternalEndpointName,
+			Visibility: string(v1beta1api.ExternalEndpoint),
+			Protocol:   url.Scheme,
+			URL:        url.DeepCopy(),
+		})
+	}
+	if clusterLocalURL != nil {
+		endpoints = append(endpoints, v1alpha1api.Endpoint{
+			Name:       v1beta1api.ClusterLocalEndpointName,
+			Visibility: string(v1beta1api.ClusterLocalEndpoint),
+			Protocol:   clusterLocalURL.Scheme,
+			URL:        clusterLocalURL.DeepCopy(),
+		})
+	}
+	return endpoints
+}
+
 func inferenceGraphReadiness(status v1alpha1api.InferenceGraphStatus) bool {
 	return status.Conditions != nil &&
 		status.GetCondition(apis.ConditionReady) != nil &&
@@ -292,7 +652,8 @@ func (r *InferenceGraphReconciler) SetupWithManager(mgr ctrl.Manager, deployConf
 
 	ctrlBuilder := ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1api.InferenceGraph{}).
-		Owns(&appsv1.Deployment{})
+		Owns(&appsv1.Deployment{}).
+		Watches(&v1beta1.InferenceService{}, handler.EnqueueRequestsFromMapFunc(r.graphsForService))
 
 	if ksvcFound {
 		ctrlBuilder = ctrlBuilder.Owns(&knservingv1.Service{})
@@ -300,5 +661,14 @@ func (r *InferenceGraphReconciler) SetupWithManager(mgr ctrl.Manager, deployConf
 		r.Log.Info("The InferenceGraph controller won't watch serving.knative.dev/v1/Service resources because the CRD is not available.")
 	}
 
-	return ctrlBuilder.Complete(r)
+	r.controller, err = ctrlBuilder.Build(r)
+	return err
+}
+
+// WatchKnativeServices watches the Knative Services owned by the InferenceGraphs, it is called when Knative Serving is
+// installed after the controller started
+func (r *InferenceGraphReconciler) WatchKnativeServices(mgr ctrl.Manager) error {
+	r.Log.Info("Watching serving.knative.dev/v1/Service resources because the CRD is now available.")
+	return r.controller.Watch(source.Kind(mgr.GetCache(), &knservingv1.Service{}),
+		handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &v1alpha1api.InferenceGraph{}, handler.OnlyControllerOwner()))
 }
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/constants"
+	pkgtest "github.com/kserve/kserve/pkg/testing"
 	"github.com/kserve/kserve/pkg/utils"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -66,13 +67,7 @@ var _ = Describe("Inference Graph controller test", func() {
 	Context("When creating an inferencegraph with headers in global config", func() {
 		It("Should create a knative service with headers as env var of podspec", func() {
 			By("By creating a new InferenceGraph")
-			var configMap = &v1.ConfigMap{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      constants.InferenceServiceConfigMapName,
-					Namespace: constants.KServeNamespace,
-				},
-				Data: configs,
-			}
+			var configMap = pkgtest.NewConfigMap(configs)
 			Expect(k8sClient.Create(context.TODO(), configMap)).NotTo(HaveOccurred())
 			defer k8sClient.Delete(context.TODO(), configMap)
 			graphName := "singlenode1"
@@ -130,11 +125,14 @@ var _ = Describe("Inference Graph controller test", func() {
 							ObjectMeta: metav1.ObjectMeta{
 								Labels: map[string]string{
 									"serving.kserve.io/inferencegraph": graphName,
+									"kserve.io/part-of":                graphName,
 								},
 								Annotations: map[string]string{
 									"autoscaling.knative.dev/min-scale": "1",
 									"autoscaling.knative.dev/class":     "kpa.autoscaling.knative.dev",
 									"serving.kserve.io/deploymentMode":  "Serverless",
+									constants.RouterConfigHashAnnotationKey: actualKnServiceCreated.Spec.Template.
+										Annotations[constants.RouterConfigHashAnnotationKey],
 								},
 							},
 							Spec: knservingv1.RevisionSpec{
@@ -152,7 +150,7 @@ var _ = Describe("Inference Graph controller test", func() {
 											},
 											Args: []string{
 												"--graph-json",
-												"{\"nodes\":{\"root\":{\"routerType\":\"Sequence\",\"steps\":[{\"serviceUrl\":\"http://someservice.exmaple.com\"}]}},\"resources\":{}}",
+												"{\"version\":\"v1\",\"nodes\":{\"root\":{\"routerType\":\"Sequence\",\"steps\":[{\"serviceUrl\":\"http://someservice.exmaple.com\"}]}},\"resources\":{}}",
 											},
 											Resources: v1.ResourceRequirements{
 												Limits: v1.ResourceList{
@@ -185,13 +183,7 @@ var _ = Describe("Inference Graph controller test", func() {
 
 	Context("When creating an IG with resource requirements in the spec", func() {
 		It("Should propagate to underlying pod", func() {
-			var configMap = &v1.ConfigMap{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      constants.InferenceServiceConfigMapName,
-					Namespace: constants.KServeNamespace,
-				},
-				Data: configs,
-			}
+			var configMap = pkgtest.NewConfigMap(configs)
 			Expect(k8sClient.Create(context.TODO(), configMap)).NotTo(HaveOccurred())
 			defer k8sClient.Delete(context.TODO(), configMap)
 			graphName := "singlenode2"
@@ -259,11 +251,14 @@ var _ = Describe("Inference Graph controller test", func() {
 							ObjectMeta: metav1.ObjectMeta{
 								Labels: map[string]string{
 									"serving.kserve.io/inferencegraph": graphName,
+									"kserve.io/part-of":                graphName,
 								},
 								Annotations: map[string]string{
 									"autoscaling.knative.dev/min-scale": "1",
 									"autoscaling.knative.dev/class":     "kpa.autoscaling.knative.dev",
 									"serving.kserve.io/deploymentMode":  "Serverless",
+									constants.RouterConfigHashAnnotationKey: actualKnServiceCreated.Spec.Template.
+										Annotations[constants.RouterConfigHashAnnotationKey],
 								},
 							},
 							Spec: knservingv1.RevisionSpec{
@@ -281,7 +276,7 @@ var _ = Describe("Inference Graph controller test", func() {
 											},
 											Args: []string{
 												"--graph-json",
-												"{\"nodes\":{\"root\":{\"routerType\":\"Sequence\",\"steps\":[{\"serviceUrl\":\"http://someservice.exmaple.com\"}]}},\"resources\":{\"limits\":{\"cpu\":\"123m\",\"memory\":\"123Mi\"},\"requests\":{\"cpu\":\"123m\",\"memory\":\"123Mi\"}}}",
+												"{\"version\":\"v1\",\"nodes\":{\"root\":{\"routerType\":\"Sequence\",\"steps\":[{\"serviceUrl\":\"http://someservice.exmaple.com\"}]}},\"resources\":{\"limits\":{\"cpu\":\"123m\",\"memory\":\"123Mi\"},\"requests\":{\"cpu\":\"123m\",\"memory\":\"123Mi\"}}}",
 											},
 											Resources: v1.ResourceRequirements{
 												Limits: v1.ResourceList{
@@ -314,13 +309,7 @@ var _ = Describe("Inference Graph controller test", func() {
 
 	Context("When creating an IG with podaffinity in the spec", func() {
 		It("Should propagate to underlying pod", func() {
-			var configMap = &v1.ConfigMap{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      constants.InferenceServiceConfigMapName,
-					Namespace: constants.KServeNamespace,
-				},
-				Data: configs,
-			}
+			var configMap = pkgtest.NewConfigMap(configs)
 			Expect(k8sClient.Create(context.TODO(), configMap)).NotTo(HaveOccurred())
 			defer k8sClient.Delete(context.TODO(), configMap)
 			graphName := "singlenode3"
@@ -402,11 +391,14 @@ var _ = Describe("Inference Graph controller test", func() {
 							ObjectMeta: metav1.ObjectMeta{
 								Labels: map[string]string{
 									"serving.kserve.io/inferencegraph": graphName,
+									"kserve.io/part-of":                graphName,
 								},
 								Annotations: map[string]string{
 									"autoscaling.knative.dev/min-scale": "1",
 									"autoscaling.knative.dev/class":     "kpa.autoscaling.knative.dev",
 									"serving.kserve.io/deploymentMode":  "Serverless",
+									constants.RouterConfigHashAnnotationKey: actualKnServiceCreated.Spec.Template.
+										Annotations[constants.RouterConfigHashAnnotationKey],
 								},
 							},
 							Spec: knservingv1.RevisionSpec{
@@ -424,7 +416,7 @@ var _ = Describe("Inference Graph controller test", func() {
 											},
 											Args: []string{
 												"--graph-json",
-												"{\"nodes\":{\"root\":{\"routerType\":\"Sequence\",\"steps\":[{\"serviceUrl\":\"http://someservice.exmaple.com\"}]}},\"resources\":{},\"affinity\":{\"podAffinity\":{\"preferredDuringSchedulingIgnoredDuringExecution\":[{\"weight\":100,\"podAffinityTerm\":{\"labelSelector\":{\"matchExpressions\":[{\"key\":\"serving.kserve.io/inferencegraph\",\"operator\":\"In\",\"values\":[\"singlenode3\"]}]},\"topologyKey\":\"topology.kubernetes.io/zone\"}}]}}}",
+												"{\"version\":\"v1\",\"nodes\":{\"root\":{\"routerType\":\"Sequence\",\"steps\":[{\"serviceUrl\":\"http://someservice.exmaple.com\"}]}},\"resources\":{},\"affinity\":{\"podAffinity\":{\"preferredDuringSchedulingIgnoredDuringExecution\":[{\"weight\":100,\"podAffinityTerm\":{\"labelSelector\":{\"matchExpressions\":[{\"key\":\"serving.kserve.io/inferencegraph\",\"operator\":\"In\",\"values\":[\"singlenode3\"]}]},\"topologyKey\":\"topology.kubernetes.io/zone\"}}]}}}",
 											},
 											Resources: v1.ResourceRequirements{
 												Limits: v1.ResourceList{
@@ -481,13 +473,7 @@ var _ = Describe("Inference Graph controller test", func() {
 	Context("When creating an inferencegraph in Raw deployment mode with annotations", func() {
 		It("Should create a raw k8s resources with podspec", func() {
 			By("By creating a new InferenceGraph")
-			var configMap = &v1.ConfigMap{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      constants.InferenceServiceConfigMapName,
-					Namespace: constants.KServeNamespace,
-				},
-				Data: configs,
-			}
+			var configMap = pkgtest.NewConfigMap(configs)
 			Expect(k8sClient.Create(context.TODO(), configMap)).NotTo(HaveOccurred())
 			defer k8sClient.Delete(context.TODO(), configMap)
 			graphName := "igraw1"
@@ -587,13 +573,7 @@ var _ = Describe("Inference Graph controller test", func() {
 			utils.SetAvailableResourcesForApi(knservingv1.SchemeGroupVersion.String(), nil)
 
 			By("By creating a new InferenceGraph")
-			var configMap = &v1.ConfigMap{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      constants.InferenceServiceConfigMapName,
-					Namespace: constants.KServeNamespace,
-				},
-				Data: configs,
-			}
+			var configMap = pkgtest.NewConfigMap(configs)
 			Expect(k8sClient.Create(context.TODO(), configMap)).NotTo(HaveOccurred())
 			defer k8sClient.Delete(context.TODO(), configMap)
 
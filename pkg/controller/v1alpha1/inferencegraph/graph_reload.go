@@ -0,0 +1,160 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+	routerapi "github.com/kserve/kserve/pkg/router/api"
+)
+
+// routerGraphFileFlag is the flag of the router reading the graph document from a file it reloads when it changes
+const routerGraphFileFlag = "--graph-file"
+
+// startupGraphSpec is the part of the graph the router applies when it starts only
+type startupGraphSpec struct {
+	Quota        *v1alpha1api.QuotaSpec          `json:"quota,omitempty"`
+	CORS         *v1alpha1api.CORSPolicy         `json:"cors,omitempty"`
+	LoadShedding *v1alpha1api.LoadSheddingPolicy `json:"loadShedding,omitempty"`
+}
+
+/*
+Returns whether the raw router of the graph reads the graph document from the graph ConfigMap, so that the graph edits
+are reloaded by the router pods instead of rolling them out. The graphs setting a canary traffic percent are passed
+the graph in the container args, so that their edits are rolled out as a canary revision.
+*/
+func graphHotReload(graph *v1alpha1api.InferenceGraph, config *RouterConfig) bool {
+	return config.GraphHotReload && graph.Spec.CanaryTrafficPercent == nil
+}
+
+// readsGraphFile returns whether the router container reads the graph document from the graph file
+func readsGraphFile(container *v1.Container) bool {
+	return len(container.Args) > 0 && container.Args[0] == routerGraphFileFlag
+}
+
+// setRouterGraphFile mounts the graph ConfigMap into the router container and passes the graph file to the router
+// instead of the graph document
+func setRouterGraphFile(podSpec *v1.PodSpec, graph *v1alpha1api.InferenceGraph) {
+	container := &podSpec.Containers[0]
+	container.Args = []string{routerGraphFileFlag, path.Join(constants.RouterGraphDir, constants.RouterGraphKey)}
+	podSpec.Volumes = append(podSpec.Volumes, v1.Volume{
+		Name: constants.RouterGraphVolumeName,
+		VolumeSource: v1.VolumeSource{
+			ConfigMap: &v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{
+					Name: constants.InferenceGraphRouterGraphConfigMapName(graph.Name),
+				},
+			},
+		},
+	})
+	// the directory is mounted rather than the file, the kubelet does not update the files mounted with a sub path
+	container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{
+		Name:      constants.RouterGraphVolumeName,
+		MountPath: constants.RouterGraphDir,
+		ReadOnly:  true,
+	})
+}
+
+func createRouterGraphConfigMap(graph *v1alpha1api.InferenceGraph) (*v1.ConfigMap, error) {
+	routerSpec, _ := routerGraphSpec(&graph.Spec, false)
+	data, err := routerapi.Encode(routerSpec)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      constants.InferenceGraphRouterGraphConfigMapName(graph.Name),
+			Namespace: graph.Namespace,
+			Labels:    map[string]string{constants.InferenceGraphLabel: graph.Name},
+		},
+		Data: map[string]string{
+			constants.RouterGraphKey: string(data),
+		},
+	}, nil
+}
+
+/*
+Writes the graph document to the graph ConfigMap the raw router reloads. The ConfigMap is left unchanged while the
+graph sets a canary traffic percent, since the router pods of the stable revision may still read it, and is deleted
+once the router config disables the hot reload when it is owned by the graph. A ConfigMap of the same name the graph
+does not control is never updated.
+*/
+func (r *InferenceGraphReconciler) reconcileRouterGraphConfigMap(ctx context.Context, graph *v1alpha1api.InferenceGraph,
+	config *RouterConfig) error {
+	name := constants.InferenceGraphRouterGraphConfigMapName(graph.Name)
+	if !graphHotReload(graph, config) {
+		if config.GraphHotReload {
+			return nil
+		}
+		// the ConfigMap is looked up first so that the graphs which never enabled the hot reload are not sent a
+		// delete on every reconcile, and a ConfigMap of the same name the graph does not own is left alone
+		existing, err := r.Clientset.CoreV1().ConfigMaps(graph.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if apierr.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		if !metav1.IsControlledBy(existing, graph) {
+			return nil
+		}
+		r.Log.Info("Deleting inference graph router graph configmap", "namespace", graph.Namespace, "name", name)
+		err = r.Clientset.CoreV1().ConfigMaps(graph.Namespace).Delete(ctx, name, metav1.DeleteOptions{})
+		if apierr.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	desired, err := createRouterGraphConfigMap(graph)
+	if err != nil {
+		return err
+	}
+	if err := controllerutil.SetControllerReference(graph, desired, r.Scheme); err != nil {
+		return err
+	}
+
+	existing, err := r.Clientset.CoreV1().ConfigMaps(graph.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierr.IsNotFound(err) {
+			r.Log.Info("Creating inference graph router graph configmap", "namespace", graph.Namespace, "name", name)
+			_, err = r.Clientset.CoreV1().ConfigMaps(graph.Namespace).Create(ctx, desired, metav1.CreateOptions{})
+		}
+		return err
+	}
+	if !metav1.IsControlledBy(existing, graph) {
+		return fmt.Errorf("configmap %s/%s exists and is not controlled by inference graph %s", existing.Namespace,
+			existing.Name, graph.Name)
+	}
+	if equality.Semantic.DeepEqual(desired.Data, existing.Data) &&
+		equality.Semantic.DeepEqual(desired.Labels, existing.Labels) {
+		return nil
+	}
+	existing.Labels = desired.Labels
+	existing.Data = desired.Data
+	_, err = r.Clientset.CoreV1().ConfigMaps(graph.Namespace).Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func newHotReloadGraph() *v1alpha1api.InferenceGraph {
+	return &v1alpha1api.InferenceGraph{
+		ObjectMeta: metav1.ObjectMeta{Name: "graph", Namespace: "default", UID: "uid"},
+		Spec: v1alpha1api.InferenceGraphSpec{
+			Nodes: map[string]v1alpha1api.InferenceRouter{
+				v1alpha1api.GraphRootNodeName: {
+					RouterType: v1alpha1api.Sequence,
+					Steps: []v1alpha1api.InferenceStep{{
+						InferenceTarget: v1alpha1api.InferenceTarget{ServiceURL: "http://model"},
+						Headers: []v1alpha1api.StepHeader{{Name: "X-Api-Key", SecretKeyRef: &v1.SecretKeySelector{
+							LocalObjectReference: v1.LocalObjectReference{Name: "api-keys"}, Key: "model"}}},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func TestRouterGraphFilePodSpec(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	config := &RouterConfig{Image: "kserve/router:v0.10.0", CpuRequest: "100m", CpuLimit: "100m", MemoryRequest: "100Mi",
+		MemoryLimit: "100Mi", GraphHotReload: true}
+	graph := newHotReloadGraph()
+
+	podSpec := createInferenceGraphPodSpec(graph, config)
+	container := podSpec.Containers[0]
+	g.Expect(container.Args).To(gomega.Equal([]string{"--graph-file", "/etc/router/graph/graph.json"}))
+	g.Expect(container.VolumeMounts).To(gomega.ContainElement(v1.VolumeMount{
+		Name: constants.RouterGraphVolumeName, MountPath: constants.RouterGraphDir, ReadOnly: true}))
+	g.Expect(podSpec.Volumes).To(gomega.ContainElement(v1.Volume{
+		Name: constants.RouterGraphVolumeName,
+		VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{
+			LocalObjectReference: v1.LocalObjectReference{Name: "graph-router-graph"}}},
+	}))
+	g.Expect(container.Env).To(gomega.ContainElement(gomega.HaveField("Name", "KSERVE_STEP_HEADER_0")))
+
+	// the graph document is passed in the args with a canary traffic percent or without hot reload
+	graph.Spec.CanaryTrafficPercent = proto.Int64(10)
+	g.Expect(createInferenceGraphPodSpec(graph, config).Containers[0].Args[0]).To(gomega.Equal("--graph-json"))
+	graph.Spec.CanaryTrafficPercent = nil
+	config.GraphHotReload = false
+	g.Expect(createInferenceGraphPodSpec(graph, config).Containers[0].Args[0]).To(gomega.Equal("--graph-json"))
+}
+
+func TestRouterGraphFileConfigHash(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	clientset := fakeclientset.NewSimpleClientset()
+	config := &RouterConfig{Image: "kserve/router:v0.10.0", CpuRequest: "100m", CpuLimit: "100m", MemoryRequest: "100Mi",
+		MemoryLimit: "100Mi", GraphHotReload: true}
+	graph := newHotReloadGraph()
+	hash := func() string {
+		podSpec := createInferenceGraphPodSpec(graph, config)
+		configHash, err := routerConfigHash(clientset, graph, config, &podSpec.Containers[0])
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		return configHash
+	}
+	initial := hash()
+
+	// the nodes are reloaded by the router, editing them does not roll the router pods out
+	graph.Spec.Nodes[v1alpha1api.GraphRootNodeName].Steps[0].ServiceURL = "http://model-v2"
+	g.Expect(hash()).To(gomega.Equal(initial))
+
+	// the quota is applied when the router starts
+	graph.Spec.Quota = &v1alpha1api.QuotaSpec{Period: metav1.Duration{Duration: 60e9}}
+	withQuota := hash()
+	g.Expect(withQuota).NotTo(gomega.Equal(initial))
+	graph.Spec.LoadShedding = &v1alpha1api.LoadSheddingPolicy{MaxInFlightRequests: proto.Int32(100)}
+	g.Expect(hash()).NotTo(gomega.Equal(withQuota))
+}
+
+func TestReconcileRouterGraphConfigMap(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(v1alpha1api.AddToScheme(scheme)).To(gomega.Succeed())
+	clientset := fakeclientset.NewSimpleClientset()
+	r := &InferenceGraphReconciler{Clientset: clientset, Log: logf.Log.WithName("test"), Scheme: scheme}
+	config := &RouterConfig{GraphHotReload: true}
+	graph := newHotReloadGraph()
+	ctx := context.TODO()
+	getConfigMap := func() (*v1.ConfigMap, error) {
+		return clientset.CoreV1().ConfigMaps("default").Get(ctx, "graph-router-graph", metav1.GetOptions{})
+	}
+
+	// the Secret references of the step headers are not escaped in the graph file
+	g.Expect(r.reconcileRouterGraphConfigMap(ctx, graph, config)).To(gomega.Succeed())
+	configMap, err := getConfigMap()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(configMap.Labels).To(gomega.Equal(map[string]string{constants.InferenceGraphLabel: "graph"}))
+	g.Expect(configMap.OwnerReferences).To(gomega.HaveLen(1))
+	g.Expect(configMap.Data[constants.RouterGraphKey]).To(gomega.HavePrefix(`{"version":"v1",`))
+	g.Expect(configMap.Data[constants.RouterGraphKey]).To(gomega.ContainSubstring(
+		`"headers":[{"name":"X-Api-Key","value":"$(KSERVE_STEP_HEADER_0)"}]`))
+
+	// the graph edits are written to the ConfigMap
+	graph.Spec.Nodes[v1alpha1api.GraphRootNodeName].Steps[0].ServiceURL = "http://model-v2"
+	g.Expect(r.reconcileRouterGraphConfigMap(ctx, graph, config)).To(gomega.Succeed())
+	configMap, _ = getConfigMap()
+	g.Expect(configMap.Data[constants.RouterGraphKey]).To(gomega.ContainSubstring("http://model-v2"))
+
+	// the ConfigMap is kept as is during a canary rollout
+	graph.Spec.CanaryTrafficPercent = proto.Int64(10)
+	graph.Spec.Nodes[v1alpha1api.GraphRootNodeName].Steps[0].ServiceURL = "http://model-v3"
+	g.Expect(r.reconcileRouterGraphConfigMap(ctx, graph, config)).To(gomega.Succeed())
+	configMap, _ = getConfigMap()
+	g.Expect(configMap.Data[constants.RouterGraphKey]).To(gomega.ContainSubstring("http://model-v2"))
+
+	// the ConfigMap is deleted once the hot reload is disabled
+	config.GraphHotReload = false
+	g.Expect(r.reconcileRouterGraphConfigMap(ctx, graph, config)).To(gomega.Succeed())
+	_, err = getConfigMap()
+	g.Expect(err).To(gomega.HaveOccurred())
+
+	// nothing is deleted when there is no ConfigMap
+	clientset.ClearActions()
+	g.Expect(r.reconcileRouterGraphConfigMap(ctx, graph, config)).To(gomega.Succeed())
+	for _, action := range clientset.Actions() {
+		g.Expect(action.GetVerb()).NotTo(gomega.Equal("delete"))
+	}
+
+	// a ConfigMap of the same name the graph does not own is left alone
+	_, err = clientset.CoreV1().ConfigMaps("default").Create(ctx, &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "graph-router-graph", Namespace: "default"},
+	}, metav1.CreateOptions{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(r.reconcileRouterGraphConfigMap(ctx, graph, config)).To(gomega.Succeed())
+	_, err = getConfigMap()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	// and is not overwritten once the hot reload is enabled again
+	config.GraphHotReload = true
+	graph.Spec.CanaryTrafficPercent = nil
+	err = r.reconcileRouterGraphConfigMap(ctx, graph, config)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("is not controlled by inference graph graph")))
+	configMap, err = getConfigMap()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(configMap.Data).To(gomega.BeEmpty())
+}
@@ -18,13 +18,14 @@ package inferencegraph
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"reflect"
 	"strings"
 
 	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/constants"
+	isvcutils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
+	routerapi "github.com/kserve/kserve/pkg/router/api"
 	"github.com/kserve/kserve/pkg/utils"
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/proto"
@@ -84,6 +85,16 @@ func reconcileKsvc(desired *knservingv1.Service, existing *knservingv1.Service)
 	existing.Spec.ConfigurationSpec = desired.Spec.ConfigurationSpec
 	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
 	existing.Spec.Traffic = desired.Spec.Traffic
+	if existing.ObjectMeta.Annotations == nil {
+		existing.ObjectMeta.Annotations = make(map[string]string)
+	}
+	for _, key := range constants.RouteAnnotationAllowList {
+		if desiredValue, ok := desired.ObjectMeta.Annotations[key]; ok {
+			existing.ObjectMeta.Annotations[key] = desiredValue
+		} else {
+			delete(existing.ObjectMeta.Annotations, key)
+		}
+	}
 	return nil
 }
 
@@ -91,6 +102,7 @@ func (r *GraphKnativeServiceReconciler) Reconcile() (*knservingv1.ServiceStatus,
 	desired := r.Service
 	existing := &knservingv1.Service{}
 
+	checkResult := constants.CheckResultUnknown
 	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
 		log.Info("Updating inference graph knative service", "namespace", desired.Namespace, "name", desired.Name)
 		if err := r.client.Get(context.TODO(), types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing); err != nil {
@@ -114,6 +126,10 @@ func (r *GraphKnativeServiceReconciler) Reconcile() (*knservingv1.ServiceStatus,
 			return err
 		}
 
+		checkResult = constants.CheckResultUpdate
+		if semanticEquals(desired, existing) {
+			checkResult = constants.CheckResultExisted
+		}
 		if err := reconcileKsvc(desired, existing); err != nil {
 			return err
 		}
@@ -122,28 +138,35 @@ func (r *GraphKnativeServiceReconciler) Reconcile() (*knservingv1.ServiceStatus,
 	if err != nil {
 		if apierr.IsNotFound(err) {
 			log.Info("Creating inference graph knative service", "namespace", desired.Namespace, "name", desired.Name)
-			return &desired.Status, r.client.Create(context.TODO(), desired)
+			err = r.client.Create(context.TODO(), desired)
+			isvcutils.ChildResources.RecordChild("KnativeService", desired, constants.CheckResultCreate, err)
+			return &desired.Status, err
 		}
+		isvcutils.ChildResources.RecordChild("KnativeService", desired, checkResult, err)
 		return &existing.Status, errors.Wrapf(err, "fails to reconcile inference graph knative service")
 	}
+	isvcutils.ChildResources.RecordChild("KnativeService", desired, checkResult, nil)
 	return &existing.Status, nil
 }
 
 func semanticEquals(desiredService, service *knservingv1.Service) bool {
 	return equality.Semantic.DeepEqual(desiredService.Spec.ConfigurationSpec, service.Spec.ConfigurationSpec) &&
 		equality.Semantic.DeepEqual(desiredService.ObjectMeta.Labels, service.ObjectMeta.Labels) &&
-		equality.Semantic.DeepEqual(desiredService.Spec.RouteSpec, service.Spec.RouteSpec)
+		equality.Semantic.DeepEqual(desiredService.Spec.RouteSpec, service.Spec.RouteSpec) &&
+		equality.Semantic.DeepEqual(utils.GetRouteAnnotations(desiredService.ObjectMeta.Annotations),
+			utils.GetRouteAnnotations(service.ObjectMeta.Annotations))
 }
 
 func createKnativeService(componentMeta metav1.ObjectMeta, graph *v1alpha1api.InferenceGraph, config *RouterConfig) *knservingv1.Service {
-	bytes, err := json.Marshal(graph.Spec)
+	routerSpec, stepHeaderEnvs := routerGraphSpec(&graph.Spec, true)
+	bytes, err := routerapi.Encode(routerSpec)
 	if err != nil {
 		return nil
 	}
-	annotations := componentMeta.GetAnnotations()
-	if annotations == nil {
-		annotations = make(map[string]string)
-	}
+	// annotations set on the InferenceGraph take precedence over the router defaults
+	annotations := utils.Union(routerPrometheusAnnotations(config), componentMeta.GetAnnotations())
+	// the estimated cost is not propagated, a new revision is not created when the pricing changes
+	delete(annotations, constants.EstimatedHourlyCostAnnotationKey)
 	labels := componentMeta.GetLabels()
 	if labels == nil {
 		labels = make(map[string]string) //nolint:ineffassign, staticcheck
@@ -165,15 +188,21 @@ func createKnativeService(componentMeta metav1.ObjectMeta, graph *v1alpha1api.In
 		delete(annotations, constants.KnativeOpenshiftEnablePassthroughKey)
 	}
 
+	// Router annotations are copied by Openshift Serverless onto the generated routes
+	for key, value := range utils.GetRouteAnnotations(annotations) {
+		ksvcAnnotations[key] = value
+		delete(annotations, key)
+	}
+
 	labels = utils.Filter(componentMeta.Labels, func(key string) bool {
 		return !utils.Includes(constants.RevisionTemplateLabelDisallowedList, key)
 	})
-	labels[constants.InferenceGraphLabel] = componentMeta.Name
+	labels = utils.Union(labels, constants.InferenceGraphOwnerLabels(componentMeta.Name))
 	service := &knservingv1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        componentMeta.Name,
 			Namespace:   componentMeta.Namespace,
-			Labels:      componentMeta.Labels,
+			Labels:      utils.Union(componentMeta.Labels, constants.InferenceGraphOwnerLabels(componentMeta.Name)),
 			Annotations: ksvcAnnotations,
 		},
 		Spec: knservingv1.ServiceSpec{
@@ -196,16 +225,26 @@ func createKnativeService(componentMeta metav1.ObjectMeta, graph *v1alpha1api.In
 									Resources: constructResourceRequirements(*graph, *config),
 								},
 							},
-							Affinity: graph.Spec.Affinity,
+							Affinity:                  graph.Spec.Affinity,
+							Tolerations:               graph.Spec.Tolerations,
+							NodeSelector:              graph.Spec.NodeSelector,
+							PriorityClassName:         graph.Spec.PriorityClassName,
+							TopologySpreadConstraints: graph.Spec.TopologySpreadConstraints,
+							ServiceAccountName:        graph.Spec.ServiceAccountName,
+							ImagePullSecrets:          graph.Spec.ImagePullSecrets,
 						},
 					},
 				},
 			},
+			RouteSpec: knservingv1.RouteSpec{
+				Traffic: knativeRouterTraffic(graph),
+			},
 		},
 	}
 
-	// Only adding this env variable "PROPAGATE_HEADERS" if router's headers config has the key "propagate"
-	value, exists := config.Headers["propagate"]
+	// Only adding this env variable "PROPAGATE_HEADERS" if router's headers config has the key "propagate" or the graph
+	// sets headers to propagate
+	value, exists := headersToPropagate(graph, config)
 	if exists {
 		service.Spec.ConfigurationSpec.Template.Spec.PodSpec.Containers[0].Env = []v1.EnvVar{
 			{
@@ -214,6 +253,16 @@ func createKnativeService(componentMeta metav1.ObjectMeta, graph *v1alpha1api.In
 			},
 		}
 	}
+	service.Spec.ConfigurationSpec.Template.Spec.PodSpec.Containers[0].Env = append(
+		service.Spec.ConfigurationSpec.Template.Spec.PodSpec.Containers[0].Env, stepHeaderEnvs...)
+	setRouterListeners(&service.Spec.ConfigurationSpec.Template.Spec.PodSpec.Containers[0], config, false)
+	setRouterVolumes(&service.Spec.ConfigurationSpec.Template.Spec.PodSpec, graph)
+	setRouterLimits(&service.Spec.ConfigurationSpec.Template.Spec.PodSpec.Containers[0], config)
+	setRouterLogging(&service.Spec.ConfigurationSpec.Template.Spec.PodSpec.Containers[0], graph)
+	setRouterTraces(&service.Spec.ConfigurationSpec.Template.Spec.PodSpec.Containers[0], graph, config)
+	setRouterPlugins(&service.Spec.ConfigurationSpec.Template.Spec.PodSpec, graph)
+	setRouterSecurityContext(&service.Spec.ConfigurationSpec.Template.Spec.PodSpec, graph)
+	setRouterCaBundle(&service.Spec.ConfigurationSpec.Template.Spec.PodSpec, graph, config)
 	return service
 }
 
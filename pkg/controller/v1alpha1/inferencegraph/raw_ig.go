@@ -17,15 +17,25 @@ limitations under the License.
 package inferencegraph
 
 import (
+	"context"
+	"crypto/sha256"
 	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	"knative.dev/pkg/apis"
 	knapis "knative.dev/pkg/apis"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -36,6 +46,9 @@ import (
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/raw"
+	s3credential "github.com/kserve/kserve/pkg/credentials/s3"
+	routerapi "github.com/kserve/kserve/pkg/router/api"
+	"github.com/kserve/kserve/pkg/utils"
 )
 
 var logger = logf.Log.WithName("InferenceGraphRawDeployer")
@@ -47,7 +60,9 @@ Also propagates headers onto podspec container environment variables.
 This function makes sense to be used in raw k8s deployment mode
 */
 func createInferenceGraphPodSpec(graph *v1alpha1api.InferenceGraph, config *RouterConfig) *v1.PodSpec {
-	bytes, err := json.Marshal(graph.Spec)
+	hotReload := graphHotReload(graph, config)
+	routerSpec, stepHeaderEnvs := routerGraphSpec(&graph.Spec, !hotReload)
+	bytes, err := routerapi.Encode(routerSpec)
 	if err != nil {
 		return nil
 	}
@@ -65,11 +80,18 @@ func createInferenceGraphPodSpec(graph *v1alpha1api.InferenceGraph, config *Rout
 				Resources: constructResourceRequirements(*graph, *config),
 			},
 		},
-		Affinity: graph.Spec.Affinity,
+		Affinity:                  graph.Spec.Affinity,
+		Tolerations:               graph.Spec.Tolerations,
+		NodeSelector:              graph.Spec.NodeSelector,
+		PriorityClassName:         graph.Spec.PriorityClassName,
+		TopologySpreadConstraints: graph.Spec.TopologySpreadConstraints,
+		ServiceAccountName:        graph.Spec.ServiceAccountName,
+		ImagePullSecrets:          graph.Spec.ImagePullSecrets,
 	}
 
-	// Only adding this env variable "PROPAGATE_HEADERS" if router's headers config has the key "propagate"
-	value, exists := config.Headers["propagate"]
+	// Only adding this env variable "PROPAGATE_HEADERS" if router's headers config has the key "propagate" or the graph
+	// sets headers to propagate
+	value, exists := headersToPropagate(graph, config)
 	if exists {
 		podSpec.Containers[0].Env = []v1.EnvVar{
 			{
@@ -78,28 +100,453 @@ func createInferenceGraphPodSpec(graph *v1alpha1api.InferenceGraph, config *Rout
 			},
 		}
 	}
+	podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, stepHeaderEnvs...)
+
+	if hotReload {
+		setRouterGraphFile(podSpec, graph)
+	}
+	setRouterListeners(&podSpec.Containers[0], config, true)
+	setRouterVolumes(podSpec, graph)
+	setRouterTLS(podSpec, graph, config)
+	setRouterLimits(&podSpec.Containers[0], config)
+	setRouterLogging(&podSpec.Containers[0], graph)
+	setRouterTraces(&podSpec.Containers[0], graph, config)
+	setRouterPlugins(podSpec, graph)
+	setRouterSecurityContext(podSpec, graph)
+	setRouterCaBundle(podSpec, graph, config)
 
 	return podSpec
 }
 
+/*
+Returns the graph spec passed to the router along with the environment variables of the router container the step
+headers sourced from Secrets reference. The Secret values are never written to the graph json, the router expands
+the `$(VAR_NAME)` reference of the header value with the environment variable populated by the kubelet instead. The
+reference is escaped as `$$(VAR_NAME)` when the graph is passed in the container args, since the kubelet would
+otherwise expand it.
+*/
+func routerGraphSpec(spec *v1alpha1api.InferenceGraphSpec, escapeArgs bool) (*v1alpha1api.InferenceGraphSpec, []v1.EnvVar) {
+	routerSpec := spec.DeepCopy()
+	// iterate the nodes in a stable order so that the environment variables do not change between reconciliations
+	nodeNames := make([]string, 0, len(routerSpec.Nodes))
+	for nodeName := range routerSpec.Nodes {
+		nodeNames = append(nodeNames, nodeName)
+	}
+	sort.Strings(nodeNames)
+	var envs []v1.EnvVar
+	for _, nodeName := range nodeNames {
+		for _, step := range routerSpec.Nodes[nodeName].Steps {
+			for i := range step.Headers {
+				header := &step.Headers[i]
+				if header.SecretKeyRef == nil {
+					continue
+				}
+				envName := constants.RouterStepHeaderEnvVarPrefix + strconv.Itoa(len(envs))
+				envs = append(envs, v1.EnvVar{
+					Name:      envName,
+					ValueFrom: &v1.EnvVarSource{SecretKeyRef: header.SecretKeyRef},
+				})
+				header.Value = "$(" + envName + ")"
+				if escapeArgs {
+					header.Value = "$" + header.Value
+				}
+				header.SecretKeyRef = nil
+			}
+		}
+	}
+	return routerSpec, envs
+}
+
+/*
+Adds the dedicated health and metrics listeners of the router to its container. The serverless router can only
+expose a single port, so the listeners are only declared as container ports and probed in raw deployment mode.
+*/
+func setRouterListeners(container *v1.Container, config *RouterConfig, declarePorts bool) {
+	if config.HealthPort != 0 {
+		container.Args = append(container.Args, "--health-port", strconv.Itoa(int(config.HealthPort)))
+	}
+	if config.MetricsPort != 0 {
+		container.Args = append(container.Args, "--metrics-port", strconv.Itoa(int(config.MetricsPort)))
+	}
+	if !declarePorts || (config.HealthPort == 0 && config.MetricsPort == 0) {
+		return
+	}
+	container.Ports = []v1.ContainerPort{
+		{Name: constants.RouterPortName, ContainerPort: constants.RouterDefaultPort, Protocol: v1.ProtocolTCP},
+	}
+	if config.MetricsPort != 0 {
+		container.Ports = append(container.Ports, v1.ContainerPort{
+			Name:          constants.RouterMetricsPortName,
+			ContainerPort: config.MetricsPort,
+			Protocol:      v1.ProtocolTCP,
+		})
+	}
+	if config.HealthPort != 0 {
+		probe := &v1.Probe{
+			ProbeHandler: v1.ProbeHandler{
+				HTTPGet: &v1.HTTPGetAction{
+					Path: constants.RouterHealthPath,
+					Port: intstr.FromInt(int(config.HealthPort)),
+				},
+			},
+		}
+		container.LivenessProbe = probe
+		container.ReadinessProbe = probe.DeepCopy()
+	}
+}
+
+// routerTLSEnabled returns whether the router of the graph serves HTTPS, the router TLS annotation of the graph takes
+// precedence over the router config
+func routerTLSEnabled(graph *v1alpha1api.InferenceGraph, config *RouterConfig) bool {
+	if value, ok := graph.Annotations[constants.RouterTLSAnnotationKey]; ok {
+		if enabled, err := strconv.ParseBool(value); err == nil {
+			return enabled
+		}
+		logger.Info("Ignoring invalid annotation", "annotation", constants.RouterTLSAnnotationKey, "value", value)
+	}
+	return config.TLS != nil && config.TLS.Enabled
+}
+
+// setRouterVolumes adds the volumes of the graph to the router pod and their mounts to the router container, ahead of
+// the volumes of the router
+func setRouterVolumes(podSpec *v1.PodSpec, graph *v1alpha1api.InferenceGraph) {
+	for i := range graph.Spec.Volumes {
+		podSpec.Volumes = append(podSpec.Volumes, *graph.Spec.Volumes[i].DeepCopy())
+	}
+	container := &podSpec.Containers[0]
+	for i := range graph.Spec.VolumeMounts {
+		container.VolumeMounts = append(container.VolumeMounts, *graph.Spec.VolumeMounts[i].DeepCopy())
+	}
+}
+
+// setRouterTLS serves the graph port of the router over HTTPS with the certificate of the TLS Secret of the graph.
+// The graph port is declared as the https port so that the Service exposes it on 443, and the probes of the health
+// endpoint served on the graph port use HTTPS. A dedicated health port keeps serving HTTP.
+func setRouterTLS(podSpec *v1.PodSpec, graph *v1alpha1api.InferenceGraph, config *RouterConfig) {
+	if !routerTLSEnabled(graph, config) {
+		return
+	}
+	podSpec.Volumes = append(podSpec.Volumes, v1.Volume{
+		Name: constants.RouterTLSVolumeName,
+		VolumeSource: v1.VolumeSource{
+			Secret: &v1.SecretVolumeSource{SecretName: graph.Name + constants.RouterTLSSecretSuffix},
+		},
+	})
+	container := &podSpec.Containers[0]
+	container.Args = append(container.Args, "--enable-tls",
+		"--tls-cert-file", path.Join(constants.RouterTLSDir, v1.TLSCertKey),
+		"--tls-key-file", path.Join(constants.RouterTLSDir, v1.TLSPrivateKeyKey))
+	container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{
+		Name:      constants.RouterTLSVolumeName,
+		MountPath: constants.RouterTLSDir,
+		ReadOnly:  true,
+	})
+	if len(container.Ports) == 0 {
+		container.Ports = []v1.ContainerPort{
+			{ContainerPort: constants.RouterDefaultPort, Protocol: v1.ProtocolTCP},
+		}
+	}
+	container.Ports[0].Name = constants.HttpsPortName
+	if config.HealthPort == 0 {
+		probe := &v1.Probe{
+			ProbeHandler: v1.ProbeHandler{
+				HTTPGet: &v1.HTTPGetAction{
+					Path:   constants.RouterHealthPath,
+					Port:   intstr.FromInt(constants.RouterDefaultPort),
+					Scheme: v1.URISchemeHTTPS,
+				},
+			},
+		}
+		container.LivenessProbe = probe
+		container.ReadinessProbe = probe.DeepCopy()
+	}
+}
+
+// routerServingCertAnnotations returns the annotations requesting the certificate of the router from the OpenShift
+// service-ca operator, which writes it to the TLS Secret of the graph
+func routerServingCertAnnotations(graph *v1alpha1api.InferenceGraph, config *RouterConfig) map[string]string {
+	if !routerTLSEnabled(graph, config) || config.TLS == nil || !config.TLS.OpenshiftServingCert {
+		return nil
+	}
+	return map[string]string{
+		constants.OpenshiftServingCertSecretAnnotationKey: graph.Name + constants.RouterTLSSecretSuffix,
+	}
+}
+
+// headersToPropagate returns the headers the router of the graph propagates to the steps, the headersToPropagate of the
+// graph are added to the propagate headers of the router config unless they replace them. It returns false when no
+// headers are configured.
+func headersToPropagate(graph *v1alpha1api.InferenceGraph, config *RouterConfig) ([]string, bool) {
+	global, exists := config.Headers["propagate"]
+	if graph.Spec.HeadersPropagationPolicy == v1alpha1api.ReplaceHeaders {
+		return graph.Spec.HeadersToPropagate, len(graph.Spec.HeadersToPropagate) > 0
+	}
+	if len(graph.Spec.HeadersToPropagate) == 0 {
+		return global, exists
+	}
+	headers := append([]string{}, global...)
+	for _, header := range graph.Spec.HeadersToPropagate {
+		if !slices.Contains(headers, header) {
+			headers = append(headers, header)
+		}
+	}
+	return headers, true
+}
+
+// setRouterLimits passes the graph limits enforced by the router to its container
+func setRouterLimits(container *v1.Container, config *RouterConfig) {
+	if config.MaxNodesVisited != 0 {
+		container.Args = append(container.Args, "--max-nodes-visited", strconv.Itoa(config.MaxNodesVisited))
+	}
+	if config.MaxFanOut != 0 {
+		container.Args = append(container.Args, "--max-fan-out", strconv.Itoa(config.MaxFanOut))
+	}
+}
+
+// setRouterFIPSMode restricts the HTTPS listener of the router to the FIPS approved TLS settings in FIPS mode
+func setRouterFIPSMode(container *v1.Container, securityConfig *v1beta1.SecurityConfig) {
+	if securityConfig.FIPSMode {
+		container.Args = append(container.Args, "--fips-mode")
+	}
+}
+
+// setRouterLogging passes the log level and format of the graph to the router container
+func setRouterLogging(container *v1.Container, graph *v1alpha1api.InferenceGraph) {
+	logging := graph.Spec.SidecarLogging
+	if logging == nil {
+		return
+	}
+	if logging.Level != "" {
+		container.Args = append(container.Args, "--log-level", string(logging.Level))
+	}
+	if logging.Format != "" {
+		container.Args = append(container.Args, "--log-format", string(logging.Format))
+	}
+}
+
+// setRouterSecurityContext applies the security context overrides of the graph to the router pod, the supplemental
+// groups are set on the pod and the other fields on the router container
+func setRouterSecurityContext(podSpec *v1.PodSpec, graph *v1alpha1api.InferenceGraph) {
+	override := graph.Spec.RouterSecurityContext
+	if override == nil {
+		return
+	}
+	podSpec.Containers[0].SecurityContext = utils.ApplySecurityContextOverride(podSpec.Containers[0].SecurityContext,
+		override.ToSecurityContext())
+	if len(override.SupplementalGroups) > 0 {
+		if podSpec.SecurityContext == nil {
+			podSpec.SecurityContext = &v1.PodSecurityContext{}
+		}
+		podSpec.SecurityContext.SupplementalGroups = override.SupplementalGroups
+	}
+}
+
+/*
+Passes the trace config completed with the identity of the graph to the router container. The S3 credentials of the
+trace store are read from the optional Secret of the namespace of the graph, so that the router falls back on the
+default AWS credentials when it does not exist.
+*/
+func setRouterTraces(container *v1.Container, graph *v1alpha1api.InferenceGraph, config *RouterConfig) {
+	if config.Traces == nil {
+		return
+	}
+	traces := *config.Traces
+	traces.Graph = graph.Name
+	traces.Namespace = graph.Namespace
+	traces.UID = string(graph.UID)
+	traceConfig, err := json.Marshal(&traces)
+	if err != nil {
+		logger.Error(err, "failed to marshal the trace config of the router", "graph", graph.Name)
+		return
+	}
+	container.Args = append(container.Args, "--trace-config", string(traceConfig))
+	if traces.Store != v1alpha1api.S3TraceStore || traces.S3.SecretName == "" {
+		return
+	}
+	optional := true
+	for _, env := range [][2]string{
+		{s3credential.AWSAccessKeyId, s3credential.AWSAccessKeyIdName},
+		{s3credential.AWSSecretAccessKey, s3credential.AWSSecretAccessKeyName},
+	} {
+		container.Env = append(container.Env, v1.EnvVar{
+			Name: env[0],
+			ValueFrom: &v1.EnvVarSource{
+				SecretKeyRef: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{Name: traces.S3.SecretName},
+					Key:                  env[1],
+					Optional:             &optional,
+				},
+			},
+		})
+	}
+}
+
+// routerCaBundle returns the ConfigMap and the key of the CA bundle trusted by the router, the one of the graph
+// annotation or else of the router config. The name is empty when the router trusts the system CAs.
+func routerCaBundle(graph *v1alpha1api.InferenceGraph, config *RouterConfig) (string, string) {
+	name, key := config.CaBundleConfigMapName, ""
+	if value, ok := graph.Annotations[constants.RouterCaBundleAnnotationKey]; ok {
+		if value == constants.RouterCaBundleDisabled {
+			return "", ""
+		}
+		name, key, _ = strings.Cut(value, "/")
+	}
+	if key == "" {
+		key = config.CaBundleKey
+	}
+	if key == "" {
+		key = constants.DefaultCaBundleFileName
+	}
+	return name, key
+}
+
+// setRouterCaBundle mounts the CA bundle trusted by the router and points SSL_CERT_FILE to it, the Go TLS clients of
+// the router then trust the CAs of the bundle instead of the system CAs
+func setRouterCaBundle(podSpec *v1.PodSpec, graph *v1alpha1api.InferenceGraph, config *RouterConfig) {
+	name, key := routerCaBundle(graph, config)
+	if name == "" {
+		return
+	}
+	podSpec.Volumes = append(podSpec.Volumes, v1.Volume{
+		Name: constants.RouterCaBundleVolumeName,
+		VolumeSource: v1.VolumeSource{
+			ConfigMap: &v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: name},
+				Items:                []v1.KeyToPath{{Key: key, Path: key}},
+			},
+		},
+	})
+	container := &podSpec.Containers[0]
+	container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{
+		Name:      constants.RouterCaBundleVolumeName,
+		MountPath: constants.RouterCaBundleDir,
+		ReadOnly:  true,
+	})
+	container.Env = append(container.Env, v1.EnvVar{
+		Name:  constants.SSLCertFileEnvVar,
+		Value: path.Join(constants.RouterCaBundleDir, key),
+	})
+}
+
+// injectedRouterConfig is the configuration the controller injects into the router pods
+type injectedRouterConfig struct {
+	Container v1.Container `json:"container"`
+	CaBundle  string       `json:"caBundle,omitempty"`
+	// StartupGraph is the part of the graph the router does not reload from the graph file
+	StartupGraph *startupGraphSpec `json:"startupGraph,omitempty"`
+}
+
+// routerConfigHash returns the hash of the configuration injected into the router pods: the router container, with the
+// router config, the headers and the listeners it is built from, the content of the CA bundle it trusts and, when the
+// router reads the graph from the graph file, the part of the graph it does not reload. The hash is recorded in the pod
+// template, so that the router pods are rolled out once when the injected configuration changes, including the CA
+// bundle content which the pod spec only references, and never when it does not change.
+func routerConfigHash(clientset kubernetes.Interface, graph *v1alpha1api.InferenceGraph, config *RouterConfig,
+	container *v1.Container) (string, error) {
+	injected := injectedRouterConfig{Container: *container}
+	if readsGraphFile(container) {
+		injected.StartupGraph = &startupGraphSpec{Quota: graph.Spec.Quota, CORS: graph.Spec.CORS,
+			LoadShedding: graph.Spec.LoadShedding}
+	}
+	if name, key := routerCaBundle(graph, config); name != "" {
+		configMap, err := clientset.CoreV1().ConfigMaps(graph.Namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil && !apierr.IsNotFound(err) {
+			return "", err
+		}
+		if err == nil {
+			injected.CaBundle = configMap.Data[key]
+		}
+	}
+	data, err := json.Marshal(injected)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
+
+/*
+Mounts the Go plugins of the graph into the router container as <plugin dir>/<plugin name>.so. The plugins of a
+ConfigMap are mounted from the ConfigMap key, the plugins of an image are copied to an emptyDir volume by an init
+container running cp in the image.
+*/
+func setRouterPlugins(podSpec *v1.PodSpec, graph *v1alpha1api.InferenceGraph) {
+	if len(graph.Spec.Plugins) == 0 {
+		return
+	}
+	container := &podSpec.Containers[0]
+	sharedVolumeMount := v1.VolumeMount{Name: constants.RouterPluginVolumeName, MountPath: constants.RouterPluginDir}
+	for i, plugin := range graph.Spec.Plugins {
+		fileName := plugin.Name + ".so"
+		if plugin.ConfigMap != nil {
+			volumeName := fmt.Sprintf("%s-%d", constants.RouterPluginVolumeName, i)
+			podSpec.Volumes = append(podSpec.Volumes, v1.Volume{
+				Name: volumeName,
+				VolumeSource: v1.VolumeSource{
+					ConfigMap: &v1.ConfigMapVolumeSource{
+						LocalObjectReference: plugin.ConfigMap.LocalObjectReference,
+						Items:                []v1.KeyToPath{{Key: plugin.ConfigMap.Key, Path: fileName}},
+					},
+				},
+			})
+			container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{
+				Name:      volumeName,
+				MountPath: path.Join(constants.RouterPluginDir, fileName),
+				SubPath:   fileName,
+				ReadOnly:  true,
+			})
+			continue
+		}
+		imagePath := plugin.Path
+		if imagePath == "" {
+			imagePath = constants.RouterPluginDefaultImagePath
+		}
+		podSpec.InitContainers = append(podSpec.InitContainers, v1.Container{
+			Name:         fmt.Sprintf("%s-%d", constants.RouterPluginVolumeName, i),
+			Image:        plugin.Image,
+			Command:      []string{"cp", imagePath, path.Join(constants.RouterPluginDir, fileName)},
+			VolumeMounts: []v1.VolumeMount{sharedVolumeMount},
+		})
+	}
+	if len(podSpec.InitContainers) > 0 {
+		podSpec.Volumes = append(podSpec.Volumes, v1.Volume{
+			Name:         constants.RouterPluginVolumeName,
+			VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}},
+		})
+		// the shared volume is mounted first so that the ConfigMap plugins are mounted into it
+		container.VolumeMounts = append([]v1.VolumeMount{sharedVolumeMount}, container.VolumeMounts...)
+	}
+}
+
+// routerPrometheusAnnotations returns the prometheus scraping annotations of the router metrics listener
+func routerPrometheusAnnotations(config *RouterConfig) map[string]string {
+	if config.MetricsPort == 0 {
+		return nil
+	}
+	return map[string]string{
+		constants.PrometheusPortAnnotationKey: strconv.Itoa(int(config.MetricsPort)),
+		constants.PrometheusPathAnnotationKey: constants.DefaultPrometheusPath,
+	}
+}
+
 /*
 A simple utility to create a basic meta object given name and namespace;  Can be extended to accept labels, annotations as well
 */
 func constructForRawDeployment(graph *v1alpha1api.InferenceGraph) (metav1.ObjectMeta, v1beta1.ComponentExtensionSpec) {
 	name := graph.ObjectMeta.Name
 	namespace := graph.ObjectMeta.Namespace
-	annotations := graph.ObjectMeta.Annotations
+	// the estimated cost is not propagated, the router pods are not restarted when the pricing changes
+	annotations := utils.Filter(graph.ObjectMeta.Annotations, func(key string) bool {
+		return key != constants.EstimatedHourlyCostAnnotationKey
+	})
 	labels := graph.ObjectMeta.Labels
 
-	if annotations == nil {
-		annotations = make(map[string]string)
-	}
-
 	if labels == nil {
 		labels = make(map[string]string)
 	}
 
-	labels[constants.InferenceGraphLabel] = name
+	for key, value := range constants.InferenceGraphOwnerLabels(name) {
+		labels[key] = value
+	}
 
 	objectMeta := metav1.ObjectMeta{
 		Name:        name,
@@ -109,10 +556,12 @@ func constructForRawDeployment(graph *v1alpha1api.InferenceGraph) (metav1.Object
 	}
 
 	componentExtensionSpec := v1beta1.ComponentExtensionSpec{
-		MaxReplicas: graph.Spec.MaxReplicas,
-		MinReplicas: graph.Spec.MinReplicas,
-		ScaleMetric: (*v1beta1.ScaleMetric)(graph.Spec.ScaleMetric),
-		ScaleTarget: graph.Spec.ScaleTarget,
+		MaxReplicas:        graph.Spec.MaxReplicas,
+		MinReplicas:        graph.Spec.MinReplicas,
+		ScaleMetric:        (*v1beta1.ScaleMetric)(graph.Spec.ScaleMetric),
+		ScaleTarget:        graph.Spec.ScaleTarget,
+		DeploymentStrategy: graph.Spec.DeploymentStrategy,
+		MinReadySeconds:    graph.Spec.MinReadySeconds,
 	}
 
 	return objectMeta, componentExtensionSpec
@@ -120,25 +569,38 @@ func constructForRawDeployment(graph *v1alpha1api.InferenceGraph) (metav1.Object
 
 /*
 Handles bulk of raw deployment logic for Inference graph controller
-1. Constructs PodSpec
-2. Constructs Meta and Extensionspec
-3. Creates a reconciler
-4. Set controller references
-5. Finally reconcile
+1. Constructs Meta and Extensionspec
+2. Creates a reconciler
+3. Set controller references
+4. Finally reconcile
+
+The Service selects the pods of the canary Deployment as well when a canary rollout is in progress, the stable Deployment
+is then scaled to at least the replicas the canary traffic percent is split from.
 */
-func handleInferenceGraphRawDeployment(cl client.Client, clientset kubernetes.Interface, scheme *runtime.Scheme,
-	graph *v1alpha1api.InferenceGraph, routerConfig *RouterConfig) (*appsv1.Deployment, *knapis.URL, error) {
-	// create desired service object.
-	desiredSvc := createInferenceGraphPodSpec(graph, routerConfig)
+func handleInferenceGraphRawDeployment(cl client.Client, clientset kubernetes.Interface, scheme *runtime.Scheme, recorder record.EventRecorder,
+	graph *v1alpha1api.InferenceGraph, revision *routerRevision, canary bool, config *RouterConfig) (*appsv1.Deployment, *knapis.URL, error) {
 
 	objectMeta, componentExtSpec := constructForRawDeployment(graph)
+	var minReplicas int32
+	if canary {
+		// the stable Deployment is not scaled below the replicas the canary traffic percent is split from
+		minReplicas = rawStableMinReplicas(graph)
+		autoscalerMinReplicas := int(minReplicas)
+		componentExtSpec.MinReplicas = &autoscalerMinReplicas
+	}
+	// annotations set on the InferenceGraph take precedence over the router defaults
+	objectMeta.Annotations = utils.Union(routerPrometheusAnnotations(config), routerServingCertAnnotations(graph, config),
+		objectMeta.Annotations, revision.annotations())
 
 	// create the reconciler
-	reconciler, err := raw.NewRawKubeReconciler(cl, clientset, scheme, objectMeta, &componentExtSpec, desiredSvc)
+	reconciler, err := raw.NewRawKubeReconciler(cl, clientset, scheme, recorder, objectMeta, &componentExtSpec, revision.PodSpec)
 
 	if err != nil {
 		return nil, reconciler.URL, errors.Wrapf(err, "fails to create NewRawKubeReconciler for inference graph")
 	}
+	if canary {
+		reconciler.Service.Service.Spec.Selector = map[string]string{constants.InferenceGraphLabel: graph.Name}
+	}
 	// set Deployment Controller
 	if err := controllerutil.SetControllerReference(graph, reconciler.Deployment.Deployment, scheme); err != nil {
 		return nil, reconciler.URL, errors.Wrapf(err, "fails to set deployment owner reference for inference graph")
@@ -161,6 +623,13 @@ func handleInferenceGraphRawDeployment(cl client.Client, clientset kubernetes.In
 	if err != nil {
 		return deployment, reconciler.URL, errors.Wrapf(err, "fails to reconcile inference graph raw")
 	}
+	// the replicas are not compared by the deployment reconciler, the autoscaler only scales up to its new minimum later
+	if canary && (deployment.Spec.Replicas == nil || *deployment.Spec.Replicas < minReplicas) {
+		deployment.Spec.Replicas = &minReplicas
+		if err := cl.Update(context.TODO(), deployment); err != nil {
+			return deployment, reconciler.URL, errors.Wrapf(err, "fails to scale inference graph raw deployment")
+		}
+	}
 
 	return deployment, reconciler.URL, nil
 }
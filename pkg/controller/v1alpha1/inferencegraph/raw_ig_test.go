@@ -17,7 +17,9 @@ limitations under the License.
 package inferencegraph
 
 import (
+	"context"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	. "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
@@ -25,9 +27,12 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"testing"
+	"time"
 )
 
 func TestCreateInferenceGraphPodSpec(t *testing.T) {
@@ -148,7 +153,7 @@ func TestCreateInferenceGraphPodSpec(t *testing.T) {
 					Name:  "basic-ig",
 					Args: []string{
 						"--graph-json",
-						"{\"nodes\":{\"root\":{\"routerType\":\"Sequence\",\"steps\":[{\"serviceUrl\":\"http://someservice.exmaple.com\"}]}},\"resources\":{}}",
+						"{\"version\":\"v1\",\"nodes\":{\"root\":{\"routerType\":\"Sequence\",\"steps\":[{\"serviceUrl\":\"http://someservice.exmaple.com\"}]}},\"resources\":{}}",
 					},
 					Resources: v1.ResourceRequirements{
 						Limits: v1.ResourceList{
@@ -170,7 +175,7 @@ func TestCreateInferenceGraphPodSpec(t *testing.T) {
 					Name:  "basic-ig",
 					Args: []string{
 						"--graph-json",
-						"{\"nodes\":{\"root\":{\"routerType\":\"Sequence\",\"steps\":[{\"serviceUrl\":\"http://someservice.exmaple.com\"}]}},\"resources\":{}}",
+						"{\"version\":\"v1\",\"nodes\":{\"root\":{\"routerType\":\"Sequence\",\"steps\":[{\"serviceUrl\":\"http://someservice.exmaple.com\"}]}},\"resources\":{}}",
 					},
 					Env: []v1.EnvVar{
 						{
@@ -198,7 +203,7 @@ func TestCreateInferenceGraphPodSpec(t *testing.T) {
 					Name:  "resource-ig",
 					Args: []string{
 						"--graph-json",
-						"{\"nodes\":{\"root\":{\"routerType\":\"Sequence\",\"steps\":[{\"serviceUrl\":\"http://someservice.exmaple.com\"}]}},\"resources\":{\"limits\":{\"cpu\":\"100m\",\"memory\":\"500Mi\"},\"requests\":{\"cpu\":\"100m\",\"memory\":\"100Mi\"}}}",
+						"{\"version\":\"v1\",\"nodes\":{\"root\":{\"routerType\":\"Sequence\",\"steps\":[{\"serviceUrl\":\"http://someservice.exmaple.com\"}]}},\"resources\":{\"limits\":{\"cpu\":\"100m\",\"memory\":\"500Mi\"},\"requests\":{\"cpu\":\"100m\",\"memory\":\"100Mi\"}}}",
 					},
 					Resources: v1.ResourceRequirements{
 						Limits: v1.ResourceList{
@@ -264,6 +269,7 @@ func TestConstructGraphObjectMeta(t *testing.T) {
 	}
 
 	cpuResource := v1beta1.MetricCPU
+	minReadySeconds := int32(30)
 
 	scenarios := []struct {
 		name     string
@@ -286,6 +292,7 @@ func TestConstructGraphObjectMeta(t *testing.T) {
 					Namespace: "basic-ig-namespace",
 					Labels: map[string]string{
 						"serving.kserve.io/inferencegraph": "basic-ig",
+						"kserve.io/part-of":                "basic-ig",
 					},
 					Annotations: map[string]string{},
 				},
@@ -321,6 +328,7 @@ func TestConstructGraphObjectMeta(t *testing.T) {
 					Namespace: "basic-ig-namespace",
 					Labels: map[string]string{
 						"serving.kserve.io/inferencegraph": "basic-ig",
+						"kserve.io/part-of":                "basic-ig",
 					},
 					Annotations: map[string]string{
 						"test": "test",
@@ -354,6 +362,7 @@ func TestConstructGraphObjectMeta(t *testing.T) {
 					Namespace: "basic-ig-namespace",
 					Labels: map[string]string{
 						"serving.kserve.io/inferencegraph": "basic-ig",
+						"kserve.io/part-of":                "basic-ig",
 						"test":                             "test",
 					},
 					Annotations: map[string]string{},
@@ -385,6 +394,10 @@ func TestConstructGraphObjectMeta(t *testing.T) {
 						MaxReplicas: 10,
 						ScaleTarget: v1beta1.GetIntReference(50),
 						ScaleMetric: (*ScaleMetric)(&cpuResource),
+						DeploymentStrategy: &appsv1.DeploymentStrategy{
+							Type: appsv1.RecreateDeploymentStrategyType,
+						},
+						MinReadySeconds: &minReadySeconds,
 					},
 				},
 			},
@@ -394,6 +407,7 @@ func TestConstructGraphObjectMeta(t *testing.T) {
 					Namespace: "basic-ig-namespace",
 					Labels: map[string]string{
 						"serving.kserve.io/inferencegraph": "basic-ig",
+						"kserve.io/part-of":                "basic-ig",
 						"test":                             "test",
 					},
 					Annotations: map[string]string{
@@ -405,6 +419,10 @@ func TestConstructGraphObjectMeta(t *testing.T) {
 					MaxReplicas: 10,
 					ScaleTarget: v1beta1.GetIntReference(50),
 					ScaleMetric: &cpuResource,
+					DeploymentStrategy: &appsv1.DeploymentStrategy{
+						Type: appsv1.RecreateDeploymentStrategyType,
+					},
+					MinReadySeconds: &minReadySeconds,
 				},
 			},
 		},
@@ -511,3 +529,805 @@ func TestPropagateRawStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestSetPausedCondition(t *testing.T) {
+	ready := apis.Condition{Type: apis.ConditionReady, Status: v1.ConditionTrue}
+	status := &InferenceGraphStatus{Status: duckv1.Status{Conditions: duckv1.Conditions{ready}}}
+
+	setPausedCondition(status, true)
+	paused := status.GetCondition(v1beta1.Paused)
+	if paused == nil || paused.Status != v1.ConditionTrue || len(status.Conditions) != 2 {
+		t.Errorf("Expected a Paused condition, got %v", status.Conditions)
+	}
+
+	// The existing condition is kept so that the status does not change on every reconciliation
+	setPausedCondition(status, true)
+	if diff := cmp.Diff(paused, status.GetCondition(v1beta1.Paused)); diff != "" {
+		t.Errorf("Paused condition changed (-want +got): %v", diff)
+	}
+
+	setPausedCondition(status, false)
+	if diff := cmp.Diff(duckv1.Conditions{ready}, status.Conditions); diff != "" {
+		t.Errorf("Conditions mismatch (-want +got): %v", diff)
+	}
+}
+
+func TestSetServiceMeshMemberCondition(t *testing.T) {
+	paused := apis.Condition{Type: v1beta1.Paused, Status: v1.ConditionTrue}
+	status := &InferenceGraphStatus{Status: duckv1.Status{Conditions: duckv1.Conditions{paused}}}
+
+	setServiceMeshMemberCondition(status, "not enrolled")
+	member := status.GetCondition(v1beta1.ServiceMeshMember)
+	if member == nil || member.Status != v1.ConditionFalse || member.Message != "not enrolled" || len(status.Conditions) != 2 {
+		t.Errorf("Expected a false ServiceMeshMember condition, got %v", status.Conditions)
+	}
+
+	// The existing condition is kept so that the status does not change on every reconciliation
+	setServiceMeshMemberCondition(status, "not enrolled")
+	if diff := cmp.Diff(member, status.GetCondition(v1beta1.ServiceMeshMember)); diff != "" {
+		t.Errorf("ServiceMeshMember condition changed (-want +got): %v", diff)
+	}
+}
+
+func TestSetScheduledCondition(t *testing.T) {
+	ready := apis.Condition{Type: apis.ConditionReady, Status: v1.ConditionTrue}
+	status := &InferenceGraphStatus{Status: duckv1.Status{Conditions: duckv1.Conditions{ready}}}
+	closing := time.Date(2024, 3, 1, 18, 0, 0, 0, time.UTC)
+
+	setScheduledCondition(status, true, true, closing)
+	scheduled := status.GetCondition(v1beta1.Scheduled)
+	if scheduled == nil || scheduled.Status != v1.ConditionTrue || scheduled.Message != "The router runs until 2024-03-01T18:00:00Z" ||
+		len(status.Conditions) != 2 {
+		t.Errorf("Expected a true Scheduled condition, got %v", status.Conditions)
+	}
+
+	// The transition time is kept so that the status does not change on every reconciliation
+	setScheduledCondition(status, true, true, closing)
+	if diff := cmp.Diff(scheduled, status.GetCondition(v1beta1.Scheduled)); diff != "" {
+		t.Errorf("Scheduled condition changed (-want +got): %v", diff)
+	}
+
+	setScheduledCondition(status, true, false, closing.Add(14*time.Hour))
+	scheduled = status.GetCondition(v1beta1.Scheduled)
+	if scheduled.Status != v1.ConditionFalse || scheduled.Message != "The router is removed until 2024-03-02T08:00:00Z" {
+		t.Errorf("Expected a false Scheduled condition, got %v", scheduled)
+	}
+	if notReady := status.GetCondition(apis.ConditionReady); notReady.Status != v1.ConditionFalse || notReady.Reason != "OutsideActiveHours" {
+		t.Errorf("Expected the graph not ready outside the active hours, got %v", notReady)
+	}
+
+	setScheduledCondition(status, false, true, time.Time{})
+	if status.GetCondition(v1beta1.Scheduled) != nil || len(status.Conditions) != 1 {
+		t.Errorf("Expected the Scheduled condition to be removed, got %v", status.Conditions)
+	}
+}
+
+func TestGetInferenceGraphEndpoints(t *testing.T) {
+	externalURL := &apis.URL{Scheme: "https", Host: "graph.default.example.com"}
+	clusterLocalURL := &apis.URL{Scheme: "http", Host: "graph.default.svc.cluster.local"}
+	scenarios := map[string]struct {
+		url             *apis.URL
+		clusterLocalURL *apis.URL
+		expected        []Endpoint
+	}{
+		"external": {
+			url:             externalURL,
+			clusterLocalURL: clusterLocalURL,
+			expected: []Endpoint{
+				{Name: "external", Visibility: "External", Protocol: "https", URL: externalURL},
+				{Name: "cluster-local", Visibility: "ClusterLocal", Protocol: "http", URL: clusterLocalURL},
+			},
+		},
+		"cluster local": {
+			url:             clusterLocalURL,
+			clusterLocalURL: clusterLocalURL,
+			expected: []Endpoint{
+				{Name: "cluster-local", Visibility: "ClusterLocal", Protocol: "http", URL: clusterLocalURL},
+			},
+		},
+		"not ready": {
+			clusterLocalURL: clusterLocalURL,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			endpoints := getInferenceGraphEndpoints(scenario.url, scenario.clusterLocalURL)
+			if diff := cmp.Diff(scenario.expected, endpoints); diff != "" {
+				t.Errorf("Endpoints mismatch (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestSetRouterListeners(t *testing.T) {
+	config := &RouterConfig{HealthPort: 8081, MetricsPort: 9091}
+	container := &v1.Container{Args: []string{"--graph-json", "{}"}}
+	setRouterListeners(container, config, true)
+
+	probe := &v1.Probe{
+		ProbeHandler: v1.ProbeHandler{
+			HTTPGet: &v1.HTTPGetAction{Path: constants.RouterHealthPath, Port: intstr.FromInt(8081)},
+		},
+	}
+	expected := &v1.Container{
+		Args: []string{"--graph-json", "{}", "--health-port", "8081", "--metrics-port", "9091"},
+		Ports: []v1.ContainerPort{
+			{Name: constants.RouterPortName, ContainerPort: constants.RouterDefaultPort, Protocol: v1.ProtocolTCP},
+			{Name: constants.RouterMetricsPortName, ContainerPort: 9091, Protocol: v1.ProtocolTCP},
+		},
+		LivenessProbe:  probe,
+		ReadinessProbe: probe,
+	}
+	if diff := cmp.Diff(expected, container); diff != "" {
+		t.Errorf("Raw router container mismatch (-want +got): %v", diff)
+	}
+
+	// The serverless router can only expose the graph port
+	container = &v1.Container{}
+	setRouterListeners(container, config, false)
+	if diff := cmp.Diff(&v1.Container{Args: []string{"--health-port", "8081", "--metrics-port", "9091"}}, container); diff != "" {
+		t.Errorf("Serverless router container mismatch (-want +got): %v", diff)
+	}
+
+	container = &v1.Container{}
+	setRouterListeners(container, &RouterConfig{}, true)
+	if diff := cmp.Diff(&v1.Container{}, container); diff != "" {
+		t.Errorf("Router container without listeners mismatch (-want +got): %v", diff)
+	}
+
+	expectedAnnotations := map[string]string{
+		constants.PrometheusPortAnnotationKey: "9091",
+		constants.PrometheusPathAnnotationKey: constants.DefaultPrometheusPath,
+	}
+	if diff := cmp.Diff(expectedAnnotations, routerPrometheusAnnotations(config)); diff != "" {
+		t.Errorf("Router prometheus annotations mismatch (-want +got): %v", diff)
+	}
+}
+
+func TestSetRouterLimits(t *testing.T) {
+	container := &v1.Container{}
+	setRouterLimits(container, &RouterConfig{GraphLimits: GraphLimits{MaxNodesVisited: 10, MaxFanOut: 4}})
+	expected := []string{"--max-nodes-visited", "10", "--max-fan-out", "4"}
+	if diff := cmp.Diff(expected, container.Args); diff != "" {
+		t.Errorf("Router args mismatch (-want +got): %v", diff)
+	}
+}
+
+func TestSetRouterFIPSMode(t *testing.T) {
+	container := &v1.Container{}
+	setRouterFIPSMode(container, &v1beta1.SecurityConfig{})
+	if len(container.Args) != 0 {
+		t.Errorf("Unexpected router args %v", container.Args)
+	}
+	setRouterFIPSMode(container, &v1beta1.SecurityConfig{FIPSMode: true})
+	if diff := cmp.Diff([]string{"--fips-mode"}, container.Args); diff != "" {
+		t.Errorf("Router args mismatch (-want +got): %v", diff)
+	}
+}
+
+func TestHeadersToPropagate(t *testing.T) {
+	config := &RouterConfig{Headers: map[string][]string{"propagate": {"Authorization", "Test-Header-*"}}}
+	scenarios := map[string]struct {
+		spec     InferenceGraphSpec
+		config   *RouterConfig
+		expected []string
+		exists   bool
+	}{
+		"global headers": {
+			config:   config,
+			expected: []string{"Authorization", "Test-Header-*"},
+			exists:   true,
+		},
+		"no headers": {
+			config: &RouterConfig{},
+		},
+		"merged headers": {
+			spec:     InferenceGraphSpec{HeadersToPropagate: []string{"X-Tenant", "Authorization"}},
+			config:   config,
+			expected: []string{"Authorization", "Test-Header-*", "X-Tenant"},
+			exists:   true,
+		},
+		"graph headers without global headers": {
+			spec:     InferenceGraphSpec{HeadersToPropagate: []string{"X-Tenant"}},
+			config:   &RouterConfig{},
+			expected: []string{"X-Tenant"},
+			exists:   true,
+		},
+		"replaced headers": {
+			spec:     InferenceGraphSpec{HeadersToPropagate: []string{"X-Tenant"}, HeadersPropagationPolicy: ReplaceHeaders},
+			config:   config,
+			expected: []string{"X-Tenant"},
+			exists:   true,
+		},
+		"replaced headers without graph headers": {
+			spec:   InferenceGraphSpec{HeadersPropagationPolicy: ReplaceHeaders},
+			config: config,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			headers, exists := headersToPropagate(&InferenceGraph{Spec: scenario.spec}, scenario.config)
+			if exists != scenario.exists {
+				t.Errorf("Expected headers to exist %v, got %v", scenario.exists, exists)
+			}
+			if diff := cmp.Diff(scenario.expected, headers, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Headers mismatch (-want +got): %v", diff)
+			}
+			// the headers of the router config are shared by the graphs and left unchanged
+			if diff := cmp.Diff([]string{"Authorization", "Test-Header-*"}, config.Headers["propagate"]); diff != "" {
+				t.Errorf("Router config headers mismatch (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestSetRouterLogging(t *testing.T) {
+	container := &v1.Container{}
+	setRouterLogging(container, &InferenceGraph{})
+	if len(container.Args) != 0 {
+		t.Errorf("Router args should be empty without logging spec, got %v", container.Args)
+	}
+
+	setRouterLogging(container, &InferenceGraph{
+		Spec: InferenceGraphSpec{
+			SidecarLogging: &SidecarLoggingSpec{Level: SidecarLogLevelDebug, Format: SidecarLogFormatText},
+		},
+	})
+	expected := []string{"--log-level", "debug", "--log-format", "text"}
+	if diff := cmp.Diff(expected, container.Args); diff != "" {
+		t.Errorf("Router args mismatch (-want +got): %v", diff)
+	}
+}
+
+func TestSetRouterSecurityContext(t *testing.T) {
+	runAsUser := int64(1000)
+	podSpec := &v1.PodSpec{Containers: []v1.Container{{Name: "router"}}}
+	setRouterSecurityContext(podSpec, &InferenceGraph{})
+	if podSpec.SecurityContext != nil || podSpec.Containers[0].SecurityContext != nil {
+		t.Errorf("Router security context should not be set without overrides, got %v", podSpec)
+	}
+
+	setRouterSecurityContext(podSpec, &InferenceGraph{
+		Spec: InferenceGraphSpec{
+			RouterSecurityContext: &RouterSecurityContext{
+				RunAsUser:          &runAsUser,
+				SupplementalGroups: []int64{1000, 2000},
+				SELinuxOptions:     &v1.SELinuxOptions{Type: "container_logreader_t"},
+				AddCapabilities:    []v1.Capability{"NET_BIND_SERVICE"},
+			},
+		},
+	})
+	expected := &v1.PodSpec{
+		Containers: []v1.Container{{
+			Name: "router",
+			SecurityContext: &v1.SecurityContext{
+				RunAsUser:      &runAsUser,
+				SELinuxOptions: &v1.SELinuxOptions{Type: "container_logreader_t"},
+				Capabilities:   &v1.Capabilities{Add: []v1.Capability{"NET_BIND_SERVICE"}},
+			},
+		}},
+		SecurityContext: &v1.PodSecurityContext{SupplementalGroups: []int64{1000, 2000}},
+	}
+	if diff := cmp.Diff(expected, podSpec); diff != "" {
+		t.Errorf("Router pod spec mismatch (-want +got): %v", diff)
+	}
+}
+
+func TestSetRouterTraces(t *testing.T) {
+	graph := &InferenceGraph{ObjectMeta: metav1.ObjectMeta{Name: "graph", Namespace: "default", UID: "uid"}}
+	container := &v1.Container{}
+	setRouterTraces(container, graph, &RouterConfig{})
+	if len(container.Args) != 0 || len(container.Env) != 0 {
+		t.Errorf("Expected no trace config, got args %v and env %v", container.Args, container.Env)
+	}
+
+	container = &v1.Container{}
+	setRouterTraces(container, graph, &RouterConfig{Traces: &GraphTraceConfig{Store: ConfigMapTraceStore}})
+	expected := []string{"--trace-config", `{"store":"configmap","graph":"graph","namespace":"default","uid":"uid"}`}
+	if diff := cmp.Diff(expected, container.Args); diff != "" {
+		t.Errorf("Router args mismatch (-want +got): %v", diff)
+	}
+	if len(container.Env) != 0 {
+		t.Errorf("Expected no env, got %v", container.Env)
+	}
+
+	container = &v1.Container{}
+	setRouterTraces(container, graph, &RouterConfig{Traces: &GraphTraceConfig{
+		Store: S3TraceStore,
+		S3:    &S3TraceStoreConfig{Bucket: "traces", SecretName: "trace-bucket"},
+	}})
+	optional := true
+	secretKey := func(key string) *v1.EnvVarSource {
+		return &v1.EnvVarSource{SecretKeyRef: &v1.SecretKeySelector{
+			LocalObjectReference: v1.LocalObjectReference{Name: "trace-bucket"},
+			Key:                  key,
+			Optional:             &optional,
+		}}
+	}
+	expectedEnv := []v1.EnvVar{
+		{Name: "AWS_ACCESS_KEY_ID", ValueFrom: secretKey("awsAccessKeyID")},
+		{Name: "AWS_SECRET_ACCESS_KEY", ValueFrom: secretKey("awsSecretAccessKey")},
+	}
+	if diff := cmp.Diff(expectedEnv, container.Env); diff != "" {
+		t.Errorf("Router env mismatch (-want +got): %v", diff)
+	}
+}
+
+func TestRouterGraphSpec(t *testing.T) {
+	apiKey := func(name string) *v1.SecretKeySelector {
+		return &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: name}, Key: "api-key"}
+	}
+	spec := &InferenceGraphSpec{
+		Nodes: map[string]InferenceRouter{
+			GraphRootNodeName: {
+				RouterType: Sequence,
+				Steps: []InferenceStep{
+					{
+						InferenceTarget: InferenceTarget{ServiceURL: "http://model1"},
+						Headers: []StepHeader{
+							{Name: "Model-Version", Value: "2"},
+							{Name: "X-Api-Key", SecretKeyRef: apiKey("model1")},
+						},
+					},
+					{
+						InferenceTarget: InferenceTarget{NodeName: "ensemble"},
+					},
+				},
+			},
+			"ensemble": {
+				RouterType: Ensemble,
+				Steps: []InferenceStep{
+					{
+						InferenceTarget: InferenceTarget{ServiceURL: "http://model2"},
+						Headers:         []StepHeader{{Name: "X-Api-Key", SecretKeyRef: apiKey("model2")}},
+					},
+				},
+			},
+		},
+	}
+	routerSpec, envs := routerGraphSpec(spec, true)
+
+	expectedEnvs := []v1.EnvVar{
+		{Name: "KSERVE_STEP_HEADER_0", ValueFrom: &v1.EnvVarSource{SecretKeyRef: apiKey("model2")}},
+		{Name: "KSERVE_STEP_HEADER_1", ValueFrom: &v1.EnvVarSource{SecretKeyRef: apiKey("model1")}},
+	}
+	if diff := cmp.Diff(expectedEnvs, envs); diff != "" {
+		t.Errorf("Router env mismatch (-want +got): %v", diff)
+	}
+	expectedRootHeaders := []StepHeader{
+		{Name: "Model-Version", Value: "2"},
+		{Name: "X-Api-Key", Value: "$$(KSERVE_STEP_HEADER_1)"},
+	}
+	if diff := cmp.Diff(expectedRootHeaders, routerSpec.Nodes[GraphRootNodeName].Steps[0].Headers); diff != "" {
+		t.Errorf("Router step headers mismatch (-want +got): %v", diff)
+	}
+	expectedEnsembleHeaders := []StepHeader{{Name: "X-Api-Key", Value: "$$(KSERVE_STEP_HEADER_0)"}}
+	if diff := cmp.Diff(expectedEnsembleHeaders, routerSpec.Nodes["ensemble"].Steps[0].Headers); diff != "" {
+		t.Errorf("Router step headers mismatch (-want +got): %v", diff)
+	}
+	// the spec of the InferenceGraph is left unchanged
+	if spec.Nodes[GraphRootNodeName].Steps[0].Headers[1].SecretKeyRef == nil {
+		t.Errorf("The InferenceGraph spec should not be modified")
+	}
+}
+
+func TestSetRouterPlugins(t *testing.T) {
+	graph := &InferenceGraph{
+		Spec: InferenceGraphSpec{
+			Plugins: []RouterPluginSource{
+				{Name: "auth", ConfigMap: &v1.ConfigMapKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{Name: "router-plugins"}, Key: "auth"}},
+				{Name: "translate", Image: "registry.example.com/translate:v1"},
+			},
+		},
+	}
+	podSpec := &v1.PodSpec{Containers: []v1.Container{{Name: "router"}}}
+	setRouterPlugins(podSpec, graph)
+
+	expected := &v1.PodSpec{
+		InitContainers: []v1.Container{
+			{
+				Name:    "router-plugins-1",
+				Image:   "registry.example.com/translate:v1",
+				Command: []string{"cp", "/plugin.so", "/mnt/router-plugins/translate.so"},
+				VolumeMounts: []v1.VolumeMount{
+					{Name: constants.RouterPluginVolumeName, MountPath: constants.RouterPluginDir},
+				},
+			},
+		},
+		Containers: []v1.Container{
+			{
+				Name: "router",
+				VolumeMounts: []v1.VolumeMount{
+					{Name: constants.RouterPluginVolumeName, MountPath: constants.RouterPluginDir},
+					{Name: "router-plugins-0", MountPath: "/mnt/router-plugins/auth.so", SubPath: "auth.so", ReadOnly: true},
+				},
+			},
+		},
+		Volumes: []v1.Volume{
+			{
+				Name: "router-plugins-0",
+				VolumeSource: v1.VolumeSource{
+					ConfigMap: &v1.ConfigMapVolumeSource{
+						LocalObjectReference: v1.LocalObjectReference{Name: "router-plugins"},
+						Items:                []v1.KeyToPath{{Key: "auth", Path: "auth.so"}},
+					},
+				},
+			},
+			{
+				Name:         constants.RouterPluginVolumeName,
+				VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}},
+			},
+		},
+	}
+	if diff := cmp.Diff(expected, podSpec); diff != "" {
+		t.Errorf("Router plugins mismatch (-want +got): %v", diff)
+	}
+}
+
+func TestSetRouterCaBundle(t *testing.T) {
+	newGraph := func(annotation string) *InferenceGraph {
+		graph := &InferenceGraph{ObjectMeta: metav1.ObjectMeta{Name: "graph", Namespace: "default"}}
+		if annotation != "" {
+			graph.Annotations = map[string]string{constants.RouterCaBundleAnnotationKey: annotation}
+		}
+		return graph
+	}
+	caBundlePodSpec := func(name string, key string) *v1.PodSpec {
+		return &v1.PodSpec{
+			Containers: []v1.Container{{
+				Name: "router",
+				Env:  []v1.EnvVar{{Name: "SSL_CERT_FILE", Value: "/etc/ssl/router-ca-bundle/" + key}},
+				VolumeMounts: []v1.VolumeMount{{
+					Name:      "router-ca-bundle",
+					MountPath: "/etc/ssl/router-ca-bundle",
+					ReadOnly:  true,
+				}},
+			}},
+			Volumes: []v1.Volume{{
+				Name: "router-ca-bundle",
+				VolumeSource: v1.VolumeSource{
+					ConfigMap: &v1.ConfigMapVolumeSource{
+						LocalObjectReference: v1.LocalObjectReference{Name: name},
+						Items:                []v1.KeyToPath{{Key: key, Path: key}},
+					},
+				},
+			}},
+		}
+	}
+	serviceCA := &RouterConfig{CaBundleConfigMapName: "openshift-service-ca.crt", CaBundleKey: "service-ca.crt"}
+
+	scenarios := map[string]struct {
+		graph    *InferenceGraph
+		config   *RouterConfig
+		expected *v1.PodSpec
+	}{
+		"no CA bundle": {
+			graph:    newGraph(""),
+			config:   &RouterConfig{},
+			expected: &v1.PodSpec{Containers: []v1.Container{{Name: "router"}}},
+		},
+		"CA bundle of the router config": {
+			graph:    newGraph(""),
+			config:   serviceCA,
+			expected: caBundlePodSpec("openshift-service-ca.crt", "service-ca.crt"),
+		},
+		"CA bundle disabled by the graph": {
+			graph:    newGraph("none"),
+			config:   serviceCA,
+			expected: &v1.PodSpec{Containers: []v1.Container{{Name: "router"}}},
+		},
+		"CA bundle substituted by the graph": {
+			graph:    newGraph("custom-ca/ca.pem"),
+			config:   serviceCA,
+			expected: caBundlePodSpec("custom-ca", "ca.pem"),
+		},
+		"CA bundle of the graph with the default key": {
+			graph:    newGraph("custom-ca"),
+			config:   &RouterConfig{},
+			expected: caBundlePodSpec("custom-ca", "cabundle.crt"),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			podSpec := &v1.PodSpec{Containers: []v1.Container{{Name: "router"}}}
+			setRouterCaBundle(podSpec, scenario.graph, scenario.config)
+			if diff := cmp.Diff(scenario.expected, podSpec); diff != "" {
+				t.Errorf("Router pod spec mismatch (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestSetRouterTLS(t *testing.T) {
+	newGraph := func(annotation string) *InferenceGraph {
+		graph := &InferenceGraph{ObjectMeta: metav1.ObjectMeta{Name: "graph", Namespace: "default"}}
+		if annotation != "" {
+			graph.Annotations = map[string]string{constants.RouterTLSAnnotationKey: annotation}
+		}
+		return graph
+	}
+	tlsPodSpec := func(probePort int, probeScheme v1.URIScheme, ports ...v1.ContainerPort) *v1.PodSpec {
+		probe := &v1.Probe{
+			ProbeHandler: v1.ProbeHandler{
+				HTTPGet: &v1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt(probePort), Scheme: probeScheme},
+			},
+		}
+		return &v1.PodSpec{
+			Containers: []v1.Container{{
+				Name: "router",
+				Args: []string{"--enable-tls", "--tls-cert-file", "/etc/tls/router/tls.crt",
+					"--tls-key-file", "/etc/tls/router/tls.key"},
+				Ports:          ports,
+				LivenessProbe:  probe,
+				ReadinessProbe: probe.DeepCopy(),
+				VolumeMounts: []v1.VolumeMount{{
+					Name:      "router-tls",
+					MountPath: "/etc/tls/router",
+					ReadOnly:  true,
+				}},
+			}},
+			Volumes: []v1.Volume{{
+				Name: "router-tls",
+				VolumeSource: v1.VolumeSource{
+					Secret: &v1.SecretVolumeSource{SecretName: "graph-router-tls"},
+				},
+			}},
+		}
+	}
+	httpsPort := v1.ContainerPort{Name: "https", ContainerPort: 8080, Protocol: v1.ProtocolTCP}
+	tlsConfig := &RouterConfig{TLS: &RouterTLSConfig{Enabled: true}}
+
+	scenarios := map[string]struct {
+		graph    *InferenceGraph
+		config   *RouterConfig
+		expected *v1.PodSpec
+	}{
+		"TLS disabled": {
+			graph:    newGraph(""),
+			config:   &RouterConfig{},
+			expected: &v1.PodSpec{Containers: []v1.Container{{Name: "router"}}},
+		},
+		"TLS of the router config": {
+			graph:    newGraph(""),
+			config:   tlsConfig,
+			expected: tlsPodSpec(8080, v1.URISchemeHTTPS, httpsPort),
+		},
+		"TLS disabled by the graph": {
+			graph:    newGraph("false"),
+			config:   tlsConfig,
+			expected: &v1.PodSpec{Containers: []v1.Container{{Name: "router"}}},
+		},
+		"TLS enabled by the graph": {
+			graph:    newGraph("true"),
+			config:   &RouterConfig{},
+			expected: tlsPodSpec(8080, v1.URISchemeHTTPS, httpsPort),
+		},
+		"invalid annotation": {
+			graph:    newGraph("https"),
+			config:   tlsConfig,
+			expected: tlsPodSpec(8080, v1.URISchemeHTTPS, httpsPort),
+		},
+		"dedicated health port": {
+			graph:  newGraph(""),
+			config: &RouterConfig{HealthPort: 8081, TLS: &RouterTLSConfig{Enabled: true}},
+			expected: tlsPodSpec(8081, "", httpsPort,
+				v1.ContainerPort{Name: "metrics", ContainerPort: 9090, Protocol: v1.ProtocolTCP}),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			podSpec := &v1.PodSpec{Containers: []v1.Container{{Name: "router"}}}
+			if scenario.config.HealthPort != 0 {
+				podSpec.Containers[0].Ports = []v1.ContainerPort{
+					{Name: "http", ContainerPort: 8080, Protocol: v1.ProtocolTCP},
+					{Name: "metrics", ContainerPort: 9090, Protocol: v1.ProtocolTCP},
+				}
+				probe := &v1.Probe{
+					ProbeHandler: v1.ProbeHandler{
+						HTTPGet: &v1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt(8081)},
+					},
+				}
+				podSpec.Containers[0].LivenessProbe = probe
+				podSpec.Containers[0].ReadinessProbe = probe.DeepCopy()
+			}
+			setRouterTLS(podSpec, scenario.graph, scenario.config)
+			if diff := cmp.Diff(scenario.expected, podSpec); diff != "" {
+				t.Errorf("Router pod spec mismatch (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestRouterServingCertAnnotations(t *testing.T) {
+	graph := &InferenceGraph{ObjectMeta: metav1.ObjectMeta{Name: "graph", Namespace: "default"}}
+	scenarios := map[string]struct {
+		config   *RouterConfig
+		expected map[string]string
+	}{
+		"TLS disabled": {
+			config: &RouterConfig{},
+		},
+		"certificate not requested": {
+			config: &RouterConfig{TLS: &RouterTLSConfig{Enabled: true}},
+		},
+		"OpenShift serving certificate": {
+			config: &RouterConfig{TLS: &RouterTLSConfig{Enabled: true, OpenshiftServingCert: true}},
+			expected: map[string]string{
+				"service.beta.openshift.io/serving-cert-secret-name": "graph-router-tls",
+			},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(scenario.expected, routerServingCertAnnotations(graph, scenario.config)); diff != "" {
+				t.Errorf("Router annotations mismatch (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestRouterPodScheduling(t *testing.T) {
+	graph := &InferenceGraph{
+		ObjectMeta: metav1.ObjectMeta{Name: "graph", Namespace: "default"},
+		Spec: InferenceGraphSpec{
+			Nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{InferenceTarget: InferenceTarget{ServiceURL: "http://someservice.exmaple.com"}},
+					},
+				},
+			},
+			Tolerations: []v1.Toleration{
+				{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "routers", Effect: v1.TaintEffectNoSchedule},
+			},
+			NodeSelector:       map[string]string{"node-role.kubernetes.io/router": ""},
+			PriorityClassName:  "high-priority",
+			ServiceAccountName: "router-sa",
+			ImagePullSecrets:   []v1.LocalObjectReference{{Name: "registry-credentials"}},
+			TopologySpreadConstraints: []v1.TopologySpreadConstraint{
+				{
+					MaxSkew:           1,
+					TopologyKey:       "topology.kubernetes.io/zone",
+					WhenUnsatisfiable: v1.ScheduleAnyway,
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{constants.InferenceGraphLabel: "graph"},
+					},
+				},
+			},
+		},
+	}
+	config := &RouterConfig{
+		Image:         "kserve/router:v0.10.0",
+		CpuRequest:    "100m",
+		CpuLimit:      "100m",
+		MemoryRequest: "100Mi",
+		MemoryLimit:   "500Mi",
+	}
+	podSpecs := map[string]*v1.PodSpec{
+		"raw deployment": createInferenceGraphPodSpec(graph, config),
+		"serverless":     &createKnativeService(graph.ObjectMeta, graph, config).Spec.Template.Spec.PodSpec,
+	}
+	for name, podSpec := range podSpecs {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(graph.Spec.Tolerations, podSpec.Tolerations); diff != "" {
+				t.Errorf("Tolerations mismatch (-want +got): %v", diff)
+			}
+			if diff := cmp.Diff(graph.Spec.NodeSelector, podSpec.NodeSelector); diff != "" {
+				t.Errorf("Node selector mismatch (-want +got): %v", diff)
+			}
+			if podSpec.PriorityClassName != graph.Spec.PriorityClassName {
+				t.Errorf("Expected priority class %q, got %q", graph.Spec.PriorityClassName, podSpec.PriorityClassName)
+			}
+			if diff := cmp.Diff(graph.Spec.TopologySpreadConstraints, podSpec.TopologySpreadConstraints); diff != "" {
+				t.Errorf("Topology spread constraints mismatch (-want +got): %v", diff)
+			}
+			if podSpec.ServiceAccountName != graph.Spec.ServiceAccountName {
+				t.Errorf("Expected service account %q, got %q", graph.Spec.ServiceAccountName, podSpec.ServiceAccountName)
+			}
+			if diff := cmp.Diff(graph.Spec.ImagePullSecrets, podSpec.ImagePullSecrets); diff != "" {
+				t.Errorf("Image pull secrets mismatch (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestRouterVolumes(t *testing.T) {
+	graph := &InferenceGraph{
+		ObjectMeta: metav1.ObjectMeta{Name: "graph", Namespace: "default"},
+		Spec: InferenceGraphSpec{
+			Nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{InferenceTarget: InferenceTarget{ServiceURL: "http://someservice.exmaple.com"}},
+					},
+				},
+			},
+			Volumes: []v1.Volume{
+				{
+					Name: "condition-templates",
+					VolumeSource: v1.VolumeSource{
+						ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: v1.LocalObjectReference{Name: "templates"}},
+					},
+				},
+			},
+			VolumeMounts: []v1.VolumeMount{{Name: "condition-templates", MountPath: "/etc/templates", ReadOnly: true}},
+			Plugins:      []RouterPluginSource{{Name: "auth", Image: "example.com/auth-plugin:v1"}},
+		},
+	}
+	config := &RouterConfig{
+		Image:         "kserve/router:v0.10.0",
+		CpuRequest:    "100m",
+		CpuLimit:      "100m",
+		MemoryRequest: "100Mi",
+		MemoryLimit:   "500Mi",
+	}
+	podSpecs := map[string]*v1.PodSpec{
+		"raw deployment": createInferenceGraphPodSpec(graph, config),
+		"serverless":     &createKnativeService(graph.ObjectMeta, graph, config).Spec.Template.Spec.PodSpec,
+	}
+	for name, podSpec := range podSpecs {
+		t.Run(name, func(t *testing.T) {
+			// the volumes of the graph come first, followed by the volumes of the router
+			if len(podSpec.Volumes) < 2 {
+				t.Fatalf("Expected the volumes of the graph and of the router, got %v", podSpec.Volumes)
+			}
+			if diff := cmp.Diff(graph.Spec.Volumes, podSpec.Volumes[:1]); diff != "" {
+				t.Errorf("Volumes mismatch (-want +got): %v", diff)
+			}
+			mounts := podSpec.Containers[0].VolumeMounts
+			found := false
+			for _, mount := range mounts {
+				if cmp.Equal(graph.Spec.VolumeMounts[0], mount) {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("Expected the volume mount %v in %v", graph.Spec.VolumeMounts[0], mounts)
+			}
+		})
+	}
+	if len(graph.Spec.Volumes) != 1 || len(graph.Spec.VolumeMounts) != 1 {
+		t.Errorf("The volumes of the graph spec should be left unchanged, got %v and %v", graph.Spec.Volumes,
+			graph.Spec.VolumeMounts)
+	}
+}
+
+func TestRouterConfigHash(t *testing.T) {
+	graph := &InferenceGraph{ObjectMeta: metav1.ObjectMeta{Name: "graph", Namespace: "default"}}
+	config := &RouterConfig{Image: "kserve/router:v0.10.0", CaBundleConfigMapName: "router-ca"}
+	container := &v1.Container{Image: "kserve/router:v0.10.0", Args: []string{"--graph-json", "{}"}}
+	caBundle := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "router-ca", Namespace: "default"},
+		Data:       map[string]string{constants.DefaultCaBundleFileName: "ca-1"},
+	}
+	clientset := fakeclientset.NewSimpleClientset(caBundle)
+
+	hash, err := routerConfigHash(clientset, graph, config, container)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// the hash does not change while the injected configuration does not change
+	if again, _ := routerConfigHash(clientset, graph, config, container); again != hash {
+		t.Errorf("Expected the hash %q to be stable, got %q", hash, again)
+	}
+	// the hash changes with the router container
+	if upgraded, _ := routerConfigHash(clientset, graph, config, &v1.Container{Image: "kserve/router:v0.11.0",
+		Args: container.Args}); upgraded == hash {
+		t.Errorf("Expected the hash to change with the router image")
+	}
+	// the hash changes with the content of the CA bundle, which the pod spec only references
+	caBundle.Data[constants.DefaultCaBundleFileName] = "ca-2"
+	if _, err := clientset.CoreV1().ConfigMaps("default").Update(context.TODO(), caBundle, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if rotated, _ := routerConfigHash(clientset, graph, config, container); rotated == hash {
+		t.Errorf("Expected the hash to change with the CA bundle")
+	}
+	// a missing CA bundle is hashed as empty
+	if _, err := routerConfigHash(fakeclientset.NewSimpleClientset(), graph, config, container); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+// routerImageRolloutPeriod is how often a graph waiting for the canaries of a router image rollout checks them again
+const routerImageRolloutPeriod = 30 * time.Second
+
+// RouterImageRollout configures the staged rollout of a new router image: the canary graphs are upgraded first and
+// the other graphs keep their current image until every canary runs the new image and is Ready.
+type RouterImageRollout struct {
+	// CanaryPercentage is the percentage of the graphs upgraded first, rounded up. The image is rolled out to every
+	// graph at once when it is 0.
+	CanaryPercentage int32 `json:"canaryPercentage"`
+	// CanarySelector is the label selector of the graphs the canaries are picked from, e.g. environment=staging. The
+	// canaries are picked from every graph when it is empty.
+	CanarySelector string `json:"canarySelector,omitempty"`
+}
+
+func (c *RouterImageRollout) Validate() error {
+	if c.CanaryPercentage < 0 || c.CanaryPercentage > 100 {
+		return fmt.Errorf("the canary percentage of the router image rollout must be between 0 and 100, got %d",
+			c.CanaryPercentage)
+	}
+	if _, err := labels.Parse(c.CanarySelector); err != nil {
+		return fmt.Errorf("invalid canary selector of the router image rollout: %w", err)
+	}
+	return nil
+}
+
+// pickCanaries returns the canaries among the candidate graphs. They are picked by the hash of their namespace and
+// name, so that the same graphs are picked by every reconcile and spread over the namespaces.
+func pickCanaries(candidates []v1alpha1api.InferenceGraph, percentage int32) []v1alpha1api.InferenceGraph {
+	count := (len(candidates)*int(percentage) + 99) / 100
+	if count == 0 {
+		return nil
+	}
+	hashes := make(map[string]uint32, len(candidates))
+	for _, graph := range candidates {
+		hashes[graphKey(&graph)] = graphHash(&graph)
+	}
+	sorted := append([]v1alpha1api.InferenceGraph{}, candidates...)
+	sort.Slice(sorted, func(i, j int) bool {
+		hi, hj := hashes[graphKey(&sorted[i])], hashes[graphKey(&sorted[j])]
+		if hi != hj {
+			return hi < hj
+		}
+		return graphKey(&sorted[i]) < graphKey(&sorted[j])
+	})
+	return sorted[:count]
+}
+
+func graphKey(graph *v1alpha1api.InferenceGraph) string {
+	return graph.Namespace + "/" + graph.Name
+}
+
+func graphHash(graph *v1alpha1api.InferenceGraph) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(graphKey(graph)))
+	return h.Sum32()
+}
+
+// canariesUpgraded returns true when every canary runs the target image and is Ready
+func canariesUpgraded(canaries []v1alpha1api.InferenceGraph, targetImage string) bool {
+	for _, canary := range canaries {
+		if canary.Status.EffectiveConfig == nil || canary.Status.EffectiveConfig.RouterImage != targetImage ||
+			!inferenceGraphReadiness(canary.Status) {
+			return false
+		}
+	}
+	return true
+}
+
+// rolloutRouterImage returns the router image of the graph. While a new router image is rolled out, the graphs which
+// are not canaries keep their current image until the canaries are upgraded, wait is true then. The new graphs and the
+// graphs without a router are created with the new image. The router image of the graph spec is not rolled out.
+func (r *InferenceGraphReconciler) rolloutRouterImage(ctx context.Context, graph *v1alpha1api.InferenceGraph,
+	routerConfig *RouterConfig, configMap *v1.ConfigMap) (image string, wait bool, err error) {
+	if graph.Spec.RouterImage != "" {
+		return graph.Spec.RouterImage, false, nil
+	}
+	rollout := routerConfig.ImageRollout
+	if rollout == nil || graph.Status.EffectiveConfig == nil || graph.Status.EffectiveConfig.RouterImage == "" {
+		return routerConfig.Image, false, nil
+	}
+	currentImage := graph.Status.EffectiveConfig.RouterImage
+	// The images of the status are the images of the router containers, after the FIPS images and the image policy
+	// are applied
+	podSpec := &v1.PodSpec{Containers: []v1.Container{{Image: routerConfig.Image}}}
+	if err := r.setPodDefaults(podSpec, graph, configMap); err != nil {
+		return "", false, err
+	}
+	targetImage := podSpec.Containers[0].Image
+	if currentImage == targetImage {
+		return routerConfig.Image, false, nil
+	}
+
+	candidates := &v1alpha1api.InferenceGraphList{}
+	selector, err := labels.Parse(rollout.CanarySelector)
+	if err != nil {
+		return "", false, err
+	}
+	if err := r.List(ctx, candidates, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return "", false, err
+	}
+	canaries := pickCanaries(candidates.Items, rollout.CanaryPercentage)
+	for _, canary := range canaries {
+		if graphKey(&canary) == graphKey(graph) {
+			r.Log.Info("Upgrading the router image of canary inference graph", "graph", graph.Name,
+				"image", targetImage)
+			return routerConfig.Image, false, nil
+		}
+	}
+	if !canariesUpgraded(canaries, targetImage) {
+		r.Log.Info("Keeping the router image of inference graph until the canaries are upgraded", "graph", graph.Name,
+			"image", currentImage, "canaries", len(canaries))
+		return currentImage, true, nil
+	}
+	return routerConfig.Image, false, nil
+}
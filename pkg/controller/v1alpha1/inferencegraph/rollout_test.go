@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+func makeRolloutGraph(name string, image string, ready bool, labels map[string]string) *v1alpha1api.InferenceGraph {
+	status := v1.ConditionFalse
+	if ready {
+		status = v1.ConditionTrue
+	}
+	return &v1alpha1api.InferenceGraph{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+		Status: v1alpha1api.InferenceGraphStatus{
+			Status: duckv1.Status{
+				Conditions: duckv1.Conditions{{Type: apis.ConditionReady, Status: status}},
+			},
+			EffectiveConfig: &v1alpha1api.EffectiveConfig{RouterImage: image},
+		},
+	}
+}
+
+func TestRouterImageRolloutValidate(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	g.Expect((&RouterImageRollout{CanaryPercentage: 10, CanarySelector: "environment=staging"}).Validate()).To(gomega.Succeed())
+	g.Expect((&RouterImageRollout{CanaryPercentage: 101}).Validate()).To(gomega.HaveOccurred())
+	g.Expect((&RouterImageRollout{CanaryPercentage: -1}).Validate()).To(gomega.HaveOccurred())
+	g.Expect((&RouterImageRollout{CanaryPercentage: 10, CanarySelector: "environment in staging"}).Validate()).
+		To(gomega.HaveOccurred())
+}
+
+func TestPickCanaries(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	var graphs []v1alpha1api.InferenceGraph
+	for i := 0; i < 20; i++ {
+		graphs = append(graphs, *makeRolloutGraph(fmt.Sprintf("graph-%d", i), "", true, nil))
+	}
+	g.Expect(pickCanaries(graphs, 0)).To(gomega.BeEmpty())
+	g.Expect(pickCanaries(graphs, 100)).To(gomega.HaveLen(20))
+	g.Expect(pickCanaries(graphs, 1)).To(gomega.HaveLen(1))
+	canaries := pickCanaries(graphs, 10)
+	g.Expect(canaries).To(gomega.HaveLen(2))
+	// the same canaries are picked whatever the order of the graphs
+	reversed := make([]v1alpha1api.InferenceGraph, len(graphs))
+	for i := range graphs {
+		reversed[len(graphs)-1-i] = graphs[i]
+	}
+	g.Expect(pickCanaries(reversed, 10)).To(gomega.Equal(canaries))
+}
+
+func TestRolloutRouterImage(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(v1alpha1api.AddToScheme(scheme)).To(gomega.Succeed())
+	configMap := &v1.ConfigMap{}
+
+	canary := makeRolloutGraph("canary", "kserve/router:v1", true, map[string]string{"environment": "staging"})
+	production := makeRolloutGraph("production", "kserve/router:v1", true, map[string]string{"environment": "production"})
+	routerConfig := &RouterConfig{
+		Image:        "kserve/router:v2",
+		ImageRollout: &RouterImageRollout{CanaryPercentage: 100, CanarySelector: "environment=staging"},
+	}
+	newReconciler := func(graphs ...*v1alpha1api.InferenceGraph) *InferenceGraphReconciler {
+		builder := fake.NewClientBuilder().WithScheme(scheme)
+		for _, graph := range graphs {
+			builder = builder.WithObjects(graph)
+		}
+		return &InferenceGraphReconciler{
+			Client:    builder.Build(),
+			Clientset: fakeclientset.NewSimpleClientset(),
+			Log:       logf.Log.WithName("test"),
+		}
+	}
+
+	// The canaries are upgraded first while the other graphs keep their image
+	r := newReconciler(canary, production)
+	image, wait, err := r.rolloutRouterImage(context.TODO(), canary, routerConfig, configMap)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(image).To(gomega.Equal("kserve/router:v2"))
+	g.Expect(wait).To(gomega.BeFalse())
+	image, wait, err = r.rolloutRouterImage(context.TODO(), production, routerConfig, configMap)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(image).To(gomega.Equal("kserve/router:v1"))
+	g.Expect(wait).To(gomega.BeTrue())
+
+	// The canary runs the new image but is not Ready
+	r = newReconciler(makeRolloutGraph("canary", "kserve/router:v2", false, canary.Labels), production)
+	image, wait, _ = r.rolloutRouterImage(context.TODO(), production, routerConfig, configMap)
+	g.Expect(image).To(gomega.Equal("kserve/router:v1"))
+	g.Expect(wait).To(gomega.BeTrue())
+
+	// The other graphs are upgraded once the canaries are Ready
+	r = newReconciler(makeRolloutGraph("canary", "kserve/router:v2", true, canary.Labels), production)
+	image, wait, _ = r.rolloutRouterImage(context.TODO(), production, routerConfig, configMap)
+	g.Expect(image).To(gomega.Equal("kserve/router:v2"))
+	g.Expect(wait).To(gomega.BeFalse())
+
+	// The new graphs are created with the new image
+	created := makeRolloutGraph("created", "", false, nil)
+	r = newReconciler(canary, created)
+	image, wait, _ = r.rolloutRouterImage(context.TODO(), created, routerConfig, configMap)
+	g.Expect(image).To(gomega.Equal("kserve/router:v2"))
+	g.Expect(wait).To(gomega.BeFalse())
+
+	// Every graph is upgraded at once without a rollout
+	image, wait, _ = r.rolloutRouterImage(context.TODO(), production, &RouterConfig{Image: "kserve/router:v2"}, configMap)
+	g.Expect(image).To(gomega.Equal("kserve/router:v2"))
+	g.Expect(wait).To(gomega.BeFalse())
+
+	// The router image of the graph spec is used whatever the rollout
+	pinned := makeRolloutGraph("pinned", "kserve/router:v1", true, map[string]string{"environment": "production"})
+	pinned.Spec.RouterImage = "kserve/router:v3-rc1"
+	r = newReconciler(canary, pinned)
+	image, wait, err = r.rolloutRouterImage(context.TODO(), pinned, routerConfig, configMap)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(image).To(gomega.Equal("kserve/router:v3-rc1"))
+	g.Expect(wait).To(gomega.BeFalse())
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/capabilities"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/ingress"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+// routeTLSTermination returns the route TLS termination requested on the graph, falling back to the ingress config
+// default. An empty value means no termination is configured.
+func routeTLSTermination(graph *v1alpha1api.InferenceGraph, ingressConfig *v1beta1.IngressConfig) constants.RouteTLSTerminationType {
+	if value, ok := graph.Annotations[constants.RouteTLSTerminationAnnotationKey]; ok {
+		return constants.RouteTLSTerminationType(value)
+	}
+	return constants.RouteTLSTerminationType(ingressConfig.RouteTLSTermination)
+}
+
+// createRouterIngress builds the Ingress of the router of the graph, which the OpenShift ingress-to-route controller
+// turns into a route with the requested TLS termination. The Ingress targets the https port of the router Service when
+// the router serves HTTPS.
+func createRouterIngress(graph *v1alpha1api.InferenceGraph, ingressConfig *v1beta1.IngressConfig,
+	termination constants.RouteTLSTerminationType, routerTLS bool) (*netv1.Ingress, error) {
+	host, err := ingress.GenerateDomainName(graph.Name, graph.ObjectMeta, ingressConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating router ingress host: %w", err)
+	}
+	port := netv1.ServiceBackendPort{Number: constants.CommonDefaultHttpPort}
+	if routerTLS {
+		port = netv1.ServiceBackendPort{Name: constants.HttpsPortName}
+	}
+	pathType := netv1.PathTypePrefix
+	return &netv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        graph.Name,
+			Namespace:   graph.Namespace,
+			Labels:      constants.InferenceGraphOwnerLabels(graph.Name),
+			Annotations: ingress.RouteTLSAnnotations(graph.Name, termination),
+		},
+		Spec: netv1.IngressSpec{
+			IngressClassName: ingressConfig.IngressClassName,
+			Rules: []netv1.IngressRule{
+				{
+					Host: host,
+					IngressRuleValue: netv1.IngressRuleValue{
+						HTTP: &netv1.HTTPIngressRuleValue{
+							Paths: []netv1.HTTPIngressPath{
+								{
+									Path:     "/",
+									PathType: &pathType,
+									Backend: netv1.IngressBackend{
+										Service: &netv1.IngressServiceBackend{
+											Name: graph.Name,
+											Port: port,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+/*
+Exposes the router of the graph in raw deployment mode with an OpenShift route when a route TLS termination is requested
+on the graph or in the ingress config. The re-encrypt route is only created when the router serves HTTPS, and an
+Ingress of the same name the graph does not control is never updated.
+*/
+func (r *InferenceGraphReconciler) reconcileRouterRoute(ctx context.Context, graph *v1alpha1api.InferenceGraph,
+	config *RouterConfig) error {
+	ingressConfig, err := v1beta1.NewIngressConfig(r.Clientset)
+	if err != nil {
+		return err
+	}
+	ingressConfig = ingressConfig.ForObject(graph.ObjectMeta)
+	termination := routeTLSTermination(graph, ingressConfig)
+	if termination == "" || ingressConfig.DisableIngressCreation ||
+		ingressConfig.IngressDomain == constants.ClusterLocalDomain ||
+		graph.Labels[constants.NetworkVisibility] == constants.ClusterLocalVisibility {
+		return nil
+	}
+	available, err := utils.IsCrdAvailable(r.ClientConfig, capabilities.OpenShiftRoute.GroupVersion,
+		capabilities.OpenShiftRoute.Kind)
+	if err != nil {
+		return err
+	}
+	if !available {
+		r.Log.Info("Skipping the router route, OpenShift routes are not available", "namespace", graph.Namespace,
+			"name", graph.Name, "termination", termination)
+		return nil
+	}
+	routerTLS := routerTLSEnabled(graph, config)
+	if termination == constants.RouteTLSTerminationReencrypt && !routerTLS {
+		r.Recorder.Eventf(graph, v1.EventTypeWarning, ingress.BackendNotServingTLSReason,
+			"The re-encrypt route is not created, the router does not serve HTTPS, set the %s annotation to true",
+			constants.RouterTLSAnnotationKey)
+		return nil
+	}
+
+	desired, err := createRouterIngress(graph, ingressConfig, termination, routerTLS)
+	if err != nil {
+		return err
+	}
+	if err := controllerutil.SetControllerReference(graph, desired, r.Scheme); err != nil {
+		return err
+	}
+	if termination == constants.RouteTLSTerminationReencrypt {
+		if err := ingress.ReconcileDestinationCASecret(ctx, r.Clientset, r.Scheme, graph,
+			constants.InferenceGraphOwnerLabels(graph.Name)); err != nil {
+			return err
+		}
+	}
+
+	ingresses := r.Clientset.NetworkingV1().Ingresses(graph.Namespace)
+	existing, err := ingresses.Get(ctx, desired.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierr.IsNotFound(err) {
+			r.Log.Info("Creating inference graph router ingress", "namespace", desired.Namespace, "name", desired.Name)
+			_, err = ingresses.Create(ctx, desired, metav1.CreateOptions{})
+		}
+		return err
+	}
+	if !metav1.IsControlledBy(existing, graph) {
+		return fmt.Errorf("ingress %s/%s exists and is not controlled by inference graph %s", existing.Namespace,
+			existing.Name, graph.Name)
+	}
+	if equality.Semantic.DeepEqual(desired.Spec, existing.Spec) &&
+		equality.Semantic.DeepEqual(desired.Annotations, existing.Annotations) {
+		return nil
+	}
+	r.Log.Info("Updating inference graph router ingress", "namespace", desired.Namespace, "name", desired.Name)
+	existing.Annotations = desired.Annotations
+	existing.Spec = desired.Spec
+	_, err = ingresses.Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/capabilities"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+func TestCreateRouterIngress(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	graph := &v1alpha1api.InferenceGraph{ObjectMeta: metav1.ObjectMeta{Name: "graph", Namespace: "default"}}
+	ingressConfig := &v1beta1.IngressConfig{IngressDomain: "example.com", DomainTemplate: "{{ .Name }}-{{ .Namespace }}.{{ .IngressDomain }}"}
+
+	edge, err := createRouterIngress(graph, ingressConfig, constants.RouteTLSTerminationEdge, false)
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(edge.Annotations).To(gomega.Equal(map[string]string{
+		constants.OpenshiftRouteTerminationAnnotationKey: "edge",
+	}))
+	g.Expect(edge.Spec.Rules[0].Host).To(gomega.Equal("graph-default.example.com"))
+	g.Expect(edge.Spec.Rules[0].HTTP.Paths[0].Backend.Service).To(gomega.Equal(&netv1.IngressServiceBackend{
+		Name: "graph", Port: netv1.ServiceBackendPort{Number: constants.CommonDefaultHttpPort},
+	}))
+
+	// the re-encrypt route targets the https port of the router
+	reencrypt, err := createRouterIngress(graph, ingressConfig, constants.RouteTLSTerminationReencrypt, true)
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(reencrypt.Annotations).To(gomega.Equal(map[string]string{
+		constants.OpenshiftRouteTerminationAnnotationKey:         "reencrypt",
+		constants.OpenshiftRouteDestinationCASecretAnnotationKey: "graph-route-destination-ca",
+	}))
+	g.Expect(reencrypt.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port).To(gomega.Equal(netv1.ServiceBackendPort{
+		Name: constants.HttpsPortName,
+	}))
+}
+
+func TestReconcileRouterRoute(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(v1alpha1api.AddToScheme(scheme)).To(gomega.Succeed())
+	defer utils.SetAvailableResourcesForApi(capabilities.OpenShiftRoute.GroupVersion, nil)
+	utils.SetAvailableResourcesForApi(capabilities.OpenShiftRoute.GroupVersion, &metav1.APIResourceList{
+		GroupVersion: capabilities.OpenShiftRoute.GroupVersion,
+		APIResources: []metav1.APIResource{{Name: "routes", Kind: capabilities.OpenShiftRoute.Kind, Namespaced: true}},
+	})
+	ctx := context.TODO()
+	newReconciler := func(objects ...runtime.Object) (*InferenceGraphReconciler, *fakeclientset.Clientset, *record.FakeRecorder) {
+		objects = append(objects,
+			&v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+				Data: map[string]string{
+					v1beta1.IngressConfigKeyName: `{"ingressGateway": "knative-serving/knative-ingress-gateway",
+						"ingressService": "istio-ingressgateway.istio-system.svc.cluster.local",
+						"ingressDomain": "example.com", "domainTemplate": "{{ .Name }}-{{ .Namespace }}.{{ .IngressDomain }}"}`,
+				},
+			},
+			&v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: constants.OpenshiftServiceCAConfigMapName, Namespace: "default"},
+				Data:       map[string]string{constants.OpenshiftServiceCAFileName: "ca-bundle"},
+			})
+		clientset := fakeclientset.NewSimpleClientset(objects...)
+		recorder := record.NewFakeRecorder(10)
+		return &InferenceGraphReconciler{Clientset: clientset, Log: logf.Log.WithName("test"), Scheme: scheme,
+			Recorder: recorder}, clientset, recorder
+	}
+	newGraph := func(termination string) *v1alpha1api.InferenceGraph {
+		return &v1alpha1api.InferenceGraph{ObjectMeta: metav1.ObjectMeta{Name: "graph", Namespace: "default", UID: "uid",
+			Annotations: map[string]string{constants.RouteTLSTerminationAnnotationKey: termination}}}
+	}
+	tlsConfig := &RouterConfig{TLS: &RouterTLSConfig{Enabled: true}}
+
+	t.Run("NoTermination", func(t *testing.T) {
+		r, clientset, _ := newReconciler()
+		graph := newGraph("")
+		delete(graph.Annotations, constants.RouteTLSTerminationAnnotationKey)
+		g.Expect(r.reconcileRouterRoute(ctx, graph, &RouterConfig{})).To(gomega.Succeed())
+		ingresses, err := clientset.NetworkingV1().Ingresses("default").List(ctx, metav1.ListOptions{})
+		g.Expect(err).ShouldNot(gomega.HaveOccurred())
+		g.Expect(ingresses.Items).To(gomega.BeEmpty())
+	})
+
+	t.Run("ReencryptWithoutRouterTLS", func(t *testing.T) {
+		r, clientset, recorder := newReconciler()
+		g.Expect(r.reconcileRouterRoute(ctx, newGraph("reencrypt"), &RouterConfig{})).To(gomega.Succeed())
+		ingresses, err := clientset.NetworkingV1().Ingresses("default").List(ctx, metav1.ListOptions{})
+		g.Expect(err).ShouldNot(gomega.HaveOccurred())
+		g.Expect(ingresses.Items).To(gomega.BeEmpty())
+		g.Expect(<-recorder.Events).To(gomega.ContainSubstring("BackendNotServingTLS"))
+	})
+
+	t.Run("ReencryptWithRouterTLS", func(t *testing.T) {
+		r, clientset, _ := newReconciler()
+		graph := newGraph("reencrypt")
+		g.Expect(r.reconcileRouterRoute(ctx, graph, tlsConfig)).To(gomega.Succeed())
+		ingress, err := clientset.NetworkingV1().Ingresses("default").Get(ctx, "graph", metav1.GetOptions{})
+		g.Expect(err).ShouldNot(gomega.HaveOccurred())
+		g.Expect(metav1.IsControlledBy(ingress, graph)).To(gomega.BeTrue())
+		g.Expect(ingress.Annotations[constants.OpenshiftRouteTerminationAnnotationKey]).To(gomega.Equal("reencrypt"))
+		secret, err := clientset.CoreV1().Secrets("default").Get(ctx, "graph-route-destination-ca", metav1.GetOptions{})
+		g.Expect(err).ShouldNot(gomega.HaveOccurred())
+		g.Expect(secret.Data[v1.TLSCertKey]).To(gomega.Equal([]byte("ca-bundle")))
+
+		// the termination change is applied to the ingress of the graph
+		g.Expect(r.reconcileRouterRoute(ctx, newGraph("edge"), tlsConfig)).To(gomega.Succeed())
+		ingress, err = clientset.NetworkingV1().Ingresses("default").Get(ctx, "graph", metav1.GetOptions{})
+		g.Expect(err).ShouldNot(gomega.HaveOccurred())
+		g.Expect(ingress.Annotations).To(gomega.Equal(map[string]string{
+			constants.OpenshiftRouteTerminationAnnotationKey: "edge",
+		}))
+	})
+
+	t.Run("IngressOfAnotherOwner", func(t *testing.T) {
+		r, clientset, _ := newReconciler(&netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "graph", Namespace: "default"}})
+		err := r.reconcileRouterRoute(ctx, newGraph("edge"), &RouterConfig{})
+		g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("is not controlled by inference graph graph")))
+		ingress, err := clientset.NetworkingV1().Ingresses("default").Get(ctx, "graph", metav1.GetOptions{})
+		g.Expect(err).ShouldNot(gomega.HaveOccurred())
+		g.Expect(ingress.Annotations).To(gomega.BeEmpty())
+	})
+}
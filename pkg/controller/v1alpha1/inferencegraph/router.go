@@ -0,0 +1,166 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/network"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// RouterResult is the outcome of the reconciliation of the router workload of an InferenceGraph
+type RouterResult struct {
+	// Image is the image of the router container
+	Image string
+	// ClusterLocalURL is the address of the router inside the cluster, if it is known
+	ClusterLocalURL *apis.URL
+	// Requeue is set when the router is not available yet
+	Requeue bool
+}
+
+// RouterReconciler reconciles the router workload of an InferenceGraph in a deployment mode and propagates its status
+// to the status of the graph
+type RouterReconciler func(r *InferenceGraphReconciler, graph *v1alpha1api.InferenceGraph, routerConfig *RouterConfig,
+	configMap *v1.ConfigMap) (*RouterResult, error)
+
+var (
+	routerReconcilersLock sync.RWMutex
+	routerReconcilers     = map[constants.DeploymentModeType]RouterReconciler{
+		constants.RawDeployment: reconcileRawRouter,
+		constants.Serverless:    reconcileKnativeRouter,
+	}
+)
+
+// RegisterRouterReconciler makes the router reconciler of a deployment mode available to the InferenceGraph
+// controller, the backend of the mode is registered with the backend package. It panics when a router reconciler is
+// already registered for the mode.
+func RegisterRouterReconciler(mode constants.DeploymentModeType, reconciler RouterReconciler) {
+	routerReconcilersLock.Lock()
+	defer routerReconcilersLock.Unlock()
+	if _, dup := routerReconcilers[mode]; dup {
+		panic("inferencegraph: RegisterRouterReconciler called twice for " + string(mode))
+	}
+	routerReconcilers[mode] = reconciler
+}
+
+func getRouterReconciler(mode constants.DeploymentModeType) (RouterReconciler, bool) {
+	routerReconcilersLock.RLock()
+	defer routerReconcilersLock.RUnlock()
+	reconciler, ok := routerReconcilers[mode]
+	return reconciler, ok
+}
+
+// reconcileRawRouter creates the deployment, the service, the hpa and the route of the router in raw deployment mode
+func reconcileRawRouter(r *InferenceGraphReconciler, graph *v1alpha1api.InferenceGraph, routerConfig *RouterConfig,
+	configMap *v1.ConfigMap) (*RouterResult, error) {
+	podSpec := createInferenceGraphPodSpec(graph, routerConfig)
+	if err := r.setPodDefaults(podSpec, graph, configMap); err != nil {
+		return nil, errors.Wrapf(err, "fails to set router pod defaults")
+	}
+	result := &RouterResult{Image: podSpec.Containers[0].Image}
+	if err := r.reconcileRouterGraphConfigMap(context.TODO(), graph, routerConfig); err != nil {
+		return nil, errors.Wrapf(err, "fails to reconcile the router graph configmap")
+	}
+	latest, err := newRouterRevision(r.Clientset, graph, routerConfig, podSpec)
+	if err != nil {
+		return nil, err
+	}
+	// The stable Deployment keeps its revision while the latest revision is deployed as a canary
+	stable, canary, err := r.planRawRollout(context.TODO(), graph, latest)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fails to plan the rollout of inference graph")
+	}
+	deployment, url, err := handleInferenceGraphRawDeployment(r.Client, r.Clientset, r.Scheme, r.Recorder, graph, stable,
+		canary != nil, routerConfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fails to reconcile inference graph raw deployment")
+	}
+	canaryDeployment, err := r.reconcileRawCanary(context.TODO(), graph, canary, deployment, routerConfig)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.reconcileRouterRoute(context.TODO(), graph, routerConfig); err != nil {
+		return nil, errors.Wrapf(err, "fails to reconcile the router route")
+	}
+
+	r.Log.Info("Inference graph raw", "deployment conditions", deployment.Status.Conditions)
+	if !deploymentAvailable(deployment) {
+		// If Deployment resource not yet available, IG is not available as well. Reconcile again.
+		result.Requeue = true
+		return result, nil
+	}
+	logger.Info("Inference graph raw before propagate status")
+	PropagateRawStatus(&graph.Status, deployment, url)
+	propagateRawRevisions(&graph.Status, latest, stable, deploymentAvailable(canaryDeployment),
+		rawCanaryTrafficPercent(deployment, canaryDeployment))
+	scheme := "http"
+	if routerTLSEnabled(graph, routerConfig) {
+		scheme = "https"
+	}
+	result.ClusterLocalURL = &apis.URL{Scheme: scheme, Host: network.GetServiceHostname(graph.Name, graph.Namespace)}
+	return result, nil
+}
+
+// reconcileKnativeRouter creates the Knative Service of the router in serverless mode
+func reconcileKnativeRouter(r *InferenceGraphReconciler, graph *v1alpha1api.InferenceGraph, routerConfig *RouterConfig,
+	configMap *v1.ConfigMap) (*RouterResult, error) {
+	desired := createKnativeService(graph.ObjectMeta, graph, routerConfig)
+	if err := r.setPodDefaults(&desired.Spec.Template.Spec.PodSpec, graph, configMap); err != nil {
+		return nil, errors.Wrapf(err, "fails to set router pod defaults")
+	}
+	configHash, err := routerConfigHash(r.Clientset, graph, routerConfig, &desired.Spec.Template.Spec.Containers[0])
+	if err != nil {
+		return nil, errors.Wrapf(err, "fails to hash the router config")
+	}
+	desired.Spec.Template.Annotations[constants.RouterConfigHashAnnotationKey] = configHash
+	result := &RouterResult{Image: desired.Spec.Template.Spec.Containers[0].Image}
+	if err := controllerutil.SetControllerReference(graph, desired, r.Scheme); err != nil {
+		return nil, err
+	}
+	knativeReconciler := NewGraphKnativeServiceReconciler(r.Client, r.Scheme, desired)
+	ksvcStatus, err := knativeReconciler.Reconcile()
+	if err != nil {
+		r.Log.Error(err, "failed to reconcile inference graph ksvc", "name", graph.GetName())
+		return nil, errors.Wrapf(err, "fails to reconcile inference graph ksvc")
+	}
+
+	r.Log.Info("updating inference graph status", "status", ksvcStatus)
+	graph.Status.Conditions = ksvcStatus.Status.Conditions
+	propagateKnativeRevisions(&graph.Status, ksvcStatus)
+	// @TODO Need to check the status of all the graph components, find the inference services from all the nodes and collect the status
+	for _, con := range ksvcStatus.Status.Conditions {
+		if con.Type == apis.ConditionReady {
+			if con.Status == "True" {
+				graph.Status.URL = ksvcStatus.URL
+				if ksvcStatus.Address != nil {
+					result.ClusterLocalURL = ksvcStatus.Address.URL
+				}
+			} else {
+				graph.Status.URL = nil
+			}
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestRegisterRouterReconciler(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	for _, mode := range []constants.DeploymentModeType{constants.RawDeployment, constants.Serverless} {
+		_, ok := getRouterReconciler(mode)
+		g.Expect(ok).To(gomega.BeTrue(), string(mode))
+	}
+	// the graphs in ModelMesh mode fall back to the serverless router
+	_, ok := getRouterReconciler(constants.ModelMeshDeployment)
+	g.Expect(ok).To(gomega.BeFalse())
+
+	mode := constants.DeploymentModeType("LeaderWorkerSet")
+	RegisterRouterReconciler(mode, func(r *InferenceGraphReconciler, graph *v1alpha1api.InferenceGraph,
+		routerConfig *RouterConfig, configMap *v1.ConfigMap) (*RouterResult, error) {
+		return &RouterResult{Image: routerConfig.Image}, nil
+	})
+	defer func() {
+		routerReconcilersLock.Lock()
+		delete(routerReconcilers, mode)
+		routerReconcilersLock.Unlock()
+	}()
+	reconciler, ok := getRouterReconciler(mode)
+	g.Expect(ok).To(gomega.BeTrue())
+	result, err := reconciler(nil, &v1alpha1api.InferenceGraph{}, &RouterConfig{Image: "kserve/router:latest"}, nil)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(result.Image).To(gomega.Equal("kserve/router:latest"))
+	g.Expect(func() { RegisterRouterReconciler(constants.Serverless, reconcileKnativeRouter) }).To(gomega.Panic())
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/jsonpath"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+const (
+	// defaultSmokeTestTimeout bounds the smoke test request when the graph does not set its own timeout
+	defaultSmokeTestTimeout = 30 * time.Second
+	// smokeTestRetryInterval is the delay before a failed smoke test is sent again
+	smokeTestRetryInterval = 30 * time.Second
+	// maxSmokeTestResponseSize is the size of the response read for the assertions
+	maxSmokeTestResponseSize = 1 << 20
+)
+
+var smokeTestClient = &http.Client{}
+
+// reconcileSmokeTest sends the golden request to the ready graph once per generation, and again after a failure, and
+// holds the Ready condition until the response matches the expectations. It returns the delay before the next retry.
+func (r *InferenceGraphReconciler) reconcileSmokeTest(ctx context.Context, graph *v1alpha1api.InferenceGraph,
+	url *apis.URL) time.Duration {
+	if graph.Spec.SmokeTest == nil {
+		graph.Status.SmokeTest = nil
+		return 0
+	}
+	if url == nil || !inferenceGraphReadiness(graph.Status) {
+		return 0
+	}
+
+	previous := graph.Status.SmokeTest
+	if previous == nil || previous.ObservedGeneration != graph.Generation || !previous.Succeeded {
+		timeout := defaultSmokeTestTimeout
+		if graph.Spec.TimeoutSeconds != nil {
+			timeout = time.Duration(*graph.Spec.TimeoutSeconds) * time.Second
+		}
+		now := metav1.Now()
+		result := &v1alpha1api.SmokeTestStatus{
+			ObservedGeneration: graph.Generation,
+			Succeeded:          true,
+			LastRunTime:        &now,
+		}
+		if err := runSmokeTest(ctx, url, graph.Spec.SmokeTest, timeout); err != nil {
+			result.Succeeded = false
+			result.Message = err.Error()
+		}
+		if previous == nil || previous.ObservedGeneration != result.ObservedGeneration ||
+			previous.Succeeded != result.Succeeded || previous.Message != result.Message {
+			if result.Succeeded {
+				r.Recorder.Eventf(graph, v1.EventTypeNormal, string(v1alpha1api.SmokeTestSucceeded),
+					"Smoke test of InferenceGraph generation %d succeeded", graph.Generation)
+			} else {
+				r.Recorder.Eventf(graph, v1.EventTypeWarning, "SmokeTestFailed",
+					"Smoke test of InferenceGraph generation %d failed: %s", graph.Generation, result.Message)
+			}
+		}
+		graph.Status.SmokeTest = result
+	}
+
+	setSmokeTestCondition(&graph.Status)
+	if !graph.Status.SmokeTest.Succeeded {
+		return smokeTestRetryInterval
+	}
+	return 0
+}
+
+// runSmokeTest posts the request of the smoke test to the graph and checks the status code and the assertions on the
+// response
+func runSmokeTest(ctx context.Context, url *apis.URL, smokeTest *v1alpha1api.SmokeTestSpec, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	path := smokeTest.Path
+	if path == "" {
+		path = "/"
+	}
+	target := strings.TrimSuffix(url.String(), "/") + path
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, strings.NewReader(smokeTest.Request))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := smokeTestClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSmokeTestResponseSize))
+	if err != nil {
+		return fmt.Errorf("failed to read the response: %w", err)
+	}
+
+	expectedStatusCode := http.StatusOK
+	if smokeTest.ExpectedStatusCode != nil {
+		expectedStatusCode = int(*smokeTest.ExpectedStatusCode)
+	}
+	if resp.StatusCode != expectedStatusCode {
+		return fmt.Errorf("expected status code %d, got %d", expectedStatusCode, resp.StatusCode)
+	}
+	if len(smokeTest.Assertions) == 0 {
+		return nil
+	}
+	var document interface{}
+	if err := json.Unmarshal(body, &document); err != nil {
+		return fmt.Errorf("the response is not a JSON document: %w", err)
+	}
+	for _, assertion := range smokeTest.Assertions {
+		expression := jsonpath.New("assertion")
+		if err := expression.Parse(assertion.JSONPath); err != nil {
+			return fmt.Errorf("invalid assertion %s: %w", assertion.JSONPath, err)
+		}
+		var value bytes.Buffer
+		if err := expression.Execute(&value, document); err != nil {
+			return fmt.Errorf("assertion %s failed: %w", assertion.JSONPath, err)
+		}
+		if assertion.Value != nil && value.String() != *assertion.Value {
+			return fmt.Errorf("assertion %s failed: expected %q, got %q", assertion.JSONPath, *assertion.Value, value.String())
+		}
+	}
+	return nil
+}
+
+// setSmokeTestCondition sets the SmokeTestSucceeded condition from the result of the smoke test and sets the Ready
+// condition to false while the smoke test fails
+func setSmokeTestCondition(status *v1alpha1api.InferenceGraphStatus) {
+	result := status.SmokeTest
+	condition := apis.Condition{
+		Type:               v1alpha1api.SmokeTestSucceeded,
+		Status:             v1.ConditionTrue,
+		LastTransitionTime: apis.VolatileTime{Inner: *result.LastRunTime},
+	}
+	if !result.Succeeded {
+		condition.Status = v1.ConditionFalse
+		condition.Reason = "SmokeTestFailed"
+		condition.Message = result.Message
+	}
+	if existing := status.GetCondition(v1alpha1api.SmokeTestSucceeded); existing != nil && existing.Status == condition.Status {
+		condition.LastTransitionTime = existing.LastTransitionTime
+	}
+
+	conditions := duckv1.Conditions{}
+	for _, c := range status.Conditions {
+		if c.Type == v1alpha1api.SmokeTestSucceeded {
+			continue
+		}
+		if c.Type == apis.ConditionReady && !result.Succeeded {
+			c.Status = v1.ConditionFalse
+			c.Reason = condition.Reason
+			c.Message = condition.Message
+		}
+		conditions = append(conditions, c)
+	}
+	status.Conditions = append(conditions, condition)
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestRunSmokeTest(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.URL.Path != "/v1/models/graph:predict" || string(body) != `{"instances":[[1,2]]}` {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"predictions":[{"label":"cat","score":0.9}]}`))
+	}))
+	defer server.Close()
+	url, _ := apis.ParseURL(server.URL)
+
+	label := "cat"
+	created := int32(http.StatusCreated)
+	scenarios := map[string]struct {
+		smokeTest *v1alpha1.SmokeTestSpec
+		matcher   gomega.OmegaMatcher
+	}{
+		"Succeeded": {
+			smokeTest: &v1alpha1.SmokeTestSpec{
+				Path:    "/v1/models/graph:predict",
+				Request: `{"instances":[[1,2]]}`,
+				Assertions: []v1alpha1.SmokeTestAssertion{
+					{JSONPath: "{.predictions[0].label}", Value: &label},
+					{JSONPath: "{.predictions[0].score}"},
+				},
+			},
+			matcher: gomega.BeNil(),
+		},
+		"UnexpectedStatusCode": {
+			smokeTest: &v1alpha1.SmokeTestSpec{
+				Path:               "/v1/models/graph:predict",
+				Request:            `{"instances":[[1,2]]}`,
+				ExpectedStatusCode: &created,
+			},
+			matcher: gomega.MatchError("expected status code 201, got 200"),
+		},
+		"WrongPath": {
+			smokeTest: &v1alpha1.SmokeTestSpec{
+				Request: `{"instances":[[1,2]]}`,
+			},
+			matcher: gomega.MatchError("expected status code 200, got 400"),
+		},
+		"MissingField": {
+			smokeTest: &v1alpha1.SmokeTestSpec{
+				Path:       "/v1/models/graph:predict",
+				Request:    `{"instances":[[1,2]]}`,
+				Assertions: []v1alpha1.SmokeTestAssertion{{JSONPath: "{.outputs}"}},
+			},
+			matcher: gomega.HaveOccurred(),
+		},
+		"UnexpectedValue": {
+			smokeTest: &v1alpha1.SmokeTestSpec{
+				Path:    "/v1/models/graph:predict",
+				Request: `{"instances":[[1,2]]}`,
+				Assertions: []v1alpha1.SmokeTestAssertion{
+					{JSONPath: "{.predictions[0].score}", Value: &label},
+				},
+			},
+			matcher: gomega.MatchError(`assertion {.predictions[0].score} failed: expected "cat", got "0.9"`),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			err := runSmokeTest(context.TODO(), url, scenario.smokeTest, time.Second)
+			g.Expect(err).To(scenario.matcher)
+		})
+	}
+}
+
+func TestReconcileSmokeTest(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	healthy := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	url, _ := apis.ParseURL(server.URL)
+
+	recorder := record.NewFakeRecorder(10)
+	reconciler := &InferenceGraphReconciler{Recorder: recorder}
+	graph := &v1alpha1.InferenceGraph{
+		ObjectMeta: metav1.ObjectMeta{Name: "graph", Namespace: "default", Generation: 1},
+		Spec: v1alpha1.InferenceGraphSpec{
+			SmokeTest: &v1alpha1.SmokeTestSpec{Request: "{}"},
+		},
+	}
+	ready := func() {
+		graph.Status.Conditions = duckv1.Conditions{{Type: apis.ConditionReady, Status: v1.ConditionTrue}}
+	}
+
+	// the smoke test fails and the graph is held not ready
+	healthy = false
+	ready()
+	g.Expect(reconciler.reconcileSmokeTest(context.TODO(), graph, url)).To(gomega.Equal(smokeTestRetryInterval))
+	g.Expect(graph.Status.SmokeTest.Succeeded).To(gomega.BeFalse())
+	g.Expect(graph.Status.GetCondition(apis.ConditionReady).Status).To(gomega.Equal(v1.ConditionFalse))
+	g.Expect(graph.Status.GetCondition(v1alpha1.SmokeTestSucceeded).Status).To(gomega.Equal(v1.ConditionFalse))
+
+	// the smoke test is retried and succeeds
+	healthy = true
+	ready()
+	g.Expect(reconciler.reconcileSmokeTest(context.TODO(), graph, url)).To(gomega.BeZero())
+	g.Expect(graph.Status.SmokeTest.Succeeded).To(gomega.BeTrue())
+	g.Expect(graph.Status.GetCondition(apis.ConditionReady).Status).To(gomega.Equal(v1.ConditionTrue))
+	g.Expect(graph.Status.GetCondition(v1alpha1.SmokeTestSucceeded).Status).To(gomega.Equal(v1.ConditionTrue))
+
+	// the result is kept for the same generation even if the graph stops answering
+	healthy = false
+	ready()
+	g.Expect(reconciler.reconcileSmokeTest(context.TODO(), graph, url)).To(gomega.BeZero())
+	g.Expect(graph.Status.GetCondition(apis.ConditionReady).Status).To(gomega.Equal(v1.ConditionTrue))
+
+	g.Expect(recorder.Events).To(gomega.HaveLen(2))
+
+	// the status is cleared when the smoke test is removed
+	graph.Spec.SmokeTest = nil
+	g.Expect(reconciler.reconcileSmokeTest(context.TODO(), graph, url)).To(gomega.BeZero())
+	g.Expect(graph.Status.SmokeTest).To(gomega.BeNil())
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	isvcutils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
+)
+
+// graphTargets are the InferenceServices targeted by name by the steps of a graph which are missing or not ready
+type graphTargets struct {
+	// services are the names of the InferenceServices targeted by name, qualified with their namespace when it is not
+	// the namespace of the graph
+	services []string
+	missing  []string
+	notReady []string
+	// forbidden are the InferenceServices of namespaces which the router config does not allow
+	forbidden []string
+	// unresolved is true when the URL of a step cannot be resolved, the router cannot be built then
+	unresolved bool
+}
+
+func (t *graphTargets) ready() bool {
+	return len(t.missing) == 0 && len(t.notReady) == 0 && len(t.forbidden) == 0
+}
+
+func (t *graphTargets) message() string {
+	var messages []string
+	for _, name := range t.forbidden {
+		messages = append(messages, fmt.Sprintf("InferenceService %q is in a namespace which is not allowed", name))
+	}
+	for _, name := range t.missing {
+		messages = append(messages, fmt.Sprintf("InferenceService %q is not found", name))
+	}
+	for _, name := range t.notReady {
+		messages = append(messages, fmt.Sprintf("InferenceService %q is not ready", name))
+	}
+	return strings.Join(messages, "; ")
+}
+
+// stepTarget returns the namespaced name of the InferenceService targeted by name by the step, in the namespace of the
+// graph unless the step sets another one
+func stepTarget(graph *v1alpha1api.InferenceGraph, step *v1alpha1api.InferenceStep) types.NamespacedName {
+	target := types.NamespacedName{Namespace: graph.Namespace, Name: step.ServiceName}
+	if step.ServiceNamespace != "" {
+		target.Namespace = step.ServiceNamespace
+	}
+	return target
+}
+
+// targetName returns the name of the InferenceService targeted by the graph, qualified with its namespace when it is
+// not the namespace of the graph
+func targetName(graph *v1alpha1api.InferenceGraph, target types.NamespacedName) string {
+	if target.Namespace == graph.Namespace {
+		return target.Name
+	}
+	return target.String()
+}
+
+// resolveTargets checks the InferenceServices targeted by name by the steps of the graph and sets the URL of the steps
+// without one to the predictor endpoint of their InferenceService. The InferenceServices of the namespaces which are
+// not allowed by the router config are not read.
+func (r *InferenceGraphReconciler) resolveTargets(ctx context.Context, graph *v1alpha1api.InferenceGraph,
+	namespaces *v1alpha1api.TargetNamespaces) (*graphTargets, error) {
+	targets := &graphTargets{}
+	seen := map[string]bool{}
+	notReady := map[string]bool{}
+	nodeNames := make([]string, 0, len(graph.Spec.Nodes))
+	for name := range graph.Spec.Nodes {
+		nodeNames = append(nodeNames, name)
+	}
+	sort.Strings(nodeNames)
+	for _, node := range nodeNames {
+		for i, step := range graph.Spec.Nodes[node].Steps {
+			if step.ServiceName == "" {
+				continue
+			}
+			target := stepTarget(graph, &step)
+			name := targetName(graph, target)
+			if !seen[name] {
+				seen[name] = true
+				targets.services = append(targets.services, name)
+			}
+			if !namespaces.Allows(graph.Namespace, target.Namespace) {
+				if !notReady[name] {
+					notReady[name] = true
+					targets.forbidden = append(targets.forbidden, name)
+				}
+				targets.unresolved = true
+				continue
+			}
+			isvc := &v1beta1.InferenceService{}
+			if err := r.Client.Get(ctx, target, isvc); err != nil {
+				if !apierr.IsNotFound(err) {
+					return nil, err
+				}
+				if !notReady[name] {
+					notReady[name] = true
+					targets.missing = append(targets.missing, name)
+				}
+				if step.ServiceURL == "" {
+					targets.unresolved = true
+				}
+				continue
+			}
+			ready := isvc.Status.IsReady()
+			if step.ServiceURL == "" {
+				if serviceURL, err := isvcutils.GetPredictorEndpoint(isvc); err == nil {
+					graph.Spec.Nodes[node].Steps[i].ServiceURL = serviceURL
+				} else {
+					targets.unresolved = true
+					ready = false
+				}
+			}
+			if !ready && !notReady[name] {
+				notReady[name] = true
+				targets.notReady = append(targets.notReady, name)
+			}
+		}
+	}
+	return targets, nil
+}
+
+// setTargetsCondition sets the TargetsReady condition of the graphs targeting InferenceServices by name and sets the
+// Ready condition to false while one of them is missing or not ready
+func setTargetsCondition(status *v1alpha1api.InferenceGraphStatus, targets *graphTargets) {
+	conditions := duckv1.Conditions{}
+	for _, c := range status.Conditions {
+		if c.Type != v1alpha1api.TargetsReady {
+			conditions = append(conditions, c)
+		}
+	}
+	if len(targets.services) == 0 {
+		if len(conditions) == 0 {
+			conditions = nil
+		}
+		status.Conditions = conditions
+		return
+	}
+
+	condition := apis.Condition{
+		Type:   v1alpha1api.TargetsReady,
+		Status: v1.ConditionTrue,
+	}
+	if !targets.ready() {
+		condition.Status = v1.ConditionFalse
+		condition.Reason = "TargetsNotReady"
+		if len(targets.missing) > 0 {
+			condition.Reason = "TargetsNotFound"
+		}
+		if len(targets.forbidden) > 0 {
+			condition.Reason = "TargetsNotAllowed"
+		}
+		condition.Message = targets.message()
+	}
+	if existing := status.GetCondition(v1alpha1api.TargetsReady); existing != nil && existing.Status == condition.Status {
+		condition.LastTransitionTime = existing.LastTransitionTime
+	} else {
+		condition.LastTransitionTime = apis.VolatileTime{Inner: metav1.Now()}
+	}
+
+	if !targets.ready() {
+		readyFound := false
+		for i := range conditions {
+			if conditions[i].Type == apis.ConditionReady {
+				readyFound = true
+				conditions[i].Status = v1.ConditionFalse
+				conditions[i].Reason = condition.Reason
+				conditions[i].Message = condition.Message
+			}
+		}
+		if !readyFound {
+			conditions = append(conditions, apis.Condition{
+				Type:               apis.ConditionReady,
+				Status:             v1.ConditionFalse,
+				LastTransitionTime: condition.LastTransitionTime,
+				Reason:             condition.Reason,
+				Message:            condition.Message,
+			})
+		}
+	}
+	status.Conditions = append(conditions, condition)
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+func makeTargetService(name string, ready bool) *v1beta1.InferenceService {
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: v1beta1.InferenceServiceSpec{
+			Predictor: v1beta1.PredictorSpec{
+				SKLearn: &v1beta1.SKLearnSpec{PredictorExtensionSpec: v1beta1.PredictorExtensionSpec{
+					StorageURI: proto.String("gs://kserve/models/sklearn"),
+				}},
+			},
+		},
+	}
+	if ready {
+		isvc.Status.Address = &duckv1.Addressable{URL: apis.HTTP(name + ".default.svc.cluster.local")}
+		isvc.Status.Conditions = duckv1.Conditions{{Type: apis.ConditionReady, Status: v1.ConditionTrue}}
+	}
+	return isvc
+}
+
+func TestResolveTargets(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(v1alpha1api.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(v1beta1.AddToScheme(scheme)).To(gomega.Succeed())
+
+	newGraph := func(steps ...v1alpha1api.InferenceStep) *v1alpha1api.InferenceGraph {
+		return &v1alpha1api.InferenceGraph{
+			ObjectMeta: metav1.ObjectMeta{Name: "graph", Namespace: "default"},
+			Spec: v1alpha1api.InferenceGraphSpec{
+				Nodes: map[string]v1alpha1api.InferenceRouter{
+					v1alpha1api.GraphRootNodeName: {RouterType: v1alpha1api.Sequence, Steps: steps},
+				},
+			},
+		}
+	}
+	newReconciler := func(services ...*v1beta1.InferenceService) *InferenceGraphReconciler {
+		builder := fake.NewClientBuilder().WithScheme(scheme)
+		for _, isvc := range services {
+			builder = builder.WithObjects(isvc)
+		}
+		return &InferenceGraphReconciler{Client: builder.Build(), Log: logf.Log.WithName("test")}
+	}
+	byName := func(name string) v1alpha1api.InferenceStep {
+		return v1alpha1api.InferenceStep{InferenceTarget: v1alpha1api.InferenceTarget{ServiceName: name}}
+	}
+
+	t.Run("ready targets resolve their URL", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		graph := newGraph(byName("model1"), byName("model1"))
+		targets, err := newReconciler(makeTargetService("model1", true)).resolveTargets(context.TODO(), graph, &v1alpha1api.TargetNamespaces{})
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(targets.ready()).To(gomega.BeTrue())
+		g.Expect(targets.unresolved).To(gomega.BeFalse())
+		g.Expect(targets.services).To(gomega.Equal([]string{"model1"}))
+		g.Expect(graph.Spec.Nodes[v1alpha1api.GraphRootNodeName].Steps[0].ServiceURL).
+			To(gomega.HavePrefix("http://model1.default.svc.cluster.local"))
+	})
+
+	t.Run("missing and not ready targets", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		graph := newGraph(byName("model1"), byName("model2"), byName("model3"))
+		targets, err := newReconciler(makeTargetService("model1", true), makeTargetService("model2", false)).
+			resolveTargets(context.TODO(), graph, &v1alpha1api.TargetNamespaces{})
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(targets.ready()).To(gomega.BeFalse())
+		g.Expect(targets.unresolved).To(gomega.BeTrue())
+		g.Expect(targets.missing).To(gomega.Equal([]string{"model3"}))
+		g.Expect(targets.notReady).To(gomega.Equal([]string{"model2"}))
+		g.Expect(targets.message()).To(gomega.Equal(
+			`InferenceService "model3" is not found; InferenceService "model2" is not ready`))
+	})
+
+	t.Run("targets with an explicit URL do not block the router", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		step := byName("model1")
+		step.ServiceURL = "http://model1.example.com"
+		graph := newGraph(step)
+		targets, err := newReconciler().resolveTargets(context.TODO(), graph, &v1alpha1api.TargetNamespaces{})
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(targets.ready()).To(gomega.BeFalse())
+		g.Expect(targets.unresolved).To(gomega.BeFalse())
+		g.Expect(targets.missing).To(gomega.Equal([]string{"model1"}))
+	})
+
+	t.Run("targets of other namespaces", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		isvc := makeTargetService("model1", true)
+		isvc.Namespace = "models"
+		isvc.Status.Address = &duckv1.Addressable{URL: apis.HTTP("model1.models.svc.cluster.local")}
+		step := byName("model1")
+		step.ServiceNamespace = "models"
+
+		graph := newGraph(step)
+		targets, err := newReconciler(isvc).resolveTargets(context.TODO(), graph, &v1alpha1api.TargetNamespaces{})
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(targets.unresolved).To(gomega.BeTrue())
+		g.Expect(targets.forbidden).To(gomega.Equal([]string{"models/model1"}))
+		g.Expect(targets.message()).To(gomega.Equal(
+			`InferenceService "models/model1" is in a namespace which is not allowed`))
+
+		graph = newGraph(step)
+		targets, err = newReconciler(isvc).resolveTargets(context.TODO(), graph,
+			&v1alpha1api.TargetNamespaces{EnableCrossNamespaceTargets: true, AllowedTargetNamespaces: []string{"models"}})
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(targets.ready()).To(gomega.BeTrue())
+		g.Expect(targets.services).To(gomega.Equal([]string{"models/model1"}))
+		g.Expect(graph.Spec.Nodes[v1alpha1api.GraphRootNodeName].Steps[0].ServiceURL).
+			To(gomega.HavePrefix("http://model1.models.svc.cluster.local"))
+	})
+}
+
+func TestSetTargetsCondition(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	status := &v1alpha1api.InferenceGraphStatus{
+		Status: duckv1.Status{
+			Conditions: duckv1.Conditions{{Type: apis.ConditionReady, Status: v1.ConditionTrue}},
+		},
+	}
+	setTargetsCondition(status, &graphTargets{services: []string{"model1"}, missing: []string{"model1"}})
+	condition := status.GetCondition(v1alpha1api.TargetsReady)
+	g.Expect(condition).NotTo(gomega.BeNil())
+	g.Expect(condition.Status).To(gomega.Equal(v1.ConditionFalse))
+	g.Expect(condition.Reason).To(gomega.Equal("TargetsNotFound"))
+	g.Expect(condition.Message).To(gomega.Equal(`InferenceService "model1" is not found`))
+	g.Expect(status.GetCondition(apis.ConditionReady).Status).To(gomega.Equal(v1.ConditionFalse))
+
+	transition := condition.LastTransitionTime
+	setTargetsCondition(status, &graphTargets{services: []string{"model1"}, notReady: []string{"model1"}})
+	condition = status.GetCondition(v1alpha1api.TargetsReady)
+	g.Expect(condition.Reason).To(gomega.Equal("TargetsNotReady"))
+	g.Expect(condition.LastTransitionTime).To(gomega.Equal(transition))
+
+	setTargetsCondition(status, &graphTargets{services: []string{"model1"}})
+	g.Expect(status.GetCondition(v1alpha1api.TargetsReady).Status).To(gomega.Equal(v1.ConditionTrue))
+
+	setTargetsCondition(status, &graphTargets{})
+	g.Expect(status.GetCondition(v1alpha1api.TargetsReady)).To(gomega.BeNil())
+	g.Expect(status.GetCondition(apis.ConditionReady)).NotTo(gomega.BeNil())
+}
@@ -0,0 +1,334 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// The kinds of the vertices of the graph topology
+const (
+	TopologyRouterKind  = "Router"
+	TopologyServiceKind = "InferenceService"
+	TopologyURLKind     = "URL"
+)
+
+// The states of the targets of the graph
+const (
+	TargetReady    = "Ready"
+	TargetNotReady = "NotReady"
+	TargetNotFound = "NotFound"
+	// TargetNotAllowed is the state of the InferenceServices of the namespaces the router config does not allow, they
+	// are not read by the controller
+	TargetNotAllowed = "NotAllowed"
+	// TargetExternal is the state of the URLs the graph calls, their state is not known to the controller
+	TargetExternal = "External"
+)
+
+// GraphTopology is the JSON representation of the graph rendered for the UIs
+type GraphTopology struct {
+	Graph     string           `json:"graph"`
+	Namespace string           `json:"namespace"`
+	Vertices  []TopologyVertex `json:"vertices"`
+	Edges     []TopologyEdge   `json:"edges"`
+}
+
+// TopologyVertex is a router node, an InferenceService or a URL of the graph
+type TopologyVertex struct {
+	ID         string `json:"id"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	RouterType string `json:"routerType,omitempty"`
+	URL        string `json:"url,omitempty"`
+	State      string `json:"state,omitempty"`
+	Message    string `json:"message,omitempty"`
+}
+
+// TopologyEdge is a step of a router node
+type TopologyEdge struct {
+	From       string `json:"from"`
+	To         string `json:"to"`
+	Step       string `json:"step,omitempty"`
+	Index      int    `json:"index"`
+	Condition  string `json:"condition,omitempty"`
+	Weight     *int64 `json:"weight,omitempty"`
+	Dependency string `json:"dependency,omitempty"`
+}
+
+// targetState is the resolved state of an InferenceService targeted by the graph
+type targetState struct {
+	State   string
+	URL     string
+	Message string
+}
+
+// reconcileTopology maintains the ConfigMap holding the topology of the graph in JSON and DOT with the states of the
+// InferenceServices it targets, so that the UIs can render the graph without parsing its spec.
+func (r *InferenceGraphReconciler) reconcileTopology(ctx context.Context, graph *v1alpha1api.InferenceGraph,
+	namespaces *v1alpha1api.TargetNamespaces) error {
+	states := map[string]targetState{}
+	for _, node := range graph.Spec.Nodes {
+		for _, step := range node.Steps {
+			if step.ServiceName == "" {
+				continue
+			}
+			target := stepTarget(graph, &step)
+			name := targetName(graph, target)
+			if _, ok := states[name]; ok {
+				continue
+			}
+			if !namespaces.Allows(graph.Namespace, target.Namespace) {
+				states[name] = targetState{State: TargetNotAllowed}
+				continue
+			}
+			state, err := r.resolveTargetState(ctx, target.Namespace, target.Name)
+			if err != nil {
+				return err
+			}
+			states[name] = state
+		}
+	}
+	desired, err := createTopologyConfigMap(graph, states)
+	if err != nil {
+		return err
+	}
+	if err := controllerutil.SetControllerReference(graph, desired, r.Scheme); err != nil {
+		return err
+	}
+
+	existing, err := r.Clientset.CoreV1().ConfigMaps(graph.Namespace).Get(ctx, desired.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierr.IsNotFound(err) {
+			r.Log.Info("Creating inference graph topology configmap", "namespace", graph.Namespace, "name", desired.Name)
+			_, err = r.Clientset.CoreV1().ConfigMaps(graph.Namespace).Create(ctx, desired, metav1.CreateOptions{})
+		}
+		return err
+	}
+	if equality.Semantic.DeepEqual(desired.Data, existing.Data) &&
+		equality.Semantic.DeepEqual(desired.Labels, existing.Labels) {
+		return nil
+	}
+	existing.Labels = desired.Labels
+	existing.Data = desired.Data
+	_, err = r.Clientset.CoreV1().ConfigMaps(graph.Namespace).Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
+
+// resolveTargetState returns the readiness and the URL of an InferenceService targeted by the graph
+func (r *InferenceGraphReconciler) resolveTargetState(ctx context.Context, namespace string, name string) (targetState, error) {
+	isvc := &v1beta1.InferenceService{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, isvc); err != nil {
+		if apierr.IsNotFound(err) {
+			return targetState{State: TargetNotFound}, nil
+		}
+		return targetState{}, err
+	}
+	state := targetState{State: TargetNotReady}
+	if isvc.Status.URL != nil {
+		state.URL = isvc.Status.URL.String()
+	}
+	if condition := isvc.Status.GetCondition(apis.ConditionReady); condition != nil {
+		if condition.IsTrue() {
+			state.State = TargetReady
+		} else {
+			state.Message = condition.Message
+		}
+	}
+	return state, nil
+}
+
+func createTopologyConfigMap(graph *v1alpha1api.InferenceGraph, states map[string]targetState) (*v1.ConfigMap, error) {
+	topology := buildTopology(graph, states)
+	data, err := json.MarshalIndent(topology, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      constants.InferenceGraphTopologyConfigMapName(graph.Name),
+			Namespace: graph.Namespace,
+			Labels:    map[string]string{constants.InferenceGraphLabel: graph.Name},
+		},
+		Data: map[string]string{
+			constants.InferenceGraphTopologyJSONKey: string(data),
+			constants.InferenceGraphTopologyDOTKey:  renderDOT(topology),
+		},
+	}, nil
+}
+
+// buildTopology returns the vertices of the router nodes, sorted by name, followed by the vertices of their targets
+// in the order of the steps, and an edge per step.
+func buildTopology(graph *v1alpha1api.InferenceGraph, states map[string]targetState) *GraphTopology {
+	topology := &GraphTopology{
+		Graph:     graph.Name,
+		Namespace: graph.Namespace,
+		Vertices:  []TopologyVertex{},
+		Edges:     []TopologyEdge{},
+	}
+	nodeNames := make([]string, 0, len(graph.Spec.Nodes))
+	for name := range graph.Spec.Nodes {
+		nodeNames = append(nodeNames, name)
+	}
+	sort.Strings(nodeNames)
+	for _, name := range nodeNames {
+		topology.Vertices = append(topology.Vertices, TopologyVertex{
+			ID:         routerVertexID(name),
+			Kind:       TopologyRouterKind,
+			Name:       name,
+			RouterType: string(graph.Spec.Nodes[name].RouterType),
+		})
+	}
+
+	seen := map[string]bool{}
+	for _, name := range nodeNames {
+		for i, step := range graph.Spec.Nodes[name].Steps {
+			var target TopologyVertex
+			switch {
+			case step.NodeName != "":
+				target = TopologyVertex{ID: routerVertexID(step.NodeName)}
+			case step.ServiceName != "":
+				name := targetName(graph, stepTarget(graph, &step))
+				state := states[name]
+				target = TopologyVertex{
+					ID:      "isvc/" + name,
+					Kind:    TopologyServiceKind,
+					Name:    name,
+					URL:     state.URL,
+					State:   state.State,
+					Message: state.Message,
+				}
+				if step.ServiceURL != "" {
+					target.URL = step.ServiceURL
+				}
+			default:
+				target = TopologyVertex{
+					ID:    "url/" + step.ServiceURL,
+					Kind:  TopologyURLKind,
+					Name:  step.ServiceURL,
+					URL:   step.ServiceURL,
+					State: TargetExternal,
+				}
+			}
+			if target.Kind != "" && !seen[target.ID] {
+				seen[target.ID] = true
+				topology.Vertices = append(topology.Vertices, target)
+			}
+			topology.Edges = append(topology.Edges, TopologyEdge{
+				From:       routerVertexID(name),
+				To:         target.ID,
+				Step:       step.StepName,
+				Index:      i,
+				Condition:  step.Condition,
+				Weight:     step.Weight,
+				Dependency: string(step.Dependency),
+			})
+		}
+	}
+	return topology
+}
+
+func routerVertexID(name string) string {
+	return "node/" + name
+}
+
+// renderDOT renders the topology in the Graphviz DOT language, the targets are colored by state.
+func renderDOT(topology *GraphTopology) string {
+	colors := map[string]string{
+		TargetReady:      "green",
+		TargetNotReady:   "orange",
+		TargetNotFound:   "red",
+		TargetNotAllowed: "red",
+		TargetExternal:   "gray",
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %q {\n", topology.Graph)
+	b.WriteString("  rankdir=LR;\n")
+	for _, vertex := range topology.Vertices {
+		switch vertex.Kind {
+		case TopologyRouterKind:
+			fmt.Fprintf(&b, "  %q [label=%q, shape=box];\n", vertex.ID, vertex.Name+"\n"+vertex.RouterType)
+		default:
+			fmt.Fprintf(&b, "  %q [label=%q, shape=ellipse, color=%s];\n", vertex.ID, vertex.Name+"\n"+vertex.State,
+				colors[vertex.State])
+		}
+	}
+	for _, edge := range topology.Edges {
+		var labels []string
+		if edge.Step != "" {
+			labels = append(labels, edge.Step)
+		}
+		if edge.Weight != nil {
+			labels = append(labels, fmt.Sprintf("weight %d", *edge.Weight))
+		}
+		if edge.Condition != "" {
+			labels = append(labels, "if "+edge.Condition)
+		}
+		fmt.Fprintf(&b, "  %q -> %q [label=%q];\n", edge.From, edge.To, strings.Join(labels, "\n"))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// graphsForService enqueues the graphs targeting the InferenceService, so that their topology and their TargetsReady
+// condition follow the state of the service. The graphs of every namespace are listed since they can target the
+// InferenceServices of other namespaces.
+func (r *InferenceGraphReconciler) graphsForService(ctx context.Context, obj client.Object) []reconcile.Request {
+	graphs := &v1alpha1api.InferenceGraphList{}
+	if err := r.Client.List(ctx, graphs); err != nil {
+		r.Log.Error(err, "Failed to list the inference graphs")
+		return nil
+	}
+	service := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+	var requests []reconcile.Request
+	for _, graph := range graphs.Items {
+		if graphTargetsService(&graph, service) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: graph.Namespace, Name: graph.Name},
+			})
+		}
+	}
+	return requests
+}
+
+func graphTargetsService(graph *v1alpha1api.InferenceGraph, service types.NamespacedName) bool {
+	for _, node := range graph.Spec.Nodes {
+		for _, step := range node.Steps {
+			if step.ServiceName != "" && stepTarget(graph, &step) == service {
+				return true
+			}
+		}
+	}
+	return false
+}
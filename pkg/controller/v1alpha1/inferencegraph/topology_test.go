@@ -0,0 +1,88 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestBuildTopology(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	graph := &v1alpha1.InferenceGraph{
+		ObjectMeta: metav1.ObjectMeta{Name: "graph", Namespace: "default"},
+		Spec: v1alpha1.InferenceGraphSpec{
+			Nodes: map[string]v1alpha1.InferenceRouter{
+				v1alpha1.GraphRootNodeName: {
+					RouterType: v1alpha1.Sequence,
+					Steps: []v1alpha1.InferenceStep{
+						{StepName: "preprocess", InferenceTarget: v1alpha1.InferenceTarget{ServiceName: "preprocess"}},
+						{StepName: "split", InferenceTarget: v1alpha1.InferenceTarget{NodeName: "split"}},
+					},
+				},
+				"split": {
+					RouterType: v1alpha1.Splitter,
+					Steps: []v1alpha1.InferenceStep{
+						{InferenceTarget: v1alpha1.InferenceTarget{ServiceName: "model-a"}, Weight: proto.Int64(80)},
+						{InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: "http://model-b.example.com"}, Weight: proto.Int64(20)},
+					},
+				},
+			},
+		},
+	}
+	states := map[string]targetState{
+		"preprocess": {State: TargetReady, URL: "http://preprocess.default.example.com"},
+		"model-a":    {State: TargetNotReady, Message: "Revision missing"},
+	}
+
+	topology := buildTopology(graph, states)
+	g.Expect(topology.Vertices).To(gomega.Equal([]TopologyVertex{
+		{ID: "node/root", Kind: TopologyRouterKind, Name: "root", RouterType: "Sequence"},
+		{ID: "node/split", Kind: TopologyRouterKind, Name: "split", RouterType: "Splitter"},
+		{ID: "isvc/preprocess", Kind: TopologyServiceKind, Name: "preprocess", URL: "http://preprocess.default.example.com", State: TargetReady},
+		{ID: "isvc/model-a", Kind: TopologyServiceKind, Name: "model-a", State: TargetNotReady, Message: "Revision missing"},
+		{ID: "url/http://model-b.example.com", Kind: TopologyURLKind, Name: "http://model-b.example.com", URL: "http://model-b.example.com", State: TargetExternal},
+	}))
+	g.Expect(topology.Edges).To(gomega.Equal([]TopologyEdge{
+		{From: "node/root", To: "isvc/preprocess", Step: "preprocess", Index: 0},
+		{From: "node/root", To: "node/split", Step: "split", Index: 1},
+		{From: "node/split", To: "isvc/model-a", Index: 0, Weight: proto.Int64(80)},
+		{From: "node/split", To: "url/http://model-b.example.com", Index: 1, Weight: proto.Int64(20)},
+	}))
+
+	dot := renderDOT(topology)
+	g.Expect(dot).To(gomega.HavePrefix("digraph \"graph\" {\n"))
+	g.Expect(dot).To(gomega.ContainSubstring(`"isvc/model-a" [label="model-a\nNotReady", shape=ellipse, color=orange];`))
+	g.Expect(dot).To(gomega.ContainSubstring(`"node/split" -> "isvc/model-a" [label="weight 80"];`))
+
+	g.Expect(graphTargetsService(graph, types.NamespacedName{Namespace: "default", Name: "model-a"})).To(gomega.BeTrue())
+	g.Expect(graphTargetsService(graph, types.NamespacedName{Namespace: "models", Name: "model-a"})).To(gomega.BeFalse())
+	g.Expect(graphTargetsService(graph, types.NamespacedName{Namespace: "default", Name: "model-b"})).To(gomega.BeFalse())
+
+	// the InferenceServices of other namespaces are qualified with their namespace
+	step := &graph.Spec.Nodes["split"].Steps[0]
+	step.ServiceNamespace = "models"
+	g.Expect(graphTargetsService(graph, types.NamespacedName{Namespace: "models", Name: "model-a"})).To(gomega.BeTrue())
+	topology = buildTopology(graph, map[string]targetState{"models/model-a": {State: TargetNotAllowed}})
+	g.Expect(topology.Vertices).To(gomega.ContainElement(
+		TopologyVertex{ID: "isvc/models/model-a", Kind: TopologyServiceKind, Name: "models/model-a", State: TargetNotAllowed}))
+}
@@ -19,17 +19,25 @@ package components
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/kserve/kserve/pkg/controller/v1alpha1/trainedmodel/sharding/memory"
 	v1beta1utils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
 	"github.com/kserve/kserve/pkg/credentials"
+	"github.com/kserve/kserve/pkg/podconfig"
+	v1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// attestationHTTPClient gets the sigstore attestations of the images from the registries
+var attestationHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
 // Component can be reconciled to create underlying resources for an InferenceService
 type Component interface {
 	Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, error)
@@ -63,6 +71,9 @@ func addLoggerAnnotations(logger *v1beta1.LoggerSpec, annotations map[string]str
 			annotations[constants.LoggerSinkUrlInternalAnnotationKey] = *logger.URL
 		}
 		annotations[constants.LoggerModeInternalAnnotationKey] = string(logger.Mode)
+		if logger.DeliveryMode != "" {
+			annotations[constants.LoggerDeliveryModeInternalAnnotationKey] = string(logger.DeliveryMode)
+		}
 	}
 }
 
@@ -81,6 +92,46 @@ func addBatcherAnnotations(batcher *v1beta1.Batcher, annotations map[string]stri
 	}
 }
 
+func addMiddlewareAnnotations(middleware *v1beta1.AgentMiddleware, annotations map[string]string) {
+	if middleware != nil {
+		if jsonMiddleware, err := json.Marshal(middleware); err == nil {
+			annotations[constants.AgentMiddlewareInternalAnnotationKey] = string(jsonMiddleware)
+		}
+	}
+}
+
+func addSidecarLoggingAnnotations(logging *v1beta1.SidecarLoggingSpec, annotations map[string]string) {
+	if logging != nil {
+		if jsonLogging, err := json.Marshal(logging); err == nil {
+			annotations[constants.SidecarLoggingInternalAnnotationKey] = string(jsonLogging)
+		}
+	}
+}
+
+func addAgentSecurityContextAnnotations(securityContext *v1beta1.SidecarSecurityContext, annotations map[string]string) {
+	if securityContext != nil {
+		if jsonSecurityContext, err := json.Marshal(securityContext); err == nil {
+			annotations[constants.AgentSecurityContextInternalAnnotationKey] = string(jsonSecurityContext)
+		}
+	}
+}
+
+func addCORSAnnotations(policy *v1beta1.CORSPolicy, annotations map[string]string) {
+	if policy != nil {
+		if jsonPolicy, err := json.Marshal(policy); err == nil {
+			annotations[constants.CORSInternalAnnotationKey] = string(jsonPolicy)
+		}
+	}
+}
+
+func addModelConversionAnnotations(conversion *v1beta1.ModelConversionSpec, annotations map[string]string) {
+	if conversion != nil {
+		if jsonConversion, err := json.Marshal(conversion); err == nil {
+			annotations[constants.ModelConversionInternalAnnotationKey] = string(jsonConversion)
+		}
+	}
+}
+
 func addAgentAnnotations(isvc *v1beta1.InferenceService, annotations map[string]string) bool {
 	if v1beta1utils.IsMMSPredictor(&isvc.Spec.Predictor) {
 		annotations[constants.AgentShouldInjectAnnotationKey] = "true"
@@ -95,3 +146,50 @@ func addAgentAnnotations(isvc *v1beta1.InferenceService, annotations map[string]
 	}
 	return false
 }
+
+// listComponentPods lists the pods of the latest revision of a component, newest first
+func listComponentPods(cl client.Client, isvc *v1beta1.InferenceService, component v1beta1.ComponentType,
+	serviceName string, rawDeployment bool) (*v1.PodList, error) {
+	if rawDeployment {
+		return v1beta1utils.ListPodsByLabel(cl, isvc.Namespace, constants.RawDeploymentAppLabel,
+			constants.GetRawServiceLabel(serviceName))
+	}
+	return v1beta1utils.ListPodsByLabel(cl, isvc.Namespace, constants.RevisionLabel,
+		isvc.Status.Components[component].LatestCreatedRevision)
+}
+
+// propagateImageStatus records the images of the latest pod of the component in its status and verifies their
+// sigstore attestations when an attestation public key is configured in the image policy
+func propagateImageStatus(imagePolicyConfig *podconfig.ImagePolicyConfig, isvc *v1beta1.InferenceService,
+	component v1beta1.ComponentType, pods *v1.PodList) error {
+	isvc.Status.PropagateImageStatus(component, pods)
+	statusSpec, ok := isvc.Status.Components[component]
+	if !ok || len(statusSpec.Images) == 0 {
+		return nil
+	}
+	if imagePolicyConfig.AttestationPublicKey == "" {
+		for i := range statusSpec.Images {
+			statusSpec.Images[i].Verification = ""
+			statusSpec.Images[i].Message = ""
+		}
+		return nil
+	}
+	publicKey, err := podconfig.ParseAttestationPublicKey(imagePolicyConfig.AttestationPublicKey)
+	if err != nil {
+		return err
+	}
+	for i := range statusSpec.Images {
+		image := &statusSpec.Images[i]
+		if image.Digest == "" || image.Verification == v1beta1.ImageVerified {
+			continue
+		}
+		if err := podconfig.VerifyImageAttestation(attestationHTTPClient, image.Image, image.Digest, publicKey); err != nil {
+			image.Verification = v1beta1.ImageVerificationFailed
+			image.Message = err.Error()
+		} else {
+			image.Verification = v1beta1.ImageVerified
+			image.Message = ""
+		}
+	}
+	return nil
+}
@@ -27,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -37,6 +38,7 @@ import (
 	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/raw"
 	isvcutils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
 	"github.com/kserve/kserve/pkg/credentials"
+	"github.com/kserve/kserve/pkg/podconfig"
 	"github.com/kserve/kserve/pkg/utils"
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
@@ -49,19 +51,24 @@ type Explainer struct {
 	client                 client.Client
 	clientset              kubernetes.Interface
 	scheme                 *runtime.Scheme
+	recorder               record.EventRecorder
 	inferenceServiceConfig *v1beta1.InferenceServicesConfig
+	imagePolicyConfig      *podconfig.ImagePolicyConfig
 	credentialBuilder      *credentials.CredentialBuilder //nolint: unused
 	deploymentMode         constants.DeploymentModeType
 	Log                    logr.Logger
 }
 
-func NewExplainer(client client.Client, clientset kubernetes.Interface, scheme *runtime.Scheme,
-	inferenceServiceConfig *v1beta1.InferenceServicesConfig, deploymentMode constants.DeploymentModeType) Component {
+func NewExplainer(client client.Client, clientset kubernetes.Interface, scheme *runtime.Scheme, recorder record.EventRecorder,
+	inferenceServiceConfig *v1beta1.InferenceServicesConfig, imagePolicyConfig *podconfig.ImagePolicyConfig,
+	deploymentMode constants.DeploymentModeType) Component {
 	return &Explainer{
 		client:                 client,
 		clientset:              clientset,
 		scheme:                 scheme,
+		recorder:               recorder,
 		inferenceServiceConfig: inferenceServiceConfig,
+		imagePolicyConfig:      imagePolicyConfig,
 		deploymentMode:         deploymentMode,
 		Log:                    ctrl.Log.WithName("ExplainerReconciler"),
 	}
@@ -84,6 +91,10 @@ func (e *Explainer) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 		}
 	}
 	addLoggerAnnotations(isvc.Spec.Explainer.Logger, annotations)
+	addMiddlewareAnnotations(isvc.Spec.Explainer.Middleware, annotations)
+	addSidecarLoggingAnnotations(isvc.Spec.Explainer.SidecarLogging, annotations)
+	addAgentSecurityContextAnnotations(isvc.Spec.Explainer.AgentSecurityContext, annotations)
+	addCORSAnnotations(isvc.Spec.CORS, annotations)
 
 	explainerName := constants.ExplainerServiceName(isvc.Name)
 	predictorName := constants.PredictorServiceName(isvc.Name)
@@ -118,9 +129,9 @@ func (e *Explainer) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 		Labels: utils.Union(
 			isvc.Labels,
 			explainerLabels,
+			constants.InferenceServiceOwnerLabels(isvc.Name),
 			map[string]string{
-				constants.InferenceServicePodLabelKey: isvc.Name,
-				constants.KServiceComponentLabel:      string(v1beta1.ExplainerComponent),
+				constants.KServiceComponentLabel: string(v1beta1.ExplainerComponent),
 			},
 		),
 		Annotations: utils.Union(
@@ -141,7 +152,7 @@ func (e *Explainer) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 
 	// Here we allow switch between knative and vanilla deployment
 	if e.deploymentMode == constants.RawDeployment {
-		r, err := raw.NewRawKubeReconciler(e.client, e.clientset, e.scheme, objectMeta,
+		r, err := raw.NewRawKubeReconciler(e.client, e.clientset, e.scheme, e.recorder, objectMeta,
 			&isvc.Spec.Explainer.ComponentExtensionSpec, &podSpec)
 		if err != nil {
 			return ctrl.Result{}, errors.Wrapf(err, "fails to create NewRawKubeReconciler for explainer")
@@ -164,6 +175,7 @@ func (e *Explainer) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 			return ctrl.Result{}, errors.Wrapf(err, "fails to reconcile explainer")
 		}
 		isvc.Status.PropagateRawStatus(v1beta1.ExplainerComponent, deployment, r.URL)
+		isvc.Status.PropagateRawServiceStatus(v1beta1.ExplainerComponent, r.Service.Service)
 	} else {
 		r := knative.NewKsvcReconciler(e.client, e.scheme, objectMeta, &isvc.Spec.Explainer.ComponentExtensionSpec,
 			&podSpec, isvc.Status.Components[v1beta1.ExplainerComponent])
@@ -177,5 +189,12 @@ func (e *Explainer) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 		}
 		isvc.Status.PropagateStatus(v1beta1.ExplainerComponent, status)
 	}
+	pods, err := listComponentPods(e.client, isvc, v1beta1.ExplainerComponent, explainerName, e.deploymentMode == constants.RawDeployment)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "fails to list explainer pods")
+	}
+	if err := propagateImageStatus(e.imagePolicyConfig, isvc, v1beta1.ExplainerComponent, pods); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "fails to propagate the image status of the explainer")
+	}
 	return ctrl.Result{}, nil
 }
@@ -27,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -40,6 +41,7 @@ import (
 	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/raw"
 	isvcutils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
 	"github.com/kserve/kserve/pkg/credentials"
+	"github.com/kserve/kserve/pkg/podconfig"
 	"github.com/kserve/kserve/pkg/utils"
 )
 
@@ -50,19 +52,24 @@ type Predictor struct {
 	client                 client.Client
 	clientset              kubernetes.Interface
 	scheme                 *runtime.Scheme
+	recorder               record.EventRecorder
 	inferenceServiceConfig *v1beta1.InferenceServicesConfig
+	imagePolicyConfig      *podconfig.ImagePolicyConfig
 	credentialBuilder      *credentials.CredentialBuilder //nolint: unused
 	deploymentMode         constants.DeploymentModeType
 	Log                    logr.Logger
 }
 
-func NewPredictor(client client.Client, clientset kubernetes.Interface, scheme *runtime.Scheme,
-	inferenceServiceConfig *v1beta1.InferenceServicesConfig, deploymentMode constants.DeploymentModeType) Component {
+func NewPredictor(client client.Client, clientset kubernetes.Interface, scheme *runtime.Scheme, recorder record.EventRecorder,
+	inferenceServiceConfig *v1beta1.InferenceServicesConfig, imagePolicyConfig *podconfig.ImagePolicyConfig,
+	deploymentMode constants.DeploymentModeType) Component {
 	return &Predictor{
 		client:                 client,
 		clientset:              clientset,
 		scheme:                 scheme,
+		recorder:               recorder,
 		inferenceServiceConfig: inferenceServiceConfig,
+		imagePolicyConfig:      imagePolicyConfig,
 		deploymentMode:         deploymentMode,
 		Log:                    ctrl.Log.WithName("PredictorReconciler"),
 	}
@@ -80,7 +87,12 @@ func (p *Predictor) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 	})
 
 	addLoggerAnnotations(isvc.Spec.Predictor.Logger, annotations)
+	addMiddlewareAnnotations(isvc.Spec.Predictor.Middleware, annotations)
+	addSidecarLoggingAnnotations(isvc.Spec.Predictor.SidecarLogging, annotations)
+	addAgentSecurityContextAnnotations(isvc.Spec.Predictor.AgentSecurityContext, annotations)
+	addCORSAnnotations(isvc.Spec.CORS, annotations)
 	addBatcherAnnotations(isvc.Spec.Predictor.Batcher, annotations)
+	addModelConversionAnnotations(isvc.Spec.Predictor.ModelConversion, annotations)
 	// Add StorageSpec annotations so mutator will mount storage credentials to InferenceService's predictor
 	addStorageSpecAnnotations(isvc.Spec.Predictor.GetImplementation().GetStorageSpec(), annotations)
 	// Add agent annotations so mutator will mount model agent to multi-model InferenceService's predictor
@@ -223,6 +235,14 @@ func (p *Predictor) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 		podSpec.Containers = append(podSpec.Containers, sRuntime.Containers[:kserveContainerIdx]...)
 		podSpec.Containers = append(podSpec.Containers, sRuntime.Containers[kserveContainerIdx+1:]...)
 
+		// Pull the runtime default images from the registry mirrors, images set on the predictor are kept as is
+		for i := range podSpec.Containers {
+			if i == 0 && isvc.Spec.Predictor.Model.Container.Image != "" {
+				continue
+			}
+			podSpec.Containers[i].Image = p.imagePolicyConfig.MirrorImage(podSpec.Containers[i].Image)
+		}
+
 		// Label filter will be handled in ksvc_reconciler
 		sRuntimeLabels = sRuntime.ServingRuntimePodSpec.Labels
 		sRuntimeAnnotations = utils.Filter(sRuntime.ServingRuntimePodSpec.Annotations, func(key string) bool {
@@ -285,9 +305,9 @@ func (p *Predictor) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 			sRuntimeLabels,
 			isvc.Labels,
 			predictorLabels,
+			constants.InferenceServiceOwnerLabels(isvc.Name),
 			map[string]string{
-				constants.InferenceServicePodLabelKey: isvc.Name,
-				constants.KServiceComponentLabel:      string(v1beta1.PredictorComponent),
+				constants.KServiceComponentLabel: string(v1beta1.PredictorComponent),
 			},
 		),
 		Annotations: utils.Union(
@@ -306,7 +326,7 @@ func (p *Predictor) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 	if p.deploymentMode == constants.RawDeployment {
 		rawDeployment = true
 		podLabelKey = constants.RawDeploymentAppLabel
-		r, err := raw.NewRawKubeReconciler(p.client, p.clientset, p.scheme, objectMeta, &isvc.Spec.Predictor.ComponentExtensionSpec,
+		r, err := raw.NewRawKubeReconciler(p.client, p.clientset, p.scheme, p.recorder, objectMeta, &isvc.Spec.Predictor.ComponentExtensionSpec,
 			&podSpec)
 		if err != nil {
 			return ctrl.Result{}, errors.Wrapf(err, "fails to create NewRawKubeReconciler for predictor")
@@ -329,6 +349,7 @@ func (p *Predictor) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 			return ctrl.Result{}, errors.Wrapf(err, "fails to reconcile predictor")
 		}
 		isvc.Status.PropagateRawStatus(v1beta1.PredictorComponent, deployment, r.URL)
+		isvc.Status.PropagateRawServiceStatus(v1beta1.PredictorComponent, r.Service.Service)
 	} else {
 		podLabelKey = constants.RevisionLabel
 		r := knative.NewKsvcReconciler(p.client, p.scheme, objectMeta, &isvc.Spec.Predictor.ComponentExtensionSpec,
@@ -353,5 +374,8 @@ func (p *Predictor) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 		return ctrl.Result{}, errors.Wrapf(err, "fails to list inferenceservice pods by label")
 	}
 	isvc.Status.PropagateModelStatus(statusSpec, predictorPods, rawDeployment)
+	if err := propagateImageStatus(p.imagePolicyConfig, isvc, v1beta1.PredictorComponent, predictorPods); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "fails to propagate the image status of the predictor")
+	}
 	return ctrl.Result{}, nil
 }
@@ -29,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -40,6 +41,7 @@ import (
 	raw "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/raw"
 	isvcutils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
 	"github.com/kserve/kserve/pkg/credentials"
+	"github.com/kserve/kserve/pkg/podconfig"
 	"github.com/kserve/kserve/pkg/utils"
 )
 
@@ -50,19 +52,24 @@ type Transformer struct {
 	client                 client.Client
 	clientset              kubernetes.Interface
 	scheme                 *runtime.Scheme
+	recorder               record.EventRecorder
 	inferenceServiceConfig *v1beta1.InferenceServicesConfig
+	imagePolicyConfig      *podconfig.ImagePolicyConfig
 	credentialBuilder      *credentials.CredentialBuilder //nolint: unused
 	deploymentMode         constants.DeploymentModeType
 	Log                    logr.Logger
 }
 
-func NewTransformer(client client.Client, clientset kubernetes.Interface, scheme *runtime.Scheme,
-	inferenceServiceConfig *v1beta1.InferenceServicesConfig, deploymentMode constants.DeploymentModeType) Component {
+func NewTransformer(client client.Client, clientset kubernetes.Interface, scheme *runtime.Scheme, recorder record.EventRecorder,
+	inferenceServiceConfig *v1beta1.InferenceServicesConfig, imagePolicyConfig *podconfig.ImagePolicyConfig,
+	deploymentMode constants.DeploymentModeType) Component {
 	return &Transformer{
 		client:                 client,
 		clientset:              clientset,
 		scheme:                 scheme,
+		recorder:               recorder,
 		inferenceServiceConfig: inferenceServiceConfig,
+		imagePolicyConfig:      imagePolicyConfig,
 		deploymentMode:         deploymentMode,
 		Log:                    ctrl.Log.WithName("TransformerReconciler"),
 	}
@@ -85,6 +92,10 @@ func (p *Transformer) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, er
 		}
 	}
 	addLoggerAnnotations(isvc.Spec.Transformer.Logger, annotations)
+	addMiddlewareAnnotations(isvc.Spec.Transformer.Middleware, annotations)
+	addSidecarLoggingAnnotations(isvc.Spec.Transformer.SidecarLogging, annotations)
+	addAgentSecurityContextAnnotations(isvc.Spec.Transformer.AgentSecurityContext, annotations)
+	addCORSAnnotations(isvc.Spec.CORS, annotations)
 	addBatcherAnnotations(isvc.Spec.Transformer.Batcher, annotations)
 
 	transformerName := constants.TransformerServiceName(isvc.Name)
@@ -120,9 +131,9 @@ func (p *Transformer) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, er
 		Labels: utils.Union(
 			isvc.Labels,
 			transformerLabels,
+			constants.InferenceServiceOwnerLabels(isvc.Name),
 			map[string]string{
-				constants.InferenceServicePodLabelKey: isvc.Name,
-				constants.KServiceComponentLabel:      string(v1beta1.TransformerComponent),
+				constants.KServiceComponentLabel: string(v1beta1.TransformerComponent),
 			},
 		),
 		Annotations: utils.Union(
@@ -170,7 +181,7 @@ func (p *Transformer) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, er
 
 	// Here we allow switch between knative and vanilla deployment
 	if p.deploymentMode == constants.RawDeployment {
-		r, err := raw.NewRawKubeReconciler(p.client, p.clientset, p.scheme, objectMeta,
+		r, err := raw.NewRawKubeReconciler(p.client, p.clientset, p.scheme, p.recorder, objectMeta,
 			&isvc.Spec.Transformer.ComponentExtensionSpec, &podSpec)
 		if err != nil {
 			return ctrl.Result{}, errors.Wrapf(err, "fails to create NewRawKubeReconciler for transformer")
@@ -193,6 +204,7 @@ func (p *Transformer) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, er
 			return ctrl.Result{}, errors.Wrapf(err, "fails to reconcile transformer")
 		}
 		isvc.Status.PropagateRawStatus(v1beta1.TransformerComponent, deployment, r.URL)
+		isvc.Status.PropagateRawServiceStatus(v1beta1.TransformerComponent, r.Service.Service)
 	} else {
 		r := knative.NewKsvcReconciler(p.client, p.scheme, objectMeta, &isvc.Spec.Transformer.ComponentExtensionSpec,
 			&podSpec, isvc.Status.Components[v1beta1.TransformerComponent])
@@ -205,5 +217,12 @@ func (p *Transformer) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, er
 		}
 		isvc.Status.PropagateStatus(v1beta1.TransformerComponent, status)
 	}
+	pods, err := listComponentPods(p.client, isvc, v1beta1.TransformerComponent, transformerName, p.deploymentMode == constants.RawDeployment)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "fails to list transformer pods")
+	}
+	if err := propagateImageStatus(p.imagePolicyConfig, isvc, v1beta1.TransformerComponent, pods); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "fails to propagate the image status of the transformer")
+	}
 	return ctrl.Result{}, nil
 }
@@ -20,14 +20,17 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	istioclientv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/equality"
+	netv1 "k8s.io/api/networking/v1"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -38,16 +41,24 @@ import (
 	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	v1beta1api "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/backend"
 	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/components"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/benchmarkjob"
 	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/cabundleconfigmap"
-	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/ingress"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/cost"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/dashboard"
 	modelconfig "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/modelconfig"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/validationjob"
 	isvcutils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
+	"github.com/kserve/kserve/pkg/podconfig"
 	"github.com/kserve/kserve/pkg/utils"
 )
 
@@ -56,6 +67,7 @@ import (
 // +kubebuilder:rbac:groups=serving.kserve.io,resources=servingruntimes/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=serving.kserve.io,resources=clusterservingruntimes;clusterservingruntimes/finalizers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=serving.kserve.io,resources=clusterservingruntimes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=servingprofiles,verbs=get;list;watch
 // +kubebuilder:rbac:groups=serving.kserve.io,resources=clusterstoragecontainers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
@@ -66,15 +78,23 @@ import (
 // +kubebuilder:rbac:groups=networking.istio.io,resources=virtualservices,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.istio.io,resources=virtualservices/finalizers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.istio.io,resources=virtualservices/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=security.istio.io,resources=peerauthentications,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations;validatingwebhookconfigurations,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;update
 // +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=scheduling.k8s.io,resources=priorityclasses,verbs=get
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get
-// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;create
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;create;update
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods/log,verbs=get
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list
 
 // InferenceState describes the Readiness of the InferenceService
 type InferenceServiceState string
@@ -93,9 +113,12 @@ type InferenceServiceReconciler struct {
 	Log          logr.Logger
 	Scheme       *runtime.Scheme
 	Recorder     record.EventRecorder
+
+	// controller is kept to watch the Knative Services when Knative Serving is installed after the controller started
+	controller controller.Controller
 }
 
-func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, reconcileErr error) {
 	_ = context.Background()
 
 	// Fetch the InferenceService instance
@@ -104,10 +127,18 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		if apierr.IsNotFound(err) {
 			// Object not found, return.  Created objects are automatically garbage collected.
 			// For additional cleanup logic use finalizers.
+			isvcutils.ChildResources.Forget(isvcutils.InferenceServiceKind, req.Namespace, req.Name)
+			isvcutils.LifecycleEvents.Forget(isvcutils.InferenceServiceKind, req.Namespace, req.Name)
+			if err := dashboard.NewDashboardReconciler(r.Client, r.Clientset).Reconcile(req.Namespace); err != nil {
+				return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile dashboard")
+			}
 			return reconcile.Result{}, nil
 		}
 		return reconcile.Result{}, err
 	}
+	defer func() {
+		isvcutils.ChildResources.RecordReconcile(isvcutils.InferenceServiceKind, req.Namespace, req.Name, reconcileErr)
+	}()
 	// get annotations from isvc
 	annotations := utils.Filter(isvc.Annotations, func(key string) bool {
 		return !utils.Includes(constants.ServiceAnnotationDisallowedList, key)
@@ -120,8 +151,18 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 
 	deploymentMode := isvcutils.GetDeploymentMode(annotations, deployConfig)
 	r.Log.Info("Inference service deployment mode ", "deployment mode ", deploymentMode)
+	deploymentBackend, err := backend.New(deploymentMode, backend.Options{
+		Client:       r.Client,
+		Clientset:    r.Clientset,
+		ClientConfig: r.ClientConfig,
+		Scheme:       r.Scheme,
+		Recorder:     r.Recorder,
+	})
+	if err != nil {
+		return reconcile.Result{}, reconcile.TerminalError(err)
+	}
 
-	if deploymentMode == constants.ModelMeshDeployment {
+	if !deploymentBackend.ReconcilesPredictor() {
 		if isvc.Spec.Transformer == nil {
 			// Skip if no transformers
 			r.Log.Info("Skipping reconciliation for InferenceService", constants.DeploymentMode, deploymentMode,
@@ -167,26 +208,94 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, nil
 	}
 
-	// Abort early if the resolved deployment mode is Serverless, but Knative Services are not available
-	if deploymentMode == constants.Serverless {
-		ksvcAvailable, checkKsvcErr := utils.IsCrdAvailable(r.ClientConfig, knservingv1.SchemeGroupVersion.String(), constants.KnativeServiceKind)
+	// Freeze the child resources while the InferenceService is paused, nothing is created, updated or scaled down
+	isvc.Status.SetPaused(utils.IsPaused(isvc.Annotations))
+	if utils.IsPaused(isvc.Annotations) {
+		r.Log.Info("Skipping reconciliation of paused InferenceService", "isvc", isvc.Name)
+		if err := r.updateStatus(isvc, deploymentBackend); err != nil {
+			return reconcile.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Remove the workloads while the InferenceService is stopped, they are created again once the annotation is removed
+	isvc.Status.SetStopped(utils.IsStopped(isvc.Annotations))
+	if utils.IsStopped(isvc.Annotations) {
+		r.Log.Info("Removing the workloads of stopped InferenceService", "isvc", isvc.Name)
+		if err := r.deleteWorkloads(isvc, deploymentBackend); err != nil {
+			return reconcile.Result{}, errors.Wrapf(err, "fails to remove the workloads of stopped InferenceService")
+		}
+		if err := r.updateStatus(isvc, deploymentBackend); err != nil {
+			return reconcile.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Remove the workloads outside the active hours, they are created again when the next window opens
+	requeueAfter := time.Duration(0)
+	if isvc.Spec.ActiveHours == nil {
+		isvc.Status.ClearCondition(v1beta1api.Scheduled)
+	} else {
+		active, next, err := isvc.Spec.ActiveHours.IsActive(time.Now())
 		if err != nil {
-			return reconcile.Result{}, checkKsvcErr
+			return reconcile.Result{}, reconcile.TerminalError(errors.Wrapf(err, "fails to evaluate the active hours"))
 		}
+		if !next.IsZero() {
+			requeueAfter = time.Until(next)
+		}
+		if previous := isvc.Status.GetCondition(v1beta1api.Scheduled); previous != nil && previous.IsTrue() != active {
+			if active {
+				r.Recorder.Event(isvc, v1.EventTypeNormal, "ActiveHoursStarted", "Creating the workloads as an active window opened")
+			} else {
+				r.Recorder.Event(isvc, v1.EventTypeNormal, "ActiveHoursEnded", "Removing the workloads as the active windows closed")
+			}
+		}
+		isvc.Status.SetScheduled(active, next)
+		if !active {
+			r.Log.Info("Removing the workloads of InferenceService outside its active hours", "isvc", isvc.Name,
+				"requeueAfter", requeueAfter)
+			if err := r.deleteWorkloads(isvc, deploymentBackend); err != nil {
+				return reconcile.Result{}, errors.Wrapf(err, "fails to remove the workloads outside the active hours")
+			}
+			if err := r.updateStatus(isvc, deploymentBackend); err != nil {
+				return reconcile.Result{}, err
+			}
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
+	}
 
-		if !ksvcAvailable {
-			r.Recorder.Event(isvc, v1.EventTypeWarning, "ServerlessModeRejected",
-				"It is not possible to use Serverless deployment mode when Knative Services are not available")
-			return reconcile.Result{Requeue: false}, reconcile.TerminalError(fmt.Errorf("the resolved deployment mode of InferenceService '%s' is Serverless, but Knative Serving is not available", isvc.Name))
+	// Abort early if the resolved deployment mode cannot be used, e.g. Serverless while Knative Services are not available
+	if err := deploymentBackend.Admit(ctx, isvc.Namespace); err != nil {
+		var rejected *backend.RejectedError
+		if !errors.As(err, &rejected) {
+			return reconcile.Result{}, err
+		}
+		r.Recorder.Event(isvc, v1.EventTypeWarning, rejected.Reason, rejected.Message)
+		if rejected.Condition == v1beta1api.ServiceMeshMember {
+			isvc.Status.SetServiceMeshMember(false, rejected.Message)
+			if err := r.updateStatus(isvc, deploymentBackend); err != nil {
+				return reconcile.Result{}, err
+			}
 		}
+		return reconcile.Result{Requeue: false}, reconcile.TerminalError(fmt.Errorf("the resolved deployment mode of InferenceService '%s' is %s, but %s", isvc.Name, deploymentMode, rejected.Cause))
 	}
+	isvc.Status.SetServiceMeshMember(true, "")
 
 	// Setup reconcilers
 	r.Log.Info("Reconciling inference service", "apiVersion", isvc.APIVersion, "isvc", isvc.Name)
-	isvcConfig, err := v1beta1api.NewInferenceServicesConfig(r.Clientset)
+	configMap, err := r.Clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Get(context.TODO(),
+		constants.InferenceServiceConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to get %s", constants.InferenceServiceConfigMapName)
+	}
+	isvcConfig, err := v1beta1api.GetInferenceServicesConfig(configMap)
 	if err != nil {
 		return reconcile.Result{}, errors.Wrapf(err, "fails to create InferenceServicesConfig")
 	}
+	imagePolicyConfig, err := podconfig.GetImagePolicyConfig(configMap)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to get image policy config")
+	}
 
 	// Reconcile cabundleConfigMap
 	caBundleConfigMapReconciler := cabundleconfigmap.NewCaBundleConfigMapReconciler(r.Client, r.Clientset, r.Scheme)
@@ -194,22 +303,32 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return reconcile.Result{}, err
 	}
 
+	// Reconcile the Grafana dashboard of the namespace
+	if err := dashboard.NewDashboardReconciler(r.Client, r.Clientset).Reconcile(isvc.Namespace); err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile dashboard")
+	}
+
+	// Annotate the estimated hourly cost when the cost estimation is enabled
+	if err := cost.NewCostReconciler(r.Client, r.Clientset).Reconcile(isvc); err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile estimated cost")
+	}
+
 	reconcilers := []components.Component{}
-	if deploymentMode != constants.ModelMeshDeployment {
-		reconcilers = append(reconcilers, components.NewPredictor(r.Client, r.Clientset, r.Scheme, isvcConfig, deploymentMode))
+	if deploymentBackend.ReconcilesPredictor() {
+		reconcilers = append(reconcilers, components.NewPredictor(r.Client, r.Clientset, r.Scheme, r.Recorder, isvcConfig, imagePolicyConfig, deploymentMode))
 	}
 	if isvc.Spec.Transformer != nil {
-		reconcilers = append(reconcilers, components.NewTransformer(r.Client, r.Clientset, r.Scheme, isvcConfig, deploymentMode))
+		reconcilers = append(reconcilers, components.NewTransformer(r.Client, r.Clientset, r.Scheme, r.Recorder, isvcConfig, imagePolicyConfig, deploymentMode))
 	}
 	if isvc.Spec.Explainer != nil {
-		reconcilers = append(reconcilers, components.NewExplainer(r.Client, r.Clientset, r.Scheme, isvcConfig, deploymentMode))
+		reconcilers = append(reconcilers, components.NewExplainer(r.Client, r.Clientset, r.Scheme, r.Recorder, isvcConfig, imagePolicyConfig, deploymentMode))
 	}
 	for _, reconciler := range reconcilers {
 		result, err := reconciler.Reconcile(isvc)
 		if err != nil {
 			r.Log.Error(err, "Failed to reconcile", "reconciler", reflect.ValueOf(reconciler), "Name", isvc.Name)
 			r.Recorder.Eventf(isvc, v1.EventTypeWarning, "InternalError", err.Error())
-			if err := r.updateStatus(isvc, deploymentMode); err != nil {
+			if err := r.updateStatus(isvc, deploymentBackend); err != nil {
 				r.Log.Error(err, "Error updating status")
 				return result, err
 			}
@@ -219,39 +338,18 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			return result, nil
 		}
 	}
-	// reconcile RoutesReady and LatestDeploymentReady conditions for serverless deployment
-	if deploymentMode == constants.Serverless {
-		componentList := []v1beta1api.ComponentType{v1beta1api.PredictorComponent}
-		if isvc.Spec.Transformer != nil {
-			componentList = append(componentList, v1beta1api.TransformerComponent)
-		}
-		if isvc.Spec.Explainer != nil {
-			componentList = append(componentList, v1beta1api.ExplainerComponent)
-		}
-		isvc.Status.PropagateCrossComponentStatus(componentList, v1beta1api.RoutesReady)
-		isvc.Status.PropagateCrossComponentStatus(componentList, v1beta1api.LatestDeploymentReady)
-	}
+	// reconcile the conditions specific to the deployment mode, e.g. RoutesReady and LatestDeploymentReady for serverless
+	// deployment
+	deploymentBackend.PropagateStatus(isvc)
 	// Reconcile ingress
 	ingressConfig, err := v1beta1api.NewIngressConfig(r.Clientset)
 	if err != nil {
 		return reconcile.Result{}, errors.Wrapf(err, "fails to create IngressConfig")
 	}
 
-	// check raw deployment
-	if deploymentMode == constants.RawDeployment {
-		reconciler, err := ingress.NewRawIngressReconciler(r.Client, r.Scheme, ingressConfig)
-		if err != nil {
-			return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile ingress")
-		}
-		if err := reconciler.Reconcile(isvc); err != nil {
-			return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile ingress")
-		}
-	} else {
-		reconciler := ingress.NewIngressReconciler(r.Client, r.Clientset, r.Scheme, ingressConfig)
-		r.Log.Info("Reconciling ingress for inference service", "isvc", isvc.Name)
-		if err := reconciler.Reconcile(isvc); err != nil {
-			return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile ingress")
-		}
+	r.Log.Info("Reconciling ingress for inference service", "isvc", isvc.Name)
+	if err := deploymentBackend.ReconcileIngress(isvc, ingressConfig.ForObject(isvc.ObjectMeta)); err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile ingress")
 	}
 
 	// Reconcile modelConfig
@@ -260,22 +358,44 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return reconcile.Result{}, err
 	}
 
-	if err = r.updateStatus(isvc, deploymentMode); err != nil {
+	// Reconcile the validation job of the predictor revision, it holds the readiness until it succeeds
+	validationJobReconciler := validationjob.NewValidationJobReconciler(r.Client, r.Clientset, r.Scheme, r.Recorder)
+	if err := validationJobReconciler.Reconcile(isvc); err != nil {
+		if err := r.updateStatus(isvc, deploymentBackend); err != nil {
+			r.Log.Error(err, "Error updating status")
+		}
+		return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile validation job")
+	}
+
+	// Reconcile the benchmark job of the predictor revision, it only reports the results in the status
+	benchmarkJobReconciler := benchmarkjob.NewBenchmarkJobReconciler(r.Client, r.Clientset, r.Scheme, r.Recorder)
+	if err := benchmarkJobReconciler.Reconcile(isvc); err != nil {
+		if err := r.updateStatus(isvc, deploymentBackend); err != nil {
+			r.Log.Error(err, "Error updating status")
+		}
+		return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile benchmark job")
+	}
+
+	// Record the global configuration the InferenceService was built with
+	isvc.Status.EffectiveConfig = isvcutils.GetEffectiveConfig(isvc, deploymentMode, ingressConfig, configMap.ResourceVersion)
+	isvc.Status.Endpoints = isvcutils.GetEndpoints(isvc)
+
+	if err = r.updateStatus(isvc, deploymentBackend); err != nil {
 		r.Recorder.Eventf(isvc, v1.EventTypeWarning, "InternalError", err.Error())
 		return reconcile.Result{}, err
 	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
-func (r *InferenceServiceReconciler) updateStatus(desiredService *v1beta1api.InferenceService, deploymentMode constants.DeploymentModeType) error {
+func (r *InferenceServiceReconciler) updateStatus(desiredService *v1beta1api.InferenceService, deploymentBackend backend.DeploymentBackend) error {
 	existingService := &v1beta1api.InferenceService{}
 	namespacedName := types.NamespacedName{Name: desiredService.Name, Namespace: desiredService.Namespace}
 	if err := r.Get(context.TODO(), namespacedName, existingService); err != nil {
 		return err
 	}
 	wasReady := inferenceServiceReadiness(existingService.Status)
-	if inferenceServiceStatusEqual(existingService.Status, desiredService.Status, deploymentMode) {
+	if deploymentBackend.StatusEqual(existingService.Status, desiredService.Status) {
 		// If we didn't change anything then don't call updateStatus.
 		// This is important because the copy we loaded from the informer's
 		// cache may be stale and we don't want to overwrite a prior update
@@ -296,28 +416,43 @@ func (r *InferenceServiceReconciler) updateStatus(desiredService *v1beta1api.Inf
 				fmt.Sprintf("InferenceService [%v] is Ready", desiredService.GetName()))
 		}
 	}
+	r.publishLifecycleEvents(existingService, desiredService)
 	return nil
 }
 
+// publishLifecycleEvents sends the lifecycle transitions of the InferenceService to the event sink of its namespace
+func (r *InferenceServiceReconciler) publishLifecycleEvents(existingService *v1beta1api.InferenceService,
+	desiredService *v1beta1api.InferenceService) {
+	lifecycleEventsConfig, err := v1beta1api.NewLifecycleEventsConfig(r.Clientset)
+	if err != nil {
+		r.Log.Error(err, "Failed to read the lifecycle events config", "InferenceService", desiredService.Name)
+		return
+	}
+	sink := lifecycleEventsConfig.GetSink(desiredService.Namespace)
+	if sink == "" {
+		return
+	}
+	data := isvcutils.LifecycleEventData{
+		Kind:       isvcutils.InferenceServiceKind,
+		Namespace:  desiredService.Namespace,
+		Name:       desiredService.Name,
+		Generation: desiredService.Generation,
+	}
+	if desiredService.Status.URL != nil {
+		data.URL = desiredService.Status.URL.String()
+	}
+	readyReplicas := isvcutils.ReadyReplicas(r.Client, desiredService.Namespace,
+		map[string]string{constants.InferenceServicePodLabelKey: desiredService.Name})
+	isvcutils.LifecycleEvents.Publish(sink, data, existingService.Status.GetCondition(apis.ConditionReady),
+		desiredService.Status.GetCondition(apis.ConditionReady), readyReplicas)
+}
+
 func inferenceServiceReadiness(status v1beta1api.InferenceServiceStatus) bool {
 	return status.Conditions != nil &&
 		status.GetCondition(apis.ConditionReady) != nil &&
 		status.GetCondition(apis.ConditionReady).Status == v1.ConditionTrue
 }
 
-func inferenceServiceStatusEqual(s1, s2 v1beta1api.InferenceServiceStatus, deploymentMode constants.DeploymentModeType) bool {
-	if deploymentMode == constants.ModelMeshDeployment {
-		// If the deployment mode is ModelMesh, reduce the status scope to compare.
-		// Exclude Predictor and ModelStatus which are mananged by ModelMesh controllers
-		return equality.Semantic.DeepEqual(s1.Address, s2.Address) &&
-			equality.Semantic.DeepEqual(s1.URL, s2.URL) &&
-			equality.Semantic.DeepEqual(s1.Status, s2.Status) &&
-			equality.Semantic.DeepEqual(s1.Components[v1beta1api.TransformerComponent], s2.Components[v1beta1api.TransformerComponent]) &&
-			equality.Semantic.DeepEqual(s1.Components[v1beta1api.ExplainerComponent], s2.Components[v1beta1api.ExplainerComponent])
-	}
-	return equality.Semantic.DeepEqual(s1, s2)
-}
-
 func (r *InferenceServiceReconciler) SetupWithManager(mgr ctrl.Manager, deployConfig *v1beta1api.DeployConfig, ingressConfig *v1beta1api.IngressConfig) error {
 	r.ClientConfig = mgr.GetConfig()
 
@@ -331,9 +466,13 @@ func (r *InferenceServiceReconciler) SetupWithManager(mgr ctrl.Manager, deployCo
 		return err
 	}
 
+	// the raw services and ingresses are watched so that their out-of-band edits are handled by the drift policy
 	ctrlBuilder := ctrl.NewControllerManagedBy(mgr).
 		For(&v1beta1api.InferenceService{}).
-		Owns(&appsv1.Deployment{})
+		Owns(&appsv1.Deployment{}).
+		Owns(&batchv1.Job{}).
+		Owns(&v1.Service{}).
+		Owns(&netv1.Ingress{})
 
 	if ksvcFound {
 		ctrlBuilder = ctrlBuilder.Owns(&knservingv1.Service{})
@@ -347,7 +486,38 @@ func (r *InferenceServiceReconciler) SetupWithManager(mgr ctrl.Manager, deployCo
 		r.Log.Info("The InferenceService controller won't watch networking.istio.io/v1beta1/VirtualService resources because the CRD is not available.")
 	}
 
-	return ctrlBuilder.Complete(r)
+	r.controller, err = ctrlBuilder.Build(r)
+	return err
+}
+
+// WatchKnativeServices watches the Knative Services owned by the InferenceServices, it is called when Knative Serving is
+// installed after the controller started
+func (r *InferenceServiceReconciler) WatchKnativeServices(mgr ctrl.Manager) error {
+	r.Log.Info("Watching serving.knative.dev/v1/Service resources because the CRD is now available.")
+	return r.controller.Watch(source.Kind(mgr.GetCache(), &knservingv1.Service{}),
+		handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &v1beta1api.InferenceService{}, handler.OnlyControllerOwner()))
+}
+
+// deleteWorkloads deletes the Knative services, or the deployments and their autoscalers, of the components of the
+// InferenceService
+func (r *InferenceServiceReconciler) deleteWorkloads(isvc *v1beta1api.InferenceService, deploymentBackend backend.DeploymentBackend) error {
+	lists := deploymentBackend.Workloads()
+	for _, list := range lists {
+		if err := r.List(context.TODO(), list, client.InNamespace(isvc.Namespace),
+			client.MatchingLabels{constants.InferenceServicePodLabelKey: isvc.Name}); err != nil {
+			return err
+		}
+		if err := meta.EachListItem(list, func(obj runtime.Object) error {
+			workload := obj.(client.Object)
+			if !metav1.IsControlledBy(workload, isvc) {
+				return nil
+			}
+			return client.IgnoreNotFound(r.Delete(context.TODO(), workload, client.PropagationPolicy(metav1.DeletePropagationBackground)))
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (r *InferenceServiceReconciler) deleteExternalResources(isvc *v1beta1api.InferenceService) error {
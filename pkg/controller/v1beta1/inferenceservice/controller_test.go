@@ -228,6 +228,7 @@ var _ = Describe("v1beta1 inference service controller", func() {
 								Labels: map[string]string{
 									constants.KServiceComponentLabel:      constants.Predictor.String(),
 									constants.InferenceServicePodLabelKey: serviceName,
+									constants.PartOfLabel:                 serviceName,
 									"key1":                                "val1FromSR",
 									"key2":                                "val2FromISVC",
 									"key3":                                "val3FromPredictor",
@@ -614,6 +615,7 @@ var _ = Describe("v1beta1 inference service controller", func() {
 						Template: knservingv1.RevisionTemplateSpec{
 							ObjectMeta: metav1.ObjectMeta{
 								Labels: map[string]string{"serving.kserve.io/inferenceservice": serviceName,
+									constants.PartOfLabel:            serviceName,
 									constants.KServiceComponentLabel: constants.Transformer.String(),
 									"key1":                           "val1FromISVC",
 									"key2":                           "val2FromTransformer",
@@ -785,6 +787,10 @@ var _ = Describe("v1beta1 inference service controller", func() {
 						URL:                   transformerUrl,
 					},
 				},
+				EffectiveConfig: &v1beta1.EffectiveConfig{
+					DeploymentMode:  "Serverless",
+					AutoscalerClass: "kpa.autoscaling.knative.dev",
+				},
 				ModelStatus: v1beta1.ModelStatus{
 					TransitionStatus:    "InProgress",
 					ModelRevisionStates: &v1beta1.ModelRevisionStates{TargetModelState: "Pending"},
@@ -795,7 +801,8 @@ var _ = Describe("v1beta1 inference service controller", func() {
 				if err := k8sClient.Get(context.TODO(), serviceKey, isvc); err != nil {
 					return err.Error()
 				}
-				return cmp.Diff(&expectedIsvcStatus, &isvc.Status, cmpopts.IgnoreTypes(apis.Condition{}, "LastTransitionTime", "Severity"))
+				return cmp.Diff(&expectedIsvcStatus, &isvc.Status, cmpopts.IgnoreTypes(apis.Condition{}, "LastTransitionTime", "Severity"),
+					cmpopts.IgnoreFields(v1beta1.EffectiveConfig{}, "ConfigMapResourceVersion"))
 			}, timeout).Should(gomega.BeEmpty())
 		})
 	})
@@ -1236,6 +1243,7 @@ var _ = Describe("v1beta1 inference service controller", func() {
 						Template: knservingv1.RevisionTemplateSpec{
 							ObjectMeta: metav1.ObjectMeta{
 								Labels: map[string]string{"serving.kserve.io/inferenceservice": serviceName,
+									constants.PartOfLabel:            serviceName,
 									constants.KServiceComponentLabel: constants.Predictor.String(),
 									"key1":                           "val1FromSR",
 									"key2":                           "val2FromISVC",
@@ -214,6 +214,7 @@ var _ = Describe("v1beta1 inference service controller", func() {
 								"app":                                 "isvc." + predictorDeploymentKey.Name,
 								constants.KServiceComponentLabel:      constants.Predictor.String(),
 								constants.InferenceServicePodLabelKey: serviceName,
+								constants.PartOfLabel:                 serviceName,
 							},
 							Annotations: map[string]string{
 								constants.StorageInitializerSourceUriInternalAnnotationKey: *isvc.Spec.Predictor.Model.StorageURI,
@@ -354,7 +355,7 @@ var _ = Describe("v1beta1 inference service controller", func() {
 												Service: &netv1.IngressServiceBackend{
 													Name: "raw-foo-predictor",
 													Port: netv1.ServiceBackendPort{
-														Number: 80,
+														Name: "raw-foo-predictor",
 													},
 												},
 											},
@@ -375,7 +376,7 @@ var _ = Describe("v1beta1 inference service controller", func() {
 												Service: &netv1.IngressServiceBackend{
 													Name: "raw-foo-predictor",
 													Port: netv1.ServiceBackendPort{
-														Number: 80,
+														Name: "raw-foo-predictor",
 													},
 												},
 											},
@@ -425,6 +426,10 @@ var _ = Describe("v1beta1 inference service controller", func() {
 						},
 					},
 				},
+				EffectiveConfig: &v1beta1.EffectiveConfig{
+					DeploymentMode:  "RawDeployment",
+					AutoscalerClass: "hpa",
+				},
 				ModelStatus: v1beta1.ModelStatus{
 					TransitionStatus:    "InProgress",
 					ModelRevisionStates: &v1beta1.ModelRevisionStates{TargetModelState: "Pending"},
@@ -435,7 +440,8 @@ var _ = Describe("v1beta1 inference service controller", func() {
 				if err := k8sClient.Get(context.TODO(), serviceKey, isvc); err != nil {
 					return err.Error()
 				}
-				return cmp.Diff(&expectedIsvcStatus, &isvc.Status, cmpopts.IgnoreTypes(apis.VolatileTime{}))
+				return cmp.Diff(&expectedIsvcStatus, &isvc.Status, cmpopts.IgnoreTypes(apis.VolatileTime{}),
+					cmpopts.IgnoreFields(v1beta1.EffectiveConfig{}, "ConfigMapResourceVersion"))
 			}, timeout).Should(gomega.BeEmpty())
 
 			//check HPA
@@ -631,6 +637,7 @@ var _ = Describe("v1beta1 inference service controller", func() {
 								"app":                                 "isvc." + predictorDeploymentKey.Name,
 								constants.KServiceComponentLabel:      constants.Predictor.String(),
 								constants.InferenceServicePodLabelKey: serviceName,
+								constants.PartOfLabel:                 serviceName,
 							},
 							Annotations: map[string]string{
 								constants.StorageInitializerSourceUriInternalAnnotationKey: *isvc.Spec.Predictor.Model.StorageURI,
@@ -768,7 +775,7 @@ var _ = Describe("v1beta1 inference service controller", func() {
 												Service: &netv1.IngressServiceBackend{
 													Name: "raw-foo-customized-predictor",
 													Port: netv1.ServiceBackendPort{
-														Number: 80,
+														Name: "raw-foo-customized-predictor",
 													},
 												},
 											},
@@ -789,7 +796,7 @@ var _ = Describe("v1beta1 inference service controller", func() {
 												Service: &netv1.IngressServiceBackend{
 													Name: "raw-foo-customized-predictor",
 													Port: netv1.ServiceBackendPort{
-														Number: 80,
+														Name: "raw-foo-customized-predictor",
 													},
 												},
 											},
@@ -839,6 +846,10 @@ var _ = Describe("v1beta1 inference service controller", func() {
 						},
 					},
 				},
+				EffectiveConfig: &v1beta1.EffectiveConfig{
+					DeploymentMode:  "RawDeployment",
+					AutoscalerClass: "hpa",
+				},
 				ModelStatus: v1beta1.ModelStatus{
 					TransitionStatus:    "InProgress",
 					ModelRevisionStates: &v1beta1.ModelRevisionStates{TargetModelState: "Pending"},
@@ -849,7 +860,8 @@ var _ = Describe("v1beta1 inference service controller", func() {
 				if err := k8sClient.Get(context.TODO(), serviceKey, isvc); err != nil {
 					return err.Error()
 				}
-				return cmp.Diff(&expectedIsvcStatus, &isvc.Status, cmpopts.IgnoreTypes(apis.VolatileTime{}))
+				return cmp.Diff(&expectedIsvcStatus, &isvc.Status, cmpopts.IgnoreTypes(apis.VolatileTime{}),
+					cmpopts.IgnoreFields(v1beta1.EffectiveConfig{}, "ConfigMapResourceVersion"))
 			}, timeout).Should(gomega.BeEmpty())
 
 			//check HPA
@@ -1035,6 +1047,7 @@ var _ = Describe("v1beta1 inference service controller", func() {
 								"app":                                 "isvc." + predictorDeploymentKey.Name,
 								constants.KServiceComponentLabel:      constants.Predictor.String(),
 								constants.InferenceServicePodLabelKey: serviceName,
+								constants.PartOfLabel:                 serviceName,
 							},
 							Annotations: map[string]string{
 								constants.StorageInitializerSourceUriInternalAnnotationKey: *isvc.Spec.Predictor.Model.StorageURI,
@@ -1173,7 +1186,7 @@ var _ = Describe("v1beta1 inference service controller", func() {
 												Service: &netv1.IngressServiceBackend{
 													Name: "raw-foo-2-predictor",
 													Port: netv1.ServiceBackendPort{
-														Number: 80,
+														Name: "raw-foo-2-predictor",
 													},
 												},
 											},
@@ -1194,7 +1207,7 @@ var _ = Describe("v1beta1 inference service controller", func() {
 												Service: &netv1.IngressServiceBackend{
 													Name: "raw-foo-2-predictor",
 													Port: netv1.ServiceBackendPort{
-														Number: 80,
+														Name: "raw-foo-2-predictor",
 													},
 												},
 											},
@@ -1244,6 +1257,10 @@ var _ = Describe("v1beta1 inference service controller", func() {
 						},
 					},
 				},
+				EffectiveConfig: &v1beta1.EffectiveConfig{
+					DeploymentMode:  "RawDeployment",
+					AutoscalerClass: "external",
+				},
 				ModelStatus: v1beta1.ModelStatus{
 					TransitionStatus:    "InProgress",
 					ModelRevisionStates: &v1beta1.ModelRevisionStates{TargetModelState: "Pending"},
@@ -1254,7 +1271,8 @@ var _ = Describe("v1beta1 inference service controller", func() {
 				if err := k8sClient.Get(context.TODO(), serviceKey, isvc); err != nil {
 					return err.Error()
 				}
-				return cmp.Diff(&expectedIsvcStatus, &isvc.Status, cmpopts.IgnoreTypes(apis.VolatileTime{}))
+				return cmp.Diff(&expectedIsvcStatus, &isvc.Status, cmpopts.IgnoreTypes(apis.VolatileTime{}),
+					cmpopts.IgnoreFields(v1beta1.EffectiveConfig{}, "ConfigMapResourceVersion"))
 			}, timeout).Should(gomega.BeEmpty())
 
 			//check HPA is not created
@@ -1407,6 +1425,7 @@ var _ = Describe("v1beta1 inference service controller", func() {
 								"app":                                 "isvc." + predictorDeploymentKey.Name,
 								constants.KServiceComponentLabel:      constants.Predictor.String(),
 								constants.InferenceServicePodLabelKey: serviceName,
+								constants.PartOfLabel:                 serviceName,
 							},
 							Annotations: map[string]string{
 								constants.StorageInitializerSourceUriInternalAnnotationKey: *isvc.Spec.Predictor.Model.StorageURI,
@@ -1547,7 +1566,7 @@ var _ = Describe("v1beta1 inference service controller", func() {
 												Service: &netv1.IngressServiceBackend{
 													Name: fmt.Sprintf("%s-predictor", serviceName),
 													Port: netv1.ServiceBackendPort{
-														Number: 80,
+														Name: fmt.Sprintf("%s-predictor", serviceName),
 													},
 												},
 											},
@@ -1568,7 +1587,7 @@ var _ = Describe("v1beta1 inference service controller", func() {
 												Service: &netv1.IngressServiceBackend{
 													Name: fmt.Sprintf("%s-predictor", serviceName),
 													Port: netv1.ServiceBackendPort{
-														Number: 80,
+														Name: fmt.Sprintf("%s-predictor", serviceName),
 													},
 												},
 											},
@@ -1618,6 +1637,10 @@ var _ = Describe("v1beta1 inference service controller", func() {
 						},
 					},
 				},
+				EffectiveConfig: &v1beta1.EffectiveConfig{
+					DeploymentMode:  "RawDeployment",
+					AutoscalerClass: "hpa",
+				},
 				ModelStatus: v1beta1.ModelStatus{
 					TransitionStatus:    "InProgress",
 					ModelRevisionStates: &v1beta1.ModelRevisionStates{TargetModelState: "Pending"},
@@ -1628,7 +1651,8 @@ var _ = Describe("v1beta1 inference service controller", func() {
 				if err := k8sClient.Get(context.TODO(), serviceKey, isvc); err != nil {
 					return err.Error()
 				}
-				return cmp.Diff(&expectedIsvcStatus, &isvc.Status, cmpopts.IgnoreTypes(apis.VolatileTime{}))
+				return cmp.Diff(&expectedIsvcStatus, &isvc.Status, cmpopts.IgnoreTypes(apis.VolatileTime{}),
+					cmpopts.IgnoreFields(v1beta1.EffectiveConfig{}, "ConfigMapResourceVersion"))
 			}, timeout).Should(gomega.BeEmpty())
 
 			//check HPA
@@ -1840,6 +1864,7 @@ var _ = Describe("v1beta1 inference service controller", func() {
 								"app":                                 "isvc." + predictorDeploymentKey.Name,
 								constants.KServiceComponentLabel:      constants.Predictor.String(),
 								constants.InferenceServicePodLabelKey: serviceName,
+								constants.PartOfLabel:                 serviceName,
 							},
 							Annotations: map[string]string{
 								constants.StorageInitializerSourceUriInternalAnnotationKey: *isvc.Spec.Predictor.Model.StorageURI,
@@ -1980,7 +2005,7 @@ var _ = Describe("v1beta1 inference service controller", func() {
 												Service: &netv1.IngressServiceBackend{
 													Name: fmt.Sprintf("%s-predictor", serviceName),
 													Port: netv1.ServiceBackendPort{
-														Number: 80,
+														Name: fmt.Sprintf("%s-predictor", serviceName),
 													},
 												},
 											},
@@ -2001,7 +2026,7 @@ var _ = Describe("v1beta1 inference service controller", func() {
 												Service: &netv1.IngressServiceBackend{
 													Name: fmt.Sprintf("%s-predictor", serviceName),
 													Port: netv1.ServiceBackendPort{
-														Number: 80,
+														Name: fmt.Sprintf("%s-predictor", serviceName),
 													},
 												},
 											},
@@ -2051,6 +2076,10 @@ var _ = Describe("v1beta1 inference service controller", func() {
 						},
 					},
 				},
+				EffectiveConfig: &v1beta1.EffectiveConfig{
+					DeploymentMode:  "RawDeployment",
+					AutoscalerClass: "hpa",
+				},
 				ModelStatus: v1beta1.ModelStatus{
 					TransitionStatus:    "InProgress",
 					ModelRevisionStates: &v1beta1.ModelRevisionStates{TargetModelState: "Pending"},
@@ -2061,7 +2090,8 @@ var _ = Describe("v1beta1 inference service controller", func() {
 				if err := k8sClient.Get(context.TODO(), serviceKey, isvc); err != nil {
 					return err.Error()
 				}
-				return cmp.Diff(&expectedIsvcStatus, &isvc.Status, cmpopts.IgnoreTypes(apis.VolatileTime{}))
+				return cmp.Diff(&expectedIsvcStatus, &isvc.Status, cmpopts.IgnoreTypes(apis.VolatileTime{}),
+					cmpopts.IgnoreFields(v1beta1.EffectiveConfig{}, "ConfigMapResourceVersion"))
 			}, timeout).Should(gomega.BeEmpty())
 
 			//check HPA
@@ -0,0 +1,342 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmarkjob
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("BenchmarkJobReconciler")
+
+// vegetaScript writes the vegeta target from the environment and prints the JSON report of the attack
+const vegetaScript = `printf 'POST %s\nContent-Type: application/json\n@%s\n' "$TARGET_URL" "$PAYLOAD_FILE" > /tmp/targets && ` +
+	`vegeta attack -targets=/tmp/targets -rate="$RPS" -duration="$DURATION" | vegeta report -type=json`
+
+// BenchmarkJobReconciler runs the benchmark Job of the latest predictor revision and records its results in the
+// status of the InferenceService, the readiness is not affected
+type BenchmarkJobReconciler struct {
+	client    client.Client
+	clientset kubernetes.Interface
+	scheme    *runtime.Scheme
+	recorder  record.EventRecorder
+}
+
+func NewBenchmarkJobReconciler(client client.Client, clientset kubernetes.Interface, scheme *runtime.Scheme,
+	recorder record.EventRecorder) *BenchmarkJobReconciler {
+	return &BenchmarkJobReconciler{
+		client:    client,
+		clientset: clientset,
+		scheme:    scheme,
+		recorder:  recorder,
+	}
+}
+
+// Reconcile creates the benchmark Job of the latest predictor revision once the predictor is ready, and records
+// the results of the Job once it completes. Each revision is benchmarked once.
+func (r *BenchmarkJobReconciler) Reconcile(isvc *v1beta1.InferenceService) error {
+	if isvc.Spec.Benchmark == nil {
+		isvc.Status.Benchmark = nil
+		return r.deleteStaleJobs(isvc, "")
+	}
+	predictorStatus := isvc.Status.Components[v1beta1.PredictorComponent]
+	revision := predictorStatus.LatestReadyRevision
+	if revision == "" {
+		revision = predictorStatus.LatestCreatedRevision
+	}
+	if revision == "" || !isvc.Status.IsConditionReady(v1beta1.PredictorReady) {
+		return nil
+	}
+
+	name := constants.BenchmarkJobName(isvc.Name, revision)
+	if status := isvc.Status.Benchmark; status != nil && status.JobName == name && status.State != v1beta1.BenchmarkRunning {
+		return nil
+	}
+	job := &batchv1.Job{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: isvc.Namespace, Name: name}, job)
+	if apierr.IsNotFound(err) {
+		job, err = r.createJob(isvc, name, revision)
+		if err != nil {
+			r.setStatus(isvc, &v1beta1.BenchmarkStatus{
+				Revision: revision,
+				JobName:  name,
+				State:    v1beta1.BenchmarkFailed,
+				Message:  fmt.Sprintf("Failed to create the benchmark job: %v", err),
+			})
+			return err
+		}
+		if err := r.deleteStaleJobs(isvc, name); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	status := &v1beta1.BenchmarkStatus{
+		Revision: revision,
+		JobName:  name,
+		State:    v1beta1.BenchmarkRunning,
+	}
+	switch {
+	case hasJobCondition(job, batchv1.JobComplete):
+		status.CompletionTime = job.Status.CompletionTime
+		results, err := r.getResults(isvc, job)
+		if err != nil {
+			status.State = v1beta1.BenchmarkFailed
+			status.Message = fmt.Sprintf("Failed to read the results of the benchmark: %v", err)
+			break
+		}
+		status.State = v1beta1.BenchmarkSucceeded
+		results.apply(status)
+	case hasJobCondition(job, batchv1.JobFailed):
+		status.State = v1beta1.BenchmarkFailed
+		status.Message = fmt.Sprintf("Benchmark job %s failed", job.Name)
+	}
+	r.setStatus(isvc, status)
+	return nil
+}
+
+// createJob creates the benchmark Job running the load generator configured by the InferenceService
+func (r *BenchmarkJobReconciler) createJob(isvc *v1beta1.InferenceService, name string, revision string) (*batchv1.Job, error) {
+	benchmarkConfig, err := v1beta1.NewBenchmarkConfig(r.clientset)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.clientset.CoreV1().ConfigMaps(isvc.Namespace).Get(context.TODO(),
+		isvc.Spec.Benchmark.PayloadConfigMap, metav1.GetOptions{}); err != nil {
+		return nil, err
+	}
+	job, err := createBenchmarkJob(isvc, benchmarkConfig, name, revision)
+	if err != nil {
+		return nil, err
+	}
+	if err := controllerutil.SetControllerReference(isvc, job, r.scheme); err != nil {
+		return nil, err
+	}
+	log.Info("Creating benchmark job", "namespace", job.Namespace, "name", job.Name, "revision", revision)
+	if err := r.client.Create(context.TODO(), job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+func createBenchmarkJob(isvc *v1beta1.InferenceService, benchmarkConfig *v1beta1.BenchmarkConfig, name string,
+	revision string) (*batchv1.Job, error) {
+	spec := isvc.Spec.Benchmark
+	target, err := getTargetURL(isvc)
+	if err != nil {
+		return nil, err
+	}
+	payloadFile := constants.BenchmarkPayloadMountPath + "/" + constants.BenchmarkPayloadKey
+	duration := strconv.Itoa(int(spec.DurationSeconds)) + "s"
+
+	container := corev1.Container{Name: constants.BenchmarkContainerName}
+	switch spec.Tool {
+	case v1beta1.BenchmarkToolGhz:
+		if benchmarkConfig.GhzImage == "" {
+			return nil, fmt.Errorf("the ghz image is not configured in the %s config", v1beta1.BenchmarkConfigName)
+		}
+		call := spec.Call
+		if call == "" {
+			call = constants.DefaultBenchmarkGRPCCall
+		}
+		host := target.Host
+		if target.Port() == "" {
+			host += ":80"
+			if target.Scheme == "https" {
+				host = target.Host + ":443"
+			}
+		}
+		tlsFlag := "--insecure"
+		if target.Scheme == "https" {
+			tlsFlag = "--skipTLS"
+		}
+		container.Image = benchmarkConfig.GhzImage
+		container.Args = []string{
+			tlsFlag,
+			"--call", call,
+			"--data-file", payloadFile,
+			"--rps", strconv.Itoa(int(spec.RPS)),
+			"--duration", duration,
+			"--format", "json",
+			host,
+		}
+	default:
+		if benchmarkConfig.VegetaImage == "" {
+			return nil, fmt.Errorf("the vegeta image is not configured in the %s config", v1beta1.BenchmarkConfigName)
+		}
+		protocol := constants.ProtocolV1
+		if implementations := isvc.Spec.Predictor.GetImplementations(); len(implementations) != 0 {
+			protocol = implementations[0].GetProtocol()
+		}
+		target.Path = constants.PredictPath(isvc.Name, protocol)
+		container.Image = benchmarkConfig.VegetaImage
+		container.Command = []string{"/bin/sh", "-c", vegetaScript}
+		container.Env = []corev1.EnvVar{
+			{Name: "TARGET_URL", Value: target.String()},
+			{Name: "PAYLOAD_FILE", Value: payloadFile},
+			{Name: "RPS", Value: strconv.Itoa(int(spec.RPS))},
+			{Name: "DURATION", Value: duration},
+		}
+	}
+	container.VolumeMounts = []corev1.VolumeMount{{
+		Name:      constants.BenchmarkPayloadVolumeName,
+		MountPath: constants.BenchmarkPayloadMountPath,
+		ReadOnly:  true,
+	}}
+
+	backoffLimit := int32(0)
+	deadline := int64(spec.DurationSeconds) + constants.BenchmarkJobDeadlineMarginSeconds
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: isvc.Namespace,
+			Labels: map[string]string{
+				constants.InferenceServicePodLabelKey: isvc.Name,
+				constants.BenchmarkJobLabel:           "true",
+			},
+			Annotations: map[string]string{
+				constants.BenchmarkJobRevisionAnnotationKey: revision,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          &backoffLimit,
+			ActiveDeadlineSeconds: &deadline,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						constants.InferenceServicePodLabelKey: isvc.Name,
+						constants.BenchmarkJobLabel:           "true",
+					},
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers:    []corev1.Container{container},
+					Volumes: []corev1.Volume{{
+						Name: constants.BenchmarkPayloadVolumeName,
+						VolumeSource: corev1.VolumeSource{
+							ConfigMap: &corev1.ConfigMapVolumeSource{
+								LocalObjectReference: corev1.LocalObjectReference{Name: spec.PayloadConfigMap},
+							},
+						},
+					}},
+				},
+			},
+		},
+	}, nil
+}
+
+// getTargetURL returns the cluster local address of the InferenceService, or else its URL
+func getTargetURL(isvc *v1beta1.InferenceService) (*url.URL, error) {
+	var target string
+	if isvc.Status.Address != nil && isvc.Status.Address.URL != nil {
+		target = isvc.Status.Address.URL.String()
+	} else if isvc.Status.URL != nil {
+		target = isvc.Status.URL.String()
+	}
+	if target == "" {
+		return nil, fmt.Errorf("the InferenceService has no address yet")
+	}
+	return url.Parse(target)
+}
+
+// getResults parses the report the load generator printed in the logs of the succeeded pod of the Job
+func (r *BenchmarkJobReconciler) getResults(isvc *v1beta1.InferenceService, job *batchv1.Job) (*results, error) {
+	pods, err := r.clientset.CoreV1().Pods(job.Namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: "job-name=" + job.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodSucceeded {
+			continue
+		}
+		logs, err := r.clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+			Container: constants.BenchmarkContainerName,
+		}).DoRaw(context.TODO())
+		if err != nil {
+			return nil, err
+		}
+		return parseReport(isvc.Spec.Benchmark.Tool, logs)
+	}
+	return nil, fmt.Errorf("no succeeded pod found for job %s", job.Name)
+}
+
+// deleteStaleJobs deletes the benchmark Jobs other than the current one
+func (r *BenchmarkJobReconciler) deleteStaleJobs(isvc *v1beta1.InferenceService, current string) error {
+	jobs := &batchv1.JobList{}
+	if err := r.client.List(context.TODO(), jobs, client.InNamespace(isvc.Namespace), client.MatchingLabels{
+		constants.InferenceServicePodLabelKey: isvc.Name,
+		constants.BenchmarkJobLabel:           "true",
+	}); err != nil {
+		return err
+	}
+	for i := range jobs.Items {
+		if jobs.Items[i].Name == current {
+			continue
+		}
+		log.Info("Deleting stale benchmark job", "namespace", isvc.Namespace, "name", jobs.Items[i].Name)
+		if err := r.client.Delete(context.TODO(), &jobs.Items[i],
+			client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setStatus sets the benchmark status and records an event when the benchmark completes
+func (r *BenchmarkJobReconciler) setStatus(isvc *v1beta1.InferenceService, status *v1beta1.BenchmarkStatus) {
+	previous := isvc.Status.Benchmark
+	isvc.Status.Benchmark = status
+	if previous != nil && previous.JobName == status.JobName && previous.State == status.State {
+		return
+	}
+	switch status.State {
+	case v1beta1.BenchmarkSucceeded:
+		r.recorder.Eventf(isvc, corev1.EventTypeNormal, "BenchmarkSucceeded",
+			"Benchmark of revision %s: p50 %s, p95 %s, throughput %s req/s, success ratio %s",
+			status.Revision, status.P50Latency, status.P95Latency, status.Throughput, status.SuccessRatio)
+	case v1beta1.BenchmarkFailed:
+		r.recorder.Event(isvc, corev1.EventTypeWarning, "BenchmarkFailed", status.Message)
+	}
+}
+
+func hasJobCondition(job *batchv1.Job, conditionType batchv1.JobConditionType) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == conditionType && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmarkjob
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestBenchmarkJobReconcile(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(v1beta1.AddToScheme(scheme)).To(gomega.Succeed())
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	clientset := fakeclientset.NewSimpleClientset(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+			Data:       map[string]string{v1beta1.BenchmarkConfigName: `{"vegetaImage": "peterevans/vegeta:6.9.1"}`},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "sklearn-payload", Namespace: "default"},
+			Data:       map[string]string{constants.BenchmarkPayloadKey: `{"instances": [[6.8, 2.8, 4.8, 1.4]]}`},
+		},
+	)
+	recorder := record.NewFakeRecorder(10)
+	reconciler := NewBenchmarkJobReconciler(client, clientset, scheme, recorder)
+
+	url, _ := apis.ParseURL("http://sklearn.default.svc.cluster.local")
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default", UID: "sklearn-uid"},
+		Spec: v1beta1.InferenceServiceSpec{
+			Predictor: v1beta1.PredictorSpec{
+				SKLearn: &v1beta1.SKLearnSpec{PredictorExtensionSpec: v1beta1.PredictorExtensionSpec{}},
+			},
+			Benchmark: &v1beta1.BenchmarkSpec{RPS: 10, DurationSeconds: 30, PayloadConfigMap: "sklearn-payload"},
+		},
+	}
+	isvc.Status.InitializeConditions()
+	isvc.Status.Address = &duckv1.Addressable{URL: url}
+
+	// the job is not created before the predictor is ready
+	g.Expect(reconciler.Reconcile(isvc)).To(gomega.Succeed())
+	g.Expect(isvc.Status.Benchmark).To(gomega.BeNil())
+
+	isvc.Status.Components = map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+		v1beta1.PredictorComponent: {LatestCreatedRevision: "1"},
+	}
+	isvc.Status.SetCondition(v1beta1.PredictorReady, &apis.Condition{Status: corev1.ConditionTrue})
+	g.Expect(reconciler.Reconcile(isvc)).To(gomega.Succeed())
+	g.Expect(isvc.Status.Benchmark.State).To(gomega.Equal(v1beta1.BenchmarkRunning))
+
+	job := &batchv1.Job{}
+	name := constants.BenchmarkJobName("sklearn", "1")
+	g.Expect(client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: "default"}, job)).To(gomega.Succeed())
+	g.Expect(*job.Spec.ActiveDeadlineSeconds).To(gomega.Equal(int64(30) + constants.BenchmarkJobDeadlineMarginSeconds))
+	g.Expect(job.Spec.Template.Spec.RestartPolicy).To(gomega.Equal(corev1.RestartPolicyNever))
+	container := job.Spec.Template.Spec.Containers[0]
+	g.Expect(container.Image).To(gomega.Equal("peterevans/vegeta:6.9.1"))
+	g.Expect(container.Env).To(gomega.ContainElements(
+		corev1.EnvVar{Name: "TARGET_URL", Value: "http://sklearn.default.svc.cluster.local/v1/models/sklearn:predict"},
+		corev1.EnvVar{Name: "RPS", Value: "10"},
+		corev1.EnvVar{Name: "DURATION", Value: "30s"},
+	))
+	g.Expect(job.Spec.Template.Spec.Volumes[0].ConfigMap.Name).To(gomega.Equal("sklearn-payload"))
+	g.Expect(job.OwnerReferences).To(gomega.HaveLen(1))
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}}
+	g.Expect(client.Status().Update(context.TODO(), job)).To(gomega.Succeed())
+	g.Expect(reconciler.Reconcile(isvc)).To(gomega.Succeed())
+	g.Expect(isvc.Status.Benchmark.State).To(gomega.Equal(v1beta1.BenchmarkFailed))
+	g.Expect(recorder.Events).To(gomega.HaveLen(1))
+
+	// a revision is benchmarked once
+	g.Expect(reconciler.Reconcile(isvc)).To(gomega.Succeed())
+	g.Expect(recorder.Events).To(gomega.HaveLen(1))
+
+	// a new revision is benchmarked again and the job of the previous revision is deleted
+	isvc.Status.Components[v1beta1.PredictorComponent] = v1beta1.ComponentStatusSpec{LatestCreatedRevision: "2"}
+	g.Expect(reconciler.Reconcile(isvc)).To(gomega.Succeed())
+	g.Expect(isvc.Status.Benchmark.Revision).To(gomega.Equal("2"))
+	jobs := &batchv1.JobList{}
+	g.Expect(client.List(context.TODO(), jobs)).To(gomega.Succeed())
+	g.Expect(jobs.Items).To(gomega.HaveLen(1))
+	g.Expect(jobs.Items[0].Name).To(gomega.Equal(constants.BenchmarkJobName("sklearn", "2")))
+
+	// removing the benchmark clears the status and the jobs
+	isvc.Spec.Benchmark = nil
+	g.Expect(reconciler.Reconcile(isvc)).To(gomega.Succeed())
+	g.Expect(isvc.Status.Benchmark).To(gomega.BeNil())
+	g.Expect(client.List(context.TODO(), jobs)).To(gomega.Succeed())
+	g.Expect(jobs.Items).To(gomega.BeEmpty())
+}
+
+func TestBenchmarkJobReconcileMissingPayload(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(v1beta1.AddToScheme(scheme)).To(gomega.Succeed())
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	clientset := fakeclientset.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+		Data:       map[string]string{v1beta1.BenchmarkConfigName: `{"vegetaImage": "peterevans/vegeta:6.9.1"}`},
+	})
+	recorder := record.NewFakeRecorder(10)
+	reconciler := NewBenchmarkJobReconciler(client, clientset, scheme, recorder)
+
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default"},
+		Spec: v1beta1.InferenceServiceSpec{
+			Benchmark: &v1beta1.BenchmarkSpec{RPS: 10, DurationSeconds: 30, PayloadConfigMap: "sklearn-payload"},
+		},
+	}
+	isvc.Status.InitializeConditions()
+	isvc.Status.Components = map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+		v1beta1.PredictorComponent: {LatestReadyRevision: "1"},
+	}
+	isvc.Status.SetCondition(v1beta1.PredictorReady, &apis.Condition{Status: corev1.ConditionTrue})
+	g.Expect(reconciler.Reconcile(isvc)).NotTo(gomega.Succeed())
+	g.Expect(isvc.Status.Benchmark.State).To(gomega.Equal(v1beta1.BenchmarkFailed))
+	g.Expect(isvc.Status.Benchmark.Message).To(gomega.ContainSubstring("sklearn-payload"))
+}
+
+func TestCreateGhzBenchmarkJob(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	url, _ := apis.ParseURL("https://sklearn.default.example.com")
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default"},
+		Spec: v1beta1.InferenceServiceSpec{
+			Benchmark: &v1beta1.BenchmarkSpec{
+				Tool:             v1beta1.BenchmarkToolGhz,
+				RPS:              5,
+				DurationSeconds:  60,
+				PayloadConfigMap: "sklearn-payload",
+			},
+		},
+	}
+	isvc.Status.URL = url
+
+	_, err := createBenchmarkJob(isvc, &v1beta1.BenchmarkConfig{VegetaImage: "peterevans/vegeta:6.9.1"}, "job", "1")
+	g.Expect(err).To(gomega.HaveOccurred())
+
+	job, err := createBenchmarkJob(isvc, &v1beta1.BenchmarkConfig{GhzImage: "obvionaoe/ghz:v0.117.0"}, "job", "1")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(job.Spec.Template.Spec.Containers[0].Args).To(gomega.Equal([]string{
+		"--skipTLS",
+		"--call", constants.DefaultBenchmarkGRPCCall,
+		"--data-file", constants.BenchmarkPayloadMountPath + "/" + constants.BenchmarkPayloadKey,
+		"--rps", "5",
+		"--duration", "60s",
+		"--format", "json",
+		"sklearn.default.example.com:443",
+	}))
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmarkjob
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+// results holds the measurements of a benchmark run
+type results struct {
+	p50Latency   time.Duration
+	p95Latency   time.Duration
+	throughput   float64
+	successRatio float64
+}
+
+func (res *results) apply(status *v1beta1.BenchmarkStatus) {
+	status.P50Latency = res.p50Latency.Round(time.Microsecond).String()
+	status.P95Latency = res.p95Latency.Round(time.Microsecond).String()
+	status.Throughput = fmt.Sprintf("%.2f", res.throughput)
+	status.SuccessRatio = fmt.Sprintf("%.3f", res.successRatio)
+}
+
+// vegetaReport is the subset of the JSON report of vegeta used by the reconciler, latencies are in nanoseconds
+type vegetaReport struct {
+	Latencies  map[string]int64 `json:"latencies"`
+	Throughput float64          `json:"throughput"`
+	Success    float64          `json:"success"`
+}
+
+// ghzReport is the subset of the JSON report of ghz used by the reconciler, latencies are in nanoseconds
+type ghzReport struct {
+	Count               int64   `json:"count"`
+	RPS                 float64 `json:"rps"`
+	LatencyDistribution []struct {
+		Percentage int   `json:"percentage"`
+		Latency    int64 `json:"latency"`
+	} `json:"latencyDistribution"`
+	StatusCodeDistribution map[string]int64 `json:"statusCodeDistribution"`
+}
+
+// parseReport parses the JSON report printed by the given tool, ignoring any output around it
+func parseReport(tool v1beta1.BenchmarkTool, logs []byte) (*results, error) {
+	start := bytes.IndexByte(logs, '{')
+	end := bytes.LastIndexByte(logs, '}')
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("no report found in the benchmark output")
+	}
+	report := logs[start : end+1]
+
+	switch tool {
+	case v1beta1.BenchmarkToolGhz:
+		ghz := &ghzReport{}
+		if err := json.Unmarshal(report, ghz); err != nil {
+			return nil, fmt.Errorf("failed to parse the ghz report: %w", err)
+		}
+		res := &results{}
+		for _, latency := range ghz.LatencyDistribution {
+			switch latency.Percentage {
+			case 50:
+				res.p50Latency = time.Duration(latency.Latency)
+			case 95:
+				res.p95Latency = time.Duration(latency.Latency)
+			}
+		}
+		if ghz.Count > 0 {
+			res.successRatio = float64(ghz.StatusCodeDistribution["OK"]) / float64(ghz.Count)
+		}
+		res.throughput = ghz.RPS * res.successRatio
+		return res, nil
+	default:
+		vegeta := &vegetaReport{}
+		if err := json.Unmarshal(report, vegeta); err != nil {
+			return nil, fmt.Errorf("failed to parse the vegeta report: %w", err)
+		}
+		return &results{
+			p50Latency:   time.Duration(vegeta.Latencies["50th"]),
+			p95Latency:   time.Duration(vegeta.Latencies["95th"]),
+			throughput:   vegeta.Throughput,
+			successRatio: vegeta.Success,
+		}, nil
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmarkjob
+
+import (
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/onsi/gomega"
+)
+
+func TestParseReport(t *testing.T) {
+	scenarios := map[string]struct {
+		tool     v1beta1.BenchmarkTool
+		logs     string
+		expected *v1beta1.BenchmarkStatus
+		err      bool
+	}{
+		"vegeta": {
+			tool: v1beta1.BenchmarkToolVegeta,
+			logs: `{"latencies":{"total":2400000000,"mean":8000000,"50th":7512345,"90th":12000000,"95th":15234567,` +
+				`"99th":30000000,"max":40000000,"min":1000000},"rate":10.03,"throughput":9.98,"success":0.9966}`,
+			expected: &v1beta1.BenchmarkStatus{
+				P50Latency:   "7.512ms",
+				P95Latency:   "15.235ms",
+				Throughput:   "9.98",
+				SuccessRatio: "0.997",
+			},
+		},
+		"ghz with surrounding output": {
+			tool: v1beta1.BenchmarkToolGhz,
+			logs: "Summary:\n" + `{"count":100,"rps":10,"latencyDistribution":[{"percentage":50,"latency":2000000},` +
+				`{"percentage":95,"latency":5000000}],"statusCodeDistribution":{"OK":90,"Unavailable":10}}` + "\n",
+			expected: &v1beta1.BenchmarkStatus{
+				P50Latency:   "2ms",
+				P95Latency:   "5ms",
+				Throughput:   "9.00",
+				SuccessRatio: "0.900",
+			},
+		},
+		"no report": {
+			tool: v1beta1.BenchmarkToolVegeta,
+			logs: "fake logs",
+			err:  true,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			res, err := parseReport(scenario.tool, []byte(scenario.logs))
+			if scenario.err {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			status := &v1beta1.BenchmarkStatus{}
+			res.apply(status)
+			g.Expect(status).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cost
+
+import (
+	"context"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("CostReconciler")
+
+// CostReconciler annotates the InferenceServices and InferenceGraphs with their estimated hourly cost when the
+// cost estimation is enabled
+type CostReconciler struct {
+	client    client.Client
+	clientset kubernetes.Interface
+}
+
+func NewCostReconciler(client client.Client, clientset kubernetes.Interface) *CostReconciler {
+	return &CostReconciler{
+		client:    client,
+		clientset: clientset,
+	}
+}
+
+// Reconcile sets the estimated hourly cost annotation of an InferenceService or InferenceGraph, the annotation is
+// removed when the cost estimation is disabled
+func (r *CostReconciler) Reconcile(obj client.Object) error {
+	config, err := v1beta1.NewCostEstimationConfig(r.clientset)
+	if err != nil {
+		return err
+	}
+	desired := ""
+	if config.Enabled {
+		switch o := obj.(type) {
+		case *v1beta1.InferenceService:
+			desired = Format(config, EstimateInferenceService(config, o))
+		case *v1alpha1.InferenceGraph:
+			desired = Format(config, EstimateInferenceGraph(config, o))
+		}
+	}
+	existing, ok := obj.GetAnnotations()[constants.EstimatedHourlyCostAnnotationKey]
+	if existing == desired && ok == (desired != "") {
+		return nil
+	}
+
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	annotations := obj.GetAnnotations()
+	if desired == "" {
+		delete(annotations, constants.EstimatedHourlyCostAnnotationKey)
+	} else {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[constants.EstimatedHourlyCostAnnotationKey] = desired
+	}
+	obj.SetAnnotations(annotations)
+	log.Info("Updating estimated hourly cost", "namespace", obj.GetNamespace(), "name", obj.GetName(), "cost", desired)
+	return r.client.Patch(context.TODO(), obj, patch)
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cost
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCostReconcile(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(v1beta1.AddToScheme(scheme)).To(gomega.Succeed())
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default"},
+		Spec: v1beta1.InferenceServiceSpec{
+			Predictor: v1beta1.PredictorSpec{
+				SKLearn: &v1beta1.SKLearnSpec{
+					PredictorExtensionSpec: v1beta1.PredictorExtensionSpec{
+						Container: corev1.Container{
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(isvc).Build()
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+		Data: map[string]string{
+			v1beta1.CostEstimationConfigName: `{"enabled": true, "currency": "EUR", "cpuCoreHour": 0.05}`,
+		},
+	}
+	clientset := fakeclientset.NewSimpleClientset(configMap)
+	reconciler := NewCostReconciler(client, clientset)
+
+	key := types.NamespacedName{Name: "sklearn", Namespace: "default"}
+	g.Expect(client.Get(context.TODO(), key, isvc)).To(gomega.Succeed())
+	g.Expect(reconciler.Reconcile(isvc)).To(gomega.Succeed())
+	actual := &v1beta1.InferenceService{}
+	g.Expect(client.Get(context.TODO(), key, actual)).To(gomega.Succeed())
+	g.Expect(actual.Annotations).To(gomega.HaveKeyWithValue(constants.EstimatedHourlyCostAnnotationKey, "0.050 EUR"))
+
+	// the annotation is removed once the estimation is disabled
+	configMap.Data[v1beta1.CostEstimationConfigName] = `{"enabled": false}`
+	_, err := clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Update(context.TODO(), configMap, metav1.UpdateOptions{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(reconciler.Reconcile(isvc)).To(gomega.Succeed())
+	g.Expect(client.Get(context.TODO(), key, actual)).To(gomega.Succeed())
+	g.Expect(actual.Annotations).NotTo(gomega.HaveKey(constants.EstimatedHourlyCostAnnotationKey))
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cost
+
+import (
+	"fmt"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const bytesPerGiB = 1 << 30
+
+// EstimateInferenceService returns the hourly cost of the predictor, transformer and explainer of the
+// InferenceService at their minimum number of replicas
+func EstimateInferenceService(config *v1beta1.CostEstimationConfig, isvc *v1beta1.InferenceService) float64 {
+	predictor := &isvc.Spec.Predictor
+	containers := predictor.Containers
+	if container := predictor.GetModelServerContainer(); container != nil {
+		containers = append([]corev1.Container{*container}, containers...)
+	}
+	cost := estimateComponent(config, containers, predictor.MinReplicas)
+	if transformer := isvc.Spec.Transformer; transformer != nil {
+		cost += estimateComponent(config, transformer.Containers, transformer.MinReplicas)
+	}
+	if explainer := isvc.Spec.Explainer; explainer != nil {
+		containers := explainer.Containers
+		if explainer.ART != nil {
+			containers = append([]corev1.Container{explainer.ART.Container}, containers...)
+		}
+		cost += estimateComponent(config, containers, explainer.MinReplicas)
+	}
+	return cost
+}
+
+// EstimateInferenceGraph returns the hourly cost of the router of the InferenceGraph at its minimum number of
+// replicas, the InferenceServices of the steps are estimated on their own
+func EstimateInferenceGraph(config *v1beta1.CostEstimationConfig, graph *v1alpha1.InferenceGraph) float64 {
+	return estimateComponent(config, []corev1.Container{{Resources: graph.Spec.Resources}}, graph.Spec.MinReplicas)
+}
+
+// Format returns the estimated cost as it is shown in the annotation
+func Format(config *v1beta1.CostEstimationConfig, cost float64) string {
+	return fmt.Sprintf("%.3f %s", cost, config.Currency)
+}
+
+// estimateComponent returns the hourly cost of the replicas of a component, the components scaling to zero are
+// estimated with one replica to reflect their cost while they serve
+func estimateComponent(config *v1beta1.CostEstimationConfig, containers []corev1.Container, minReplicas *int) float64 {
+	replicas := 1
+	if minReplicas != nil && *minReplicas > 1 {
+		replicas = *minReplicas
+	}
+	cost := 0.0
+	for _, container := range containers {
+		requests := getRequests(container.Resources)
+		if cpu, ok := requests[corev1.ResourceCPU]; ok {
+			cost += cpu.AsApproximateFloat64() * config.CPUCoreHour
+		}
+		if memory, ok := requests[corev1.ResourceMemory]; ok {
+			cost += memory.AsApproximateFloat64() / bytesPerGiB * config.MemoryGiBHour
+		}
+		for name, price := range config.Accelerators {
+			if quantity, ok := requests[corev1.ResourceName(name)]; ok {
+				cost += quantity.AsApproximateFloat64() * price
+			}
+		}
+	}
+	return cost * float64(replicas)
+}
+
+// getRequests returns the requests of the container, the limits stand in for the requests that are not set as
+// they do when the pod is admitted
+func getRequests(resources corev1.ResourceRequirements) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for name, quantity := range resources.Limits {
+		requests[name] = quantity
+	}
+	for name, quantity := range resources.Requests {
+		requests[name] = quantity
+	}
+	return requests
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cost
+
+import (
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var pricing = &v1beta1.CostEstimationConfig{
+	Enabled:       true,
+	Currency:      "USD",
+	CPUCoreHour:   0.04,
+	MemoryGiBHour: 0.005,
+	Accelerators:  map[string]float64{"nvidia.com/gpu": 2.5},
+}
+
+func TestEstimateInferenceService(t *testing.T) {
+	two := 2
+	zero := 0
+	scenarios := map[string]struct {
+		isvc     *v1beta1.InferenceService
+		expected string
+	}{
+		"model with requests": {
+			isvc: &v1beta1.InferenceService{
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{
+						Model: &v1beta1.ModelSpec{
+							PredictorExtensionSpec: v1beta1.PredictorExtensionSpec{
+								Container: corev1.Container{
+									Resources: corev1.ResourceRequirements{
+										Requests: corev1.ResourceList{
+											corev1.ResourceCPU:    resource.MustParse("2"),
+											corev1.ResourceMemory: resource.MustParse("4Gi"),
+										},
+									},
+								},
+							},
+						},
+						ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{MinReplicas: &two},
+					},
+				},
+			},
+			expected: "0.200 USD",
+		},
+		"limits stand in for requests and scale to zero counts one replica": {
+			isvc: &v1beta1.InferenceService{
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{
+						PodSpec: v1beta1.PodSpec{
+							Containers: []corev1.Container{{
+								Resources: corev1.ResourceRequirements{
+									Limits: corev1.ResourceList{
+										corev1.ResourceCPU:                    resource.MustParse("500m"),
+										corev1.ResourceName("nvidia.com/gpu"): resource.MustParse("1"),
+									},
+								},
+							}},
+						},
+						ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{MinReplicas: &zero},
+					},
+				},
+			},
+			expected: "2.520 USD",
+		},
+		"transformer and explainer": {
+			isvc: &v1beta1.InferenceService{
+				Spec: v1beta1.InferenceServiceSpec{
+					Transformer: &v1beta1.TransformerSpec{
+						PodSpec: v1beta1.PodSpec{
+							Containers: []corev1.Container{{
+								Resources: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+								},
+							}},
+						},
+					},
+					Explainer: &v1beta1.ExplainerSpec{
+						ART: &v1beta1.ARTExplainerSpec{
+							ExplainerExtensionSpec: v1beta1.ExplainerExtensionSpec{
+								Container: corev1.Container{
+									Resources: corev1.ResourceRequirements{
+										Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: "0.050 USD",
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			g.Expect(Format(pricing, EstimateInferenceService(pricing, scenario.isvc))).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
+
+func TestEstimateInferenceGraph(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	three := 3
+	graph := &v1alpha1.InferenceGraph{
+		Spec: v1alpha1.InferenceGraphSpec{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("100m"),
+					corev1.ResourceMemory: resource.MustParse("512Mi"),
+				},
+			},
+			MinReplicas: &three,
+		},
+	}
+	g.Expect(Format(pricing, EstimateInferenceGraph(pricing, graph))).To(gomega.Equal("0.020 USD"))
+}
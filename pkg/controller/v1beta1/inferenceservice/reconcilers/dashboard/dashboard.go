@@ -0,0 +1,237 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dashboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+)
+
+const (
+	panelWidth  = 8
+	panelHeight = 8
+	// maxUIDLength is the maximum length of the uid of a Grafana dashboard
+	maxUIDLength = 40
+)
+
+var quantiles = []struct {
+	value  string
+	legend string
+}{
+	{value: "0.5", legend: "p50"},
+	{value: "0.95", legend: "p95"},
+	{value: "0.99", legend: "p99"},
+}
+
+type dashboard struct {
+	UID           string     `json:"uid"`
+	Title         string     `json:"title"`
+	Tags          []string   `json:"tags"`
+	SchemaVersion int        `json:"schemaVersion"`
+	Refresh       string     `json:"refresh"`
+	Time          timeRange  `json:"time"`
+	Templating    templating `json:"templating"`
+	Panels        []panel    `json:"panels"`
+}
+
+type timeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type templating struct {
+	List []variable `json:"list"`
+}
+
+type variable struct {
+	Name  string `json:"name"`
+	Label string `json:"label"`
+	Type  string `json:"type"`
+	Query string `json:"query"`
+}
+
+type datasource struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+type gridPos struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type fieldConfig struct {
+	Defaults fieldDefaults `json:"defaults"`
+}
+
+type fieldDefaults struct {
+	Unit string `json:"unit"`
+}
+
+type target struct {
+	RefID        string `json:"refId"`
+	Expr         string `json:"expr"`
+	LegendFormat string `json:"legendFormat"`
+}
+
+type panel struct {
+	ID          int          `json:"id"`
+	Type        string       `json:"type"`
+	Title       string       `json:"title"`
+	GridPos     gridPos      `json:"gridPos"`
+	Datasource  *datasource  `json:"datasource,omitempty"`
+	FieldConfig *fieldConfig `json:"fieldConfig,omitempty"`
+	Targets     []target     `json:"targets,omitempty"`
+}
+
+// createDashboard returns the Grafana dashboard showing the standard metrics of the InferenceServices and the
+// InferenceGraphs of the namespace, with one row per resource
+func createDashboard(namespace string, services []string, graphs []string) (string, error) {
+	sort.Strings(services)
+	sort.Strings(graphs)
+	builder := &dashboardBuilder{}
+	for _, name := range services {
+		// the pods of the raw deployments and of the Knative revisions are prefixed by the name of the component
+		selector := fmt.Sprintf(`namespace="%s", pod=~"%s-(predictor|transformer|explainer)-.*"`, namespace, name)
+		builder.addRow("InferenceService "+name, []panel{
+			{
+				Title:       "Request rate",
+				FieldConfig: &fieldConfig{Defaults: fieldDefaults{Unit: "reqps"}},
+				Targets: []target{
+					{Expr: fmt.Sprintf("sum(rate(request_predict_seconds_count{%s}[5m]))", selector), LegendFormat: "predict"},
+					{Expr: fmt.Sprintf("sum(rate(request_explain_seconds_count{%s}[5m]))", selector), LegendFormat: "explain"},
+				},
+			},
+			{
+				Title:       "Predict latency",
+				FieldConfig: &fieldConfig{Defaults: fieldDefaults{Unit: "s"}},
+				Targets:     quantileTargets("request_predict_seconds", selector),
+			},
+			{
+				Title:       "Pre/post-process and explain latency (p95)",
+				FieldConfig: &fieldConfig{Defaults: fieldDefaults{Unit: "s"}},
+				Targets: []target{
+					quantileTarget("0.95", "request_preprocess_seconds", selector, "preprocess"),
+					quantileTarget("0.95", "request_postprocess_seconds", selector, "postprocess"),
+					quantileTarget("0.95", "request_explain_seconds", selector, "explain"),
+				},
+			},
+		})
+	}
+	for _, name := range graphs {
+		// the router pods of the raw deployments and of the Knative revisions
+		selector := fmt.Sprintf(`namespace="%s", pod=~"%s-([0-9]{5}-deployment-)?[a-z0-9]+-[a-z0-9]{5}"`, namespace, name)
+		builder.addRow("InferenceGraph "+name, []panel{
+			{
+				Title:       "Request rate by status code",
+				FieldConfig: &fieldConfig{Defaults: fieldDefaults{Unit: "reqps"}},
+				Targets: []target{
+					{Expr: fmt.Sprintf("sum by (code) (rate(kserve_router_requests_total{%s}[5m]))", selector), LegendFormat: "{{code}}"},
+				},
+			},
+			{
+				Title:       "Request latency",
+				FieldConfig: &fieldConfig{Defaults: fieldDefaults{Unit: "s"}},
+				Targets:     quantileTargets("kserve_router_request_duration_seconds", selector),
+			},
+			{
+				Title:       "Error ratio",
+				FieldConfig: &fieldConfig{Defaults: fieldDefaults{Unit: "percentunit"}},
+				Targets: []target{
+					{
+						Expr: fmt.Sprintf(`sum(rate(kserve_router_requests_total{%s, code=~"5.."}[5m])) / sum(rate(kserve_router_requests_total{%s}[5m]))`,
+							selector, selector),
+						LegendFormat: "5xx",
+					},
+				},
+			},
+		})
+	}
+
+	uid := "kserve-" + namespace
+	if len(uid) > maxUIDLength {
+		hash := fnv.New32a()
+		hash.Write([]byte(namespace))
+		uid = fmt.Sprintf("kserve-%x", hash.Sum32())
+	}
+	content, err := json.MarshalIndent(dashboard{
+		UID:           uid,
+		Title:         "KServe / " + namespace,
+		Tags:          []string{"kserve"},
+		SchemaVersion: 39,
+		Refresh:       "30s",
+		Time:          timeRange{From: "now-1h", To: "now"},
+		Templating: templating{List: []variable{
+			{Name: "datasource", Label: "Data source", Type: "datasource", Query: "prometheus"},
+		}},
+		Panels: builder.panels,
+	}, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("fails to marshal dashboard: %w", err)
+	}
+	return string(content), nil
+}
+
+// dashboardBuilder lays out the rows of the dashboard, each row holds up to three panels side by side
+type dashboardBuilder struct {
+	panels []panel
+	y      int
+}
+
+func (b *dashboardBuilder) nextID() int {
+	return len(b.panels) + 1
+}
+
+func (b *dashboardBuilder) addRow(title string, panels []panel) {
+	b.panels = append(b.panels, panel{
+		ID:      b.nextID(),
+		Type:    "row",
+		Title:   title,
+		GridPos: gridPos{H: 1, W: 24, X: 0, Y: b.y},
+	})
+	b.y++
+	for i, p := range panels {
+		p.ID = b.nextID()
+		p.Type = "timeseries"
+		p.Datasource = &datasource{Type: "prometheus", UID: "${datasource}"}
+		p.GridPos = gridPos{H: panelHeight, W: panelWidth, X: i * panelWidth, Y: b.y}
+		for j := range p.Targets {
+			p.Targets[j].RefID = string(rune('A' + j))
+		}
+		b.panels = append(b.panels, p)
+	}
+	b.y += panelHeight
+}
+
+func quantileTarget(quantile string, histogram string, selector string, legend string) target {
+	return target{
+		Expr:         fmt.Sprintf("histogram_quantile(%s, sum by (le) (rate(%s_bucket{%s}[5m])))", quantile, histogram, selector),
+		LegendFormat: legend,
+	}
+}
+
+func quantileTargets(histogram string, selector string) []target {
+	targets := make([]target, 0, len(quantiles))
+	for _, quantile := range quantiles {
+		targets = append(targets, quantileTarget(quantile.value, histogram, selector, quantile.legend))
+	}
+	return targets
+}
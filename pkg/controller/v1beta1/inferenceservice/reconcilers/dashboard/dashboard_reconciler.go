@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dashboard
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	isvcutils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
+)
+
+var log = logf.Log.WithName("DashboardReconciler")
+
+// FolderAnnotationKey is the annotation the Grafana sidecar reads the folder of a dashboard from
+const FolderAnnotationKey = "grafana_folder"
+
+// DashboardReconciler maintains the Grafana dashboard ConfigMap of a namespace, it shows the standard metrics of all
+// the InferenceServices and InferenceGraphs of the namespace. The ConfigMap is owned by all of them, so it is
+// garbage collected with the last one.
+type DashboardReconciler struct {
+	client    client.Client
+	clientset kubernetes.Interface
+}
+
+func NewDashboardReconciler(client client.Client, clientset kubernetes.Interface) *DashboardReconciler {
+	return &DashboardReconciler{
+		client:    client,
+		clientset: clientset,
+	}
+}
+
+// Reconcile creates or updates the dashboard ConfigMap of the namespace when the dashboards are enabled
+func (r *DashboardReconciler) Reconcile(namespace string) error {
+	dashboardConfig, err := v1beta1.NewDashboardConfig(r.clientset)
+	if err != nil {
+		return fmt.Errorf("fails to create DashboardConfig: %w", err)
+	}
+	if !dashboardConfig.Enabled {
+		return nil
+	}
+
+	services := &v1beta1.InferenceServiceList{}
+	if err := r.client.List(context.TODO(), services, client.InNamespace(namespace)); err != nil {
+		return err
+	}
+	graphs := &v1alpha1.InferenceGraphList{}
+	if err := r.client.List(context.TODO(), graphs, client.InNamespace(namespace)); err != nil {
+		return err
+	}
+	desired, err := createDashboardConfigMap(namespace, dashboardConfig, services.Items, graphs.Items)
+	if err != nil {
+		return err
+	}
+	if desired == nil {
+		// the ConfigMap is garbage collected once its owners are deleted
+		return nil
+	}
+
+	existing, err := r.clientset.CoreV1().ConfigMaps(namespace).Get(context.TODO(), desired.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierr.IsNotFound(err) {
+			log.Info("Creating dashboard configmap", "namespace", namespace, "name", desired.Name)
+			_, err = r.clientset.CoreV1().ConfigMaps(namespace).Create(context.TODO(), desired, metav1.CreateOptions{})
+			return err
+		}
+		return err
+	}
+	if equality.Semantic.DeepEqual(desired.Data, existing.Data) &&
+		equality.Semantic.DeepEqual(desired.OwnerReferences, existing.OwnerReferences) &&
+		existing.Labels[dashboardConfig.Label] == dashboardConfig.LabelValue &&
+		existing.Annotations[FolderAnnotationKey] == desired.Annotations[FolderAnnotationKey] {
+		return nil
+	}
+	log.Info("Updating dashboard configmap", "namespace", namespace, "name", desired.Name)
+	existing.Labels = desired.Labels
+	existing.Annotations = desired.Annotations
+	existing.OwnerReferences = desired.OwnerReferences
+	existing.Data = desired.Data
+	if _, err := r.clientset.CoreV1().ConfigMaps(namespace).Update(context.TODO(), existing, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("fails to update dashboard configmap: %w", err)
+	}
+	return nil
+}
+
+// createDashboardConfigMap returns the dashboard ConfigMap of the resources which are not being deleted, it is nil
+// when there are none
+func createDashboardConfigMap(namespace string, dashboardConfig *v1beta1.DashboardConfig,
+	services []v1beta1.InferenceService, graphs []v1alpha1.InferenceGraph) (*corev1.ConfigMap, error) {
+	var serviceNames, graphNames []string
+	var owners []metav1.OwnerReference
+	for _, isvc := range services {
+		if isvc.DeletionTimestamp != nil {
+			continue
+		}
+		serviceNames = append(serviceNames, isvc.Name)
+		owners = append(owners, metav1.OwnerReference{
+			APIVersion: v1beta1.SchemeGroupVersion.String(),
+			Kind:       isvcutils.InferenceServiceKind,
+			Name:       isvc.Name,
+			UID:        isvc.UID,
+		})
+	}
+	for _, graph := range graphs {
+		if graph.DeletionTimestamp != nil {
+			continue
+		}
+		graphNames = append(graphNames, graph.Name)
+		owners = append(owners, metav1.OwnerReference{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       isvcutils.InferenceGraphKind,
+			Name:       graph.Name,
+			UID:        graph.UID,
+		})
+	}
+	if len(owners) == 0 {
+		return nil, nil
+	}
+
+	dashboard, err := createDashboard(namespace, serviceNames, graphNames)
+	if err != nil {
+		return nil, err
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            constants.DashboardConfigMapName,
+			Namespace:       namespace,
+			Labels:          map[string]string{dashboardConfig.Label: dashboardConfig.LabelValue},
+			OwnerReferences: owners,
+		},
+		Data: map[string]string{
+			constants.DashboardFileName: dashboard,
+		},
+	}
+	if dashboardConfig.Folder != "" {
+		configMap.Annotations = map[string]string{FolderAnnotationKey: dashboardConfig.Folder}
+	}
+	return configMap, nil
+}
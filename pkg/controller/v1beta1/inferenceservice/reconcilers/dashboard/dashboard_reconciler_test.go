@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestDashboardReconcile(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(v1beta1.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(v1alpha1.AddToScheme(scheme)).To(gomega.Succeed())
+	isvcConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+		Data:       map[string]string{v1beta1.DashboardConfigName: `{"enabled": false}`},
+	}
+	sklearn := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default", UID: "sklearn-uid"}}
+	graph := &v1alpha1.InferenceGraph{ObjectMeta: metav1.ObjectMeta{Name: "graph", Namespace: "default", UID: "graph-uid"}}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(sklearn, graph).Build()
+	clientset := fakeclientset.NewSimpleClientset(isvcConfigMap)
+	reconciler := NewDashboardReconciler(client, clientset)
+
+	// nothing is created while the dashboards are disabled
+	g.Expect(reconciler.Reconcile("default")).To(gomega.Succeed())
+	_, err := clientset.CoreV1().ConfigMaps("default").Get(context.TODO(), constants.DashboardConfigMapName, metav1.GetOptions{})
+	g.Expect(err).Should(gomega.HaveOccurred())
+
+	isvcConfigMap.Data[v1beta1.DashboardConfigName] = `{"enabled": true, "folder": "KServe"}`
+	_, err = clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Update(context.TODO(), isvcConfigMap, metav1.UpdateOptions{})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(reconciler.Reconcile("default")).To(gomega.Succeed())
+	configMap, err := clientset.CoreV1().ConfigMaps("default").Get(context.TODO(), constants.DashboardConfigMapName, metav1.GetOptions{})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(configMap.Labels).To(gomega.HaveKeyWithValue(constants.DefaultDashboardLabel, constants.DefaultDashboardLabelValue))
+	g.Expect(configMap.Annotations).To(gomega.HaveKeyWithValue(FolderAnnotationKey, "KServe"))
+	g.Expect(configMap.OwnerReferences).To(gomega.HaveLen(2))
+
+	var content map[string]interface{}
+	g.Expect(json.Unmarshal([]byte(configMap.Data[constants.DashboardFileName]), &content)).To(gomega.Succeed())
+	g.Expect(content["uid"]).To(gomega.Equal("kserve-default"))
+	var titles []string
+	for _, panel := range content["panels"].([]interface{}) {
+		if panel.(map[string]interface{})["type"] == "row" {
+			titles = append(titles, panel.(map[string]interface{})["title"].(string))
+		}
+	}
+	g.Expect(titles).To(gomega.Equal([]string{"InferenceService sklearn", "InferenceGraph graph"}))
+
+	// a deleted resource is dropped from the dashboard
+	g.Expect(client.Delete(context.TODO(), graph)).To(gomega.Succeed())
+	g.Expect(reconciler.Reconcile("default")).To(gomega.Succeed())
+	configMap, err = clientset.CoreV1().ConfigMaps("default").Get(context.TODO(), constants.DashboardConfigMapName, metav1.GetOptions{})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(configMap.OwnerReferences).To(gomega.Equal([]metav1.OwnerReference{{
+		APIVersion: v1beta1.SchemeGroupVersion.String(),
+		Kind:       "InferenceService",
+		Name:       "sklearn",
+		UID:        "sklearn-uid",
+	}}))
+	g.Expect(configMap.Data[constants.DashboardFileName]).NotTo(gomega.ContainSubstring("InferenceGraph graph"))
+}
+
+func TestCreateDashboardUID(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	dashboard, err := createDashboard("a-very-long-namespace-name-for-the-team-models", []string{"sklearn"}, nil)
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	var content map[string]interface{}
+	g.Expect(json.Unmarshal([]byte(dashboard), &content)).To(gomega.Succeed())
+	g.Expect(len(content["uid"].(string))).To(gomega.BeNumerically("<=", maxUIDLength))
+}
@@ -18,10 +18,14 @@ package deployment
 
 import (
 	"context"
+	"maps"
+	"strconv"
+	"strings"
 
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
@@ -29,7 +33,10 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
 	"knative.dev/pkg/kmp"
+	"knative.dev/pkg/ptr"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -40,26 +47,36 @@ var log = logf.Log.WithName("DeploymentReconciler")
 type DeploymentReconciler struct {
 	client       kclient.Client
 	scheme       *runtime.Scheme
+	recorder     record.EventRecorder
+	driftPolicy  v1beta1.DriftPolicy
 	Deployment   *appsv1.Deployment
 	componentExt *v1beta1.ComponentExtensionSpec
 }
 
 func NewDeploymentReconciler(client kclient.Client,
 	scheme *runtime.Scheme,
+	recorder record.EventRecorder,
+	driftPolicy v1beta1.DriftPolicy,
+	deployConfig *v1beta1.DeployConfig,
+	meshConfig *v1beta1.MeshConfig,
 	componentMeta metav1.ObjectMeta,
 	componentExt *v1beta1.ComponentExtensionSpec,
 	podSpec *corev1.PodSpec) *DeploymentReconciler {
 	return &DeploymentReconciler{
 		client:       client,
 		scheme:       scheme,
-		Deployment:   createRawDeployment(componentMeta, componentExt, podSpec),
+		recorder:     recorder,
+		driftPolicy:  driftPolicy,
+		Deployment:   createRawDeployment(componentMeta, componentExt, podSpec, deployConfig, meshConfig),
 		componentExt: componentExt,
 	}
 }
 
 func createRawDeployment(componentMeta metav1.ObjectMeta,
 	componentExt *v1beta1.ComponentExtensionSpec, //nolint:unparam
-	podSpec *corev1.PodSpec) *appsv1.Deployment {
+	podSpec *corev1.PodSpec,
+	deployConfig *v1beta1.DeployConfig,
+	meshConfig *v1beta1.MeshConfig) *appsv1.Deployment {
 	podMetadata := componentMeta
 	podMetadata.Labels["app"] = constants.GetRawServiceLabel(componentMeta.Name)
 	setDefaultPodSpec(podSpec)
@@ -80,12 +97,93 @@ func createRawDeployment(componentMeta metav1.ObjectMeta,
 	if componentExt.DeploymentStrategy != nil {
 		deployment.Spec.Strategy = *componentExt.DeploymentStrategy
 	}
+	if componentExt.MinReadySeconds != nil {
+		deployment.Spec.MinReadySeconds = *componentExt.MinReadySeconds
+	}
+	setDeploymentOverrides(&deployment.Spec, componentMeta.Annotations, deployConfig)
 	setDefaultDeploymentSpec(&deployment.Spec)
+	setSidecarInjection(&deployment.Spec.Template, meshConfig)
 	return deployment
 }
 
+// setSidecarInjection requests the Istio sidecar injection into the pod template unless it is already set, and
+// excludes the health and metrics ports from the sidecar interception so that they stay reachable from the kubelet
+// and from the scrapers outside the mesh.
+func setSidecarInjection(template *corev1.PodTemplateSpec, meshConfig *v1beta1.MeshConfig) {
+	if meshConfig == nil || !meshConfig.Enabled {
+		return
+	}
+	// the pod template metadata shares its maps with the Deployment and Service metadata
+	template.Labels = maps.Clone(template.Labels)
+	template.Annotations = maps.Clone(template.Annotations)
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	_, hasLabel := template.Labels[constants.IstioSidecarInjectKey]
+	_, hasAnnotation := template.Annotations[constants.IstioSidecarInjectKey]
+	if !hasLabel && !hasAnnotation {
+		// the label is read by the Istio injector and the annotation by the OpenShift Service Mesh one
+		template.Labels[constants.IstioSidecarInjectKey] = "true"
+		template.Annotations[constants.IstioSidecarInjectKey] = "true"
+	}
+
+	excludedPorts := sets.NewInt32(meshConfig.ExcludeInboundPorts...)
+	for _, value := range strings.Split(template.Annotations[constants.IstioExcludeInboundPortsAnnotationKey], ",") {
+		if port, err := strconv.ParseInt(strings.TrimSpace(value), 10, 32); err == nil {
+			excludedPorts.Insert(int32(port))
+		}
+	}
+	for _, key := range []string{constants.PrometheusPortAnnotationKey, constants.KserveContainerPrometheusPortKey} {
+		if port, err := strconv.ParseInt(template.Annotations[key], 10, 32); err == nil {
+			excludedPorts.Insert(int32(port))
+		}
+	}
+	for i := range template.Spec.Containers {
+		excludedPorts.Insert(getHealthPorts(&template.Spec.Containers[i])...)
+	}
+	if excludedPorts.Len() == 0 {
+		return
+	}
+	ports := make([]string, 0, excludedPorts.Len())
+	for _, port := range excludedPorts.List() {
+		ports = append(ports, strconv.Itoa(int(port)))
+	}
+	template.Annotations[constants.IstioExcludeInboundPortsAnnotationKey] = strings.Join(ports, ",")
+}
+
+// getHealthPorts returns the ports of the container probes which are not serving ports of the container, the probes
+// of the serving ports are rewritten by the sidecar injector instead.
+func getHealthPorts(container *corev1.Container) []int32 {
+	servingPorts := sets.NewInt32()
+	for _, port := range container.Ports {
+		servingPorts.Insert(port.ContainerPort)
+	}
+	var healthPorts []int32
+	for _, probe := range []*corev1.Probe{container.LivenessProbe, container.ReadinessProbe, container.StartupProbe} {
+		if probe == nil {
+			continue
+		}
+		var port int32
+		switch {
+		case probe.HTTPGet != nil && probe.HTTPGet.Port.Type == intstr.Int:
+			port = probe.HTTPGet.Port.IntVal
+		case probe.TCPSocket != nil && probe.TCPSocket.Port.Type == intstr.Int:
+			port = probe.TCPSocket.Port.IntVal
+		case probe.GRPC != nil:
+			port = probe.GRPC.Port
+		}
+		if port > 0 && !servingPorts.Has(port) {
+			healthPorts = append(healthPorts, port)
+		}
+	}
+	return healthPorts
+}
+
 // checkDeploymentExist checks if the deployment exists?
 func (r *DeploymentReconciler) checkDeploymentExist(client kclient.Client) (constants.CheckResultType, *appsv1.Deployment, error) {
+	if err := utils.SetDesiredSpecHash(r.Deployment, r.Deployment.Spec); err != nil {
+		return constants.CheckResultUnknown, nil, err
+	}
 	// get deployment
 	existingDeployment := &appsv1.Deployment{}
 	err := client.Get(context.TODO(), types.NamespacedName{
@@ -98,6 +196,10 @@ func (r *DeploymentReconciler) checkDeploymentExist(client kclient.Client) (cons
 		}
 		return constants.CheckResultUnknown, nil, err
 	}
+	// a Deployment left over from a deleted InferenceService of the same name keeps serving under the new one
+	if err := utils.AdoptResource(context.TODO(), client, r.recorder, "Deployment", r.Deployment, existingDeployment); err != nil {
+		return constants.CheckResultUnknown, nil, err
+	}
 	// existed, check equivalence
 	// for HPA scaling, we should ignore Replicas of Deployment
 	ignoreFields := cmpopts.IgnoreFields(appsv1.DeploymentSpec{}, "Replicas")
@@ -107,15 +209,142 @@ func (r *DeploymentReconciler) checkDeploymentExist(client kclient.Client) (cons
 		log.Error(err, "Failed to perform dry-run update of deployment", "Deployment", r.Deployment.Name)
 		return constants.CheckResultUnknown, nil, err
 	}
+	// Keep the fields mutated by admission plugins which do not run on dry-run requests, e.g. SCC UID assignment and
+	// injected sidecars, so that the Deployment is not updated back and forth on every reconcile
+	preserveAdmissionMutations(&r.Deployment.Spec.Template, &existingDeployment.Spec.Template)
 	if diff, err := kmp.SafeDiff(r.Deployment.Spec, existingDeployment.Spec, ignoreFields); err != nil {
 		return constants.CheckResultUnknown, nil, err
-	} else if diff != "" {
+	} else if diff != "" && utils.ShouldUpdateDriftedResource(r.recorder, r.driftPolicy, "Deployment", r.Deployment, existingDeployment, diff) {
 		log.Info("Deployment Updated", "Diff", diff)
 		return constants.CheckResultUpdate, existingDeployment, nil
 	}
 	return constants.CheckResultExisted, existingDeployment, nil
 }
 
+// preserveAdmissionMutations copies onto the desired pod template the fields the cluster admission set on the existing
+// one: the security context fields left unset by the desired template, the known injected containers along with their
+// volumes and the known injected annotations.
+func preserveAdmissionMutations(desired *corev1.PodTemplateSpec, existing *corev1.PodTemplateSpec) {
+	for _, key := range constants.AdmissionInjectedPodAnnotations {
+		value, ok := existing.Annotations[key]
+		if _, set := desired.Annotations[key]; !ok || set {
+			continue
+		}
+		if desired.Annotations == nil {
+			desired.Annotations = map[string]string{}
+		}
+		desired.Annotations[key] = value
+	}
+
+	if existing.Spec.SecurityContext != nil {
+		if desired.Spec.SecurityContext == nil {
+			desired.Spec.SecurityContext = &corev1.PodSecurityContext{}
+		}
+		preservePodSecurityContext(desired.Spec.SecurityContext, existing.Spec.SecurityContext)
+	}
+	preserveContainerSecurityContexts(desired.Spec.Containers, existing.Spec.Containers)
+	preserveContainerSecurityContexts(desired.Spec.InitContainers, existing.Spec.InitContainers)
+
+	injectedVolumes := sets.NewString()
+	desired.Spec.InitContainers = preserveInjectedContainers(desired.Spec.InitContainers, existing.Spec.InitContainers, injectedVolumes)
+	desired.Spec.Containers = preserveInjectedContainers(desired.Spec.Containers, existing.Spec.Containers, injectedVolumes)
+	desiredVolumes := sets.NewString()
+	for _, volume := range desired.Spec.Volumes {
+		desiredVolumes.Insert(volume.Name)
+	}
+	for idx, volume := range existing.Spec.Volumes {
+		if !injectedVolumes.Has(volume.Name) || desiredVolumes.Has(volume.Name) {
+			continue
+		}
+		if idx > len(desired.Spec.Volumes) {
+			idx = len(desired.Spec.Volumes)
+		}
+		desired.Spec.Volumes = append(desired.Spec.Volumes[:idx], append([]corev1.Volume{volume}, desired.Spec.Volumes[idx:]...)...)
+	}
+}
+
+func preservePodSecurityContext(desired *corev1.PodSecurityContext, existing *corev1.PodSecurityContext) {
+	if desired.RunAsUser == nil {
+		desired.RunAsUser = existing.RunAsUser
+	}
+	if desired.RunAsGroup == nil {
+		desired.RunAsGroup = existing.RunAsGroup
+	}
+	if desired.RunAsNonRoot == nil {
+		desired.RunAsNonRoot = existing.RunAsNonRoot
+	}
+	if desired.FSGroup == nil {
+		desired.FSGroup = existing.FSGroup
+	}
+	if desired.SupplementalGroups == nil {
+		desired.SupplementalGroups = existing.SupplementalGroups
+	}
+	if desired.SELinuxOptions == nil {
+		desired.SELinuxOptions = existing.SELinuxOptions
+	}
+	if desired.SeccompProfile == nil {
+		desired.SeccompProfile = existing.SeccompProfile
+	}
+}
+
+func preserveContainerSecurityContexts(desired []corev1.Container, existing []corev1.Container) {
+	for i := range desired {
+		for j := range existing {
+			if desired[i].Name != existing[j].Name || existing[j].SecurityContext == nil {
+				continue
+			}
+			if desired[i].SecurityContext == nil {
+				desired[i].SecurityContext = &corev1.SecurityContext{}
+			}
+			desiredContext, existingContext := desired[i].SecurityContext, existing[j].SecurityContext
+			if desiredContext.RunAsUser == nil {
+				desiredContext.RunAsUser = existingContext.RunAsUser
+			}
+			if desiredContext.RunAsGroup == nil {
+				desiredContext.RunAsGroup = existingContext.RunAsGroup
+			}
+			if desiredContext.RunAsNonRoot == nil {
+				desiredContext.RunAsNonRoot = existingContext.RunAsNonRoot
+			}
+			if desiredContext.AllowPrivilegeEscalation == nil {
+				desiredContext.AllowPrivilegeEscalation = existingContext.AllowPrivilegeEscalation
+			}
+			if desiredContext.Capabilities == nil {
+				desiredContext.Capabilities = existingContext.Capabilities
+			}
+			if desiredContext.SELinuxOptions == nil {
+				desiredContext.SELinuxOptions = existingContext.SELinuxOptions
+			}
+			if desiredContext.SeccompProfile == nil {
+				desiredContext.SeccompProfile = existingContext.SeccompProfile
+			}
+		}
+	}
+}
+
+// preserveInjectedContainers inserts the known injected containers of the existing pod template missing from the
+// desired one at their existing position, and records the volumes they mount
+func preserveInjectedContainers(desired []corev1.Container, existing []corev1.Container, injectedVolumes sets.String) []corev1.Container {
+	injectedNames := sets.NewString(constants.AdmissionInjectedContainerNames...)
+	desiredNames := sets.NewString()
+	for _, container := range desired {
+		desiredNames.Insert(container.Name)
+	}
+	for idx, container := range existing {
+		if desiredNames.Has(container.Name) || !injectedNames.Has(container.Name) {
+			continue
+		}
+		if idx > len(desired) {
+			idx = len(desired)
+		}
+		desired = append(desired[:idx], append([]corev1.Container{container}, desired[idx:]...)...)
+		for _, volumeMount := range container.VolumeMounts {
+			injectedVolumes.Insert(volumeMount.Name)
+		}
+	}
+	return desired
+}
+
 func setDefaultPodSpec(podSpec *corev1.PodSpec) {
 	if podSpec.DNSPolicy == "" {
 		podSpec.DNSPolicy = corev1.DNSClusterFirst
@@ -201,11 +430,37 @@ func setDefaultDeploymentSpec(spec *appsv1.DeploymentSpec) {
 	}
 }
 
+// setDeploymentOverrides sets the revision history limit and progress deadline from the annotations of the
+// component, falling back to the global deploy config.
+func setDeploymentOverrides(spec *appsv1.DeploymentSpec, annotations map[string]string, deployConfig *v1beta1.DeployConfig) {
+	if deployConfig != nil && deployConfig.RevisionHistoryLimit != nil {
+		spec.RevisionHistoryLimit = ptr.Int32(*deployConfig.RevisionHistoryLimit)
+	}
+	if deployConfig != nil && deployConfig.ProgressDeadlineSeconds != nil {
+		spec.ProgressDeadlineSeconds = ptr.Int32(*deployConfig.ProgressDeadlineSeconds)
+	}
+	if value, ok := annotations[constants.RevisionHistoryLimitAnnotationKey]; ok {
+		if revisionHistoryLimit, err := strconv.ParseInt(value, 10, 32); err == nil && revisionHistoryLimit >= 0 {
+			spec.RevisionHistoryLimit = ptr.Int32(int32(revisionHistoryLimit))
+		} else {
+			log.Info("Ignoring invalid annotation", "annotation", constants.RevisionHistoryLimitAnnotationKey, "value", value)
+		}
+	}
+	if value, ok := annotations[constants.ProgressDeadlineSecondsAnnotationKey]; ok {
+		if progressDeadlineSeconds, err := strconv.ParseInt(value, 10, 32); err == nil && progressDeadlineSeconds > 0 {
+			spec.ProgressDeadlineSeconds = ptr.Int32(int32(progressDeadlineSeconds))
+		} else {
+			log.Info("Ignoring invalid annotation", "annotation", constants.ProgressDeadlineSecondsAnnotationKey, "value", value)
+		}
+	}
+}
+
 // Reconcile ...
 func (r *DeploymentReconciler) Reconcile() (*appsv1.Deployment, error) {
 	// Reconcile Deployment
 	checkResult, deployment, err := r.checkDeploymentExist(r.client)
 	if err != nil {
+		utils.ChildResources.RecordChild("Deployment", r.Deployment, checkResult, err)
 		return nil, err
 	}
 	log.Info("deployment reconcile", "checkResult", checkResult, "err", err)
@@ -217,8 +472,10 @@ func (r *DeploymentReconciler) Reconcile() (*appsv1.Deployment, error) {
 	case constants.CheckResultUpdate:
 		opErr = r.client.Update(context.TODO(), r.Deployment)
 	default:
+		utils.ChildResources.RecordChild("Deployment", r.Deployment, checkResult, nil)
 		return deployment, nil
 	}
+	utils.ChildResources.RecordChild("Deployment", r.Deployment, checkResult, opErr)
 
 	if opErr != nil {
 		return nil, opErr
@@ -0,0 +1,261 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployment
+
+import (
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"knative.dev/pkg/ptr"
+)
+
+func TestCreateRawDeploymentRolloutDefaults(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		annotations                     map[string]string
+		deployConfig                    *v1beta1.DeployConfig
+		expectedRevisionHistoryLimit    int32
+		expectedProgressDeadlineSeconds int32
+	}{
+		"defaults": {
+			deployConfig:                    &v1beta1.DeployConfig{},
+			expectedRevisionHistoryLimit:    10,
+			expectedProgressDeadlineSeconds: 600,
+		},
+		"deploy config": {
+			deployConfig: &v1beta1.DeployConfig{
+				RevisionHistoryLimit:    ptr.Int32(3),
+				ProgressDeadlineSeconds: ptr.Int32(1800),
+			},
+			expectedRevisionHistoryLimit:    3,
+			expectedProgressDeadlineSeconds: 1800,
+		},
+		"annotations override deploy config": {
+			annotations: map[string]string{
+				constants.RevisionHistoryLimitAnnotationKey:    "0",
+				constants.ProgressDeadlineSecondsAnnotationKey: "3600",
+			},
+			deployConfig: &v1beta1.DeployConfig{
+				RevisionHistoryLimit:    ptr.Int32(3),
+				ProgressDeadlineSeconds: ptr.Int32(1800),
+			},
+			expectedRevisionHistoryLimit:    0,
+			expectedProgressDeadlineSeconds: 3600,
+		},
+		"invalid annotations are ignored": {
+			annotations: map[string]string{
+				constants.RevisionHistoryLimitAnnotationKey:    "-1",
+				constants.ProgressDeadlineSecondsAnnotationKey: "1h",
+			},
+			deployConfig:                    &v1beta1.DeployConfig{ProgressDeadlineSeconds: ptr.Int32(1800)},
+			expectedRevisionHistoryLimit:    10,
+			expectedProgressDeadlineSeconds: 1800,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			componentMeta := metav1.ObjectMeta{
+				Name:        "sklearn-predictor",
+				Namespace:   "default",
+				Labels:      map[string]string{},
+				Annotations: scenario.annotations,
+			}
+			podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: constants.InferenceServiceContainerName}}}
+			deployment := createRawDeployment(componentMeta, &v1beta1.ComponentExtensionSpec{}, podSpec, scenario.deployConfig, nil)
+			g.Expect(*deployment.Spec.RevisionHistoryLimit).To(gomega.Equal(scenario.expectedRevisionHistoryLimit))
+			g.Expect(*deployment.Spec.ProgressDeadlineSeconds).To(gomega.Equal(scenario.expectedProgressDeadlineSeconds))
+		})
+	}
+}
+
+func TestCreateRawDeploymentMinReadySeconds(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	componentMeta := metav1.ObjectMeta{Name: "sklearn-predictor", Namespace: "default", Labels: map[string]string{}}
+	podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: constants.InferenceServiceContainerName}}}
+
+	deployment := createRawDeployment(componentMeta, &v1beta1.ComponentExtensionSpec{}, podSpec, &v1beta1.DeployConfig{}, nil)
+	g.Expect(deployment.Spec.MinReadySeconds).To(gomega.BeZero())
+
+	componentExt := &v1beta1.ComponentExtensionSpec{MinReadySeconds: ptr.Int32(30)}
+	deployment = createRawDeployment(componentMeta, componentExt, podSpec.DeepCopy(), &v1beta1.DeployConfig{}, nil)
+	g.Expect(deployment.Spec.MinReadySeconds).To(gomega.Equal(int32(30)))
+}
+
+func TestCreateRawDeploymentSidecarInjection(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	newPodSpec := func() *corev1.PodSpec {
+		return &corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  constants.InferenceServiceContainerName,
+					Ports: []corev1.ContainerPort{{Name: "http1", ContainerPort: 8080}},
+					LivenessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt(8081)},
+						},
+					},
+					ReadinessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							HTTPGet: &corev1.HTTPGetAction{Path: "/ready", Port: intstr.FromInt(8080)},
+						},
+					},
+				},
+			},
+		}
+	}
+	scenarios := map[string]struct {
+		annotations         map[string]string
+		meshConfig          *v1beta1.MeshConfig
+		expectedLabels      map[string]string
+		expectedAnnotations map[string]string
+	}{
+		"mesh disabled": {
+			meshConfig:     &v1beta1.MeshConfig{},
+			expectedLabels: map[string]string{"app": "isvc.sklearn-predictor"},
+		},
+		"mesh enabled": {
+			annotations: map[string]string{constants.PrometheusPortAnnotationKey: "8082"},
+			meshConfig:  &v1beta1.MeshConfig{Enabled: true, ExcludeInboundPorts: []int32{15020}},
+			expectedLabels: map[string]string{
+				"app":                           "isvc.sklearn-predictor",
+				constants.IstioSidecarInjectKey: "true",
+			},
+			expectedAnnotations: map[string]string{
+				constants.PrometheusPortAnnotationKey:           "8082",
+				constants.IstioSidecarInjectKey:                 "true",
+				constants.IstioExcludeInboundPortsAnnotationKey: "8081,8082,15020",
+			},
+		},
+		"injection opted out": {
+			annotations: map[string]string{
+				constants.IstioSidecarInjectKey:                 "false",
+				constants.IstioExcludeInboundPortsAnnotationKey: "9000",
+			},
+			meshConfig:     &v1beta1.MeshConfig{Enabled: true},
+			expectedLabels: map[string]string{"app": "isvc.sklearn-predictor"},
+			expectedAnnotations: map[string]string{
+				constants.IstioSidecarInjectKey:                 "false",
+				constants.IstioExcludeInboundPortsAnnotationKey: "8081,9000",
+			},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			componentMeta := metav1.ObjectMeta{
+				Name:        "sklearn-predictor",
+				Namespace:   "default",
+				Labels:      map[string]string{},
+				Annotations: scenario.annotations,
+			}
+			deployment := createRawDeployment(componentMeta, &v1beta1.ComponentExtensionSpec{}, newPodSpec(), &v1beta1.DeployConfig{}, scenario.meshConfig)
+			g.Expect(deployment.Spec.Template.Labels).To(gomega.Equal(scenario.expectedLabels))
+			g.Expect(deployment.Spec.Template.Annotations).To(gomega.BeEquivalentTo(scenario.expectedAnnotations))
+			// the sidecar settings are only set on the pod template
+			g.Expect(deployment.Labels).NotTo(gomega.HaveKey(constants.IstioSidecarInjectKey))
+			g.Expect(deployment.Annotations).To(gomega.Equal(scenario.annotations))
+		})
+	}
+}
+
+func TestPreserveAdmissionMutations(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	newDesired := func() *corev1.PodTemplateSpec {
+		return &corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{"foo": "bar"},
+			},
+			Spec: corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{RunAsNonRoot: ptr.Bool(true)},
+				Containers: []corev1.Container{
+					{
+						Name:  constants.InferenceServiceContainerName,
+						Image: "kserve/sklearnserver:latest",
+					},
+				},
+				Volumes: []corev1.Volume{
+					{Name: "model-cache"},
+				},
+			},
+		}
+	}
+	existing := &corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				"foo":                               "bar",
+				"kubectl.kubernetes.io/restartedAt": "2024-01-01T00:00:00Z",
+				"unknown":                           "value",
+			},
+		},
+		Spec: corev1.PodSpec{
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsNonRoot: ptr.Bool(false),
+				FSGroup:      ptr.Int64(1000680000),
+				SeccompProfile: &corev1.SeccompProfile{
+					Type: corev1.SeccompProfileTypeRuntimeDefault,
+				},
+			},
+			InitContainers: []corev1.Container{
+				{Name: constants.IstioInitContainerName},
+			},
+			Containers: []corev1.Container{
+				{
+					Name:  "istio-proxy",
+					Image: "istio/proxyv2",
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: "istio-envoy", MountPath: "/etc/istio/proxy"},
+					},
+				},
+				{
+					Name:            constants.InferenceServiceContainerName,
+					Image:           "kserve/sklearnserver:latest",
+					SecurityContext: &corev1.SecurityContext{RunAsUser: ptr.Int64(1000680000)},
+				},
+				{
+					Name: "removed-sidecar",
+				},
+			},
+			Volumes: []corev1.Volume{
+				{Name: "model-cache"},
+				{Name: "istio-envoy"},
+			},
+		},
+	}
+
+	desired := newDesired()
+	preserveAdmissionMutations(desired, existing)
+
+	expected := newDesired()
+	expected.Annotations["kubectl.kubernetes.io/restartedAt"] = "2024-01-01T00:00:00Z"
+	expected.Spec.SecurityContext.FSGroup = ptr.Int64(1000680000)
+	expected.Spec.SecurityContext.SeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}
+	expected.Spec.InitContainers = []corev1.Container{{Name: constants.IstioInitContainerName}}
+	expected.Spec.Containers = []corev1.Container{
+		existing.Spec.Containers[0],
+		{
+			Name:            constants.InferenceServiceContainerName,
+			Image:           "kserve/sklearnserver:latest",
+			SecurityContext: &corev1.SecurityContext{RunAsUser: ptr.Int64(1000680000)},
+		},
+	}
+	expected.Spec.Volumes = []corev1.Volume{{Name: "model-cache"}, {Name: "istio-envoy"}}
+	g.Expect(desired).To(gomega.Equal(expected))
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -178,6 +179,7 @@ func (r *HPAReconciler) Reconcile() (*autoscalingv2.HorizontalPodAutoscaler, err
 	checkResult, existingHPA, err := r.checkHPAExist(r.client)
 	log.Info("HorizontalPodAutoscaler reconcile", "checkResult", checkResult, "err", err)
 	if err != nil {
+		utils.ChildResources.RecordChild("HorizontalPodAutoscaler", r.HPA, checkResult, err)
 		return nil, err
 	}
 
@@ -190,8 +192,10 @@ func (r *HPAReconciler) Reconcile() (*autoscalingv2.HorizontalPodAutoscaler, err
 	case constants.CheckResultDelete:
 		opErr = r.client.Delete(context.TODO(), r.HPA)
 	default:
+		utils.ChildResources.RecordChild("HorizontalPodAutoscaler", r.HPA, checkResult, nil)
 		return existingHPA, nil
 	}
+	utils.ChildResources.RecordChild("HorizontalPodAutoscaler", r.HPA, checkResult, opErr)
 
 	if opErr != nil {
 		return nil, opErr
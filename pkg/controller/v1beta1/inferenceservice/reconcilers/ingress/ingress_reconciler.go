@@ -21,10 +21,12 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
 	istiov1beta1 "istio.io/api/networking/v1beta1"
 	istioclientv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	corev1 "k8s.io/api/core/v1"
@@ -48,6 +50,7 @@ import (
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/cors"
 	"github.com/kserve/kserve/pkg/utils"
 )
 
@@ -413,6 +416,14 @@ func createIngress(isvc *v1beta1.InferenceService, useDefault bool, config *v1be
 		}
 		httpRoutes = append(httpRoutes, &explainerRouter)
 	}
+	// Add the routes of the pinned model versions before the predict route they take precedence over
+	backendComponent := v1beta1.PredictorComponent
+	if isvc.Spec.Transformer != nil {
+		backendComponent = v1beta1.TransformerComponent
+	}
+	httpRoutes = append(httpRoutes, createPinnedVersionRoutes(isvc, backendComponent,
+		createHTTPMatchRequest("", serviceHost, network.GetServiceHostname(isvc.Name, isvc.Namespace), additionalHosts,
+			isInternal, config), config)...)
 	// Add predict route
 	httpRoutes = append(httpRoutes, &istiov1beta1.HTTPRoute{
 		Match: createHTTPMatchRequest("", serviceHost,
@@ -498,6 +509,13 @@ func createIngress(isvc *v1beta1.InferenceService, useDefault bool, config *v1be
 		// We only append the additional hosts, when the ingress is not internal.
 		hosts = append(hosts, *additionalHosts...)
 	}
+	// Mirror the CORS policy enforced by the agent so that the gateway answers the preflight requests
+	if isvc.Spec.CORS != nil {
+		corsPolicy := createCorsPolicy(isvc.Spec.CORS)
+		for _, httpRoute := range httpRoutes {
+			httpRoute.CorsPolicy = corsPolicy
+		}
+	}
 	annotations := utils.Filter(isvc.Annotations, func(key string) bool {
 		return !utils.Includes(constants.ServiceAnnotationDisallowedList, key)
 	})
@@ -506,7 +524,7 @@ func createIngress(isvc *v1beta1.InferenceService, useDefault bool, config *v1be
 			Name:        isvc.Name,
 			Namespace:   isvc.Namespace,
 			Annotations: annotations,
-			Labels:      isvc.Labels,
+			Labels:      utils.Union(isvc.Labels, constants.InferenceServiceOwnerLabels(isvc.Name)),
 		},
 		Spec: istiov1beta1.VirtualService{
 			Hosts:    hosts,
@@ -517,6 +535,79 @@ func createIngress(isvc *v1beta1.InferenceService, useDefault bool, config *v1be
 	return desiredIngress
 }
 
+// createCorsPolicy converts the CORS policy of the InferenceService to the CORS policy of the VirtualService routes
+func createCorsPolicy(policy *v1beta1.CORSPolicy) *istiov1beta1.CorsPolicy {
+	config := policy.Config()
+	corsPolicy := &istiov1beta1.CorsPolicy{
+		AllowMethods: config.Methods(),
+		AllowHeaders: config.AllowHeaders,
+	}
+	for _, origin := range config.AllowOrigins {
+		if origin == cors.AnyOrigin {
+			corsPolicy.AllowOrigins = append(corsPolicy.AllowOrigins, &istiov1beta1.StringMatch{
+				MatchType: &istiov1beta1.StringMatch_Regex{Regex: ".*"},
+			})
+			continue
+		}
+		corsPolicy.AllowOrigins = append(corsPolicy.AllowOrigins, &istiov1beta1.StringMatch{
+			MatchType: &istiov1beta1.StringMatch_Exact{Exact: origin},
+		})
+	}
+	if config.MaxAge != nil {
+		corsPolicy.MaxAge = durationpb.New(time.Duration(*config.MaxAge) * time.Second)
+	}
+	return corsPolicy
+}
+
+// createPinnedVersionRoutes routes the requests carrying the model version header to the tagged revisions of the
+// component, so that the results of a given revision can be reproduced during a canary rollout. The header matches
+// the traffic tag, e.g. "prev" or "latest", or the name of the revision.
+func createPinnedVersionRoutes(isvc *v1beta1.InferenceService, component v1beta1.ComponentType,
+	matches []*istiov1beta1.HTTPMatchRequest, config *v1beta1.IngressConfig) []*istiov1beta1.HTTPRoute {
+	status, ok := isvc.Status.Components[component]
+	if !ok {
+		return nil
+	}
+	var routes []*istiov1beta1.HTTPRoute
+	for _, target := range status.Traffic {
+		if target.Tag == "" || target.URL == nil {
+			continue
+		}
+		versions := []string{target.Tag}
+		if target.RevisionName != "" {
+			versions = append(versions, target.RevisionName)
+		}
+		var pinnedMatches []*istiov1beta1.HTTPMatchRequest
+		for _, version := range versions {
+			for _, match := range matches {
+				pinnedMatch := match.DeepCopy()
+				pinnedMatch.Headers = map[string]*istiov1beta1.StringMatch{
+					constants.ModelVersionHeader: {
+						MatchType: &istiov1beta1.StringMatch_Exact{Exact: version},
+					},
+				}
+				pinnedMatches = append(pinnedMatches, pinnedMatch)
+			}
+		}
+		// Knative serves the tagged revision on the host named after the tag and the component, e.g. prev-sklearn-predictor
+		taggedName, _, _ := strings.Cut(target.URL.Host, ".")
+		routes = append(routes, &istiov1beta1.HTTPRoute{
+			Match: pinnedMatches,
+			Route: []*istiov1beta1.HTTPRouteDestination{
+				createHTTPRouteDestination(config.LocalGatewayServiceName),
+			},
+			Headers: &istiov1beta1.Headers{
+				Request: &istiov1beta1.Headers_HeaderOperations{
+					Set: map[string]string{
+						"Host": network.GetServiceHostname(taggedName, isvc.Namespace),
+					},
+				},
+			},
+		})
+	}
+	return routes
+}
+
 // getDomainList gets all the available domain names available with Knative Serving.
 func getDomainList(clientset kubernetes.Interface) *[]string {
 	res := new([]string)
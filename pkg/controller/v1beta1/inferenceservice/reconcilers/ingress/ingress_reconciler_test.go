@@ -20,13 +20,16 @@ import (
 	"fmt"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/onsi/gomega"
 	gomegaTypes "github.com/onsi/gomega/types"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
 	istiov1beta1 "istio.io/api/networking/v1beta1"
 	istioclientv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	corev1 "k8s.io/api/core/v1"
@@ -34,6 +37,7 @@ import (
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/network"
+	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
 )
 
 func TestCreateVirtualService(t *testing.T) {
@@ -42,6 +46,11 @@ func TestCreateVirtualService(t *testing.T) {
 	annotations := map[string]string{"test": "test"}
 	isvcAnnotations := map[string]string{"test": "test", "kubectl.kubernetes.io/last-applied-configuration": "test"}
 	labels := map[string]string{"test": "test"}
+	expectedLabels := map[string]string{
+		"test":                                "test",
+		constants.InferenceServicePodLabelKey: serviceName,
+		constants.PartOfLabel:                 serviceName,
+	}
 	domain := "example.com"
 	additionalDomain := "my-additional-domain.com"
 	additionalSecondDomain := "my-second-additional-domain.com"
@@ -129,7 +138,7 @@ func TestCreateVirtualService(t *testing.T) {
 			},
 		},
 		expectedService: &istioclientv1beta1.VirtualService{
-			ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: namespace, Annotations: annotations, Labels: labels},
+			ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: namespace, Annotations: annotations, Labels: expectedLabels},
 			Spec: istiov1beta1.VirtualService{
 				Hosts:    []string{serviceInternalHostName, serviceHostName},
 				Gateways: []string{constants.KnativeLocalGateway, constants.KnativeIngressGateway},
@@ -184,7 +193,7 @@ func TestCreateVirtualService(t *testing.T) {
 			},
 		},
 		expectedService: &istioclientv1beta1.VirtualService{
-			ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: namespace, Annotations: annotations, Labels: labels},
+			ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: namespace, Annotations: annotations, Labels: expectedLabels},
 			Spec: istiov1beta1.VirtualService{
 				Hosts:    []string{serviceInternalHostName},
 				Gateways: []string{constants.KnativeLocalGateway},
@@ -292,7 +301,7 @@ func TestCreateVirtualService(t *testing.T) {
 				},
 			},
 			expectedService: &istioclientv1beta1.VirtualService{
-				ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: namespace, Annotations: annotations, Labels: labels},
+				ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: namespace, Annotations: annotations, Labels: expectedLabels},
 				Spec: istiov1beta1.VirtualService{
 					Hosts:    []string{serviceInternalHostName, serviceHostName},
 					Gateways: []string{constants.KnativeLocalGateway, constants.KnativeIngressGateway},
@@ -364,7 +373,7 @@ func TestCreateVirtualService(t *testing.T) {
 				},
 			},
 			expectedService: &istioclientv1beta1.VirtualService{
-				ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: namespace, Annotations: annotations, Labels: labels},
+				ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: namespace, Annotations: annotations, Labels: expectedLabels},
 				Spec: istiov1beta1.VirtualService{
 					Hosts:    []string{serviceInternalHostName, serviceHostName},
 					Gateways: []string{constants.KnativeLocalGateway, constants.KnativeIngressGateway},
@@ -463,7 +472,7 @@ func TestCreateVirtualService(t *testing.T) {
 				},
 			},
 			expectedService: &istioclientv1beta1.VirtualService{
-				ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: namespace, Annotations: annotations, Labels: labels},
+				ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: namespace, Annotations: annotations, Labels: expectedLabels},
 				Spec: istiov1beta1.VirtualService{
 					Hosts:    []string{serviceInternalHostName, serviceHostName},
 					Gateways: []string{constants.KnativeLocalGateway, constants.KnativeIngressGateway},
@@ -564,7 +573,7 @@ func TestCreateVirtualService(t *testing.T) {
 				},
 			},
 			expectedService: &istioclientv1beta1.VirtualService{
-				ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: namespace, Annotations: annotations, Labels: labels},
+				ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: namespace, Annotations: annotations, Labels: expectedLabels},
 				Spec: istiov1beta1.VirtualService{
 					Hosts:    []string{serviceInternalHostName, serviceHostName, "my-domain.com"},
 					Gateways: []string{constants.KnativeLocalGateway, constants.KnativeIngressGateway},
@@ -685,7 +694,7 @@ func TestCreateVirtualService(t *testing.T) {
 				},
 			},
 			expectedService: &istioclientv1beta1.VirtualService{
-				ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: namespace, Annotations: annotations, Labels: labels},
+				ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: namespace, Annotations: annotations, Labels: expectedLabels},
 				Spec: istiov1beta1.VirtualService{
 					Hosts: []string{serviceInternalHostName, serviceHostName, "my-domain.com",
 						"my-model.test.my-additional-domain.com", "my-model.test.my-second-additional-domain.com"},
@@ -817,7 +826,7 @@ func TestCreateVirtualService(t *testing.T) {
 				},
 			},
 			expectedService: &istioclientv1beta1.VirtualService{
-				ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: namespace, Annotations: annotations, Labels: labels},
+				ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: namespace, Annotations: annotations, Labels: expectedLabels},
 				Spec: istiov1beta1.VirtualService{
 					Hosts:    []string{serviceInternalHostName, serviceHostName},
 					Gateways: []string{constants.KnativeLocalGateway, constants.KnativeIngressGateway},
@@ -888,7 +897,7 @@ func TestCreateVirtualService(t *testing.T) {
 				},
 			},
 			expectedService: &istioclientv1beta1.VirtualService{
-				ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: namespace, Annotations: annotations, Labels: labels},
+				ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: namespace, Annotations: annotations, Labels: expectedLabels},
 				Spec: istiov1beta1.VirtualService{
 					Hosts:    []string{serviceInternalHostName, serviceHostName},
 					Gateways: []string{constants.KnativeLocalGateway, constants.KnativeIngressGateway},
@@ -1085,7 +1094,9 @@ func TestCreateVirtualService(t *testing.T) {
 			},
 			expectedService: &istioclientv1beta1.VirtualService{
 				ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: namespace, Annotations: annotations, Labels: map[string]string{
-					constants.VisibilityLabel: constants.ClusterLocalVisibility,
+					constants.VisibilityLabel:             constants.ClusterLocalVisibility,
+					constants.InferenceServicePodLabelKey: serviceName,
+					constants.PartOfLabel:                 serviceName,
 				}},
 				Spec: istiov1beta1.VirtualService{
 					Hosts:    []string{serviceInternalHostName},
@@ -1157,6 +1168,108 @@ func TestCreateVirtualService(t *testing.T) {
 	}
 }
 
+func TestCreatePinnedVersionRoutes(t *testing.T) {
+	config := &v1beta1.IngressConfig{LocalGatewayServiceName: "knative-local-gateway.istio-system.svc.cluster.local"}
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default"},
+		Status: v1beta1.InferenceServiceStatus{
+			Components: map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+				v1beta1.PredictorComponent: {
+					Traffic: []knservingv1.TrafficTarget{
+						{
+							RevisionName: "sklearn-predictor-00002",
+							Percent:      proto.Int64(10),
+						},
+						{
+							Tag:          "prev",
+							RevisionName: "sklearn-predictor-00001",
+							Percent:      proto.Int64(90),
+							URL:          &apis.URL{Scheme: "http", Host: "prev-sklearn-predictor.default.example.com"},
+						},
+					},
+				},
+			},
+		},
+	}
+	matches := []*istiov1beta1.HTTPMatchRequest{{Gateways: []string{"knative-serving/knative-local-gateway"}}}
+
+	routes := createPinnedVersionRoutes(isvc, v1beta1.PredictorComponent, matches, config)
+	expected := []*istiov1beta1.HTTPRoute{
+		{
+			Match: []*istiov1beta1.HTTPMatchRequest{
+				{
+					Gateways: []string{"knative-serving/knative-local-gateway"},
+					Headers: map[string]*istiov1beta1.StringMatch{
+						constants.ModelVersionHeader: {MatchType: &istiov1beta1.StringMatch_Exact{Exact: "prev"}},
+					},
+				},
+				{
+					Gateways: []string{"knative-serving/knative-local-gateway"},
+					Headers: map[string]*istiov1beta1.StringMatch{
+						constants.ModelVersionHeader: {MatchType: &istiov1beta1.StringMatch_Exact{Exact: "sklearn-predictor-00001"}},
+					},
+				},
+			},
+			Route: []*istiov1beta1.HTTPRouteDestination{createHTTPRouteDestination(config.LocalGatewayServiceName)},
+			Headers: &istiov1beta1.Headers{
+				Request: &istiov1beta1.Headers_HeaderOperations{
+					Set: map[string]string{"Host": network.GetServiceHostname("prev-sklearn-predictor", "default")},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(expected, routes, protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected routes (-want +got): %v", diff)
+	}
+	// the matches of the predict route are left untouched
+	if matches[0].Headers != nil {
+		t.Errorf("the predict route matches were modified: %v", matches[0].Headers)
+	}
+	if routes := createPinnedVersionRoutes(isvc, v1beta1.TransformerComponent, matches, config); len(routes) != 0 {
+		t.Errorf("unexpected routes for a component without status: %v", routes)
+	}
+}
+
+func TestCreateCorsPolicy(t *testing.T) {
+	scenarios := map[string]struct {
+		policy   *v1beta1.CORSPolicy
+		expected *istiov1beta1.CorsPolicy
+	}{
+		"AllowedOrigins": {
+			policy: &v1beta1.CORSPolicy{
+				AllowOrigins: []string{"https://app.example.com"},
+				AllowMethods: []string{"POST"},
+				AllowHeaders: []string{"Content-Type"},
+				MaxAge:       proto.Int32(600),
+			},
+			expected: &istiov1beta1.CorsPolicy{
+				AllowOrigins: []*istiov1beta1.StringMatch{
+					{MatchType: &istiov1beta1.StringMatch_Exact{Exact: "https://app.example.com"}},
+				},
+				AllowMethods: []string{"POST"},
+				AllowHeaders: []string{"Content-Type"},
+				MaxAge:       durationpb.New(10 * time.Minute),
+			},
+		},
+		"AnyOrigin": {
+			policy: &v1beta1.CORSPolicy{AllowOrigins: []string{"*"}},
+			expected: &istiov1beta1.CorsPolicy{
+				AllowOrigins: []*istiov1beta1.StringMatch{
+					{MatchType: &istiov1beta1.StringMatch_Regex{Regex: ".*"}},
+				},
+				AllowMethods: []string{"GET", "HEAD", "POST"},
+			},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(scenario.expected, createCorsPolicy(scenario.policy), protocmp.Transform()); diff != "" {
+				t.Errorf("unexpected cors policy (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
 func TestGetServiceHost(t *testing.T) {
 
 	testCases := []struct {
@@ -21,7 +21,9 @@ import (
 	"fmt"
 
 	v1beta1 "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/capabilities"
 	"github.com/kserve/kserve/pkg/constants"
+	isvcutils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
 	"github.com/kserve/kserve/pkg/utils"
 	corev1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
@@ -30,9 +32,13 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"knative.dev/pkg/apis"
 	knapis "knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmp"
 	"knative.dev/pkg/network"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -41,17 +47,32 @@ import (
 // RawIngressReconciler reconciles the kubernetes ingress
 type RawIngressReconciler struct {
 	client        client.Client
+	clientset     kubernetes.Interface
+	clientConfig  *rest.Config
 	scheme        *runtime.Scheme
+	recorder      record.EventRecorder
 	ingressConfig *v1beta1.IngressConfig
+	driftPolicy   v1beta1.DriftPolicy
 }
 
 func NewRawIngressReconciler(client client.Client,
+	clientset kubernetes.Interface,
+	clientConfig *rest.Config,
 	scheme *runtime.Scheme,
+	recorder record.EventRecorder,
 	ingressConfig *v1beta1.IngressConfig) (*RawIngressReconciler, error) {
+	driftPolicyConfig, err := v1beta1.NewDriftPolicyConfig(clientset)
+	if err != nil {
+		return nil, err
+	}
 	return &RawIngressReconciler{
 		client:        client,
+		clientset:     clientset,
+		clientConfig:  clientConfig,
 		scheme:        scheme,
+		recorder:      recorder,
 		ingressConfig: ingressConfig,
+		driftPolicy:   driftPolicyConfig.Ingress,
 	}, nil
 }
 
@@ -68,7 +89,13 @@ func createRawURL(isvc *v1beta1.InferenceService,
 	return url, nil
 }
 
-func getRawServiceHost(isvc *v1beta1.InferenceService, client client.Client) string {
+func getRawServiceHost(isvc *v1beta1.InferenceService, client client.Client, ingressConfig *v1beta1.IngressConfig) string {
+	return getClusterLocalHost(getRawServiceName(isvc, client), isvc.Namespace, ingressConfig)
+}
+
+// getRawServiceName returns the name of the top level Service of a raw deployment, the transformer Service when the
+// InferenceService has a transformer and the predictor Service otherwise
+func getRawServiceName(isvc *v1beta1.InferenceService, client client.Client) string {
 	existingService := &corev1.Service{}
 	if isvc.Spec.Transformer != nil {
 		transformerName := constants.TransformerServiceName(isvc.Name)
@@ -78,7 +105,7 @@ func getRawServiceHost(isvc *v1beta1.InferenceService, client client.Client) str
 		if err == nil {
 			transformerName = constants.DefaultTransformerServiceName(isvc.Name)
 		}
-		return network.GetServiceHostname(transformerName, isvc.Namespace)
+		return transformerName
 	}
 
 	predictorName := constants.PredictorServiceName(isvc.Name)
@@ -88,10 +115,31 @@ func getRawServiceHost(isvc *v1beta1.InferenceService, client client.Client) str
 	if err == nil {
 		predictorName = constants.DefaultPredictorServiceName(isvc.Name)
 	}
-	return network.GetServiceHostname(predictorName, isvc.Namespace)
+	return predictorName
+}
+
+// getClusterLocalHost returns the cluster local host of a service using the cluster domain and the internal port of
+// the ingress config.
+func getClusterLocalHost(name string, namespace string, ingressConfig *v1beta1.IngressConfig) string {
+	host := network.GetServiceHostname(name, namespace)
+	if ingressConfig.ClusterDomain != "" {
+		host = fmt.Sprintf("%s.%s.svc.%s", name, namespace, ingressConfig.ClusterDomain)
+	}
+	if ingressConfig.InternalPort != 0 {
+		host = fmt.Sprintf("%s:%d", host, ingressConfig.InternalPort)
+	}
+	return host
+}
+
+// getInternalUrlScheme returns the scheme of the cluster local addresses
+func getInternalUrlScheme(ingressConfig *v1beta1.IngressConfig) string {
+	if ingressConfig.InternalUrlScheme != "" {
+		return ingressConfig.InternalUrlScheme
+	}
+	return ingressConfig.UrlScheme
 }
 
-func generateRule(ingressHost string, componentName string, path string, port int32) netv1.IngressRule { //nolint:unparam
+func generateRule(ingressHost string, componentName string, path string, port netv1.ServiceBackendPort) netv1.IngressRule { //nolint:unparam
 	pathType := netv1.PathTypePrefix
 	rule := netv1.IngressRule{
 		Host: ingressHost,
@@ -104,9 +152,7 @@ func generateRule(ingressHost string, componentName string, path string, port in
 						Backend: netv1.IngressBackend{
 							Service: &netv1.IngressServiceBackend{
 								Name: componentName,
-								Port: netv1.ServiceBackendPort{
-									Number: port,
-								},
+								Port: port,
 							},
 						},
 					},
@@ -117,6 +163,19 @@ func generateRule(ingressHost string, componentName string, path string, port in
 	return rule
 }
 
+// backendPort selects the HTTP port of the component Service by name, which is the first port of the Service, so
+// that the ingress keeps routing to it when the Service exposes several ports. The common HTTP port number is used
+// when the Service is not found or its port is unnamed.
+func backendPort(cl client.Client, namespace string, serviceName string) netv1.ServiceBackendPort {
+	service := &corev1.Service{}
+	if err := cl.Get(context.TODO(), types.NamespacedName{Name: serviceName, Namespace: namespace}, service); err == nil {
+		if len(service.Spec.Ports) > 0 && service.Spec.Ports[0].Name != "" {
+			return netv1.ServiceBackendPort{Name: service.Spec.Ports[0].Name}
+		}
+	}
+	return netv1.ServiceBackendPort{Number: constants.CommonDefaultHttpPort}
+}
+
 func generateMetadata(isvc *v1beta1.InferenceService,
 	componentType constants.InferenceServiceComponent, name string) metav1.ObjectMeta {
 	// get annotations from isvc
@@ -126,9 +185,8 @@ func generateMetadata(isvc *v1beta1.InferenceService,
 	objectMeta := metav1.ObjectMeta{
 		Name:      name,
 		Namespace: isvc.Namespace,
-		Labels: utils.Union(isvc.Labels, map[string]string{
-			constants.InferenceServicePodLabelKey: isvc.Name,
-			constants.KServiceComponentLabel:      string(componentType),
+		Labels: utils.Union(isvc.Labels, constants.InferenceServiceOwnerLabels(isvc.Name), map[string]string{
+			constants.KServiceComponentLabel: string(componentType),
 		}),
 		Annotations: annotations,
 	}
@@ -150,7 +208,7 @@ func generateIngressHost(ingressConfig *v1beta1.IngressConfig,
 }
 
 func createRawIngress(scheme *runtime.Scheme, isvc *v1beta1.InferenceService,
-	ingressConfig *v1beta1.IngressConfig, client client.Client) (*netv1.Ingress, error) {
+	ingressConfig *v1beta1.IngressConfig, termination constants.RouteTLSTerminationType, client client.Client) (*netv1.Ingress, error) {
 	if !isvc.Status.IsConditionReady(v1beta1.PredictorReady) {
 		isvc.Status.SetCondition(v1beta1.IngressReady, &apis.Condition{
 			Type:   v1beta1.IngressReady,
@@ -193,11 +251,11 @@ func createRawIngress(scheme *runtime.Scheme, isvc *v1beta1.InferenceService,
 			if err != nil {
 				return nil, fmt.Errorf("failed creating explainer ingress host: %w", err)
 			}
-			rules = append(rules, generateRule(explainerHost, explainerName, "/", constants.CommonDefaultHttpPort))
+			rules = append(rules, generateRule(explainerHost, explainerName, "/", backendPort(client, isvc.Namespace, explainerName)))
 		}
 		// :predict routes to the transformer when there are both predictor and transformer
-		rules = append(rules, generateRule(host, transformerName, "/", constants.CommonDefaultHttpPort))
-		rules = append(rules, generateRule(transformerHost, predictorName, "/", constants.CommonDefaultHttpPort))
+		rules = append(rules, generateRule(host, transformerName, "/", backendPort(client, isvc.Namespace, transformerName)))
+		rules = append(rules, generateRule(transformerHost, predictorName, "/", backendPort(client, isvc.Namespace, predictorName)))
 	case isvc.Spec.Explainer != nil:
 		if !isvc.Status.IsConditionReady(v1beta1.ExplainerReady) {
 			isvc.Status.SetCondition(v1beta1.IngressReady, &apis.Condition{
@@ -222,8 +280,8 @@ func createRawIngress(scheme *runtime.Scheme, isvc *v1beta1.InferenceService,
 			return nil, fmt.Errorf("failed creating explainer ingress host: %w", err)
 		}
 		// :predict routes to the predictor when there is only predictor and explainer
-		rules = append(rules, generateRule(host, predictorName, "/", constants.CommonDefaultHttpPort))
-		rules = append(rules, generateRule(explainerHost, explainerName, "/", constants.CommonDefaultHttpPort))
+		rules = append(rules, generateRule(host, predictorName, "/", backendPort(client, isvc.Namespace, predictorName)))
+		rules = append(rules, generateRule(explainerHost, explainerName, "/", backendPort(client, isvc.Namespace, explainerName)))
 	default:
 		err := client.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultPredictorServiceName(isvc.Name), Namespace: isvc.Namespace}, existing)
 		if err == nil {
@@ -233,20 +291,34 @@ func createRawIngress(scheme *runtime.Scheme, isvc *v1beta1.InferenceService,
 		if err != nil {
 			return nil, fmt.Errorf("failed creating top level predictor ingress host: %w", err)
 		}
-		rules = append(rules, generateRule(host, predictorName, "/", constants.CommonDefaultHttpPort))
+		rules = append(rules, generateRule(host, predictorName, "/", backendPort(client, isvc.Namespace, predictorName)))
 	}
 	// add predictor rule
 	predictorHost, err := generateIngressHost(ingressConfig, isvc, string(constants.Predictor), false, predictorName)
 	if err != nil {
 		return nil, fmt.Errorf("failed creating predictor ingress host: %w", err)
 	}
-	rules = append(rules, generateRule(predictorHost, predictorName, "/", constants.CommonDefaultHttpPort))
+	rules = append(rules, generateRule(predictorHost, predictorName, "/", backendPort(client, isvc.Namespace, predictorName)))
+	// the re-encrypt routes can only reach the components serving HTTPS
+	if termination == constants.RouteTLSTerminationReencrypt {
+		if serviceName, ok := backendsServeTLS(rules); !ok {
+			isvc.Status.SetCondition(v1beta1.IngressReady, &apis.Condition{
+				Type:   v1beta1.IngressReady,
+				Status: corev1.ConditionFalse,
+				Reason: BackendNotServingTLSReason,
+				Message: fmt.Sprintf("the re-encrypt route is not created, the first port of service %s is not the %s port",
+					serviceName, constants.HttpsPortName),
+			})
+			return nil, nil
+		}
+	}
 
 	ingress := &netv1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        isvc.ObjectMeta.Name,
 			Namespace:   isvc.ObjectMeta.Namespace,
-			Annotations: isvc.Annotations,
+			Labels:      utils.Union(isvc.Labels, constants.InferenceServiceOwnerLabels(isvc.Name)),
+			Annotations: utils.Union(isvc.Annotations, RouteTLSAnnotations(isvc.Name, termination)),
 		},
 		Spec: netv1.IngressSpec{
 			IngressClassName: ingressConfig.IngressClassName,
@@ -260,7 +332,11 @@ func createRawIngress(scheme *runtime.Scheme, isvc *v1beta1.InferenceService,
 }
 
 func semanticIngressEquals(desired, existing *netv1.Ingress) bool {
-	return equality.Semantic.DeepEqual(desired.Spec, existing.Spec)
+	return equality.Semantic.DeepEqual(desired.Spec, existing.Spec) &&
+		equality.Semantic.DeepEqual(desired.Labels, existing.Labels) &&
+		equality.Semantic.DeepEqual(utils.GetRouteAnnotations(desired.Annotations),
+			utils.GetRouteAnnotations(existing.Annotations)) &&
+		semanticRouteTLSEquals(desired.Annotations, existing.Annotations)
 }
 
 func (r *RawIngressReconciler) Reconcile(isvc *v1beta1.InferenceService) error {
@@ -273,22 +349,46 @@ func (r *RawIngressReconciler) Reconcile(isvc *v1beta1.InferenceService) error {
 	if r.ingressConfig.IngressDomain == constants.ClusterLocalDomain {
 		isInternal = true
 	}
+	ready := &apis.Condition{
+		Type:   v1beta1.IngressReady,
+		Status: corev1.ConditionTrue,
+	}
 	if !isInternal && !r.ingressConfig.DisableIngressCreation {
-		ingress, err := createRawIngress(r.scheme, isvc, r.ingressConfig, r.client)
+		termination, err := r.routeTLSTermination(isvc)
+		if err != nil {
+			return err
+		}
+		if termination == "" && getRouteTLSTermination(isvc, r.ingressConfig) != "" {
+			ready.Reason = RoutesNotAvailableReason
+			ready.Message = fmt.Sprintf("the route TLS termination is not applied, %s %s is not served by the cluster",
+				capabilities.OpenShiftRoute.GroupVersion, capabilities.OpenShiftRoute.Kind)
+		}
+		ingress, err := createRawIngress(r.scheme, isvc, r.ingressConfig, termination, r.client)
 		if ingress == nil {
 			return nil
 		}
 		if err != nil {
 			return err
 		}
+		if termination == constants.RouteTLSTerminationReencrypt {
+			if err := ReconcileDestinationCASecret(context.TODO(), r.clientset, r.scheme, isvc,
+				constants.InferenceServiceOwnerLabels(isvc.Name)); err != nil {
+				return err
+			}
+		}
+		if err := isvcutils.SetDesiredSpecHash(ingress, []interface{}{ingress.Spec, ingress.Annotations}); err != nil {
+			return err
+		}
 		// reconcile ingress
 		existingIngress := &netv1.Ingress{}
 		err = r.client.Get(context.TODO(), types.NamespacedName{
 			Namespace: isvc.Namespace,
 			Name:      isvc.Name,
 		}, existingIngress)
+		checkResult := constants.CheckResultExisted
 		if err != nil {
 			if apierr.IsNotFound(err) {
+				checkResult = constants.CheckResultCreate
 				err = r.client.Create(context.TODO(), ingress)
 				log.Info("creating ingress", "ingressName", isvc.Name, "err", err)
 			} else {
@@ -296,10 +396,18 @@ func (r *RawIngressReconciler) Reconcile(isvc *v1beta1.InferenceService) error {
 			}
 		} else {
 			if !semanticIngressEquals(ingress, existingIngress) {
-				err = r.client.Update(context.TODO(), ingress)
-				log.Info("updating ingress", "ingressName", isvc.Name, "err", err)
+				diff, diffErr := kmp.SafeDiff(ingress.Spec, existingIngress.Spec)
+				if diffErr != nil {
+					return diffErr
+				}
+				if isvcutils.ShouldUpdateDriftedResource(r.recorder, r.driftPolicy, "Ingress", ingress, existingIngress, diff) {
+					checkResult = constants.CheckResultUpdate
+					err = r.client.Update(context.TODO(), ingress)
+					log.Info("updating ingress", "ingressName", isvc.Name, "err", err)
+				}
 			}
 		}
+		isvcutils.ChildResources.RecordChild("Ingress", ingress, checkResult, err)
 		if err != nil {
 			return err
 		}
@@ -310,14 +418,14 @@ func (r *RawIngressReconciler) Reconcile(isvc *v1beta1.InferenceService) error {
 	}
 	isvc.Status.Address = &duckv1.Addressable{
 		URL: &apis.URL{
-			Host:   getRawServiceHost(isvc, r.client),
-			Scheme: r.ingressConfig.UrlScheme,
+			Host:   getRawServiceHost(isvc, r.client, r.ingressConfig),
+			Scheme: getInternalUrlScheme(r.ingressConfig),
 			Path:   "",
 		},
 	}
-	isvc.Status.SetCondition(v1beta1.IngressReady, &apis.Condition{
-		Type:   v1beta1.IngressReady,
-		Status: corev1.ConditionTrue,
-	})
+	if err := r.reconcileServiceAlias(isvc, ready); err != nil {
+		return err
+	}
+	isvc.Status.SetCondition(v1beta1.IngressReady, ready)
 	return nil
 }
@@ -0,0 +1,112 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/network"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+func TestGetClusterLocalHost(t *testing.T) {
+	scenarios := map[string]struct {
+		ingressConfig  *v1beta1.IngressConfig
+		expectedHost   string
+		expectedScheme string
+	}{
+		"Default": {
+			ingressConfig:  &v1beta1.IngressConfig{UrlScheme: "https"},
+			expectedHost:   network.GetServiceHostname("sklearn-predictor", "default"),
+			expectedScheme: "https",
+		},
+		"CustomClusterDomain": {
+			ingressConfig:  &v1beta1.IngressConfig{UrlScheme: "http", ClusterDomain: "corp.internal"},
+			expectedHost:   "sklearn-predictor.default.svc.corp.internal",
+			expectedScheme: "http",
+		},
+		"PlaintextInternalWithPort": {
+			ingressConfig: &v1beta1.IngressConfig{
+				UrlScheme:         "https",
+				ClusterDomain:     "cluster.local",
+				InternalUrlScheme: "http",
+				InternalPort:      8080,
+			},
+			expectedHost:   "sklearn-predictor.default.svc.cluster.local:8080",
+			expectedScheme: "http",
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			host := getClusterLocalHost("sklearn-predictor", "default", scenario.ingressConfig)
+			if diff := cmp.Diff(scenario.expectedHost, host); diff != "" {
+				t.Errorf("Test %q unexpected host (-want +got): %v", name, diff)
+			}
+			scheme := getInternalUrlScheme(scenario.ingressConfig)
+			if diff := cmp.Diff(scenario.expectedScheme, scheme); diff != "" {
+				t.Errorf("Test %q unexpected scheme (-want +got): %v", name, diff)
+			}
+		})
+	}
+}
+
+func TestBackendPort(t *testing.T) {
+	named := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn-predictor", Namespace: "default"},
+		Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{
+			{Name: "http1", Port: 80},
+			{Name: "grpc", Port: 9000},
+		}},
+	}
+	unnamed := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn-transformer", Namespace: "default"},
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: 80}}},
+	}
+	cl := fake.NewClientBuilder().WithObjects(named, unnamed).Build()
+
+	scenarios := map[string]struct {
+		serviceName string
+		expected    netv1.ServiceBackendPort
+	}{
+		"NamedPorts": {
+			serviceName: "sklearn-predictor",
+			expected:    netv1.ServiceBackendPort{Name: "http1"},
+		},
+		"UnnamedPort": {
+			serviceName: "sklearn-transformer",
+			expected:    netv1.ServiceBackendPort{Number: 80},
+		},
+		"ServiceNotFound": {
+			serviceName: "sklearn-explainer",
+			expected:    netv1.ServiceBackendPort{Number: 80},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			port := backendPort(cl, "default", scenario.serviceName)
+			if diff := cmp.Diff(scenario.expected, port); diff != "" {
+				t.Errorf("Test %q unexpected port (-want +got): %v", name, diff)
+			}
+		})
+	}
+}
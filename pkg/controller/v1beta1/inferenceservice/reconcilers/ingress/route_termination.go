@@ -0,0 +1,180 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/capabilities"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+const (
+	// RoutesNotAvailableReason is the reason of the IngressReady condition of an InferenceService whose route TLS
+	// termination is not applied because the cluster does not serve OpenShift routes
+	RoutesNotAvailableReason = "RoutesNotAvailable"
+	// BackendNotServingTLSReason is the reason of the IngressReady condition of an InferenceService whose re-encrypt
+	// route is not created because a component does not serve HTTPS
+	BackendNotServingTLSReason = "BackendNotServingTLS"
+)
+
+// getRouteTLSTermination returns the route TLS termination requested on the InferenceService,
+// falling back to the ingress config default. An empty value means no termination is configured.
+func getRouteTLSTermination(isvc *v1beta1.InferenceService, ingressConfig *v1beta1.IngressConfig) constants.RouteTLSTerminationType {
+	if value, ok := isvc.Annotations[constants.RouteTLSTerminationAnnotationKey]; ok {
+		return constants.RouteTLSTerminationType(value)
+	}
+	return constants.RouteTLSTerminationType(ingressConfig.RouteTLSTermination)
+}
+
+// routeTLSTermination returns the route TLS termination of the InferenceService when the cluster serves OpenShift
+// routes. The termination is skipped on other clusters, so that the ingress is still created without it.
+func (r *RawIngressReconciler) routeTLSTermination(isvc *v1beta1.InferenceService) (constants.RouteTLSTerminationType, error) {
+	termination := getRouteTLSTermination(isvc, r.ingressConfig)
+	if termination == "" {
+		return termination, nil
+	}
+	available, err := utils.IsCrdAvailable(r.clientConfig, capabilities.OpenShiftRoute.GroupVersion,
+		capabilities.OpenShiftRoute.Kind)
+	if err != nil {
+		return "", err
+	}
+	if !available {
+		log.Info("skipping the route TLS termination, OpenShift routes are not available", "namespace", isvc.Namespace,
+			"name", isvc.Name, "termination", termination)
+		return "", nil
+	}
+	return termination, nil
+}
+
+// RouteTLSAnnotations returns the annotations which make the OpenShift ingress-to-route controller generate the routes
+// of the named InferenceService or InferenceGraph with the requested TLS termination.
+func RouteTLSAnnotations(name string, termination constants.RouteTLSTerminationType) map[string]string {
+	switch termination {
+	case constants.RouteTLSTerminationEdge:
+		return map[string]string{
+			constants.OpenshiftRouteTerminationAnnotationKey: string(constants.RouteTLSTerminationEdge),
+		}
+	case constants.RouteTLSTerminationReencrypt:
+		return map[string]string{
+			constants.OpenshiftRouteTerminationAnnotationKey:         string(constants.RouteTLSTerminationReencrypt),
+			constants.OpenshiftRouteDestinationCASecretAnnotationKey: constants.RouteDestinationCASecretName(name),
+		}
+	default:
+		return map[string]string{}
+	}
+}
+
+// backendsServeTLS returns the first backend Service of the rules which does not serve HTTPS, the first port of the
+// Services of the components serving HTTPS is named https
+func backendsServeTLS(rules []netv1.IngressRule) (string, bool) {
+	for _, rule := range rules {
+		for _, path := range rule.HTTP.Paths {
+			if backend := path.Backend.Service; backend.Port.Name != constants.HttpsPortName {
+				return backend.Name, false
+			}
+		}
+	}
+	return "", true
+}
+
+// createDestinationCASecret builds the secret referenced as destination CA by re-encrypt routes from the
+// service-ca bundle which OpenShift publishes in every namespace.
+func createDestinationCASecret(scheme *runtime.Scheme, owner metav1.Object, labels map[string]string,
+	serviceCA *corev1.ConfigMap) (*corev1.Secret, error) {
+	caBundle, ok := serviceCA.Data[constants.OpenshiftServiceCAFileName]
+	if !ok || caBundle == "" {
+		return nil, fmt.Errorf("%s not found in configmap %s/%s", constants.OpenshiftServiceCAFileName,
+			serviceCA.Namespace, serviceCA.Name)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      constants.RouteDestinationCASecretName(owner.GetName()),
+			Namespace: owner.GetNamespace(),
+			Labels:    labels,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			corev1.TLSCertKey: []byte(caBundle),
+		},
+	}
+	if err := controllerutil.SetControllerReference(owner, secret, scheme); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// ReconcileDestinationCASecret keeps the destination CA secret of the re-encrypt routes of the owner in sync with the
+// service-ca bundle. A secret of the same name which is not controlled by the owner is left untouched.
+func ReconcileDestinationCASecret(ctx context.Context, clientset kubernetes.Interface, scheme *runtime.Scheme,
+	owner metav1.Object, labels map[string]string) error {
+	serviceCA, err := clientset.CoreV1().ConfigMaps(owner.GetNamespace()).Get(ctx,
+		constants.OpenshiftServiceCAConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get service-ca bundle for re-encrypt route: %w", err)
+	}
+	desired, err := createDestinationCASecret(scheme, owner, labels, serviceCA)
+	if err != nil {
+		return err
+	}
+
+	secrets := clientset.CoreV1().Secrets(desired.Namespace)
+	existing, err := secrets.Get(ctx, desired.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierr.IsNotFound(err) {
+			log.Info("creating route destination CA secret", "namespace", desired.Namespace, "name", desired.Name)
+			_, err = secrets.Create(ctx, desired, metav1.CreateOptions{})
+		}
+		return err
+	}
+	if !metav1.IsControlledBy(existing, owner) {
+		return fmt.Errorf("route destination CA secret %s/%s exists and is not controlled by %s", existing.Namespace,
+			existing.Name, owner.GetName())
+	}
+	if equality.Semantic.DeepEqual(desired.Data, existing.Data) {
+		return nil
+	}
+	log.Info("updating route destination CA secret", "namespace", desired.Namespace, "name", desired.Name)
+	existing.Data = desired.Data
+	_, err = secrets.Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
+
+// semanticRouteTLSEquals compares the route TLS annotations of two ingresses.
+func semanticRouteTLSEquals(desired, existing map[string]string) bool {
+	for _, key := range []string{
+		constants.OpenshiftRouteTerminationAnnotationKey,
+		constants.OpenshiftRouteDestinationCASecretAnnotationKey,
+	} {
+		if desired[key] != existing[key] {
+			return false
+		}
+	}
+	return true
+}
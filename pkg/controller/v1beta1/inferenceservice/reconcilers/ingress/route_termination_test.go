@@ -0,0 +1,219 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/capabilities"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+func TestRouteTLSAnnotations(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		annotations   map[string]string
+		ingressConfig *v1beta1.IngressConfig
+		expected      map[string]string
+	}{
+		"NoTermination": {
+			annotations:   map[string]string{},
+			ingressConfig: &v1beta1.IngressConfig{},
+			expected:      map[string]string{},
+		},
+		"EdgeFromConfig": {
+			annotations:   map[string]string{},
+			ingressConfig: &v1beta1.IngressConfig{RouteTLSTermination: "edge"},
+			expected: map[string]string{
+				constants.OpenshiftRouteTerminationAnnotationKey: "edge",
+			},
+		},
+		"ReencryptFromAnnotation": {
+			annotations: map[string]string{
+				constants.RouteTLSTerminationAnnotationKey: "reencrypt",
+			},
+			ingressConfig: &v1beta1.IngressConfig{RouteTLSTermination: "edge"},
+			expected: map[string]string{
+				constants.OpenshiftRouteTerminationAnnotationKey:         "reencrypt",
+				constants.OpenshiftRouteDestinationCASecretAnnotationKey: "my-model-route-destination-ca",
+			},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			isvc := &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "my-model",
+					Namespace:   "default",
+					Annotations: scenario.annotations,
+				},
+			}
+			res := RouteTLSAnnotations(isvc.Name, getRouteTLSTermination(isvc, scenario.ingressConfig))
+			g.Expect(res).Should(gomega.Equal(scenario.expected))
+		})
+	}
+}
+
+func TestCreateDestinationCASecret(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(v1beta1.AddToScheme(scheme)).Should(gomega.Succeed())
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-model",
+			Namespace: "default",
+		},
+	}
+
+	secret, err := createDestinationCASecret(scheme, isvc, constants.InferenceServiceOwnerLabels(isvc.Name), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.OpenshiftServiceCAConfigMapName, Namespace: "default"},
+		Data:       map[string]string{constants.OpenshiftServiceCAFileName: "ca-bundle"},
+	})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(secret.Name).Should(gomega.Equal("my-model-route-destination-ca"))
+	g.Expect(secret.Data[corev1.TLSCertKey]).Should(gomega.Equal([]byte("ca-bundle")))
+	g.Expect(secret.OwnerReferences).Should(gomega.HaveLen(1))
+
+	_, err = createDestinationCASecret(scheme, isvc, constants.InferenceServiceOwnerLabels(isvc.Name), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.OpenshiftServiceCAConfigMapName, Namespace: "default"},
+	})
+	g.Expect(err).Should(gomega.HaveOccurred())
+}
+
+func TestReconcileDestinationCASecret(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(v1beta1.AddToScheme(scheme)).Should(gomega.Succeed())
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-model",
+			Namespace: "default",
+			UID:       types.UID("my-model-uid"),
+		},
+	}
+	labels := constants.InferenceServiceOwnerLabels(isvc.Name)
+	serviceCA := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.OpenshiftServiceCAConfigMapName, Namespace: "default"},
+		Data:       map[string]string{constants.OpenshiftServiceCAFileName: "ca-bundle"},
+	}
+	secretName := constants.RouteDestinationCASecretName(isvc.Name)
+
+	t.Run("CreatesSecret", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset(serviceCA.DeepCopy())
+		g.Expect(ReconcileDestinationCASecret(context.TODO(), clientset, scheme, isvc, labels)).Should(gomega.Succeed())
+		secret, err := clientset.CoreV1().Secrets("default").Get(context.TODO(), secretName, metav1.GetOptions{})
+		g.Expect(err).ShouldNot(gomega.HaveOccurred())
+		g.Expect(secret.Data[corev1.TLSCertKey]).Should(gomega.Equal([]byte("ca-bundle")))
+		g.Expect(metav1.IsControlledBy(secret, isvc)).Should(gomega.BeTrue())
+	})
+
+	t.Run("UpdatesControlledSecret", func(t *testing.T) {
+		existing, err := createDestinationCASecret(scheme, isvc, labels, &corev1.ConfigMap{
+			Data: map[string]string{constants.OpenshiftServiceCAFileName: "stale-bundle"},
+		})
+		g.Expect(err).ShouldNot(gomega.HaveOccurred())
+		clientset := fake.NewSimpleClientset(serviceCA.DeepCopy(), existing)
+		g.Expect(ReconcileDestinationCASecret(context.TODO(), clientset, scheme, isvc, labels)).Should(gomega.Succeed())
+		secret, err := clientset.CoreV1().Secrets("default").Get(context.TODO(), secretName, metav1.GetOptions{})
+		g.Expect(err).ShouldNot(gomega.HaveOccurred())
+		g.Expect(secret.Data[corev1.TLSCertKey]).Should(gomega.Equal([]byte("ca-bundle")))
+	})
+
+	t.Run("RejectsSecretOfAnotherOwner", func(t *testing.T) {
+		existing := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: "default"},
+			Data:       map[string][]byte{corev1.TLSCertKey: []byte("other-bundle")},
+		}
+		clientset := fake.NewSimpleClientset(serviceCA.DeepCopy(), existing)
+		err := ReconcileDestinationCASecret(context.TODO(), clientset, scheme, isvc, labels)
+		g.Expect(err).Should(gomega.MatchError(gomega.ContainSubstring("is not controlled by my-model")))
+		secret, err := clientset.CoreV1().Secrets("default").Get(context.TODO(), secretName, metav1.GetOptions{})
+		g.Expect(err).ShouldNot(gomega.HaveOccurred())
+		g.Expect(secret.Data[corev1.TLSCertKey]).Should(gomega.Equal([]byte("other-bundle")))
+	})
+}
+
+func TestBackendsServeTLS(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	rule := func(service, port string) netv1.IngressRule {
+		return netv1.IngressRule{
+			IngressRuleValue: netv1.IngressRuleValue{
+				HTTP: &netv1.HTTPIngressRuleValue{
+					Paths: []netv1.HTTPIngressPath{{
+						Backend: netv1.IngressBackend{
+							Service: &netv1.IngressServiceBackend{
+								Name: service,
+								Port: netv1.ServiceBackendPort{Name: port},
+							},
+						},
+					}},
+				},
+			},
+		}
+	}
+
+	service, ok := backendsServeTLS([]netv1.IngressRule{rule("my-model-predictor", constants.HttpsPortName)})
+	g.Expect(ok).Should(gomega.BeTrue())
+	g.Expect(service).Should(gomega.BeEmpty())
+
+	service, ok = backendsServeTLS([]netv1.IngressRule{
+		rule("my-model-predictor", constants.HttpsPortName),
+		rule("my-model-transformer", "http"),
+	})
+	g.Expect(ok).Should(gomega.BeFalse())
+	g.Expect(service).Should(gomega.Equal("my-model-transformer"))
+}
+
+func TestRouteTLSTermination(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-model",
+			Namespace: "default",
+			Annotations: map[string]string{
+				constants.RouteTLSTerminationAnnotationKey: "reencrypt",
+			},
+		},
+	}
+	r := &RawIngressReconciler{ingressConfig: &v1beta1.IngressConfig{}}
+	defer utils.SetAvailableResourcesForApi(capabilities.OpenShiftRoute.GroupVersion, nil)
+
+	utils.SetAvailableResourcesForApi(capabilities.OpenShiftRoute.GroupVersion, &metav1.APIResourceList{
+		GroupVersion: capabilities.OpenShiftRoute.GroupVersion,
+		APIResources: []metav1.APIResource{{Name: "routes", Kind: capabilities.OpenShiftRoute.Kind, Namespaced: true}},
+	})
+	termination, err := r.routeTLSTermination(isvc)
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(termination).Should(gomega.Equal(constants.RouteTLSTerminationReencrypt))
+
+	// the termination is skipped when the cluster does not serve OpenShift routes
+	utils.SetAvailableResourcesForApi(capabilities.OpenShiftRoute.GroupVersion, nil)
+	termination, err = r.routeTLSTermination(isvc)
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(termination).Should(gomega.BeEmpty())
+}
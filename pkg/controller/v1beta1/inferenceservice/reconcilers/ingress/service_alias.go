@@ -0,0 +1,245 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"fmt"
+
+	v1beta1 "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/network"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// ServiceAliasConflictReason is the reason of the IngressReady condition of an InferenceService requesting a service
+// alias which is held by another InferenceService
+const ServiceAliasConflictReason = "ServiceAliasConflict"
+
+// reconcileServiceAlias creates the alias Service of the InferenceService and deletes its previous aliases. An alias
+// held by another InferenceService is kept by it as long as it requests the alias, the conflict is reported on the
+// ready condition. The alias is handed over once the annotation is removed from the InferenceService holding it, so that
+// moving the alias annotation from one InferenceService to another cuts the clients of the alias over.
+func (r *RawIngressReconciler) reconcileServiceAlias(isvc *v1beta1.InferenceService, ready *apis.Condition) error {
+	alias := isvc.Annotations[constants.ServiceAliasAnnotationKey]
+	if err := r.deleteStaleServiceAliases(isvc, alias); err != nil {
+		return err
+	}
+	if alias == "" {
+		return nil
+	}
+	desired, err := r.createServiceAlias(isvc, alias)
+	if err != nil {
+		return err
+	}
+
+	existing := &corev1.Service{}
+	err = r.client.Get(context.TODO(), types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}, existing)
+	if err != nil {
+		if apierr.IsNotFound(err) {
+			log.Info("creating service alias", "namespace", desired.Namespace, "name", desired.Name, "isvc", isvc.Name)
+			return r.client.Create(context.TODO(), desired)
+		}
+		return err
+	}
+	previous, isAlias := existing.Labels[constants.ServiceAliasLabelKey]
+	if !isAlias {
+		return fmt.Errorf("service %s already exists and is not a service alias", alias)
+	}
+	if previous != isvc.Name {
+		held, err := r.holdsServiceAlias(isvc.Namespace, previous, alias)
+		if err != nil {
+			return err
+		}
+		if held {
+			ready.Reason = ServiceAliasConflictReason
+			ready.Message = fmt.Sprintf("the service alias %s is held by InferenceService %s, it is handed over once "+
+				"the %s annotation is removed from %s", alias, previous, constants.ServiceAliasAnnotationKey, previous)
+			return nil
+		}
+		log.Info("moving service alias", "namespace", desired.Namespace, "name", desired.Name, "from", previous,
+			"to", isvc.Name)
+		r.recorder.Eventf(isvc, corev1.EventTypeNormal, "ServiceAliasMoved", "Moved service alias %s from %s", alias,
+			previous)
+	}
+	return r.updateServiceAlias(desired, existing)
+}
+
+// updateServiceAlias updates the existing alias Service to the desired one
+func (r *RawIngressReconciler) updateServiceAlias(desired, existing *corev1.Service) error {
+	if semanticServiceAliasEquals(desired, existing) {
+		return nil
+	}
+	if desired.Spec.Type != existing.Spec.Type {
+		// the cluster IP of an alias is allocated when it becomes a ClusterIP Service and released when it becomes an
+		// ExternalName Service, the alias is recreated rather than updated
+		if err := r.client.Delete(context.TODO(), existing); err != nil && !apierr.IsNotFound(err) {
+			return err
+		}
+		return r.client.Create(context.TODO(), desired)
+	}
+	log.Info("updating service alias", "namespace", desired.Namespace, "name", desired.Name,
+		"isvc", desired.Labels[constants.ServiceAliasLabelKey])
+	existing.Labels = desired.Labels
+	existing.OwnerReferences = desired.OwnerReferences
+	existing.Spec.ExternalName = desired.Spec.ExternalName
+	existing.Spec.Selector = desired.Spec.Selector
+	existing.Spec.Ports = desired.Spec.Ports
+	return r.client.Update(context.TODO(), existing)
+}
+
+// holdsServiceAlias returns whether the named InferenceService exists and still requests the alias
+func (r *RawIngressReconciler) holdsServiceAlias(namespace string, name string, alias string) (bool, error) {
+	holder := &v1beta1.InferenceService{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, holder); err != nil {
+		if apierr.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return holder.DeletionTimestamp == nil && holder.Annotations[constants.ServiceAliasAnnotationKey] == alias, nil
+}
+
+// serviceAliasClaimant returns the InferenceService the alias is handed over to when the InferenceService holding it
+// no longer requests it, the oldest of the other InferenceServices requesting the alias
+func (r *RawIngressReconciler) serviceAliasClaimant(holder *v1beta1.InferenceService, alias string) (*v1beta1.InferenceService, error) {
+	isvcs := &v1beta1.InferenceServiceList{}
+	if err := r.client.List(context.TODO(), isvcs, client.InNamespace(holder.Namespace)); err != nil {
+		return nil, err
+	}
+	var claimant *v1beta1.InferenceService
+	for i := range isvcs.Items {
+		candidate := &isvcs.Items[i]
+		if candidate.Name == holder.Name || candidate.DeletionTimestamp != nil ||
+			candidate.Annotations[constants.ServiceAliasAnnotationKey] != alias {
+			continue
+		}
+		if claimant == nil || candidate.CreationTimestamp.Before(&claimant.CreationTimestamp) ||
+			(candidate.CreationTimestamp.Equal(&claimant.CreationTimestamp) && candidate.Name < claimant.Name) {
+			claimant = candidate
+		}
+	}
+	return claimant, nil
+}
+
+// deleteStaleServiceAliases deletes the aliases of the InferenceService other than the current one, e.g. after the
+// alias annotation is renamed or removed. A stale alias requested by another InferenceService is handed over to it.
+func (r *RawIngressReconciler) deleteStaleServiceAliases(isvc *v1beta1.InferenceService, alias string) error {
+	aliases := &corev1.ServiceList{}
+	if err := r.client.List(context.TODO(), aliases, client.InNamespace(isvc.Namespace),
+		client.MatchingLabels{constants.ServiceAliasLabelKey: isvc.Name}); err != nil {
+		return err
+	}
+	for i := range aliases.Items {
+		stale := &aliases.Items[i]
+		if stale.Name == alias || !metav1.IsControlledBy(stale, isvc) {
+			continue
+		}
+		claimant, err := r.serviceAliasClaimant(isvc, stale.Name)
+		if err != nil {
+			return err
+		}
+		if claimant != nil {
+			desired, err := r.createServiceAlias(claimant, stale.Name)
+			if err != nil {
+				return err
+			}
+			log.Info("handing over service alias", "namespace", stale.Namespace, "name", stale.Name, "from", isvc.Name,
+				"to", claimant.Name)
+			r.recorder.Eventf(claimant, corev1.EventTypeNormal, "ServiceAliasMoved", "Moved service alias %s from %s",
+				stale.Name, isvc.Name)
+			if err := r.updateServiceAlias(desired, stale); err != nil {
+				return err
+			}
+			continue
+		}
+		log.Info("deleting service alias", "namespace", stale.Namespace, "name", stale.Name, "isvc", isvc.Name)
+		if err := r.client.Delete(context.TODO(), stale); err != nil && !apierr.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// createServiceAlias returns the alias Service of the InferenceService pointing to its top level Service
+func (r *RawIngressReconciler) createServiceAlias(isvc *v1beta1.InferenceService, alias string) (*corev1.Service, error) {
+	target := &corev1.Service{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: isvc.Namespace,
+		Name: getRawServiceName(isvc, r.client)}, target); err != nil {
+		return nil, fmt.Errorf("failed to get the service of alias %s: %w", alias, err)
+	}
+	return createServiceAlias(r.scheme, isvc, alias, target, r.ingressConfig)
+}
+
+// createServiceAlias returns the alias Service pointing to the target Service. An ExternalName alias resolves to the
+// cluster local host of the target, a ClusterIP alias selects the pods of the target with the same ports.
+func createServiceAlias(scheme *runtime.Scheme, isvc *v1beta1.InferenceService, alias string, target *corev1.Service,
+	ingressConfig *v1beta1.IngressConfig) (*corev1.Service, error) {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      alias,
+			Namespace: isvc.Namespace,
+			Labels: map[string]string{
+				constants.ServiceAliasLabelKey: isvc.Name,
+			},
+		},
+	}
+	if corev1.ServiceType(isvc.Annotations[constants.ServiceAliasTypeAnnotationKey]) == corev1.ServiceTypeClusterIP {
+		ports := make([]corev1.ServicePort, len(target.Spec.Ports))
+		for i, port := range target.Spec.Ports {
+			// the node ports of a NodePort or LoadBalancer target are not shared with the alias
+			port.NodePort = 0
+			ports[i] = port
+		}
+		service.Spec = corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeClusterIP,
+			Selector: target.Spec.Selector,
+			Ports:    ports,
+		}
+	} else {
+		host := network.GetServiceHostname(target.Name, target.Namespace)
+		if ingressConfig.ClusterDomain != "" {
+			host = fmt.Sprintf("%s.%s.svc.%s", target.Name, target.Namespace, ingressConfig.ClusterDomain)
+		}
+		service.Spec = corev1.ServiceSpec{
+			Type:         corev1.ServiceTypeExternalName,
+			ExternalName: host,
+		}
+	}
+	if err := controllerutil.SetControllerReference(isvc, service, scheme); err != nil {
+		return nil, err
+	}
+	return service, nil
+}
+
+// semanticServiceAliasEquals compares the target, type and owner of two alias Services
+func semanticServiceAliasEquals(desired, existing *corev1.Service) bool {
+	return desired.Labels[constants.ServiceAliasLabelKey] == existing.Labels[constants.ServiceAliasLabelKey] &&
+		equality.Semantic.DeepEqual(desired.OwnerReferences, existing.OwnerReferences) &&
+		desired.Spec.Type == existing.Spec.Type &&
+		desired.Spec.ExternalName == existing.Spec.ExternalName &&
+		equality.Semantic.DeepEqual(desired.Spec.Selector, existing.Spec.Selector) &&
+		equality.Semantic.DeepEqual(desired.Spec.Ports, existing.Spec.Ports)
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestReconcileServiceAlias(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).Should(gomega.Succeed())
+	g.Expect(v1beta1.AddToScheme(scheme)).Should(gomega.Succeed())
+
+	predictorService := func(isvcName string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: constants.PredictorServiceName(isvcName), Namespace: "default"},
+			Spec: corev1.ServiceSpec{
+				Selector: map[string]string{"app": "isvc." + constants.PredictorServiceName(isvcName)},
+				Ports: []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromInt(8080),
+					Protocol: corev1.ProtocolTCP, NodePort: 30080}},
+				Type: corev1.ServiceTypeNodePort,
+			},
+		}
+	}
+	isvc := func(name string, uid types.UID, created int64, annotations map[string]string) *v1beta1.InferenceService {
+		return &v1beta1.InferenceService{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: uid, Annotations: annotations,
+				CreationTimestamp: metav1.Unix(created, 0)},
+		}
+	}
+	blue := isvc("blue", "blue-uid", 1, map[string]string{constants.ServiceAliasAnnotationKey: "production"})
+	green := isvc("green", "green-uid", 2, map[string]string{
+		constants.ServiceAliasAnnotationKey:     "production",
+		constants.ServiceAliasTypeAnnotationKey: "ClusterIP",
+	})
+	existing := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "taken", Namespace: "default"}}
+	cl := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(predictorService("blue"), predictorService("green"), existing, blue, green).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &RawIngressReconciler{
+		client:        cl,
+		scheme:        scheme,
+		recorder:      recorder,
+		ingressConfig: &v1beta1.IngressConfig{ClusterDomain: "cluster.local"},
+	}
+	getAlias := func() (*corev1.Service, error) {
+		alias := &corev1.Service{}
+		err := cl.Get(context.TODO(), types.NamespacedName{Name: "production", Namespace: "default"}, alias)
+		return alias, err
+	}
+	updateAnnotations := func(isvc *v1beta1.InferenceService, annotations map[string]string) {
+		isvc.Annotations = annotations
+		g.Expect(cl.Update(context.TODO(), isvc)).Should(gomega.Succeed())
+	}
+	reconcile := func(isvc *v1beta1.InferenceService) *apis.Condition {
+		ready := &apis.Condition{Type: v1beta1.IngressReady, Status: corev1.ConditionTrue}
+		g.Expect(r.reconcileServiceAlias(isvc, ready)).Should(gomega.Succeed())
+		return ready
+	}
+
+	// the alias resolves to the predictor of blue
+	g.Expect(reconcile(blue).Reason).Should(gomega.BeEmpty())
+	alias, err := getAlias()
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(alias.Spec.Type).Should(gomega.Equal(corev1.ServiceTypeExternalName))
+	g.Expect(alias.Spec.ExternalName).Should(gomega.Equal("blue-predictor.default.svc.cluster.local"))
+	g.Expect(alias.Labels[constants.ServiceAliasLabelKey]).Should(gomega.Equal("blue"))
+	g.Expect(metav1.IsControlledBy(alias, blue)).Should(gomega.BeTrue())
+
+	// green requests the alias held by blue, blue keeps it and the conflict is reported on green
+	for i := 0; i < 2; i++ {
+		ready := reconcile(green)
+		g.Expect(ready.Status).Should(gomega.Equal(corev1.ConditionTrue))
+		g.Expect(ready.Reason).Should(gomega.Equal(ServiceAliasConflictReason))
+		g.Expect(ready.Message).Should(gomega.ContainSubstring("held by InferenceService blue"))
+		g.Expect(reconcile(blue).Reason).Should(gomega.BeEmpty())
+	}
+	alias, err = getAlias()
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(alias.Labels[constants.ServiceAliasLabelKey]).Should(gomega.Equal("blue"))
+	g.Expect(recorder.Events).Should(gomega.BeEmpty())
+
+	// removing the annotation from blue hands the alias over to green
+	updateAnnotations(blue, nil)
+	reconcile(blue)
+	alias, err = getAlias()
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(alias.Spec.Type).Should(gomega.Equal(corev1.ServiceTypeClusterIP))
+	g.Expect(alias.Spec.Selector).Should(gomega.Equal(map[string]string{"app": "isvc.green-predictor"}))
+	g.Expect(alias.Spec.Ports).Should(gomega.Equal([]corev1.ServicePort{{Name: "http", Port: 80,
+		TargetPort: intstr.FromInt(8080), Protocol: corev1.ProtocolTCP}}))
+	g.Expect(alias.Labels[constants.ServiceAliasLabelKey]).Should(gomega.Equal("green"))
+	g.Expect(metav1.IsControlledBy(alias, green)).Should(gomega.BeTrue())
+	g.Expect(<-recorder.Events).Should(gomega.ContainSubstring("ServiceAliasMoved"))
+	g.Expect(reconcile(green).Reason).Should(gomega.BeEmpty())
+
+	// an alias whose holder no longer requests it is taken over
+	updateAnnotations(blue, map[string]string{constants.ServiceAliasAnnotationKey: "production"})
+	updateAnnotations(green, map[string]string{constants.ServiceAliasAnnotationKey: "staging"})
+	g.Expect(reconcile(blue).Reason).Should(gomega.BeEmpty())
+	alias, err = getAlias()
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(alias.Labels[constants.ServiceAliasLabelKey]).Should(gomega.Equal("blue"))
+	g.Expect(<-recorder.Events).Should(gomega.ContainSubstring("ServiceAliasMoved"))
+
+	// blue deletes its alias once the annotation is removed and no other InferenceService requests it
+	updateAnnotations(blue, nil)
+	reconcile(blue)
+	_, err = getAlias()
+	g.Expect(apierr.IsNotFound(err)).Should(gomega.BeTrue())
+
+	// a Service which is not an alias is not taken over
+	blue.Annotations = map[string]string{constants.ServiceAliasAnnotationKey: "taken"}
+	g.Expect(r.reconcileServiceAlias(blue, &apis.Condition{})).ShouldNot(gomega.Succeed())
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
+	isvcutils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
 	"github.com/kserve/kserve/pkg/utils"
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/proto"
@@ -46,6 +47,16 @@ var managedKsvcAnnotations = map[string]bool{
 	constants.RollOutDurationAnnotationKey: true,
 	// Required for the integration of Openshift Serverless with Openshift Service Mesh
 	constants.KnativeOpenshiftEnablePassthroughKey: true,
+	// Router annotations are copied by Openshift Serverless onto the generated routes
+	constants.RouteTimeoutAnnotationKey:                  true,
+	constants.RouteTimeoutTunnelAnnotationKey:            true,
+	constants.RouteBalanceAnnotationKey:                  true,
+	constants.RouteIPWhitelistAnnotationKey:              true,
+	constants.RouteDisableCookiesAnnotationKey:           true,
+	constants.RouteRateLimitConnectionsAnnotationKey:     true,
+	constants.RouteRateLimitConcurrentTCPAnnotationKey:   true,
+	constants.RouteRateLimitHTTPRequestRateAnnotationKey: true,
+	constants.RouteRateLimitTCPRequestRateAnnotationKey:  true,
 }
 
 type KsvcReconciler struct {
@@ -217,6 +228,7 @@ func (r *KsvcReconciler) Reconcile() (*knservingv1.ServiceStatus, error) {
 	desired := r.Service
 	existing := &knservingv1.Service{}
 
+	checkResult := constants.CheckResultUnknown
 	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
 		log.Info("Updating knative service", "namespace", desired.Namespace, "name", desired.Name)
 		if err := r.client.Get(context.TODO(), types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing); err != nil {
@@ -239,6 +251,10 @@ func (r *KsvcReconciler) Reconcile() (*knservingv1.ServiceStatus, error) {
 			}
 			return err
 		}
+		checkResult = constants.CheckResultUpdate
+		if semanticEquals(desired, existing) {
+			checkResult = constants.CheckResultExisted
+		}
 		if err := reconcileKsvc(desired, existing); err != nil {
 			return err
 		}
@@ -248,10 +264,14 @@ func (r *KsvcReconciler) Reconcile() (*knservingv1.ServiceStatus, error) {
 		// Create service if it does not exist
 		if apierr.IsNotFound(err) {
 			log.Info("Creating knative service", "namespace", desired.Namespace, "name", desired.Name)
-			return &desired.Status, r.client.Create(context.TODO(), desired)
+			err = r.client.Create(context.TODO(), desired)
+			isvcutils.ChildResources.RecordChild("KnativeService", desired, constants.CheckResultCreate, err)
+			return &desired.Status, err
 		}
+		isvcutils.ChildResources.RecordChild("KnativeService", desired, checkResult, err)
 		return &existing.Status, errors.Wrapf(err, "fails to reconcile knative service")
 	}
+	isvcutils.ChildResources.RecordChild("KnativeService", desired, checkResult, nil)
 	return &existing.Status, nil
 }
 
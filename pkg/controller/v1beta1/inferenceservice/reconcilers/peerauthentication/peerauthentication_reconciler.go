@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package peerauthentication
+
+import (
+	"context"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
+	"google.golang.org/protobuf/testing/protocmp"
+	istiosecurityv1beta1 "istio.io/api/security/v1beta1"
+	istiotypev1beta1 "istio.io/api/type/v1beta1"
+	istioclientsecurityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("PeerAuthenticationReconciler")
+
+// PeerAuthenticationReconciler reconciles the Istio PeerAuthentication of a raw deployment
+type PeerAuthenticationReconciler struct {
+	client             client.Client
+	PeerAuthentication *istioclientsecurityv1beta1.PeerAuthentication
+}
+
+func NewPeerAuthenticationReconciler(client client.Client,
+	componentMeta metav1.ObjectMeta,
+	mode string) *PeerAuthenticationReconciler {
+	return &PeerAuthenticationReconciler{
+		client:             client,
+		PeerAuthentication: createPeerAuthentication(componentMeta, mode),
+	}
+}
+
+func createPeerAuthentication(componentMeta metav1.ObjectMeta, mode string) *istioclientsecurityv1beta1.PeerAuthentication {
+	return &istioclientsecurityv1beta1.PeerAuthentication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        componentMeta.Name,
+			Namespace:   componentMeta.Namespace,
+			Labels:      componentMeta.Labels,
+			Annotations: componentMeta.Annotations,
+		},
+		Spec: istiosecurityv1beta1.PeerAuthentication{
+			Selector: &istiotypev1beta1.WorkloadSelector{
+				MatchLabels: map[string]string{
+					"app": constants.GetRawServiceLabel(componentMeta.Name),
+				},
+			},
+			Mtls: &istiosecurityv1beta1.PeerAuthentication_MutualTLS{
+				Mode: istiosecurityv1beta1.PeerAuthentication_MutualTLS_Mode(
+					istiosecurityv1beta1.PeerAuthentication_MutualTLS_Mode_value[mode]),
+			},
+		},
+	}
+}
+
+// checkPeerAuthenticationExist checks if the PeerAuthentication exists?
+func (r *PeerAuthenticationReconciler) checkPeerAuthenticationExist(client client.Client) (constants.CheckResultType, *istioclientsecurityv1beta1.PeerAuthentication, error) {
+	existing := &istioclientsecurityv1beta1.PeerAuthentication{}
+	err := client.Get(context.TODO(), types.NamespacedName{
+		Namespace: r.PeerAuthentication.Namespace,
+		Name:      r.PeerAuthentication.Name,
+	}, existing)
+	if err != nil {
+		if apierr.IsNotFound(err) {
+			return constants.CheckResultCreate, nil, nil
+		}
+		return constants.CheckResultUnknown, nil, err
+	}
+	if cmp.Equal(r.PeerAuthentication.Spec.DeepCopy(), existing.Spec.DeepCopy(), protocmp.Transform()) {
+		return constants.CheckResultExisted, existing, nil
+	}
+	return constants.CheckResultUpdate, existing, nil
+}
+
+// Reconcile ...
+func (r *PeerAuthenticationReconciler) Reconcile() (*istioclientsecurityv1beta1.PeerAuthentication, error) {
+	checkResult, existing, err := r.checkPeerAuthenticationExist(r.client)
+	log.Info("PeerAuthentication reconcile", "checkResult", checkResult, "err", err)
+	if err != nil {
+		utils.ChildResources.RecordChild(constants.IstioPeerAuthenticationKind, r.PeerAuthentication, checkResult, err)
+		return nil, err
+	}
+
+	var opErr error
+	switch checkResult {
+	case constants.CheckResultCreate:
+		opErr = r.client.Create(context.TODO(), r.PeerAuthentication)
+	case constants.CheckResultUpdate:
+		existing.Spec = *r.PeerAuthentication.Spec.DeepCopy()
+		opErr = r.client.Update(context.TODO(), existing)
+	default:
+		utils.ChildResources.RecordChild(constants.IstioPeerAuthenticationKind, r.PeerAuthentication, checkResult, nil)
+		return existing, nil
+	}
+	utils.ChildResources.RecordChild(constants.IstioPeerAuthenticationKind, r.PeerAuthentication, checkResult, opErr)
+
+	if opErr != nil {
+		return nil, opErr
+	}
+	return r.PeerAuthentication, nil
+}
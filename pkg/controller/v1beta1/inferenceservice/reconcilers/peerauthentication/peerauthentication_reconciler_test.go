@@ -0,0 +1,51 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package peerauthentication
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	istiosecurityv1beta1 "istio.io/api/security/v1beta1"
+	istioclientsecurityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPeerAuthenticationReconcile(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(istioclientsecurityv1beta1.AddToScheme(scheme)).To(gomega.Succeed())
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	componentMeta := metav1.ObjectMeta{Name: "sklearn-predictor", Namespace: "default"}
+	key := types.NamespacedName{Name: "sklearn-predictor", Namespace: "default"}
+
+	_, err := NewPeerAuthenticationReconciler(client, componentMeta, "STRICT").Reconcile()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	peerAuthentication := &istioclientsecurityv1beta1.PeerAuthentication{}
+	g.Expect(client.Get(context.TODO(), key, peerAuthentication)).To(gomega.Succeed())
+	g.Expect(peerAuthentication.Spec.GetSelector().GetMatchLabels()).To(gomega.Equal(map[string]string{"app": "isvc.sklearn-predictor"}))
+	g.Expect(peerAuthentication.Spec.GetMtls().GetMode()).To(gomega.Equal(istiosecurityv1beta1.PeerAuthentication_MutualTLS_STRICT))
+
+	_, err = NewPeerAuthenticationReconciler(client, componentMeta, "PERMISSIVE").Reconcile()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(client.Get(context.TODO(), key, peerAuthentication)).To(gomega.Succeed())
+	g.Expect(peerAuthentication.Spec.GetMtls().GetMode()).To(gomega.Equal(istiosecurityv1beta1.PeerAuthentication_MutualTLS_PERMISSIVE))
+}
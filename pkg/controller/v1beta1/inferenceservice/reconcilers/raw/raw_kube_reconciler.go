@@ -24,16 +24,22 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	knapis "knative.dev/pkg/apis"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	autoscaler "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/autoscaler"
 	deployment "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/deployment"
 	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/ingress"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/peerauthentication"
 	service "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/service"
+	"github.com/kserve/kserve/pkg/utils"
 )
 
+var log = logf.Log.WithName("RawKubeReconciler")
+
 // RawKubeReconciler reconciles the Native K8S Resources
 type RawKubeReconciler struct {
 	client     client.Client
@@ -41,13 +47,16 @@ type RawKubeReconciler struct {
 	Deployment *deployment.DeploymentReconciler
 	Service    *service.ServiceReconciler
 	Scaler     *autoscaler.AutoscalerReconciler
-	URL        *knapis.URL
+	// PeerAuthentication is only set when a peer authentication mode is configured for the mesh
+	PeerAuthentication *peerauthentication.PeerAuthenticationReconciler
+	URL                *knapis.URL
 }
 
 // NewRawKubeReconciler creates raw kubernetes resource reconciler.
 func NewRawKubeReconciler(client client.Client,
 	clientset kubernetes.Interface,
 	scheme *runtime.Scheme,
+	recorder record.EventRecorder,
 	componentMeta metav1.ObjectMeta,
 	componentExt *v1beta1.ComponentExtensionSpec,
 	podSpec *corev1.PodSpec) (*RawKubeReconciler, error) {
@@ -61,13 +70,34 @@ func NewRawKubeReconciler(client client.Client,
 		return nil, err
 	}
 
+	driftPolicyConfig, err := v1beta1.NewDriftPolicyConfig(clientset)
+	if err != nil {
+		return nil, err
+	}
+
+	deployConfig, err := v1beta1.NewDeployConfig(clientset)
+	if err != nil {
+		return nil, err
+	}
+
+	meshConfig, err := v1beta1.NewMeshConfig(clientset)
+	if err != nil {
+		return nil, err
+	}
+
+	var peerAuthentication *peerauthentication.PeerAuthenticationReconciler
+	if meshConfig.PeerAuthenticationMode != "" {
+		peerAuthentication = peerauthentication.NewPeerAuthenticationReconciler(client, componentMeta, meshConfig.PeerAuthenticationMode)
+	}
+
 	return &RawKubeReconciler{
-		client:     client,
-		scheme:     scheme,
-		Deployment: deployment.NewDeploymentReconciler(client, scheme, componentMeta, componentExt, podSpec),
-		Service:    service.NewServiceReconciler(client, scheme, componentMeta, componentExt, podSpec),
-		Scaler:     as,
-		URL:        url,
+		client:             client,
+		scheme:             scheme,
+		Deployment:         deployment.NewDeploymentReconciler(client, scheme, recorder, driftPolicyConfig.Deployment, deployConfig, meshConfig, componentMeta, componentExt, podSpec),
+		Service:            service.NewServiceReconciler(client, scheme, recorder, driftPolicyConfig.Service, componentMeta, componentExt, podSpec),
+		Scaler:             as,
+		PeerAuthentication: peerAuthentication,
+		URL:                url,
 	}, nil
 }
 
@@ -76,9 +106,10 @@ func createRawURL(clientset kubernetes.Interface, metadata metav1.ObjectMeta) (*
 	if err != nil {
 		return nil, err
 	}
+	ingressConfig = ingressConfig.ForObject(metadata)
 
 	url := &knapis.URL{}
-	url.Scheme = "http"
+	url.Scheme = ingressConfig.UrlScheme
 	url.Host, err = ingress.GenerateDomainName(metadata.Name, metadata, ingressConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed creating host name: %w", err)
@@ -94,15 +125,28 @@ func (r *RawKubeReconciler) Reconcile() (*appsv1.Deployment, error) {
 	if err != nil {
 		return nil, err
 	}
-	// reconcile Service
-	_, err = r.Service.Reconcile()
+	// reconcile Service, keep the observed status so the load balancer ingress can be propagated
+	service, err := r.Service.Reconcile()
 	if err != nil {
 		return nil, err
 	}
+	r.Service.Service.Status = service.Status
 	// reconcile HPA
 	err = r.Scaler.Reconcile()
 	if err != nil {
 		return nil, err
 	}
+	// reconcile PeerAuthentication, it is owned by the owner of the Deployment. It is skipped when Istio is not
+	// installed, so that the Deployment and the Service are still served.
+	if r.PeerAuthentication != nil {
+		r.PeerAuthentication.PeerAuthentication.OwnerReferences = r.Deployment.Deployment.OwnerReferences
+		if _, err = r.PeerAuthentication.Reconcile(); err != nil {
+			if !utils.IsAPINotAvailable(err) {
+				return nil, err
+			}
+			log.Info("skipping the PeerAuthentication, the Istio security API is not available",
+				"namespace", deployment.Namespace, "name", deployment.Name, "err", err)
+		}
+	}
 	return deployment, nil
 }
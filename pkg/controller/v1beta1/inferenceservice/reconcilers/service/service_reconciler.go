@@ -18,10 +18,14 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"maps"
 	"strconv"
+	"strings"
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
@@ -29,6 +33,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/kmp"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -39,18 +45,24 @@ var log = logf.Log.WithName("ServiceReconciler")
 type ServiceReconciler struct {
 	client       client.Client
 	scheme       *runtime.Scheme
+	recorder     record.EventRecorder
+	driftPolicy  v1beta1.DriftPolicy
 	Service      *corev1.Service
 	componentExt *v1beta1.ComponentExtensionSpec
 }
 
 func NewServiceReconciler(client client.Client,
 	scheme *runtime.Scheme,
+	recorder record.EventRecorder,
+	driftPolicy v1beta1.DriftPolicy,
 	componentMeta metav1.ObjectMeta,
 	componentExt *v1beta1.ComponentExtensionSpec,
 	podSpec *corev1.PodSpec) *ServiceReconciler {
 	return &ServiceReconciler{
 		client:       client,
 		scheme:       scheme,
+		recorder:     recorder,
+		driftPolicy:  driftPolicy,
 		Service:      createService(componentMeta, componentExt, podSpec),
 		componentExt: componentExt,
 	}
@@ -68,34 +80,7 @@ func createService(componentMeta metav1.ObjectMeta, componentExt *v1beta1.Compon
 			}
 		}
 		if len(container.Ports) > 0 {
-			var servicePort corev1.ServicePort
-			servicePort = corev1.ServicePort{
-				Name: container.Ports[0].Name,
-				Port: constants.CommonDefaultHttpPort,
-				TargetPort: intstr.IntOrString{
-					Type:   intstr.Int,
-					IntVal: container.Ports[0].ContainerPort,
-				},
-				Protocol: container.Ports[0].Protocol,
-			}
-			servicePorts = append(servicePorts, servicePort)
-
-			for i := 1; i < len(container.Ports); i++ {
-				port := container.Ports[i]
-				if port.Protocol == "" {
-					port.Protocol = corev1.ProtocolTCP
-				}
-				servicePort = corev1.ServicePort{
-					Name: port.Name,
-					Port: port.ContainerPort,
-					TargetPort: intstr.IntOrString{
-						Type:   intstr.Int,
-						IntVal: port.ContainerPort,
-					},
-					Protocol: port.Protocol,
-				}
-				servicePorts = append(servicePorts, servicePort)
-			}
+			servicePorts = createServicePorts(componentMeta.Name, container.Ports)
 		} else {
 			port, _ := strconv.Atoi(constants.InferenceServiceDefaultHttpPort)
 			servicePorts = append(servicePorts, corev1.ServicePort{
@@ -129,17 +114,120 @@ func createService(componentMeta metav1.ObjectMeta, componentExt *v1beta1.Compon
 				"app": constants.GetRawServiceLabel(componentMeta.Name),
 			},
 			Ports: servicePorts,
+			Type:  corev1.ServiceTypeClusterIP,
 			// TODO - add a control flag
 			// Need to add a control flag to properly set it, enable/disable this behavior.
 			// Follow up issue to align with upstream: https://issues.redhat.com/browse/RHOAIENG-5077
 			ClusterIP: corev1.ClusterIPNone,
 		},
 	}
+	setServiceType(&service.Spec, componentMeta.Annotations)
+	setTrafficRouting(service, componentMeta.Annotations)
 	return service
 }
 
+// createServicePorts exposes every port of the container as a named Service port, so that the ingress can select
+// the ports by name. The first port is the HTTP port of the component and is exposed on the common port 80, the
+// other ports, e.g. grpc or metrics, keep their number. Unnamed ports are named after the component for the first
+// port and after the protocol and number for the others. Ports with a duplicate name or number are skipped.
+func createServicePorts(componentName string, ports []corev1.ContainerPort) []corev1.ServicePort {
+	servicePorts := make([]corev1.ServicePort, 0, len(ports))
+	names := map[string]bool{}
+	numbers := map[int32]bool{}
+	for i, port := range ports {
+		if port.Protocol == "" {
+			port.Protocol = corev1.ProtocolTCP
+		}
+		servicePort := corev1.ServicePort{
+			Name: port.Name,
+			Port: port.ContainerPort,
+			TargetPort: intstr.IntOrString{
+				Type:   intstr.Int,
+				IntVal: port.ContainerPort,
+			},
+			Protocol: port.Protocol,
+		}
+		if i == 0 {
+			servicePort.Port = constants.CommonDefaultHttpPort
+			if port.Name == constants.HttpsPortName {
+				servicePort.Port = constants.CommonDefaultHttpsPort
+			}
+			if servicePort.Name == "" {
+				servicePort.Name = componentName
+			}
+		} else if servicePort.Name == "" {
+			servicePort.Name = fmt.Sprintf("%s-%d", strings.ToLower(string(port.Protocol)), port.ContainerPort)
+		}
+		if names[servicePort.Name] || numbers[servicePort.Port] {
+			log.Info("Skipping duplicate container port", "component", componentName, "name", servicePort.Name,
+				"port", servicePort.Port)
+			continue
+		}
+		names[servicePort.Name] = true
+		numbers[servicePort.Port] = true
+		servicePorts = append(servicePorts, servicePort)
+	}
+	return servicePorts
+}
+
+// setTrafficRouting sets the internal traffic policy and enables the topology aware routing of the Service from the
+// annotations of the component, so that calls between the components can stay on the same node or zone.
+func setTrafficRouting(service *corev1.Service, annotations map[string]string) {
+	routed := false
+	if value, ok := annotations[constants.InternalTrafficPolicyAnnotationKey]; ok {
+		policy := corev1.ServiceInternalTrafficPolicy(value)
+		service.Spec.InternalTrafficPolicy = &policy
+		routed = true
+	}
+	if value, ok := annotations[constants.TopologyAwareRoutingAnnotationKey]; ok {
+		if enabled, err := strconv.ParseBool(value); err == nil {
+			if enabled {
+				// the annotations are shared with the other resources of the component
+				service.Annotations = maps.Clone(service.Annotations)
+				service.Annotations[corev1.AnnotationTopologyMode] = "Auto"
+				routed = true
+			}
+		} else {
+			log.Info("Ignoring invalid annotation", "annotation", constants.TopologyAwareRoutingAnnotationKey, "value", value)
+		}
+	}
+	// kube-proxy does not route the traffic of headless Services
+	if routed && service.Spec.ClusterIP == corev1.ClusterIPNone {
+		service.Spec.ClusterIP = ""
+	}
+}
+
+// setServiceType exposes the Service outside the cluster when the component is annotated with the NodePort or
+// LoadBalancer type, for environments without an ingress. Such Services are not headless.
+func setServiceType(spec *corev1.ServiceSpec, annotations map[string]string) {
+	serviceType := corev1.ServiceType(annotations[constants.ServiceTypeAnnotationKey])
+	switch serviceType {
+	case corev1.ServiceTypeNodePort, corev1.ServiceTypeLoadBalancer:
+	default:
+		return
+	}
+	spec.Type = serviceType
+	spec.ClusterIP = ""
+	if serviceType != corev1.ServiceTypeLoadBalancer {
+		return
+	}
+	if value, ok := annotations[constants.LoadBalancerClassAnnotationKey]; ok {
+		spec.LoadBalancerClass = &value
+	}
+	if value, ok := annotations[constants.AllocateLoadBalancerNodePortsAnnotationKey]; ok {
+		if allocate, err := strconv.ParseBool(value); err == nil {
+			spec.AllocateLoadBalancerNodePorts = &allocate
+		} else {
+			log.Info("Ignoring invalid annotation", "annotation", constants.AllocateLoadBalancerNodePortsAnnotationKey, "value", value)
+		}
+	}
+}
+
 // checkServiceExist checks if the service exists?
 func (r *ServiceReconciler) checkServiceExist(client client.Client) (constants.CheckResultType, *corev1.Service, error) {
+	if err := utils.SetDesiredSpecHash(r.Service, r.Service.Spec); err != nil {
+		return constants.CheckResultUnknown, nil, err
+	}
 	// get service
 	existingService := &corev1.Service{}
 	err := client.Get(context.TODO(), types.NamespacedName{
@@ -152,17 +240,50 @@ func (r *ServiceReconciler) checkServiceExist(client client.Client) (constants.C
 		}
 		return constants.CheckResultUnknown, nil, err
 	}
+	// a Service left over from a deleted InferenceService of the same name keeps serving under the new one
+	if err := utils.AdoptResource(context.TODO(), client, r.recorder, "Service", r.Service, existingService); err != nil {
+		return constants.CheckResultUnknown, nil, err
+	}
 
 	// existed, check equivalent
 	if semanticServiceEquals(r.Service, existingService) {
 		return constants.CheckResultExisted, existingService, nil
 	}
+	diff, err := kmp.SafeDiff(r.Service.Spec, existingService.Spec)
+	if err != nil {
+		return constants.CheckResultUnknown, nil, err
+	}
+	if !utils.ShouldUpdateDriftedResource(r.recorder, r.driftPolicy, "Service", r.Service, existingService, diff) {
+		return constants.CheckResultExisted, existingService, nil
+	}
 	return constants.CheckResultUpdate, existingService, nil
 }
 
 func semanticServiceEquals(desired, existing *corev1.Service) bool {
+	// node port allocation is defaulted by the api server for LoadBalancer Services, only compare it when it is set
+	if desired.Spec.AllocateLoadBalancerNodePorts != nil &&
+		!equality.Semantic.DeepEqual(desired.Spec.AllocateLoadBalancerNodePorts, existing.Spec.AllocateLoadBalancerNodePorts) {
+		return false
+	}
+	// the internal traffic policy is defaulted as well
+	if desired.Spec.InternalTrafficPolicy != nil &&
+		!equality.Semantic.DeepEqual(desired.Spec.InternalTrafficPolicy, existing.Spec.InternalTrafficPolicy) {
+		return false
+	}
 	return equality.Semantic.DeepEqual(desired.Spec.Ports, existing.Spec.Ports) &&
-		equality.Semantic.DeepEqual(desired.Spec.Selector, existing.Spec.Selector)
+		equality.Semantic.DeepEqual(desired.Spec.Selector, existing.Spec.Selector) &&
+		desired.Spec.Type == existing.Spec.Type &&
+		(desired.Spec.ClusterIP == corev1.ClusterIPNone) == (existing.Spec.ClusterIP == corev1.ClusterIPNone) &&
+		equality.Semantic.DeepEqual(desired.Spec.LoadBalancerClass, existing.Spec.LoadBalancerClass) &&
+		desired.Annotations[corev1.AnnotationTopologyMode] == existing.Annotations[corev1.AnnotationTopologyMode]
+}
+
+// requiresRecreate reports whether the existing Service cannot be updated in place: the cluster IP of a headless
+// Service and the LoadBalancer class are immutable.
+func requiresRecreate(desired, existing *corev1.Service) bool {
+	return (desired.Spec.ClusterIP == corev1.ClusterIPNone) != (existing.Spec.ClusterIP == corev1.ClusterIPNone) ||
+		(existing.Spec.LoadBalancerClass != nil &&
+			!equality.Semantic.DeepEqual(desired.Spec.LoadBalancerClass, existing.Spec.LoadBalancerClass))
 }
 
 // Reconcile ...
@@ -171,6 +292,7 @@ func (r *ServiceReconciler) Reconcile() (*corev1.Service, error) {
 	checkResult, existingService, err := r.checkServiceExist(r.client)
 	log.Info("service reconcile", "checkResult", checkResult, "err", err)
 	if err != nil {
+		utils.ChildResources.RecordChild("Service", r.Service, checkResult, err)
 		return nil, err
 	}
 
@@ -179,10 +301,20 @@ func (r *ServiceReconciler) Reconcile() (*corev1.Service, error) {
 	case constants.CheckResultCreate:
 		opErr = r.client.Create(context.TODO(), r.Service)
 	case constants.CheckResultUpdate:
-		opErr = r.client.Update(context.TODO(), r.Service)
+		if requiresRecreate(r.Service, existingService) {
+			log.Info("Recreating service", "namespace", r.Service.Namespace, "name", r.Service.Name,
+				"type", r.Service.Spec.Type)
+			if opErr = r.client.Delete(context.TODO(), existingService); opErr == nil {
+				opErr = r.client.Create(context.TODO(), r.Service)
+			}
+		} else {
+			opErr = r.client.Update(context.TODO(), r.Service)
+		}
 	default:
+		utils.ChildResources.RecordChild("Service", r.Service, checkResult, nil)
 		return existingService, nil
 	}
+	utils.ChildResources.RecordChild("Service", r.Service, checkResult, opErr)
 
 	if opErr != nil {
 		return nil, opErr
@@ -0,0 +1,150 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"testing"
+
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestCreateServiceTypeAndRouting(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	podSpec := &corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name:  constants.InferenceServiceContainerName,
+				Ports: []corev1.ContainerPort{{Name: "http1", ContainerPort: 8080, Protocol: corev1.ProtocolTCP}},
+			},
+		},
+	}
+	local := corev1.ServiceInternalTrafficPolicyLocal
+	allocate := false
+	class := "example.com/internal-vip"
+
+	scenarios := map[string]struct {
+		annotations map[string]string
+		expected    corev1.ServiceSpec
+		topology    bool
+	}{
+		"Headless": {
+			annotations: map[string]string{},
+			expected: corev1.ServiceSpec{
+				Type:      corev1.ServiceTypeClusterIP,
+				ClusterIP: corev1.ClusterIPNone,
+			},
+		},
+		"NodePort": {
+			annotations: map[string]string{constants.ServiceTypeAnnotationKey: "NodePort"},
+			expected: corev1.ServiceSpec{
+				Type: corev1.ServiceTypeNodePort,
+			},
+		},
+		"LoadBalancer": {
+			annotations: map[string]string{
+				constants.ServiceTypeAnnotationKey:                   "LoadBalancer",
+				constants.LoadBalancerClassAnnotationKey:             class,
+				constants.AllocateLoadBalancerNodePortsAnnotationKey: "false",
+			},
+			expected: corev1.ServiceSpec{
+				Type:                          corev1.ServiceTypeLoadBalancer,
+				LoadBalancerClass:             &class,
+				AllocateLoadBalancerNodePorts: &allocate,
+			},
+		},
+		"LocalTrafficWithTopologyAwareRouting": {
+			annotations: map[string]string{
+				constants.InternalTrafficPolicyAnnotationKey: "Local",
+				constants.TopologyAwareRoutingAnnotationKey:  "true",
+			},
+			expected: corev1.ServiceSpec{
+				Type:                  corev1.ServiceTypeClusterIP,
+				InternalTrafficPolicy: &local,
+			},
+			topology: true,
+		},
+		"TopologyAwareRoutingDisabled": {
+			annotations: map[string]string{constants.TopologyAwareRoutingAnnotationKey: "false"},
+			expected: corev1.ServiceSpec{
+				Type:      corev1.ServiceTypeClusterIP,
+				ClusterIP: corev1.ClusterIPNone,
+			},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			componentMeta := metav1.ObjectMeta{
+				Name:        "sklearn-predictor",
+				Namespace:   "default",
+				Annotations: scenario.annotations,
+			}
+			service := createService(componentMeta, nil, podSpec)
+			g.Expect(service.Spec.Type).Should(gomega.Equal(scenario.expected.Type))
+			g.Expect(service.Spec.ClusterIP).Should(gomega.Equal(scenario.expected.ClusterIP))
+			g.Expect(service.Spec.LoadBalancerClass).Should(gomega.Equal(scenario.expected.LoadBalancerClass))
+			g.Expect(service.Spec.AllocateLoadBalancerNodePorts).Should(gomega.Equal(scenario.expected.AllocateLoadBalancerNodePorts))
+			g.Expect(service.Spec.InternalTrafficPolicy).Should(gomega.Equal(scenario.expected.InternalTrafficPolicy))
+			if scenario.topology {
+				g.Expect(service.Annotations).Should(gomega.HaveKeyWithValue(corev1.AnnotationTopologyMode, "Auto"))
+				// the annotations of the component are left untouched
+				g.Expect(componentMeta.Annotations).ShouldNot(gomega.HaveKey(corev1.AnnotationTopologyMode))
+			} else {
+				g.Expect(service.Annotations).ShouldNot(gomega.HaveKey(corev1.AnnotationTopologyMode))
+			}
+		})
+	}
+}
+
+func TestCreateServicePorts(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ports := createServicePorts("sklearn-predictor", []corev1.ContainerPort{
+		{ContainerPort: 8080},
+		{Name: "grpc", ContainerPort: 8081, Protocol: corev1.ProtocolTCP},
+		{ContainerPort: 9090},
+		{Name: "grpc", ContainerPort: 8082},
+		{Name: "metrics", ContainerPort: 9090},
+	})
+	g.Expect(ports).Should(gomega.Equal([]corev1.ServicePort{
+		{Name: "sklearn-predictor", Port: 80, TargetPort: intstr.FromInt(8080), Protocol: corev1.ProtocolTCP},
+		{Name: "grpc", Port: 8081, TargetPort: intstr.FromInt(8081), Protocol: corev1.ProtocolTCP},
+		{Name: "tcp-9090", Port: 9090, TargetPort: intstr.FromInt(9090), Protocol: corev1.ProtocolTCP},
+	}))
+
+	ports = createServicePorts("graph", []corev1.ContainerPort{{Name: "https", ContainerPort: 8080}})
+	g.Expect(ports).Should(gomega.Equal([]corev1.ServicePort{
+		{Name: "https", Port: 443, TargetPort: intstr.FromInt(8080), Protocol: corev1.ProtocolTCP},
+	}))
+}
+
+func TestRequiresRecreate(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	headless := &corev1.Service{Spec: corev1.ServiceSpec{ClusterIP: corev1.ClusterIPNone}}
+	nodePort := &corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeNodePort, ClusterIP: "10.0.0.1"}}
+	g.Expect(requiresRecreate(nodePort, headless)).Should(gomega.BeTrue())
+	g.Expect(requiresRecreate(headless, nodePort)).Should(gomega.BeTrue())
+	g.Expect(requiresRecreate(headless, headless)).Should(gomega.BeFalse())
+
+	class := "example.com/internal-vip"
+	other := "example.com/external-vip"
+	desired := &corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, LoadBalancerClass: &other}}
+	existing := &corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, ClusterIP: "10.0.0.1", LoadBalancerClass: &class}}
+	g.Expect(requiresRecreate(desired, existing)).Should(gomega.BeTrue())
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validationjob
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+)
+
+var log = logf.Log.WithName("ValidationJobReconciler")
+
+// ValidationJobReconciler runs the validation Job of the latest predictor revision, the InferenceService is held
+// not ready until the Job succeeds
+type ValidationJobReconciler struct {
+	client    client.Client
+	clientset kubernetes.Interface
+	scheme    *runtime.Scheme
+	recorder  record.EventRecorder
+}
+
+func NewValidationJobReconciler(client client.Client, clientset kubernetes.Interface, scheme *runtime.Scheme,
+	recorder record.EventRecorder) *ValidationJobReconciler {
+	return &ValidationJobReconciler{
+		client:    client,
+		clientset: clientset,
+		scheme:    scheme,
+		recorder:  recorder,
+	}
+}
+
+// Reconcile creates the validation Job of the latest predictor revision once the predictor is ready and propagates
+// the result of the Job to the ValidationSucceeded condition
+func (r *ValidationJobReconciler) Reconcile(isvc *v1beta1.InferenceService) error {
+	if isvc.Spec.Validation == nil {
+		isvc.Status.ClearCondition(v1beta1.ValidationSucceeded)
+		return nil
+	}
+	predictorStatus := isvc.Status.Components[v1beta1.PredictorComponent]
+	revision := predictorStatus.LatestReadyRevision
+	if revision == "" {
+		revision = predictorStatus.LatestCreatedRevision
+	}
+	if revision == "" || !isvc.Status.IsConditionReady(v1beta1.PredictorReady) {
+		r.setCondition(isvc, corev1.ConditionUnknown, "PredictorNotReady",
+			"Waiting for the predictor to be ready before running the validation job")
+		return nil
+	}
+
+	name := constants.ValidationJobName(isvc.Name, revision)
+	job := &batchv1.Job{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: isvc.Namespace, Name: name}, job)
+	if apierr.IsNotFound(err) {
+		job, err = r.createJob(isvc, name, revision)
+		if err != nil {
+			r.setCondition(isvc, corev1.ConditionFalse, "JobCreationFailed",
+				fmt.Sprintf("Failed to create the validation job for revision %s: %v", revision, err))
+			return err
+		}
+		if err := r.deleteStaleJobs(isvc, name); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	switch {
+	case hasJobCondition(job, batchv1.JobComplete):
+		r.setCondition(isvc, corev1.ConditionTrue, "JobSucceeded",
+			fmt.Sprintf("Validation job %s succeeded for revision %s", job.Name, revision))
+	case hasJobCondition(job, batchv1.JobFailed):
+		r.setCondition(isvc, corev1.ConditionFalse, "JobFailed",
+			fmt.Sprintf("Validation job %s failed for revision %s", job.Name, revision))
+	default:
+		r.setCondition(isvc, corev1.ConditionUnknown, "JobRunning",
+			fmt.Sprintf("Validation job %s is running for revision %s", job.Name, revision))
+	}
+	return nil
+}
+
+// createJob creates the validation Job from the template held by the ConfigMap referenced by the InferenceService
+func (r *ValidationJobReconciler) createJob(isvc *v1beta1.InferenceService, name string, revision string) (*batchv1.Job, error) {
+	configMap, err := r.clientset.CoreV1().ConfigMaps(isvc.Namespace).Get(context.TODO(),
+		isvc.Spec.Validation.JobTemplateConfigMap, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	job, err := createJobFromTemplate(isvc, configMap, name, revision)
+	if err != nil {
+		return nil, err
+	}
+	if err := controllerutil.SetControllerReference(isvc, job, r.scheme); err != nil {
+		return nil, err
+	}
+	log.Info("Creating validation job", "namespace", job.Namespace, "name", job.Name, "revision", revision)
+	if err := r.client.Create(context.TODO(), job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+func createJobFromTemplate(isvc *v1beta1.InferenceService, configMap *corev1.ConfigMap, name string,
+	revision string) (*batchv1.Job, error) {
+	manifest, ok := configMap.Data[constants.ValidationJobTemplateKey]
+	if !ok {
+		return nil, fmt.Errorf("configmap %s has no %q key", configMap.Name, constants.ValidationJobTemplateKey)
+	}
+	template := &batchv1.Job{}
+	if err := yaml.Unmarshal([]byte(manifest), template); err != nil {
+		return nil, fmt.Errorf("failed to parse the job template of configmap %s: %w", configMap.Name, err)
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: isvc.Namespace,
+			Labels: utils.Union(template.Labels, map[string]string{
+				constants.InferenceServicePodLabelKey: isvc.Name,
+				constants.ValidationJobLabel:          "true",
+			}),
+			Annotations: utils.Union(template.Annotations, map[string]string{
+				constants.ValidationJobRevisionAnnotationKey: revision,
+			}),
+		},
+		Spec: template.Spec,
+	}
+	if isvc.Spec.Validation.TimeoutSeconds != nil {
+		job.Spec.ActiveDeadlineSeconds = isvc.Spec.Validation.TimeoutSeconds
+	} else if job.Spec.ActiveDeadlineSeconds == nil {
+		timeout := constants.DefaultValidationJobTimeoutSeconds
+		job.Spec.ActiveDeadlineSeconds = &timeout
+	}
+	if job.Spec.Template.Spec.RestartPolicy == "" {
+		job.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyNever
+	}
+
+	var url string
+	if isvc.Status.Address != nil && isvc.Status.Address.URL != nil {
+		url = isvc.Status.Address.URL.String()
+	} else if isvc.Status.URL != nil {
+		url = isvc.Status.URL.String()
+	}
+	for i := range job.Spec.Template.Spec.Containers {
+		container := &job.Spec.Template.Spec.Containers[i]
+		container.Env = append(container.Env,
+			corev1.EnvVar{Name: constants.ValidationInferenceServiceURLEnvVarKey, Value: url},
+			corev1.EnvVar{Name: constants.ValidationPredictorRevisionEnvVarKey, Value: revision},
+		)
+	}
+	return job, nil
+}
+
+// deleteStaleJobs deletes the validation Jobs of the previous revisions
+func (r *ValidationJobReconciler) deleteStaleJobs(isvc *v1beta1.InferenceService, current string) error {
+	jobs := &batchv1.JobList{}
+	if err := r.client.List(context.TODO(), jobs, client.InNamespace(isvc.Namespace), client.MatchingLabels{
+		constants.InferenceServicePodLabelKey: isvc.Name,
+		constants.ValidationJobLabel:          "true",
+	}); err != nil {
+		return err
+	}
+	for i := range jobs.Items {
+		if jobs.Items[i].Name == current {
+			continue
+		}
+		log.Info("Deleting stale validation job", "namespace", isvc.Namespace, "name", jobs.Items[i].Name)
+		if err := r.client.Delete(context.TODO(), &jobs.Items[i],
+			client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setCondition sets the ValidationSucceeded condition and records an event when it changes
+func (r *ValidationJobReconciler) setCondition(isvc *v1beta1.InferenceService, status corev1.ConditionStatus,
+	reason string, message string) {
+	previous := isvc.Status.GetCondition(v1beta1.ValidationSucceeded)
+	isvc.Status.SetValidationCondition(status, reason, message)
+	if previous != nil && previous.Status == status && previous.Reason == reason && previous.Message == message {
+		return
+	}
+	eventType := corev1.EventTypeNormal
+	if status == corev1.ConditionFalse {
+		eventType = corev1.EventTypeWarning
+	}
+	r.recorder.Event(isvc, eventType, "Validation"+reason, message)
+}
+
+func hasJobCondition(job *batchv1.Job, conditionType batchv1.JobConditionType) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == conditionType && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
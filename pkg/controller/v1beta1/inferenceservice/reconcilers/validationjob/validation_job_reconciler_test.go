@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validationjob
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const jobTemplate = `
+spec:
+  backoffLimit: 1
+  template:
+    spec:
+      containers:
+      - name: golden-requests
+        image: curlimages/curl
+        command: ["sh", "-c", "curl -f $INFERENCE_SERVICE_URL/v1/models/sklearn"]
+`
+
+func TestValidationJobReconcile(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(v1beta1.AddToScheme(scheme)).To(gomega.Succeed())
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	clientset := fakeclientset.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "golden-requests", Namespace: "default"},
+		Data:       map[string]string{constants.ValidationJobTemplateKey: jobTemplate},
+	})
+	recorder := record.NewFakeRecorder(10)
+	reconciler := NewValidationJobReconciler(client, clientset, scheme, recorder)
+
+	url, _ := apis.ParseURL("http://sklearn.default.svc.cluster.local")
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default", UID: "sklearn-uid"},
+		Spec: v1beta1.InferenceServiceSpec{
+			Validation: &v1beta1.ValidationSpec{JobTemplateConfigMap: "golden-requests"},
+		},
+	}
+	isvc.Status.InitializeConditions()
+	isvc.Status.Address = &duckv1.Addressable{URL: url}
+
+	// the job is not created before the predictor is ready
+	g.Expect(reconciler.Reconcile(isvc)).To(gomega.Succeed())
+	g.Expect(isvc.Status.GetCondition(v1beta1.ValidationSucceeded).Reason).To(gomega.Equal("PredictorNotReady"))
+
+	isvc.Status.Components = map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+		v1beta1.PredictorComponent: {LatestCreatedRevision: "1"},
+	}
+	isvc.Status.SetCondition(v1beta1.PredictorReady, &apis.Condition{Status: corev1.ConditionTrue})
+	isvc.Status.SetCondition(v1beta1.IngressReady, &apis.Condition{Status: corev1.ConditionTrue})
+	g.Expect(reconciler.Reconcile(isvc)).To(gomega.Succeed())
+	g.Expect(isvc.Status.GetCondition(v1beta1.ValidationSucceeded).Reason).To(gomega.Equal("JobRunning"))
+	g.Expect(isvc.Status.IsReady()).To(gomega.BeFalse())
+
+	job := &batchv1.Job{}
+	key := types.NamespacedName{Name: constants.ValidationJobName("sklearn", "1"), Namespace: "default"}
+	g.Expect(client.Get(context.TODO(), key, job)).To(gomega.Succeed())
+	g.Expect(job.Spec.ActiveDeadlineSeconds).To(gomega.Equal(&constants.DefaultValidationJobTimeoutSeconds))
+	g.Expect(job.Spec.Template.Spec.RestartPolicy).To(gomega.Equal(corev1.RestartPolicyNever))
+	g.Expect(job.Spec.Template.Spec.Containers[0].Env).To(gomega.ContainElements(
+		corev1.EnvVar{Name: constants.ValidationInferenceServiceURLEnvVarKey, Value: url.String()},
+		corev1.EnvVar{Name: constants.ValidationPredictorRevisionEnvVarKey, Value: "1"},
+	))
+	g.Expect(job.OwnerReferences).To(gomega.HaveLen(1))
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	g.Expect(client.Status().Update(context.TODO(), job)).To(gomega.Succeed())
+	g.Expect(reconciler.Reconcile(isvc)).To(gomega.Succeed())
+	g.Expect(isvc.Status.IsConditionReady(v1beta1.ValidationSucceeded)).To(gomega.BeTrue())
+
+	// a new revision is validated again and the job of the previous revision is deleted
+	isvc.Status.Components[v1beta1.PredictorComponent] = v1beta1.ComponentStatusSpec{LatestCreatedRevision: "2"}
+	g.Expect(reconciler.Reconcile(isvc)).To(gomega.Succeed())
+	g.Expect(isvc.Status.GetCondition(v1beta1.ValidationSucceeded).Reason).To(gomega.Equal("JobRunning"))
+	jobs := &batchv1.JobList{}
+	g.Expect(client.List(context.TODO(), jobs)).To(gomega.Succeed())
+	g.Expect(jobs.Items).To(gomega.HaveLen(1))
+	g.Expect(jobs.Items[0].Name).To(gomega.Equal(constants.ValidationJobName("sklearn", "2")))
+
+	g.Expect(recorder.Events).To(gomega.HaveLen(4))
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+var adoptionLog = logf.Log.WithName("Adoption")
+
+// ShouldAdoptResource reports whether an existing child resource is left over from a deleted InferenceService of the
+// same name, e.g. when GitOps tooling replaces the InferenceService by deleting and recreating it. Such a child
+// resource carries the InferenceService label of the desired one and either has no controller, after an orphan
+// deletion, or is still controlled by the deleted InferenceService, which would have it garbage collected. A child
+// resource controlled by anything else is never adopted.
+func ShouldAdoptResource(desired client.Object, existing client.Object) bool {
+	owner := metav1.GetControllerOf(desired)
+	if owner == nil {
+		return false
+	}
+	name, ok := existing.GetLabels()[constants.InferenceServicePodLabelKey]
+	if !ok || name != desired.GetLabels()[constants.InferenceServicePodLabelKey] {
+		return false
+	}
+	current := metav1.GetControllerOf(existing)
+	if current == nil {
+		return true
+	}
+	if current.UID == owner.UID {
+		return false
+	}
+	currentGV, err := schema.ParseGroupVersion(current.APIVersion)
+	if err != nil {
+		return false
+	}
+	ownerGV, err := schema.ParseGroupVersion(owner.APIVersion)
+	if err != nil {
+		return false
+	}
+	return currentGV.Group == ownerGV.Group && current.Kind == owner.Kind && current.Name == owner.Name
+}
+
+// AdoptResource hands an existing child resource over to the controller of the desired one when
+// ShouldAdoptResource allows it, only the owner references of the child resource are updated so that it keeps
+// serving while the rest of its state is reconciled as usual. An event is recorded on the adopted child resource.
+func AdoptResource(ctx context.Context, cl client.Client, recorder record.EventRecorder, kind string,
+	desired client.Object, existing client.Object) error {
+	if !ShouldAdoptResource(desired, existing) {
+		return nil
+	}
+	owner := metav1.GetControllerOf(desired)
+	adoptionLog.Info("Adopting existing resource", "kind", kind, "name", existing.GetName(),
+		"namespace", existing.GetNamespace(), "owner", owner.Name, "uid", owner.UID)
+	var references []metav1.OwnerReference
+	for _, reference := range existing.GetOwnerReferences() {
+		if reference.Controller == nil || !*reference.Controller {
+			references = append(references, reference)
+		}
+	}
+	existing.SetOwnerReferences(append(references, *owner))
+	if err := cl.Update(ctx, existing); err != nil {
+		return err
+	}
+	if recorder != nil {
+		recorder.Eventf(existing, v1.EventTypeNormal, "Adopted", "%s %s was adopted by %s %s",
+			kind, existing.GetName(), owner.Kind, owner.Name)
+	}
+	return nil
+}
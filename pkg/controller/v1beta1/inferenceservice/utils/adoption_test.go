@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func newOwnerReference(kind string, name string, uid types.UID) metav1.OwnerReference {
+	return metav1.OwnerReference{
+		APIVersion:         "serving.kserve.io/v1beta1",
+		Kind:               kind,
+		Name:               name,
+		UID:                uid,
+		Controller:         proto.Bool(true),
+		BlockOwnerDeletion: proto.Bool(true),
+	}
+}
+
+func newTestDeployment(isvcName string, owners ...metav1.OwnerReference) *appsv1.Deployment {
+	return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+		Name:            isvcName + "-predictor",
+		Namespace:       "default",
+		Labels:          constants.InferenceServiceOwnerLabels(isvcName),
+		OwnerReferences: owners,
+	}}
+}
+
+func TestShouldAdoptResource(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	desired := newTestDeployment("sklearn", newOwnerReference("InferenceService", "sklearn", "new"))
+
+	scenarios := map[string]struct {
+		existing *appsv1.Deployment
+		expected bool
+	}{
+		"Orphaned": {
+			existing: newTestDeployment("sklearn"),
+			expected: true,
+		},
+		"ControlledByDeletedInferenceService": {
+			existing: newTestDeployment("sklearn", newOwnerReference("InferenceService", "sklearn", "old")),
+			expected: true,
+		},
+		"AlreadyOwned": {
+			existing: newTestDeployment("sklearn", newOwnerReference("InferenceService", "sklearn", "new")),
+			expected: false,
+		},
+		"ControlledByAnotherKind": {
+			existing: newTestDeployment("sklearn", newOwnerReference("InferenceGraph", "sklearn", "graph")),
+			expected: false,
+		},
+		"ControlledByAnotherInferenceService": {
+			existing: newTestDeployment("sklearn", newOwnerReference("InferenceService", "xgboost", "other")),
+			expected: false,
+		},
+		"LabelMismatch": {
+			existing: newTestDeployment("xgboost"),
+			expected: false,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g.Expect(ShouldAdoptResource(desired, scenario.existing)).To(gomega.Equal(scenario.expected))
+		})
+	}
+
+	// a child resource without a desired controller is never adopted
+	g.Expect(ShouldAdoptResource(newTestDeployment("sklearn"), newTestDeployment("sklearn"))).To(gomega.BeFalse())
+}
+
+func TestAdoptResource(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ctx := context.Background()
+	owner := newOwnerReference("InferenceService", "sklearn", "new")
+	desired := newTestDeployment("sklearn", owner)
+	other := metav1.OwnerReference{APIVersion: "v1", Kind: "ConfigMap", Name: "extra", UID: "extra"}
+	existing := newTestDeployment("sklearn", newOwnerReference("InferenceService", "sklearn", "old"), other)
+	existing.Spec.MinReadySeconds = 5
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(existing).Build()
+	recorder := record.NewFakeRecorder(10)
+
+	g.Expect(AdoptResource(ctx, cl, recorder, "Deployment", desired, existing)).To(gomega.Succeed())
+	g.Expect(recorder.Events).To(gomega.Receive(gomega.Equal(
+		"Normal Adopted Deployment sklearn-predictor was adopted by InferenceService sklearn")))
+
+	adopted := &appsv1.Deployment{}
+	g.Expect(cl.Get(ctx, types.NamespacedName{Name: "sklearn-predictor", Namespace: "default"}, adopted)).To(gomega.Succeed())
+	g.Expect(adopted.OwnerReferences).To(gomega.Equal([]metav1.OwnerReference{other, owner}))
+	g.Expect(adopted.Spec.MinReadySeconds).To(gomega.Equal(int32(5)))
+
+	// the adopted child resource is left alone afterwards
+	g.Expect(AdoptResource(ctx, cl, recorder, "Deployment", desired, adopted)).To(gomega.Succeed())
+	g.Expect(recorder.Events).NotTo(gomega.Receive())
+}
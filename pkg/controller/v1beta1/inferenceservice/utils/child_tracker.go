@@ -0,0 +1,191 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+const (
+	// ChildResourcesDebugPath is the path of the debug endpoint serving the reconciled child resources, it is
+	// served by the metrics server to the users allowed to get the path
+	ChildResourcesDebugPath = "/debug/children"
+
+	InferenceServiceKind = "InferenceService"
+	InferenceGraphKind   = "InferenceGraph"
+)
+
+// ChildResourceStatus is the outcome of the last reconciliation of a child resource
+type ChildResourceStatus struct {
+	Kind         string      `json:"kind"`
+	Name         string      `json:"name"`
+	Component    string      `json:"component,omitempty"`
+	CheckResult  string      `json:"checkResult,omitempty"`
+	LastError    string      `json:"lastError,omitempty"`
+	ReconciledAt metav1.Time `json:"reconciledAt"`
+}
+
+// ReconcileTree is the tree of child resources reconciled for an InferenceService or an InferenceGraph
+type ReconcileTree struct {
+	Kind         string                `json:"kind"`
+	Namespace    string                `json:"namespace"`
+	Name         string                `json:"name"`
+	LastError    string                `json:"lastError,omitempty"`
+	ReconciledAt metav1.Time           `json:"reconciledAt"`
+	Children     []ChildResourceStatus `json:"children"`
+}
+
+// ChildResourceTracker keeps the last reconciliation outcome of every child resource in memory, so that support
+// can see what the controller did for an InferenceService or an InferenceGraph without raising the log level.
+type ChildResourceTracker struct {
+	mutex sync.RWMutex
+	trees map[string]*ReconcileTree
+}
+
+// ChildResources is the tracker the reconcilers record to
+var ChildResources = NewChildResourceTracker()
+
+func NewChildResourceTracker() *ChildResourceTracker {
+	return &ChildResourceTracker{trees: map[string]*ReconcileTree{}}
+}
+
+func treeKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+// getTree returns the tree of the owner, creating it if needed. The caller must hold the lock.
+func (t *ChildResourceTracker) getTree(kind, namespace, name string) *ReconcileTree {
+	key := treeKey(kind, namespace, name)
+	tree, ok := t.trees[key]
+	if !ok {
+		tree = &ReconcileTree{Kind: kind, Namespace: namespace, Name: name}
+		t.trees[key] = tree
+	}
+	return tree
+}
+
+// RecordChild records the outcome of the reconciliation of a child resource. The owner is found from the owner
+// labels of the child resource, resources without them are ignored.
+func (t *ChildResourceTracker) RecordChild(kind string, obj metav1.Object, checkResult constants.CheckResultType, err error) {
+	var ownerKind, ownerName string
+	if name, ok := obj.GetLabels()[constants.InferenceGraphLabel]; ok {
+		ownerKind, ownerName = InferenceGraphKind, name
+	} else if name, ok := obj.GetLabels()[constants.InferenceServicePodLabelKey]; ok {
+		ownerKind, ownerName = InferenceServiceKind, name
+	} else {
+		return
+	}
+	child := ChildResourceStatus{
+		Kind:         kind,
+		Name:         obj.GetName(),
+		Component:    obj.GetLabels()[constants.KServiceComponentLabel],
+		CheckResult:  checkResult.String(),
+		ReconciledAt: metav1.Now(),
+	}
+	if err != nil {
+		child.LastError = err.Error()
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	tree := t.getTree(ownerKind, obj.GetNamespace(), ownerName)
+	for i := range tree.Children {
+		if tree.Children[i].Kind == child.Kind && tree.Children[i].Name == child.Name {
+			tree.Children[i] = child
+			return
+		}
+	}
+	tree.Children = append(tree.Children, child)
+	sort.Slice(tree.Children, func(i, j int) bool {
+		if tree.Children[i].Kind != tree.Children[j].Kind {
+			return tree.Children[i].Kind < tree.Children[j].Kind
+		}
+		return tree.Children[i].Name < tree.Children[j].Name
+	})
+}
+
+// RecordReconcile records the outcome of the reconciliation of an InferenceService or an InferenceGraph
+func (t *ChildResourceTracker) RecordReconcile(kind, namespace, name string, err error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	tree := t.getTree(kind, namespace, name)
+	tree.ReconciledAt = metav1.Now()
+	tree.LastError = ""
+	if err != nil {
+		tree.LastError = err.Error()
+	}
+}
+
+// Forget drops the tree of a deleted InferenceService or InferenceGraph
+func (t *ChildResourceTracker) Forget(kind, namespace, name string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	delete(t.trees, treeKey(kind, namespace, name))
+}
+
+// ServeHTTP returns the tree of the owner selected by the kind, namespace and name query parameters. When no name is
+// given all trees of the kind and namespace are returned, an empty kind or namespace matches everything.
+func (t *ChildResourceTracker) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	query := req.URL.Query()
+	kind, namespace, name := query.Get("kind"), query.Get("namespace"), query.Get("name")
+
+	t.mutex.RLock()
+	var response interface{}
+	if name != "" {
+		if kind == "" {
+			kind = InferenceServiceKind
+		}
+		tree, ok := t.trees[treeKey(kind, namespace, name)]
+		if !ok {
+			t.mutex.RUnlock()
+			http.Error(w, kind+" "+namespace+"/"+name+" has not been reconciled", http.StatusNotFound)
+			return
+		}
+		response = tree
+	} else {
+		trees := []*ReconcileTree{}
+		for _, tree := range t.trees {
+			if (kind == "" || tree.Kind == kind) && (namespace == "" || tree.Namespace == namespace) {
+				trees = append(trees, tree)
+			}
+		}
+		sort.Slice(trees, func(i, j int) bool {
+			return treeKey(trees[i].Kind, trees[i].Namespace, trees[i].Name) <
+				treeKey(trees[j].Kind, trees[j].Namespace, trees[j].Name)
+		})
+		response = trees
+	}
+	data, err := json.Marshal(response)
+	t.mutex.RUnlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestChildResourceTracker(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	tracker := NewChildResourceTracker()
+	labels := map[string]string{
+		constants.InferenceServicePodLabelKey: "sklearn",
+		constants.KServiceComponentLabel:      "predictor",
+	}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn-predictor", Namespace: "default", Labels: labels},
+	}
+	service := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn-predictor", Namespace: "default", Labels: labels},
+	}
+	tracker.RecordChild("Service", service, constants.CheckResultCreate, nil)
+	tracker.RecordChild("Deployment", deployment, constants.CheckResultCreate, nil)
+	tracker.RecordChild("Deployment", deployment, constants.CheckResultUpdate, errors.New("conflict"))
+	tracker.RecordChild("Deployment", &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "unowned"}},
+		constants.CheckResultCreate, nil)
+	tracker.RecordReconcile(InferenceServiceKind, "default", "sklearn", nil)
+
+	get := func(query string) (int, []byte) {
+		recorder := httptest.NewRecorder()
+		tracker.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, ChildResourcesDebugPath+query, nil))
+		return recorder.Code, recorder.Body.Bytes()
+	}
+
+	code, body := get("?namespace=default&name=sklearn")
+	g.Expect(code).To(gomega.Equal(http.StatusOK))
+	tree := &ReconcileTree{}
+	g.Expect(json.Unmarshal(body, tree)).To(gomega.Succeed())
+	g.Expect(tree.Kind).To(gomega.Equal(InferenceServiceKind))
+	g.Expect(tree.Children).To(gomega.HaveLen(2))
+	g.Expect(tree.Children[0].Kind).To(gomega.Equal("Deployment"))
+	g.Expect(tree.Children[0].Component).To(gomega.Equal("predictor"))
+	g.Expect(tree.Children[0].CheckResult).To(gomega.Equal("Update"))
+	g.Expect(tree.Children[0].LastError).To(gomega.Equal("conflict"))
+	g.Expect(tree.Children[1].Kind).To(gomega.Equal("Service"))
+	g.Expect(tree.Children[1].CheckResult).To(gomega.Equal("Create"))
+
+	code, body = get("?kind=InferenceGraph")
+	g.Expect(code).To(gomega.Equal(http.StatusOK))
+	g.Expect(string(body)).To(gomega.Equal("[]"))
+
+	tracker.Forget(InferenceServiceKind, "default", "sklearn")
+	code, _ = get("?namespace=default&name=sklearn")
+	g.Expect(code).To(gomega.Equal(http.StatusNotFound))
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"net/http"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var debugLog = logf.Log.WithName("DebugHandler")
+
+// debugAuthorizer serves the debug endpoint to the users authenticated with a TokenReview and allowed to get its
+// path with a SubjectAccessReview, the same way the metrics are served through the authenticating proxy.
+type debugAuthorizer struct {
+	clientset kubernetes.Interface
+	path      string
+	next      http.Handler
+}
+
+// AuthorizeDebugRequests wraps the handler of a debug endpoint so that it only serves the requests whose bearer token
+// belongs to a user allowed to get the non resource URL of the path, e.g. bound to a ClusterRole with the rule
+// nonResourceURLs: ["/debug/children"], verbs: ["get"].
+func AuthorizeDebugRequests(clientset kubernetes.Interface, path string, next http.Handler) http.Handler {
+	return &debugAuthorizer{clientset: clientset, path: path, next: next}
+}
+
+func (a *debugAuthorizer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		http.Error(w, "a bearer token is required", http.StatusUnauthorized)
+		return
+	}
+	tokenReview, err := a.clientset.AuthenticationV1().TokenReviews().Create(req.Context(), &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		debugLog.Error(err, "failed to review the token of the debug request")
+		http.Error(w, "failed to review the token", http.StatusInternalServerError)
+		return
+	}
+	if !tokenReview.Status.Authenticated {
+		http.Error(w, "the token is not valid", http.StatusUnauthorized)
+		return
+	}
+
+	user := tokenReview.Status.User
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	accessReview, err := a.clientset.AuthorizationV1().SubjectAccessReviews().Create(req.Context(),
+		&authorizationv1.SubjectAccessReview{
+			Spec: authorizationv1.SubjectAccessReviewSpec{
+				NonResourceAttributes: &authorizationv1.NonResourceAttributes{
+					Path: a.path,
+					Verb: strings.ToLower(req.Method),
+				},
+				User:   user.Username,
+				Groups: user.Groups,
+				Extra:  extra,
+				UID:    user.UID,
+			},
+		}, metav1.CreateOptions{})
+	if err != nil {
+		debugLog.Error(err, "failed to review the access of the debug request", "user", user.Username)
+		http.Error(w, "failed to review the access", http.StatusInternalServerError)
+		return
+	}
+	if !accessReview.Status.Allowed {
+		http.Error(w, "user "+user.Username+" cannot "+strings.ToLower(req.Method)+" "+a.path, http.StatusForbidden)
+		return
+	}
+	a.next.ServeHTTP(w, req)
+}
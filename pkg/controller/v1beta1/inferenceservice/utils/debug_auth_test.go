@@ -0,0 +1,76 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onsi/gomega"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestAuthorizeDebugRequests(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		switch review.Spec.Token {
+		case "admin-token":
+			review.Status = authenticationv1.TokenReviewStatus{Authenticated: true,
+				User: authenticationv1.UserInfo{Username: "admin"}}
+		case "tenant-token":
+			review.Status = authenticationv1.TokenReviewStatus{Authenticated: true,
+				User: authenticationv1.UserInfo{Username: "tenant"}}
+		}
+		return true, review, nil
+	})
+	clientset.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		g.Expect(*review.Spec.NonResourceAttributes).To(gomega.Equal(authorizationv1.NonResourceAttributes{
+			Path: ChildResourcesDebugPath, Verb: "get",
+		}))
+		review.Status.Allowed = review.Spec.User == "admin"
+		return true, review, nil
+	})
+	served := 0
+	handler := AuthorizeDebugRequests(clientset, ChildResourcesDebugPath, http.HandlerFunc(
+		func(w http.ResponseWriter, req *http.Request) {
+			served++
+		}))
+	send := func(token string) int {
+		req := httptest.NewRequest(http.MethodGet, ChildResourcesDebugPath, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	g.Expect(send("")).To(gomega.Equal(http.StatusUnauthorized))
+	g.Expect(send("expired-token")).To(gomega.Equal(http.StatusUnauthorized))
+	g.Expect(send("tenant-token")).To(gomega.Equal(http.StatusForbidden))
+	g.Expect(served).To(gomega.Equal(0))
+	g.Expect(send("admin-token")).To(gomega.Equal(http.StatusOK))
+	g.Expect(served).To(gomega.Equal(1))
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+var driftLog = logf.Log.WithName("DriftDetection")
+
+// SetDesiredSpecHash records the hash of the desired state of a child resource in its annotations, so that an
+// out-of-band edit of the child resource can be told apart from a change of the InferenceService.
+func SetDesiredSpecHash(obj metav1.Object, desired interface{}) error {
+	data, err := json.Marshal(desired)
+	if err != nil {
+		return err
+	}
+	annotations := make(map[string]string, len(obj.GetAnnotations())+1)
+	for key, value := range obj.GetAnnotations() {
+		annotations[key] = value
+	}
+	annotations[constants.DesiredSpecHashAnnotationKey] = fmt.Sprintf("%x", sha256.Sum256(data))
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// ShouldUpdateDriftedResource decides whether an existing child resource which differs from its desired state is
+// updated. The child resource is always updated when its desired state changed since the last update, otherwise
+// it was edited out-of-band and the drift policy decides whether the edit is reverted. An event is recorded on the
+// child resource in both cases.
+func ShouldUpdateDriftedResource(recorder record.EventRecorder, policy v1beta1.DriftPolicy, kind string,
+	desired client.Object, existing client.Object, diff string) bool {
+	existingHash := existing.GetAnnotations()[constants.DesiredSpecHashAnnotationKey]
+	if existingHash == "" || existingHash != desired.GetAnnotations()[constants.DesiredSpecHashAnnotationKey] {
+		return true
+	}
+	driftLog.Info("Detected out-of-band edit", "kind", kind, "name", existing.GetName(),
+		"namespace", existing.GetNamespace(), "policy", policy, "diff", diff)
+	if policy == v1beta1.DriftPolicyWarn {
+		if recorder != nil {
+			recorder.Eventf(existing, v1.EventTypeWarning, "DriftDetected",
+				"%s %s was edited out-of-band, the changes are kept since the %s drift policy is %s",
+				kind, existing.GetName(), kind, policy)
+		}
+		return false
+	}
+	if recorder != nil {
+		recorder.Eventf(existing, v1.EventTypeNormal, "DriftReverted",
+			"%s %s was edited out-of-band, the changes are reverted", kind, existing.GetName())
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func newTestService(port int32) *v1.Service {
+	return &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn-predictor", Namespace: "default", Annotations: map[string]string{"foo": "bar"}},
+		Spec:       v1.ServiceSpec{Ports: []v1.ServicePort{{Name: "http", Port: port}}},
+	}
+}
+
+func TestShouldUpdateDriftedResource(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	desired := newTestService(80)
+	g.Expect(SetDesiredSpecHash(desired, desired.Spec)).Should(gomega.Succeed())
+	g.Expect(desired.Annotations).Should(gomega.HaveKeyWithValue("foo", "bar"))
+	g.Expect(desired.Annotations).Should(gomega.HaveKey(constants.DesiredSpecHashAnnotationKey))
+
+	scenarios := map[string]struct {
+		policy   v1beta1.DriftPolicy
+		existing func() *v1.Service
+		expected bool
+		event    string
+	}{
+		"CreatedBeforeDriftDetection": {
+			policy:   v1beta1.DriftPolicyWarn,
+			existing: func() *v1.Service { return newTestService(8080) },
+			expected: true,
+		},
+		"DesiredStateChanged": {
+			policy: v1beta1.DriftPolicyWarn,
+			existing: func() *v1.Service {
+				existing := newTestService(8080)
+				g.Expect(SetDesiredSpecHash(existing, existing.Spec)).Should(gomega.Succeed())
+				return existing
+			},
+			expected: true,
+		},
+		"EditedOutOfBandWithRevertPolicy": {
+			policy: v1beta1.DriftPolicyRevert,
+			existing: func() *v1.Service {
+				existing := desired.DeepCopy()
+				existing.Spec.Ports[0].Port = 8080
+				return existing
+			},
+			expected: true,
+			event:    "Normal DriftReverted Service sklearn-predictor was edited out-of-band, the changes are reverted",
+		},
+		"EditedOutOfBandWithWarnPolicy": {
+			policy: v1beta1.DriftPolicyWarn,
+			existing: func() *v1.Service {
+				existing := desired.DeepCopy()
+				existing.Spec.Ports[0].Port = 8080
+				return existing
+			},
+			expected: false,
+			event: "Warning DriftDetected Service sklearn-predictor was edited out-of-band, the changes are kept " +
+				"since the Service drift policy is Warn",
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(1)
+			g.Expect(ShouldUpdateDriftedResource(recorder, scenario.policy, "Service", desired, scenario.existing(), "")).
+				Should(gomega.Equal(scenario.expected))
+			if scenario.event == "" {
+				g.Expect(recorder.Events).Should(gomega.BeEmpty())
+			} else {
+				g.Expect(recorder.Events).Should(gomega.Receive(gomega.Equal(scenario.event)))
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	guuid "github.com/google/uuid"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// LifecycleEventType is the CloudEvents type of a lifecycle event
+type LifecycleEventType string
+
+const (
+	LifecycleCreated LifecycleEventType = "org.kserve.lifecycle.created"
+	LifecycleReady   LifecycleEventType = "org.kserve.lifecycle.ready"
+	LifecycleFailed  LifecycleEventType = "org.kserve.lifecycle.failed"
+	LifecycleScaled  LifecycleEventType = "org.kserve.lifecycle.scaled"
+	LifecycleStopped LifecycleEventType = "org.kserve.lifecycle.stopped"
+
+	// cloud events extension attributes have to be lowercase alphanumeric
+	LifecycleKindAttr      = "kind"
+	LifecycleNamespaceAttr = "namespace"
+
+	lifecycleEventTimeout = 5 * time.Second
+)
+
+// LifecycleEventData is the payload of a lifecycle event
+type LifecycleEventData struct {
+	Kind          string `json:"kind"`
+	Namespace     string `json:"namespace"`
+	Name          string `json:"name"`
+	Generation    int64  `json:"generation"`
+	URL           string `json:"url,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+	Message       string `json:"message,omitempty"`
+	ReadyReplicas *int32 `json:"readyReplicas,omitempty"`
+}
+
+// LifecycleEventPublisher sends the lifecycle transitions of the InferenceServices and InferenceGraphs as
+// CloudEvents. The created, ready and failed transitions are found from the Ready condition before and after the
+// status update, the scaled and stopped transitions from the ready replicas last seen in memory.
+type LifecycleEventPublisher struct {
+	mutex    sync.Mutex
+	replicas map[string]int32
+	send     func(ctx context.Context, sink string, event cloudevents.Event) error
+}
+
+// LifecycleEvents is the publisher the reconcilers send the lifecycle events with
+var LifecycleEvents = NewLifecycleEventPublisher()
+
+func NewLifecycleEventPublisher() *LifecycleEventPublisher {
+	publisher := &LifecycleEventPublisher{replicas: map[string]int32{}}
+	publisher.send = publisher.sendCloudEvent
+	return publisher
+}
+
+// Publish sends an event to the sink for every lifecycle transition between the previous and the current Ready
+// condition and the ready replicas, which are nil when unknown. The events are sent in the background so that an
+// unavailable sink does not hold the reconciliation.
+func (p *LifecycleEventPublisher) Publish(sink string, data LifecycleEventData, previous *apis.Condition,
+	current *apis.Condition, readyReplicas *int32) {
+	data.ReadyReplicas = readyReplicas
+	transitions := p.transitions(data, previous, current, readyReplicas)
+	if len(transitions) == 0 {
+		return
+	}
+	events := make([]cloudevents.Event, 0, len(transitions))
+	for _, transition := range transitions {
+		event, err := newLifecycleEvent(transition, data, current)
+		if err != nil {
+			logf.Log.Error(err, "Failed to build lifecycle event", "type", transition, "namespace", data.Namespace, "name", data.Name)
+			continue
+		}
+		events = append(events, event)
+	}
+	go func() {
+		for _, event := range events {
+			ctx, cancel := context.WithTimeout(context.Background(), lifecycleEventTimeout)
+			if err := p.send(ctx, sink, event); err != nil {
+				logf.Log.Error(err, "Failed to send lifecycle event", "type", event.Type(), "sink", sink,
+					"namespace", data.Namespace, "name", data.Name)
+			}
+			cancel()
+		}
+	}()
+}
+
+// Forget drops the ready replicas recorded for a deleted InferenceService or InferenceGraph
+func (p *LifecycleEventPublisher) Forget(kind, namespace, name string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	delete(p.replicas, treeKey(kind, namespace, name))
+}
+
+func (p *LifecycleEventPublisher) transitions(data LifecycleEventData, previous *apis.Condition,
+	current *apis.Condition, readyReplicas *int32) []LifecycleEventType {
+	var transitions []LifecycleEventType
+	if previous == nil && current != nil {
+		transitions = append(transitions, LifecycleCreated)
+	}
+	if current != nil && (previous == nil || previous.Status != current.Status) {
+		switch current.Status {
+		case v1.ConditionTrue:
+			transitions = append(transitions, LifecycleReady)
+		case v1.ConditionFalse:
+			transitions = append(transitions, LifecycleFailed)
+		}
+	}
+
+	if readyReplicas != nil {
+		key := treeKey(data.Kind, data.Namespace, data.Name)
+		p.mutex.Lock()
+		last, seen := p.replicas[key]
+		p.replicas[key] = *readyReplicas
+		p.mutex.Unlock()
+		if seen && last != *readyReplicas {
+			if *readyReplicas == 0 {
+				transitions = append(transitions, LifecycleStopped)
+			} else {
+				transitions = append(transitions, LifecycleScaled)
+			}
+		}
+	}
+	return transitions
+}
+
+func newLifecycleEvent(eventType LifecycleEventType, data LifecycleEventData, current *apis.Condition) (cloudevents.Event, error) {
+	if current != nil && eventType == LifecycleFailed {
+		data.Reason = current.Reason
+		data.Message = current.Message
+	}
+	event := cloudevents.NewEvent(cloudevents.VersionV1)
+	event.SetID(guuid.New().String())
+	event.SetType(string(eventType))
+	event.SetSource(fmt.Sprintf("/apis/serving.kserve.io/namespaces/%s/%ss/%s", data.Namespace,
+		strings.ToLower(data.Kind), data.Name))
+	event.SetSubject(data.Name)
+	event.SetTime(time.Now())
+	event.SetExtension(LifecycleKindAttr, data.Kind)
+	event.SetExtension(LifecycleNamespaceAttr, data.Namespace)
+	if err := event.SetData(cloudevents.ApplicationJSON, data); err != nil {
+		return event, fmt.Errorf("while setting cloudevents data: %w", err)
+	}
+	return event, nil
+}
+
+func (p *LifecycleEventPublisher) sendCloudEvent(ctx context.Context, sink string, event cloudevents.Event) error {
+	ceClient, err := cloudevents.NewClientHTTP()
+	if err != nil {
+		return fmt.Errorf("while creating new cloudevents client: %w", err)
+	}
+	if result := ceClient.Send(cloudevents.ContextWithTarget(ctx, sink), event); !cloudevents.IsACK(result) {
+		return fmt.Errorf("while sending event: %w", result)
+	}
+	return nil
+}
+
+// ReadyReplicas returns the ready replicas of the Deployments matching the labels, it is nil when they can not be
+// listed
+func ReadyReplicas(cl client.Client, namespace string, labels map[string]string) *int32 {
+	deployments := &appsv1.DeploymentList{}
+	if err := cl.List(context.TODO(), deployments, client.InNamespace(namespace), client.MatchingLabels(labels)); err != nil {
+		logf.Log.Error(err, "Failed to list deployments", "namespace", namespace, "labels", labels)
+		return nil
+	}
+	var readyReplicas int32
+	for _, deployment := range deployments.Items {
+		readyReplicas += deployment.Status.ReadyReplicas
+	}
+	return &readyReplicas
+}
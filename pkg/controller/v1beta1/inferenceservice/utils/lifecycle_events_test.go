@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestLifecycleEventTransitions(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	publisher := NewLifecycleEventPublisher()
+	data := LifecycleEventData{Kind: InferenceServiceKind, Namespace: "default", Name: "sklearn"}
+	unknown := &apis.Condition{Type: apis.ConditionReady, Status: v1.ConditionUnknown}
+	ready := &apis.Condition{Type: apis.ConditionReady, Status: v1.ConditionTrue}
+	failed := &apis.Condition{Type: apis.ConditionReady, Status: v1.ConditionFalse, Reason: "MinimumReplicasUnavailable"}
+	replicas := func(n int32) *int32 { return &n }
+
+	g.Expect(publisher.transitions(data, nil, unknown, replicas(0))).To(gomega.Equal([]LifecycleEventType{LifecycleCreated}))
+	g.Expect(publisher.transitions(data, unknown, unknown, replicas(0))).To(gomega.BeEmpty())
+	g.Expect(publisher.transitions(data, unknown, ready, replicas(2))).
+		To(gomega.Equal([]LifecycleEventType{LifecycleReady, LifecycleScaled}))
+	g.Expect(publisher.transitions(data, ready, ready, replicas(3))).To(gomega.Equal([]LifecycleEventType{LifecycleScaled}))
+	g.Expect(publisher.transitions(data, ready, ready, nil)).To(gomega.BeEmpty())
+	g.Expect(publisher.transitions(data, ready, failed, replicas(0))).
+		To(gomega.Equal([]LifecycleEventType{LifecycleFailed, LifecycleStopped}))
+
+	// the replicas of a deleted resource are not compared with the replicas of a new one
+	publisher.Forget(InferenceServiceKind, "default", "sklearn")
+	g.Expect(publisher.transitions(data, nil, unknown, replicas(1))).To(gomega.Equal([]LifecycleEventType{LifecycleCreated}))
+}
+
+func TestLifecycleEventPublish(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	var mutex sync.Mutex
+	var received []cloudevents.Event
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		event, err := cloudevents.NewEventFromHTTPRequest(r)
+		g.Expect(err).ShouldNot(gomega.HaveOccurred())
+		mutex.Lock()
+		received = append(received, *event)
+		mutex.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer sink.Close()
+
+	publisher := NewLifecycleEventPublisher()
+	data := LifecycleEventData{Kind: InferenceGraphKind, Namespace: "default", Name: "graph", Generation: 2}
+	failed := &apis.Condition{Type: apis.ConditionReady, Status: v1.ConditionFalse, Reason: "SmokeTestFailed",
+		Message: "expected status code 200, got 500"}
+	publisher.Publish(sink.URL, data, nil, failed, nil)
+
+	g.Eventually(func() int {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return len(received)
+	}).Should(gomega.Equal(2))
+	mutex.Lock()
+	defer mutex.Unlock()
+	g.Expect(received[0].Type()).To(gomega.Equal(string(LifecycleCreated)))
+	g.Expect(received[1].Type()).To(gomega.Equal(string(LifecycleFailed)))
+	g.Expect(received[1].Source()).To(gomega.Equal("/apis/serving.kserve.io/namespaces/default/inferencegraphs/graph"))
+	g.Expect(received[1].Extensions()).To(gomega.HaveKeyWithValue(LifecycleNamespaceAttr, "default"))
+	payload := LifecycleEventData{}
+	g.Expect(json.Unmarshal(received[1].Data(), &payload)).To(gomega.Succeed())
+	g.Expect(payload).To(gomega.Equal(LifecycleEventData{
+		Kind:       InferenceGraphKind,
+		Namespace:  "default",
+		Name:       "graph",
+		Generation: 2,
+		Reason:     "SmokeTestFailed",
+		Message:    "expected status code 200, got 500",
+	}))
+
+	// a sink which does not accept the event is reported
+	unavailable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unavailable.Close()
+	g.Expect(publisher.sendCloudEvent(context.TODO(), unavailable.URL, received[0])).Should(gomega.HaveOccurred())
+}
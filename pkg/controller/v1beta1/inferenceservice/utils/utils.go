@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"html/template"
+	"net"
 	"regexp"
 	"sort"
 	"strings"
@@ -39,6 +40,9 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/apis"
+	"knative.dev/serving/pkg/apis/autoscaling"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -176,6 +180,130 @@ func GetDeploymentMode(annotations map[string]string, deployConfig *v1beta1api.D
 	return constants.DeploymentModeType(deployConfig.DefaultDeploymentMode)
 }
 
+// CheckServiceMeshMembership returns whether the namespace is enrolled into the service mesh, it always succeeds when
+// the membership is not required by the mesh config.
+func CheckServiceMeshMembership(clientset kubernetes.Interface, namespace string) (bool, error) {
+	meshConfig, err := v1beta1api.NewMeshConfig(clientset)
+	if err != nil {
+		return false, goerrors.Wrapf(err, "fails to create MeshConfig")
+	}
+	if !meshConfig.RequireNamespaceMembership {
+		return true, nil
+	}
+	ns, err := clientset.CoreV1().Namespaces().Get(context.TODO(), namespace, metav1.GetOptions{})
+	if err != nil {
+		return false, goerrors.Wrapf(err, "fails to get namespace %s", namespace)
+	}
+	return isServiceMeshMember(ns), nil
+}
+
+// isServiceMeshMember returns whether the namespace is enrolled either by the OpenShift Service Mesh member roll or by
+// the Istio sidecar injection labels.
+func isServiceMeshMember(namespace *v1.Namespace) bool {
+	if _, ok := namespace.Labels[constants.ServiceMeshMemberOfLabel]; ok {
+		return true
+	}
+	if _, ok := namespace.Labels[constants.IstioRevisionLabel]; ok {
+		return true
+	}
+	return namespace.Labels[constants.IstioInjectionLabel] == "enabled"
+}
+
+// GetAutoscalerClass returns the autoscaler class resolved from the annotations for the deployment mode, the
+// HPA is the default in raw deployment mode and the KPA in serverless mode.
+func GetAutoscalerClass(annotations map[string]string, deploymentMode constants.DeploymentModeType) string {
+	switch deploymentMode {
+	case constants.RawDeployment:
+		if value, ok := annotations[constants.AutoscalerClass]; ok {
+			return value
+		}
+		return string(constants.DefaultAutoscalerClass)
+	case constants.Serverless:
+		if value, ok := annotations[autoscaling.ClassAnnotationKey]; ok {
+			return value
+		}
+		return autoscaling.KPA
+	}
+	return ""
+}
+
+// GetEffectiveConfig returns the global configuration an InferenceService is built with
+func GetEffectiveConfig(isvc *v1beta1api.InferenceService, deploymentMode constants.DeploymentModeType,
+	ingressConfig *v1beta1api.IngressConfig, configMapResourceVersion string) *v1beta1api.EffectiveConfig {
+	effectiveConfig := &v1beta1api.EffectiveConfig{
+		ConfigMapResourceVersion: configMapResourceVersion,
+		DeploymentMode:           string(deploymentMode),
+		AutoscalerClass:          GetAutoscalerClass(isvc.Annotations, deploymentMode),
+	}
+	if deploymentMode == constants.RawDeployment && ingressConfig.IngressClassName != nil {
+		effectiveConfig.IngressClassName = *ingressConfig.IngressClassName
+	}
+	return effectiveConfig
+}
+
+// GetEndpoints lists the addresses the InferenceService is reachable at from its URL and cluster local address: the
+// http or https endpoints, the grpc targets when the predictor serves a grpc protocol and the OpenAI API base
+// paths when the predictor serves a huggingface model.
+func GetEndpoints(isvc *v1beta1api.InferenceService) []v1beta1api.Endpoint {
+	var endpoints []v1beta1api.Endpoint
+	var predictorProtocol constants.InferenceServiceProtocol
+	var modelFormat string
+	if implementations := isvc.Spec.Predictor.GetImplementations(); len(implementations) > 0 {
+		predictorProtocol = implementations[0].GetProtocol()
+	}
+	if isvc.Spec.Predictor.Model != nil {
+		modelFormat = isvc.Spec.Predictor.Model.ModelFormat.Name
+	}
+
+	addEndpoints := func(url *apis.URL, visibility v1beta1api.EndpointVisibility, names [3]string) {
+		endpoints = append(endpoints, v1beta1api.Endpoint{
+			Name:       names[0],
+			Visibility: visibility,
+			Protocol:   url.Scheme,
+			URL:        url.DeepCopy(),
+		})
+		if predictorProtocol == constants.ProtocolGRPCV1 || predictorProtocol == constants.ProtocolGRPCV2 {
+			port := url.URL().Port()
+			if port == "" {
+				port = "80"
+				if url.Scheme == "https" {
+					port = "443"
+				}
+			}
+			endpoints = append(endpoints, v1beta1api.Endpoint{
+				Name:       names[1],
+				Visibility: visibility,
+				Protocol:   "grpc",
+				Target:     net.JoinHostPort(url.URL().Hostname(), port),
+			})
+		} else if modelFormat == constants.SupportedModelHuggingFace {
+			openAIURL := url.DeepCopy()
+			openAIURL.Path = strings.TrimSuffix(openAIURL.Path, "/") + constants.OpenAIBasePath
+			endpoints = append(endpoints, v1beta1api.Endpoint{
+				Name:       names[2],
+				Visibility: visibility,
+				Protocol:   url.Scheme,
+				URL:        openAIURL,
+			})
+		}
+	}
+
+	var clusterLocalURL *apis.URL
+	if isvc.Status.Address != nil {
+		clusterLocalURL = isvc.Status.Address.URL
+	}
+	// the URL of a cluster local InferenceService is its cluster local address
+	if isvc.Status.URL != nil && (clusterLocalURL == nil || isvc.Status.URL.Host != clusterLocalURL.Host) {
+		addEndpoints(isvc.Status.URL, v1beta1api.ExternalEndpoint, [3]string{v1beta1api.ExternalEndpointName,
+			v1beta1api.GrpcEndpointName, v1beta1api.OpenAIEndpointName})
+	}
+	if clusterLocalURL != nil {
+		addEndpoints(clusterLocalURL, v1beta1api.ClusterLocalEndpoint, [3]string{v1beta1api.ClusterLocalEndpointName,
+			v1beta1api.GrpcClusterLocalEndpointName, v1beta1api.OpenAIClusterLocalEndpointName})
+	}
+	return endpoints
+}
+
 // MergeRuntimeContainers Merge the predictor Container struct with the runtime Container struct, allowing users
 // to override runtime container settings from the predictor spec.
 func MergeRuntimeContainers(runtimeContainer *v1.Container, predictorContainer *v1.Container) (*v1.Container, error) {
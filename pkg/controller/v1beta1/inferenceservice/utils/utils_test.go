@@ -34,6 +34,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -1276,6 +1277,67 @@ func TestGetDeploymentMode(t *testing.T) {
 	}
 }
 
+func TestGetAutoscalerClass(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		annotations    map[string]string
+		deploymentMode constants.DeploymentModeType
+		expected       string
+	}{
+		"RawDeploymentDefault": {
+			annotations:    map[string]string{},
+			deploymentMode: constants.RawDeployment,
+			expected:       string(constants.AutoscalerClassHPA),
+		},
+		"RawDeploymentExternal": {
+			annotations:    map[string]string{constants.AutoscalerClass: string(constants.AutoscalerClassExternal)},
+			deploymentMode: constants.RawDeployment,
+			expected:       string(constants.AutoscalerClassExternal),
+		},
+		"ServerlessDefault": {
+			annotations:    map[string]string{},
+			deploymentMode: constants.Serverless,
+			expected:       "kpa.autoscaling.knative.dev",
+		},
+		"ServerlessHPA": {
+			annotations:    map[string]string{"autoscaling.knative.dev/class": "hpa.autoscaling.knative.dev"},
+			deploymentMode: constants.Serverless,
+			expected:       "hpa.autoscaling.knative.dev",
+		},
+		"ModelMeshDeployment": {
+			annotations:    map[string]string{},
+			deploymentMode: constants.ModelMeshDeployment,
+			expected:       "",
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g.Expect(GetAutoscalerClass(scenario.annotations, scenario.deploymentMode)).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
+
+func TestGetEffectiveConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default"},
+	}
+	ingressConfig := &v1beta1.IngressConfig{IngressClassName: proto.String("nginx")}
+
+	g.Expect(GetEffectiveConfig(isvc, constants.RawDeployment, ingressConfig, "1234")).To(gomega.Equal(&v1beta1.EffectiveConfig{
+		ConfigMapResourceVersion: "1234",
+		DeploymentMode:           string(constants.RawDeployment),
+		IngressClassName:         "nginx",
+		AutoscalerClass:          string(constants.AutoscalerClassHPA),
+	}))
+	g.Expect(GetEffectiveConfig(isvc, constants.Serverless, ingressConfig, "1234")).To(gomega.Equal(&v1beta1.EffectiveConfig{
+		ConfigMapResourceVersion: "1234",
+		DeploymentMode:           string(constants.Serverless),
+		AutoscalerClass:          "kpa.autoscaling.knative.dev",
+	}))
+}
+
 func TestModelName(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	var requestedResource = v1.ResourceRequirements{
@@ -1816,3 +1878,121 @@ func TestValidateStorageURIForDefaultStorageInitializerCRD(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckServiceMeshMembership(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	namespaces := []runtime.Object{
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "plain"}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ossm", Labels: map[string]string{constants.ServiceMeshMemberOfLabel: "istio-system"}}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "injected", Labels: map[string]string{constants.IstioInjectionLabel: "enabled"}}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "revision", Labels: map[string]string{constants.IstioRevisionLabel: "canary"}}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "disabled", Labels: map[string]string{constants.IstioInjectionLabel: "disabled"}}},
+	}
+	newClientset := func(meshConfig string) *fakeclientset.Clientset {
+		return fakeclientset.NewSimpleClientset(append([]runtime.Object{&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+			Data:       map[string]string{MeshConfigName: meshConfig},
+		}}, namespaces...)...)
+	}
+
+	member, err := CheckServiceMeshMembership(newClientset(`{}`), "plain")
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(member).Should(gomega.BeTrue())
+
+	clientset := newClientset(`{"requireNamespaceMembership": true}`)
+	for namespace, expected := range map[string]bool{
+		"plain":    false,
+		"ossm":     true,
+		"injected": true,
+		"revision": true,
+		"disabled": false,
+	} {
+		member, err := CheckServiceMeshMembership(clientset, namespace)
+		g.Expect(err).ShouldNot(gomega.HaveOccurred())
+		g.Expect(member).Should(gomega.Equal(expected), namespace)
+	}
+
+	_, err = CheckServiceMeshMembership(clientset, "missing")
+	g.Expect(err).Should(gomega.HaveOccurred())
+}
+
+func TestGetEndpoints(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	externalURL := &apis.URL{Scheme: "https", Host: "sklearn.default.example.com"}
+	clusterLocalURL := &apis.URL{Scheme: "http", Host: "sklearn-predictor.default.svc.cluster.local"}
+	newIsvc := func(modelFormat string, protocol constants.InferenceServiceProtocol) *InferenceService {
+		return &InferenceService{
+			Spec: InferenceServiceSpec{
+				Predictor: PredictorSpec{
+					Model: &ModelSpec{
+						ModelFormat: ModelFormat{Name: modelFormat},
+						PredictorExtensionSpec: PredictorExtensionSpec{
+							StorageURI:      proto.String("gs://bucket/model"),
+							ProtocolVersion: &protocol,
+						},
+					},
+				},
+			},
+			Status: InferenceServiceStatus{
+				URL:     externalURL,
+				Address: &knativeV1.Addressable{URL: clusterLocalURL},
+			},
+		}
+	}
+
+	scenarios := map[string]struct {
+		isvc     *InferenceService
+		expected []Endpoint
+	}{
+		"http": {
+			isvc: newIsvc("sklearn", constants.ProtocolV1),
+			expected: []Endpoint{
+				{Name: ExternalEndpointName, Visibility: ExternalEndpoint, Protocol: "https", URL: externalURL},
+				{Name: ClusterLocalEndpointName, Visibility: ClusterLocalEndpoint, Protocol: "http", URL: clusterLocalURL},
+			},
+		},
+		"grpc": {
+			isvc: newIsvc("triton", constants.ProtocolGRPCV2),
+			expected: []Endpoint{
+				{Name: ExternalEndpointName, Visibility: ExternalEndpoint, Protocol: "https", URL: externalURL},
+				{Name: GrpcEndpointName, Visibility: ExternalEndpoint, Protocol: "grpc", Target: "sklearn.default.example.com:443"},
+				{Name: ClusterLocalEndpointName, Visibility: ClusterLocalEndpoint, Protocol: "http", URL: clusterLocalURL},
+				{Name: GrpcClusterLocalEndpointName, Visibility: ClusterLocalEndpoint, Protocol: "grpc", Target: "sklearn-predictor.default.svc.cluster.local:80"},
+			},
+		},
+		"openai": {
+			isvc: newIsvc(constants.SupportedModelHuggingFace, constants.ProtocolV2),
+			expected: []Endpoint{
+				{Name: ExternalEndpointName, Visibility: ExternalEndpoint, Protocol: "https", URL: externalURL},
+				{Name: OpenAIEndpointName, Visibility: ExternalEndpoint, Protocol: "https",
+					URL: &apis.URL{Scheme: "https", Host: "sklearn.default.example.com", Path: "/openai/v1"}},
+				{Name: ClusterLocalEndpointName, Visibility: ClusterLocalEndpoint, Protocol: "http", URL: clusterLocalURL},
+				{Name: OpenAIClusterLocalEndpointName, Visibility: ClusterLocalEndpoint, Protocol: "http",
+					URL: &apis.URL{Scheme: "http", Host: "sklearn-predictor.default.svc.cluster.local", Path: "/openai/v1"}},
+			},
+		},
+		"cluster local": {
+			isvc: func() *InferenceService {
+				isvc := newIsvc("sklearn", constants.ProtocolV1)
+				isvc.Status.URL = clusterLocalURL
+				return isvc
+			}(),
+			expected: []Endpoint{
+				{Name: ClusterLocalEndpointName, Visibility: ClusterLocalEndpoint, Protocol: "http", URL: clusterLocalURL},
+			},
+		},
+		"not ready": {
+			isvc: func() *InferenceService {
+				isvc := newIsvc("sklearn", constants.ProtocolV1)
+				isvc.Status = InferenceServiceStatus{}
+				return isvc
+			}(),
+			expected: nil,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g.Expect(GetEndpoints(scenario.isvc)).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cors
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// AnyOrigin allows the requests of any origin
+	AnyOrigin = "*"
+	// AnyHeader allows any request header
+	AnyHeader = "*"
+)
+
+// DefaultMethods are the methods allowed when the config does not list any
+var DefaultMethods = []string{http.MethodGet, http.MethodHead, http.MethodPost}
+
+// supportedMethods are the methods which can be allowed
+var supportedMethods = []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+	http.MethodDelete, http.MethodOptions}
+
+// Config specifies the cross-origin requests the browsers are allowed to send
+type Config struct {
+	// AllowOrigins are the origins allowed to send requests, e.g. https://app.example.com, or AnyOrigin
+	AllowOrigins []string
+	// AllowMethods are the methods allowed, they default to DefaultMethods
+	AllowMethods []string
+	// AllowHeaders are the request headers allowed besides the CORS-safelisted ones, or AnyHeader
+	AllowHeaders []string
+	// MaxAge is how long in seconds the browsers cache the response to a preflight request, they use their own
+	// default when nil
+	MaxAge *int32
+}
+
+// Validate returns an error when an origin, a method or a header of the config is invalid
+func (c Config) Validate() error {
+	if len(c.AllowOrigins) == 0 {
+		return fmt.Errorf("at least one origin must be allowed")
+	}
+	for _, origin := range c.AllowOrigins {
+		if origin == AnyOrigin {
+			continue
+		}
+		originURL, err := url.Parse(origin)
+		if err != nil || (originURL.Scheme != "http" && originURL.Scheme != "https") || originURL.Host == "" ||
+			originURL.Path != "" || originURL.RawQuery != "" || originURL.Fragment != "" || originURL.User != nil {
+			return fmt.Errorf("invalid origin %q: expected %q or a scheme and a host, e.g. https://app.example.com",
+				origin, AnyOrigin)
+		}
+	}
+	for _, method := range c.AllowMethods {
+		if !contains(supportedMethods, method) {
+			return fmt.Errorf("unsupported method %q: expected one of %s", method, strings.Join(supportedMethods, ", "))
+		}
+	}
+	for _, header := range c.AllowHeaders {
+		if header == AnyHeader {
+			continue
+		}
+		if errs := validation.IsHTTPHeaderName(header); len(errs) > 0 {
+			return fmt.Errorf("invalid header %q: %s", header, strings.Join(errs, ", "))
+		}
+	}
+	if c.MaxAge != nil && *c.MaxAge < 0 {
+		return fmt.Errorf("max age must not be negative")
+	}
+	return nil
+}
+
+// Methods returns the allowed methods
+func (c Config) Methods() []string {
+	if len(c.AllowMethods) == 0 {
+		return DefaultMethods
+	}
+	return c.AllowMethods
+}
+
+// Handler answers the preflight requests and sets the CORS headers on the responses to the cross-origin requests of
+// the allowed origins, the requests of the other origins are passed without the headers so that the browsers block
+// them. The preflight requests of the origins, methods or headers which are not allowed are rejected with
+// 403 Forbidden.
+type Handler struct {
+	config Config
+	next   http.Handler
+}
+
+func NewHandler(config Config, next http.Handler) *Handler {
+	return &Handler{config: config, next: next}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+	w.Header().Add("Vary", "Origin")
+	preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+	allowed := h.allowOrigin(origin)
+	if !preflight {
+		if allowed {
+			h.setAllowOrigin(w, origin)
+		}
+		h.next.ServeHTTP(w, r)
+		return
+	}
+
+	if !allowed {
+		http.Error(w, fmt.Sprintf("the origin %s is not allowed", origin), http.StatusForbidden)
+		return
+	}
+	method := r.Header.Get("Access-Control-Request-Method")
+	if !contains(h.config.Methods(), method) {
+		http.Error(w, fmt.Sprintf("the method %s is not allowed", method), http.StatusForbidden)
+		return
+	}
+	var requestHeaders []string
+	for _, header := range strings.Split(r.Header.Get("Access-Control-Request-Headers"), ",") {
+		if header = strings.TrimSpace(header); header != "" {
+			requestHeaders = append(requestHeaders, header)
+		}
+	}
+	for _, header := range requestHeaders {
+		if !h.allowHeader(header) {
+			http.Error(w, fmt.Sprintf("the header %s is not allowed", header), http.StatusForbidden)
+			return
+		}
+	}
+
+	h.setAllowOrigin(w, origin)
+	w.Header().Set("Access-Control-Allow-Methods", strings.Join(h.config.Methods(), ", "))
+	if len(requestHeaders) > 0 {
+		w.Header().Set("Access-Control-Allow-Headers", strings.Join(requestHeaders, ", "))
+	}
+	if h.config.MaxAge != nil {
+		w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(*h.config.MaxAge)))
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) allowOrigin(origin string) bool {
+	for _, allowed := range h.config.AllowOrigins {
+		if allowed == AnyOrigin || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *Handler) allowHeader(header string) bool {
+	for _, allowed := range h.config.AllowHeaders {
+		if allowed == AnyHeader || strings.EqualFold(allowed, header) {
+			return true
+		}
+	}
+	return false
+}
+
+// setAllowOrigin echoes the origin, unless any origin is allowed
+func (h *Handler) setAllowOrigin(w http.ResponseWriter, origin string) {
+	if contains(h.config.AllowOrigins, AnyOrigin) {
+		w.Header().Set("Access-Control-Allow-Origin", AnyOrigin)
+		return
+	}
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestValidate(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		config  Config
+		matcher gomega.OmegaMatcher
+	}{
+		"AnyOrigin": {
+			config:  Config{AllowOrigins: []string{AnyOrigin}},
+			matcher: gomega.Succeed(),
+		},
+		"Full": {
+			config: Config{
+				AllowOrigins: []string{"https://app.example.com", "http://localhost:3000"},
+				AllowMethods: []string{http.MethodPost, http.MethodOptions},
+				AllowHeaders: []string{"Authorization", "Content-Type"},
+				MaxAge:       proto.Int32(600),
+			},
+			matcher: gomega.Succeed(),
+		},
+		"NoOrigin": {
+			config:  Config{},
+			matcher: gomega.MatchError(gomega.ContainSubstring("at least one origin")),
+		},
+		"OriginWithPath": {
+			config:  Config{AllowOrigins: []string{"https://app.example.com/ui"}},
+			matcher: gomega.MatchError(gomega.ContainSubstring("invalid origin")),
+		},
+		"OriginWithoutScheme": {
+			config:  Config{AllowOrigins: []string{"app.example.com"}},
+			matcher: gomega.MatchError(gomega.ContainSubstring("invalid origin")),
+		},
+		"UnsupportedMethod": {
+			config:  Config{AllowOrigins: []string{AnyOrigin}, AllowMethods: []string{"TRACE"}},
+			matcher: gomega.MatchError(gomega.ContainSubstring("unsupported method")),
+		},
+		"InvalidHeader": {
+			config:  Config{AllowOrigins: []string{AnyOrigin}, AllowHeaders: []string{"X Tenant"}},
+			matcher: gomega.MatchError(gomega.ContainSubstring("invalid header")),
+		},
+		"NegativeMaxAge": {
+			config:  Config{AllowOrigins: []string{AnyOrigin}, MaxAge: proto.Int32(-1)},
+			matcher: gomega.MatchError(gomega.ContainSubstring("max age")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g.Expect(scenario.config.Validate()).To(scenario.matcher)
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	served := 0
+	handler := NewHandler(Config{
+		AllowOrigins: []string{"https://app.example.com"},
+		AllowHeaders: []string{"Content-Type"},
+		MaxAge:       proto.Int32(600),
+	}, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served++
+	}))
+
+	send := func(method string, headers map[string]string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, "/v1/models/sklearn:predict", nil)
+		for key, value := range headers {
+			r.Header.Set(key, value)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	// Requests of the same origin are passed without the headers
+	w := send(http.MethodPost, nil)
+	g.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(w.Header().Get("Access-Control-Allow-Origin")).To(gomega.BeEmpty())
+	g.Expect(served).To(gomega.Equal(1))
+
+	w = send(http.MethodPost, map[string]string{"Origin": "https://app.example.com"})
+	g.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(w.Header().Get("Access-Control-Allow-Origin")).To(gomega.Equal("https://app.example.com"))
+	g.Expect(w.Header().Get("Vary")).To(gomega.Equal("Origin"))
+	g.Expect(served).To(gomega.Equal(2))
+
+	// The browsers block the responses to the other origins
+	w = send(http.MethodPost, map[string]string{"Origin": "https://evil.example.com"})
+	g.Expect(w.Header().Get("Access-Control-Allow-Origin")).To(gomega.BeEmpty())
+	g.Expect(served).To(gomega.Equal(3))
+
+	w = send(http.MethodOptions, map[string]string{
+		"Origin":                         "https://app.example.com",
+		"Access-Control-Request-Method":  http.MethodPost,
+		"Access-Control-Request-Headers": "content-type",
+	})
+	g.Expect(w.Code).To(gomega.Equal(http.StatusNoContent))
+	g.Expect(w.Header().Get("Access-Control-Allow-Origin")).To(gomega.Equal("https://app.example.com"))
+	g.Expect(w.Header().Get("Access-Control-Allow-Methods")).To(gomega.Equal("GET, HEAD, POST"))
+	g.Expect(w.Header().Get("Access-Control-Allow-Headers")).To(gomega.Equal("content-type"))
+	g.Expect(w.Header().Get("Access-Control-Max-Age")).To(gomega.Equal("600"))
+	g.Expect(served).To(gomega.Equal(3))
+
+	for _, headers := range []map[string]string{
+		{"Origin": "https://evil.example.com", "Access-Control-Request-Method": http.MethodPost},
+		{"Origin": "https://app.example.com", "Access-Control-Request-Method": http.MethodDelete},
+		{"Origin": "https://app.example.com", "Access-Control-Request-Method": http.MethodPost,
+			"Access-Control-Request-Headers": "Authorization"},
+	} {
+		g.Expect(send(http.MethodOptions, headers).Code).To(gomega.Equal(http.StatusForbidden))
+	}
+	g.Expect(served).To(gomega.Equal(3))
+}
+
+func TestHandlerAnyOrigin(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	handler := NewHandler(Config{AllowOrigins: []string{AnyOrigin}, AllowHeaders: []string{AnyHeader}},
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	r := httptest.NewRequest(http.MethodOptions, "/v1/models/sklearn:predict", nil)
+	r.Header.Set("Origin", "https://app.example.com")
+	r.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	r.Header.Set("Access-Control-Request-Headers", "Authorization, X-Tenant-Id")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	g.Expect(w.Code).To(gomega.Equal(http.StatusNoContent))
+	g.Expect(w.Header().Get("Access-Control-Allow-Origin")).To(gomega.Equal(AnyOrigin))
+	g.Expect(w.Header().Get("Access-Control-Allow-Headers")).To(gomega.Equal("Authorization, X-Tenant-Id"))
+	g.Expect(w.Header().Get("Access-Control-Max-Age")).To(gomega.BeEmpty())
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package expression compiles and evaluates the Common Expression Language (CEL) expressions of the conditions of the
+InferenceGraph router, e.g.
+
+	body.instances[0].age >= 18 && headers["x-tenant"] in ["gold", "silver"]
+
+The expressions are evaluated with the JSON body of the request as `body` and the headers of the request with lower
+case names as `headers`, they must evaluate to a bool.
+*/
+package expression
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/interpreter"
+)
+
+const (
+	// BodyVariable is the JSON body of the request, it is null when the body is not JSON
+	BodyVariable = "body"
+	// HeadersVariable is the map of the headers of the request by lower case name, the values of a repeated header
+	// are joined with commas
+	HeadersVariable = "headers"
+)
+
+// env declares the variables of the expressions, the numbers of the JSON bodies are doubles so they can be compared
+// with the int literals
+var env, envErr = cel.NewEnv(
+	cel.Variable(BodyVariable, cel.DynType),
+	cel.Variable(HeadersVariable, cel.MapType(cel.StringType, cel.StringType)),
+	cel.CrossTypeNumericComparisons(true),
+)
+
+// Program is a compiled expression
+type Program struct {
+	source  string
+	program cel.Program
+}
+
+// Compile parses and type-checks the expression, the expressions which can not evaluate to a bool are rejected
+func Compile(source string) (*Program, error) {
+	if envErr != nil {
+		return nil, envErr
+	}
+	ast, issues := env.Compile(source)
+	if issues.Err() != nil {
+		return nil, fmt.Errorf("invalid expression %q: %w", source, issues.Err())
+	}
+	if outputType := ast.OutputType(); !outputType.IsAssignableType(cel.BoolType) {
+		return nil, fmt.Errorf("invalid expression %q: evaluates to %s, expected a bool", source, outputType)
+	}
+	// the regular expressions of the matches calls are compiled once with the program
+	program, err := env.Program(ast, cel.OptimizeRegex(interpreter.MatchesRegexOptimization))
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression %q: %w", source, err)
+	}
+	return &Program{source: source, program: program}, nil
+}
+
+// String returns the source of the expression
+func (p *Program) String() string {
+	return p.source
+}
+
+// Vars returns the variables of the expressions for the JSON body and the headers of a request
+func Vars(body interface{}, header http.Header) map[string]interface{} {
+	headers := make(map[string]string, len(header))
+	for name, values := range header {
+		headers[strings.ToLower(name)] = strings.Join(values, ",")
+	}
+	return map[string]interface{}{BodyVariable: body, HeadersVariable: headers}
+}
+
+// Matches evaluates the expression with the variables returned by Vars
+func (p *Program) Matches(vars map[string]interface{}) (bool, error) {
+	value, _, err := p.program.Eval(vars)
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate expression %q: %w", p.source, err)
+	}
+	matched, ok := value.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression %q evaluates to %s, expected a bool", p.source, value.Type().TypeName())
+	}
+	return matched, nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package expression
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestMatches(t *testing.T) {
+	var body interface{}
+	if err := json.Unmarshal([]byte(`{"instances": [{"age": 42, "name": "dog", "tags": ["a", "b"]}], "model": "resnet-v2"}`), &body); err != nil {
+		t.Fatal(err)
+	}
+	vars := Vars(body, http.Header{"X-Tenant": {"gold"}})
+	scenarios := map[string]struct {
+		expression string
+		matched    bool
+		err        bool
+	}{
+		"comparison":           {expression: `body.instances[0].age >= 18`, matched: true},
+		"mixed numeric types":  {expression: `body.instances[0].age == 42.0 && 41.5 < body.instances[0].age`, matched: true},
+		"header":               {expression: `headers["x-tenant"] == "gold"`, matched: true},
+		"in list":              {expression: `headers["x-tenant"] in ['silver', 'bronze']`, matched: false},
+		"in map":               {expression: `"model" in body`, matched: true},
+		"string methods":       {expression: `body.model.startsWith("resnet") && body.model.matches("-v[0-9]+$")`, matched: true},
+		"size":                 {expression: `size(body.instances[0].tags) == 2 && body.model.size() > 3`, matched: true},
+		"has":                  {expression: `has(body.instances[0].name) && !has(body.parameters)`, matched: true},
+		"arithmetic":           {expression: `(1 + 2) * 3 % 4 == 1 && -body.instances[0].age / 2.0 == -21.0`, matched: true},
+		"mixed arithmetic":     {expression: `body.instances[0].age / 2 == 21`, err: true},
+		"conditional":          {expression: `has(body.parameters) ? body.parameters.fast : body.model != ""`, matched: true},
+		"conversions":          {expression: `int("12") + int(2.7) == 14 && string(1) + "x" == "1x"`, matched: true},
+		"or absorbs error":     {expression: `body.missing == 1 || true`, matched: true},
+		"and absorbs error":    {expression: `false && body.missing == 1`, matched: false},
+		"missing field":        {expression: `body.missing == 1`, err: true},
+		"index out of range":   {expression: `body.instances[1].age > 0`, err: true},
+		"not a bool":           {expression: `body.model`, err: true},
+		"mismatched operands":  {expression: `body.model > 1`, err: true},
+		"list equality":        {expression: `body.instances[0].tags == ["a", "b"]`, matched: true},
+		"string escape":        {expression: `"a\"b".contains("\"")`, matched: true},
+		"double literal":       {expression: `1.5e1 == 15.0`, matched: true},
+		"macro":                {expression: `body.instances.exists(i, i.name == "dog")`, matched: true},
+		"division by zero":     {expression: `1 / 0 == 1`, err: true},
+		"negated header check": {expression: `!(headers["x-tenant"].endsWith("d"))`, matched: false},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			program, err := Compile(scenario.expression)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			matched, err := program.Matches(vars)
+			if scenario.err {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(matched).To(gomega.Equal(scenario.matched))
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	for _, source := range []string{
+		``,
+		`body.`,
+		`body.model ==`,
+		`(1 + 2`,
+		`"unterminated`,
+		`body.model = "x"`,
+		`1 2`,
+		`[1, 2`,
+		`a ? b`,
+		`"\q"`,
+		`request.model == "x"`,
+		`exists(body.model)`,
+		`headers["x-tenant"] == 1`,
+		`size(headers) + 1`,
+		`body.model.matches("[")`,
+	} {
+		_, err := Compile(source)
+		g.Expect(err).To(gomega.HaveOccurred(), source)
+	}
+}
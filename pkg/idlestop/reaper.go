@@ -0,0 +1,175 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idlestop
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+// Reaper periodically stops the InferenceServices that received no requests for the idle period of the idle stop
+// config, by setting their stop annotation. It only runs on the leader.
+type Reaper struct {
+	Client     client.Client
+	Config     *v1beta1.IdleStopConfig
+	HTTPClient *http.Client
+	Recorder   record.EventRecorder
+	Log        logr.Logger
+}
+
+// queryResponse is the subset of the response of the Prometheus instant query API used by the reaper
+type queryResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		Result []struct {
+			Value []interface{} `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// Start checks the traffic of the InferenceServices every interval until the context is done, the failed checks are
+// logged and retried at the next interval
+func (r *Reaper) Start(ctx context.Context) error {
+	ticker := time.NewTicker(time.Duration(r.Config.CheckIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+	for {
+		if err := r.Reap(ctx); err != nil {
+			r.Log.Error(err, "unable to stop the idle InferenceServices")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Reap stops the idle InferenceServices, an InferenceService is only stopped when it has been ready for the idle
+// period and Prometheus reports that it received no requests during that period
+func (r *Reaper) Reap(ctx context.Context) error {
+	isvcs := &v1beta1.InferenceServiceList{}
+	if err := r.Client.List(ctx, isvcs); err != nil {
+		return err
+	}
+	idlePeriod := time.Duration(r.Config.IdleSeconds) * time.Second
+	for i := range isvcs.Items {
+		isvc := &isvcs.Items[i]
+		if !r.isCandidate(isvc, idlePeriod) {
+			continue
+		}
+		requests, found, err := r.countRequests(ctx, isvc)
+		if err != nil {
+			r.Log.Error(err, "unable to query the requests of InferenceService", "namespace", isvc.Namespace, "name", isvc.Name)
+			continue
+		}
+		// the InferenceServices whose model servers do not export the request metrics are never stopped
+		if !found || requests > 0 {
+			continue
+		}
+		if err := r.stop(ctx, isvc, idlePeriod); err != nil {
+			r.Log.Error(err, "unable to stop idle InferenceService", "namespace", isvc.Namespace, "name", isvc.Name)
+		}
+	}
+	return nil
+}
+
+// isCandidate returns true if the InferenceService is running, not exempt and has been ready for the idle period
+func (r *Reaper) isCandidate(isvc *v1beta1.InferenceService, idlePeriod time.Duration) bool {
+	if isvc.Labels[constants.IdleStopExemptLabelKey] == "true" || utils.IsStopped(isvc.Annotations) ||
+		utils.IsPaused(isvc.Annotations) || !isvc.DeletionTimestamp.IsZero() {
+		return false
+	}
+	ready := isvc.Status.GetCondition(v1beta1.PredictorReady)
+	if ready == nil || ready.Status != corev1.ConditionTrue {
+		return false
+	}
+	return !ready.LastTransitionTime.Inner.Time.Add(idlePeriod).After(time.Now())
+}
+
+// countRequests returns the number of requests the model servers of the InferenceService received during the idle
+// period, found is false when Prometheus has no request metrics for the InferenceService
+func (r *Reaper) countRequests(ctx context.Context, isvc *v1beta1.InferenceService) (requests float64, found bool, err error) {
+	// the pods of the raw deployments and of the Knative revisions are prefixed by the name of the component
+	selector := fmt.Sprintf(`namespace="%s", pod=~"%s-(predictor|transformer|explainer)-.*"`, isvc.Namespace, isvc.Name)
+	query := fmt.Sprintf(`sum(increase({__name__=~"request_(predict|explain)_seconds_count", %s}[%ds]))`,
+		selector, r.Config.IdleSeconds)
+	endpoint := r.Config.PrometheusURL + "/api/v1/query?" + url.Values{"query": {query}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, false, err
+	}
+	resp, err := r.HTTPClient.Do(req)
+	if err != nil {
+		return 0, false, err
+	}
+	defer resp.Body.Close()
+	response := &queryResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return 0, false, fmt.Errorf("failed to decode the Prometheus response: %w", err)
+	}
+	if response.Status != "success" {
+		return 0, false, fmt.Errorf("prometheus query failed: %s", response.Error)
+	}
+	if len(response.Data.Result) == 0 {
+		return 0, false, nil
+	}
+	value := response.Data.Result[0].Value
+	if len(value) != 2 {
+		return 0, false, fmt.Errorf("unexpected Prometheus sample %v", value)
+	}
+	sample, ok := value[1].(string)
+	if !ok {
+		return 0, false, fmt.Errorf("unexpected Prometheus sample %v", value)
+	}
+	requests, err = strconv.ParseFloat(sample, 64)
+	if err != nil {
+		return 0, false, err
+	}
+	return requests, true, nil
+}
+
+// stop sets the stop annotation of the InferenceService and records an event
+func (r *Reaper) stop(ctx context.Context, isvc *v1beta1.InferenceService, idlePeriod time.Duration) error {
+	patch := client.MergeFrom(isvc.DeepCopy())
+	if isvc.Annotations == nil {
+		isvc.Annotations = map[string]string{}
+	}
+	isvc.Annotations[constants.StopAnnotationKey] = "true"
+	if err := r.Client.Patch(ctx, isvc, patch); err != nil {
+		return err
+	}
+	r.Log.Info("Stopped idle InferenceService", "namespace", isvc.Namespace, "name", isvc.Name)
+	r.Recorder.Eventf(isvc, corev1.EventTypeNormal, "IdleStopped",
+		"No requests were received for %s, the InferenceService is stopped until the %s annotation is removed",
+		idlePeriod, constants.StopAnnotationKey)
+	return nil
+}
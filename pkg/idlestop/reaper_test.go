@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idlestop
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestReap(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	// the requests received by each InferenceService, the ones missing have no request metrics
+	requests := map[string]string{"idle": "0", "busy": "12", "exempt": "0", "recent": "0"}
+	prometheus := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		query := req.URL.Query().Get("query")
+		g.Expect(query).To(gomega.ContainSubstring("[604800s]"))
+		for name, count := range requests {
+			if strings.Contains(query, fmt.Sprintf(`pod=~"%s-(predictor|transformer|explainer)-.*"`, name)) {
+				fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"%s"]}]}}`, count)
+				return
+			}
+		}
+		fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[]}}`)
+	}))
+	defer prometheus.Close()
+
+	isvc := func(name string, readySince time.Duration, labels map[string]string) *v1beta1.InferenceService {
+		isvc := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels}}
+		// the condition manager sets the last transition time to now
+		isvc.Status.Conditions = duckv1.Conditions{{
+			Type:               v1beta1.PredictorReady,
+			Status:             v1.ConditionTrue,
+			LastTransitionTime: apis.VolatileTime{Inner: metav1.NewTime(time.Now().Add(-readySince))},
+		}}
+		return isvc
+	}
+	week := 7 * 24 * time.Hour
+	scheme := runtime.NewScheme()
+	g.Expect(v1beta1.AddToScheme(scheme)).To(gomega.Succeed())
+	client := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(
+		isvc("idle", 2*week, nil),
+		isvc("busy", 2*week, nil),
+		isvc("exempt", 2*week, map[string]string{constants.IdleStopExemptLabelKey: "true"}),
+		isvc("recent", time.Hour, nil),
+		isvc("custom", 2*week, nil),
+	).Build()
+	recorder := record.NewFakeRecorder(10)
+	reaper := &Reaper{
+		Client: client,
+		Config: &v1beta1.IdleStopConfig{
+			Enabled:              true,
+			PrometheusURL:        prometheus.URL,
+			IdleSeconds:          v1beta1.DefaultIdleSeconds,
+			CheckIntervalSeconds: v1beta1.DefaultIdleCheckIntervalSeconds,
+		},
+		HTTPClient: http.DefaultClient,
+		Recorder:   recorder,
+		Log:        logr.Discard(),
+	}
+	g.Expect(reaper.Reap(context.TODO())).To(gomega.Succeed())
+
+	for name, stopped := range map[string]bool{"idle": true, "busy": false, "exempt": false, "recent": false, "custom": false} {
+		actual := &v1beta1.InferenceService{}
+		g.Expect(client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: name}, actual)).To(gomega.Succeed())
+		g.Expect(actual.Annotations[constants.StopAnnotationKey] == "true").To(gomega.Equal(stopped), name)
+	}
+	g.Expect(recorder.Events).To(gomega.HaveLen(1))
+	g.Expect(<-recorder.Events).To(gomega.ContainSubstring("IdleStopped"))
+}
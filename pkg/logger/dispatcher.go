@@ -22,28 +22,24 @@ import (
 
 var WorkerQueue chan chan LogRequest
 
-func StartDispatcher(nworkers int, logger *zap.SugaredLogger) {
+func StartDispatcher(nworkers int, maxRetries int, logger *zap.SugaredLogger) {
 	// First, initialize the channel we are going to but the workers' work channels into.
 	WorkerQueue = make(chan chan LogRequest, nworkers)
 
 	// Now, create all of our workers.
 	for i := 0; i < nworkers; i++ {
 		logger.Info("Starting worker ", i+1)
-		worker := NewWorker(i+1, WorkerQueue, logger)
+		worker := NewWorker(i+1, WorkerQueue, maxRetries, logger)
 		worker.Start()
 	}
 
-	// nolint: gosimple
+	// The work requests are only taken from the queue once a worker is available, so that the queue bounds the
+	// log events waiting to be sent.
 	go func() {
-		for {
-			select {
-			case work := <-WorkQueue:
-				go func() {
-					worker := <-WorkerQueue
-
-					worker <- work
-				}()
-			}
+		for work := range WorkQueue {
+			worker := <-WorkerQueue
+
+			worker <- work
 		}
 	}()
 }
@@ -18,6 +18,7 @@ package logger
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -36,6 +37,7 @@ type LoggerHandler struct {
 	logUrl           *url.URL
 	sourceUri        *url.URL
 	logMode          v1beta1.LoggerType
+	deliveryMode     v1beta1.LoggerDeliveryMode
 	inferenceService string
 	namespace        string
 	component        string
@@ -43,7 +45,7 @@ type LoggerHandler struct {
 	next             http.Handler
 }
 
-func New(logUrl *url.URL, sourceUri *url.URL, logMode v1beta1.LoggerType,
+func New(logUrl *url.URL, sourceUri *url.URL, logMode v1beta1.LoggerType, deliveryMode v1beta1.LoggerDeliveryMode,
 	inferenceService string, namespace string, endpoint string, component string, next http.Handler) http.Handler {
 	logf.SetLogger(zap.New())
 	return &LoggerHandler{
@@ -51,6 +53,7 @@ func New(logUrl *url.URL, sourceUri *url.URL, logMode v1beta1.LoggerType,
 		logUrl:           logUrl,
 		sourceUri:        sourceUri,
 		logMode:          logMode,
+		deliveryMode:     deliveryMode,
 		inferenceService: inferenceService,
 		namespace:        namespace,
 		component:        component,
@@ -67,6 +70,25 @@ func getOrCreateID(r *http.Request) string {
 	return id
 }
 
+// queue queues the log event according to the delivery mode, the events which do not fit in the queue are
+// spilled to disk or dropped
+func (eh *LoggerHandler) queue(req LogRequest) error {
+	if eh.deliveryMode == v1beta1.LogDeliveryBackPressure {
+		WaitQueueLogRequest(req)
+		return nil
+	}
+	err := QueueLogRequest(req)
+	if errors.Is(err, ErrQueueFull) {
+		if eh.deliveryMode == v1beta1.LogDeliverySpill {
+			if err = SpillLogRequest(req); err == nil {
+				return nil
+			}
+		}
+		eventCount.WithLabelValues(eventDropped).Inc()
+	}
+	return err
+}
+
 // call svc and add send request/responses to logUrl
 func (eh *LoggerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if network.IsKubeletProbe(r) {
@@ -75,6 +97,11 @@ func (eh *LoggerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
+	if eh.deliveryMode == v1beta1.LogDeliveryBackPressure && QueueFull() {
+		eh.log.Info("Rejecting request, the logger queue is full")
+		http.Error(w, ErrQueueFull.Error(), http.StatusServiceUnavailable)
+		return
+	}
 	// Read Payload
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
@@ -87,7 +114,7 @@ func (eh *LoggerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	contentType := r.Header.Get("Content-Type")
 	// log Request
 	if eh.logMode == v1beta1.LogAll || eh.logMode == v1beta1.LogRequest {
-		if err := QueueLogRequest(LogRequest{
+		if err := eh.queue(LogRequest{
 			Url:              eh.logUrl,
 			Bytes:            &body,
 			ContentType:      contentType,
@@ -115,7 +142,7 @@ func (eh *LoggerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// log response if OK
 	if rr.Code == http.StatusOK {
 		if eh.logMode == v1beta1.LogAll || eh.logMode == v1beta1.LogResponse {
-			if err := QueueLogRequest(LogRequest{
+			if err := eh.queue(LogRequest{
 				Url:              eh.logUrl,
 				Bytes:            &responseBody,
 				ContentType:      contentType,
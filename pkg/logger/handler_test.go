@@ -27,6 +27,7 @@ import (
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	pkglogging "knative.dev/pkg/logging"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -76,9 +77,9 @@ func TestLogger(t *testing.T) {
 	targetUri, err := url.Parse(predictor.URL)
 	g.Expect(err).To(gomega.BeNil())
 
-	StartDispatcher(5, logger)
+	StartDispatcher(5, 0, logger)
 	httpProxy := httputil.NewSingleHostReverseProxy(targetUri)
-	oh := New(logSvcUrl, sourceUri, v1beta1.LogAll, "mymodel", "default", "default", "default", httpProxy)
+	oh := New(logSvcUrl, sourceUri, v1beta1.LogAll, v1beta1.LogDeliveryBestEffort, "mymodel", "default", "default", "default", httpProxy)
 
 	oh.ServeHTTP(w, r)
 
@@ -119,11 +120,53 @@ func TestBadResponse(t *testing.T) {
 	targetUri, err := url.Parse(predictor.URL)
 	g.Expect(err).To(gomega.BeNil())
 
-	StartDispatcher(1, logger)
+	StartDispatcher(1, 0, logger)
 	httpProxy := httputil.NewSingleHostReverseProxy(targetUri)
-	oh := New(logSvcUrl, sourceUri, v1beta1.LogAll, "mymodel", "default", "default", "default", httpProxy)
+	oh := New(logSvcUrl, sourceUri, v1beta1.LogAll, v1beta1.LogDeliveryBestEffort, "mymodel", "default", "default", "default", httpProxy)
 
 	oh.ServeHTTP(w, r)
 	g.Expect(w.Code).To(gomega.Equal(400))
 	g.Expect(w.Body.String()).To(gomega.Equal(predictorResponse))
 }
+
+func TestDeliveryModes(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logf.SetLogger(zap.New())
+	predictorRequest := []byte(`{"instances":[[0,0,0]]}`)
+	predictorResponse := []byte(`{"predictions":[1]}`)
+	predictorCalls := 0
+	predictor := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		predictorCalls++
+		_, err := rw.Write(predictorResponse)
+		g.Expect(err).To(gomega.BeNil())
+	})
+	logSvcUrl, err := url.Parse("http://loggersvc")
+	g.Expect(err).To(gomega.BeNil())
+	sourceUri, err := url.Parse("http://localhost:9081/")
+	g.Expect(err).To(gomega.BeNil())
+
+	// a full queue which is not drained by a dispatcher
+	workQueue, depth := WorkQueue, testutil.ToFloat64(queueDepth)
+	WorkQueue = make(chan LogRequest, 1)
+	defer func() {
+		WorkQueue = workQueue
+		queueDepth.Set(depth)
+	}()
+	g.Expect(QueueLogRequest(LogRequest{})).To(gomega.Succeed())
+
+	// the log events are dropped and the request is served
+	dropped := testutil.ToFloat64(eventCount.WithLabelValues(eventDropped))
+	oh := New(logSvcUrl, sourceUri, v1beta1.LogAll, v1beta1.LogDeliveryBestEffort, "mymodel", "default", "default", "default", predictor)
+	w := httptest.NewRecorder()
+	oh.ServeHTTP(w, httptest.NewRequest("POST", "http://a", bytes.NewReader(predictorRequest)))
+	g.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(w.Body.Bytes()).To(gomega.Equal(predictorResponse))
+	g.Expect(testutil.ToFloat64(eventCount.WithLabelValues(eventDropped))).To(gomega.Equal(dropped + 2))
+
+	// the request is rejected
+	oh = New(logSvcUrl, sourceUri, v1beta1.LogAll, v1beta1.LogDeliveryBackPressure, "mymodel", "default", "default", "default", predictor)
+	w = httptest.NewRecorder()
+	oh.ServeHTTP(w, httptest.NewRequest("POST", "http://a", bytes.NewReader(predictorRequest)))
+	g.Expect(w.Code).To(gomega.Equal(http.StatusServiceUnavailable))
+	g.Expect(predictorCalls).To(gomega.Equal(1))
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	eventSent    = "sent"
+	eventDropped = "dropped"
+	eventSpilled = "spilled"
+)
+
+var (
+	queueDepth = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "kserve_agent_logger_queue_depth",
+		Help: "Number of log events queued and not yet sent",
+	})
+	eventCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kserve_agent_logger_events_total",
+		Help: "Number of log events by result, an event is dropped when it does not fit in the queue or can not be delivered after the retries",
+	}, []string{"result"})
+	spillSize = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "kserve_agent_logger_spill_bytes",
+		Help: "Size of the log events spilled to disk and not yet queued back",
+	})
+	retryCount = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "kserve_agent_logger_retries_total",
+		Help: "Number of retried log event deliveries",
+	})
+)
+
+// RegisterMetrics registers the metrics of the logger queue and workers
+func RegisterMetrics(registerer prometheus.Registerer) {
+	registerer.MustRegister(queueDepth, eventCount, spillSize, retryCount)
+}
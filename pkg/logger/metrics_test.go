@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+func TestWorkerMetrics(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	attempts := 0
+	logSvc := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		attempts++
+		rw.WriteHeader(http.StatusAccepted)
+	}))
+	defer logSvc.Close()
+	logSvcUrl, err := url.Parse(logSvc.URL)
+	g.Expect(err).To(gomega.BeNil())
+	unavailableUrl, err := url.Parse("http://127.0.0.1:1")
+	g.Expect(err).To(gomega.BeNil())
+	sourceUri, err := url.Parse("http://localhost:9081/")
+	g.Expect(err).To(gomega.BeNil())
+	body := []byte(`{"instances":[[0,0,0]]}`)
+
+	// wait for the events of the other tests to be sent so that they do not change the counters
+	g.Eventually(func() float64 { return testutil.ToFloat64(queueDepth) }, "10s").Should(gomega.BeZero())
+	sent := testutil.ToFloat64(eventCount.WithLabelValues(eventSent))
+	dropped := testutil.ToFloat64(eventCount.WithLabelValues(eventDropped))
+	retries := testutil.ToFloat64(retryCount)
+
+	worker := NewWorker(1, nil, 2, logger)
+	queueDepth.Inc()
+	worker.send(LogRequest{Url: logSvcUrl, Bytes: &body, ContentType: "application/json", ReqType: CEInferenceRequest,
+		Id: "1", SourceUri: sourceUri})
+	g.Expect(attempts).To(gomega.Equal(1))
+	g.Expect(testutil.ToFloat64(eventCount.WithLabelValues(eventSent))).To(gomega.Equal(sent + 1))
+
+	// an event which can not be delivered is retried before being dropped
+	queueDepth.Inc()
+	worker.send(LogRequest{Url: unavailableUrl, Bytes: &body, ContentType: "application/json", ReqType: CEInferenceRequest,
+		Id: "2", SourceUri: sourceUri})
+	g.Expect(testutil.ToFloat64(eventCount.WithLabelValues(eventDropped))).To(gomega.Equal(dropped + 1))
+	g.Expect(testutil.ToFloat64(retryCount)).To(gomega.Equal(retries + 2))
+	g.Expect(testutil.ToFloat64(queueDepth)).To(gomega.BeZero())
+}
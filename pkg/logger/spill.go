@@ -0,0 +1,239 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	spillFileSuffix = ".json"
+	// SpillInterval is the interval the spilled log events are queued back at
+	SpillInterval = time.Second
+)
+
+var (
+	// ErrSpillFull is returned when a log event does not fit in the spill directory
+	ErrSpillFull = errors.New("logger spill directory is full")
+
+	spiller *Spiller
+)
+
+// spilledRequest is a log event as written to the spill directory
+type spilledRequest struct {
+	Url              string `json:"url"`
+	Bytes            []byte `json:"bytes"`
+	ContentType      string `json:"contentType"`
+	ReqType          string `json:"reqType"`
+	Id               string `json:"id"`
+	SourceUri        string `json:"sourceUri"`
+	InferenceService string `json:"inferenceService"`
+	Namespace        string `json:"namespace"`
+	Component        string `json:"component"`
+	Endpoint         string `json:"endpoint"`
+}
+
+// Spiller writes the log events which do not fit in the work queue to a directory, and queues them back in the
+// order they were written once the queue has room. The spilled events survive a restart of the agent as long as
+// the directory does.
+type Spiller struct {
+	dir      string
+	maxBytes int64
+	log      *zap.SugaredLogger
+	mutex    sync.Mutex
+	size     int64
+	sequence uint64
+}
+
+func NewSpiller(dir string, maxBytes int64, logger *zap.SugaredLogger) (*Spiller, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("while creating spill directory: %w", err)
+	}
+	s := &Spiller{
+		dir:      dir,
+		maxBytes: maxBytes,
+		log:      logger,
+	}
+	files, err := s.files()
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		if info, err := os.Stat(file); err == nil {
+			s.size += info.Size()
+		}
+	}
+	spillSize.Set(float64(s.size))
+	return s, nil
+}
+
+// StartSpiller starts queueing back the log events spilled to the directory, the log events which do not fit in
+// the work queue are then spilled with SpillLogRequest
+func StartSpiller(dir string, maxBytes int64, logger *zap.SugaredLogger) error {
+	s, err := NewSpiller(dir, maxBytes, logger)
+	if err != nil {
+		return err
+	}
+	spiller = s
+	go func() {
+		for range time.Tick(SpillInterval) {
+			s.Requeue()
+		}
+	}()
+	return nil
+}
+
+// SpillLogRequest writes the log event to the spill directory started with StartSpiller
+func SpillLogRequest(req LogRequest) error {
+	if spiller == nil {
+		return errors.New("logger spill is not started")
+	}
+	return spiller.Spill(req)
+}
+
+// Spill writes the log event to the directory, it returns ErrSpillFull when the directory holds maxBytes already
+func (s *Spiller) Spill(req LogRequest) error {
+	spilled := spilledRequest{
+		ContentType:      req.ContentType,
+		ReqType:          req.ReqType,
+		Id:               req.Id,
+		InferenceService: req.InferenceService,
+		Namespace:        req.Namespace,
+		Component:        req.Component,
+		Endpoint:         req.Endpoint,
+	}
+	if req.Url != nil {
+		spilled.Url = req.Url.String()
+	}
+	if req.SourceUri != nil {
+		spilled.SourceUri = req.SourceUri.String()
+	}
+	if req.Bytes != nil {
+		spilled.Bytes = *req.Bytes
+	}
+	content, err := json.Marshal(spilled)
+	if err != nil {
+		return fmt.Errorf("while marshalling log event: %w", err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.size+int64(len(content)) > s.maxBytes {
+		return ErrSpillFull
+	}
+	s.sequence++
+	name := filepath.Join(s.dir, fmt.Sprintf("%020d-%010d%s", time.Now().UnixNano(), s.sequence, spillFileSuffix))
+	// the file is renamed once written so that a partially written event is never queued back
+	if err := os.WriteFile(name+".tmp", content, 0o600); err != nil {
+		return fmt.Errorf("while writing log event: %w", err)
+	}
+	if err := os.Rename(name+".tmp", name); err != nil {
+		return fmt.Errorf("while writing log event: %w", err)
+	}
+	s.size += int64(len(content))
+	spillSize.Set(float64(s.size))
+	eventCount.WithLabelValues(eventSpilled).Inc()
+	return nil
+}
+
+// Requeue queues back the spilled log events until the work queue is full
+func (s *Spiller) Requeue() {
+	files, err := s.files()
+	if err != nil {
+		s.log.Errorw("Failed to list spilled log events", zap.Error(err))
+		return
+	}
+	for _, file := range files {
+		if QueueFull() {
+			return
+		}
+		req, size, err := readSpilledRequest(file)
+		if err != nil {
+			// an event which can not be read is dropped rather than blocking the ones spilled after it
+			s.log.Errorw("Failed to read spilled log event", "file", file, zap.Error(err))
+			eventCount.WithLabelValues(eventDropped).Inc()
+		} else if err := QueueLogRequest(req); err != nil {
+			return
+		}
+		if err := os.Remove(file); err != nil {
+			s.log.Errorw("Failed to remove spilled log event", "file", file, zap.Error(err))
+		}
+		s.mutex.Lock()
+		s.size -= size
+		spillSize.Set(float64(s.size))
+		s.mutex.Unlock()
+	}
+}
+
+// files returns the spilled log events in the order they were written
+func (s *Spiller) files() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("while reading spill directory: %w", err)
+	}
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), spillFileSuffix) {
+			files = append(files, filepath.Join(s.dir, entry.Name()))
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func readSpilledRequest(file string) (LogRequest, int64, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return LogRequest{}, 0, err
+	}
+	size := int64(len(content))
+	spilled := spilledRequest{}
+	if err := json.Unmarshal(content, &spilled); err != nil {
+		return LogRequest{}, size, err
+	}
+	logUrl, err := url.Parse(spilled.Url)
+	if err != nil {
+		return LogRequest{}, size, err
+	}
+	sourceUri, err := url.Parse(spilled.SourceUri)
+	if err != nil {
+		return LogRequest{}, size, err
+	}
+	return LogRequest{
+		Url:              logUrl,
+		Bytes:            &spilled.Bytes,
+		ContentType:      spilled.ContentType,
+		ReqType:          spilled.ReqType,
+		Id:               spilled.Id,
+		SourceUri:        sourceUri,
+		InferenceService: spilled.InferenceService,
+		Namespace:        spilled.Namespace,
+		Component:        spilled.Component,
+		Endpoint:         spilled.Endpoint,
+	}, size, nil
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+func TestSpiller(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	// a full queue which is not drained by a dispatcher
+	workQueue, depth := WorkQueue, testutil.ToFloat64(queueDepth)
+	WorkQueue = make(chan LogRequest, 1)
+	defer func() {
+		WorkQueue = workQueue
+		queueDepth.Set(depth)
+	}()
+	g.Expect(QueueLogRequest(LogRequest{Id: "queued"})).To(gomega.Succeed())
+	g.Expect(QueueLogRequest(LogRequest{Id: "full"})).To(gomega.MatchError(ErrQueueFull))
+
+	dir := t.TempDir()
+	logUrl, _ := url.Parse("http://message-dumper.default")
+	sourceUri, _ := url.Parse("http://localhost:9081/")
+	newRequest := func(id string) LogRequest {
+		body := []byte(`{"instances":[[0,0,0]]}`)
+		return LogRequest{Url: logUrl, Bytes: &body, ContentType: "application/json", ReqType: CEInferenceRequest,
+			Id: id, SourceUri: sourceUri, InferenceService: "sklearn", Namespace: "default"}
+	}
+	spiller, err := NewSpiller(dir, 800, logger)
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(spiller.Spill(newRequest("1"))).To(gomega.Succeed())
+	g.Expect(spiller.Spill(newRequest("2"))).To(gomega.Succeed())
+	g.Expect(spiller.Spill(newRequest("3"))).To(gomega.MatchError(ErrSpillFull))
+
+	// the spilled events are kept until the queue has room
+	spiller.Requeue()
+	entries, err := os.ReadDir(dir)
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(entries).To(gomega.HaveLen(2))
+
+	// the size of the events spilled before a restart is recovered
+	restarted, err := NewSpiller(dir, 800, logger)
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(restarted.size).To(gomega.Equal(spiller.size))
+
+	g.Expect((<-WorkQueue).Id).To(gomega.Equal("queued"))
+	restarted.Requeue()
+	requeued := <-WorkQueue
+	g.Expect(requeued).To(gomega.Equal(newRequest("1")))
+	restarted.Requeue()
+	g.Expect((<-WorkQueue).Id).To(gomega.Equal("2"))
+	entries, err = os.ReadDir(dir)
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(entries).To(gomega.BeEmpty())
+	g.Expect(restarted.size).To(gomega.BeZero())
+}
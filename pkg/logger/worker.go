@@ -18,6 +18,7 @@ package logger
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
@@ -43,19 +44,41 @@ const (
 // A buffered channel that we can send work requests on.
 var WorkQueue = make(chan LogRequest, LoggerWorkerQueueSize)
 
+// ErrQueueFull is returned when a log event does not fit in the work queue
+var ErrQueueFull = errors.New("logger queue is full")
+
+// QueueLogRequest queues the log event without blocking, it returns ErrQueueFull when the queue has no room
 func QueueLogRequest(req LogRequest) error {
+	queueDepth.Inc()
+	select {
+	case WorkQueue <- req:
+		return nil
+	default:
+		queueDepth.Dec()
+		return ErrQueueFull
+	}
+}
+
+// WaitQueueLogRequest queues the log event, it blocks until the queue has room
+func WaitQueueLogRequest(req LogRequest) {
+	queueDepth.Inc()
 	WorkQueue <- req
-	return nil
+}
+
+// QueueFull returns whether the work queue has no room for another log event
+func QueueFull() bool {
+	return len(WorkQueue) >= cap(WorkQueue)
 }
 
 // NewWorker creates, and returns a new Worker object. Its only argument
 // is a channel that the worker can add itself to whenever it is done its
 // work.
-func NewWorker(id int, workerQueue chan chan LogRequest, logger *zap.SugaredLogger) Worker {
+func NewWorker(id int, workerQueue chan chan LogRequest, maxRetries int, logger *zap.SugaredLogger) Worker {
 	// Create, and return the worker.
 	return Worker{
 		Log:         logger,
 		ID:          id,
+		MaxRetries:  maxRetries,
 		Work:        make(chan LogRequest),
 		WorkerQueue: workerQueue,
 		QuitChan:    make(chan bool),
@@ -66,6 +89,7 @@ func NewWorker(id int, workerQueue chan chan LogRequest, logger *zap.SugaredLogg
 type Worker struct {
 	Log         *zap.SugaredLogger
 	ID          int
+	MaxRetries  int
 	Work        chan LogRequest
 	WorkerQueue chan chan LogRequest
 	QuitChan    chan bool
@@ -101,12 +125,29 @@ func (w *Worker) sendCloudEvent(logReq LogRequest) error {
 		return fmt.Errorf("while setting cloudevents data: %w", err)
 	}
 
-	if result := c.Send(w.CeCtx, event); cloudevents.IsUndelivered(result) {
+	if result := c.Send(w.CeCtx, event); !cloudevents.IsACK(result) {
 		return fmt.Errorf("while sending event: %w", result)
 	}
 	return nil
 }
 
+// send delivers the log event, retrying up to MaxRetries times before dropping it
+func (w *Worker) send(logReq LogRequest) {
+	defer queueDepth.Dec()
+	var err error
+	for attempt := 0; attempt <= w.MaxRetries; attempt++ {
+		if attempt > 0 {
+			retryCount.Inc()
+		}
+		if err = w.sendCloudEvent(logReq); err == nil {
+			eventCount.WithLabelValues(eventSent).Inc()
+			return
+		}
+	}
+	eventCount.WithLabelValues(eventDropped).Inc()
+	w.Log.Error(err, "Failed to send cloud event, url: %s", logReq.Url.String())
+}
+
 // This function "starts" the worker by starting a goroutine, that is
 // an infinite "for-select" loop.
 func (w *Worker) Start() {
@@ -120,9 +161,7 @@ func (w *Worker) Start() {
 				// Receive a work request.
 				w.Log.Infof("Received work request %d, url: %s, requestId: %s", w.ID, work.Url.String(), work.Id)
 
-				if err := w.sendCloudEvent(work); err != nil {
-					w.Log.Error(err, "Failed to send cloud event, url: %s", work.Url.String())
-				}
+				w.send(work)
 
 			case <-w.QuitChan:
 				// We have been asked to stop.
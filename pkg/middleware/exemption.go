@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+// DefaultUnauthenticatedSourceCIDRs are the sources allowed to send requests without a bearer token when the
+// unauthenticated path does not list its own, the loopback and the private networks
+var DefaultUnauthenticatedSourceCIDRs = []string{"127.0.0.0/8", "::1/128", "10.0.0.0/8", "172.16.0.0/12",
+	"192.168.0.0/16", "fc00::/7"}
+
+// authExemption exempts the requests of the paths under the prefix from the bearer token when they are sent from
+// one of the sources
+type authExemption struct {
+	prefix  string
+	sources []*net.IPNet
+}
+
+func newAuthExemptions(paths []v1beta1.UnauthenticatedPath) ([]authExemption, error) {
+	exemptions := make([]authExemption, 0, len(paths))
+	for _, unauthenticated := range paths {
+		cidrs := unauthenticated.SourceCIDRs
+		if len(cidrs) == 0 {
+			cidrs = DefaultUnauthenticatedSourceCIDRs
+		}
+		exemption := authExemption{prefix: unauthenticated.Prefix}
+		for _, cidr := range cidrs {
+			_, source, err := net.ParseCIDR(cidr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid source CIDR %q of unauthenticated path %q: %w", cidr,
+					unauthenticated.Prefix, err)
+			}
+			exemption.sources = append(exemption.sources, source)
+		}
+		exemptions = append(exemptions, exemption)
+	}
+	return exemptions, nil
+}
+
+// isExempt returns true when the request can be proxied without a bearer token. Only the GET and HEAD requests of
+// clean paths are exempted, so that a path such as /v2/health/../models/llm/infer does not bypass the token.
+func isExempt(exemptions []authExemption, r *http.Request) bool {
+	if len(exemptions) == 0 || (r.Method != http.MethodGet && r.Method != http.MethodHead) {
+		return false
+	}
+	requestPath := r.URL.Path
+	if path.Clean(requestPath) != requestPath {
+		return false
+	}
+	source := sourceIP(r)
+	if source == nil {
+		return false
+	}
+	for _, exemption := range exemptions {
+		if requestPath != exemption.prefix && !strings.HasPrefix(requestPath, exemption.prefix+"/") {
+			continue
+		}
+		for _, allowed := range exemption.sources {
+			if allowed.Contains(source) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sourceIP returns the address of the client of the request. The agent receives the requests from the queue proxy
+// of its own pod, so the address appended to X-Forwarded-For by the proxy is used when the peer is the loopback.
+func sourceIP(r *http.Request) net.IP {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	peer := net.ParseIP(host)
+	if peer == nil || !peer.IsLoopback() {
+		return peer
+	}
+	forwarded := r.Header.Values("X-Forwarded-For")
+	if len(forwarded) == 0 {
+		return peer
+	}
+	addresses := strings.Split(forwarded[len(forwarded)-1], ",")
+	return net.ParseIP(strings.TrimSpace(addresses[len(addresses)-1]))
+}
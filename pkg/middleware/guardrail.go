@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+const (
+	// GuardrailStageHeader tells the hook whether the body it moderates is a request or a response
+	GuardrailStageHeader   = "X-Guardrail-Stage"
+	GuardrailStageRequest  = "request"
+	GuardrailStageResponse = "response"
+	// defaultGuardrailTimeout bounds the callout to the hooks without a timeout
+	defaultGuardrailTimeout = 5 * time.Second
+	// maxRejectionSize bounds the body of the rejections returned to the client
+	maxRejectionSize = 1 << 20
+)
+
+// GuardrailRejection is the response of a hook rejecting a request or a response, it is returned to the client
+type GuardrailRejection struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// GuardrailHook posts the bodies of the requests or of the responses to a moderation service
+type GuardrailHook struct {
+	url    string
+	policy v1beta1.GuardrailFailurePolicy
+	client *http.Client
+}
+
+func NewGuardrailHook(spec *v1beta1.GuardrailHook) *GuardrailHook {
+	timeout := defaultGuardrailTimeout
+	if spec.TimeoutSeconds != nil {
+		timeout = time.Duration(*spec.TimeoutSeconds) * time.Second
+	}
+	policy := spec.FailurePolicy
+	if policy == "" {
+		policy = v1beta1.GuardrailFailurePolicyFail
+	}
+	return &GuardrailHook{
+		url:    spec.URL,
+		policy: policy,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Check posts the body to the hook, it returns the rejection of the hook or nil when the hook allows the body. An
+// error is returned when the hook can not be called or fails, the failure policy decides whether the body is allowed.
+func (g *GuardrailHook) Check(ctx context.Context, stage string, contentType string, body []byte) (*GuardrailRejection, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(GuardrailStageHeader, stage)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach the guardrail hook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxRejectionSize))
+		return nil, nil
+	}
+	if resp.StatusCode < 400 || resp.StatusCode >= 500 {
+		return nil, fmt.Errorf("the guardrail hook returned %d", resp.StatusCode)
+	}
+	rejection, err := io.ReadAll(io.LimitReader(resp.Body, maxRejectionSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the response of the guardrail hook: %w", err)
+	}
+	return &GuardrailRejection{
+		StatusCode:  resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		Body:        rejection,
+	}, nil
+}
+
+// FailOpen returns whether the bodies are allowed when the hook fails
+func (g *GuardrailHook) FailOpen() bool {
+	return g.policy == v1beta1.GuardrailFailurePolicyIgnore
+}
+
+func writeRejection(w http.ResponseWriter, rejection *GuardrailRejection) {
+	if rejection.ContentType != "" {
+		w.Header().Set("Content-Type", rejection.ContentType)
+	}
+	w.WriteHeader(rejection.StatusCode)
+	_, _ = w.Write(rejection.Body)
+}
+
+// responseBuffer holds the response of the component until the guardrail hook allows it
+type responseBuffer struct {
+	header     http.Header
+	statusCode int
+	body       bytes.Buffer
+}
+
+func newResponseBuffer() *responseBuffer {
+	return &responseBuffer{header: http.Header{}, statusCode: http.StatusOK}
+}
+
+func (b *responseBuffer) Header() http.Header {
+	return b.header
+}
+
+func (b *responseBuffer) WriteHeader(statusCode int) {
+	b.statusCode = statusCode
+}
+
+func (b *responseBuffer) Write(p []byte) (int, error) {
+	return b.body.Write(p)
+}
+
+// writeTo writes the buffered response to the client
+func (b *responseBuffer) writeTo(w http.ResponseWriter) {
+	for name, values := range b.header {
+		w.Header()[name] = values
+	}
+	w.WriteHeader(b.statusCode)
+	_, _ = w.Write(b.body.Bytes())
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"go.uber.org/zap"
+	"knative.dev/pkg/network"
+)
+
+// MiddlewareHandler applies the middleware declared on the component to the requests proxied by the agent: the bearer
+// token of the requests is reviewed by the API server and exchanged for a token accepted by the component, the
+// authenticated requests and their responses are moderated by the guardrail hooks, their headers are transformed and
+// the token usage of the responses is accounted.
+type MiddlewareHandler struct {
+	log              *zap.SugaredLogger
+	requestHeaders   *v1beta1.HeaderTransform
+	responseHeaders  *v1beta1.HeaderTransform
+	reviewer         *TokenReviewer
+	reviewExemptions []authExemption
+	exchanger        *TokenExchanger
+	exemptions       []authExemption
+	requestHook      *GuardrailHook
+	responseHook     *GuardrailHook
+	tokenUsage       *v1beta1.TokenUsageSpec
+	next             http.Handler
+}
+
+// New returns the handler of the middleware, the reviewer validates the tokens of the requests when the middleware
+// declares a token review
+func New(middleware *v1beta1.AgentMiddleware, clientID string, clientSecret string, reviewer *TokenReviewer,
+	next http.Handler, logger *zap.SugaredLogger) *MiddlewareHandler {
+	h := &MiddlewareHandler{
+		log:             logger,
+		requestHeaders:  middleware.RequestHeaders,
+		responseHeaders: middleware.ResponseHeaders,
+		tokenUsage:      middleware.TokenUsage,
+		next:            next,
+	}
+	if middleware.TokenReview != nil {
+		h.reviewer = reviewer
+		exemptions, err := newAuthExemptions(middleware.TokenReview.UnauthenticatedPaths)
+		if err != nil {
+			// Every request requires a token rather than exempting the paths of the wrong sources
+			logger.Errorw("Ignoring the unauthenticated paths", zap.Error(err))
+		}
+		h.reviewExemptions = exemptions
+	}
+	if middleware.TokenExchange != nil {
+		h.exchanger = NewTokenExchanger(middleware.TokenExchange, clientID, clientSecret)
+		exemptions, err := newAuthExemptions(middleware.TokenExchange.UnauthenticatedPaths)
+		if err != nil {
+			// Every request requires a token rather than exempting the paths of the wrong sources
+			logger.Errorw("Ignoring the unauthenticated paths", zap.Error(err))
+		}
+		h.exemptions = exemptions
+	}
+	if guardrail := middleware.Guardrail; guardrail != nil {
+		if guardrail.Request != nil {
+			h.requestHook = NewGuardrailHook(guardrail.Request)
+		}
+		if guardrail.Response != nil {
+			h.responseHook = NewGuardrailHook(guardrail.Response)
+		}
+	}
+	return h
+}
+
+// transformHeaders removes, sets and adds the headers of the transform, in that order
+func transformHeaders(header http.Header, transform *v1beta1.HeaderTransform) {
+	if transform == nil {
+		return
+	}
+	for _, name := range transform.Remove {
+		header.Del(name)
+	}
+	for name, value := range transform.Set {
+		header.Set(name, value)
+	}
+	for name, value := range transform.Add {
+		header.Add(name, value)
+	}
+}
+
+// headerWriter transforms the headers of the response before they are written
+type headerWriter struct {
+	http.ResponseWriter
+	transform   *v1beta1.HeaderTransform
+	wroteHeader bool
+}
+
+func (w *headerWriter) WriteHeader(statusCode int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		transformHeaders(w.ResponseWriter.Header(), w.transform)
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *headerWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *headerWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (h *MiddlewareHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if network.IsKubeletProbe(r) {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+
+	r = r.Clone(r.Context())
+	if h.reviewer != nil {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		switch {
+		case ok && token != "":
+			user, err := h.reviewer.Review(r.Context(), token)
+			if err != nil {
+				h.log.Infow("Rejecting the token of the request", zap.Error(err))
+				http.Error(w, err.Error(), statusCode(err))
+				return
+			}
+			r = r.WithContext(ContextWithUser(r.Context(), user))
+		case !isExempt(h.reviewExemptions, r):
+			http.Error(w, "a bearer token is required", http.StatusUnauthorized)
+			return
+		}
+	}
+	if h.exchanger != nil {
+		subjectToken, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		switch {
+		case ok && subjectToken != "":
+			token, err := h.exchanger.Exchange(r.Context(), subjectToken)
+			if err != nil {
+				h.log.Errorw("Failed to exchange the token of the request", zap.Error(err))
+				http.Error(w, err.Error(), statusCode(err))
+				return
+			}
+			r.Header.Set("Authorization", "Bearer "+token)
+		case !isExempt(h.exemptions, r):
+			http.Error(w, "a bearer token is required", http.StatusUnauthorized)
+			return
+		}
+	}
+	// the requests are only moderated once authenticated, so that the hook is never called for anonymous requests
+	if h.requestHook != nil {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "failed to read the request", http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		if !h.moderate(w, r, h.requestHook, GuardrailStageRequest, r.Header.Get("Content-Type"), body) {
+			return
+		}
+	}
+	transformHeaders(r.Header, h.requestHeaders)
+
+	if h.responseHeaders != nil {
+		w = &headerWriter{ResponseWriter: w, transform: h.responseHeaders}
+	}
+	if h.tokenUsage != nil {
+		usage := newUsageWriter(w, h.tokenUsage.Headers)
+		defer usage.finish()
+		w = usage
+	}
+	if h.responseHook == nil {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+	response := newResponseBuffer()
+	h.next.ServeHTTP(response, r)
+	if response.statusCode >= 200 && response.statusCode < 300 &&
+		!h.moderate(w, r, h.responseHook, GuardrailStageResponse, response.header.Get("Content-Type"), response.body.Bytes()) {
+		return
+	}
+	response.writeTo(w)
+}
+
+// moderate checks the body with the guardrail hook, it writes the rejection of the hook, or the failure of the hook
+// when it is fail-closed, and returns false when the request must not proceed
+func (h *MiddlewareHandler) moderate(w http.ResponseWriter, r *http.Request, hook *GuardrailHook, stage string,
+	contentType string, body []byte) bool {
+	rejection, err := hook.Check(r.Context(), stage, contentType, body)
+	if err != nil {
+		if hook.FailOpen() {
+			h.log.Warnw("The guardrail hook failed, the "+stage+" is allowed", zap.Error(err))
+			return true
+		}
+		h.log.Errorw("The guardrail hook failed, the "+stage+" is rejected", zap.Error(err))
+		http.Error(w, "the guardrail hook is unavailable", http.StatusServiceUnavailable)
+		return false
+	}
+	if rejection != nil {
+		writeRejection(w, rejection)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,468 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+func TestHeaderTransforms(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	var received http.Header
+	predictor := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		w.Header().Set("Server", "model-server")
+		w.Header().Set("X-Model-Version", "1")
+		_, _ = w.Write([]byte(`{"predictions":[1]}`))
+	})
+	handler := New(&v1beta1.AgentMiddleware{
+		RequestHeaders: &v1beta1.HeaderTransform{
+			Set:    map[string]string{"X-Tenant": "team-a"},
+			Add:    map[string]string{"X-Forwarded-Client": "agent"},
+			Remove: []string{"Cookie", "X-Tenant"},
+		},
+		ResponseHeaders: &v1beta1.HeaderTransform{
+			Set:    map[string]string{"X-Model-Version": "2"},
+			Remove: []string{"Server"},
+		},
+	}, "", "", nil, predictor, logger)
+
+	r := httptest.NewRequest(http.MethodPost, "/v1/models/test:predict", strings.NewReader(`{"instances":[1]}`))
+	r.Header.Set("Cookie", "session=1")
+	r.Header.Set("X-Tenant", "spoofed")
+	r.Header.Set("X-Forwarded-Client", "gateway")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	g.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(received.Get("Cookie")).To(gomega.BeEmpty())
+	g.Expect(received.Values("X-Tenant")).To(gomega.Equal([]string{"team-a"}))
+	g.Expect(received.Values("X-Forwarded-Client")).To(gomega.Equal([]string{"gateway", "agent"}))
+	g.Expect(w.Header().Get("Server")).To(gomega.BeEmpty())
+	g.Expect(w.Header().Get("X-Model-Version")).To(gomega.Equal("2"))
+	// The headers of the original request are not modified
+	g.Expect(r.Header.Get("Cookie")).To(gomega.Equal("session=1"))
+}
+
+func TestTokenExchange(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	exchanges := 0
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		exchanges++
+		clientID, clientSecret, _ := r.BasicAuth()
+		if clientID != "agent" || clientSecret != "secret" {
+			http.Error(w, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+			return
+		}
+		_ = r.ParseForm()
+		if r.Form.Get("grant_type") != TokenExchangeGrantType || r.Form.Get("subject_token") != "user-token" {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		g.Expect(r.Form.Get("audience")).To(gomega.Equal("model-server"))
+		g.Expect(r.Form.Get("scope")).To(gomega.Equal("predict explain"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"exchanged-token","issued_token_type":"` + AccessTokenType +
+			`","token_type":"Bearer","expires_in":60}`))
+	}))
+	defer tokenServer.Close()
+
+	var authorization string
+	predictor := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"predictions":[1]}`))
+	})
+	spec := &v1beta1.AgentMiddleware{
+		TokenExchange: &v1beta1.TokenExchange{
+			TokenURL: tokenServer.URL,
+			Audience: "model-server",
+			Scopes:   []string{"predict", "explain"},
+		},
+	}
+	handler := New(spec, "agent", "secret", nil, predictor, logger)
+	now := time.Now()
+	handler.exchanger.now = func() time.Time { return now }
+
+	send := func(handler http.Handler, token string) int {
+		r := httptest.NewRequest(http.MethodPost, "/v1/models/test:predict", strings.NewReader(`{"instances":[1]}`))
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	g.Expect(send(handler, "")).To(gomega.Equal(http.StatusUnauthorized))
+	g.Expect(exchanges).To(gomega.Equal(0))
+
+	g.Expect(send(handler, "user-token")).To(gomega.Equal(http.StatusOK))
+	g.Expect(authorization).To(gomega.Equal("Bearer exchanged-token"))
+	g.Expect(exchanges).To(gomega.Equal(1))
+
+	// The exchanged token is cached until it is about to expire
+	g.Expect(send(handler, "user-token")).To(gomega.Equal(http.StatusOK))
+	g.Expect(exchanges).To(gomega.Equal(1))
+	now = now.Add(time.Minute)
+	g.Expect(send(handler, "user-token")).To(gomega.Equal(http.StatusOK))
+	g.Expect(exchanges).To(gomega.Equal(2))
+
+	authorization = ""
+	g.Expect(send(handler, "other-token")).To(gomega.Equal(http.StatusUnauthorized))
+	g.Expect(authorization).To(gomega.BeEmpty())
+
+	unauthenticated := New(spec, "", "", nil, predictor, logger)
+	g.Expect(send(unauthenticated, "user-token")).To(gomega.Equal(http.StatusUnauthorized))
+
+	tokenServer.Close()
+	unreachable := New(spec, "agent", "secret", nil, predictor, logger)
+	g.Expect(send(unreachable, "user-token")).To(gomega.Equal(http.StatusBadGateway))
+}
+
+func TestUnauthenticatedPaths(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	predictor := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"ready":true}`))
+	})
+	handler := New(&v1beta1.AgentMiddleware{
+		TokenExchange: &v1beta1.TokenExchange{
+			TokenURL: "http://sts.example.com/token",
+			UnauthenticatedPaths: []v1beta1.UnauthenticatedPath{
+				{Prefix: "/v2/health"},
+				{Prefix: "/metrics", SourceCIDRs: []string{"10.128.0.0/14"}},
+			},
+		},
+	}, "", "", nil, predictor, logger)
+
+	send := func(method string, target string, remoteAddr string, forwardedFor string) int {
+		r := httptest.NewRequest(method, target, nil)
+		r.RemoteAddr = remoteAddr
+		if forwardedFor != "" {
+			r.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	scenarios := map[string]struct {
+		method       string
+		target       string
+		remoteAddr   string
+		forwardedFor string
+		expected     int
+	}{
+		"HealthFromPod":            {http.MethodGet, "/v2/health/ready", "10.0.3.4:51000", "", http.StatusOK},
+		"HealthHead":               {http.MethodHead, "/v2/health/live", "192.168.1.2:51000", "", http.StatusOK},
+		"HealthFromQueueProxy":     {http.MethodGet, "/v2/health/ready", "127.0.0.1:51000", "203.0.113.7, 10.0.3.4", http.StatusOK},
+		"HealthFromInternet":       {http.MethodGet, "/v2/health/ready", "127.0.0.1:51000", "10.0.3.4, 203.0.113.7", http.StatusUnauthorized},
+		"HealthPost":               {http.MethodPost, "/v2/health/ready", "10.0.3.4:51000", "", http.StatusUnauthorized},
+		"PathTraversal":            {http.MethodGet, "/v2/health/../models/llm", "10.0.3.4:51000", "", http.StatusUnauthorized},
+		"PartialSegment":           {http.MethodGet, "/v2/healthz", "10.0.3.4:51000", "", http.StatusUnauthorized},
+		"MetricsFromPodNetwork":    {http.MethodGet, "/metrics", "10.128.2.9:51000", "", http.StatusOK},
+		"MetricsFromOtherNetwork":  {http.MethodGet, "/metrics", "10.0.3.4:51000", "", http.StatusUnauthorized},
+		"InferenceWithoutAnyToken": {http.MethodGet, "/v2/models/llm", "10.0.3.4:51000", "", http.StatusUnauthorized},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g.Expect(send(scenario.method, scenario.target, scenario.remoteAddr, scenario.forwardedFor)).
+				To(gomega.Equal(scenario.expected))
+		})
+	}
+}
+
+func TestTokenReview(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	client := fake.NewSimpleClientset()
+	tokenReviews := 0
+	client.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		tokenReviews++
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		g.Expect(review.Spec.Audiences).To(gomega.Equal([]string{"model-server"}))
+		switch review.Spec.Token {
+		case "alice-token":
+			review.Status = authenticationv1.TokenReviewStatus{Authenticated: true,
+				User: authenticationv1.UserInfo{Username: "alice", Groups: []string{"team-a"}}}
+		case "bob-token":
+			review.Status = authenticationv1.TokenReviewStatus{Authenticated: true,
+				User: authenticationv1.UserInfo{Username: "bob"}}
+		default:
+			review.Status = authenticationv1.TokenReviewStatus{Error: "token expired"}
+		}
+		return true, review, nil
+	})
+	client.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		g.Expect(*review.Spec.ResourceAttributes).To(gomega.Equal(authorizationv1.ResourceAttributes{
+			Namespace: "default", Verb: "get", Group: "serving.kserve.io", Resource: "inferenceservices", Name: "llm",
+		}))
+		review.Status.Allowed = review.Spec.User == "alice"
+		return true, review, nil
+	})
+
+	var authorization, user string
+	predictor := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		user = UserFromContext(r.Context())
+		_, _ = w.Write([]byte(`{"predictions":[1]}`))
+	})
+	spec := &v1beta1.AgentMiddleware{
+		TokenReview: &v1beta1.TokenReview{
+			Audiences:            []string{"model-server"},
+			UnauthenticatedPaths: []v1beta1.UnauthenticatedPath{{Prefix: "/v2/health"}},
+		},
+	}
+	reviewer := NewTokenReviewer(spec.TokenReview, client, "default", "llm")
+	handler := New(spec, "", "", reviewer, predictor, logger)
+
+	send := func(target string, token string) int {
+		r := httptest.NewRequest(http.MethodGet, target, nil)
+		r.RemoteAddr = "10.0.3.4:51000"
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	g.Expect(send("/v1/models/llm:predict", "alice-token")).To(gomega.Equal(http.StatusOK))
+	g.Expect(authorization).To(gomega.Equal("Bearer alice-token"))
+	g.Expect(user).To(gomega.Equal("alice"))
+	g.Expect(send("/v1/models/llm:predict", "alice-token")).To(gomega.Equal(http.StatusOK))
+	g.Expect(user).To(gomega.Equal("alice"))
+	g.Expect(tokenReviews).To(gomega.Equal(1))
+
+	g.Expect(send("/v1/models/llm:predict", "bob-token")).To(gomega.Equal(http.StatusForbidden))
+	g.Expect(send("/v1/models/llm:predict", "expired-token")).To(gomega.Equal(http.StatusUnauthorized))
+	g.Expect(send("/v1/models/llm:predict", "")).To(gomega.Equal(http.StatusUnauthorized))
+	g.Expect(send("/v2/health/ready", "")).To(gomega.Equal(http.StatusOK))
+	g.Expect(user).To(gomega.BeEmpty())
+
+	failing := fake.NewSimpleClientset()
+	failing.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("connection refused")
+	})
+	unreachable := New(spec, "", "", NewTokenReviewer(spec.TokenReview, failing, "default", "llm"), predictor, logger)
+	r := httptest.NewRequest(http.MethodGet, "/v1/models/llm:predict", nil)
+	r.Header.Set("Authorization", "Bearer alice-token")
+	w := httptest.NewRecorder()
+	unreachable.ServeHTTP(w, r)
+	g.Expect(w.Code).To(gomega.Equal(http.StatusBadGateway))
+}
+
+func TestGuardrail(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	var stages []string
+	hookServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		stages = append(stages, r.Header.Get(GuardrailStageHeader))
+		switch {
+		case strings.Contains(string(body), "unsafe"):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error":"flagged by moderation"}`))
+		case strings.Contains(string(body), "crash"):
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer hookServer.Close()
+
+	predictions := 0
+	predictor := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		predictions++
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"predictions":["` + strings.TrimPrefix(string(body), "echo ") + `"]}`))
+	})
+	send := func(handler http.Handler, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/v1/models/test:predict", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	handler := New(&v1beta1.AgentMiddleware{
+		Guardrail: &v1beta1.GuardrailSpec{
+			Request:  &v1beta1.GuardrailHook{URL: hookServer.URL},
+			Response: &v1beta1.GuardrailHook{URL: hookServer.URL, FailurePolicy: v1beta1.GuardrailFailurePolicyIgnore},
+		},
+	}, "", "", nil, predictor, logger)
+
+	w := send(handler, "echo hello")
+	g.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(w.Body.String()).To(gomega.Equal(`{"predictions":["hello"]}`))
+	g.Expect(stages).To(gomega.Equal([]string{GuardrailStageRequest, GuardrailStageResponse}))
+	g.Expect(predictions).To(gomega.Equal(1))
+
+	// The rejection of the request hook is returned without calling the component
+	w = send(handler, "unsafe prompt")
+	g.Expect(w.Code).To(gomega.Equal(http.StatusForbidden))
+	g.Expect(w.Body.String()).To(gomega.Equal(`{"error":"flagged by moderation"}`))
+	g.Expect(predictions).To(gomega.Equal(1))
+
+	// The request hook is fail-closed by default
+	g.Expect(send(handler, "crash").Code).To(gomega.Equal(http.StatusServiceUnavailable))
+	g.Expect(predictions).To(gomega.Equal(1))
+
+	// The response hook moderates the response of the component and is fail-open
+	responseOnly := New(&v1beta1.AgentMiddleware{
+		Guardrail: &v1beta1.GuardrailSpec{
+			Response: &v1beta1.GuardrailHook{URL: hookServer.URL, FailurePolicy: v1beta1.GuardrailFailurePolicyIgnore},
+		},
+	}, "", "", nil, predictor, logger)
+	w = send(responseOnly, "echo unsafe answer")
+	g.Expect(w.Code).To(gomega.Equal(http.StatusForbidden))
+	g.Expect(w.Body.String()).To(gomega.Equal(`{"error":"flagged by moderation"}`))
+	g.Expect(predictions).To(gomega.Equal(2))
+	w = send(responseOnly, "echo crash")
+	g.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(w.Body.String()).To(gomega.Equal(`{"predictions":["crash"]}`))
+}
+
+func TestGuardrailAfterTokenReview(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	hookCalls := 0
+	hookServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hookCalls++
+	}))
+	defer hookServer.Close()
+
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		if review.Spec.Token == "alice-token" {
+			review.Status = authenticationv1.TokenReviewStatus{Authenticated: true,
+				User: authenticationv1.UserInfo{Username: "alice"}}
+		} else {
+			review.Status = authenticationv1.TokenReviewStatus{Error: "token expired"}
+		}
+		return true, review, nil
+	})
+	client.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		review.Status.Allowed = true
+		return true, review, nil
+	})
+	predictor := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"predictions":[1]}`))
+	})
+	spec := &v1beta1.AgentMiddleware{
+		TokenReview: &v1beta1.TokenReview{},
+		Guardrail:   &v1beta1.GuardrailSpec{Request: &v1beta1.GuardrailHook{URL: hookServer.URL}},
+	}
+	handler := New(spec, "", "", NewTokenReviewer(spec.TokenReview, client, "default", "llm"), predictor, logger)
+	send := func(token string) int {
+		r := httptest.NewRequest(http.MethodPost, "/v1/models/llm:predict", strings.NewReader(`{"instances":[1]}`))
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	// The unauthenticated requests never reach the hook
+	g.Expect(send("")).To(gomega.Equal(http.StatusUnauthorized))
+	g.Expect(send("expired-token")).To(gomega.Equal(http.StatusUnauthorized))
+	g.Expect(hookCalls).To(gomega.Equal(0))
+
+	g.Expect(send("alice-token")).To(gomega.Equal(http.StatusOK))
+	g.Expect(hookCalls).To(gomega.Equal(1))
+}
+
+func TestTokenUsage(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	completion := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "error") {
+			http.Error(w, `{"usage":{"prompt_tokens":1}}`, http.StatusBadRequest)
+			return
+		}
+		if r.Header.Get("Accept") == "text/event-stream" {
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = w.Write([]byte("data: {\"model\":\"llama\",\"choices\":[{\"delta\":{\"content\":\"hi\"}}]}\n\n"))
+			w.(http.Flusher).Flush()
+			_, _ = w.Write([]byte("data: {\"model\":\"llama\",\"choices\":[],\"usage\":{\"prompt_tokens\":3,"))
+			_, _ = w.Write([]byte("\"completion_tokens\":1,\"total_tokens\":4}}\n\ndata: [DONE]\n\n"))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"model":"llama","choices":[{"message":{"content":"hello"}}],` +
+			`"usage":{"prompt_tokens":5,"completion_tokens":2,"total_tokens":7}}`))
+	})
+	handler := New(&v1beta1.AgentMiddleware{
+		TokenUsage: &v1beta1.TokenUsageSpec{Headers: true},
+	}, "", "", nil, completion, logger)
+	send := func(path string, accept string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"model":"llama"}`))
+		r.Header.Set("Accept", accept)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+	promptTokens := testutil.ToFloat64(tokens.WithLabelValues("llama", "prompt"))
+	completionTokens := testutil.ToFloat64(tokens.WithLabelValues("llama", "completion"))
+
+	w := send("/openai/v1/chat/completions", "application/json")
+	g.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(w.Header().Get(PromptTokensHeader)).To(gomega.Equal("5"))
+	g.Expect(w.Header().Get(CompletionTokensHeader)).To(gomega.Equal("2"))
+	g.Expect(w.Header().Get(TotalTokensHeader)).To(gomega.Equal("7"))
+	g.Expect(w.Body.String()).To(gomega.ContainSubstring(`"content":"hello"`))
+
+	// The usage of the streamed responses is returned in trailers
+	w = send("/openai/v1/chat/completions", "text/event-stream")
+	g.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(w.Body.String()).To(gomega.HaveSuffix("data: [DONE]\n\n"))
+	g.Expect(w.Result().Trailer.Get(TotalTokensHeader)).To(gomega.Equal("4"))
+
+	// The failed responses are not accounted
+	g.Expect(send("/openai/v1/error", "application/json").Code).To(gomega.Equal(http.StatusBadRequest))
+
+	g.Expect(testutil.ToFloat64(tokens.WithLabelValues("llama", "prompt")) - promptTokens).To(gomega.Equal(8.0))
+	g.Expect(testutil.ToFloat64(tokens.WithLabelValues("llama", "completion")) - completionTokens).To(gomega.Equal(3.0))
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// reviewTTL is how long the result of the review of a token is cached, so that the revocation of a token or of the
+// permissions of its user is applied within a minute
+const reviewTTL = time.Minute
+
+var (
+	// ErrTokenInvalid is returned when the API server does not authenticate the token of the request
+	ErrTokenInvalid = errors.New("the token is not valid")
+	// ErrAccessDenied is returned when the user of the token is not allowed on the InferenceService
+	ErrAccessDenied = errors.New("the user of the token is not allowed to access the InferenceService")
+)
+
+type cachedReview struct {
+	user   string
+	err    error
+	expiry time.Time
+}
+
+type userKey struct{}
+
+// ContextWithUser returns a copy of the context holding the user the bearer token of the request is authenticated as
+func ContextWithUser(ctx context.Context, user string) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// UserFromContext returns the user the bearer token of the request is authenticated as, or an empty string when the
+// request is not authenticated
+func UserFromContext(ctx context.Context) string {
+	user, _ := ctx.Value(userKey{}).(string)
+	return user
+}
+
+// TokenReviewer validates the bearer tokens of the requests with a TokenReview and authorizes their user with a
+// SubjectAccessReview on the InferenceService, the results are cached for a minute.
+type TokenReviewer struct {
+	client    kubernetes.Interface
+	audiences []string
+	// authorize is false when the tokens are only authenticated, without a SubjectAccessReview
+	authorize bool
+	verb      string
+	namespace string
+	name      string
+	now       func() time.Time
+
+	mu      sync.Mutex
+	reviews map[string]cachedReview
+}
+
+func NewTokenReviewer(spec *v1beta1.TokenReview, client kubernetes.Interface, namespace string,
+	inferenceService string) *TokenReviewer {
+	return &TokenReviewer{
+		client:    client,
+		audiences: spec.Audiences,
+		authorize: true,
+		verb:      spec.GetVerb(),
+		namespace: namespace,
+		name:      inferenceService,
+		now:       time.Now,
+		reviews:   make(map[string]cachedReview),
+	}
+}
+
+// NewTokenAuthenticator returns a TokenReviewer which only authenticates the tokens, their user is not authorized on
+// any resource
+func NewTokenAuthenticator(client kubernetes.Interface, audiences []string) *TokenReviewer {
+	return &TokenReviewer{
+		client:    client,
+		audiences: audiences,
+		now:       time.Now,
+		reviews:   make(map[string]cachedReview),
+	}
+}
+
+// Review returns the user the token is authenticated as. It returns ErrTokenInvalid when the token is not
+// authenticated and ErrAccessDenied when its user is not allowed on the InferenceService, the other errors are
+// failures of the API server
+func (r *TokenReviewer) Review(ctx context.Context, token string) (string, error) {
+	r.mu.Lock()
+	cached, ok := r.reviews[token]
+	r.mu.Unlock()
+	if ok && r.now().Before(cached.expiry) {
+		return cached.user, cached.err
+	}
+
+	user, err := r.review(ctx, token)
+	if err != nil && !errors.Is(err, ErrTokenInvalid) && !errors.Is(err, ErrAccessDenied) {
+		return "", err
+	}
+	if err != nil {
+		user = ""
+	}
+	r.mu.Lock()
+	if len(r.reviews) >= MaxCachedTokens {
+		r.reviews = make(map[string]cachedReview)
+	}
+	r.reviews[token] = cachedReview{user: user, err: err, expiry: r.now().Add(reviewTTL)}
+	r.mu.Unlock()
+	return user, err
+}
+
+func (r *TokenReviewer) review(ctx context.Context, token string) (string, error) {
+	tokenReview, err := r.client.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token, Audiences: r.audiences},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to review the token: %w", err)
+	}
+	if !tokenReview.Status.Authenticated {
+		if tokenReview.Status.Error != "" {
+			return "", fmt.Errorf("%w: %s", ErrTokenInvalid, tokenReview.Status.Error)
+		}
+		return "", ErrTokenInvalid
+	}
+
+	user := tokenReview.Status.User
+	if !r.authorize {
+		return user.Username, nil
+	}
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	accessReview, err := r.client.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: r.namespace,
+				Verb:      r.verb,
+				Group:     constants.KServeAPIGroupName,
+				Resource:  constants.InferenceServiceAPIName,
+				Name:      r.name,
+			},
+			User:   user.Username,
+			Groups: user.Groups,
+			Extra:  extra,
+			UID:    user.UID,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to review the access of the user %q: %w", user.Username, err)
+	}
+	if !accessReview.Status.Allowed {
+		return "", fmt.Errorf("%w: user %q cannot %s inferenceservice %q", ErrAccessDenied, user.Username, r.verb,
+			r.name)
+	}
+	return user.Username, nil
+}
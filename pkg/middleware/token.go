@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+const (
+	// TokenExchangeGrantType is the grant type of the OAuth 2.0 token exchange (RFC 8693)
+	TokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+	// AccessTokenType is the type of the subject token and of the requested token
+	AccessTokenType = "urn:ietf:params:oauth:token-type:access_token"
+	// MaxCachedTokens bounds the number of exchanged tokens kept, the cache is cleared when it is full
+	MaxCachedTokens = 1000
+	// exchangeTimeout bounds the request to the token endpoint
+	exchangeTimeout = 10 * time.Second
+	// expiryDelta is subtracted from the lifetime of the exchanged tokens so that they are not used when about to
+	// expire
+	expiryDelta = 10 * time.Second
+)
+
+// ErrTokenRejected is returned when the token endpoint rejects the exchange of the token of the request
+var ErrTokenRejected = errors.New("the token endpoint rejected the token exchange")
+
+type cachedToken struct {
+	token  string
+	expiry time.Time
+}
+
+// tokenResponse is the successful response of the token endpoint
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// TokenExchanger exchanges the bearer tokens of the requests at the token endpoint and caches the exchanged tokens
+// until they expire.
+type TokenExchanger struct {
+	tokenURL     string
+	audience     string
+	scopes       []string
+	clientID     string
+	clientSecret string
+	client       *http.Client
+	now          func() time.Time
+
+	mu     sync.Mutex
+	tokens map[string]cachedToken
+}
+
+func NewTokenExchanger(spec *v1beta1.TokenExchange, clientID string, clientSecret string) *TokenExchanger {
+	return &TokenExchanger{
+		tokenURL:     spec.TokenURL,
+		audience:     spec.Audience,
+		scopes:       spec.Scopes,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		client:       &http.Client{Timeout: exchangeTimeout},
+		now:          time.Now,
+		tokens:       make(map[string]cachedToken),
+	}
+}
+
+// Exchange returns the token the subject token is exchanged for, from the cache when it has not expired yet
+func (e *TokenExchanger) Exchange(ctx context.Context, subjectToken string) (string, error) {
+	e.mu.Lock()
+	cached, ok := e.tokens[subjectToken]
+	e.mu.Unlock()
+	if ok && e.now().Before(cached.expiry) {
+		return cached.token, nil
+	}
+
+	form := url.Values{
+		"grant_type":           {TokenExchangeGrantType},
+		"subject_token":        {subjectToken},
+		"subject_token_type":   {AccessTokenType},
+		"requested_token_type": {AccessTokenType},
+	}
+	if e.audience != "" {
+		form.Set("audience", e.audience)
+	}
+	if len(e.scopes) > 0 {
+		form.Set("scope", strings.Join(e.scopes, " "))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if e.clientID != "" {
+		req.SetBasicAuth(url.QueryEscape(e.clientID), url.QueryEscape(e.clientSecret))
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to reach the token endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("failed to read the response of the token endpoint: %w", err)
+	}
+	if resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnauthorized {
+		return "", fmt.Errorf("%w: %s", ErrTokenRejected, strings.TrimSpace(string(body)))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("the token endpoint returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	token := &tokenResponse{}
+	if err := json.Unmarshal(body, token); err != nil {
+		return "", fmt.Errorf("failed to parse the response of the token endpoint: %w", err)
+	}
+	if token.AccessToken == "" {
+		return "", errors.New("the token endpoint returned no access token")
+	}
+
+	if token.ExpiresIn > 0 {
+		expiry := e.now().Add(time.Duration(token.ExpiresIn)*time.Second - expiryDelta)
+		e.mu.Lock()
+		if len(e.tokens) >= MaxCachedTokens {
+			e.tokens = make(map[string]cachedToken)
+		}
+		e.tokens[subjectToken] = cachedToken{token: token.AccessToken, expiry: expiry}
+		e.mu.Unlock()
+	}
+	return token.AccessToken, nil
+}
+
+// statusCode returns the status code of the response to a request whose token could not be reviewed or exchanged
+func statusCode(err error) int {
+	switch {
+	case errors.Is(err, ErrTokenRejected), errors.Is(err, ErrTokenInvalid):
+		return http.StatusUnauthorized
+	case errors.Is(err, ErrAccessDenied):
+		return http.StatusForbidden
+	}
+	return http.StatusBadGateway
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	PromptTokensHeader     = "X-Usage-Prompt-Tokens"
+	CompletionTokensHeader = "X-Usage-Completion-Tokens"
+	TotalTokensHeader      = "X-Usage-Total-Tokens"
+	// maxUsageBodySize bounds the size of the non streamed responses parsed for their usage, the usage of larger
+	// responses is not accounted
+	maxUsageBodySize = 10 << 20
+)
+
+var tokens = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "kserve_agent_tokens_total",
+	Help: "Number of prompt and completion tokens reported in the usage of the OpenAI protocol responses, by model and type",
+}, []string{"model", "type"})
+
+// RegisterMetrics registers the metrics of the middleware
+func RegisterMetrics(registerer prometheus.Registerer) {
+	registerer.MustRegister(tokens)
+}
+
+// usage is the token usage of an OpenAI protocol response, it is set on the last chunk of the streamed responses
+// when the request sets stream_options.include_usage
+type usage struct {
+	PromptTokens     int64 `json:"prompt_tokens"`
+	CompletionTokens int64 `json:"completion_tokens"`
+	TotalTokens      int64 `json:"total_tokens"`
+}
+
+type usageResponse struct {
+	Model string `json:"model"`
+	Usage *usage `json:"usage"`
+}
+
+// usageWriter accounts the token usage of the responses of the component. The streamed responses are parsed event
+// by event as they are written, the other responses are parsed once complete and are buffered when the usage headers
+// are enabled so that the headers can be set before the body is written.
+type usageWriter struct {
+	http.ResponseWriter
+	headers     bool
+	wroteHeader bool
+	statusCode  int
+	stream      bool
+	buffer      bool
+	body        bytes.Buffer
+	line        []byte
+	response    *usageResponse
+}
+
+func newUsageWriter(w http.ResponseWriter, headers bool) *usageWriter {
+	return &usageWriter{ResponseWriter: w, headers: headers, statusCode: http.StatusOK}
+}
+
+func (w *usageWriter) WriteHeader(statusCode int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.statusCode = statusCode
+	w.stream = strings.HasPrefix(w.Header().Get("Content-Type"), "text/event-stream")
+	w.buffer = w.headers && !w.stream && w.accounted()
+	if w.stream && w.headers && w.accounted() {
+		w.Header().Add("Trailer", PromptTokensHeader+", "+CompletionTokensHeader+", "+TotalTokensHeader)
+	}
+	if !w.buffer {
+		w.ResponseWriter.WriteHeader(statusCode)
+	}
+}
+
+// accounted returns whether the usage of the response is accounted, only the successful responses report a usage
+func (w *usageWriter) accounted() bool {
+	return w.statusCode >= 200 && w.statusCode < 300
+}
+
+func (w *usageWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.accounted() {
+		if w.stream {
+			w.parseEvents(b)
+		} else if w.buffer || w.body.Len()+len(b) <= maxUsageBodySize {
+			w.body.Write(b)
+		}
+	}
+	if w.buffer {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *usageWriter) Flush() {
+	if w.buffer {
+		return
+	}
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// parseEvents parses the data lines of the server-sent events, the last event reporting a usage is kept
+func (w *usageWriter) parseEvents(b []byte) {
+	w.line = append(w.line, b...)
+	for {
+		i := bytes.IndexByte(w.line, '\n')
+		if i < 0 {
+			break
+		}
+		line := bytes.TrimSpace(w.line[:i])
+		w.line = w.line[i+1:]
+		data, ok := bytes.CutPrefix(line, []byte("data:"))
+		if !ok {
+			continue
+		}
+		event := &usageResponse{}
+		if err := json.Unmarshal(bytes.TrimSpace(data), event); err == nil && event.Usage != nil {
+			w.response = event
+		}
+	}
+	if len(w.line) > maxUsageBodySize {
+		w.line = nil
+	}
+}
+
+// finish accounts the usage of the response and writes the usage headers, or trailers for the streamed responses,
+// along with the buffered body
+func (w *usageWriter) finish() {
+	if !w.wroteHeader || !w.accounted() {
+		return
+	}
+	if !w.stream && w.body.Len() <= maxUsageBodySize {
+		response := &usageResponse{}
+		if err := json.Unmarshal(w.body.Bytes(), response); err == nil && response.Usage != nil {
+			w.response = response
+		}
+	}
+	if w.response != nil {
+		tokens.WithLabelValues(w.response.Model, "prompt").Add(float64(w.response.Usage.PromptTokens))
+		tokens.WithLabelValues(w.response.Model, "completion").Add(float64(w.response.Usage.CompletionTokens))
+		if w.headers {
+			w.setUsageHeaders(w.response.Usage)
+		}
+	}
+	if w.buffer {
+		w.ResponseWriter.WriteHeader(w.statusCode)
+		_, _ = w.ResponseWriter.Write(w.body.Bytes())
+	}
+}
+
+// setUsageHeaders sets the usage headers, they are sent as trailers once the body of the streamed responses is written
+func (w *usageWriter) setUsageHeaders(u *usage) {
+	header := w.Header()
+	for name, value := range map[string]int64{
+		PromptTokensHeader:     u.PromptTokens,
+		CompletionTokensHeader: u.CompletionTokens,
+		TotalTokensHeader:      u.TotalTokens,
+	} {
+		header.Set(name, strconv.FormatInt(value, 10))
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -120,7 +121,7 @@ func CreateEmptyModelConfig(isvc *v1beta1.InferenceService, shardId int) (*v1.Co
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      multiModelConfigMapName,
 			Namespace: isvc.Namespace,
-			Labels:    isvc.Labels,
+			Labels:    utils.Union(isvc.Labels, constants.InferenceServiceOwnerLabels(isvc.Name)),
 		},
 		Data: map[string]string{
 			constants.ModelConfigFileName: "[]",
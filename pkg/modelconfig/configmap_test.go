@@ -269,7 +269,7 @@ func TestCreateEmptyModelConfig(t *testing.T) {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      constants.ModelConfigName(isvc.Name, shardId),
 			Namespace: isvc.Namespace,
-			Labels:    isvc.Labels,
+			Labels:    constants.InferenceServiceOwnerLabels(isvc.Name),
 		},
 		Data: map[string]string{
 			constants.ModelConfigFileName: "[]",
@@ -0,0 +1,152 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+const (
+	EgressProxyConfigMapKeyName = "egressProxy"
+	// DefaultNoProxy keeps the traffic to the local and in-cluster endpoints away from the egress proxy
+	DefaultNoProxy = "localhost,127.0.0.1,.svc,.cluster.local"
+)
+
+// EgressProxyConfig injects the egress proxy and the host aliases of the cluster into the generated pods
+// +kubebuilder:object:generate=false
+type EgressProxyConfig struct {
+	// HTTPProxy is set as HTTP_PROXY and http_proxy on the containers when not empty
+	HTTPProxy string `json:"httpProxy,omitempty"`
+	// HTTPSProxy is set as HTTPS_PROXY and https_proxy on the containers when not empty
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+	// NoProxy is appended to DefaultNoProxy and set as NO_PROXY and no_proxy on the containers when a proxy is set
+	NoProxy string `json:"noProxy,omitempty"`
+	// HostAliases are added to the hosts file of the pods
+	HostAliases []v1.HostAlias `json:"hostAliases,omitempty"`
+}
+
+func GetEgressProxyConfig(configMap *v1.ConfigMap) (*EgressProxyConfig, error) {
+	config := &EgressProxyConfig{}
+	if value, ok := configMap.Data[EgressProxyConfigMapKeyName]; ok {
+		if err := json.Unmarshal([]byte(value), config); err != nil {
+			return nil, fmt.Errorf("unable to unmarshall %v json string due to %w ", EgressProxyConfigMapKeyName, err)
+		}
+	}
+	for _, proxy := range []string{config.HTTPProxy, config.HTTPSProxy} {
+		if proxy == "" {
+			continue
+		}
+		if u, err := url.Parse(proxy); err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid %v config - proxy %q must be an absolute URL", EgressProxyConfigMapKeyName, proxy)
+		}
+	}
+	for _, alias := range config.HostAliases {
+		if net.ParseIP(alias.IP) == nil {
+			return nil, fmt.Errorf("invalid %v config - hostAliases ip %q is not an IP address", EgressProxyConfigMapKeyName, alias.IP)
+		}
+		if len(alias.Hostnames) == 0 {
+			return nil, fmt.Errorf("invalid %v config - hostAliases of %s must have hostnames", EgressProxyConfigMapKeyName, alias.IP)
+		}
+	}
+	return config, nil
+}
+
+// InjectEgressProxy applies the egress proxy config to all the containers of the pod, including the injected ones.
+func (c *EgressProxyConfig) InjectEgressProxy(pod *v1.Pod) error {
+	c.ApplyToPodSpec(&pod.Spec)
+	return nil
+}
+
+// ApplyToPodSpec sets the proxy environment variables on the containers and the init containers and adds the
+// host aliases. The proxy variables already set on a container, in upper or lower case, are kept.
+func (c *EgressProxyConfig) ApplyToPodSpec(podSpec *v1.PodSpec) {
+	envs := c.proxyEnvs()
+	for i := range podSpec.InitContainers {
+		addProxyEnvs(&podSpec.InitContainers[i], envs)
+	}
+	for i := range podSpec.Containers {
+		addProxyEnvs(&podSpec.Containers[i], envs)
+	}
+	for _, alias := range c.HostAliases {
+		podSpec.HostAliases = mergeHostAlias(podSpec.HostAliases, alias)
+	}
+}
+
+// proxyEnvs returns the values of the proxy environment variables keyed by their upper case name.
+func (c *EgressProxyConfig) proxyEnvs() map[string]string {
+	envs := map[string]string{}
+	if c.HTTPProxy != "" {
+		envs["HTTP_PROXY"] = c.HTTPProxy
+	}
+	if c.HTTPSProxy != "" {
+		envs["HTTPS_PROXY"] = c.HTTPSProxy
+	}
+	if len(envs) != 0 {
+		noProxy := DefaultNoProxy
+		if c.NoProxy != "" {
+			noProxy += "," + c.NoProxy
+		}
+		envs["NO_PROXY"] = noProxy
+	}
+	return envs
+}
+
+func addProxyEnvs(container *v1.Container, envs map[string]string) {
+	// Iterate in a fixed order so that the generated pod spec is stable
+	for _, name := range []string{"HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY"} {
+		value, ok := envs[name]
+		if !ok || hasEnv(container, name) || hasEnv(container, strings.ToLower(name)) {
+			continue
+		}
+		container.Env = append(container.Env,
+			v1.EnvVar{Name: name, Value: value},
+			v1.EnvVar{Name: strings.ToLower(name), Value: value})
+	}
+}
+
+func hasEnv(container *v1.Container, name string) bool {
+	for _, env := range container.Env {
+		if env.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeHostAlias adds the hostnames of the alias to the existing alias of the same IP, or appends the alias.
+func mergeHostAlias(aliases []v1.HostAlias, alias v1.HostAlias) []v1.HostAlias {
+	for i := range aliases {
+		if aliases[i].IP != alias.IP {
+			continue
+		}
+		for _, hostname := range alias.Hostnames {
+			if !utils.Includes(aliases[i].Hostnames, hostname) {
+				aliases[i].Hostnames = append(aliases[i].Hostnames, hostname)
+			}
+		}
+		return aliases
+	}
+	return append(aliases, *alias.DeepCopy())
+}
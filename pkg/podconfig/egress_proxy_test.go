@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podconfig
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestGetEgressProxyConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	config, err := GetEgressProxyConfig(&v1.ConfigMap{
+		Data: map[string]string{
+			EgressProxyConfigMapKeyName: `{"httpsProxy": "http://proxy.corp:3128", "hostAliases": [{"ip": "10.0.0.1", "hostnames": ["models.corp"]}]}`,
+		},
+	})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(config).Should(gomega.Equal(&EgressProxyConfig{
+		HTTPSProxy:  "http://proxy.corp:3128",
+		HostAliases: []v1.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"models.corp"}}},
+	}))
+
+	for _, value := range []string{
+		`{"httpProxy": "proxy.corp:3128"}`,
+		`{"hostAliases": [{"ip": "models.corp", "hostnames": ["models.corp"]}]}`,
+		`{"hostAliases": [{"ip": "10.0.0.1"}]}`,
+	} {
+		_, err = GetEgressProxyConfig(&v1.ConfigMap{Data: map[string]string{EgressProxyConfigMapKeyName: value}})
+		g.Expect(err).Should(gomega.HaveOccurred(), value)
+	}
+}
+
+func TestInjectEgressProxy(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	config := &EgressProxyConfig{
+		HTTPProxy:   "http://proxy.corp:3128",
+		HTTPSProxy:  "http://proxy.corp:3128",
+		NoProxy:     ".corp",
+		HostAliases: []v1.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"models.corp", "proxy.corp"}}},
+	}
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{
+			InitContainers: []v1.Container{{Name: constants.StorageInitializerContainerName}},
+			Containers: []v1.Container{
+				{Name: "kserve-container", Env: []v1.EnvVar{{Name: "https_proxy", Value: "http://other:8080"}}},
+			},
+			HostAliases: []v1.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"models.corp"}}},
+		},
+	}
+	g.Expect(config.InjectEgressProxy(pod)).Should(gomega.Succeed())
+
+	noProxy := DefaultNoProxy + ",.corp"
+	g.Expect(pod.Spec.InitContainers[0].Env).Should(gomega.Equal([]v1.EnvVar{
+		{Name: "HTTP_PROXY", Value: "http://proxy.corp:3128"},
+		{Name: "http_proxy", Value: "http://proxy.corp:3128"},
+		{Name: "HTTPS_PROXY", Value: "http://proxy.corp:3128"},
+		{Name: "https_proxy", Value: "http://proxy.corp:3128"},
+		{Name: "NO_PROXY", Value: noProxy},
+		{Name: "no_proxy", Value: noProxy},
+	}))
+	g.Expect(pod.Spec.Containers[0].Env).Should(gomega.Equal([]v1.EnvVar{
+		{Name: "https_proxy", Value: "http://other:8080"},
+		{Name: "HTTP_PROXY", Value: "http://proxy.corp:3128"},
+		{Name: "http_proxy", Value: "http://proxy.corp:3128"},
+		{Name: "NO_PROXY", Value: noProxy},
+		{Name: "no_proxy", Value: noProxy},
+	}))
+	g.Expect(pod.Spec.HostAliases).Should(gomega.Equal([]v1.HostAlias{
+		{IP: "10.0.0.1", Hostnames: []string{"models.corp", "proxy.corp"}},
+	}))
+}
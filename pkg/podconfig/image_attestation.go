@@ -0,0 +1,239 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podconfig
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+)
+
+const (
+	// DSSEPayloadType is the payload type of the in-toto statements signed by cosign
+	DSSEPayloadType = "application/vnd.in-toto+json"
+	// attestationCacheTTL is how long the result of the verification of an image digest is reused
+	attestationCacheTTL = 10 * time.Minute
+	// maxAttestationSize limits the size of the attestation manifests and layers read from the registry
+	maxAttestationSize = 10 << 20
+)
+
+type attestationResult struct {
+	err        error
+	verifiedAt time.Time
+}
+
+var (
+	attestationCacheMutex sync.Mutex
+	attestationCache      = map[string]attestationResult{}
+)
+
+// dsseEnvelope is the envelope of an attestation, see https://github.com/secure-systems-lab/dsse
+type dsseEnvelope struct {
+	PayloadType string `json:"payloadType"`
+	Payload     string `json:"payload"`
+	Signatures  []struct {
+		Sig string `json:"sig"`
+	} `json:"signatures"`
+}
+
+// inTotoStatement is the subject part of an in-toto statement
+type inTotoStatement struct {
+	Subject []struct {
+		Name   string            `json:"name"`
+		Digest map[string]string `json:"digest"`
+	} `json:"subject"`
+}
+
+// ParseAttestationPublicKey parses the PEM encoded ECDSA, RSA or Ed25519 public key the attestations are signed with.
+func ParseAttestationPublicKey(key string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found in the attestation public key")
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	switch publicKey.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		return publicKey, nil
+	default:
+		return nil, fmt.Errorf("unsupported attestation public key type %T", publicKey)
+	}
+}
+
+// VerifyImageAttestation verifies that an image digest has a sigstore attestation signed with the public key. The
+// attestations are looked up where cosign stores them, under the sha256-<hex>.att tag of the repository of the image,
+// and at least one of them must have a valid signature and the digest as subject. The results are cached by digest.
+func VerifyImageAttestation(httpClient *http.Client, image string, digest string, publicKey crypto.PublicKey) error {
+	attestationCacheMutex.Lock()
+	result, ok := attestationCache[digest]
+	attestationCacheMutex.Unlock()
+	if ok && time.Since(result.verifiedAt) < attestationCacheTTL {
+		return result.err
+	}
+	err := verifyImageAttestation(httpClient, image, digest, publicKey)
+	attestationCacheMutex.Lock()
+	attestationCache[digest] = attestationResult{err: err, verifiedAt: time.Now()}
+	attestationCacheMutex.Unlock()
+	return err
+}
+
+func verifyImageAttestation(httpClient *http.Client, image string, digest string, publicKey crypto.PublicKey) error {
+	algorithm, hexDigest, found := strings.Cut(digest, ":")
+	if !found || algorithm != "sha256" {
+		return fmt.Errorf("unsupported image digest %q", digest)
+	}
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return err
+	}
+	repository := ref.Context()
+	baseURL := fmt.Sprintf("%s://%s/v2/%s", repository.Registry.Scheme(), repository.RegistryStr(), repository.RepositoryStr())
+
+	body, err := getRegistryResource(httpClient, fmt.Sprintf("%s/manifests/sha256-%s.att", baseURL, hexDigest),
+		"application/vnd.oci.image.manifest.v1+json, application/vnd.docker.distribution.manifest.v2+json")
+	if err != nil {
+		return fmt.Errorf("failed to get the attestations of %s: %w", image, err)
+	}
+	manifest := struct {
+		Layers []struct {
+			Digest string `json:"digest"`
+		} `json:"layers"`
+	}{}
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		return fmt.Errorf("failed to parse the attestation manifest of %s: %w", image, err)
+	}
+	for _, layer := range manifest.Layers {
+		envelope, err := getRegistryResource(httpClient, fmt.Sprintf("%s/blobs/%s", baseURL, layer.Digest), "*/*")
+		if err != nil {
+			return fmt.Errorf("failed to get the attestation %s of %s: %w", layer.Digest, image, err)
+		}
+		if err = verifyAttestationEnvelope(envelope, hexDigest, publicKey); err == nil {
+			return nil
+		}
+		log.Info("Attestation not verified", "image", image, "attestation", layer.Digest, "reason", err.Error())
+	}
+	return fmt.Errorf("no attestation of %s is signed with the configured public key", image)
+}
+
+// verifyAttestationEnvelope verifies the signature of a DSSE envelope and that its in-toto statement has the digest as subject.
+func verifyAttestationEnvelope(data []byte, hexDigest string, publicKey crypto.PublicKey) error {
+	envelope := dsseEnvelope{}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return err
+	}
+	if envelope.PayloadType != DSSEPayloadType {
+		return fmt.Errorf("unsupported payload type %q", envelope.PayloadType)
+	}
+	payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	if err != nil {
+		return err
+	}
+	verified := false
+	for _, signature := range envelope.Signatures {
+		sig, err := base64.StdEncoding.DecodeString(signature.Sig)
+		if err == nil && verifySignature(publicKey, preAuthEncoding(envelope.PayloadType, payload), sig) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return fmt.Errorf("invalid signature")
+	}
+	statement := inTotoStatement{}
+	if err := json.Unmarshal(payload, &statement); err != nil {
+		return err
+	}
+	for _, subject := range statement.Subject {
+		if subject.Digest["sha256"] == hexDigest {
+			return nil
+		}
+	}
+	return fmt.Errorf("the image digest is not a subject of the attestation")
+}
+
+// preAuthEncoding returns the message signed in a DSSE envelope.
+func preAuthEncoding(payloadType string, payload []byte) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "DSSEv1 %d %s %d ", len(payloadType), payloadType, len(payload))
+	buf.Write(payload)
+	return buf.Bytes()
+}
+
+func verifySignature(publicKey crypto.PublicKey, message []byte, sig []byte) bool {
+	hash := sha256.Sum256(message)
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(key, hash[:], sig)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, hash[:], sig) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, message, sig)
+	}
+	return false
+}
+
+// getRegistryResource gets a manifest or a blob from the registry HTTP API, with a bearer token issued anonymously
+// when the registry requires one.
+func getRegistryResource(httpClient *http.Client, url string, accept string) ([]byte, error) {
+	token := ""
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", accept)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxAttestationSize))
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusUnauthorized && attempt == 0 {
+			if token, err = fetchAnonymousToken(httpClient, resp.Header.Get("WWW-Authenticate")); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+		}
+		return body, nil
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+)
+
+func newTestAttestation(g *gomega.WithT, key *ecdsa.PrivateKey, subjectDigest string) []byte {
+	statement := fmt.Sprintf(`{"_type":"https://in-toto.io/Statement/v0.1","subject":[{"name":"model","digest":{"sha256":"%s"}}]}`,
+		subjectDigest)
+	hash := sha256.Sum256(preAuthEncoding(DSSEPayloadType, []byte(statement)))
+	sig, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	envelope, err := json.Marshal(map[string]interface{}{
+		"payloadType": DSSEPayloadType,
+		"payload":     base64.StdEncoding.EncodeToString([]byte(statement)),
+		"signatures":  []map[string]string{{"sig": base64.StdEncoding.EncodeToString(sig)}},
+	})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	return envelope
+}
+
+func TestVerifyImageAttestation(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	publicKey, err := ParseAttestationPublicKey(string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})))
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+
+	signedDigest := strings.Repeat("a", 64)
+	foreignDigest := strings.Repeat("b", 64)
+	blobs := map[string][]byte{
+		"sha256:signed":  newTestAttestation(g, key, signedDigest),
+		"sha256:foreign": newTestAttestation(g, otherKey, foreignDigest),
+	}
+	manifests := map[string]string{
+		"sha256-" + signedDigest + ".att":  `{"layers":[{"digest":"sha256:signed"}]}`,
+		"sha256-" + foreignDigest + ".att": `{"layers":[{"digest":"sha256:foreign"}]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/v2/kserve/model/")
+		if manifest, ok := manifests[strings.TrimPrefix(path, "manifests/")]; ok {
+			fmt.Fprint(w, manifest)
+			return
+		}
+		if blob, ok := blobs[strings.TrimPrefix(path, "blobs/")]; ok {
+			w.Write(blob)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	image := strings.TrimPrefix(server.URL, "http://") + "/kserve/model:v1"
+
+	scenarios := map[string]struct {
+		digest     string
+		errMatcher gomega.OmegaMatcher
+	}{
+		"Signed": {
+			digest:     "sha256:" + signedDigest,
+			errMatcher: gomega.Succeed(),
+		},
+		"SignedWithAnotherKey": {
+			digest:     "sha256:" + foreignDigest,
+			errMatcher: gomega.MatchError(gomega.ContainSubstring("no attestation")),
+		},
+		"NoAttestation": {
+			digest:     "sha256:" + strings.Repeat("c", 64),
+			errMatcher: gomega.MatchError(gomega.ContainSubstring("unexpected status 404")),
+		},
+		"UnsupportedDigest": {
+			digest:     "sha512:" + signedDigest,
+			errMatcher: gomega.HaveOccurred(),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g.Expect(VerifyImageAttestation(server.Client(), image, scenario.digest, publicKey)).Should(scenario.errMatcher)
+		})
+	}
+}
+
+func TestVerifyAttestationEnvelope(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	digest := strings.Repeat("a", 64)
+	envelope := newTestAttestation(g, key, digest)
+
+	g.Expect(verifyAttestationEnvelope(envelope, digest, &key.PublicKey)).Should(gomega.Succeed())
+	g.Expect(verifyAttestationEnvelope(envelope, strings.Repeat("b", 64), &key.PublicKey)).Should(
+		gomega.MatchError(gomega.ContainSubstring("not a subject")))
+
+	// a payload modified after the signature is rejected
+	tampered := map[string]interface{}{}
+	g.Expect(json.Unmarshal(envelope, &tampered)).Should(gomega.Succeed())
+	tampered["payload"] = base64.StdEncoding.EncodeToString([]byte(`{"subject":[]}`))
+	data, err := json.Marshal(tampered)
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(verifyAttestationEnvelope(data, digest, &key.PublicKey)).Should(gomega.MatchError("invalid signature"))
+}
+
+func TestParseAttestationPublicKey(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	_, err := ParseAttestationPublicKey("not a key")
+	g.Expect(err).Should(gomega.HaveOccurred())
+
+	_, err = GetImagePolicyConfig(&v1.ConfigMap{
+		Data: map[string]string{
+			ImagePolicyConfigMapKeyName: `{"attestationPublicKey": "not a key"}`,
+		},
+	})
+	g.Expect(err).Should(gomega.HaveOccurred())
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podconfig
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DefaultResolvedImagesLoadInterval is the interval the digests recorded by the leader are loaded at
+const DefaultResolvedImagesLoadInterval = time.Minute
+
+// ImageDigestResolver resolves the system images to digests in the background once the manager is started, so that
+// slow registries do not hold the startup. It only runs on the leader, which records the digests in the resolved
+// images configmap.
+type ImageDigestResolver struct {
+	Clientset  kubernetes.Interface
+	HTTPClient *http.Client
+	Log        logr.Logger
+}
+
+// NeedLeaderElection returns true so that a single replica resolves the images and records them
+func (r *ImageDigestResolver) NeedLeaderElection() bool {
+	return true
+}
+
+// Start resolves the system image digests once, the images are not pinned when they can not be resolved
+func (r *ImageDigestResolver) Start(ctx context.Context) error {
+	if err := ResolveSystemImageDigests(ctx, r.Clientset, r.HTTPClient); err != nil {
+		r.Log.Error(err, "unable to resolve system image digests, images will not be pinned")
+	}
+	return nil
+}
+
+// ResolvedImageDigestLoader loads the digests recorded by the leader every interval, so that the pods mutated by the
+// webhooks of every replica are pinned to the same digests.
+type ResolvedImageDigestLoader struct {
+	Clientset kubernetes.Interface
+	Interval  time.Duration
+	Log       logr.Logger
+}
+
+// NeedLeaderElection returns false since every replica serves the webhooks
+func (l *ResolvedImageDigestLoader) NeedLeaderElection() bool {
+	return false
+}
+
+// Start loads the recorded digests every interval until the context is done
+func (l *ResolvedImageDigestLoader) Start(ctx context.Context) error {
+	interval := l.Interval
+	if interval == 0 {
+		interval = DefaultResolvedImagesLoadInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := LoadResolvedImageDigests(ctx, l.Clientset); err != nil {
+			l.Log.Error(err, "unable to load the resolved image digests")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
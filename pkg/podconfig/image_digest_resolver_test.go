@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podconfig
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestImageDigestResolver(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	clientset := fake.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+		Data:       map[string]string{ImagePolicyConfigMapKeyName: `{"pinDigests": false}`},
+	})
+	resolver := &ImageDigestResolver{Clientset: clientset, Log: ctrl.Log.WithName("test")}
+	g.Expect(resolver.NeedLeaderElection()).Should(gomega.BeTrue())
+	g.Expect(resolver.Start(context.TODO())).Should(gomega.Succeed())
+
+	// nothing is recorded when the digests are not pinned
+	_, err := clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Get(context.TODO(),
+		ResolvedImagesConfigMapName, metav1.GetOptions{})
+	g.Expect(err).Should(gomega.HaveOccurred())
+}
+
+func TestLoadResolvedImageDigests(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	image := "registry.example.com/kserve/router:v0.12.0"
+	clientset := fake.NewSimpleClientset()
+	g.Expect(LoadResolvedImageDigests(context.TODO(), clientset)).Should(gomega.Succeed())
+	g.Expect(PinnedImage(image)).Should(gomega.Equal(image))
+
+	_, err := clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Create(context.TODO(), &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: ResolvedImagesConfigMapName, Namespace: constants.KServeNamespace},
+		Data: map[string]string{
+			RouterConfigMapKeyName: `{"image": "` + image + `", "digest": "` + testDigest + `", "resolvedAt": "now"}`,
+			loggerConfigMapKeyName: `{"image": "registry.example.com/kserve/logger:v0.12.0", "error": "unexpected status 404"}`,
+		},
+	}, metav1.CreateOptions{})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+
+	loader := &ResolvedImageDigestLoader{Clientset: clientset, Log: ctrl.Log.WithName("test")}
+	g.Expect(loader.NeedLeaderElection()).Should(gomega.BeFalse())
+	g.Expect(LoadResolvedImageDigests(context.TODO(), clientset)).Should(gomega.Succeed())
+	g.Expect(PinnedImage(image)).Should(gomega.Equal("registry.example.com/kserve/router@" + testDigest))
+	g.Expect(PinnedImage("registry.example.com/kserve/logger:v0.12.0")).Should(gomega.Equal("registry.example.com/kserve/logger:v0.12.0"))
+}
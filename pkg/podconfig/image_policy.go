@@ -0,0 +1,411 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package podconfig holds the cluster wide pod settings of the inferenceservice-config ConfigMap, like the image
+// policy, the default pull secrets and the egress proxy, which are applied both by the pod mutator and by the
+// controllers to the pods they generate.
+package podconfig
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+const (
+	ImagePolicyConfigMapKeyName = "imagePolicy"
+	// ResolvedImagesConfigMapName is the configmap which records the digests resolved for the system images
+	ResolvedImagesConfigMapName = "inferenceservice-resolved-images"
+	// RouterConfigMapKeyName is the inference graph router configuration key
+	RouterConfigMapKeyName = "router"
+	// configuration keys of the other images injected by KServe
+	storageInitializerConfigMapKeyName = "storageInitializer"
+	loggerConfigMapKeyName             = "logger"
+	batcherConfigMapKeyName            = "batcher"
+)
+
+// ImagePolicyConfig controls how the images of the containers injected by KServe are pulled
+// +kubebuilder:object:generate=false
+type ImagePolicyConfig struct {
+	// ImagePullPolicy is set on the agent, router and storage-initializer containers when not empty
+	ImagePullPolicy v1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// PinDigests replaces the image tags of the agent, router and storage-initializer containers
+	// by the digests the leader resolves when the controller starts
+	PinDigests bool `json:"pinDigests,omitempty"`
+	// RegistryMirrors rewrites the images injected by KServe, including the serving runtime defaults, so that they
+	// are pulled from a mirror, e.g. "registry.redhat.io" -> "mirror.internal". A key is either a registry or a
+	// registry followed by a repository prefix, the longest matching key wins.
+	RegistryMirrors map[string]string `json:"registryMirrors,omitempty"`
+	// AttestationPublicKey is the PEM encoded public key the sigstore attestations of the images run by the
+	// InferenceServices are verified with, the result is recorded in the images of the component statuses.
+	// The images are not verified when it is empty.
+	AttestationPublicKey string `json:"attestationPublicKey,omitempty"`
+}
+
+// ResolvedImage records the digest a system image was resolved to
+// +kubebuilder:object:generate=false
+type ResolvedImage struct {
+	Image      string `json:"image"`
+	Digest     string `json:"digest,omitempty"`
+	Error      string `json:"error,omitempty"`
+	ResolvedAt string `json:"resolvedAt"`
+}
+
+var (
+	log = logf.Log.WithName("podconfig")
+
+	resolvedImagesMutex sync.RWMutex
+	resolvedImages      = map[string]string{}
+)
+
+func GetImagePolicyConfig(configMap *v1.ConfigMap) (*ImagePolicyConfig, error) {
+	config := &ImagePolicyConfig{}
+	if value, ok := configMap.Data[ImagePolicyConfigMapKeyName]; ok {
+		if err := json.Unmarshal([]byte(value), config); err != nil {
+			return nil, fmt.Errorf("unable to unmarshall %v json string due to %w ", ImagePolicyConfigMapKeyName, err)
+		}
+	}
+	switch config.ImagePullPolicy {
+	case "", v1.PullAlways, v1.PullIfNotPresent, v1.PullNever:
+	default:
+		return nil, fmt.Errorf("invalid %v config - imagePullPolicy %q is not supported", ImagePolicyConfigMapKeyName,
+			config.ImagePullPolicy)
+	}
+	for source, mirror := range config.RegistryMirrors {
+		if source == "" || mirror == "" {
+			return nil, fmt.Errorf("invalid %v config - registryMirrors entries must not be empty", ImagePolicyConfigMapKeyName)
+		}
+	}
+	if config.AttestationPublicKey != "" {
+		if _, err := ParseAttestationPublicKey(config.AttestationPublicKey); err != nil {
+			return nil, fmt.Errorf("invalid %v config - attestationPublicKey: %w", ImagePolicyConfigMapKeyName, err)
+		}
+	}
+	return config, nil
+}
+
+// MirrorImage rewrites the registry of an image according to the registry mirrors.
+func (c *ImagePolicyConfig) MirrorImage(image string) string {
+	source := ""
+	for key := range c.RegistryMirrors {
+		if len(key) > len(source) && matchesImagePrefix(image, key) {
+			source = key
+		}
+	}
+	if source == "" {
+		return image
+	}
+	if strings.HasPrefix(image, source+"/") {
+		return c.RegistryMirrors[source] + strings.TrimPrefix(image, source)
+	}
+	// The image has no registry and defaults to docker.io
+	return c.RegistryMirrors[source] + "/" + strings.TrimPrefix(normalizeDockerHubImage(image), source+"/")
+}
+
+// matchesImagePrefix returns true when the image is under the registry or repository prefix.
+func matchesImagePrefix(image string, prefix string) bool {
+	return strings.HasPrefix(image, prefix+"/") || strings.HasPrefix(normalizeDockerHubImage(image), prefix+"/")
+}
+
+// normalizeDockerHubImage prepends docker.io to the images without registry, e.g. "kserve/agent" or "nginx".
+func normalizeDockerHubImage(image string) string {
+	firstSegment, _, found := strings.Cut(image, "/")
+	if found && (strings.ContainsAny(firstSegment, ".:") || firstSegment == "localhost") {
+		return image
+	}
+	if !found {
+		return "docker.io/library/" + image
+	}
+	return "docker.io/" + image
+}
+
+// PinnedImage returns the image pinned by digest if it was resolved, otherwise the image is returned unchanged.
+func PinnedImage(image string) string {
+	resolvedImagesMutex.RLock()
+	defer resolvedImagesMutex.RUnlock()
+	if digest, ok := resolvedImages[image]; ok {
+		ref, err := name.ParseReference(image)
+		if err != nil {
+			return image
+		}
+		return ref.Context().Name() + "@" + digest
+	}
+	return image
+}
+
+// SetResolvedImageDigest stores the digest resolved for an image.
+func SetResolvedImageDigest(image string, digest string) {
+	resolvedImagesMutex.Lock()
+	defer resolvedImagesMutex.Unlock()
+	resolvedImages[image] = digest
+}
+
+// ApplyToContainer sets the pull policy and the mirrored, pinned image on a container injected by KServe.
+func (c *ImagePolicyConfig) ApplyToContainer(container *v1.Container) {
+	container.Image = c.MirrorImage(container.Image)
+	if c.PinDigests {
+		container.Image = PinnedImage(container.Image)
+	}
+	if c.ImagePullPolicy != "" {
+		container.ImagePullPolicy = c.ImagePullPolicy
+	}
+}
+
+// InjectImagePolicy applies the image policy to the storage-initializer and agent containers.
+func (c *ImagePolicyConfig) InjectImagePolicy(pod *v1.Pod) error {
+	for _, container := range SystemContainers(pod) {
+		c.ApplyToContainer(container)
+	}
+	return nil
+}
+
+// SystemContainers returns the storage-initializer and agent containers injected by KServe.
+func SystemContainers(pod *v1.Pod) []*v1.Container {
+	var containers []*v1.Container
+	for i := range pod.Spec.InitContainers {
+		if pod.Spec.InitContainers[i].Name == constants.StorageInitializerContainerName {
+			containers = append(containers, &pod.Spec.InitContainers[i])
+		}
+	}
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == constants.AgentContainerName {
+			containers = append(containers, &pod.Spec.Containers[i])
+		}
+	}
+	return containers
+}
+
+// getSystemImages returns the images of the containers injected by KServe, keyed by configuration name.
+func getSystemImages(configMap *v1.ConfigMap) map[string]string {
+	images := map[string]string{}
+	for _, key := range []string{
+		storageInitializerConfigMapKeyName,
+		constants.AgentConfigMapKeyName,
+		loggerConfigMapKeyName,
+		batcherConfigMapKeyName,
+		RouterConfigMapKeyName,
+	} {
+		config := struct {
+			Image string `json:"image"`
+		}{}
+		if value, ok := configMap.Data[key]; ok {
+			if err := json.Unmarshal([]byte(value), &config); err == nil && config.Image != "" {
+				images[key] = config.Image
+			}
+		}
+	}
+	return images
+}
+
+// ResolveSystemImageDigests resolves the tags of the system images to digests when digest pinning is enabled
+// and records the result in the resolved images configmap for audit.
+func ResolveSystemImageDigests(ctx context.Context, clientset kubernetes.Interface, httpClient *http.Client) error {
+	configMap, err := clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Get(ctx,
+		constants.InferenceServiceConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	imagePolicyConfig, err := GetImagePolicyConfig(configMap)
+	if err != nil {
+		return err
+	}
+	if !imagePolicyConfig.PinDigests {
+		return nil
+	}
+	securityConfig, err := v1beta1.GetSecurityConfig(configMap)
+	if err != nil {
+		return err
+	}
+
+	status := map[string]string{}
+	for key, image := range getSystemImages(configMap) {
+		// The mirrored FIPS variants are the images running in the generated pods
+		image = imagePolicyConfig.MirrorImage(securityConfig.GetImage(image))
+		resolved := ResolvedImage{Image: image, ResolvedAt: time.Now().UTC().Format(time.RFC3339)}
+		digest, err := ResolveImageDigest(httpClient, image)
+		if err != nil {
+			log.Error(err, "Failed to resolve image digest", "image", image)
+			resolved.Error = err.Error()
+		} else {
+			SetResolvedImageDigest(image, digest)
+			resolved.Digest = digest
+		}
+		bytes, err := json.Marshal(resolved)
+		if err != nil {
+			return err
+		}
+		status[key] = string(bytes)
+	}
+	return recordResolvedImages(ctx, clientset, status)
+}
+
+func recordResolvedImages(ctx context.Context, clientset kubernetes.Interface, data map[string]string) error {
+	configMaps := clientset.CoreV1().ConfigMaps(constants.KServeNamespace)
+	existing, err := configMaps.Get(ctx, ResolvedImagesConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		if !apierr.IsNotFound(err) {
+			return err
+		}
+		_, err = configMaps.Create(ctx, &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ResolvedImagesConfigMapName,
+				Namespace: constants.KServeNamespace,
+			},
+			Data: data,
+		}, metav1.CreateOptions{})
+		return err
+	}
+	existing.Data = data
+	_, err = configMaps.Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
+
+// LoadResolvedImageDigests stores the digests recorded in the resolved images configmap, nothing is loaded until
+// the configmap is recorded.
+func LoadResolvedImageDigests(ctx context.Context, clientset kubernetes.Interface) error {
+	configMap, err := clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Get(ctx, ResolvedImagesConfigMapName,
+		metav1.GetOptions{})
+	if err != nil {
+		if apierr.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	for _, value := range configMap.Data {
+		resolved := ResolvedImage{}
+		if err := json.Unmarshal([]byte(value), &resolved); err != nil || resolved.Digest == "" {
+			continue
+		}
+		SetResolvedImageDigest(resolved.Image, resolved.Digest)
+	}
+	return nil
+}
+
+// ResolveImageDigest resolves an image reference to the digest of its manifest using the registry HTTP API.
+// Only anonymous access and bearer tokens issued anonymously are supported.
+func ResolveImageDigest(httpClient *http.Client, image string) (string, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return "", err
+	}
+	if digest, ok := ref.(name.Digest); ok {
+		return digest.DigestStr(), nil
+	}
+	registry := ref.Context().Registry
+	url := fmt.Sprintf("%s://%s/v2/%s/manifests/%s", registry.Scheme(), registry.RegistryStr(),
+		ref.Context().RepositoryStr(), ref.Identifier())
+
+	resp, err := headManifest(httpClient, url, "")
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		token, err := fetchAnonymousToken(httpClient, resp.Header.Get("WWW-Authenticate"))
+		if err != nil {
+			return "", err
+		}
+		if resp, err = headManifest(httpClient, url, token); err != nil {
+			return "", err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %d when resolving %s", resp.StatusCode, image)
+	}
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		return "", fmt.Errorf("registry did not return a digest for %s", image)
+	}
+	return digest, nil
+}
+
+func headManifest(httpClient *http.Client, url string, token string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodHead, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join([]string{
+		"application/vnd.oci.image.index.v1+json",
+		"application/vnd.docker.distribution.manifest.list.v2+json",
+		"application/vnd.oci.image.manifest.v1+json",
+		"application/vnd.docker.distribution.manifest.v2+json",
+	}, ", "))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+// fetchAnonymousToken requests a bearer token following the challenge returned by the registry.
+func fetchAnonymousToken(httpClient *http.Client, challenge string) (string, error) {
+	if !strings.HasPrefix(challenge, "Bearer ") {
+		return "", fmt.Errorf("unsupported registry authentication challenge %q", challenge)
+	}
+	params := map[string]string{}
+	for _, param := range strings.Split(strings.TrimPrefix(challenge, "Bearer "), ",") {
+		if key, value, found := strings.Cut(strings.TrimSpace(param), "="); found {
+			params[key] = strings.Trim(value, `"`)
+		}
+	}
+	req, err := http.NewRequest(http.MethodGet, params["realm"], nil)
+	if err != nil {
+		return "", err
+	}
+	query := req.URL.Query()
+	for _, key := range []string{"service", "scope"} {
+		if params[key] != "" {
+			query.Set(key, params[key])
+		}
+	}
+	req.URL.RawQuery = query.Encode()
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %d when requesting registry token", resp.StatusCode)
+	}
+	token := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", err
+	}
+	if token.Token != "" {
+		return token.Token, nil
+	}
+	return token.AccessToken, nil
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podconfig
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+const testDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func newTestRegistry() *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			fmt.Fprint(w, `{"token": "anonymous"}`)
+		case r.Header.Get("Authorization") != "Bearer anonymous":
+			w.Header().Set("WWW-Authenticate",
+				fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:kserve/agent:pull"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/v2/kserve/agent/manifests/v0.12.0":
+			w.Header().Set("Docker-Content-Digest", testDigest)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server
+}
+
+func TestGetImagePolicyConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	config, err := GetImagePolicyConfig(&v1.ConfigMap{
+		Data: map[string]string{
+			ImagePolicyConfigMapKeyName: `{"imagePullPolicy": "Always", "pinDigests": true}`,
+		},
+	})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(config).Should(gomega.Equal(&ImagePolicyConfig{ImagePullPolicy: v1.PullAlways, PinDigests: true}))
+
+	_, err = GetImagePolicyConfig(&v1.ConfigMap{
+		Data: map[string]string{
+			ImagePolicyConfigMapKeyName: `{"imagePullPolicy": "Sometimes"}`,
+		},
+	})
+	g.Expect(err).Should(gomega.HaveOccurred())
+}
+
+func TestInjectImagePolicy(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	SetResolvedImageDigest("kserve/agent:v0.12.0", testDigest)
+
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{
+			InitContainers: []v1.Container{{Name: constants.StorageInitializerContainerName, Image: "kserve/storage-initializer:v0.12.0"}},
+			Containers: []v1.Container{
+				{Name: constants.InferenceServiceContainerName, Image: "user/model:latest"},
+				{Name: constants.AgentContainerName, Image: "kserve/agent:v0.12.0"},
+			},
+		},
+	}
+	config := &ImagePolicyConfig{ImagePullPolicy: v1.PullIfNotPresent, PinDigests: true}
+	g.Expect(config.InjectImagePolicy(pod)).Should(gomega.Succeed())
+
+	g.Expect(pod.Spec.InitContainers[0].Image).Should(gomega.Equal("kserve/storage-initializer:v0.12.0"))
+	g.Expect(pod.Spec.InitContainers[0].ImagePullPolicy).Should(gomega.Equal(v1.PullIfNotPresent))
+	g.Expect(pod.Spec.Containers[0].Image).Should(gomega.Equal("user/model:latest"))
+	g.Expect(pod.Spec.Containers[0].ImagePullPolicy).Should(gomega.BeEmpty())
+	g.Expect(pod.Spec.Containers[1].Image).Should(gomega.Equal("index.docker.io/kserve/agent@" + testDigest))
+	g.Expect(pod.Spec.Containers[1].ImagePullPolicy).Should(gomega.Equal(v1.PullIfNotPresent))
+}
+
+func TestResolveSystemImageDigests(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	server := newTestRegistry()
+	defer server.Close()
+	registry := strings.TrimPrefix(server.URL, "http://")
+
+	image := registry + "/kserve/agent:v0.12.0"
+	clientset := fake.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+		Data: map[string]string{
+			ImagePolicyConfigMapKeyName:     `{"pinDigests": true}`,
+			constants.AgentConfigMapKeyName: fmt.Sprintf(`{"image": "%s"}`, image),
+			loggerConfigMapKeyName:          fmt.Sprintf(`{"image": "%s/kserve/missing:v0.12.0"}`, registry),
+		},
+	})
+	g.Expect(ResolveSystemImageDigests(context.TODO(), clientset, server.Client())).Should(gomega.Succeed())
+	g.Expect(PinnedImage(image)).Should(gomega.Equal(registry + "/kserve/agent@" + testDigest))
+
+	resolved, err := clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Get(context.TODO(),
+		ResolvedImagesConfigMapName, metav1.GetOptions{})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(resolved.Data[constants.AgentConfigMapKeyName]).Should(gomega.ContainSubstring(testDigest))
+	g.Expect(resolved.Data[loggerConfigMapKeyName]).Should(gomega.ContainSubstring("unexpected status 404"))
+}
+
+func TestMirrorImage(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	config := &ImagePolicyConfig{
+		RegistryMirrors: map[string]string{
+			"registry.redhat.io":        "mirror.internal",
+			"quay.io/opendatahub":       "mirror.internal/odh",
+			"docker.io":                 "mirror.internal/dockerhub",
+			"docker.io/kserve":          "mirror.internal/kserve",
+			"localhost:5000/not-in-use": "mirror.internal/unused",
+		},
+	}
+	scenarios := map[string]struct {
+		image    string
+		expected string
+	}{
+		"Registry":                 {image: "registry.redhat.io/rhoai/router:v2", expected: "mirror.internal/rhoai/router:v2"},
+		"RepositoryPrefix":         {image: "quay.io/opendatahub/kserve-agent:latest", expected: "mirror.internal/odh/kserve-agent:latest"},
+		"OtherRepository":          {image: "quay.io/modh/vllm:latest", expected: "quay.io/modh/vllm:latest"},
+		"ImplicitDockerHub":        {image: "kserve/agent:latest", expected: "mirror.internal/kserve/agent:latest"},
+		"ImplicitDockerHubLibrary": {image: "nginx", expected: "mirror.internal/dockerhub/library/nginx"},
+		"Digest": {
+			image:    "registry.redhat.io/rhoai/router@" + testDigest,
+			expected: "mirror.internal/rhoai/router@" + testDigest,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g.Expect(config.MirrorImage(scenario.image)).Should(gomega.Equal(scenario.expected))
+		})
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podconfig
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	ImagePullSecretsConfigMapKeyName = "imagePullSecrets"
+	defaultServiceAccountName        = "default"
+)
+
+// ImagePullSecretsConfig designates the pull secrets which are added to the pods generated by KServe
+// +kubebuilder:object:generate=false
+type ImagePullSecretsConfig struct {
+	// SecretNames is the list of secrets added to the generated pods, a secret is only added
+	// when it exists in the namespace of the pod.
+	SecretNames []string `json:"secretNames,omitempty"`
+}
+
+func GetImagePullSecretsConfig(configMap *v1.ConfigMap) (*ImagePullSecretsConfig, error) {
+	config := &ImagePullSecretsConfig{}
+	if value, ok := configMap.Data[ImagePullSecretsConfigMapKeyName]; ok {
+		if err := json.Unmarshal([]byte(value), config); err != nil {
+			return nil, fmt.Errorf("unable to unmarshall %v json string due to %w ", ImagePullSecretsConfigMapKeyName, err)
+		}
+	}
+	return config, nil
+}
+
+// GetDefaultImagePullSecrets returns the pull secrets of the service account followed by the configured
+// pull secrets which exist in the namespace.
+func GetDefaultImagePullSecrets(clientset kubernetes.Interface, config *ImagePullSecretsConfig,
+	namespace string, serviceAccountName string) ([]v1.LocalObjectReference, error) {
+	var secrets []v1.LocalObjectReference
+	if serviceAccountName == "" {
+		serviceAccountName = defaultServiceAccountName
+	}
+	serviceAccount, err := clientset.CoreV1().ServiceAccounts(namespace).Get(context.TODO(), serviceAccountName, metav1.GetOptions{})
+	if err == nil {
+		secrets = MergeImagePullSecrets(secrets, serviceAccount.ImagePullSecrets...)
+	} else if !apierr.IsNotFound(err) {
+		return nil, err
+	}
+
+	for _, name := range config.SecretNames {
+		if _, err := clientset.CoreV1().Secrets(namespace).Get(context.TODO(), name, metav1.GetOptions{}); err != nil {
+			if apierr.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		secrets = MergeImagePullSecrets(secrets, v1.LocalObjectReference{Name: name})
+	}
+	return secrets, nil
+}
+
+// MergeImagePullSecrets appends the given pull secrets which are not yet part of the slice
+func MergeImagePullSecrets(slice []v1.LocalObjectReference, elems ...v1.LocalObjectReference) []v1.LocalObjectReference {
+	for _, elem := range elems {
+		exists := false
+		for _, item := range slice {
+			if item.Name == elem.Name {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			slice = append(slice, elem)
+		}
+	}
+	return slice
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podconfig
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestGetImagePullSecretsConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	config, err := GetImagePullSecretsConfig(&v1.ConfigMap{
+		Data: map[string]string{
+			ImagePullSecretsConfigMapKeyName: `{"secretNames": ["registry-secret"]}`,
+		},
+	})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(config.SecretNames).Should(gomega.Equal([]string{"registry-secret"}))
+
+	_, err = GetImagePullSecretsConfig(&v1.ConfigMap{
+		Data: map[string]string{
+			ImagePullSecretsConfigMapKeyName: `["registry-secret"]`,
+		},
+	})
+	g.Expect(err).Should(gomega.HaveOccurred())
+}
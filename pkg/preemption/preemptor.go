@@ -0,0 +1,287 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preemption
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+// Preemptor periodically stops the InferenceServices of lower priority of the same namespace when the GPU pods of an
+// InferenceService cannot be scheduled, and starts them again once the InferenceService that preempted them is deleted. It only runs
+// on the leader.
+type Preemptor struct {
+	Client    client.Client
+	Clientset kubernetes.Interface
+	Config    *v1beta1.GPUPreemptionConfig
+	Recorder  record.EventRecorder
+	Log       logr.Logger
+}
+
+// gpuUsage holds the GPUs requested by the pods of an InferenceService
+type gpuUsage struct {
+	// pending is the number of GPUs of the pods unschedulable for the pending period
+	pending int64
+	// scheduled is the number of GPUs of the pods bound to a node
+	scheduled int64
+}
+
+// Start checks the pending GPU pods every interval until the context is done, the failed checks are logged and
+// retried at the next interval
+func (p *Preemptor) Start(ctx context.Context) error {
+	ticker := time.NewTicker(time.Duration(p.Config.CheckIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+	for {
+		if err := p.Preempt(ctx); err != nil {
+			p.Log.Error(err, "unable to preempt the InferenceServices of lower priority")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Preempt starts the InferenceServices whose preemptor is deleted, then stops the InferenceServices of lower
+// priority for each InferenceService whose GPU pods cannot be scheduled, the highest priority first. Only the
+// InferenceServices of the namespace of the preemptor are stopped, so that a tenant cannot stop the InferenceServices
+// of the others. They are only stopped when they free enough GPUs for the pending pods, the placement of the GPUs on
+// the nodes is not taken into account.
+func (p *Preemptor) Preempt(ctx context.Context) error {
+	isvcList := &v1beta1.InferenceServiceList{}
+	if err := p.Client.List(ctx, isvcList); err != nil {
+		return err
+	}
+	isvcs := map[string]*v1beta1.InferenceService{}
+	for i := range isvcList.Items {
+		isvcs[key(&isvcList.Items[i])] = &isvcList.Items[i]
+	}
+	for _, isvc := range isvcs {
+		if preemptor, ok := isvc.Annotations[constants.PreemptedByAnnotationKey]; ok && isvcs[preemptor] == nil {
+			if err := p.restore(ctx, isvc, preemptor); err != nil {
+				p.Log.Error(err, "unable to start preempted InferenceService", "namespace", isvc.Namespace, "name", isvc.Name)
+			}
+		}
+	}
+
+	usages, err := p.getGPUUsages(ctx, isvcs)
+	if err != nil {
+		return err
+	}
+	// the GPUs still held by the pods of the InferenceServices being stopped for each preemptor
+	freeing := map[string]int64{}
+	for name, isvc := range isvcs {
+		if preemptor, ok := isvc.Annotations[constants.PreemptedByAnnotationKey]; ok {
+			freeing[preemptor] += usages[name].scheduled
+		}
+	}
+	priorities := &priorityResolver{clientset: p.Clientset, maxServingPriority: p.Config.MaxServingPriority,
+		priorityClasses: map[string]int32{}}
+	var preemptors []string
+	for name, usage := range usages {
+		// the preemptor waits for the pods of the InferenceServices it already stopped to be deleted
+		if usage.pending > 0 && freeing[name] == 0 && !utils.IsStopped(isvcs[name].Annotations) {
+			preemptors = append(preemptors, name)
+		}
+	}
+	sort.Slice(preemptors, func(i, j int) bool {
+		pi, pj := priorities.get(ctx, isvcs[preemptors[i]]), priorities.get(ctx, isvcs[preemptors[j]])
+		return pi > pj || (pi == pj && preemptors[i] < preemptors[j])
+	})
+
+	for _, name := range preemptors {
+		preemptor := isvcs[name]
+		priority := priorities.get(ctx, preemptor)
+		var candidates []string
+		for victim, usage := range usages {
+			if isvcs[victim].Namespace != preemptor.Namespace || usage.scheduled == 0 ||
+				utils.IsStopped(isvcs[victim].Annotations) || priorities.get(ctx, isvcs[victim]) >= priority {
+				continue
+			}
+			candidates = append(candidates, victim)
+		}
+		// the lowest priority first, then the most recently created
+		sort.Slice(candidates, func(i, j int) bool {
+			ci, cj := isvcs[candidates[i]], isvcs[candidates[j]]
+			pi, pj := priorities.get(ctx, ci), priorities.get(ctx, cj)
+			if pi != pj {
+				return pi < pj
+			}
+			if !ci.CreationTimestamp.Equal(&cj.CreationTimestamp) {
+				return cj.CreationTimestamp.Before(&ci.CreationTimestamp)
+			}
+			return candidates[i] < candidates[j]
+		})
+		var victims []string
+		freed := int64(0)
+		for _, victim := range candidates {
+			if freed >= usages[name].pending {
+				break
+			}
+			victims = append(victims, victim)
+			freed += usages[victim].scheduled
+		}
+		if freed < usages[name].pending {
+			p.Log.Info("Not enough GPUs held by InferenceServices of lower priority in the namespace",
+				"namespace", preemptor.Namespace, "name", preemptor.Name, "pending", usages[name].pending, "preemptible", freed)
+			continue
+		}
+		for _, victim := range victims {
+			if err := p.stop(ctx, isvcs[victim], preemptor, usages[victim].scheduled, priority); err != nil {
+				p.Log.Error(err, "unable to preempt InferenceService", "namespace", isvcs[victim].Namespace,
+					"name", isvcs[victim].Name)
+			}
+		}
+	}
+	return nil
+}
+
+// getGPUUsages returns the GPUs requested by the pods of each InferenceService
+func (p *Preemptor) getGPUUsages(ctx context.Context, isvcs map[string]*v1beta1.InferenceService) (map[string]gpuUsage, error) {
+	pods, err := p.Clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		LabelSelector: constants.InferenceServicePodLabelKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	pendingPeriod := time.Duration(p.Config.PendingSeconds) * time.Second
+	usages := map[string]gpuUsage{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		name := pod.Namespace + "/" + pod.Labels[constants.InferenceServicePodLabelKey]
+		gpus := getGPUs(pod, corev1.ResourceName(p.Config.ResourceName))
+		if isvcs[name] == nil || gpus == 0 || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		usage := usages[name]
+		if pod.Spec.NodeName != "" {
+			usage.scheduled += gpus
+		} else if pod.DeletionTimestamp.IsZero() && isUnschedulable(pod, pendingPeriod) {
+			usage.pending += gpus
+		}
+		usages[name] = usage
+	}
+	return usages, nil
+}
+
+// stop sets the stop annotation of the InferenceService of lower priority and records the preemption
+func (p *Preemptor) stop(ctx context.Context, victim *v1beta1.InferenceService, preemptor *v1beta1.InferenceService,
+	gpus int64, priority int32) error {
+	patch := client.MergeFrom(victim.DeepCopy())
+	if victim.Annotations == nil {
+		victim.Annotations = map[string]string{}
+	}
+	victim.Annotations[constants.StopAnnotationKey] = "true"
+	victim.Annotations[constants.PreemptedByAnnotationKey] = key(preemptor)
+	if err := p.Client.Patch(ctx, victim, patch); err != nil {
+		return err
+	}
+	p.Log.Info("Preempted InferenceService", "namespace", victim.Namespace, "name", victim.Name, "preemptor", key(preemptor))
+	p.Recorder.Eventf(victim, corev1.EventTypeWarning, "Preempted",
+		"Stopped to free %d GPUs for InferenceService %s of higher priority %d", gpus, key(preemptor), priority)
+	p.Recorder.Eventf(preemptor, corev1.EventTypeNormal, "PreemptedLowerPriority",
+		"Stopped InferenceService %s to free %d GPUs for the unschedulable pods", key(victim), gpus)
+	return nil
+}
+
+// restore removes the stop annotation set by the preemption once the preemptor is deleted
+func (p *Preemptor) restore(ctx context.Context, isvc *v1beta1.InferenceService, preemptor string) error {
+	patch := client.MergeFrom(isvc.DeepCopy())
+	delete(isvc.Annotations, constants.StopAnnotationKey)
+	delete(isvc.Annotations, constants.PreemptedByAnnotationKey)
+	if err := p.Client.Patch(ctx, isvc, patch); err != nil {
+		return err
+	}
+	p.Log.Info("Started preempted InferenceService", "namespace", isvc.Namespace, "name", isvc.Name)
+	p.Recorder.Eventf(isvc, corev1.EventTypeNormal, "PreemptionLifted",
+		"Started again as InferenceService %s that preempted it is deleted", preemptor)
+	return nil
+}
+
+// priorityResolver resolves the priorities of the InferenceServices, the PriorityClasses are cached
+type priorityResolver struct {
+	clientset          kubernetes.Interface
+	maxServingPriority int32
+	priorityClasses    map[string]int32
+}
+
+// get returns the servingPriority of the InferenceService, or else the value of the PriorityClass of its predictor,
+// capped to the maxServingPriority. The servingPriority may have been set before the max was lowered, and the value of
+// a system PriorityClass exceeds it.
+func (r *priorityResolver) get(ctx context.Context, isvc *v1beta1.InferenceService) int32 {
+	if isvc.Spec.ServingPriority != nil {
+		return min(*isvc.Spec.ServingPriority, r.maxServingPriority)
+	}
+	name := isvc.Spec.Predictor.PriorityClassName
+	if name == "" {
+		return 0
+	}
+	if value, ok := r.priorityClasses[name]; ok {
+		return min(value, r.maxServingPriority)
+	}
+	value := int32(0)
+	priorityClass, err := r.clientset.SchedulingV1().PriorityClasses().Get(ctx, name, metav1.GetOptions{})
+	if err == nil {
+		value = priorityClass.Value
+	} else if !apierr.IsNotFound(err) {
+		return 0
+	}
+	r.priorityClasses[name] = value
+	return min(value, r.maxServingPriority)
+}
+
+// isUnschedulable returns true if the scheduler reported the pod unschedulable for the pending period
+func isUnschedulable(pod *corev1.Pod, pendingPeriod time.Duration) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse &&
+			condition.Reason == corev1.PodReasonUnschedulable {
+			return !condition.LastTransitionTime.Add(pendingPeriod).After(time.Now())
+		}
+	}
+	return false
+}
+
+// getGPUs returns the number of GPUs requested by the containers of the pod
+func getGPUs(pod *corev1.Pod, resourceName corev1.ResourceName) int64 {
+	gpus := int64(0)
+	for _, container := range pod.Spec.Containers {
+		if quantity, ok := container.Resources.Limits[resourceName]; ok {
+			gpus += quantity.Value()
+		} else if quantity, ok := container.Resources.Requests[resourceName]; ok {
+			gpus += quantity.Value()
+		}
+	}
+	return gpus
+}
+
+func key(isvc *v1beta1.InferenceService) string {
+	return isvc.Namespace + "/" + isvc.Name
+}
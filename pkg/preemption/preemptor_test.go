@@ -0,0 +1,283 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preemption
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+	v1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestPreempt(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	now := time.Now()
+	isvc := func(name string, priority *int32, priorityClassName string, age time.Duration) *v1beta1.InferenceService {
+		isvc := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			CreationTimestamp: metav1.NewTime(now.Add(-age)),
+		}}
+		isvc.Spec.ServingPriority = priority
+		isvc.Spec.Predictor.PriorityClassName = priorityClassName
+		return isvc
+	}
+	pod := func(isvcName string, gpus int64, nodeName string, unschedulableSince time.Duration) runtime.Object {
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      isvcName + "-predictor-0",
+				Namespace: "default",
+				Labels:    map[string]string{constants.InferenceServicePodLabelKey: isvcName},
+			},
+			Spec: v1.PodSpec{
+				NodeName: nodeName,
+				Containers: []v1.Container{{
+					Name: constants.InferenceServiceContainerName,
+					Resources: v1.ResourceRequirements{Limits: v1.ResourceList{
+						constants.NvidiaGPUResourceType: *resource.NewQuantity(gpus, resource.DecimalSI),
+					}},
+				}},
+			},
+			Status: v1.PodStatus{Phase: v1.PodPending},
+		}
+		if nodeName != "" {
+			pod.Status.Phase = v1.PodRunning
+		} else {
+			pod.Status.Conditions = []v1.PodCondition{{
+				Type:               v1.PodScheduled,
+				Status:             v1.ConditionFalse,
+				Reason:             v1.PodReasonUnschedulable,
+				LastTransitionTime: metav1.NewTime(now.Add(-unschedulableSince)),
+			}}
+		}
+		return pod
+	}
+
+	orphan := isvc("orphan", nil, "", time.Hour)
+	orphan.Annotations = map[string]string{
+		constants.StopAnnotationKey:        "true",
+		constants.PreemptedByAnnotationKey: "default/deleted",
+	}
+	scheme := runtime.NewScheme()
+	g.Expect(v1beta1.AddToScheme(scheme)).To(gomega.Succeed())
+	client := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(
+		// critical waits for 2 GPUs, the InferenceServices of priority 0 are preempted before batch
+		isvc("critical", proto.Int32(100), "", time.Hour),
+		isvc("gold", proto.Int32(200), "", time.Hour),
+		isvc("batch", nil, "low", 3*time.Hour),
+		isvc("experiment", nil, "", 2*time.Hour),
+		isvc("dev", nil, "", time.Hour),
+		// recent has not been unschedulable for the pending period
+		isvc("recent", proto.Int32(50), "", time.Minute),
+		orphan,
+	).Build()
+	clientset := fake.NewSimpleClientset(
+		&schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: "low"}, Value: 10},
+		pod("critical", 2, "", 5*time.Minute),
+		pod("gold", 4, "node-1", 0),
+		pod("batch", 1, "node-2", 0),
+		pod("experiment", 1, "node-2", 0),
+		pod("dev", 1, "node-3", 0),
+		pod("recent", 1, "", 30*time.Second),
+	)
+	recorder := record.NewFakeRecorder(10)
+	preemptor := &Preemptor{
+		Client:    client,
+		Clientset: clientset,
+		Config: &v1beta1.GPUPreemptionConfig{
+			Enabled:              true,
+			ResourceName:         constants.NvidiaGPUResourceType,
+			PendingSeconds:       v1beta1.DefaultPreemptionPendingSeconds,
+			CheckIntervalSeconds: v1beta1.DefaultPreemptionCheckIntervalSeconds,
+			MaxServingPriority:   1000,
+		},
+		Recorder: recorder,
+		Log:      logr.Discard(),
+	}
+	g.Expect(preemptor.Preempt(context.TODO())).To(gomega.Succeed())
+
+	for name, preemptedBy := range map[string]string{
+		"critical": "", "gold": "", "batch": "", "experiment": "default/critical", "dev": "default/critical",
+		"recent": "", "orphan": "",
+	} {
+		actual := &v1beta1.InferenceService{}
+		g.Expect(client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: name}, actual)).To(gomega.Succeed())
+		g.Expect(actual.Annotations[constants.PreemptedByAnnotationKey]).To(gomega.Equal(preemptedBy), name)
+		g.Expect(actual.Annotations[constants.StopAnnotationKey] == "true").To(gomega.Equal(preemptedBy != ""), name)
+	}
+	g.Expect(recorder.Events).To(gomega.HaveLen(5))
+
+	// the preemptor waits for the pods of the preempted InferenceServices to be deleted
+	g.Expect(preemptor.Preempt(context.TODO())).To(gomega.Succeed())
+	batch := &v1beta1.InferenceService{}
+	g.Expect(client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "batch"}, batch)).To(gomega.Succeed())
+	g.Expect(batch.Annotations).NotTo(gomega.HaveKey(constants.StopAnnotationKey))
+	g.Expect(recorder.Events).To(gomega.HaveLen(5))
+}
+
+func TestPreemptNotEnoughGPUs(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(v1beta1.AddToScheme(scheme)).To(gomega.Succeed())
+	critical := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Name: "critical", Namespace: "default"}}
+	critical.Spec.ServingPriority = proto.Int32(100)
+	dev := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "default"}}
+	client := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(critical, dev).Build()
+	gpus := v1.ResourceList{constants.NvidiaGPUResourceType: resource.MustParse("4")}
+	clientset := fake.NewSimpleClientset(
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "critical-predictor-0", Namespace: "default",
+				Labels: map[string]string{constants.InferenceServicePodLabelKey: "critical"}},
+			Spec: v1.PodSpec{Containers: []v1.Container{{Resources: v1.ResourceRequirements{Requests: gpus}}}},
+			Status: v1.PodStatus{Phase: v1.PodPending, Conditions: []v1.PodCondition{{
+				Type:               v1.PodScheduled,
+				Status:             v1.ConditionFalse,
+				Reason:             v1.PodReasonUnschedulable,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+			}}},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "dev-predictor-0", Namespace: "default",
+				Labels: map[string]string{constants.InferenceServicePodLabelKey: "dev"}},
+			Spec: v1.PodSpec{NodeName: "node-1", Containers: []v1.Container{{
+				Resources: v1.ResourceRequirements{Limits: v1.ResourceList{constants.NvidiaGPUResourceType: resource.MustParse("1")}},
+			}}},
+			Status: v1.PodStatus{Phase: v1.PodRunning},
+		},
+	)
+	recorder := record.NewFakeRecorder(10)
+	preemptor := &Preemptor{
+		Client:    client,
+		Clientset: clientset,
+		Config: &v1beta1.GPUPreemptionConfig{ResourceName: constants.NvidiaGPUResourceType, PendingSeconds: 120,
+			MaxServingPriority: 1000},
+		Recorder: recorder,
+		Log:      logr.Discard(),
+	}
+	g.Expect(preemptor.Preempt(context.TODO())).To(gomega.Succeed())
+
+	actual := &v1beta1.InferenceService{}
+	g.Expect(client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "dev"}, actual)).To(gomega.Succeed())
+	g.Expect(actual.Annotations).NotTo(gomega.HaveKey(constants.StopAnnotationKey))
+	g.Expect(recorder.Events).To(gomega.BeEmpty())
+}
+
+func TestPreemptNamespaceAndMaxServingPriority(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := func(namespace string, name string, priority *int32) *v1beta1.InferenceService {
+		isvc := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+		isvc.Spec.ServingPriority = priority
+		return isvc
+	}
+	pod := func(namespace string, isvcName string, nodeName string) runtime.Object {
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: isvcName + "-predictor-0", Namespace: namespace,
+				Labels: map[string]string{constants.InferenceServicePodLabelKey: isvcName}},
+			Spec: v1.PodSpec{NodeName: nodeName, Containers: []v1.Container{{
+				Resources: v1.ResourceRequirements{Limits: v1.ResourceList{constants.NvidiaGPUResourceType: resource.MustParse("1")}},
+			}}},
+			Status: v1.PodStatus{Phase: v1.PodRunning},
+		}
+		if nodeName == "" {
+			pod.Status = v1.PodStatus{Phase: v1.PodPending, Conditions: []v1.PodCondition{{
+				Type:               v1.PodScheduled,
+				Status:             v1.ConditionFalse,
+				Reason:             v1.PodReasonUnschedulable,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+			}}}
+		}
+		return pod
+	}
+	scheme := runtime.NewScheme()
+	g.Expect(v1beta1.AddToScheme(scheme)).To(gomega.Succeed())
+	client := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(
+		// the servingPriority of critical is capped to 100, the one of dev
+		isvc("team-a", "critical", proto.Int32(5000)),
+		isvc("team-a", "dev", proto.Int32(100)),
+		isvc("team-a", "scratch", nil),
+		// other is in the namespace of another tenant
+		isvc("team-b", "other", nil),
+	).Build()
+	clientset := fake.NewSimpleClientset(
+		pod("team-a", "critical", ""),
+		pod("team-a", "dev", "node-1"),
+		pod("team-a", "scratch", "node-1"),
+		pod("team-b", "other", "node-2"),
+	)
+	recorder := record.NewFakeRecorder(10)
+	preemptor := &Preemptor{
+		Client:    client,
+		Clientset: clientset,
+		Config: &v1beta1.GPUPreemptionConfig{ResourceName: constants.NvidiaGPUResourceType, PendingSeconds: 120,
+			MaxServingPriority: 100},
+		Recorder: recorder,
+		Log:      logr.Discard(),
+	}
+	g.Expect(preemptor.Preempt(context.TODO())).To(gomega.Succeed())
+
+	for _, name := range []types.NamespacedName{{Namespace: "team-a", Name: "dev"}, {Namespace: "team-b", Name: "other"}} {
+		actual := &v1beta1.InferenceService{}
+		g.Expect(client.Get(context.TODO(), name, actual)).To(gomega.Succeed())
+		g.Expect(actual.Annotations).NotTo(gomega.HaveKey(constants.StopAnnotationKey), name.String())
+	}
+	scratch := &v1beta1.InferenceService{}
+	g.Expect(client.Get(context.TODO(), types.NamespacedName{Namespace: "team-a", Name: "scratch"}, scratch)).To(gomega.Succeed())
+	g.Expect(scratch.Annotations[constants.PreemptedByAnnotationKey]).To(gomega.Equal("team-a/critical"))
+	g.Expect(recorder.Events).To(gomega.HaveLen(2))
+}
+
+func TestPriorityResolver(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := func(priority *int32, priorityClassName string) *v1beta1.InferenceService {
+		isvc := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Name: "isvc", Namespace: "default"}}
+		isvc.Spec.ServingPriority = priority
+		isvc.Spec.Predictor.PriorityClassName = priorityClassName
+		return isvc
+	}
+	resolver := &priorityResolver{
+		clientset: fake.NewSimpleClientset(
+			&schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: "low"}, Value: 10},
+			&schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: "system-node-critical"}, Value: 2000001000},
+		),
+		maxServingPriority: 100,
+		priorityClasses:    map[string]int32{},
+	}
+	ctx := context.TODO()
+
+	g.Expect(resolver.get(ctx, isvc(proto.Int32(50), "system-node-critical"))).To(gomega.Equal(int32(50)))
+	g.Expect(resolver.get(ctx, isvc(proto.Int32(5000), ""))).To(gomega.Equal(int32(100)))
+	g.Expect(resolver.get(ctx, isvc(nil, "low"))).To(gomega.Equal(int32(10)))
+	g.Expect(resolver.get(ctx, isvc(nil, "missing"))).To(gomega.Equal(int32(0)))
+	// the value of a PriorityClass is capped as well, including once it is cached
+	g.Expect(resolver.get(ctx, isvc(nil, "system-node-critical"))).To(gomega.Equal(int32(100)))
+	g.Expect(resolver.get(ctx, isvc(nil, "system-node-critical"))).To(gomega.Equal(int32(100)))
+}
@@ -0,0 +1,37 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	requests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kserve_quota_requests_total",
+		Help: "Number of requests checked against the quota of their tenant, by tenant and result",
+	}, []string{"tenant", "result"})
+	remainingRequests = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kserve_quota_remaining_requests",
+		Help: "Number of requests remaining in the current quota window of the tenants with a quota of their own",
+	}, []string{"tenant"})
+)
+
+// RegisterMetrics registers the metrics of the quotas
+func RegisterMetrics(registerer prometheus.Registerer) {
+	registerer.MustRegister(requests, remainingRequests)
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"knative.dev/pkg/network"
+
+	"github.com/kserve/kserve/pkg/middleware"
+)
+
+const (
+	LimitHeader     = "X-Quota-Limit"
+	RemainingHeader = "X-Quota-Remaining"
+	ResetHeader     = "X-Quota-Reset"
+
+	// defaultTenantLabel is the tenant label of the metrics of the tenants without a quota of their own, so that
+	// the cardinality of the metrics does not depend on the tenants sending requests
+	defaultTenantLabel = "default"
+	// defaultTenant is the key of the window shared by the tenants without a quota of their own
+	defaultTenant = ""
+)
+
+// Config specifies the per-tenant request quotas, the requests of a tenant are counted over fixed windows of the
+// period and rejected once the quota of the tenant is used up. The tenant of a request is the user its bearer token
+// is authenticated as, so the handler must be wrapped by the handler authenticating the tokens.
+type Config struct {
+	Period time.Duration
+	// DefaultLimit is the quota shared by the tenants without a quota of their own and by the requests which are not
+	// authenticated, they are unlimited when nil
+	DefaultLimit *int64
+	Tenants      map[string]int64
+}
+
+type window struct {
+	start time.Time
+	count int64
+}
+
+// Handler enforces the quotas of the tenants, the requests over the quota of their tenant are rejected with
+// 429 Too Many Requests. The quotas are counted by each replica independently.
+type Handler struct {
+	config  Config
+	next    http.Handler
+	now     func() time.Time
+	mu      sync.Mutex
+	windows map[string]*window
+	// pruned is the start of the window the ended windows were last pruned in
+	pruned time.Time
+}
+
+func NewHandler(config Config, next http.Handler) *Handler {
+	return &Handler{
+		config:  config,
+		next:    next,
+		now:     time.Now,
+		windows: map[string]*window{},
+	}
+}
+
+// tenant returns the key of the window the request is counted in and its quota, and the tenant label of its metrics.
+// The tenants without a quota of their own share a single window, so that the windows kept do not depend on the
+// users sending requests.
+func (h *Handler) tenant(r *http.Request) (string, *int64, string) {
+	user := middleware.UserFromContext(r.Context())
+	if limit, ok := h.config.Tenants[user]; ok && user != "" {
+		return user, &limit, user
+	}
+	return defaultTenant, h.config.DefaultLimit, defaultTenantLabel
+}
+
+// take counts the request in the current window of the tenant, it returns whether the request is within the quota,
+// the remaining requests and the end of the window
+func (h *Handler) take(tenant string, limit int64) (bool, int64, time.Time) {
+	now := h.now()
+	start := now.Truncate(h.config.Period)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.pruned.Before(start) {
+		h.prune(start)
+	}
+	w, ok := h.windows[tenant]
+	if !ok || w.start.Before(start) {
+		w = &window{start: start}
+		h.windows[tenant] = w
+	}
+	end := w.start.Add(h.config.Period)
+	if w.count >= limit {
+		return false, 0, end
+	}
+	w.count++
+	return true, limit - w.count, end
+}
+
+// prune removes the windows which ended, so that the tenants which stopped sending requests are not kept in memory
+func (h *Handler) prune(start time.Time) {
+	for tenant, w := range h.windows {
+		if w.start.Before(start) {
+			delete(h.windows, tenant)
+		}
+	}
+	h.pruned = start
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if network.IsKubeletProbe(r) {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+	tenant, limit, label := h.tenant(r)
+	if limit == nil {
+		requests.WithLabelValues(label, "allowed").Inc()
+		h.next.ServeHTTP(w, r)
+		return
+	}
+	allowed, remaining, end := h.take(tenant, *limit)
+	reset := strconv.Itoa(int(math.Ceil(end.Sub(h.now()).Seconds())))
+	w.Header().Set(LimitHeader, strconv.FormatInt(*limit, 10))
+	w.Header().Set(RemainingHeader, strconv.FormatInt(remaining, 10))
+	w.Header().Set(ResetHeader, reset)
+	if label != defaultTenantLabel {
+		remainingRequests.WithLabelValues(label).Set(float64(remaining))
+	}
+	if !allowed {
+		requests.WithLabelValues(label, "rejected").Inc()
+		w.Header().Set("Retry-After", reset)
+		http.Error(w, "the request quota of the tenant is exceeded", http.StatusTooManyRequests)
+		return
+	}
+	requests.WithLabelValues(label, "allowed").Inc()
+	h.next.ServeHTTP(w, r)
+}
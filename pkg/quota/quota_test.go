@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/kserve/kserve/pkg/middleware"
+)
+
+func TestTenantQuotas(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	now := time.Date(2024, 5, 1, 10, 0, 30, 0, time.UTC)
+	served := 0
+	handler := NewHandler(Config{
+		Period:       time.Minute,
+		DefaultLimit: proto.Int64(2),
+		Tenants:      map[string]int64{"team-a": 2},
+	}, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served++
+	}))
+	handler.now = func() time.Time { return now }
+
+	send := func(user string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/v1/models/llm:predict", nil)
+		if user != "" {
+			r = r.WithContext(middleware.ContextWithUser(r.Context(), user))
+		}
+		// the tenant is never taken from the headers of the request
+		r.Header.Set("X-Tenant-Id", "team-a")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	w := send("team-a")
+	g.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(w.Header().Get(LimitHeader)).To(gomega.Equal("2"))
+	g.Expect(w.Header().Get(RemainingHeader)).To(gomega.Equal("1"))
+	g.Expect(w.Header().Get(ResetHeader)).To(gomega.Equal("30"))
+	g.Expect(send("team-a").Code).To(gomega.Equal(http.StatusOK))
+	w = send("team-a")
+	g.Expect(w.Code).To(gomega.Equal(http.StatusTooManyRequests))
+	g.Expect(w.Header().Get("Retry-After")).To(gomega.Equal("30"))
+	g.Expect(w.Header().Get(RemainingHeader)).To(gomega.Equal("0"))
+
+	// the tenants without a quota of their own and the unauthenticated requests share the default quota
+	g.Expect(send("team-b").Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(send("team-c").Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(send("team-d").Code).To(gomega.Equal(http.StatusTooManyRequests))
+	g.Expect(send("").Code).To(gomega.Equal(http.StatusTooManyRequests))
+	g.Expect(served).To(gomega.Equal(4))
+	g.Expect(handler.windows).To(gomega.HaveLen(2))
+
+	// the quotas are reset in the next window and the ended windows are pruned
+	now = now.Add(time.Minute)
+	g.Expect(send("team-a").Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(handler.windows).To(gomega.HaveLen(1))
+}
+
+func TestUnlimitedDefaultQuota(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	handler := NewHandler(Config{
+		Period:  time.Hour,
+		Tenants: map[string]int64{"batch-job": 1},
+	}, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	send := func(user string) int {
+		r := httptest.NewRequest(http.MethodPost, "/v1/models/llm:predict", nil)
+		r = r.WithContext(middleware.ContextWithUser(r.Context(), user))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	g.Expect(send("batch-job")).To(gomega.Equal(http.StatusOK))
+	g.Expect(send("batch-job")).To(gomega.Equal(http.StatusTooManyRequests))
+	// the other tenants are unlimited without a default quota
+	g.Expect(send("interactive")).To(gomega.Equal(http.StatusOK))
+	g.Expect(send("interactive")).To(gomega.Equal(http.StatusOK))
+	g.Expect(handler.windows).To(gomega.HaveLen(1))
+}
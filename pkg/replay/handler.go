@@ -0,0 +1,267 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"knative.dev/pkg/network"
+)
+
+const (
+	// DebugPath is the path the captured requests are listed at, a request is replayed with a POST on
+	// DebugPath/<id>/replay
+	DebugPath = "/debug/requests"
+	// MaxBodySize is the size above which the body of a failing request is not captured, nor buffered
+	MaxBodySize = 1 << 20
+	// replayTimeout bounds the replay of a request against a revision
+	replayTimeout = time.Minute
+)
+
+// Request is a failing request captured by the replay handler
+type Request struct {
+	ID         int         `json:"id"`
+	Time       time.Time   `json:"time"`
+	Method     string      `json:"method"`
+	Path       string      `json:"path"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+	StatusCode int         `json:"statusCode"`
+}
+
+// ReplayHandler captures the last failing requests in a ring buffer and allows replaying them, either against the
+// local component or against another revision of the same namespace. The debug endpoints require the bearer token
+// the handler is created with.
+type ReplayHandler struct {
+	log       *zap.SugaredLogger
+	token     []byte
+	namespace string
+	next      http.Handler
+	client    *http.Client
+
+	mu       sync.Mutex
+	requests []Request
+	capacity int
+	nextID   int
+}
+
+func New(capacity int, token string, namespace string, next http.Handler, logger *zap.SugaredLogger) *ReplayHandler {
+	return &ReplayHandler{
+		log:       logger,
+		token:     []byte(token),
+		namespace: namespace,
+		next:      next,
+		client:    &http.Client{Timeout: replayTimeout},
+		requests:  make([]Request, 0, capacity),
+		capacity:  capacity,
+		nextID:    1,
+	}
+}
+
+// statusRecorder keeps the status code written to the response
+type statusRecorder struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (r *statusRecorder) WriteHeader(statusCode int) {
+	r.statusCode = statusCode
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (h *ReplayHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == DebugPath || strings.HasPrefix(r.URL.Path, DebugPath+"/") {
+		h.serveDebug(w, r)
+		return
+	}
+	if network.IsKubeletProbe(r) {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+
+	// at most MaxBodySize+1 bytes are buffered, the rest of a larger body is streamed to the next handler unchanged
+	// and the request is not captured
+	body, err := io.ReadAll(io.LimitReader(r.Body, MaxBodySize+1))
+	if err != nil {
+		http.Error(w, "can't read body", http.StatusBadRequest)
+		return
+	}
+	r.Body = &prefixedBody{Reader: io.MultiReader(bytes.NewReader(body), r.Body), Closer: r.Body}
+	recorder := &statusRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+	h.next.ServeHTTP(recorder, r)
+	if recorder.statusCode >= http.StatusInternalServerError && len(body) <= MaxBodySize {
+		h.capture(r, body, recorder.statusCode)
+	}
+}
+
+// prefixedBody reads the buffered start of a request body before the rest of it, and closes the original body
+type prefixedBody struct {
+	io.Reader
+	io.Closer
+}
+
+// capture adds the request to the ring buffer, the oldest request is dropped when the buffer is full
+func (h *ReplayHandler) capture(r *http.Request, body []byte, statusCode int) {
+	header := r.Header.Clone()
+	header.Del("Authorization")
+	header.Del("Cookie")
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	request := Request{
+		ID:         h.nextID,
+		Time:       time.Now().UTC(),
+		Method:     r.Method,
+		Path:       r.URL.RequestURI(),
+		Header:     header,
+		Body:       body,
+		StatusCode: statusCode,
+	}
+	h.nextID++
+	if len(h.requests) == h.capacity {
+		h.requests = append(h.requests[1:], request)
+	} else {
+		h.requests = append(h.requests, request)
+	}
+}
+
+// Requests returns the captured requests, the oldest first
+func (h *ReplayHandler) Requests() []Request {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	requests := make([]Request, len(h.requests))
+	copy(requests, h.requests)
+	return requests
+}
+
+func (h *ReplayHandler) request(id int) (Request, bool) {
+	for _, request := range h.Requests() {
+		if request.ID == id {
+			return request, true
+		}
+	}
+	return Request{}, false
+}
+
+func (h *ReplayHandler) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && len(h.token) > 0 && subtle.ConstantTimeCompare([]byte(token), h.token) == 1
+}
+
+func (h *ReplayHandler) serveDebug(w http.ResponseWriter, r *http.Request) {
+	if !h.authorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if r.URL.Path == DebugPath {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(h.Requests()); err != nil {
+			h.log.Errorw("Failed to write the captured requests", zap.Error(err))
+		}
+		return
+	}
+
+	var id int
+	if _, err := fmt.Sscanf(r.URL.Path, DebugPath+"/%d/replay", &id); err != nil ||
+		r.URL.Path != fmt.Sprintf("%s/%d/replay", DebugPath, id) {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	request, ok := h.request(id)
+	if !ok {
+		http.Error(w, fmt.Sprintf("request %d is not captured", id), http.StatusNotFound)
+		return
+	}
+	h.replay(w, r, request)
+}
+
+// replay sends the captured request again and writes the response of the replay. The request is sent to the local
+// component unless a revision is given, it is then sent to the private service of that revision.
+func (h *ReplayHandler) replay(w http.ResponseWriter, r *http.Request, request Request) {
+	revision := r.URL.Query().Get("revision")
+	if revision == "" {
+		replayed, err := http.NewRequestWithContext(r.Context(), request.Method, request.Path, bytes.NewReader(request.Body))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		replayed.Header = request.Header.Clone()
+		h.log.Infof("Replaying request %d against the local component", request.ID)
+		h.next.ServeHTTP(w, replayed)
+		return
+	}
+
+	if errs := validation.IsDNS1123Label(revision); len(errs) > 0 {
+		http.Error(w, fmt.Sprintf("invalid revision %s: %s", revision, strings.Join(errs, ", ")), http.StatusBadRequest)
+		return
+	}
+	target := &url.URL{
+		Scheme: "http",
+		Host:   network.GetServiceHostname(revision+"-private", h.namespace),
+	}
+	path, err := url.ParseRequestURI(request.Path)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	target.Path = path.Path
+	target.RawQuery = path.RawQuery
+	replayed, err := http.NewRequestWithContext(r.Context(), request.Method, target.String(), bytes.NewReader(request.Body))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	replayed.Header = request.Header.Clone()
+	h.log.Infof("Replaying request %d against revision %s", request.ID, revision)
+	resp, err := h.client.Do(replayed)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to replay request %d: %v", request.ID, err), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+	for k, v := range resp.Header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(resp.StatusCode)
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		h.log.Errorw("Failed to write the response of the replay", zap.Error(err))
+	}
+}
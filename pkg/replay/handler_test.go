@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+func TestReplayHandler(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	healthy := false
+	predictor := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !healthy || strings.Contains(string(body), "poison") {
+			http.Error(w, "model failed", http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{"predictions":[1]}`))
+	})
+	handler := New(2, "secret", "default", predictor, logger)
+
+	send := func(body string) int {
+		r := httptest.NewRequest(http.MethodPost, "/v1/models/test:predict", strings.NewReader(body))
+		r.Header.Set("Authorization", "Bearer user-token")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+	debug := func(method string, path string, token string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	// only the last failing requests are kept
+	for i := 1; i <= 3; i++ {
+		g.Expect(send(fmt.Sprintf(`{"instances":[%d]}`, i))).To(gomega.Equal(http.StatusInternalServerError))
+	}
+	healthy = true
+	g.Expect(send(`{"instances":[4]}`)).To(gomega.Equal(http.StatusOK))
+	requests := handler.Requests()
+	g.Expect(requests).To(gomega.HaveLen(2))
+	g.Expect(requests[0].ID).To(gomega.Equal(2))
+	g.Expect(string(requests[1].Body)).To(gomega.Equal(`{"instances":[3]}`))
+	g.Expect(requests[1].Path).To(gomega.Equal("/v1/models/test:predict"))
+	g.Expect(requests[1].Header).NotTo(gomega.HaveKey("Authorization"))
+
+	// the debug endpoints require the token
+	g.Expect(debug(http.MethodGet, DebugPath, "").Code).To(gomega.Equal(http.StatusUnauthorized))
+	g.Expect(debug(http.MethodGet, DebugPath, "wrong").Code).To(gomega.Equal(http.StatusUnauthorized))
+
+	w := debug(http.MethodGet, DebugPath, "secret")
+	g.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+	var listed []Request
+	g.Expect(json.Unmarshal(w.Body.Bytes(), &listed)).To(gomega.Succeed())
+	g.Expect(listed).To(gomega.Equal(requests))
+
+	// the request succeeds once replayed against the fixed component
+	w = debug(http.MethodPost, DebugPath+"/3/replay", "secret")
+	g.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(w.Body.String()).To(gomega.Equal(`{"predictions":[1]}`))
+
+	g.Expect(debug(http.MethodPost, DebugPath+"/1/replay", "secret").Code).To(gomega.Equal(http.StatusNotFound))
+	g.Expect(debug(http.MethodGet, DebugPath+"/3/replay", "secret").Code).To(gomega.Equal(http.StatusMethodNotAllowed))
+	g.Expect(debug(http.MethodPost, DebugPath+"/3/other", "secret").Code).To(gomega.Equal(http.StatusNotFound))
+	g.Expect(debug(http.MethodPost, DebugPath+"/3/replay?revision=Not.A.Revision", "secret").Code).
+		To(gomega.Equal(http.StatusBadRequest))
+}
+
+func TestReplayHandlerLargeBody(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	var received []byte
+	predictor := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		http.Error(w, "model failed", http.StatusInternalServerError)
+	})
+	handler := New(2, "secret", "default", predictor, logger)
+	send := func(body string) {
+		r := httptest.NewRequest(http.MethodPost, "/v1/models/test:predict", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		g.Expect(w.Code).To(gomega.Equal(http.StatusInternalServerError))
+	}
+
+	// a body of the max size is captured
+	body := strings.Repeat("a", MaxBodySize)
+	send(body)
+	g.Expect(string(received)).To(gomega.Equal(body))
+	g.Expect(handler.Requests()).To(gomega.HaveLen(1))
+
+	// a larger body is passed on unchanged, without being captured
+	body = strings.Repeat("b", MaxBodySize) + strings.Repeat("c", MaxBodySize)
+	send(body)
+	g.Expect(string(received)).To(gomega.Equal(body))
+	g.Expect(handler.Requests()).To(gomega.HaveLen(1))
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package api defines the graph document the InferenceGraph controller passes to the router with the --graph-json
+// flag. The document is versioned and published as a JSON schema so that the router and the controller can be
+// upgraded independently and that graphs can be generated outside of the controller.
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+const (
+	// Version1 is the first version of the graph document, the documents without version are read as Version1
+	Version1 = "v1"
+	// CurrentVersion is the version of the documents written by the controller
+	CurrentVersion = Version1
+)
+
+// SupportedVersions are the versions of the graph document the router can decode
+var SupportedVersions = []string{Version1}
+
+// Graph is the graph document routed by the router
+type Graph struct {
+	// Version of the document, the documents without version are read as Version1
+	Version string `json:"version,omitempty"`
+	// InferenceGraphSpec is the spec of the graph, the fields which only configure the router deployment are ignored
+	// by the router
+	v1alpha1.InferenceGraphSpec `json:",inline"`
+}
+
+// NewGraph returns the document of the current version for the given graph spec
+func NewGraph(spec *v1alpha1.InferenceGraphSpec) *Graph {
+	return &Graph{
+		Version:            CurrentVersion,
+		InferenceGraphSpec: *spec,
+	}
+}
+
+// Encode returns the document of the current version for the given graph spec
+func Encode(spec *v1alpha1.InferenceGraphSpec) ([]byte, error) {
+	return json.Marshal(NewGraph(spec))
+}
+
+// Decode reads a graph document, the document is rejected when it has a version the router does not support, an
+// unknown field or trailing data so that a graph is never routed differently than the writer intended
+func Decode(data []byte) (*Graph, error) {
+	graph := &Graph{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(graph); err != nil {
+		return nil, fmt.Errorf("invalid graph document: %w", err)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("invalid graph document: unexpected data after the document")
+	}
+	if graph.Version == "" {
+		graph.Version = Version1
+	}
+	if !slices.Contains(SupportedVersions, graph.Version) {
+		return nil, fmt.Errorf("unsupported graph document version %q, the supported versions are %v", graph.Version,
+			SupportedVersions)
+	}
+	return graph, nil
+}
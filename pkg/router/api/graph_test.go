@@ -0,0 +1,145 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+func TestDecode(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		document string
+		expected *Graph
+		matcher  gomega.OmegaMatcher
+	}{
+		"Current": {
+			document: `{"version":"v1","nodes":{"root":{"routerType":"Sequence","steps":[{"serviceUrl":"http://model"}]}}}`,
+			expected: &Graph{
+				Version: Version1,
+				InferenceGraphSpec: v1alpha1.InferenceGraphSpec{
+					Nodes: map[string]v1alpha1.InferenceRouter{
+						v1alpha1.GraphRootNodeName: {
+							RouterType: v1alpha1.Sequence,
+							Steps: []v1alpha1.InferenceStep{
+								{InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: "http://model"}},
+							},
+						},
+					},
+				},
+			},
+			matcher: gomega.Succeed(),
+		},
+		// the documents written by the controllers before the document was versioned
+		"WithoutVersion": {
+			document: `{"nodes":{"root":{"routerType":"Sequence","steps":[{"serviceUrl":"http://model"}]}},"resources":{"limits":{"cpu":"100m"}}}`,
+			expected: &Graph{
+				Version: Version1,
+				InferenceGraphSpec: v1alpha1.InferenceGraphSpec{
+					Nodes: map[string]v1alpha1.InferenceRouter{
+						v1alpha1.GraphRootNodeName: {
+							RouterType: v1alpha1.Sequence,
+							Steps: []v1alpha1.InferenceStep{
+								{InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: "http://model"}},
+							},
+						},
+					},
+					Resources: v1.ResourceRequirements{
+						Limits: v1.ResourceList{v1.ResourceCPU: resource.MustParse("100m")},
+					},
+				},
+			},
+			matcher: gomega.Succeed(),
+		},
+		"UnsupportedVersion": {
+			document: `{"version":"v2","nodes":{"root":{"routerType":"Sequence"}}}`,
+			matcher:  gomega.MatchError(`unsupported graph document version "v2", the supported versions are [v1]`),
+		},
+		"UnknownField": {
+			document: `{"version":"v1","nodes":{"root":{"routerType":"Sequence","steps":[{"serviceUrl":"http://model","endpoint":"http://other"}]}}}`,
+			matcher:  gomega.MatchError(`invalid graph document: json: unknown field "endpoint"`),
+		},
+		"TrailingData": {
+			document: `{"version":"v1","nodes":{}}{}`,
+			matcher:  gomega.MatchError("invalid graph document: unexpected data after the document"),
+		},
+		"Malformed": {
+			document: `{"version":"v1","nodes":`,
+			matcher:  gomega.MatchError("invalid graph document: unexpected EOF"),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			graph, err := Decode([]byte(scenario.document))
+			g.Expect(err).Should(scenario.matcher)
+			g.Expect(graph).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	spec := &v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			v1alpha1.GraphRootNodeName: {
+				RouterType: v1alpha1.Splitter,
+				Steps: []v1alpha1.InferenceStep{
+					{
+						StepName:        "model",
+						InferenceTarget: v1alpha1.InferenceTarget{ServiceName: "model"},
+						Weight:          proto.Int64(100),
+					},
+				},
+			},
+		},
+		Quota: &v1alpha1.QuotaSpec{Audiences: []string{"llm-graph"}, Period: metav1.Duration{Duration: time.Minute}},
+	}
+	data, err := Encode(spec)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(string(data)).To(gomega.Equal(`{"version":"v1","nodes":{"root":{"routerType":"Splitter","steps":[{"name":"model","serviceName":"model","weight":100}]}},"resources":{},"quota":{"audiences":["llm-graph"],"period":"1m0s"}}`))
+
+	graph, err := Decode(data)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(graph).To(gomega.Equal(NewGraph(spec)))
+}
+
+func TestSchema(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	schema, err := Schema()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	// the published schema is regenerated with hack/update-openapigen.sh
+	published, err := os.ReadFile("graph." + CurrentVersion + ".schema.json")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(string(published)).To(gomega.Equal(string(schema) + "\n"))
+
+	var document map[string]interface{}
+	g.Expect(json.Unmarshal(schema, &document)).To(gomega.Succeed())
+	g.Expect(document["properties"]).To(gomega.HaveKey("version"))
+	g.Expect(document["additionalProperties"]).To(gomega.BeFalse())
+	g.Expect(document["definitions"]).To(gomega.HaveKey("v1alpha1.InferenceRouter"))
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"k8s.io/kube-openapi/pkg/common"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+const (
+	// SchemaID is the identifier of the published JSON schema of the graph document
+	SchemaID = "https://kserve.github.io/schemas/router/graph." + CurrentVersion + ".schema.json"
+
+	graphSpecDefinition = "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceGraphSpec"
+	kservePackagePrefix = "github.com/kserve/kserve/"
+)
+
+var definitionRefPattern = regexp.MustCompile(`"\$ref":"#/definitions/([^"]+)"`)
+
+// Schema returns the JSON schema of the graph document of the current version. The schema is generated from the
+// OpenAPI definitions of the InferenceGraph API, the Kubernetes types only configuring the router deployment are
+// accepted as is.
+func Schema() ([]byte, error) {
+	names := map[string]string{}
+	openAPIDefs := v1beta1.GetOpenAPIDefinitions(func(name string) spec.Ref {
+		names[definitionName(name)] = name
+		return spec.MustCreateRef("#/definitions/" + common.EscapeJsonPointer(definitionName(name)))
+	})
+
+	root := openAPIDefs[graphSpecDefinition].Schema
+	root.SchemaProps.Schema = "http://json-schema.org/draft-04/schema#"
+	root.ID = SchemaID
+	root.Title = "InferenceGraph router graph document"
+	root.Description = "Graph routed by the InferenceGraph router, the fields only configuring the router deployment " +
+		"are ignored by the router"
+	root.Properties["version"] = *spec.StringProperty().
+		WithDescription("Version of the document, the documents without version are read as " + Version1).
+		WithEnum(toInterfaces(SupportedVersions)...)
+	root.AdditionalProperties = &spec.SchemaOrBool{Allows: false}
+
+	// only the definitions reachable from the graph spec are published
+	definitions := spec.Definitions{}
+	pending := []spec.Schema{root}
+	for len(pending) > 0 {
+		data, err := json.Marshal(pending[0])
+		if err != nil {
+			return nil, err
+		}
+		pending = pending[1:]
+		for _, match := range definitionRefPattern.FindAllStringSubmatch(string(data), -1) {
+			name := match[1]
+			if _, ok := definitions[name]; ok {
+				continue
+			}
+			definition, ok := openAPIDefs[names[name]]
+			if !ok {
+				definitions[name] = *new(spec.Schema).WithDescription(names[name] + " of the Kubernetes API")
+				continue
+			}
+			schema := definition.Schema
+			if strings.HasPrefix(names[name], kservePackagePrefix) && len(schema.Properties) > 0 &&
+				schema.AdditionalProperties == nil {
+				schema.AdditionalProperties = &spec.SchemaOrBool{Allows: false}
+			}
+			definitions[name] = schema
+			pending = append(pending, schema)
+		}
+	}
+	root.Definitions = definitions
+	return json.MarshalIndent(root, "", "  ")
+}
+
+// definitionName returns the name of the definition of a Go type, e.g. v1alpha1.InferenceRouter or
+// core.v1.Affinity
+func definitionName(name string) string {
+	name = strings.TrimPrefix(name, kservePackagePrefix+"pkg/apis/serving/")
+	name = strings.TrimPrefix(name, "k8s.io/api/")
+	name = strings.TrimPrefix(name, "k8s.io/apimachinery/pkg/apis/")
+	name = strings.TrimPrefix(name, "k8s.io/apimachinery/pkg/api/")
+	return strings.ReplaceAll(name, "/", ".")
+}
+
+func toInterfaces(values []string) []interface{} {
+	result := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		result = append(result, value)
+	}
+	return result
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package routerplugin is the extension point of the InferenceGraph router. A plugin processes the request of a node
+before it is routed to the steps of the node and the response of the node, e.g. to check a custom auth scheme or to
+translate the payload between the formats of the caller and the models.
+
+A plugin is either compiled into a custom router image and registered with Register, or built as a Go plugin
+exporting a NewPlugin function of the Factory signature:
+
+	package main
+
+	func NewPlugin(config map[string]string) (routerplugin.Plugin, error) {
+		return &myPlugin{}, nil
+	}
+
+	go build -buildmode=plugin -o my-plugin.so
+
+A Go plugin can only be loaded by a router built with the same Go version and the same versions of the packages
+they share, it is built against the kserve module of the router release.
+*/
+package routerplugin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	goplugin "plugin"
+	"sync"
+)
+
+// FactorySymbol is the name of the function a Go plugin exports to create the plugin
+const FactorySymbol = "NewPlugin"
+
+// Plugin processes the requests and the responses of the InferenceGraph nodes it is configured on
+type Plugin interface {
+	// ProcessRequest is called before the request is routed to the steps of the node, the changes to the request
+	// are seen by the steps. Returning an error stops the request.
+	ProcessRequest(ctx context.Context, req *Request) error
+	// ProcessResponse is called with the response of the node, the changes to the response are returned to the
+	// caller of the node. Returning an error fails the request.
+	ProcessResponse(ctx context.Context, resp *Response) error
+}
+
+// Factory creates a plugin with the configuration set on the node
+type Factory func(config map[string]string) (Plugin, error)
+
+// Request is the request of a node
+type Request struct {
+	// Node is the name of the node
+	Node string
+	// Headers are the headers propagated to the steps of the node
+	Headers http.Header
+	// Body is the payload of the request
+	Body []byte
+}
+
+// Response is the response of a node
+type Response struct {
+	// Node is the name of the node
+	Node string
+	// StatusCode is the status code of the response
+	StatusCode int
+	// Body is the payload of the response
+	Body []byte
+}
+
+// Error is returned by a plugin to answer the request with a status code, e.g. 401 when the request is not
+// authenticated. Other errors are answered with 500.
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// NewError returns an error answering the request with the status code
+func NewError(statusCode int, format string, args ...interface{}) *Error {
+	return &Error{StatusCode: statusCode, Message: fmt.Sprintf(format, args...)}
+}
+
+var (
+	mutex     sync.RWMutex
+	factories = map[string]Factory{}
+)
+
+// Register makes a plugin compiled into the router available under the name, it takes precedence over a Go plugin
+// of the same name
+func Register(name string, factory Factory) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	factories[name] = factory
+}
+
+// Load returns the factory of the plugin registered under the name, or else of the Go plugin <dir>/<name>.so
+func Load(dir string, name string) (Factory, error) {
+	mutex.RLock()
+	factory, ok := factories[name]
+	mutex.RUnlock()
+	if ok {
+		return factory, nil
+	}
+
+	path := filepath.Join(dir, name+".so")
+	p, err := goplugin.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open plugin %q: %w", path, err)
+	}
+	symbol, err := p.Lookup(FactorySymbol)
+	if err != nil {
+		return nil, fmt.Errorf("plugin %q does not export %s: %w", path, FactorySymbol, err)
+	}
+	newPlugin, ok := symbol.(func(map[string]string) (Plugin, error))
+	if !ok {
+		return nil, fmt.Errorf("plugin %q exports %s with type %T instead of the routerplugin.Factory signature",
+			path, FactorySymbol, symbol)
+	}
+	return newPlugin, nil
+}
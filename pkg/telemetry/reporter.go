@@ -0,0 +1,178 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+const (
+	// UnknownDeploymentMode counts the InferenceServices not reconciled yet
+	UnknownDeploymentMode = "Unknown"
+	// UnknownRuntime counts the predictors of the deprecated framework specs not defaulted to a model spec yet
+	UnknownRuntime = "unknown"
+	// CustomRuntime counts the InferenceServices with custom predictor containers
+	CustomRuntime = "custom"
+)
+
+// Report is the anonymous usage report posted to the telemetry endpoint, it only holds aggregate counts
+type Report struct {
+	// InstallationID is a hash of the UID of the KServe namespace, it tells the reports of an installation apart
+	// without identifying the cluster
+	InstallationID string `json:"installationId"`
+	Timestamp      string `json:"timestamp"`
+	// InferenceServices counts the InferenceServices per deployment mode
+	InferenceServices map[string]int `json:"inferenceServices"`
+	// Runtimes counts the InferenceServices per serving runtime, or per model format when the runtime is selected
+	// automatically
+	Runtimes map[string]int `json:"runtimes"`
+	// InferenceGraphs is the number of InferenceGraphs
+	InferenceGraphs int `json:"inferenceGraphs"`
+	// InferenceGraphNodes counts the nodes of the InferenceGraphs per router type
+	InferenceGraphNodes map[string]int `json:"inferenceGraphNodes"`
+}
+
+// Reporter periodically posts the usage report to the endpoint of the telemetry config. It only runs on the leader
+// so that the installation is reported once per interval.
+type Reporter struct {
+	Client     client.Client
+	Clientset  kubernetes.Interface
+	Config     *v1beta1.TelemetryConfig
+	HTTPClient *http.Client
+	Log        logr.Logger
+}
+
+// Start reports the usage every interval until the context is done, the failed reports are logged and retried at the
+// next interval
+func (r *Reporter) Start(ctx context.Context) error {
+	ticker := time.NewTicker(time.Duration(r.Config.IntervalSeconds) * time.Second)
+	defer ticker.Stop()
+	for {
+		if err := r.report(ctx); err != nil {
+			r.Log.Error(err, "unable to report the usage telemetry", "endpoint", r.Config.Endpoint)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Reporter) report(ctx context.Context) error {
+	report, err := r.Collect(ctx)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.Config.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := r.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("telemetry endpoint returned status %d", resp.StatusCode)
+	}
+	r.Log.V(1).Info("Reported the usage telemetry", "report", report)
+	return nil
+}
+
+// Collect counts the InferenceServices and InferenceGraphs of the cluster
+func (r *Reporter) Collect(ctx context.Context) (*Report, error) {
+	namespace, err := r.Clientset.CoreV1().Namespaces().Get(ctx, constants.KServeNamespace, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	installationID := sha256.Sum256([]byte(namespace.UID))
+	report := &Report{
+		InstallationID:      hex.EncodeToString(installationID[:16]),
+		Timestamp:           time.Now().UTC().Format(time.RFC3339),
+		InferenceServices:   map[string]int{},
+		Runtimes:            map[string]int{},
+		InferenceGraphNodes: map[string]int{},
+	}
+
+	isvcs := &v1beta1.InferenceServiceList{}
+	if err := r.Client.List(ctx, isvcs); err != nil {
+		return nil, err
+	}
+	for _, isvc := range isvcs.Items {
+		report.InferenceServices[deploymentMode(&isvc)]++
+		report.Runtimes[runtimeName(&isvc.Spec.Predictor)]++
+	}
+
+	graphs := &v1alpha1.InferenceGraphList{}
+	if err := r.Client.List(ctx, graphs); err != nil {
+		return nil, err
+	}
+	report.InferenceGraphs = len(graphs.Items)
+	for _, graph := range graphs.Items {
+		for _, node := range graph.Spec.Nodes {
+			report.InferenceGraphNodes[string(node.RouterType)]++
+		}
+	}
+	return report, nil
+}
+
+// deploymentMode returns the deployment mode resolved at the last reconciliation, or else the one of the annotation
+func deploymentMode(isvc *v1beta1.InferenceService) string {
+	if isvc.Status.EffectiveConfig != nil && isvc.Status.EffectiveConfig.DeploymentMode != "" {
+		return isvc.Status.EffectiveConfig.DeploymentMode
+	}
+	if mode, ok := isvc.Annotations[constants.DeploymentMode]; ok {
+		return mode
+	}
+	return UnknownDeploymentMode
+}
+
+// runtimeName returns the runtime of the predictor, the model format when the runtime is selected automatically
+func runtimeName(predictor *v1beta1.PredictorSpec) string {
+	if predictor.Model != nil {
+		if predictor.Model.Runtime != nil && *predictor.Model.Runtime != "" {
+			return *predictor.Model.Runtime
+		}
+		return predictor.Model.ModelFormat.Name
+	}
+	if len(predictor.Containers) > 0 {
+		return CustomRuntime
+	}
+	return UnknownRuntime
+}
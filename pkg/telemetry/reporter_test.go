@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"knative.dev/pkg/ptr"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func newReporter(endpoint string) *Reporter {
+	scheme := runtime.NewScheme()
+	_ = v1beta1.AddToScheme(scheme)
+	_ = v1alpha1.AddToScheme(scheme)
+	isvc := func(name string, predictor v1beta1.PredictorSpec, annotations map[string]string,
+		effectiveConfig *v1beta1.EffectiveConfig) *v1beta1.InferenceService {
+		return &v1beta1.InferenceService{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Annotations: annotations},
+			Spec:       v1beta1.InferenceServiceSpec{Predictor: predictor},
+			Status:     v1beta1.InferenceServiceStatus{EffectiveConfig: effectiveConfig},
+		}
+	}
+	sklearn := v1beta1.PredictorSpec{Model: &v1beta1.ModelSpec{ModelFormat: v1beta1.ModelFormat{Name: "sklearn"}}}
+	client := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(
+		isvc("sklearn", sklearn, nil, &v1beta1.EffectiveConfig{DeploymentMode: string(constants.Serverless)}),
+		isvc("triton", v1beta1.PredictorSpec{Model: &v1beta1.ModelSpec{ModelFormat: v1beta1.ModelFormat{Name: "onnx"},
+			Runtime: ptr.String("kserve-tritonserver")}}, nil,
+			&v1beta1.EffectiveConfig{DeploymentMode: string(constants.RawDeployment)}),
+		isvc("modelmesh", sklearn, map[string]string{constants.DeploymentMode: string(constants.ModelMeshDeployment)}, nil),
+		isvc("custom", v1beta1.PredictorSpec{PodSpec: v1beta1.PodSpec{Containers: []v1.Container{{Image: "custom"}}}}, nil, nil),
+		&v1alpha1.InferenceGraph{
+			ObjectMeta: metav1.ObjectMeta{Name: "graph", Namespace: "default"},
+			Spec: v1alpha1.InferenceGraphSpec{Nodes: map[string]v1alpha1.InferenceRouter{
+				v1alpha1.GraphRootNodeName: {RouterType: v1alpha1.Sequence},
+				"ensemble":                 {RouterType: v1alpha1.Ensemble},
+				"switch":                   {RouterType: v1alpha1.Switch},
+			}},
+		},
+	).Build()
+	return &Reporter{
+		Client: client,
+		Clientset: fake.NewSimpleClientset(&v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: constants.KServeNamespace, UID: types.UID("kserve-uid")},
+		}),
+		Config:     &v1beta1.TelemetryConfig{Enabled: true, Endpoint: endpoint, IntervalSeconds: 3600},
+		HTTPClient: http.DefaultClient,
+		Log:        logr.Discard(),
+	}
+}
+
+func TestCollect(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	report, err := newReporter("").Collect(context.Background())
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(report.InstallationID).Should(gomega.HaveLen(32))
+	g.Expect(report.InstallationID).ShouldNot(gomega.ContainSubstring("kserve-uid"))
+	g.Expect(report.InferenceServices).Should(gomega.Equal(map[string]int{
+		"Serverless": 1, "RawDeployment": 1, "ModelMesh": 1, UnknownDeploymentMode: 1,
+	}))
+	g.Expect(report.Runtimes).Should(gomega.Equal(map[string]int{
+		"sklearn": 2, "kserve-tritonserver": 1, CustomRuntime: 1,
+	}))
+	g.Expect(report.InferenceGraphs).Should(gomega.Equal(1))
+	g.Expect(report.InferenceGraphNodes).Should(gomega.Equal(map[string]int{
+		"Sequence": 1, "Ensemble": 1, "Switch": 1,
+	}))
+}
+
+func TestReporterStart(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	reports := make(chan Report, 1)
+	endpoint := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var report Report
+		if err := json.NewDecoder(req.Body).Decode(&report); err != nil || req.Method != http.MethodPost {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		reports <- report
+	}))
+	defer endpoint.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = newReporter(endpoint.URL).Start(ctx)
+	}()
+	// the usage is reported right away and then every interval
+	select {
+	case report := <-reports:
+		g.Expect(report.InferenceGraphs).Should(gomega.Equal(1))
+		g.Expect(report.InferenceServices).Should(gomega.HaveKeyWithValue("Serverless", 1))
+	case <-time.After(5 * time.Second):
+		t.Fatal("the usage was not reported")
+	}
+}
+
+func TestReportError(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	endpoint := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer endpoint.Close()
+	err := newReporter(endpoint.URL).report(context.Background())
+	g.Expect(err).Should(gomega.MatchError(gomega.ContainSubstring("status 503")))
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// InferenceServiceBuilder builds the InferenceServices of the tests with the builders of the v1beta1 API, it starts
+// from a predictor serving a model so that the tests only set what they exercise
+type InferenceServiceBuilder struct {
+	isvc      *v1beta1.InferenceServiceBuilder
+	predictor *v1beta1.PredictorBuilder
+}
+
+// NewInferenceService returns a builder of an InferenceService serving the model of the format from the storage URI
+func NewInferenceService(name, namespace, modelFormat, storageURI string) *InferenceServiceBuilder {
+	return &InferenceServiceBuilder{
+		isvc:      v1beta1.NewInferenceServiceBuilder(name, namespace),
+		predictor: v1beta1.NewPredictorBuilder().Model(modelFormat, storageURI),
+	}
+}
+
+// WithAnnotation sets an annotation of the InferenceService
+func (b *InferenceServiceBuilder) WithAnnotation(key, value string) *InferenceServiceBuilder {
+	b.isvc.Annotation(key, value)
+	return b
+}
+
+// WithLabel sets a label of the InferenceService
+func (b *InferenceServiceBuilder) WithLabel(key, value string) *InferenceServiceBuilder {
+	b.isvc.Label(key, value)
+	return b
+}
+
+// WithDeploymentMode sets the deployment mode annotation
+func (b *InferenceServiceBuilder) WithDeploymentMode(mode constants.DeploymentModeType) *InferenceServiceBuilder {
+	b.isvc.DeploymentMode(mode)
+	return b
+}
+
+// WithRuntime selects the serving runtime of the model
+func (b *InferenceServiceBuilder) WithRuntime(runtime string) *InferenceServiceBuilder {
+	b.predictor.Runtime(runtime)
+	return b
+}
+
+// WithReplicas sets the min and max replicas of the predictor
+func (b *InferenceServiceBuilder) WithReplicas(minReplicas, maxReplicas int) *InferenceServiceBuilder {
+	b.predictor.Replicas(minReplicas, maxReplicas)
+	return b
+}
+
+// WithResources sets the resources of the model container
+func (b *InferenceServiceBuilder) WithResources(resources v1.ResourceRequirements) *InferenceServiceBuilder {
+	b.predictor.Resources(resources)
+	return b
+}
+
+// WithTransformer adds a transformer with the container
+func (b *InferenceServiceBuilder) WithTransformer(container v1.Container) *InferenceServiceBuilder {
+	b.isvc.Transformer(container)
+	return b
+}
+
+// Build returns the InferenceService
+func (b *InferenceServiceBuilder) Build() *v1beta1.InferenceService {
+	return b.isvc.Predictor(b.predictor.Build()).Build()
+}
+
+// InferenceGraphBuilder builds the InferenceGraphs of the tests with the builder of the v1alpha1 API
+type InferenceGraphBuilder struct {
+	graph *v1alpha1.InferenceGraphBuilder
+}
+
+// NewInferenceGraph returns a builder of an InferenceGraph without nodes
+func NewInferenceGraph(name, namespace string) *InferenceGraphBuilder {
+	return &InferenceGraphBuilder{graph: v1alpha1.NewInferenceGraphBuilder(name, namespace)}
+}
+
+// WithAnnotation sets an annotation of the InferenceGraph
+func (b *InferenceGraphBuilder) WithAnnotation(key, value string) *InferenceGraphBuilder {
+	b.graph.Annotation(key, value)
+	return b
+}
+
+// WithDeploymentMode sets the deployment mode annotation
+func (b *InferenceGraphBuilder) WithDeploymentMode(mode constants.DeploymentModeType) *InferenceGraphBuilder {
+	return b.WithAnnotation(constants.DeploymentMode, string(mode))
+}
+
+// WithNode adds a node routing to the steps, the root node is named v1alpha1.GraphRootNodeName
+func (b *InferenceGraphBuilder) WithNode(name string, routerType v1alpha1.InferenceRouterType,
+	steps ...v1alpha1.InferenceStep) *InferenceGraphBuilder {
+	b.graph.Node(name, routerType, steps...)
+	return b
+}
+
+// Build returns the InferenceGraph
+func (b *InferenceGraphBuilder) Build() *v1alpha1.InferenceGraph {
+	return b.graph.Build()
+}
+
+// ServiceStep returns a step calling the InferenceService
+func ServiceStep(name, serviceName string) v1alpha1.InferenceStep {
+	return v1alpha1.NewStepBuilder(name).Service(serviceName).Build()
+}
+
+// NodeStep returns a step calling another node of the graph
+func NodeStep(name, nodeName string) v1alpha1.InferenceStep {
+	return v1alpha1.NewStepBuilder(name).Node(nodeName).Build()
+}
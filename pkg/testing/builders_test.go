@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"os"
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestCRDDirectoryPaths(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	for _, path := range CRDDirectoryPaths() {
+		_, err := os.Stat(path)
+		g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	}
+}
+
+func TestInferenceServiceBuilder(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := NewInferenceService("sklearn", "default", "sklearn", "gs://kfserving-examples/models/sklearn/1.0/model").
+		WithDeploymentMode(constants.RawDeployment).
+		WithRuntime("kserve-sklearnserver").
+		WithReplicas(1, 3).
+		Build()
+	g.Expect(isvc.Annotations).Should(gomega.HaveKeyWithValue(constants.DeploymentMode, "RawDeployment"))
+	g.Expect(*isvc.Spec.Predictor.Model.Runtime).Should(gomega.Equal("kserve-sklearnserver"))
+	g.Expect(*isvc.Spec.Predictor.MinReplicas).Should(gomega.Equal(1))
+	_, err := isvc.ValidateCreate()
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+}
+
+func TestInferenceGraphBuilder(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	graph := NewInferenceGraph("graph", "default").
+		WithNode(v1alpha1.GraphRootNodeName, v1alpha1.Sequence,
+			ServiceStep("preprocess", "transformer"), NodeStep("ensemble", "ensemble")).
+		WithNode("ensemble", v1alpha1.Ensemble, ServiceStep("a", "model-a"), ServiceStep("b", "model-b")).
+		Build()
+	g.Expect(graph.Spec.Nodes).Should(gomega.HaveLen(2))
+	_, err := graph.ValidateCreate()
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+}
+
+func TestNewConfigMap(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	configs := DefaultConfigs()
+	configs["router"] = `{"image": "kserve/router:test"}`
+	configMap := NewConfigMap(configs)
+	g.Expect(configMap.Name).Should(gomega.Equal(constants.InferenceServiceConfigMapName))
+	g.Expect(configMap.Namespace).Should(gomega.Equal(constants.KServeNamespace))
+	g.Expect(configMap.Data).Should(gomega.HaveKeyWithValue("router", `{"image": "kserve/router:test"}`))
+	// each call returns a new map so that the overrides of a test do not leak
+	g.Expect(DefaultConfigs()["router"]).ShouldNot(gomega.ContainSubstring("kserve/router:test"))
+}
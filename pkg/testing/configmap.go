@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// DefaultConfigs returns the inferenceservice-config data the controllers are tested with. The tests override the
+// keys they exercise on the returned map.
+func DefaultConfigs() map[string]string {
+	return map[string]string{
+		"explainers": `{
+			"art": {
+				"image": "kserve/art-explainer",
+				"defaultImageVersion": "latest"
+			}
+		}`,
+		"ingress": `{
+			"ingressGateway": "knative-serving/knative-ingress-gateway",
+			"ingressService": "test-destination",
+			"localGateway": "knative-serving/knative-local-gateway",
+			"localGatewayService": "knative-local-gateway.istio-system.svc.cluster.local"
+		}`,
+		"storageInitializer": `{
+			"image" : "kserve/storage-initializer:latest",
+			"memoryRequest": "100Mi",
+			"memoryLimit": "1Gi",
+			"cpuRequest": "100m",
+			"cpuLimit": "1",
+			"caBundleConfigMapName": "",
+			"caBundleVolumeMountPath": "/etc/ssl/custom-certs",
+			"enableDirectPvcVolumeMount": false
+		}`,
+		"router": `{
+			"image": "kserve/router:latest",
+			"memoryRequest": "100Mi",
+			"memoryLimit": "500Mi",
+			"cpuRequest": "100m",
+			"cpuLimit": "100m"
+		}`,
+	}
+}
+
+// NewConfigMap returns the inferenceservice-config ConfigMap of the KServe namespace with the configs
+func NewConfigMap(configs map[string]string) *v1.ConfigMap {
+	return &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      constants.InferenceServiceConfigMapName,
+			Namespace: constants.KServeNamespace,
+		},
+		Data: configs,
+	}
+}
@@ -19,6 +19,7 @@ package testing
 import (
 	"context"
 	"path/filepath"
+	goruntime "runtime"
 	"sync"
 
 	"google.golang.org/protobuf/proto"
@@ -35,17 +36,26 @@ import (
 
 var log = logf.Log.WithName("TestingEnvSetup")
 
+// CRDDirectoryPaths returns the absolute paths of the KServe, Knative and Istio CRDs the controllers need. They are
+// resolved from the source of this package so that the environment can be set up from any package, including the
+// ones of the operators embedding the KServe APIs.
+func CRDDirectoryPaths() []string {
+	_, file, _, _ := goruntime.Caller(0)
+	return []string{filepath.Join(filepath.Dir(file), "..", "..", "test", "crds")}
+}
+
+// SetupEnvTest returns a test environment with the KServe CRDs
 func SetupEnvTest() *envtest.Environment {
+	return NewEnvTest()
+}
+
+// NewEnvTest returns a test environment with the KServe CRDs and the extra CRDs of the caller, and registers the
+// networking, Knative and Istio schemes
+func NewEnvTest(extraCRDPaths ...string) *envtest.Environment {
 	t := &envtest.Environment{
-		// The relative paths must be provided for each level of test nesting
-		// This code should be illegal
-		CRDDirectoryPaths: []string{
-			filepath.Join("..", "..", "..", "..", "..", "..", "config", "crd", "serving.kserve.io_trainedmodels.yaml"),
-			filepath.Join("..", "..", "..", "..", "..", "..", "test", "crds"),
-			filepath.Join("..", "..", "..", "..", "config", "crd", "serving.kserve.io_trainedmodels.yaml"),
-			filepath.Join("..", "..", "..", "..", "test", "crds"),
-		},
-		UseExistingCluster: proto.Bool(false),
+		CRDDirectoryPaths:     append(CRDDirectoryPaths(), extraCRDPaths...),
+		ErrorIfCRDPathMissing: true,
+		UseExistingCluster:    proto.Bool(false),
 	}
 
 	if err := netv1.SchemeBuilder.AddToScheme(scheme.Scheme); err != nil {
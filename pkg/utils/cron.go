@@ -0,0 +1,180 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a standard cron expression of five fields: minute, hour, day of month, month and day of week
+type CronSchedule struct {
+	minute, hour, dayOfMonth, month, dayOfWeek uint64
+	// a day matches when both day fields match if one of them is *, or else when either of them matches
+	anyDay bool
+}
+
+// ActiveWindow is a window opened at the times of a cron schedule and closed after a duration
+type ActiveWindow struct {
+	Schedule string
+	Duration time.Duration
+}
+
+var cronFieldRanges = [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+
+// ParseCronSchedule parses a cron expression, the fields support *, lists, ranges and steps
+func ParseCronSchedule(expr string) (*CronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron schedule %q: expected 5 fields, minute hour day-of-month month day-of-week", expr)
+	}
+	var bits [5]uint64
+	for i, field := range fields {
+		fieldBits, err := parseCronField(field, cronFieldRanges[i][0], cronFieldRanges[i][1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron schedule %q: %w", expr, err)
+		}
+		bits[i] = fieldBits
+	}
+	// both 0 and 7 are Sunday
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1
+	}
+	return &CronSchedule{
+		minute:     bits[0],
+		hour:       bits[1],
+		dayOfMonth: bits[2],
+		month:      bits[3],
+		dayOfWeek:  bits[4],
+		anyDay:     fields[2] == "*" || fields[4] == "*",
+	}, nil
+}
+
+func parseCronField(field string, min, max int) (uint64, error) {
+	bits := uint64(0)
+	for _, part := range strings.Split(field, ",") {
+		valueRange, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+			valueRange = part[:i]
+		}
+		low, high := min, max
+		if valueRange != "*" {
+			bounds := strings.SplitN(valueRange, "-", 2)
+			var err error
+			if low, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, fmt.Errorf("invalid value in %q", part)
+			}
+			high = low
+			if len(bounds) == 2 {
+				if high, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, fmt.Errorf("invalid value in %q", part)
+				}
+			} else if step > 1 {
+				// a step from a single value runs to the end of the range
+				high = max
+			}
+			if low < min || high > max || low > high {
+				return 0, fmt.Errorf("%q is out of the range %d-%d", part, min, max)
+			}
+		}
+		for value := low; value <= high; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+	return bits, nil
+}
+
+// Next returns the first time after t matching the schedule in the location of t, or the zero time when no time
+// matches in the next five years
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	location := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, location)
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, location)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, location)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (s *CronSchedule) matchesDay(t time.Time) bool {
+	dayOfMonth := s.dayOfMonth&(1<<uint(t.Day())) != 0
+	dayOfWeek := s.dayOfWeek&(1<<uint(t.Weekday())) != 0
+	if s.anyDay {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
+
+// GetActiveWindows returns whether one of the windows is open at now, the schedules being evaluated in the time zone
+// or else in UTC, and the time of the next opening or closing of a window. The next time is zero when no window
+// opens or closes anymore.
+func GetActiveWindows(windows []ActiveWindow, timeZone string, now time.Time) (bool, time.Time, error) {
+	location := time.UTC
+	if timeZone != "" {
+		var err error
+		if location, err = time.LoadLocation(timeZone); err != nil {
+			return false, time.Time{}, fmt.Errorf("invalid time zone %q: %w", timeZone, err)
+		}
+	}
+	now = now.In(location)
+	active := false
+	var next time.Time
+	for _, window := range windows {
+		schedule, err := ParseCronSchedule(window.Schedule)
+		if err != nil {
+			return false, time.Time{}, err
+		}
+		if window.Duration <= 0 {
+			return false, time.Time{}, fmt.Errorf("the duration of the window %q must be positive", window.Schedule)
+		}
+		// the last opening of the window that is not closed yet, then the next opening
+		var opened time.Time
+		opening := schedule.Next(now.Add(-window.Duration))
+		for !opening.IsZero() && !opening.After(now) {
+			opened = opening
+			opening = schedule.Next(opening)
+		}
+		transition := opening
+		if !opened.IsZero() {
+			active = true
+			if closing := opened.Add(window.Duration); transition.IsZero() || closing.Before(transition) {
+				transition = closing
+			}
+		}
+		if !transition.IsZero() && (next.IsZero() || transition.Before(next)) {
+			next = transition
+		}
+	}
+	return active, next, nil
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	// Friday
+	from := time.Date(2024, 3, 1, 17, 30, 0, 0, time.UTC)
+	scenarios := map[string]struct {
+		expr     string
+		expected time.Time
+	}{
+		"EveryMinute": {
+			expr:     "* * * * *",
+			expected: time.Date(2024, 3, 1, 17, 31, 0, 0, time.UTC),
+		},
+		"WeekdayMornings": {
+			expr:     "0 8 * * 1-5",
+			expected: time.Date(2024, 3, 4, 8, 0, 0, 0, time.UTC),
+		},
+		"Steps": {
+			expr:     "*/20 18/2 * * *",
+			expected: time.Date(2024, 3, 1, 18, 0, 0, 0, time.UTC),
+		},
+		"Lists": {
+			expr:     "15,45 17 * * *",
+			expected: time.Date(2024, 3, 1, 17, 45, 0, 0, time.UTC),
+		},
+		"SundayAsSeven": {
+			expr:     "0 0 * * 7",
+			expected: time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC),
+		},
+		"DayOfMonthOrDayOfWeek": {
+			expr:     "0 0 15 * 6",
+			expected: time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC),
+		},
+		"LeapDay": {
+			expr:     "0 0 29 2 *",
+			expected: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		"Never": {
+			expr:     "0 0 30 2 *",
+			expected: time.Time{},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			schedule, err := ParseCronSchedule(scenario.expr)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(schedule.Next(from)).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
+
+func TestParseCronScheduleErrors(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	for _, expr := range []string{"* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8",
+		"5-1 * * * *", "*/0 * * * *", "a * * * *"} {
+		_, err := ParseCronSchedule(expr)
+		g.Expect(err).To(gomega.HaveOccurred(), expr)
+	}
+}
+
+func TestGetActiveWindows(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	businessHours := []ActiveWindow{{Schedule: "0 8 * * 1-5", Duration: 10 * time.Hour}}
+	scenarios := map[string]struct {
+		windows        []ActiveWindow
+		timeZone       string
+		now            time.Time
+		expectedActive bool
+		expectedNext   time.Time
+	}{
+		"Open": {
+			windows:        businessHours,
+			now:            time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC),
+			expectedActive: true,
+			expectedNext:   time.Date(2024, 3, 1, 18, 0, 0, 0, time.UTC),
+		},
+		"ClosedOverTheWeekend": {
+			windows:        businessHours,
+			now:            time.Date(2024, 3, 1, 18, 0, 0, 0, time.UTC),
+			expectedActive: false,
+			expectedNext:   time.Date(2024, 3, 4, 8, 0, 0, 0, time.UTC),
+		},
+		"TimeZone": {
+			windows:        businessHours,
+			timeZone:       "Europe/Paris",
+			now:            time.Date(2024, 3, 1, 7, 30, 0, 0, time.UTC),
+			expectedActive: true,
+			expectedNext:   time.Date(2024, 3, 1, 17, 0, 0, 0, time.UTC),
+		},
+		"OvernightWindow": {
+			windows:        []ActiveWindow{{Schedule: "0 22 * * *", Duration: 8 * time.Hour}},
+			now:            time.Date(2024, 3, 2, 5, 0, 0, 0, time.UTC),
+			expectedActive: true,
+			expectedNext:   time.Date(2024, 3, 2, 6, 0, 0, 0, time.UTC),
+		},
+		"EarliestTransition": {
+			windows: append([]ActiveWindow{{Schedule: "0 12 * * *", Duration: time.Hour}}, businessHours...),
+			now:     time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC),
+			// the noon window opens before the business hours close
+			expectedActive: true,
+			expectedNext:   time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			active, next, err := GetActiveWindows(scenario.windows, scenario.timeZone, scenario.now)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(active).To(gomega.Equal(scenario.expectedActive))
+			g.Expect(next.Equal(scenario.expectedNext)).To(gomega.BeTrue(), next.String())
+		})
+	}
+
+	_, _, err := GetActiveWindows(businessHours, "Mars/Olympus", time.Now())
+	g.Expect(err).To(gomega.HaveOccurred())
+	_, _, err = GetActiveWindows([]ActiveWindow{{Schedule: "0 8 * * *"}}, "", time.Now())
+	g.Expect(err).To(gomega.HaveOccurred())
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"crypto/tls"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// fipsMode is set by the controller manager from the security config every time it changes, the webhooks
+// do not have access to the configmap.
+var fipsMode atomic.Bool
+
+// FIPSCipherSuites is the list of FIPS approved TLS 1.2 cipher suites, TLS 1.3 suites are not configurable
+// and are all FIPS approved.
+var FIPSCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+}
+
+func SetFIPSMode(enabled bool) {
+	fipsMode.Store(enabled)
+}
+
+func IsFIPSMode() bool {
+	return fipsMode.Load()
+}
+
+// ApplyFIPSTLSConfig restricts a TLS configuration to the FIPS approved protocol versions, cipher suites and curves.
+func ApplyFIPSTLSConfig(config *tls.Config) {
+	config.MinVersion = tls.VersionTLS12
+	config.CipherSuites = FIPSCipherSuites
+	config.CurvePreferences = []tls.CurveID{tls.CurveP256, tls.CurveP384}
+}
+
+// ValidateFIPSCompatibility rejects the options which bypass the FIPS compliant TLS settings when FIPS mode is enabled:
+// passthrough routes, which are terminated by the model server, and user provided route destination CA secrets.
+func ValidateFIPSCompatibility(annotations map[string]string) error {
+	if !IsFIPSMode() {
+		return nil
+	}
+	if annotations[constants.OpenshiftRouteTerminationAnnotationKey] == string(constants.RouteTLSTerminationPassthrough) {
+		return fmt.Errorf("route termination %q is not supported in FIPS mode", constants.RouteTLSTerminationPassthrough)
+	}
+	if _, ok := annotations[constants.OpenshiftRouteDestinationCASecretAnnotationKey]; ok {
+		return fmt.Errorf("annotation %q is not supported in FIPS mode, the destination CA is managed by KServe",
+			constants.OpenshiftRouteDestinationCASecretAnnotationKey)
+	}
+	return nil
+}
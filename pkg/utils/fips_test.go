@@ -0,0 +1,80 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestValidateFIPSCompatibility(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	defer SetFIPSMode(false)
+
+	scenarios := map[string]struct {
+		fipsMode    bool
+		annotations map[string]string
+		matcher     gomega.OmegaMatcher
+	}{
+		"FIPSModeDisabled": {
+			fipsMode: false,
+			annotations: map[string]string{
+				constants.OpenshiftRouteTerminationAnnotationKey: "passthrough",
+			},
+			matcher: gomega.Succeed(),
+		},
+		"EdgeTermination": {
+			fipsMode: true,
+			annotations: map[string]string{
+				constants.OpenshiftRouteTerminationAnnotationKey: "edge",
+			},
+			matcher: gomega.Succeed(),
+		},
+		"PassthroughTermination": {
+			fipsMode: true,
+			annotations: map[string]string{
+				constants.OpenshiftRouteTerminationAnnotationKey: "passthrough",
+			},
+			matcher: gomega.HaveOccurred(),
+		},
+		"CustomDestinationCASecret": {
+			fipsMode: true,
+			annotations: map[string]string{
+				constants.OpenshiftRouteDestinationCASecretAnnotationKey: "my-ca",
+			},
+			matcher: gomega.HaveOccurred(),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			SetFIPSMode(scenario.fipsMode)
+			g.Expect(ValidateFIPSCompatibility(scenario.annotations)).Should(scenario.matcher)
+		})
+	}
+}
+
+func TestApplyFIPSTLSConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	config := &tls.Config{MinVersion: tls.VersionTLS10}
+	ApplyFIPSTLSConfig(config)
+	g.Expect(config.MinVersion).Should(gomega.Equal(uint16(tls.VersionTLS12)))
+	g.Expect(config.CipherSuites).Should(gomega.Equal(FIPSCipherSuites))
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// SecurityContextBounds are the bounds set by the administrator on the security context overrides of the router and
+// agent containers, a field cannot be overridden when it has no bounds.
+type SecurityContextBounds struct {
+	// RunAsUser are the ranges of UIDs the containers can run as
+	RunAsUser []IDRange `json:"runAsUser,omitempty"`
+	// Groups are the ranges of GIDs the containers can run as and the router pods can get as supplemental groups
+	Groups []IDRange `json:"groups,omitempty"`
+	// SELinuxTypes are the SELinux types the containers can run with
+	SELinuxTypes []string `json:"seLinuxTypes,omitempty"`
+	// SELinuxLevels are the SELinux levels the containers can run with
+	SELinuxLevels []string `json:"seLinuxLevels,omitempty"`
+	// Capabilities are the capabilities which can be added to the containers
+	Capabilities []corev1.Capability `json:"capabilities,omitempty"`
+}
+
+// IDRange is an inclusive range of UIDs or GIDs
+type IDRange struct {
+	Min int64 `json:"min"`
+	Max int64 `json:"max"`
+}
+
+// securityContextBounds is set by the controller manager from the security config every time it changes, the webhooks
+// do not have access to the configmap.
+var securityContextBounds atomic.Pointer[SecurityContextBounds]
+
+// SetSecurityContextBounds sets the bounds the security context overrides are validated against
+func SetSecurityContextBounds(bounds *SecurityContextBounds) {
+	securityContextBounds.Store(bounds)
+}
+
+func getSecurityContextBounds() *SecurityContextBounds {
+	if bounds := securityContextBounds.Load(); bounds != nil {
+		return bounds
+	}
+	return &SecurityContextBounds{}
+}
+
+// Validate checks that the ranges of the bounds are not empty and do not contain negative IDs
+func (b *SecurityContextBounds) Validate() error {
+	for _, ranges := range [][]IDRange{b.RunAsUser, b.Groups} {
+		for _, r := range ranges {
+			if r.Min < 0 || r.Max < r.Min {
+				return fmt.Errorf("invalid security context bounds - range %d-%d must not be negative or empty", r.Min, r.Max)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateSecurityContextOverride rejects the security context overrides outside the bounds set by the administrator.
+// The override is given as the fields of the container security context and the supplemental groups of the pod.
+func ValidateSecurityContextOverride(override *corev1.SecurityContext, supplementalGroups []int64) error {
+	bounds := getSecurityContextBounds()
+	if override != nil {
+		if override.RunAsUser != nil && !inRanges(*override.RunAsUser, bounds.RunAsUser) {
+			return fmt.Errorf("runAsUser %d is not allowed by the security context bounds", *override.RunAsUser)
+		}
+		if override.RunAsGroup != nil && !inRanges(*override.RunAsGroup, bounds.Groups) {
+			return fmt.Errorf("runAsGroup %d is not allowed by the security context bounds", *override.RunAsGroup)
+		}
+		if options := override.SELinuxOptions; options != nil {
+			if options.User != "" || options.Role != "" {
+				return fmt.Errorf("the SELinux user and role cannot be overridden")
+			}
+			if options.Type != "" && !Includes(bounds.SELinuxTypes, options.Type) {
+				return fmt.Errorf("SELinux type %q is not allowed by the security context bounds", options.Type)
+			}
+			if options.Level != "" && !Includes(bounds.SELinuxLevels, options.Level) {
+				return fmt.Errorf("SELinux level %q is not allowed by the security context bounds", options.Level)
+			}
+		}
+		if override.Capabilities != nil {
+			for _, capability := range override.Capabilities.Add {
+				if !includesCapability(bounds.Capabilities, capability) {
+					return fmt.Errorf("capability %q is not allowed by the security context bounds", capability)
+				}
+			}
+		}
+	}
+	for _, group := range supplementalGroups {
+		if !inRanges(group, bounds.Groups) {
+			return fmt.Errorf("supplemental group %d is not allowed by the security context bounds", group)
+		}
+	}
+	return nil
+}
+
+// ApplySecurityContextOverride returns a copy of the security context of a container with the overridden fields set,
+// the added capabilities are appended to the capabilities already added.
+func ApplySecurityContextOverride(securityContext *corev1.SecurityContext, override *corev1.SecurityContext) *corev1.SecurityContext {
+	if override == nil {
+		return securityContext
+	}
+	result := securityContext.DeepCopy()
+	if result == nil {
+		result = &corev1.SecurityContext{}
+	}
+	if override.RunAsUser != nil {
+		result.RunAsUser = override.RunAsUser
+	}
+	if override.RunAsGroup != nil {
+		result.RunAsGroup = override.RunAsGroup
+	}
+	if override.SELinuxOptions != nil {
+		result.SELinuxOptions = override.SELinuxOptions.DeepCopy()
+	}
+	if override.Capabilities != nil && len(override.Capabilities.Add) > 0 {
+		if result.Capabilities == nil {
+			result.Capabilities = &corev1.Capabilities{}
+		}
+		for _, capability := range override.Capabilities.Add {
+			if !includesCapability(result.Capabilities.Add, capability) {
+				result.Capabilities.Add = append(result.Capabilities.Add, capability)
+			}
+		}
+	}
+	return result
+}
+
+func inRanges(id int64, ranges []IDRange) bool {
+	for _, r := range ranges {
+		if id >= r.Min && id <= r.Max {
+			return true
+		}
+	}
+	return false
+}
+
+func includesCapability(capabilities []corev1.Capability, capability corev1.Capability) bool {
+	for _, c := range capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestValidateSecurityContextOverride(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	defer SetSecurityContextBounds(nil)
+	bounds := &SecurityContextBounds{
+		RunAsUser:     []IDRange{{Min: 1000, Max: 1999}},
+		Groups:        []IDRange{{Min: 1000, Max: 1999}, {Min: 5000, Max: 5000}},
+		SELinuxTypes:  []string{"container_logreader_t"},
+		SELinuxLevels: []string{"s0:c123,c456"},
+		Capabilities:  []corev1.Capability{"NET_BIND_SERVICE"},
+	}
+
+	scenarios := map[string]struct {
+		bounds             *SecurityContextBounds
+		override           *corev1.SecurityContext
+		supplementalGroups []int64
+		matcher            gomega.OmegaMatcher
+	}{
+		"NoOverride": {
+			override: nil,
+			matcher:  gomega.Succeed(),
+		},
+		"NoBounds": {
+			override: &corev1.SecurityContext{RunAsUser: proto.Int64(1000)},
+			matcher:  gomega.MatchError(gomega.ContainSubstring("runAsUser 1000")),
+		},
+		"WithinBounds": {
+			bounds: bounds,
+			override: &corev1.SecurityContext{
+				RunAsUser:      proto.Int64(1500),
+				RunAsGroup:     proto.Int64(1000),
+				SELinuxOptions: &corev1.SELinuxOptions{Type: "container_logreader_t", Level: "s0:c123,c456"},
+				Capabilities:   &corev1.Capabilities{Add: []corev1.Capability{"NET_BIND_SERVICE"}},
+			},
+			supplementalGroups: []int64{1999, 5000},
+			matcher:            gomega.Succeed(),
+		},
+		"RunAsUserOutOfBounds": {
+			bounds:   bounds,
+			override: &corev1.SecurityContext{RunAsUser: proto.Int64(0)},
+			matcher:  gomega.MatchError(gomega.ContainSubstring("runAsUser 0")),
+		},
+		"RunAsGroupOutOfBounds": {
+			bounds:   bounds,
+			override: &corev1.SecurityContext{RunAsGroup: proto.Int64(2000)},
+			matcher:  gomega.MatchError(gomega.ContainSubstring("runAsGroup 2000")),
+		},
+		"SupplementalGroupOutOfBounds": {
+			bounds:             bounds,
+			supplementalGroups: []int64{1000, 4999},
+			matcher:            gomega.MatchError(gomega.ContainSubstring("supplemental group 4999")),
+		},
+		"SELinuxUser": {
+			bounds:   bounds,
+			override: &corev1.SecurityContext{SELinuxOptions: &corev1.SELinuxOptions{User: "system_u"}},
+			matcher:  gomega.MatchError(gomega.ContainSubstring("user and role")),
+		},
+		"SELinuxTypeOutOfBounds": {
+			bounds:   bounds,
+			override: &corev1.SecurityContext{SELinuxOptions: &corev1.SELinuxOptions{Type: "spc_t"}},
+			matcher:  gomega.MatchError(gomega.ContainSubstring("spc_t")),
+		},
+		"CapabilityOutOfBounds": {
+			bounds:   bounds,
+			override: &corev1.SecurityContext{Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"SYS_ADMIN"}}},
+			matcher:  gomega.MatchError(gomega.ContainSubstring("SYS_ADMIN")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			SetSecurityContextBounds(scenario.bounds)
+			g.Expect(ValidateSecurityContextOverride(scenario.override, scenario.supplementalGroups)).Should(scenario.matcher)
+		})
+	}
+}
+
+func TestSecurityContextBoundsValidate(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	g.Expect((&SecurityContextBounds{RunAsUser: []IDRange{{Min: 1000, Max: 1000}}}).Validate()).Should(gomega.Succeed())
+	g.Expect((&SecurityContextBounds{RunAsUser: []IDRange{{Min: 2000, Max: 1000}}}).Validate()).ShouldNot(gomega.Succeed())
+	g.Expect((&SecurityContextBounds{Groups: []IDRange{{Min: -1, Max: 1000}}}).Validate()).ShouldNot(gomega.Succeed())
+}
+
+func TestApplySecurityContextOverride(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	securityContext := &corev1.SecurityContext{
+		RunAsNonRoot: proto.Bool(true),
+		RunAsUser:    proto.Int64(1000),
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+			Add:  []corev1.Capability{"NET_BIND_SERVICE"},
+		},
+	}
+	override := &corev1.SecurityContext{
+		RunAsUser:      proto.Int64(1500),
+		SELinuxOptions: &corev1.SELinuxOptions{Type: "container_logreader_t"},
+		Capabilities:   &corev1.Capabilities{Add: []corev1.Capability{"NET_BIND_SERVICE", "CHOWN"}},
+	}
+
+	g.Expect(ApplySecurityContextOverride(securityContext, override)).Should(gomega.Equal(&corev1.SecurityContext{
+		RunAsNonRoot:   proto.Bool(true),
+		RunAsUser:      proto.Int64(1500),
+		SELinuxOptions: &corev1.SELinuxOptions{Type: "container_logreader_t"},
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+			Add:  []corev1.Capability{"NET_BIND_SERVICE", "CHOWN"},
+		},
+	}))
+	// the security context of the container is not modified
+	g.Expect(*securityContext.RunAsUser).Should(gomega.Equal(int64(1000)))
+	g.Expect(ApplySecurityContextOverride(securityContext, nil)).Should(gomega.BeIdenticalTo(securityContext))
+	g.Expect(ApplySecurityContextOverride(nil, &corev1.SecurityContext{RunAsGroup: proto.Int64(1000)})).Should(
+		gomega.Equal(&corev1.SecurityContext{RunAsGroup: proto.Int64(1000)}))
+}
@@ -17,12 +17,21 @@ limitations under the License.
 package utils
 
 import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/kserve/kserve/pkg/constants"
 	v1 "k8s.io/api/core/v1"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/rest"
 )
@@ -33,7 +42,21 @@ import (
  * Please add functional style container operations sparingly and intentionally.
  */
 
-var gvResourcesCache map[string]*metav1.APIResourceList
+// DefaultAvailableResourcesTTL is the default duration the discovered API resources are cached for
+const DefaultAvailableResourcesTTL = 5 * time.Minute
+
+// discoveredResources is an entry of the cache of the discovered API resources
+type discoveredResources struct {
+	resources *metav1.APIResourceList
+	// discovered is when the resources were discovered, the entries set for the tests are zero and never expire
+	discovered time.Time
+}
+
+var (
+	gvResourcesCache     map[string]discoveredResources
+	gvResourcesCacheLock sync.RWMutex
+	gvResourcesTTL       = DefaultAvailableResourcesTTL
+)
 
 func Filter(origin map[string]string, predicate func(string) bool) map[string]string {
 	result := make(map[string]string)
@@ -88,6 +111,16 @@ func IsGPUEnabled(requirements v1.ResourceRequirements) bool {
 }
 
 // FirstNonNilError returns the first non nil interface in the slice
+// IsPaused returns true if the paused annotation freezes the reconciliation of the child resources
+func IsPaused(annotations map[string]string) bool {
+	return annotations[constants.PausedAnnotationKey] == "true"
+}
+
+// IsStopped returns true if the stop annotation removes the workloads of the InferenceService
+func IsStopped(annotations map[string]string) bool {
+	return annotations[constants.StopAnnotationKey] == "true"
+}
+
 func FirstNonNilError(objects []error) error {
 	for _, object := range objects {
 		if object != nil {
@@ -197,40 +230,228 @@ func IsCrdAvailable(config *rest.Config, groupVersion, kind string) (bool, error
 	return found, nil
 }
 
+// IsAPINotAvailable checks if an error is caused by an optional API which is not served by the cluster, or which was
+// not added to the scheme because it was not served when the controller started.
+func IsAPINotAvailable(err error) bool {
+	return meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err)
+}
+
 // GetAvailableResourcesForApi returns the list of discovered resources that belong
 // to the API specified in groupVersion. The first query to a specifig groupVersion will
 // query the cluster API server to discover the available resources and the discovered
 // resources will be cached and returned to subsequent invocations to prevent additional
-// queries to the API server.
+// queries to the API server. The cached resources are discovered again once they are older
+// than the TTL, so that the APIs installed after the controller started are picked up.
 func GetAvailableResourcesForApi(config *rest.Config, groupVersion string) (*metav1.APIResourceList, error) {
-	var gvResources *metav1.APIResourceList
-	var ok bool
-
-	if gvResources, ok = gvResourcesCache[groupVersion]; !ok {
-		discoveryClient, newClientErr := discovery.NewDiscoveryClientForConfig(config)
-		if newClientErr != nil {
-			return nil, newClientErr
-		}
+	gvResourcesCacheLock.RLock()
+	entry, ok := gvResourcesCache[groupVersion]
+	ttl := gvResourcesTTL
+	gvResourcesCacheLock.RUnlock()
+	if ok && (entry.discovered.IsZero() || time.Since(entry.discovered) < ttl) {
+		return entry.resources, nil
+	}
+	return RefreshAvailableResourcesForApi(config, groupVersion)
+}
 
-		var getGvResourcesErr error
-		gvResources, getGvResourcesErr = discoveryClient.ServerResourcesForGroupVersion(groupVersion)
-		if getGvResourcesErr != nil && !apierr.IsNotFound(getGvResourcesErr) {
-			return nil, getGvResourcesErr
-		}
+// RefreshAvailableResourcesForApi queries the cluster API server for the resources that belong
+// to the API specified in groupVersion and caches them for the TTL.
+func RefreshAvailableResourcesForApi(config *rest.Config, groupVersion string) (*metav1.APIResourceList, error) {
+	discoveryClient, newClientErr := discovery.NewDiscoveryClientForConfig(config)
+	if newClientErr != nil {
+		return nil, newClientErr
+	}
 
-		SetAvailableResourcesForApi(groupVersion, gvResources)
+	gvResources, getGvResourcesErr := discoveryClient.ServerResourcesForGroupVersion(groupVersion)
+	if getGvResourcesErr != nil && !apierr.IsNotFound(getGvResourcesErr) {
+		return nil, getGvResourcesErr
 	}
 
+	gvResourcesCacheLock.Lock()
+	defer gvResourcesCacheLock.Unlock()
+	setAvailableResourcesForApi(groupVersion, discoveredResources{
+		resources:  gvResources,
+		discovered: time.Now(),
+	})
 	return gvResources, nil
 }
 
 // SetAvailableResourcesForApi stores the value fo resources argument in the global cache
 // of discovered API resources. This function should never be called directly. It is exported
-// for usage in tests.
+// for usage in tests, the stored resources never expire.
 func SetAvailableResourcesForApi(groupVersion string, resources *metav1.APIResourceList) {
+	gvResourcesCacheLock.Lock()
+	defer gvResourcesCacheLock.Unlock()
+	setAvailableResourcesForApi(groupVersion, discoveredResources{resources: resources})
+}
+
+func setAvailableResourcesForApi(groupVersion string, entry discoveredResources) {
 	if gvResourcesCache == nil {
-		gvResourcesCache = make(map[string]*metav1.APIResourceList)
+		gvResourcesCache = make(map[string]discoveredResources)
+	}
+
+	gvResourcesCache[groupVersion] = entry
+}
+
+// SetAvailableResourcesTTL sets the duration the discovered API resources are cached for
+func SetAvailableResourcesTTL(ttl time.Duration) {
+	gvResourcesCacheLock.Lock()
+	defer gvResourcesCacheLock.Unlock()
+	gvResourcesTTL = ttl
+}
+
+// GetRouteAnnotations returns the allow-listed OpenShift router annotations found in the given annotations.
+func GetRouteAnnotations(annotations map[string]string) map[string]string {
+	return Filter(annotations, func(key string) bool {
+		return Includes(constants.RouteAnnotationAllowList, key)
+	})
+}
+
+// ValidateRouteAnnotations checks that every OpenShift router annotation is part of the allow-list
+// and carries a value the router is able to understand.
+func ValidateRouteAnnotations(annotations map[string]string) error {
+	for key, value := range annotations {
+		if !strings.HasPrefix(key, constants.OpenshiftRouterAnnotationPrefix) {
+			continue
+		}
+		if !Includes(constants.RouteAnnotationAllowList, key) {
+			return fmt.Errorf("annotation %q is not supported, allowed router annotations are %v",
+				key, constants.RouteAnnotationAllowList)
+		}
+		var err error
+		switch key {
+		case constants.RouteTimeoutAnnotationKey, constants.RouteTimeoutTunnelAnnotationKey:
+			if !routeTimeoutRegexp.MatchString(value) {
+				err = fmt.Errorf("expected a positive integer with an optional unit (us, ms, s, m, h, d)")
+			}
+		case constants.RouteBalanceAnnotationKey:
+			if !Includes(constants.RouteBalanceAlgorithms, value) {
+				err = fmt.Errorf("expected one of %v", constants.RouteBalanceAlgorithms)
+			}
+		case constants.RouteIPWhitelistAnnotationKey:
+			err = validateIPWhitelist(value)
+		case constants.RouteDisableCookiesAnnotationKey, constants.RouteRateLimitConnectionsAnnotationKey:
+			if _, parseErr := strconv.ParseBool(value); parseErr != nil {
+				err = fmt.Errorf("expected a boolean")
+			}
+		default:
+			if n, parseErr := strconv.Atoi(value); parseErr != nil || n < 0 {
+				err = fmt.Errorf("expected a non negative integer")
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("invalid value %q for annotation %q: %w", value, key, err)
+		}
+	}
+	return nil
+}
+
+// ChangedAnnotations returns the annotations of updated which are missing from old or have a different value there.
+func ChangedAnnotations(old, updated map[string]string) map[string]string {
+	changed := make(map[string]string)
+	for key, value := range updated {
+		if oldValue, ok := old[key]; !ok || oldValue != value {
+			changed[key] = value
+		}
 	}
+	return changed
+}
 
-	gvResourcesCache[groupVersion] = resources
+// ValidateDeploymentAnnotations checks the annotations overriding the defaults of the raw deployments.
+func ValidateDeploymentAnnotations(annotations map[string]string) error {
+	if value, ok := annotations[constants.RevisionHistoryLimitAnnotationKey]; ok {
+		if n, err := strconv.ParseInt(value, 10, 32); err != nil || n < 0 {
+			return fmt.Errorf("invalid value %q for annotation %q: expected a non negative integer",
+				value, constants.RevisionHistoryLimitAnnotationKey)
+		}
+	}
+	if value, ok := annotations[constants.ProgressDeadlineSecondsAnnotationKey]; ok {
+		if n, err := strconv.ParseInt(value, 10, 32); err != nil || n <= 0 {
+			return fmt.Errorf("invalid value %q for annotation %q: expected a positive integer",
+				value, constants.ProgressDeadlineSecondsAnnotationKey)
+		}
+	}
+	return nil
+}
+
+// ValidateServiceAnnotations checks the annotations setting the type and the traffic routing of the Service created
+// for a raw deployment. The LoadBalancer options are only accepted together with the LoadBalancer type.
+func ValidateServiceAnnotations(annotations map[string]string) error {
+	serviceType := v1.ServiceTypeClusterIP
+	if value, ok := annotations[constants.ServiceTypeAnnotationKey]; ok {
+		serviceType = v1.ServiceType(value)
+		switch serviceType {
+		case v1.ServiceTypeClusterIP, v1.ServiceTypeNodePort, v1.ServiceTypeLoadBalancer:
+		default:
+			return fmt.Errorf("invalid value %q for annotation %q: expected one of %v", value,
+				constants.ServiceTypeAnnotationKey, []v1.ServiceType{v1.ServiceTypeClusterIP,
+					v1.ServiceTypeNodePort, v1.ServiceTypeLoadBalancer})
+		}
+	}
+	for _, key := range []string{constants.LoadBalancerClassAnnotationKey, constants.AllocateLoadBalancerNodePortsAnnotationKey} {
+		if _, ok := annotations[key]; ok && serviceType != v1.ServiceTypeLoadBalancer {
+			return fmt.Errorf("annotation %q requires annotation %q to be %q", key,
+				constants.ServiceTypeAnnotationKey, v1.ServiceTypeLoadBalancer)
+		}
+	}
+	if value, ok := annotations[constants.LoadBalancerClassAnnotationKey]; ok {
+		if errs := validation.IsQualifiedName(value); len(errs) > 0 {
+			return fmt.Errorf("invalid value %q for annotation %q: %s", value,
+				constants.LoadBalancerClassAnnotationKey, strings.Join(errs, ", "))
+		}
+	}
+	for _, key := range []string{constants.AllocateLoadBalancerNodePortsAnnotationKey, constants.TopologyAwareRoutingAnnotationKey} {
+		if value, ok := annotations[key]; ok {
+			if _, err := strconv.ParseBool(value); err != nil {
+				return fmt.Errorf("invalid value %q for annotation %q: expected a boolean", value, key)
+			}
+		}
+	}
+	if value, ok := annotations[constants.InternalTrafficPolicyAnnotationKey]; ok {
+		switch v1.ServiceInternalTrafficPolicy(value) {
+		case v1.ServiceInternalTrafficPolicyCluster, v1.ServiceInternalTrafficPolicyLocal:
+		default:
+			return fmt.Errorf("invalid value %q for annotation %q: expected one of %v", value,
+				constants.InternalTrafficPolicyAnnotationKey, []v1.ServiceInternalTrafficPolicy{
+					v1.ServiceInternalTrafficPolicyCluster, v1.ServiceInternalTrafficPolicyLocal})
+		}
+	}
+	alias, hasAlias := annotations[constants.ServiceAliasAnnotationKey]
+	if hasAlias {
+		if errs := validation.IsDNS1035Label(alias); len(errs) > 0 {
+			return fmt.Errorf("invalid value %q for annotation %q: %s", alias,
+				constants.ServiceAliasAnnotationKey, strings.Join(errs, ", "))
+		}
+	}
+	if value, ok := annotations[constants.ServiceAliasTypeAnnotationKey]; ok {
+		if !hasAlias {
+			return fmt.Errorf("annotation %q requires annotation %q", constants.ServiceAliasTypeAnnotationKey,
+				constants.ServiceAliasAnnotationKey)
+		}
+		switch v1.ServiceType(value) {
+		case v1.ServiceTypeExternalName, v1.ServiceTypeClusterIP:
+		default:
+			return fmt.Errorf("invalid value %q for annotation %q: expected one of %v", value,
+				constants.ServiceAliasTypeAnnotationKey, []v1.ServiceType{v1.ServiceTypeExternalName, v1.ServiceTypeClusterIP})
+		}
+	}
+	return nil
+}
+
+var routeTimeoutRegexp = regexp.MustCompile(`^[1-9][0-9]*(us|ms|s|m|h|d)?$`)
+
+// validateIPWhitelist validates a space separated list of IP addresses and CIDR ranges.
+func validateIPWhitelist(value string) error {
+	entries := strings.Fields(value)
+	if len(entries) == 0 {
+		return fmt.Errorf("expected at least one IP address or CIDR range")
+	}
+	for _, entry := range entries {
+		if net.ParseIP(entry) != nil {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(entry); err != nil {
+			return fmt.Errorf("%q is neither an IP address nor a CIDR range", entry)
+		}
+	}
+	return nil
 }
@@ -17,15 +17,25 @@ limitations under the License.
 package utils
 
 import (
+	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/kserve/kserve/pkg/credentials/gcs"
 	"github.com/onsi/gomega"
 	"github.com/onsi/gomega/types"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -541,3 +551,278 @@ func TestIsPrefixSupported(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateRouteAnnotations(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		annotations map[string]string
+		expectErr   bool
+	}{
+		"NoRouterAnnotations": {
+			annotations: map[string]string{"foo": "bar"},
+			expectErr:   false,
+		},
+		"ValidAnnotations": {
+			annotations: map[string]string{
+				constants.RouteTimeoutAnnotationKey:                "5m",
+				constants.RouteBalanceAnnotationKey:                "leastconn",
+				constants.RouteIPWhitelistAnnotationKey:            "192.168.1.10 10.0.0.0/8",
+				constants.RouteDisableCookiesAnnotationKey:         "true",
+				constants.RouteRateLimitConcurrentTCPAnnotationKey: "100",
+			},
+			expectErr: false,
+		},
+		"UnknownRouterAnnotation": {
+			annotations: map[string]string{constants.OpenshiftRouterAnnotationPrefix + "hsts_header": "max-age=31536000"},
+			expectErr:   true,
+		},
+		"InvalidTimeout": {
+			annotations: map[string]string{constants.RouteTimeoutAnnotationKey: "5 minutes"},
+			expectErr:   true,
+		},
+		"InvalidBalance": {
+			annotations: map[string]string{constants.RouteBalanceAnnotationKey: "fastest"},
+			expectErr:   true,
+		},
+		"InvalidIPWhitelist": {
+			annotations: map[string]string{constants.RouteIPWhitelistAnnotationKey: "10.0.0.0/8 not-an-ip"},
+			expectErr:   true,
+		},
+		"InvalidRateLimit": {
+			annotations: map[string]string{constants.RouteRateLimitHTTPRequestRateAnnotationKey: "-1"},
+			expectErr:   true,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateRouteAnnotations(scenario.annotations)
+			if scenario.expectErr {
+				g.Expect(err).Should(gomega.HaveOccurred())
+			} else {
+				g.Expect(err).ShouldNot(gomega.HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestChangedAnnotations(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	old := map[string]string{"kept": "a", "changed": "b", "removed": "c"}
+	updated := map[string]string{"kept": "a", "changed": "B", "added": "d"}
+	g.Expect(ChangedAnnotations(old, updated)).To(gomega.Equal(map[string]string{"changed": "B", "added": "d"}))
+	g.Expect(ChangedAnnotations(nil, updated)).To(gomega.Equal(updated))
+	g.Expect(ChangedAnnotations(old, nil)).To(gomega.BeEmpty())
+}
+
+func TestValidateDeploymentAnnotations(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		annotations map[string]string
+		expectErr   bool
+	}{
+		"NoDeploymentAnnotations": {
+			annotations: map[string]string{"foo": "bar"},
+			expectErr:   false,
+		},
+		"ValidAnnotations": {
+			annotations: map[string]string{
+				constants.RevisionHistoryLimitAnnotationKey:    "0",
+				constants.ProgressDeadlineSecondsAnnotationKey: "3600",
+			},
+			expectErr: false,
+		},
+		"NegativeRevisionHistoryLimit": {
+			annotations: map[string]string{constants.RevisionHistoryLimitAnnotationKey: "-1"},
+			expectErr:   true,
+		},
+		"ZeroProgressDeadline": {
+			annotations: map[string]string{constants.ProgressDeadlineSecondsAnnotationKey: "0"},
+			expectErr:   true,
+		},
+		"InvalidProgressDeadline": {
+			annotations: map[string]string{constants.ProgressDeadlineSecondsAnnotationKey: "1h"},
+			expectErr:   true,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateDeploymentAnnotations(scenario.annotations)
+			if scenario.expectErr {
+				g.Expect(err).Should(gomega.HaveOccurred())
+			} else {
+				g.Expect(err).ShouldNot(gomega.HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestValidateServiceAnnotations(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		annotations map[string]string
+		expectErr   bool
+	}{
+		"NoServiceAnnotations": {
+			annotations: map[string]string{"foo": "bar"},
+			expectErr:   false,
+		},
+		"NodePort": {
+			annotations: map[string]string{constants.ServiceTypeAnnotationKey: "NodePort"},
+			expectErr:   false,
+		},
+		"LoadBalancerWithOptions": {
+			annotations: map[string]string{
+				constants.ServiceTypeAnnotationKey:                   "LoadBalancer",
+				constants.LoadBalancerClassAnnotationKey:             "example.com/internal-vip",
+				constants.AllocateLoadBalancerNodePortsAnnotationKey: "false",
+			},
+			expectErr: false,
+		},
+		"UnknownServiceType": {
+			annotations: map[string]string{constants.ServiceTypeAnnotationKey: "ExternalName"},
+			expectErr:   true,
+		},
+		"LoadBalancerClassWithoutLoadBalancer": {
+			annotations: map[string]string{
+				constants.ServiceTypeAnnotationKey:       "NodePort",
+				constants.LoadBalancerClassAnnotationKey: "example.com/internal-vip",
+			},
+			expectErr: true,
+		},
+		"AllocateNodePortsWithoutServiceType": {
+			annotations: map[string]string{constants.AllocateLoadBalancerNodePortsAnnotationKey: "true"},
+			expectErr:   true,
+		},
+		"InvalidLoadBalancerClass": {
+			annotations: map[string]string{
+				constants.ServiceTypeAnnotationKey:       "LoadBalancer",
+				constants.LoadBalancerClassAnnotationKey: "not a class",
+			},
+			expectErr: true,
+		},
+		"TrafficRouting": {
+			annotations: map[string]string{
+				constants.InternalTrafficPolicyAnnotationKey: "Local",
+				constants.TopologyAwareRoutingAnnotationKey:  "true",
+			},
+			expectErr: false,
+		},
+		"InvalidInternalTrafficPolicy": {
+			annotations: map[string]string{constants.InternalTrafficPolicyAnnotationKey: "Node"},
+			expectErr:   true,
+		},
+		"InvalidTopologyAwareRouting": {
+			annotations: map[string]string{constants.TopologyAwareRoutingAnnotationKey: "Auto"},
+			expectErr:   true,
+		},
+		"InvalidAllocateNodePorts": {
+			annotations: map[string]string{
+				constants.ServiceTypeAnnotationKey:                   "LoadBalancer",
+				constants.AllocateLoadBalancerNodePortsAnnotationKey: "maybe",
+			},
+			expectErr: true,
+		},
+		"ServiceAlias": {
+			annotations: map[string]string{
+				constants.ServiceAliasAnnotationKey:     "production",
+				constants.ServiceAliasTypeAnnotationKey: "ClusterIP",
+			},
+			expectErr: false,
+		},
+		"InvalidServiceAlias": {
+			annotations: map[string]string{constants.ServiceAliasAnnotationKey: "Production.svc"},
+			expectErr:   true,
+		},
+		"InvalidServiceAliasType": {
+			annotations: map[string]string{
+				constants.ServiceAliasAnnotationKey:     "production",
+				constants.ServiceAliasTypeAnnotationKey: "NodePort",
+			},
+			expectErr: true,
+		},
+		"ServiceAliasTypeWithoutAlias": {
+			annotations: map[string]string{constants.ServiceAliasTypeAnnotationKey: "ExternalName"},
+			expectErr:   true,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateServiceAnnotations(scenario.annotations)
+			if scenario.expectErr {
+				g.Expect(err).Should(gomega.HaveOccurred())
+			} else {
+				g.Expect(err).ShouldNot(gomega.HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestGetRouteAnnotations(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	annotations := map[string]string{
+		"foo":                               "bar",
+		constants.RouteTimeoutAnnotationKey: "30s",
+	}
+	g.Expect(GetRouteAnnotations(annotations)).Should(gomega.Equal(map[string]string{
+		constants.RouteTimeoutAnnotationKey: "30s",
+	}))
+}
+
+func TestGetAvailableResourcesForApiRefresh(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	groupVersion := "serving.knative.dev/v1"
+	var installed atomic.Bool
+	var queries atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries.Add(1)
+		if r.URL.Path != "/apis/"+groupVersion || !installed.Load() {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(&metav1.APIResourceList{
+			GroupVersion: groupVersion,
+			APIResources: []metav1.APIResource{{Name: "services", Kind: "Service", Namespaced: true}},
+		})
+	}))
+	defer server.Close()
+	config := &rest.Config{Host: server.URL}
+	SetAvailableResourcesTTL(time.Hour)
+	defer SetAvailableResourcesTTL(DefaultAvailableResourcesTTL)
+
+	found, err := IsCrdAvailable(config, groupVersion, "Service")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(found).To(gomega.BeFalse())
+
+	// the cached resources are returned until they expire
+	installed.Store(true)
+	found, err = IsCrdAvailable(config, groupVersion, "Service")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(found).To(gomega.BeFalse())
+	g.Expect(queries.Load()).To(gomega.Equal(int32(1)))
+
+	SetAvailableResourcesTTL(0)
+	found, err = IsCrdAvailable(config, groupVersion, "Service")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(found).To(gomega.BeTrue())
+
+	// the resources set for the tests never expire
+	SetAvailableResourcesForApi(groupVersion, nil)
+	found, err = IsCrdAvailable(config, groupVersion, "Service")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(found).To(gomega.BeFalse())
+
+	resources, err := RefreshAvailableResourcesForApi(config, groupVersion)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(resources.APIResources).To(gomega.HaveLen(1))
+}
+
+func TestIsAPINotAvailable(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	gvk := schema.GroupVersionKind{Group: "security.istio.io", Version: "v1beta1", Kind: "PeerAuthentication"}
+	g.Expect(IsAPINotAvailable(&meta.NoKindMatchError{GroupKind: gvk.GroupKind(), SearchedVersions: []string{"v1beta1"}})).
+		To(gomega.BeTrue())
+	_, _, err := runtime.NewScheme().ObjectKinds(&v1.Pod{})
+	g.Expect(IsAPINotAvailable(err)).To(gomega.BeTrue())
+	g.Expect(IsAPINotAvailable(errors.New("connection refused"))).To(gomega.BeFalse())
+}
@@ -0,0 +1,296 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+var log = logf.Log.WithName(constants.CapacityValidatorWebhookName)
+
+const (
+	CapacityCheckConfigKeyName = "capacityCheck"
+	// UnschedulableComponentError is returned when no node in the cluster can ever run a component
+	UnschedulableComponentError = "the %s requests %s but no node in the cluster has enough allocatable capacity%s"
+	defaultNodeCacheTTLSeconds  = 60
+)
+
+// CapacityCheckMode controls what happens to an InferenceService that can not be scheduled on any node
+type CapacityCheckMode string
+
+const (
+	CapacityCheckDisabled CapacityCheckMode = "Disabled"
+	CapacityCheckWarn     CapacityCheckMode = "Warn"
+	CapacityCheckReject   CapacityCheckMode = "Reject"
+)
+
+// CapacityCheckConfig is the configuration of the node capacity check
+// +kubebuilder:object:generate=false
+type CapacityCheckConfig struct {
+	// Mode is one of Disabled, Warn or Reject, the check is disabled by default
+	Mode CapacityCheckMode `json:"mode,omitempty"`
+	// Resources are the extended resources to check, defaults to nvidia.com/gpu
+	Resources []v1.ResourceName `json:"resources,omitempty"`
+	// NodeCacheTTLSeconds is how long the node capacity summary is reused before the nodes are listed again
+	NodeCacheTTLSeconds int64 `json:"nodeCacheTTLSeconds,omitempty"`
+}
+
+func GetCapacityCheckConfig(configMap *v1.ConfigMap) (*CapacityCheckConfig, error) {
+	config := &CapacityCheckConfig{}
+	if value, ok := configMap.Data[CapacityCheckConfigKeyName]; ok {
+		if err := json.Unmarshal([]byte(value), config); err != nil {
+			return nil, fmt.Errorf("unable to unmarshall %v json string due to %w ", CapacityCheckConfigKeyName, err)
+		}
+	}
+	switch config.Mode {
+	case "":
+		config.Mode = CapacityCheckDisabled
+	case CapacityCheckDisabled, CapacityCheckWarn, CapacityCheckReject:
+	default:
+		return nil, fmt.Errorf("invalid %s mode %q, must be one of %s, %s or %s", CapacityCheckConfigKeyName,
+			config.Mode, CapacityCheckDisabled, CapacityCheckWarn, CapacityCheckReject)
+	}
+	if len(config.Resources) == 0 {
+		config.Resources = []v1.ResourceName{constants.NvidiaGPUResourceType}
+	}
+	if config.NodeCacheTTLSeconds <= 0 {
+		config.NodeCacheTTLSeconds = defaultNodeCacheTTLSeconds
+	}
+	return config, nil
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-serving-kserve-io-v1beta1-inferenceservice-capacity,mutating=false,failurePolicy=ignore,groups=serving.kserve.io,resources=inferenceservices,versions=v1beta1,name=capacity.inferenceservice.kserve-webhook-server.validator
+
+// CapacityValidator warns about or rejects InferenceServices requesting accelerators that no node in the cluster
+// can provide, instead of leaving their pods pending forever.
+type CapacityValidator struct {
+	Clientset kubernetes.Interface
+	Decoder   *admission.Decoder
+
+	mutex       sync.Mutex
+	nodes       []nodeSummary
+	refreshedAt time.Time
+}
+
+// nodeSummary is the part of a node which is relevant to decide whether a component can ever fit on it
+type nodeSummary struct {
+	labels      map[string]string
+	allocatable v1.ResourceList
+}
+
+// Handle validates the incoming InferenceService against the node capacity summary
+func (cv *CapacityValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	isvc := &v1beta1.InferenceService{}
+	if err := cv.Decoder.Decode(req, isvc); err != nil {
+		log.Error(err, "Failed to decode inference service", "name", isvc.Name, "namespace", isvc.Namespace)
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	configMap, err := cv.Clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Get(context.TODO(),
+		constants.InferenceServiceConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		log.Error(err, "Failed to find config map", "name", constants.InferenceServiceConfigMapName)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	config, err := GetCapacityCheckConfig(configMap)
+	if err != nil {
+		log.Error(err, "Failed to get capacity check config")
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if config.Mode == CapacityCheckDisabled {
+		return admission.Allowed("")
+	}
+
+	reasons, err := cv.unschedulableComponents(isvc, config)
+	if err != nil {
+		log.Error(err, "Failed to check node capacity", "name", isvc.Name, "namespace", isvc.Namespace)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if len(reasons) == 0 {
+		return admission.Allowed("")
+	}
+	if config.Mode == CapacityCheckReject {
+		return admission.Denied(strings.Join(reasons, "; "))
+	}
+	return admission.Allowed("").WithWarnings(reasons...)
+}
+
+// component is the pod spec and containers of an InferenceService component
+type component struct {
+	name       v1beta1.ComponentType
+	podSpec    *v1beta1.PodSpec
+	containers []v1.Container
+}
+
+// unschedulableComponents returns a message for every component which can not fit on any node
+func (cv *CapacityValidator) unschedulableComponents(isvc *v1beta1.InferenceService, config *CapacityCheckConfig) ([]string, error) {
+	components := []component{
+		{v1beta1.PredictorComponent, &isvc.Spec.Predictor.PodSpec, predictorContainers(&isvc.Spec.Predictor)},
+	}
+	if isvc.Spec.Transformer != nil {
+		components = append(components, component{v1beta1.TransformerComponent, &isvc.Spec.Transformer.PodSpec, isvc.Spec.Transformer.Containers})
+	}
+	if isvc.Spec.Explainer != nil {
+		containers := isvc.Spec.Explainer.Containers
+		if isvc.Spec.Explainer.ART != nil {
+			containers = append([]v1.Container{isvc.Spec.Explainer.ART.Container}, containers...)
+		}
+		components = append(components, component{v1beta1.ExplainerComponent, &isvc.Spec.Explainer.PodSpec, containers})
+	}
+
+	var reasons []string
+	for _, c := range components {
+		requested := requestedResources(c.containers, config.Resources)
+		if len(requested) == 0 {
+			continue
+		}
+		nodes, err := cv.getNodes(time.Duration(config.NodeCacheTTLSeconds) * time.Second)
+		if err != nil {
+			return nil, err
+		}
+		if !fitsAnyNode(nodes, c.podSpec.NodeSelector, requested) {
+			selector := ""
+			if len(c.podSpec.NodeSelector) != 0 {
+				selector = fmt.Sprintf(" matching node selector %s", formatMap(c.podSpec.NodeSelector))
+			}
+			reasons = append(reasons, fmt.Sprintf(UnschedulableComponentError, c.name,
+				formatResources(requested), selector))
+		}
+	}
+	return reasons, nil
+}
+
+// getNodes returns the cached node summary, listing the nodes again once it is older than ttl
+func (cv *CapacityValidator) getNodes(ttl time.Duration) ([]nodeSummary, error) {
+	cv.mutex.Lock()
+	defer cv.mutex.Unlock()
+	if cv.nodes != nil && time.Since(cv.refreshedAt) < ttl {
+		return cv.nodes, nil
+	}
+	nodeList, err := cv.Clientset.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]nodeSummary, 0, len(nodeList.Items))
+	for _, node := range nodeList.Items {
+		nodes = append(nodes, nodeSummary{labels: node.Labels, allocatable: node.Status.Allocatable})
+	}
+	cv.nodes = nodes
+	cv.refreshedAt = time.Now()
+	return nodes, nil
+}
+
+// predictorContainers returns the containers of the predictor, including the one of the model framework
+func predictorContainers(predictor *v1beta1.PredictorSpec) []v1.Container {
+	var containers []v1.Container
+	for _, implementation := range predictor.GetImplementations() {
+		if _, ok := implementation.(*v1beta1.CustomPredictor); ok {
+			continue
+		}
+		containers = append(containers, *implementation.GetContainer(metav1.ObjectMeta{}, nil, nil))
+	}
+	return append(containers, predictor.Containers...)
+}
+
+// requestedResources sums the checked resources over the containers of a component. Extended resources
+// can not be overcommitted so the limit is used when only the limit is set.
+func requestedResources(containers []v1.Container, resourceNames []v1.ResourceName) v1.ResourceList {
+	requested := v1.ResourceList{}
+	for _, container := range containers {
+		for _, resourceName := range resourceNames {
+			quantity, ok := container.Resources.Requests[resourceName]
+			if !ok {
+				quantity, ok = container.Resources.Limits[resourceName]
+			}
+			if !ok || quantity.IsZero() {
+				continue
+			}
+			total := requested[resourceName]
+			total.Add(quantity)
+			requested[resourceName] = total
+		}
+	}
+	return requested
+}
+
+// fitsAnyNode returns true if a node matching the node selector has enough allocatable capacity. Taints and
+// current usage are ignored since they may change, the check only catches requests which can never be satisfied.
+func fitsAnyNode(nodes []nodeSummary, nodeSelector map[string]string, requested v1.ResourceList) bool {
+	for _, node := range nodes {
+		if !matchesNodeSelector(node.labels, nodeSelector) {
+			continue
+		}
+		fits := true
+		for resourceName, quantity := range requested {
+			allocatable, ok := node.allocatable[resourceName]
+			if !ok || allocatable.Cmp(quantity) < 0 {
+				fits = false
+				break
+			}
+		}
+		if fits {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesNodeSelector(labels map[string]string, nodeSelector map[string]string) bool {
+	for key, value := range nodeSelector {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func formatResources(resources v1.ResourceList) string {
+	values := make(map[string]string, len(resources))
+	for resourceName, quantity := range resources {
+		values[string(resourceName)] = quantity.String()
+	}
+	return formatMap(values)
+}
+
+func formatMap(values map[string]string) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+values[key])
+	}
+	return strings.Join(pairs, ",")
+}
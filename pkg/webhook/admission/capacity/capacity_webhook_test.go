@@ -0,0 +1,153 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacity
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func newNode(name string, labels map[string]string, gpus string) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Status: v1.NodeStatus{
+			Allocatable: v1.ResourceList{
+				v1.ResourceCPU:                  resource.MustParse("32"),
+				constants.NvidiaGPUResourceType: resource.MustParse(gpus),
+			},
+		},
+	}
+}
+
+func newInferenceService(gpus string, nodeSelector map[string]string) *v1beta1.InferenceService {
+	return &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+		Spec: v1beta1.InferenceServiceSpec{
+			Predictor: v1beta1.PredictorSpec{
+				PodSpec: v1beta1.PodSpec{NodeSelector: nodeSelector},
+				Model: &v1beta1.ModelSpec{
+					ModelFormat: v1beta1.ModelFormat{Name: "huggingface"},
+					PredictorExtensionSpec: v1beta1.PredictorExtensionSpec{
+						Container: v1.Container{
+							Resources: v1.ResourceRequirements{
+								Limits: v1.ResourceList{constants.NvidiaGPUResourceType: resource.MustParse(gpus)},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGetCapacityCheckConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	config, err := GetCapacityCheckConfig(&v1.ConfigMap{})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(config).Should(gomega.Equal(&CapacityCheckConfig{
+		Mode:                CapacityCheckDisabled,
+		Resources:           []v1.ResourceName{constants.NvidiaGPUResourceType},
+		NodeCacheTTLSeconds: defaultNodeCacheTTLSeconds,
+	}))
+
+	_, err = GetCapacityCheckConfig(&v1.ConfigMap{
+		Data: map[string]string{CapacityCheckConfigKeyName: `{"mode": "Sometimes"}`},
+	})
+	g.Expect(err).Should(gomega.HaveOccurred())
+}
+
+func TestCapacityValidator(t *testing.T) {
+	nodes := []runtime.Object{
+		newNode("cpu", map[string]string{}, "0"),
+		newNode("a100", map[string]string{"nvidia.com/gpu.product": "A100"}, "8"),
+		newNode("t4", map[string]string{"nvidia.com/gpu.product": "T4"}, "1"),
+	}
+	scenarios := map[string]struct {
+		mode     CapacityCheckMode
+		isvc     *v1beta1.InferenceService
+		allowed  bool
+		warnings int
+	}{
+		"FitsOnANode": {
+			mode:    CapacityCheckReject,
+			isvc:    newInferenceService("4", nil),
+			allowed: true,
+		},
+		"FitsOnTheSelectedGPUType": {
+			mode:    CapacityCheckReject,
+			isvc:    newInferenceService("1", map[string]string{"nvidia.com/gpu.product": "T4"}),
+			allowed: true,
+		},
+		"TooManyGPUs": {
+			mode:    CapacityCheckReject,
+			isvc:    newInferenceService("16", nil),
+			allowed: false,
+		},
+		"TooManyGPUsOfTheSelectedType": {
+			mode:    CapacityCheckReject,
+			isvc:    newInferenceService("2", map[string]string{"nvidia.com/gpu.product": "T4"}),
+			allowed: false,
+		},
+		"UnknownGPUType": {
+			mode:     CapacityCheckWarn,
+			isvc:     newInferenceService("1", map[string]string{"nvidia.com/gpu.product": "H100"}),
+			allowed:  true,
+			warnings: 1,
+		},
+		"Disabled": {
+			mode:    CapacityCheckDisabled,
+			isvc:    newInferenceService("16", nil),
+			allowed: true,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			configMap := &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+				Data:       map[string]string{CapacityCheckConfigKeyName: `{"mode": "` + string(scenario.mode) + `"}`},
+			}
+			validator := &CapacityValidator{
+				Clientset: fake.NewSimpleClientset(append([]runtime.Object{configMap}, nodes...)...),
+				Decoder:   admission.NewDecoder(runtime.NewScheme()),
+			}
+			raw, err := json.Marshal(scenario.isvc)
+			g.Expect(err).ShouldNot(gomega.HaveOccurred())
+			response := validator.Handle(context.TODO(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Object:    runtime.RawExtension{Raw: raw},
+				},
+			})
+			g.Expect(response.Allowed).Should(gomega.Equal(scenario.allowed))
+			g.Expect(response.Warnings).Should(gomega.HaveLen(scenario.warnings))
+		})
+	}
+}
@@ -19,6 +19,7 @@ package pod
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -26,6 +27,7 @@ import (
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/kserve/kserve/pkg/credentials"
+	"github.com/kserve/kserve/pkg/utils"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
@@ -35,10 +37,23 @@ const (
 	LoggerArgumentLogUrl           = "--log-url"
 	LoggerArgumentSourceUri        = "--source-uri"
 	LoggerArgumentMode             = "--log-mode"
+	LoggerArgumentDeliveryMode     = "--log-delivery-mode"
+	LoggerArgumentSpillDir         = "--log-spill-dir"
 	LoggerArgumentInferenceService = "--inference-service"
 	LoggerArgumentNamespace        = "--namespace"
 	LoggerArgumentEndpoint         = "--endpoint"
 	LoggerArgumentComponent        = "--component"
+	MiddlewareArgument             = "--middleware"
+	CORSArgument                   = "--cors"
+	AgentArgumentMetricsPort       = "--metrics-port"
+	AgentArgumentLogLevel          = "--log-level"
+	AgentArgumentLogFormat         = "--log-format"
+	AgentArgumentEnableReplay      = "--enable-replay"
+	AgentArgumentReplayBufferSize  = "--replay-buffer-size"
+	AgentArgumentReplayTokenFile   = "--replay-token-file"
+	// Environment variables the agent reads the client credentials of the token exchange from
+	TokenExchangeClientIdEnvVar     = "TOKEN_EXCHANGE_CLIENT_ID"
+	TokenExchangeClientSecretEnvVar = "TOKEN_EXCHANGE_CLIENT_SECRET"
 )
 
 type AgentConfig struct {
@@ -120,8 +135,11 @@ func (ag *AgentInjector) InjectAgent(pod *v1.Pod) error {
 	_, injectLogger := pod.ObjectMeta.Annotations[constants.LoggerInternalAnnotationKey]
 	_, injectPuller := pod.ObjectMeta.Annotations[constants.AgentShouldInjectAnnotationKey]
 	_, injectBatcher := pod.ObjectMeta.Annotations[constants.BatcherInternalAnnotationKey]
+	middleware, injectMiddleware := pod.ObjectMeta.Annotations[constants.AgentMiddlewareInternalAnnotationKey]
+	corsPolicy, injectCORS := pod.ObjectMeta.Annotations[constants.CORSInternalAnnotationKey]
+	replaySecret, injectReplay := pod.ObjectMeta.Annotations[constants.RequestReplaySecretAnnotationKey]
 
-	if !injectLogger && !injectPuller && !injectBatcher {
+	if !injectLogger && !injectPuller && !injectBatcher && !injectMiddleware && !injectCORS && !injectReplay {
 		return nil
 	}
 
@@ -196,6 +214,70 @@ func (ag *AgentInjector) InjectAgent(pod *v1.Pod) error {
 			component,
 		}
 		args = append(args, loggerArgs...)
+
+		if deliveryMode, ok := pod.ObjectMeta.Annotations[constants.LoggerDeliveryModeInternalAnnotationKey]; ok {
+			args = append(args, LoggerArgumentDeliveryMode, deliveryMode)
+			if deliveryMode == string(v1beta1.LogDeliverySpill) {
+				args = append(args, LoggerArgumentSpillDir, constants.LoggerSpillDir)
+			}
+		}
+	}
+
+	var middlewareEnvs []v1.EnvVar
+	// Only inject if the middleware required annotations are set
+	if injectMiddleware {
+		middlewareSpec := &v1beta1.AgentMiddleware{}
+		if err := json.Unmarshal([]byte(middleware), middlewareSpec); err != nil {
+			return fmt.Errorf("failed to parse the agent middleware: %w", err)
+		}
+		args = append(args, MiddlewareArgument, middleware)
+		if middlewareSpec.TokenExchange != nil && middlewareSpec.TokenExchange.ClientSecretName != "" {
+			middlewareEnvs = append(middlewareEnvs,
+				secretKeyEnvVar(TokenExchangeClientIdEnvVar, middlewareSpec.TokenExchange.ClientSecretName, "client_id"),
+				secretKeyEnvVar(TokenExchangeClientSecretEnvVar, middlewareSpec.TokenExchange.ClientSecretName, "client_secret"))
+		}
+		if middlewareSpec.TokenReview != nil && !injectLogger && !injectReplay {
+			// The users of the tokens are authorized on the InferenceService of the pod
+			args = append(args, LoggerArgumentInferenceService, pod.ObjectMeta.Labels[constants.InferenceServiceLabel],
+				LoggerArgumentNamespace, pod.ObjectMeta.Namespace)
+		}
+	}
+
+	if injectCORS {
+		policy := &v1beta1.CORSPolicy{}
+		if err := json.Unmarshal([]byte(corsPolicy), policy); err != nil {
+			return fmt.Errorf("failed to parse the cors policy: %w", err)
+		}
+		args = append(args, CORSArgument, corsPolicy)
+	}
+
+	if injectReplay {
+		args = append(args, AgentArgumentEnableReplay,
+			AgentArgumentReplayTokenFile, constants.ReplayTokenDir+"/"+constants.ReplayTokenSecretKey)
+		if bufferSize, ok := pod.ObjectMeta.Annotations[constants.RequestReplayBufferSizeAnnotationKey]; ok {
+			args = append(args, AgentArgumentReplayBufferSize, bufferSize)
+		}
+		if !injectLogger {
+			// The failing requests are replayed against the revisions of the namespace of the pod
+			args = append(args, LoggerArgumentInferenceService, pod.ObjectMeta.Labels[constants.InferenceServiceLabel],
+				LoggerArgumentNamespace, pod.ObjectMeta.Namespace)
+		}
+	}
+
+	// The metrics of the agent are served whatever features are enabled
+	args = append(args, AgentArgumentMetricsPort, strconv.Itoa(constants.InferenceServiceAgentMetricsPort))
+
+	if value, ok := pod.ObjectMeta.Annotations[constants.SidecarLoggingInternalAnnotationKey]; ok {
+		logging := &v1beta1.SidecarLoggingSpec{}
+		if err := json.Unmarshal([]byte(value), logging); err != nil {
+			return fmt.Errorf("failed to parse the sidecar logging: %w", err)
+		}
+		if logging.Level != "" {
+			args = append(args, AgentArgumentLogLevel, string(logging.Level))
+		}
+		if logging.Format != "" {
+			args = append(args, AgentArgumentLogFormat, string(logging.Format))
+		}
 	}
 
 	var queueProxyEnvs []v1.EnvVar
@@ -234,8 +316,21 @@ func (ag *AgentInjector) InjectAgent(pod *v1.Pod) error {
 		}
 	}
 
+	agentEnvs = append(agentEnvs, middlewareEnvs...)
+
 	// Make sure securityContext is initialized and valid
 	securityContext := pod.Spec.Containers[0].SecurityContext.DeepCopy()
+	if value, ok := pod.ObjectMeta.Annotations[constants.AgentSecurityContextInternalAnnotationKey]; ok {
+		override := &v1beta1.SidecarSecurityContext{}
+		if err := json.Unmarshal([]byte(value), override); err != nil {
+			return fmt.Errorf("failed to parse the agent security context: %w", err)
+		}
+		// the annotation can be set on the pod directly, the bounds are enforced again
+		if err := utils.ValidateSecurityContextOverride(override.ToSecurityContext(), nil); err != nil {
+			return fmt.Errorf("invalid agent security context: %w", err)
+		}
+		securityContext = utils.ApplySecurityContextOverride(securityContext, override.ToSecurityContext())
+	}
 
 	agentContainer := &v1.Container{
 		Name:  constants.AgentContainerName,
@@ -257,6 +352,11 @@ func (ag *AgentInjector) InjectAgent(pod *v1.Pod) error {
 				ContainerPort: constants.InferenceServiceDefaultAgentPort,
 				Protocol:      "TCP",
 			},
+			{
+				Name:          constants.AgentMetricsPortName,
+				ContainerPort: constants.InferenceServiceAgentMetricsPort,
+				Protocol:      "TCP",
+			},
 		},
 		SecurityContext: securityContext,
 		Env:             agentEnvs,
@@ -291,6 +391,31 @@ func (ag *AgentInjector) InjectAgent(pod *v1.Pod) error {
 	// Add container to the spec
 	pod.Spec.Containers = append(pod.Spec.Containers, *agentContainer)
 
+	if injectLogger && pod.ObjectMeta.Annotations[constants.LoggerDeliveryModeInternalAnnotationKey] == string(v1beta1.LogDeliverySpill) {
+		// Mount the volume the log events are spilled to into the agent container
+		spillVolume := v1.Volume{
+			Name: constants.LoggerSpillVolumeName,
+			VolumeSource: v1.VolumeSource{
+				EmptyDir: &v1.EmptyDirVolumeSource{},
+			},
+		}
+		mountVolumeToContainer(constants.AgentContainerName, pod, spillVolume, constants.LoggerSpillDir)
+	}
+
+	if injectReplay {
+		// Mount the bearer token of the replay endpoints into the agent container
+		replayTokenVolume := v1.Volume{
+			Name: constants.ReplayTokenVolumeName,
+			VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{
+					SecretName: replaySecret,
+					Items:      []v1.KeyToPath{{Key: constants.ReplayTokenSecretKey, Path: constants.ReplayTokenSecretKey}},
+				},
+			},
+		}
+		mountVolumeToContainer(constants.AgentContainerName, pod, replayTokenVolume, constants.ReplayTokenDir)
+	}
+
 	if _, ok := pod.ObjectMeta.Annotations[constants.AgentShouldInjectAnnotationKey]; ok {
 		// Mount the modelDir volume to the pod and model agent container
 		err := mountModelDir(pod)
@@ -307,6 +432,18 @@ func (ag *AgentInjector) InjectAgent(pod *v1.Pod) error {
 	return nil
 }
 
+func secretKeyEnvVar(name string, secretName string, key string) v1.EnvVar {
+	return v1.EnvVar{
+		Name: name,
+		ValueFrom: &v1.EnvVarSource{
+			SecretKeyRef: &v1.SecretKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: secretName},
+				Key:                  key,
+			},
+		},
+	}
+}
+
 func mountModelDir(pod *v1.Pod) error {
 	if _, ok := pod.ObjectMeta.Annotations[constants.AgentModelDirAnnotationKey]; ok {
 		modelDirVolume := v1.Volume{
@@ -24,12 +24,14 @@ import (
 	"github.com/kserve/kserve/pkg/credentials"
 	"github.com/onsi/gomega"
 	"github.com/onsi/gomega/types"
+	"google.golang.org/protobuf/proto"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"knative.dev/pkg/kmp"
 
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -158,15 +160,610 @@ func TestAgentInjector(t *testing.T) {
 									MountPath: constants.ModelConfigDir,
 								},
 							},
-							Args: []string{"--enable-puller", "--config-dir", "/mnt/configs", "--model-dir", "/mnt/models"},
+							Args: []string{"--enable-puller", "--config-dir", "/mnt/configs", "--model-dir", "/mnt/models", AgentArgumentMetricsPort, "9089"},
+							Ports: []v1.ContainerPort{
+								{
+									Name:          "agent-port",
+									ContainerPort: constants.InferenceServiceDefaultAgentPort,
+									Protocol:      "TCP",
+								},
+								{
+									Name:          constants.AgentMetricsPortName,
+									ContainerPort: constants.InferenceServiceAgentMetricsPort,
+									Protocol:      "TCP",
+								},
+							},
+							Env: []v1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
+							ReadinessProbe: &v1.Probe{
+								ProbeHandler: v1.ProbeHandler{
+									HTTPGet: &v1.HTTPGetAction{
+										HTTPHeaders: []v1.HTTPHeader{
+											{
+												Name:  "K-Network-Probe",
+												Value: "queue",
+											},
+										},
+										Port:   intstr.FromInt(9081),
+										Path:   "/",
+										Scheme: "HTTP",
+									},
+								},
+							},
+						},
+					},
+					Volumes: []v1.Volume{
+						{
+							Name: "model-dir",
+							VolumeSource: v1.VolumeSource{
+								EmptyDir: &v1.EmptyDirVolumeSource{},
+							},
+						},
+						{
+							Name: "model-config",
+							VolumeSource: v1.VolumeSource{
+								ConfigMap: &v1.ConfigMapVolumeSource{
+									LocalObjectReference: v1.LocalObjectReference{
+										Name: "modelconfig-deployment-0",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"DoNotAddAgent": {
+			original: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "deployment",
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{{
+						Name: "sklearn",
+					}},
+				},
+			},
+			expected: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "deployment",
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{{
+						Name: "sklearn",
+					}},
+				},
+			},
+		},
+		"AddLogger": {
+			original: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment",
+					Namespace: "default",
+					Annotations: map[string]string{
+						constants.LoggerInternalAnnotationKey:        "true",
+						constants.LoggerSinkUrlInternalAnnotationKey: "http://httpbin.org/",
+						constants.LoggerModeInternalAnnotationKey:    string(v1beta1.LogAll),
+					},
+					Labels: map[string]string{
+						"serving.kserve.io/inferenceservice": "sklearn",
+						constants.KServiceModelLabel:         "sklearn",
+						constants.KServiceEndpointLabel:      "default",
+						constants.KServiceComponentLabel:     "predictor",
+					},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name: "sklearn",
+							ReadinessProbe: &v1.Probe{
+								ProbeHandler: v1.ProbeHandler{
+									TCPSocket: &v1.TCPSocketAction{
+										Port: intstr.IntOrString{
+											IntVal: 8080,
+										},
+									},
+								},
+								InitialDelaySeconds: 0,
+								TimeoutSeconds:      1,
+								PeriodSeconds:       10,
+								SuccessThreshold:    1,
+								FailureThreshold:    3,
+							},
+						},
+						{
+							Name: "queue-proxy",
+							Env:  []v1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
+						},
+					},
+				},
+			},
+			expected: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "deployment",
+					Annotations: map[string]string{
+						constants.LoggerInternalAnnotationKey:        "true",
+						constants.LoggerSinkUrlInternalAnnotationKey: "http://httpbin.org/",
+						constants.LoggerModeInternalAnnotationKey:    string(v1beta1.LogAll),
+					},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name: "sklearn",
+							ReadinessProbe: &v1.Probe{
+								ProbeHandler: v1.ProbeHandler{
+									TCPSocket: &v1.TCPSocketAction{
+										Port: intstr.IntOrString{
+											IntVal: 8080,
+										},
+									},
+								},
+								InitialDelaySeconds: 0,
+								TimeoutSeconds:      1,
+								PeriodSeconds:       10,
+								SuccessThreshold:    1,
+								FailureThreshold:    3,
+							},
+						},
+						{
+							Name: "queue-proxy",
+							Env:  []v1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
+						},
+						{
+							Name:  constants.AgentContainerName,
+							Image: loggerConfig.Image,
+							Args: []string{
+								LoggerArgumentLogUrl,
+								"http://httpbin.org/",
+								LoggerArgumentSourceUri,
+								"deployment",
+								LoggerArgumentMode,
+								"all",
+								LoggerArgumentInferenceService,
+								"sklearn",
+								LoggerArgumentNamespace,
+								"default",
+								LoggerArgumentEndpoint,
+								"default",
+								LoggerArgumentComponent,
+								"predictor",
+								AgentArgumentMetricsPort,
+								"9089",
+							},
+							Ports: []v1.ContainerPort{
+								{
+									Name:          "agent-port",
+									ContainerPort: constants.InferenceServiceDefaultAgentPort,
+									Protocol:      "TCP",
+								},
+								{
+									Name:          constants.AgentMetricsPortName,
+									ContainerPort: constants.InferenceServiceAgentMetricsPort,
+									Protocol:      "TCP",
+								},
+							},
+							Env:       []v1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
+							Resources: agentResourceRequirement,
+							ReadinessProbe: &v1.Probe{
+								ProbeHandler: v1.ProbeHandler{
+									HTTPGet: &v1.HTTPGetAction{
+										HTTPHeaders: []v1.HTTPHeader{
+											{
+												Name:  "K-Network-Probe",
+												Value: "queue",
+											},
+										},
+										Port:   intstr.FromInt(9081),
+										Path:   "/",
+										Scheme: "HTTP",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"AddLoggerWithSpill": {
+			original: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment",
+					Namespace: "default",
+					Annotations: map[string]string{
+						constants.LoggerInternalAnnotationKey:             "true",
+						constants.LoggerSinkUrlInternalAnnotationKey:      "http://httpbin.org/",
+						constants.LoggerModeInternalAnnotationKey:         string(v1beta1.LogAll),
+						constants.LoggerDeliveryModeInternalAnnotationKey: string(v1beta1.LogDeliverySpill),
+					},
+					Labels: map[string]string{
+						"serving.kserve.io/inferenceservice": "sklearn",
+						constants.KServiceModelLabel:         "sklearn",
+						constants.KServiceEndpointLabel:      "default",
+						constants.KServiceComponentLabel:     "predictor",
+					},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name: "sklearn",
+							ReadinessProbe: &v1.Probe{
+								ProbeHandler: v1.ProbeHandler{
+									TCPSocket: &v1.TCPSocketAction{
+										Port: intstr.IntOrString{
+											IntVal: 8080,
+										},
+									},
+								},
+								InitialDelaySeconds: 0,
+								TimeoutSeconds:      1,
+								PeriodSeconds:       10,
+								SuccessThreshold:    1,
+								FailureThreshold:    3,
+							},
+						},
+						{
+							Name: "queue-proxy",
+							Env:  []v1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
+						},
+					},
+				},
+			},
+			expected: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "deployment",
+					Annotations: map[string]string{
+						constants.LoggerInternalAnnotationKey:             "true",
+						constants.LoggerSinkUrlInternalAnnotationKey:      "http://httpbin.org/",
+						constants.LoggerModeInternalAnnotationKey:         string(v1beta1.LogAll),
+						constants.LoggerDeliveryModeInternalAnnotationKey: string(v1beta1.LogDeliverySpill),
+					},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name: "sklearn",
+							ReadinessProbe: &v1.Probe{
+								ProbeHandler: v1.ProbeHandler{
+									TCPSocket: &v1.TCPSocketAction{
+										Port: intstr.IntOrString{
+											IntVal: 8080,
+										},
+									},
+								},
+								InitialDelaySeconds: 0,
+								TimeoutSeconds:      1,
+								PeriodSeconds:       10,
+								SuccessThreshold:    1,
+								FailureThreshold:    3,
+							},
+						},
+						{
+							Name: "queue-proxy",
+							Env:  []v1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
+						},
+						{
+							Name:  constants.AgentContainerName,
+							Image: loggerConfig.Image,
+							Args: []string{
+								LoggerArgumentLogUrl,
+								"http://httpbin.org/",
+								LoggerArgumentSourceUri,
+								"deployment",
+								LoggerArgumentMode,
+								"all",
+								LoggerArgumentInferenceService,
+								"sklearn",
+								LoggerArgumentNamespace,
+								"default",
+								LoggerArgumentEndpoint,
+								"default",
+								LoggerArgumentComponent,
+								"predictor",
+								LoggerArgumentDeliveryMode,
+								"spill",
+								LoggerArgumentSpillDir,
+								constants.LoggerSpillDir,
+								AgentArgumentMetricsPort,
+								"9089",
+							},
+							Ports: []v1.ContainerPort{
+								{
+									Name:          "agent-port",
+									ContainerPort: constants.InferenceServiceDefaultAgentPort,
+									Protocol:      "TCP",
+								},
+								{
+									Name:          constants.AgentMetricsPortName,
+									ContainerPort: constants.InferenceServiceAgentMetricsPort,
+									Protocol:      "TCP",
+								},
+							},
+							Env:       []v1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
+							Resources: agentResourceRequirement,
+							VolumeMounts: []v1.VolumeMount{
+								{
+									Name:      constants.LoggerSpillVolumeName,
+									MountPath: constants.LoggerSpillDir,
+								},
+							},
+							ReadinessProbe: &v1.Probe{
+								ProbeHandler: v1.ProbeHandler{
+									HTTPGet: &v1.HTTPGetAction{
+										HTTPHeaders: []v1.HTTPHeader{
+											{
+												Name:  "K-Network-Probe",
+												Value: "queue",
+											},
+										},
+										Port:   intstr.FromInt(9081),
+										Path:   "/",
+										Scheme: "HTTP",
+									},
+								},
+							},
+						},
+					},
+					Volumes: []v1.Volume{
+						{
+							Name: constants.LoggerSpillVolumeName,
+							VolumeSource: v1.VolumeSource{
+								EmptyDir: &v1.EmptyDirVolumeSource{},
+							},
+						},
+					},
+				},
+			},
+		},
+		"AddMiddleware": {
+			original: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment",
+					Namespace: "default",
+					Annotations: map[string]string{
+						constants.AgentMiddlewareInternalAnnotationKey: `{"requestHeaders":{"set":{"X-Tenant":"team-a"}},"tokenExchange":{"tokenUrl":"https://sts.example.com/token","clientSecretName":"sts-client"}}`,
+					},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name: "sklearn",
+						},
+						{
+							Name: "queue-proxy",
+							Env:  []v1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
+						},
+					},
+				},
+			},
+			expected: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "deployment",
+					Annotations: map[string]string{
+						constants.AgentMiddlewareInternalAnnotationKey: `{"requestHeaders":{"set":{"X-Tenant":"team-a"}},"tokenExchange":{"tokenUrl":"https://sts.example.com/token","clientSecretName":"sts-client"}}`,
+					},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name: "sklearn",
+						},
+						{
+							Name: "queue-proxy",
+							Env:  []v1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
+						},
+						{
+							Name:  constants.AgentContainerName,
+							Image: loggerConfig.Image,
+							Args: []string{
+								MiddlewareArgument,
+								`{"requestHeaders":{"set":{"X-Tenant":"team-a"}},"tokenExchange":{"tokenUrl":"https://sts.example.com/token","clientSecretName":"sts-client"}}`,
+								AgentArgumentMetricsPort,
+								"9089",
+							},
+							Ports: []v1.ContainerPort{
+								{
+									Name:          "agent-port",
+									ContainerPort: constants.InferenceServiceDefaultAgentPort,
+									Protocol:      "TCP",
+								},
+								{
+									Name:          constants.AgentMetricsPortName,
+									ContainerPort: constants.InferenceServiceAgentMetricsPort,
+									Protocol:      "TCP",
+								},
+							},
+							Env: []v1.EnvVar{
+								{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"},
+								{
+									Name: TokenExchangeClientIdEnvVar,
+									ValueFrom: &v1.EnvVarSource{
+										SecretKeyRef: &v1.SecretKeySelector{
+											LocalObjectReference: v1.LocalObjectReference{Name: "sts-client"},
+											Key:                  "client_id",
+										},
+									},
+								},
+								{
+									Name: TokenExchangeClientSecretEnvVar,
+									ValueFrom: &v1.EnvVarSource{
+										SecretKeyRef: &v1.SecretKeySelector{
+											LocalObjectReference: v1.LocalObjectReference{Name: "sts-client"},
+											Key:                  "client_secret",
+										},
+									},
+								},
+							},
+							Resources: agentResourceRequirement,
+							ReadinessProbe: &v1.Probe{
+								ProbeHandler: v1.ProbeHandler{
+									HTTPGet: &v1.HTTPGetAction{
+										HTTPHeaders: []v1.HTTPHeader{
+											{
+												Name:  "K-Network-Probe",
+												Value: "queue",
+											},
+										},
+										Port:   intstr.FromInt(9081),
+										Path:   "/",
+										Scheme: "HTTP",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"AddMiddlewareWithTokenReview": {
+			original: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment",
+					Namespace: "default",
+					Labels:    map[string]string{constants.InferenceServiceLabel: "sklearn"},
+					Annotations: map[string]string{
+						constants.AgentMiddlewareInternalAnnotationKey: `{"tokenReview":{"unauthenticatedPaths":[{"prefix":"/v2/health"}]}}`,
+					},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name: "sklearn",
+						},
+						{
+							Name: "queue-proxy",
+							Env:  []v1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
+						},
+					},
+				},
+			},
+			expected: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "deployment",
+					Labels: map[string]string{constants.InferenceServiceLabel: "sklearn"},
+					Annotations: map[string]string{
+						constants.AgentMiddlewareInternalAnnotationKey: `{"tokenReview":{"unauthenticatedPaths":[{"prefix":"/v2/health"}]}}`,
+					},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name: "sklearn",
+						},
+						{
+							Name: "queue-proxy",
+							Env:  []v1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
+						},
+						{
+							Name:  constants.AgentContainerName,
+							Image: loggerConfig.Image,
+							Args: []string{
+								MiddlewareArgument,
+								`{"tokenReview":{"unauthenticatedPaths":[{"prefix":"/v2/health"}]}}`,
+								LoggerArgumentInferenceService,
+								"sklearn",
+								LoggerArgumentNamespace,
+								"default",
+								AgentArgumentMetricsPort,
+								"9089",
+							},
+							Ports: []v1.ContainerPort{
+								{
+									Name:          "agent-port",
+									ContainerPort: constants.InferenceServiceDefaultAgentPort,
+									Protocol:      "TCP",
+								},
+								{
+									Name:          constants.AgentMetricsPortName,
+									ContainerPort: constants.InferenceServiceAgentMetricsPort,
+									Protocol:      "TCP",
+								},
+							},
+							Env: []v1.EnvVar{
+								{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"},
+							},
+							Resources: agentResourceRequirement,
+							ReadinessProbe: &v1.Probe{
+								ProbeHandler: v1.ProbeHandler{
+									HTTPGet: &v1.HTTPGetAction{
+										HTTPHeaders: []v1.HTTPHeader{
+											{
+												Name:  "K-Network-Probe",
+												Value: "queue",
+											},
+										},
+										Port:   intstr.FromInt(9081),
+										Path:   "/",
+										Scheme: "HTTP",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"AddMiddlewareWithTokenUsage": {
+			original: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment",
+					Namespace: "default",
+					Annotations: map[string]string{
+						constants.AgentMiddlewareInternalAnnotationKey: `{"tokenUsage":{"headers":true}}`,
+					},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name: "sklearn",
+						},
+						{
+							Name: "queue-proxy",
+							Env:  []v1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
+						},
+					},
+				},
+			},
+			expected: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "deployment",
+					Annotations: map[string]string{
+						constants.AgentMiddlewareInternalAnnotationKey: `{"tokenUsage":{"headers":true}}`,
+					},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name: "sklearn",
+						},
+						{
+							Name: "queue-proxy",
+							Env:  []v1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
+						},
+						{
+							Name:  constants.AgentContainerName,
+							Image: loggerConfig.Image,
+							Args: []string{
+								MiddlewareArgument,
+								`{"tokenUsage":{"headers":true}}`,
+								AgentArgumentMetricsPort,
+								"9089",
+							},
 							Ports: []v1.ContainerPort{
 								{
 									Name:          "agent-port",
 									ContainerPort: constants.InferenceServiceDefaultAgentPort,
 									Protocol:      "TCP",
 								},
+								{
+									Name:          constants.AgentMetricsPortName,
+									ContainerPort: constants.InferenceServiceAgentMetricsPort,
+									Protocol:      "TCP",
+								},
 							},
-							Env: []v1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
+							Env: []v1.EnvVar{
+								{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"},
+							},
+							Resources: agentResourceRequirement,
 							ReadinessProbe: &v1.Probe{
 								ProbeHandler: v1.ProbeHandler{
 									HTTPGet: &v1.HTTPGetAction{
@@ -184,19 +781,89 @@ func TestAgentInjector(t *testing.T) {
 							},
 						},
 					},
-					Volumes: []v1.Volume{
+				},
+			},
+		},
+		"AddMiddlewareWithSidecarLogging": {
+			original: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment",
+					Namespace: "default",
+					Annotations: map[string]string{
+						constants.AgentMiddlewareInternalAnnotationKey: `{"requestHeaders":{"set":{"X-Tenant":"team-a"}}}`,
+						constants.SidecarLoggingInternalAnnotationKey:  `{"level":"debug","format":"json"}`,
+					},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
 						{
-							Name: "model-dir",
-							VolumeSource: v1.VolumeSource{
-								EmptyDir: &v1.EmptyDirVolumeSource{},
-							},
+							Name: "sklearn",
 						},
 						{
-							Name: "model-config",
-							VolumeSource: v1.VolumeSource{
-								ConfigMap: &v1.ConfigMapVolumeSource{
-									LocalObjectReference: v1.LocalObjectReference{
-										Name: "modelconfig-deployment-0",
+							Name: "queue-proxy",
+							Env:  []v1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
+						},
+					},
+				},
+			},
+			expected: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "deployment",
+					Annotations: map[string]string{
+						constants.AgentMiddlewareInternalAnnotationKey: `{"requestHeaders":{"set":{"X-Tenant":"team-a"}}}`,
+						constants.SidecarLoggingInternalAnnotationKey:  `{"level":"debug","format":"json"}`,
+					},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name: "sklearn",
+						},
+						{
+							Name: "queue-proxy",
+							Env:  []v1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
+						},
+						{
+							Name:  constants.AgentContainerName,
+							Image: loggerConfig.Image,
+							Args: []string{
+								MiddlewareArgument,
+								`{"requestHeaders":{"set":{"X-Tenant":"team-a"}}}`,
+								AgentArgumentMetricsPort,
+								"9089",
+								AgentArgumentLogLevel,
+								"debug",
+								AgentArgumentLogFormat,
+								"json",
+							},
+							Ports: []v1.ContainerPort{
+								{
+									Name:          "agent-port",
+									ContainerPort: constants.InferenceServiceDefaultAgentPort,
+									Protocol:      "TCP",
+								},
+								{
+									Name:          constants.AgentMetricsPortName,
+									ContainerPort: constants.InferenceServiceAgentMetricsPort,
+									Protocol:      "TCP",
+								},
+							},
+							Env: []v1.EnvVar{
+								{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"},
+							},
+							Resources: agentResourceRequirement,
+							ReadinessProbe: &v1.Probe{
+								ProbeHandler: v1.ProbeHandler{
+									HTTPGet: &v1.HTTPGetAction{
+										HTTPHeaders: []v1.HTTPHeader{
+											{
+												Name:  "K-Network-Probe",
+												Value: "queue",
+											},
+										},
+										Port:   intstr.FromInt(9081),
+										Path:   "/",
+										Scheme: "HTTP",
 									},
 								},
 							},
@@ -205,63 +872,105 @@ func TestAgentInjector(t *testing.T) {
 				},
 			},
 		},
-		"DoNotAddAgent": {
+		"AddCORS": {
 			original: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
-					Name: "deployment",
+					Name:      "deployment",
+					Namespace: "default",
+					Annotations: map[string]string{
+						constants.CORSInternalAnnotationKey: `{"allowOrigins":["https://app.example.com"],"allowHeaders":["Content-Type"]}`,
+					},
 				},
 				Spec: v1.PodSpec{
-					Containers: []v1.Container{{
-						Name: "sklearn",
-					}},
+					Containers: []v1.Container{
+						{
+							Name: "sklearn",
+						},
+						{
+							Name: "queue-proxy",
+							Env:  []v1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
+						},
+					},
 				},
 			},
 			expected: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "deployment",
+					Annotations: map[string]string{
+						constants.CORSInternalAnnotationKey: `{"allowOrigins":["https://app.example.com"],"allowHeaders":["Content-Type"]}`,
+					},
 				},
 				Spec: v1.PodSpec{
-					Containers: []v1.Container{{
-						Name: "sklearn",
-					}},
+					Containers: []v1.Container{
+						{
+							Name: "sklearn",
+						},
+						{
+							Name: "queue-proxy",
+							Env:  []v1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
+						},
+						{
+							Name:  constants.AgentContainerName,
+							Image: loggerConfig.Image,
+							Args: []string{
+								CORSArgument,
+								`{"allowOrigins":["https://app.example.com"],"allowHeaders":["Content-Type"]}`,
+								AgentArgumentMetricsPort,
+								"9089",
+							},
+							Ports: []v1.ContainerPort{
+								{
+									Name:          "agent-port",
+									ContainerPort: constants.InferenceServiceDefaultAgentPort,
+									Protocol:      "TCP",
+								},
+								{
+									Name:          constants.AgentMetricsPortName,
+									ContainerPort: constants.InferenceServiceAgentMetricsPort,
+									Protocol:      "TCP",
+								},
+							},
+							Env: []v1.EnvVar{
+								{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"},
+							},
+							Resources: agentResourceRequirement,
+							ReadinessProbe: &v1.Probe{
+								ProbeHandler: v1.ProbeHandler{
+									HTTPGet: &v1.HTTPGetAction{
+										HTTPHeaders: []v1.HTTPHeader{
+											{
+												Name:  "K-Network-Probe",
+												Value: "queue",
+											},
+										},
+										Port:   intstr.FromInt(9081),
+										Path:   "/",
+										Scheme: "HTTP",
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
-		"AddLogger": {
+		"AddReplay": {
 			original: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "deployment",
 					Namespace: "default",
 					Annotations: map[string]string{
-						constants.LoggerInternalAnnotationKey:        "true",
-						constants.LoggerSinkUrlInternalAnnotationKey: "http://httpbin.org/",
-						constants.LoggerModeInternalAnnotationKey:    string(v1beta1.LogAll),
+						constants.RequestReplaySecretAnnotationKey:     "replay-token",
+						constants.RequestReplayBufferSizeAnnotationKey: "20",
 					},
 					Labels: map[string]string{
 						"serving.kserve.io/inferenceservice": "sklearn",
-						constants.KServiceModelLabel:         "sklearn",
-						constants.KServiceEndpointLabel:      "default",
-						constants.KServiceComponentLabel:     "predictor",
 					},
 				},
 				Spec: v1.PodSpec{
 					Containers: []v1.Container{
 						{
 							Name: "sklearn",
-							ReadinessProbe: &v1.Probe{
-								ProbeHandler: v1.ProbeHandler{
-									TCPSocket: &v1.TCPSocketAction{
-										Port: intstr.IntOrString{
-											IntVal: 8080,
-										},
-									},
-								},
-								InitialDelaySeconds: 0,
-								TimeoutSeconds:      1,
-								PeriodSeconds:       10,
-								SuccessThreshold:    1,
-								FailureThreshold:    3,
-							},
 						},
 						{
 							Name: "queue-proxy",
@@ -274,29 +983,14 @@ func TestAgentInjector(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "deployment",
 					Annotations: map[string]string{
-						constants.LoggerInternalAnnotationKey:        "true",
-						constants.LoggerSinkUrlInternalAnnotationKey: "http://httpbin.org/",
-						constants.LoggerModeInternalAnnotationKey:    string(v1beta1.LogAll),
+						constants.RequestReplaySecretAnnotationKey:     "replay-token",
+						constants.RequestReplayBufferSizeAnnotationKey: "20",
 					},
 				},
 				Spec: v1.PodSpec{
 					Containers: []v1.Container{
 						{
 							Name: "sklearn",
-							ReadinessProbe: &v1.Probe{
-								ProbeHandler: v1.ProbeHandler{
-									TCPSocket: &v1.TCPSocketAction{
-										Port: intstr.IntOrString{
-											IntVal: 8080,
-										},
-									},
-								},
-								InitialDelaySeconds: 0,
-								TimeoutSeconds:      1,
-								PeriodSeconds:       10,
-								SuccessThreshold:    1,
-								FailureThreshold:    3,
-							},
 						},
 						{
 							Name: "queue-proxy",
@@ -306,20 +1000,17 @@ func TestAgentInjector(t *testing.T) {
 							Name:  constants.AgentContainerName,
 							Image: loggerConfig.Image,
 							Args: []string{
-								LoggerArgumentLogUrl,
-								"http://httpbin.org/",
-								LoggerArgumentSourceUri,
-								"deployment",
-								LoggerArgumentMode,
-								"all",
+								AgentArgumentEnableReplay,
+								AgentArgumentReplayTokenFile,
+								"/var/run/secrets/kserve/replay/token",
+								AgentArgumentReplayBufferSize,
+								"20",
 								LoggerArgumentInferenceService,
 								"sklearn",
 								LoggerArgumentNamespace,
 								"default",
-								LoggerArgumentEndpoint,
-								"default",
-								LoggerArgumentComponent,
-								"predictor",
+								AgentArgumentMetricsPort,
+								"9089",
 							},
 							Ports: []v1.ContainerPort{
 								{
@@ -327,8 +1018,15 @@ func TestAgentInjector(t *testing.T) {
 									ContainerPort: constants.InferenceServiceDefaultAgentPort,
 									Protocol:      "TCP",
 								},
+								{
+									Name:          constants.AgentMetricsPortName,
+									ContainerPort: constants.InferenceServiceAgentMetricsPort,
+									Protocol:      "TCP",
+								},
+							},
+							Env: []v1.EnvVar{
+								{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"},
 							},
-							Env:       []v1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
 							Resources: agentResourceRequirement,
 							ReadinessProbe: &v1.Probe{
 								ProbeHandler: v1.ProbeHandler{
@@ -345,6 +1043,23 @@ func TestAgentInjector(t *testing.T) {
 									},
 								},
 							},
+							VolumeMounts: []v1.VolumeMount{
+								{
+									Name:      constants.ReplayTokenVolumeName,
+									MountPath: constants.ReplayTokenDir,
+								},
+							},
+						},
+					},
+					Volumes: []v1.Volume{
+						{
+							Name: constants.ReplayTokenVolumeName,
+							VolumeSource: v1.VolumeSource{
+								Secret: &v1.SecretVolumeSource{
+									SecretName: "replay-token",
+									Items:      []v1.KeyToPath{{Key: "token", Path: "token"}},
+								},
+							},
 						},
 					},
 				},
@@ -456,6 +1171,8 @@ func TestAgentInjector(t *testing.T) {
 								"30",
 								BatcherArgumentMaxLatency,
 								"100",
+								AgentArgumentMetricsPort,
+								"9089",
 							},
 							Ports: []v1.ContainerPort{
 								{
@@ -463,6 +1180,11 @@ func TestAgentInjector(t *testing.T) {
 									ContainerPort: constants.InferenceServiceDefaultAgentPort,
 									Protocol:      "TCP",
 								},
+								{
+									Name:          constants.AgentMetricsPortName,
+									ContainerPort: constants.InferenceServiceAgentMetricsPort,
+									Protocol:      "TCP",
+								},
 							},
 							Env:       []v1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
 							Resources: agentResourceRequirement,
@@ -790,6 +1512,8 @@ func TestAgentInjector(t *testing.T) {
 								"default",
 								LoggerArgumentComponent,
 								"predictor",
+								AgentArgumentMetricsPort,
+								"9089",
 								"--component-port",
 								constants.InferenceServiceDefaultHttpPort,
 							},
@@ -799,6 +1523,11 @@ func TestAgentInjector(t *testing.T) {
 									ContainerPort: constants.InferenceServiceDefaultAgentPort,
 									Protocol:      "TCP",
 								},
+								{
+									Name:          constants.AgentMetricsPortName,
+									ContainerPort: constants.InferenceServiceAgentMetricsPort,
+									Protocol:      "TCP",
+								},
 							},
 							Env:       []v1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
 							Resources: agentResourceRequirement,
@@ -919,6 +1648,8 @@ func TestAgentInjector(t *testing.T) {
 								"default",
 								LoggerArgumentComponent,
 								"predictor",
+								AgentArgumentMetricsPort,
+								"9089",
 								"--component-port",
 								constants.InferenceServiceDefaultHttpPort,
 							},
@@ -928,6 +1659,11 @@ func TestAgentInjector(t *testing.T) {
 									ContainerPort: constants.InferenceServiceDefaultAgentPort,
 									Protocol:      "TCP",
 								},
+								{
+									Name:          constants.AgentMetricsPortName,
+									ContainerPort: constants.InferenceServiceAgentMetricsPort,
+									Protocol:      "TCP",
+								},
 							},
 							Env: []v1.EnvVar{
 								{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"},
@@ -1053,13 +1789,18 @@ func TestAgentInjector(t *testing.T) {
 									MountPath: constants.ModelConfigDir,
 								},
 							},
-							Args: []string{"--enable-puller", "--config-dir", "/mnt/configs", "--model-dir", "/mnt/models", "--component-port", "80"},
+							Args: []string{"--enable-puller", "--config-dir", "/mnt/configs", "--model-dir", "/mnt/models", AgentArgumentMetricsPort, "9089", "--component-port", "80"},
 							Ports: []v1.ContainerPort{
 								{
 									Name:          "agent-port",
 									ContainerPort: constants.InferenceServiceDefaultAgentPort,
 									Protocol:      "TCP",
 								},
+								{
+									Name:          constants.AgentMetricsPortName,
+									ContainerPort: constants.InferenceServiceAgentMetricsPort,
+									Protocol:      "TCP",
+								},
 							},
 							Env: []v1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
 							ReadinessProbe: &v1.Probe{
@@ -1120,6 +1861,83 @@ func TestAgentInjector(t *testing.T) {
 	}
 }
 
+func TestInjectAgentSecurityContext(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	defer utils.SetSecurityContextBounds(nil)
+	utils.SetSecurityContextBounds(&utils.SecurityContextBounds{SELinuxTypes: []string{"container_logreader_t"}})
+	newPod := func(securityContext string) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "deployment",
+				Namespace: "default",
+				Annotations: map[string]string{
+					constants.AgentMiddlewareInternalAnnotationKey:      `{"requestHeaders":{"set":{"X-Tenant":"team-a"}}}`,
+					constants.AgentSecurityContextInternalAnnotationKey: securityContext,
+				},
+			},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{{
+					Name:            "sklearn",
+					SecurityContext: &v1.SecurityContext{RunAsNonRoot: proto.Bool(true)},
+				}},
+			},
+		}
+	}
+	injector := &AgentInjector{
+		credentials.NewCredentialBuilder(c, fakeclientset.NewSimpleClientset(), &v1.ConfigMap{Data: map[string]string{}}),
+		agentConfig,
+		loggerConfig,
+		batcherTestConfig,
+	}
+
+	pod := newPod(`{"seLinuxOptions":{"type":"container_logreader_t"}}`)
+	g.Expect(injector.InjectAgent(pod)).Should(gomega.Succeed())
+	g.Expect(pod.Spec.Containers).Should(gomega.HaveLen(2))
+	g.Expect(pod.Spec.Containers[1].SecurityContext).Should(gomega.Equal(&v1.SecurityContext{
+		RunAsNonRoot:   proto.Bool(true),
+		SELinuxOptions: &v1.SELinuxOptions{Type: "container_logreader_t"},
+	}))
+	// the model container keeps its security context
+	g.Expect(pod.Spec.Containers[0].SecurityContext.SELinuxOptions).Should(gomega.BeNil())
+
+	pod = newPod(`{"seLinuxOptions":{"type":"spc_t"}}`)
+	g.Expect(injector.InjectAgent(pod)).ShouldNot(gomega.Succeed())
+	g.Expect(pod.Spec.Containers).Should(gomega.HaveLen(1))
+}
+
+func TestInjectAgentMetricsPort(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "deployment",
+			Namespace: "default",
+			Annotations: map[string]string{
+				constants.LoggerInternalAnnotationKey:        "true",
+				constants.LoggerSinkUrlInternalAnnotationKey: "http://httpbin.org/",
+			},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "sklearn"}},
+		},
+	}
+	injector := &AgentInjector{
+		credentials.NewCredentialBuilder(c, fakeclientset.NewSimpleClientset(), &v1.ConfigMap{Data: map[string]string{}}),
+		agentConfig,
+		loggerConfig,
+		batcherTestConfig,
+	}
+
+	// the agent of a logger only InferenceService serves its metrics
+	g.Expect(injector.InjectAgent(pod)).Should(gomega.Succeed())
+	g.Expect(pod.Spec.Containers).Should(gomega.HaveLen(2))
+	g.Expect(pod.Spec.Containers[1].Args).Should(gomega.ContainElements(AgentArgumentMetricsPort, "9089"))
+	g.Expect(pod.Spec.Containers[1].Ports).Should(gomega.ContainElement(v1.ContainerPort{
+		Name:          constants.AgentMetricsPortName,
+		ContainerPort: constants.InferenceServiceAgentMetricsPort,
+		Protocol:      "TCP",
+	}))
+}
+
 func TestGetLoggerConfigs(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	cases := []struct {
@@ -0,0 +1,41 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/podconfig"
+)
+
+// FIPSImageInjector selects the FIPS validated images of the containers injected by KServe
+// +kubebuilder:object:generate=false
+type FIPSImageInjector struct {
+	config *v1beta1.SecurityConfig
+}
+
+// InjectFIPSImages replaces the storage-initializer and agent images by their FIPS validated variants in FIPS mode.
+func (f *FIPSImageInjector) InjectFIPSImages(pod *v1.Pod) error {
+	if !f.config.FIPSMode {
+		return nil
+	}
+	for _, container := range podconfig.SystemContainers(pod) {
+		container.Image = f.config.GetImage(container.Image)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestInjectFIPSImages(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	fipsImages := map[string]string{
+		"kserve/agent:latest":               "kserve/agent-fips:latest",
+		"kserve/storage-initializer:latest": "kserve/storage-initializer-fips:latest",
+		"user/model:latest":                 "user/model-fips:latest",
+	}
+	scenarios := map[string]struct {
+		fipsMode bool
+		expected []string
+	}{
+		"FIPSModeDisabled": {
+			fipsMode: false,
+			expected: []string{"kserve/storage-initializer:latest", "user/model:latest", "kserve/agent:latest"},
+		},
+		"FIPSModeEnabled": {
+			fipsMode: true,
+			expected: []string{"kserve/storage-initializer-fips:latest", "user/model:latest", "kserve/agent-fips:latest"},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			pod := &v1.Pod{
+				Spec: v1.PodSpec{
+					InitContainers: []v1.Container{{Name: StorageInitializerContainerName, Image: "kserve/storage-initializer:latest"}},
+					Containers: []v1.Container{
+						{Name: constants.InferenceServiceContainerName, Image: "user/model:latest"},
+						{Name: constants.AgentContainerName, Image: "kserve/agent:latest"},
+					},
+				},
+			}
+			injector := &FIPSImageInjector{config: &v1beta1.SecurityConfig{FIPSMode: scenario.fipsMode, FIPSImages: fipsImages}}
+			g.Expect(injector.InjectFIPSImages(pod)).Should(gomega.Succeed())
+			g.Expect([]string{pod.Spec.InitContainers[0].Image, pod.Spec.Containers[0].Image, pod.Spec.Containers[1].Image}).
+				Should(gomega.Equal(scenario.expected))
+		})
+	}
+}
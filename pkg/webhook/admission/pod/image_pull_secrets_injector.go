@@ -0,0 +1,45 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kserve/kserve/pkg/podconfig"
+)
+
+// ImagePullSecretsInjector merges the namespace default pull secrets into the pods
+// +kubebuilder:object:generate=false
+type ImagePullSecretsInjector struct {
+	clientset kubernetes.Interface
+	config    *podconfig.ImagePullSecretsConfig
+}
+
+// InjectImagePullSecrets adds the default pull secrets so that the images injected by KServe, like the
+// storage initializer, can be pulled from private registries without patching every InferenceService.
+func (i *ImagePullSecretsInjector) InjectImagePullSecrets(pod *v1.Pod) error {
+	if len(i.config.SecretNames) == 0 {
+		return nil
+	}
+	secrets, err := podconfig.GetDefaultImagePullSecrets(i.clientset, i.config, pod.Namespace, pod.Spec.ServiceAccountName)
+	if err != nil {
+		return err
+	}
+	pod.Spec.ImagePullSecrets = podconfig.MergeImagePullSecrets(pod.Spec.ImagePullSecrets, secrets...)
+	return nil
+}
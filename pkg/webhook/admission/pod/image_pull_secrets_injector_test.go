@@ -0,0 +1,72 @@
+/*
+Copyright 2023 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kserve/kserve/pkg/podconfig"
+)
+
+func TestInjectImagePullSecrets(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	clientset := fake.NewSimpleClientset(
+		&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "registry-secret", Namespace: "default"}},
+		&v1.ServiceAccount{
+			ObjectMeta:       metav1.ObjectMeta{Name: "default", Namespace: "default"},
+			ImagePullSecrets: []v1.LocalObjectReference{{Name: "sa-secret"}},
+		},
+	)
+
+	scenarios := map[string]struct {
+		config   *podconfig.ImagePullSecretsConfig
+		original []v1.LocalObjectReference
+		expected []v1.LocalObjectReference
+	}{
+		"NoSecretsConfigured": {
+			config:   &podconfig.ImagePullSecretsConfig{},
+			original: []v1.LocalObjectReference{{Name: "user-secret"}},
+			expected: []v1.LocalObjectReference{{Name: "user-secret"}},
+		},
+		"MergeServiceAccountAndNamespaceDefaults": {
+			config:   &podconfig.ImagePullSecretsConfig{SecretNames: []string{"registry-secret", "missing-secret"}},
+			original: []v1.LocalObjectReference{{Name: "user-secret"}},
+			expected: []v1.LocalObjectReference{{Name: "user-secret"}, {Name: "sa-secret"}, {Name: "registry-secret"}},
+		},
+		"NoDuplicates": {
+			config:   &podconfig.ImagePullSecretsConfig{SecretNames: []string{"registry-secret"}},
+			original: []v1.LocalObjectReference{{Name: "sa-secret"}, {Name: "registry-secret"}},
+			expected: []v1.LocalObjectReference{{Name: "sa-secret"}, {Name: "registry-secret"}},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			injector := &ImagePullSecretsInjector{clientset: clientset, config: scenario.config}
+			pod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default"},
+				Spec:       v1.PodSpec{ImagePullSecrets: scenario.original},
+			}
+			g.Expect(injector.InjectImagePullSecrets(pod)).Should(gomega.Succeed())
+			g.Expect(pod.Spec.ImagePullSecrets).Should(gomega.Equal(scenario.expected))
+		})
+	}
+}
@@ -27,8 +27,10 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/kserve/kserve/pkg/credentials"
+	"github.com/kserve/kserve/pkg/podconfig"
 )
 
 // +kubebuilder:webhook:path=/mutate-pods,mutating=true,failurePolicy=fail,groups="",resources=pods,verbs=create,versions=v1,name=inferenceservice.kserve-webhook-server.pod-mutator,reinvocationPolicy=IfNeeded
@@ -119,17 +121,48 @@ func (mutator *Mutator) mutate(pod *v1.Pod, configMap *v1.ConfigMap) error {
 		return err
 	}
 
+	imagePullSecretsConfig, err := podconfig.GetImagePullSecretsConfig(configMap)
+	if err != nil {
+		return err
+	}
+
+	imagePullSecretsInjector := &ImagePullSecretsInjector{
+		clientset: mutator.Clientset,
+		config:    imagePullSecretsConfig,
+	}
+
+	securityConfig, err := v1beta1.GetSecurityConfig(configMap)
+	if err != nil {
+		return err
+	}
+
+	fipsImageInjector := &FIPSImageInjector{config: securityConfig}
+
+	imagePolicyConfig, err := podconfig.GetImagePolicyConfig(configMap)
+	if err != nil {
+		return err
+	}
+
+	egressProxyConfig, err := podconfig.GetEgressProxyConfig(configMap)
+	if err != nil {
+		return err
+	}
+
 	mutators := []func(pod *v1.Pod) error{
 		InjectGKEAcceleratorSelector,
 		storageInitializer.InjectStorageInitializer,
 		storageInitializer.SetIstioCniSecurityContext,
 		agentInjector.InjectAgent,
 		metricsAggregator.InjectMetricsAggregator,
+		imagePullSecretsInjector.InjectImagePullSecrets,
 	}
 
 	if storageInitializer.config.EnableOciImageSource {
 		mutators = append(mutators, storageInitializer.InjectModelcar)
 	}
+	// The egress proxy, the FIPS images and the image policy are applied last so that they cover all the injected containers
+	mutators = append(mutators, egressProxyConfig.InjectEgressProxy, fipsImageInjector.InjectFIPSImages,
+		imagePolicyConfig.InjectImagePolicy)
 
 	for _, mutator := range mutators {
 		if err := mutator(pod); err != nil {
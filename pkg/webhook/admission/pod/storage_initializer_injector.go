@@ -18,16 +18,21 @@ package pod
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"path/filepath"
 	"strconv"
 	"strings"
 
+	apierr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/kserve/kserve/pkg/credentials"
 	"github.com/kserve/kserve/pkg/credentials/s3"
@@ -51,6 +56,21 @@ const (
 	ModelInitModeEnv                        = "MODEL_INIT_MODE"
 	CpuModelcarDefault                      = "10m"
 	MemoryModelcarDefault                   = "15Mi"
+	// DefaultDownloadSlotsPort is the port of the node the download slots coordinator DaemonSet listens on
+	DefaultDownloadSlotsPort = 9099
+	// Environment variables the storage initializer reads the download slots coordinator of the node from
+	MaxConcurrentDownloadsEnv = "MAX_CONCURRENT_DOWNLOADS"
+	DownloadSlotsHostEnv      = "DOWNLOAD_SLOTS_HOST"
+	DownloadSlotsPortEnv      = "DOWNLOAD_SLOTS_PORT"
+	// Environment variables the storage initializer reads its log level and format from
+	LogLevelEnv  = "KSERVE_LOGLEVEL"
+	LogFormatEnv = "KSERVE_LOG_FORMAT"
+	// The init container converting the model after the storage initializer and the cache of the converted artifacts
+	ModelConversionContainerName   = "model-conversion"
+	ModelConversionCacheVolumeName = "kserve-conversion-cache"
+	ModelConversionCacheMountPath  = "/mnt/conversion-cache"
+	ModelDirEnv                    = "MODEL_DIR"
+	ConversionCacheDirEnv          = "CONVERSION_CACHE_DIR"
 )
 
 type StorageInitializerConfig struct {
@@ -66,6 +86,11 @@ type StorageInitializerConfig struct {
 	EnableDirectPvcVolumeMount bool   `json:"enableDirectPvcVolumeMount"`
 	EnableOciImageSource       bool   `json:"enableModelcar"`
 	UidModelcar                *int64 `json:"uidModelcar"`
+	// MaxConcurrentDownloadsPerNode limits the storage initializers downloading a model at the same time on a node,
+	// the downloads are not limited when it is not set
+	MaxConcurrentDownloadsPerNode int `json:"maxConcurrentDownloadsPerNode"`
+	// DownloadSlotsPort is the port of the nodes the download slots coordinator DaemonSet listens on
+	DownloadSlotsPort int `json:"downloadSlotsPort"`
 }
 
 type StorageInitializerInjector struct {
@@ -79,7 +104,7 @@ func getStorageInitializerConfigs(configMap *v1.ConfigMap) (*StorageInitializerC
 	if initializerConfig, ok := configMap.Data[StorageInitializerConfigMapKeyName]; ok {
 		err := json.Unmarshal([]byte(initializerConfig), &storageInitializerConfig)
 		if err != nil {
-			panic(fmt.Errorf("Unable to unmarshall %v json string due to %w ", StorageInitializerConfigMapKeyName, err))
+			return storageInitializerConfig, fmt.Errorf("Unable to unmarshall %v json string due to %w ", StorageInitializerConfigMapKeyName, err)
 		}
 	}
 	// Ensure that we set proper values for CPU/Memory Limit/Request
@@ -100,6 +125,11 @@ func getStorageInitializerConfigs(configMap *v1.ConfigMap) (*StorageInitializerC
 func GetContainerSpecForStorageUri(storageUri string, client client.Client) (*v1.Container, error) {
 	storageContainers := &v1alpha1.ClusterStorageContainerList{}
 	if err := client.List(context.TODO(), storageContainers); err != nil {
+		// A namespace-scoped KServe instance may not be allowed to read the cluster-scoped storage containers,
+		// the default storage initializer is used then
+		if apierr.IsForbidden(err) {
+			return nil, nil
+		}
 		return nil, err
 	}
 
@@ -278,6 +308,9 @@ func (mi *StorageInitializerInjector) InjectStorageInitializer(pod *v1.Pod) erro
 
 				transformerContainer.VolumeMounts = append(transformerContainer.VolumeMounts, pvcSourceVolumeMount)
 			}
+			for _, container := range getInitContainersAfterStorageInitializer(pod) {
+				container.VolumeMounts = append(container.VolumeMounts, pvcSourceVolumeMount)
+			}
 			// change the CustomSpecStorageUri env variable value
 			// to the default model path if present
 			for index, envVar := range userContainer.Env {
@@ -307,6 +340,9 @@ func (mi *StorageInitializerInjector) InjectStorageInitializer(pod *v1.Pod) erro
 		if transformerContainer != nil {
 			transformerContainer.VolumeMounts = append(transformerContainer.VolumeMounts, pvcSourceVolumeMount)
 		}
+		for _, container := range getInitContainersAfterStorageInitializer(pod) {
+			container.VolumeMounts = append(container.VolumeMounts, pvcSourceVolumeMount)
+		}
 		// modify the sourceURI to point to the PVC path
 		srcURI = PvcSourceMountPath + "/" + pvcPath
 	}
@@ -367,6 +403,10 @@ func (mi *StorageInitializerInjector) InjectStorageInitializer(pod *v1.Pod) erro
 	if transformerContainer != nil {
 		transformerContainer.VolumeMounts = append(transformerContainer.VolumeMounts, sharedVolumeReadMount)
 	}
+	// The user init containers running after the storage initializer can read the model as well
+	for _, container := range getInitContainersAfterStorageInitializer(pod) {
+		container.VolumeMounts = append(container.VolumeMounts, sharedVolumeReadMount)
+	}
 	// Change the CustomSpecStorageUri env variable value to the default model path if present
 	for index, envVar := range userContainer.Env {
 		if envVar.Name == constants.CustomSpecStorageUriEnvVarKey && envVar.Value != "" {
@@ -465,6 +505,23 @@ func (mi *StorageInitializerInjector) InjectStorageInitializer(pod *v1.Pod) erro
 		initContainer.VolumeMounts = append(initContainer.VolumeMounts, caBundleVolumeMount)
 	}
 
+	if mi.config.MaxConcurrentDownloadsPerNode > 0 {
+		addDownloadSlots(initContainer, mi.config)
+	}
+
+	if value, ok := pod.ObjectMeta.Annotations[constants.SidecarLoggingInternalAnnotationKey]; ok {
+		logging := &v1beta1.SidecarLoggingSpec{}
+		if err := json.Unmarshal([]byte(value), logging); err != nil {
+			return fmt.Errorf("failed to parse the sidecar logging: %w", err)
+		}
+		if logging.Level != "" {
+			addOrReplaceEnv(initContainer, LogLevelEnv, string(logging.Level))
+		}
+		if logging.Format != "" {
+			addOrReplaceEnv(initContainer, LogFormatEnv, string(logging.Format))
+		}
+	}
+
 	// Update initContainer (container spec) from a storage container CR if there is a match,
 	// otherwise initContainer is not updated.
 	// Priority: CR > configMap
@@ -479,12 +536,125 @@ func (mi *StorageInitializerInjector) InjectStorageInitializer(pod *v1.Pod) erro
 		}
 	}
 
-	// Add init container to the spec
-	pod.Spec.InitContainers = append(pod.Spec.InitContainers, *initContainer)
+	initContainers := []v1.Container{*initContainer}
+	// The model conversion runs right after the storage initializer, on the model it downloaded
+	if value, ok := pod.ObjectMeta.Annotations[constants.ModelConversionInternalAnnotationKey]; ok {
+		conversion := &v1beta1.ModelConversionSpec{}
+		if err := json.Unmarshal([]byte(value), conversion); err != nil {
+			return fmt.Errorf("failed to parse the model conversion: %w", err)
+		}
+		sourceURI := pod.ObjectMeta.Annotations[constants.StorageInitializerSourceUriInternalAnnotationKey]
+		initContainers = append(initContainers, addModelConversion(pod, conversion, sourceURI, securityContext))
+	}
+
+	// Add init containers to the spec, before the user init containers which run after the storage initializer
+	pod.Spec.InitContainers = insertStorageInitializer(pod, initContainers...)
 
 	return nil
 }
 
+// addDownloadSlots points the storage initializer to the download slots coordinator of its node, which grants a slot
+// to a connection while fewer than the max downloads are running. The storage initializer holds the connection while
+// it downloads the model, so the slots of crashed or deleted pods are released when the kernel closes it. The pod
+// needs no host volume, the coordinator is reached on the IP of the node.
+func addDownloadSlots(initContainer *v1.Container, config *StorageInitializerConfig) {
+	port := config.DownloadSlotsPort
+	if port == 0 {
+		port = DefaultDownloadSlotsPort
+	}
+	initContainer.Env = append(initContainer.Env,
+		v1.EnvVar{Name: MaxConcurrentDownloadsEnv, Value: strconv.Itoa(config.MaxConcurrentDownloadsPerNode)},
+		v1.EnvVar{
+			Name: DownloadSlotsHostEnv,
+			ValueFrom: &v1.EnvVarSource{
+				FieldRef: &v1.ObjectFieldSelector{FieldPath: "status.hostIP"},
+			},
+		},
+		v1.EnvVar{Name: DownloadSlotsPortEnv, Value: strconv.Itoa(port)},
+	)
+}
+
+// addModelConversion returns the init container converting the model in place in the shared volume of the storage
+// initializer. When a cache claim is set it is mounted on a sub directory keyed by the storage uri and the conversion,
+// so the pods converting the same model with the same conversion share the artifacts.
+func addModelConversion(pod *v1.Pod, conversion *v1beta1.ModelConversionSpec, sourceURI string,
+	securityContext *v1.SecurityContext) v1.Container {
+	container := v1.Container{
+		Name:                     ModelConversionContainerName,
+		Image:                    conversion.Image,
+		Command:                  conversion.Command,
+		Args:                     conversion.Args,
+		Env:                      append(conversion.Env, v1.EnvVar{Name: ModelDirEnv, Value: constants.DefaultModelLocalMountPath}),
+		Resources:                conversion.Resources,
+		TerminationMessagePolicy: v1.TerminationMessageFallbackToLogsOnError,
+		VolumeMounts: []v1.VolumeMount{
+			{
+				Name:      StorageInitializerVolumeName,
+				MountPath: constants.DefaultModelLocalMountPath,
+				ReadOnly:  false,
+			},
+		},
+		SecurityContext: securityContext.DeepCopy(),
+	}
+	if conversion.CachePVCName != "" {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+			Name: ModelConversionCacheVolumeName,
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+					ClaimName: conversion.CachePVCName,
+				},
+			},
+		})
+		// The kubelet creates the sub directory of the key when it does not exist yet
+		parts := append([]string{sourceURI, conversion.Image}, conversion.Command...)
+		parts = append(parts, conversion.Args...)
+		key := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+		container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{
+			Name:      ModelConversionCacheVolumeName,
+			MountPath: ModelConversionCacheMountPath,
+			SubPath:   hex.EncodeToString(key[:])[:16],
+		})
+		container.Env = append(container.Env, v1.EnvVar{Name: ConversionCacheDirEnv, Value: ModelConversionCacheMountPath})
+	}
+	return container
+}
+
+// getInitContainersAfterStorageInitializer returns the user init containers listed in the
+// serving.kserve.io/init-containers-after-storage-initializer annotation
+func getInitContainersAfterStorageInitializer(pod *v1.Pod) []*v1.Container {
+	names := sets.NewString()
+	for _, name := range strings.Split(pod.ObjectMeta.Annotations[constants.InitContainersAfterStorageInitializerAnnotationKey], ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names.Insert(name)
+		}
+	}
+	containers := []*v1.Container{}
+	for idx, container := range pod.Spec.InitContainers {
+		if names.Has(container.Name) {
+			containers = append(containers, &pod.Spec.InitContainers[idx])
+		}
+	}
+	return containers
+}
+
+// insertStorageInitializer returns the init containers of the pod with the storage initializer, followed by the
+// model conversion if any, inserted before the first user init container which runs after the storage initializer,
+// or appended when there is none. The order of the user init containers is preserved.
+func insertStorageInitializer(pod *v1.Pod, storageInitializer ...v1.Container) []v1.Container {
+	after := getInitContainersAfterStorageInitializer(pod)
+	if len(after) == 0 {
+		return append(pod.Spec.InitContainers, storageInitializer...)
+	}
+	initContainers := make([]v1.Container, 0, len(pod.Spec.InitContainers)+len(storageInitializer))
+	for _, container := range pod.Spec.InitContainers {
+		if container.Name == after[0].Name {
+			initContainers = append(initContainers, storageInitializer...)
+		}
+		initContainers = append(initContainers, container)
+	}
+	return initContainers
+}
+
 // SetIstioCniSecurityContext determines if Istio is installed in using the CNI plugin. If so,
 // the UserID of the storage initializer is changed to match the UserID of the Istio sidecar.
 // This is to ensure that the storage initializer can access the network.
@@ -19,6 +19,7 @@ package pod
 import (
 	"context"
 	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -26,12 +27,17 @@ import (
 	"github.com/onsi/gomega/types"
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/kmp"
 	"knative.dev/pkg/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/kserve/kserve/pkg/credentials"
 	"github.com/kserve/kserve/pkg/credentials/gcs"
@@ -1128,6 +1134,18 @@ func TestGetStorageInitializerConfigs(t *testing.T) {
 				gomega.HaveOccurred(),
 			},
 		},
+		{
+			name: "Invalid Json",
+			configMap: &v1.ConfigMap{
+				Data: map[string]string{
+					StorageInitializerConfigMapKeyName: `{"Image": "gcr.io/kserve/storage-initializer:latest",`,
+				},
+			},
+			matchers: []types.GomegaMatcher{
+				gomega.Equal(&StorageInitializerConfig{}),
+				gomega.HaveOccurred(),
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -2400,6 +2418,19 @@ func TestGetStorageContainerSpec(t *testing.T) {
 	}
 }
 
+func TestGetStorageContainerSpecForbidden(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	forbidden := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		List: func(_ context.Context, _ client.WithWatch, _ client.ObjectList, _ ...client.ListOption) error {
+			return apierr.NewForbidden(v1alpha1.Resource("clusterstoragecontainers"), "", nil)
+		},
+	}).Build()
+	// the default storage initializer is used when the storage containers cannot be read
+	container, err := GetContainerSpecForStorageUri("s3://foo", forbidden)
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(container).Should(gomega.BeNil())
+}
+
 func TestStorageContainerCRDInjection(t *testing.T) {
 	customSpec := v1alpha1.ClusterStorageContainer{
 		ObjectMeta: metav1.ObjectMeta{
@@ -3678,3 +3709,221 @@ func TestStorageInitializerUIDForIstioCNI(t *testing.T) {
 		}
 	}
 }
+
+func TestInitContainersAfterStorageInitializer(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		annotation        string
+		expectedOrder     []string
+		expectedModelRead []string
+	}{
+		"NoAnnotation": {
+			expectedOrder: []string{"db-warmer", "tokenizer", StorageInitializerContainerName},
+		},
+		"TokenizerAfterStorageInitializer": {
+			annotation:        "tokenizer",
+			expectedOrder:     []string{"db-warmer", StorageInitializerContainerName, "tokenizer"},
+			expectedModelRead: []string{"tokenizer"},
+		},
+		"AllAfterStorageInitializer": {
+			annotation:        "tokenizer, db-warmer",
+			expectedOrder:     []string{StorageInitializerContainerName, "db-warmer", "tokenizer"},
+			expectedModelRead: []string{"db-warmer", "tokenizer"},
+		},
+		"UnknownContainer": {
+			annotation:    "unknown",
+			expectedOrder: []string{"db-warmer", "tokenizer", StorageInitializerContainerName},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			pod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						constants.StorageInitializerSourceUriInternalAnnotationKey: "gs://foo",
+					},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name: constants.InferenceServiceContainerName,
+						},
+					},
+					InitContainers: []v1.Container{
+						{
+							Name: "db-warmer",
+						},
+						{
+							Name: "tokenizer",
+						},
+					},
+				},
+			}
+			if scenario.annotation != "" {
+				pod.Annotations[constants.InitContainersAfterStorageInitializerAnnotationKey] = scenario.annotation
+			}
+			injector := &StorageInitializerInjector{
+				credentialBuilder: credentials.NewCredentialBuilder(c, clientset, &v1.ConfigMap{
+					Data: map[string]string{},
+				}),
+				config: storageInitializerConfig,
+				client: c,
+			}
+			g.Expect(injector.InjectStorageInitializer(pod)).To(gomega.Succeed())
+
+			var order, modelRead []string
+			for _, container := range pod.Spec.InitContainers {
+				order = append(order, container.Name)
+				for _, volumeMount := range container.VolumeMounts {
+					if container.Name != StorageInitializerContainerName && volumeMount.Name == StorageInitializerVolumeName {
+						g.Expect(volumeMount.ReadOnly).To(gomega.BeTrue())
+						modelRead = append(modelRead, container.Name)
+					}
+				}
+			}
+			g.Expect(order).To(gomega.Equal(scenario.expectedOrder))
+			g.Expect(modelRead).To(gomega.Equal(scenario.expectedModelRead))
+
+			// the injection is idempotent
+			g.Expect(injector.InjectStorageInitializer(pod)).To(gomega.Succeed())
+			g.Expect(pod.Spec.InitContainers).To(gomega.HaveLen(3))
+		})
+	}
+}
+
+func TestAddDownloadSlots(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		config       *StorageInitializerConfig
+		expectedPort string
+	}{
+		"DefaultPort": {
+			config:       &StorageInitializerConfig{MaxConcurrentDownloadsPerNode: 2},
+			expectedPort: "9099",
+		},
+		"CustomPort": {
+			config:       &StorageInitializerConfig{MaxConcurrentDownloadsPerNode: 1, DownloadSlotsPort: 19099},
+			expectedPort: "19099",
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			initContainer := &v1.Container{Name: StorageInitializerContainerName}
+			addDownloadSlots(initContainer, scenario.config)
+
+			g.Expect(initContainer.VolumeMounts).To(gomega.BeEmpty())
+			g.Expect(initContainer.Env).To(gomega.ConsistOf(
+				v1.EnvVar{Name: MaxConcurrentDownloadsEnv, Value: strconv.Itoa(scenario.config.MaxConcurrentDownloadsPerNode)},
+				v1.EnvVar{Name: DownloadSlotsHostEnv, ValueFrom: &v1.EnvVarSource{
+					FieldRef: &v1.ObjectFieldSelector{FieldPath: "status.hostIP"},
+				}},
+				v1.EnvVar{Name: DownloadSlotsPortEnv, Value: scenario.expectedPort},
+			))
+		})
+	}
+}
+
+func TestAddModelConversion(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	conversion := &v1beta1.ModelConversionSpec{
+		Image:        "converter:latest",
+		Args:         []string{"--to", "safetensors"},
+		CachePVCName: "conversion-cache",
+	}
+
+	pod := &v1.Pod{}
+	container := addModelConversion(pod, conversion, "s3://models/llm", nil)
+	g.Expect(container.Name).To(gomega.Equal(ModelConversionContainerName))
+	g.Expect(container.Image).To(gomega.Equal("converter:latest"))
+	g.Expect(container.Env).To(gomega.ConsistOf(
+		v1.EnvVar{Name: ModelDirEnv, Value: constants.DefaultModelLocalMountPath},
+		v1.EnvVar{Name: ConversionCacheDirEnv, Value: ModelConversionCacheMountPath},
+	))
+	g.Expect(container.VolumeMounts).To(gomega.HaveLen(2))
+	g.Expect(container.VolumeMounts[0]).To(gomega.Equal(v1.VolumeMount{
+		Name:      StorageInitializerVolumeName,
+		MountPath: constants.DefaultModelLocalMountPath,
+	}))
+	g.Expect(container.VolumeMounts[1].Name).To(gomega.Equal(ModelConversionCacheVolumeName))
+	g.Expect(container.VolumeMounts[1].SubPath).To(gomega.HaveLen(16))
+	g.Expect(pod.Spec.Volumes).To(gomega.Equal([]v1.Volume{{
+		Name: ModelConversionCacheVolumeName,
+		VolumeSource: v1.VolumeSource{
+			PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "conversion-cache"},
+		},
+	}}))
+
+	// The same model converted the same way shares the cache, another model does not
+	same := addModelConversion(&v1.Pod{}, conversion, "s3://models/llm", nil)
+	g.Expect(same.VolumeMounts[1].SubPath).To(gomega.Equal(container.VolumeMounts[1].SubPath))
+	other := addModelConversion(&v1.Pod{}, conversion, "s3://models/other", nil)
+	g.Expect(other.VolumeMounts[1].SubPath).NotTo(gomega.Equal(container.VolumeMounts[1].SubPath))
+
+	// Without a cache only the model volume is mounted
+	pod = &v1.Pod{}
+	container = addModelConversion(pod, &v1beta1.ModelConversionSpec{Image: "converter:latest"}, "s3://models/llm", nil)
+	g.Expect(container.VolumeMounts).To(gomega.HaveLen(1))
+	g.Expect(pod.Spec.Volumes).To(gomega.BeEmpty())
+}
+
+func TestInjectModelConversion(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				constants.StorageInitializerSourceUriInternalAnnotationKey:   "gs://foo",
+				constants.ModelConversionInternalAnnotationKey:               `{"image":"converter:latest"}`,
+				constants.InitContainersAfterStorageInitializerAnnotationKey: "warmup",
+			},
+		},
+		Spec: v1.PodSpec{
+			InitContainers: []v1.Container{{Name: "warmup"}},
+			Containers:     []v1.Container{{Name: constants.InferenceServiceContainerName}},
+		},
+	}
+	injector := &StorageInitializerInjector{
+		credentialBuilder: credentials.NewCredentialBuilder(c, clientset, &v1.ConfigMap{
+			Data: map[string]string{},
+		}),
+		config: storageInitializerConfig,
+		client: c,
+	}
+	g.Expect(injector.InjectStorageInitializer(pod)).To(gomega.Succeed())
+
+	names := []string{}
+	for _, container := range pod.Spec.InitContainers {
+		names = append(names, container.Name)
+	}
+	g.Expect(names).To(gomega.Equal([]string{StorageInitializerContainerName, ModelConversionContainerName, "warmup"}))
+}
+
+func TestInjectSidecarLogging(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				constants.StorageInitializerSourceUriInternalAnnotationKey: "gs://foo",
+				constants.SidecarLoggingInternalAnnotationKey:              `{"level":"warn","format":"json"}`,
+			},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: constants.InferenceServiceContainerName}},
+		},
+	}
+	injector := &StorageInitializerInjector{
+		credentialBuilder: credentials.NewCredentialBuilder(c, clientset, &v1.ConfigMap{
+			Data: map[string]string{},
+		}),
+		config: storageInitializerConfig,
+		client: c,
+	}
+	g.Expect(injector.InjectStorageInitializer(pod)).To(gomega.Succeed())
+
+	g.Expect(pod.Spec.InitContainers).To(gomega.HaveLen(1))
+	g.Expect(pod.Spec.InitContainers[0].Env).To(gomega.ContainElements(
+		v1.EnvVar{Name: LogLevelEnv, Value: "warn"},
+		v1.EnvVar{Name: LogFormatEnv, Value: "json"},
+	))
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// ParseNamespaces parses the comma separated allow-list of namespaces of the --watch-namespaces flag
+func ParseNamespaces(value string) []string {
+	var namespaces []string
+	for _, namespace := range strings.Split(value, ",") {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return namespaces
+}
+
+// NamespaceScopedHandler restricts an admission handler to the namespaces managed by this KServe instance, the
+// requests of the other namespaces are admitted unchanged so that the KServe instances of the other tenants handle
+// them. All the namespaces are managed when the allow-list is empty.
+type NamespaceScopedHandler struct {
+	Namespaces sets.Set[string]
+	Handler    admission.Handler
+}
+
+// NewNamespaceScopedHandler wraps the handler with the allow-list of namespaces
+func NewNamespaceScopedHandler(namespaces []string, handler admission.Handler) admission.Handler {
+	if len(namespaces) == 0 {
+		return handler
+	}
+	return &NamespaceScopedHandler{Namespaces: sets.New(namespaces...), Handler: handler}
+}
+
+func (h *NamespaceScopedHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if !h.Namespaces.Has(req.Namespace) {
+		return admission.Allowed("namespace " + req.Namespace + " is not managed by this KServe instance")
+	}
+	return h.Handler.Handle(ctx, req)
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestParseNamespaces(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	g.Expect(ParseNamespaces("")).Should(gomega.BeEmpty())
+	g.Expect(ParseNamespaces("team-a, team-b,,")).Should(gomega.Equal([]string{"team-a", "team-b"}))
+}
+
+func TestNamespaceScopedHandler(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	denied := admission.HandlerFunc(func(ctx context.Context, req admission.Request) admission.Response {
+		return admission.Denied("denied")
+	})
+	request := func(namespace string) admission.Request {
+		return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Namespace: namespace}}
+	}
+
+	// all the namespaces are managed without an allow-list
+	handler := NewNamespaceScopedHandler(nil, denied)
+	g.Expect(handler.Handle(context.TODO(), request("team-c")).Allowed).Should(gomega.BeFalse())
+
+	handler = NewNamespaceScopedHandler([]string{"team-a", "team-b"}, denied)
+	g.Expect(handler.Handle(context.TODO(), request("team-a")).Allowed).Should(gomega.BeFalse())
+	response := handler.Handle(context.TODO(), request("team-c"))
+	g.Expect(response.Allowed).Should(gomega.BeTrue())
+	g.Expect(response.Patches).Should(gomega.BeEmpty())
+}
@@ -0,0 +1,318 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certs
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// DefaultCertName, DefaultKeyName and DefaultCAName are the keys of the webhook serving cert Secret written by
+	// cert-manager
+	DefaultCertName = "tls.crt"
+	DefaultKeyName  = "tls.key"
+	DefaultCAName   = "ca.crt"
+	// ServiceCAConfigMapKey is the key of the CA bundle in the ConfigMaps injected by the OpenShift service-ca
+	// operator
+	ServiceCAConfigMapKey = "service-ca.crt"
+	// DefaultResyncPeriod is how often the cert dir is checked for a rotated certificate
+	DefaultResyncPeriod = 10 * time.Second
+)
+
+// Rotator hot-reloads the serving certificate of the webhook server when it is rotated by cert-manager or the
+// service-ca operator, and patches the CA bundle of the webhook configurations and of the conversion webhooks of the
+// CRDs pointing to the webhook service, so that the rotation does not cause a window of admission failures.
+// The cert dir is polled rather than watched with inotify since the Secret volumes are updated by swapping symlinks.
+type Rotator struct {
+	// CertDir is the directory the webhook serving cert Secret is mounted to
+	CertDir string
+	// ServiceName and ServiceNamespace identify the webhook service of the configurations to patch
+	ServiceName      string
+	ServiceNamespace string
+	// CRDs are the names of the CRDs whose conversion webhook is served by the webhook service
+	CRDs []string
+	// CABundleConfigMap is the ConfigMap of the service namespace the CA bundle is read from when the cert dir has
+	// no ca.crt, e.g. the ConfigMap injected by the service-ca operator
+	CABundleConfigMap string
+	ResyncPeriod      time.Duration
+
+	Clientset              kubernetes.Interface
+	APIExtensionsClientset apiextensionsclientset.Interface
+	Log                    logr.Logger
+
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+	certPEM  []byte
+	keyPEM   []byte
+	caBundle []byte
+}
+
+// ConfigureTLS makes the webhook server serve the certificate of the rotator, it is passed to the TLSOpts of the
+// webhook server options
+func (r *Rotator) ConfigureTLS(config *tls.Config) {
+	config.GetCertificate = r.GetCertificate
+}
+
+// GetCertificate returns the current serving certificate
+func (r *Rotator) GetCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.cert == nil {
+		return nil, fmt.Errorf("webhook serving certificate is not loaded from %s", r.CertDir)
+	}
+	return r.cert, nil
+}
+
+// Start loads the certificate and then checks the cert dir for rotations every resync period until the context is
+// done. The CA bundles are synced at each check so that the configurations reset by a redeploy are patched again.
+func (r *Rotator) Start(ctx context.Context) error {
+	if _, err := r.reloadCertificate(); err != nil {
+		return err
+	}
+	r.syncCABundles(ctx)
+	period := r.ResyncPeriod
+	if period <= 0 {
+		period = DefaultResyncPeriod
+	}
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if rotated, err := r.reloadCertificate(); err != nil {
+				// the previous certificate is served until the new one can be loaded, e.g. while the files are
+				// being swapped
+				r.Log.Error(err, "unable to reload the webhook serving certificate")
+			} else if rotated {
+				r.Log.Info("Reloaded the rotated webhook serving certificate", "certDir", r.CertDir)
+			}
+			r.syncCABundles(ctx)
+		}
+	}
+}
+
+// NeedLeaderElection returns false since every replica serves the webhooks with its own copy of the certificate
+func (r *Rotator) NeedLeaderElection() bool {
+	return false
+}
+
+// reloadCertificate loads the key pair when it changed and reports whether it did
+func (r *Rotator) reloadCertificate() (bool, error) {
+	certPEM, err := os.ReadFile(filepath.Join(r.CertDir, DefaultCertName))
+	if err != nil {
+		return false, err
+	}
+	keyPEM, err := os.ReadFile(filepath.Join(r.CertDir, DefaultKeyName))
+	if err != nil {
+		return false, err
+	}
+	r.mu.RLock()
+	unchanged := r.cert != nil && bytes.Equal(certPEM, r.certPEM) && bytes.Equal(keyPEM, r.keyPEM)
+	r.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return false, fmt.Errorf("invalid webhook serving certificate in %s: %w", r.CertDir, err)
+	}
+	r.mu.Lock()
+	r.cert, r.certPEM, r.keyPEM = &cert, certPEM, keyPEM
+	r.mu.Unlock()
+	return true, nil
+}
+
+// readCABundle reads the CA bundle from the cert dir, or else from the CA bundle ConfigMap. It is empty when neither
+// has one, the CA bundles are then left to the cainjector of cert-manager.
+func (r *Rotator) readCABundle(ctx context.Context) ([]byte, error) {
+	caBundle, err := os.ReadFile(filepath.Join(r.CertDir, DefaultCAName))
+	if err == nil && len(caBundle) > 0 {
+		return caBundle, nil
+	}
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if r.CABundleConfigMap == "" {
+		return nil, nil
+	}
+	configMap, err := r.Clientset.CoreV1().ConfigMaps(r.ServiceNamespace).Get(ctx, r.CABundleConfigMap, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return []byte(configMap.Data[ServiceCAConfigMapKey]), nil
+}
+
+// syncCABundles patches the CA bundles which differ from the current one
+func (r *Rotator) syncCABundles(ctx context.Context) {
+	caBundle, err := r.readCABundle(ctx)
+	if err != nil {
+		r.Log.Error(err, "unable to read the webhook CA bundle")
+		return
+	}
+	if len(caBundle) == 0 {
+		return
+	}
+	r.mu.Lock()
+	rotated := !bytes.Equal(caBundle, r.caBundle)
+	r.caBundle = caBundle
+	r.mu.Unlock()
+	if rotated {
+		r.Log.Info("Syncing the webhook CA bundle", "service", r.ServiceNamespace+"/"+r.ServiceName)
+	}
+	if err := r.syncMutatingWebhookConfigurations(ctx, caBundle); err != nil {
+		r.logSyncError(err, "unable to patch the CA bundle of the mutating webhook configurations")
+	}
+	if err := r.syncValidatingWebhookConfigurations(ctx, caBundle); err != nil {
+		r.logSyncError(err, "unable to patch the CA bundle of the validating webhook configurations")
+	}
+	for _, name := range r.CRDs {
+		if err := r.syncCRD(ctx, name, caBundle); err != nil {
+			r.logSyncError(err, "unable to patch the CA bundle of the conversion webhook", "crd", name)
+		}
+	}
+}
+
+// logSyncError logs the errors of the CA bundle sync, a namespace-scoped KServe instance is not allowed to patch the
+// cluster-scoped configurations so that the forbidden errors are only logged at the debug level
+func (r *Rotator) logSyncError(err error, msg string, keysAndValues ...interface{}) {
+	if apierr.IsForbidden(err) {
+		r.Log.V(1).Info(msg, append(keysAndValues, "error", err.Error())...)
+		return
+	}
+	r.Log.Error(err, msg, keysAndValues...)
+}
+
+// setCABundle sets the CA bundle of the client config if it points to the webhook service and reports whether it
+// changed
+func (r *Rotator) setCABundle(clientConfig *admissionregistrationv1.WebhookClientConfig, caBundle []byte) bool {
+	service := clientConfig.Service
+	if service == nil || service.Name != r.ServiceName || service.Namespace != r.ServiceNamespace {
+		return false
+	}
+	if bytes.Equal(clientConfig.CABundle, caBundle) {
+		return false
+	}
+	clientConfig.CABundle = caBundle
+	return true
+}
+
+func (r *Rotator) syncMutatingWebhookConfigurations(ctx context.Context, caBundle []byte) error {
+	client := r.Clientset.AdmissionregistrationV1().MutatingWebhookConfigurations()
+	configurations, err := client.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, configuration := range configurations.Items {
+		name := configuration.Name
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			configuration, err := client.Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			changed := false
+			for i := range configuration.Webhooks {
+				if r.setCABundle(&configuration.Webhooks[i].ClientConfig, caBundle) {
+					changed = true
+				}
+			}
+			if !changed {
+				return nil
+			}
+			_, err = client.Update(ctx, configuration, metav1.UpdateOptions{})
+			return err
+		})
+		if err != nil && !apierr.IsNotFound(err) {
+			return fmt.Errorf("mutating webhook configuration %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (r *Rotator) syncValidatingWebhookConfigurations(ctx context.Context, caBundle []byte) error {
+	client := r.Clientset.AdmissionregistrationV1().ValidatingWebhookConfigurations()
+	configurations, err := client.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, configuration := range configurations.Items {
+		name := configuration.Name
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			configuration, err := client.Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			changed := false
+			for i := range configuration.Webhooks {
+				if r.setCABundle(&configuration.Webhooks[i].ClientConfig, caBundle) {
+					changed = true
+				}
+			}
+			if !changed {
+				return nil
+			}
+			_, err = client.Update(ctx, configuration, metav1.UpdateOptions{})
+			return err
+		})
+		if err != nil && !apierr.IsNotFound(err) {
+			return fmt.Errorf("validating webhook configuration %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (r *Rotator) syncCRD(ctx context.Context, name string, caBundle []byte) error {
+	client := r.APIExtensionsClientset.ApiextensionsV1().CustomResourceDefinitions()
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		crd, err := client.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		conversion := crd.Spec.Conversion
+		if conversion == nil || conversion.Webhook == nil || conversion.Webhook.ClientConfig == nil {
+			return nil
+		}
+		service := conversion.Webhook.ClientConfig.Service
+		if service == nil || service.Name != r.ServiceName || service.Namespace != r.ServiceNamespace ||
+			bytes.Equal(conversion.Webhook.ClientConfig.CABundle, caBundle) {
+			return nil
+		}
+		conversion.Webhook.ClientConfig.CABundle = caBundle
+		_, err = client.Update(ctx, crd, metav1.UpdateOptions{})
+		return err
+	})
+	if apierr.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certs
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/onsi/gomega"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	v1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// writeCertificate writes a self-signed key pair and its CA to the dir and returns the CA
+func writeCertificate(t *testing.T, dir string, serial int64) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "kserve-webhook-server-service.kserve.svc"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	files := map[string][]byte{
+		DefaultCertName: certPEM,
+		DefaultKeyName:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}),
+		DefaultCAName:   certPEM,
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return certPEM
+}
+
+func webhookClientConfig(service string) admissionregistrationv1.WebhookClientConfig {
+	return admissionregistrationv1.WebhookClientConfig{
+		Service:  &admissionregistrationv1.ServiceReference{Name: service, Namespace: "kserve"},
+		CABundle: []byte("stale"),
+	}
+}
+
+func TestRotator(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	dir := t.TempDir()
+	caBundle := writeCertificate(t, dir, 1)
+
+	clientset := fake.NewSimpleClientset(
+		&admissionregistrationv1.MutatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "inferenceservice.serving.kserve.io"},
+			Webhooks: []admissionregistrationv1.MutatingWebhook{
+				{Name: "defaulter", ClientConfig: webhookClientConfig("kserve-webhook-server-service")},
+			},
+		},
+		&admissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "inferenceservice.serving.kserve.io"},
+			Webhooks: []admissionregistrationv1.ValidatingWebhook{
+				{Name: "validator", ClientConfig: webhookClientConfig("kserve-webhook-server-service")},
+				{Name: "other", ClientConfig: webhookClientConfig("other-webhook-service")},
+			},
+		},
+	)
+	apiExtensionsClientset := apiextensionsfake.NewSimpleClientset(&apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "inferenceservices.serving.kserve.io"},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Conversion: &apiextensionsv1.CustomResourceConversion{
+				Strategy: apiextensionsv1.WebhookConverter,
+				Webhook: &apiextensionsv1.WebhookConversion{
+					ClientConfig: &apiextensionsv1.WebhookClientConfig{
+						Service: &apiextensionsv1.ServiceReference{Name: "kserve-webhook-server-service", Namespace: "kserve"},
+					},
+				},
+			},
+		},
+	})
+	rotator := &Rotator{
+		CertDir:                dir,
+		ServiceName:            "kserve-webhook-server-service",
+		ServiceNamespace:       "kserve",
+		CRDs:                   []string{"inferenceservices.serving.kserve.io", "missing.serving.kserve.io"},
+		ResyncPeriod:           10 * time.Millisecond,
+		Clientset:              clientset,
+		APIExtensionsClientset: apiExtensionsClientset,
+		Log:                    logr.Discard(),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = rotator.Start(ctx)
+	}()
+
+	expectCABundles := func(caBundle []byte) {
+		g.Eventually(func(g gomega.Gomega) {
+			mutating, err := clientset.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(ctx,
+				"inferenceservice.serving.kserve.io", metav1.GetOptions{})
+			g.Expect(err).ShouldNot(gomega.HaveOccurred())
+			g.Expect(mutating.Webhooks[0].ClientConfig.CABundle).Should(gomega.Equal(caBundle))
+			validating, err := clientset.AdmissionregistrationV1().ValidatingWebhookConfigurations().Get(ctx,
+				"inferenceservice.serving.kserve.io", metav1.GetOptions{})
+			g.Expect(err).ShouldNot(gomega.HaveOccurred())
+			g.Expect(validating.Webhooks[0].ClientConfig.CABundle).Should(gomega.Equal(caBundle))
+			// the webhooks of the other services are left alone
+			g.Expect(validating.Webhooks[1].ClientConfig.CABundle).Should(gomega.Equal([]byte("stale")))
+			crd, err := apiExtensionsClientset.ApiextensionsV1().CustomResourceDefinitions().Get(ctx,
+				"inferenceservices.serving.kserve.io", metav1.GetOptions{})
+			g.Expect(err).ShouldNot(gomega.HaveOccurred())
+			g.Expect(crd.Spec.Conversion.Webhook.ClientConfig.CABundle).Should(gomega.Equal(caBundle))
+		}, 5*time.Second, 10*time.Millisecond).Should(gomega.Succeed())
+	}
+	expectServedSerial := func(serial int64) {
+		g.Eventually(func(g gomega.Gomega) {
+			cert, err := rotator.GetCertificate(nil)
+			g.Expect(err).ShouldNot(gomega.HaveOccurred())
+			leaf, err := x509.ParseCertificate(cert.Certificate[0])
+			g.Expect(err).ShouldNot(gomega.HaveOccurred())
+			g.Expect(leaf.SerialNumber.Int64()).Should(gomega.Equal(serial))
+		}, 5*time.Second, 10*time.Millisecond).Should(gomega.Succeed())
+	}
+	expectServedSerial(1)
+	expectCABundles(caBundle)
+
+	// the rotated certificate is served and its CA is patched without a restart
+	caBundle = writeCertificate(t, dir, 2)
+	expectServedSerial(2)
+	expectCABundles(caBundle)
+}
+
+func TestRotatorServiceCAConfigMap(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	dir := t.TempDir()
+	writeCertificate(t, dir, 1)
+	g.Expect(os.Remove(filepath.Join(dir, DefaultCAName))).Should(gomega.Succeed())
+
+	rotator := &Rotator{
+		CertDir:          dir,
+		ServiceNamespace: "kserve",
+		Clientset: fake.NewSimpleClientset(&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "openshift-service-ca.crt", Namespace: "kserve"},
+			Data:       map[string]string{ServiceCAConfigMapKey: "service-ca"},
+		}),
+		Log: logr.Discard(),
+	}
+	// the CA bundles are left to the cainjector without a CA bundle ConfigMap
+	caBundle, err := rotator.readCABundle(context.Background())
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(caBundle).Should(gomega.BeEmpty())
+
+	rotator.CABundleConfigMap = "openshift-service-ca.crt"
+	caBundle, err = rotator.readCABundle(context.Background())
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(caBundle).Should(gomega.Equal([]byte("service-ca")))
+}
+
+func TestRotatorInvalidCertificate(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	dir := t.TempDir()
+	g.Expect(os.WriteFile(filepath.Join(dir, DefaultCertName), []byte("cert"), 0o600)).Should(gomega.Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, DefaultKeyName), []byte("key"), 0o600)).Should(gomega.Succeed())
+	rotator := &Rotator{CertDir: dir, Log: logr.Discard()}
+	g.Expect(rotator.Start(context.Background())).Should(gomega.MatchError(gomega.ContainSubstring("invalid webhook serving certificate")))
+	_, err := rotator.GetCertificate(nil)
+	g.Expect(err).Should(gomega.HaveOccurred())
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package settings keeps the settings the admission webhooks read from package variables in sync with the
+// inferenceservice-config ConfigMap, since the webhook validators have no access to the ConfigMap.
+package settings
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+// DefaultReloadInterval is the interval the configmap is checked for changes
+const DefaultReloadInterval = 30 * time.Second
+
+// Reloader sets the FIPS mode, the sidecar security context bounds, the graph limits, the graph target namespaces
+// and the max serving priority every time the configmap changes. It runs on every replica since they all serve the
+// webhooks.
+type Reloader struct {
+	Clientset kubernetes.Interface
+	Interval  time.Duration
+	Log       logr.Logger
+
+	resourceVersion string
+}
+
+// NeedLeaderElection returns false since every replica serves the webhooks
+func (r *Reloader) NeedLeaderElection() bool {
+	return false
+}
+
+// Start reloads the settings every interval until the context is done, the previous settings are kept when the
+// configmap is invalid
+func (r *Reloader) Start(ctx context.Context) error {
+	interval := r.Interval
+	if interval == 0 {
+		interval = DefaultReloadInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := r.Reload(ctx); err != nil {
+			r.Log.Error(err, "unable to reload the webhook settings from the inferenceservice configmap")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Reload sets the settings from the configmap when it changed since the last reload, none of them is changed when
+// one of them is invalid
+func (r *Reloader) Reload(ctx context.Context) error {
+	configMap, err := r.Clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Get(ctx,
+		constants.InferenceServiceConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if configMap.ResourceVersion == r.resourceVersion {
+		return nil
+	}
+	securityConfig, err := v1beta1.GetSecurityConfig(configMap)
+	if err != nil {
+		return err
+	}
+	graphLimits, err := v1alpha1.GetGraphLimits(configMap)
+	if err != nil {
+		return err
+	}
+	targetNamespaces, err := v1alpha1.GetTargetNamespaces(configMap)
+	if err != nil {
+		return err
+	}
+	gpuPreemptionConfig, err := v1beta1.GetGPUPreemptionConfig(configMap)
+	if err != nil {
+		return err
+	}
+	utils.SetFIPSMode(securityConfig.FIPSMode)
+	utils.SetSecurityContextBounds(securityConfig.SidecarSecurityContextBounds)
+	v1alpha1.SetGraphLimits(graphLimits)
+	v1alpha1.SetTargetNamespaces(targetNamespaces)
+	v1beta1.SetMaxServingPriority(gpuPreemptionConfig.MaxServingPriority)
+	r.resourceVersion = configMap.ResourceVersion
+	r.Log.Info("reloaded the webhook settings", "resourceVersion", configMap.ResourceVersion)
+	return nil
+}
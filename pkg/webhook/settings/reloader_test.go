@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package settings
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+func TestReload(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	defer utils.SetFIPSMode(false)
+	configMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            constants.InferenceServiceConfigMapName,
+			Namespace:       constants.KServeNamespace,
+			ResourceVersion: "1",
+		},
+		Data: map[string]string{v1beta1.SecurityConfigName: `{"fipsMode": true}`},
+	}
+	clientset := fake.NewSimpleClientset(configMap)
+	reloader := &Reloader{Clientset: clientset, Log: ctrl.Log.WithName("test")}
+	g.Expect(reloader.NeedLeaderElection()).Should(gomega.BeFalse())
+	g.Expect(reloader.Reload(context.TODO())).Should(gomega.Succeed())
+	g.Expect(utils.IsFIPSMode()).Should(gomega.BeTrue())
+
+	// nothing is changed when one of the settings is invalid
+	configMap.ResourceVersion = "2"
+	configMap.Data = map[string]string{
+		v1beta1.SecurityConfigName:   `{"fipsMode": false}`,
+		v1alpha1.RouterConfigKeyName: `{"maxFanOut": -1}`,
+	}
+	_, err := clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Update(context.TODO(), configMap, metav1.UpdateOptions{})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(reloader.Reload(context.TODO())).ShouldNot(gomega.Succeed())
+	g.Expect(utils.IsFIPSMode()).Should(gomega.BeTrue())
+
+	configMap.ResourceVersion = "3"
+	configMap.Data[v1alpha1.RouterConfigKeyName] = `{"maxFanOut": 10}`
+	_, err = clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Update(context.TODO(), configMap, metav1.UpdateOptions{})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(reloader.Reload(context.TODO())).Should(gomega.Succeed())
+	g.Expect(utils.IsFIPSMode()).Should(gomega.BeFalse())
+}
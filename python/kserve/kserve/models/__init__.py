@@ -28,11 +28,18 @@
 from __future__ import absolute_import
 
 # import models into model package
+from kserve.models.v1alpha1_active_hours import V1alpha1ActiveHours
+from kserve.models.v1alpha1_active_window import V1alpha1ActiveWindow
+from kserve.models.v1alpha1_aggregation_spec import V1alpha1AggregationSpec
 from kserve.models.v1alpha1_built_in_adapter import V1alpha1BuiltInAdapter
+from kserve.models.v1alpha1_cors_policy import V1alpha1CORSPolicy
+from kserve.models.v1alpha1_circuit_breaker_policy import V1alpha1CircuitBreakerPolicy
 from kserve.models.v1alpha1_cluster_serving_runtime import V1alpha1ClusterServingRuntime
 from kserve.models.v1alpha1_cluster_serving_runtime_list import V1alpha1ClusterServingRuntimeList
 from kserve.models.v1alpha1_cluster_storage_container import V1alpha1ClusterStorageContainer
 from kserve.models.v1alpha1_cluster_storage_container_list import V1alpha1ClusterStorageContainerList
+from kserve.models.v1alpha1_effective_config import V1alpha1EffectiveConfig
+from kserve.models.v1alpha1_endpoint import V1alpha1Endpoint
 from kserve.models.v1alpha1_inference_graph import V1alpha1InferenceGraph
 from kserve.models.v1alpha1_inference_graph_list import V1alpha1InferenceGraphList
 from kserve.models.v1alpha1_inference_graph_spec import V1alpha1InferenceGraphSpec
@@ -40,11 +47,29 @@ from kserve.models.v1alpha1_inference_graph_status import V1alpha1InferenceGraph
 from kserve.models.v1alpha1_inference_router import V1alpha1InferenceRouter
 from kserve.models.v1alpha1_inference_step import V1alpha1InferenceStep
 from kserve.models.v1alpha1_inference_target import V1alpha1InferenceTarget
+from kserve.models.v1alpha1_load_shedding_policy import V1alpha1LoadSheddingPolicy
+from kserve.models.v1alpha1_map_router_spec import V1alpha1MapRouterSpec
 from kserve.models.v1alpha1_model_spec import V1alpha1ModelSpec
+from kserve.models.v1alpha1_node_plugin import V1alpha1NodePlugin
+from kserve.models.v1alpha1_protocol_translation import V1alpha1ProtocolTranslation
+from kserve.models.v1alpha1_quota_spec import V1alpha1QuotaSpec
+from kserve.models.v1alpha1_router_plugin_source import V1alpha1RouterPluginSource
+from kserve.models.v1alpha1_router_revision_status import V1alpha1RouterRevisionStatus
+from kserve.models.v1alpha1_router_security_context import V1alpha1RouterSecurityContext
+from kserve.models.v1alpha1_router_traffic_target import V1alpha1RouterTrafficTarget
+from kserve.models.v1alpha1_serving_profile import V1alpha1ServingProfile
+from kserve.models.v1alpha1_serving_profile_list import V1alpha1ServingProfileList
+from kserve.models.v1alpha1_serving_profile_size import V1alpha1ServingProfileSize
+from kserve.models.v1alpha1_serving_profile_spec import V1alpha1ServingProfileSpec
 from kserve.models.v1alpha1_serving_runtime import V1alpha1ServingRuntime
 from kserve.models.v1alpha1_serving_runtime_list import V1alpha1ServingRuntimeList
 from kserve.models.v1alpha1_serving_runtime_pod_spec import V1alpha1ServingRuntimePodSpec
 from kserve.models.v1alpha1_serving_runtime_spec import V1alpha1ServingRuntimeSpec
+from kserve.models.v1alpha1_sidecar_logging_spec import V1alpha1SidecarLoggingSpec
+from kserve.models.v1alpha1_smoke_test_assertion import V1alpha1SmokeTestAssertion
+from kserve.models.v1alpha1_smoke_test_spec import V1alpha1SmokeTestSpec
+from kserve.models.v1alpha1_smoke_test_status import V1alpha1SmokeTestStatus
+from kserve.models.v1alpha1_step_header import V1alpha1StepHeader
 from kserve.models.v1alpha1_storage_container_spec import V1alpha1StorageContainerSpec
 from kserve.models.v1alpha1_storage_helper import V1alpha1StorageHelper
 from kserve.models.v1alpha1_supported_model_format import V1alpha1SupportedModelFormat
@@ -53,19 +78,31 @@ from kserve.models.v1alpha1_trained_model import V1alpha1TrainedModel
 from kserve.models.v1alpha1_trained_model_list import V1alpha1TrainedModelList
 from kserve.models.v1alpha1_trained_model_spec import V1alpha1TrainedModelSpec
 from kserve.models.v1beta1_art_explainer_spec import V1beta1ARTExplainerSpec
+from kserve.models.v1beta1_active_hours import V1beta1ActiveHours
+from kserve.models.v1beta1_active_window import V1beta1ActiveWindow
+from kserve.models.v1beta1_agent_middleware import V1beta1AgentMiddleware
 from kserve.models.v1beta1_batcher import V1beta1Batcher
+from kserve.models.v1beta1_benchmark_spec import V1beta1BenchmarkSpec
+from kserve.models.v1beta1_benchmark_status import V1beta1BenchmarkStatus
+from kserve.models.v1beta1_cors_policy import V1beta1CORSPolicy
 from kserve.models.v1beta1_component_extension_spec import V1beta1ComponentExtensionSpec
 from kserve.models.v1beta1_component_status_spec import V1beta1ComponentStatusSpec
 from kserve.models.v1beta1_custom_explainer import V1beta1CustomExplainer
 from kserve.models.v1beta1_custom_predictor import V1beta1CustomPredictor
 from kserve.models.v1beta1_custom_transformer import V1beta1CustomTransformer
 from kserve.models.v1beta1_deploy_config import V1beta1DeployConfig
+from kserve.models.v1beta1_effective_config import V1beta1EffectiveConfig
+from kserve.models.v1beta1_endpoint import V1beta1Endpoint
 from kserve.models.v1beta1_explainer_config import V1beta1ExplainerConfig
 from kserve.models.v1beta1_explainer_extension_spec import V1beta1ExplainerExtensionSpec
 from kserve.models.v1beta1_explainer_spec import V1beta1ExplainerSpec
 from kserve.models.v1beta1_explainers_config import V1beta1ExplainersConfig
 from kserve.models.v1beta1_failure_info import V1beta1FailureInfo
+from kserve.models.v1beta1_guardrail_hook import V1beta1GuardrailHook
+from kserve.models.v1beta1_guardrail_spec import V1beta1GuardrailSpec
+from kserve.models.v1beta1_header_transform import V1beta1HeaderTransform
 from kserve.models.v1beta1_hugging_face_runtime_spec import V1beta1HuggingFaceRuntimeSpec
+from kserve.models.v1beta1_image_status import V1beta1ImageStatus
 from kserve.models.v1beta1_inference_service import V1beta1InferenceService
 from kserve.models.v1beta1_inference_service_list import V1beta1InferenceServiceList
 from kserve.models.v1beta1_inference_service_spec import V1beta1InferenceServiceSpec
@@ -74,6 +111,7 @@ from kserve.models.v1beta1_inference_services_config import V1beta1InferenceServ
 from kserve.models.v1beta1_ingress_config import V1beta1IngressConfig
 from kserve.models.v1beta1_light_gbm_spec import V1beta1LightGBMSpec
 from kserve.models.v1beta1_logger_spec import V1beta1LoggerSpec
+from kserve.models.v1beta1_model_conversion_spec import V1beta1ModelConversionSpec
 from kserve.models.v1beta1_model_copies import V1beta1ModelCopies
 from kserve.models.v1beta1_model_format import V1beta1ModelFormat
 from kserve.models.v1beta1_model_revision_states import V1beta1ModelRevisionStates
@@ -85,10 +123,18 @@ from kserve.models.v1beta1_paddle_server_spec import V1beta1PaddleServerSpec
 from kserve.models.v1beta1_pod_spec import V1beta1PodSpec
 from kserve.models.v1beta1_predictor_extension_spec import V1beta1PredictorExtensionSpec
 from kserve.models.v1beta1_predictor_spec import V1beta1PredictorSpec
+from kserve.models.v1beta1_quota_spec import V1beta1QuotaSpec
 from kserve.models.v1beta1_sk_learn_spec import V1beta1SKLearnSpec
+from kserve.models.v1beta1_sidecar_logging_spec import V1beta1SidecarLoggingSpec
+from kserve.models.v1beta1_sidecar_security_context import V1beta1SidecarSecurityContext
 from kserve.models.v1beta1_storage_spec import V1beta1StorageSpec
 from kserve.models.v1beta1_tf_serving_spec import V1beta1TFServingSpec
+from kserve.models.v1beta1_token_exchange import V1beta1TokenExchange
+from kserve.models.v1beta1_token_review import V1beta1TokenReview
+from kserve.models.v1beta1_token_usage_spec import V1beta1TokenUsageSpec
 from kserve.models.v1beta1_torch_serve_spec import V1beta1TorchServeSpec
 from kserve.models.v1beta1_transformer_spec import V1beta1TransformerSpec
 from kserve.models.v1beta1_triton_spec import V1beta1TritonSpec
+from kserve.models.v1beta1_unauthenticated_path import V1beta1UnauthenticatedPath
+from kserve.models.v1beta1_validation_spec import V1beta1ValidationSpec
 from kserve.models.v1beta1_xg_boost_spec import V1beta1XGBoostSpec
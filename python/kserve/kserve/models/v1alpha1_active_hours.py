@@ -0,0 +1,165 @@
+# Copyright 2023 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1ActiveHours(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'time_zone': 'str',
+        'windows': 'list[V1alpha1ActiveWindow]'
+    }
+
+    attribute_map = {
+        'time_zone': 'timeZone',
+        'windows': 'windows'
+    }
+
+    def __init__(self, time_zone=None, windows=None, local_vars_configuration=None):  # noqa: E501
+        """V1alpha1ActiveHours - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._time_zone = None
+        self._windows = None
+        self.discriminator = None
+
+        if time_zone is not None:
+            self.time_zone = time_zone
+        self.windows = windows
+
+    @property
+    def time_zone(self):
+        """Gets the time_zone of this V1alpha1ActiveHours.  # noqa: E501
+
+        IANA time zone the schedules are evaluated in, e.g. Europe/Paris, defaults to UTC  # noqa: E501
+
+        :return: The time_zone of this V1alpha1ActiveHours.  # noqa: E501
+        :rtype: str
+        """
+        return self._time_zone
+
+    @time_zone.setter
+    def time_zone(self, time_zone):
+        """Sets the time_zone of this V1alpha1ActiveHours.
+
+        IANA time zone the schedules are evaluated in, e.g. Europe/Paris, defaults to UTC  # noqa: E501
+
+        :param time_zone: The time_zone of this V1alpha1ActiveHours.  # noqa: E501
+        :type: str
+        """
+
+        self._time_zone = time_zone
+
+    @property
+    def windows(self):
+        """Gets the windows of this V1alpha1ActiveHours.  # noqa: E501
+
+        Windows during which the router runs, at least one of them must be open  # noqa: E501
+
+        :return: The windows of this V1alpha1ActiveHours.  # noqa: E501
+        :rtype: list[V1alpha1ActiveWindow]
+        """
+        return self._windows
+
+    @windows.setter
+    def windows(self, windows):
+        """Sets the windows of this V1alpha1ActiveHours.
+
+        Windows during which the router runs, at least one of them must be open  # noqa: E501
+
+        :param windows: The windows of this V1alpha1ActiveHours.  # noqa: E501
+        :type: list[V1alpha1ActiveWindow]
+        """
+        if self.local_vars_configuration.client_side_validation and windows is None:  # noqa: E501
+            raise ValueError("Invalid value for `windows`, must not be `None`")  # noqa: E501
+
+        self._windows = windows
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1ActiveHours):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1ActiveHours):
+            return True
+
+        return self.to_dict() != other.to_dict()
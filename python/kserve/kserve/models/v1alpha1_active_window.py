@@ -0,0 +1,164 @@
+# Copyright 2023 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1ActiveWindow(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'duration': 'V1Duration',
+        'schedule': 'str'
+    }
+
+    attribute_map = {
+        'duration': 'duration',
+        'schedule': 'schedule'
+    }
+
+    def __init__(self, duration=None, schedule='', local_vars_configuration=None):  # noqa: E501
+        """V1alpha1ActiveWindow - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._duration = None
+        self._schedule = None
+        self.discriminator = None
+
+        self.duration = duration
+        self.schedule = schedule
+
+    @property
+    def duration(self):
+        """Gets the duration of this V1alpha1ActiveWindow.  # noqa: E501
+
+
+        :return: The duration of this V1alpha1ActiveWindow.  # noqa: E501
+        :rtype: V1Duration
+        """
+        return self._duration
+
+    @duration.setter
+    def duration(self, duration):
+        """Sets the duration of this V1alpha1ActiveWindow.
+
+
+        :param duration: The duration of this V1alpha1ActiveWindow.  # noqa: E501
+        :type: V1Duration
+        """
+        if self.local_vars_configuration.client_side_validation and duration is None:  # noqa: E501
+            raise ValueError("Invalid value for `duration`, must not be `None`")  # noqa: E501
+
+        self._duration = duration
+
+    @property
+    def schedule(self):
+        """Gets the schedule of this V1alpha1ActiveWindow.  # noqa: E501
+
+        Cron expression of five fields, minute hour day-of-month month day-of-week, the window opens at, e.g. \"0 8 * * 1-5\" for 8am on weekdays  # noqa: E501
+
+        :return: The schedule of this V1alpha1ActiveWindow.  # noqa: E501
+        :rtype: str
+        """
+        return self._schedule
+
+    @schedule.setter
+    def schedule(self, schedule):
+        """Sets the schedule of this V1alpha1ActiveWindow.
+
+        Cron expression of five fields, minute hour day-of-month month day-of-week, the window opens at, e.g. \"0 8 * * 1-5\" for 8am on weekdays  # noqa: E501
+
+        :param schedule: The schedule of this V1alpha1ActiveWindow.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and schedule is None:  # noqa: E501
+            raise ValueError("Invalid value for `schedule`, must not be `None`")  # noqa: E501
+
+        self._schedule = schedule
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1ActiveWindow):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1ActiveWindow):
+            return True
+
+        return self.to_dict() != other.to_dict()
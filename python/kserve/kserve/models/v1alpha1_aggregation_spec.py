@@ -0,0 +1,164 @@
+# Copyright 2023 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1AggregationSpec(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'format': 'str',
+        'template': 'str'
+    }
+
+    attribute_map = {
+        'format': 'format',
+        'template': 'template'
+    }
+
+    def __init__(self, format=None, template=None, local_vars_configuration=None):  # noqa: E501
+        """V1alpha1AggregationSpec - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._format = None
+        self._template = None
+        self.discriminator = None
+
+        if format is not None:
+            self.format = format
+        if template is not None:
+            self.template = template
+
+    @property
+    def format(self):
+        """Gets the format of this V1alpha1AggregationSpec.  # noqa: E501
+
+        Protocol of the responses of the steps and of the node, defaults to `v1`. The predictions are read from the predictions field of V1 responses, and from the first dimension of the single output tensor of V2 responses.  # noqa: E501
+
+        :return: The format of this V1alpha1AggregationSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._format
+
+    @format.setter
+    def format(self, format):
+        """Sets the format of this V1alpha1AggregationSpec.
+
+        Protocol of the responses of the steps and of the node, defaults to `v1`. The predictions are read from the predictions field of V1 responses, and from the first dimension of the single output tensor of V2 responses.  # noqa: E501
+
+        :param format: The format of this V1alpha1AggregationSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._format = format
+
+    @property
+    def template(self):
+        """Gets the template of this V1alpha1AggregationSpec.  # noqa: E501
+
+        Go template rendering the response of the node, required by the Template aggregation. The responses of the steps are available as .steps.<step name>, or by step index for unnamed steps, and the json function encodes a value as JSON, e.g. `{\"predictions\": {{ json .steps.classifier.predictions }}}`. The rendered response must be JSON.  # noqa: E501
+
+        :return: The template of this V1alpha1AggregationSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._template
+
+    @template.setter
+    def template(self, template):
+        """Sets the template of this V1alpha1AggregationSpec.
+
+        Go template rendering the response of the node, required by the Template aggregation. The responses of the steps are available as .steps.<step name>, or by step index for unnamed steps, and the json function encodes a value as JSON, e.g. `{\"predictions\": {{ json .steps.classifier.predictions }}}`. The rendered response must be JSON.  # noqa: E501
+
+        :param template: The template of this V1alpha1AggregationSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._template = template
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1AggregationSpec):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1AggregationSpec):
+            return True
+
+        return self.to_dict() != other.to_dict()
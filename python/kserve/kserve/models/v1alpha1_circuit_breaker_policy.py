@@ -0,0 +1,163 @@
+# Copyright 2023 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1CircuitBreakerPolicy(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'consecutive_errors': 'int',
+        'ejection_time': 'V1Duration'
+    }
+
+    attribute_map = {
+        'consecutive_errors': 'consecutiveErrors',
+        'ejection_time': 'ejectionTime'
+    }
+
+    def __init__(self, consecutive_errors=0, ejection_time=None, local_vars_configuration=None):  # noqa: E501
+        """V1alpha1CircuitBreakerPolicy - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._consecutive_errors = None
+        self._ejection_time = None
+        self.discriminator = None
+
+        self.consecutive_errors = consecutive_errors
+        if ejection_time is not None:
+            self.ejection_time = ejection_time
+
+    @property
+    def consecutive_errors(self):
+        """Gets the consecutive_errors of this V1alpha1CircuitBreakerPolicy.  # noqa: E501
+
+        Number of consecutive calls, after their retries, failing with an error, a timeout or a 5xx status which eject the target  # noqa: E501
+
+        :return: The consecutive_errors of this V1alpha1CircuitBreakerPolicy.  # noqa: E501
+        :rtype: int
+        """
+        return self._consecutive_errors
+
+    @consecutive_errors.setter
+    def consecutive_errors(self, consecutive_errors):
+        """Sets the consecutive_errors of this V1alpha1CircuitBreakerPolicy.
+
+        Number of consecutive calls, after their retries, failing with an error, a timeout or a 5xx status which eject the target  # noqa: E501
+
+        :param consecutive_errors: The consecutive_errors of this V1alpha1CircuitBreakerPolicy.  # noqa: E501
+        :type: int
+        """
+        if self.local_vars_configuration.client_side_validation and consecutive_errors is None:  # noqa: E501
+            raise ValueError("Invalid value for `consecutive_errors`, must not be `None`")  # noqa: E501
+
+        self._consecutive_errors = consecutive_errors
+
+    @property
+    def ejection_time(self):
+        """Gets the ejection_time of this V1alpha1CircuitBreakerPolicy.  # noqa: E501
+
+
+        :return: The ejection_time of this V1alpha1CircuitBreakerPolicy.  # noqa: E501
+        :rtype: V1Duration
+        """
+        return self._ejection_time
+
+    @ejection_time.setter
+    def ejection_time(self, ejection_time):
+        """Sets the ejection_time of this V1alpha1CircuitBreakerPolicy.
+
+
+        :param ejection_time: The ejection_time of this V1alpha1CircuitBreakerPolicy.  # noqa: E501
+        :type: V1Duration
+        """
+
+        self._ejection_time = ejection_time
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1CircuitBreakerPolicy):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1CircuitBreakerPolicy):
+            return True
+
+        return self.to_dict() != other.to_dict()
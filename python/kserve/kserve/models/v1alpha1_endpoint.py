@@ -0,0 +1,222 @@
+# Copyright 2023 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1Endpoint(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'name': 'str',
+        'protocol': 'str',
+        'url': 'KnativeURL',
+        'visibility': 'str'
+    }
+
+    attribute_map = {
+        'name': 'name',
+        'protocol': 'protocol',
+        'url': 'url',
+        'visibility': 'visibility'
+    }
+
+    def __init__(self, name='', protocol='', url=None, visibility='', local_vars_configuration=None):  # noqa: E501
+        """V1alpha1Endpoint - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._name = None
+        self._protocol = None
+        self._url = None
+        self._visibility = None
+        self.discriminator = None
+
+        self.name = name
+        self.protocol = protocol
+        self.url = url
+        self.visibility = visibility
+
+    @property
+    def name(self):
+        """Gets the name of this V1alpha1Endpoint.  # noqa: E501
+
+        Name of the endpoint, external or cluster-local  # noqa: E501
+
+        :return: The name of this V1alpha1Endpoint.  # noqa: E501
+        :rtype: str
+        """
+        return self._name
+
+    @name.setter
+    def name(self, name):
+        """Sets the name of this V1alpha1Endpoint.
+
+        Name of the endpoint, external or cluster-local  # noqa: E501
+
+        :param name: The name of this V1alpha1Endpoint.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and name is None:  # noqa: E501
+            raise ValueError("Invalid value for `name`, must not be `None`")  # noqa: E501
+
+        self._name = name
+
+    @property
+    def protocol(self):
+        """Gets the protocol of this V1alpha1Endpoint.  # noqa: E501
+
+        Protocol served at the endpoint, http or https  # noqa: E501
+
+        :return: The protocol of this V1alpha1Endpoint.  # noqa: E501
+        :rtype: str
+        """
+        return self._protocol
+
+    @protocol.setter
+    def protocol(self, protocol):
+        """Sets the protocol of this V1alpha1Endpoint.
+
+        Protocol served at the endpoint, http or https  # noqa: E501
+
+        :param protocol: The protocol of this V1alpha1Endpoint.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and protocol is None:  # noqa: E501
+            raise ValueError("Invalid value for `protocol`, must not be `None`")  # noqa: E501
+
+        self._protocol = protocol
+
+    @property
+    def url(self):
+        """Gets the url of this V1alpha1Endpoint.  # noqa: E501
+
+
+        :return: The url of this V1alpha1Endpoint.  # noqa: E501
+        :rtype: KnativeURL
+        """
+        return self._url
+
+    @url.setter
+    def url(self, url):
+        """Sets the url of this V1alpha1Endpoint.
+
+
+        :param url: The url of this V1alpha1Endpoint.  # noqa: E501
+        :type: KnativeURL
+        """
+        if self.local_vars_configuration.client_side_validation and url is None:  # noqa: E501
+            raise ValueError("Invalid value for `url`, must not be `None`")  # noqa: E501
+
+        self._url = url
+
+    @property
+    def visibility(self):
+        """Gets the visibility of this V1alpha1Endpoint.  # noqa: E501
+
+        Visibility of the endpoint, External or ClusterLocal  # noqa: E501
+
+        :return: The visibility of this V1alpha1Endpoint.  # noqa: E501
+        :rtype: str
+        """
+        return self._visibility
+
+    @visibility.setter
+    def visibility(self, visibility):
+        """Sets the visibility of this V1alpha1Endpoint.
+
+        Visibility of the endpoint, External or ClusterLocal  # noqa: E501
+
+        :param visibility: The visibility of this V1alpha1Endpoint.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and visibility is None:  # noqa: E501
+            raise ValueError("Invalid value for `visibility`, must not be `None`")  # noqa: E501
+
+        self._visibility = visibility
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1Endpoint):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1Endpoint):
+            return True
+
+        return self.to_dict() != other.to_dict()
@@ -47,58 +47,194 @@ class V1alpha1InferenceGraphSpec(object):
                             and the value is json key in definition.
     """
     openapi_types = {
+        'active_hours': 'V1alpha1ActiveHours',
         'affinity': 'V1Affinity',
+        'canary_traffic_percent': 'int',
+        'circuit_breaker': 'V1alpha1CircuitBreakerPolicy',
+        'cors': 'V1alpha1CORSPolicy',
+        'deployment_strategy': 'K8sIoApiAppsV1DeploymentStrategy',
+        'headers_propagation_policy': 'str',
+        'headers_to_propagate': 'list[str]',
+        'image_pull_secrets': 'list[V1LocalObjectReference]',
+        'load_shedding': 'V1alpha1LoadSheddingPolicy',
         'max_replicas': 'int',
+        'min_ready_seconds': 'int',
         'min_replicas': 'int',
+        'node_selector': 'dict(str, str)',
         'nodes': 'dict(str, V1alpha1InferenceRouter)',
+        'plugins': 'list[V1alpha1RouterPluginSource]',
+        'priority_class_name': 'str',
+        'quota': 'V1alpha1QuotaSpec',
         'resources': 'V1ResourceRequirements',
+        'router_image': 'str',
+        'router_security_context': 'V1alpha1RouterSecurityContext',
         'scale_metric': 'str',
         'scale_target': 'int',
-        'timeout': 'int'
+        'service_account_name': 'str',
+        'sidecar_logging': 'V1alpha1SidecarLoggingSpec',
+        'smoke_test': 'V1alpha1SmokeTestSpec',
+        'timeout': 'int',
+        'tolerations': 'list[V1Toleration]',
+        'topology_spread_constraints': 'list[V1TopologySpreadConstraint]',
+        'volume_mounts': 'list[V1VolumeMount]',
+        'volumes': 'list[V1Volume]'
     }
 
     attribute_map = {
+        'active_hours': 'activeHours',
         'affinity': 'affinity',
+        'canary_traffic_percent': 'canaryTrafficPercent',
+        'circuit_breaker': 'circuitBreaker',
+        'cors': 'cors',
+        'deployment_strategy': 'deploymentStrategy',
+        'headers_propagation_policy': 'headersPropagationPolicy',
+        'headers_to_propagate': 'headersToPropagate',
+        'image_pull_secrets': 'imagePullSecrets',
+        'load_shedding': 'loadShedding',
         'max_replicas': 'maxReplicas',
+        'min_ready_seconds': 'minReadySeconds',
         'min_replicas': 'minReplicas',
+        'node_selector': 'nodeSelector',
         'nodes': 'nodes',
+        'plugins': 'plugins',
+        'priority_class_name': 'priorityClassName',
+        'quota': 'quota',
         'resources': 'resources',
+        'router_image': 'routerImage',
+        'router_security_context': 'routerSecurityContext',
         'scale_metric': 'scaleMetric',
         'scale_target': 'scaleTarget',
-        'timeout': 'timeout'
+        'service_account_name': 'serviceAccountName',
+        'sidecar_logging': 'sidecarLogging',
+        'smoke_test': 'smokeTest',
+        'timeout': 'timeout',
+        'tolerations': 'tolerations',
+        'topology_spread_constraints': 'topologySpreadConstraints',
+        'volume_mounts': 'volumeMounts',
+        'volumes': 'volumes'
     }
 
-    def __init__(self, affinity=None, max_replicas=None, min_replicas=None, nodes=None, resources=None, scale_metric=None, scale_target=None, timeout=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, active_hours=None, affinity=None, canary_traffic_percent=None, circuit_breaker=None, cors=None, deployment_strategy=None, headers_propagation_policy=None, headers_to_propagate=None, image_pull_secrets=None, load_shedding=None, max_replicas=None, min_ready_seconds=None, min_replicas=None, node_selector=None, nodes=None, plugins=None, priority_class_name=None, quota=None, resources=None, router_image=None, router_security_context=None, scale_metric=None, scale_target=None, service_account_name=None, sidecar_logging=None, smoke_test=None, timeout=None, tolerations=None, topology_spread_constraints=None, volume_mounts=None, volumes=None, local_vars_configuration=None):  # noqa: E501
         """V1alpha1InferenceGraphSpec - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
         self.local_vars_configuration = local_vars_configuration
 
+        self._active_hours = None
         self._affinity = None
+        self._canary_traffic_percent = None
+        self._circuit_breaker = None
+        self._cors = None
+        self._deployment_strategy = None
+        self._headers_propagation_policy = None
+        self._headers_to_propagate = None
+        self._image_pull_secrets = None
+        self._load_shedding = None
         self._max_replicas = None
+        self._min_ready_seconds = None
         self._min_replicas = None
+        self._node_selector = None
         self._nodes = None
+        self._plugins = None
+        self._priority_class_name = None
+        self._quota = None
         self._resources = None
+        self._router_image = None
+        self._router_security_context = None
         self._scale_metric = None
         self._scale_target = None
+        self._service_account_name = None
+        self._sidecar_logging = None
+        self._smoke_test = None
         self._timeout = None
+        self._tolerations = None
+        self._topology_spread_constraints = None
+        self._volume_mounts = None
+        self._volumes = None
         self.discriminator = None
 
+        if active_hours is not None:
+            self.active_hours = active_hours
         if affinity is not None:
             self.affinity = affinity
+        if canary_traffic_percent is not None:
+            self.canary_traffic_percent = canary_traffic_percent
+        if circuit_breaker is not None:
+            self.circuit_breaker = circuit_breaker
+        if cors is not None:
+            self.cors = cors
+        if deployment_strategy is not None:
+            self.deployment_strategy = deployment_strategy
+        if headers_propagation_policy is not None:
+            self.headers_propagation_policy = headers_propagation_policy
+        if headers_to_propagate is not None:
+            self.headers_to_propagate = headers_to_propagate
+        if image_pull_secrets is not None:
+            self.image_pull_secrets = image_pull_secrets
+        if load_shedding is not None:
+            self.load_shedding = load_shedding
         if max_replicas is not None:
             self.max_replicas = max_replicas
+        if min_ready_seconds is not None:
+            self.min_ready_seconds = min_ready_seconds
         if min_replicas is not None:
             self.min_replicas = min_replicas
+        if node_selector is not None:
+            self.node_selector = node_selector
         self.nodes = nodes
+        if plugins is not None:
+            self.plugins = plugins
+        if priority_class_name is not None:
+            self.priority_class_name = priority_class_name
+        if quota is not None:
+            self.quota = quota
         if resources is not None:
             self.resources = resources
+        if router_image is not None:
+            self.router_image = router_image
+        if router_security_context is not None:
+            self.router_security_context = router_security_context
         if scale_metric is not None:
             self.scale_metric = scale_metric
         if scale_target is not None:
             self.scale_target = scale_target
+        if service_account_name is not None:
+            self.service_account_name = service_account_name
+        if sidecar_logging is not None:
+            self.sidecar_logging = sidecar_logging
+        if smoke_test is not None:
+            self.smoke_test = smoke_test
         if timeout is not None:
             self.timeout = timeout
+        if tolerations is not None:
+            self.tolerations = tolerations
+        if topology_spread_constraints is not None:
+            self.topology_spread_constraints = topology_spread_constraints
+        if volume_mounts is not None:
+            self.volume_mounts = volume_mounts
+        if volumes is not None:
+            self.volumes = volumes
+
+    @property
+    def active_hours(self):
+        """Gets the active_hours of this V1alpha1InferenceGraphSpec.  # noqa: E501
+
+
+        :return: The active_hours of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :rtype: V1alpha1ActiveHours
+        """
+        return self._active_hours
+
+    @active_hours.setter
+    def active_hours(self, active_hours):
+        """Sets the active_hours of this V1alpha1InferenceGraphSpec.
+
+
+        :param active_hours: The active_hours of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :type: V1alpha1ActiveHours
+        """
+
+        self._active_hours = active_hours
 
     @property
     def affinity(self):
@@ -121,6 +257,182 @@ class V1alpha1InferenceGraphSpec(object):
 
         self._affinity = affinity
 
+    @property
+    def canary_traffic_percent(self):
+        """Gets the canary_traffic_percent of this V1alpha1InferenceGraphSpec.  # noqa: E501
+
+        CanaryTrafficPercent defines the traffic split percentage between the candidate revision and the last rolled out revision of the router. In Serverless mode the last rolled out Knative revision is pinned. In raw deployment mode the candidate revision runs in a second Deployment behind the Service of the graph, the traffic is then split by the number of replicas of the two Deployments, the last rolled out revision being scaled up within MaxReplicas to deliver the percentage. The candidate revision is rolled out when it is not set or 100.  # noqa: E501
+
+        :return: The canary_traffic_percent of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :rtype: int
+        """
+        return self._canary_traffic_percent
+
+    @canary_traffic_percent.setter
+    def canary_traffic_percent(self, canary_traffic_percent):
+        """Sets the canary_traffic_percent of this V1alpha1InferenceGraphSpec.
+
+        CanaryTrafficPercent defines the traffic split percentage between the candidate revision and the last rolled out revision of the router. In Serverless mode the last rolled out Knative revision is pinned. In raw deployment mode the candidate revision runs in a second Deployment behind the Service of the graph, the traffic is then split by the number of replicas of the two Deployments, the last rolled out revision being scaled up within MaxReplicas to deliver the percentage. The candidate revision is rolled out when it is not set or 100.  # noqa: E501
+
+        :param canary_traffic_percent: The canary_traffic_percent of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :type: int
+        """
+
+        self._canary_traffic_percent = canary_traffic_percent
+
+    @property
+    def circuit_breaker(self):
+        """Gets the circuit_breaker of this V1alpha1InferenceGraphSpec.  # noqa: E501
+
+
+        :return: The circuit_breaker of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :rtype: V1alpha1CircuitBreakerPolicy
+        """
+        return self._circuit_breaker
+
+    @circuit_breaker.setter
+    def circuit_breaker(self, circuit_breaker):
+        """Sets the circuit_breaker of this V1alpha1InferenceGraphSpec.
+
+
+        :param circuit_breaker: The circuit_breaker of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :type: V1alpha1CircuitBreakerPolicy
+        """
+
+        self._circuit_breaker = circuit_breaker
+
+    @property
+    def cors(self):
+        """Gets the cors of this V1alpha1InferenceGraphSpec.  # noqa: E501
+
+
+        :return: The cors of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :rtype: V1alpha1CORSPolicy
+        """
+        return self._cors
+
+    @cors.setter
+    def cors(self, cors):
+        """Sets the cors of this V1alpha1InferenceGraphSpec.
+
+
+        :param cors: The cors of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :type: V1alpha1CORSPolicy
+        """
+
+        self._cors = cors
+
+    @property
+    def deployment_strategy(self):
+        """Gets the deployment_strategy of this V1alpha1InferenceGraphSpec.  # noqa: E501
+
+
+        :return: The deployment_strategy of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :rtype: K8sIoApiAppsV1DeploymentStrategy
+        """
+        return self._deployment_strategy
+
+    @deployment_strategy.setter
+    def deployment_strategy(self, deployment_strategy):
+        """Sets the deployment_strategy of this V1alpha1InferenceGraphSpec.
+
+
+        :param deployment_strategy: The deployment_strategy of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :type: K8sIoApiAppsV1DeploymentStrategy
+        """
+
+        self._deployment_strategy = deployment_strategy
+
+    @property
+    def headers_propagation_policy(self):
+        """Gets the headers_propagation_policy of this V1alpha1InferenceGraphSpec.  # noqa: E501
+
+        HeadersPropagationPolicy defines how the headersToPropagate are combined with the headers of the router ConfigMap entry, defaults to Merge.  # noqa: E501
+
+        :return: The headers_propagation_policy of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._headers_propagation_policy
+
+    @headers_propagation_policy.setter
+    def headers_propagation_policy(self, headers_propagation_policy):
+        """Sets the headers_propagation_policy of this V1alpha1InferenceGraphSpec.
+
+        HeadersPropagationPolicy defines how the headersToPropagate are combined with the headers of the router ConfigMap entry, defaults to Merge.  # noqa: E501
+
+        :param headers_propagation_policy: The headers_propagation_policy of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._headers_propagation_policy = headers_propagation_policy
+
+    @property
+    def headers_to_propagate(self):
+        """Gets the headers_to_propagate of this V1alpha1InferenceGraphSpec.  # noqa: E501
+
+        HeadersToPropagate lists the headers, or regular expressions matching them, the router forwards from the request of the graph to its steps, e.g. graph specific authorization or tracing headers. They are added to the headers of the router ConfigMap entry, or replace them when the headersPropagationPolicy is Replace.  # noqa: E501
+
+        :return: The headers_to_propagate of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._headers_to_propagate
+
+    @headers_to_propagate.setter
+    def headers_to_propagate(self, headers_to_propagate):
+        """Sets the headers_to_propagate of this V1alpha1InferenceGraphSpec.
+
+        HeadersToPropagate lists the headers, or regular expressions matching them, the router forwards from the request of the graph to its steps, e.g. graph specific authorization or tracing headers. They are added to the headers of the router ConfigMap entry, or replace them when the headersPropagationPolicy is Replace.  # noqa: E501
+
+        :param headers_to_propagate: The headers_to_propagate of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._headers_to_propagate = headers_to_propagate
+
+    @property
+    def image_pull_secrets(self):
+        """Gets the image_pull_secrets of this V1alpha1InferenceGraphSpec.  # noqa: E501
+
+        ImagePullSecrets are the secrets used to pull the router image, they are merged with the default image pull secrets of the inferenceservice-config ConfigMap.  # noqa: E501
+
+        :return: The image_pull_secrets of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :rtype: list[V1LocalObjectReference]
+        """
+        return self._image_pull_secrets
+
+    @image_pull_secrets.setter
+    def image_pull_secrets(self, image_pull_secrets):
+        """Sets the image_pull_secrets of this V1alpha1InferenceGraphSpec.
+
+        ImagePullSecrets are the secrets used to pull the router image, they are merged with the default image pull secrets of the inferenceservice-config ConfigMap.  # noqa: E501
+
+        :param image_pull_secrets: The image_pull_secrets of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :type: list[V1LocalObjectReference]
+        """
+
+        self._image_pull_secrets = image_pull_secrets
+
+    @property
+    def load_shedding(self):
+        """Gets the load_shedding of this V1alpha1InferenceGraphSpec.  # noqa: E501
+
+
+        :return: The load_shedding of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :rtype: V1alpha1LoadSheddingPolicy
+        """
+        return self._load_shedding
+
+    @load_shedding.setter
+    def load_shedding(self, load_shedding):
+        """Sets the load_shedding of this V1alpha1InferenceGraphSpec.
+
+
+        :param load_shedding: The load_shedding of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :type: V1alpha1LoadSheddingPolicy
+        """
+
+        self._load_shedding = load_shedding
+
     @property
     def max_replicas(self):
         """Gets the max_replicas of this V1alpha1InferenceGraphSpec.  # noqa: E501
@@ -144,6 +456,29 @@ class V1alpha1InferenceGraphSpec(object):
 
         self._max_replicas = max_replicas
 
+    @property
+    def min_ready_seconds(self):
+        """Gets the min_ready_seconds of this V1alpha1InferenceGraphSpec.  # noqa: E501
+
+        Minimum number of seconds a new router pod should be ready without any of its containers crashing before it is considered available and the rollout proceeds. Only applicable for raw deployment mode.  # noqa: E501
+
+        :return: The min_ready_seconds of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :rtype: int
+        """
+        return self._min_ready_seconds
+
+    @min_ready_seconds.setter
+    def min_ready_seconds(self, min_ready_seconds):
+        """Sets the min_ready_seconds of this V1alpha1InferenceGraphSpec.
+
+        Minimum number of seconds a new router pod should be ready without any of its containers crashing before it is considered available and the rollout proceeds. Only applicable for raw deployment mode.  # noqa: E501
+
+        :param min_ready_seconds: The min_ready_seconds of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :type: int
+        """
+
+        self._min_ready_seconds = min_ready_seconds
+
     @property
     def min_replicas(self):
         """Gets the min_replicas of this V1alpha1InferenceGraphSpec.  # noqa: E501
@@ -167,6 +502,29 @@ class V1alpha1InferenceGraphSpec(object):
 
         self._min_replicas = min_replicas
 
+    @property
+    def node_selector(self):
+        """Gets the node_selector of this V1alpha1InferenceGraphSpec.  # noqa: E501
+
+        NodeSelector is a selector which must be true for the router pod to fit on a node.  # noqa: E501
+
+        :return: The node_selector of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :rtype: dict(str, str)
+        """
+        return self._node_selector
+
+    @node_selector.setter
+    def node_selector(self, node_selector):
+        """Sets the node_selector of this V1alpha1InferenceGraphSpec.
+
+        NodeSelector is a selector which must be true for the router pod to fit on a node.  # noqa: E501
+
+        :param node_selector: The node_selector of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :type: dict(str, str)
+        """
+
+        self._node_selector = node_selector
+
     @property
     def nodes(self):
         """Gets the nodes of this V1alpha1InferenceGraphSpec.  # noqa: E501
@@ -192,6 +550,73 @@ class V1alpha1InferenceGraphSpec(object):
 
         self._nodes = nodes
 
+    @property
+    def plugins(self):
+        """Gets the plugins of this V1alpha1InferenceGraphSpec.  # noqa: E501
+
+        Plugins are the router plugins the nodes of the graph can use to process their requests and responses  # noqa: E501
+
+        :return: The plugins of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :rtype: list[V1alpha1RouterPluginSource]
+        """
+        return self._plugins
+
+    @plugins.setter
+    def plugins(self, plugins):
+        """Sets the plugins of this V1alpha1InferenceGraphSpec.
+
+        Plugins are the router plugins the nodes of the graph can use to process their requests and responses  # noqa: E501
+
+        :param plugins: The plugins of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :type: list[V1alpha1RouterPluginSource]
+        """
+
+        self._plugins = plugins
+
+    @property
+    def priority_class_name(self):
+        """Gets the priority_class_name of this V1alpha1InferenceGraphSpec.  # noqa: E501
+
+        If specified, indicates the priority of the router pod.  # noqa: E501
+
+        :return: The priority_class_name of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._priority_class_name
+
+    @priority_class_name.setter
+    def priority_class_name(self, priority_class_name):
+        """Sets the priority_class_name of this V1alpha1InferenceGraphSpec.
+
+        If specified, indicates the priority of the router pod.  # noqa: E501
+
+        :param priority_class_name: The priority_class_name of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._priority_class_name = priority_class_name
+
+    @property
+    def quota(self):
+        """Gets the quota of this V1alpha1InferenceGraphSpec.  # noqa: E501
+
+
+        :return: The quota of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :rtype: V1alpha1QuotaSpec
+        """
+        return self._quota
+
+    @quota.setter
+    def quota(self, quota):
+        """Sets the quota of this V1alpha1InferenceGraphSpec.
+
+
+        :param quota: The quota of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :type: V1alpha1QuotaSpec
+        """
+
+        self._quota = quota
+
     @property
     def resources(self):
         """Gets the resources of this V1alpha1InferenceGraphSpec.  # noqa: E501
@@ -213,6 +638,50 @@ class V1alpha1InferenceGraphSpec(object):
 
         self._resources = resources
 
+    @property
+    def router_image(self):
+        """Gets the router_image of this V1alpha1InferenceGraphSpec.  # noqa: E501
+
+        RouterImage overrides the image of the router ConfigMap entry for this graph, e.g. to canary a router build. It takes precedence over a router image rollout.  # noqa: E501
+
+        :return: The router_image of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._router_image
+
+    @router_image.setter
+    def router_image(self, router_image):
+        """Sets the router_image of this V1alpha1InferenceGraphSpec.
+
+        RouterImage overrides the image of the router ConfigMap entry for this graph, e.g. to canary a router build. It takes precedence over a router image rollout.  # noqa: E501
+
+        :param router_image: The router_image of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._router_image = router_image
+
+    @property
+    def router_security_context(self):
+        """Gets the router_security_context of this V1alpha1InferenceGraphSpec.  # noqa: E501
+
+
+        :return: The router_security_context of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :rtype: V1alpha1RouterSecurityContext
+        """
+        return self._router_security_context
+
+    @router_security_context.setter
+    def router_security_context(self, router_security_context):
+        """Sets the router_security_context of this V1alpha1InferenceGraphSpec.
+
+
+        :param router_security_context: The router_security_context of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :type: V1alpha1RouterSecurityContext
+        """
+
+        self._router_security_context = router_security_context
+
     @property
     def scale_metric(self):
         """Gets the scale_metric of this V1alpha1InferenceGraphSpec.  # noqa: E501
@@ -259,6 +728,71 @@ class V1alpha1InferenceGraphSpec(object):
 
         self._scale_target = scale_target
 
+    @property
+    def service_account_name(self):
+        """Gets the service_account_name of this V1alpha1InferenceGraphSpec.  # noqa: E501
+
+        ServiceAccountName is the name of the ServiceAccount the router pod runs with, e.g. to pull the router image from a private registry or to use a workload identity. Defaults to the default ServiceAccount of the namespace.  # noqa: E501
+
+        :return: The service_account_name of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._service_account_name
+
+    @service_account_name.setter
+    def service_account_name(self, service_account_name):
+        """Sets the service_account_name of this V1alpha1InferenceGraphSpec.
+
+        ServiceAccountName is the name of the ServiceAccount the router pod runs with, e.g. to pull the router image from a private registry or to use a workload identity. Defaults to the default ServiceAccount of the namespace.  # noqa: E501
+
+        :param service_account_name: The service_account_name of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._service_account_name = service_account_name
+
+    @property
+    def sidecar_logging(self):
+        """Gets the sidecar_logging of this V1alpha1InferenceGraphSpec.  # noqa: E501
+
+
+        :return: The sidecar_logging of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :rtype: V1alpha1SidecarLoggingSpec
+        """
+        return self._sidecar_logging
+
+    @sidecar_logging.setter
+    def sidecar_logging(self, sidecar_logging):
+        """Sets the sidecar_logging of this V1alpha1InferenceGraphSpec.
+
+
+        :param sidecar_logging: The sidecar_logging of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :type: V1alpha1SidecarLoggingSpec
+        """
+
+        self._sidecar_logging = sidecar_logging
+
+    @property
+    def smoke_test(self):
+        """Gets the smoke_test of this V1alpha1InferenceGraphSpec.  # noqa: E501
+
+
+        :return: The smoke_test of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :rtype: V1alpha1SmokeTestSpec
+        """
+        return self._smoke_test
+
+    @smoke_test.setter
+    def smoke_test(self, smoke_test):
+        """Sets the smoke_test of this V1alpha1InferenceGraphSpec.
+
+
+        :param smoke_test: The smoke_test of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :type: V1alpha1SmokeTestSpec
+        """
+
+        self._smoke_test = smoke_test
+
     @property
     def timeout(self):
         """Gets the timeout of this V1alpha1InferenceGraphSpec.  # noqa: E501
@@ -282,6 +816,98 @@ class V1alpha1InferenceGraphSpec(object):
 
         self._timeout = timeout
 
+    @property
+    def tolerations(self):
+        """Gets the tolerations of this V1alpha1InferenceGraphSpec.  # noqa: E501
+
+        If specified, the router pod's tolerations.  # noqa: E501
+
+        :return: The tolerations of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :rtype: list[V1Toleration]
+        """
+        return self._tolerations
+
+    @tolerations.setter
+    def tolerations(self, tolerations):
+        """Sets the tolerations of this V1alpha1InferenceGraphSpec.
+
+        If specified, the router pod's tolerations.  # noqa: E501
+
+        :param tolerations: The tolerations of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :type: list[V1Toleration]
+        """
+
+        self._tolerations = tolerations
+
+    @property
+    def topology_spread_constraints(self):
+        """Gets the topology_spread_constraints of this V1alpha1InferenceGraphSpec.  # noqa: E501
+
+        TopologySpreadConstraints describes how the router pods ought to spread across topology domains.  # noqa: E501
+
+        :return: The topology_spread_constraints of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :rtype: list[V1TopologySpreadConstraint]
+        """
+        return self._topology_spread_constraints
+
+    @topology_spread_constraints.setter
+    def topology_spread_constraints(self, topology_spread_constraints):
+        """Sets the topology_spread_constraints of this V1alpha1InferenceGraphSpec.
+
+        TopologySpreadConstraints describes how the router pods ought to spread across topology domains.  # noqa: E501
+
+        :param topology_spread_constraints: The topology_spread_constraints of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :type: list[V1TopologySpreadConstraint]
+        """
+
+        self._topology_spread_constraints = topology_spread_constraints
+
+    @property
+    def volume_mounts(self):
+        """Gets the volume_mounts of this V1alpha1InferenceGraphSpec.  # noqa: E501
+
+        VolumeMounts of the volumes in the router container, they must mount the volumes of the graph outside of the directories of the router.  # noqa: E501
+
+        :return: The volume_mounts of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :rtype: list[V1VolumeMount]
+        """
+        return self._volume_mounts
+
+    @volume_mounts.setter
+    def volume_mounts(self, volume_mounts):
+        """Sets the volume_mounts of this V1alpha1InferenceGraphSpec.
+
+        VolumeMounts of the volumes in the router container, they must mount the volumes of the graph outside of the directories of the router.  # noqa: E501
+
+        :param volume_mounts: The volume_mounts of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :type: list[V1VolumeMount]
+        """
+
+        self._volume_mounts = volume_mounts
+
+    @property
+    def volumes(self):
+        """Gets the volumes of this V1alpha1InferenceGraphSpec.  # noqa: E501
+
+        Volumes added to the router pod, e.g. a custom CA bundle or the files read by the condition templates. The names of the volumes created by the router, router-tls, router-ca-bundle and router-plugins, are reserved.  # noqa: E501
+
+        :return: The volumes of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :rtype: list[V1Volume]
+        """
+        return self._volumes
+
+    @volumes.setter
+    def volumes(self, volumes):
+        """Sets the volumes of this V1alpha1InferenceGraphSpec.
+
+        Volumes added to the router pod, e.g. a custom CA bundle or the files read by the condition templates. The names of the volumes created by the router, router-tls, router-ca-bundle and router-plugins, are reserved.  # noqa: E501
+
+        :param volumes: The volumes of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :type: list[V1Volume]
+        """
+
+        self._volumes = volumes
+
     def to_dict(self):
         """Returns the model properties as a dict"""
         result = {}
@@ -49,18 +49,26 @@ class V1alpha1InferenceGraphStatus(object):
     openapi_types = {
         'annotations': 'dict(str, str)',
         'conditions': 'list[KnativeCondition]',
+        'effective_config': 'V1alpha1EffectiveConfig',
+        'endpoints': 'list[V1alpha1Endpoint]',
         'observed_generation': 'int',
+        'revisions': 'V1alpha1RouterRevisionStatus',
+        'smoke_test': 'V1alpha1SmokeTestStatus',
         'url': 'KnativeURL'
     }
 
     attribute_map = {
         'annotations': 'annotations',
         'conditions': 'conditions',
+        'effective_config': 'effectiveConfig',
+        'endpoints': 'endpoints',
         'observed_generation': 'observedGeneration',
+        'revisions': 'revisions',
+        'smoke_test': 'smokeTest',
         'url': 'url'
     }
 
-    def __init__(self, annotations=None, conditions=None, observed_generation=None, url=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, annotations=None, conditions=None, effective_config=None, endpoints=None, observed_generation=None, revisions=None, smoke_test=None, url=None, local_vars_configuration=None):  # noqa: E501
         """V1alpha1InferenceGraphStatus - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
@@ -68,7 +76,11 @@ class V1alpha1InferenceGraphStatus(object):
 
         self._annotations = None
         self._conditions = None
+        self._effective_config = None
+        self._endpoints = None
         self._observed_generation = None
+        self._revisions = None
+        self._smoke_test = None
         self._url = None
         self.discriminator = None
 
@@ -76,8 +88,16 @@ class V1alpha1InferenceGraphStatus(object):
             self.annotations = annotations
         if conditions is not None:
             self.conditions = conditions
+        if effective_config is not None:
+            self.effective_config = effective_config
+        if endpoints is not None:
+            self.endpoints = endpoints
         if observed_generation is not None:
             self.observed_generation = observed_generation
+        if revisions is not None:
+            self.revisions = revisions
+        if smoke_test is not None:
+            self.smoke_test = smoke_test
         if url is not None:
             self.url = url
 
@@ -127,6 +147,50 @@ class V1alpha1InferenceGraphStatus(object):
 
         self._conditions = conditions
 
+    @property
+    def effective_config(self):
+        """Gets the effective_config of this V1alpha1InferenceGraphStatus.  # noqa: E501
+
+
+        :return: The effective_config of this V1alpha1InferenceGraphStatus.  # noqa: E501
+        :rtype: V1alpha1EffectiveConfig
+        """
+        return self._effective_config
+
+    @effective_config.setter
+    def effective_config(self, effective_config):
+        """Sets the effective_config of this V1alpha1InferenceGraphStatus.
+
+
+        :param effective_config: The effective_config of this V1alpha1InferenceGraphStatus.  # noqa: E501
+        :type: V1alpha1EffectiveConfig
+        """
+
+        self._effective_config = effective_config
+
+    @property
+    def endpoints(self):
+        """Gets the endpoints of this V1alpha1InferenceGraphStatus.  # noqa: E501
+
+        Endpoints lists all the addresses the InferenceGraph is reachable at, per protocol and visibility  # noqa: E501
+
+        :return: The endpoints of this V1alpha1InferenceGraphStatus.  # noqa: E501
+        :rtype: list[V1alpha1Endpoint]
+        """
+        return self._endpoints
+
+    @endpoints.setter
+    def endpoints(self, endpoints):
+        """Sets the endpoints of this V1alpha1InferenceGraphStatus.
+
+        Endpoints lists all the addresses the InferenceGraph is reachable at, per protocol and visibility  # noqa: E501
+
+        :param endpoints: The endpoints of this V1alpha1InferenceGraphStatus.  # noqa: E501
+        :type: list[V1alpha1Endpoint]
+        """
+
+        self._endpoints = endpoints
+
     @property
     def observed_generation(self):
         """Gets the observed_generation of this V1alpha1InferenceGraphStatus.  # noqa: E501
@@ -150,6 +214,48 @@ class V1alpha1InferenceGraphStatus(object):
 
         self._observed_generation = observed_generation
 
+    @property
+    def revisions(self):
+        """Gets the revisions of this V1alpha1InferenceGraphStatus.  # noqa: E501
+
+
+        :return: The revisions of this V1alpha1InferenceGraphStatus.  # noqa: E501
+        :rtype: V1alpha1RouterRevisionStatus
+        """
+        return self._revisions
+
+    @revisions.setter
+    def revisions(self, revisions):
+        """Sets the revisions of this V1alpha1InferenceGraphStatus.
+
+
+        :param revisions: The revisions of this V1alpha1InferenceGraphStatus.  # noqa: E501
+        :type: V1alpha1RouterRevisionStatus
+        """
+
+        self._revisions = revisions
+
+    @property
+    def smoke_test(self):
+        """Gets the smoke_test of this V1alpha1InferenceGraphStatus.  # noqa: E501
+
+
+        :return: The smoke_test of this V1alpha1InferenceGraphStatus.  # noqa: E501
+        :rtype: V1alpha1SmokeTestStatus
+        """
+        return self._smoke_test
+
+    @smoke_test.setter
+    def smoke_test(self, smoke_test):
+        """Sets the smoke_test of this V1alpha1InferenceGraphStatus.
+
+
+        :param smoke_test: The smoke_test of this V1alpha1InferenceGraphStatus.  # noqa: E501
+        :type: V1alpha1SmokeTestStatus
+        """
+
+        self._smoke_test = smoke_test
+
     @property
     def url(self):
         """Gets the url of this V1alpha1InferenceGraphStatus.  # noqa: E501
@@ -47,34 +47,168 @@ class V1alpha1InferenceRouter(object):
                             and the value is json key in definition.
     """
     openapi_types = {
+        'aggregation': 'V1alpha1AggregationSpec',
+        'map': 'V1alpha1MapRouterSpec',
+        'plugins': 'list[V1alpha1NodePlugin]',
+        'resources': 'V1ResourceRequirements',
+        'response_aggregation': 'str',
         'router_type': 'str',
         'steps': 'list[V1alpha1InferenceStep]'
     }
 
     attribute_map = {
+        'aggregation': 'aggregation',
+        'map': 'map',
+        'plugins': 'plugins',
+        'resources': 'resources',
+        'response_aggregation': 'responseAggregation',
         'router_type': 'routerType',
         'steps': 'steps'
     }
 
-    def __init__(self, router_type='', steps=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, aggregation=None, map=None, plugins=None, resources=None, response_aggregation=None, router_type='', steps=None, local_vars_configuration=None):  # noqa: E501
         """V1alpha1InferenceRouter - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
         self.local_vars_configuration = local_vars_configuration
 
+        self._aggregation = None
+        self._map = None
+        self._plugins = None
+        self._resources = None
+        self._response_aggregation = None
         self._router_type = None
         self._steps = None
         self.discriminator = None
 
+        if aggregation is not None:
+            self.aggregation = aggregation
+        if map is not None:
+            self.map = map
+        if plugins is not None:
+            self.plugins = plugins
+        if resources is not None:
+            self.resources = resources
+        if response_aggregation is not None:
+            self.response_aggregation = response_aggregation
         self.router_type = router_type
         if steps is not None:
             self.steps = steps
 
+    @property
+    def aggregation(self):
+        """Gets the aggregation of this V1alpha1InferenceRouter.  # noqa: E501
+
+
+        :return: The aggregation of this V1alpha1InferenceRouter.  # noqa: E501
+        :rtype: V1alpha1AggregationSpec
+        """
+        return self._aggregation
+
+    @aggregation.setter
+    def aggregation(self, aggregation):
+        """Sets the aggregation of this V1alpha1InferenceRouter.
+
+
+        :param aggregation: The aggregation of this V1alpha1InferenceRouter.  # noqa: E501
+        :type: V1alpha1AggregationSpec
+        """
+
+        self._aggregation = aggregation
+
+    @property
+    def map(self):
+        """Gets the map of this V1alpha1InferenceRouter.  # noqa: E501
+
+
+        :return: The map of this V1alpha1InferenceRouter.  # noqa: E501
+        :rtype: V1alpha1MapRouterSpec
+        """
+        return self._map
+
+    @map.setter
+    def map(self, map):
+        """Sets the map of this V1alpha1InferenceRouter.
+
+
+        :param map: The map of this V1alpha1InferenceRouter.  # noqa: E501
+        :type: V1alpha1MapRouterSpec
+        """
+
+        self._map = map
+
+    @property
+    def plugins(self):
+        """Gets the plugins of this V1alpha1InferenceRouter.  # noqa: E501
+
+        Plugins process the request of the node before it is routed to the steps and the response of the node, in order  # noqa: E501
+
+        :return: The plugins of this V1alpha1InferenceRouter.  # noqa: E501
+        :rtype: list[V1alpha1NodePlugin]
+        """
+        return self._plugins
+
+    @plugins.setter
+    def plugins(self, plugins):
+        """Sets the plugins of this V1alpha1InferenceRouter.
+
+        Plugins process the request of the node before it is routed to the steps and the response of the node, in order  # noqa: E501
+
+        :param plugins: The plugins of this V1alpha1InferenceRouter.  # noqa: E501
+        :type: list[V1alpha1NodePlugin]
+        """
+
+        self._plugins = plugins
+
+    @property
+    def resources(self):
+        """Gets the resources of this V1alpha1InferenceRouter.  # noqa: E501
+
+
+        :return: The resources of this V1alpha1InferenceRouter.  # noqa: E501
+        :rtype: V1ResourceRequirements
+        """
+        return self._resources
+
+    @resources.setter
+    def resources(self, resources):
+        """Sets the resources of this V1alpha1InferenceRouter.
+
+
+        :param resources: The resources of this V1alpha1InferenceRouter.  # noqa: E501
+        :type: V1ResourceRequirements
+        """
+
+        self._resources = resources
+
+    @property
+    def response_aggregation(self):
+        """Gets the response_aggregation of this V1alpha1InferenceRouter.  # noqa: E501
+
+        ResponseAggregation defines how the responses of the steps are merged into the response of the node, only applies to Splitter and Ensemble nodes  - `Keyed:` an object keyed by step name, or by step index for unnamed steps. Default for Ensemble nodes  - `Array:` an array of the responses in the order of the steps  - `FirstSuccess:` the first successful response as is. Default for Splitter nodes  - `MajorityVote:` for each instance, the prediction returned by most steps. Only for Ensemble nodes  - `Average:` for each instance, the element-wise average of the numeric predictions. Only for Ensemble nodes  - `Concat:` the predictions of the steps one after the other. Only for Ensemble nodes  - `Template:` the responses of the steps rendered with the template of the aggregation. Only for Ensemble nodes  # noqa: E501
+
+        :return: The response_aggregation of this V1alpha1InferenceRouter.  # noqa: E501
+        :rtype: str
+        """
+        return self._response_aggregation
+
+    @response_aggregation.setter
+    def response_aggregation(self, response_aggregation):
+        """Sets the response_aggregation of this V1alpha1InferenceRouter.
+
+        ResponseAggregation defines how the responses of the steps are merged into the response of the node, only applies to Splitter and Ensemble nodes  - `Keyed:` an object keyed by step name, or by step index for unnamed steps. Default for Ensemble nodes  - `Array:` an array of the responses in the order of the steps  - `FirstSuccess:` the first successful response as is. Default for Splitter nodes  - `MajorityVote:` for each instance, the prediction returned by most steps. Only for Ensemble nodes  - `Average:` for each instance, the element-wise average of the numeric predictions. Only for Ensemble nodes  - `Concat:` the predictions of the steps one after the other. Only for Ensemble nodes  - `Template:` the responses of the steps rendered with the template of the aggregation. Only for Ensemble nodes  # noqa: E501
+
+        :param response_aggregation: The response_aggregation of this V1alpha1InferenceRouter.  # noqa: E501
+        :type: str
+        """
+
+        self._response_aggregation = response_aggregation
+
     @property
     def router_type(self):
         """Gets the router_type of this V1alpha1InferenceRouter.  # noqa: E501
 
-        RouterType  - `Sequence:` chain multiple inference steps with input/output from previous step  - `Splitter:` randomly routes to the target service according to the weight  - `Ensemble:` routes the request to multiple models and then merge the responses  - `Switch:` routes the request to one of the steps based on condition  # noqa: E501
+        RouterType  - `Sequence:` chain multiple inference steps with input/output from previous step  - `Splitter:` randomly routes to the target service according to the weight  - `Ensemble:` routes the request to multiple models and then merge the responses  - `Switch:` routes the request to one of the steps based on condition  - `Map:` splits the request into per-item requests to its single step and reassembles the results in order  # noqa: E501
 
         :return: The router_type of this V1alpha1InferenceRouter.  # noqa: E501
         :rtype: str
@@ -85,7 +219,7 @@ class V1alpha1InferenceRouter(object):
     def router_type(self, router_type):
         """Sets the router_type of this V1alpha1InferenceRouter.
 
-        RouterType  - `Sequence:` chain multiple inference steps with input/output from previous step  - `Splitter:` randomly routes to the target service according to the weight  - `Ensemble:` routes the request to multiple models and then merge the responses  - `Switch:` routes the request to one of the steps based on condition  # noqa: E501
+        RouterType  - `Sequence:` chain multiple inference steps with input/output from previous step  - `Splitter:` randomly routes to the target service according to the weight  - `Ensemble:` routes the request to multiple models and then merge the responses  - `Switch:` routes the request to one of the steps based on condition  - `Map:` splits the request into per-item requests to its single step and reassembles the results in order  # noqa: E501
 
         :param router_type: The router_type of this V1alpha1InferenceRouter.  # noqa: E501
         :type: str
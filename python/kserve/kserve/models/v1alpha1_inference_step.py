@@ -47,65 +47,136 @@ class V1alpha1InferenceStep(object):
                             and the value is json key in definition.
     """
     openapi_types = {
+        'circuit_breaker': 'V1alpha1CircuitBreakerPolicy',
         'condition': 'str',
         'data': 'str',
         'dependency': 'str',
+        'expression': 'str',
+        'headers': 'list[V1alpha1StepHeader]',
         'name': 'str',
         'node_name': 'str',
+        'on_condition_not_met': 'str',
+        'remove_headers': 'list[str]',
+        'retries': 'int',
+        'retry_backoff': 'V1Duration',
         'service_name': 'str',
+        'service_namespace': 'str',
         'service_url': 'str',
+        'timeout': 'V1Duration',
+        'translation': 'V1alpha1ProtocolTranslation',
         'weight': 'int'
     }
 
     attribute_map = {
+        'circuit_breaker': 'circuitBreaker',
         'condition': 'condition',
         'data': 'data',
         'dependency': 'dependency',
+        'expression': 'expression',
+        'headers': 'headers',
         'name': 'name',
         'node_name': 'nodeName',
+        'on_condition_not_met': 'onConditionNotMet',
+        'remove_headers': 'removeHeaders',
+        'retries': 'retries',
+        'retry_backoff': 'retryBackoff',
         'service_name': 'serviceName',
+        'service_namespace': 'serviceNamespace',
         'service_url': 'serviceUrl',
+        'timeout': 'timeout',
+        'translation': 'translation',
         'weight': 'weight'
     }
 
-    def __init__(self, condition=None, data=None, dependency=None, name=None, node_name=None, service_name=None, service_url=None, weight=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, circuit_breaker=None, condition=None, data=None, dependency=None, expression=None, headers=None, name=None, node_name=None, on_condition_not_met=None, remove_headers=None, retries=None, retry_backoff=None, service_name=None, service_namespace=None, service_url=None, timeout=None, translation=None, weight=None, local_vars_configuration=None):  # noqa: E501
         """V1alpha1InferenceStep - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
         self.local_vars_configuration = local_vars_configuration
 
+        self._circuit_breaker = None
         self._condition = None
         self._data = None
         self._dependency = None
+        self._expression = None
+        self._headers = None
         self._name = None
         self._node_name = None
+        self._on_condition_not_met = None
+        self._remove_headers = None
+        self._retries = None
+        self._retry_backoff = None
         self._service_name = None
+        self._service_namespace = None
         self._service_url = None
+        self._timeout = None
+        self._translation = None
         self._weight = None
         self.discriminator = None
 
+        if circuit_breaker is not None:
+            self.circuit_breaker = circuit_breaker
         if condition is not None:
             self.condition = condition
         if data is not None:
             self.data = data
         if dependency is not None:
             self.dependency = dependency
+        if expression is not None:
+            self.expression = expression
+        if headers is not None:
+            self.headers = headers
         if name is not None:
             self.name = name
         if node_name is not None:
             self.node_name = node_name
+        if on_condition_not_met is not None:
+            self.on_condition_not_met = on_condition_not_met
+        if remove_headers is not None:
+            self.remove_headers = remove_headers
+        if retries is not None:
+            self.retries = retries
+        if retry_backoff is not None:
+            self.retry_backoff = retry_backoff
         if service_name is not None:
             self.service_name = service_name
+        if service_namespace is not None:
+            self.service_namespace = service_namespace
         if service_url is not None:
             self.service_url = service_url
+        if timeout is not None:
+            self.timeout = timeout
+        if translation is not None:
+            self.translation = translation
         if weight is not None:
             self.weight = weight
 
+    @property
+    def circuit_breaker(self):
+        """Gets the circuit_breaker of this V1alpha1InferenceStep.  # noqa: E501
+
+
+        :return: The circuit_breaker of this V1alpha1InferenceStep.  # noqa: E501
+        :rtype: V1alpha1CircuitBreakerPolicy
+        """
+        return self._circuit_breaker
+
+    @circuit_breaker.setter
+    def circuit_breaker(self, circuit_breaker):
+        """Sets the circuit_breaker of this V1alpha1InferenceStep.
+
+
+        :param circuit_breaker: The circuit_breaker of this V1alpha1InferenceStep.  # noqa: E501
+        :type: V1alpha1CircuitBreakerPolicy
+        """
+
+        self._circuit_breaker = circuit_breaker
+
     @property
     def condition(self):
         """Gets the condition of this V1alpha1InferenceStep.  # noqa: E501
 
-        routing based on the condition  # noqa: E501
+        routing based on the condition  In a Sequence node the step is executed when the condition matches the response of the previous step. The condition can instead match the request of the node with the `$request.` prefix or the response of a previous step of the node with the `$steps.<step name>.` prefix, e.g. `$steps.classifier.predictions.#(label==\"dog\")`.  # noqa: E501
 
         :return: The condition of this V1alpha1InferenceStep.  # noqa: E501
         :rtype: str
@@ -116,7 +187,7 @@ class V1alpha1InferenceStep(object):
     def condition(self, condition):
         """Sets the condition of this V1alpha1InferenceStep.
 
-        routing based on the condition  # noqa: E501
+        routing based on the condition  In a Sequence node the step is executed when the condition matches the response of the previous step. The condition can instead match the request of the node with the `$request.` prefix or the response of a previous step of the node with the `$steps.<step name>.` prefix, e.g. `$steps.classifier.predictions.#(label==\"dog\")`.  # noqa: E501
 
         :param condition: The condition of this V1alpha1InferenceStep.  # noqa: E501
         :type: str
@@ -170,6 +241,52 @@ class V1alpha1InferenceStep(object):
 
         self._dependency = dependency
 
+    @property
+    def expression(self):
+        """Gets the expression of this V1alpha1InferenceStep.  # noqa: E501
+
+        CEL expression routing the requests of a Switch node, the request is forwarded to the first step whose expression or condition matches. The expression can use the JSON body of the request as `body` and its headers, with lower case names, as `headers`, e.g. `body.instances[0].age >= 18 && headers[\"x-tenant\"] == \"gold\"`. Only supported on the steps of a Switch node and cannot be combined with condition.  # noqa: E501
+
+        :return: The expression of this V1alpha1InferenceStep.  # noqa: E501
+        :rtype: str
+        """
+        return self._expression
+
+    @expression.setter
+    def expression(self, expression):
+        """Sets the expression of this V1alpha1InferenceStep.
+
+        CEL expression routing the requests of a Switch node, the request is forwarded to the first step whose expression or condition matches. The expression can use the JSON body of the request as `body` and its headers, with lower case names, as `headers`, e.g. `body.instances[0].age >= 18 && headers[\"x-tenant\"] == \"gold\"`. Only supported on the steps of a Switch node and cannot be combined with condition.  # noqa: E501
+
+        :param expression: The expression of this V1alpha1InferenceStep.  # noqa: E501
+        :type: str
+        """
+
+        self._expression = expression
+
+    @property
+    def headers(self):
+        """Gets the headers of this V1alpha1InferenceStep.  # noqa: E501
+
+        headers set on the requests to the target service of the step, they override the headers propagated by the router. Only supported on steps with a serviceName or serviceUrl target.  # noqa: E501
+
+        :return: The headers of this V1alpha1InferenceStep.  # noqa: E501
+        :rtype: list[V1alpha1StepHeader]
+        """
+        return self._headers
+
+    @headers.setter
+    def headers(self, headers):
+        """Sets the headers of this V1alpha1InferenceStep.
+
+        headers set on the requests to the target service of the step, they override the headers propagated by the router. Only supported on steps with a serviceName or serviceUrl target.  # noqa: E501
+
+        :param headers: The headers of this V1alpha1InferenceStep.  # noqa: E501
+        :type: list[V1alpha1StepHeader]
+        """
+
+        self._headers = headers
+
     @property
     def name(self):
         """Gets the name of this V1alpha1InferenceStep.  # noqa: E501
@@ -216,6 +333,96 @@ class V1alpha1InferenceStep(object):
 
         self._node_name = node_name
 
+    @property
+    def on_condition_not_met(self):
+        """Gets the on_condition_not_met of this V1alpha1InferenceStep.  # noqa: E501
+
+        action of a Sequence node when the condition of the step does not match, `Stop` returns the response of the previous step and `Skip` continues with the next step. Defaults to `Stop`.  # noqa: E501
+
+        :return: The on_condition_not_met of this V1alpha1InferenceStep.  # noqa: E501
+        :rtype: str
+        """
+        return self._on_condition_not_met
+
+    @on_condition_not_met.setter
+    def on_condition_not_met(self, on_condition_not_met):
+        """Sets the on_condition_not_met of this V1alpha1InferenceStep.
+
+        action of a Sequence node when the condition of the step does not match, `Stop` returns the response of the previous step and `Skip` continues with the next step. Defaults to `Stop`.  # noqa: E501
+
+        :param on_condition_not_met: The on_condition_not_met of this V1alpha1InferenceStep.  # noqa: E501
+        :type: str
+        """
+
+        self._on_condition_not_met = on_condition_not_met
+
+    @property
+    def remove_headers(self):
+        """Gets the remove_headers of this V1alpha1InferenceStep.  # noqa: E501
+
+        names of the headers removed from the requests to the target service of the step, applied before the headers are set. Only supported on steps with a serviceName or serviceUrl target.  # noqa: E501
+
+        :return: The remove_headers of this V1alpha1InferenceStep.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._remove_headers
+
+    @remove_headers.setter
+    def remove_headers(self, remove_headers):
+        """Sets the remove_headers of this V1alpha1InferenceStep.
+
+        names of the headers removed from the requests to the target service of the step, applied before the headers are set. Only supported on steps with a serviceName or serviceUrl target.  # noqa: E501
+
+        :param remove_headers: The remove_headers of this V1alpha1InferenceStep.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._remove_headers = remove_headers
+
+    @property
+    def retries(self):
+        """Gets the retries of this V1alpha1InferenceStep.  # noqa: E501
+
+        number of times the call to the target service of the step is retried when it fails with an error, a timeout or a 502, 503 or 504 status. The calls are not retried when not set. Only supported on steps with a serviceName or serviceUrl target.  # noqa: E501
+
+        :return: The retries of this V1alpha1InferenceStep.  # noqa: E501
+        :rtype: int
+        """
+        return self._retries
+
+    @retries.setter
+    def retries(self, retries):
+        """Sets the retries of this V1alpha1InferenceStep.
+
+        number of times the call to the target service of the step is retried when it fails with an error, a timeout or a 502, 503 or 504 status. The calls are not retried when not set. Only supported on steps with a serviceName or serviceUrl target.  # noqa: E501
+
+        :param retries: The retries of this V1alpha1InferenceStep.  # noqa: E501
+        :type: int
+        """
+
+        self._retries = retries
+
+    @property
+    def retry_backoff(self):
+        """Gets the retry_backoff of this V1alpha1InferenceStep.  # noqa: E501
+
+
+        :return: The retry_backoff of this V1alpha1InferenceStep.  # noqa: E501
+        :rtype: V1Duration
+        """
+        return self._retry_backoff
+
+    @retry_backoff.setter
+    def retry_backoff(self, retry_backoff):
+        """Sets the retry_backoff of this V1alpha1InferenceStep.
+
+
+        :param retry_backoff: The retry_backoff of this V1alpha1InferenceStep.  # noqa: E501
+        :type: V1Duration
+        """
+
+        self._retry_backoff = retry_backoff
+
     @property
     def service_name(self):
         """Gets the service_name of this V1alpha1InferenceStep.  # noqa: E501
@@ -239,6 +446,29 @@ class V1alpha1InferenceStep(object):
 
         self._service_name = service_name
 
+    @property
+    def service_namespace(self):
+        """Gets the service_namespace of this V1alpha1InferenceStep.  # noqa: E501
+
+        Namespace of the InferenceService named by serviceName, defaults to the namespace of the InferenceGraph. The InferenceServices of other namespaces can only be targeted when the router config enables cross namespace targets.  # noqa: E501
+
+        :return: The service_namespace of this V1alpha1InferenceStep.  # noqa: E501
+        :rtype: str
+        """
+        return self._service_namespace
+
+    @service_namespace.setter
+    def service_namespace(self, service_namespace):
+        """Sets the service_namespace of this V1alpha1InferenceStep.
+
+        Namespace of the InferenceService named by serviceName, defaults to the namespace of the InferenceGraph. The InferenceServices of other namespaces can only be targeted when the router config enables cross namespace targets.  # noqa: E501
+
+        :param service_namespace: The service_namespace of this V1alpha1InferenceStep.  # noqa: E501
+        :type: str
+        """
+
+        self._service_namespace = service_namespace
+
     @property
     def service_url(self):
         """Gets the service_url of this V1alpha1InferenceStep.  # noqa: E501
@@ -262,6 +492,48 @@ class V1alpha1InferenceStep(object):
 
         self._service_url = service_url
 
+    @property
+    def timeout(self):
+        """Gets the timeout of this V1alpha1InferenceStep.  # noqa: E501
+
+
+        :return: The timeout of this V1alpha1InferenceStep.  # noqa: E501
+        :rtype: V1Duration
+        """
+        return self._timeout
+
+    @timeout.setter
+    def timeout(self, timeout):
+        """Sets the timeout of this V1alpha1InferenceStep.
+
+
+        :param timeout: The timeout of this V1alpha1InferenceStep.  # noqa: E501
+        :type: V1Duration
+        """
+
+        self._timeout = timeout
+
+    @property
+    def translation(self):
+        """Gets the translation of this V1alpha1InferenceStep.  # noqa: E501
+
+
+        :return: The translation of this V1alpha1InferenceStep.  # noqa: E501
+        :rtype: V1alpha1ProtocolTranslation
+        """
+        return self._translation
+
+    @translation.setter
+    def translation(self, translation):
+        """Sets the translation of this V1alpha1InferenceStep.
+
+
+        :param translation: The translation of this V1alpha1InferenceStep.  # noqa: E501
+        :type: V1alpha1ProtocolTranslation
+        """
+
+        self._translation = translation
+
     @property
     def weight(self):
         """Gets the weight of this V1alpha1InferenceStep.  # noqa: E501
@@ -49,16 +49,18 @@ class V1alpha1InferenceTarget(object):
     openapi_types = {
         'node_name': 'str',
         'service_name': 'str',
+        'service_namespace': 'str',
         'service_url': 'str'
     }
 
     attribute_map = {
         'node_name': 'nodeName',
         'service_name': 'serviceName',
+        'service_namespace': 'serviceNamespace',
         'service_url': 'serviceUrl'
     }
 
-    def __init__(self, node_name=None, service_name=None, service_url=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, node_name=None, service_name=None, service_namespace=None, service_url=None, local_vars_configuration=None):  # noqa: E501
         """V1alpha1InferenceTarget - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
@@ -66,6 +68,7 @@ class V1alpha1InferenceTarget(object):
 
         self._node_name = None
         self._service_name = None
+        self._service_namespace = None
         self._service_url = None
         self.discriminator = None
 
@@ -73,6 +76,8 @@ class V1alpha1InferenceTarget(object):
             self.node_name = node_name
         if service_name is not None:
             self.service_name = service_name
+        if service_namespace is not None:
+            self.service_namespace = service_namespace
         if service_url is not None:
             self.service_url = service_url
 
@@ -122,6 +127,29 @@ class V1alpha1InferenceTarget(object):
 
         self._service_name = service_name
 
+    @property
+    def service_namespace(self):
+        """Gets the service_namespace of this V1alpha1InferenceTarget.  # noqa: E501
+
+        Namespace of the InferenceService named by serviceName, defaults to the namespace of the InferenceGraph. The InferenceServices of other namespaces can only be targeted when the router config enables cross namespace targets.  # noqa: E501
+
+        :return: The service_namespace of this V1alpha1InferenceTarget.  # noqa: E501
+        :rtype: str
+        """
+        return self._service_namespace
+
+    @service_namespace.setter
+    def service_namespace(self, service_namespace):
+        """Sets the service_namespace of this V1alpha1InferenceTarget.
+
+        Namespace of the InferenceService named by serviceName, defaults to the namespace of the InferenceGraph. The InferenceServices of other namespaces can only be targeted when the router config enables cross namespace targets.  # noqa: E501
+
+        :param service_namespace: The service_namespace of this V1alpha1InferenceTarget.  # noqa: E501
+        :type: str
+        """
+
+        self._service_namespace = service_namespace
+
     @property
     def service_url(self):
         """Gets the service_url of this V1alpha1InferenceTarget.  # noqa: E501
@@ -0,0 +1,164 @@
+# Copyright 2023 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1LoadSheddingPolicy(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'max_in_flight_calls_per_target': 'int',
+        'max_in_flight_requests': 'int'
+    }
+
+    attribute_map = {
+        'max_in_flight_calls_per_target': 'maxInFlightCallsPerTarget',
+        'max_in_flight_requests': 'maxInFlightRequests'
+    }
+
+    def __init__(self, max_in_flight_calls_per_target=None, max_in_flight_requests=None, local_vars_configuration=None):  # noqa: E501
+        """V1alpha1LoadSheddingPolicy - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._max_in_flight_calls_per_target = None
+        self._max_in_flight_requests = None
+        self.discriminator = None
+
+        if max_in_flight_calls_per_target is not None:
+            self.max_in_flight_calls_per_target = max_in_flight_calls_per_target
+        if max_in_flight_requests is not None:
+            self.max_in_flight_requests = max_in_flight_requests
+
+    @property
+    def max_in_flight_calls_per_target(self):
+        """Gets the max_in_flight_calls_per_target of this V1alpha1LoadSheddingPolicy.  # noqa: E501
+
+        Maximum number of concurrent calls to a single target service, so that a slow target cannot hold all the requests of the router. The calls over it fail right away. The calls are unlimited when not set.  # noqa: E501
+
+        :return: The max_in_flight_calls_per_target of this V1alpha1LoadSheddingPolicy.  # noqa: E501
+        :rtype: int
+        """
+        return self._max_in_flight_calls_per_target
+
+    @max_in_flight_calls_per_target.setter
+    def max_in_flight_calls_per_target(self, max_in_flight_calls_per_target):
+        """Sets the max_in_flight_calls_per_target of this V1alpha1LoadSheddingPolicy.
+
+        Maximum number of concurrent calls to a single target service, so that a slow target cannot hold all the requests of the router. The calls over it fail right away. The calls are unlimited when not set.  # noqa: E501
+
+        :param max_in_flight_calls_per_target: The max_in_flight_calls_per_target of this V1alpha1LoadSheddingPolicy.  # noqa: E501
+        :type: int
+        """
+
+        self._max_in_flight_calls_per_target = max_in_flight_calls_per_target
+
+    @property
+    def max_in_flight_requests(self):
+        """Gets the max_in_flight_requests of this V1alpha1LoadSheddingPolicy.  # noqa: E501
+
+        Maximum number of requests processed concurrently by the router, the requests are unlimited when not set  # noqa: E501
+
+        :return: The max_in_flight_requests of this V1alpha1LoadSheddingPolicy.  # noqa: E501
+        :rtype: int
+        """
+        return self._max_in_flight_requests
+
+    @max_in_flight_requests.setter
+    def max_in_flight_requests(self, max_in_flight_requests):
+        """Sets the max_in_flight_requests of this V1alpha1LoadSheddingPolicy.
+
+        Maximum number of requests processed concurrently by the router, the requests are unlimited when not set  # noqa: E501
+
+        :param max_in_flight_requests: The max_in_flight_requests of this V1alpha1LoadSheddingPolicy.  # noqa: E501
+        :type: int
+        """
+
+        self._max_in_flight_requests = max_in_flight_requests
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1LoadSheddingPolicy):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1LoadSheddingPolicy):
+            return True
+
+        return self.to_dict() != other.to_dict()
@@ -0,0 +1,248 @@
+# Copyright 2023 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1MapRouterSpec(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'format': 'str',
+        'items_field': 'str',
+        'max_items': 'int',
+        'parallelism': 'int',
+        'results_field': 'str'
+    }
+
+    attribute_map = {
+        'format': 'format',
+        'items_field': 'itemsField',
+        'max_items': 'maxItems',
+        'parallelism': 'parallelism',
+        'results_field': 'resultsField'
+    }
+
+    def __init__(self, format=None, items_field=None, max_items=None, parallelism=None, results_field=None, local_vars_configuration=None):  # noqa: E501
+        """V1alpha1MapRouterSpec - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._format = None
+        self._items_field = None
+        self._max_items = None
+        self._parallelism = None
+        self._results_field = None
+        self.discriminator = None
+
+        if format is not None:
+            self.format = format
+        if items_field is not None:
+            self.items_field = items_field
+        if max_items is not None:
+            self.max_items = max_items
+        if parallelism is not None:
+            self.parallelism = parallelism
+        if results_field is not None:
+            self.results_field = results_field
+
+    @property
+    def format(self):
+        """Gets the format of this V1alpha1MapRouterSpec.  # noqa: E501
+
+        Protocol of the request, defaults to `v1`. V1 requests are split along the array of the items field, V2 requests along the first dimension of their input tensors.  # noqa: E501
+
+        :return: The format of this V1alpha1MapRouterSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._format
+
+    @format.setter
+    def format(self, format):
+        """Sets the format of this V1alpha1MapRouterSpec.
+
+        Protocol of the request, defaults to `v1`. V1 requests are split along the array of the items field, V2 requests along the first dimension of their input tensors.  # noqa: E501
+
+        :param format: The format of this V1alpha1MapRouterSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._format = format
+
+    @property
+    def items_field(self):
+        """Gets the items_field of this V1alpha1MapRouterSpec.  # noqa: E501
+
+        Field of the V1 request holding the array of items, defaults to `instances`. Each item is sent in a copy of the request whose field holds an array of the single item.  # noqa: E501
+
+        :return: The items_field of this V1alpha1MapRouterSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._items_field
+
+    @items_field.setter
+    def items_field(self, items_field):
+        """Sets the items_field of this V1alpha1MapRouterSpec.
+
+        Field of the V1 request holding the array of items, defaults to `instances`. Each item is sent in a copy of the request whose field holds an array of the single item.  # noqa: E501
+
+        :param items_field: The items_field of this V1alpha1MapRouterSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._items_field = items_field
+
+    @property
+    def max_items(self):
+        """Gets the max_items of this V1alpha1MapRouterSpec.  # noqa: E501
+
+        Maximum number of items of a request, requests with more items are rejected. Defaults to 256  # noqa: E501
+
+        :return: The max_items of this V1alpha1MapRouterSpec.  # noqa: E501
+        :rtype: int
+        """
+        return self._max_items
+
+    @max_items.setter
+    def max_items(self, max_items):
+        """Sets the max_items of this V1alpha1MapRouterSpec.
+
+        Maximum number of items of a request, requests with more items are rejected. Defaults to 256  # noqa: E501
+
+        :param max_items: The max_items of this V1alpha1MapRouterSpec.  # noqa: E501
+        :type: int
+        """
+
+        self._max_items = max_items
+
+    @property
+    def parallelism(self):
+        """Gets the parallelism of this V1alpha1MapRouterSpec.  # noqa: E501
+
+        Maximum number of items sent to the step in parallel, defaults to 8  # noqa: E501
+
+        :return: The parallelism of this V1alpha1MapRouterSpec.  # noqa: E501
+        :rtype: int
+        """
+        return self._parallelism
+
+    @parallelism.setter
+    def parallelism(self, parallelism):
+        """Sets the parallelism of this V1alpha1MapRouterSpec.
+
+        Maximum number of items sent to the step in parallel, defaults to 8  # noqa: E501
+
+        :param parallelism: The parallelism of this V1alpha1MapRouterSpec.  # noqa: E501
+        :type: int
+        """
+
+        self._parallelism = parallelism
+
+    @property
+    def results_field(self):
+        """Gets the results_field of this V1alpha1MapRouterSpec.  # noqa: E501
+
+        Field of the V1 responses holding the result of an item, defaults to `predictions`. A result holding an array of a single element is unwrapped, the results are returned in the same field in the order of the items.  # noqa: E501
+
+        :return: The results_field of this V1alpha1MapRouterSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._results_field
+
+    @results_field.setter
+    def results_field(self, results_field):
+        """Sets the results_field of this V1alpha1MapRouterSpec.
+
+        Field of the V1 responses holding the result of an item, defaults to `predictions`. A result holding an array of a single element is unwrapped, the results are returned in the same field in the order of the items.  # noqa: E501
+
+        :param results_field: The results_field of this V1alpha1MapRouterSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._results_field = results_field
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1MapRouterSpec):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1MapRouterSpec):
+            return True
+
+        return self.to_dict() != other.to_dict()
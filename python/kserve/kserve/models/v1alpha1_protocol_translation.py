@@ -0,0 +1,250 @@
+# Copyright 2023 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1ProtocolTranslation(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'datatype': 'str',
+        '_from': 'str',
+        'input_name': 'str',
+        'model': 'str',
+        'to': 'str'
+    }
+
+    attribute_map = {
+        'datatype': 'datatype',
+        '_from': 'from',
+        'input_name': 'inputName',
+        'model': 'model',
+        'to': 'to'
+    }
+
+    def __init__(self, datatype=None, _from='', input_name=None, model=None, to='', local_vars_configuration=None):  # noqa: E501
+        """V1alpha1ProtocolTranslation - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._datatype = None
+        self.__from = None
+        self._input_name = None
+        self._model = None
+        self._to = None
+        self.discriminator = None
+
+        if datatype is not None:
+            self.datatype = datatype
+        self._from = _from
+        if input_name is not None:
+            self.input_name = input_name
+        if model is not None:
+            self.model = model
+        self.to = to
+
+    @property
+    def datatype(self):
+        """Gets the datatype of this V1alpha1ProtocolTranslation.  # noqa: E501
+
+        Datatype of the V2 input tensor, inferred from the instances when not set: `BYTES` for strings, `BOOL` for booleans and `FP32` for numbers  # noqa: E501
+
+        :return: The datatype of this V1alpha1ProtocolTranslation.  # noqa: E501
+        :rtype: str
+        """
+        return self._datatype
+
+    @datatype.setter
+    def datatype(self, datatype):
+        """Sets the datatype of this V1alpha1ProtocolTranslation.
+
+        Datatype of the V2 input tensor, inferred from the instances when not set: `BYTES` for strings, `BOOL` for booleans and `FP32` for numbers  # noqa: E501
+
+        :param datatype: The datatype of this V1alpha1ProtocolTranslation.  # noqa: E501
+        :type: str
+        """
+
+        self._datatype = datatype
+
+    @property
+    def _from(self):
+        """Gets the _from of this V1alpha1ProtocolTranslation.  # noqa: E501
+
+        Protocol of the request of the step  # noqa: E501
+
+        :return: The _from of this V1alpha1ProtocolTranslation.  # noqa: E501
+        :rtype: str
+        """
+        return self.__from
+
+    @_from.setter
+    def _from(self, _from):
+        """Sets the _from of this V1alpha1ProtocolTranslation.
+
+        Protocol of the request of the step  # noqa: E501
+
+        :param _from: The _from of this V1alpha1ProtocolTranslation.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and _from is None:  # noqa: E501
+            raise ValueError("Invalid value for `_from`, must not be `None`")  # noqa: E501
+
+        self.__from = _from
+
+    @property
+    def input_name(self):
+        """Gets the input_name of this V1alpha1ProtocolTranslation.  # noqa: E501
+
+        Name of the V2 input tensor, defaults to `input-0`  # noqa: E501
+
+        :return: The input_name of this V1alpha1ProtocolTranslation.  # noqa: E501
+        :rtype: str
+        """
+        return self._input_name
+
+    @input_name.setter
+    def input_name(self, input_name):
+        """Sets the input_name of this V1alpha1ProtocolTranslation.
+
+        Name of the V2 input tensor, defaults to `input-0`  # noqa: E501
+
+        :param input_name: The input_name of this V1alpha1ProtocolTranslation.  # noqa: E501
+        :type: str
+        """
+
+        self._input_name = input_name
+
+    @property
+    def model(self):
+        """Gets the model of this V1alpha1ProtocolTranslation.  # noqa: E501
+
+        Model of the OpenAI requests and of the translated OpenAI and V2 responses  # noqa: E501
+
+        :return: The model of this V1alpha1ProtocolTranslation.  # noqa: E501
+        :rtype: str
+        """
+        return self._model
+
+    @model.setter
+    def model(self, model):
+        """Sets the model of this V1alpha1ProtocolTranslation.
+
+        Model of the OpenAI requests and of the translated OpenAI and V2 responses  # noqa: E501
+
+        :param model: The model of this V1alpha1ProtocolTranslation.  # noqa: E501
+        :type: str
+        """
+
+        self._model = model
+
+    @property
+    def to(self):
+        """Gets the to of this V1alpha1ProtocolTranslation.  # noqa: E501
+
+        Protocol of the target of the step  # noqa: E501
+
+        :return: The to of this V1alpha1ProtocolTranslation.  # noqa: E501
+        :rtype: str
+        """
+        return self._to
+
+    @to.setter
+    def to(self, to):
+        """Sets the to of this V1alpha1ProtocolTranslation.
+
+        Protocol of the target of the step  # noqa: E501
+
+        :param to: The to of this V1alpha1ProtocolTranslation.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and to is None:  # noqa: E501
+            raise ValueError("Invalid value for `to`, must not be `None`")  # noqa: E501
+
+        self._to = to
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1ProtocolTranslation):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1ProtocolTranslation):
+            return True
+
+        return self.to_dict() != other.to_dict()
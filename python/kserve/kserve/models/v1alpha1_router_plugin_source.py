@@ -0,0 +1,219 @@
+# Copyright 2023 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1RouterPluginSource(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'config_map': 'V1ConfigMapKeySelector',
+        'image': 'str',
+        'name': 'str',
+        'path': 'str'
+    }
+
+    attribute_map = {
+        'config_map': 'configMap',
+        'image': 'image',
+        'name': 'name',
+        'path': 'path'
+    }
+
+    def __init__(self, config_map=None, image=None, name='', path=None, local_vars_configuration=None):  # noqa: E501
+        """V1alpha1RouterPluginSource - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._config_map = None
+        self._image = None
+        self._name = None
+        self._path = None
+        self.discriminator = None
+
+        if config_map is not None:
+            self.config_map = config_map
+        if image is not None:
+            self.image = image
+        self.name = name
+        if path is not None:
+            self.path = path
+
+    @property
+    def config_map(self):
+        """Gets the config_map of this V1alpha1RouterPluginSource.  # noqa: E501
+
+
+        :return: The config_map of this V1alpha1RouterPluginSource.  # noqa: E501
+        :rtype: V1ConfigMapKeySelector
+        """
+        return self._config_map
+
+    @config_map.setter
+    def config_map(self, config_map):
+        """Sets the config_map of this V1alpha1RouterPluginSource.
+
+
+        :param config_map: The config_map of this V1alpha1RouterPluginSource.  # noqa: E501
+        :type: V1ConfigMapKeySelector
+        """
+
+        self._config_map = config_map
+
+    @property
+    def image(self):
+        """Gets the image of this V1alpha1RouterPluginSource.  # noqa: E501
+
+        Image holding the plugin shared object, it is copied by an init container which runs cp in the image  # noqa: E501
+
+        :return: The image of this V1alpha1RouterPluginSource.  # noqa: E501
+        :rtype: str
+        """
+        return self._image
+
+    @image.setter
+    def image(self, image):
+        """Sets the image of this V1alpha1RouterPluginSource.
+
+        Image holding the plugin shared object, it is copied by an init container which runs cp in the image  # noqa: E501
+
+        :param image: The image of this V1alpha1RouterPluginSource.  # noqa: E501
+        :type: str
+        """
+
+        self._image = image
+
+    @property
+    def name(self):
+        """Gets the name of this V1alpha1RouterPluginSource.  # noqa: E501
+
+        Name of the plugin referenced by the nodes  # noqa: E501
+
+        :return: The name of this V1alpha1RouterPluginSource.  # noqa: E501
+        :rtype: str
+        """
+        return self._name
+
+    @name.setter
+    def name(self, name):
+        """Sets the name of this V1alpha1RouterPluginSource.
+
+        Name of the plugin referenced by the nodes  # noqa: E501
+
+        :param name: The name of this V1alpha1RouterPluginSource.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and name is None:  # noqa: E501
+            raise ValueError("Invalid value for `name`, must not be `None`")  # noqa: E501
+
+        self._name = name
+
+    @property
+    def path(self):
+        """Gets the path of this V1alpha1RouterPluginSource.  # noqa: E501
+
+        Path of the plugin shared object in the image, defaults to /plugin.so  # noqa: E501
+
+        :return: The path of this V1alpha1RouterPluginSource.  # noqa: E501
+        :rtype: str
+        """
+        return self._path
+
+    @path.setter
+    def path(self, path):
+        """Sets the path of this V1alpha1RouterPluginSource.
+
+        Path of the plugin shared object in the image, defaults to /plugin.so  # noqa: E501
+
+        :param path: The path of this V1alpha1RouterPluginSource.  # noqa: E501
+        :type: str
+        """
+
+        self._path = path
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1RouterPluginSource):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1RouterPluginSource):
+            return True
+
+        return self.to_dict() != other.to_dict()
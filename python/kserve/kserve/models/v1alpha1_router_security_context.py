@@ -0,0 +1,246 @@
+# Copyright 2023 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1RouterSecurityContext(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'add_capabilities': 'list[str]',
+        'run_as_group': 'int',
+        'run_as_user': 'int',
+        'se_linux_options': 'V1SELinuxOptions',
+        'supplemental_groups': 'list[int]'
+    }
+
+    attribute_map = {
+        'add_capabilities': 'addCapabilities',
+        'run_as_group': 'runAsGroup',
+        'run_as_user': 'runAsUser',
+        'se_linux_options': 'seLinuxOptions',
+        'supplemental_groups': 'supplementalGroups'
+    }
+
+    def __init__(self, add_capabilities=None, run_as_group=None, run_as_user=None, se_linux_options=None, supplemental_groups=None, local_vars_configuration=None):  # noqa: E501
+        """V1alpha1RouterSecurityContext - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._add_capabilities = None
+        self._run_as_group = None
+        self._run_as_user = None
+        self._se_linux_options = None
+        self._supplemental_groups = None
+        self.discriminator = None
+
+        if add_capabilities is not None:
+            self.add_capabilities = add_capabilities
+        if run_as_group is not None:
+            self.run_as_group = run_as_group
+        if run_as_user is not None:
+            self.run_as_user = run_as_user
+        if se_linux_options is not None:
+            self.se_linux_options = se_linux_options
+        if supplemental_groups is not None:
+            self.supplemental_groups = supplemental_groups
+
+    @property
+    def add_capabilities(self):
+        """Gets the add_capabilities of this V1alpha1RouterSecurityContext.  # noqa: E501
+
+        The capabilities added to the router container  # noqa: E501
+
+        :return: The add_capabilities of this V1alpha1RouterSecurityContext.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._add_capabilities
+
+    @add_capabilities.setter
+    def add_capabilities(self, add_capabilities):
+        """Sets the add_capabilities of this V1alpha1RouterSecurityContext.
+
+        The capabilities added to the router container  # noqa: E501
+
+        :param add_capabilities: The add_capabilities of this V1alpha1RouterSecurityContext.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._add_capabilities = add_capabilities
+
+    @property
+    def run_as_group(self):
+        """Gets the run_as_group of this V1alpha1RouterSecurityContext.  # noqa: E501
+
+        The GID to run the router container as  # noqa: E501
+
+        :return: The run_as_group of this V1alpha1RouterSecurityContext.  # noqa: E501
+        :rtype: int
+        """
+        return self._run_as_group
+
+    @run_as_group.setter
+    def run_as_group(self, run_as_group):
+        """Sets the run_as_group of this V1alpha1RouterSecurityContext.
+
+        The GID to run the router container as  # noqa: E501
+
+        :param run_as_group: The run_as_group of this V1alpha1RouterSecurityContext.  # noqa: E501
+        :type: int
+        """
+
+        self._run_as_group = run_as_group
+
+    @property
+    def run_as_user(self):
+        """Gets the run_as_user of this V1alpha1RouterSecurityContext.  # noqa: E501
+
+        The UID to run the router container as  # noqa: E501
+
+        :return: The run_as_user of this V1alpha1RouterSecurityContext.  # noqa: E501
+        :rtype: int
+        """
+        return self._run_as_user
+
+    @run_as_user.setter
+    def run_as_user(self, run_as_user):
+        """Sets the run_as_user of this V1alpha1RouterSecurityContext.
+
+        The UID to run the router container as  # noqa: E501
+
+        :param run_as_user: The run_as_user of this V1alpha1RouterSecurityContext.  # noqa: E501
+        :type: int
+        """
+
+        self._run_as_user = run_as_user
+
+    @property
+    def se_linux_options(self):
+        """Gets the se_linux_options of this V1alpha1RouterSecurityContext.  # noqa: E501
+
+
+        :return: The se_linux_options of this V1alpha1RouterSecurityContext.  # noqa: E501
+        :rtype: V1SELinuxOptions
+        """
+        return self._se_linux_options
+
+    @se_linux_options.setter
+    def se_linux_options(self, se_linux_options):
+        """Sets the se_linux_options of this V1alpha1RouterSecurityContext.
+
+
+        :param se_linux_options: The se_linux_options of this V1alpha1RouterSecurityContext.  # noqa: E501
+        :type: V1SELinuxOptions
+        """
+
+        self._se_linux_options = se_linux_options
+
+    @property
+    def supplemental_groups(self):
+        """Gets the supplemental_groups of this V1alpha1RouterSecurityContext.  # noqa: E501
+
+        The supplemental groups of the router pod  # noqa: E501
+
+        :return: The supplemental_groups of this V1alpha1RouterSecurityContext.  # noqa: E501
+        :rtype: list[int]
+        """
+        return self._supplemental_groups
+
+    @supplemental_groups.setter
+    def supplemental_groups(self, supplemental_groups):
+        """Sets the supplemental_groups of this V1alpha1RouterSecurityContext.
+
+        The supplemental groups of the router pod  # noqa: E501
+
+        :param supplemental_groups: The supplemental_groups of this V1alpha1RouterSecurityContext.  # noqa: E501
+        :type: list[int]
+        """
+
+        self._supplemental_groups = supplemental_groups
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1RouterSecurityContext):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1RouterSecurityContext):
+            return True
+
+        return self.to_dict() != other.to_dict()
@@ -0,0 +1,222 @@
+# Copyright 2023 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1RouterTrafficTarget(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'latest_revision': 'bool',
+        'percent': 'int',
+        'revision_name': 'str',
+        'tag': 'str'
+    }
+
+    attribute_map = {
+        'latest_revision': 'latestRevision',
+        'percent': 'percent',
+        'revision_name': 'revisionName',
+        'tag': 'tag'
+    }
+
+    def __init__(self, latest_revision=None, percent=0, revision_name='', tag=None, local_vars_configuration=None):  # noqa: E501
+        """V1alpha1RouterTrafficTarget - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._latest_revision = None
+        self._percent = None
+        self._revision_name = None
+        self._tag = None
+        self.discriminator = None
+
+        if latest_revision is not None:
+            self.latest_revision = latest_revision
+        self.percent = percent
+        self.revision_name = revision_name
+        if tag is not None:
+            self.tag = tag
+
+    @property
+    def latest_revision(self):
+        """Gets the latest_revision of this V1alpha1RouterTrafficTarget.  # noqa: E501
+
+        Whether the revision is the latest revision of the router  # noqa: E501
+
+        :return: The latest_revision of this V1alpha1RouterTrafficTarget.  # noqa: E501
+        :rtype: bool
+        """
+        return self._latest_revision
+
+    @latest_revision.setter
+    def latest_revision(self, latest_revision):
+        """Sets the latest_revision of this V1alpha1RouterTrafficTarget.
+
+        Whether the revision is the latest revision of the router  # noqa: E501
+
+        :param latest_revision: The latest_revision of this V1alpha1RouterTrafficTarget.  # noqa: E501
+        :type: bool
+        """
+
+        self._latest_revision = latest_revision
+
+    @property
+    def percent(self):
+        """Gets the percent of this V1alpha1RouterTrafficTarget.  # noqa: E501
+
+        Percentage of the traffic served by the revision  # noqa: E501
+
+        :return: The percent of this V1alpha1RouterTrafficTarget.  # noqa: E501
+        :rtype: int
+        """
+        return self._percent
+
+    @percent.setter
+    def percent(self, percent):
+        """Sets the percent of this V1alpha1RouterTrafficTarget.
+
+        Percentage of the traffic served by the revision  # noqa: E501
+
+        :param percent: The percent of this V1alpha1RouterTrafficTarget.  # noqa: E501
+        :type: int
+        """
+        if self.local_vars_configuration.client_side_validation and percent is None:  # noqa: E501
+            raise ValueError("Invalid value for `percent`, must not be `None`")  # noqa: E501
+
+        self._percent = percent
+
+    @property
+    def revision_name(self):
+        """Gets the revision_name of this V1alpha1RouterTrafficTarget.  # noqa: E501
+
+        Name of the revision  # noqa: E501
+
+        :return: The revision_name of this V1alpha1RouterTrafficTarget.  # noqa: E501
+        :rtype: str
+        """
+        return self._revision_name
+
+    @revision_name.setter
+    def revision_name(self, revision_name):
+        """Sets the revision_name of this V1alpha1RouterTrafficTarget.
+
+        Name of the revision  # noqa: E501
+
+        :param revision_name: The revision_name of this V1alpha1RouterTrafficTarget.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and revision_name is None:  # noqa: E501
+            raise ValueError("Invalid value for `revision_name`, must not be `None`")  # noqa: E501
+
+        self._revision_name = revision_name
+
+    @property
+    def tag(self):
+        """Gets the tag of this V1alpha1RouterTrafficTarget.  # noqa: E501
+
+        Tag of the revision, prev for the previous rolled out revision  # noqa: E501
+
+        :return: The tag of this V1alpha1RouterTrafficTarget.  # noqa: E501
+        :rtype: str
+        """
+        return self._tag
+
+    @tag.setter
+    def tag(self, tag):
+        """Sets the tag of this V1alpha1RouterTrafficTarget.
+
+        Tag of the revision, prev for the previous rolled out revision  # noqa: E501
+
+        :param tag: The tag of this V1alpha1RouterTrafficTarget.  # noqa: E501
+        :type: str
+        """
+
+        self._tag = tag
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1RouterTrafficTarget):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1RouterTrafficTarget):
+            return True
+
+        return self.to_dict() != other.to_dict()
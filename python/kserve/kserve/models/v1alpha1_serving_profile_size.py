@@ -0,0 +1,355 @@
+# Copyright 2023 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1ServingProfileSize(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'liveness_probe': 'V1Probe',
+        'max_replicas': 'int',
+        'min_replicas': 'int',
+        'name': 'str',
+        'node_selector': 'dict(str, str)',
+        'readiness_probe': 'V1Probe',
+        'resources': 'V1ResourceRequirements',
+        'scale_target': 'int',
+        'tolerations': 'list[V1Toleration]'
+    }
+
+    attribute_map = {
+        'liveness_probe': 'livenessProbe',
+        'max_replicas': 'maxReplicas',
+        'min_replicas': 'minReplicas',
+        'name': 'name',
+        'node_selector': 'nodeSelector',
+        'readiness_probe': 'readinessProbe',
+        'resources': 'resources',
+        'scale_target': 'scaleTarget',
+        'tolerations': 'tolerations'
+    }
+
+    def __init__(self, liveness_probe=None, max_replicas=None, min_replicas=None, name='', node_selector=None, readiness_probe=None, resources=None, scale_target=None, tolerations=None, local_vars_configuration=None):  # noqa: E501
+        """V1alpha1ServingProfileSize - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._liveness_probe = None
+        self._max_replicas = None
+        self._min_replicas = None
+        self._name = None
+        self._node_selector = None
+        self._readiness_probe = None
+        self._resources = None
+        self._scale_target = None
+        self._tolerations = None
+        self.discriminator = None
+
+        if liveness_probe is not None:
+            self.liveness_probe = liveness_probe
+        if max_replicas is not None:
+            self.max_replicas = max_replicas
+        if min_replicas is not None:
+            self.min_replicas = min_replicas
+        self.name = name
+        if node_selector is not None:
+            self.node_selector = node_selector
+        if readiness_probe is not None:
+            self.readiness_probe = readiness_probe
+        if resources is not None:
+            self.resources = resources
+        if scale_target is not None:
+            self.scale_target = scale_target
+        if tolerations is not None:
+            self.tolerations = tolerations
+
+    @property
+    def liveness_probe(self):
+        """Gets the liveness_probe of this V1alpha1ServingProfileSize.  # noqa: E501
+
+
+        :return: The liveness_probe of this V1alpha1ServingProfileSize.  # noqa: E501
+        :rtype: V1Probe
+        """
+        return self._liveness_probe
+
+    @liveness_probe.setter
+    def liveness_probe(self, liveness_probe):
+        """Sets the liveness_probe of this V1alpha1ServingProfileSize.
+
+
+        :param liveness_probe: The liveness_probe of this V1alpha1ServingProfileSize.  # noqa: E501
+        :type: V1Probe
+        """
+
+        self._liveness_probe = liveness_probe
+
+    @property
+    def max_replicas(self):
+        """Gets the max_replicas of this V1alpha1ServingProfileSize.  # noqa: E501
+
+        Maximum number of replicas of the predictor  # noqa: E501
+
+        :return: The max_replicas of this V1alpha1ServingProfileSize.  # noqa: E501
+        :rtype: int
+        """
+        return self._max_replicas
+
+    @max_replicas.setter
+    def max_replicas(self, max_replicas):
+        """Sets the max_replicas of this V1alpha1ServingProfileSize.
+
+        Maximum number of replicas of the predictor  # noqa: E501
+
+        :param max_replicas: The max_replicas of this V1alpha1ServingProfileSize.  # noqa: E501
+        :type: int
+        """
+
+        self._max_replicas = max_replicas
+
+    @property
+    def min_replicas(self):
+        """Gets the min_replicas of this V1alpha1ServingProfileSize.  # noqa: E501
+
+        Minimum number of replicas of the predictor  # noqa: E501
+
+        :return: The min_replicas of this V1alpha1ServingProfileSize.  # noqa: E501
+        :rtype: int
+        """
+        return self._min_replicas
+
+    @min_replicas.setter
+    def min_replicas(self, min_replicas):
+        """Sets the min_replicas of this V1alpha1ServingProfileSize.
+
+        Minimum number of replicas of the predictor  # noqa: E501
+
+        :param min_replicas: The min_replicas of this V1alpha1ServingProfileSize.  # noqa: E501
+        :type: int
+        """
+
+        self._min_replicas = min_replicas
+
+    @property
+    def name(self):
+        """Gets the name of this V1alpha1ServingProfileSize.  # noqa: E501
+
+        Name of the size  # noqa: E501
+
+        :return: The name of this V1alpha1ServingProfileSize.  # noqa: E501
+        :rtype: str
+        """
+        return self._name
+
+    @name.setter
+    def name(self, name):
+        """Sets the name of this V1alpha1ServingProfileSize.
+
+        Name of the size  # noqa: E501
+
+        :param name: The name of this V1alpha1ServingProfileSize.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and name is None:  # noqa: E501
+            raise ValueError("Invalid value for `name`, must not be `None`")  # noqa: E501
+
+        self._name = name
+
+    @property
+    def node_selector(self):
+        """Gets the node_selector of this V1alpha1ServingProfileSize.  # noqa: E501
+
+        NodeSelector of the predictor pods  # noqa: E501
+
+        :return: The node_selector of this V1alpha1ServingProfileSize.  # noqa: E501
+        :rtype: dict(str, str)
+        """
+        return self._node_selector
+
+    @node_selector.setter
+    def node_selector(self, node_selector):
+        """Sets the node_selector of this V1alpha1ServingProfileSize.
+
+        NodeSelector of the predictor pods  # noqa: E501
+
+        :param node_selector: The node_selector of this V1alpha1ServingProfileSize.  # noqa: E501
+        :type: dict(str, str)
+        """
+
+        self._node_selector = node_selector
+
+    @property
+    def readiness_probe(self):
+        """Gets the readiness_probe of this V1alpha1ServingProfileSize.  # noqa: E501
+
+
+        :return: The readiness_probe of this V1alpha1ServingProfileSize.  # noqa: E501
+        :rtype: V1Probe
+        """
+        return self._readiness_probe
+
+    @readiness_probe.setter
+    def readiness_probe(self, readiness_probe):
+        """Sets the readiness_probe of this V1alpha1ServingProfileSize.
+
+
+        :param readiness_probe: The readiness_probe of this V1alpha1ServingProfileSize.  # noqa: E501
+        :type: V1Probe
+        """
+
+        self._readiness_probe = readiness_probe
+
+    @property
+    def resources(self):
+        """Gets the resources of this V1alpha1ServingProfileSize.  # noqa: E501
+
+
+        :return: The resources of this V1alpha1ServingProfileSize.  # noqa: E501
+        :rtype: V1ResourceRequirements
+        """
+        return self._resources
+
+    @resources.setter
+    def resources(self, resources):
+        """Sets the resources of this V1alpha1ServingProfileSize.
+
+
+        :param resources: The resources of this V1alpha1ServingProfileSize.  # noqa: E501
+        :type: V1ResourceRequirements
+        """
+
+        self._resources = resources
+
+    @property
+    def scale_target(self):
+        """Gets the scale_target of this V1alpha1ServingProfileSize.  # noqa: E501
+
+        ScaleTarget of the autoscaler of the predictor  # noqa: E501
+
+        :return: The scale_target of this V1alpha1ServingProfileSize.  # noqa: E501
+        :rtype: int
+        """
+        return self._scale_target
+
+    @scale_target.setter
+    def scale_target(self, scale_target):
+        """Sets the scale_target of this V1alpha1ServingProfileSize.
+
+        ScaleTarget of the autoscaler of the predictor  # noqa: E501
+
+        :param scale_target: The scale_target of this V1alpha1ServingProfileSize.  # noqa: E501
+        :type: int
+        """
+
+        self._scale_target = scale_target
+
+    @property
+    def tolerations(self):
+        """Gets the tolerations of this V1alpha1ServingProfileSize.  # noqa: E501
+
+        Tolerations of the predictor pods, e.g. for the taints of the GPU nodes  # noqa: E501
+
+        :return: The tolerations of this V1alpha1ServingProfileSize.  # noqa: E501
+        :rtype: list[V1Toleration]
+        """
+        return self._tolerations
+
+    @tolerations.setter
+    def tolerations(self, tolerations):
+        """Sets the tolerations of this V1alpha1ServingProfileSize.
+
+        Tolerations of the predictor pods, e.g. for the taints of the GPU nodes  # noqa: E501
+
+        :param tolerations: The tolerations of this V1alpha1ServingProfileSize.  # noqa: E501
+        :type: list[V1Toleration]
+        """
+
+        self._tolerations = tolerations
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1ServingProfileSize):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1ServingProfileSize):
+            return True
+
+        return self.to_dict() != other.to_dict()
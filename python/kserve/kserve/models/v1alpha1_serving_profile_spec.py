@@ -0,0 +1,165 @@
+# Copyright 2023 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1ServingProfileSpec(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'default_size': 'str',
+        'sizes': 'list[V1alpha1ServingProfileSize]'
+    }
+
+    attribute_map = {
+        'default_size': 'defaultSize',
+        'sizes': 'sizes'
+    }
+
+    def __init__(self, default_size=None, sizes=None, local_vars_configuration=None):  # noqa: E501
+        """V1alpha1ServingProfileSpec - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._default_size = None
+        self._sizes = None
+        self.discriminator = None
+
+        if default_size is not None:
+            self.default_size = default_size
+        self.sizes = sizes
+
+    @property
+    def default_size(self):
+        """Gets the default_size of this V1alpha1ServingProfileSpec.  # noqa: E501
+
+        DefaultSize is the size applied when the InferenceService does not select one  # noqa: E501
+
+        :return: The default_size of this V1alpha1ServingProfileSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._default_size
+
+    @default_size.setter
+    def default_size(self, default_size):
+        """Sets the default_size of this V1alpha1ServingProfileSpec.
+
+        DefaultSize is the size applied when the InferenceService does not select one  # noqa: E501
+
+        :param default_size: The default_size of this V1alpha1ServingProfileSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._default_size = default_size
+
+    @property
+    def sizes(self):
+        """Gets the sizes of this V1alpha1ServingProfileSpec.  # noqa: E501
+
+        Sizes offered by the profile, an InferenceService selects one with the serving.kserve.io/size-class annotation  # noqa: E501
+
+        :return: The sizes of this V1alpha1ServingProfileSpec.  # noqa: E501
+        :rtype: list[V1alpha1ServingProfileSize]
+        """
+        return self._sizes
+
+    @sizes.setter
+    def sizes(self, sizes):
+        """Sets the sizes of this V1alpha1ServingProfileSpec.
+
+        Sizes offered by the profile, an InferenceService selects one with the serving.kserve.io/size-class annotation  # noqa: E501
+
+        :param sizes: The sizes of this V1alpha1ServingProfileSpec.  # noqa: E501
+        :type: list[V1alpha1ServingProfileSize]
+        """
+        if self.local_vars_configuration.client_side_validation and sizes is None:  # noqa: E501
+            raise ValueError("Invalid value for `sizes`, must not be `None`")  # noqa: E501
+
+        self._sizes = sizes
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1ServingProfileSpec):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1ServingProfileSpec):
+            return True
+
+        return self.to_dict() != other.to_dict()
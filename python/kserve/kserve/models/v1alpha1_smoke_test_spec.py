@@ -0,0 +1,221 @@
+# Copyright 2023 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1SmokeTestSpec(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'assertions': 'list[V1alpha1SmokeTestAssertion]',
+        'expected_status_code': 'int',
+        'path': 'str',
+        'request': 'str'
+    }
+
+    attribute_map = {
+        'assertions': 'assertions',
+        'expected_status_code': 'expectedStatusCode',
+        'path': 'path',
+        'request': 'request'
+    }
+
+    def __init__(self, assertions=None, expected_status_code=None, path=None, request='', local_vars_configuration=None):  # noqa: E501
+        """V1alpha1SmokeTestSpec - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._assertions = None
+        self._expected_status_code = None
+        self._path = None
+        self._request = None
+        self.discriminator = None
+
+        if assertions is not None:
+            self.assertions = assertions
+        if expected_status_code is not None:
+            self.expected_status_code = expected_status_code
+        if path is not None:
+            self.path = path
+        self.request = request
+
+    @property
+    def assertions(self):
+        """Gets the assertions of this V1alpha1SmokeTestSpec.  # noqa: E501
+
+        Assertions on the JSON body of the response  # noqa: E501
+
+        :return: The assertions of this V1alpha1SmokeTestSpec.  # noqa: E501
+        :rtype: list[V1alpha1SmokeTestAssertion]
+        """
+        return self._assertions
+
+    @assertions.setter
+    def assertions(self, assertions):
+        """Sets the assertions of this V1alpha1SmokeTestSpec.
+
+        Assertions on the JSON body of the response  # noqa: E501
+
+        :param assertions: The assertions of this V1alpha1SmokeTestSpec.  # noqa: E501
+        :type: list[V1alpha1SmokeTestAssertion]
+        """
+
+        self._assertions = assertions
+
+    @property
+    def expected_status_code(self):
+        """Gets the expected_status_code of this V1alpha1SmokeTestSpec.  # noqa: E501
+
+        Expected HTTP status code of the response, defaults to 200  # noqa: E501
+
+        :return: The expected_status_code of this V1alpha1SmokeTestSpec.  # noqa: E501
+        :rtype: int
+        """
+        return self._expected_status_code
+
+    @expected_status_code.setter
+    def expected_status_code(self, expected_status_code):
+        """Sets the expected_status_code of this V1alpha1SmokeTestSpec.
+
+        Expected HTTP status code of the response, defaults to 200  # noqa: E501
+
+        :param expected_status_code: The expected_status_code of this V1alpha1SmokeTestSpec.  # noqa: E501
+        :type: int
+        """
+
+        self._expected_status_code = expected_status_code
+
+    @property
+    def path(self):
+        """Gets the path of this V1alpha1SmokeTestSpec.  # noqa: E501
+
+        Path of the request relative to the URL of the InferenceGraph, defaults to /  # noqa: E501
+
+        :return: The path of this V1alpha1SmokeTestSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._path
+
+    @path.setter
+    def path(self, path):
+        """Sets the path of this V1alpha1SmokeTestSpec.
+
+        Path of the request relative to the URL of the InferenceGraph, defaults to /  # noqa: E501
+
+        :param path: The path of this V1alpha1SmokeTestSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._path = path
+
+    @property
+    def request(self):
+        """Gets the request of this V1alpha1SmokeTestSpec.  # noqa: E501
+
+        JSON body of the request, it is sent with the POST method  # noqa: E501
+
+        :return: The request of this V1alpha1SmokeTestSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._request
+
+    @request.setter
+    def request(self, request):
+        """Sets the request of this V1alpha1SmokeTestSpec.
+
+        JSON body of the request, it is sent with the POST method  # noqa: E501
+
+        :param request: The request of this V1alpha1SmokeTestSpec.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and request is None:  # noqa: E501
+            raise ValueError("Invalid value for `request`, must not be `None`")  # noqa: E501
+
+        self._request = request
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1SmokeTestSpec):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1SmokeTestSpec):
+            return True
+
+        return self.to_dict() != other.to_dict()
@@ -0,0 +1,220 @@
+# Copyright 2023 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1SmokeTestStatus(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'last_run_time': 'V1Time',
+        'message': 'str',
+        'observed_generation': 'int',
+        'succeeded': 'bool'
+    }
+
+    attribute_map = {
+        'last_run_time': 'lastRunTime',
+        'message': 'message',
+        'observed_generation': 'observedGeneration',
+        'succeeded': 'succeeded'
+    }
+
+    def __init__(self, last_run_time=None, message=None, observed_generation=0, succeeded=False, local_vars_configuration=None):  # noqa: E501
+        """V1alpha1SmokeTestStatus - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._last_run_time = None
+        self._message = None
+        self._observed_generation = None
+        self._succeeded = None
+        self.discriminator = None
+
+        if last_run_time is not None:
+            self.last_run_time = last_run_time
+        if message is not None:
+            self.message = message
+        self.observed_generation = observed_generation
+        self.succeeded = succeeded
+
+    @property
+    def last_run_time(self):
+        """Gets the last_run_time of this V1alpha1SmokeTestStatus.  # noqa: E501
+
+
+        :return: The last_run_time of this V1alpha1SmokeTestStatus.  # noqa: E501
+        :rtype: V1Time
+        """
+        return self._last_run_time
+
+    @last_run_time.setter
+    def last_run_time(self, last_run_time):
+        """Sets the last_run_time of this V1alpha1SmokeTestStatus.
+
+
+        :param last_run_time: The last_run_time of this V1alpha1SmokeTestStatus.  # noqa: E501
+        :type: V1Time
+        """
+
+        self._last_run_time = last_run_time
+
+    @property
+    def message(self):
+        """Gets the message of this V1alpha1SmokeTestStatus.  # noqa: E501
+
+        Reason of the failure of the smoke test  # noqa: E501
+
+        :return: The message of this V1alpha1SmokeTestStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._message
+
+    @message.setter
+    def message(self, message):
+        """Sets the message of this V1alpha1SmokeTestStatus.
+
+        Reason of the failure of the smoke test  # noqa: E501
+
+        :param message: The message of this V1alpha1SmokeTestStatus.  # noqa: E501
+        :type: str
+        """
+
+        self._message = message
+
+    @property
+    def observed_generation(self):
+        """Gets the observed_generation of this V1alpha1SmokeTestStatus.  # noqa: E501
+
+        Generation of the InferenceGraph the smoke test was run against  # noqa: E501
+
+        :return: The observed_generation of this V1alpha1SmokeTestStatus.  # noqa: E501
+        :rtype: int
+        """
+        return self._observed_generation
+
+    @observed_generation.setter
+    def observed_generation(self, observed_generation):
+        """Sets the observed_generation of this V1alpha1SmokeTestStatus.
+
+        Generation of the InferenceGraph the smoke test was run against  # noqa: E501
+
+        :param observed_generation: The observed_generation of this V1alpha1SmokeTestStatus.  # noqa: E501
+        :type: int
+        """
+        if self.local_vars_configuration.client_side_validation and observed_generation is None:  # noqa: E501
+            raise ValueError("Invalid value for `observed_generation`, must not be `None`")  # noqa: E501
+
+        self._observed_generation = observed_generation
+
+    @property
+    def succeeded(self):
+        """Gets the succeeded of this V1alpha1SmokeTestStatus.  # noqa: E501
+
+        Whether the response matched the expected status code and assertions  # noqa: E501
+
+        :return: The succeeded of this V1alpha1SmokeTestStatus.  # noqa: E501
+        :rtype: bool
+        """
+        return self._succeeded
+
+    @succeeded.setter
+    def succeeded(self, succeeded):
+        """Sets the succeeded of this V1alpha1SmokeTestStatus.
+
+        Whether the response matched the expected status code and assertions  # noqa: E501
+
+        :param succeeded: The succeeded of this V1alpha1SmokeTestStatus.  # noqa: E501
+        :type: bool
+        """
+        if self.local_vars_configuration.client_side_validation and succeeded is None:  # noqa: E501
+            raise ValueError("Invalid value for `succeeded`, must not be `None`")  # noqa: E501
+
+        self._succeeded = succeeded
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1SmokeTestStatus):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1SmokeTestStatus):
+            return True
+
+        return self.to_dict() != other.to_dict()
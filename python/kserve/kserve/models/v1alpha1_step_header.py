@@ -0,0 +1,191 @@
+# Copyright 2023 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1StepHeader(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'name': 'str',
+        'secret_key_ref': 'V1SecretKeySelector',
+        'value': 'str'
+    }
+
+    attribute_map = {
+        'name': 'name',
+        'secret_key_ref': 'secretKeyRef',
+        'value': 'value'
+    }
+
+    def __init__(self, name='', secret_key_ref=None, value=None, local_vars_configuration=None):  # noqa: E501
+        """V1alpha1StepHeader - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._name = None
+        self._secret_key_ref = None
+        self._value = None
+        self.discriminator = None
+
+        self.name = name
+        if secret_key_ref is not None:
+            self.secret_key_ref = secret_key_ref
+        if value is not None:
+            self.value = value
+
+    @property
+    def name(self):
+        """Gets the name of this V1alpha1StepHeader.  # noqa: E501
+
+        Name of the header  # noqa: E501
+
+        :return: The name of this V1alpha1StepHeader.  # noqa: E501
+        :rtype: str
+        """
+        return self._name
+
+    @name.setter
+    def name(self, name):
+        """Sets the name of this V1alpha1StepHeader.
+
+        Name of the header  # noqa: E501
+
+        :param name: The name of this V1alpha1StepHeader.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and name is None:  # noqa: E501
+            raise ValueError("Invalid value for `name`, must not be `None`")  # noqa: E501
+
+        self._name = name
+
+    @property
+    def secret_key_ref(self):
+        """Gets the secret_key_ref of this V1alpha1StepHeader.  # noqa: E501
+
+
+        :return: The secret_key_ref of this V1alpha1StepHeader.  # noqa: E501
+        :rtype: V1SecretKeySelector
+        """
+        return self._secret_key_ref
+
+    @secret_key_ref.setter
+    def secret_key_ref(self, secret_key_ref):
+        """Sets the secret_key_ref of this V1alpha1StepHeader.
+
+
+        :param secret_key_ref: The secret_key_ref of this V1alpha1StepHeader.  # noqa: E501
+        :type: V1SecretKeySelector
+        """
+
+        self._secret_key_ref = secret_key_ref
+
+    @property
+    def value(self):
+        """Gets the value of this V1alpha1StepHeader.  # noqa: E501
+
+        Value of the header, `$(VAR_NAME)` references are expanded with the environment variables of the router  # noqa: E501
+
+        :return: The value of this V1alpha1StepHeader.  # noqa: E501
+        :rtype: str
+        """
+        return self._value
+
+    @value.setter
+    def value(self, value):
+        """Sets the value of this V1alpha1StepHeader.
+
+        Value of the header, `$(VAR_NAME)` references are expanded with the environment variables of the router  # noqa: E501
+
+        :param value: The value of this V1alpha1StepHeader.  # noqa: E501
+        :type: str
+        """
+
+        self._value = value
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1StepHeader):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1StepHeader):
+            return True
+
+        return self.to_dict() != other.to_dict()
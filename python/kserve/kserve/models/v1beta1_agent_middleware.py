@@ -0,0 +1,290 @@
+# Copyright 2023 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1beta1AgentMiddleware(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'guardrail': 'V1beta1GuardrailSpec',
+        'quota': 'V1beta1QuotaSpec',
+        'request_headers': 'V1beta1HeaderTransform',
+        'response_headers': 'V1beta1HeaderTransform',
+        'token_exchange': 'V1beta1TokenExchange',
+        'token_review': 'V1beta1TokenReview',
+        'token_usage': 'V1beta1TokenUsageSpec'
+    }
+
+    attribute_map = {
+        'guardrail': 'guardrail',
+        'quota': 'quota',
+        'request_headers': 'requestHeaders',
+        'response_headers': 'responseHeaders',
+        'token_exchange': 'tokenExchange',
+        'token_review': 'tokenReview',
+        'token_usage': 'tokenUsage'
+    }
+
+    def __init__(self, guardrail=None, quota=None, request_headers=None, response_headers=None, token_exchange=None, token_review=None, token_usage=None, local_vars_configuration=None):  # noqa: E501
+        """V1beta1AgentMiddleware - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._guardrail = None
+        self._quota = None
+        self._request_headers = None
+        self._response_headers = None
+        self._token_exchange = None
+        self._token_review = None
+        self._token_usage = None
+        self.discriminator = None
+
+        if guardrail is not None:
+            self.guardrail = guardrail
+        if quota is not None:
+            self.quota = quota
+        if request_headers is not None:
+            self.request_headers = request_headers
+        if response_headers is not None:
+            self.response_headers = response_headers
+        if token_exchange is not None:
+            self.token_exchange = token_exchange
+        if token_review is not None:
+            self.token_review = token_review
+        if token_usage is not None:
+            self.token_usage = token_usage
+
+    @property
+    def guardrail(self):
+        """Gets the guardrail of this V1beta1AgentMiddleware.  # noqa: E501
+
+
+        :return: The guardrail of this V1beta1AgentMiddleware.  # noqa: E501
+        :rtype: V1beta1GuardrailSpec
+        """
+        return self._guardrail
+
+    @guardrail.setter
+    def guardrail(self, guardrail):
+        """Sets the guardrail of this V1beta1AgentMiddleware.
+
+
+        :param guardrail: The guardrail of this V1beta1AgentMiddleware.  # noqa: E501
+        :type: V1beta1GuardrailSpec
+        """
+
+        self._guardrail = guardrail
+
+    @property
+    def quota(self):
+        """Gets the quota of this V1beta1AgentMiddleware.  # noqa: E501
+
+
+        :return: The quota of this V1beta1AgentMiddleware.  # noqa: E501
+        :rtype: V1beta1QuotaSpec
+        """
+        return self._quota
+
+    @quota.setter
+    def quota(self, quota):
+        """Sets the quota of this V1beta1AgentMiddleware.
+
+
+        :param quota: The quota of this V1beta1AgentMiddleware.  # noqa: E501
+        :type: V1beta1QuotaSpec
+        """
+
+        self._quota = quota
+
+    @property
+    def request_headers(self):
+        """Gets the request_headers of this V1beta1AgentMiddleware.  # noqa: E501
+
+
+        :return: The request_headers of this V1beta1AgentMiddleware.  # noqa: E501
+        :rtype: V1beta1HeaderTransform
+        """
+        return self._request_headers
+
+    @request_headers.setter
+    def request_headers(self, request_headers):
+        """Sets the request_headers of this V1beta1AgentMiddleware.
+
+
+        :param request_headers: The request_headers of this V1beta1AgentMiddleware.  # noqa: E501
+        :type: V1beta1HeaderTransform
+        """
+
+        self._request_headers = request_headers
+
+    @property
+    def response_headers(self):
+        """Gets the response_headers of this V1beta1AgentMiddleware.  # noqa: E501
+
+
+        :return: The response_headers of this V1beta1AgentMiddleware.  # noqa: E501
+        :rtype: V1beta1HeaderTransform
+        """
+        return self._response_headers
+
+    @response_headers.setter
+    def response_headers(self, response_headers):
+        """Sets the response_headers of this V1beta1AgentMiddleware.
+
+
+        :param response_headers: The response_headers of this V1beta1AgentMiddleware.  # noqa: E501
+        :type: V1beta1HeaderTransform
+        """
+
+        self._response_headers = response_headers
+
+    @property
+    def token_exchange(self):
+        """Gets the token_exchange of this V1beta1AgentMiddleware.  # noqa: E501
+
+
+        :return: The token_exchange of this V1beta1AgentMiddleware.  # noqa: E501
+        :rtype: V1beta1TokenExchange
+        """
+        return self._token_exchange
+
+    @token_exchange.setter
+    def token_exchange(self, token_exchange):
+        """Sets the token_exchange of this V1beta1AgentMiddleware.
+
+
+        :param token_exchange: The token_exchange of this V1beta1AgentMiddleware.  # noqa: E501
+        :type: V1beta1TokenExchange
+        """
+
+        self._token_exchange = token_exchange
+
+    @property
+    def token_review(self):
+        """Gets the token_review of this V1beta1AgentMiddleware.  # noqa: E501
+
+
+        :return: The token_review of this V1beta1AgentMiddleware.  # noqa: E501
+        :rtype: V1beta1TokenReview
+        """
+        return self._token_review
+
+    @token_review.setter
+    def token_review(self, token_review):
+        """Sets the token_review of this V1beta1AgentMiddleware.
+
+
+        :param token_review: The token_review of this V1beta1AgentMiddleware.  # noqa: E501
+        :type: V1beta1TokenReview
+        """
+
+        self._token_review = token_review
+
+    @property
+    def token_usage(self):
+        """Gets the token_usage of this V1beta1AgentMiddleware.  # noqa: E501
+
+
+        :return: The token_usage of this V1beta1AgentMiddleware.  # noqa: E501
+        :rtype: V1beta1TokenUsageSpec
+        """
+        return self._token_usage
+
+    @token_usage.setter
+    def token_usage(self, token_usage):
+        """Sets the token_usage of this V1beta1AgentMiddleware.
+
+
+        :param token_usage: The token_usage of this V1beta1AgentMiddleware.  # noqa: E501
+        :type: V1beta1TokenUsageSpec
+        """
+
+        self._token_usage = token_usage
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1beta1AgentMiddleware):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1beta1AgentMiddleware):
+            return True
+
+        return self.to_dict() != other.to_dict()
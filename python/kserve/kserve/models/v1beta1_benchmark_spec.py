@@ -0,0 +1,251 @@
+# Copyright 2023 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1beta1BenchmarkSpec(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'call': 'str',
+        'duration_seconds': 'int',
+        'payload_config_map': 'str',
+        'rps': 'int',
+        'tool': 'str'
+    }
+
+    attribute_map = {
+        'call': 'call',
+        'duration_seconds': 'durationSeconds',
+        'payload_config_map': 'payloadConfigMap',
+        'rps': 'rps',
+        'tool': 'tool'
+    }
+
+    def __init__(self, call=None, duration_seconds=0, payload_config_map='', rps=0, tool=None, local_vars_configuration=None):  # noqa: E501
+        """V1beta1BenchmarkSpec - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._call = None
+        self._duration_seconds = None
+        self._payload_config_map = None
+        self._rps = None
+        self._tool = None
+        self.discriminator = None
+
+        if call is not None:
+            self.call = call
+        self.duration_seconds = duration_seconds
+        self.payload_config_map = payload_config_map
+        self.rps = rps
+        if tool is not None:
+            self.tool = tool
+
+    @property
+    def call(self):
+        """Gets the call of this V1beta1BenchmarkSpec.  # noqa: E501
+
+        Fully qualified gRPC method called by ghz, defaults to inference.GRPCInferenceService/ModelInfer  # noqa: E501
+
+        :return: The call of this V1beta1BenchmarkSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._call
+
+    @call.setter
+    def call(self, call):
+        """Sets the call of this V1beta1BenchmarkSpec.
+
+        Fully qualified gRPC method called by ghz, defaults to inference.GRPCInferenceService/ModelInfer  # noqa: E501
+
+        :param call: The call of this V1beta1BenchmarkSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._call = call
+
+    @property
+    def duration_seconds(self):
+        """Gets the duration_seconds of this V1beta1BenchmarkSpec.  # noqa: E501
+
+        Duration of the load test in seconds  # noqa: E501
+
+        :return: The duration_seconds of this V1beta1BenchmarkSpec.  # noqa: E501
+        :rtype: int
+        """
+        return self._duration_seconds
+
+    @duration_seconds.setter
+    def duration_seconds(self, duration_seconds):
+        """Sets the duration_seconds of this V1beta1BenchmarkSpec.
+
+        Duration of the load test in seconds  # noqa: E501
+
+        :param duration_seconds: The duration_seconds of this V1beta1BenchmarkSpec.  # noqa: E501
+        :type: int
+        """
+        if self.local_vars_configuration.client_side_validation and duration_seconds is None:  # noqa: E501
+            raise ValueError("Invalid value for `duration_seconds`, must not be `None`")  # noqa: E501
+
+        self._duration_seconds = duration_seconds
+
+    @property
+    def payload_config_map(self):
+        """Gets the payload_config_map of this V1beta1BenchmarkSpec.  # noqa: E501
+
+        Name of the ConfigMap, in the namespace of the InferenceService, holding the body of the requests under the \"payload\" key, a JSON message of the gRPC method for ghz  # noqa: E501
+
+        :return: The payload_config_map of this V1beta1BenchmarkSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._payload_config_map
+
+    @payload_config_map.setter
+    def payload_config_map(self, payload_config_map):
+        """Sets the payload_config_map of this V1beta1BenchmarkSpec.
+
+        Name of the ConfigMap, in the namespace of the InferenceService, holding the body of the requests under the \"payload\" key, a JSON message of the gRPC method for ghz  # noqa: E501
+
+        :param payload_config_map: The payload_config_map of this V1beta1BenchmarkSpec.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and payload_config_map is None:  # noqa: E501
+            raise ValueError("Invalid value for `payload_config_map`, must not be `None`")  # noqa: E501
+
+        self._payload_config_map = payload_config_map
+
+    @property
+    def rps(self):
+        """Gets the rps of this V1beta1BenchmarkSpec.  # noqa: E501
+
+        Rate of the requests per second  # noqa: E501
+
+        :return: The rps of this V1beta1BenchmarkSpec.  # noqa: E501
+        :rtype: int
+        """
+        return self._rps
+
+    @rps.setter
+    def rps(self, rps):
+        """Sets the rps of this V1beta1BenchmarkSpec.
+
+        Rate of the requests per second  # noqa: E501
+
+        :param rps: The rps of this V1beta1BenchmarkSpec.  # noqa: E501
+        :type: int
+        """
+        if self.local_vars_configuration.client_side_validation and rps is None:  # noqa: E501
+            raise ValueError("Invalid value for `rps`, must not be `None`")  # noqa: E501
+
+        self._rps = rps
+
+    @property
+    def tool(self):
+        """Gets the tool of this V1beta1BenchmarkSpec.  # noqa: E501
+
+        Load generator, vegeta for HTTP or ghz for gRPC, defaults to vegeta  # noqa: E501
+
+        :return: The tool of this V1beta1BenchmarkSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._tool
+
+    @tool.setter
+    def tool(self, tool):
+        """Sets the tool of this V1beta1BenchmarkSpec.
+
+        Load generator, vegeta for HTTP or ghz for gRPC, defaults to vegeta  # noqa: E501
+
+        :param tool: The tool of this V1beta1BenchmarkSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._tool = tool
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1beta1BenchmarkSpec):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1beta1BenchmarkSpec):
+            return True
+
+        return self.to_dict() != other.to_dict()
@@ -0,0 +1,358 @@
+# Copyright 2023 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1beta1BenchmarkStatus(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'completion_time': 'V1Time',
+        'job_name': 'str',
+        'message': 'str',
+        'p50_latency': 'str',
+        'p95_latency': 'str',
+        'revision': 'str',
+        'state': 'str',
+        'success_ratio': 'str',
+        'throughput': 'str'
+    }
+
+    attribute_map = {
+        'completion_time': 'completionTime',
+        'job_name': 'jobName',
+        'message': 'message',
+        'p50_latency': 'p50Latency',
+        'p95_latency': 'p95Latency',
+        'revision': 'revision',
+        'state': 'state',
+        'success_ratio': 'successRatio',
+        'throughput': 'throughput'
+    }
+
+    def __init__(self, completion_time=None, job_name=None, message=None, p50_latency=None, p95_latency=None, revision=None, state=None, success_ratio=None, throughput=None, local_vars_configuration=None):  # noqa: E501
+        """V1beta1BenchmarkStatus - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._completion_time = None
+        self._job_name = None
+        self._message = None
+        self._p50_latency = None
+        self._p95_latency = None
+        self._revision = None
+        self._state = None
+        self._success_ratio = None
+        self._throughput = None
+        self.discriminator = None
+
+        if completion_time is not None:
+            self.completion_time = completion_time
+        if job_name is not None:
+            self.job_name = job_name
+        if message is not None:
+            self.message = message
+        if p50_latency is not None:
+            self.p50_latency = p50_latency
+        if p95_latency is not None:
+            self.p95_latency = p95_latency
+        if revision is not None:
+            self.revision = revision
+        if state is not None:
+            self.state = state
+        if success_ratio is not None:
+            self.success_ratio = success_ratio
+        if throughput is not None:
+            self.throughput = throughput
+
+    @property
+    def completion_time(self):
+        """Gets the completion_time of this V1beta1BenchmarkStatus.  # noqa: E501
+
+
+        :return: The completion_time of this V1beta1BenchmarkStatus.  # noqa: E501
+        :rtype: V1Time
+        """
+        return self._completion_time
+
+    @completion_time.setter
+    def completion_time(self, completion_time):
+        """Sets the completion_time of this V1beta1BenchmarkStatus.
+
+
+        :param completion_time: The completion_time of this V1beta1BenchmarkStatus.  # noqa: E501
+        :type: V1Time
+        """
+
+        self._completion_time = completion_time
+
+    @property
+    def job_name(self):
+        """Gets the job_name of this V1beta1BenchmarkStatus.  # noqa: E501
+
+        Name of the benchmark Job  # noqa: E501
+
+        :return: The job_name of this V1beta1BenchmarkStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._job_name
+
+    @job_name.setter
+    def job_name(self, job_name):
+        """Sets the job_name of this V1beta1BenchmarkStatus.
+
+        Name of the benchmark Job  # noqa: E501
+
+        :param job_name: The job_name of this V1beta1BenchmarkStatus.  # noqa: E501
+        :type: str
+        """
+
+        self._job_name = job_name
+
+    @property
+    def message(self):
+        """Gets the message of this V1beta1BenchmarkStatus.  # noqa: E501
+
+        Reason the results are missing when the benchmark failed  # noqa: E501
+
+        :return: The message of this V1beta1BenchmarkStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._message
+
+    @message.setter
+    def message(self, message):
+        """Sets the message of this V1beta1BenchmarkStatus.
+
+        Reason the results are missing when the benchmark failed  # noqa: E501
+
+        :param message: The message of this V1beta1BenchmarkStatus.  # noqa: E501
+        :type: str
+        """
+
+        self._message = message
+
+    @property
+    def p50_latency(self):
+        """Gets the p50_latency of this V1beta1BenchmarkStatus.  # noqa: E501
+
+        Median latency of the requests, e.g. 12.5ms  # noqa: E501
+
+        :return: The p50_latency of this V1beta1BenchmarkStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._p50_latency
+
+    @p50_latency.setter
+    def p50_latency(self, p50_latency):
+        """Sets the p50_latency of this V1beta1BenchmarkStatus.
+
+        Median latency of the requests, e.g. 12.5ms  # noqa: E501
+
+        :param p50_latency: The p50_latency of this V1beta1BenchmarkStatus.  # noqa: E501
+        :type: str
+        """
+
+        self._p50_latency = p50_latency
+
+    @property
+    def p95_latency(self):
+        """Gets the p95_latency of this V1beta1BenchmarkStatus.  # noqa: E501
+
+        95th percentile latency of the requests  # noqa: E501
+
+        :return: The p95_latency of this V1beta1BenchmarkStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._p95_latency
+
+    @p95_latency.setter
+    def p95_latency(self, p95_latency):
+        """Sets the p95_latency of this V1beta1BenchmarkStatus.
+
+        95th percentile latency of the requests  # noqa: E501
+
+        :param p95_latency: The p95_latency of this V1beta1BenchmarkStatus.  # noqa: E501
+        :type: str
+        """
+
+        self._p95_latency = p95_latency
+
+    @property
+    def revision(self):
+        """Gets the revision of this V1beta1BenchmarkStatus.  # noqa: E501
+
+        Predictor revision the benchmark ran against  # noqa: E501
+
+        :return: The revision of this V1beta1BenchmarkStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._revision
+
+    @revision.setter
+    def revision(self, revision):
+        """Sets the revision of this V1beta1BenchmarkStatus.
+
+        Predictor revision the benchmark ran against  # noqa: E501
+
+        :param revision: The revision of this V1beta1BenchmarkStatus.  # noqa: E501
+        :type: str
+        """
+
+        self._revision = revision
+
+    @property
+    def state(self):
+        """Gets the state of this V1beta1BenchmarkStatus.  # noqa: E501
+
+        State of the benchmark  # noqa: E501
+
+        :return: The state of this V1beta1BenchmarkStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._state
+
+    @state.setter
+    def state(self, state):
+        """Sets the state of this V1beta1BenchmarkStatus.
+
+        State of the benchmark  # noqa: E501
+
+        :param state: The state of this V1beta1BenchmarkStatus.  # noqa: E501
+        :type: str
+        """
+
+        self._state = state
+
+    @property
+    def success_ratio(self):
+        """Gets the success_ratio of this V1beta1BenchmarkStatus.  # noqa: E501
+
+        Ratio of the successful requests, between 0 and 1  # noqa: E501
+
+        :return: The success_ratio of this V1beta1BenchmarkStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._success_ratio
+
+    @success_ratio.setter
+    def success_ratio(self, success_ratio):
+        """Sets the success_ratio of this V1beta1BenchmarkStatus.
+
+        Ratio of the successful requests, between 0 and 1  # noqa: E501
+
+        :param success_ratio: The success_ratio of this V1beta1BenchmarkStatus.  # noqa: E501
+        :type: str
+        """
+
+        self._success_ratio = success_ratio
+
+    @property
+    def throughput(self):
+        """Gets the throughput of this V1beta1BenchmarkStatus.  # noqa: E501
+
+        Successful requests per second  # noqa: E501
+
+        :return: The throughput of this V1beta1BenchmarkStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._throughput
+
+    @throughput.setter
+    def throughput(self, throughput):
+        """Sets the throughput of this V1beta1BenchmarkStatus.
+
+        Successful requests per second  # noqa: E501
+
+        :param throughput: The throughput of this V1beta1BenchmarkStatus.  # noqa: E501
+        :type: str
+        """
+
+        self._throughput = throughput
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1beta1BenchmarkStatus):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1beta1BenchmarkStatus):
+            return True
+
+        return self.to_dict() != other.to_dict()
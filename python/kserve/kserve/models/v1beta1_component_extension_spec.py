@@ -47,6 +47,7 @@ class V1beta1ComponentExtensionSpec(object):
                             and the value is json key in definition.
     """
     openapi_types = {
+        'agent_security_context': 'V1beta1SidecarSecurityContext',
         'annotations': 'dict(str, str)',
         'batcher': 'V1beta1Batcher',
         'canary_traffic_percent': 'int',
@@ -55,13 +56,17 @@ class V1beta1ComponentExtensionSpec(object):
         'labels': 'dict(str, str)',
         'logger': 'V1beta1LoggerSpec',
         'max_replicas': 'int',
+        'middleware': 'V1beta1AgentMiddleware',
+        'min_ready_seconds': 'int',
         'min_replicas': 'int',
         'scale_metric': 'str',
         'scale_target': 'int',
+        'sidecar_logging': 'V1beta1SidecarLoggingSpec',
         'timeout': 'int'
     }
 
     attribute_map = {
+        'agent_security_context': 'agentSecurityContext',
         'annotations': 'annotations',
         'batcher': 'batcher',
         'canary_traffic_percent': 'canaryTrafficPercent',
@@ -70,18 +75,22 @@ class V1beta1ComponentExtensionSpec(object):
         'labels': 'labels',
         'logger': 'logger',
         'max_replicas': 'maxReplicas',
+        'middleware': 'middleware',
+        'min_ready_seconds': 'minReadySeconds',
         'min_replicas': 'minReplicas',
         'scale_metric': 'scaleMetric',
         'scale_target': 'scaleTarget',
+        'sidecar_logging': 'sidecarLogging',
         'timeout': 'timeout'
     }
 
-    def __init__(self, annotations=None, batcher=None, canary_traffic_percent=None, container_concurrency=None, deployment_strategy=None, labels=None, logger=None, max_replicas=None, min_replicas=None, scale_metric=None, scale_target=None, timeout=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, agent_security_context=None, annotations=None, batcher=None, canary_traffic_percent=None, container_concurrency=None, deployment_strategy=None, labels=None, logger=None, max_replicas=None, middleware=None, min_ready_seconds=None, min_replicas=None, scale_metric=None, scale_target=None, sidecar_logging=None, timeout=None, local_vars_configuration=None):  # noqa: E501
         """V1beta1ComponentExtensionSpec - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
         self.local_vars_configuration = local_vars_configuration
 
+        self._agent_security_context = None
         self._annotations = None
         self._batcher = None
         self._canary_traffic_percent = None
@@ -90,12 +99,17 @@ class V1beta1ComponentExtensionSpec(object):
         self._labels = None
         self._logger = None
         self._max_replicas = None
+        self._middleware = None
+        self._min_ready_seconds = None
         self._min_replicas = None
         self._scale_metric = None
         self._scale_target = None
+        self._sidecar_logging = None
         self._timeout = None
         self.discriminator = None
 
+        if agent_security_context is not None:
+            self.agent_security_context = agent_security_context
         if annotations is not None:
             self.annotations = annotations
         if batcher is not None:
@@ -112,15 +126,42 @@ class V1beta1ComponentExtensionSpec(object):
             self.logger = logger
         if max_replicas is not None:
             self.max_replicas = max_replicas
+        if middleware is not None:
+            self.middleware = middleware
+        if min_ready_seconds is not None:
+            self.min_ready_seconds = min_ready_seconds
         if min_replicas is not None:
             self.min_replicas = min_replicas
         if scale_metric is not None:
             self.scale_metric = scale_metric
         if scale_target is not None:
             self.scale_target = scale_target
+        if sidecar_logging is not None:
+            self.sidecar_logging = sidecar_logging
         if timeout is not None:
             self.timeout = timeout
 
+    @property
+    def agent_security_context(self):
+        """Gets the agent_security_context of this V1beta1ComponentExtensionSpec.  # noqa: E501
+
+
+        :return: The agent_security_context of this V1beta1ComponentExtensionSpec.  # noqa: E501
+        :rtype: V1beta1SidecarSecurityContext
+        """
+        return self._agent_security_context
+
+    @agent_security_context.setter
+    def agent_security_context(self, agent_security_context):
+        """Sets the agent_security_context of this V1beta1ComponentExtensionSpec.
+
+
+        :param agent_security_context: The agent_security_context of this V1beta1ComponentExtensionSpec.  # noqa: E501
+        :type: V1beta1SidecarSecurityContext
+        """
+
+        self._agent_security_context = agent_security_context
+
     @property
     def annotations(self):
         """Gets the annotations of this V1beta1ComponentExtensionSpec.  # noqa: E501
@@ -299,6 +340,50 @@ class V1beta1ComponentExtensionSpec(object):
 
         self._max_replicas = max_replicas
 
+    @property
+    def middleware(self):
+        """Gets the middleware of this V1beta1ComponentExtensionSpec.  # noqa: E501
+
+
+        :return: The middleware of this V1beta1ComponentExtensionSpec.  # noqa: E501
+        :rtype: V1beta1AgentMiddleware
+        """
+        return self._middleware
+
+    @middleware.setter
+    def middleware(self, middleware):
+        """Sets the middleware of this V1beta1ComponentExtensionSpec.
+
+
+        :param middleware: The middleware of this V1beta1ComponentExtensionSpec.  # noqa: E501
+        :type: V1beta1AgentMiddleware
+        """
+
+        self._middleware = middleware
+
+    @property
+    def min_ready_seconds(self):
+        """Gets the min_ready_seconds of this V1beta1ComponentExtensionSpec.  # noqa: E501
+
+        Minimum number of seconds a new pod should be ready without any of its containers crashing before it is considered available and the rollout proceeds. Only applicable for raw deployment mode.  # noqa: E501
+
+        :return: The min_ready_seconds of this V1beta1ComponentExtensionSpec.  # noqa: E501
+        :rtype: int
+        """
+        return self._min_ready_seconds
+
+    @min_ready_seconds.setter
+    def min_ready_seconds(self, min_ready_seconds):
+        """Sets the min_ready_seconds of this V1beta1ComponentExtensionSpec.
+
+        Minimum number of seconds a new pod should be ready without any of its containers crashing before it is considered available and the rollout proceeds. Only applicable for raw deployment mode.  # noqa: E501
+
+        :param min_ready_seconds: The min_ready_seconds of this V1beta1ComponentExtensionSpec.  # noqa: E501
+        :type: int
+        """
+
+        self._min_ready_seconds = min_ready_seconds
+
     @property
     def min_replicas(self):
         """Gets the min_replicas of this V1beta1ComponentExtensionSpec.  # noqa: E501
@@ -368,6 +453,27 @@ class V1beta1ComponentExtensionSpec(object):
 
         self._scale_target = scale_target
 
+    @property
+    def sidecar_logging(self):
+        """Gets the sidecar_logging of this V1beta1ComponentExtensionSpec.  # noqa: E501
+
+
+        :return: The sidecar_logging of this V1beta1ComponentExtensionSpec.  # noqa: E501
+        :rtype: V1beta1SidecarLoggingSpec
+        """
+        return self._sidecar_logging
+
+    @sidecar_logging.setter
+    def sidecar_logging(self, sidecar_logging):
+        """Sets the sidecar_logging of this V1beta1ComponentExtensionSpec.
+
+
+        :param sidecar_logging: The sidecar_logging of this V1beta1ComponentExtensionSpec.  # noqa: E501
+        :type: V1beta1SidecarLoggingSpec
+        """
+
+        self._sidecar_logging = sidecar_logging
+
     @property
     def timeout(self):
         """Gets the timeout of this V1beta1ComponentExtensionSpec.  # noqa: E501
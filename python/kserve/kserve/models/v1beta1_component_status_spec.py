@@ -48,7 +48,9 @@ class V1beta1ComponentStatusSpec(object):
     """
     openapi_types = {
         'address': 'KnativeAddressable',
+        'external_addresses': 'list[str]',
         'grpc_url': 'KnativeURL',
+        'images': 'list[V1beta1ImageStatus]',
         'latest_created_revision': 'str',
         'latest_ready_revision': 'str',
         'latest_rolledout_revision': 'str',
@@ -60,7 +62,9 @@ class V1beta1ComponentStatusSpec(object):
 
     attribute_map = {
         'address': 'address',
+        'external_addresses': 'externalAddresses',
         'grpc_url': 'grpcUrl',
+        'images': 'images',
         'latest_created_revision': 'latestCreatedRevision',
         'latest_ready_revision': 'latestReadyRevision',
         'latest_rolledout_revision': 'latestRolledoutRevision',
@@ -70,14 +74,16 @@ class V1beta1ComponentStatusSpec(object):
         'url': 'url'
     }
 
-    def __init__(self, address=None, grpc_url=None, latest_created_revision=None, latest_ready_revision=None, latest_rolledout_revision=None, previous_rolledout_revision=None, rest_url=None, traffic=None, url=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, address=None, external_addresses=None, grpc_url=None, images=None, latest_created_revision=None, latest_ready_revision=None, latest_rolledout_revision=None, previous_rolledout_revision=None, rest_url=None, traffic=None, url=None, local_vars_configuration=None):  # noqa: E501
         """V1beta1ComponentStatusSpec - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
         self.local_vars_configuration = local_vars_configuration
 
         self._address = None
+        self._external_addresses = None
         self._grpc_url = None
+        self._images = None
         self._latest_created_revision = None
         self._latest_ready_revision = None
         self._latest_rolledout_revision = None
@@ -89,8 +95,12 @@ class V1beta1ComponentStatusSpec(object):
 
         if address is not None:
             self.address = address
+        if external_addresses is not None:
+            self.external_addresses = external_addresses
         if grpc_url is not None:
             self.grpc_url = grpc_url
+        if images is not None:
+            self.images = images
         if latest_created_revision is not None:
             self.latest_created_revision = latest_created_revision
         if latest_ready_revision is not None:
@@ -127,6 +137,29 @@ class V1beta1ComponentStatusSpec(object):
 
         self._address = address
 
+    @property
+    def external_addresses(self):
+        """Gets the external_addresses of this V1beta1ComponentStatusSpec.  # noqa: E501
+
+        External IP addresses or hostnames of the load balancer, when the Service of a raw deployment is exposed with the LoadBalancer type.  # noqa: E501
+
+        :return: The external_addresses of this V1beta1ComponentStatusSpec.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._external_addresses
+
+    @external_addresses.setter
+    def external_addresses(self, external_addresses):
+        """Sets the external_addresses of this V1beta1ComponentStatusSpec.
+
+        External IP addresses or hostnames of the load balancer, when the Service of a raw deployment is exposed with the LoadBalancer type.  # noqa: E501
+
+        :param external_addresses: The external_addresses of this V1beta1ComponentStatusSpec.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._external_addresses = external_addresses
+
     @property
     def grpc_url(self):
         """Gets the grpc_url of this V1beta1ComponentStatusSpec.  # noqa: E501
@@ -148,6 +181,29 @@ class V1beta1ComponentStatusSpec(object):
 
         self._grpc_url = grpc_url
 
+    @property
+    def images(self):
+        """Gets the images of this V1beta1ComponentStatusSpec.  # noqa: E501
+
+        Images run by the containers of the latest pod of the component, including the containers injected by KServe, with the digests they were resolved to.  # noqa: E501
+
+        :return: The images of this V1beta1ComponentStatusSpec.  # noqa: E501
+        :rtype: list[V1beta1ImageStatus]
+        """
+        return self._images
+
+    @images.setter
+    def images(self, images):
+        """Sets the images of this V1beta1ComponentStatusSpec.
+
+        Images run by the containers of the latest pod of the component, including the containers injected by KServe, with the digests they were resolved to.  # noqa: E501
+
+        :param images: The images of this V1beta1ComponentStatusSpec.  # noqa: E501
+        :type: list[V1beta1ImageStatus]
+        """
+
+        self._images = images
+
     @property
     def latest_created_revision(self):
         """Gets the latest_created_revision of this V1beta1ComponentStatusSpec.  # noqa: E501
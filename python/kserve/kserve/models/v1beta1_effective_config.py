@@ -0,0 +1,220 @@
+# Copyright 2023 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1beta1EffectiveConfig(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'autoscaler_class': 'str',
+        'config_map_resource_version': 'str',
+        'deployment_mode': 'str',
+        'ingress_class_name': 'str'
+    }
+
+    attribute_map = {
+        'autoscaler_class': 'autoscalerClass',
+        'config_map_resource_version': 'configMapResourceVersion',
+        'deployment_mode': 'deploymentMode',
+        'ingress_class_name': 'ingressClassName'
+    }
+
+    def __init__(self, autoscaler_class=None, config_map_resource_version=None, deployment_mode=None, ingress_class_name=None, local_vars_configuration=None):  # noqa: E501
+        """V1beta1EffectiveConfig - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._autoscaler_class = None
+        self._config_map_resource_version = None
+        self._deployment_mode = None
+        self._ingress_class_name = None
+        self.discriminator = None
+
+        if autoscaler_class is not None:
+            self.autoscaler_class = autoscaler_class
+        if config_map_resource_version is not None:
+            self.config_map_resource_version = config_map_resource_version
+        if deployment_mode is not None:
+            self.deployment_mode = deployment_mode
+        if ingress_class_name is not None:
+            self.ingress_class_name = ingress_class_name
+
+    @property
+    def autoscaler_class(self):
+        """Gets the autoscaler_class of this V1beta1EffectiveConfig.  # noqa: E501
+
+        Resolved autoscaler class  # noqa: E501
+
+        :return: The autoscaler_class of this V1beta1EffectiveConfig.  # noqa: E501
+        :rtype: str
+        """
+        return self._autoscaler_class
+
+    @autoscaler_class.setter
+    def autoscaler_class(self, autoscaler_class):
+        """Sets the autoscaler_class of this V1beta1EffectiveConfig.
+
+        Resolved autoscaler class  # noqa: E501
+
+        :param autoscaler_class: The autoscaler_class of this V1beta1EffectiveConfig.  # noqa: E501
+        :type: str
+        """
+
+        self._autoscaler_class = autoscaler_class
+
+    @property
+    def config_map_resource_version(self):
+        """Gets the config_map_resource_version of this V1beta1EffectiveConfig.  # noqa: E501
+
+        Resource version of the inferenceservice-config ConfigMap  # noqa: E501
+
+        :return: The config_map_resource_version of this V1beta1EffectiveConfig.  # noqa: E501
+        :rtype: str
+        """
+        return self._config_map_resource_version
+
+    @config_map_resource_version.setter
+    def config_map_resource_version(self, config_map_resource_version):
+        """Sets the config_map_resource_version of this V1beta1EffectiveConfig.
+
+        Resource version of the inferenceservice-config ConfigMap  # noqa: E501
+
+        :param config_map_resource_version: The config_map_resource_version of this V1beta1EffectiveConfig.  # noqa: E501
+        :type: str
+        """
+
+        self._config_map_resource_version = config_map_resource_version
+
+    @property
+    def deployment_mode(self):
+        """Gets the deployment_mode of this V1beta1EffectiveConfig.  # noqa: E501
+
+        Resolved deployment mode  # noqa: E501
+
+        :return: The deployment_mode of this V1beta1EffectiveConfig.  # noqa: E501
+        :rtype: str
+        """
+        return self._deployment_mode
+
+    @deployment_mode.setter
+    def deployment_mode(self, deployment_mode):
+        """Sets the deployment_mode of this V1beta1EffectiveConfig.
+
+        Resolved deployment mode  # noqa: E501
+
+        :param deployment_mode: The deployment_mode of this V1beta1EffectiveConfig.  # noqa: E501
+        :type: str
+        """
+
+        self._deployment_mode = deployment_mode
+
+    @property
+    def ingress_class_name(self):
+        """Gets the ingress_class_name of this V1beta1EffectiveConfig.  # noqa: E501
+
+        Ingress class of the generated ingress, only set in raw deployment mode  # noqa: E501
+
+        :return: The ingress_class_name of this V1beta1EffectiveConfig.  # noqa: E501
+        :rtype: str
+        """
+        return self._ingress_class_name
+
+    @ingress_class_name.setter
+    def ingress_class_name(self, ingress_class_name):
+        """Sets the ingress_class_name of this V1beta1EffectiveConfig.
+
+        Ingress class of the generated ingress, only set in raw deployment mode  # noqa: E501
+
+        :param ingress_class_name: The ingress_class_name of this V1beta1EffectiveConfig.  # noqa: E501
+        :type: str
+        """
+
+        self._ingress_class_name = ingress_class_name
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1beta1EffectiveConfig):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1beta1EffectiveConfig):
+            return True
+
+        return self.to_dict() != other.to_dict()
@@ -0,0 +1,193 @@
+# Copyright 2023 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1beta1GuardrailHook(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'failure_policy': 'str',
+        'timeout_seconds': 'int',
+        'url': 'str'
+    }
+
+    attribute_map = {
+        'failure_policy': 'failurePolicy',
+        'timeout_seconds': 'timeoutSeconds',
+        'url': 'url'
+    }
+
+    def __init__(self, failure_policy=None, timeout_seconds=None, url='', local_vars_configuration=None):  # noqa: E501
+        """V1beta1GuardrailHook - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._failure_policy = None
+        self._timeout_seconds = None
+        self._url = None
+        self.discriminator = None
+
+        if failure_policy is not None:
+            self.failure_policy = failure_policy
+        if timeout_seconds is not None:
+            self.timeout_seconds = timeout_seconds
+        self.url = url
+
+    @property
+    def failure_policy(self):
+        """Gets the failure_policy of this V1beta1GuardrailHook.  # noqa: E501
+
+        What happens when the hook can not be called, times out or fails, Fail (default) or Ignore  # noqa: E501
+
+        :return: The failure_policy of this V1beta1GuardrailHook.  # noqa: E501
+        :rtype: str
+        """
+        return self._failure_policy
+
+    @failure_policy.setter
+    def failure_policy(self, failure_policy):
+        """Sets the failure_policy of this V1beta1GuardrailHook.
+
+        What happens when the hook can not be called, times out or fails, Fail (default) or Ignore  # noqa: E501
+
+        :param failure_policy: The failure_policy of this V1beta1GuardrailHook.  # noqa: E501
+        :type: str
+        """
+
+        self._failure_policy = failure_policy
+
+    @property
+    def timeout_seconds(self):
+        """Gets the timeout_seconds of this V1beta1GuardrailHook.  # noqa: E501
+
+        Timeout of the callout in seconds, defaults to 5  # noqa: E501
+
+        :return: The timeout_seconds of this V1beta1GuardrailHook.  # noqa: E501
+        :rtype: int
+        """
+        return self._timeout_seconds
+
+    @timeout_seconds.setter
+    def timeout_seconds(self, timeout_seconds):
+        """Sets the timeout_seconds of this V1beta1GuardrailHook.
+
+        Timeout of the callout in seconds, defaults to 5  # noqa: E501
+
+        :param timeout_seconds: The timeout_seconds of this V1beta1GuardrailHook.  # noqa: E501
+        :type: int
+        """
+
+        self._timeout_seconds = timeout_seconds
+
+    @property
+    def url(self):
+        """Gets the url of this V1beta1GuardrailHook.  # noqa: E501
+
+        URL of the hook  # noqa: E501
+
+        :return: The url of this V1beta1GuardrailHook.  # noqa: E501
+        :rtype: str
+        """
+        return self._url
+
+    @url.setter
+    def url(self, url):
+        """Sets the url of this V1beta1GuardrailHook.
+
+        URL of the hook  # noqa: E501
+
+        :param url: The url of this V1beta1GuardrailHook.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and url is None:  # noqa: E501
+            raise ValueError("Invalid value for `url`, must not be `None`")  # noqa: E501
+
+        self._url = url
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1beta1GuardrailHook):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1beta1GuardrailHook):
+            return True
+
+        return self.to_dict() != other.to_dict()
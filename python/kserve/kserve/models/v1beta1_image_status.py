@@ -0,0 +1,249 @@
+# Copyright 2023 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1beta1ImageStatus(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'container': 'str',
+        'digest': 'str',
+        'image': 'str',
+        'message': 'str',
+        'verification': 'str'
+    }
+
+    attribute_map = {
+        'container': 'container',
+        'digest': 'digest',
+        'image': 'image',
+        'message': 'message',
+        'verification': 'verification'
+    }
+
+    def __init__(self, container='', digest=None, image=None, message=None, verification=None, local_vars_configuration=None):  # noqa: E501
+        """V1beta1ImageStatus - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._container = None
+        self._digest = None
+        self._image = None
+        self._message = None
+        self._verification = None
+        self.discriminator = None
+
+        self.container = container
+        if digest is not None:
+            self.digest = digest
+        if image is not None:
+            self.image = image
+        if message is not None:
+            self.message = message
+        if verification is not None:
+            self.verification = verification
+
+    @property
+    def container(self):
+        """Gets the container of this V1beta1ImageStatus.  # noqa: E501
+
+        Name of the container  # noqa: E501
+
+        :return: The container of this V1beta1ImageStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._container
+
+    @container.setter
+    def container(self, container):
+        """Sets the container of this V1beta1ImageStatus.
+
+        Name of the container  # noqa: E501
+
+        :param container: The container of this V1beta1ImageStatus.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and container is None:  # noqa: E501
+            raise ValueError("Invalid value for `container`, must not be `None`")  # noqa: E501
+
+        self._container = container
+
+    @property
+    def digest(self):
+        """Gets the digest of this V1beta1ImageStatus.  # noqa: E501
+
+        Digest of the image pulled by the container runtime, e.g. sha256:4d2f...  # noqa: E501
+
+        :return: The digest of this V1beta1ImageStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._digest
+
+    @digest.setter
+    def digest(self, digest):
+        """Sets the digest of this V1beta1ImageStatus.
+
+        Digest of the image pulled by the container runtime, e.g. sha256:4d2f...  # noqa: E501
+
+        :param digest: The digest of this V1beta1ImageStatus.  # noqa: E501
+        :type: str
+        """
+
+        self._digest = digest
+
+    @property
+    def image(self):
+        """Gets the image of this V1beta1ImageStatus.  # noqa: E501
+
+        Image of the container as set in the pod  # noqa: E501
+
+        :return: The image of this V1beta1ImageStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._image
+
+    @image.setter
+    def image(self, image):
+        """Sets the image of this V1beta1ImageStatus.
+
+        Image of the container as set in the pod  # noqa: E501
+
+        :param image: The image of this V1beta1ImageStatus.  # noqa: E501
+        :type: str
+        """
+
+        self._image = image
+
+    @property
+    def message(self):
+        """Gets the message of this V1beta1ImageStatus.  # noqa: E501
+
+        Reason the verification failed  # noqa: E501
+
+        :return: The message of this V1beta1ImageStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._message
+
+    @message.setter
+    def message(self, message):
+        """Sets the message of this V1beta1ImageStatus.
+
+        Reason the verification failed  # noqa: E501
+
+        :param message: The message of this V1beta1ImageStatus.  # noqa: E501
+        :type: str
+        """
+
+        self._message = message
+
+    @property
+    def verification(self):
+        """Gets the verification of this V1beta1ImageStatus.  # noqa: E501
+
+        Result of the verification of the sigstore attestation of the image, it is empty when no attestation public key is configured in the image policy.  # noqa: E501
+
+        :return: The verification of this V1beta1ImageStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._verification
+
+    @verification.setter
+    def verification(self, verification):
+        """Sets the verification of this V1beta1ImageStatus.
+
+        Result of the verification of the sigstore attestation of the image, it is empty when no attestation public key is configured in the image policy.  # noqa: E501
+
+        :param verification: The verification of this V1beta1ImageStatus.  # noqa: E501
+        :type: str
+        """
+
+        self._verification = verification
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1beta1ImageStatus):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1beta1ImageStatus):
+            return True
+
+        return self.to_dict() != other.to_dict()
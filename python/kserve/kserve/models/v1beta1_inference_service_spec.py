@@ -47,33 +47,121 @@ class V1beta1InferenceServiceSpec(object):
                             and the value is json key in definition.
     """
     openapi_types = {
+        'active_hours': 'V1beta1ActiveHours',
+        'benchmark': 'V1beta1BenchmarkSpec',
+        'cors': 'V1beta1CORSPolicy',
         'explainer': 'V1beta1ExplainerSpec',
         'predictor': 'V1beta1PredictorSpec',
-        'transformer': 'V1beta1TransformerSpec'
+        'serving_priority': 'int',
+        'transformer': 'V1beta1TransformerSpec',
+        'validation': 'V1beta1ValidationSpec'
     }
 
     attribute_map = {
+        'active_hours': 'activeHours',
+        'benchmark': 'benchmark',
+        'cors': 'cors',
         'explainer': 'explainer',
         'predictor': 'predictor',
-        'transformer': 'transformer'
+        'serving_priority': 'servingPriority',
+        'transformer': 'transformer',
+        'validation': 'validation'
     }
 
-    def __init__(self, explainer=None, predictor=None, transformer=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, active_hours=None, benchmark=None, cors=None, explainer=None, predictor=None, serving_priority=None, transformer=None, validation=None, local_vars_configuration=None):  # noqa: E501
         """V1beta1InferenceServiceSpec - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
         self.local_vars_configuration = local_vars_configuration
 
+        self._active_hours = None
+        self._benchmark = None
+        self._cors = None
         self._explainer = None
         self._predictor = None
+        self._serving_priority = None
         self._transformer = None
+        self._validation = None
         self.discriminator = None
 
+        if active_hours is not None:
+            self.active_hours = active_hours
+        if benchmark is not None:
+            self.benchmark = benchmark
+        if cors is not None:
+            self.cors = cors
         if explainer is not None:
             self.explainer = explainer
         self.predictor = predictor
+        if serving_priority is not None:
+            self.serving_priority = serving_priority
         if transformer is not None:
             self.transformer = transformer
+        if validation is not None:
+            self.validation = validation
+
+    @property
+    def active_hours(self):
+        """Gets the active_hours of this V1beta1InferenceServiceSpec.  # noqa: E501
+
+
+        :return: The active_hours of this V1beta1InferenceServiceSpec.  # noqa: E501
+        :rtype: V1beta1ActiveHours
+        """
+        return self._active_hours
+
+    @active_hours.setter
+    def active_hours(self, active_hours):
+        """Sets the active_hours of this V1beta1InferenceServiceSpec.
+
+
+        :param active_hours: The active_hours of this V1beta1InferenceServiceSpec.  # noqa: E501
+        :type: V1beta1ActiveHours
+        """
+
+        self._active_hours = active_hours
+
+    @property
+    def benchmark(self):
+        """Gets the benchmark of this V1beta1InferenceServiceSpec.  # noqa: E501
+
+
+        :return: The benchmark of this V1beta1InferenceServiceSpec.  # noqa: E501
+        :rtype: V1beta1BenchmarkSpec
+        """
+        return self._benchmark
+
+    @benchmark.setter
+    def benchmark(self, benchmark):
+        """Sets the benchmark of this V1beta1InferenceServiceSpec.
+
+
+        :param benchmark: The benchmark of this V1beta1InferenceServiceSpec.  # noqa: E501
+        :type: V1beta1BenchmarkSpec
+        """
+
+        self._benchmark = benchmark
+
+    @property
+    def cors(self):
+        """Gets the cors of this V1beta1InferenceServiceSpec.  # noqa: E501
+
+
+        :return: The cors of this V1beta1InferenceServiceSpec.  # noqa: E501
+        :rtype: V1beta1CORSPolicy
+        """
+        return self._cors
+
+    @cors.setter
+    def cors(self, cors):
+        """Sets the cors of this V1beta1InferenceServiceSpec.
+
+
+        :param cors: The cors of this V1beta1InferenceServiceSpec.  # noqa: E501
+        :type: V1beta1CORSPolicy
+        """
+
+        self._cors = cors
 
     @property
     def explainer(self):
@@ -119,6 +207,29 @@ class V1beta1InferenceServiceSpec(object):
 
         self._predictor = predictor
 
+    @property
+    def serving_priority(self):
+        """Gets the serving_priority of this V1beta1InferenceServiceSpec.  # noqa: E501
+
+        ServingPriority ranks the InferenceService when the GPUs are scarce, an InferenceService whose GPU pods cannot be scheduled stops the InferenceServices of lower priority of its namespace when the GPU preemption is enabled. It defaults to the value of the PriorityClass of the predictor, or else to 0. It cannot exceed the maxServingPriority of the GPU preemption config.  # noqa: E501
+
+        :return: The serving_priority of this V1beta1InferenceServiceSpec.  # noqa: E501
+        :rtype: int
+        """
+        return self._serving_priority
+
+    @serving_priority.setter
+    def serving_priority(self, serving_priority):
+        """Sets the serving_priority of this V1beta1InferenceServiceSpec.
+
+        ServingPriority ranks the InferenceService when the GPUs are scarce, an InferenceService whose GPU pods cannot be scheduled stops the InferenceServices of lower priority of its namespace when the GPU preemption is enabled. It defaults to the value of the PriorityClass of the predictor, or else to 0. It cannot exceed the maxServingPriority of the GPU preemption config.  # noqa: E501
+
+        :param serving_priority: The serving_priority of this V1beta1InferenceServiceSpec.  # noqa: E501
+        :type: int
+        """
+
+        self._serving_priority = serving_priority
+
     @property
     def transformer(self):
         """Gets the transformer of this V1beta1InferenceServiceSpec.  # noqa: E501
@@ -140,6 +251,27 @@ class V1beta1InferenceServiceSpec(object):
 
         self._transformer = transformer
 
+    @property
+    def validation(self):
+        """Gets the validation of this V1beta1InferenceServiceSpec.  # noqa: E501
+
+
+        :return: The validation of this V1beta1InferenceServiceSpec.  # noqa: E501
+        :rtype: V1beta1ValidationSpec
+        """
+        return self._validation
+
+    @validation.setter
+    def validation(self, validation):
+        """Sets the validation of this V1beta1InferenceServiceSpec.
+
+
+        :param validation: The validation of this V1beta1InferenceServiceSpec.  # noqa: E501
+        :type: V1beta1ValidationSpec
+        """
+
+        self._validation = validation
+
     def to_dict(self):
         """Returns the model properties as a dict"""
         result = {}
@@ -49,8 +49,11 @@ class V1beta1InferenceServiceStatus(object):
     openapi_types = {
         'address': 'KnativeAddressable',
         'annotations': 'dict(str, str)',
+        'benchmark': 'V1beta1BenchmarkStatus',
         'components': 'dict(str, V1beta1ComponentStatusSpec)',
         'conditions': 'list[KnativeCondition]',
+        'effective_config': 'V1beta1EffectiveConfig',
+        'endpoints': 'list[V1beta1Endpoint]',
         'model_status': 'V1beta1ModelStatus',
         'observed_generation': 'int',
         'url': 'KnativeURL'
@@ -59,14 +62,17 @@ class V1beta1InferenceServiceStatus(object):
     attribute_map = {
         'address': 'address',
         'annotations': 'annotations',
+        'benchmark': 'benchmark',
         'components': 'components',
         'conditions': 'conditions',
+        'effective_config': 'effectiveConfig',
+        'endpoints': 'endpoints',
         'model_status': 'modelStatus',
         'observed_generation': 'observedGeneration',
         'url': 'url'
     }
 
-    def __init__(self, address=None, annotations=None, components=None, conditions=None, model_status=None, observed_generation=None, url=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, address=None, annotations=None, benchmark=None, components=None, conditions=None, effective_config=None, endpoints=None, model_status=None, observed_generation=None, url=None, local_vars_configuration=None):  # noqa: E501
         """V1beta1InferenceServiceStatus - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
@@ -74,8 +80,11 @@ class V1beta1InferenceServiceStatus(object):
 
         self._address = None
         self._annotations = None
+        self._benchmark = None
         self._components = None
         self._conditions = None
+        self._effective_config = None
+        self._endpoints = None
         self._model_status = None
         self._observed_generation = None
         self._url = None
@@ -85,10 +94,16 @@ class V1beta1InferenceServiceStatus(object):
             self.address = address
         if annotations is not None:
             self.annotations = annotations
+        if benchmark is not None:
+            self.benchmark = benchmark
         if components is not None:
             self.components = components
         if conditions is not None:
             self.conditions = conditions
+        if effective_config is not None:
+            self.effective_config = effective_config
+        if endpoints is not None:
+            self.endpoints = endpoints
         if model_status is not None:
             self.model_status = model_status
         if observed_generation is not None:
@@ -140,6 +155,27 @@ class V1beta1InferenceServiceStatus(object):
 
         self._annotations = annotations
 
+    @property
+    def benchmark(self):
+        """Gets the benchmark of this V1beta1InferenceServiceStatus.  # noqa: E501
+
+
+        :return: The benchmark of this V1beta1InferenceServiceStatus.  # noqa: E501
+        :rtype: V1beta1BenchmarkStatus
+        """
+        return self._benchmark
+
+    @benchmark.setter
+    def benchmark(self, benchmark):
+        """Sets the benchmark of this V1beta1InferenceServiceStatus.
+
+
+        :param benchmark: The benchmark of this V1beta1InferenceServiceStatus.  # noqa: E501
+        :type: V1beta1BenchmarkStatus
+        """
+
+        self._benchmark = benchmark
+
     @property
     def components(self):
         """Gets the components of this V1beta1InferenceServiceStatus.  # noqa: E501
@@ -186,6 +222,50 @@ class V1beta1InferenceServiceStatus(object):
 
         self._conditions = conditions
 
+    @property
+    def effective_config(self):
+        """Gets the effective_config of this V1beta1InferenceServiceStatus.  # noqa: E501
+
+
+        :return: The effective_config of this V1beta1InferenceServiceStatus.  # noqa: E501
+        :rtype: V1beta1EffectiveConfig
+        """
+        return self._effective_config
+
+    @effective_config.setter
+    def effective_config(self, effective_config):
+        """Sets the effective_config of this V1beta1InferenceServiceStatus.
+
+
+        :param effective_config: The effective_config of this V1beta1InferenceServiceStatus.  # noqa: E501
+        :type: V1beta1EffectiveConfig
+        """
+
+        self._effective_config = effective_config
+
+    @property
+    def endpoints(self):
+        """Gets the endpoints of this V1beta1InferenceServiceStatus.  # noqa: E501
+
+        Endpoints lists all the addresses the InferenceService is reachable at, per protocol and visibility  # noqa: E501
+
+        :return: The endpoints of this V1beta1InferenceServiceStatus.  # noqa: E501
+        :rtype: list[V1beta1Endpoint]
+        """
+        return self._endpoints
+
+    @endpoints.setter
+    def endpoints(self, endpoints):
+        """Sets the endpoints of this V1beta1InferenceServiceStatus.
+
+        Endpoints lists all the addresses the InferenceService is reachable at, per protocol and visibility  # noqa: E501
+
+        :param endpoints: The endpoints of this V1beta1InferenceServiceStatus.  # noqa: E501
+        :type: list[V1beta1Endpoint]
+        """
+
+        self._endpoints = endpoints
+
     @property
     def model_status(self):
         """Gets the model_status of this V1beta1InferenceServiceStatus.  # noqa: E501
@@ -47,30 +47,58 @@ class V1beta1LoggerSpec(object):
                             and the value is json key in definition.
     """
     openapi_types = {
+        'delivery_mode': 'str',
         'mode': 'str',
         'url': 'str'
     }
 
     attribute_map = {
+        'delivery_mode': 'deliveryMode',
         'mode': 'mode',
         'url': 'url'
     }
 
-    def __init__(self, mode=None, url=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, delivery_mode=None, mode=None, url=None, local_vars_configuration=None):  # noqa: E501
         """V1beta1LoggerSpec - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
         self.local_vars_configuration = local_vars_configuration
 
+        self._delivery_mode = None
         self._mode = None
         self._url = None
         self.discriminator = None
 
+        if delivery_mode is not None:
+            self.delivery_mode = delivery_mode
         if mode is not None:
             self.mode = mode
         if url is not None:
             self.url = url
 
+    @property
+    def delivery_mode(self):
+        """Gets the delivery_mode of this V1beta1LoggerSpec.  # noqa: E501
+
+        Specifies what happens to the log events when the sink can not keep up and the logger queue is full. <br /> Valid values are: <br /> - \"bestEffort\" (default): drop the log events, the inference requests are not affected; <br /> - \"backPressure\": reject the inference requests with 503 until the queue has room; <br /> - \"spill\": write the log events to a local volume and send them once the queue has room <br />  # noqa: E501
+
+        :return: The delivery_mode of this V1beta1LoggerSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._delivery_mode
+
+    @delivery_mode.setter
+    def delivery_mode(self, delivery_mode):
+        """Sets the delivery_mode of this V1beta1LoggerSpec.
+
+        Specifies what happens to the log events when the sink can not keep up and the logger queue is full. <br /> Valid values are: <br /> - \"bestEffort\" (default): drop the log events, the inference requests are not affected; <br /> - \"backPressure\": reject the inference requests with 503 until the queue has room; <br /> - \"spill\": write the log events to a local volume and send them once the queue has room <br />  # noqa: E501
+
+        :param delivery_mode: The delivery_mode of this V1beta1LoggerSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._delivery_mode = delivery_mode
+
     @property
     def mode(self):
         """Gets the mode of this V1beta1LoggerSpec.  # noqa: E501
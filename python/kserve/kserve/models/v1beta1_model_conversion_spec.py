@@ -0,0 +1,275 @@
+# Copyright 2023 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1beta1ModelConversionSpec(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'args': 'list[str]',
+        'cache_pvc_name': 'str',
+        'command': 'list[str]',
+        'env': 'list[V1EnvVar]',
+        'image': 'str',
+        'resources': 'V1ResourceRequirements'
+    }
+
+    attribute_map = {
+        'args': 'args',
+        'cache_pvc_name': 'cachePvcName',
+        'command': 'command',
+        'env': 'env',
+        'image': 'image',
+        'resources': 'resources'
+    }
+
+    def __init__(self, args=None, cache_pvc_name=None, command=None, env=None, image='', resources=None, local_vars_configuration=None):  # noqa: E501
+        """V1beta1ModelConversionSpec - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._args = None
+        self._cache_pvc_name = None
+        self._command = None
+        self._env = None
+        self._image = None
+        self._resources = None
+        self.discriminator = None
+
+        if args is not None:
+            self.args = args
+        if cache_pvc_name is not None:
+            self.cache_pvc_name = cache_pvc_name
+        if command is not None:
+            self.command = command
+        if env is not None:
+            self.env = env
+        self.image = image
+        if resources is not None:
+            self.resources = resources
+
+    @property
+    def args(self):
+        """Gets the args of this V1beta1ModelConversionSpec.  # noqa: E501
+
+        Arguments of the conversion container.  # noqa: E501
+
+        :return: The args of this V1beta1ModelConversionSpec.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._args
+
+    @args.setter
+    def args(self, args):
+        """Sets the args of this V1beta1ModelConversionSpec.
+
+        Arguments of the conversion container.  # noqa: E501
+
+        :param args: The args of this V1beta1ModelConversionSpec.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._args = args
+
+    @property
+    def cache_pvc_name(self):
+        """Gets the cache_pvc_name of this V1beta1ModelConversionSpec.  # noqa: E501
+
+        Name of a PersistentVolumeClaim caching the converted artifacts across the pods. When it is set, the CONVERSION_CACHE_DIR environment variable points to a directory of the claim which is unique for the storage uri and the conversion command, the container should reuse the artifacts found there instead of converting the model again.  # noqa: E501
+
+        :return: The cache_pvc_name of this V1beta1ModelConversionSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._cache_pvc_name
+
+    @cache_pvc_name.setter
+    def cache_pvc_name(self, cache_pvc_name):
+        """Sets the cache_pvc_name of this V1beta1ModelConversionSpec.
+
+        Name of a PersistentVolumeClaim caching the converted artifacts across the pods. When it is set, the CONVERSION_CACHE_DIR environment variable points to a directory of the claim which is unique for the storage uri and the conversion command, the container should reuse the artifacts found there instead of converting the model again.  # noqa: E501
+
+        :param cache_pvc_name: The cache_pvc_name of this V1beta1ModelConversionSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._cache_pvc_name = cache_pvc_name
+
+    @property
+    def command(self):
+        """Gets the command of this V1beta1ModelConversionSpec.  # noqa: E501
+
+        Entrypoint of the conversion container, the entrypoint of the image is used when it is not set.  # noqa: E501
+
+        :return: The command of this V1beta1ModelConversionSpec.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._command
+
+    @command.setter
+    def command(self, command):
+        """Sets the command of this V1beta1ModelConversionSpec.
+
+        Entrypoint of the conversion container, the entrypoint of the image is used when it is not set.  # noqa: E501
+
+        :param command: The command of this V1beta1ModelConversionSpec.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._command = command
+
+    @property
+    def env(self):
+        """Gets the env of this V1beta1ModelConversionSpec.  # noqa: E501
+
+        List of environment variables to set in the conversion container.  # noqa: E501
+
+        :return: The env of this V1beta1ModelConversionSpec.  # noqa: E501
+        :rtype: list[V1EnvVar]
+        """
+        return self._env
+
+    @env.setter
+    def env(self, env):
+        """Sets the env of this V1beta1ModelConversionSpec.
+
+        List of environment variables to set in the conversion container.  # noqa: E501
+
+        :param env: The env of this V1beta1ModelConversionSpec.  # noqa: E501
+        :type: list[V1EnvVar]
+        """
+
+        self._env = env
+
+    @property
+    def image(self):
+        """Gets the image of this V1beta1ModelConversionSpec.  # noqa: E501
+
+        Image of the container running the conversion.  # noqa: E501
+
+        :return: The image of this V1beta1ModelConversionSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._image
+
+    @image.setter
+    def image(self, image):
+        """Sets the image of this V1beta1ModelConversionSpec.
+
+        Image of the container running the conversion.  # noqa: E501
+
+        :param image: The image of this V1beta1ModelConversionSpec.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and image is None:  # noqa: E501
+            raise ValueError("Invalid value for `image`, must not be `None`")  # noqa: E501
+
+        self._image = image
+
+    @property
+    def resources(self):
+        """Gets the resources of this V1beta1ModelConversionSpec.  # noqa: E501
+
+
+        :return: The resources of this V1beta1ModelConversionSpec.  # noqa: E501
+        :rtype: V1ResourceRequirements
+        """
+        return self._resources
+
+    @resources.setter
+    def resources(self, resources):
+        """Sets the resources of this V1beta1ModelConversionSpec.
+
+
+        :param resources: The resources of this V1beta1ModelConversionSpec.  # noqa: E501
+        :type: V1ResourceRequirements
+        """
+
+        self._resources = resources
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1beta1ModelConversionSpec):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1beta1ModelConversionSpec):
+            return True
+
+        return self.to_dict() != other.to_dict()
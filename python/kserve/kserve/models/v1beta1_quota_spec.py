@@ -0,0 +1,191 @@
+# Copyright 2023 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1beta1QuotaSpec(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'default_limit': 'int',
+        'period': 'V1Duration',
+        'tenants': 'dict(str, int)'
+    }
+
+    attribute_map = {
+        'default_limit': 'defaultLimit',
+        'period': 'period',
+        'tenants': 'tenants'
+    }
+
+    def __init__(self, default_limit=None, period=None, tenants=None, local_vars_configuration=None):  # noqa: E501
+        """V1beta1QuotaSpec - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._default_limit = None
+        self._period = None
+        self._tenants = None
+        self.discriminator = None
+
+        if default_limit is not None:
+            self.default_limit = default_limit
+        self.period = period
+        if tenants is not None:
+            self.tenants = tenants
+
+    @property
+    def default_limit(self):
+        """Gets the default_limit of this V1beta1QuotaSpec.  # noqa: E501
+
+        Number of requests per period shared by the tenants without a quota of their own and by the requests of the unauthenticated paths. They are unlimited when not set.  # noqa: E501
+
+        :return: The default_limit of this V1beta1QuotaSpec.  # noqa: E501
+        :rtype: int
+        """
+        return self._default_limit
+
+    @default_limit.setter
+    def default_limit(self, default_limit):
+        """Sets the default_limit of this V1beta1QuotaSpec.
+
+        Number of requests per period shared by the tenants without a quota of their own and by the requests of the unauthenticated paths. They are unlimited when not set.  # noqa: E501
+
+        :param default_limit: The default_limit of this V1beta1QuotaSpec.  # noqa: E501
+        :type: int
+        """
+
+        self._default_limit = default_limit
+
+    @property
+    def period(self):
+        """Gets the period of this V1beta1QuotaSpec.  # noqa: E501
+
+
+        :return: The period of this V1beta1QuotaSpec.  # noqa: E501
+        :rtype: V1Duration
+        """
+        return self._period
+
+    @period.setter
+    def period(self, period):
+        """Sets the period of this V1beta1QuotaSpec.
+
+
+        :param period: The period of this V1beta1QuotaSpec.  # noqa: E501
+        :type: V1Duration
+        """
+        if self.local_vars_configuration.client_side_validation and period is None:  # noqa: E501
+            raise ValueError("Invalid value for `period`, must not be `None`")  # noqa: E501
+
+        self._period = period
+
+    @property
+    def tenants(self):
+        """Gets the tenants of this V1beta1QuotaSpec.  # noqa: E501
+
+        Number of requests per period, by tenant  # noqa: E501
+
+        :return: The tenants of this V1beta1QuotaSpec.  # noqa: E501
+        :rtype: dict(str, int)
+        """
+        return self._tenants
+
+    @tenants.setter
+    def tenants(self, tenants):
+        """Sets the tenants of this V1beta1QuotaSpec.
+
+        Number of requests per period, by tenant  # noqa: E501
+
+        :param tenants: The tenants of this V1beta1QuotaSpec.  # noqa: E501
+        :type: dict(str, int)
+        """
+
+        self._tenants = tenants
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1beta1QuotaSpec):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1beta1QuotaSpec):
+            return True
+
+        return self.to_dict() != other.to_dict()
@@ -0,0 +1,249 @@
+# Copyright 2023 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1beta1TokenExchange(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'audience': 'str',
+        'client_secret_name': 'str',
+        'scopes': 'list[str]',
+        'token_url': 'str',
+        'unauthenticated_paths': 'list[V1beta1UnauthenticatedPath]'
+    }
+
+    attribute_map = {
+        'audience': 'audience',
+        'client_secret_name': 'clientSecretName',
+        'scopes': 'scopes',
+        'token_url': 'tokenUrl',
+        'unauthenticated_paths': 'unauthenticatedPaths'
+    }
+
+    def __init__(self, audience=None, client_secret_name=None, scopes=None, token_url='', unauthenticated_paths=None, local_vars_configuration=None):  # noqa: E501
+        """V1beta1TokenExchange - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._audience = None
+        self._client_secret_name = None
+        self._scopes = None
+        self._token_url = None
+        self._unauthenticated_paths = None
+        self.discriminator = None
+
+        if audience is not None:
+            self.audience = audience
+        if client_secret_name is not None:
+            self.client_secret_name = client_secret_name
+        if scopes is not None:
+            self.scopes = scopes
+        self.token_url = token_url
+        if unauthenticated_paths is not None:
+            self.unauthenticated_paths = unauthenticated_paths
+
+    @property
+    def audience(self):
+        """Gets the audience of this V1beta1TokenExchange.  # noqa: E501
+
+        Audience the exchanged token is requested for  # noqa: E501
+
+        :return: The audience of this V1beta1TokenExchange.  # noqa: E501
+        :rtype: str
+        """
+        return self._audience
+
+    @audience.setter
+    def audience(self, audience):
+        """Sets the audience of this V1beta1TokenExchange.
+
+        Audience the exchanged token is requested for  # noqa: E501
+
+        :param audience: The audience of this V1beta1TokenExchange.  # noqa: E501
+        :type: str
+        """
+
+        self._audience = audience
+
+    @property
+    def client_secret_name(self):
+        """Gets the client_secret_name of this V1beta1TokenExchange.  # noqa: E501
+
+        Name of the secret holding the client_id and client_secret the agent authenticates at the token endpoint with, the agent does not authenticate when not set  # noqa: E501
+
+        :return: The client_secret_name of this V1beta1TokenExchange.  # noqa: E501
+        :rtype: str
+        """
+        return self._client_secret_name
+
+    @client_secret_name.setter
+    def client_secret_name(self, client_secret_name):
+        """Sets the client_secret_name of this V1beta1TokenExchange.
+
+        Name of the secret holding the client_id and client_secret the agent authenticates at the token endpoint with, the agent does not authenticate when not set  # noqa: E501
+
+        :param client_secret_name: The client_secret_name of this V1beta1TokenExchange.  # noqa: E501
+        :type: str
+        """
+
+        self._client_secret_name = client_secret_name
+
+    @property
+    def scopes(self):
+        """Gets the scopes of this V1beta1TokenExchange.  # noqa: E501
+
+        Scopes the exchanged token is requested for  # noqa: E501
+
+        :return: The scopes of this V1beta1TokenExchange.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._scopes
+
+    @scopes.setter
+    def scopes(self, scopes):
+        """Sets the scopes of this V1beta1TokenExchange.
+
+        Scopes the exchanged token is requested for  # noqa: E501
+
+        :param scopes: The scopes of this V1beta1TokenExchange.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._scopes = scopes
+
+    @property
+    def token_url(self):
+        """Gets the token_url of this V1beta1TokenExchange.  # noqa: E501
+
+        URL of the token endpoint of the authorization server  # noqa: E501
+
+        :return: The token_url of this V1beta1TokenExchange.  # noqa: E501
+        :rtype: str
+        """
+        return self._token_url
+
+    @token_url.setter
+    def token_url(self, token_url):
+        """Sets the token_url of this V1beta1TokenExchange.
+
+        URL of the token endpoint of the authorization server  # noqa: E501
+
+        :param token_url: The token_url of this V1beta1TokenExchange.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and token_url is None:  # noqa: E501
+            raise ValueError("Invalid value for `token_url`, must not be `None`")  # noqa: E501
+
+        self._token_url = token_url
+
+    @property
+    def unauthenticated_paths(self):
+        """Gets the unauthenticated_paths of this V1beta1TokenExchange.  # noqa: E501
+
+        Paths the GET and HEAD requests are proxied for without a bearer token, e.g. the health endpoints probed and the metrics scraped from inside the cluster. Every other request requires a token.  # noqa: E501
+
+        :return: The unauthenticated_paths of this V1beta1TokenExchange.  # noqa: E501
+        :rtype: list[V1beta1UnauthenticatedPath]
+        """
+        return self._unauthenticated_paths
+
+    @unauthenticated_paths.setter
+    def unauthenticated_paths(self, unauthenticated_paths):
+        """Sets the unauthenticated_paths of this V1beta1TokenExchange.
+
+        Paths the GET and HEAD requests are proxied for without a bearer token, e.g. the health endpoints probed and the metrics scraped from inside the cluster. Every other request requires a token.  # noqa: E501
+
+        :param unauthenticated_paths: The unauthenticated_paths of this V1beta1TokenExchange.  # noqa: E501
+        :type: list[V1beta1UnauthenticatedPath]
+        """
+
+        self._unauthenticated_paths = unauthenticated_paths
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1beta1TokenExchange):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1beta1TokenExchange):
+            return True
+
+        return self.to_dict() != other.to_dict()
@@ -0,0 +1,192 @@
+# Copyright 2023 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1beta1TokenReview(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'audiences': 'list[str]',
+        'unauthenticated_paths': 'list[V1beta1UnauthenticatedPath]',
+        'verb': 'str'
+    }
+
+    attribute_map = {
+        'audiences': 'audiences',
+        'unauthenticated_paths': 'unauthenticatedPaths',
+        'verb': 'verb'
+    }
+
+    def __init__(self, audiences=None, unauthenticated_paths=None, verb=None, local_vars_configuration=None):  # noqa: E501
+        """V1beta1TokenReview - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._audiences = None
+        self._unauthenticated_paths = None
+        self._verb = None
+        self.discriminator = None
+
+        if audiences is not None:
+            self.audiences = audiences
+        if unauthenticated_paths is not None:
+            self.unauthenticated_paths = unauthenticated_paths
+        if verb is not None:
+            self.verb = verb
+
+    @property
+    def audiences(self):
+        """Gets the audiences of this V1beta1TokenReview.  # noqa: E501
+
+        Audiences the token must be issued for, the token must be valid for the audiences of the API server when not set  # noqa: E501
+
+        :return: The audiences of this V1beta1TokenReview.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._audiences
+
+    @audiences.setter
+    def audiences(self, audiences):
+        """Sets the audiences of this V1beta1TokenReview.
+
+        Audiences the token must be issued for, the token must be valid for the audiences of the API server when not set  # noqa: E501
+
+        :param audiences: The audiences of this V1beta1TokenReview.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._audiences = audiences
+
+    @property
+    def unauthenticated_paths(self):
+        """Gets the unauthenticated_paths of this V1beta1TokenReview.  # noqa: E501
+
+        Paths the GET and HEAD requests are proxied for without a bearer token, e.g. the health endpoints probed and the metrics scraped from inside the cluster. Every other request requires a token.  # noqa: E501
+
+        :return: The unauthenticated_paths of this V1beta1TokenReview.  # noqa: E501
+        :rtype: list[V1beta1UnauthenticatedPath]
+        """
+        return self._unauthenticated_paths
+
+    @unauthenticated_paths.setter
+    def unauthenticated_paths(self, unauthenticated_paths):
+        """Sets the unauthenticated_paths of this V1beta1TokenReview.
+
+        Paths the GET and HEAD requests are proxied for without a bearer token, e.g. the health endpoints probed and the metrics scraped from inside the cluster. Every other request requires a token.  # noqa: E501
+
+        :param unauthenticated_paths: The unauthenticated_paths of this V1beta1TokenReview.  # noqa: E501
+        :type: list[V1beta1UnauthenticatedPath]
+        """
+
+        self._unauthenticated_paths = unauthenticated_paths
+
+    @property
+    def verb(self):
+        """Gets the verb of this V1beta1TokenReview.  # noqa: E501
+
+        Verb the user of the token must be allowed on the InferenceService, defaults to get  # noqa: E501
+
+        :return: The verb of this V1beta1TokenReview.  # noqa: E501
+        :rtype: str
+        """
+        return self._verb
+
+    @verb.setter
+    def verb(self, verb):
+        """Sets the verb of this V1beta1TokenReview.
+
+        Verb the user of the token must be allowed on the InferenceService, defaults to get  # noqa: E501
+
+        :param verb: The verb of this V1beta1TokenReview.  # noqa: E501
+        :type: str
+        """
+
+        self._verb = verb
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1beta1TokenReview):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1beta1TokenReview):
+            return True
+
+        return self.to_dict() != other.to_dict()
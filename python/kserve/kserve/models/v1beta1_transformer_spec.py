@@ -49,6 +49,7 @@ class V1beta1TransformerSpec(object):
     openapi_types = {
         'active_deadline_seconds': 'int',
         'affinity': 'V1Affinity',
+        'agent_security_context': 'V1beta1SidecarSecurityContext',
         'annotations': 'dict(str, str)',
         'automount_service_account_token': 'bool',
         'batcher': 'V1beta1Batcher',
@@ -71,6 +72,8 @@ class V1beta1TransformerSpec(object):
         'labels': 'dict(str, str)',
         'logger': 'V1beta1LoggerSpec',
         'max_replicas': 'int',
+        'middleware': 'V1beta1AgentMiddleware',
+        'min_ready_seconds': 'int',
         'min_replicas': 'int',
         'node_name': 'str',
         'node_selector': 'dict(str, str)',
@@ -92,6 +95,7 @@ class V1beta1TransformerSpec(object):
         'service_account_name': 'str',
         'set_hostname_as_fqdn': 'bool',
         'share_process_namespace': 'bool',
+        'sidecar_logging': 'V1beta1SidecarLoggingSpec',
         'subdomain': 'str',
         'termination_grace_period_seconds': 'int',
         'timeout': 'int',
@@ -103,6 +107,7 @@ class V1beta1TransformerSpec(object):
     attribute_map = {
         'active_deadline_seconds': 'activeDeadlineSeconds',
         'affinity': 'affinity',
+        'agent_security_context': 'agentSecurityContext',
         'annotations': 'annotations',
         'automount_service_account_token': 'automountServiceAccountToken',
         'batcher': 'batcher',
@@ -125,6 +130,8 @@ class V1beta1TransformerSpec(object):
         'labels': 'labels',
         'logger': 'logger',
         'max_replicas': 'maxReplicas',
+        'middleware': 'middleware',
+        'min_ready_seconds': 'minReadySeconds',
         'min_replicas': 'minReplicas',
         'node_name': 'nodeName',
         'node_selector': 'nodeSelector',
@@ -146,6 +153,7 @@ class V1beta1TransformerSpec(object):
         'service_account_name': 'serviceAccountName',
         'set_hostname_as_fqdn': 'setHostnameAsFQDN',
         'share_process_namespace': 'shareProcessNamespace',
+        'sidecar_logging': 'sidecarLogging',
         'subdomain': 'subdomain',
         'termination_grace_period_seconds': 'terminationGracePeriodSeconds',
         'timeout': 'timeout',
@@ -154,7 +162,7 @@ class V1beta1TransformerSpec(object):
         'volumes': 'volumes'
     }
 
-    def __init__(self, active_deadline_seconds=None, affinity=None, annotations=None, automount_service_account_token=None, batcher=None, canary_traffic_percent=None, container_concurrency=None, containers=None, deployment_strategy=None, dns_config=None, dns_policy=None, enable_service_links=None, ephemeral_containers=None, host_aliases=None, host_ipc=None, host_network=None, host_pid=None, host_users=None, hostname=None, image_pull_secrets=None, init_containers=None, labels=None, logger=None, max_replicas=None, min_replicas=None, node_name=None, node_selector=None, os=None, overhead=None, preemption_policy=None, priority=None, priority_class_name=None, readiness_gates=None, resource_claims=None, restart_policy=None, runtime_class_name=None, scale_metric=None, scale_target=None, scheduler_name=None, scheduling_gates=None, security_context=None, service_account=None, service_account_name=None, set_hostname_as_fqdn=None, share_process_namespace=None, subdomain=None, termination_grace_period_seconds=None, timeout=None, tolerations=None, topology_spread_constraints=None, volumes=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, active_deadline_seconds=None, affinity=None, agent_security_context=None, annotations=None, automount_service_account_token=None, batcher=None, canary_traffic_percent=None, container_concurrency=None, containers=None, deployment_strategy=None, dns_config=None, dns_policy=None, enable_service_links=None, ephemeral_containers=None, host_aliases=None, host_ipc=None, host_network=None, host_pid=None, host_users=None, hostname=None, image_pull_secrets=None, init_containers=None, labels=None, logger=None, max_replicas=None, middleware=None, min_ready_seconds=None, min_replicas=None, node_name=None, node_selector=None, os=None, overhead=None, preemption_policy=None, priority=None, priority_class_name=None, readiness_gates=None, resource_claims=None, restart_policy=None, runtime_class_name=None, scale_metric=None, scale_target=None, scheduler_name=None, scheduling_gates=None, security_context=None, service_account=None, service_account_name=None, set_hostname_as_fqdn=None, share_process_namespace=None, sidecar_logging=None, subdomain=None, termination_grace_period_seconds=None, timeout=None, tolerations=None, topology_spread_constraints=None, volumes=None, local_vars_configuration=None):  # noqa: E501
         """V1beta1TransformerSpec - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
@@ -162,6 +170,7 @@ class V1beta1TransformerSpec(object):
 
         self._active_deadline_seconds = None
         self._affinity = None
+        self._agent_security_context = None
         self._annotations = None
         self._automount_service_account_token = None
         self._batcher = None
@@ -184,6 +193,8 @@ class V1beta1TransformerSpec(object):
         self._labels = None
         self._logger = None
         self._max_replicas = None
+        self._middleware = None
+        self._min_ready_seconds = None
         self._min_replicas = None
         self._node_name = None
         self._node_selector = None
@@ -205,6 +216,7 @@ class V1beta1TransformerSpec(object):
         self._service_account_name = None
         self._set_hostname_as_fqdn = None
         self._share_process_namespace = None
+        self._sidecar_logging = None
         self._subdomain = None
         self._termination_grace_period_seconds = None
         self._timeout = None
@@ -217,6 +229,8 @@ class V1beta1TransformerSpec(object):
             self.active_deadline_seconds = active_deadline_seconds
         if affinity is not None:
             self.affinity = affinity
+        if agent_security_context is not None:
+            self.agent_security_context = agent_security_context
         if annotations is not None:
             self.annotations = annotations
         if automount_service_account_token is not None:
@@ -261,6 +275,10 @@ class V1beta1TransformerSpec(object):
             self.logger = logger
         if max_replicas is not None:
             self.max_replicas = max_replicas
+        if middleware is not None:
+            self.middleware = middleware
+        if min_ready_seconds is not None:
+            self.min_ready_seconds = min_ready_seconds
         if min_replicas is not None:
             self.min_replicas = min_replicas
         if node_name is not None:
@@ -303,6 +321,8 @@ class V1beta1TransformerSpec(object):
             self.set_hostname_as_fqdn = set_hostname_as_fqdn
         if share_process_namespace is not None:
             self.share_process_namespace = share_process_namespace
+        if sidecar_logging is not None:
+            self.sidecar_logging = sidecar_logging
         if subdomain is not None:
             self.subdomain = subdomain
         if termination_grace_period_seconds is not None:
@@ -360,6 +380,27 @@ class V1beta1TransformerSpec(object):
 
         self._affinity = affinity
 
+    @property
+    def agent_security_context(self):
+        """Gets the agent_security_context of this V1beta1TransformerSpec.  # noqa: E501
+
+
+        :return: The agent_security_context of this V1beta1TransformerSpec.  # noqa: E501
+        :rtype: V1beta1SidecarSecurityContext
+        """
+        return self._agent_security_context
+
+    @agent_security_context.setter
+    def agent_security_context(self, agent_security_context):
+        """Sets the agent_security_context of this V1beta1TransformerSpec.
+
+
+        :param agent_security_context: The agent_security_context of this V1beta1TransformerSpec.  # noqa: E501
+        :type: V1beta1SidecarSecurityContext
+        """
+
+        self._agent_security_context = agent_security_context
+
     @property
     def annotations(self):
         """Gets the annotations of this V1beta1TransformerSpec.  # noqa: E501
@@ -858,6 +899,50 @@ class V1beta1TransformerSpec(object):
 
         self._max_replicas = max_replicas
 
+    @property
+    def middleware(self):
+        """Gets the middleware of this V1beta1TransformerSpec.  # noqa: E501
+
+
+        :return: The middleware of this V1beta1TransformerSpec.  # noqa: E501
+        :rtype: V1beta1AgentMiddleware
+        """
+        return self._middleware
+
+    @middleware.setter
+    def middleware(self, middleware):
+        """Sets the middleware of this V1beta1TransformerSpec.
+
+
+        :param middleware: The middleware of this V1beta1TransformerSpec.  # noqa: E501
+        :type: V1beta1AgentMiddleware
+        """
+
+        self._middleware = middleware
+
+    @property
+    def min_ready_seconds(self):
+        """Gets the min_ready_seconds of this V1beta1TransformerSpec.  # noqa: E501
+
+        Minimum number of seconds a new pod should be ready without any of its containers crashing before it is considered available and the rollout proceeds. Only applicable for raw deployment mode.  # noqa: E501
+
+        :return: The min_ready_seconds of this V1beta1TransformerSpec.  # noqa: E501
+        :rtype: int
+        """
+        return self._min_ready_seconds
+
+    @min_ready_seconds.setter
+    def min_ready_seconds(self, min_ready_seconds):
+        """Sets the min_ready_seconds of this V1beta1TransformerSpec.
+
+        Minimum number of seconds a new pod should be ready without any of its containers crashing before it is considered available and the rollout proceeds. Only applicable for raw deployment mode.  # noqa: E501
+
+        :param min_ready_seconds: The min_ready_seconds of this V1beta1TransformerSpec.  # noqa: E501
+        :type: int
+        """
+
+        self._min_ready_seconds = min_ready_seconds
+
     @property
     def min_replicas(self):
         """Gets the min_replicas of this V1beta1TransformerSpec.  # noqa: E501
@@ -1337,6 +1422,27 @@ class V1beta1TransformerSpec(object):
 
         self._share_process_namespace = share_process_namespace
 
+    @property
+    def sidecar_logging(self):
+        """Gets the sidecar_logging of this V1beta1TransformerSpec.  # noqa: E501
+
+
+        :return: The sidecar_logging of this V1beta1TransformerSpec.  # noqa: E501
+        :rtype: V1beta1SidecarLoggingSpec
+        """
+        return self._sidecar_logging
+
+    @sidecar_logging.setter
+    def sidecar_logging(self, sidecar_logging):
+        """Sets the sidecar_logging of this V1beta1TransformerSpec.
+
+
+        :param sidecar_logging: The sidecar_logging of this V1beta1TransformerSpec.  # noqa: E501
+        :type: V1beta1SidecarLoggingSpec
+        """
+
+        self._sidecar_logging = sidecar_logging
+
     @property
     def subdomain(self):
         """Gets the subdomain of this V1beta1TransformerSpec.  # noqa: E501
@@ -0,0 +1,165 @@
+# Copyright 2023 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1beta1UnauthenticatedPath(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'prefix': 'str',
+        'source_cid_rs': 'list[str]'
+    }
+
+    attribute_map = {
+        'prefix': 'prefix',
+        'source_cid_rs': 'sourceCIDRs'
+    }
+
+    def __init__(self, prefix='', source_cid_rs=None, local_vars_configuration=None):  # noqa: E501
+        """V1beta1UnauthenticatedPath - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._prefix = None
+        self._source_cid_rs = None
+        self.discriminator = None
+
+        self.prefix = prefix
+        if source_cid_rs is not None:
+            self.source_cid_rs = source_cid_rs
+
+    @property
+    def prefix(self):
+        """Gets the prefix of this V1beta1UnauthenticatedPath.  # noqa: E501
+
+        Prefix of the paths, e.g. /v2/health or /metrics, it matches whole path segments  # noqa: E501
+
+        :return: The prefix of this V1beta1UnauthenticatedPath.  # noqa: E501
+        :rtype: str
+        """
+        return self._prefix
+
+    @prefix.setter
+    def prefix(self, prefix):
+        """Sets the prefix of this V1beta1UnauthenticatedPath.
+
+        Prefix of the paths, e.g. /v2/health or /metrics, it matches whole path segments  # noqa: E501
+
+        :param prefix: The prefix of this V1beta1UnauthenticatedPath.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and prefix is None:  # noqa: E501
+            raise ValueError("Invalid value for `prefix`, must not be `None`")  # noqa: E501
+
+        self._prefix = prefix
+
+    @property
+    def source_cid_rs(self):
+        """Gets the source_cid_rs of this V1beta1UnauthenticatedPath.  # noqa: E501
+
+        CIDRs of the sources allowed to send the requests without a token, they default to the loopback and the private networks, e.g. 10.0.0.0/8, the pods and the nodes of the clusters are usually assigned addresses from  # noqa: E501
+
+        :return: The source_cid_rs of this V1beta1UnauthenticatedPath.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._source_cid_rs
+
+    @source_cid_rs.setter
+    def source_cid_rs(self, source_cid_rs):
+        """Sets the source_cid_rs of this V1beta1UnauthenticatedPath.
+
+        CIDRs of the sources allowed to send the requests without a token, they default to the loopback and the private networks, e.g. 10.0.0.0/8, the pods and the nodes of the clusters are usually assigned addresses from  # noqa: E501
+
+        :param source_cid_rs: The source_cid_rs of this V1beta1UnauthenticatedPath.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._source_cid_rs = source_cid_rs
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1beta1UnauthenticatedPath):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1beta1UnauthenticatedPath):
+            return True
+
+        return self.to_dict() != other.to_dict()